@@ -0,0 +1,5 @@
+ALTER TABLE factory_work_orders
+  ADD COLUMN IF NOT EXISTS budget_cents bigint;
+
+ALTER TABLE factory_lines
+  ADD COLUMN IF NOT EXISTS monthly_budget_cents bigint;
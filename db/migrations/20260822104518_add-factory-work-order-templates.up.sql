@@ -0,0 +1,25 @@
+--
+-- Work order templates declare custom typed fields (configuration.Field
+-- definitions) that a work order created from the template must fill in.
+-- The values live on the order itself, so editing or deleting a template
+-- never rewrites existing orders.
+--
+CREATE TABLE factory_work_order_templates (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    factory_id      UUID NOT NULL REFERENCES factories(id) ON DELETE CASCADE,
+    name            TEXT NOT NULL,
+    description     TEXT NOT NULL DEFAULT '',
+    fields          JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (factory_id, name)
+);
+
+CREATE INDEX idx_factory_work_order_templates_factory_id
+    ON factory_work_order_templates (factory_id);
+
+ALTER TABLE factory_work_orders
+    ADD COLUMN IF NOT EXISTS template_id UUID REFERENCES factory_work_order_templates(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS fields JSONB NOT NULL DEFAULT '{}'::jsonb;
+
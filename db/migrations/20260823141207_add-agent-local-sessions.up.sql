@@ -0,0 +1,13 @@
+--
+-- Agent providers that only expose a stateless chat-completions API (for
+-- example OpenAI-compatible endpoints) keep the conversation transcript and
+-- tool-call bookkeeping here. The row id is the provider session id stored
+-- on agent_sessions.
+--
+CREATE TABLE agent_local_sessions (
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    provider   VARCHAR(40) NOT NULL,
+    state      JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
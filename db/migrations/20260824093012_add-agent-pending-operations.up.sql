@@ -0,0 +1,16 @@
+--
+-- Runtime operations (cancel a run, approve an execution, ...) requested by
+-- the canvas agent wait here until the user confirms them in the session.
+-- A row is consumed when the operation runs.
+--
+CREATE TABLE agent_pending_operations (
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    session_id UUID NOT NULL REFERENCES agent_sessions(id) ON DELETE CASCADE,
+    action     VARCHAR(64) NOT NULL,
+    input      JSONB NOT NULL DEFAULT '{}'::jsonb,
+    summary    TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX agent_pending_operations_session_idx ON agent_pending_operations (session_id);
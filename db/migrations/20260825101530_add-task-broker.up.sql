@@ -0,0 +1,49 @@
+--
+-- Embedded task broker. Runners register for a fleet and claim queued
+-- tasks for that fleet under a lease they renew with heartbeats. Log
+-- records are kept per task so the live-log stream can replay them.
+--
+CREATE TABLE task_broker_runners (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    fleet_id     VARCHAR(128) NOT NULL,
+    name         VARCHAR(255) NOT NULL,
+    token_hash   VARCHAR(64) NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX task_broker_runners_token_hash_idx ON task_broker_runners (token_hash);
+CREATE INDEX task_broker_runners_fleet_idx ON task_broker_runners (fleet_id, last_seen_at);
+
+CREATE TABLE task_broker_tasks (
+    id                        UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    fleet_id                  VARCHAR(128) NOT NULL,
+    status                    VARCHAR(32) NOT NULL,
+    spec                      JSONB NOT NULL DEFAULT '{}'::jsonb,
+    execution_mode            VARCHAR(32) NOT NULL,
+    docker_image              TEXT,
+    execution_timeout_seconds INTEGER NOT NULL,
+    webhook_url               TEXT,
+    runner_id                 UUID REFERENCES task_broker_runners(id) ON DELETE SET NULL,
+    cancel_requested          BOOLEAN NOT NULL DEFAULT false,
+    exit_code                 INTEGER,
+    output                    TEXT,
+    error                     TEXT,
+    result                    JSONB,
+    created_at                TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    claimed_at                TIMESTAMPTZ,
+    lease_until               TIMESTAMPTZ,
+    finished_at               TIMESTAMPTZ
+);
+
+CREATE INDEX task_broker_tasks_queue_idx ON task_broker_tasks (fleet_id, created_at) WHERE status = 'queued';
+CREATE INDEX task_broker_tasks_lease_idx ON task_broker_tasks (lease_until) WHERE status = 'running';
+
+CREATE TABLE task_broker_task_logs (
+    id         BIGSERIAL PRIMARY KEY,
+    task_id    UUID NOT NULL REFERENCES task_broker_tasks(id) ON DELETE CASCADE,
+    record     JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX task_broker_task_logs_task_idx ON task_broker_task_logs (task_id, id);
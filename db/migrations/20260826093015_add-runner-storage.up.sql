@@ -0,0 +1,38 @@
+--
+-- Runner task storage. Artifacts are files a runner task declared as
+-- outputs, stored per run until they expire. Caches are keyed per canvas
+-- and restored before a task's commands run.
+--
+CREATE TABLE runner_artifacts (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    canvas_id       UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+    run_id          UUID NOT NULL,
+    execution_id    UUID NOT NULL,
+    node_id         VARCHAR(128) NOT NULL,
+    name            VARCHAR(128) NOT NULL,
+    size_bytes      BIGINT NOT NULL,
+    sha256          VARCHAR(64) NOT NULL,
+    content         BYTEA NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX runner_artifacts_execution_name_idx ON runner_artifacts (execution_id, name);
+CREATE INDEX runner_artifacts_run_idx ON runner_artifacts (canvas_id, run_id);
+CREATE INDEX runner_artifacts_expires_at_idx ON runner_artifacts (expires_at);
+
+CREATE TABLE runner_caches (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL,
+    canvas_id       UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+    key             VARCHAR(256) NOT NULL,
+    size_bytes      BIGINT NOT NULL,
+    sha256          VARCHAR(64) NOT NULL,
+    content         BYTEA NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX runner_caches_canvas_key_idx ON runner_caches (canvas_id, key);
+CREATE INDEX runner_caches_last_used_at_idx ON runner_caches (last_used_at);
@@ -0,0 +1,41 @@
+--
+-- GitOps mirroring. Each canvas can be mirrored to one branch of an
+-- external repository, reached through a github, gitlab or bitbucket
+-- integration. Events record every push and import, including the
+-- conflicts that need someone to reconcile both sides.
+--
+CREATE TABLE canvas_git_mirrors (
+    id                     UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id        UUID NOT NULL,
+    canvas_id              UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+    integration_id         UUID NOT NULL REFERENCES app_installations(id) ON DELETE CASCADE,
+    repository             VARCHAR(255) NOT NULL,
+    branch                 VARCHAR(255) NOT NULL,
+    path                   VARCHAR(255) NOT NULL DEFAULT '',
+    import_mode            VARCHAR(32) NOT NULL,
+    created_by             UUID NOT NULL,
+    last_pushed_version_id UUID,
+    remote_head_sha        VARCHAR(64) NOT NULL DEFAULT '',
+    last_error             TEXT NOT NULL DEFAULT '',
+    last_synced_at         TIMESTAMPTZ,
+    next_sync_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at             TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX canvas_git_mirrors_canvas_id_idx ON canvas_git_mirrors (canvas_id);
+CREATE INDEX canvas_git_mirrors_next_sync_at_idx ON canvas_git_mirrors (next_sync_at);
+
+CREATE TABLE canvas_git_mirror_events (
+    id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    mirror_id         UUID NOT NULL REFERENCES canvas_git_mirrors(id) ON DELETE CASCADE,
+    canvas_id         UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+    direction         VARCHAR(16) NOT NULL,
+    status            VARCHAR(16) NOT NULL,
+    version_id        UUID,
+    remote_commit_sha VARCHAR(64) NOT NULL DEFAULT '',
+    message           TEXT NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX canvas_git_mirror_events_canvas_created_idx ON canvas_git_mirror_events (canvas_id, created_at DESC);
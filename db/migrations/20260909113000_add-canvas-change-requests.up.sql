@@ -0,0 +1,53 @@
+--
+-- Change requests. Staged canvas changes can be submitted as a proposal,
+-- discussed and reviewed before they are published. Review policies
+-- configure the required approvals per canvas or per folder, and the
+-- canvas policy takes precedence over the policy of its folder.
+--
+CREATE TABLE canvas_review_policies (
+    id                 UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id    UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    canvas_id          UUID REFERENCES workflows(id) ON DELETE CASCADE,
+    folder_id          UUID REFERENCES canvas_folders(id) ON DELETE CASCADE,
+    required_approvals INTEGER NOT NULL DEFAULT 0,
+    reviewer_ids       JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT canvas_review_policies_scope_check CHECK ((canvas_id IS NULL) <> (folder_id IS NULL))
+);
+
+CREATE UNIQUE INDEX canvas_review_policies_canvas_id_idx ON canvas_review_policies (canvas_id) WHERE canvas_id IS NOT NULL;
+CREATE UNIQUE INDEX canvas_review_policies_folder_id_idx ON canvas_review_policies (folder_id) WHERE folder_id IS NOT NULL;
+
+CREATE TABLE canvas_change_requests (
+    id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id   UUID NOT NULL,
+    canvas_id         UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+    author_id         UUID NOT NULL,
+    base_version_id   UUID NOT NULL,
+    title             VARCHAR(255) NOT NULL,
+    description       TEXT NOT NULL DEFAULT '',
+    status            VARCHAR(16) NOT NULL,
+    files             JSONB NOT NULL DEFAULT '[]'::jsonb,
+    merged_version_id UUID,
+    closed_by         UUID,
+    closed_at         TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX canvas_change_requests_canvas_created_idx ON canvas_change_requests (canvas_id, created_at DESC);
+CREATE UNIQUE INDEX canvas_change_requests_open_author_idx ON canvas_change_requests (canvas_id, author_id) WHERE status = 'open';
+
+CREATE TABLE canvas_change_request_events (
+    id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    change_request_id UUID NOT NULL REFERENCES canvas_change_requests(id) ON DELETE CASCADE,
+    user_id           UUID NOT NULL,
+    type              VARCHAR(16) NOT NULL,
+    body              TEXT NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX canvas_change_request_events_change_request_idx ON canvas_change_request_events (change_request_id, created_at);
+
+ALTER TABLE workflow_versions ADD COLUMN change_request JSONB;
@@ -0,0 +1,27 @@
+--
+-- Per-namespace memory settings: an optional JSON schema that values must
+-- satisfy, a default TTL for new records and the keys to index for lookups.
+--
+CREATE TABLE canvas_memory_namespaces (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    canvas_id           UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+    namespace           TEXT NOT NULL,
+    schema              JSONB,
+    default_ttl_seconds INTEGER,
+    indexed_keys        JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT canvas_memory_namespaces_canvas_namespace_key UNIQUE (canvas_id, namespace)
+);
+
+--
+-- Records past expires_at are hidden from reads and deleted by the
+-- cleanup worker. indexed_values holds the values of the indexed keys,
+-- so matches on them can use the GIN index instead of scanning values.
+--
+ALTER TABLE canvas_memories
+    ADD COLUMN expires_at TIMESTAMPTZ,
+    ADD COLUMN indexed_values JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX idx_canvas_memories_expires_at ON canvas_memories (expires_at) WHERE expires_at IS NOT NULL;
+CREATE INDEX idx_canvas_memories_indexed_values ON canvas_memories USING gin (indexed_values jsonb_path_ops);
@@ -0,0 +1,21 @@
+--
+-- Memory shared by every canvas of an organization. Canvases refer to
+-- these namespaces as org:<namespace>. The created_by and updated_by
+-- columns record which canvas or user wrote each record. They are not
+-- foreign keys, so the audit trail survives canvas deletion.
+--
+CREATE TABLE organization_memories (
+    id                   UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id      UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    namespace            TEXT NOT NULL,
+    "values"             JSONB NOT NULL,
+    created_by_canvas_id UUID,
+    created_by_user_id   UUID,
+    updated_by_canvas_id UUID,
+    updated_by_user_id   UUID,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_organization_memories_namespace ON organization_memories (organization_id, namespace, created_at DESC);
+CREATE INDEX idx_organization_memories_values ON organization_memories USING gin ("values" jsonb_path_ops);
@@ -0,0 +1,12 @@
+--
+-- W3C traceparent of the canvas run trace each record belongs to, so the
+-- worker that processes the record continues the trace of its run.
+--
+ALTER TABLE workflow_events
+    ADD COLUMN trace_parent character varying(64) DEFAULT '' NOT NULL;
+
+ALTER TABLE workflow_node_queue_items
+    ADD COLUMN trace_parent character varying(64) DEFAULT '' NOT NULL;
+
+ALTER TABLE workflow_node_executions
+    ADD COLUMN trace_parent character varying(64) DEFAULT '' NOT NULL;
@@ -0,0 +1,7 @@
+--
+-- Decision the user took on a pending runtime operation through its
+-- confirmation widget. Only a confirmed operation runs.
+--
+ALTER TABLE agent_pending_operations
+    ADD COLUMN decision character varying(16) DEFAULT '' NOT NULL,
+    ADD COLUMN decided_at timestamp with time zone;
@@ -0,0 +1,18 @@
+--
+-- Leases of credentials integrations issued to runs while resolving
+-- secrets. Rows are revoked and removed once their run finishes. There is
+-- no foreign key to the run, so leases are still revoked when the run or
+-- its canvas is deleted first.
+--
+CREATE TABLE run_integration_leases (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    canvas_id       UUID NOT NULL,
+    run_id          UUID NOT NULL,
+    integration_id  UUID NOT NULL,
+    lease_id        TEXT NOT NULL,
+    source          TEXT NOT NULL DEFAULT '',
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX run_integration_leases_lease_idx ON run_integration_leases (run_id, integration_id, lease_id);
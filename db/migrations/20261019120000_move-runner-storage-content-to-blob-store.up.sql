@@ -0,0 +1,23 @@
+--
+-- Runner artifact and cache contents move to the runner blob store, and
+-- rows only keep their metadata and the key of their blob. Contents can't
+-- be moved out of the database from SQL, so existing rows are dropped:
+-- artifacts expire within days and caches are saved again by the next
+-- task that uses them.
+--
+-- There is no foreign key to the canvas anymore, so rows outlive a
+-- deleted canvas until the runner storage cleanup worker deletes them
+-- together with their blobs.
+--
+DELETE FROM runner_artifacts;
+DELETE FROM runner_caches;
+
+ALTER TABLE runner_artifacts
+    DROP CONSTRAINT runner_artifacts_canvas_id_fkey,
+    DROP COLUMN content,
+    ADD COLUMN storage_key VARCHAR(256) NOT NULL;
+
+ALTER TABLE runner_caches
+    DROP CONSTRAINT runner_caches_canvas_id_fkey,
+    DROP COLUMN content,
+    ADD COLUMN storage_key VARCHAR(256) NOT NULL;
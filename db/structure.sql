@@ -102,6 +102,36 @@ CREATE TABLE public.accounts (
 );
 
 
+--
+-- Name: agent_local_sessions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.agent_local_sessions (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    provider character varying(40) NOT NULL,
+    state jsonb DEFAULT '{}'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: agent_pending_operations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.agent_pending_operations (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    session_id uuid NOT NULL,
+    action character varying(64) NOT NULL,
+    input jsonb DEFAULT '{}'::jsonb NOT NULL,
+    summary text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    decision character varying(16) DEFAULT ''::character varying NOT NULL,
+    decided_at timestamp with time zone
+);
+
+
 --
 -- Name: agent_session_messages; Type: TABLE; Schema: public; Owner: -
 --
@@ -232,6 +262,42 @@ CREATE TABLE public.app_messages (
 );
 
 
+--
+-- Name: canvas_change_request_events; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.canvas_change_request_events (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    change_request_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    type character varying(16) NOT NULL,
+    body text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: canvas_change_requests; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.canvas_change_requests (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    organization_id uuid NOT NULL,
+    canvas_id uuid NOT NULL,
+    author_id uuid NOT NULL,
+    base_version_id uuid NOT NULL,
+    title character varying(255) NOT NULL,
+    description text DEFAULT ''::text NOT NULL,
+    status character varying(16) NOT NULL,
+    files jsonb DEFAULT '[]'::jsonb NOT NULL,
+    merged_version_id uuid,
+    closed_by uuid,
+    closed_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
 --
 -- Name: canvas_folders; Type: TABLE; Schema: public; Owner: -
 --
@@ -248,6 +314,47 @@ CREATE TABLE public.canvas_folders (
 );
 
 
+--
+-- Name: canvas_git_mirror_events; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.canvas_git_mirror_events (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    mirror_id uuid NOT NULL,
+    canvas_id uuid NOT NULL,
+    direction character varying(16) NOT NULL,
+    status character varying(16) NOT NULL,
+    version_id uuid,
+    remote_commit_sha character varying(64) DEFAULT ''::character varying NOT NULL,
+    message text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: canvas_git_mirrors; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.canvas_git_mirrors (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    organization_id uuid NOT NULL,
+    canvas_id uuid NOT NULL,
+    integration_id uuid NOT NULL,
+    repository character varying(255) NOT NULL,
+    branch character varying(255) NOT NULL,
+    path character varying(255) DEFAULT ''::character varying NOT NULL,
+    import_mode character varying(32) NOT NULL,
+    created_by uuid NOT NULL,
+    last_pushed_version_id uuid,
+    remote_head_sha character varying(64) DEFAULT ''::character varying NOT NULL,
+    last_error text DEFAULT ''::text NOT NULL,
+    last_synced_at timestamp with time zone,
+    next_sync_at timestamp with time zone DEFAULT now() NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
 --
 -- Name: canvas_memories; Type: TABLE; Schema: public; Owner: -
 --
@@ -259,7 +366,42 @@ CREATE TABLE public.canvas_memories (
     id uuid DEFAULT gen_random_uuid() NOT NULL,
     created_at timestamp with time zone DEFAULT now() NOT NULL,
     updated_at timestamp with time zone DEFAULT now() NOT NULL,
-    source text DEFAULT 'node'::text NOT NULL
+    source text DEFAULT 'node'::text NOT NULL,
+    expires_at timestamp with time zone,
+    indexed_values jsonb DEFAULT '{}'::jsonb NOT NULL
+);
+
+
+--
+-- Name: canvas_memory_namespaces; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.canvas_memory_namespaces (
+    id uuid DEFAULT gen_random_uuid() NOT NULL,
+    canvas_id uuid NOT NULL,
+    namespace text NOT NULL,
+    schema jsonb,
+    default_ttl_seconds integer,
+    indexed_keys jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: canvas_review_policies; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.canvas_review_policies (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    organization_id uuid NOT NULL,
+    canvas_id uuid,
+    folder_id uuid,
+    required_approvals integer DEFAULT 0 NOT NULL,
+    reviewer_ids jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT canvas_review_policies_scope_check CHECK (((canvas_id IS NULL) <> (folder_id IS NULL)))
 );
 
 
@@ -370,7 +512,8 @@ CREATE TABLE public.factory_lines (
     name text NOT NULL,
     steps jsonb DEFAULT '[]'::jsonb NOT NULL,
     created_at timestamp with time zone DEFAULT now() NOT NULL,
-    updated_at timestamp with time zone DEFAULT now() NOT NULL
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    monthly_budget_cents bigint
 );
 
 
@@ -535,6 +678,22 @@ CREATE TABLE public.factory_work_order_queue_items (
 );
 
 
+--
+-- Name: factory_work_order_templates; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.factory_work_order_templates (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    organization_id uuid NOT NULL,
+    factory_id uuid NOT NULL,
+    name text NOT NULL,
+    description text DEFAULT ''::text NOT NULL,
+    fields jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
 --
 -- Name: factory_work_orders; Type: TABLE; Schema: public; Owner: -
 --
@@ -553,6 +712,9 @@ CREATE TABLE public.factory_work_orders (
     source_run_id uuid,
     number bigint NOT NULL,
     status_note jsonb,
+    budget_cents bigint,
+    template_id uuid,
+    fields jsonb DEFAULT '{}'::jsonb NOT NULL,
     CONSTRAINT factory_work_orders_number_positive_check CHECK ((number > 0))
 );
 
@@ -652,6 +814,24 @@ CREATE TABLE public.organization_invite_links (
 );
 
 
+--
+-- Name: organization_memories; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.organization_memories (
+    id uuid DEFAULT gen_random_uuid() NOT NULL,
+    organization_id uuid NOT NULL,
+    namespace text NOT NULL,
+    "values" jsonb NOT NULL,
+    created_by_canvas_id uuid,
+    created_by_user_id uuid,
+    updated_by_canvas_id uuid,
+    updated_by_user_id uuid,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
 --
 -- Name: organizations; Type: TABLE; Schema: public; Owner: -
 --
@@ -716,6 +896,59 @@ CREATE TABLE public.role_metadata (
 );
 
 
+--
+-- Name: run_integration_leases; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.run_integration_leases (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    canvas_id uuid NOT NULL,
+    run_id uuid NOT NULL,
+    integration_id uuid NOT NULL,
+    lease_id text NOT NULL,
+    source text DEFAULT ''::text NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: runner_artifacts; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.runner_artifacts (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    organization_id uuid NOT NULL,
+    canvas_id uuid NOT NULL,
+    run_id uuid NOT NULL,
+    execution_id uuid NOT NULL,
+    node_id character varying(128) NOT NULL,
+    name character varying(128) NOT NULL,
+    size_bytes bigint NOT NULL,
+    sha256 character varying(64) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    storage_key character varying(256) NOT NULL
+);
+
+
+--
+-- Name: runner_caches; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.runner_caches (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    organization_id uuid NOT NULL,
+    canvas_id uuid NOT NULL,
+    key character varying(256) NOT NULL,
+    size_bytes bigint NOT NULL,
+    sha256 character varying(64) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    last_used_at timestamp with time zone DEFAULT now() NOT NULL,
+    storage_key character varying(256) NOT NULL
+);
+
+
 --
 -- Name: schema_migrations; Type: TABLE; Schema: public; Owner: -
 --
@@ -743,6 +976,77 @@ CREATE TABLE public.secrets (
 );
 
 
+--
+-- Name: task_broker_runners; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.task_broker_runners (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    fleet_id character varying(128) NOT NULL,
+    name character varying(255) NOT NULL,
+    token_hash character varying(64) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    last_seen_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: task_broker_task_logs; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.task_broker_task_logs (
+    id bigint NOT NULL,
+    task_id uuid NOT NULL,
+    record jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: task_broker_task_logs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.task_broker_task_logs_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: task_broker_task_logs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.task_broker_task_logs_id_seq OWNED BY public.task_broker_task_logs.id;
+
+
+--
+-- Name: task_broker_tasks; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.task_broker_tasks (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    fleet_id character varying(128) NOT NULL,
+    status character varying(32) NOT NULL,
+    spec jsonb DEFAULT '{}'::jsonb NOT NULL,
+    execution_mode character varying(32) NOT NULL,
+    docker_image text,
+    execution_timeout_seconds integer NOT NULL,
+    webhook_url text,
+    runner_id uuid,
+    cancel_requested boolean DEFAULT false NOT NULL,
+    exit_code integer,
+    output text,
+    error text,
+    result jsonb,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    claimed_at timestamp with time zone,
+    lease_until timestamp with time zone,
+    finished_at timestamp with time zone
+);
+
+
 --
 -- Name: user_canvas_preferences; Type: TABLE; Schema: public; Owner: -
 --
@@ -828,7 +1132,8 @@ CREATE TABLE public.workflow_events (
     execution_id uuid,
     created_at timestamp without time zone NOT NULL,
     custom_name text,
-    run_id uuid NOT NULL
+    run_id uuid NOT NULL,
+    trace_parent character varying(64) DEFAULT ''::character varying NOT NULL
 );
 
 
@@ -869,7 +1174,8 @@ CREATE TABLE public.workflow_node_executions (
     cancelled_by uuid,
     run_id uuid NOT NULL,
     cancelled_at timestamp without time zone,
-    queue_name character varying(256)
+    queue_name character varying(256),
+    trace_parent character varying(64) DEFAULT ''::character varying NOT NULL
 );
 
 
@@ -885,7 +1191,8 @@ CREATE TABLE public.workflow_node_queue_items (
     event_id uuid,
     created_at timestamp without time zone NOT NULL,
     run_id uuid NOT NULL,
-    queue_name character varying(256)
+    queue_name character varying(256),
+    trace_parent character varying(64) DEFAULT ''::character varying NOT NULL
 );
 
 
@@ -991,7 +1298,8 @@ CREATE TABLE public.workflow_versions (
     console_panels jsonb DEFAULT '[]'::jsonb NOT NULL,
     console_layout jsonb DEFAULT '[]'::jsonb NOT NULL,
     commit_sha character varying(40) DEFAULT ''::character varying NOT NULL,
-    commit_message text DEFAULT ''::text NOT NULL
+    commit_message text DEFAULT ''::text NOT NULL,
+    change_request jsonb
 );
 
 
@@ -1022,6 +1330,13 @@ CREATE TABLE public.workflows (
 ALTER TABLE ONLY public.casbin_rule ALTER COLUMN id SET DEFAULT nextval('public.casbin_rule_id_seq'::regclass);
 
 
+--
+-- Name: task_broker_task_logs id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.task_broker_task_logs ALTER COLUMN id SET DEFAULT nextval('public.task_broker_task_logs_id_seq'::regclass);
+
+
 --
 -- Name: account_magic_codes account_magic_codes_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1086,6 +1401,22 @@ ALTER TABLE ONLY public.accounts
     ADD CONSTRAINT accounts_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: agent_local_sessions agent_local_sessions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.agent_local_sessions
+    ADD CONSTRAINT agent_local_sessions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: agent_pending_operations agent_pending_operations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.agent_pending_operations
+    ADD CONSTRAINT agent_pending_operations_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: agent_session_messages agent_session_messages_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1150,6 +1481,22 @@ ALTER TABLE ONLY public.app_messages
     ADD CONSTRAINT app_messages_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: canvas_change_request_events canvas_change_request_events_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_change_request_events
+    ADD CONSTRAINT canvas_change_request_events_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: canvas_change_requests canvas_change_requests_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_change_requests
+    ADD CONSTRAINT canvas_change_requests_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: canvas_folders canvas_folders_organization_id_title_key; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1166,6 +1513,22 @@ ALTER TABLE ONLY public.canvas_folders
     ADD CONSTRAINT canvas_folders_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: canvas_git_mirror_events canvas_git_mirror_events_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_git_mirror_events
+    ADD CONSTRAINT canvas_git_mirror_events_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: canvas_git_mirrors canvas_git_mirrors_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_git_mirrors
+    ADD CONSTRAINT canvas_git_mirrors_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: canvas_memories canvas_memories_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1174,6 +1537,30 @@ ALTER TABLE ONLY public.canvas_memories
     ADD CONSTRAINT canvas_memories_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: canvas_memory_namespaces canvas_memory_namespaces_canvas_namespace_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_memory_namespaces
+    ADD CONSTRAINT canvas_memory_namespaces_canvas_namespace_key UNIQUE (canvas_id, namespace);
+
+
+--
+-- Name: canvas_memory_namespaces canvas_memory_namespaces_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_memory_namespaces
+    ADD CONSTRAINT canvas_memory_namespaces_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: canvas_review_policies canvas_review_policies_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_review_policies
+    ADD CONSTRAINT canvas_review_policies_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: canvas_subscriptions canvas_subscriptions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1334,6 +1721,22 @@ ALTER TABLE ONLY public.factory_work_order_queue_items
     ADD CONSTRAINT factory_work_order_queue_items_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: factory_work_order_templates factory_work_order_templates_factory_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.factory_work_order_templates
+    ADD CONSTRAINT factory_work_order_templates_factory_id_name_key UNIQUE (factory_id, name);
+
+
+--
+-- Name: factory_work_order_templates factory_work_order_templates_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.factory_work_order_templates
+    ADD CONSTRAINT factory_work_order_templates_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: factory_work_orders factory_work_orders_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1406,6 +1809,14 @@ ALTER TABLE ONLY public.organization_invite_links
     ADD CONSTRAINT organization_invite_links_token_key UNIQUE (token);
 
 
+--
+-- Name: organization_memories organization_memories_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.organization_memories
+    ADD CONSTRAINT organization_memories_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: organizations organizations_name_key; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1462,6 +1873,30 @@ ALTER TABLE ONLY public.role_metadata
     ADD CONSTRAINT role_metadata_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: run_integration_leases run_integration_leases_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.run_integration_leases
+    ADD CONSTRAINT run_integration_leases_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: runner_artifacts runner_artifacts_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.runner_artifacts
+    ADD CONSTRAINT runner_artifacts_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: runner_caches runner_caches_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.runner_caches
+    ADD CONSTRAINT runner_caches_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: schema_migrations schema_migrations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1518,6 +1953,30 @@ ALTER TABLE ONLY public.user_notification_settings
     ADD CONSTRAINT user_notification_settings_organization_id_user_id_key UNIQUE (organization_id, user_id);
 
 
+--
+-- Name: task_broker_runners task_broker_runners_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.task_broker_runners
+    ADD CONSTRAINT task_broker_runners_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: task_broker_task_logs task_broker_task_logs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.task_broker_task_logs
+    ADD CONSTRAINT task_broker_task_logs_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: task_broker_tasks task_broker_tasks_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.task_broker_tasks
+    ADD CONSTRAINT task_broker_tasks_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: user_notification_settings user_notification_settings_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1638,6 +2097,13 @@ ALTER TABLE ONLY public.workflows
     ADD CONSTRAINT workflows_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: agent_pending_operations_session_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX agent_pending_operations_session_idx ON public.agent_pending_operations USING btree (session_id);
+
+
 --
 -- Name: agent_session_messages_provider_event_idx; Type: INDEX; Schema: public; Owner: -
 --
@@ -1666,6 +2132,62 @@ CREATE INDEX agent_sessions_provider_session_id_idx ON public.agent_sessions USI
 CREATE UNIQUE INDEX agent_sessions_user_canvas_idx ON public.agent_sessions USING btree (organization_id, user_id, canvas_id);
 
 
+--
+-- Name: canvas_change_request_events_change_request_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX canvas_change_request_events_change_request_idx ON public.canvas_change_request_events USING btree (change_request_id, created_at);
+
+
+--
+-- Name: canvas_change_requests_canvas_created_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX canvas_change_requests_canvas_created_idx ON public.canvas_change_requests USING btree (canvas_id, created_at DESC);
+
+
+--
+-- Name: canvas_change_requests_open_author_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX canvas_change_requests_open_author_idx ON public.canvas_change_requests USING btree (canvas_id, author_id) WHERE ((status)::text = 'open'::text);
+
+
+--
+-- Name: canvas_git_mirror_events_canvas_created_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX canvas_git_mirror_events_canvas_created_idx ON public.canvas_git_mirror_events USING btree (canvas_id, created_at DESC);
+
+
+--
+-- Name: canvas_git_mirrors_canvas_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX canvas_git_mirrors_canvas_id_idx ON public.canvas_git_mirrors USING btree (canvas_id);
+
+
+--
+-- Name: canvas_git_mirrors_next_sync_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX canvas_git_mirrors_next_sync_at_idx ON public.canvas_git_mirrors USING btree (next_sync_at);
+
+
+--
+-- Name: canvas_review_policies_canvas_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX canvas_review_policies_canvas_id_idx ON public.canvas_review_policies USING btree (canvas_id) WHERE (canvas_id IS NOT NULL);
+
+
+--
+-- Name: canvas_review_policies_folder_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX canvas_review_policies_folder_id_idx ON public.canvas_review_policies USING btree (folder_id) WHERE (folder_id IS NOT NULL);
+
+
 --
 -- Name: factories_organization_id_key_active_key; Type: INDEX; Schema: public; Owner: -
 --
@@ -1806,6 +2328,20 @@ CREATE INDEX idx_canvas_folders_organization_id_title ON public.canvas_folders U
 CREATE INDEX idx_canvas_memories_canvas_namespace ON public.canvas_memories USING btree (canvas_id, namespace);
 
 
+--
+-- Name: idx_canvas_memories_expires_at; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_canvas_memories_expires_at ON public.canvas_memories USING btree (expires_at) WHERE (expires_at IS NOT NULL);
+
+
+--
+-- Name: idx_canvas_memories_indexed_values; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_canvas_memories_indexed_values ON public.canvas_memories USING gin (indexed_values jsonb_path_ops);
+
+
 --
 -- Name: idx_canvas_subscriptions_target; Type: INDEX; Schema: public; Owner: -
 --
@@ -2016,6 +2552,13 @@ CREATE INDEX idx_factory_work_order_queue_items_order ON public.factory_work_ord
 CREATE INDEX idx_factory_work_order_queue_items_step ON public.factory_work_order_queue_items USING btree (line_id, step_index, created_at);
 
 
+--
+-- Name: idx_factory_work_order_templates_factory_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_factory_work_order_templates_factory_id ON public.factory_work_order_templates USING btree (factory_id);
+
+
 --
 -- Name: idx_factory_work_orders_factory_state; Type: INDEX; Schema: public; Owner: -
 --
@@ -2072,6 +2615,20 @@ CREATE INDEX idx_llm_usage_events_work_order ON public.llm_usage_events USING bt
 CREATE INDEX idx_node_requests_state_run_at ON public.workflow_node_requests USING btree (state, run_at) WHERE ((state)::text = 'pending'::text);
 
 
+--
+-- Name: idx_organization_memories_namespace; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_organization_memories_namespace ON public.organization_memories USING btree (organization_id, namespace, created_at DESC);
+
+
+--
+-- Name: idx_organization_memories_values; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_organization_memories_values ON public.organization_memories USING gin ("values" jsonb_path_ops);
+
+
 --
 -- Name: idx_organizations_deleted_at; Type: INDEX; Schema: public; Owner: -
 --
@@ -2359,6 +2916,83 @@ CREATE INDEX idx_workflows_live_version_id ON public.workflows USING btree (live
 CREATE INDEX idx_workflows_organization_id ON public.workflows USING btree (organization_id);
 
 
+--
+-- Name: run_integration_leases_lease_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX run_integration_leases_lease_idx ON public.run_integration_leases USING btree (run_id, integration_id, lease_id);
+
+
+--
+-- Name: runner_artifacts_execution_name_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX runner_artifacts_execution_name_idx ON public.runner_artifacts USING btree (execution_id, name);
+
+
+--
+-- Name: runner_artifacts_expires_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX runner_artifacts_expires_at_idx ON public.runner_artifacts USING btree (expires_at);
+
+
+--
+-- Name: runner_artifacts_run_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX runner_artifacts_run_idx ON public.runner_artifacts USING btree (canvas_id, run_id);
+
+
+--
+-- Name: runner_caches_canvas_key_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX runner_caches_canvas_key_idx ON public.runner_caches USING btree (canvas_id, key);
+
+
+--
+-- Name: runner_caches_last_used_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX runner_caches_last_used_at_idx ON public.runner_caches USING btree (last_used_at);
+
+
+--
+-- Name: task_broker_runners_fleet_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX task_broker_runners_fleet_idx ON public.task_broker_runners USING btree (fleet_id, last_seen_at);
+
+
+--
+-- Name: task_broker_runners_token_hash_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX task_broker_runners_token_hash_idx ON public.task_broker_runners USING btree (token_hash);
+
+
+--
+-- Name: task_broker_task_logs_task_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX task_broker_task_logs_task_idx ON public.task_broker_task_logs USING btree (task_id, id);
+
+
+--
+-- Name: task_broker_tasks_lease_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX task_broker_tasks_lease_idx ON public.task_broker_tasks USING btree (lease_until) WHERE ((status)::text = 'running'::text);
+
+
+--
+-- Name: task_broker_tasks_queue_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX task_broker_tasks_queue_idx ON public.task_broker_tasks USING btree (fleet_id, created_at) WHERE ((status)::text = 'queued'::text);
+
+
 --
 -- Name: unique_api_key_in_organization; Type: INDEX; Schema: public; Owner: -
 --
@@ -2389,6 +3023,14 @@ ALTER TABLE ONLY public.account_providers
     ADD CONSTRAINT account_providers_account_id_fkey FOREIGN KEY (account_id) REFERENCES public.accounts(id);
 
 
+--
+-- Name: agent_pending_operations agent_pending_operations_session_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.agent_pending_operations
+    ADD CONSTRAINT agent_pending_operations_session_id_fkey FOREIGN KEY (session_id) REFERENCES public.agent_sessions(id) ON DELETE CASCADE;
+
+
 --
 -- Name: agent_session_messages agent_session_messages_session_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -2469,6 +3111,22 @@ ALTER TABLE ONLY public.app_messages
     ADD CONSTRAINT app_messages_canvas_id_node_id_fkey FOREIGN KEY (canvas_id, node_id) REFERENCES public.workflow_nodes(workflow_id, node_id) ON DELETE CASCADE;
 
 
+--
+-- Name: canvas_change_request_events canvas_change_request_events_change_request_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_change_request_events
+    ADD CONSTRAINT canvas_change_request_events_change_request_id_fkey FOREIGN KEY (change_request_id) REFERENCES public.canvas_change_requests(id) ON DELETE CASCADE;
+
+
+--
+-- Name: canvas_change_requests canvas_change_requests_canvas_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_change_requests
+    ADD CONSTRAINT canvas_change_requests_canvas_id_fkey FOREIGN KEY (canvas_id) REFERENCES public.workflows(id) ON DELETE CASCADE;
+
+
 --
 -- Name: canvas_folders canvas_folders_organization_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -2477,6 +3135,38 @@ ALTER TABLE ONLY public.canvas_folders
     ADD CONSTRAINT canvas_folders_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
 
 
+--
+-- Name: canvas_git_mirror_events canvas_git_mirror_events_canvas_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_git_mirror_events
+    ADD CONSTRAINT canvas_git_mirror_events_canvas_id_fkey FOREIGN KEY (canvas_id) REFERENCES public.workflows(id) ON DELETE CASCADE;
+
+
+--
+-- Name: canvas_git_mirror_events canvas_git_mirror_events_mirror_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_git_mirror_events
+    ADD CONSTRAINT canvas_git_mirror_events_mirror_id_fkey FOREIGN KEY (mirror_id) REFERENCES public.canvas_git_mirrors(id) ON DELETE CASCADE;
+
+
+--
+-- Name: canvas_git_mirrors canvas_git_mirrors_canvas_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_git_mirrors
+    ADD CONSTRAINT canvas_git_mirrors_canvas_id_fkey FOREIGN KEY (canvas_id) REFERENCES public.workflows(id) ON DELETE CASCADE;
+
+
+--
+-- Name: canvas_git_mirrors canvas_git_mirrors_integration_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_git_mirrors
+    ADD CONSTRAINT canvas_git_mirrors_integration_id_fkey FOREIGN KEY (integration_id) REFERENCES public.app_installations(id) ON DELETE CASCADE;
+
+
 --
 -- Name: canvas_memories canvas_memories_canvas_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -2485,6 +3175,38 @@ ALTER TABLE ONLY public.canvas_memories
     ADD CONSTRAINT canvas_memories_canvas_id_fkey FOREIGN KEY (canvas_id) REFERENCES public.workflows(id) ON DELETE CASCADE;
 
 
+--
+-- Name: canvas_memory_namespaces canvas_memory_namespaces_canvas_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_memory_namespaces
+    ADD CONSTRAINT canvas_memory_namespaces_canvas_id_fkey FOREIGN KEY (canvas_id) REFERENCES public.workflows(id) ON DELETE CASCADE;
+
+
+--
+-- Name: canvas_review_policies canvas_review_policies_canvas_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_review_policies
+    ADD CONSTRAINT canvas_review_policies_canvas_id_fkey FOREIGN KEY (canvas_id) REFERENCES public.workflows(id) ON DELETE CASCADE;
+
+
+--
+-- Name: canvas_review_policies canvas_review_policies_folder_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_review_policies
+    ADD CONSTRAINT canvas_review_policies_folder_id_fkey FOREIGN KEY (folder_id) REFERENCES public.canvas_folders(id) ON DELETE CASCADE;
+
+
+--
+-- Name: canvas_review_policies canvas_review_policies_organization_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.canvas_review_policies
+    ADD CONSTRAINT canvas_review_policies_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
+
+
 --
 -- Name: canvas_subscriptions canvas_subscriptions_source_canvas_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -2717,6 +3439,14 @@ ALTER TABLE ONLY public.factory_work_order_queue_items
     ADD CONSTRAINT factory_work_order_queue_items_work_order_id_fkey FOREIGN KEY (work_order_id) REFERENCES public.factory_work_orders(id) ON DELETE RESTRICT;
 
 
+--
+-- Name: factory_work_order_templates factory_work_order_templates_factory_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.factory_work_order_templates
+    ADD CONSTRAINT factory_work_order_templates_factory_id_fkey FOREIGN KEY (factory_id) REFERENCES public.factories(id) ON DELETE CASCADE;
+
+
 --
 -- Name: factory_work_orders factory_work_orders_created_by_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -2741,6 +3471,22 @@ ALTER TABLE ONLY public.factory_work_orders
     ADD CONSTRAINT factory_work_orders_source_run_id_fkey FOREIGN KEY (source_run_id) REFERENCES public.workflow_runs(id) ON DELETE SET NULL;
 
 
+--
+-- Name: factory_work_orders factory_work_orders_template_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.factory_work_orders
+    ADD CONSTRAINT factory_work_orders_template_id_fkey FOREIGN KEY (template_id) REFERENCES public.factory_work_order_templates(id) ON DELETE SET NULL;
+
+
+--
+-- Name: organization_memories organization_memories_organization_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.organization_memories
+    ADD CONSTRAINT organization_memories_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
+
+
 --
 -- Name: workflow_node_execution_kvs fk_wnek_workflow; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -2837,6 +3583,22 @@ ALTER TABLE ONLY public.repository_seed_files
     ADD CONSTRAINT repository_seed_files_repository_id_fkey FOREIGN KEY (repository_id) REFERENCES public.repositories(id) ON DELETE CASCADE;
 
 
+--
+-- Name: task_broker_task_logs task_broker_task_logs_task_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.task_broker_task_logs
+    ADD CONSTRAINT task_broker_task_logs_task_id_fkey FOREIGN KEY (task_id) REFERENCES public.task_broker_tasks(id) ON DELETE CASCADE;
+
+
+--
+-- Name: task_broker_tasks task_broker_tasks_runner_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.task_broker_tasks
+    ADD CONSTRAINT task_broker_tasks_runner_id_fkey FOREIGN KEY (runner_id) REFERENCES public.task_broker_runners(id) ON DELETE SET NULL;
+
+
 --
 -- Name: user_canvas_preferences user_canvas_preferences_canvas_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -3205,7 +3967,7 @@ SET row_security = off;
 --
 
 COPY public.schema_migrations (version, dirty) FROM stdin;
-20260820071427	f
+20261019120000	f
 \.
 
 
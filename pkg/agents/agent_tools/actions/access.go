@@ -87,16 +87,18 @@ func (a accessAction) apiAccess(ctx context.Context, session agents.AgentSession
 	return accessible, unavailable, nil
 }
 
+type toolActionRequirement struct {
+	name             string
+	resource         string
+	operation        string
+	legacyOperations []string
+	scoped           bool
+	description      string
+}
+
 func (a accessAction) toolActions(ctx context.Context, session agents.AgentSessionContext, permissions []jwt.Permission) []toolAccessResult {
 	rbac := newRBACCache(ctx, a.auth, session.UserID, session.OrganizationID)
-	actions := []struct {
-		name             string
-		resource         string
-		operation        string
-		legacyOperations []string
-		scoped           bool
-		description      string
-	}{
+	actions := []toolActionRequirement{
 		{name: accessActionName, description: "No API permission required; reports this session's token and API route access."},
 		{name: readActionName, resource: "canvases", operation: "read", scoped: true},
 		{name: readRuntimeActionName, resource: "canvases", operation: "read", scoped: true},
@@ -108,6 +110,9 @@ func (a accessAction) toolActions(ctx context.Context, session agents.AgentSessi
 		{name: deleteFileActionName, resource: "canvases", operation: "update", legacyOperations: []string{"update_version"}, scoped: true},
 		{name: patchStagingActionName, resource: "canvases", operation: "update", legacyOperations: []string{"update_version"}, scoped: true},
 	}
+	for _, name := range runtimeOperationNames {
+		actions = append(actions, toolActionRequirement{name: name, resource: "canvases", operation: "update", scoped: true})
+	}
 
 	results := make([]toolAccessResult, 0, len(actions))
 	for _, action := range actions {
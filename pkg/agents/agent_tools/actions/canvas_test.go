@@ -977,6 +977,10 @@ func TestAccessAction_ReportsInterceptorBackedAgentTokenAccess(t *testing.T) {
 	assert.True(t, toolActions["write_file"].Allowed)
 	require.Contains(t, toolActions, "delete_file")
 	assert.True(t, toolActions["delete_file"].Allowed)
+	require.Contains(t, toolActions, "cancel_run")
+	assert.True(t, toolActions["cancel_run"].Allowed)
+	require.Contains(t, toolActions, "approve")
+	assert.True(t, toolActions["approve"].Allowed)
 }
 
 func TestAccessAction_ReportsLegacyDraftUpdateAccess(t *testing.T) {
@@ -1017,6 +1021,8 @@ func TestAccessAction_ReportsLegacyDraftUpdateAccess(t *testing.T) {
 	assert.True(t, toolActions["delete_file"].Allowed)
 	require.Contains(t, toolActions, "read_runtime")
 	assert.True(t, toolActions["read_runtime"].Allowed)
+	require.Contains(t, toolActions, "cancel_run")
+	assert.False(t, toolActions["cancel_run"].Allowed)
 }
 
 func TestReadRuntimeAction_ParseFilters(t *testing.T) {
@@ -1260,6 +1266,229 @@ func TestReadRuntimeAction_ReadsRunnerLogsByRunIDWithMissingNodeExecution(t *tes
 	assert.Equal(t, "agent log line", payload.Logs[0].Records[0].Text)
 }
 
+func TestRuntimeOperationAction_RequiresCanvasUpdatePermission(t *testing.T) {
+	action := newRuntimeOperationAction(Dependencies{}, cancelRunActionName)
+	action.auth = actionPermissionChecker{"canvases:read": true}
+
+	_, err := action.Execute(context.Background(), agents.AgentSessionContext{
+		SessionID:      uuid.NewString(),
+		OrganizationID: uuid.NewString(),
+		UserID:         uuid.NewString(),
+		CanvasID:       uuid.NewString(),
+	}, Input{RunID: uuid.NewString()})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "canvases:update")
+}
+
+func TestRuntimeOperationAction_CancelRunWaitsForUserConfirmation(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{
+		{
+			NodeID: "trigger-1",
+			Type:   models.NodeTypeTrigger,
+			Ref: datatypes.NewJSONType(models.NodeRef{
+				Trigger: &models.TriggerRef{Name: "start"},
+			}),
+		},
+	}, nil)
+	event := support.EmitCanvasEventForNode(t, canvas.ID, "trigger-1", "default", nil)
+
+	var run *models.CanvasRun
+	require.NoError(t, database.Conn().Transaction(func(tx *gorm.DB) error {
+		var err error
+		run, err = models.FindOrCreateCanvasRunForRootEventInTransaction(tx, event)
+		if err != nil {
+			return err
+		}
+		return event.RoutedInTransaction(tx)
+	}))
+
+	agentSession := &models.AgentSession{
+		OrganizationID: r.Organization.ID,
+		UserID:         r.User,
+		CanvasID:       canvas.ID,
+		Provider:       "test",
+		Status:         models.AgentSessionStatusStreaming,
+	}
+	require.NoError(t, models.CreateAgentSessionInTransaction(database.Conn(), agentSession))
+
+	action := newRuntimeOperationAction(Dependencies{}, cancelRunActionName)
+	action.auth = allowingPermissionChecker{}
+	session := agents.AgentSessionContext{
+		SessionID:      agentSession.ID.String(),
+		OrganizationID: r.Organization.ID.String(),
+		UserID:         r.User.String(),
+		CanvasID:       canvas.ID.String(),
+	}
+	ctx := authentication.SetUserIdInMetadata(context.Background(), r.User.String())
+
+	payload, err := action.Execute(ctx, session, Input{RunID: run.ID.String()})
+	require.NoError(t, err)
+	requested, ok := payload.(runtimeOperationResult)
+	require.True(t, ok)
+	assert.Equal(t, operationStatusConfirmationRequired, requested.Status)
+	assert.Contains(t, requested.Summary, run.ID.String())
+	require.NotEmpty(t, requested.ConfirmationID)
+
+	_, err = action.Execute(ctx, session, Input{ConfirmationID: requested.ConfirmationID})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has not confirmed")
+
+	replyAt := time.Now().Add(time.Second)
+	require.NoError(t, models.AppendAgentSessionMessage(&models.AgentSessionMessage{
+		SessionID: agentSession.ID,
+		Role:      models.AgentMessageRoleUser,
+		Content:   "no, don't cancel that run",
+		CreatedAt: &replyAt,
+	}))
+
+	_, err = action.Execute(ctx, session, Input{ConfirmationID: requested.ConfirmationID})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has not confirmed")
+
+	stored, err := models.FindCanvasRunInTransaction(database.Conn(), canvas.ID, run.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, models.CanvasRunStateCancelling, stored.State)
+
+	_, err = models.DecideAgentPendingOperation(agentSession.ID, uuid.MustParse(requested.ConfirmationID), models.AgentPendingOperationDecisionConfirmed)
+	require.NoError(t, err)
+
+	payload, err = action.Execute(ctx, session, Input{ConfirmationID: requested.ConfirmationID, RunID: uuid.NewString()})
+	require.NoError(t, err)
+	completed, ok := payload.(runtimeOperationResult)
+	require.True(t, ok)
+	assert.Equal(t, operationStatusCompleted, completed.Status)
+
+	_, err = action.Execute(ctx, session, Input{ConfirmationID: requested.ConfirmationID})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match a pending request")
+}
+
+func TestRuntimeOperationAction_DeclinedOperationDoesNotRun(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{
+		{
+			NodeID: "trigger-1",
+			Type:   models.NodeTypeTrigger,
+			Ref: datatypes.NewJSONType(models.NodeRef{
+				Trigger: &models.TriggerRef{Name: "start"},
+			}),
+		},
+	}, nil)
+	event := support.EmitCanvasEventForNode(t, canvas.ID, "trigger-1", "default", nil)
+
+	var run *models.CanvasRun
+	require.NoError(t, database.Conn().Transaction(func(tx *gorm.DB) error {
+		var err error
+		run, err = models.FindOrCreateCanvasRunForRootEventInTransaction(tx, event)
+		if err != nil {
+			return err
+		}
+		return event.RoutedInTransaction(tx)
+	}))
+
+	agentSession := &models.AgentSession{
+		OrganizationID: r.Organization.ID,
+		UserID:         r.User,
+		CanvasID:       canvas.ID,
+		Provider:       "test",
+		Status:         models.AgentSessionStatusStreaming,
+	}
+	require.NoError(t, models.CreateAgentSessionInTransaction(database.Conn(), agentSession))
+
+	action := newRuntimeOperationAction(Dependencies{}, cancelRunActionName)
+	action.auth = allowingPermissionChecker{}
+	session := agents.AgentSessionContext{
+		SessionID:      agentSession.ID.String(),
+		OrganizationID: r.Organization.ID.String(),
+		UserID:         r.User.String(),
+		CanvasID:       canvas.ID.String(),
+	}
+	ctx := authentication.SetUserIdInMetadata(context.Background(), r.User.String())
+
+	payload, err := action.Execute(ctx, session, Input{RunID: run.ID.String()})
+	require.NoError(t, err)
+	requested, ok := payload.(runtimeOperationResult)
+	require.True(t, ok)
+
+	confirmationID := uuid.MustParse(requested.ConfirmationID)
+	_, err = models.DecideAgentPendingOperation(agentSession.ID, confirmationID, models.AgentPendingOperationDecisionDeclined)
+	require.NoError(t, err)
+
+	_, err = models.DecideAgentPendingOperation(agentSession.ID, confirmationID, models.AgentPendingOperationDecisionConfirmed)
+	require.ErrorIs(t, err, models.ErrAgentPendingOperationDecided)
+
+	_, err = action.Execute(ctx, session, Input{ConfirmationID: requested.ConfirmationID})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "declined")
+
+	stored, err := models.FindCanvasRunInTransaction(database.Conn(), canvas.ID, run.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, models.CanvasRunStateCancelling, stored.State)
+
+	_, err = models.FindAgentPendingOperation(confirmationID)
+	require.ErrorIs(t, err, models.ErrAgentPendingOperationNotFound)
+}
+
+func TestRuntimeOperationAction_ApproveRequiresApprovalNode(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{
+		{
+			NodeID: "trigger-1",
+			Type:   models.NodeTypeTrigger,
+			Ref: datatypes.NewJSONType(models.NodeRef{
+				Trigger: &models.TriggerRef{Name: "start"},
+			}),
+		},
+		{
+			NodeID: "noop-1",
+			Type:   models.NodeTypeComponent,
+			Ref: datatypes.NewJSONType(models.NodeRef{
+				Component: &models.ComponentRef{Name: "noop"},
+			}),
+		},
+	}, nil)
+	event := support.EmitCanvasEventForNode(t, canvas.ID, "trigger-1", "default", nil)
+
+	var run *models.CanvasRun
+	require.NoError(t, database.Conn().Transaction(func(tx *gorm.DB) error {
+		var err error
+		run, err = models.FindOrCreateCanvasRunForRootEventInTransaction(tx, event)
+		if err != nil {
+			return err
+		}
+		return event.RoutedInTransaction(tx)
+	}))
+
+	now := time.Now()
+	execution := models.CanvasNodeExecution{
+		ID:            uuid.New(),
+		WorkflowID:    canvas.ID,
+		NodeID:        "noop-1",
+		RootEventID:   event.ID,
+		RunID:         run.ID,
+		EventID:       event.ID,
+		State:         models.CanvasNodeExecutionStateStarted,
+		Metadata:      datatypes.NewJSONType(map[string]any{}),
+		Configuration: datatypes.NewJSONType(map[string]any{}),
+		CreatedAt:     &now,
+		UpdatedAt:     &now,
+	}
+	require.NoError(t, database.Conn().Create(&execution).Error)
+
+	index := 0
+	_, _, err := prepareApprove(canvas, Input{ExecutionID: execution.ID.String(), Index: &index})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not belong to an approval node")
+}
+
 type allowingPermissionChecker struct{}
 
 func (allowingPermissionChecker) CheckOrganizationPermission(_ context.Context, _, _, _, _ string) (bool, error) {
@@ -48,6 +48,13 @@ func NewDefaultRegistry(deps Dependencies) *Registry {
 		newPatchStagingAction(deps),
 		listIntegrationsAction{},
 		newListResourcesAction(deps),
+		newRuntimeOperationAction(deps, startRunActionName),
+		newRuntimeOperationAction(deps, cancelRunActionName),
+		newRuntimeOperationAction(deps, cancelExecutionActionName),
+		newRuntimeOperationAction(deps, resolveExecutionErrorsActionName),
+		newRuntimeOperationAction(deps, reemitEventActionName),
+		newRuntimeOperationAction(deps, approveActionName),
+		newRuntimeOperationAction(deps, rejectActionName),
 	)
 }
 
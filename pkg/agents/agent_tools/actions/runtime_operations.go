@@ -0,0 +1,487 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/agents"
+	"github.com/superplanehq/superplane/pkg/authorization"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/database"
+	canvasactions "github.com/superplanehq/superplane/pkg/grpc/actions/canvases"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	startRunActionName               = "start_run"
+	cancelRunActionName              = "cancel_run"
+	cancelExecutionActionName        = "cancel_execution"
+	resolveExecutionErrorsActionName = "resolve_execution_errors"
+	reemitEventActionName            = "reemit_event"
+	approveActionName                = "approve"
+	rejectActionName                 = "reject"
+
+	operationStatusConfirmationRequired = "confirmation_required"
+	operationStatusCompleted            = "completed"
+
+	// Long enough for the user to read the confirmation prompt and answer,
+	// short enough that a stale confirmation is not replayed against a run
+	// that has moved on.
+	pendingOperationTTL = 15 * time.Minute
+
+	approvalComponentName = "approval"
+	triggerRunHookName    = "run"
+)
+
+// runtimeOperationNames lists the actions that operate a live app. Each of
+// them needs a confirmation round trip with the user before it runs.
+var runtimeOperationNames = []string{
+	startRunActionName,
+	cancelRunActionName,
+	cancelExecutionActionName,
+	resolveExecutionErrorsActionName,
+	reemitEventActionName,
+	approveActionName,
+	rejectActionName,
+}
+
+// operationInput is the normalized operation stored while it waits for
+// confirmation. The confirmed call runs exactly what was summarized to the
+// user, whatever else the agent sends along with confirmation_id.
+type operationInput struct {
+	NodeID       string            `json:"node_id,omitempty"`
+	EventID      string            `json:"event_id,omitempty"`
+	RunID        string            `json:"run_id,omitempty"`
+	ExecutionIDs []string          `json:"execution_ids,omitempty"`
+	Parameters   map[string]string `json:"parameters,omitempty"`
+	Index        int               `json:"index,omitempty"`
+	Comment      string            `json:"comment,omitempty"`
+	Reason       string            `json:"reason,omitempty"`
+}
+
+type operationDeps struct {
+	encryptor      crypto.Encryptor
+	registry       *registry.Registry
+	authService    authorization.Authorization
+	webhookBaseURL string
+}
+
+type runtimeOperationAction struct {
+	name    string
+	auth    organizationPermissionChecker
+	deps    operationDeps
+	prepare func(canvas *models.Canvas, input Input) (operationInput, string, error)
+	run     func(ctx context.Context, deps operationDeps, canvas *models.Canvas, op operationInput) (proto.Message, error)
+}
+
+func newRuntimeOperationAction(deps Dependencies, name string) runtimeOperationAction {
+	action := runtimeOperationAction{
+		name: name,
+		auth: deps.AuthService,
+		deps: operationDeps{
+			encryptor:      deps.Encryptor,
+			registry:       deps.Registry,
+			authService:    deps.AuthService,
+			webhookBaseURL: deps.WebhookBaseURL,
+		},
+	}
+
+	switch name {
+	case startRunActionName:
+		action.prepare, action.run = prepareStartRun, runStartRun
+	case cancelRunActionName:
+		action.prepare, action.run = prepareCancelRun, runCancelRun
+	case cancelExecutionActionName:
+		action.prepare, action.run = prepareCancelExecution, runCancelExecution
+	case resolveExecutionErrorsActionName:
+		action.prepare, action.run = prepareResolveExecutionErrors, runResolveExecutionErrors
+	case reemitEventActionName:
+		action.prepare, action.run = prepareReemitEvent, runReemitEvent
+	case approveActionName:
+		action.prepare, action.run = prepareApprove, runApprovalHook(approveActionName)
+	case rejectActionName:
+		action.prepare, action.run = prepareReject, runApprovalHook(rejectActionName)
+	default:
+		panic(fmt.Sprintf("unknown runtime operation %q", name))
+	}
+
+	return action
+}
+
+func (a runtimeOperationAction) Name() string {
+	return a.name
+}
+
+func (a runtimeOperationAction) Execute(ctx context.Context, session agents.AgentSessionContext, input Input) (any, error) {
+	if err := a.checkUpdatePermission(ctx, session); err != nil {
+		return runtimeOperationResult{}, err
+	}
+
+	sessionID, err := uuid.Parse(session.SessionID)
+	if err != nil {
+		return runtimeOperationResult{}, fmt.Errorf("invalid session id: %w", err)
+	}
+
+	canvas, err := findSessionCanvas(ctx, session)
+	if err != nil {
+		return runtimeOperationResult{}, err
+	}
+
+	if strings.TrimSpace(input.ConfirmationID) == "" {
+		return a.request(sessionID, canvas, input)
+	}
+
+	return a.confirm(ctx, sessionID, canvas, input.ConfirmationID)
+}
+
+func (a runtimeOperationAction) request(sessionID uuid.UUID, canvas *models.Canvas, input Input) (any, error) {
+	op, summary, err := a.prepare(canvas, input)
+	if err != nil {
+		return runtimeOperationResult{}, err
+	}
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		return runtimeOperationResult{}, fmt.Errorf("encode operation: %w", err)
+	}
+
+	pending, err := models.CreateAgentPendingOperation(sessionID, a.name, data, summary, pendingOperationTTL)
+	if err != nil {
+		return runtimeOperationResult{}, fmt.Errorf("record pending operation: %w", err)
+	}
+
+	return runtimeOperationResult{
+		Action:         a.name,
+		CanvasID:       canvas.ID.String(),
+		Status:         operationStatusConfirmationRequired,
+		ConfirmationID: pending.ID.String(),
+		Summary:        summary,
+		Next:           "Nothing has run yet. Show the summary in a :::confirm widget with confirmation_id set to this confirmation_id, and end your turn. The operation only runs once the user clicks the confirm button of that widget; a chat reply does not confirm it. After the user confirms, call the same action again with this confirmation_id.",
+	}, nil
+}
+
+func (a runtimeOperationAction) confirm(ctx context.Context, sessionID uuid.UUID, canvas *models.Canvas, rawConfirmationID string) (any, error) {
+	confirmationID, err := uuid.Parse(strings.TrimSpace(rawConfirmationID))
+	if err != nil {
+		return runtimeOperationResult{}, fmt.Errorf("invalid confirmation_id: %w", err)
+	}
+
+	pending, err := models.ConsumeAgentPendingOperation(sessionID, confirmationID, a.name)
+	if err != nil {
+		return runtimeOperationResult{}, pendingOperationError(err)
+	}
+
+	var op operationInput
+	if err := json.Unmarshal(pending.Input, &op); err != nil {
+		return runtimeOperationResult{}, fmt.Errorf("decode pending operation: %w", err)
+	}
+
+	payload, err := protoPayload(a.run(ctx, a.deps, canvas, op))
+	if err != nil {
+		return runtimeOperationResult{}, err
+	}
+
+	return runtimeOperationResult{
+		Action:   a.name,
+		CanvasID: canvas.ID.String(),
+		Status:   operationStatusCompleted,
+		Summary:  pending.Summary,
+		Payload:  payload,
+	}, nil
+}
+
+func (a runtimeOperationAction) checkUpdatePermission(ctx context.Context, session agents.AgentSessionContext) error {
+	if a.auth == nil {
+		return fmt.Errorf("authorization service is unavailable")
+	}
+
+	allowed, err := a.auth.CheckOrganizationPermission(ctx, session.UserID, session.OrganizationID, "canvases", "update")
+	if err != nil {
+		return fmt.Errorf("check canvases:update permission: %w", err)
+	}
+	if !allowed {
+		return fmt.Errorf("user RBAC does not grant canvases:update")
+	}
+	return nil
+}
+
+func pendingOperationError(err error) error {
+	switch {
+	case errors.Is(err, models.ErrAgentPendingOperationNotFound):
+		return fmt.Errorf("confirmation_id does not match a pending request for this action in this session; request the operation again")
+	case errors.Is(err, models.ErrAgentPendingOperationExpired):
+		return fmt.Errorf("confirmation expired; request the operation again and ask the user to confirm")
+	case errors.Is(err, models.ErrAgentPendingOperationNotConfirmed):
+		return fmt.Errorf("the user has not confirmed this operation; show it in a :::confirm widget with this confirmation_id and wait for the user to click confirm")
+	case errors.Is(err, models.ErrAgentPendingOperationDeclined):
+		return fmt.Errorf("the user declined this operation; do not retry it unless they ask again")
+	default:
+		return fmt.Errorf("confirm pending operation: %w", err)
+	}
+}
+
+func findSessionCanvas(ctx context.Context, session agents.AgentSessionContext) (*models.Canvas, error) {
+	orgID, err := uuid.Parse(session.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session organization id: %w", err)
+	}
+
+	canvasID, err := uuid.Parse(session.CanvasID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session canvas id: %w", err)
+	}
+
+	canvas, err := models.FindCanvasInTransaction(database.DB(ctx), orgID, canvasID)
+	if err != nil {
+		return nil, fmt.Errorf("find canvas: %w", err)
+	}
+	return canvas, nil
+}
+
+func prepareStartRun(canvas *models.Canvas, input Input) (operationInput, string, error) {
+	node, err := findOperationNode(canvas, input.NodeID)
+	if err != nil {
+		return operationInput{}, "", err
+	}
+	if node.Ref.Data().Trigger == nil {
+		return operationInput{}, "", fmt.Errorf("node %q is not a trigger", node.NodeID)
+	}
+
+	return operationInput{NodeID: node.NodeID, Parameters: input.Parameters},
+		fmt.Sprintf("Start a new run from trigger %s.", describeNode(node)), nil
+}
+
+func runStartRun(ctx context.Context, deps operationDeps, canvas *models.Canvas, op operationInput) (proto.Message, error) {
+	parameters := make(map[string]any, len(op.Parameters))
+	for key, value := range op.Parameters {
+		parameters[key] = value
+	}
+
+	return canvasactions.InvokeNodeTriggerHook(ctx, deps.authService, deps.encryptor, deps.registry, database.DB(ctx), canvas, op.NodeID, triggerRunHookName, parameters, deps.webhookBaseURL)
+}
+
+func prepareCancelRun(canvas *models.Canvas, input Input) (operationInput, string, error) {
+	runID, err := parseOperationID("run_id", input.RunID)
+	if err != nil {
+		return operationInput{}, "", err
+	}
+	if _, err := models.FindCanvasRunInTransaction(database.Conn(), canvas.ID, runID); err != nil {
+		return operationInput{}, "", fmt.Errorf("load run: %w", err)
+	}
+
+	return operationInput{RunID: runID.String()}, fmt.Sprintf("Cancel run %s and all of its pending and running executions.", runID), nil
+}
+
+func runCancelRun(ctx context.Context, _ operationDeps, canvas *models.Canvas, op operationInput) (proto.Message, error) {
+	runID, err := uuid.Parse(op.RunID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid run_id: %w", err)
+	}
+	return canvasactions.CancelRun(ctx, database.DB(ctx), canvas, runID)
+}
+
+func prepareCancelExecution(canvas *models.Canvas, input Input) (operationInput, string, error) {
+	execution, node, err := findOperationExecution(canvas, input.ExecutionID)
+	if err != nil {
+		return operationInput{}, "", err
+	}
+
+	return operationInput{ExecutionIDs: []string{execution.ID.String()}},
+		fmt.Sprintf("Cancel execution %s of %s.", execution.ID, describeNode(node)), nil
+}
+
+func runCancelExecution(ctx context.Context, deps operationDeps, canvas *models.Canvas, op operationInput) (proto.Message, error) {
+	executionID, err := singleExecutionID(op)
+	if err != nil {
+		return nil, err
+	}
+	return canvasactions.CancelExecution(ctx, deps.authService, deps.encryptor, database.DB(ctx), canvas, executionID)
+}
+
+func prepareResolveExecutionErrors(canvas *models.Canvas, input Input) (operationInput, string, error) {
+	rawIDs := append([]string{}, input.ExecutionIDs...)
+	if strings.TrimSpace(input.ExecutionID) != "" {
+		rawIDs = append(rawIDs, input.ExecutionID)
+	}
+	if len(rawIDs) == 0 {
+		return operationInput{}, "", fmt.Errorf("execution_ids is required for %s", resolveExecutionErrorsActionName)
+	}
+
+	ids := make([]string, 0, len(rawIDs))
+	for _, rawID := range rawIDs {
+		execution, _, err := findOperationExecution(canvas, rawID)
+		if err != nil {
+			return operationInput{}, "", err
+		}
+		ids = append(ids, execution.ID.String())
+	}
+
+	return operationInput{ExecutionIDs: ids},
+		fmt.Sprintf("Mark the errors of %d execution(s) as resolved: %s.", len(ids), strings.Join(ids, ", ")), nil
+}
+
+func runResolveExecutionErrors(ctx context.Context, _ operationDeps, canvas *models.Canvas, op operationInput) (proto.Message, error) {
+	ids := make([]uuid.UUID, 0, len(op.ExecutionIDs))
+	for _, rawID := range op.ExecutionIDs {
+		id, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid execution id %q: %w", rawID, err)
+		}
+		ids = append(ids, id)
+	}
+	return canvasactions.ResolveExecutionErrors(ctx, database.DB(ctx), canvas, ids)
+}
+
+func prepareReemitEvent(canvas *models.Canvas, input Input) (operationInput, string, error) {
+	node, err := findOperationNode(canvas, input.NodeID)
+	if err != nil {
+		return operationInput{}, "", err
+	}
+	if node.Ref.Data().Trigger == nil {
+		return operationInput{}, "", fmt.Errorf("node %q is not a trigger", node.NodeID)
+	}
+
+	eventID, err := parseOperationID("event_id", input.EventID)
+	if err != nil {
+		return operationInput{}, "", err
+	}
+
+	return operationInput{NodeID: node.NodeID, EventID: eventID.String()},
+		fmt.Sprintf("Re-emit event %s from trigger %s, starting a new run with the same payload.", eventID, describeNode(node)), nil
+}
+
+func runReemitEvent(ctx context.Context, _ operationDeps, canvas *models.Canvas, op operationInput) (proto.Message, error) {
+	eventID, err := uuid.Parse(op.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event_id: %w", err)
+	}
+	return canvasactions.ReemitTriggerEvent(ctx, database.DB(ctx), canvas, op.NodeID, eventID)
+}
+
+func prepareApprove(canvas *models.Canvas, input Input) (operationInput, string, error) {
+	op, node, err := prepareApprovalHook(canvas, input)
+	if err != nil {
+		return operationInput{}, "", err
+	}
+	op.Comment = strings.TrimSpace(input.Comment)
+
+	return op, fmt.Sprintf("Approve item %d of %s (execution %s) as the current user.", op.Index, describeNode(node), op.ExecutionIDs[0]), nil
+}
+
+func prepareReject(canvas *models.Canvas, input Input) (operationInput, string, error) {
+	op, node, err := prepareApprovalHook(canvas, input)
+	if err != nil {
+		return operationInput{}, "", err
+	}
+	op.Reason = strings.TrimSpace(input.Reason)
+	if op.Reason == "" {
+		return operationInput{}, "", fmt.Errorf("reason is required for %s", rejectActionName)
+	}
+
+	return op, fmt.Sprintf("Reject item %d of %s (execution %s) as the current user with reason %q.", op.Index, describeNode(node), op.ExecutionIDs[0], op.Reason), nil
+}
+
+func prepareApprovalHook(canvas *models.Canvas, input Input) (operationInput, *models.CanvasNode, error) {
+	execution, node, err := findOperationExecution(canvas, input.ExecutionID)
+	if err != nil {
+		return operationInput{}, nil, err
+	}
+
+	component := node.Ref.Data().Component
+	if component == nil || component.Name != approvalComponentName {
+		return operationInput{}, nil, fmt.Errorf("execution %s does not belong to an approval node", execution.ID)
+	}
+	if input.Index == nil {
+		return operationInput{}, nil, fmt.Errorf("index is required for approval actions")
+	}
+
+	return operationInput{ExecutionIDs: []string{execution.ID.String()}, Index: *input.Index}, node, nil
+}
+
+func runApprovalHook(hookName string) func(context.Context, operationDeps, *models.Canvas, operationInput) (proto.Message, error) {
+	return func(ctx context.Context, deps operationDeps, canvas *models.Canvas, op operationInput) (proto.Message, error) {
+		executionID, err := singleExecutionID(op)
+		if err != nil {
+			return nil, err
+		}
+
+		parameters := map[string]any{"index": op.Index}
+		if op.Comment != "" {
+			parameters["comment"] = op.Comment
+		}
+		if op.Reason != "" {
+			parameters["reason"] = op.Reason
+		}
+
+		return canvasactions.InvokeNodeExecutionHook(ctx, deps.authService, deps.encryptor, deps.registry, database.DB(ctx), canvas, executionID, hookName, parameters)
+	}
+}
+
+func findOperationNode(canvas *models.Canvas, rawNodeID string) (*models.CanvasNode, error) {
+	nodeID := strings.TrimSpace(rawNodeID)
+	if nodeID == "" {
+		return nil, fmt.Errorf("node_id is required")
+	}
+
+	node, err := canvas.FindNode(nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("load node %q: %w", nodeID, err)
+	}
+	return node, nil
+}
+
+func findOperationExecution(canvas *models.Canvas, rawExecutionID string) (*models.CanvasNodeExecution, *models.CanvasNode, error) {
+	executionID, err := parseOperationID("execution_id", rawExecutionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	execution, err := models.FindNodeExecution(canvas.ID, executionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load execution %s: %w", executionID, err)
+	}
+
+	node, err := canvas.FindNode(execution.NodeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load node %q: %w", execution.NodeID, err)
+	}
+	return execution, node, nil
+}
+
+func parseOperationID(field, raw string) (uuid.UUID, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return uuid.Nil, fmt.Errorf("%s is required", field)
+	}
+
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid %s: %w", field, err)
+	}
+	return id, nil
+}
+
+func singleExecutionID(op operationInput) (uuid.UUID, error) {
+	if len(op.ExecutionIDs) != 1 {
+		return uuid.Nil, fmt.Errorf("pending operation must target exactly one execution")
+	}
+	id, err := uuid.Parse(op.ExecutionIDs[0])
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid execution id: %w", err)
+	}
+	return id, nil
+}
+
+func describeNode(node *models.CanvasNode) string {
+	if node.Name == "" || node.Name == node.NodeID {
+		return fmt.Sprintf("%q", node.NodeID)
+	}
+	return fmt.Sprintf("%q (%s)", node.Name, node.NodeID)
+}
@@ -20,6 +20,7 @@ type Input struct {
 	NodeID              string            `json:"node_id,omitempty"`
 	EventID             string            `json:"event_id,omitempty"`
 	ExecutionID         string            `json:"execution_id,omitempty"`
+	ExecutionIDs        []string          `json:"execution_ids,omitempty"`
 	RunID               string            `json:"run_id,omitempty"`
 	Limit               uint32            `json:"limit,omitempty"`
 	Before              string            `json:"before,omitempty"`
@@ -29,6 +30,10 @@ type Input struct {
 	Paths               []string          `json:"paths,omitempty"`
 	Content             string            `json:"content,omitempty"`
 	Query               string            `json:"query,omitempty"`
+	Index               *int              `json:"index,omitempty"`
+	Comment             string            `json:"comment,omitempty"`
+	Reason              string            `json:"reason,omitempty"`
+	ConfirmationID      string            `json:"confirmation_id,omitempty"`
 }
 
 // PatchOperation describes one small graph edit for patch_staging.
@@ -120,6 +125,16 @@ type runtimeReadResult struct {
 	Payload  any    `json:"payload"`
 }
 
+type runtimeOperationResult struct {
+	Action         string `json:"action"`
+	CanvasID       string `json:"canvas_id"`
+	Status         string `json:"status"`
+	ConfirmationID string `json:"confirmation_id,omitempty"`
+	Summary        string `json:"summary"`
+	Next           string `json:"next,omitempty"`
+	Payload        any    `json:"payload,omitempty"`
+}
+
 type fileListResult struct {
 	Action       string   `json:"action"`
 	CanvasID     string   `json:"canvas_id"`
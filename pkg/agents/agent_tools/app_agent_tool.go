@@ -63,7 +63,7 @@ func (t *AppAgentTool) Name() string {
 }
 
 func (t *AppAgentTool) Description() string {
-	return "Inspect access, read the current SuperPlane app (including effective staged edits), stage canvas/Console/repository file changes, list connected integrations, list integration resources, and read runtime data. This is the only way to reach the app; there is no command line or HTTP API to call. The tool is bound to the current agent session's canvas and rejects attempts to access any other canvas. It never commits staging. Use patch_staging for graph edits, Console updates, or auto-layout without sending full canvas YAML."
+	return "Inspect access, read the current SuperPlane app (including effective staged edits), stage canvas/Console/repository file changes, list connected integrations, list integration resources, read runtime data, and operate runs on the user's behalf. This is the only way to reach the app; there is no command line or HTTP API to call. The tool is bound to the current agent session's canvas and rejects attempts to access any other canvas. It never commits staging. Runtime operations (start_run, cancel_run, cancel_execution, resolve_execution_errors, reemit_event, approve, reject) never run on the first call: they return a confirmation_id and only run when called again with it after the user has confirmed in chat. Use patch_staging for graph edits, Console updates, or auto-layout without sending full canvas YAML."
 }
 
 func (t *AppAgentTool) InputSchema() agents.CustomToolInputSchema {
@@ -73,7 +73,7 @@ func (t *AppAgentTool) InputSchema() agents.CustomToolInputSchema {
 			"action": {
 				Type:        "string",
 				Enum:        t.actions.Names(),
-				Description: "Operation to run. Use access to inspect token-backed API capabilities, read for current effective staged YAML, read_runtime for memory/runs/events/executions/queues, list_files/read_file for app repository files and AGENTS.md context, write_file/delete_file to stage normal file changes, patch_staging to apply graph edits, Console updates, or auto-layout without sending full canvas YAML, list_integrations for connected integration IDs, and list_resources for integration-backed resource values.",
+				Description: "Operation to run. Use access to inspect token-backed API capabilities, read for current effective staged YAML, read_runtime for memory/runs/events/executions/queues, list_files/read_file for app repository files and AGENTS.md context, write_file/delete_file to stage normal file changes, patch_staging to apply graph edits, Console updates, or auto-layout without sending full canvas YAML, list_integrations for connected integration IDs, list_resources for integration-backed resource values, and start_run/cancel_run/cancel_execution/resolve_execution_errors/reemit_event/approve/reject to operate the live app after user confirmation.",
 			},
 			"canvas_id": {
 				Type:        "string",
@@ -109,7 +109,7 @@ func (t *AppAgentTool) InputSchema() agents.CustomToolInputSchema {
 			},
 			"parameters": {
 				Type:        "object",
-				Description: "For list_resources. Optional provider-specific string parameters. The backend also receives resource_type as parameter type.",
+				Description: "For list_resources, optional provider-specific string parameters; the backend also receives resource_type as parameter type. For start_run, the trigger's run parameters, for example template for a manual start trigger.",
 			},
 			"path": {
 				Type:        "string",
@@ -162,19 +162,40 @@ func (t *AppAgentTool) InputSchema() agents.CustomToolInputSchema {
 			},
 			"node_id": {
 				Type:        "string",
-				Description: "For read_runtime resources node_executions, node_queue_items, and node_events.",
+				Description: "For read_runtime resources node_executions, node_queue_items, and node_events. For start_run and reemit_event, the trigger node ID.",
 			},
 			"event_id": {
 				Type:        "string",
-				Description: "For read_runtime resource event_executions.",
+				Description: "For read_runtime resource event_executions. For reemit_event, the trigger event to emit again.",
 			},
 			"execution_id": {
 				Type:        "string",
-				Description: "For read_runtime resource runner_logs. Fetch logs for a specific node execution.",
+				Description: "For read_runtime resource runner_logs, fetch logs for a specific node execution. For cancel_execution, approve, and reject, the target node execution.",
+			},
+			"execution_ids": {
+				Type:        "array",
+				Description: "For resolve_execution_errors. Failed node executions whose errors should be marked as resolved.",
+				Items:       &agents.CustomToolInputSchema{Type: "string"},
 			},
 			"run_id": {
 				Type:        "string",
-				Description: "For read_runtime resource runner_logs. Fetch logs for runner executions in a run; combine with node_id to target one node in that run.",
+				Description: "For read_runtime resource runner_logs, fetch logs for runner executions in a run; combine with node_id to target one node in that run. For cancel_run, the run to cancel.",
+			},
+			"index": {
+				Type:        "integer",
+				Description: "For approve and reject. Index of the approval item the user is acting on, as listed in the approval execution metadata.",
+			},
+			"comment": {
+				Type:        "string",
+				Description: "For approve. Optional comment recorded with the approval.",
+			},
+			"reason": {
+				Type:        "string",
+				Description: "For reject. Required rejection reason.",
+			},
+			"confirmation_id": {
+				Type:        "string",
+				Description: "For runtime operations. Omit on the first call to get a confirmation_id and summary. Pass it back, with the same action, only after the user has confirmed the summarized operation in chat; the stored operation runs regardless of other fields sent with it.",
 			},
 			"limit": {
 				Type:        "integer",
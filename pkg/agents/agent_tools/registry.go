@@ -161,6 +161,15 @@ func (r *Registry) Definitions() []Definition {
 	return definitions
 }
 
+// Definition returns the registered tool with the given name.
+func (r *Registry) Definition(name string) (Definition, bool) {
+	tool, ok := r.tools[name]
+	if !ok {
+		return nil, false
+	}
+	return tool, true
+}
+
 // ExecuteCustomTool dispatches one provider custom tool invocation to the
 // matching registered backend implementation.
 func (r *Registry) ExecuteCustomTool(ctx context.Context, session agents.AgentSessionContext, toolUse agents.CustomToolUse) agents.CustomToolResult {
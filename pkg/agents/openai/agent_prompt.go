@@ -0,0 +1,16 @@
+package openai
+
+import (
+	_ "embed"
+)
+
+// The default prompt is shorter than the Anthropic one: chat-completions
+// sessions have no mounted reference files or researcher sub-agents, so
+// everything the model needs has to come through the custom tools.
+//
+//go:embed agent_prompt.md
+var defaultAgentPrompt string
+
+func DefaultAgentPrompt() string {
+	return defaultAgentPrompt
+}
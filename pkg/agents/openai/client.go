@@ -0,0 +1,99 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.openai.com/v1"
+
+type Config struct {
+	// APIKey is sent as a bearer token. Self-hosted endpoints often run
+	// without authentication, so it is optional.
+	APIKey string
+	// Model is the model name sent with every chat-completions request.
+	Model string
+	// SystemPrompt replaces the embedded default prompt when set.
+	SystemPrompt string
+	BaseURL      string // overridable for tests and self-hosted endpoints
+	HTTPClient   *http.Client
+}
+
+// Client is the HTTP transport for an OpenAI-compatible chat-completions
+// endpoint. It knows about authentication and JSON encoding; it does not
+// know about agents.Provider concepts.
+type Client struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client // streamed completions must not time out
+}
+
+func newClient(cfg Config) *Client {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &Client{
+		apiKey:     cfg.APIKey,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// openCompletionStream issues a streamed chat-completions request. Caller
+// must close the returned body.
+func (c *Client) openCompletionStream(ctx context.Context, body chatCompletionRequest) (io.ReadCloser, error) {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewReader(buf))
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, &apiError{
+			StatusCode: resp.StatusCode,
+			Message:    truncate(string(data), 500),
+		}
+	}
+	return resp.Body, nil
+}
+
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("openai API %d: %s", e.StatusCode, e.Message)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "…"
+}
@@ -0,0 +1,326 @@
+package openai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/agents"
+)
+
+const (
+	roleSystem    = "system"
+	roleUser      = "user"
+	roleAssistant = "assistant"
+	roleTool      = "tool"
+)
+
+// errStreamFailed marks an error the endpoint reported inside the stream,
+// as opposed to a transport failure.
+var errStreamFailed = errors.New("openai: stream error")
+
+// assistantTextFlushBytes is how much new text a streamed completion has
+// to produce before the accumulated message is emitted again. The stream
+// worker upserts assistant messages by ProviderEventID, so each emission
+// replaces the previous one; flushing every delta would mean one database
+// write per token.
+const assistantTextFlushBytes = 256
+
+type chatMessage struct {
+	Role       string          `json:"role"`
+	Content    json.RawMessage `json:"content,omitempty"`
+	ToolCalls  []chatToolCall  `json:"tool_calls,omitempty"`
+	ToolCallID string          `json:"tool_call_id,omitempty"`
+}
+
+type chatToolCall struct {
+	ID       string           `json:"id"`
+	Type     string           `json:"type"`
+	Function chatFunctionCall `json:"function"`
+}
+
+type chatFunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+type chatContentPart struct {
+	Type     string        `json:"type"`
+	Text     string        `json:"text,omitempty"`
+	ImageURL *chatImageURL `json:"image_url,omitempty"`
+}
+
+type chatImageURL struct {
+	URL string `json:"url"`
+}
+
+type chatTool struct {
+	Type     string       `json:"type"`
+	Function chatFunction `json:"function"`
+}
+
+type chatFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters"`
+}
+
+type chatCompletionRequest struct {
+	Model         string             `json:"model"`
+	Messages      []chatMessage      `json:"messages"`
+	Tools         []chatTool         `json:"tools,omitempty"`
+	Stream        bool               `json:"stream"`
+	StreamOptions *chatStreamOptions `json:"stream_options,omitempty"`
+}
+
+type chatStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatCompletionChunk struct {
+	ID      string `json:"id"`
+	Model   string `json:"model"`
+	Choices []struct {
+		Index        int       `json:"index"`
+		Delta        chatDelta `json:"delta"`
+		FinishReason *string   `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *chatUsage `json:"usage,omitempty"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+type chatDelta struct {
+	Content   string `json:"content"`
+	ToolCalls []struct {
+		Index    int    `json:"index"`
+		ID       string `json:"id"`
+		Type     string `json:"type"`
+		Function struct {
+			Name      string `json:"name"`
+			Arguments string `json:"arguments"`
+		} `json:"function"`
+	} `json:"tool_calls"`
+}
+
+type chatUsage struct {
+	PromptTokens        int64 `json:"prompt_tokens"`
+	CompletionTokens    int64 `json:"completion_tokens"`
+	TotalTokens         int64 `json:"total_tokens"`
+	PromptTokensDetails *struct {
+		CachedTokens int64 `json:"cached_tokens"`
+	} `json:"prompt_tokens_details,omitempty"`
+}
+
+func (u *chatUsage) sessionUsage() sessionUsage {
+	if u == nil {
+		return sessionUsage{}
+	}
+
+	var cached int64
+	if u.PromptTokensDetails != nil {
+		cached = u.PromptTokensDetails.CachedTokens
+	}
+	total := u.TotalTokens
+	if total == 0 {
+		total = u.PromptTokens + u.CompletionTokens
+	}
+	return sessionUsage{
+		InputTokens:     u.PromptTokens - cached,
+		OutputTokens:    u.CompletionTokens,
+		CacheReadTokens: cached,
+		TotalTokens:     total,
+	}
+}
+
+func (u *sessionUsage) add(other sessionUsage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheReadTokens += other.CacheReadTokens
+	u.TotalTokens += other.TotalTokens
+}
+
+// completion is one streamed chat-completions response folded back into a
+// single assistant message.
+type completion struct {
+	ID        string
+	Model     string
+	Text      string
+	ToolCalls []chatToolCall
+	Usage     sessionUsage
+}
+
+func (c *completion) message() chatMessage {
+	msg := chatMessage{Role: roleAssistant, ToolCalls: c.ToolCalls}
+	if c.Text != "" || len(c.ToolCalls) == 0 {
+		msg.Content = textContent(c.Text)
+	}
+	return msg
+}
+
+// readCompletion consumes a chat-completions SSE stream. Only the first
+// choice is used; the provider never asks for more than one. onText is
+// called with the accumulated assistant text as it grows.
+func readCompletion(ctx context.Context, body io.Reader, onText func(text string) error) (*completion, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	result := &completion{}
+	var text strings.Builder
+	flushedAt := 0
+	toolCalls := map[int]*chatToolCall{}
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		payload := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if payload == "" {
+			continue
+		}
+		if payload == "[DONE]" {
+			break
+		}
+
+		var chunk chatCompletionChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			log.WithError(err).Debug("openai: skipping malformed sse chunk")
+			continue
+		}
+		if chunk.Error != nil {
+			return nil, fmt.Errorf("%w: %s", errStreamFailed, chunk.Error.Message)
+		}
+		if result.ID == "" {
+			result.ID = chunk.ID
+		}
+		if chunk.Model != "" {
+			result.Model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			result.Usage = chunk.Usage.sessionUsage()
+		}
+
+		for _, choice := range chunk.Choices {
+			if choice.Index != 0 {
+				continue
+			}
+			text.WriteString(choice.Delta.Content)
+			for _, delta := range choice.Delta.ToolCalls {
+				call, ok := toolCalls[delta.Index]
+				if !ok {
+					call = &chatToolCall{Type: "function"}
+					toolCalls[delta.Index] = call
+				}
+				if delta.ID != "" {
+					call.ID = delta.ID
+				}
+				call.Function.Name += delta.Function.Name
+				call.Function.Arguments += delta.Function.Arguments
+			}
+		}
+
+		if text.Len()-flushedAt >= assistantTextFlushBytes {
+			flushedAt = text.Len()
+			if err := onText(text.String()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("openai: stream read: %w", err)
+	}
+
+	result.Text = text.String()
+	result.ToolCalls = sortedToolCalls(toolCalls)
+	return result, nil
+}
+
+func sortedToolCalls(calls map[int]*chatToolCall) []chatToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	indexes := make([]int, 0, len(calls))
+	for index := range calls {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	result := make([]chatToolCall, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, *calls[index])
+	}
+	return result
+}
+
+func assistantMessageEvent(id, text string) agents.ProviderEvent {
+	return agents.ProviderEvent{
+		ProviderEventID: id,
+		Type:            agents.ProviderEventAssistantMessage,
+		Text:            redactSensitive(text),
+	}
+}
+
+func customToolUseEvent(call chatToolCall) agents.ProviderEvent {
+	input := strings.TrimSpace(call.Function.Arguments)
+	if input == "" {
+		input = "{}"
+	}
+	return agents.ProviderEvent{
+		ProviderEventID: call.ID,
+		Type:            agents.ProviderEventCustomToolUseStarted,
+		ToolName:        call.Function.Name,
+		ToolCallID:      call.ID,
+		ToolInput:       redactSensitive(input),
+		CustomToolUse: &agents.CustomToolUse{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Input: input,
+		},
+	}
+}
+
+func textContent(text string) json.RawMessage {
+	data, _ := json.Marshal(text)
+	return data
+}
+
+func userContent(text string, images []agents.MessageImage) json.RawMessage {
+	if len(images) == 0 {
+		return textContent(text)
+	}
+
+	parts := []chatContentPart{{Type: "text", Text: text}}
+	for _, image := range images {
+		parts = append(parts, chatContentPart{
+			Type:     "image_url",
+			ImageURL: &chatImageURL{URL: "data:" + image.MediaType + ";base64," + image.Data},
+		})
+	}
+	data, _ := json.Marshal(parts)
+	return data
+}
+
+// Same defense in depth as the Anthropic provider: never relay a
+// JWT-shaped secret that surfaces in model output.
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+
+func redactSensitive(s string) string {
+	if s == "" {
+		return s
+	}
+	return jwtPattern.ReplaceAllString(s, "<redacted>")
+}
@@ -0,0 +1,430 @@
+// Package openai implements agents.Provider against any OpenAI-compatible
+// chat-completions endpoint, including self-hosted ones. Those endpoints
+// are stateless, so the provider keeps the session transcript and runs the
+// tool loop itself.
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/agents"
+	agenttools "github.com/superplanehq/superplane/pkg/agents/agent_tools"
+)
+
+const ProviderName = "openai"
+
+type Provider struct {
+	model        string
+	systemPrompt string
+	tools        []chatTool
+	client       *Client
+	store        sessionStore
+
+	// inflight holds the cancel func of the completion currently streaming
+	// for each session, so InterruptSession can stop it when the stream
+	// runs in this process.
+	inflight sync.Map
+}
+
+func New(cfg Config) (*Provider, error) {
+	return newProvider(cfg, dbSessionStore{})
+}
+
+func newProvider(cfg Config, store sessionStore) (*Provider, error) {
+	if strings.TrimSpace(cfg.Model) == "" {
+		return nil, fmt.Errorf("openai: Model is required")
+	}
+
+	systemPrompt := cfg.SystemPrompt
+	if strings.TrimSpace(systemPrompt) == "" {
+		systemPrompt = DefaultAgentPrompt()
+	}
+
+	return &Provider{
+		model:        cfg.Model,
+		systemPrompt: systemPrompt,
+		tools:        defaultTools(),
+		client:       newClient(cfg),
+		store:        store,
+	}, nil
+}
+
+func (p *Provider) Name() string { return ProviderName }
+
+func (p *Provider) ToolSchemaRevision() string {
+	return agenttools.SchemaRevision()
+}
+
+func (p *Provider) CreateSession(ctx context.Context, opts agents.CreateSessionOptions) (*agents.CreateSessionResult, error) {
+	state := &sessionState{Messages: []chatMessage{}}
+	if opts.InitialContext != "" {
+		state.Messages = append(state.Messages, chatMessage{
+			Role:    roleSystem,
+			Content: textContent(opts.InitialContext),
+		})
+	}
+
+	id, err := p.store.create(ctx, state)
+	if err != nil {
+		return nil, fmt.Errorf("openai: create session: %w", err)
+	}
+	return &agents.CreateSessionResult{ProviderSessionID: id}, nil
+}
+
+func (p *Provider) SendMessage(ctx context.Context, providerSessionID, message string, opts agents.SendMessageOptions) error {
+	if providerSessionID == "" {
+		return fmt.Errorf("openai: provider session id is required")
+	}
+
+	msg := chatMessage{
+		Role:    roleUser,
+		Content: userContent(withPreamble(message, opts.ContextPreamble), opts.Images),
+	}
+	if err := p.store.update(ctx, providerSessionID, func(state *sessionState) error {
+		enqueueUserMessage(state, msg)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("openai: send message: %w", err)
+	}
+	return nil
+}
+
+// DefineOutcome has no provider-side grader to hand off to, so the goal and
+// rubric are sent as a regular user turn and the model runs the build loop
+// through its tools.
+func (p *Provider) DefineOutcome(ctx context.Context, providerSessionID string, opts agents.DefineOutcomeOptions) error {
+	if providerSessionID == "" {
+		return fmt.Errorf("openai: provider session id is required")
+	}
+
+	var b strings.Builder
+	b.WriteString("Goal:\n")
+	b.WriteString(opts.Description)
+	if strings.TrimSpace(opts.Rubric) != "" {
+		b.WriteString("\n\nThe goal is met when all of these hold:\n")
+		b.WriteString(opts.Rubric)
+	}
+	b.WriteString("\n\nKeep working with your tools until every criterion holds, checking your work against the list after each change")
+	if opts.MaxIterations > 0 {
+		fmt.Fprintf(&b, " (at most %d attempts)", opts.MaxIterations)
+	}
+	b.WriteString(". Then report which criteria are met.")
+
+	msg := chatMessage{
+		Role:    roleUser,
+		Content: textContent(withPreamble(b.String(), opts.ContextPreamble)),
+	}
+	if err := p.store.update(ctx, providerSessionID, func(state *sessionState) error {
+		enqueueUserMessage(state, msg)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("openai: define outcome: %w", err)
+	}
+	return nil
+}
+
+func (p *Provider) InterruptSession(ctx context.Context, providerSessionID string) error {
+	if providerSessionID == "" {
+		return fmt.Errorf("openai: provider session id is required")
+	}
+
+	if cancel, ok := p.inflight.Load(providerSessionID); ok {
+		cancel.(context.CancelFunc)()
+	}
+
+	err := p.store.update(ctx, providerSessionID, func(state *sessionState) error {
+		state.Generation++
+		for _, id := range state.PendingToolCalls {
+			state.Messages = append(state.Messages, chatMessage{
+				Role:       roleTool,
+				ToolCallID: id,
+				Content:    textContent("Interrupted by the user before the tool ran."),
+			})
+		}
+		state.PendingToolCalls = nil
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("openai: interrupt session: %w", err)
+	}
+	return nil
+}
+
+func (p *Provider) SendCustomToolResults(ctx context.Context, providerSessionID string, results []agents.CustomToolResult) error {
+	if providerSessionID == "" {
+		return fmt.Errorf("openai: provider session id is required")
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]agents.CustomToolResult, len(results))
+	for _, result := range results {
+		if result.CustomToolUseID == "" {
+			return fmt.Errorf("openai: custom tool use id is required")
+		}
+		byID[result.CustomToolUseID] = result
+	}
+
+	err := p.store.update(ctx, providerSessionID, func(state *sessionState) error {
+		for _, id := range state.PendingToolCalls {
+			if _, ok := byID[id]; !ok {
+				return fmt.Errorf("missing result for tool call %s", id)
+			}
+		}
+
+		for _, id := range state.PendingToolCalls {
+			result := byID[id]
+			content := result.Content
+			if result.IsError {
+				content = "Error: " + content
+			}
+			state.Messages = append(state.Messages, chatMessage{
+				Role:       roleTool,
+				ToolCallID: id,
+				Content:    textContent(content),
+			})
+		}
+		state.PendingToolCalls = nil
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("openai: send custom tool results: %w", err)
+	}
+	return nil
+}
+
+// StreamEvents runs model turns until the model answers without asking for
+// tools, or until it asks for tools and the caller has to supply results
+// through SendCustomToolResults before streaming again.
+func (p *Provider) StreamEvents(ctx context.Context, providerSessionID string, onEvent func(agents.ProviderEvent) error) error {
+	if providerSessionID == "" {
+		return fmt.Errorf("openai: provider session id is required")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	p.inflight.Store(providerSessionID, cancel)
+	defer p.inflight.Delete(providerSessionID)
+
+	for {
+		var snapshot sessionState
+		err := p.store.update(ctx, providerSessionID, func(state *sessionState) error {
+			state.flushQueued()
+			snapshot = *state
+			snapshot.Messages = append([]chatMessage(nil), state.Messages...)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("openai: load session: %w", err)
+		}
+
+		if len(snapshot.PendingToolCalls) > 0 {
+			return p.requireToolResults(pendingToolCalls(&snapshot), onEvent)
+		}
+
+		if !snapshot.awaitingModel() {
+			return onEvent(agents.ProviderEvent{
+				Type:  agents.ProviderEventTurnCompleted,
+				Model: p.modelName(snapshot.Model),
+				Usage: snapshot.Usage.tokenUsage(),
+			})
+		}
+
+		result, err := p.complete(ctx, &snapshot, onEvent)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			var apiErr *apiError
+			if errors.As(err, &apiErr) || errors.Is(err, errStreamFailed) {
+				return onEvent(agents.ProviderEvent{
+					Type:         agents.ProviderEventSessionFailed,
+					ErrorMessage: redactSensitive(err.Error()),
+				})
+			}
+			return err
+		}
+
+		applied, err := p.applyCompletion(ctx, providerSessionID, &snapshot, result)
+		if err != nil {
+			return fmt.Errorf("openai: save completion: %w", err)
+		}
+		if !applied {
+			log.WithField("provider_session_id", providerSessionID).Info("openai: dropping completion for interrupted turn")
+			return nil
+		}
+
+		if len(result.ToolCalls) > 0 {
+			return p.requireToolResults(result.ToolCalls, onEvent)
+		}
+	}
+}
+
+func (p *Provider) complete(ctx context.Context, state *sessionState, onEvent func(agents.ProviderEvent) error) (*completion, error) {
+	messages := make([]chatMessage, 0, len(state.Messages)+1)
+	messages = append(messages, chatMessage{Role: roleSystem, Content: textContent(p.systemPrompt)})
+	messages = append(messages, state.Messages...)
+
+	body, err := p.client.openCompletionStream(ctx, chatCompletionRequest{
+		Model:         p.model,
+		Messages:      messages,
+		Tools:         p.tools,
+		Stream:        true,
+		StreamOptions: &chatStreamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	messageID := "msg_" + uuid.NewString()
+	result, err := readCompletion(ctx, body, func(text string) error {
+		return onEvent(assistantMessageEvent(messageID, text))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result.ToolCalls {
+		if result.ToolCalls[i].ID == "" {
+			result.ToolCalls[i].ID = "call_" + uuid.NewString()
+		}
+	}
+
+	if result.Text != "" {
+		if err := onEvent(assistantMessageEvent(messageID, result.Text)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// applyCompletion appends the assistant message at the position the request
+// was built from. User messages that arrived while the model was answering
+// move back to the queue so they follow the turn instead of splitting an
+// assistant message from its tool results.
+func (p *Provider) applyCompletion(ctx context.Context, providerSessionID string, snapshot *sessionState, result *completion) (bool, error) {
+	applied := false
+	err := p.store.update(ctx, providerSessionID, func(state *sessionState) error {
+		if state.Generation != snapshot.Generation || len(state.Messages) < len(snapshot.Messages) {
+			return nil
+		}
+
+		arrived := append([]chatMessage(nil), state.Messages[len(snapshot.Messages):]...)
+		state.Messages = append(state.Messages[:len(snapshot.Messages)], result.message())
+		state.Queued = append(arrived, state.Queued...)
+		state.PendingToolCalls = nil
+		for _, call := range result.ToolCalls {
+			state.PendingToolCalls = append(state.PendingToolCalls, call.ID)
+		}
+		if result.Model != "" {
+			state.Model = result.Model
+		}
+		state.Usage.add(result.Usage)
+		applied = true
+		return nil
+	})
+	return applied, err
+}
+
+func (p *Provider) requireToolResults(calls []chatToolCall, onEvent func(agents.ProviderEvent) error) error {
+	ids := make([]string, 0, len(calls))
+	for _, call := range calls {
+		if err := onEvent(customToolUseEvent(call)); err != nil {
+			return err
+		}
+		ids = append(ids, call.ID)
+	}
+
+	return onEvent(agents.ProviderEvent{
+		Type:               agents.ProviderEventCustomToolResultsRequired,
+		CustomToolEventIDs: ids,
+	})
+}
+
+func (p *Provider) modelName(reported string) string {
+	if reported != "" {
+		return reported
+	}
+	return p.model
+}
+
+func (p *Provider) DeleteSession(ctx context.Context, providerSessionID string) error {
+	if providerSessionID == "" {
+		return fmt.Errorf("openai: provider session id is required")
+	}
+	if err := p.store.delete(ctx, providerSessionID); err != nil {
+		return fmt.Errorf("openai: delete session: %w", err)
+	}
+	return nil
+}
+
+func (p *Provider) RetrieveSessionUsage(ctx context.Context, providerSessionID string) (*agents.TokenUsage, error) {
+	state, err := p.store.load(ctx, providerSessionID)
+	if err != nil {
+		return nil, err
+	}
+	return state.Usage.tokenUsage(), nil
+}
+
+// pendingToolCalls returns the calls of the last assistant message that
+// still wait for results, so a restarted stream can announce them again.
+func pendingToolCalls(state *sessionState) []chatToolCall {
+	pending := make(map[string]struct{}, len(state.PendingToolCalls))
+	for _, id := range state.PendingToolCalls {
+		pending[id] = struct{}{}
+	}
+
+	for i := len(state.Messages) - 1; i >= 0; i-- {
+		msg := state.Messages[i]
+		if msg.Role != roleAssistant || len(msg.ToolCalls) == 0 {
+			continue
+		}
+		calls := make([]chatToolCall, 0, len(pending))
+		for _, call := range msg.ToolCalls {
+			if _, ok := pending[call.ID]; ok {
+				calls = append(calls, call)
+			}
+		}
+		return calls
+	}
+	return nil
+}
+
+func enqueueUserMessage(state *sessionState, msg chatMessage) {
+	if len(state.PendingToolCalls) > 0 {
+		state.Queued = append(state.Queued, msg)
+		return
+	}
+	state.Messages = append(state.Messages, msg)
+}
+
+func defaultTools() []chatTool {
+	definitions := agenttools.DefaultDefinitions()
+	tools := make([]chatTool, 0, len(definitions))
+	for _, definition := range definitions {
+		tools = append(tools, chatTool{
+			Type: "function",
+			Function: chatFunction{
+				Name:        definition.Name(),
+				Description: definition.Description(),
+				Parameters:  definition.InputSchema().Map(),
+			},
+		})
+	}
+	return tools
+}
+
+func withPreamble(message, preamble string) string {
+	if preamble == "" {
+		return message
+	}
+	return preamble + "\n\n" + message
+}
@@ -0,0 +1,162 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/agents"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+// sessionState is the locally managed conversation. Chat-completions
+// endpoints are stateless, so everything the model needs for the next
+// request lives here.
+type sessionState struct {
+	// Messages is the transcript sent to the model, without the system
+	// prompt (that is prepended per request so prompt updates apply to
+	// running sessions).
+	Messages []chatMessage `json:"messages"`
+	// Queued holds user messages that arrived while a turn was waiting on
+	// tool results. They join the transcript at the next turn boundary so
+	// tool results always follow the assistant message that asked for them.
+	Queued []chatMessage `json:"queued,omitempty"`
+	// PendingToolCalls are the tool call ids of the last assistant message
+	// that still need results.
+	PendingToolCalls []string `json:"pending_tool_calls,omitempty"`
+	// Generation is bumped on interrupt so a completion that finishes
+	// after the user pressed Stop is discarded instead of appended.
+	Generation int64        `json:"generation"`
+	Model      string       `json:"model,omitempty"`
+	Usage      sessionUsage `json:"usage"`
+}
+
+type sessionUsage struct {
+	InputTokens     int64 `json:"input_tokens"`
+	OutputTokens    int64 `json:"output_tokens"`
+	CacheReadTokens int64 `json:"cache_read_tokens"`
+	TotalTokens     int64 `json:"total_tokens"`
+}
+
+func (u sessionUsage) tokenUsage() *agents.TokenUsage {
+	if u.TotalTokens == 0 {
+		return nil
+	}
+	return &agents.TokenUsage{
+		InputTokens:     u.InputTokens,
+		OutputTokens:    u.OutputTokens,
+		CacheReadTokens: u.CacheReadTokens,
+		TotalTokens:     u.TotalTokens,
+	}
+}
+
+// awaitingModel reports whether the transcript ends with something the
+// model has not answered yet.
+func (s *sessionState) awaitingModel() bool {
+	if len(s.Messages) == 0 {
+		return false
+	}
+	role := s.Messages[len(s.Messages)-1].Role
+	return role == roleUser || role == roleTool
+}
+
+func (s *sessionState) flushQueued() {
+	if len(s.PendingToolCalls) > 0 || len(s.Queued) == 0 {
+		return
+	}
+	s.Messages = append(s.Messages, s.Queued...)
+	s.Queued = nil
+}
+
+type sessionStore interface {
+	create(ctx context.Context, state *sessionState) (string, error)
+	load(ctx context.Context, id string) (*sessionState, error)
+	update(ctx context.Context, id string, fn func(*sessionState) error) error
+	delete(ctx context.Context, id string) error
+}
+
+// dbSessionStore keeps session state in agent_local_sessions so the API
+// server and the stream worker can run in different processes.
+type dbSessionStore struct{}
+
+func (dbSessionStore) create(_ context.Context, state *sessionState) (string, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("encode session state: %w", err)
+	}
+
+	session, err := models.CreateAgentLocalSession(ProviderName, data)
+	if err != nil {
+		return "", err
+	}
+	return session.ID.String(), nil
+}
+
+func (dbSessionStore) load(_ context.Context, id string) (*sessionState, error) {
+	sessionID, err := parseSessionID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := models.FindAgentLocalSession(sessionID)
+	if err != nil {
+		return nil, mapStoreError(err)
+	}
+
+	return decodeSessionState(session.State)
+}
+
+func (dbSessionStore) update(_ context.Context, id string, fn func(*sessionState) error) error {
+	sessionID, err := parseSessionID(id)
+	if err != nil {
+		return err
+	}
+
+	err = models.UpdateAgentLocalSession(sessionID, func(data []byte) ([]byte, error) {
+		state, err := decodeSessionState(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := fn(state); err != nil {
+			return nil, err
+		}
+		return json.Marshal(state)
+	})
+	return mapStoreError(err)
+}
+
+func (dbSessionStore) delete(_ context.Context, id string) error {
+	sessionID, err := parseSessionID(id)
+	if err != nil {
+		return err
+	}
+	return models.DeleteAgentLocalSession(sessionID)
+}
+
+func parseSessionID(id string) (uuid.UUID, error) {
+	sessionID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid session id %q", agents.ErrProviderSessionUnavailable, id)
+	}
+	return sessionID, nil
+}
+
+func decodeSessionState(data []byte) (*sessionState, error) {
+	state := &sessionState{}
+	if len(data) == 0 {
+		return state, nil
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("decode session state: %w", err)
+	}
+	return state, nil
+}
+
+func mapStoreError(err error) error {
+	if errors.Is(err, models.ErrAgentLocalSessionNotFound) {
+		return fmt.Errorf("%w: %w", agents.ErrProviderSessionUnavailable, err)
+	}
+	return err
+}
@@ -33,18 +33,43 @@ func (a *GatewayAuthorizer) AuthorizeHTTP(
 	route HTTPRoute,
 	pathParams map[string]string,
 ) (context.Context, error) {
-	rule, requiresAuth := a.rules[route]
-	if !requiresAuth {
+	if _, requiresAuth := a.rules[route]; !requiresAuth {
 		return withAuthorizedContext(ctx, pathParams, ""), nil
 	}
 
-	userID := firstHTTPHeader(r, "x-user-id")
+	return a.AuthorizeRoute(
+		ctx,
+		route,
+		firstHTTPHeader(r, "x-user-id"),
+		firstHTTPHeader(r, "x-organization-id"),
+		firstHTTPHeader(r, "x-token-scopes"),
+		pathParams,
+	)
+}
+
+// AuthorizeRoute applies the rule registered for route to a caller that
+// reaches the same actions without going through the gRPC gateway, such as
+// the MCP endpoint. tokenScopes is the JSON-encoded scope list of a scoped
+// token, or empty for unscoped credentials.
+func (a *GatewayAuthorizer) AuthorizeRoute(
+	ctx context.Context,
+	route HTTPRoute,
+	userID string,
+	organizationID string,
+	tokenScopes string,
+	pathParams map[string]string,
+) (context.Context, error) {
+	rule, ok := a.rules[route]
+	if !ok {
+		log.Errorf("No authorization rule registered for %s", route.String())
+		return nil, status.Error(codes.NotFound, "Not found")
+	}
+
 	if userID == "" {
 		log.Errorf("User not found in request headers")
 		return nil, status.Error(codes.NotFound, "Not found")
 	}
 
-	organizationID := firstHTTPHeader(r, "x-organization-id")
 	if organizationID == "" {
 		log.Errorf("Organization not found in request headers")
 		return nil, status.Error(codes.NotFound, "Not found")
@@ -60,7 +85,7 @@ func (a *GatewayAuthorizer) AuthorizeHTTP(
 		return nil, status.Error(codes.NotFound, "Not found")
 	}
 
-	if !hasRequiredScopedTokenPermissionForScopes(firstHTTPHeader(r, "x-token-scopes"), pathParams, rule) {
+	if !hasRequiredScopedTokenPermissionForScopes(tokenScopes, pathParams, rule) {
 		log.Warnf(
 			"Scoped token for user %s is missing required permission %s:%s",
 			userID,
@@ -133,6 +133,15 @@ func Test__AuthService_OrganizationPermissions(t *testing.T) {
 		allowed, err = r.AuthService.CheckOrganizationPermission(context.Background(), viewerID, orgID, "notifications", "update")
 		require.NoError(t, err)
 		assert.True(t, allowed)
+
+		// Organization memory: viewers read it, but cannot write it.
+		allowed, err = r.AuthService.CheckOrganizationPermission(context.Background(), viewerID, orgID, "memory", "read")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = r.AuthService.CheckOrganizationPermission(context.Background(), viewerID, orgID, "memory", "create")
+		require.NoError(t, err)
+		assert.False(t, allowed)
 	})
 }
 
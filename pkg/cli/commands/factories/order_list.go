@@ -17,6 +17,8 @@ type orderListCommand struct {
 	states     *[]string
 	results    *[]string
 	unassigned *bool
+	template   *string
+	fields     *[]string
 }
 
 func (c *orderListCommand) Execute(ctx core.CommandContext) error {
@@ -53,6 +55,19 @@ func (c *orderListCommand) Execute(ctx core.CommandContext) error {
 		request = request.Unassigned(true)
 	}
 
+	if template := strings.TrimSpace(stringValue(c.template)); template != "" {
+		request = request.TemplateId(template)
+	}
+
+	if c.fields != nil && len(*c.fields) > 0 {
+		for _, field := range *c.fields {
+			if name, _, ok := strings.Cut(field, "="); !ok || strings.TrimSpace(name) == "" {
+				return fmt.Errorf("invalid --field %q: expected name=value", field)
+			}
+		}
+		request = request.Fields(*c.fields)
+	}
+
 	response, _, err := request.Execute()
 	if err != nil {
 		return err
@@ -131,6 +131,41 @@ func TestOrderListCommand_Filters(t *testing.T) {
 	assert.Equal(t, []string{"true"}, seenQuery["unassigned"])
 }
 
+func TestOrderListCommand_TemplateFieldFilters(t *testing.T) {
+	var seenQuery map[string][]string
+	server := newOrderListServer(t, func(t *testing.T, query map[string][]string) {
+		seenQuery = query
+	})
+
+	ctx, _ := cli.NewCommandContext(t, server, "text")
+
+	factory := testOrderListFactoryID
+	template := "33333333-3333-3333-3333-333333333333"
+	fields := []string{"environment=production", "risk=high"}
+
+	err := (&orderListCommand{
+		factory:  &factory,
+		template: &template,
+		fields:   &fields,
+	}).Execute(ctx)
+	require.NoError(t, err)
+
+	require.NotNil(t, seenQuery)
+	assert.Equal(t, []string{template}, seenQuery["templateId"])
+	assert.ElementsMatch(t, fields, seenQuery["fields"])
+}
+
+func TestOrderListCommand_InvalidFieldFilter(t *testing.T) {
+	server := newOrderListServer(t, nil)
+	ctx, _ := cli.NewCommandContext(t, server, "text")
+
+	factory := testOrderListFactoryID
+	fields := []string{"environment"}
+
+	err := (&orderListCommand{factory: &factory, fields: &fields}).Execute(ctx)
+	require.ErrorContains(t, err, `invalid --field "environment"`)
+}
+
 func TestOrderListCommand_DefaultsToOpenState(t *testing.T) {
 	var seenQuery map[string][]string
 	server := newOrderListServer(t, func(t *testing.T, query map[string][]string) {
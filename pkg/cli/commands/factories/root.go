@@ -152,6 +152,8 @@ func newOrdersCommand(options core.BindOptions) *cobra.Command {
 		orderListStates     []string
 		orderListResults    []string
 		orderListUnassigned bool
+		orderListTemplate   string
+		orderListFields     []string
 	)
 
 	orderListCmd := &cobra.Command{
@@ -170,11 +172,15 @@ completed, rejected, failed).
 By default, only open work orders are shown. Pass --state all to see
 draft, open, and closed work orders.
 
+--template filters by work order template UUID. --field filters by a
+template field value in name=value form (repeatable, all must match).
+
 Examples:
   superplane factory orders list --factory shipping --state open
   superplane factory orders list --assignees alice@example.com --result failed
   superplane factory orders list --unassigned
-  superplane factory orders list --state all`,
+  superplane factory orders list --state all
+  superplane factory orders list --field environment=production --field risk=high`,
 		Args: cobra.NoArgs,
 	}
 	orderListCmd.Flags().StringVar(&orderListFactory, "factory", "", "factory name or UUID (default: active factory)")
@@ -182,12 +188,16 @@ Examples:
 	orderListCmd.Flags().StringSliceVar(&orderListStates, "state", nil, "filter by work order state (repeatable, e.g. open or STATE_OPEN); defaults to open when omitted; pass 'all' to include every state")
 	orderListCmd.Flags().StringSliceVar(&orderListResults, "result", nil, "filter by work order result (repeatable, e.g. completed or RESULT_COMPLETED)")
 	orderListCmd.Flags().BoolVar(&orderListUnassigned, "unassigned", false, "only show work orders with no assignees")
+	orderListCmd.Flags().StringVar(&orderListTemplate, "template", "", "filter by work order template UUID")
+	orderListCmd.Flags().StringArrayVar(&orderListFields, "field", nil, "filter by template field value as name=value (repeatable)")
 	core.Bind(orderListCmd, &orderListCommand{
 		factory:    &orderListFactory,
 		assignees:  &orderListAssignees,
 		states:     &orderListStates,
 		results:    &orderListResults,
 		unassigned: &orderListUnassigned,
+		template:   &orderListTemplate,
+		fields:     &orderListFields,
 	}, options)
 
 	var (
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/cli/core"
+	"github.com/superplanehq/superplane/pkg/openapi_client"
+)
+
+type addCommand struct {
+	namespace *string
+	values    *string
+}
+
+func (c *addCommand) Execute(ctx core.CommandContext) error {
+	namespace := strings.TrimPrefix(strings.TrimSpace(*c.namespace), "org:")
+	if namespace == "" {
+		return fmt.Errorf("--namespace is required")
+	}
+
+	values := map[string]any{}
+	if err := json.Unmarshal([]byte(*c.values), &values); err != nil {
+		return fmt.Errorf("--values must be a JSON object: %w", err)
+	}
+
+	organizationID, err := core.ResolveOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := openapi_client.OrganizationsCreateOrganizationMemoryBody{}
+	body.SetNamespace(namespace)
+	body.SetValues(values)
+
+	response, _, err := ctx.API.OrganizationAPI.
+		OrganizationsCreateOrganizationMemory(ctx.Context, organizationID).
+		Body(body).
+		Execute()
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Renderer.IsText() {
+		return ctx.Renderer.Render(response.GetMemory())
+	}
+
+	memory := response.GetMemory()
+	return ctx.Renderer.RenderText(func(stdout io.Writer) error {
+		_, err := fmt.Fprintf(stdout, "Memory added to org:%s: %s\n", memory.GetNamespace(), memory.GetId())
+		return err
+	})
+}
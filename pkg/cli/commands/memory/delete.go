@@ -0,0 +1,33 @@
+package memory
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/superplanehq/superplane/pkg/cli/core"
+)
+
+type deleteCommand struct{}
+
+func (c *deleteCommand) Execute(ctx core.CommandContext) error {
+	organizationID, err := core.ResolveOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	response, _, err := ctx.API.OrganizationAPI.
+		OrganizationsDeleteOrganizationMemory(ctx.Context, organizationID, ctx.Args[0]).
+		Execute()
+	if err != nil {
+		return err
+	}
+
+	if ctx.Renderer.IsText() {
+		return ctx.Renderer.RenderText(func(stdout io.Writer) error {
+			_, err := fmt.Fprintf(stdout, "Memory deleted: %s\n", ctx.Args[0])
+			return err
+		})
+	}
+
+	return ctx.Renderer.Render(response)
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/cli/core"
+	"github.com/superplanehq/superplane/pkg/openapi_client"
+)
+
+type listCommand struct {
+	namespace *string
+}
+
+func (c *listCommand) Execute(ctx core.CommandContext) error {
+	organizationID, err := core.ResolveOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	request := ctx.API.OrganizationAPI.OrganizationsListOrganizationMemories(ctx.Context, organizationID)
+	if namespace := c.namespaceValue(); namespace != "" {
+		request = request.Namespace(namespace)
+	}
+
+	response, _, err := request.Execute()
+	if err != nil {
+		return err
+	}
+
+	memories := response.GetItems()
+	if !ctx.Renderer.IsText() {
+		return ctx.Renderer.Render(memories)
+	}
+
+	return ctx.Renderer.RenderText(func(stdout io.Writer) error {
+		return renderMemoryListText(stdout, memories)
+	})
+}
+
+func (c *listCommand) namespaceValue() string {
+	if c.namespace == nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.TrimSpace(*c.namespace), "org:")
+}
+
+func renderMemoryListText(stdout io.Writer, memories []openapi_client.OrganizationsOrganizationMemory) error {
+	if len(memories) == 0 {
+		_, err := fmt.Fprintln(stdout, "No memory records found.")
+		return err
+	}
+
+	writer := tabwriter.NewWriter(stdout, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "ID\tNAMESPACE\tVALUES\tWRITTEN BY\tUPDATED AT")
+
+	for _, memory := range memories {
+		values, err := json.Marshal(memory.GetValues())
+		if err != nil {
+			return fmt.Errorf("failed to format memory values: %w", err)
+		}
+
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\n",
+			memory.GetId(),
+			memory.GetNamespace(),
+			string(values),
+			formatWriter(memory.GetUpdatedBy()),
+			memory.GetUpdatedAt().Format(time.RFC3339),
+		)
+	}
+
+	return writer.Flush()
+}
+
+func formatWriter(writer openapi_client.OrganizationsOrganizationMemoryWriter) string {
+	if writer.GetCanvasId() != "" {
+		return "app " + nameOrID(writer.GetCanvasName(), writer.GetCanvasId())
+	}
+
+	if writer.GetUserId() != "" {
+		return "user " + nameOrID(writer.GetUserName(), writer.GetUserId())
+	}
+
+	return "-"
+}
+
+// nameOrID falls back to the ID for writers
+// whose name is not known anymore.
+func nameOrID(name, id string) string {
+	if name != "" {
+		return name
+	}
+
+	return id
+}
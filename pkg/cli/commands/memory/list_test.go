@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/test/support/cli"
+)
+
+const organizationMemoriesResponse = `{
+	"items": [
+		{
+			"id": "memory-001",
+			"namespace": "preview-environments",
+			"values": {
+				"pr": "42",
+				"url": "https://pr-42.preview.example.com"
+			},
+			"createdBy": {"canvasId": "canvas-1", "canvasName": "previews"},
+			"updatedBy": {"canvasId": "canvas-2", "canvasName": "cleanup"},
+			"createdAt": "2026-09-20T10:00:00Z",
+			"updatedAt": "2026-09-20T10:15:00Z"
+		}
+	]
+}`
+
+func newOrganizationMemoryServer(t *testing.T, expectedNamespace string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+
+		switch r.URL.Path {
+		case "/api/v1/me":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"user":{"id":"user-1","organizationId":"org-123"}}`))
+		case "/api/v1/organizations/org-123/memory":
+			require.Equal(t, expectedNamespace, r.URL.Query().Get("namespace"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(organizationMemoriesResponse))
+		case "/api/v1/organizations/org-123/memory/namespaces":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{
+				"namespaces": [
+					{
+						"namespace": "preview-environments",
+						"recordCount": "1",
+						"lastWrittenAt": "2026-09-20T10:15:00Z",
+						"writers": [
+							{"canvasId": "canvas-1", "canvasName": "previews"},
+							{"canvasId": "canvas-2"}
+						]
+					}
+				]
+			}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestListCommandReturnsJSON(t *testing.T) {
+	server := newOrganizationMemoryServer(t, "preview-environments")
+	ctx, stdout := cli.NewCommandContext(t, server, "json")
+
+	namespace := "org:preview-environments"
+	err := (&listCommand{namespace: &namespace}).Execute(ctx)
+	require.NoError(t, err)
+
+	var result []map[string]any
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+	require.Len(t, result, 1)
+	require.Equal(t, "memory-001", result[0]["id"])
+	require.Equal(t, "preview-environments", result[0]["namespace"])
+}
+
+func TestListCommandReturnsText(t *testing.T) {
+	server := newOrganizationMemoryServer(t, "")
+	ctx, stdout := cli.NewCommandContext(t, server, "text")
+
+	err := (&listCommand{}).Execute(ctx)
+	require.NoError(t, err)
+	require.Contains(t, stdout.String(), "WRITTEN BY")
+	require.Contains(t, stdout.String(), "memory-001")
+	require.Contains(t, stdout.String(), "preview-environments")
+	require.Contains(t, stdout.String(), "app cleanup")
+}
+
+func TestNamespacesCommandReturnsText(t *testing.T) {
+	server := newOrganizationMemoryServer(t, "")
+	ctx, stdout := cli.NewCommandContext(t, server, "text")
+
+	err := (&namespacesCommand{}).Execute(ctx)
+	require.NoError(t, err)
+	require.Contains(t, stdout.String(), "org:preview-environments")
+	require.Contains(t, stdout.String(), "previews, canvas-2")
+}
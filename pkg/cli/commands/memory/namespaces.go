@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/cli/core"
+	"github.com/superplanehq/superplane/pkg/openapi_client"
+)
+
+type namespacesCommand struct{}
+
+func (c *namespacesCommand) Execute(ctx core.CommandContext) error {
+	organizationID, err := core.ResolveOrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	response, _, err := ctx.API.OrganizationAPI.
+		OrganizationsListOrganizationMemoryNamespaces(ctx.Context, organizationID).
+		Execute()
+	if err != nil {
+		return err
+	}
+
+	namespaces := response.GetNamespaces()
+	if !ctx.Renderer.IsText() {
+		return ctx.Renderer.Render(namespaces)
+	}
+
+	return ctx.Renderer.RenderText(func(stdout io.Writer) error {
+		return renderNamespacesText(stdout, namespaces)
+	})
+}
+
+func renderNamespacesText(stdout io.Writer, namespaces []openapi_client.OrganizationsOrganizationMemoryNamespace) error {
+	if len(namespaces) == 0 {
+		_, err := fmt.Fprintln(stdout, "No memory namespaces found.")
+		return err
+	}
+
+	writer := tabwriter.NewWriter(stdout, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "NAMESPACE\tRECORDS\tLAST WRITTEN\tAPPS")
+
+	for _, namespace := range namespaces {
+		apps := make([]string, 0, len(namespace.GetWriters()))
+		for _, writer := range namespace.GetWriters() {
+			apps = append(apps, nameOrID(writer.GetCanvasName(), writer.GetCanvasId()))
+		}
+
+		if len(apps) == 0 {
+			apps = append(apps, "-")
+		}
+
+		_, _ = fmt.Fprintf(
+			writer,
+			"org:%s\t%s\t%s\t%s\n",
+			namespace.GetNamespace(),
+			namespace.GetRecordCount(),
+			namespace.GetLastWrittenAt().Format(time.RFC3339),
+			strings.Join(apps, ", "),
+		)
+	}
+
+	return writer.Flush()
+}
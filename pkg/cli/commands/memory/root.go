@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/superplanehq/superplane/pkg/cli/core"
+)
+
+func NewCommand(options core.BindOptions) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "memory",
+		Short: "Manage organization memory",
+		Long: `Manage memory shared by every app of the organization.
+
+Apps read and write these namespaces with the org: prefix,
+for example org:preview-environments.`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List organization memory records",
+		Args:  cobra.NoArgs,
+	}
+	var listNamespace string
+	listCmd.Flags().StringVar(&listNamespace, "namespace", "", "filter memory records by namespace")
+	core.Bind(listCmd, &listCommand{namespace: &listNamespace}, options)
+
+	namespacesCmd := &cobra.Command{
+		Use:   "namespaces",
+		Short: "List organization memory namespaces and the apps that write to them",
+		Args:  cobra.NoArgs,
+	}
+	core.Bind(namespacesCmd, &namespacesCommand{}, options)
+
+	addCmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a record to organization memory",
+		Args:  cobra.NoArgs,
+	}
+	var addNamespace string
+	var addValues string
+	addCmd.Flags().StringVar(&addNamespace, "namespace", "", "memory namespace")
+	addCmd.Flags().StringVar(&addValues, "values", "", `record values as a JSON object, e.g. '{"pr":"42"}'`)
+	_ = addCmd.MarkFlagRequired("namespace")
+	_ = addCmd.MarkFlagRequired("values")
+	core.Bind(addCmd, &addCommand{namespace: &addNamespace, values: &addValues}, options)
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <memory-id>",
+		Short: "Delete a record from organization memory",
+		Args:  cobra.ExactArgs(1),
+	}
+	core.Bind(deleteCmd, &deleteCommand{}, options)
+
+	root.AddCommand(listCmd)
+	root.AddCommand(namespacesCmd)
+	root.AddCommand(addCmd)
+	root.AddCommand(deleteCmd)
+
+	return root
+}
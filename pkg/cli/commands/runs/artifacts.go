@@ -0,0 +1,196 @@
+package runs
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/cli/core"
+	"github.com/superplanehq/superplane/pkg/openapi_client"
+)
+
+type ListRunArtifactsCommand struct {
+	AppID *string
+}
+
+func (c *ListRunArtifactsCommand) Execute(ctx core.CommandContext) error {
+	appID, err := core.ResolveAppID(ctx, *c.AppID)
+	if err != nil {
+		return err
+	}
+
+	runID := ctx.Args[0]
+	response, _, err := ctx.API.CanvasRunAPI.
+		CanvasesDescribeRun(ctx.Context, appID, runID).
+		Execute()
+	if err != nil {
+		return err
+	}
+
+	run, ok := response.GetRunOk()
+	if !ok || run == nil {
+		return fmt.Errorf("run %q not found", runID)
+	}
+
+	artifacts := run.GetArtifacts()
+	if !ctx.Renderer.IsText() {
+		return ctx.Renderer.Render(describeRunArtifacts(artifacts))
+	}
+
+	return ctx.Renderer.RenderText(func(stdout io.Writer) error {
+		if len(artifacts) == 0 {
+			_, err := fmt.Fprintln(stdout, "No artifacts found.")
+			return err
+		}
+
+		writer := tabwriter.NewWriter(stdout, 0, 8, 2, ' ', 0)
+		_, _ = fmt.Fprintln(writer, "ID\tNAME\tNODE_ID\tSIZE\tCREATED\tEXPIRES")
+		for _, artifact := range artifacts {
+			_, _ = fmt.Fprintf(
+				writer,
+				"%s\t%s\t%s\t%s\t%s\t%s\n",
+				artifact.GetId(),
+				artifact.GetName(),
+				artifact.GetNodeId(),
+				formatArtifactSize(artifact.GetSizeBytes()),
+				formatRelativeTime(artifact.GetCreatedAt()),
+				formatTimestamp(artifact.GetExpiresAt()),
+			)
+		}
+		return writer.Flush()
+	})
+}
+
+func describeRunArtifacts(artifacts []openapi_client.CanvasesCanvasRunArtifact) []map[string]any {
+	described := make([]map[string]any, len(artifacts))
+	for i, artifact := range artifacts {
+		described[i] = map[string]any{
+			"id":          artifact.GetId(),
+			"name":        artifact.GetName(),
+			"nodeId":      artifact.GetNodeId(),
+			"executionId": artifact.GetExecutionId(),
+			"sizeBytes":   artifact.GetSizeBytes(),
+			"sha256":      artifact.GetSha256(),
+			"createdAt":   formatTimestamp(artifact.GetCreatedAt()),
+			"expiresAt":   formatTimestamp(artifact.GetExpiresAt()),
+		}
+	}
+
+	return described
+}
+
+func formatArtifactSize(value string) string {
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return "-"
+	}
+
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+type DownloadRunArtifactCommand struct {
+	AppID *string
+	File  *string
+}
+
+// Execute saves the artifact tarball. The content is served outside the
+// gRPC gateway, so it is fetched with a plain HTTP request.
+func (c *DownloadRunArtifactCommand) Execute(ctx core.CommandContext) error {
+	appID, err := core.ResolveAppID(ctx, *c.AppID)
+	if err != nil {
+		return err
+	}
+
+	runID := ctx.Args[0]
+	artifactID := ctx.Args[1]
+
+	config := ctx.API.GetConfig()
+	if config == nil {
+		return fmt.Errorf("api client config is required")
+	}
+
+	baseURL, err := config.ServerURLWithContext(ctx.Context, "CanvasRunAPIService.CanvasesDescribeRun")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(baseURL) == "" {
+		return fmt.Errorf("api_url is required")
+	}
+
+	endpoint := fmt.Sprintf(
+		"%s/api/v1/canvases/%s/runs/%s/artifacts/%s/content",
+		strings.TrimRight(baseURL, "/"),
+		url.PathEscape(appID),
+		url.PathEscape(runID),
+		url.PathEscape(artifactID),
+	)
+
+	request, err := http.NewRequestWithContext(ctx.Context, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if authorization := strings.TrimSpace(config.DefaultHeader["Authorization"]); authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Minute}
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		if trimmed := strings.TrimSpace(string(message)); trimmed != "" {
+			return fmt.Errorf("%s", trimmed)
+		}
+		return fmt.Errorf("failed to download artifact: %s", response.Status)
+	}
+
+	output := strings.TrimSpace(*c.File)
+	if output == "" {
+		output = artifactID + ".tar.gz"
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+
+	written, err := io.Copy(file, response.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+
+	if !ctx.Renderer.IsText() {
+		return ctx.Renderer.Render(map[string]any{"path": output, "sizeBytes": written})
+	}
+
+	return ctx.Renderer.RenderText(func(stdout io.Writer) error {
+		_, err := fmt.Fprintf(stdout, "Saved artifact to %s (%s)\n", output, formatArtifactSize(strconv.FormatInt(written, 10)))
+		return err
+	})
+}
@@ -11,6 +11,7 @@ func NewCommand(options core.BindOptions) *cobra.Command {
 	var before string
 	var states []string
 	var results []string
+	var file string
 
 	root := &cobra.Command{
 		Use:     "runs",
@@ -46,8 +47,32 @@ func NewCommand(options core.BindOptions) *cobra.Command {
 		AppID: &appID,
 	}, options)
 
+	artifactsCmd := &cobra.Command{
+		Use:   "artifacts [run-id]",
+		Short: "List artifacts uploaded by runner tasks in a run",
+		Args:  cobra.ExactArgs(1),
+	}
+	core.BindAppIDFlag(artifactsCmd, &appID, "app ID")
+	core.Bind(artifactsCmd, &ListRunArtifactsCommand{
+		AppID: &appID,
+	}, options)
+
+	downloadArtifactCmd := &cobra.Command{
+		Use:   "download-artifact [run-id] [artifact-id]",
+		Short: "Download a run artifact as a .tar.gz file",
+		Args:  cobra.ExactArgs(2),
+	}
+	core.BindAppIDFlag(downloadArtifactCmd, &appID, "app ID")
+	downloadArtifactCmd.Flags().StringVarP(&file, "file", "f", "", "file to write (defaults to <artifact-id>.tar.gz)")
+	core.Bind(downloadArtifactCmd, &DownloadRunArtifactCommand{
+		AppID: &appID,
+		File:  &file,
+	}, options)
+
 	root.AddCommand(listCmd)
 	root.AddCommand(describeCmd)
+	root.AddCommand(artifactsCmd)
+	root.AddCommand(downloadArtifactCmd)
 
 	return root
 }
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/superplanehq/superplane/pkg/mcp"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve SuperPlane tools to an MCP client over stdio",
+	Long: `Serve SuperPlane tools to a Model Context Protocol client over stdio.
+
+The command relays newline-delimited JSON-RPC messages between stdin/stdout
+and the /api/v1/mcp endpoint of the current context, authenticating with its
+API token. Configure your MCP client to launch "superplane mcp".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token := GetAPIToken()
+		if token == "" {
+			return fmt.Errorf("no API token configured for the current context")
+		}
+
+		bridge := &mcp.StdioBridge{
+			Endpoint: strings.TrimRight(GetAPIURL(), "/") + "/api/v1/mcp",
+			Token:    token,
+			Client: &http.Client{
+				CheckRedirect: methodSafeRedirectPolicy(),
+			},
+		}
+
+		return bridge.Run(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(mcpCmd)
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/superplanehq/superplane/pkg/runneragent"
+)
+
+var runnerConfig runneragent.Config
+
+var runnerCmd = &cobra.Command{
+	Use:   "runner",
+	Short: "Run a self-hosted runner for the embedded task broker",
+	Long: `Run a self-hosted runner that executes runner component tasks.
+
+The runner registers with the task broker embedded in SuperPlane under a
+fleet ID, claims queued tasks for that fleet and runs them on this host, or
+in a container when a task uses the docker execution mode. Live logs are
+streamed back to SuperPlane while the task runs.
+
+Add the fleet ID to TASK_BROKER_SELF_HOSTED_FLEETS on the server to make it
+selectable as a machine type.`,
+	Args: cobra.NoArgs,
+	// The runner authenticates with the broker, not with a CLI context.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if !Verbose {
+			log.SetOutput(io.Discard)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if runnerConfig.RegistrationToken == "" {
+			runnerConfig.RegistrationToken = os.Getenv("SUPERPLANE_RUNNER_REGISTRATION_TOKEN")
+		}
+
+		agent, err := runneragent.New(runnerConfig)
+		if err != nil {
+			return err
+		}
+
+		// Runner progress is the point of this command, so always log it.
+		log.SetOutput(cmd.ErrOrStderr())
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		return agent.Run(ctx)
+	},
+}
+
+func init() {
+	runnerCmd.Flags().StringVar(&runnerConfig.BrokerURL, "broker-url", "", "task broker URL, for example https://superplane.example.com/broker")
+	runnerCmd.Flags().StringVar(&runnerConfig.RegistrationToken, "registration-token", "", "runner registration token (default $SUPERPLANE_RUNNER_REGISTRATION_TOKEN)")
+	runnerCmd.Flags().StringVar(&runnerConfig.FleetID, "fleet", "", "fleet ID this runner claims tasks for")
+	runnerCmd.Flags().StringVar(&runnerConfig.Name, "name", "", "runner name shown in the broker (default hostname)")
+	runnerCmd.Flags().IntVar(&runnerConfig.Concurrency, "concurrency", 1, "number of tasks to run in parallel")
+	runnerCmd.Flags().StringVar(&runnerConfig.WorkDir, "work-dir", "", "directory for task files (default system temp dir)")
+	runnerCmd.Flags().StringVar(&runnerConfig.ContainerRuntime, "container-runtime", "docker", "container runtime for docker execution mode, for example docker or podman")
+	_ = runnerCmd.MarkFlagRequired("broker-url")
+	_ = runnerCmd.MarkFlagRequired("fleet")
+
+	RootCmd.AddCommand(runnerCmd)
+}
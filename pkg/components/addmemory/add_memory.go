@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/components/memorywrite"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/exprruntime"
 	"github.com/superplanehq/superplane/pkg/registry"
 )
 
@@ -25,6 +27,7 @@ type Spec struct {
 	Namespace    string      `json:"namespace"`
 	Values       any         `json:"values,omitempty"`
 	ValueList    []ValuePair `json:"valueList,omitempty"`
+	TTL          string      `json:"ttl,omitempty"`
 	IterateList  bool        `json:"iterateList,omitempty"`
 	ListSource   string      `json:"listSource,omitempty"`
 	ItemVariable string      `json:"itemVariable,omitempty"`
@@ -38,8 +41,28 @@ func (s Spec) listMode() memorywrite.ListMode {
 	}.Normalize()
 }
 
+// ttl returns nil when no TTL is configured,
+// so the default TTL of the namespace applies.
+func (s Spec) ttl() (*time.Duration, error) {
+	value := strings.TrimSpace(s.TTL)
+	if value == "" {
+		return nil, nil
+	}
+
+	ttl, err := exprruntime.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ttl: %w", err)
+	}
+
+	return &ttl, nil
+}
+
 type ValuePair = memorywrite.NameValuePair
 
+type canvasMemoryTTLContext interface {
+	AddRecordWithTTL(namespace string, values any, ttl *time.Duration) (core.CanvasMemoryRecord, error)
+}
+
 func (c *AddMemory) Name() string {
 	return ComponentName
 }
@@ -67,6 +90,16 @@ func (c *AddMemory) Documentation() string {
 2. Appends a new memory row for the current canvas
 3. Emits ` + "`memory.added`" + ` with the saved payload
 
+## Organization Memory
+
+Prefix the namespace with ` + "`org:`" + `, for example ` + "`org:preview-environments`" + `, to add the row to memory shared by every
+canvas of the organization. Each row records which canvas wrote it. Organization memory rows do not expire.
+
+## Expiry
+
+Set ` + "`ttl`" + ` (for example ` + "`30m`" + `, ` + "`12h`" + ` or ` + "`7d`" + `) to make the row expire. Expired rows are no longer
+returned by reads and are deleted in the background. Without a TTL, the default TTL of the namespace applies, if any.
+
 ## List Mode
 
 Enable "Input is a list" to add one memory row per element of a list expression.
@@ -94,9 +127,16 @@ func (c *AddMemory) Configuration() []configuration.Field {
 			Name:        "namespace",
 			Label:       "Namespace",
 			Type:        configuration.FieldTypeString,
-			Description: "Memory namespace for this record",
+			Description: "Memory namespace for this record. Use org:<namespace> for organization memory",
 			Required:    true,
 		},
+		{
+			Name:        "ttl",
+			Label:       "Expire After",
+			Type:        configuration.FieldTypeString,
+			Description: "Optional TTL, e.g. 30m, 12h or 7d. Defaults to the TTL configured for the namespace",
+			Placeholder: "e.g. 7d",
+		},
 		{
 			Name:        "iterateList",
 			Label:       "Input is a list",
@@ -163,8 +203,8 @@ func (c *AddMemory) Execute(ctx core.ExecutionContext) error {
 	}
 
 	spec.Namespace = strings.TrimSpace(spec.Namespace)
-	if spec.Namespace == "" {
-		return fmt.Errorf("namespace is required")
+	if err := core.ValidateMemoryNamespace(spec.Namespace); err != nil {
+		return err
 	}
 
 	mode := spec.listMode()
@@ -172,8 +212,17 @@ func (c *AddMemory) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
+	ttl, err := spec.ttl()
+	if err != nil {
+		return err
+	}
+
+	add := func(values any) error {
+		return addRecord(ctx.CanvasMemory, spec.Namespace, values, ttl)
+	}
+
 	if mode.IterateList {
-		return c.executeListMode(ctx, spec, mode)
+		return c.executeListMode(ctx, spec, mode, add)
 	}
 
 	values := buildValues(spec)
@@ -186,7 +235,7 @@ func (c *AddMemory) Execute(ctx core.ExecutionContext) error {
 		return fmt.Errorf("failed to set execution metadata: %w", err)
 	}
 
-	if err := ctx.CanvasMemory.Add(spec.Namespace, values); err != nil {
+	if err := add(values); err != nil {
 		return fmt.Errorf("failed to add canvas memory: %w", err)
 	}
 
@@ -204,7 +253,7 @@ func (c *AddMemory) Execute(ctx core.ExecutionContext) error {
 	)
 }
 
-func (c *AddMemory) executeListMode(ctx core.ExecutionContext, spec Spec, mode memorywrite.ListMode) error {
+func (c *AddMemory) executeListMode(ctx core.ExecutionContext, spec Spec, mode memorywrite.ListMode, add func(values any) error) error {
 	items, err := mode.EvaluateList(ctx.Expressions)
 	if err != nil {
 		return err
@@ -217,7 +266,7 @@ func (c *AddMemory) executeListMode(ctx core.ExecutionContext, spec Spec, mode m
 
 	writtenValues := make([]any, 0, len(resolved))
 	for i, values := range resolved {
-		if err := ctx.CanvasMemory.Add(spec.Namespace, values); err != nil {
+		if err := add(values); err != nil {
 			return fmt.Errorf("failed to add canvas memory for list item %d: %w", i, err)
 		}
 		writtenValues = append(writtenValues, values)
@@ -250,6 +299,20 @@ func (c *AddMemory) executeListMode(ctx core.ExecutionContext, spec Spec, mode m
 	)
 }
 
+func addRecord(memory core.CanvasMemoryContext, namespace string, values any, ttl *time.Duration) error {
+	if ttl == nil {
+		return memory.Add(namespace, values)
+	}
+
+	ttlMemory, ok := memory.(canvasMemoryTTLContext)
+	if !ok {
+		return fmt.Errorf("canvas memory context does not support expiring records")
+	}
+
+	_, err := ttlMemory.AddRecordWithTTL(namespace, values, ttl)
+	return err
+}
+
 func buildValues(spec Spec) any {
 	if len(spec.ValueList) == 0 {
 		return spec.Values
@@ -290,6 +353,16 @@ func (c *AddMemory) Setup(ctx core.SetupContext) error {
 	if err := mapstructure.Decode(ctx.Configuration, &spec); err != nil {
 		return fmt.Errorf("failed to decode configuration: %w", err)
 	}
+
+	//
+	// The TTL can be an expression, which is only resolved on execution.
+	//
+	if !strings.Contains(spec.TTL, "{{") {
+		if _, err := spec.ttl(); err != nil {
+			return err
+		}
+	}
+
 	return spec.listMode().Validate()
 }
 
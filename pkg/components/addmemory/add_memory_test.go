@@ -3,6 +3,7 @@ package addmemory
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,6 +35,16 @@ func (c *canvasMemoryContext) FindFirst(namespace string, matches map[string]any
 	return nil, c.err
 }
 
+type expiringCanvasMemoryContext struct {
+	canvasMemoryContext
+	ttls []time.Duration
+}
+
+func (c *expiringCanvasMemoryContext) AddRecordWithTTL(namespace string, values any, ttl *time.Duration) (core.CanvasMemoryRecord, error) {
+	c.ttls = append(c.ttls, *ttl)
+	return core.CanvasMemoryRecord{Values: values}, c.Add(namespace, values)
+}
+
 func TestAddMemoryExecute(t *testing.T) {
 	t.Run("adds memory and emits payload", func(t *testing.T) {
 		component := &AddMemory{}
@@ -189,9 +200,64 @@ func TestAddMemoryExecute(t *testing.T) {
 		require.Error(t, err)
 		assert.Equal(t, 0, memoryCtx.addCalls)
 	})
+
+	t.Run("adds memory with ttl", func(t *testing.T) {
+		component := &AddMemory{}
+		memoryCtx := &expiringCanvasMemoryContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"namespace": "locks",
+				"ttl":       "1d12h",
+				"valueList": []map[string]any{
+					{"name": "name", "value": "deploy"},
+				},
+			},
+			Metadata:       &contexts.MetadataContext{},
+			NodeMetadata:   &contexts.MetadataContext{},
+			CanvasMemory:   memoryCtx,
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, memoryCtx.addCalls)
+		assert.Equal(t, []time.Duration{36 * time.Hour}, memoryCtx.ttls)
+	})
+
+	t.Run("rejects ttl when memory cannot expire records", func(t *testing.T) {
+		component := &AddMemory{}
+		memoryCtx := &canvasMemoryContext{}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"namespace": "locks",
+				"ttl":       "1h",
+				"valueList": []map[string]any{
+					{"name": "name", "value": "deploy"},
+				},
+			},
+			Metadata:       &contexts.MetadataContext{},
+			NodeMetadata:   &contexts.MetadataContext{},
+			CanvasMemory:   memoryCtx,
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "expiring records")
+		assert.Equal(t, 0, memoryCtx.addCalls)
+	})
 }
 
 func TestAddMemorySetup(t *testing.T) {
+	t.Run("rejects invalid ttl", func(t *testing.T) {
+		err := (&AddMemory{}).Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"namespace": "locks",
+				"ttl":       "soon",
+			},
+		})
+		require.ErrorContains(t, err, "invalid ttl")
+	})
+
 	t.Run("accepts valid list-mode config", func(t *testing.T) {
 		err := (&AddMemory{}).Setup(core.SetupContext{
 			Configuration: map[string]any{
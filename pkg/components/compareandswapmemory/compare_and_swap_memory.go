@@ -0,0 +1,384 @@
+package compareandswapmemory
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/components/memorywrite"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/exprruntime"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const ComponentName = "compareAndSwapMemory"
+const PayloadType = "memory.swapped"
+const ChannelNameSwapped = "swapped"
+const ChannelNameConflict = "conflict"
+
+const (
+	ExpectAbsent = "absent"
+	ExpectMatch  = "match"
+)
+
+func init() {
+	registry.RegisterAction(ComponentName, &CompareAndSwapMemory{})
+}
+
+type CompareAndSwapMemory struct{}
+
+type Spec struct {
+	Namespace    string      `json:"namespace"`
+	MatchList    []FieldPair `json:"matchList"`
+	Expect       string      `json:"expect"`
+	ExpectedList []FieldPair `json:"expectedList,omitempty"`
+	ValueList    []FieldPair `json:"valueList,omitempty"`
+	TTL          string      `json:"ttl,omitempty"`
+}
+
+type FieldPair = memorywrite.NameValuePair
+
+func (s Spec) ttl() (*time.Duration, error) {
+	value := strings.TrimSpace(s.TTL)
+	if value == "" {
+		return nil, nil
+	}
+
+	ttl, err := exprruntime.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ttl: %w", err)
+	}
+
+	return &ttl, nil
+}
+
+type canvasMemorySwapContext interface {
+	CompareAndSwap(namespace string, swap core.CanvasMemorySwap) (bool, *core.CanvasMemoryRecord, error)
+}
+
+func (c *CompareAndSwapMemory) Name() string {
+	return ComponentName
+}
+
+func (c *CompareAndSwapMemory) Label() string {
+	return "Compare and Swap Memory"
+}
+
+func (c *CompareAndSwapMemory) Description() string {
+	return "Atomically write to canvas memory only if it is in the expected state"
+}
+
+func (c *CompareAndSwapMemory) Documentation() string {
+	return `The Compare and Swap Memory component writes to canvas memory only when the current row is in the expected state. The check and the write happen atomically, so concurrent runs cannot both succeed.
+
+## Use Cases
+
+- Locks: only one run at a time may deploy to an environment
+- State machines: move a release from ` + "`pending`" + ` to ` + "`approved`" + ` exactly once
+- Deduplication: process each external event ID only once
+
+## How It Works
+
+1. Finds the newest memory row in ` + "`namespace`" + ` matching ` + "`matchList`" + `
+2. Compares it with the expected state:
+   - **No matching row**: the swap applies only if no row matches. A new row is created with the match fields and ` + "`valueList`" + `
+   - **Row with fields**: the swap applies only if the row exists and contains ` + "`expectedList`" + `. The ` + "`valueList`" + ` fields are then set on it
+3. Emits ` + "`memory.swapped`" + ` with ` + "`swapped`" + ` and the ` + "`current`" + ` row, which is the row after the swap or the row that caused the conflict
+
+## Output Channels
+
+- **Swapped**: The write was applied
+- **Conflict**: Memory was not in the expected state and nothing was written
+
+## Locks
+
+To acquire a lock, match on the lock name, expect no matching row and set a ` + "`ttl`" + ` so the lock is released
+even if the run never gets to release it. To release it, delete the row with the Delete Memory component.
+When a ` + "`ttl`" + ` is set on a swap of an existing row, the row expiry is reset.`
+}
+
+func (c *CompareAndSwapMemory) Icon() string {
+	return "database"
+}
+
+func (c *CompareAndSwapMemory) Color() string {
+	return "blue"
+}
+
+func (c *CompareAndSwapMemory) ExampleOutput() map[string]any {
+	return exampleOutput()
+}
+
+func (c *CompareAndSwapMemory) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: ChannelNameSwapped, Label: "Swapped"},
+		{Name: ChannelNameConflict, Label: "Conflict"},
+	}
+}
+
+func (c *CompareAndSwapMemory) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "namespace",
+			Label:       "Namespace",
+			Type:        configuration.FieldTypeString,
+			Description: "Memory namespace to swap in",
+			Required:    true,
+		},
+		{
+			Name:        "matchList",
+			Label:       "Matches",
+			Type:        configuration.FieldTypeList,
+			Description: "List of exact field/value matches used to find the row",
+			Required:    true,
+			TypeOptions: fieldPairListOptions("Match", "Field name to match", "Expected field value (can be expression)"),
+		},
+		{
+			Name:        "expect",
+			Label:       "Expect",
+			Type:        configuration.FieldTypeSelect,
+			Description: "State memory must be in for the swap to apply",
+			Required:    true,
+			Default:     ExpectAbsent,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "No matching row", Value: ExpectAbsent},
+						{Label: "Row with fields", Value: ExpectMatch},
+					},
+				},
+			},
+		},
+		{
+			Name:        "expectedList",
+			Label:       "Expected Fields",
+			Type:        configuration.FieldTypeList,
+			Description: "Fields the matching row must currently have",
+			TypeOptions: fieldPairListOptions("Field", "Field name to compare", "Current field value (can be expression)"),
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "expect", Values: []string{ExpectMatch}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "expect", Values: []string{ExpectMatch}},
+			},
+		},
+		{
+			Name:        "valueList",
+			Label:       "Values",
+			Type:        configuration.FieldTypeList,
+			Description: "Fields to write when the swap applies",
+			TypeOptions: fieldPairListOptions("Field", "Field name to set", "Field value (can be expression)"),
+		},
+		{
+			Name:        "ttl",
+			Label:       "Expire After",
+			Type:        configuration.FieldTypeString,
+			Description: "Optional TTL for the written row, e.g. 10m for a lock",
+			Placeholder: "e.g. 10m",
+		},
+	}
+}
+
+func fieldPairListOptions(itemLabel, nameDescription, valueDescription string) *configuration.TypeOptions {
+	return &configuration.TypeOptions{
+		List: &configuration.ListTypeOptions{
+			ItemLabel: itemLabel,
+			ItemDefinition: &configuration.ListItemDefinition{
+				Type: configuration.FieldTypeObject,
+				Schema: []configuration.Field{
+					{
+						Name:        "name",
+						Label:       "Field Name",
+						Type:        configuration.FieldTypeString,
+						Description: nameDescription,
+						Required:    true,
+					},
+					{
+						Name:        "value",
+						Label:       "Field Value",
+						Type:        configuration.FieldTypeExpression,
+						Description: valueDescription,
+						Required:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *CompareAndSwapMemory) Setup(ctx core.SetupContext) error {
+	spec, err := decodeSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if err := validateSpec(spec); err != nil {
+		return err
+	}
+
+	//
+	// The TTL can be an expression, which is only resolved on execution.
+	//
+	if strings.Contains(spec.TTL, "{{") {
+		return nil
+	}
+
+	_, err = spec.ttl()
+	return err
+}
+
+func (c *CompareAndSwapMemory) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if err := validateSpec(spec); err != nil {
+		return err
+	}
+
+	ttl, err := spec.ttl()
+	if err != nil {
+		return err
+	}
+
+	swapCtx, ok := ctx.CanvasMemory.(canvasMemorySwapContext)
+	if !ok {
+		return fmt.Errorf("canvas memory compare-and-swap operations are not supported")
+	}
+
+	swap := core.CanvasMemorySwap{
+		Matches: buildPairs(spec.MatchList),
+		Values:  buildPairs(spec.ValueList),
+		TTL:     ttl,
+	}
+
+	if spec.Expect == ExpectMatch {
+		swap.Expected = buildPairs(spec.ExpectedList)
+	}
+
+	swapped, record, err := swapCtx.CompareAndSwap(spec.Namespace, swap)
+	if err != nil {
+		return fmt.Errorf("failed to compare and swap canvas memory: %w", err)
+	}
+
+	var current any
+	if record != nil {
+		current = record.Values
+	}
+
+	metadata := map[string]any{
+		"namespace":   spec.Namespace,
+		"matchFields": memorywrite.FieldNames(spec.MatchList),
+		"expect":      spec.Expect,
+		"swapped":     swapped,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	channel := ChannelNameConflict
+	if swapped {
+		channel = ChannelNameSwapped
+	}
+
+	var expected any
+	if swap.Expected != nil {
+		expected = swap.Expected
+	}
+
+	return ctx.ExecutionState.Emit(
+		channel,
+		PayloadType,
+		[]any{
+			map[string]any{
+				"data": map[string]any{
+					"namespace": spec.Namespace,
+					"matches":   swap.Matches,
+					"expected":  expected,
+					"values":    swap.Values,
+					"swapped":   swapped,
+					"current":   current,
+				},
+			},
+		},
+	)
+}
+
+func decodeSpec(raw any) (Spec, error) {
+	var spec Spec
+	if err := mapstructure.Decode(raw, &spec); err != nil {
+		return Spec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Namespace = strings.TrimSpace(spec.Namespace)
+	spec.Expect = strings.TrimSpace(spec.Expect)
+	if spec.Expect == "" {
+		spec.Expect = ExpectAbsent
+	}
+
+	return spec, nil
+}
+
+func validateSpec(spec Spec) error {
+	if spec.Namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	if _, organization := core.ParseMemoryNamespace(spec.Namespace); organization {
+		return fmt.Errorf("organization memory does not support compare-and-swap")
+	}
+	if len(buildPairs(spec.MatchList)) == 0 {
+		return fmt.Errorf("at least one memory match is required")
+	}
+
+	switch spec.Expect {
+	case ExpectAbsent:
+		return nil
+	case ExpectMatch:
+		if len(buildPairs(spec.ExpectedList)) == 0 {
+			return fmt.Errorf("at least one expected field is required")
+		}
+		if len(buildPairs(spec.ValueList)) == 0 {
+			return fmt.Errorf("at least one memory value is required")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid expect %q: must be %s or %s", spec.Expect, ExpectAbsent, ExpectMatch)
+	}
+}
+
+func buildPairs(pairs []FieldPair) map[string]any {
+	values := make(map[string]any, len(pairs))
+	for _, pair := range pairs {
+		name := strings.TrimSpace(pair.Name)
+		if name == "" {
+			continue
+		}
+		values[name] = pair.Value
+	}
+	return values
+}
+
+func (c *CompareAndSwapMemory) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CompareAndSwapMemory) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CompareAndSwapMemory) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CompareAndSwapMemory) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CompareAndSwapMemory) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
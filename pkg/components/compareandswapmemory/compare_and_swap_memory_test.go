@@ -0,0 +1,185 @@
+package compareandswapmemory
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+// canvasMemoryContext keeps a single row, which is
+// enough to exercise both kinds of expected state.
+type canvasMemoryContext struct {
+	row   map[string]any
+	swaps []core.CanvasMemorySwap
+}
+
+func (c *canvasMemoryContext) Add(namespace string, values any) error {
+	return nil
+}
+
+func (c *canvasMemoryContext) Find(namespace string, matches map[string]any) ([]any, error) {
+	return []any{}, nil
+}
+
+func (c *canvasMemoryContext) FindFirst(namespace string, matches map[string]any) (any, error) {
+	return nil, nil
+}
+
+func (c *canvasMemoryContext) CompareAndSwap(namespace string, swap core.CanvasMemorySwap) (bool, *core.CanvasMemoryRecord, error) {
+	c.swaps = append(c.swaps, swap)
+
+	if swap.Expected == nil {
+		if c.row != nil {
+			return false, &core.CanvasMemoryRecord{Values: c.row}, nil
+		}
+
+		c.row = maps.Clone(swap.Matches)
+		maps.Copy(c.row, swap.Values)
+		return true, &core.CanvasMemoryRecord{Values: c.row}, nil
+	}
+
+	if c.row == nil {
+		return false, nil, nil
+	}
+
+	for key, value := range swap.Expected {
+		if c.row[key] != value {
+			return false, &core.CanvasMemoryRecord{Values: c.row}, nil
+		}
+	}
+
+	maps.Copy(c.row, swap.Values)
+	return true, &core.CanvasMemoryRecord{Values: c.row}, nil
+}
+
+func execute(t *testing.T, memoryCtx core.CanvasMemoryContext, configuration map[string]any) (*contexts.ExecutionStateContext, error) {
+	t.Helper()
+
+	execState := &contexts.ExecutionStateContext{}
+	err := (&CompareAndSwapMemory{}).Execute(core.ExecutionContext{
+		Configuration:  configuration,
+		Metadata:       &contexts.MetadataContext{},
+		NodeMetadata:   &contexts.MetadataContext{},
+		CanvasMemory:   memoryCtx,
+		ExecutionState: execState,
+	})
+
+	return execState, err
+}
+
+func payloadData(t *testing.T, execState *contexts.ExecutionStateContext) map[string]any {
+	t.Helper()
+
+	require.Len(t, execState.Payloads, 1)
+	emitted := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+	return emitted["data"].(map[string]any)
+}
+
+func TestCompareAndSwapMemoryExecute(t *testing.T) {
+	acquire := map[string]any{
+		"namespace": "locks",
+		"matchList": []map[string]any{{"name": "lock", "value": "production"}},
+		"valueList": []map[string]any{{"name": "holder", "value": "run-1"}},
+		"ttl":       "10m",
+	}
+
+	t.Run("acquires a free lock", func(t *testing.T) {
+		memoryCtx := &canvasMemoryContext{}
+
+		execState, err := execute(t, memoryCtx, acquire)
+		require.NoError(t, err)
+
+		assert.Equal(t, ChannelNameSwapped, execState.Channel)
+		assert.Equal(t, PayloadType, execState.Type)
+		data := payloadData(t, execState)
+		assert.Equal(t, true, data["swapped"])
+		assert.Nil(t, data["expected"])
+		assert.Equal(t, map[string]any{"lock": "production", "holder": "run-1"}, data["current"])
+
+		require.Len(t, memoryCtx.swaps, 1)
+		assert.Nil(t, memoryCtx.swaps[0].Expected)
+		require.NotNil(t, memoryCtx.swaps[0].TTL)
+		assert.Equal(t, 10*time.Minute, *memoryCtx.swaps[0].TTL)
+	})
+
+	t.Run("reports a conflict when the lock is held", func(t *testing.T) {
+		memoryCtx := &canvasMemoryContext{row: map[string]any{"lock": "production", "holder": "run-0"}}
+
+		execState, err := execute(t, memoryCtx, acquire)
+		require.NoError(t, err)
+
+		assert.Equal(t, ChannelNameConflict, execState.Channel)
+		data := payloadData(t, execState)
+		assert.Equal(t, false, data["swapped"])
+		assert.Equal(t, map[string]any{"lock": "production", "holder": "run-0"}, data["current"])
+	})
+
+	t.Run("swaps a row in the expected state", func(t *testing.T) {
+		memoryCtx := &canvasMemoryContext{row: map[string]any{"release": "v1", "status": "pending"}}
+		configuration := map[string]any{
+			"namespace":    "releases",
+			"matchList":    []map[string]any{{"name": "release", "value": "v1"}},
+			"expect":       ExpectMatch,
+			"expectedList": []map[string]any{{"name": "status", "value": "pending"}},
+			"valueList":    []map[string]any{{"name": "status", "value": "approved"}},
+		}
+
+		execState, err := execute(t, memoryCtx, configuration)
+		require.NoError(t, err)
+		assert.Equal(t, ChannelNameSwapped, execState.Channel)
+		assert.Equal(t, "approved", memoryCtx.row["status"])
+
+		execState, err = execute(t, memoryCtx, configuration)
+		require.NoError(t, err)
+		assert.Equal(t, ChannelNameConflict, execState.Channel)
+	})
+
+	t.Run("requires a memory context with compare-and-swap", func(t *testing.T) {
+		_, err := execute(t, &basicCanvasMemoryContext{}, acquire)
+		require.ErrorContains(t, err, "not supported")
+	})
+}
+
+type basicCanvasMemoryContext struct{}
+
+func (c *basicCanvasMemoryContext) Add(namespace string, values any) error {
+	return nil
+}
+
+func (c *basicCanvasMemoryContext) Find(namespace string, matches map[string]any) ([]any, error) {
+	return []any{}, nil
+}
+
+func (c *basicCanvasMemoryContext) FindFirst(namespace string, matches map[string]any) (any, error) {
+	return nil, nil
+}
+
+func TestCompareAndSwapMemorySetup(t *testing.T) {
+	t.Run("requires expected fields when expecting a row", func(t *testing.T) {
+		err := (&CompareAndSwapMemory{}).Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"namespace": "releases",
+				"matchList": []map[string]any{{"name": "release", "value": "v1"}},
+				"expect":    ExpectMatch,
+				"valueList": []map[string]any{{"name": "status", "value": "approved"}},
+			},
+		})
+		require.ErrorContains(t, err, "expected field")
+	})
+
+	t.Run("rejects unknown expectation", func(t *testing.T) {
+		err := (&CompareAndSwapMemory{}).Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"namespace": "releases",
+				"matchList": []map[string]any{{"name": "release", "value": "v1"}},
+				"expect":    "present",
+			},
+		})
+		require.ErrorContains(t, err, "invalid expect")
+	})
+}
@@ -62,6 +62,11 @@ func (c *DeleteMemory) Documentation() string {
 2. Deletes memory rows matching all configured key/value pairs
 3. Emits ` + "`memory.deleted`" + ` to the ` + "`deleted`" + ` or ` + "`notFound`" + ` channel
 
+## Organization Memory
+
+Prefix the namespace with ` + "`org:`" + `, for example ` + "`org:preview-environments`" + `, to delete from memory shared by every
+canvas of the organization, including rows written by other canvases.
+
 ## Output Channels
 
 - **Deleted**: At least one matching memory row was removed
@@ -93,7 +98,7 @@ func (c *DeleteMemory) Configuration() []configuration.Field {
 			Name:        "namespace",
 			Label:       "Namespace",
 			Type:        configuration.FieldTypeString,
-			Description: "Memory namespace to delete from",
+			Description: "Memory namespace to delete from. Use org:<namespace> for organization memory",
 			Required:    true,
 		},
 		{
@@ -206,8 +211,8 @@ func normalizeSpec(spec Spec) Spec {
 }
 
 func validateSpec(spec Spec) error {
-	if spec.Namespace == "" {
-		return fmt.Errorf("namespace is required")
+	if err := core.ValidateMemoryNamespace(spec.Namespace); err != nil {
+		return err
 	}
 
 	matches := buildMatches(spec.MatchList)
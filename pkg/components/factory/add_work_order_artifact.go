@@ -11,6 +11,10 @@ import (
 
 const AddWorkOrderArtifactComponentName = "addWorkOrderArtifact"
 
+// runnerArtifactType is not an artifact type of its own: it attaches a
+// runner artifact as a link to its download URL.
+const runnerArtifactType = "runnerArtifact"
+
 func init() {
 	registry.RegisterAction(AddWorkOrderArtifactComponentName, &AddWorkOrderArtifact{})
 }
@@ -28,7 +32,10 @@ type AddWorkOrderArtifactConfiguration struct {
 	OrderID      string `json:"orderId" mapstructure:"orderId"`
 	ArtifactType string `json:"artifactType" mapstructure:"artifactType"`
 	URL          string `json:"url" mapstructure:"url"`
-	Number       string `json:"number" mapstructure:"number"`
+	// RunnerArtifactID is the runner artifact to attach
+	// when ArtifactType is runnerArtifact.
+	RunnerArtifactID string `json:"runnerArtifactId" mapstructure:"runnerArtifactId"`
+	Number           string `json:"number" mapstructure:"number"`
 	// State / Merged / Draft accept expressions, so a flow can wire
 	// them directly to a `github.onPullRequest` payload. `any` because
 	// after resolution the value may be a bool, string, or number.
@@ -54,7 +61,7 @@ func (c *AddWorkOrderArtifact) Label() string {
 }
 
 func (c *AddWorkOrderArtifact) Description() string {
-	return "Attach a typed artifact (PR, markdown note, branch, or link) to a work order"
+	return "Attach a typed artifact (PR, markdown note, branch, link, or runner artifact) to a work order"
 }
 
 func (c *AddWorkOrderArtifact) Documentation() string {
@@ -66,6 +73,7 @@ Supported types:
 - **Markdown note** (` + "`markdown`" + `): requires ` + "`body`" + `; optional ` + "`title`" + `.
 - **Branch** (` + "`branch`" + `): requires ` + "`name`" + ` (the branch name). Set ` + "`url`" + ` when you attach the branch — SuperPlane does not wait for a pull request. If you set ` + "`repository`" + ` (` + "`owner/repo`" + ` or a repository http(s) URL) and leave ` + "`url`" + ` blank, SuperPlane writes a GitHub tree URL from that repository and the branch name.
 - **Link** (` + "`link`" + `): requires ` + "`url`" + ` (must be http or https); optional ` + "`title`" + ` for the artifact chip's label — e.g. attach a preview-environment URL as "Preview".
+- **Runner artifact** (` + "`runnerArtifact`" + `): requires ` + "`runnerArtifactId`" + `, the ` + "`id`" + ` of an entry in the ` + "`artifacts`" + ` list a runner task emits when it finishes, e.g. ` + "`{{ previous().data.artifacts[0].id }}`" + `. The artifact must come from a run of the same app. It is stored as a link to the artifact's download URL, with its ` + "`name`" + `, ` + "`sizeBytes`" + `, ` + "`sha256`" + ` and ` + "`expiresAt`" + ` in its data; ` + "`title`" + ` defaults to the artifact's name. The download stops working once the runner artifact expires.
 
 PR, markdown, and link types accept a free-form ` + "`data`" + ` list of ` + "`{name, value}`" + ` entries that gets merged into the artifact's ` + "`data`" + ` map. Typed inputs take precedence over free-form entries with the same key.
 
@@ -113,7 +121,8 @@ func (c *AddWorkOrderArtifact) Configuration() []configuration.Field {
 	markdownOnly := []configuration.VisibilityCondition{{Field: "artifactType", Values: []string{"markdown"}}}
 	branchOnly := []configuration.VisibilityCondition{{Field: "artifactType", Values: []string{"branch"}}}
 	linkableTypes := []configuration.VisibilityCondition{{Field: "artifactType", Values: []string{"pr", "branch", "link"}}}
-	titledTypes := []configuration.VisibilityCondition{{Field: "artifactType", Values: []string{"pr", "markdown", "link"}}}
+	runnerArtifactOnly := []configuration.VisibilityCondition{{Field: "artifactType", Values: []string{runnerArtifactType}}}
+	titledTypes := []configuration.VisibilityCondition{{Field: "artifactType", Values: []string{"pr", "markdown", "link", runnerArtifactType}}}
 	withMetadata := []configuration.VisibilityCondition{{Field: "artifactType", Values: []string{"pr", "markdown", "link"}}}
 
 	fields := []configuration.Field{
@@ -139,6 +148,7 @@ func (c *AddWorkOrderArtifact) Configuration() []configuration.Field {
 						{Label: "Markdown", Value: "markdown"},
 						{Label: "Branch", Value: "branch"},
 						{Label: "Link", Value: "link"},
+						{Label: "Runner Artifact", Value: runnerArtifactType},
 					},
 				},
 			},
@@ -154,6 +164,17 @@ func (c *AddWorkOrderArtifact) Configuration() []configuration.Field {
 				{Field: "artifactType", Values: []string{"pr", "link"}},
 			},
 		},
+		{
+			Name:                 "runnerArtifactId",
+			Label:                "Runner Artifact ID",
+			Description:          "ID of an artifact a runner task of this app uploaded, e.g. {{ previous().data.artifacts[0].id }}",
+			Type:                 configuration.FieldTypeString,
+			Required:             false,
+			VisibilityConditions: runnerArtifactOnly,
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "artifactType", Values: []string{runnerArtifactType}},
+			},
+		},
 		{
 			Name:                 "number",
 			Label:                "Number",
@@ -203,7 +224,7 @@ func (c *AddWorkOrderArtifact) Configuration() []configuration.Field {
 		configuration.Field{
 			Name:                 "title",
 			Label:                "Title",
-			Description:          "Optional artifact title — for links, this becomes the chip's label (e.g. \"Preview\")",
+			Description:          "Optional artifact title — for links, this becomes the chip's label (e.g. \"Preview\"). Runner artifacts default to their name.",
 			Type:                 configuration.FieldTypeString,
 			Required:             false,
 			VisibilityConditions: titledTypes,
@@ -264,17 +285,7 @@ func (c *AddWorkOrderArtifact) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
-	data, err := buildArtifactData(config)
-	if err != nil {
-		return err
-	}
-
-	artifact, err := ctx.Factory.AddWorkOrderArtifact(core.AddWorkOrderArtifactParams{
-		OrderID: config.OrderID,
-		Type:    config.ArtifactType,
-		Data:    data,
-		Key:     config.ArtifactKey,
-	})
+	artifact, err := addArtifact(ctx.Factory, config)
 	if err != nil {
 		return err
 	}
@@ -312,6 +323,29 @@ func (c *AddWorkOrderArtifact) HandleHook(ctx core.ActionHookContext) error {
 	return nil
 }
 
+func addArtifact(factory core.FactoryContext, config AddWorkOrderArtifactConfiguration) (*core.WorkOrderArtifact, error) {
+	if config.ArtifactType == runnerArtifactType {
+		return factory.AddRunnerArtifactToWorkOrder(core.AddRunnerArtifactToWorkOrderParams{
+			OrderID:          config.OrderID,
+			RunnerArtifactID: config.RunnerArtifactID,
+			Title:            config.Title,
+			Key:              config.ArtifactKey,
+		})
+	}
+
+	data, err := buildArtifactData(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory.AddWorkOrderArtifact(core.AddWorkOrderArtifactParams{
+		OrderID: config.OrderID,
+		Type:    config.ArtifactType,
+		Data:    data,
+		Key:     config.ArtifactKey,
+	})
+}
+
 // buildArtifactData folds the free-form list into a map and layers the
 // typed inputs on top, so a user who defines both `url` and a `url`
 // row still ends up with the typed value on the wire.
@@ -13,8 +13,8 @@ import (
 )
 
 // fakeFactoryContext lets Execute-level tests drive `core.FactoryContext`
-// without spinning up a database. Only UpdateWorkOrderStatus and
-// FindWorkOrder are wired up; the rest of the interface returns zero values.
+// without spinning up a database. Methods without recorded calls
+// return zero values.
 type fakeFactoryContext struct {
 	statusCalls int
 	nextChanged bool
@@ -28,6 +28,10 @@ type fakeFactoryContext struct {
 	findOrder  *core.WorkOrder
 	findErr    error
 
+	addRunnerArtifactCalls  int
+	addRunnerArtifactParams core.AddRunnerArtifactToWorkOrderParams
+	addRunnerArtifactResult *core.WorkOrderArtifact
+
 	updateArtifactCalls  int
 	updateArtifactParams core.UpdateWorkOrderArtifactParams
 	updateArtifactResult *core.WorkOrderArtifact
@@ -68,6 +72,12 @@ func (f *fakeFactoryContext) AddWorkOrderArtifact(_ core.AddWorkOrderArtifactPar
 	return nil, nil
 }
 
+func (f *fakeFactoryContext) AddRunnerArtifactToWorkOrder(params core.AddRunnerArtifactToWorkOrderParams) (*core.WorkOrderArtifact, error) {
+	f.addRunnerArtifactCalls++
+	f.addRunnerArtifactParams = params
+	return f.addRunnerArtifactResult, nil
+}
+
 func (f *fakeFactoryContext) UpdateWorkOrderArtifact(params core.UpdateWorkOrderArtifactParams) (*core.WorkOrderArtifact, error) {
 	f.updateArtifactCalls++
 	f.updateArtifactParams = params
@@ -408,6 +418,16 @@ func TestAddWorkOrderArtifact_ValidatesConfiguration(t *testing.T) {
 		}
 	})
 
+	t.Run("requires runnerArtifactId for runner artifacts", func(t *testing.T) {
+		err := configuration.ValidateConfiguration(fields, map[string]any{
+			"orderId":      "{{ order().id }}",
+			"artifactType": "runnerArtifact",
+		})
+		if err == nil {
+			t.Fatal("expected error for runner artifact without runnerArtifactId")
+		}
+	})
+
 	t.Run("requires orderId", func(t *testing.T) {
 		err := configuration.ValidateConfiguration(fields, map[string]any{
 			"artifactType": "pr",
@@ -560,6 +580,38 @@ func TestAddWorkOrderArtifact_ValidatesConfiguration(t *testing.T) {
 	})
 }
 
+func TestAddWorkOrderArtifact_Execute_RunnerArtifact(t *testing.T) {
+	component := &AddWorkOrderArtifact{}
+	artifact := &core.WorkOrderArtifact{ID: "art-1", WorkOrderID: "wo-1", Type: "link", Data: map[string]any{
+		"url":   "https://superplane.example.com/api/v1/canvases/c/runs/r/artifacts/a/content",
+		"title": "Coverage",
+	}}
+	factoryCtx := &fakeFactoryContext{addRunnerArtifactResult: artifact}
+	stateCtx := &contexts.ExecutionStateContext{}
+
+	err := component.Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"orderId":          "wo-1",
+			"artifactType":     "runnerArtifact",
+			"runnerArtifactId": "a",
+			"title":            "Coverage",
+			"artifactKey":      "coverage",
+		},
+		ExecutionState: stateCtx,
+		Factory:        factoryCtx,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, factoryCtx.addRunnerArtifactCalls)
+	assert.Equal(t, core.AddRunnerArtifactToWorkOrderParams{
+		OrderID:          "wo-1",
+		RunnerArtifactID: "a",
+		Title:            "Coverage",
+		Key:              "coverage",
+	}, factoryCtx.addRunnerArtifactParams)
+	assert.Equal(t, "workOrder.artifactAdded", stateCtx.Type)
+	assert.Len(t, stateCtx.Payloads, 1)
+}
+
 func TestUpdateWorkOrderArtifact_Execute(t *testing.T) {
 	component := &UpdateWorkOrderArtifact{}
 	artifact := &core.WorkOrderArtifact{ID: "art-1", WorkOrderID: "wo-1", Type: "pr", Data: map[string]any{
@@ -0,0 +1,321 @@
+package incrementmemory
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/components/memorywrite"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/exprruntime"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const ComponentName = "incrementMemory"
+const PayloadType = "memory.incremented"
+
+func init() {
+	registry.RegisterAction(ComponentName, &IncrementMemory{})
+}
+
+type IncrementMemory struct{}
+
+type Spec struct {
+	Namespace string      `json:"namespace"`
+	MatchList []FieldPair `json:"matchList"`
+	Field     string      `json:"field"`
+	Amount    *float64    `json:"amount,omitempty"`
+	TTL       string      `json:"ttl,omitempty"`
+}
+
+type FieldPair = memorywrite.NameValuePair
+
+func (s Spec) amount() float64 {
+	if s.Amount == nil {
+		return 1
+	}
+
+	return *s.Amount
+}
+
+func (s Spec) ttl() (*time.Duration, error) {
+	value := strings.TrimSpace(s.TTL)
+	if value == "" {
+		return nil, nil
+	}
+
+	ttl, err := exprruntime.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ttl: %w", err)
+	}
+
+	return &ttl, nil
+}
+
+type canvasMemoryIncrementContext interface {
+	Increment(namespace string, matches map[string]any, field string, delta float64, ttl *time.Duration) (core.CanvasMemoryRecord, error)
+}
+
+func (c *IncrementMemory) Name() string {
+	return ComponentName
+}
+
+func (c *IncrementMemory) Label() string {
+	return "Increment Memory"
+}
+
+func (c *IncrementMemory) Description() string {
+	return "Atomically add to a numeric field in canvas memory"
+}
+
+func (c *IncrementMemory) Documentation() string {
+	return `The Increment Memory component atomically adds an amount to a numeric field of a canvas memory row.
+
+## Use Cases
+
+- Count deployments, failures or retries per environment
+- Enforce quotas, for example at most 5 deployments per hour
+- Generate sequence numbers that concurrent runs never share
+
+## How It Works
+
+1. Finds the newest memory row in ` + "`namespace`" + ` matching ` + "`matchList`" + `
+2. Adds ` + "`amount`" + ` (default 1, can be negative) to ` + "`field`" + `. A missing field counts as 0
+3. If no row matches, creates one with the match fields and ` + "`field`" + ` set to ` + "`amount`" + `
+4. Emits ` + "`memory.incremented`" + ` with the new ` + "`value`" + `
+
+Concurrent runs incrementing the same row never lose updates, and each run sees a distinct ` + "`value`" + `.
+
+## Quotas
+
+Set ` + "`ttl`" + ` to count over a fixed window. The TTL only applies when the row is created, so with
+a ` + "`1h`" + ` TTL the count starts over an hour after the first increment. Follow the component with an If
+component on ` + "`data.value`" + ` to stop runs that exceed the quota.`
+}
+
+func (c *IncrementMemory) Icon() string {
+	return "database"
+}
+
+func (c *IncrementMemory) Color() string {
+	return "blue"
+}
+
+func (c *IncrementMemory) ExampleOutput() map[string]any {
+	return exampleOutput()
+}
+
+func (c *IncrementMemory) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *IncrementMemory) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "namespace",
+			Label:       "Namespace",
+			Type:        configuration.FieldTypeString,
+			Description: "Memory namespace of the counter",
+			Required:    true,
+		},
+		{
+			Name:        "matchList",
+			Label:       "Matches",
+			Type:        configuration.FieldTypeList,
+			Description: "List of exact field/value matches used to find the counter row",
+			Required:    true,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Match",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:        "name",
+								Label:       "Field Name",
+								Type:        configuration.FieldTypeString,
+								Description: "Field name to match",
+								Required:    true,
+							},
+							{
+								Name:        "value",
+								Label:       "Field Value",
+								Type:        configuration.FieldTypeExpression,
+								Description: "Expected field value (can be expression)",
+								Required:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "field",
+			Label:       "Field",
+			Type:        configuration.FieldTypeString,
+			Description: "Numeric field to increment",
+			Placeholder: "e.g. count",
+			Required:    true,
+		},
+		{
+			Name:        "amount",
+			Label:       "Amount",
+			Type:        configuration.FieldTypeNumber,
+			Description: "Amount to add. Use a negative amount to decrement",
+			Default:     1,
+		},
+		{
+			Name:        "ttl",
+			Label:       "Expire After",
+			Type:        configuration.FieldTypeString,
+			Description: "Optional TTL for newly created counters, e.g. 1h or 1d",
+			Placeholder: "e.g. 1h",
+		},
+	}
+}
+
+func (c *IncrementMemory) Setup(ctx core.SetupContext) error {
+	spec, err := decodeSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if err := validateSpec(spec); err != nil {
+		return err
+	}
+
+	//
+	// The TTL can be an expression, which is only resolved on execution.
+	//
+	if strings.Contains(spec.TTL, "{{") {
+		return nil
+	}
+
+	_, err = spec.ttl()
+	return err
+}
+
+func (c *IncrementMemory) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if err := validateSpec(spec); err != nil {
+		return err
+	}
+
+	ttl, err := spec.ttl()
+	if err != nil {
+		return err
+	}
+
+	incrementCtx, ok := ctx.CanvasMemory.(canvasMemoryIncrementContext)
+	if !ok {
+		return fmt.Errorf("canvas memory increment operations are not supported")
+	}
+
+	matches := buildPairs(spec.MatchList)
+	record, err := incrementCtx.Increment(spec.Namespace, matches, spec.Field, spec.amount(), ttl)
+	if err != nil {
+		return fmt.Errorf("failed to increment canvas memory: %w", err)
+	}
+
+	var value any
+	if values, ok := record.Values.(map[string]any); ok {
+		value = values[spec.Field]
+	}
+
+	metadata := map[string]any{
+		"namespace":   spec.Namespace,
+		"matchFields": memorywrite.FieldNames(spec.MatchList),
+		"field":       spec.Field,
+		"value":       value,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return fmt.Errorf("failed to set execution metadata: %w", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		PayloadType,
+		[]any{
+			map[string]any{
+				"data": map[string]any{
+					"namespace": spec.Namespace,
+					"matches":   matches,
+					"field":     spec.Field,
+					"amount":    spec.amount(),
+					"value":     value,
+					"values":    record.Values,
+				},
+			},
+		},
+	)
+}
+
+func decodeSpec(raw any) (Spec, error) {
+	var spec Spec
+	if err := mapstructure.Decode(raw, &spec); err != nil {
+		return Spec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Namespace = strings.TrimSpace(spec.Namespace)
+	spec.Field = strings.TrimSpace(spec.Field)
+	return spec, nil
+}
+
+func validateSpec(spec Spec) error {
+	if spec.Namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	if _, organization := core.ParseMemoryNamespace(spec.Namespace); organization {
+		return fmt.Errorf("organization memory does not support increments")
+	}
+	if len(buildPairs(spec.MatchList)) == 0 {
+		return fmt.Errorf("at least one memory match is required")
+	}
+	if spec.Field == "" {
+		return fmt.Errorf("field is required")
+	}
+	if _, ok := buildPairs(spec.MatchList)[spec.Field]; ok {
+		return fmt.Errorf("field %q cannot also be a match", spec.Field)
+	}
+	return nil
+}
+
+func buildPairs(pairs []FieldPair) map[string]any {
+	values := make(map[string]any, len(pairs))
+	for _, pair := range pairs {
+		name := strings.TrimSpace(pair.Name)
+		if name == "" {
+			continue
+		}
+		values[name] = pair.Value
+	}
+	return values
+}
+
+func (c *IncrementMemory) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *IncrementMemory) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *IncrementMemory) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *IncrementMemory) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *IncrementMemory) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
@@ -0,0 +1,193 @@
+package incrementmemory
+
+import (
+	"errors"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+type canvasMemoryContext struct {
+	counters map[string]float64
+	calls    int
+	ttls     []*time.Duration
+	err      error
+}
+
+func (c *canvasMemoryContext) Add(namespace string, values any) error {
+	return nil
+}
+
+func (c *canvasMemoryContext) Find(namespace string, matches map[string]any) ([]any, error) {
+	return []any{}, nil
+}
+
+func (c *canvasMemoryContext) FindFirst(namespace string, matches map[string]any) (any, error) {
+	return nil, nil
+}
+
+func (c *canvasMemoryContext) Increment(
+	namespace string,
+	matches map[string]any,
+	field string,
+	delta float64,
+	ttl *time.Duration,
+) (core.CanvasMemoryRecord, error) {
+	c.calls++
+	c.ttls = append(c.ttls, ttl)
+	if c.err != nil {
+		return core.CanvasMemoryRecord{}, c.err
+	}
+
+	if c.counters == nil {
+		c.counters = map[string]float64{}
+	}
+
+	c.counters[namespace] += delta
+	values := maps.Clone(matches)
+	values[field] = c.counters[namespace]
+	return core.CanvasMemoryRecord{Values: values}, nil
+}
+
+type basicCanvasMemoryContext struct{}
+
+func (c *basicCanvasMemoryContext) Add(namespace string, values any) error {
+	return nil
+}
+
+func (c *basicCanvasMemoryContext) Find(namespace string, matches map[string]any) ([]any, error) {
+	return []any{}, nil
+}
+
+func (c *basicCanvasMemoryContext) FindFirst(namespace string, matches map[string]any) (any, error) {
+	return nil, nil
+}
+
+func TestIncrementMemoryExecute(t *testing.T) {
+	config := func(extra map[string]any) map[string]any {
+		configuration := map[string]any{
+			"namespace": "deploy-quota",
+			"matchList": []map[string]any{
+				{"name": "environment", "value": "production"},
+			},
+			"field": "count",
+		}
+
+		maps.Copy(configuration, extra)
+		return configuration
+	}
+
+	t.Run("increments by one by default and emits the new value", func(t *testing.T) {
+		memoryCtx := &canvasMemoryContext{}
+		execState := &contexts.ExecutionStateContext{}
+		execMetadata := &contexts.MetadataContext{}
+
+		for range 2 {
+			err := (&IncrementMemory{}).Execute(core.ExecutionContext{
+				Configuration:  config(nil),
+				Metadata:       execMetadata,
+				NodeMetadata:   &contexts.MetadataContext{},
+				CanvasMemory:   memoryCtx,
+				ExecutionState: execState,
+			})
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 2, memoryCtx.calls)
+		assert.Equal(t, "default", execState.Channel)
+		assert.Equal(t, PayloadType, execState.Type)
+
+		emitted := execState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		payload := emitted["data"].(map[string]any)
+		assert.Equal(t, 2.0, payload["value"])
+		assert.Equal(t, map[string]any{"environment": "production"}, payload["matches"])
+		assert.Equal(t, map[string]any{"environment": "production", "count": 2.0}, payload["values"])
+		assert.Equal(t, 2.0, execMetadata.Get().(map[string]any)["value"])
+	})
+
+	t.Run("passes amount and ttl", func(t *testing.T) {
+		memoryCtx := &canvasMemoryContext{}
+
+		err := (&IncrementMemory{}).Execute(core.ExecutionContext{
+			Configuration:  config(map[string]any{"amount": -3, "ttl": "1h"}),
+			Metadata:       &contexts.MetadataContext{},
+			NodeMetadata:   &contexts.MetadataContext{},
+			CanvasMemory:   memoryCtx,
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, -3.0, memoryCtx.counters["deploy-quota"])
+		require.Len(t, memoryCtx.ttls, 1)
+		require.NotNil(t, memoryCtx.ttls[0])
+		assert.Equal(t, time.Hour, *memoryCtx.ttls[0])
+	})
+
+	t.Run("returns increment errors", func(t *testing.T) {
+		memoryCtx := &canvasMemoryContext{err: errors.New("memory field is not a number")}
+		execState := &contexts.ExecutionStateContext{}
+
+		err := (&IncrementMemory{}).Execute(core.ExecutionContext{
+			Configuration:  config(nil),
+			Metadata:       &contexts.MetadataContext{},
+			NodeMetadata:   &contexts.MetadataContext{},
+			CanvasMemory:   memoryCtx,
+			ExecutionState: execState,
+		})
+
+		require.ErrorContains(t, err, "not a number")
+		assert.False(t, execState.Passed)
+	})
+
+	t.Run("requires a memory context with increments", func(t *testing.T) {
+		err := (&IncrementMemory{}).Execute(core.ExecutionContext{
+			Configuration:  config(nil),
+			Metadata:       &contexts.MetadataContext{},
+			NodeMetadata:   &contexts.MetadataContext{},
+			CanvasMemory:   &basicCanvasMemoryContext{},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "not supported")
+	})
+}
+
+func TestIncrementMemorySetup(t *testing.T) {
+	t.Run("rejects missing field", func(t *testing.T) {
+		err := (&IncrementMemory{}).Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"namespace": "deploy-quota",
+				"matchList": []map[string]any{{"name": "environment", "value": "production"}},
+			},
+		})
+		require.ErrorContains(t, err, "field is required")
+	})
+
+	t.Run("rejects field that is also a match", func(t *testing.T) {
+		err := (&IncrementMemory{}).Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"namespace": "deploy-quota",
+				"matchList": []map[string]any{{"name": "count", "value": "1"}},
+				"field":     "count",
+			},
+		})
+		require.ErrorContains(t, err, "cannot also be a match")
+	})
+
+	t.Run("rejects invalid ttl", func(t *testing.T) {
+		err := (&IncrementMemory{}).Setup(core.SetupContext{
+			Configuration: map[string]any{
+				"namespace": "deploy-quota",
+				"matchList": []map[string]any{{"name": "environment", "value": "production"}},
+				"field":     "count",
+				"ttl":       "hourly",
+			},
+		})
+		require.ErrorContains(t, err, "invalid ttl")
+	})
+}
@@ -66,6 +66,11 @@ func (c *ReadMemory) Documentation() string {
 2. Finds memory rows matching all configured key/value pairs
 3. Emits ` + "`memory.read`" + ` to the ` + "`found`" + ` or ` + "`notFound`" + ` channel
 
+## Organization Memory
+
+Prefix the namespace with ` + "`org:`" + `, for example ` + "`org:preview-environments`" + `, to read memory shared by every canvas
+of the organization, including rows written by other canvases.
+
 ## Output Channels
 
 - **Found**: At least one matching memory row was found
@@ -103,7 +108,7 @@ func (c *ReadMemory) Configuration() []configuration.Field {
 			Name:        "namespace",
 			Label:       "Namespace",
 			Type:        configuration.FieldTypeString,
-			Description: "Memory namespace to search in",
+			Description: "Memory namespace to search in. Use org:<namespace> for organization memory",
 			Required:    true,
 		},
 		{
@@ -265,8 +270,8 @@ func normalizeSpec(spec Spec) Spec {
 }
 
 func validateSpec(spec Spec) error {
-	if spec.Namespace == "" {
-		return fmt.Errorf("namespace is required")
+	if err := core.ValidateMemoryNamespace(spec.Namespace); err != nil {
+		return err
 	}
 	if spec.ResultMode != ResultModeAll && spec.ResultMode != ResultModeLatest {
 		return fmt.Errorf("resultMode must be either %q or %q", ResultModeAll, ResultModeLatest)
@@ -100,6 +100,7 @@ type brokerCreateTaskRequest struct {
 	DockerImage             string                      `json:"docker_image,omitempty"`
 	ExecutionTimeoutSeconds *int                        `json:"execution_timeout_seconds,omitempty"`
 	Labels                  map[string]string           `json:"labels,omitempty"`
+	Storage                 *BrokerTaskStorage          `json:"storage,omitempty"`
 }
 
 // BrokerTaskFile is materialized under SUPERPLANE_TASK_DIR before execution.
@@ -182,6 +183,7 @@ type CreateTaskParams struct {
 	DockerImage             string
 	TimeoutSeconds          int // 0 = DefaultExecutionTimeoutSeconds
 	Labels                  map[string]string
+	Storage                 *BrokerTaskStorage
 }
 
 type brokerCreateTaskResponse struct {
@@ -218,6 +220,7 @@ func (b *BrokerClient) CreateTask(p CreateTaskParams) (string, error) {
 		ExecutionMode:           mode,
 		DockerImage:             strings.TrimSpace(p.DockerImage),
 		Labels:                  p.Labels,
+		Storage:                 p.Storage,
 	}
 	timeout := p.TimeoutSeconds
 	if timeout <= 0 {
@@ -367,6 +367,10 @@ func (c *RunClaudeCode) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
+	if err := runner.EnsureFactoryBudgetAvailable(ctx); err != nil {
+		return err
+	}
+
 	broker, err := runner.NewBrokerClient(ctx.HTTP)
 	if err != nil {
 		return fmt.Errorf("new broker client: %w", err)
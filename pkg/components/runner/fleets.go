@@ -2,6 +2,7 @@ package runner
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/superplanehq/superplane/pkg/configuration"
@@ -21,13 +22,28 @@ const (
 	MachineTypeE1TinyARM64  = "e1-tiny-arm64"
 )
 
-var machineTypeSelectOptions = []configuration.FieldOption{
+var hostedMachineTypeOptions = []configuration.FieldOption{
 	{Label: MachineTypeE1LargeAMD64, Value: MachineTypeE1LargeAMD64},
 	{Label: MachineTypeE1LargeARM64, Value: MachineTypeE1LargeARM64},
 	{Label: MachineTypeE1TinyAMD64, Value: MachineTypeE1TinyAMD64},
 	{Label: MachineTypeE1TinyARM64, Value: MachineTypeE1TinyARM64},
 }
 
+// machineTypeSelectOptions lists the hosted machine types followed by the
+// self-hosted fleets named in TASK_BROKER_SELF_HOSTED_FLEETS, a
+// comma-separated list of the fleet IDs `superplane runner` registers with.
+func machineTypeSelectOptions() []configuration.FieldOption {
+	options := append([]configuration.FieldOption{}, hostedMachineTypeOptions...)
+	for _, fleet := range strings.Split(os.Getenv("TASK_BROKER_SELF_HOSTED_FLEETS"), ",") {
+		fleet = strings.TrimSpace(fleet)
+		if fleet == "" {
+			continue
+		}
+		options = append(options, configuration.FieldOption{Label: fleet + " (self-hosted)", Value: fleet})
+	}
+	return options
+}
+
 func requireMachineType(machineType string) (string, error) {
 	fleet := strings.TrimSpace(machineType)
 	if fleet == "" {
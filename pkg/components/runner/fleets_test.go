@@ -16,3 +16,13 @@ func TestRequireMachineType(t *testing.T) {
 	_, err = requireMachineType("")
 	require.Error(t, err)
 }
+
+func TestMachineTypeSelectOptionsIncludesSelfHostedFleets(t *testing.T) {
+	t.Setenv("TASK_BROKER_SELF_HOSTED_FLEETS", " build-linux ,, gpu ")
+
+	options := machineTypeSelectOptions()
+
+	require.Len(t, options, len(hostedMachineTypeOptions)+2)
+	assert.Equal(t, "build-linux", options[len(hostedMachineTypeOptions)].Value)
+	assert.Equal(t, "gpu (self-hosted)", options[len(hostedMachineTypeOptions)+1].Label)
+}
@@ -83,7 +83,7 @@ printf '{"pr":%s}\n' "$num" > "$SUPERPLANE_RESULT_FILE"
 - **Script**: Bash source executed by the runner.
 - **Setup commands**: Optional shell commands (one per line) run before the script in the same environment and working directory.
 - **Environment variables**: Optional key/value pairs available during execution.
-
+` + storageDocumentation() + `
 ## Output channels
 - **Passed**: The script finished with exit code **0**.
 - **Failed**: The script finished with non-zero exit code.
@@ -91,7 +91,7 @@ printf '{"pr":%s}\n' "$num" > "$SUPERPLANE_RESULT_FILE"
 }
 
 func (c *RunBash) Configuration() []configuration.Field {
-	return []configuration.Field{
+	fields := []configuration.Field{
 		{
 			Name:     configurationFieldMachineType,
 			Label:    "Machine type",
@@ -99,7 +99,7 @@ func (c *RunBash) Configuration() []configuration.Field {
 			Required: true,
 			TypeOptions: &configuration.TypeOptions{
 				Select: &configuration.SelectTypeOptions{
-					Options: machineTypeSelectOptions,
+					Options: machineTypeSelectOptions(),
 				},
 			},
 		},
@@ -273,6 +273,8 @@ func (c *RunBash) Configuration() []configuration.Field {
 			},
 		},
 	}
+
+	return append(fields, storageConfigurationFields()...)
 }
 
 func (c *RunBash) Setup(ctx core.SetupContext) error {
@@ -318,11 +320,20 @@ func (c *RunBash) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
+	if err := ensureFactoryBudgetAvailable(ctx); err != nil {
+		return err
+	}
+
 	broker, err := NewBrokerClient(ctx.HTTP)
 	if err != nil {
 		return fmt.Errorf("new broker client: %w", err)
 	}
 
+	storage, err := taskStorage(ctx, spec.StorageSpec, spec.ExecutionTimeoutSeconds)
+	if err != nil {
+		return err
+	}
+
 	mode := normalizeExecutionMode(spec.ExecutionMode)
 	var setupCommands []string
 	if spec.EnableSetupCommands {
@@ -341,6 +352,7 @@ func (c *RunBash) Execute(ctx core.ExecutionContext) error {
 		DockerImage:    resolvedRunBashDockerImageRef(spec),
 		TimeoutSeconds: spec.ExecutionTimeoutSeconds,
 		Labels:         OriginLabelsForTask(ctx),
+		Storage:        storage,
 	}
 
 	taskID, err := broker.CreateTask(params)
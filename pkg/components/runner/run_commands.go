@@ -78,7 +78,7 @@ func (c *Runner) Documentation() string {
 - **Execution timeout**: Optional wall-clock limit in seconds (1–86400). Defaults to **3600** (1 hour) when unset or **0**.
 - **Commands**: One or more shell commands, one per line.
 - **Environment variables**: Optional key/value pairs available during command execution. Values can be literal strings (with expression support) or organization secret keys.
-
+` + storageDocumentation() + `
 ## Output channels
 - **Passed**: The commands finished with exit code **0**.
 - **Failed**: The commands finished with non-zero exit code.
@@ -89,7 +89,7 @@ If the completed broker task includes valid JSON in **result**, SuperPlane inclu
 }
 
 func (c *Runner) Configuration() []configuration.Field {
-	return []configuration.Field{
+	fields := []configuration.Field{
 		{
 			Name:     configurationFieldMachineType,
 			Label:    "Machine type",
@@ -97,7 +97,7 @@ func (c *Runner) Configuration() []configuration.Field {
 			Required: true,
 			TypeOptions: &configuration.TypeOptions{
 				Select: &configuration.SelectTypeOptions{
-					Options: machineTypeSelectOptions,
+					Options: machineTypeSelectOptions(),
 				},
 			},
 		},
@@ -253,6 +253,8 @@ func (c *Runner) Configuration() []configuration.Field {
 			},
 		},
 	}
+
+	return append(fields, storageConfigurationFields()...)
 }
 
 func intPtr(v int) *int {
@@ -302,11 +304,20 @@ func (c *Runner) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
+	if err := ensureFactoryBudgetAvailable(ctx); err != nil {
+		return err
+	}
+
 	broker, err := NewBrokerClient(ctx.HTTP)
 	if err != nil {
 		return fmt.Errorf("new broker client: %w", err)
 	}
 
+	storage, err := taskStorage(ctx, spec.StorageSpec, spec.ExecutionTimeoutSeconds)
+	if err != nil {
+		return err
+	}
+
 	mode := normalizeExecutionMode(spec.ExecutionMode)
 	params := CreateTaskParams{
 		MachineType:    spec.MachineType,
@@ -317,6 +328,7 @@ func (c *Runner) Execute(ctx core.ExecutionContext) error {
 		DockerImage:    resolvedDockerImageRef(spec),
 		TimeoutSeconds: spec.ExecutionTimeoutSeconds,
 		Labels:         OriginLabelsForTask(ctx),
+		Storage:        storage,
 	}
 
 	taskID, err := broker.CreateTask(params)
@@ -75,7 +75,7 @@ function main() {
 - **Script**: JavaScript source executed by Node.js.
 - **Setup commands**: Optional shell commands (one per line) run before the script in the same environment and working directory.
 - **Environment variables**: Optional key/value pairs available during execution.
-
+` + storageDocumentation() + `
 ## Output channels
 - **Passed**: The script finished with exit code **0**.
 - **Failed**: The script finished with non-zero exit code.
@@ -87,7 +87,7 @@ var setupCommandsEnabledOnly = []configuration.VisibilityCondition{
 }
 
 func (c *RunJS) Configuration() []configuration.Field {
-	return []configuration.Field{
+	fields := []configuration.Field{
 		{
 			Name:     configurationFieldMachineType,
 			Label:    "Machine type",
@@ -95,7 +95,7 @@ func (c *RunJS) Configuration() []configuration.Field {
 			Required: true,
 			TypeOptions: &configuration.TypeOptions{
 				Select: &configuration.SelectTypeOptions{
-					Options: machineTypeSelectOptions,
+					Options: machineTypeSelectOptions(),
 				},
 			},
 		},
@@ -271,6 +271,8 @@ func (c *RunJS) Configuration() []configuration.Field {
 			},
 		},
 	}
+
+	return append(fields, storageConfigurationFields()...)
 }
 
 func (c *RunJS) Setup(ctx core.SetupContext) error {
@@ -316,11 +318,20 @@ func (c *RunJS) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
+	if err := ensureFactoryBudgetAvailable(ctx); err != nil {
+		return err
+	}
+
 	broker, err := NewBrokerClient(ctx.HTTP)
 	if err != nil {
 		return fmt.Errorf("new broker client: %w", err)
 	}
 
+	storage, err := taskStorage(ctx, spec.StorageSpec, spec.ExecutionTimeoutSeconds)
+	if err != nil {
+		return err
+	}
+
 	mode := normalizeExecutionMode(spec.ExecutionMode)
 	var setupCommands []string
 	if spec.EnableSetupCommands {
@@ -339,6 +350,7 @@ func (c *RunJS) Execute(ctx core.ExecutionContext) error {
 		DockerImage:    resolvedRunJSDockerImageRef(spec),
 		TimeoutSeconds: spec.ExecutionTimeoutSeconds,
 		Labels:         OriginLabelsForTask(ctx),
+		Storage:        storage,
 	}
 
 	taskID, err := broker.CreateTask(params)
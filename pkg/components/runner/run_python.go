@@ -74,7 +74,7 @@ def main(payload):
 - **Script**: Python source executed by Python 3.
 - **Setup commands**: Optional shell commands (one per line) run before the script in the same environment and working directory.
 - **Environment variables**: Optional key/value pairs available during execution.
-
+` + storageDocumentation() + `
 ## Output channels
 - **Passed**: The script finished with exit code **0**.
 - **Failed**: The script finished with non-zero exit code.
@@ -82,7 +82,7 @@ def main(payload):
 }
 
 func (c *RunPython) Configuration() []configuration.Field {
-	return []configuration.Field{
+	fields := []configuration.Field{
 		{
 			Name:     configurationFieldMachineType,
 			Label:    "Machine type",
@@ -90,7 +90,7 @@ func (c *RunPython) Configuration() []configuration.Field {
 			Required: true,
 			TypeOptions: &configuration.TypeOptions{
 				Select: &configuration.SelectTypeOptions{
-					Options: machineTypeSelectOptions,
+					Options: machineTypeSelectOptions(),
 				},
 			},
 		},
@@ -266,6 +266,8 @@ func (c *RunPython) Configuration() []configuration.Field {
 			},
 		},
 	}
+
+	return append(fields, storageConfigurationFields()...)
 }
 
 func (c *RunPython) Setup(ctx core.SetupContext) error {
@@ -311,11 +313,20 @@ func (c *RunPython) Execute(ctx core.ExecutionContext) error {
 		return err
 	}
 
+	if err := ensureFactoryBudgetAvailable(ctx); err != nil {
+		return err
+	}
+
 	broker, err := NewBrokerClient(ctx.HTTP)
 	if err != nil {
 		return fmt.Errorf("new broker client: %w", err)
 	}
 
+	storage, err := taskStorage(ctx, spec.StorageSpec, spec.ExecutionTimeoutSeconds)
+	if err != nil {
+		return err
+	}
+
 	mode := normalizeExecutionMode(spec.ExecutionMode)
 	var setupCommands []string
 	if spec.EnableSetupCommands {
@@ -334,6 +345,7 @@ func (c *RunPython) Execute(ctx core.ExecutionContext) error {
 		DockerImage:    resolvedRunPythonDockerImageRef(spec),
 		TimeoutSeconds: spec.ExecutionTimeoutSeconds,
 		Labels:         OriginLabelsForTask(ctx),
+		Storage:        storage,
 	}
 
 	taskID, err := broker.CreateTask(params)
@@ -17,6 +17,7 @@ type RunPythonSpec struct {
 	DockerImagePreset       string                 `mapstructure:"docker_image_preset"`
 	DockerImage             string                 `mapstructure:"docker_image"`
 	ExecutionTimeoutSeconds int                    `mapstructure:"execution_timeout_seconds"` // 0 = DefaultExecutionTimeoutSeconds
+	StorageSpec             `mapstructure:",squash"`
 }
 
 func decodeRunPythonSpec(raw any) (RunPythonSpec, error) {
@@ -72,6 +73,10 @@ func validateRunPythonSpec(spec RunPythonSpec) error {
 		return err
 	}
 
+	if err := validateStorageSpec(spec.StorageSpec); err != nil {
+		return err
+	}
+
 	if spec.EnableSetupCommands {
 		if err := validateCommands(spec.SetupCommands); err != nil {
 			return fmt.Errorf("setup commands: %w", err)
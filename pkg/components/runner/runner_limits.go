@@ -3,6 +3,7 @@ package runner
 import (
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/superplanehq/superplane/pkg/core"
 	"google.golang.org/grpc/status"
 )
@@ -17,6 +18,17 @@ func SetRunnerMinutesLimitChecker(checker RunnerMinutesLimitChecker) {
 	runnerMinutesLimitChecker = checker
 }
 
+// FactoryBudgetChecker blocks starting a new runner task when the work order
+// or factory line the execution runs for has spent its budget. Wired from
+// process startup so this package does not import models directly.
+type FactoryBudgetChecker func(workflowID string, executionID uuid.UUID) error
+
+var factoryBudgetChecker FactoryBudgetChecker
+
+func SetFactoryBudgetChecker(checker FactoryBudgetChecker) {
+	factoryBudgetChecker = checker
+}
+
 func ensureRunnerMinutesAvailable(ctx core.ExecutionContext) error {
 	if runnerMinutesLimitChecker == nil {
 		return nil
@@ -31,3 +43,11 @@ func ensureRunnerMinutesAvailable(ctx core.ExecutionContext) error {
 	// canvas failure reason verbatim, so keep only the human-readable message.
 	return errors.New(status.Convert(err).Message())
 }
+
+func ensureFactoryBudgetAvailable(ctx core.ExecutionContext) error {
+	if factoryBudgetChecker == nil {
+		return nil
+	}
+
+	return factoryBudgetChecker(ctx.WorkflowID, ctx.ID)
+}
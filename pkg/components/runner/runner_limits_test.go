@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/superplanehq/superplane/pkg/core"
@@ -46,3 +47,32 @@ func setRunnerMinutesLimitCheckerForTest(t *testing.T, checker RunnerMinutesLimi
 	runnerMinutesLimitChecker = checker
 	t.Cleanup(func() { runnerMinutesLimitChecker = previous })
 }
+
+func TestEnsureFactoryBudgetAvailable(t *testing.T) {
+	t.Run("no checker allows the task", func(t *testing.T) {
+		setFactoryBudgetCheckerForTest(t, nil)
+
+		require.NoError(t, ensureFactoryBudgetAvailable(core.ExecutionContext{WorkflowID: "canvas-id", ID: uuid.New()}))
+	})
+
+	t.Run("checker receives the execution and its error is returned", func(t *testing.T) {
+		executionID := uuid.New()
+		setFactoryBudgetCheckerForTest(t, func(workflowID string, id uuid.UUID) error {
+			assert.Equal(t, "canvas-id", workflowID)
+			assert.Equal(t, executionID, id)
+			return errors.New("factory spend budget exceeded")
+		})
+
+		err := ensureFactoryBudgetAvailable(core.ExecutionContext{WorkflowID: "canvas-id", ID: executionID})
+		require.Error(t, err)
+		assert.Equal(t, "factory spend budget exceeded", err.Error())
+	})
+}
+
+func setFactoryBudgetCheckerForTest(t *testing.T, checker FactoryBudgetChecker) {
+	t.Helper()
+
+	previous := factoryBudgetChecker
+	factoryBudgetChecker = checker
+	t.Cleanup(func() { factoryBudgetChecker = previous })
+}
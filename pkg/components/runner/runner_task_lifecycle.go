@@ -23,6 +23,8 @@ func afterRunnerTaskCreated(ctx core.ExecutionContext, taskID string) error {
 	return ctx.Requests.ScheduleActionCall(hookActionPoll, map[string]any{
 		"task_id":         taskID,
 		"organization_id": ctx.OrganizationID,
+		"execution_id":    ctx.ID.String(),
+		"base_url":        ctx.BaseURL,
 	}, pollInterval)
 }
 
@@ -36,19 +38,25 @@ func pollBrokerTask(ctx core.ActionHookContext, finishedEventType string) error
 		return fmt.Errorf("task_id is missing from parameters")
 	}
 	organizationID, _ := ctx.Parameters["organization_id"].(string)
+	executionID, _ := ctx.Parameters["execution_id"].(string)
+	baseURL, _ := ctx.Parameters["base_url"].(string)
 
 	broker, err := NewBrokerClient(ctx.HTTP)
 	if err != nil {
 		return fmt.Errorf("new broker client: %w", err)
 	}
 
+	pollParameters := map[string]any{
+		"task_id":         taskID,
+		"organization_id": organizationID,
+		"execution_id":    executionID,
+		"base_url":        baseURL,
+	}
+
 	task, err := broker.FetchTaskStatus(taskID)
 	if err != nil {
 		ctx.Logger.WithError(err).Warn("runner: broker poll failed, will retry")
-		return ctx.Requests.ScheduleActionCall(hookActionPoll, map[string]any{
-			"task_id":         taskID,
-			"organization_id": organizationID,
-		}, pollInterval)
+		return ctx.Requests.ScheduleActionCall(hookActionPoll, pollParameters, pollInterval)
 	}
 
 	sink := taskLogFromBrokerTask(task)
@@ -57,13 +65,11 @@ func pollBrokerTask(ctx core.ActionHookContext, finishedEventType string) error
 	}
 
 	if task.IsInTerminalState() {
-		return processBrokerTaskStatus(ctx.ExecutionState, task, finishedEventType, organizationID, ctx.Logger)
+		artifacts := runArtifactsOutput(executionID, baseURL, ctx.Logger)
+		return finishBrokerTask(ctx.ExecutionState, task, finishedEventType, organizationID, artifacts, ctx.Logger)
 	}
 
-	return ctx.Requests.ScheduleActionCall(hookActionPoll, map[string]any{
-		"task_id":         taskID,
-		"organization_id": organizationID,
-	}, pollInterval)
+	return ctx.Requests.ScheduleActionCall(hookActionPoll, pollParameters, pollInterval)
 }
 
 func handleBrokerWebhook(ctx core.WebhookRequestContext, finishedEventType string) (int, *core.WebhookResponseBody, error) {
@@ -102,7 +108,8 @@ func handleBrokerWebhook(ctx core.WebhookRequestContext, finishedEventType strin
 		}
 	}
 
-	if err := processBrokerTaskStatus(executionCtx.ExecutionState, task, finishedEventType, executionCtx.OrganizationID, ctx.Logger); err != nil {
+	artifacts := runArtifactsOutput(executionCtx.ID.String(), executionCtx.BaseURL, ctx.Logger)
+	if err := finishBrokerTask(executionCtx.ExecutionState, task, finishedEventType, executionCtx.OrganizationID, artifacts, ctx.Logger); err != nil {
 		return http.StatusInternalServerError, nil, fmt.Errorf("process task status: %w", err)
 	}
 
@@ -115,6 +122,19 @@ func processBrokerTaskStatus(
 	finishedEventType string,
 	organizationID string,
 	logger *log.Entry,
+) error {
+	return finishBrokerTask(state, task, finishedEventType, organizationID, nil, logger)
+}
+
+// finishBrokerTask emits the finished event for a terminal task, listing
+// the artifacts the task uploaded when there are any.
+func finishBrokerTask(
+	state core.ExecutionStateContext,
+	task *Task,
+	finishedEventType string,
+	organizationID string,
+	artifacts []any,
+	logger *log.Entry,
 ) error {
 	if state.IsFinished() {
 		return nil
@@ -138,6 +158,9 @@ func processBrokerTaskStatus(
 	if v := brokerResultAsAny(task.Result); v != nil {
 		out["result"] = v
 	}
+	if len(artifacts) > 0 {
+		out["artifacts"] = artifacts
+	}
 	return state.Emit(channel, finishedEventType, []any{out})
 }
 
@@ -42,6 +42,7 @@ type Spec struct {
 	DockerImagePreset       string                 `mapstructure:"docker_image_preset"`
 	DockerImage             string                 `mapstructure:"docker_image"`
 	ExecutionTimeoutSeconds int                    `mapstructure:"execution_timeout_seconds"` // 0 = DefaultExecutionTimeoutSeconds
+	StorageSpec             `mapstructure:",squash"`
 }
 
 func NewSpecDecoder(result any) (*mapstructure.Decoder, error) {
@@ -115,6 +116,10 @@ func validateRunnerSpec(spec Spec) error {
 		return err
 	}
 
+	if err := validateStorageSpec(spec.StorageSpec); err != nil {
+		return err
+	}
+
 	if strings.TrimSpace(spec.MachineType) == "" {
 		return fmt.Errorf("machine type is required")
 	}
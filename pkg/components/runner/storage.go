@@ -0,0 +1,352 @@
+package runner
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	StorageTokenPurpose  = "runner_storage"
+	StorageTokenAudience = "superplane"
+
+	// RunnerStorageAPIPath is where runners upload and fetch artifacts and
+	// caches, relative to the SuperPlane base URL.
+	RunnerStorageAPIPath = "/api/v1/runner-storage"
+
+	// storageTokenGrace keeps the token valid while the runner uploads
+	// after a task that used its whole execution timeout.
+	storageTokenGrace = time.Hour
+
+	maxArtifactsPerTask = 10
+	maxCacheKeyChars    = 256
+)
+
+var artifactNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+// ValidArtifactName reports whether name can be used for an artifact.
+// Names are at most 128 characters, the size of the stored column.
+func ValidArtifactName(name string) bool {
+	return artifactNamePattern.MatchString(name)
+}
+
+// StorageSpec is the artifacts and cache part of runner component
+// configuration. Runner specs embed it with mapstructure squash.
+type StorageSpec struct {
+	Artifacts         []ArtifactSpec `mapstructure:"artifacts"`
+	DownloadArtifacts bool           `mapstructure:"download_artifacts"`
+	CacheKey          string         `mapstructure:"cache_key"`
+	CachePaths        string         `mapstructure:"cache_paths"`
+}
+
+// ArtifactSpec declares one output artifact: the files matched by Paths
+// (one glob per line, relative to the task directory) under Name.
+type ArtifactSpec struct {
+	Name  string `mapstructure:"name"`
+	Paths string `mapstructure:"paths"`
+}
+
+// BrokerTaskStorage tells the runner where to upload artifacts and caches
+// and what to restore before the task starts.
+type BrokerTaskStorage struct {
+	APIURL            string                `json:"api_url"`
+	Token             string                `json:"token"`
+	UploadArtifacts   []BrokerTaskArtifact  `json:"upload_artifacts,omitempty"`
+	DownloadArtifacts bool                  `json:"download_artifacts,omitempty"`
+	Cache             *BrokerTaskCacheEntry `json:"cache,omitempty"`
+}
+
+type BrokerTaskArtifact struct {
+	Name  string   `json:"name"`
+	Paths []string `json:"paths"`
+}
+
+type BrokerTaskCacheEntry struct {
+	Key   string   `json:"key"`
+	Paths []string `json:"paths"`
+}
+
+// StorageTokenClaims scope a runner storage token to one node execution.
+type StorageTokenClaims struct {
+	ExecutionID    string `json:"execution_id"`
+	CanvasID       string `json:"canvas_id"`
+	OrganizationID string `json:"organization_id"`
+	Purpose        string `json:"purpose"`
+	gojwt.RegisteredClaims
+}
+
+func storageConfigurationFields() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "artifacts",
+			Label:       "Artifacts",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Files uploaded after the task succeeds. They are kept with the run and downstream runner tasks can download them.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Artifact",
+					MaxItems:  intPtr(maxArtifactsPerTask),
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:        "name",
+								Label:       "Name",
+								Type:        configuration.FieldTypeString,
+								Description: "Letters, numbers, dots, dashes and underscores",
+								Placeholder: "e.g. build-output",
+								Required:    true,
+							},
+							{
+								Name:        "paths",
+								Label:       "Paths",
+								Type:        configuration.FieldTypeText,
+								Description: "One glob per line, relative to SUPERPLANE_TASK_DIR",
+								Placeholder: "dist/**\ncoverage.xml",
+								Required:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "download_artifacts",
+			Label:       "Download run artifacts",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Extract artifacts uploaded earlier in the same run into the task directory before the task starts.",
+		},
+		{
+			Name:        "cache_key",
+			Label:       "Cache key",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "e.g. deps-{{ root().data.head_commit.id }}",
+			Description: "Restores the cache saved under this key before the task and saves it again after a successful run. Supports expressions.",
+			TypeOptions: &configuration.TypeOptions{
+				String: &configuration.StringTypeOptions{
+					MaxLength: intPtr(maxCacheKeyChars),
+				},
+			},
+		},
+		{
+			Name:        "cache_paths",
+			Label:       "Cache paths",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Placeholder: "node_modules/**",
+			Description: "One glob per line, relative to SUPERPLANE_TASK_DIR",
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "cache_key", Values: []string{"*"}},
+			},
+		},
+	}
+}
+
+func storageDocumentation() string {
+	return `
+## Artifacts and caches
+- **Artifacts**: Named file sets (globs relative to ` + "`SUPERPLANE_TASK_DIR`" + `) uploaded after the task succeeds. They are kept with the run, listed on the run and downloadable with ` + "`superplane runs download-artifact`" + `. The finished event lists them under **artifacts**.
+- **Download run artifacts**: Extracts every artifact uploaded earlier in the same run into the task directory before the task starts.
+- **Cache key** / **Cache paths**: Files matched by the cache paths are restored before the task when a cache with the same key exists on this canvas, and saved after a successful run. Caches unused for a week are evicted.
+`
+}
+
+func splitStoragePaths(paths string) []string {
+	var out []string
+	for _, line := range strings.Split(paths, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+func validateStorageSpec(spec StorageSpec) error {
+	if len(spec.Artifacts) > maxArtifactsPerTask {
+		return fmt.Errorf("at most %d artifacts can be declared", maxArtifactsPerTask)
+	}
+
+	names := map[string]bool{}
+	for _, artifact := range spec.Artifacts {
+		name := strings.TrimSpace(artifact.Name)
+		if !ValidArtifactName(name) {
+			return fmt.Errorf("invalid artifact name %q", artifact.Name)
+		}
+		if names[name] {
+			return fmt.Errorf("duplicate artifact name %q", name)
+		}
+		names[name] = true
+
+		if err := validateStoragePaths(artifact.Paths); err != nil {
+			return fmt.Errorf("artifact %s: %w", name, err)
+		}
+	}
+
+	if strings.TrimSpace(spec.CacheKey) == "" {
+		return nil
+	}
+	if len(spec.CacheKey) > maxCacheKeyChars {
+		return fmt.Errorf("cache key must be at most %d characters", maxCacheKeyChars)
+	}
+	if err := validateStoragePaths(spec.CachePaths); err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+	return nil
+}
+
+func validateStoragePaths(paths string) error {
+	lines := splitStoragePaths(paths)
+	if len(lines) == 0 {
+		return fmt.Errorf("at least one path is required")
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "/") || line == ".." || strings.HasPrefix(line, "../") || strings.Contains(line, "/../") {
+			return fmt.Errorf("path %q must stay inside the task directory", line)
+		}
+	}
+	return nil
+}
+
+// taskStorage builds the storage section of a broker task, or nil when the
+// node neither declares artifacts nor uses a cache.
+func taskStorage(ctx core.ExecutionContext, spec StorageSpec, timeoutSeconds int) (*BrokerTaskStorage, error) {
+	cacheKey := strings.TrimSpace(spec.CacheKey)
+	if len(spec.Artifacts) == 0 && !spec.DownloadArtifacts && cacheKey == "" {
+		return nil, nil
+	}
+
+	baseURL := strings.TrimRight(strings.TrimSpace(ctx.BaseURL), "/")
+	if baseURL == "" {
+		return nil, fmt.Errorf("artifacts and caches need the SuperPlane base URL")
+	}
+
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = DefaultExecutionTimeoutSeconds
+	}
+	expiresAt := time.Now().Add(time.Duration(timeoutSeconds)*time.Second + storageTokenGrace)
+	token, err := MintStorageToken(ctx.ID.String(), ctx.WorkflowID, ctx.OrganizationID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	storage := &BrokerTaskStorage{
+		APIURL:            baseURL + RunnerStorageAPIPath,
+		Token:             token,
+		DownloadArtifacts: spec.DownloadArtifacts,
+	}
+	for _, artifact := range spec.Artifacts {
+		storage.UploadArtifacts = append(storage.UploadArtifacts, BrokerTaskArtifact{
+			Name:  strings.TrimSpace(artifact.Name),
+			Paths: splitStoragePaths(artifact.Paths),
+		})
+	}
+	if cacheKey != "" {
+		storage.Cache = &BrokerTaskCacheEntry{Key: cacheKey, Paths: splitStoragePaths(spec.CachePaths)}
+	}
+
+	return storage, nil
+}
+
+// MintStorageToken signs the token a runner uses to upload artifacts and
+// caches for one execution.
+func MintStorageToken(executionID, canvasID, organizationID string, expiresAt time.Time) (string, error) {
+	secret, err := taskBrokerAuthToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := StorageTokenClaims{
+		ExecutionID:    executionID,
+		CanvasID:       canvasID,
+		OrganizationID: organizationID,
+		Purpose:        StorageTokenPurpose,
+		RegisteredClaims: gojwt.RegisteredClaims{
+			Audience:  gojwt.ClaimStrings{StorageTokenAudience},
+			ExpiresAt: gojwt.NewNumericDate(expiresAt),
+			IssuedAt:  gojwt.NewNumericDate(now),
+			NotBefore: gojwt.NewNumericDate(now.Add(-time.Minute)),
+		},
+	}
+
+	token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("sign storage token: %w", err)
+	}
+	return token, nil
+}
+
+// ValidateStorageToken checks a runner storage token and returns its
+// claims.
+func ValidateStorageToken(tokenString string) (*StorageTokenClaims, error) {
+	secret, err := taskBrokerAuthToken()
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &StorageTokenClaims{}
+	token, err := gojwt.ParseWithClaims(tokenString, claims, func(token *gojwt.Token) (any, error) {
+		if _, ok := token.Method.(*gojwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method")
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+	if claims.Purpose != StorageTokenPurpose {
+		return nil, fmt.Errorf("invalid purpose")
+	}
+	if !claims.VerifyAudience(StorageTokenAudience, true) {
+		return nil, fmt.Errorf("invalid audience")
+	}
+	return claims, nil
+}
+
+// runArtifactsOutput lists the artifacts an execution uploaded in the shape
+// emitted on the finished event. The url downloads the artifact for users
+// with read access to the canvas, and the id attaches it to a work order
+// with addWorkOrderArtifact.
+func runArtifactsOutput(executionID, baseURL string, logger *log.Entry) []any {
+	id, err := uuid.Parse(executionID)
+	if err != nil {
+		return nil
+	}
+
+	artifacts, err := models.ListRunnerArtifactsForExecution(id)
+	if err != nil {
+		if logger != nil {
+			logger.WithError(err).Warn("runner: failed to list execution artifacts")
+		}
+		return nil
+	}
+
+	baseURL = strings.TrimRight(baseURL, "/")
+	out := make([]any, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		out = append(out, map[string]any{
+			"id":         artifact.ID.String(),
+			"name":       artifact.Name,
+			"size_bytes": artifact.SizeBytes,
+			"sha256":     artifact.SHA256,
+			"url":        baseURL + artifact.DownloadPath(),
+		})
+	}
+	return out
+}
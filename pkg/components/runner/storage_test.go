@@ -0,0 +1,113 @@
+package runner
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func TestMintAndValidateStorageToken(t *testing.T) {
+	t.Setenv("TASK_BROKER_AUTH_TOKEN", "storage-secret")
+
+	token, err := MintStorageToken("execution-1", "canvas-1", "org-1", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	claims, err := ValidateStorageToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "execution-1", claims.ExecutionID)
+	assert.Equal(t, "canvas-1", claims.CanvasID)
+	assert.Equal(t, "org-1", claims.OrganizationID)
+
+	expired, err := MintStorageToken("execution-1", "canvas-1", "org-1", time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	_, err = ValidateStorageToken(expired)
+	require.Error(t, err)
+
+	liveLogToken, _, err := MintLiveLogStreamToken("task-1", time.Now())
+	require.NoError(t, err)
+	_, err = ValidateStorageToken(liveLogToken)
+	require.Error(t, err)
+}
+
+func TestDecodeRunBashSpecStorage(t *testing.T) {
+	spec, err := decodeRunBashSpec(map[string]any{
+		"machine_type":       testRunnerMachineType,
+		"script":             "echo hi",
+		"artifacts":          []any{map[string]any{"name": "build", "paths": "dist/**\ncoverage.xml\n"}},
+		"download_artifacts": "true",
+		"cache_key":          "deps-abc",
+		"cache_paths":        "node_modules/**",
+	})
+	require.NoError(t, err)
+	require.NoError(t, validateRunBashSpec(spec))
+
+	assert.Equal(t, []ArtifactSpec{{Name: "build", Paths: "dist/**\ncoverage.xml\n"}}, spec.Artifacts)
+	assert.True(t, spec.DownloadArtifacts)
+	assert.Equal(t, "deps-abc", spec.CacheKey)
+}
+
+func TestValidateStorageSpec(t *testing.T) {
+	valid := StorageSpec{Artifacts: []ArtifactSpec{{Name: "build", Paths: "dist/**"}}}
+	require.NoError(t, validateStorageSpec(valid))
+
+	for name, spec := range map[string]StorageSpec{
+		"invalid artifact name":   {Artifacts: []ArtifactSpec{{Name: "../build", Paths: "dist"}}},
+		"duplicate artifact name": {Artifacts: []ArtifactSpec{{Name: "a", Paths: "x"}, {Name: "a", Paths: "y"}}},
+		"artifact without paths":  {Artifacts: []ArtifactSpec{{Name: "a", Paths: "\n"}}},
+		"absolute artifact path":  {Artifacts: []ArtifactSpec{{Name: "a", Paths: "/etc/passwd"}}},
+		"escaping cache path":     {CacheKey: "deps", CachePaths: "../outside"},
+		"cache without paths":     {CacheKey: "deps"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, validateStorageSpec(spec))
+		})
+	}
+}
+
+func TestValidArtifactName(t *testing.T) {
+	assert.True(t, ValidArtifactName("build"))
+	assert.True(t, ValidArtifactName("coverage-report_v2.tar.gz"))
+	assert.True(t, ValidArtifactName(strings.Repeat("a", 128)))
+
+	assert.False(t, ValidArtifactName(""))
+	assert.False(t, ValidArtifactName(strings.Repeat("a", 129)))
+	assert.False(t, ValidArtifactName(".hidden"))
+	assert.False(t, ValidArtifactName("../build"))
+	assert.False(t, ValidArtifactName("build output"))
+}
+
+func TestTaskStorage(t *testing.T) {
+	t.Setenv("TASK_BROKER_AUTH_TOKEN", "storage-secret")
+
+	ctx := core.ExecutionContext{
+		ID:             uuid.New(),
+		WorkflowID:     uuid.NewString(),
+		OrganizationID: uuid.NewString(),
+		BaseURL:        "https://superplane.example/",
+	}
+
+	storage, err := taskStorage(ctx, StorageSpec{}, 0)
+	require.NoError(t, err)
+	assert.Nil(t, storage)
+
+	storage, err = taskStorage(ctx, StorageSpec{
+		Artifacts:  []ArtifactSpec{{Name: "build", Paths: "dist/**\n\ncoverage.xml"}},
+		CacheKey:   " deps-abc ",
+		CachePaths: "node_modules",
+	}, 60)
+	require.NoError(t, err)
+	require.NotNil(t, storage)
+	assert.Equal(t, "https://superplane.example/api/v1/runner-storage", storage.APIURL)
+	assert.Equal(t, []BrokerTaskArtifact{{Name: "build", Paths: []string{"dist/**", "coverage.xml"}}}, storage.UploadArtifacts)
+	assert.Equal(t, &BrokerTaskCacheEntry{Key: "deps-abc", Paths: []string{"node_modules"}}, storage.Cache)
+
+	claims, err := ValidateStorageToken(storage.Token)
+	require.NoError(t, err)
+	assert.Equal(t, ctx.ID.String(), claims.ExecutionID)
+	assert.Equal(t, ctx.WorkflowID, claims.CanvasID)
+}
@@ -14,7 +14,7 @@ const (
 )
 
 func MachineTypeOptions() []configuration.FieldOption {
-	return machineTypeSelectOptions
+	return machineTypeSelectOptions()
 }
 
 func IntPtr(v int) *int { return intPtr(v) }
@@ -42,3 +42,7 @@ func ProcessBrokerTaskStatus(state core.ExecutionStateContext, task *Task, finis
 func EnsureRunnerMinutesAvailable(ctx core.ExecutionContext) error {
 	return ensureRunnerMinutesAvailable(ctx)
 }
+
+func EnsureFactoryBudgetAvailable(ctx core.ExecutionContext) error {
+	return ensureFactoryBudgetAvailable(ctx)
+}
@@ -25,6 +25,9 @@ type Client struct {
 	PrivateKey []byte
 	Passphrase []byte
 
+	// Optional OpenSSH certificate for the private key
+	Certificate []byte
+
 	// For password auth
 	Password []byte
 
@@ -76,6 +79,12 @@ func (c *Client) Connect() (*ssh.Client, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse private key: %w", err)
 		}
+		if len(c.Certificate) > 0 {
+			signer, err = certificateSigner(signer, c.Certificate)
+			if err != nil {
+				return nil, err
+			}
+		}
 		auth = []ssh.AuthMethod{ssh.PublicKeys(signer)}
 	case AuthMethodPassword:
 		auth = []ssh.AuthMethod{ssh.Password(string(c.Password))}
@@ -132,6 +141,27 @@ func (c *Client) getSigner() (ssh.Signer, error) {
 	)
 }
 
+// certificateSigner presents the signed certificate instead of
+// the bare public key when authenticating with the private key.
+func certificateSigner(signer ssh.Signer, certificate []byte) (ssh.Signer, error) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	cert, ok := publicKey.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("certificate is a plain public key, not an SSH certificate")
+	}
+
+	certSigner, err := ssh.NewCertSigner(cert, signer)
+	if err != nil {
+		return nil, fmt.Errorf("certificate does not match private key: %w", err)
+	}
+
+	return certSigner, nil
+}
+
 func parseSigner(keyBytes []byte, passphrase []byte) (ssh.Signer, error) {
 	if len(passphrase) > 0 {
 		return ssh.ParsePrivateKeyWithPassphrase(keyBytes, passphrase)
@@ -43,6 +43,10 @@ type AuthSpec struct {
 	PrivateKey configuration.SecretKeyRef `json:"privateKey" mapstructure:"privateKey"`
 	Passphrase configuration.SecretKeyRef `json:"passphrase" mapstructure:"passphrase"`
 	Password   configuration.SecretKeyRef `json:"password" mapstructure:"password"`
+
+	// Certificate is an optional OpenSSH certificate for the private key,
+	// e.g. one signed by a Vault SSH secrets engine for this run.
+	Certificate string `json:"certificate,omitempty" mapstructure:"certificate"`
 }
 
 type RetrySpec struct {
@@ -130,6 +134,7 @@ func (c *SSHCommand) Documentation() string {
 Choose **SSH key** or **Password**, then select the organization Secret and the key name within that secret that holds the credential.
 
 - **SSH key**: Secret key containing the private key (PEM/OpenSSH). Optionally a second secret+key for passphrase if the key is encrypted.
+  Optionally a signed OpenSSH certificate for that key, for hosts that trust a certificate authority (e.g. the output of Vault's **Sign SSH Key**).
 - **Password**: Secret key containing the password.
 
 ## Configuration
@@ -232,6 +237,15 @@ func (c *SSHCommand) Configuration() []configuration.Field {
 							Required:             false,
 							VisibilityConditions: sshKeyOnly,
 						},
+						{
+							Name:                 "certificate",
+							Label:                "Certificate",
+							Type:                 configuration.FieldTypeText,
+							Description:          "Signed OpenSSH certificate for the private key, if the host trusts a certificate authority",
+							Placeholder:          "e.g. ssh-ed25519-cert-v01@openssh.com AAAA...",
+							Required:             false,
+							VisibilityConditions: sshKeyOnly,
+						},
 						{
 							Name:                 "password",
 							Label:                "Password",
@@ -1061,5 +1075,7 @@ func (c *SSHCommand) createClientSSHKey(secrets core.SecretsContext, metadata Ex
 		return nil, fmt.Errorf("cannot get private key: %w", err)
 	}
 
-	return NewClientKey(metadata.Host, metadata.Port, metadata.User, privateKey, nil), nil
+	client := NewClientKey(metadata.Host, metadata.Port, metadata.User, privateKey, nil)
+	client.Certificate = []byte(strings.TrimSpace(metadata.Authentication.Certificate))
+	return client, nil
 }
@@ -1,6 +1,8 @@
 package ssh
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"golang.org/x/crypto/ssh"
 )
 
 // Legacy SSH nodes were saved before the commandSource field existed, so their
@@ -999,3 +1002,45 @@ func TestSSHCommand_Setup_RejectsPaddedCommandSource(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid command source")
 }
+
+func TestCertificateSigner(t *testing.T) {
+	newSigner := func(t *testing.T) ssh.Signer {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		signer, err := ssh.NewSignerFromKey(key)
+		require.NoError(t, err)
+		return signer
+	}
+
+	ca := newSigner(t)
+	userKey := newSigner(t)
+	cert := &ssh.Certificate{
+		Key:             userKey.PublicKey(),
+		CertType:        ssh.UserCert,
+		ValidPrincipals: []string{"ubuntu"},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	require.NoError(t, cert.SignCert(rand.Reader, ca))
+	signedKey := ssh.MarshalAuthorizedKey(cert)
+
+	t.Run("presents the certificate", func(t *testing.T) {
+		signer, err := certificateSigner(userKey, signedKey)
+		require.NoError(t, err)
+		assert.IsType(t, &ssh.Certificate{}, signer.PublicKey())
+	})
+
+	t.Run("certificate for another key is rejected", func(t *testing.T) {
+		_, err := certificateSigner(newSigner(t), signedKey)
+		require.ErrorContains(t, err, "certificate does not match private key")
+	})
+
+	t.Run("plain public key is rejected", func(t *testing.T) {
+		_, err := certificateSigner(userKey, ssh.MarshalAuthorizedKey(userKey.PublicKey()))
+		require.ErrorContains(t, err, "not an SSH certificate")
+	})
+
+	t.Run("garbage is rejected", func(t *testing.T) {
+		_, err := certificateSigner(userKey, []byte("not a certificate"))
+		require.ErrorContains(t, err, "failed to parse certificate")
+	})
+}
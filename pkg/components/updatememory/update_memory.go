@@ -78,6 +78,11 @@ func (c *UpdateMemory) Documentation() string {
 2. Updates all matching memory rows in a single SQL operation
 3. Emits ` + "`memory.updated`" + ` to the ` + "`found`" + ` or ` + "`notFound`" + ` channel
 
+## Organization Memory
+
+Prefix the namespace with ` + "`org:`" + `, for example ` + "`org:preview-environments`" + `, to update memory shared by every
+canvas of the organization. Updated rows record this canvas as their last writer.
+
 ## Output Channels
 
 - **Found**: At least one matching memory row was updated
@@ -120,7 +125,7 @@ func (c *UpdateMemory) Configuration() []configuration.Field {
 			Name:        "namespace",
 			Label:       "Namespace",
 			Type:        configuration.FieldTypeString,
-			Description: "Memory namespace to update in",
+			Description: "Memory namespace to update in. Use org:<namespace> for organization memory",
 			Required:    true,
 		},
 		{
@@ -399,8 +404,8 @@ func normalizeSpec(spec Spec) Spec {
 }
 
 func validateSpec(spec Spec) error {
-	if spec.Namespace == "" {
-		return fmt.Errorf("namespace is required")
+	if err := core.ValidateMemoryNamespace(spec.Namespace); err != nil {
+		return err
 	}
 	if len(buildPairs(spec.MatchList)) == 0 {
 		return fmt.Errorf("at least one memory match is required")
@@ -87,6 +87,11 @@ func (c *UpsertMemory) Documentation() string {
 If ` + "`matchList`" + ` is empty, the component treats the namespace as a singleton record and upserts at namespace level.
 This lets you store just one field (for example ` + "`value`" + `) without extra marker fields.
 
+## Organization Memory
+
+Prefix the namespace with ` + "`org:`" + `, for example ` + "`org:preview-environments`" + `, to upsert into memory shared by every
+canvas of the organization. Written rows record this canvas as their last writer.
+
 ## Output
 
 Always emits to the default channel. Check ` + "`data.operation`" + ` to know whether the component updated existing rows or created a new row.
@@ -125,7 +130,7 @@ func (c *UpsertMemory) Configuration() []configuration.Field {
 			Name:        "namespace",
 			Label:       "Namespace",
 			Type:        configuration.FieldTypeString,
-			Description: "Memory namespace to upsert in",
+			Description: "Memory namespace to upsert in. Use org:<namespace> for organization memory",
 			Required:    true,
 		},
 		{
@@ -486,8 +491,8 @@ func normalizeSpec(spec Spec) Spec {
 }
 
 func validateSpec(spec Spec) error {
-	if spec.Namespace == "" {
-		return fmt.Errorf("namespace is required")
+	if err := core.ValidateMemoryNamespace(spec.Namespace); err != nil {
+		return err
 	}
 	if len(buildPairs(spec.ValueList)) == 0 {
 		return fmt.Errorf("at least one memory value update is required")
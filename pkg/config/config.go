@@ -52,3 +52,28 @@ func LoadAnthropicAgentConfig() AnthropicAgentConfig {
 func (c AnthropicAgentConfig) Enabled() bool {
 	return c.APIKey != "" && c.AgentID != "" && c.EnvironmentID != ""
 }
+
+// OpenAIAgentConfig points the canvas agent at an OpenAI-compatible
+// chat-completions endpoint. BaseURL defaults to the OpenAI API when empty;
+// APIKey may be empty for self-hosted endpoints without authentication.
+type OpenAIAgentConfig struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+}
+
+// LoadOpenAIAgentConfig reads the env vars for the OpenAI-compatible agent
+// provider. It is only used when the Anthropic provider is not configured.
+func LoadOpenAIAgentConfig() OpenAIAgentConfig {
+	return OpenAIAgentConfig{
+		BaseURL: os.Getenv("OPENAI_AGENT_BASE_URL"),
+		APIKey:  os.Getenv("OPENAI_AGENT_API_KEY"),
+		Model:   os.Getenv("OPENAI_AGENT_MODEL"),
+	}
+}
+
+// Enabled reports whether a model was configured for the OpenAI-compatible
+// provider.
+func (c OpenAIAgentConfig) Enabled() bool {
+	return c.Model != ""
+}
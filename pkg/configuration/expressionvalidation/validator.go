@@ -148,10 +148,41 @@ func checkTopLevelCall(name string, args []ast.Node) error {
 		if len(args) != 0 {
 			return fmt.Errorf("order() takes no arguments, got %d", len(args))
 		}
+	default:
+		if fn, ok := exprruntime.LookupFunction(name); ok {
+			return checkStandardLibraryCall(fn, args)
+		}
 	}
 	return nil
 }
 
+// checkStandardLibraryCall checks the arity, and string literal arguments
+// like regular expressions and queries, which are cheap to verify up front.
+// Argument types are checked when the expression is compiled.
+func checkStandardLibraryCall(fn exprruntime.Function, args []ast.Node) error {
+	if err := fn.CheckArity(len(args)); err != nil {
+		return err
+	}
+
+	for i, arg := range args {
+		validate, ok := fn.LiteralArgs[i]
+		if !ok {
+			continue
+		}
+
+		literal, ok := arg.(*ast.StringNode)
+		if !ok {
+			continue
+		}
+
+		if err := validate(literal.Value); err != nil {
+			return fmt.Errorf("%s() argument %d: %w", fn.Name, i+1, err)
+		}
+	}
+
+	return nil
+}
+
 func checkMemoryCall(method string, args []ast.Node) error {
 	switch method {
 	case "find", "findFirst":
@@ -190,6 +221,8 @@ func compileWithStubEnv(body string, knownNodeNames map[string]struct{}, extraEn
 		expr.Function("order", func(params ...any) (any, error) { return nil, nil }),
 	}
 
+	opts = append(opts, exprruntime.FunctionOptions()...)
+
 	if _, err := expr.Compile(body, opts...); err != nil {
 		return fmt.Errorf("compile error: %s", firstLine(err.Error()))
 	}
@@ -111,3 +111,23 @@ func TestValidateExpression_UnknownIdentifier(t *testing.T) {
 		{name: "wrong case memory", raw: `Memory.find('ns', {})`, wantErr: "compile error"},
 	})
 }
+
+func TestValidateExpression_StandardLibrary(t *testing.T) {
+	runExprCases(t, "standard_library", []exprCase{
+		{name: "regex match", raw: `regexMatch($['Build'].ref, '^refs/tags/v')`, knownNames: []string{"Build"}},
+		{name: "semver bump", raw: `semverBump(semver('1.2.3').version, 'minor')`},
+		{name: "jmespath lookup", raw: `jmesPath(root(), 'items[?ok].name')`},
+		{name: "humanize duration", raw: `humanizeDuration(durationBetween(run().started_at, now()))`},
+		{name: "csv without header", raw: `parseCSV(root().body, false)[0]`},
+		{name: "bool result in condition", raw: `regexMatch(root().ref, 'main') && true`},
+		{name: "too few args", raw: `regexReplace('a', 'b')`, wantErr: "regexReplace() takes 3 arguments, got 2"},
+		{name: "too many args", raw: `slugify('a', 'b')`, wantErr: "slugify() takes 1 argument, got 2"},
+		{name: "invalid regex literal", raw: `regexMatch(root().ref, '[')`, wantErr: "regexMatch() argument 2: invalid regular expression"},
+		{name: "invalid jmespath literal", raw: `jmesPath(root(), 'items[')`, wantErr: "jmesPath() argument 2: invalid JMESPath query"},
+		{name: "invalid jsonpath literal", raw: `jsonPath(root(), 'items')`, wantErr: "must start with $"},
+		{name: "invalid version part", raw: `semverBump('1.0.0', 'build')`, wantErr: "invalid version part"},
+		{name: "invalid duration literal", raw: `parseDuration('soon')`, wantErr: "invalid duration"},
+		{name: "wrong argument type", raw: `slugify(42)`, wantErr: "compile error"},
+		{name: "wrong optional argument type", raw: `parseCSV('a,b', 'yes')`, wantErr: "compile error"},
+	})
+}
@@ -1,9 +1,13 @@
 package core
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -72,6 +76,22 @@ type HTTPContext interface {
 	Do(*http.Request) (*http.Response, error)
 }
 
+type tlsConfigKey struct{}
+
+/*
+ * WithTLSConfig returns a copy of the request that the HTTPContext sends
+ * with its own TLS configuration. Services with a private certificate authority
+ * or that authenticate clients with certificates, like Kubernetes API servers, need it.
+ */
+func WithTLSConfig(request *http.Request, config *tls.Config) *http.Request {
+	return request.WithContext(context.WithValue(request.Context(), tlsConfigKey{}, config))
+}
+
+func TLSConfigFromRequest(request *http.Request) *tls.Config {
+	config, _ := request.Context().Value(tlsConfigKey{}).(*tls.Config)
+	return config
+}
+
 /*
  * ExecutionContext allows the component
  * to control the state and metadata of each execution of it.
@@ -95,6 +115,35 @@ type CanvasMemoryContext interface {
 	FindFirst(namespace string, matches map[string]any) (any, error)
 }
 
+// OrganizationMemoryPrefix marks a memory namespace reference as
+// organization memory, which every canvas of the organization shares.
+const OrganizationMemoryPrefix = "org:"
+
+// ParseMemoryNamespace splits a namespace reference such as org:previews
+// into the namespace name and whether it refers to organization memory.
+func ParseMemoryNamespace(reference string) (string, bool) {
+	reference = strings.TrimSpace(reference)
+	name, organization := strings.CutPrefix(reference, OrganizationMemoryPrefix)
+	if !organization {
+		return reference, false
+	}
+
+	return strings.TrimSpace(name), true
+}
+
+func ValidateMemoryNamespace(reference string) error {
+	name, organization := ParseMemoryNamespace(reference)
+	if name != "" {
+		return nil
+	}
+
+	if organization {
+		return fmt.Errorf("namespace is required after %q", OrganizationMemoryPrefix)
+	}
+
+	return fmt.Errorf("namespace is required")
+}
+
 type RepositoryFilesContext interface {
 	List() ([]string, error)
 	Read(path string) (io.ReadCloser, error)
@@ -105,6 +154,16 @@ type CanvasMemoryRecord struct {
 	Values any
 }
 
+// CanvasMemorySwap is a compare-and-swap on the newest record containing
+// Matches. A nil Expected means no such record may exist yet, otherwise
+// the record must contain Expected. Values are written when the swap applies.
+type CanvasMemorySwap struct {
+	Matches  map[string]any
+	Expected map[string]any
+	Values   map[string]any
+	TTL      *time.Duration
+}
+
 /*
  * ExecutionStateContext allows components to control execution lifecycle.
  */
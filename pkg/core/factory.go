@@ -20,6 +20,10 @@ type FactoryContext interface {
 	UpdateWorkOrderStatus(params UpdateWorkOrderStatusParams) (order *WorkOrder, changed bool, err error)
 	AddWorkOrderComment(params AddWorkOrderCommentParams) error
 	AddWorkOrderArtifact(params AddWorkOrderArtifactParams) (*WorkOrderArtifact, error)
+	// AddRunnerArtifactToWorkOrder attaches an artifact a runner task of
+	// the same canvas uploaded, as a link artifact to its download URL.
+	// The link stops working once the runner artifact expires.
+	AddRunnerArtifactToWorkOrder(params AddRunnerArtifactToWorkOrderParams) (*WorkOrderArtifact, error)
 	// UpdateWorkOrderArtifact merges Data into an artifact already
 	// attached to the work order, resolved by the key it was given at
 	// attach time (AddWorkOrderArtifactParams.Key). This is how a
@@ -82,6 +86,19 @@ type AddWorkOrderArtifactParams struct {
 	Key string
 }
 
+type AddRunnerArtifactToWorkOrderParams struct {
+	// OrderID identifies the work order to target; see
+	// UpdateWorkOrderStatusParams.OrderID.
+	OrderID string
+	// RunnerArtifactID is the id of the runner artifact, as emitted in
+	// the `artifacts` list of a runner task's finished event.
+	RunnerArtifactID string
+	// Title labels the artifact chip; defaults to the artifact's name.
+	Title string
+	// Key works like AddWorkOrderArtifactParams.Key.
+	Key string
+}
+
 // UpdateWorkOrderArtifactParams targets an existing artifact by the key
 // it was attached with, rather than by id — the same key
 // FindWorkOrder(by: artifactKey) uses, typically a pull request's URL.
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+/*
+ * IntegrationGitRemoteContext is the context given to integrations when resolving git remotes.
+ */
+type IntegrationGitRemoteContext struct {
+	Logger      *logrus.Entry
+	HTTP        HTTPContext
+	Integration IntegrationContext
+}
+
+/*
+ * GitRemote describes how to reach a repository with the git smart HTTP protocol.
+ * Username and Password are sent with HTTP basic authentication.
+ */
+type GitRemote struct {
+	URL      string
+	Username string
+	Password string
+}
+
+/*
+ * IntegrationGitRemoteProvider is an optional integration capability for resolving
+ * clone URLs and credentials, so SuperPlane can fetch from and push to repositories
+ * hosted in the external system. The repository is given in the integration's own
+ * format, e.g. "owner/repo" for GitHub or "group/project" for GitLab.
+ */
+type IntegrationGitRemoteProvider interface {
+	ResolveGitRemote(ctx IntegrationGitRemoteContext, repository string) (*GitRemote, error)
+}
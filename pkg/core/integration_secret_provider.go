@@ -11,6 +11,13 @@ type IntegrationSecretContext struct {
 	Logger      *logrus.Entry
 	HTTP        HTTPContext
 	Integration IntegrationContext
+
+	//
+	// Leases records the leases of credentials issued while resolving secrets,
+	// so they are revoked when the run that imported them finishes.
+	// It is nil when the secrets are not resolved for a run.
+	//
+	Leases IntegrationLeaseRecorder
 }
 
 /*
@@ -20,3 +27,34 @@ type IntegrationSecretContext struct {
 type IntegrationSecretProvider interface {
 	ResolveSecrets(ctx IntegrationSecretContext) (map[string][]byte, error)
 }
+
+/*
+ * IntegrationLease is a lease on credentials an integration issued for a run.
+ * It never holds the credentials themselves.
+ */
+type IntegrationLease struct {
+	ID     string `json:"id" mapstructure:"id"`
+	Source string `json:"source" mapstructure:"source"`
+}
+
+type IntegrationLeaseRecorder interface {
+	Record(lease IntegrationLease) error
+}
+
+/*
+ * IntegrationLeaseContext is the context given to integrations when revoking leases.
+ */
+type IntegrationLeaseContext struct {
+	Logger      *logrus.Entry
+	HTTP        HTTPContext
+	Integration IntegrationContext
+}
+
+/*
+ * IntegrationLeaseRevoker is implemented by integrations that record leases
+ * while resolving secrets. Leases recorded for a run are revoked through it
+ * once the run finishes.
+ */
+type IntegrationLeaseRevoker interface {
+	RevokeLease(ctx IntegrationLeaseContext, leaseID string) error
+}
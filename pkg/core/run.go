@@ -17,6 +17,12 @@ type RunExecutionContext interface {
 	Create(params RunCreationParams) (*Run, error)
 	Cancel() error
 	AddError(message string) error
+
+	//
+	// Leases returns the leases the integration of the node
+	// issued to the current run while resolving secrets.
+	//
+	Leases() ([]IntegrationLease, error)
 }
 
 type Run struct {
@@ -221,7 +221,17 @@ func TruncateTables() error {
 			workflow_node_requests,
 			webhooks,
 			agent_sessions,
-			agent_session_messages
+			agent_session_messages,
+			task_broker_task_logs,
+			task_broker_tasks,
+			task_broker_runners,
+			runner_artifacts,
+			runner_caches,
+			canvas_git_mirrors,
+			canvas_git_mirror_events,
+			canvas_review_policies,
+			canvas_change_requests,
+			canvas_change_request_events
 		restart identity cascade;
 	`).Error
 }
@@ -0,0 +1,232 @@
+package exprruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationDaysPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)(w|d)`)
+
+func init() {
+	register(Function{
+		Name:        "parseDuration",
+		Signature:   "parseDuration(str)",
+		Description: "Parses a duration like duration() does, also accepting days (d) and weeks (w).",
+		Example:     `parseDuration("1d12h") == duration("36h")`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) time.Duration)},
+		LiteralArgs: map[int]func(string) error{0: validateDuration},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("parseDuration", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			d, err := ParseDuration(s)
+			if err != nil {
+				return nil, fmt.Errorf("parseDuration(): %w", err)
+			}
+
+			return d, nil
+		},
+	})
+
+	register(Function{
+		Name:        "addDuration",
+		Signature:   "addDuration(date, duration)",
+		Description: "Adds a duration to a date. Dates and durations can be given as strings.",
+		Example:     `addDuration("2026-03-17T10:00:00Z", "1d").Day() == 18`,
+		MinArgs:     2,
+		MaxArgs:     2,
+		Types:       []any{new(func(any, any) time.Time)},
+		Call: func(params ...any) (any, error) {
+			t, err := timeArg("addDuration", params[0])
+			if err != nil {
+				return nil, err
+			}
+
+			d, err := durationArg("addDuration", params[1])
+			if err != nil {
+				return nil, err
+			}
+
+			return t.Add(d), nil
+		},
+	})
+
+	register(Function{
+		Name:        "durationBetween",
+		Signature:   "durationBetween(start, end)",
+		Description: "Returns the duration from start to end. Dates can be given as strings.",
+		Example:     `durationBetween("2026-03-17T10:00:00Z", "2026-03-17T10:30:00Z").Minutes() == 30`,
+		MinArgs:     2,
+		MaxArgs:     2,
+		Types:       []any{new(func(any, any) time.Duration)},
+		Call: func(params ...any) (any, error) {
+			start, err := timeArg("durationBetween", params[0])
+			if err != nil {
+				return nil, err
+			}
+
+			end, err := timeArg("durationBetween", params[1])
+			if err != nil {
+				return nil, err
+			}
+
+			return end.Sub(start), nil
+		},
+	})
+
+	register(Function{
+		Name:        "humanizeDuration",
+		Signature:   "humanizeDuration(duration)",
+		Description: "Formats a duration for people, using its two largest units. Numbers are read as seconds.",
+		Example:     `humanizeDuration(duration("93m")) == "1h 33m"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(any) string)},
+		Call: func(params ...any) (any, error) {
+			d, err := durationArg("humanizeDuration", params[0])
+			if err != nil {
+				return nil, err
+			}
+
+			return HumanizeDuration(d), nil
+		},
+	})
+}
+
+// ParseDuration extends time.ParseDuration with days and weeks,
+// which are always 24 and 168 hours long.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	var total time.Duration
+	rest := durationDaysPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := durationDaysPattern.FindStringSubmatch(match)
+		value, _ := strconv.ParseFloat(parts[1], 64)
+		unit := 24 * time.Hour
+		if parts[2] == "w" {
+			unit *= 7
+		}
+
+		total += time.Duration(value * float64(unit))
+		return ""
+	})
+
+	if rest != "" {
+		d, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+
+		total += d
+	}
+
+	if negative {
+		total = -total
+	}
+
+	return total, nil
+}
+
+func validateDuration(s string) error {
+	_, err := ParseDuration(s)
+	return err
+}
+
+// HumanizeDuration keeps the two largest non-zero units,
+// e.g. "2d 3h" or "1m 30s". Durations under a second are shown in milliseconds.
+func HumanizeDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + HumanizeDuration(-d)
+	}
+
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+
+	units := []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	}
+
+	parts := []string{}
+	for _, unit := range units {
+		if len(parts) == 2 {
+			break
+		}
+
+		n := d / unit.size
+		if n == 0 {
+			if len(parts) > 0 {
+				break
+			}
+
+			continue
+		}
+
+		parts = append(parts, fmt.Sprintf("%d%s", n, unit.suffix))
+		d -= n * unit.size
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func durationArg(name string, value any) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		d, err := ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("%s(): %w", name, err)
+		}
+
+		return d, nil
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case int64:
+		return time.Duration(v) * time.Second, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("%s(): invalid number %q", name, v.String())
+		}
+
+		return time.Duration(f * float64(time.Second)), nil
+	default:
+		return 0, fmt.Errorf("%s() expects a duration, string or number of seconds, got %T", name, value)
+	}
+}
+
+func timeArg(name string, value any) (time.Time, error) {
+	t, ok, err := parseTime(value, time.UTC)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s(): %w", name, err)
+	}
+
+	if !ok {
+		return time.Time{}, fmt.Errorf("%s() expects a date, got nil", name)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,275 @@
+package exprruntime
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+)
+
+func init() {
+	register(Function{
+		Name:        "toBase64URL",
+		Signature:   "toBase64URL(str)",
+		Description: "Encodes the string with the URL-safe Base64 alphabet, without padding.",
+		Example:     `toBase64URL("a?b") == "YT9i"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) string)},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("toBase64URL", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			return base64.RawURLEncoding.EncodeToString([]byte(s)), nil
+		},
+	})
+
+	register(Function{
+		Name:        "fromBase64URL",
+		Signature:   "fromBase64URL(str)",
+		Description: "Decodes a URL-safe Base64 string, with or without padding.",
+		Example:     `fromBase64URL("YT9i") == "a?b"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) string)},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("fromBase64URL", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			decoded, err := base64.RawURLEncoding.DecodeString(trimBase64Padding(s))
+			if err != nil {
+				return nil, fmt.Errorf("fromBase64URL(): %w", err)
+			}
+
+			return string(decoded), nil
+		},
+	})
+
+	register(Function{
+		Name:        "toHex",
+		Signature:   "toHex(str)",
+		Description: "Encodes the string as lowercase hexadecimal.",
+		Example:     `toHex("hi") == "6869"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) string)},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("toHex", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			return hex.EncodeToString([]byte(s)), nil
+		},
+	})
+
+	register(Function{
+		Name:        "fromHex",
+		Signature:   "fromHex(str)",
+		Description: "Decodes a hexadecimal string.",
+		Example:     `fromHex("6869") == "hi"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) string)},
+		LiteralArgs: map[int]func(string) error{0: validateHex},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("fromHex", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			decoded, err := hex.DecodeString(s)
+			if err != nil {
+				return nil, fmt.Errorf("fromHex(): %w", err)
+			}
+
+			return string(decoded), nil
+		},
+	})
+
+	register(Function{
+		Name:        "sha256",
+		Signature:   "sha256(str)",
+		Description: "Returns the SHA-256 digest of the string, hex encoded.",
+		Example:     `sha256("abc")[:8] == "ba7816bf"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) string)},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("sha256", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			sum := sha256.Sum256([]byte(s))
+			return hex.EncodeToString(sum[:]), nil
+		},
+	})
+
+	register(Function{
+		Name:        "hmacSHA256",
+		Signature:   "hmacSHA256(key, message)",
+		Description: "Returns the HMAC-SHA256 of the message with the given key, hex encoded.",
+		Example:     `hmacSHA256("secret", root().body)`,
+		MinArgs:     2,
+		MaxArgs:     2,
+		Types:       []any{new(func(string, string) string)},
+		Call: func(params ...any) (any, error) {
+			key, err := stringArg("hmacSHA256", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			message, err := stringArg("hmacSHA256", params, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			mac := hmac.New(sha256.New, []byte(key))
+			mac.Write([]byte(message))
+			return hex.EncodeToString(mac.Sum(nil)), nil
+		},
+	})
+
+	register(Function{
+		Name:        "parseURL",
+		Signature:   "parseURL(url)",
+		Description: "Parses a URL into scheme, user, host, hostname, port, path, query and fragment. Query values are strings, or lists of strings when repeated.",
+		Example:     `parseURL("https://example.com:8443/a?env=prod").query.env == "prod"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) map[string]any)},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("parseURL", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			u, err := url.Parse(s)
+			if err != nil {
+				return nil, fmt.Errorf("parseURL(): %w", err)
+			}
+
+			user := ""
+			if u.User != nil {
+				user = u.User.Username()
+			}
+
+			return map[string]any{
+				"scheme":   u.Scheme,
+				"user":     user,
+				"host":     u.Host,
+				"hostname": u.Hostname(),
+				"port":     u.Port(),
+				"path":     u.Path,
+				"rawQuery": u.RawQuery,
+				"query":    queryToMap(u.Query()),
+				"fragment": u.Fragment,
+			}, nil
+		},
+	})
+
+	register(Function{
+		Name:        "parseQuery",
+		Signature:   "parseQuery(query)",
+		Description: "Parses a URL query string. Values are strings, or lists of strings when repeated.",
+		Example:     `parseQuery("a=1&b=2&b=3") == {a: "1", b: ["2", "3"]}`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) map[string]any)},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("parseQuery", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(s) > 0 && s[0] == '?' {
+				s = s[1:]
+			}
+
+			values, err := url.ParseQuery(s)
+			if err != nil {
+				return nil, fmt.Errorf("parseQuery(): %w", err)
+			}
+
+			return queryToMap(values), nil
+		},
+	})
+
+	register(Function{
+		Name:        "urlEncode",
+		Signature:   "urlEncode(str)",
+		Description: "Escapes the string so it can be placed in a URL query.",
+		Example:     `urlEncode("a b&c") == "a+b%26c"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) string)},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("urlEncode", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			return url.QueryEscape(s), nil
+		},
+	})
+
+	register(Function{
+		Name:        "urlDecode",
+		Signature:   "urlDecode(str)",
+		Description: "Reverses urlEncode.",
+		Example:     `urlDecode("a+b%26c") == "a b&c"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) string)},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("urlDecode", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			decoded, err := url.QueryUnescape(s)
+			if err != nil {
+				return nil, fmt.Errorf("urlDecode(): %w", err)
+			}
+
+			return decoded, nil
+		},
+	})
+}
+
+func validateHex(s string) error {
+	if _, err := hex.DecodeString(s); err != nil {
+		return fmt.Errorf("invalid hex string: %w", err)
+	}
+
+	return nil
+}
+
+func trimBase64Padding(s string) string {
+	for len(s) > 0 && s[len(s)-1] == '=' {
+		s = s[:len(s)-1]
+	}
+
+	return s
+}
+
+func queryToMap(values url.Values) map[string]any {
+	result := make(map[string]any, len(values))
+	for key, list := range values {
+		if len(list) == 1 {
+			result[key] = list[0]
+			continue
+		}
+
+		result[key] = stringsToAny(list)
+	}
+
+	return result
+}
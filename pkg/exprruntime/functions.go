@@ -0,0 +1,128 @@
+package exprruntime
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/expr-lang/expr"
+)
+
+// Function is a helper available to every configuration expression,
+// on top of the expr builtins and the run context functions.
+type Function struct {
+	Name        string
+	Signature   string
+	Description string
+	Example     string
+
+	// MinArgs and MaxArgs are checked at validation time,
+	// together with Types, which expr uses to type check
+	// the arguments when the expression is compiled.
+	MinArgs int
+	MaxArgs int
+	Types   []any
+
+	// LiteralArgs validates arguments given as string literals,
+	// keyed by argument position, so invalid patterns and queries
+	// are reported when the canvas is saved, not when it runs.
+	LiteralArgs map[int]func(string) error
+
+	Call func(params ...any) (any, error)
+}
+
+var functions = map[string]Function{}
+
+func register(fn Function) {
+	if _, ok := functions[fn.Name]; ok {
+		panic(fmt.Sprintf("expression function %s registered twice", fn.Name))
+	}
+
+	functions[fn.Name] = fn
+}
+
+// Functions returns the standard library, sorted by name.
+func Functions() []Function {
+	list := make([]Function, 0, len(functions))
+	for _, fn := range functions {
+		list = append(list, fn)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
+
+func LookupFunction(name string) (Function, bool) {
+	fn, ok := functions[name]
+	return fn, ok
+}
+
+// FunctionOptions returns the expr options that register the standard library.
+// The same options are used when validating and when evaluating expressions.
+func FunctionOptions() []expr.Option {
+	options := []expr.Option{}
+	for _, fn := range Functions() {
+		options = append(options, fn.option())
+	}
+
+	return options
+}
+
+func (f Function) option() expr.Option {
+	call := func(params ...any) (any, error) {
+		if err := f.CheckArity(len(params)); err != nil {
+			return nil, err
+		}
+
+		return f.Call(params...)
+	}
+
+	return expr.Function(f.Name, call, f.Types...)
+}
+
+func (f Function) CheckArity(n int) error {
+	if n >= f.MinArgs && (f.MaxArgs < 0 || n <= f.MaxArgs) {
+		return nil
+	}
+
+	switch {
+	case f.MinArgs == f.MaxArgs:
+		return fmt.Errorf("%s() takes %s, got %d", f.Name, pluralArgs(f.MinArgs), n)
+	case f.MaxArgs < 0:
+		return fmt.Errorf("%s() takes at least %s, got %d", f.Name, pluralArgs(f.MinArgs), n)
+	default:
+		return fmt.Errorf("%s() takes %d to %d arguments, got %d", f.Name, f.MinArgs, f.MaxArgs, n)
+	}
+}
+
+func pluralArgs(n int) string {
+	if n == 1 {
+		return "1 argument"
+	}
+
+	return fmt.Sprintf("%d arguments", n)
+}
+
+func stringArg(name string, params []any, i int) (string, error) {
+	value, ok := params[i].(string)
+	if !ok {
+		return "", fmt.Errorf("%s() argument %d must be a string, got %T", name, i+1, params[i])
+	}
+
+	return value, nil
+}
+
+func optionalBoolArg(name string, params []any, i int, fallback bool) (bool, error) {
+	if len(params) <= i {
+		return fallback, nil
+	}
+
+	value, ok := params[i].(bool)
+	if !ok {
+		return false, fmt.Errorf("%s() argument %d must be a bool, got %T", name, i+1, params[i])
+	}
+
+	return value, nil
+}
@@ -0,0 +1,157 @@
+package exprruntime
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func evaluate(t *testing.T, expression string, env map[string]any) any {
+	t.Helper()
+
+	options := append([]expr.Option{
+		expr.Env(env),
+		expr.AsAny(),
+		expr.Timezone(time.UTC.String()),
+		DateFunctionOption(),
+	}, FunctionOptions()...)
+
+	program, err := expr.Compile(expression, options...)
+	require.NoError(t, err)
+
+	out, err := expr.Run(program, env)
+	require.NoError(t, err)
+	return out
+}
+
+func TestFunctions_ExamplesHold(t *testing.T) {
+	for _, fn := range Functions() {
+		if !strings.Contains(fn.Example, "==") {
+			continue
+		}
+
+		t.Run(fn.Name, func(t *testing.T) {
+			assert.Equal(t, true, evaluate(t, fn.Example, map[string]any{}), fn.Example)
+		})
+	}
+}
+
+func TestFunctions_RuntimeErrors(t *testing.T) {
+	env := map[string]any{"payload": map[string]any{"version": "not-a-version", "pattern": "(", "count": 3}}
+
+	for _, expression := range []string{
+		`semver(payload.version)`,
+		`regexMatch("a", payload.pattern)`,
+		`slugify(payload.count)`,
+		`fromHex("zz")`,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			options := append([]expr.Option{expr.Env(env), expr.AsAny()}, FunctionOptions()...)
+			program, err := expr.Compile(expression, options...)
+			require.NoError(t, err)
+
+			_, err = expr.Run(program, env)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestVersion_CompareAndBump(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.10.0", "2.0.0"}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, err := ParseVersion(ordered[i])
+		require.NoError(t, err)
+		b, err := ParseVersion(ordered[i+1])
+		require.NoError(t, err)
+
+		assert.Equal(t, -1, a.Compare(b), "%s < %s", ordered[i], ordered[i+1])
+		assert.Equal(t, 1, b.Compare(a), "%s > %s", ordered[i+1], ordered[i])
+	}
+
+	for _, tc := range []struct{ version, part, expected string }{
+		{"1.2.3", "patch", "1.2.4"},
+		{"1.2.3+build.5", "minor", "1.3.0"},
+		{"1.2.3", "major", "2.0.0"},
+		{"1.3.0-rc.1", "minor", "1.3.0"},
+		{"1.3.1-rc.1", "minor", "1.4.0"},
+		{"2.0.0-beta", "major", "2.0.0"},
+	} {
+		version, err := ParseVersion(tc.version)
+		require.NoError(t, err)
+
+		bumped, err := version.Bump(tc.part)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, bumped.String(), "%s %s", tc.version, tc.part)
+	}
+
+	_, err := ParseVersion("1.2")
+	assert.Error(t, err)
+}
+
+func TestJSONPath(t *testing.T) {
+	data := map[string]any{
+		"items": []any{
+			map[string]any{"name": "a", "tags": map[string]any{"env": "prod"}},
+			map[string]any{"name": "b", "tags": map[string]any{"env": "dev"}},
+		},
+	}
+
+	segments, err := parseJSONPath("$.items[-1]['tags'].env")
+	require.NoError(t, err)
+	assert.Equal(t, "dev", evaluateJSONPath(data, segments))
+
+	segments, err = parseJSONPath("$.items[*].tags.env")
+	require.NoError(t, err)
+	assert.Equal(t, []any{"prod", "dev"}, evaluateJSONPath(data, segments))
+
+	segments, err = parseJSONPath("$.missing.key")
+	require.NoError(t, err)
+	assert.Nil(t, evaluateJSONPath(data, segments))
+
+	for _, path := range []string{"items", "$..name", "$.items[", "$.items[?(@.a)]"} {
+		_, err := parseJSONPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestDurations(t *testing.T) {
+	d, err := ParseDuration("1w2d3h")
+	require.NoError(t, err)
+	assert.Equal(t, 9*24*time.Hour+3*time.Hour, d)
+
+	d, err = ParseDuration("-1d")
+	require.NoError(t, err)
+	assert.Equal(t, -24*time.Hour, d)
+
+	_, err = ParseDuration("1y")
+	assert.Error(t, err)
+
+	assert.Equal(t, "2d 3h", HumanizeDuration(51*time.Hour+20*time.Minute))
+	assert.Equal(t, "1h", HumanizeDuration(time.Hour+30*time.Second))
+	assert.Equal(t, "45s", HumanizeDuration(45*time.Second))
+	assert.Equal(t, "250ms", HumanizeDuration(250*time.Millisecond))
+	assert.Equal(t, "-1m 30s", HumanizeDuration(-90*time.Second))
+	assert.Equal(t, "1m 30s", evaluate(t, `humanizeDuration(90)`, map[string]any{}))
+}
+
+func TestParseCSV(t *testing.T) {
+	rows, err := ParseCSV("name, env\napi, prod\nweb", true)
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"name": "api", "env": "prod"},
+		map[string]any{"name": "web", "env": ""},
+	}, rows)
+
+	rows, err = ParseCSV("a,b\nc,d", false)
+	require.NoError(t, err)
+	assert.Equal(t, []any{[]any{"a", "b"}, []any{"c", "d"}}, rows)
+}
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "release-v1-2-crepe", Slugify("  Release v1.2 — Crêpe!! "))
+	assert.Equal(t, "", Slugify("!!!"))
+}
@@ -0,0 +1,254 @@
+package exprruntime
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+func init() {
+	register(Function{
+		Name:        "jsonPath",
+		Signature:   "jsonPath(value, path)",
+		Description: "Looks up a JSONPath such as $.items[0].name. Paths with a * wildcard return a list.",
+		Example:     `jsonPath({items: [{name: "a"}, {name: "b"}]}, "$.items[*].name") == ["a", "b"]`,
+		MinArgs:     2,
+		MaxArgs:     2,
+		Types:       []any{new(func(any, string) any)},
+		LiteralArgs: map[int]func(string) error{1: validateJSONPath},
+		Call: func(params ...any) (any, error) {
+			path, err := stringArg("jsonPath", params, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			segments, err := parseJSONPath(path)
+			if err != nil {
+				return nil, fmt.Errorf("jsonPath(): %w", err)
+			}
+
+			return evaluateJSONPath(params[0], segments), nil
+		},
+	})
+
+	register(Function{
+		Name:        "jmesPath",
+		Signature:   "jmesPath(value, query)",
+		Description: "Evaluates a JMESPath query, e.g. to filter and project lists.",
+		Example:     `jmesPath({items: [{n: "a", ok: true}, {n: "b", ok: false}]}, "items[?ok].n") == ["a"]`,
+		MinArgs:     2,
+		MaxArgs:     2,
+		Types:       []any{new(func(any, string) any)},
+		LiteralArgs: map[int]func(string) error{1: validateJMESPath},
+		Call: func(params ...any) (any, error) {
+			query, err := stringArg("jmesPath", params, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			result, err := jmespath.Search(query, params[0])
+			if err != nil {
+				return nil, fmt.Errorf("jmesPath(): %w", err)
+			}
+
+			return result, nil
+		},
+	})
+}
+
+func validateJMESPath(query string) error {
+	if _, err := jmespath.Compile(query); err != nil {
+		return fmt.Errorf("invalid JMESPath query: %w", err)
+	}
+
+	return nil
+}
+
+func validateJSONPath(path string) error {
+	if _, err := parseJSONPath(path); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// jsonPathSegment is a key, an index or a wildcard.
+// Filters, slices and recursive descent are not supported,
+// jmesPath() covers those.
+type jsonPathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	rest := strings.TrimSpace(path)
+	if !strings.HasPrefix(rest, "$") {
+		return nil, fmt.Errorf("invalid JSONPath %q: must start with $", path)
+	}
+
+	rest = rest[1:]
+	segments := []jsonPathSegment{}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			if strings.HasPrefix(rest, ".") {
+				return nil, fmt.Errorf("invalid JSONPath %q: recursive descent is not supported", path)
+			}
+
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+
+			key := rest[:end]
+			if key == "" {
+				return nil, fmt.Errorf("invalid JSONPath %q: empty key", path)
+			}
+
+			if key == "*" {
+				segments = append(segments, jsonPathSegment{wildcard: true})
+			} else {
+				segments = append(segments, jsonPathSegment{key: key})
+			}
+
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid JSONPath %q: unclosed bracket", path)
+			}
+
+			segment, err := parseJSONPathBracket(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSONPath %q: %w", path, err)
+			}
+
+			segments = append(segments, segment)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid JSONPath %q: unexpected %q", path, rest[0])
+		}
+	}
+
+	return segments, nil
+}
+
+func parseJSONPathBracket(value string) (jsonPathSegment, error) {
+	value = strings.TrimSpace(value)
+	if value == "*" {
+		return jsonPathSegment{wildcard: true}, nil
+	}
+
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		return jsonPathSegment{key: value[1 : len(value)-1]}, nil
+	}
+
+	index, err := strconv.Atoi(value)
+	if err != nil {
+		return jsonPathSegment{}, fmt.Errorf("unsupported selector [%s]", value)
+	}
+
+	return jsonPathSegment{index: index, isIndex: true}, nil
+}
+
+func evaluateJSONPath(value any, segments []jsonPathSegment) any {
+	current := []any{value}
+	multiple := false
+
+	for _, segment := range segments {
+		next := []any{}
+		for _, item := range current {
+			next = append(next, selectJSONPathSegment(item, segment)...)
+		}
+
+		current = next
+		if segment.wildcard {
+			multiple = true
+		}
+	}
+
+	if multiple {
+		return current
+	}
+
+	if len(current) == 0 {
+		return nil
+	}
+
+	return current[0]
+}
+
+func selectJSONPathSegment(value any, segment jsonPathSegment) []any {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) {
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if segment.wildcard {
+			keys := v.MapKeys()
+			sortMapKeys(keys)
+			values := make([]any, 0, len(keys))
+			for _, key := range keys {
+				values = append(values, v.MapIndex(key).Interface())
+			}
+
+			return values
+		}
+
+		if segment.isIndex || v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+
+		item := v.MapIndex(reflect.ValueOf(segment.key).Convert(v.Type().Key()))
+		if !item.IsValid() {
+			return nil
+		}
+
+		return []any{item.Interface()}
+	case reflect.Slice, reflect.Array:
+		if segment.wildcard {
+			values := make([]any, 0, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				values = append(values, v.Index(i).Interface())
+			}
+
+			return values
+		}
+
+		if !segment.isIndex {
+			return nil
+		}
+
+		index := segment.index
+		if index < 0 {
+			index += v.Len()
+		}
+
+		if index < 0 || index >= v.Len() {
+			return nil
+		}
+
+		return []any{v.Index(index).Interface()}
+	}
+
+	return nil
+}
+
+func sortMapKeys(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+}
@@ -0,0 +1,146 @@
+package exprruntime
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	register(Function{
+		Name:        "parseCSV",
+		Signature:   "parseCSV(str, header = true)",
+		Description: "Parses CSV. With a header row, returns a list of maps keyed by column name, otherwise a list of rows.",
+		Example:     `parseCSV("name,env\napi,prod")[0].env == "prod"`,
+		MinArgs:     1,
+		MaxArgs:     2,
+		Types: []any{
+			new(func(string) []any),
+			new(func(string, bool) []any),
+		},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("parseCSV", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			header, err := optionalBoolArg("parseCSV", params, 1, true)
+			if err != nil {
+				return nil, err
+			}
+
+			return ParseCSV(s, header)
+		},
+	})
+
+	register(Function{
+		Name:        "parseYAML",
+		Signature:   "parseYAML(str)",
+		Description: "Parses a YAML document.",
+		Example:     `parseYAML("image:\n  tag: v2").image.tag == "v2"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) any)},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("parseYAML", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			var value any
+			if err := yaml.Unmarshal([]byte(s), &value); err != nil {
+				return nil, fmt.Errorf("parseYAML(): %w", err)
+			}
+
+			return normalizeYAML(value), nil
+		},
+	})
+
+	register(Function{
+		Name:        "toYAML",
+		Signature:   "toYAML(value)",
+		Description: "Converts the value to a YAML document.",
+		Example:     `toYAML({replicas: 3}) == "replicas: 3\n"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(any) string)},
+		Call: func(params ...any) (any, error) {
+			out, err := yaml.Marshal(params[0])
+			if err != nil {
+				return nil, fmt.Errorf("toYAML(): %w", err)
+			}
+
+			return string(out), nil
+		},
+	})
+}
+
+func ParseCSV(s string, header bool) ([]any, error) {
+	reader := csv.NewReader(strings.NewReader(s))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parseCSV(): %w", err)
+	}
+
+	rows := []any{}
+	if !header {
+		for _, record := range records {
+			rows = append(rows, stringsToAny(record))
+		}
+
+		return rows, nil
+	}
+
+	if len(records) == 0 {
+		return rows, nil
+	}
+
+	columns := records[0]
+	for _, record := range records[1:] {
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			if i < len(record) {
+				row[column] = record[i]
+			} else {
+				row[column] = ""
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// normalizeYAML converts the map[any]any values YAML allows
+// into the map[string]any values the rest of the payload uses.
+func normalizeYAML(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeYAML(item)
+		}
+
+		return v
+	case map[any]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[fmt.Sprint(key)] = normalizeYAML(item)
+		}
+
+		return result
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeYAML(item)
+		}
+
+		return v
+	default:
+		return v
+	}
+}
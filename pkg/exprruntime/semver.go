@@ -0,0 +1,246 @@
+package exprruntime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var semverPattern = regexp.MustCompile(
+	`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`,
+)
+
+// Version is a parsed semantic version, as described by https://semver.org.
+// A leading "v" is accepted and dropped.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Build      string
+}
+
+func ParseVersion(s string) (*Version, error) {
+	match := semverPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return nil, fmt.Errorf("invalid semantic version %q", s)
+	}
+
+	version := &Version{Prerelease: match[4], Build: match[5]}
+	for i, target := range []*uint64{&version.Major, &version.Minor, &version.Patch} {
+		n, err := strconv.ParseUint(match[i+1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid semantic version %q: %w", s, err)
+		}
+
+		*target = n
+	}
+
+	return version, nil
+}
+
+func (v *Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+
+	return s
+}
+
+// Compare returns -1, 0 or 1. Build metadata is ignored,
+// and a prerelease sorts before its release.
+func (v *Version) Compare(other *Version) int {
+	for _, pair := range [][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			return compareUint(pair[0], pair[1])
+		}
+	}
+
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	}
+
+	left := strings.Split(v.Prerelease, ".")
+	right := strings.Split(other.Prerelease, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		if c := comparePrereleaseIdentifier(left[i], right[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareUint(uint64(len(left)), uint64(len(right)))
+}
+
+// Bump increments the given part and resets the lower parts.
+// Bumping a prerelease only drops the prerelease, since 1.2.3-rc.1
+// is already a prerelease of 1.2.3.
+func (v *Version) Bump(part string) (*Version, error) {
+	bumped := &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	prerelease := v.Prerelease != ""
+
+	switch part {
+	case "major":
+		if !prerelease || v.Minor != 0 || v.Patch != 0 {
+			bumped.Major++
+		}
+
+		bumped.Minor = 0
+		bumped.Patch = 0
+	case "minor":
+		if !prerelease || v.Patch != 0 {
+			bumped.Minor++
+		}
+
+		bumped.Patch = 0
+	case "patch":
+		if !prerelease {
+			bumped.Patch++
+		}
+	default:
+		return nil, fmt.Errorf("invalid version part %q: must be major, minor or patch", part)
+	}
+
+	return bumped, nil
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func comparePrereleaseIdentifier(a, b string) int {
+	left, leftErr := strconv.ParseUint(a, 10, 64)
+	right, rightErr := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case leftErr == nil && rightErr == nil:
+		return compareUint(left, right)
+	case leftErr == nil:
+		return -1
+	case rightErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func validateVersionPart(part string) error {
+	_, err := (&Version{}).Bump(part)
+	return err
+}
+
+func init() {
+	register(Function{
+		Name:        "semver",
+		Signature:   "semver(version)",
+		Description: "Parses a semantic version into major, minor, patch, prerelease, build and version.",
+		Example:     `semver("v1.4.2-rc.1").minor == 4`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) map[string]any)},
+		Call: func(params ...any) (any, error) {
+			version, err := versionArg("semver", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]any{
+				"major":      int(version.Major),
+				"minor":      int(version.Minor),
+				"patch":      int(version.Patch),
+				"prerelease": version.Prerelease,
+				"build":      version.Build,
+				"version":    version.String(),
+			}, nil
+		},
+	})
+
+	register(Function{
+		Name:        "semverCompare",
+		Signature:   "semverCompare(a, b)",
+		Description: "Compares two semantic versions, returning -1, 0 or 1.",
+		Example:     `semverCompare("1.10.0", "1.9.3") == 1`,
+		MinArgs:     2,
+		MaxArgs:     2,
+		Types:       []any{new(func(string, string) int)},
+		Call: func(params ...any) (any, error) {
+			a, err := versionArg("semverCompare", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			b, err := versionArg("semverCompare", params, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			return a.Compare(b), nil
+		},
+	})
+
+	register(Function{
+		Name:        "semverBump",
+		Signature:   "semverBump(version, part)",
+		Description: "Increments the major, minor or patch part of a semantic version. A leading v is kept.",
+		Example:     `semverBump("v1.4.2", "minor") == "v1.5.0"`,
+		MinArgs:     2,
+		MaxArgs:     2,
+		Types:       []any{new(func(string, string) string)},
+		LiteralArgs: map[int]func(string) error{1: validateVersionPart},
+		Call: func(params ...any) (any, error) {
+			version, err := versionArg("semverBump", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			part, err := stringArg("semverBump", params, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			bumped, err := version.Bump(part)
+			if err != nil {
+				return nil, fmt.Errorf("semverBump(): %w", err)
+			}
+
+			if strings.HasPrefix(strings.TrimSpace(params[0].(string)), "v") {
+				return "v" + bumped.String(), nil
+			}
+
+			return bumped.String(), nil
+		},
+	})
+}
+
+func versionArg(name string, params []any, i int) (*Version, error) {
+	s, err := stringArg(name, params, i)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := ParseVersion(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s(): %w", name, err)
+	}
+
+	return version, nil
+}
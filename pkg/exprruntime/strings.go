@@ -0,0 +1,180 @@
+package exprruntime
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func init() {
+	register(Function{
+		Name:        "regexMatch",
+		Signature:   "regexMatch(str, pattern)",
+		Description: "Reports whether the string contains a match of the regular expression.",
+		Example:     `regexMatch("v1.2.3", "^v\\d+")`,
+		MinArgs:     2,
+		MaxArgs:     2,
+		Types:       []any{new(func(string, string) bool)},
+		LiteralArgs: map[int]func(string) error{1: validateRegex},
+		Call: func(params ...any) (any, error) {
+			s, re, err := regexArgs("regexMatch", params)
+			if err != nil {
+				return nil, err
+			}
+
+			return re.MatchString(s), nil
+		},
+	})
+
+	register(Function{
+		Name:        "regexFind",
+		Signature:   "regexFind(str, pattern)",
+		Description: "Returns the first match of the regular expression, or nil. With capture groups, returns the groups instead.",
+		Example:     `regexFind("release-1.4", "(\\d+)\\.(\\d+)") == ["1", "4"]`,
+		MinArgs:     2,
+		MaxArgs:     2,
+		Types:       []any{new(func(string, string) any)},
+		LiteralArgs: map[int]func(string) error{1: validateRegex},
+		Call: func(params ...any) (any, error) {
+			s, re, err := regexArgs("regexFind", params)
+			if err != nil {
+				return nil, err
+			}
+
+			match := re.FindStringSubmatch(s)
+			if match == nil {
+				return nil, nil
+			}
+
+			if len(match) == 1 {
+				return match[0], nil
+			}
+
+			return stringsToAny(match[1:]), nil
+		},
+	})
+
+	register(Function{
+		Name:        "regexFindAll",
+		Signature:   "regexFindAll(str, pattern)",
+		Description: "Returns all matches of the regular expression.",
+		Example:     `regexFindAll("a1 b2", "\\d") == ["1", "2"]`,
+		MinArgs:     2,
+		MaxArgs:     2,
+		Types:       []any{new(func(string, string) []any)},
+		LiteralArgs: map[int]func(string) error{1: validateRegex},
+		Call: func(params ...any) (any, error) {
+			s, re, err := regexArgs("regexFindAll", params)
+			if err != nil {
+				return nil, err
+			}
+
+			return stringsToAny(re.FindAllString(s, -1)), nil
+		},
+	})
+
+	register(Function{
+		Name:        "regexReplace",
+		Signature:   "regexReplace(str, pattern, replacement)",
+		Description: "Replaces all matches of the regular expression. The replacement can reference groups with $1 or ${name}.",
+		Example:     `regexReplace("feature/JIRA-12", "^feature/", "") == "JIRA-12"`,
+		MinArgs:     3,
+		MaxArgs:     3,
+		Types:       []any{new(func(string, string, string) string)},
+		LiteralArgs: map[int]func(string) error{1: validateRegex},
+		Call: func(params ...any) (any, error) {
+			s, re, err := regexArgs("regexReplace", params)
+			if err != nil {
+				return nil, err
+			}
+
+			replacement, err := stringArg("regexReplace", params, 2)
+			if err != nil {
+				return nil, err
+			}
+
+			return re.ReplaceAllString(s, replacement), nil
+		},
+	})
+
+	register(Function{
+		Name:        "slugify",
+		Signature:   "slugify(str)",
+		Description: "Lowercases the string, drops accents and joins words with dashes, so it can be used in names, branches and URLs.",
+		Example:     `slugify("Fix: Crème Brûlée!") == "fix-creme-brulee"`,
+		MinArgs:     1,
+		MaxArgs:     1,
+		Types:       []any{new(func(string) string)},
+		Call: func(params ...any) (any, error) {
+			s, err := stringArg("slugify", params, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			return Slugify(s), nil
+		},
+	})
+}
+
+func validateRegex(pattern string) error {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid regular expression: %w", err)
+	}
+
+	return nil
+}
+
+func regexArgs(name string, params []any) (string, *regexp.Regexp, error) {
+	s, err := stringArg(name, params, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pattern, err := stringArg(name, params, 1)
+	if err != nil {
+		return "", nil, err
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s(): invalid regular expression: %w", name, err)
+	}
+
+	return s, re, nil
+}
+
+// Slugify keeps ASCII letters and digits, and collapses everything else into single dashes.
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+
+	for _, r := range norm.NFKD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+
+			b.WriteRune(unicode.ToLower(r))
+			dash = false
+		default:
+			dash = true
+		}
+	}
+
+	return b.String()
+}
+
+func stringsToAny(values []string) []any {
+	result := make([]any, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+
+	return result
+}
@@ -0,0 +1,700 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/git/provider"
+)
+
+/*
+ * Local filesystem git provider.
+ *
+ * Repositories are bare git repositories stored under a root directory,
+ * which can be a local disk or a mounted volume. All git operations go
+ * through the git binary, using plumbing commands only, so no working
+ * tree is ever checked out.
+ *
+ * Branch updates use `git update-ref <ref> <new> <old>`, which only
+ * succeeds if the branch still points to the commit we built on top of.
+ * That makes concurrent writers safe, even across processes sharing
+ * the same volume.
+ */
+type Provider struct {
+	root          string
+	defaultBranch string
+	locks         sync.Map
+}
+
+const zeroOID = "0000000000000000000000000000000000000000"
+
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+func NewProvider() (*Provider, error) {
+	root := strings.TrimSpace(os.Getenv("GIT_STORAGE_LOCAL_PATH"))
+	if root == "" {
+		return nil, fmt.Errorf("GIT_STORAGE_LOCAL_PATH is required")
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git binary is required for the local git storage provider: %w", err)
+	}
+
+	return NewProviderWithRoot(root)
+}
+
+func NewProviderWithRoot(root string) (*Provider, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving repository root: %w", err)
+	}
+
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("error creating repository root: %w", err)
+	}
+
+	return &Provider{
+		root:          root,
+		defaultBranch: "main",
+	}, nil
+}
+
+func (p *Provider) Name() string {
+	return provider.LocalProvider
+}
+
+func (p *Provider) GetRepositoryID(options provider.RepositoryOptions) string {
+	return fmt.Sprintf("orgs/%s/canvases/%s", options.OrganizationID.String(), options.CanvasID.String())
+}
+
+func (p *Provider) CreateRepository(ctx context.Context, repoID string) (*provider.Repository, error) {
+	repoPath, err := p.repositoryPath(repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(repoPath); err == nil {
+		return nil, fmt.Errorf("%w: repository %q already exists", provider.ErrInvalidRepositoryID, repoID)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(repoPath), 0o750); err != nil {
+		return nil, fmt.Errorf("error creating repository directory: %w", err)
+	}
+
+	_, err = runGit(ctx, "", nil, nil, "init", "--bare", "--quiet", "--initial-branch="+p.defaultBranch, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.Commit(ctx, repoID, provider.InitialRepositoryCommitOptions(p.defaultBranch))
+	if err != nil {
+		_ = os.RemoveAll(repoPath)
+		return nil, err
+	}
+
+	return &provider.Repository{ID: repoID}, nil
+}
+
+func (p *Provider) DeleteRepository(_ context.Context, repoID string) error {
+	repoPath, err := p.repositoryPath(repoID)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(repoPath)
+}
+
+func (p *Provider) ListFiles(ctx context.Context, repoID, ref string) ([]string, error) {
+	repoPath, err := p.existingRepositoryPath(repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	sha, err := p.resolveRef(ctx, repoPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := runGit(ctx, repoPath, nil, nil, "ls-tree", "-r", "-z", "--name-only", sha)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func (p *Provider) GetFile(ctx context.Context, repoID, path, ref string) (io.ReadCloser, error) {
+	filePath, err := provider.NormalizePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath, err := p.existingRepositoryPath(repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	sha, err := p.resolveRef(ctx, repoPath, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	blob, ok, err := revParse(ctx, repoPath, sha+":"+filePath)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, provider.ErrInvalidPath
+	}
+
+	content, err := runGit(ctx, repoPath, nil, nil, "cat-file", "blob", blob)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (p *Provider) Commit(ctx context.Context, repoID string, options provider.CommitOptions) (string, error) {
+	if err := provider.ValidateCommitMetadata(options.Message, options.Author); err != nil {
+		return "", err
+	}
+
+	operations, err := provider.ValidateCommitOperations(options.Operations)
+	if err != nil {
+		return "", err
+	}
+
+	repoPath, err := p.existingRepositoryPath(repoID)
+	if err != nil {
+		return "", err
+	}
+
+	branch := provider.RefOrDefault(options.Branch, p.defaultBranch)
+	if err := validateBranchName(ctx, branch); err != nil {
+		return "", err
+	}
+
+	unlock := p.lock(repoID)
+	defer unlock()
+
+	currentHead, err := p.branchHead(ctx, repoPath, branch)
+	if err != nil {
+		return "", err
+	}
+
+	parent, err := p.commitParent(ctx, repoPath, currentHead, strings.TrimSpace(options.BaseBranch))
+	if err != nil {
+		return "", err
+	}
+
+	expectedHead := strings.TrimSpace(options.ExpectedHeadSHA)
+	if expectedHead != "" && expectedHead != parent {
+		return "", provider.ErrExpectedHeadMismatch
+	}
+
+	tree, err := p.writeTree(ctx, repoPath, parent, operations)
+	if err != nil {
+		return "", err
+	}
+
+	parents := []string{}
+	if parent != "" {
+		parents = append(parents, parent)
+	}
+
+	commitSHA, err := commitTree(ctx, repoPath, tree, parents, options.Message, options.Author)
+	if err != nil {
+		return "", err
+	}
+
+	if err := p.updateBranch(ctx, repoPath, branch, commitSHA, currentHead); err != nil {
+		return "", err
+	}
+
+	return commitSHA, nil
+}
+
+func (p *Provider) Head(ctx context.Context, repoID, ref string) (string, error) {
+	repoPath, err := p.existingRepositoryPath(repoID)
+	if err != nil {
+		return "", err
+	}
+
+	return p.resolveRef(ctx, repoPath, ref)
+}
+
+func (p *Provider) ListBranches(ctx context.Context, repoID, prefix string) ([]string, error) {
+	repoPath, err := p.existingRepositoryPath(repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := runGit(ctx, repoPath, nil, nil, "for-each-ref", "--format=%(refname)", "refs/heads/")
+	if err != nil {
+		return nil, err
+	}
+
+	branches := []string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		branch := strings.TrimPrefix(strings.TrimSpace(line), "refs/heads/")
+		if branch == "" || !strings.HasPrefix(branch, prefix) {
+			continue
+		}
+		branches = append(branches, branch)
+	}
+
+	sort.Strings(branches)
+	return branches, nil
+}
+
+func (p *Provider) CreateBranch(ctx context.Context, repoID, branch, fromRef string) error {
+	repoPath, err := p.existingRepositoryPath(repoID)
+	if err != nil {
+		return err
+	}
+
+	branch = strings.TrimSpace(branch)
+	if err := validateBranchName(ctx, branch); err != nil {
+		return err
+	}
+
+	fromSHA, err := p.resolveRef(ctx, repoPath, fromRef)
+	if err != nil {
+		return err
+	}
+
+	existing, err := p.branchHead(ctx, repoPath, branch)
+	if err != nil {
+		return err
+	}
+	if existing != "" {
+		return fmt.Errorf("%w: branch %q already exists", provider.ErrInvalidRef, branch)
+	}
+
+	return p.updateBranch(ctx, repoPath, branch, fromSHA, "")
+}
+
+func (p *Provider) MergeBranch(ctx context.Context, repoID, sourceBranch, targetBranch, message string, author provider.CommitAuthor) (string, error) {
+	if err := provider.ValidateCommitMetadata(message, author); err != nil {
+		return "", err
+	}
+
+	repoPath, err := p.existingRepositoryPath(repoID)
+	if err != nil {
+		return "", err
+	}
+
+	sourceBranch = strings.TrimSpace(sourceBranch)
+	targetBranch = provider.RefOrDefault(targetBranch, p.defaultBranch)
+
+	unlock := p.lock(repoID)
+	defer unlock()
+
+	sourceSHA, err := p.existingBranchHead(ctx, repoPath, sourceBranch)
+	if err != nil {
+		return "", err
+	}
+
+	targetSHA, err := p.existingBranchHead(ctx, repoPath, targetBranch)
+	if err != nil {
+		return "", err
+	}
+
+	//
+	// Nothing to merge if the target already contains the source.
+	//
+	merged, err := isAncestor(ctx, repoPath, sourceSHA, targetSHA)
+	if err != nil {
+		return "", err
+	}
+	if merged {
+		return targetSHA, nil
+	}
+
+	//
+	// Prefer fast-forwarding, like the hosted providers do.
+	//
+	fastForward, err := isAncestor(ctx, repoPath, targetSHA, sourceSHA)
+	if err != nil {
+		return "", err
+	}
+	if fastForward {
+		if err := p.updateBranch(ctx, repoPath, targetBranch, sourceSHA, targetSHA); err != nil {
+			return "", err
+		}
+		return sourceSHA, nil
+	}
+
+	tree, err := mergeTree(ctx, repoPath, targetSHA, sourceSHA)
+	if err != nil {
+		return "", err
+	}
+
+	commitSHA, err := commitTree(ctx, repoPath, tree, []string{targetSHA, sourceSHA}, message, author)
+	if err != nil {
+		return "", err
+	}
+
+	if err := p.updateBranch(ctx, repoPath, targetBranch, commitSHA, targetSHA); err != nil {
+		return "", err
+	}
+
+	return commitSHA, nil
+}
+
+func (p *Provider) DeleteBranch(ctx context.Context, repoID, branch string) error {
+	repoPath, err := p.existingRepositoryPath(repoID)
+	if err != nil {
+		return err
+	}
+
+	branch = strings.TrimSpace(branch)
+	if branch == "" {
+		return provider.ErrInvalidRef
+	}
+
+	if branch == p.defaultBranch {
+		return fmt.Errorf("%w: cannot delete default branch", provider.ErrInvalidRef)
+	}
+
+	head, err := p.existingBranchHead(ctx, repoPath, branch)
+	if err != nil {
+		return err
+	}
+
+	_, err = runGit(ctx, repoPath, nil, nil, "update-ref", "-d", "refs/heads/"+branch, head)
+	return err
+}
+
+func (p *Provider) repositoryPath(repoID string) (string, error) {
+	normalized, err := provider.NormalizePath(repoID)
+	if err != nil || normalized != strings.TrimSpace(repoID) {
+		return "", provider.ErrInvalidRepositoryID
+	}
+
+	return filepath.Join(p.root, filepath.FromSlash(normalized)+".git"), nil
+}
+
+func (p *Provider) existingRepositoryPath(repoID string) (string, error) {
+	repoPath, err := p.repositoryPath(repoID)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(filepath.Join(repoPath, "HEAD")); err != nil {
+		return "", provider.ErrInvalidRepositoryID
+	}
+
+	return repoPath, nil
+}
+
+// lock serializes writes to a repository within this process, so that
+// concurrent commits without an expected head do not fail on each other.
+// Writers in other processes are still caught by update-ref.
+func (p *Provider) lock(repoID string) func() {
+	value, _ := p.locks.LoadOrStore(repoID, &sync.Mutex{})
+	mutex := value.(*sync.Mutex)
+	mutex.Lock()
+	return mutex.Unlock
+}
+
+func (p *Provider) resolveRef(ctx context.Context, repoPath, ref string) (string, error) {
+	ref = provider.RefOrDefault(ref, p.defaultBranch)
+	if strings.HasPrefix(ref, "-") {
+		return "", provider.ErrInvalidRef
+	}
+
+	sha, ok, err := revParse(ctx, repoPath, "refs/heads/"+ref+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return sha, nil
+	}
+
+	if !commitSHAPattern.MatchString(ref) {
+		return "", provider.ErrInvalidRef
+	}
+
+	sha, ok, err = revParse(ctx, repoPath, ref+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", provider.ErrInvalidRef
+	}
+
+	return sha, nil
+}
+
+// branchHead returns the commit a branch points to,
+// or an empty string if the branch does not exist.
+func (p *Provider) branchHead(ctx context.Context, repoPath, branch string) (string, error) {
+	sha, _, err := revParse(ctx, repoPath, "refs/heads/"+branch+"^{commit}")
+	return sha, err
+}
+
+func (p *Provider) existingBranchHead(ctx context.Context, repoPath, branch string) (string, error) {
+	if branch == "" || strings.HasPrefix(branch, "-") {
+		return "", provider.ErrInvalidRef
+	}
+
+	sha, err := p.branchHead(ctx, repoPath, branch)
+	if err != nil {
+		return "", err
+	}
+	if sha == "" {
+		return "", fmt.Errorf("%w: branch %q does not exist", provider.ErrInvalidRef, branch)
+	}
+
+	return sha, nil
+}
+
+// commitParent decides what a new commit is built on. Existing branches
+// build on their head; new branches start from the base branch; and only
+// the first commit of an empty repository has no parent at all.
+func (p *Provider) commitParent(ctx context.Context, repoPath, currentHead, baseBranch string) (string, error) {
+	if currentHead != "" {
+		return currentHead, nil
+	}
+
+	if baseBranch != "" {
+		return p.existingBranchHead(ctx, repoPath, baseBranch)
+	}
+
+	output, err := runGit(ctx, repoPath, nil, nil, "for-each-ref", "--count=1", "--format=%(refname)", "refs/heads/")
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(string(output)) != "" {
+		return "", fmt.Errorf("%w: branch does not exist and no base branch was given", provider.ErrInvalidRef)
+	}
+
+	return "", nil
+}
+
+// writeTree applies the operations on top of the parent tree using a
+// temporary index file, and writes the resulting tree object.
+func (p *Provider) writeTree(ctx context.Context, repoPath, parent string, operations []provider.FileOperation) (string, error) {
+	indexDir, err := os.MkdirTemp("", "superplane-git-index-")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary index: %w", err)
+	}
+	defer os.RemoveAll(indexDir)
+
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(indexDir, "index")}
+	if parent != "" {
+		_, err = runGit(ctx, repoPath, env, nil, "read-tree", parent)
+	} else {
+		_, err = runGit(ctx, repoPath, env, nil, "read-tree", "--empty")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	//
+	// Index entries use "<mode> <sha>\t<path>" lines,
+	// where mode 0 removes the path from the index.
+	//
+	var entries bytes.Buffer
+	for _, operation := range operations {
+		if operation.Delete {
+			fmt.Fprintf(&entries, "0 %s\t%s\x00", zeroOID, operation.Path)
+			continue
+		}
+
+		blob, err := runGit(ctx, repoPath, env, operation.Content, "hash-object", "-w", "--stdin")
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(&entries, "100644 %s\t%s\x00", strings.TrimSpace(string(blob)), operation.Path)
+	}
+
+	_, err = runGit(ctx, repoPath, env, &entries, "update-index", "-z", "--index-info")
+	if err != nil {
+		return "", err
+	}
+
+	tree, err := runGit(ctx, repoPath, env, nil, "write-tree")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(tree)), nil
+}
+
+// updateBranch moves the branch to newSHA only if it still points to
+// oldSHA. An empty oldSHA means the branch must not exist yet.
+func (p *Provider) updateBranch(ctx context.Context, repoPath, branch, newSHA, oldSHA string) error {
+	expectedOID := oldSHA
+	if expectedOID == "" {
+		expectedOID = zeroOID
+	}
+
+	_, err := runGit(ctx, repoPath, nil, nil, "update-ref", "refs/heads/"+branch, newSHA, expectedOID)
+	if err == nil {
+		return nil
+	}
+
+	current, headErr := p.branchHead(ctx, repoPath, branch)
+	if headErr == nil && current != oldSHA {
+		return provider.ErrExpectedHeadMismatch
+	}
+
+	return err
+}
+
+func commitTree(ctx context.Context, repoPath, tree string, parents []string, message string, author provider.CommitAuthor) (string, error) {
+	name := strings.TrimSpace(author.Name)
+	email := strings.TrimSpace(author.Email)
+	env := []string{
+		"GIT_AUTHOR_NAME=" + name,
+		"GIT_AUTHOR_EMAIL=" + email,
+		"GIT_COMMITTER_NAME=" + name,
+		"GIT_COMMITTER_EMAIL=" + email,
+	}
+
+	args := []string{"commit-tree", tree}
+	for _, parent := range parents {
+		args = append(args, "-p", parent)
+	}
+	args = append(args, "-F", "-")
+
+	output, err := runGit(ctx, repoPath, env, strings.NewReader(strings.TrimSpace(message)), args...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func mergeTree(ctx context.Context, repoPath, targetSHA, sourceSHA string) (string, error) {
+	output, err := runGit(ctx, repoPath, nil, nil, "merge-tree", "--write-tree", "--no-messages", "--name-only", targetSHA, sourceSHA)
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+
+	if exitCode(err) == 1 {
+		conflicts := []string{}
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				conflicts = append(conflicts, line)
+			}
+		}
+		return "", fmt.Errorf("%w: %s", provider.ErrMergeConflict, strings.Join(conflicts, ", "))
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(lines[0]), nil
+}
+
+func isAncestor(ctx context.Context, repoPath, ancestor, descendant string) (bool, error) {
+	_, err := runGit(ctx, repoPath, nil, nil, "merge-base", "--is-ancestor", ancestor, descendant)
+	if err == nil {
+		return true, nil
+	}
+	if exitCode(err) == 1 {
+		return false, nil
+	}
+	return false, err
+}
+
+func revParse(ctx context.Context, repoPath, spec string) (string, bool, error) {
+	output, err := runGit(ctx, repoPath, nil, nil, "rev-parse", "--verify", "--quiet", "--end-of-options", spec)
+	if exitCode(err) == 1 {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return strings.TrimSpace(string(output)), true, nil
+}
+
+func validateBranchName(ctx context.Context, branch string) error {
+	if branch == "" || strings.HasPrefix(branch, "-") {
+		return provider.ErrInvalidRef
+	}
+
+	if _, err := runGit(ctx, "", nil, nil, "check-ref-format", "refs/heads/"+branch); err != nil {
+		return fmt.Errorf("%w: invalid branch name %q", provider.ErrInvalidRef, branch)
+	}
+
+	return nil
+}
+
+// runGit runs git against repoPath, ignoring any system or user git
+// configuration so behavior does not depend on the host.
+func runGit(ctx context.Context, repoPath string, env []string, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_CONFIG_GLOBAL="+os.DevNull,
+		"GIT_TERMINAL_PROMPT=0",
+	)
+	if repoPath != "" {
+		cmd.Env = append(cmd.Env, "GIT_DIR="+repoPath)
+	}
+	cmd.Env = append(cmd.Env, env...)
+	cmd.Stdin = stdin
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return stdout.Bytes(), &gitError{
+			args:   args,
+			stderr: strings.TrimSpace(stderr.String()),
+			err:    err,
+		}
+	}
+
+	return stdout.Bytes(), nil
+}
+
+type gitError struct {
+	args   []string
+	stderr string
+	err    error
+}
+
+func (e *gitError) Error() string {
+	if e.stderr == "" {
+		return fmt.Sprintf("git %s: %v", e.args[0], e.err)
+	}
+	return fmt.Sprintf("git %s: %v: %s", e.args[0], e.err, e.stderr)
+}
+
+func (e *gitError) Unwrap() error {
+	return e.err
+}
+
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
@@ -0,0 +1,216 @@
+package local
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/git/provider"
+)
+
+func TestProviderRepositoryLifecycle(t *testing.T) {
+	p, repoID := newTestRepository(t)
+	ctx := context.Background()
+
+	files, err := p.ListFiles(ctx, repoID, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md"}, files)
+
+	headSHA, err := p.Head(ctx, repoID, "")
+	require.NoError(t, err)
+	assert.Len(t, headSHA, 40)
+
+	commitSHA, err := p.Commit(ctx, repoID, provider.CommitOptions{
+		ExpectedHeadSHA: headSHA,
+		Message:         "add docs",
+		Author:          testAuthor(),
+		Operations: []provider.FileOperation{
+			{Path: "docs/guide.md", Content: strings.NewReader("guide"), SizeBytes: 5},
+			{Path: "README.md", Delete: true},
+		},
+	})
+	require.NoError(t, err)
+
+	files, err = p.ListFiles(ctx, repoID, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/guide.md"}, files)
+	assert.Equal(t, "guide", readFile(t, p, repoID, "docs/guide.md", ""))
+
+	files, err = p.ListFiles(ctx, repoID, headSHA)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md"}, files)
+
+	_, err = p.GetFile(ctx, repoID, "missing.md", "")
+	require.ErrorIs(t, err, provider.ErrInvalidPath)
+
+	_, err = p.Commit(ctx, repoID, provider.CommitOptions{
+		ExpectedHeadSHA: headSHA,
+		Message:         "stale head",
+		Author:          testAuthor(),
+		Operations:      []provider.FileOperation{{Path: "a.md", Content: strings.NewReader("a")}},
+	})
+	require.ErrorIs(t, err, provider.ErrExpectedHeadMismatch)
+
+	currentHead, err := p.Head(ctx, repoID, "main")
+	require.NoError(t, err)
+	assert.Equal(t, commitSHA, currentHead)
+
+	_, err = p.Commit(ctx, repoID, provider.CommitOptions{
+		Message:    "reserved",
+		Author:     testAuthor(),
+		Operations: []provider.FileOperation{{Path: ".superplane/config.yaml", Content: strings.NewReader("x")}},
+	})
+	require.ErrorIs(t, err, provider.ErrReservedPath)
+
+	_, err = p.CreateRepository(ctx, repoID)
+	require.ErrorIs(t, err, provider.ErrInvalidRepositoryID)
+
+	require.NoError(t, p.DeleteRepository(ctx, repoID))
+	_, err = p.Head(ctx, repoID, "")
+	require.ErrorIs(t, err, provider.ErrInvalidRepositoryID)
+}
+
+func TestProviderBranchOperations(t *testing.T) {
+	p, repoID := newTestRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, p.CreateBranch(ctx, repoID, "staging/one", ""))
+	require.ErrorIs(t, p.CreateBranch(ctx, repoID, "staging/one", ""), provider.ErrInvalidRef)
+	require.ErrorIs(t, p.CreateBranch(ctx, repoID, "bad..name", ""), provider.ErrInvalidRef)
+
+	_, err := p.Commit(ctx, repoID, provider.CommitOptions{
+		Branch:     "staging/one",
+		Message:    "stage",
+		Author:     testAuthor(),
+		Operations: []provider.FileOperation{{Path: "canvas.yaml", Content: strings.NewReader("v1")}},
+	})
+	require.NoError(t, err)
+
+	_, err = p.Commit(ctx, repoID, provider.CommitOptions{
+		Branch:     "staging/two",
+		BaseBranch: "main",
+		Message:    "stage from base",
+		Author:     testAuthor(),
+		Operations: []provider.FileOperation{{Path: "other.yaml", Content: strings.NewReader("v1")}},
+	})
+	require.NoError(t, err)
+
+	_, err = p.Commit(ctx, repoID, provider.CommitOptions{
+		Branch:     "missing",
+		Message:    "no base",
+		Author:     testAuthor(),
+		Operations: []provider.FileOperation{{Path: "x.yaml", Content: strings.NewReader("x")}},
+	})
+	require.ErrorIs(t, err, provider.ErrInvalidRef)
+
+	branches, err := p.ListBranches(ctx, repoID, "staging/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"staging/one", "staging/two"}, branches)
+
+	//
+	// First merge fast-forwards main to the staging branch.
+	//
+	stagingHead, err := p.Head(ctx, repoID, "staging/one")
+	require.NoError(t, err)
+	mergedSHA, err := p.MergeBranch(ctx, repoID, "staging/one", "main", "merge one", testAuthor())
+	require.NoError(t, err)
+	assert.Equal(t, stagingHead, mergedSHA)
+
+	//
+	// Second merge diverged from main, so it creates a merge commit.
+	//
+	mergedSHA, err = p.MergeBranch(ctx, repoID, "staging/two", "main", "merge two", testAuthor())
+	require.NoError(t, err)
+	files, err := p.ListFiles(ctx, repoID, mergedSHA)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "canvas.yaml", "other.yaml"}, files)
+
+	parents, err := runGit(ctx, p.mustPath(t, repoID), nil, nil, "rev-list", "--parents", "-n", "1", mergedSHA)
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(string(parents)), 3)
+
+	require.ErrorIs(t, p.DeleteBranch(ctx, repoID, "main"), provider.ErrInvalidRef)
+	require.NoError(t, p.DeleteBranch(ctx, repoID, "staging/one"))
+	require.ErrorIs(t, p.DeleteBranch(ctx, repoID, "staging/one"), provider.ErrInvalidRef)
+}
+
+func TestProviderMergeConflict(t *testing.T) {
+	p, repoID := newTestRepository(t)
+	ctx := context.Background()
+
+	require.NoError(t, p.CreateBranch(ctx, repoID, "feature", "main"))
+	for _, branch := range []string{"main", "feature"} {
+		_, err := p.Commit(ctx, repoID, provider.CommitOptions{
+			Branch:     branch,
+			Message:    "edit on " + branch,
+			Author:     testAuthor(),
+			Operations: []provider.FileOperation{{Path: "canvas.yaml", Content: strings.NewReader(branch)}},
+		})
+		require.NoError(t, err)
+	}
+
+	mainHead, err := p.Head(ctx, repoID, "main")
+	require.NoError(t, err)
+
+	_, err = p.MergeBranch(ctx, repoID, "feature", "main", "merge feature", testAuthor())
+	require.ErrorIs(t, err, provider.ErrMergeConflict)
+	assert.Contains(t, err.Error(), "canvas.yaml")
+
+	currentHead, err := p.Head(ctx, repoID, "main")
+	require.NoError(t, err)
+	assert.Equal(t, mainHead, currentHead)
+}
+
+func TestProviderRejectsInvalidRepositoryIDs(t *testing.T) {
+	p, err := NewProviderWithRoot(t.TempDir())
+	require.NoError(t, err)
+
+	for _, repoID := range []string{"../escape", "orgs/../../escape", "orgs/x/.git", ""} {
+		_, err := p.CreateRepository(context.Background(), repoID)
+		require.ErrorIs(t, err, provider.ErrInvalidRepositoryID, repoID)
+	}
+}
+
+func newTestRepository(t *testing.T) (*Provider, string) {
+	t.Helper()
+
+	p, err := NewProviderWithRoot(t.TempDir())
+	require.NoError(t, err)
+
+	repoID := p.GetRepositoryID(provider.RepositoryOptions{
+		OrganizationID: uuid.New(),
+		CanvasID:       uuid.New(),
+	})
+
+	_, err = p.CreateRepository(context.Background(), repoID)
+	require.NoError(t, err)
+	return p, repoID
+}
+
+func (p *Provider) mustPath(t *testing.T, repoID string) string {
+	t.Helper()
+
+	repoPath, err := p.existingRepositoryPath(repoID)
+	require.NoError(t, err)
+	return repoPath
+}
+
+func readFile(t *testing.T, p *Provider, repoID, path, ref string) string {
+	t.Helper()
+
+	reader, err := p.GetFile(context.Background(), repoID, path, ref)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func testAuthor() provider.CommitAuthor {
+	return provider.CommitAuthor{Name: "Jane", Email: "jane@example.com"}
+}
@@ -9,13 +9,15 @@ import (
 )
 
 /*
- * Two available git storage providers:
+ * Three available git storage providers:
  * - codestorage - https://code.storage
  * - supergit - https://github.com/superplanehq/supergit
+ * - local - bare repositories on a local disk or mounted volume
  */
 const (
 	CodeStorageProvider = "codestorage"
 	SuperGitProvider    = "supergit"
+	LocalProvider       = "local"
 )
 
 /*
@@ -32,6 +34,7 @@ var (
 	ErrReservedPath         = errors.New("path is reserved for SuperPlane")
 	ErrInvalidCommit        = errors.New("invalid commit")
 	ErrExpectedHeadMismatch = errors.New("expected head sha does not match current branch head")
+	ErrMergeConflict        = errors.New("merge conflict")
 )
 
 type Provider interface {
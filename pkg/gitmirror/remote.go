@@ -0,0 +1,340 @@
+package gitmirror
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	gitprovider "github.com/superplanehq/superplane/pkg/git/provider"
+)
+
+var ErrRemoteMoved = errors.New("remote branch moved while pushing")
+
+/*
+ * workspace is a temporary bare repository used to talk to a remote.
+ *
+ * Branches are fetched shallowly, files are read with plumbing commands,
+ * and new commits are built with a temporary index on top of the fetched
+ * head, so files outside the mirrored path are preserved.
+ *
+ * Credentials are passed as an http.extraHeader through GIT_CONFIG_*
+ * environment variables, so they never show up in process arguments
+ * or in the remote URL.
+ */
+type workspace struct {
+	dir    string
+	remote *core.GitRemote
+}
+
+func openWorkspace(ctx context.Context, remote *core.GitRemote) (*workspace, error) {
+	dir, err := os.MkdirTemp("", "superplane-git-mirror-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating workspace: %w", err)
+	}
+
+	w := &workspace{dir: dir, remote: remote}
+	if _, err := w.git(ctx, nil, nil, "init", "--bare", "--quiet", dir); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *workspace) Close() {
+	_ = os.RemoveAll(w.dir)
+}
+
+// head returns the commit the remote branch points to,
+// or an empty string if the branch does not exist.
+func (w *workspace) head(ctx context.Context, branch string) (string, error) {
+	output, err := w.git(ctx, nil, nil, "ls-remote", "--heads", "--end-of-options", w.remote.URL, "refs/heads/"+branch)
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == "refs/heads/"+branch {
+			return fields[0], nil
+		}
+	}
+
+	return "", nil
+}
+
+// fetch downloads the tip of the branch and returns its commit.
+// An empty string means the branch does not exist on the remote.
+func (w *workspace) fetch(ctx context.Context, branch string) (string, error) {
+	head, err := w.head(ctx, branch)
+	if err != nil || head == "" {
+		return "", err
+	}
+
+	_, err = w.git(ctx, nil, nil, "fetch", "--quiet", "--no-tags", "--depth=1", "--end-of-options", w.remote.URL, "+refs/heads/"+branch+":refs/mirror/head")
+	if err != nil {
+		return "", err
+	}
+
+	output, err := w.git(ctx, nil, nil, "rev-parse", "--verify", "refs/mirror/head^{commit}")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// message returns the full commit message of a fetched commit.
+func (w *workspace) message(ctx context.Context, commit string) (string, error) {
+	output, err := w.git(ctx, nil, nil, "log", "-1", "--format=%B", commit)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// readFiles returns the regular files under prefix in the commit,
+// keyed by their path relative to prefix.
+func (w *workspace) readFiles(ctx context.Context, commit, prefix string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	if commit == "" {
+		return files, nil
+	}
+
+	args := []string{"ls-tree", "-r", "-z", commit}
+	if prefix != "" {
+		args = append(args, "--", prefix)
+	}
+
+	output, err := w.git(ctx, nil, nil, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range strings.Split(string(output), "\x00") {
+		meta, path, ok := strings.Cut(entry, "\t")
+		if !ok {
+			continue
+		}
+
+		//
+		// Entries are "<mode> <type> <sha>\t<path>".
+		// Only regular files are mirrored; symlinks and submodules are skipped.
+		//
+		fields := strings.Fields(meta)
+		if len(fields) != 3 || fields[1] != "blob" || !strings.HasPrefix(fields[0], "1006") {
+			continue
+		}
+
+		relative, ok := relativePath(prefix, path)
+		if !ok {
+			continue
+		}
+
+		content, err := w.git(ctx, nil, nil, "cat-file", "blob", fields[2])
+		if err != nil {
+			return nil, err
+		}
+
+		files[relative] = content
+	}
+
+	return files, nil
+}
+
+// commit replaces everything under prefix in the parent commit with files,
+// and returns the new commit. An empty parent creates a root commit.
+func (w *workspace) commit(ctx context.Context, parent, prefix string, files map[string][]byte, message string, author gitprovider.CommitAuthor) (string, error) {
+	indexDir, err := os.MkdirTemp("", "superplane-git-mirror-index-")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary index: %w", err)
+	}
+	defer os.RemoveAll(indexDir)
+
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(indexDir, "index")}
+	if parent != "" {
+		_, err = w.git(ctx, env, nil, "read-tree", parent)
+	} else {
+		_, err = w.git(ctx, env, nil, "read-tree", "--empty")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := w.readFiles(ctx, parent, prefix)
+	if err != nil {
+		return "", err
+	}
+
+	//
+	// Index entries use "<mode> <sha>\t<path>" lines,
+	// where mode 0 removes the path from the index.
+	//
+	var entries bytes.Buffer
+	for path := range existing {
+		if _, ok := files[path]; !ok {
+			fmt.Fprintf(&entries, "0 %s\t%s\x00", zeroOID, joinPath(prefix, path))
+		}
+	}
+
+	for path, content := range files {
+		blob, err := w.git(ctx, env, bytes.NewReader(content), "hash-object", "-w", "--stdin")
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(&entries, "100644 %s\t%s\x00", strings.TrimSpace(string(blob)), joinPath(prefix, path))
+	}
+
+	if _, err := w.git(ctx, env, &entries, "update-index", "-z", "--index-info"); err != nil {
+		return "", err
+	}
+
+	tree, err := w.git(ctx, env, nil, "write-tree")
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.TrimSpace(author.Name)
+	email := strings.TrimSpace(author.Email)
+	commitEnv := []string{
+		"GIT_AUTHOR_NAME=" + name,
+		"GIT_AUTHOR_EMAIL=" + email,
+		"GIT_COMMITTER_NAME=" + name,
+		"GIT_COMMITTER_EMAIL=" + email,
+	}
+
+	args := []string{"commit-tree", strings.TrimSpace(string(tree))}
+	if parent != "" {
+		args = append(args, "-p", parent)
+	}
+	args = append(args, "-F", "-")
+
+	output, err := w.git(ctx, commitEnv, strings.NewReader(strings.TrimSpace(message)), args...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// push updates the remote branch to the commit. The push is never forced,
+// so if someone pushed to the branch since it was fetched, ErrRemoteMoved
+// is returned and the next sync sees the new remote head.
+func (w *workspace) push(ctx context.Context, commit, branch string) error {
+	_, err := w.git(ctx, nil, nil, "push", "--quiet", "--porcelain", "--end-of-options", w.remote.URL, commit+":refs/heads/"+branch)
+	if err == nil {
+		return nil
+	}
+
+	var gitErr *gitError
+	if errors.As(err, &gitErr) && (strings.Contains(gitErr.output, "[rejected]") || strings.Contains(gitErr.output, "non-fast-forward")) {
+		return ErrRemoteMoved
+	}
+
+	return err
+}
+
+// git runs git against the workspace, ignoring any system or user git
+// configuration so behavior does not depend on the host.
+func (w *workspace) git(ctx context.Context, env []string, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_CONFIG_GLOBAL="+os.DevNull,
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_DIR="+w.dir,
+	)
+	cmd.Env = append(cmd.Env, w.credentialsEnv()...)
+	cmd.Env = append(cmd.Env, env...)
+	cmd.Stdin = stdin
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return stdout.Bytes(), &gitError{
+			command: args[0],
+			output:  w.redact(strings.TrimSpace(stderr.String() + "\n" + stdout.String())),
+			err:     err,
+		}
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func (w *workspace) credentialsEnv() []string {
+	if w.remote.Username == "" && w.remote.Password == "" {
+		return nil
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(w.remote.Username + ":" + w.remote.Password))
+	return []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.extraHeader",
+		"GIT_CONFIG_VALUE_0=Authorization: Basic " + credentials,
+	}
+}
+
+func (w *workspace) redact(output string) string {
+	if w.remote.Password == "" {
+		return output
+	}
+
+	return strings.ReplaceAll(output, w.remote.Password, "[REDACTED]")
+}
+
+type gitError struct {
+	command string
+	output  string
+	err     error
+}
+
+func (e *gitError) Error() string {
+	if e.output == "" {
+		return fmt.Sprintf("git %s: %v", e.command, e.err)
+	}
+	return fmt.Sprintf("git %s: %v: %s", e.command, e.err, e.output)
+}
+
+func (e *gitError) Unwrap() error {
+	return e.err
+}
+
+const zeroOID = "0000000000000000000000000000000000000000"
+
+// normalizePrefix turns the configured mirror path into a tree prefix,
+// where an empty prefix mirrors the whole repository.
+func normalizePrefix(path string) (string, error) {
+	if strings.Trim(strings.TrimSpace(path), "/") == "" {
+		return "", nil
+	}
+
+	return gitprovider.ValidateUserPath(path)
+}
+
+func relativePath(prefix, path string) (string, bool) {
+	if prefix == "" {
+		return path, true
+	}
+
+	return strings.CutPrefix(path, prefix+"/")
+}
+
+func joinPath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+
+	return prefix + "/" + path
+}
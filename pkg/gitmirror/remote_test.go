@@ -0,0 +1,147 @@
+package gitmirror
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	gitprovider "github.com/superplanehq/superplane/pkg/git/provider"
+)
+
+func Test__Workspace__PushAndRead(t *testing.T) {
+	remote := newTestRemote(t)
+	ctx := context.Background()
+	author := gitprovider.CommitAuthor{Name: "Jane", Email: "jane@example.com"}
+
+	w, err := openWorkspace(ctx, remote)
+	require.NoError(t, err)
+	defer w.Close()
+
+	head, err := w.fetch(ctx, "main")
+	require.NoError(t, err)
+	assert.Empty(t, head)
+
+	//
+	// Seed the branch with a file outside the mirrored path.
+	//
+	seed, err := w.commit(ctx, "", "", map[string][]byte{"README.md": []byte("hello")}, "Seed", author)
+	require.NoError(t, err)
+	require.NoError(t, w.push(ctx, seed, "main"))
+
+	head, err = w.fetch(ctx, "main")
+	require.NoError(t, err)
+	assert.Equal(t, seed, head)
+
+	first, err := w.commit(ctx, head, "canvases/demo", map[string][]byte{
+		"canvas.yaml":    []byte("name: demo\n"),
+		"scripts/run.sh": []byte("echo hi\n"),
+	}, "Publish\n\nSuperPlane-Version: 1", author)
+	require.NoError(t, err)
+	require.NoError(t, w.push(ctx, first, "main"))
+
+	second, err := w.commit(ctx, first, "canvases/demo", map[string][]byte{
+		"canvas.yaml": []byte("name: demo-2\n"),
+	}, "Publish again", author)
+	require.NoError(t, err)
+	require.NoError(t, w.push(ctx, second, "main"))
+
+	other, err := openWorkspace(ctx, remote)
+	require.NoError(t, err)
+	defer other.Close()
+
+	head, err = other.fetch(ctx, "main")
+	require.NoError(t, err)
+	assert.Equal(t, second, head)
+
+	files, err := other.readFiles(ctx, head, "canvases/demo")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"canvas.yaml": []byte("name: demo-2\n")}, files)
+
+	all, err := other.readFiles(ctx, head, "")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), all["README.md"])
+
+	message, err := other.message(ctx, first)
+	require.Error(t, err, "commits behind the shallow fetch are not available")
+	assert.Empty(t, message)
+
+	message, err = other.message(ctx, head)
+	require.NoError(t, err)
+	assert.Equal(t, "Publish again", message)
+}
+
+func Test__Workspace__PushRejectedWhenRemoteMoved(t *testing.T) {
+	remote := newTestRemote(t)
+	ctx := context.Background()
+	author := gitprovider.SuperPlaneBotAuthor()
+
+	w, err := openWorkspace(ctx, remote)
+	require.NoError(t, err)
+	defer w.Close()
+
+	base, err := w.commit(ctx, "", "", map[string][]byte{"canvas.yaml": []byte("a")}, "Base", author)
+	require.NoError(t, err)
+	require.NoError(t, w.push(ctx, base, "main"))
+
+	moved, err := w.commit(ctx, base, "", map[string][]byte{"canvas.yaml": []byte("b")}, "Moved", author)
+	require.NoError(t, err)
+	require.NoError(t, w.push(ctx, moved, "main"))
+
+	stale, err := w.commit(ctx, base, "", map[string][]byte{"canvas.yaml": []byte("c")}, "Stale", author)
+	require.NoError(t, err)
+	assert.ErrorIs(t, w.push(ctx, stale, "main"), ErrRemoteMoved)
+}
+
+func Test__Workspace__RedactsCredentials(t *testing.T) {
+	ctx := context.Background()
+	w, err := openWorkspace(ctx, &core.GitRemote{
+		URL:      "file://" + filepath.Join(t.TempDir(), "missing-secret-token.git"),
+		Username: "x-access-token",
+		Password: "secret-token",
+	})
+	require.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.head(ctx, "main")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-token")
+	assert.Contains(t, err.Error(), "[REDACTED]")
+}
+
+func Test__NormalizePrefix(t *testing.T) {
+	for input, expected := range map[string]string{
+		"":                 "",
+		"/":                "",
+		"canvases/demo":    "canvases/demo",
+		"/canvases/demo/":  "canvases/demo",
+		"canvases//demo":   "canvases/demo",
+		" canvases\\demo ": "canvases/demo",
+	} {
+		prefix, err := normalizePrefix(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, prefix, input)
+	}
+
+	for _, input := range []string{"../outside", ".git/hooks", ".superplane", "a/.git"} {
+		_, err := normalizePrefix(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func newTestRemote(t *testing.T) *core.GitRemote {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary is not available")
+	}
+
+	path := filepath.Join(t.TempDir(), "remote.git")
+	output, err := exec.Command("git", "init", "--bare", "--quiet", "--initial-branch=main", path).CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	return &core.GitRemote{URL: "file://" + path}
+}
@@ -0,0 +1,529 @@
+package gitmirror
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	"github.com/superplanehq/superplane/pkg/authorization"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/database"
+	gitprovider "github.com/superplanehq/superplane/pkg/git/provider"
+	"github.com/superplanehq/superplane/pkg/grpc/actions/canvases"
+	"github.com/superplanehq/superplane/pkg/logging"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"github.com/superplanehq/superplane/pkg/usage"
+	"github.com/superplanehq/superplane/pkg/workers/contexts"
+)
+
+// SyncInterval is how often each mirror polls its remote branch.
+const SyncInterval = time.Minute
+
+/*
+ * Service syncs canvases with their external git mirrors.
+ *
+ * A mirror remembers the last canvas version it pushed and the remote
+ * commit that matched it. On every sync, the live version and the remote
+ * head are compared against them:
+ *
+ * - Only the canvas changed: the live version is pushed as a new commit.
+ * - Only the remote changed: the remote files are imported, either into
+ *   the staging area of the user who configured the mirror, or published
+ *   directly as a new version. With imports disabled, the live version is
+ *   pushed over the remote changes instead.
+ * - Both changed: unless both sides ended up with the same files, this
+ *   is a conflict. It is recorded as a mirror event, shown in the canvas
+ *   version history, and nothing is synced until it is resolved.
+ */
+type Service struct {
+	Registry       *registry.Registry
+	Encryptor      crypto.Encryptor
+	AuthService    authorization.Authorization
+	GitProvider    gitprovider.Provider
+	WebhookBaseURL string
+	UsageService   usage.Service
+}
+
+func (s *Service) Sync(ctx context.Context, mirror *models.CanvasGitMirror) error {
+	err := s.sync(ctx, mirror)
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, ErrRemoteMoved) {
+		return mirror.MarkChecked(database.Conn(), err.Error(), time.Now())
+	}
+
+	if recordErr := s.recordEvent(mirror, models.CanvasGitMirrorEvent{
+		Direction: failureDirection(err),
+		Status:    models.CanvasGitMirrorEventStatusFailed,
+		Message:   err.Error(),
+	}); recordErr != nil {
+		log.Errorf("failed to record git mirror event for canvas %s: %v", mirror.CanvasID, recordErr)
+	}
+
+	if markErr := mirror.MarkChecked(database.Conn(), err.Error(), time.Now().Add(SyncInterval)); markErr != nil {
+		return markErr
+	}
+
+	return err
+}
+
+func (s *Service) sync(ctx context.Context, mirror *models.CanvasGitMirror) error {
+	canvas, err := models.FindCanvasWithoutOrgScope(mirror.CanvasID)
+	if err != nil {
+		return fmt.Errorf("canvas not found: %w", err)
+	}
+
+	liveVersion, err := models.FindLiveCanvasVersion(canvas.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load live version: %w", err)
+	}
+
+	prefix, err := normalizePrefix(mirror.Path)
+	if err != nil {
+		return err
+	}
+
+	remote, err := s.resolveRemote(mirror)
+	if err != nil {
+		return err
+	}
+
+	w, err := openWorkspace(ctx, remote)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	head, err := w.head(ctx, mirror.Branch)
+	if err != nil {
+		return err
+	}
+
+	localChanged := mirror.LastPushedVersionID == nil || *mirror.LastPushedVersionID != liveVersion.ID
+	remoteChanged := mirror.RemoteHeadSHA != "" && head != "" && head != mirror.RemoteHeadSHA
+	if !localChanged && !remoteChanged && head != "" {
+		return mirror.MarkChecked(database.Conn(), "", time.Now().Add(SyncInterval))
+	}
+
+	head, err = w.fetch(ctx, mirror.Branch)
+	if err != nil {
+		return err
+	}
+
+	remoteFiles, err := w.readFiles(ctx, head, prefix)
+	if err != nil {
+		return err
+	}
+
+	localFiles, err := s.canvasFiles(ctx, canvas, liveVersion)
+	if err != nil {
+		return err
+	}
+
+	//
+	// Both sides may have converged on their own,
+	// e.g. when someone applied the same change on both.
+	//
+	if head != "" && sameFiles(localFiles, remoteFiles) {
+		return mirror.MarkSynced(database.Conn(), liveVersion.ID, head, time.Now().Add(SyncInterval))
+	}
+
+	importEnabled := mirror.ImportMode != models.CanvasGitMirrorImportModeDisabled
+	if remoteChanged && importEnabled {
+		if localChanged {
+			return s.recordConflict(mirror, head, "both the canvas and the remote branch changed since the last sync")
+		}
+
+		return s.importRemote(ctx, w, mirror, canvas, liveVersion, head, localFiles, remoteFiles)
+	}
+
+	return s.push(ctx, w, mirror, liveVersion, head, prefix, localFiles)
+}
+
+func (s *Service) push(
+	ctx context.Context,
+	w *workspace,
+	mirror *models.CanvasGitMirror,
+	version *models.CanvasVersion,
+	head string,
+	prefix string,
+	files map[string][]byte,
+) error {
+	commit, err := w.commit(ctx, head, prefix, files, pushCommitMessage(version), s.versionAuthor(mirror, version))
+	if err != nil {
+		return &syncError{direction: models.CanvasGitMirrorDirectionPush, err: err}
+	}
+
+	if err := w.push(ctx, commit, mirror.Branch); err != nil {
+		if errors.Is(err, ErrRemoteMoved) {
+			return err
+		}
+
+		return &syncError{direction: models.CanvasGitMirrorDirectionPush, err: err}
+	}
+
+	if err := mirror.MarkSynced(database.Conn(), version.ID, commit, time.Now().Add(SyncInterval)); err != nil {
+		return err
+	}
+
+	return s.recordEvent(mirror, models.CanvasGitMirrorEvent{
+		Direction:       models.CanvasGitMirrorDirectionPush,
+		Status:          models.CanvasGitMirrorEventStatusSucceeded,
+		VersionID:       &version.ID,
+		RemoteCommitSHA: commit,
+		Message:         fmt.Sprintf("Pushed to %s", mirror.Branch),
+	})
+}
+
+func (s *Service) importRemote(
+	ctx context.Context,
+	w *workspace,
+	mirror *models.CanvasGitMirror,
+	canvas *models.Canvas,
+	liveVersion *models.CanvasVersion,
+	head string,
+	localFiles map[string][]byte,
+	remoteFiles map[string][]byte,
+) error {
+	operations, err := importOperations(localFiles, remoteFiles)
+	if err != nil {
+		return &syncError{direction: models.CanvasGitMirrorDirectionImport, err: err}
+	}
+
+	db := database.Conn()
+	userCtx := authentication.SetUserIdInMetadata(ctx, mirror.CreatedBy.String())
+
+	policy, err := models.FindEffectiveCanvasReviewPolicy(db, canvas)
+	if err != nil {
+		return err
+	}
+
+	//
+	// Canvases under a review policy are only published through
+	// approved change requests, so remote changes are always staged.
+	//
+	if mirror.ImportMode == models.CanvasGitMirrorImportModeStaging || policy.RequiresReview() {
+		if _, err := canvases.PutCanvasStaging(userCtx, db, canvas, operations); err != nil {
+			return &syncError{direction: models.CanvasGitMirrorDirectionImport, err: err}
+		}
+
+		//
+		// The live version did not change, so it is still the last one pushed.
+		// Once the staged changes are published, the next sync pushes them back,
+		// which is a no-op if nothing else changed in the meantime.
+		//
+		if err := mirror.MarkSynced(db, liveVersion.ID, head, time.Now().Add(SyncInterval)); err != nil {
+			return err
+		}
+
+		return s.recordEvent(mirror, models.CanvasGitMirrorEvent{
+			Direction:       models.CanvasGitMirrorDirectionImport,
+			Status:          models.CanvasGitMirrorEventStatusSucceeded,
+			RemoteCommitSHA: head,
+			Message:         fmt.Sprintf("Staged %d file(s) from %s", len(operations), mirror.Branch),
+		})
+	}
+
+	//
+	// Publishing goes through the staging area of the mirror owner,
+	// so it must not mix remote changes with their unpublished work.
+	//
+	staged, err := models.ListStagedFilesForUser(db, canvas.ID, mirror.CreatedBy)
+	if err != nil {
+		return err
+	}
+
+	if len(staged) > 0 {
+		return s.recordConflict(mirror, head, "the remote branch changed, but the mirror owner has unpublished staged changes")
+	}
+
+	message, err := w.message(ctx, head)
+	if err != nil {
+		return &syncError{direction: models.CanvasGitMirrorDirectionImport, err: err}
+	}
+
+	if _, err := canvases.PutCanvasStaging(userCtx, db, canvas, operations); err != nil {
+		return &syncError{direction: models.CanvasGitMirrorDirectionImport, err: err}
+	}
+
+	_, err = canvases.CommitCanvasStaging(
+		userCtx,
+		db,
+		s.GitProvider,
+		s.UsageService,
+		s.Encryptor,
+		s.Registry,
+		canvas,
+		importCommitMessage(message, mirror.Branch, head),
+		s.WebhookBaseURL,
+		s.AuthService,
+	)
+
+	if err != nil {
+		if discardErr := models.DiscardStagedFilesForUser(db, canvas.ID, mirror.CreatedBy, nil); discardErr != nil {
+			log.Errorf("failed to discard imported staging for canvas %s: %v", canvas.ID, discardErr)
+		}
+
+		return &syncError{direction: models.CanvasGitMirrorDirectionImport, err: err}
+	}
+
+	newVersion, err := models.FindLiveCanvasVersion(canvas.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := mirror.MarkSynced(db, newVersion.ID, head, time.Now().Add(SyncInterval)); err != nil {
+		return err
+	}
+
+	return s.recordEvent(mirror, models.CanvasGitMirrorEvent{
+		Direction:       models.CanvasGitMirrorDirectionImport,
+		Status:          models.CanvasGitMirrorEventStatusSucceeded,
+		VersionID:       &newVersion.ID,
+		RemoteCommitSHA: head,
+		Message:         fmt.Sprintf("Published from %s", mirror.Branch),
+	})
+}
+
+func (s *Service) recordConflict(mirror *models.CanvasGitMirror, head, message string) error {
+	if err := s.recordEvent(mirror, models.CanvasGitMirrorEvent{
+		Direction:       models.CanvasGitMirrorDirectionImport,
+		Status:          models.CanvasGitMirrorEventStatusConflict,
+		RemoteCommitSHA: head,
+		Message:         message,
+	}); err != nil {
+		return err
+	}
+
+	return mirror.MarkChecked(database.Conn(), "conflict: "+message, time.Now().Add(SyncInterval))
+}
+
+func (s *Service) recordEvent(mirror *models.CanvasGitMirror, event models.CanvasGitMirrorEvent) error {
+	event.MirrorID = mirror.ID
+	event.CanvasID = mirror.CanvasID
+	return models.CreateCanvasGitMirrorEvent(database.Conn(), &event)
+}
+
+func (s *Service) resolveRemote(mirror *models.CanvasGitMirror) (*core.GitRemote, error) {
+	tx := database.Conn()
+	integration, err := models.FindIntegrationInTransaction(tx, mirror.OrganizationID, mirror.IntegrationID)
+	if err != nil {
+		return nil, fmt.Errorf("integration not found: %w", err)
+	}
+
+	if integration.State != models.IntegrationStateReady {
+		return nil, fmt.Errorf("integration %q is not ready", integration.InstallationName)
+	}
+
+	integrationImpl, err := s.Registry.GetIntegration(integration.AppName)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, ok := registry.UnwrapIntegration(integrationImpl).(core.IntegrationGitRemoteProvider)
+	if !ok {
+		return nil, fmt.Errorf("integration %q does not support git mirroring", integration.InstallationName)
+	}
+
+	return provider.ResolveGitRemote(core.IntegrationGitRemoteContext{
+		Logger:      logging.ForIntegration(*integration),
+		HTTP:        s.Registry.HTTPContextInTransaction(tx),
+		Integration: contexts.NewIntegrationContext(tx, nil, integration, s.Encryptor, s.Registry, nil),
+	}, mirror.Repository)
+}
+
+// canvasFiles returns the files of the canvas repository as of the
+// live version: the generated spec files plus the repository files.
+func (s *Service) canvasFiles(ctx context.Context, canvas *models.Canvas, version *models.CanvasVersion) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for _, path := range []string{canvases.CanvasYAMLRepositoryPath, canvases.ConsoleYAMLRepositoryPath} {
+		content, err := canvases.ReadRepositorySpecFile(ctx, canvas, version, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", path, err)
+		}
+
+		files[path] = []byte(content)
+	}
+
+	if s.GitProvider == nil {
+		return files, nil
+	}
+
+	repository, err := models.FindRepositoryUnscoped(canvas.ID)
+	if err != nil || repository.Status != models.RepositoryStatusReady {
+		return files, nil
+	}
+
+	paths, err := s.GitProvider.ListFiles(ctx, repository.RepoID, "main")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository files: %w", err)
+	}
+
+	for _, path := range paths {
+		if isReservedPath(path) || canvases.IsRepositorySpecFilePath(path) {
+			continue
+		}
+
+		reader, err := s.GitProvider.GetFile(ctx, repository.RepoID, path, "main")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		content, err := io.ReadAll(reader)
+		_ = reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		files[path] = content
+	}
+
+	return files, nil
+}
+
+func (s *Service) versionAuthor(mirror *models.CanvasGitMirror, version *models.CanvasVersion) gitprovider.CommitAuthor {
+	if version.OwnerID == nil {
+		return gitprovider.SuperPlaneBotAuthor()
+	}
+
+	user, err := models.FindActiveUserByID(mirror.OrganizationID.String(), version.OwnerID.String())
+	if err != nil {
+		return gitprovider.SuperPlaneBotAuthor()
+	}
+
+	return gitprovider.CommitAuthor{Name: user.Name, Email: user.GetEmail()}
+}
+
+// importOperations turns the remote files into staging operations.
+// Files removed on the remote are deleted, except for the spec files,
+// which every canvas has: canvas.yaml must be present on the remote,
+// and a missing console.yaml leaves the console untouched.
+func importOperations(localFiles, remoteFiles map[string][]byte) ([]*pb.CanvasRepositoryFileOperation, error) {
+	if _, ok := remoteFiles[canvases.CanvasYAMLRepositoryPath]; !ok {
+		return nil, fmt.Errorf("remote branch has no %s", canvases.CanvasYAMLRepositoryPath)
+	}
+
+	operations := []*pb.CanvasRepositoryFileOperation{}
+	for _, path := range sortedPaths(remoteFiles) {
+		if isReservedPath(path) {
+			continue
+		}
+
+		local, ok := localFiles[path]
+		if ok && bytes.Equal(local, remoteFiles[path]) {
+			continue
+		}
+
+		operations = append(operations, &pb.CanvasRepositoryFileOperation{
+			Path:    path,
+			Content: remoteFiles[path],
+		})
+	}
+
+	for _, path := range sortedPaths(localFiles) {
+		if _, ok := remoteFiles[path]; ok || canvases.IsRepositorySpecFilePath(path) {
+			continue
+		}
+
+		operations = append(operations, &pb.CanvasRepositoryFileOperation{
+			Path:   path,
+			Delete: true,
+		})
+	}
+
+	return operations, nil
+}
+
+func sameFiles(localFiles, remoteFiles map[string][]byte) bool {
+	count := 0
+	for path, content := range remoteFiles {
+		if isReservedPath(path) {
+			continue
+		}
+
+		local, ok := localFiles[path]
+		if !ok || !bytes.Equal(local, content) {
+			return false
+		}
+
+		count++
+	}
+
+	return count == len(localFiles)
+}
+
+func sortedPaths(files map[string][]byte) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+	return paths
+}
+
+func isReservedPath(path string) bool {
+	return path == gitprovider.ReservedSuperPlanePath || strings.HasPrefix(path, gitprovider.ReservedSuperPlanePath+"/")
+}
+
+func pushCommitMessage(version *models.CanvasVersion) string {
+	message := strings.TrimSpace(version.CommitMessage)
+	if message == "" {
+		message = "Publish canvas version"
+	}
+
+	return fmt.Sprintf("%s\n\nSuperPlane-Version: %s", message, version.ID)
+}
+
+func importCommitMessage(remoteMessage, branch, head string) string {
+	subject, _, _ := strings.Cut(strings.TrimSpace(remoteMessage), "\n")
+	if subject == "" {
+		subject = "Import from " + branch
+	}
+
+	return fmt.Sprintf("%s (%s@%s)", subject, branch, shortSHA(head))
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+
+	return sha
+}
+
+// syncError tags a failure with the direction it happened in,
+// so the recorded event shows up on the right side of the history.
+type syncError struct {
+	direction string
+	err       error
+}
+
+func (e *syncError) Error() string {
+	return e.err.Error()
+}
+
+func (e *syncError) Unwrap() error {
+	return e.err
+}
+
+func failureDirection(err error) string {
+	var syncErr *syncError
+	if errors.As(err, &syncErr) {
+		return syncErr.direction
+	}
+
+	return models.CanvasGitMirrorDirectionPush
+}
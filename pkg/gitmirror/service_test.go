@@ -0,0 +1,60 @@
+package gitmirror
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+)
+
+func Test__ImportOperations(t *testing.T) {
+	local := map[string][]byte{
+		"canvas.yaml":  []byte("old"),
+		"console.yaml": []byte("console"),
+		"README.md":    []byte("readme"),
+		"old.sh":       []byte("echo old"),
+	}
+
+	remote := map[string][]byte{
+		"canvas.yaml":          []byte("new"),
+		"README.md":            []byte("readme"),
+		"new.sh":               []byte("echo new"),
+		".superplane/state":    []byte("ignored"),
+		".superplane/internal": []byte("ignored"),
+	}
+
+	operations, err := importOperations(local, remote)
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.CanvasRepositoryFileOperation{
+		{Path: "canvas.yaml", Content: []byte("new")},
+		{Path: "new.sh", Content: []byte("echo new")},
+		{Path: "old.sh", Delete: true},
+	}, operations)
+
+	_, err = importOperations(local, map[string][]byte{"README.md": []byte("readme")})
+	assert.ErrorContains(t, err, "canvas.yaml")
+}
+
+func Test__SameFiles(t *testing.T) {
+	local := map[string][]byte{"canvas.yaml": []byte("a"), "README.md": []byte("b")}
+
+	assert.True(t, sameFiles(local, map[string][]byte{"canvas.yaml": []byte("a"), "README.md": []byte("b")}))
+	assert.True(t, sameFiles(local, map[string][]byte{"canvas.yaml": []byte("a"), "README.md": []byte("b"), ".superplane/x": []byte("c")}))
+	assert.False(t, sameFiles(local, map[string][]byte{"canvas.yaml": []byte("a")}))
+	assert.False(t, sameFiles(local, map[string][]byte{"canvas.yaml": []byte("a"), "README.md": []byte("changed")}))
+	assert.False(t, sameFiles(local, map[string][]byte{"canvas.yaml": []byte("a"), "README.md": []byte("b"), "extra": []byte("c")}))
+}
+
+func Test__CommitMessages(t *testing.T) {
+	version := &models.CanvasVersion{ID: uuid.MustParse("7d3c3a3c-8a42-4a44-9d0b-5d7f0b7e6a10"), CommitMessage: " Add approval step "}
+	assert.Equal(t, "Add approval step\n\nSuperPlane-Version: 7d3c3a3c-8a42-4a44-9d0b-5d7f0b7e6a10", pushCommitMessage(version))
+
+	version.CommitMessage = ""
+	assert.Equal(t, "Publish canvas version\n\nSuperPlane-Version: 7d3c3a3c-8a42-4a44-9d0b-5d7f0b7e6a10", pushCommitMessage(version))
+
+	assert.Equal(t, "Bump timeout (main@0123456789ab)", importCommitMessage("Bump timeout\n\nLonger body", "main", "0123456789abcdef"))
+	assert.Equal(t, "Import from main (main@abc)", importCommitMessage("", "main", "abc"))
+}
@@ -0,0 +1,57 @@
+package canvases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"gorm.io/gorm"
+)
+
+const maxChangeRequestCommentLength = 10000
+
+func AddCanvasChangeRequestComment(
+	ctx context.Context,
+	db *gorm.DB,
+	canvas *models.Canvas,
+	changeRequestID string,
+	body string,
+) (*pb.AddCanvasChangeRequestCommentResponse, error) {
+	user, ok := authentication.GetUserIdFromMetadata(ctx)
+	if !ok {
+		return nil, grpcerrors.Unauthenticated(nil, "user not authenticated")
+	}
+
+	id, err := parseChangeRequestID(changeRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, grpcerrors.InvalidArgument(nil, "comment is required")
+	}
+
+	if len(body) > maxChangeRequestCommentLength {
+		return nil, grpcerrors.InvalidArgument(nil, "comment must be 10000 characters or less")
+	}
+
+	request, err := models.FindCanvasChangeRequest(db, canvas.ID, id)
+	if err != nil {
+		return nil, changeRequestErrorToStatus(err, "failed to find change request")
+	}
+
+	userID := uuid.MustParse(user)
+	event, err := models.CreateCanvasChangeRequestEvent(db, request.ID, userID, models.CanvasChangeRequestEventComment, body)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to add comment")
+	}
+
+	return &pb.AddCanvasChangeRequestCommentResponse{
+		Event: serializeCanvasChangeRequestEvent(canvas.OrganizationID.String(), event, nil),
+	}, nil
+}
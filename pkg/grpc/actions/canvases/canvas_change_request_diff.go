@@ -0,0 +1,176 @@
+package canvases
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"github.com/superplanehq/superplane/pkg/yaml"
+)
+
+// buildCanvasChangeRequestDiff compares the proposed files with the version
+// the change request is based on. Nodes are matched by ID, and only the parts
+// of a node a reviewer cares about are compared, so moving a node around
+// the canvas is not reported as a change.
+func buildCanvasChangeRequestDiff(
+	registry *registry.Registry,
+	canvas *models.Canvas,
+	baseVersion *models.CanvasVersion,
+	files []models.CanvasChangeRequestFile,
+) *pb.CanvasChangeRequestDiff {
+	diff := &pb.CanvasChangeRequestDiff{
+		Nodes: []*pb.CanvasChangeRequestDiff_NodeChange{},
+		Edges: []*pb.CanvasChangeRequestDiff_EdgeChange{},
+		Files: []*pb.CanvasChangeRequestDiff_FileChange{},
+	}
+
+	for _, file := range files {
+		switch file.Path {
+		case CanvasYAMLRepositoryPath:
+			spec, err := yaml.CanvasFromYAML([]byte(file.Content))
+			if err != nil {
+				diff.Error = err.Error()
+				continue
+			}
+
+			nodes, edges, err := spec.Parse(registry, canvas.OrganizationID.String())
+			if err != nil {
+				diff.Error = err.Error()
+				continue
+			}
+
+			diff.Nodes = diffCanvasNodes(baseVersion.Nodes, nodes)
+			diff.Edges = diffCanvasEdges(baseVersion.Edges, edges)
+
+		case ConsoleYAMLRepositoryPath:
+			current, err := yaml.VersionToConsoleYML(canvas.Name, baseVersion)
+			diff.ConsoleChanged = err != nil || current != file.Content
+
+		default:
+			kind := pb.CanvasChangeRequestDiff_CHANGE_KIND_CHANGED
+			if file.Deleted {
+				kind = pb.CanvasChangeRequestDiff_CHANGE_KIND_REMOVED
+			}
+
+			diff.Files = append(diff.Files, &pb.CanvasChangeRequestDiff_FileChange{
+				Kind: kind,
+				Path: file.Path,
+			})
+		}
+	}
+
+	return diff
+}
+
+func diffCanvasNodes(base []models.Node, proposed []models.Node) []*pb.CanvasChangeRequestDiff_NodeChange {
+	changes := []*pb.CanvasChangeRequestDiff_NodeChange{}
+	baseByID := make(map[string]models.Node, len(base))
+	for _, node := range base {
+		baseByID[node.ID] = node
+	}
+
+	proposedIDs := make(map[string]bool, len(proposed))
+	for _, node := range proposed {
+		proposedIDs[node.ID] = true
+
+		previous, ok := baseByID[node.ID]
+		if !ok {
+			changes = append(changes, &pb.CanvasChangeRequestDiff_NodeChange{
+				Kind:     pb.CanvasChangeRequestDiff_CHANGE_KIND_ADDED,
+				NodeId:   node.ID,
+				NodeName: node.Name,
+			})
+			continue
+		}
+
+		fields := changedNodeFields(previous, node)
+		if len(fields) == 0 {
+			continue
+		}
+
+		changes = append(changes, &pb.CanvasChangeRequestDiff_NodeChange{
+			Kind:     pb.CanvasChangeRequestDiff_CHANGE_KIND_CHANGED,
+			NodeId:   node.ID,
+			NodeName: node.Name,
+			Fields:   fields,
+		})
+	}
+
+	for _, node := range base {
+		if proposedIDs[node.ID] {
+			continue
+		}
+
+		changes = append(changes, &pb.CanvasChangeRequestDiff_NodeChange{
+			Kind:     pb.CanvasChangeRequestDiff_CHANGE_KIND_REMOVED,
+			NodeId:   node.ID,
+			NodeName: node.Name,
+		})
+	}
+
+	return changes
+}
+
+// changedNodeFields compares values through their JSON encoding,
+// since nodes loaded from the database and nodes parsed from YAML
+// do not use the same Go types for numbers.
+func changedNodeFields(base, proposed models.Node) []string {
+	fields := []string{}
+	compare := func(field string, a, b any) {
+		if !sameJSON(a, b) {
+			fields = append(fields, field)
+		}
+	}
+
+	compare("name", base.Name, proposed.Name)
+	compare("ref", base.Ref, proposed.Ref)
+	compare("configuration", base.Configuration, proposed.Configuration)
+	compare("concurrency", base.Concurrency, proposed.Concurrency)
+	compare("integration", base.IntegrationID, proposed.IntegrationID)
+	return fields
+}
+
+func sameJSON(a, b any) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return bytes.Equal(encodedA, encodedB)
+}
+
+func diffCanvasEdges(base []models.Edge, proposed []models.Edge) []*pb.CanvasChangeRequestDiff_EdgeChange {
+	changes := []*pb.CanvasChangeRequestDiff_EdgeChange{}
+	baseSet := make(map[models.Edge]bool, len(base))
+	for _, edge := range base {
+		baseSet[edge] = true
+	}
+
+	proposedSet := make(map[models.Edge]bool, len(proposed))
+	for _, edge := range proposed {
+		proposedSet[edge] = true
+		if !baseSet[edge] {
+			changes = append(changes, edgeChange(pb.CanvasChangeRequestDiff_CHANGE_KIND_ADDED, edge))
+		}
+	}
+
+	for _, edge := range base {
+		if !proposedSet[edge] {
+			changes = append(changes, edgeChange(pb.CanvasChangeRequestDiff_CHANGE_KIND_REMOVED, edge))
+		}
+	}
+
+	return changes
+}
+
+func edgeChange(kind pb.CanvasChangeRequestDiff_ChangeKind, edge models.Edge) *pb.CanvasChangeRequestDiff_EdgeChange {
+	return &pb.CanvasChangeRequestDiff_EdgeChange{
+		Kind:     kind,
+		SourceId: edge.SourceID,
+		TargetId: edge.TargetID,
+		Channel:  edge.Channel,
+	}
+}
@@ -0,0 +1,261 @@
+package canvases
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// changeRequestReview is where a change request stands against
+// the review policy of its canvas. Only reviews given since the last
+// resubmission, by users the policy allows to review, count.
+type changeRequestReview struct {
+	requiredApprovals int
+	approvals         []models.CanvasChangeRequestEvent
+	rejections        []models.CanvasChangeRequestEvent
+	stale             bool
+	blockedReason     string
+}
+
+func (r *changeRequestReview) mergeable() bool {
+	return r.blockedReason == ""
+}
+
+func evaluateChangeRequest(
+	canvas *models.Canvas,
+	request *models.CanvasChangeRequest,
+	policy *models.CanvasReviewPolicy,
+	events []models.CanvasChangeRequestEvent,
+) *changeRequestReview {
+	review := &changeRequestReview{
+		stale:      canvas.LiveVersionID == nil || *canvas.LiveVersionID != request.BaseVersionID,
+		approvals:  []models.CanvasChangeRequestEvent{},
+		rejections: []models.CanvasChangeRequestEvent{},
+	}
+
+	if policy.RequiresReview() {
+		review.requiredApprovals = policy.RequiredApprovals
+	}
+
+	for _, event := range models.CurrentCanvasChangeRequestReviews(events) {
+		if event.UserID == request.AuthorID || !policy.CanReview(event.UserID) {
+			continue
+		}
+
+		if event.Type == models.CanvasChangeRequestEventApproved {
+			review.approvals = append(review.approvals, event)
+		} else {
+			review.rejections = append(review.rejections, event)
+		}
+	}
+
+	switch {
+	case !request.IsOpen():
+		review.blockedReason = fmt.Sprintf("change request is %s", request.Status)
+	case review.stale:
+		review.blockedReason = "the canvas changed since the change request was submitted, resubmit it from a fresh staging"
+	case len(review.rejections) > 0:
+		review.blockedReason = "change request was rejected by a reviewer"
+	case len(review.approvals) < review.requiredApprovals:
+		review.blockedReason = fmt.Sprintf("%d more approval(s) required", review.requiredApprovals-len(review.approvals))
+	}
+
+	return review
+}
+
+func serializeCanvasChangeRequest(
+	canvas *models.Canvas,
+	request *models.CanvasChangeRequest,
+	policy *models.CanvasReviewPolicy,
+	events []models.CanvasChangeRequestEvent,
+	usersByID map[string]*models.User,
+) *pb.CanvasChangeRequest {
+	organizationID := canvas.OrganizationID.String()
+	review := evaluateChangeRequest(canvas, request, policy, events)
+
+	result := &pb.CanvasChangeRequest{
+		Metadata: &pb.CanvasChangeRequest_Metadata{
+			Id:            request.ID.String(),
+			CanvasId:      request.CanvasID.String(),
+			Author:        canvasVersionOwnerRef(organizationID, request.AuthorID.String(), usersByID),
+			BaseVersionId: request.BaseVersionID.String(),
+		},
+		Spec: &pb.CanvasChangeRequest_Spec{
+			Title:       request.Title,
+			Description: request.Description,
+		},
+		Status: &pb.CanvasChangeRequest_Status{
+			State:             changeRequestStateToProto(request.Status),
+			RequiredApprovals: uint32(review.requiredApprovals),
+			ApprovedBy:        changeRequestReviewerRefs(organizationID, review.approvals, usersByID),
+			RejectedBy:        changeRequestReviewerRefs(organizationID, review.rejections, usersByID),
+			Stale:             review.stale,
+			Mergeable:         review.mergeable(),
+			BlockedReason:     review.blockedReason,
+		},
+	}
+
+	if request.CreatedAt != nil {
+		result.Metadata.CreatedAt = timestamppb.New(*request.CreatedAt)
+	}
+	if request.UpdatedAt != nil {
+		result.Metadata.UpdatedAt = timestamppb.New(*request.UpdatedAt)
+	}
+	if request.MergedVersionID != nil {
+		result.Status.MergedVersionId = request.MergedVersionID.String()
+	}
+	if request.ClosedBy != nil {
+		result.Status.ClosedBy = canvasVersionOwnerRef(organizationID, request.ClosedBy.String(), usersByID)
+	}
+	if request.ClosedAt != nil {
+		result.Status.ClosedAt = timestamppb.New(*request.ClosedAt)
+	}
+
+	return result
+}
+
+func changeRequestReviewerRefs(organizationID string, reviews []models.CanvasChangeRequestEvent, usersByID map[string]*models.User) []*pb.UserRef {
+	refs := make([]*pb.UserRef, 0, len(reviews))
+	for _, review := range reviews {
+		refs = append(refs, canvasVersionOwnerRef(organizationID, review.UserID.String(), usersByID))
+	}
+
+	return refs
+}
+
+func serializeCanvasChangeRequestEvent(organizationID string, event *models.CanvasChangeRequestEvent, usersByID map[string]*models.User) *pb.CanvasChangeRequestEvent {
+	result := &pb.CanvasChangeRequestEvent{
+		Id:   event.ID.String(),
+		Type: changeRequestEventTypeToProto(event.Type),
+		User: canvasVersionOwnerRef(organizationID, event.UserID.String(), usersByID),
+		Body: event.Body,
+	}
+
+	if event.CreatedAt != nil {
+		result.CreatedAt = timestamppb.New(*event.CreatedAt)
+	}
+
+	return result
+}
+
+func serializeCanvasChangeRequestEvents(organizationID string, events []models.CanvasChangeRequestEvent, usersByID map[string]*models.User) []*pb.CanvasChangeRequestEvent {
+	result := make([]*pb.CanvasChangeRequestEvent, 0, len(events))
+	for i := range events {
+		result = append(result, serializeCanvasChangeRequestEvent(organizationID, &events[i], usersByID))
+	}
+
+	return result
+}
+
+// serializeVersionChangeRequest turns the change request record stored
+// on a version into its API representation.
+func serializeVersionChangeRequest(organizationID string, record *models.CanvasVersionChangeRequest, usersByID map[string]*models.User) *pb.CanvasVersion_ChangeRequestRecord {
+	result := &pb.CanvasVersion_ChangeRequestRecord{
+		Id:       record.ID,
+		Title:    record.Title,
+		Author:   canvasVersionOwnerRef(organizationID, record.AuthorID, usersByID),
+		MergedBy: canvasVersionOwnerRef(organizationID, record.MergedBy, usersByID),
+		MergedAt: timestamppb.New(record.MergedAt),
+		Reviews:  make([]*pb.CanvasVersion_ChangeRequestRecord_Review, 0, len(record.Reviews)),
+	}
+
+	for _, review := range record.Reviews {
+		result.Reviews = append(result.Reviews, &pb.CanvasVersion_ChangeRequestRecord_Review{
+			Reviewer:  canvasVersionOwnerRef(organizationID, review.UserID, usersByID),
+			Approved:  review.Approved,
+			CreatedAt: timestamppb.New(review.CreatedAt),
+		})
+	}
+
+	return result
+}
+
+// changeRequestUsersByID loads every user referenced by the change
+// requests and their threads in a single query.
+func changeRequestUsersByID(
+	db *gorm.DB,
+	organizationID string,
+	requests []models.CanvasChangeRequest,
+	events []models.CanvasChangeRequestEvent,
+) map[string]*models.User {
+	ids := []string{}
+	seen := map[uuid.UUID]bool{}
+	add := func(id *uuid.UUID) {
+		if id == nil || seen[*id] {
+			return
+		}
+
+		seen[*id] = true
+		ids = append(ids, id.String())
+	}
+
+	for i := range requests {
+		add(&requests[i].AuthorID)
+		add(requests[i].ClosedBy)
+	}
+
+	for i := range events {
+		add(&events[i].UserID)
+	}
+
+	users, err := models.FindUsersByIDsInOrganization(db, organizationID, ids)
+	if err != nil {
+		return nil
+	}
+
+	usersByID := make(map[string]*models.User, len(users))
+	for i := range users {
+		usersByID[users[i].ID.String()] = &users[i]
+	}
+
+	return usersByID
+}
+
+func changeRequestStateToProto(status string) pb.CanvasChangeRequest_State {
+	switch status {
+	case models.CanvasChangeRequestStatusOpen:
+		return pb.CanvasChangeRequest_STATE_OPEN
+	case models.CanvasChangeRequestStatusMerged:
+		return pb.CanvasChangeRequest_STATE_MERGED
+	case models.CanvasChangeRequestStatusClosed:
+		return pb.CanvasChangeRequest_STATE_CLOSED
+	}
+
+	return pb.CanvasChangeRequest_STATE_UNSPECIFIED
+}
+
+func changeRequestStateFromProto(state pb.CanvasChangeRequest_State) string {
+	switch state {
+	case pb.CanvasChangeRequest_STATE_OPEN:
+		return models.CanvasChangeRequestStatusOpen
+	case pb.CanvasChangeRequest_STATE_MERGED:
+		return models.CanvasChangeRequestStatusMerged
+	case pb.CanvasChangeRequest_STATE_CLOSED:
+		return models.CanvasChangeRequestStatusClosed
+	}
+
+	return ""
+}
+
+func changeRequestEventTypeToProto(eventType string) pb.CanvasChangeRequestEvent_Type {
+	switch eventType {
+	case models.CanvasChangeRequestEventComment:
+		return pb.CanvasChangeRequestEvent_TYPE_COMMENT
+	case models.CanvasChangeRequestEventApproved:
+		return pb.CanvasChangeRequestEvent_TYPE_APPROVED
+	case models.CanvasChangeRequestEventRejected:
+		return pb.CanvasChangeRequestEvent_TYPE_REJECTED
+	case models.CanvasChangeRequestEventUpdated:
+		return pb.CanvasChangeRequestEvent_TYPE_UPDATED
+	case models.CanvasChangeRequestEventMerged:
+		return pb.CanvasChangeRequestEvent_TYPE_MERGED
+	case models.CanvasChangeRequestEventClosed:
+		return pb.CanvasChangeRequestEvent_TYPE_CLOSED
+	}
+
+	return pb.CanvasChangeRequestEvent_TYPE_UNSPECIFIED
+}
@@ -0,0 +1,176 @@
+package canvases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	"github.com/superplanehq/superplane/pkg/database"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"github.com/superplanehq/superplane/test/support"
+	"google.golang.org/grpc/codes"
+)
+
+func Test__CanvasChangeRequest(t *testing.T) {
+	r := support.Setup(t)
+	authorCtx := authentication.SetUserIdInMetadata(context.Background(), r.User.String())
+	db := database.DB(t.Context())
+
+	reviewer := support.CreateUser(t, r, r.Organization.ID)
+	reviewerCtx := authentication.SetUserIdInMetadata(context.Background(), reviewer.ID.String())
+
+	outsider := support.CreateUser(t, r, r.Organization.ID)
+	outsiderCtx := authentication.SetUserIdInMetadata(context.Background(), outsider.ID.String())
+
+	setup := func(t *testing.T) (*models.Canvas, string) {
+		canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, nil, nil)
+		liveVersion, err := models.FindLiveCanvasVersion(canvas.ID)
+		require.NoError(t, err)
+
+		baseline, err := ReadRepositorySpecFile(authorCtx, canvas, liveVersion, CanvasYAMLRepositoryPath)
+		require.NoError(t, err)
+
+		_, err = UpdateCanvasReviewPolicy(authorCtx, db, canvas, 1, []string{reviewer.ID.String()})
+		require.NoError(t, err)
+
+		return canvas, baseline
+	}
+
+	stage := func(t *testing.T, canvas *models.Canvas, content string) {
+		_, err := PutCanvasStaging(authorCtx, db, canvas, []*pb.CanvasRepositoryFileOperation{
+			{Path: CanvasYAMLRepositoryPath, Content: []byte(content)},
+		})
+		require.NoError(t, err)
+	}
+
+	requireCode := func(t *testing.T, err error, expected codes.Code) string {
+		code, msg, ok := grpcerrors.HandlerStatus(err)
+		require.True(t, ok)
+		assert.Equal(t, expected, code)
+		return msg
+	}
+
+	t.Run("policy validation", func(t *testing.T) {
+		canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, nil, nil)
+
+		_, err := UpdateCanvasReviewPolicy(authorCtx, db, canvas, 2, []string{reviewer.ID.String()})
+		requireCode(t, err, codes.InvalidArgument)
+
+		_, err = UpdateCanvasReviewPolicy(authorCtx, db, canvas, 1, []string{"nope"})
+		requireCode(t, err, codes.InvalidArgument)
+
+		response, err := UpdateCanvasReviewPolicy(authorCtx, db, canvas, 1, nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), response.Policy.RequiredApprovals)
+		assert.Equal(t, pb.CanvasReviewPolicy_SOURCE_CANVAS, response.Policy.Source)
+
+		response, err = UpdateCanvasReviewPolicy(authorCtx, db, canvas, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(0), response.Policy.RequiredApprovals)
+	})
+
+	t.Run("policy blocks direct commits", func(t *testing.T) {
+		canvas, baseline := setup(t)
+		stage(t, canvas, baseline+"\n# direct\n")
+
+		_, err := CommitCanvasStaging(authorCtx, db, r.GitProvider, nil, r.Encryptor, r.Registry, canvas, "Direct", "", r.AuthService)
+		msg := requireCode(t, err, codes.FailedPrecondition)
+		assert.Contains(t, msg, "approved change request")
+	})
+
+	t.Run("create, review and merge", func(t *testing.T) {
+		canvas, baseline := setup(t)
+		stage(t, canvas, baseline+"\n# reviewed\n")
+
+		created, err := CreateCanvasChangeRequest(authorCtx, db, canvas, "Add comment", "Adds a comment")
+		require.NoError(t, err)
+		changeRequestID := created.ChangeRequest.Metadata.Id
+		assert.False(t, created.ChangeRequest.Status.Mergeable)
+
+		_, err = CreateCanvasChangeRequest(authorCtx, db, canvas, "Again", "")
+		requireCode(t, err, codes.AlreadyExists)
+
+		_, err = ReviewCanvasChangeRequest(authorCtx, db, canvas, changeRequestID, pb.ReviewCanvasChangeRequestRequest_DECISION_APPROVE, "")
+		requireCode(t, err, codes.PermissionDenied)
+
+		_, err = ReviewCanvasChangeRequest(outsiderCtx, db, canvas, changeRequestID, pb.ReviewCanvasChangeRequestRequest_DECISION_APPROVE, "")
+		requireCode(t, err, codes.PermissionDenied)
+
+		_, err = MergeCanvasChangeRequest(authorCtx, db, r.GitProvider, nil, r.Encryptor, r.Registry, canvas, changeRequestID, "", r.AuthService)
+		requireCode(t, err, codes.FailedPrecondition)
+
+		reviewed, err := ReviewCanvasChangeRequest(reviewerCtx, db, canvas, changeRequestID, pb.ReviewCanvasChangeRequestRequest_DECISION_APPROVE, "LGTM")
+		require.NoError(t, err)
+		assert.True(t, reviewed.ChangeRequest.Status.Mergeable)
+
+		merged, err := MergeCanvasChangeRequest(authorCtx, db, r.GitProvider, nil, r.Encryptor, r.Registry, canvas, changeRequestID, "", r.AuthService)
+		require.NoError(t, err)
+		assert.Equal(t, pb.CanvasChangeRequest_STATE_MERGED, merged.ChangeRequest.Status.State)
+		assert.Equal(t, merged.Version.Metadata.Id, merged.ChangeRequest.Status.MergedVersionId)
+
+		record := merged.Version.Metadata.ChangeRequest
+		require.NotNil(t, record)
+		assert.Equal(t, changeRequestID, record.Id)
+		require.Len(t, record.Reviews, 1)
+		assert.Equal(t, reviewer.ID.String(), record.Reviews[0].Reviewer.Id)
+		assert.True(t, record.Reviews[0].Approved)
+
+		liveVersion, err := models.FindLiveCanvasVersion(canvas.ID)
+		require.NoError(t, err)
+		assert.Equal(t, merged.Version.Metadata.Id, liveVersion.ID.String())
+
+		hasStaging, err := models.HasStagedFilesForUser(db, canvas.ID, r.User)
+		require.NoError(t, err)
+		assert.False(t, hasStaging)
+	})
+
+	t.Run("resubmitting dismisses approvals", func(t *testing.T) {
+		canvas, baseline := setup(t)
+		stage(t, canvas, baseline+"\n# first\n")
+
+		created, err := CreateCanvasChangeRequest(authorCtx, db, canvas, "First", "")
+		require.NoError(t, err)
+		changeRequestID := created.ChangeRequest.Metadata.Id
+
+		_, err = ReviewCanvasChangeRequest(reviewerCtx, db, canvas, changeRequestID, pb.ReviewCanvasChangeRequestRequest_DECISION_APPROVE, "")
+		require.NoError(t, err)
+
+		stage(t, canvas, baseline+"\n# second\n")
+		_, err = UpdateCanvasChangeRequest(reviewerCtx, db, canvas, changeRequestID, nil, true)
+		requireCode(t, err, codes.PermissionDenied)
+
+		updated, err := UpdateCanvasChangeRequest(authorCtx, db, canvas, changeRequestID, nil, true)
+		require.NoError(t, err)
+		assert.False(t, updated.ChangeRequest.Status.Mergeable)
+		assert.Empty(t, updated.ChangeRequest.Status.ApprovedBy)
+
+		described, err := DescribeCanvasChangeRequest(authorCtx, db, r.Registry, canvas, changeRequestID)
+		require.NoError(t, err)
+		require.NotNil(t, described.Diff)
+		assert.Len(t, described.Events, 2)
+	})
+
+	t.Run("closed requests cannot be reviewed", func(t *testing.T) {
+		canvas, baseline := setup(t)
+		stage(t, canvas, baseline+"\n# closed\n")
+
+		created, err := CreateCanvasChangeRequest(authorCtx, db, canvas, "Closed", "")
+		require.NoError(t, err)
+		changeRequestID := created.ChangeRequest.Metadata.Id
+
+		closed, err := CloseCanvasChangeRequest(authorCtx, db, canvas, changeRequestID, "not needed")
+		require.NoError(t, err)
+		assert.Equal(t, pb.CanvasChangeRequest_STATE_CLOSED, closed.ChangeRequest.Status.State)
+
+		_, err = ReviewCanvasChangeRequest(reviewerCtx, db, canvas, changeRequestID, pb.ReviewCanvasChangeRequestRequest_DECISION_APPROVE, "")
+		requireCode(t, err, codes.FailedPrecondition)
+
+		listed, err := ListCanvasChangeRequests(authorCtx, db, canvas, pb.CanvasChangeRequest_STATE_OPEN, 0)
+		require.NoError(t, err)
+		assert.Empty(t, listed.ChangeRequests)
+	})
+}
@@ -0,0 +1,155 @@
+package canvases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	"github.com/superplanehq/superplane/pkg/database"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"github.com/superplanehq/superplane/test/support"
+	"google.golang.org/grpc/codes"
+)
+
+func Test__CanvasGitMirror(t *testing.T) {
+	r := support.Setup(t)
+	ctx := authentication.SetUserIdInMetadata(context.Background(), r.User.String())
+	db := database.DB(t.Context())
+
+	integration, err := models.CreateIntegration(uuid.New(), r.Organization.ID, "github", support.RandomName("github"), nil)
+	require.NoError(t, err)
+
+	t.Run("integration not ready -> error", func(t *testing.T) {
+		canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{}, []models.Edge{})
+
+		_, err := UpdateCanvasGitMirror(ctx, db, r.Registry, canvas, &pb.CanvasGitMirror_Spec{
+			IntegrationId: integration.ID.String(),
+			Repository:    "acme/canvases",
+		})
+
+		code, _, ok := grpcerrors.HandlerStatus(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, code)
+	})
+
+	require.NoError(t, db.Model(integration).Update("state", models.IntegrationStateReady).Error)
+
+	t.Run("invalid spec -> error", func(t *testing.T) {
+		canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{}, []models.Edge{})
+
+		for name, spec := range map[string]*pb.CanvasGitMirror_Spec{
+			"missing repository":  {IntegrationId: integration.ID.String()},
+			"invalid integration": {IntegrationId: "nope", Repository: "acme/canvases"},
+			"invalid branch":      {IntegrationId: integration.ID.String(), Repository: "acme/canvases", Branch: "feature..x"},
+			"escaping path":       {IntegrationId: integration.ID.String(), Repository: "acme/canvases", Path: "../other"},
+			"reserved path":       {IntegrationId: integration.ID.String(), Repository: "acme/canvases", Path: ".superplane"},
+		} {
+			_, err := UpdateCanvasGitMirror(ctx, db, r.Registry, canvas, spec)
+			code, _, ok := grpcerrors.HandlerStatus(err)
+			require.True(t, ok, name)
+			assert.Equal(t, codes.InvalidArgument, code, name)
+		}
+	})
+
+	t.Run("configure, sync and delete mirror", func(t *testing.T) {
+		canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{}, []models.Edge{})
+
+		_, err := GetCanvasGitMirror(ctx, db, canvas)
+		code, _, ok := grpcerrors.HandlerStatus(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.NotFound, code)
+
+		response, err := UpdateCanvasGitMirror(ctx, db, r.Registry, canvas, &pb.CanvasGitMirror_Spec{
+			IntegrationId: integration.ID.String(),
+			Repository:    "acme/canvases",
+			Path:          "/canvases/demo/",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "main", response.Mirror.Spec.Branch)
+		assert.Equal(t, "canvases/demo", response.Mirror.Spec.Path)
+		assert.Equal(t, pb.CanvasGitMirror_IMPORT_MODE_STAGING, response.Mirror.Spec.ImportMode)
+		assert.Equal(t, r.User.String(), response.Mirror.Metadata.CreatedBy.Id)
+
+		//
+		// Simulate a previous sync, and an import conflict.
+		//
+		mirror, err := models.FindCanvasGitMirror(canvas.ID)
+		require.NoError(t, err)
+		liveVersion, err := models.FindLiveCanvasVersion(canvas.ID)
+		require.NoError(t, err)
+		previousVersionID := uuid.New()
+		require.NoError(t, db.Model(mirror).Updates(map[string]any{
+			"remote_head_sha":        "abc123",
+			"last_pushed_version_id": previousVersionID,
+		}).Error)
+
+		conflict := &models.CanvasGitMirrorEvent{
+			MirrorID:        mirror.ID,
+			CanvasID:        canvas.ID,
+			Direction:       models.CanvasGitMirrorDirectionImport,
+			Status:          models.CanvasGitMirrorEventStatusConflict,
+			RemoteCommitSHA: "def456",
+			Message:         "both changed",
+		}
+		require.NoError(t, models.CreateCanvasGitMirrorEvent(db, conflict))
+		duplicate := *conflict
+		duplicate.ID = uuid.Nil
+		require.NoError(t, models.CreateCanvasGitMirrorEvent(db, &duplicate))
+
+		mirrorResponse, err := GetCanvasGitMirror(ctx, db, canvas)
+		require.NoError(t, err)
+		require.Len(t, mirrorResponse.Events, 1, "repeated conflicts are recorded once")
+		assert.Equal(t, pb.CanvasGitMirrorEvent_STATUS_CONFLICT, mirrorResponse.Events[0].Status)
+
+		versions, err := ListCanvasVersionsPaginated(ctx, db, canvas, 0, nil)
+		require.NoError(t, err)
+		require.Len(t, versions.MirrorEvents, 1)
+		assert.Equal(t, "def456", versions.MirrorEvents[0].RemoteCommitSha)
+
+		//
+		// Keeping the remote marks the live version as pushed,
+		// so the next sync imports the remote branch.
+		//
+		syncResponse, err := SyncCanvasGitMirror(ctx, db, canvas, pb.SyncCanvasGitMirrorRequest_RESOLUTION_KEEP_REMOTE)
+		require.NoError(t, err)
+		assert.Equal(t, liveVersion.ID.String(), syncResponse.Mirror.Status.LastPushedVersionId)
+		assert.Equal(t, "abc123", syncResponse.Mirror.Status.RemoteHeadSha)
+
+		//
+		// Keeping the canvas forgets the remote head,
+		// so the next sync pushes over the remote branch.
+		//
+		syncResponse, err = SyncCanvasGitMirror(ctx, db, canvas, pb.SyncCanvasGitMirrorRequest_RESOLUTION_KEEP_CANVAS)
+		require.NoError(t, err)
+		assert.Empty(t, syncResponse.Mirror.Status.RemoteHeadSha)
+
+		//
+		// Pointing the mirror somewhere else resets the sync state.
+		//
+		require.NoError(t, db.Model(mirror).Update("remote_head_sha", "abc123").Error)
+		response, err = UpdateCanvasGitMirror(ctx, db, r.Registry, canvas, &pb.CanvasGitMirror_Spec{
+			IntegrationId: integration.ID.String(),
+			Repository:    "acme/canvases",
+			Branch:        "release",
+			ImportMode:    pb.CanvasGitMirror_IMPORT_MODE_DISABLED,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, response.Mirror.Status.RemoteHeadSha)
+		assert.Empty(t, response.Mirror.Status.LastPushedVersionId)
+
+		_, err = SyncCanvasGitMirror(ctx, db, canvas, pb.SyncCanvasGitMirrorRequest_RESOLUTION_KEEP_REMOTE)
+		code, _, ok = grpcerrors.HandlerStatus(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, code)
+
+		_, err = DeleteCanvasGitMirror(ctx, db, canvas)
+		require.NoError(t, err)
+		_, err = models.FindCanvasGitMirror(canvas.ID)
+		assert.ErrorIs(t, err, models.ErrCanvasGitMirrorNotFound)
+	})
+}
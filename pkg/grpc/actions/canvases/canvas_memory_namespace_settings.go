@@ -0,0 +1,156 @@
+package canvases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/grpc/actions/messages"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func GetCanvasMemoryNamespaceSettings(ctx context.Context, db *gorm.DB, canvas *models.Canvas, namespace string) (*pb.GetCanvasMemoryNamespaceSettingsResponse, error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" {
+		return nil, grpcerrors.InvalidArgument(nil, "namespace is required")
+	}
+
+	settings, err := models.FindCanvasMemoryNamespace(db, canvas.ID, namespace)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to find canvas memory namespace settings")
+	}
+
+	serialized, err := canvasMemoryNamespaceSettingsToProto(namespace, settings)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to serialize canvas memory namespace settings")
+	}
+
+	return &pb.GetCanvasMemoryNamespaceSettingsResponse{Settings: serialized}, nil
+}
+
+func UpdateCanvasMemoryNamespaceSettings(
+	ctx context.Context,
+	db *gorm.DB,
+	canvas *models.Canvas,
+	namespace string,
+	schema *structpb.Struct,
+	defaultTTLSeconds int32,
+	indexedKeys []string,
+) (*pb.UpdateCanvasMemoryNamespaceSettingsResponse, error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" {
+		return nil, grpcerrors.InvalidArgument(nil, "namespace is required")
+	}
+
+	if defaultTTLSeconds < 0 {
+		return nil, grpcerrors.InvalidArgument(nil, "default TTL cannot be negative")
+	}
+
+	var rawSchema datatypes.JSON
+	if schema != nil && len(schema.Fields) > 0 {
+		data, err := json.Marshal(schema.AsMap())
+		if err != nil {
+			return nil, grpcerrors.InvalidArgument(err, "invalid schema")
+		}
+		rawSchema = data
+	}
+
+	var saved *models.CanvasMemoryNamespace
+	err := db.Transaction(func(tx *gorm.DB) error {
+		settings, txErr := models.FindCanvasMemoryNamespace(tx, canvas.ID, namespace)
+		if txErr != nil {
+			return txErr
+		}
+
+		if settings == nil {
+			settings = &models.CanvasMemoryNamespace{CanvasID: canvas.ID, Namespace: namespace}
+		}
+
+		settings.Schema = rawSchema
+		settings.IndexedKeys = indexedKeys
+		settings.DefaultTTLSeconds = nil
+		if defaultTTLSeconds > 0 {
+			ttl := int(defaultTTLSeconds)
+			settings.DefaultTTLSeconds = &ttl
+		}
+
+		if txErr := models.SaveCanvasMemoryNamespace(tx, settings); txErr != nil {
+			return txErr
+		}
+
+		saved = settings
+		return nil
+	})
+
+	if err != nil {
+		return nil, canvasMemoryError(err, "failed to update canvas memory namespace settings")
+	}
+
+	if err := messages.NewCanvasMemoryUpdatedMessage(canvas.ID.String()).PublishMemoryUpdated(); err != nil {
+		log.Errorf("failed to publish canvas memory updated RabbitMQ message: %v", err)
+	}
+
+	serialized, err := canvasMemoryNamespaceSettingsToProto(namespace, saved)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to serialize canvas memory namespace settings")
+	}
+
+	return &pb.UpdateCanvasMemoryNamespaceSettingsResponse{Settings: serialized}, nil
+}
+
+// canvasMemoryError turns memory validation errors into invalid
+// arguments, so API callers see why their values were rejected.
+func canvasMemoryError(err error, message string) error {
+	if _, _, ok := grpcerrors.HandlerStatus(err); ok {
+		return err
+	}
+
+	if errors.Is(err, models.ErrCanvasMemoryInvalidSchema) ||
+		errors.Is(err, models.ErrCanvasMemorySchemaViolation) ||
+		errors.Is(err, models.ErrCanvasMemoryInvalidTTL) ||
+		errors.Is(err, models.ErrCanvasMemoryInvalidIndexedKey) {
+		return grpcerrors.InvalidArgument(err, err.Error())
+	}
+
+	return grpcerrors.Internal(err, message)
+}
+
+func canvasMemoryNamespaceSettingsToProto(namespace string, settings *models.CanvasMemoryNamespace) (*pb.CanvasMemoryNamespaceSettings, error) {
+	serialized := &pb.CanvasMemoryNamespaceSettings{
+		Namespace:   namespace,
+		IndexedKeys: []string{},
+	}
+
+	if settings == nil {
+		return serialized, nil
+	}
+
+	if settings.HasSchema() {
+		var schema map[string]any
+		if err := json.Unmarshal(settings.Schema, &schema); err != nil {
+			return nil, err
+		}
+
+		value, err := structpb.NewStruct(schema)
+		if err != nil {
+			return nil, err
+		}
+		serialized.Schema = value
+	}
+
+	if settings.DefaultTTLSeconds != nil {
+		serialized.DefaultTtlSeconds = int32(*settings.DefaultTTLSeconds)
+	}
+
+	serialized.IndexedKeys = append(serialized.IndexedKeys, settings.IndexedKeys...)
+	serialized.UpdatedAt = timestamppb.New(settings.UpdatedAt)
+	return serialized, nil
+}
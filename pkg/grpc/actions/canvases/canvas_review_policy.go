@@ -0,0 +1,100 @@
+package canvases
+
+import (
+	"context"
+	"errors"
+
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"gorm.io/gorm"
+)
+
+func GetCanvasReviewPolicy(ctx context.Context, db *gorm.DB, canvas *models.Canvas) (*pb.GetCanvasReviewPolicyResponse, error) {
+	policy, err := models.FindEffectiveCanvasReviewPolicy(db, canvas)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to load review policy")
+	}
+
+	return &pb.GetCanvasReviewPolicyResponse{
+		Policy: serializeCanvasReviewPolicy(policy),
+	}, nil
+}
+
+// UpdateCanvasReviewPolicy sets the policy on the canvas itself.
+// Zero required approvals removes it, so the canvas goes back
+// to the policy of its folder, if any.
+func UpdateCanvasReviewPolicy(
+	ctx context.Context,
+	db *gorm.DB,
+	canvas *models.Canvas,
+	requiredApprovals uint32,
+	reviewerIDs []string,
+) (*pb.UpdateCanvasReviewPolicyResponse, error) {
+	if requiredApprovals == 0 {
+		if err := models.DeleteCanvasReviewPolicy(db, canvas.ID); err != nil {
+			return nil, grpcerrors.Internal(err, "failed to delete review policy")
+		}
+
+		return updatedCanvasReviewPolicyResponse(db, canvas)
+	}
+
+	reviewers, err := models.NormalizeCanvasReviewers(db, canvas.OrganizationID, int(requiredApprovals), reviewerIDs)
+	if err != nil {
+		return nil, CanvasReviewPolicyErrorToStatus(err)
+	}
+
+	err = models.SaveCanvasReviewPolicy(db, &models.CanvasReviewPolicy{
+		OrganizationID:    canvas.OrganizationID,
+		CanvasID:          &canvas.ID,
+		RequiredApprovals: int(requiredApprovals),
+		ReviewerIDs:       reviewers,
+	})
+
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to save review policy")
+	}
+
+	return updatedCanvasReviewPolicyResponse(db, canvas)
+}
+
+func updatedCanvasReviewPolicyResponse(db *gorm.DB, canvas *models.Canvas) (*pb.UpdateCanvasReviewPolicyResponse, error) {
+	policy, err := models.FindEffectiveCanvasReviewPolicy(db, canvas)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to load review policy")
+	}
+
+	return &pb.UpdateCanvasReviewPolicyResponse{
+		Policy: serializeCanvasReviewPolicy(policy),
+	}, nil
+}
+
+func CanvasReviewPolicyErrorToStatus(err error) error {
+	switch {
+	case errors.Is(err, models.ErrCanvasReviewPolicyTooManyApprovals):
+		return grpcerrors.InvalidArgument(err, "at most 10 approvals can be required")
+	case errors.Is(err, models.ErrCanvasReviewPolicyInvalidReviewer):
+		return grpcerrors.InvalidArgument(err, "reviewers must be members of the organization")
+	case errors.Is(err, models.ErrCanvasReviewPolicyNotEnoughReviewers):
+		return grpcerrors.InvalidArgument(err, "required approvals exceed the number of reviewers")
+	default:
+		return grpcerrors.Internal(err, "failed to validate review policy")
+	}
+}
+
+func serializeCanvasReviewPolicy(policy *models.CanvasReviewPolicy) *pb.CanvasReviewPolicy {
+	if policy == nil {
+		return &pb.CanvasReviewPolicy{ReviewerIds: []string{}}
+	}
+
+	source := pb.CanvasReviewPolicy_SOURCE_FOLDER
+	if policy.Source() == models.CanvasReviewPolicySourceCanvas {
+		source = pb.CanvasReviewPolicy_SOURCE_CANVAS
+	}
+
+	return &pb.CanvasReviewPolicy{
+		RequiredApprovals: uint32(policy.RequiredApprovals),
+		ReviewerIds:       policy.ReviewerIDs,
+		Source:            source,
+	}
+}
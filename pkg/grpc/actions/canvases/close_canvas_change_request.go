@@ -0,0 +1,66 @@
+package canvases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"gorm.io/gorm"
+)
+
+func CloseCanvasChangeRequest(
+	ctx context.Context,
+	db *gorm.DB,
+	canvas *models.Canvas,
+	changeRequestID string,
+	comment string,
+) (*pb.CloseCanvasChangeRequestResponse, error) {
+	user, ok := authentication.GetUserIdFromMetadata(ctx)
+	if !ok {
+		return nil, grpcerrors.Unauthenticated(nil, "user not authenticated")
+	}
+
+	id, err := parseChangeRequestID(changeRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := uuid.MustParse(user)
+	var request *models.CanvasChangeRequest
+	err = db.Transaction(func(tx *gorm.DB) error {
+		locked, err := models.LockCanvasChangeRequest(tx, canvas.ID, id)
+		if err != nil {
+			return err
+		}
+
+		if !locked.IsOpen() {
+			return grpcerrors.FailedPrecondition(nil, "change request is not open")
+		}
+
+		if err := locked.MarkClosed(tx, userID); err != nil {
+			return err
+		}
+
+		if _, err := models.CreateCanvasChangeRequestEvent(tx, locked.ID, userID, models.CanvasChangeRequestEventClosed, strings.TrimSpace(comment)); err != nil {
+			return err
+		}
+
+		request = locked
+		return nil
+	})
+
+	if err != nil {
+		return nil, changeRequestErrorToStatus(err, "failed to close change request")
+	}
+
+	serialized, err := serializeLoadedCanvasChangeRequest(db, canvas, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CloseCanvasChangeRequestResponse{ChangeRequest: serialized}, nil
+}
@@ -46,6 +46,19 @@ func CommitCanvasStaging(
 		return nil, grpcerrors.Unauthenticated(nil, "user not authenticated")
 	}
 
+	//
+	// Canvases under a review policy are only published
+	// through approved change requests.
+	//
+	policy, err := models.FindEffectiveCanvasReviewPolicy(db, canvas)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to load review policy")
+	}
+
+	if policy.RequiresReview() {
+		return nil, grpcerrors.FailedPrecondition(nil, "canvas requires an approved change request to publish changes")
+	}
+
 	userID := uuid.MustParse(user)
 	stagedFiles, err := models.ListStagedFilesForUser(db, canvas.ID, userID)
 	if err != nil {
@@ -56,6 +69,59 @@ func CommitCanvasStaging(
 		return nil, grpcerrors.FailedPrecondition(nil, "no staged changes to commit")
 	}
 
+	newLiveVersion, err := commitStagedFiles(
+		ctx,
+		db,
+		gitProvider,
+		usageService,
+		encryptor,
+		registry,
+		canvas,
+		userID,
+		stagedFiles,
+		commitMessage,
+		webhookBaseURL,
+		authService,
+		func(tx *gorm.DB, _ *models.CanvasVersion) error {
+			return models.DiscardStagedFilesForUser(tx, canvas.ID, userID, nil)
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := messages.NewCanvasStagingMessage(canvas.ID.String(), userID.String()).Publish(); err != nil {
+		log.Errorf("failed to publish canvas staging updated RabbitMQ message: %v", err)
+	}
+
+	ownersByID, _ := ownersByIDForCanvasVersions(ctx, canvas.OrganizationID.String(), []models.CanvasVersion{*newLiveVersion})
+
+	return &pb.CommitCanvasStagingResponse{
+		Version:        SerializeCanvasVersion(newLiveVersion, canvas.OrganizationID.String(), ownersByID),
+		StagingSummary: buildStagingSummary(canvas, []models.WorkflowStagedFile{}),
+	}, nil
+}
+
+// commitStagedFiles publishes staged files authored by userID as the new
+// live version. Non-spec files are committed to git first, and reverted
+// if the new version cannot be published. The finalize callback runs in
+// the publishing transaction, after the new version is created.
+func commitStagedFiles(
+	ctx context.Context,
+	db *gorm.DB,
+	gitProvider gitprovider.Provider,
+	usageService usage.Service,
+	encryptor crypto.Encryptor,
+	registry *registry.Registry,
+	canvas *models.Canvas,
+	userID uuid.UUID,
+	stagedFiles []models.WorkflowStagedFile,
+	commitMessage string,
+	webhookBaseURL string,
+	authService authorization.Authorization,
+	finalize func(tx *gorm.DB, version *models.CanvasVersion) error,
+) (*models.CanvasVersion, error) {
 	//
 	// Verify if staged files are for the live version.
 	// Staged files for stale versions cannot be committed.
@@ -92,7 +158,7 @@ func CommitCanvasStaging(
 
 	var newLiveVersion *models.CanvasVersion
 	var publishResult changesets.CanvasPublishResult
-	err = db.Transaction(func(tx *gorm.DB) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
 		liveVersion, err := models.FindLiveCanvasVersionInTransaction(tx, canvas.ID)
 		if err != nil {
 			return grpcerrors.Internal(err, "failed to load live version")
@@ -147,11 +213,7 @@ func CommitCanvasStaging(
 			return err
 		}
 
-		//
-		// Remove staged files for user.
-		//
-		err = models.DiscardStagedFilesForUser(tx, canvas.ID, userID, nil)
-		if err != nil {
+		if err := finalize(tx, nextVersion); err != nil {
 			return err
 		}
 
@@ -181,18 +243,8 @@ func CommitCanvasStaging(
 		log.Errorf("failed to publish canvas updated RabbitMQ message: %v", err)
 	}
 
-	if err := messages.NewCanvasStagingMessage(canvas.ID.String(), userID.String()).Publish(); err != nil {
-		log.Errorf("failed to publish canvas staging updated RabbitMQ message: %v", err)
-	}
-
 	publishDeletedNodeCleanupMessages(canvas.ID, publishResult)
-
-	ownersByID, _ := ownersByIDForCanvasVersions(ctx, canvas.OrganizationID.String(), []models.CanvasVersion{*newLiveVersion})
-
-	return &pb.CommitCanvasStagingResponse{
-		Version:        SerializeCanvasVersion(newLiveVersion, canvas.OrganizationID.String(), ownersByID),
-		StagingSummary: buildStagingSummary(canvas, []models.WorkflowStagedFile{}),
-	}, nil
+	return newLiveVersion, nil
 }
 
 func publishDeletedNodeCleanupMessages(canvasID uuid.UUID, result changesets.CanvasPublishResult) {
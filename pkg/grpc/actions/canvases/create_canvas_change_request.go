@@ -0,0 +1,130 @@
+package canvases
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+const maxChangeRequestTitleLength = 255
+
+// CreateCanvasChangeRequest submits a snapshot of the user's staging for
+// review. The staging itself is kept, so the author can go on addressing
+// feedback and resubmit it with UpdateCanvasChangeRequest.
+func CreateCanvasChangeRequest(
+	ctx context.Context,
+	db *gorm.DB,
+	canvas *models.Canvas,
+	title string,
+	description string,
+) (*pb.CreateCanvasChangeRequestResponse, error) {
+	user, ok := authentication.GetUserIdFromMetadata(ctx)
+	if !ok {
+		return nil, grpcerrors.Unauthenticated(nil, "user not authenticated")
+	}
+
+	title, err := validateChangeRequestTitle(title)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := uuid.MustParse(user)
+	baseVersionID, files, err := snapshotStagingForChangeRequest(db, canvas, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &models.CanvasChangeRequest{
+		OrganizationID: canvas.OrganizationID,
+		CanvasID:       canvas.ID,
+		AuthorID:       userID,
+		BaseVersionID:  baseVersionID,
+		Title:          title,
+		Description:    strings.TrimSpace(description),
+		Files:          files,
+	}
+
+	if err := models.CreateCanvasChangeRequest(db, request); err != nil {
+		if errors.Is(err, models.ErrCanvasChangeRequestAlreadyOpen) {
+			return nil, grpcerrors.AlreadyExists(err, "you already have an open change request for this canvas")
+		}
+
+		return nil, grpcerrors.Internal(err, "failed to create change request")
+	}
+
+	serialized, err := serializeLoadedCanvasChangeRequest(db, canvas, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateCanvasChangeRequestResponse{ChangeRequest: serialized}, nil
+}
+
+func snapshotStagingForChangeRequest(db *gorm.DB, canvas *models.Canvas, userID uuid.UUID) (uuid.UUID, []models.CanvasChangeRequestFile, error) {
+	stagedFiles, err := models.ListStagedFilesForUser(db, canvas.ID, userID)
+	if err != nil {
+		return uuid.Nil, nil, grpcerrors.Internal(err, "failed to load staging")
+	}
+
+	if len(stagedFiles) == 0 {
+		return uuid.Nil, nil, grpcerrors.FailedPrecondition(nil, "no staged changes to submit")
+	}
+
+	baseVersionID := findStagingBaseVersionID(stagedFiles)
+	if canvas.LiveVersionID == nil || *canvas.LiveVersionID != baseVersionID {
+		return uuid.Nil, nil, grpcerrors.FailedPrecondition(nil, "stale staging cannot be submitted")
+	}
+
+	files := make([]models.CanvasChangeRequestFile, 0, len(stagedFiles))
+	for _, file := range stagedFiles {
+		files = append(files, models.CanvasChangeRequestFile{
+			Path:    file.Path,
+			Content: file.Content,
+			Deleted: file.Deleted,
+		})
+	}
+
+	return baseVersionID, files, nil
+}
+
+func validateChangeRequestTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", grpcerrors.InvalidArgument(nil, "title is required")
+	}
+
+	if len(title) > maxChangeRequestTitleLength {
+		return "", grpcerrors.InvalidArgument(nil, "title must be 255 characters or less")
+	}
+
+	return title, nil
+}
+
+func parseChangeRequestID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, grpcerrors.InvalidArgument(err, "invalid change request id")
+	}
+
+	return parsed, nil
+}
+
+func changeRequestErrorToStatus(err error, internalMessage string) error {
+	if errors.Is(err, models.ErrCanvasChangeRequestNotFound) {
+		return grpcerrors.NotFound(err, "change request not found")
+	}
+
+	if grpcerrors.Code(err) != codes.Unknown {
+		return err
+	}
+
+	return grpcerrors.Internal(err, internalMessage)
+}
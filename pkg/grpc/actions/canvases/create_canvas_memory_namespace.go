@@ -54,10 +54,7 @@ func CreateCanvasMemoryNamespace(ctx context.Context, db *gorm.DB, canvas *model
 	})
 
 	if err != nil {
-		if _, _, ok := grpcerrors.HandlerStatus(err); ok {
-			return nil, err
-		}
-		return nil, grpcerrors.Internal(err, "failed to create canvas memory namespace")
+		return nil, canvasMemoryError(err, "failed to create canvas memory namespace")
 	}
 
 	if err := messages.NewCanvasMemoryUpdatedMessage(canvas.ID.String()).PublishMemoryUpdated(); err != nil {
@@ -0,0 +1,24 @@
+package canvases
+
+import (
+	"context"
+	"errors"
+
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"gorm.io/gorm"
+)
+
+func DeleteCanvasGitMirror(ctx context.Context, db *gorm.DB, canvas *models.Canvas) (*pb.DeleteCanvasGitMirrorResponse, error) {
+	err := models.DeleteCanvasGitMirror(db, canvas.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrCanvasGitMirrorNotFound) {
+			return nil, grpcerrors.NotFound(err, "git mirror not configured")
+		}
+
+		return nil, grpcerrors.Internal(err, "failed to delete git mirror")
+	}
+
+	return &pb.DeleteCanvasGitMirrorResponse{}, nil
+}
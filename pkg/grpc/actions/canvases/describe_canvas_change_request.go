@@ -0,0 +1,75 @@
+package canvases
+
+import (
+	"context"
+
+	"github.com/superplanehq/superplane/pkg/authentication"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"gorm.io/gorm"
+)
+
+func DescribeCanvasChangeRequest(
+	ctx context.Context,
+	db *gorm.DB,
+	registry *registry.Registry,
+	canvas *models.Canvas,
+	changeRequestID string,
+) (*pb.DescribeCanvasChangeRequestResponse, error) {
+	if _, ok := authentication.GetUserIdFromMetadata(ctx); !ok {
+		return nil, grpcerrors.Unauthenticated(nil, "user not authenticated")
+	}
+
+	id, err := parseChangeRequestID(changeRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := models.FindCanvasChangeRequest(db, canvas.ID, id)
+	if err != nil {
+		return nil, changeRequestErrorToStatus(err, "failed to find change request")
+	}
+
+	policy, err := models.FindEffectiveCanvasReviewPolicy(db, canvas)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to load review policy")
+	}
+
+	events, err := models.ListCanvasChangeRequestEvents(db, request.ID)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to list change request events")
+	}
+
+	baseVersion, err := models.FindCanvasVersionInTransaction(db, canvas.ID, request.BaseVersionID)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to load base version")
+	}
+
+	organizationID := canvas.OrganizationID.String()
+	usersByID := changeRequestUsersByID(db, organizationID, []models.CanvasChangeRequest{*request}, events)
+
+	return &pb.DescribeCanvasChangeRequestResponse{
+		ChangeRequest: serializeCanvasChangeRequest(canvas, request, policy, events, usersByID),
+		Diff:          buildCanvasChangeRequestDiff(registry, canvas, baseVersion, request.Files),
+		Events:        serializeCanvasChangeRequestEvents(organizationID, events, usersByID),
+	}, nil
+}
+
+// serializeLoadedCanvasChangeRequest loads what is needed to report
+// where a change request stands after it was created or updated.
+func serializeLoadedCanvasChangeRequest(db *gorm.DB, canvas *models.Canvas, request *models.CanvasChangeRequest) (*pb.CanvasChangeRequest, error) {
+	policy, err := models.FindEffectiveCanvasReviewPolicy(db, canvas)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to load review policy")
+	}
+
+	events, err := models.ListCanvasChangeRequestEvents(db, request.ID)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to list change request events")
+	}
+
+	usersByID := changeRequestUsersByID(db, canvas.OrganizationID.String(), []models.CanvasChangeRequest{*request}, events)
+	return serializeCanvasChangeRequest(canvas, request, policy, events, usersByID), nil
+}
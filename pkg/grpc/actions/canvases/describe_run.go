@@ -9,6 +9,7 @@ import (
 	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
 	"github.com/superplanehq/superplane/pkg/models"
 	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
 )
 
@@ -46,11 +47,40 @@ func DescribeRun(ctx context.Context, db *gorm.DB, canvas *models.Canvas, runID
 		return nil, err
 	}
 
+	artifacts, err := models.ListRunnerArtifactsForRun(db, canvas.ID, run.ID)
+	if err != nil {
+		log.WithError(err).Error("failed to list run artifacts")
+		return nil, grpcerrors.Internal(err, "failed to list run artifacts")
+	}
+	serializedRun.Artifacts = serializeRunArtifacts(artifacts)
+
 	return &pb.DescribeRunResponse{
 		Run: serializedRun,
 	}, nil
 }
 
+func serializeRunArtifacts(artifacts []models.RunnerArtifact) []*pb.CanvasRunArtifact {
+	out := make([]*pb.CanvasRunArtifact, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		serialized := &pb.CanvasRunArtifact{
+			Id:          artifact.ID.String(),
+			Name:        artifact.Name,
+			NodeId:      artifact.NodeID,
+			ExecutionId: artifact.ExecutionID.String(),
+			SizeBytes:   artifact.SizeBytes,
+			Sha256:      artifact.SHA256,
+		}
+		if artifact.CreatedAt != nil {
+			serialized.CreatedAt = timestamppb.New(*artifact.CreatedAt)
+		}
+		if artifact.ExpiresAt != nil {
+			serialized.ExpiresAt = timestamppb.New(*artifact.ExpiresAt)
+		}
+		out = append(out, serialized)
+	}
+	return out
+}
+
 func parentRunForDescribe(parentRunsByRunID map[string]models.CanvasRun, runID string) *models.CanvasRun {
 	parent, ok := parentRunsByRunID[runID]
 	if !ok {
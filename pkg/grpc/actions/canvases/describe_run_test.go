@@ -3,6 +3,7 @@ package canvases
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -48,6 +49,39 @@ func Test__DescribeRun(t *testing.T) {
 		assert.Equal(t, rootEvent.ID.String(), serializedRun.RootEvent.Id)
 		require.Len(t, serializedRun.Executions, 1)
 		assert.Equal(t, execution.ID.String(), serializedRun.Executions[0].Id)
+		assert.Empty(t, serializedRun.Artifacts)
+	})
+
+	t.Run("returns unexpired run artifacts", func(t *testing.T) {
+		canvas, _ := support.CreateCanvas(
+			t,
+			r.Organization.ID,
+			r.User,
+			[]models.CanvasNode{
+				{NodeID: "trigger", Type: models.NodeTypeTrigger},
+				{NodeID: "node-1", Type: models.NodeTypeComponent},
+			},
+			[]models.Edge{},
+		)
+
+		rootEvent := support.EmitCanvasEventForNode(t, canvas.ID, "trigger", "default", nil)
+		run := createFinishedRun(t, rootEvent, models.CanvasRunResultPassed)
+		execution := createRunExecution(t, run, rootEvent.ID, "node-1", models.CanvasNodeExecutionResultPassed)
+
+		blob := models.RunnerBlob{StorageKey: "build", SizeBytes: 7, SHA256: "sha"}
+		artifact, _, err := models.SaveRunnerArtifact(execution, r.Organization.ID, "build", blob, time.Hour)
+		require.NoError(t, err)
+		_, _, err = models.SaveRunnerArtifact(execution, r.Organization.ID, "expired", blob, -time.Hour)
+		require.NoError(t, err)
+
+		response, err := DescribeRun(context.Background(), database.DB(t.Context()), canvas, run.ID.String())
+		require.NoError(t, err)
+		require.Len(t, response.Run.Artifacts, 1)
+		assert.Equal(t, artifact.ID.String(), response.Run.Artifacts[0].Id)
+		assert.Equal(t, "build", response.Run.Artifacts[0].Name)
+		assert.Equal(t, "node-1", response.Run.Artifacts[0].NodeId)
+		assert.Equal(t, execution.ID.String(), response.Run.Artifacts[0].ExecutionId)
+		assert.Equal(t, int64(len("content")), response.Run.Artifacts[0].SizeBytes)
 	})
 
 	t.Run("returns run with queue items", func(t *testing.T) {
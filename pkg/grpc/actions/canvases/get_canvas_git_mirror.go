@@ -0,0 +1,34 @@
+package canvases
+
+import (
+	"context"
+	"errors"
+
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"gorm.io/gorm"
+)
+
+const canvasGitMirrorEventsLimit = 20
+
+func GetCanvasGitMirror(ctx context.Context, db *gorm.DB, canvas *models.Canvas) (*pb.GetCanvasGitMirrorResponse, error) {
+	mirror, err := models.FindCanvasGitMirrorInTransaction(db, canvas.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrCanvasGitMirrorNotFound) {
+			return nil, grpcerrors.NotFound(err, "git mirror not configured")
+		}
+
+		return nil, grpcerrors.Internal(err, "failed to find git mirror")
+	}
+
+	events, err := models.ListCanvasGitMirrorEvents(db, canvas.ID, canvasGitMirrorEventsLimit)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to list git mirror events")
+	}
+
+	return &pb.GetCanvasGitMirrorResponse{
+		Mirror: serializeCanvasGitMirror(mirror),
+		Events: serializeCanvasGitMirrorEvents(events),
+	}, nil
+}
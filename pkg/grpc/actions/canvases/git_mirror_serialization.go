@@ -0,0 +1,121 @@
+package canvases
+
+import (
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func serializeCanvasGitMirror(mirror *models.CanvasGitMirror) *pb.CanvasGitMirror {
+	lastPushedVersionID := ""
+	if mirror.LastPushedVersionID != nil {
+		lastPushedVersionID = mirror.LastPushedVersionID.String()
+	}
+
+	result := &pb.CanvasGitMirror{
+		Metadata: &pb.CanvasGitMirror_Metadata{
+			CanvasId:  mirror.CanvasID.String(),
+			CreatedBy: canvasVersionOwnerRef(mirror.OrganizationID.String(), mirror.CreatedBy.String(), nil),
+		},
+		Spec: &pb.CanvasGitMirror_Spec{
+			IntegrationId: mirror.IntegrationID.String(),
+			Repository:    mirror.Repository,
+			Branch:        mirror.Branch,
+			Path:          mirror.Path,
+			ImportMode:    gitMirrorImportModeToProto(mirror.ImportMode),
+		},
+		Status: &pb.CanvasGitMirror_Status{
+			LastPushedVersionId: lastPushedVersionID,
+			RemoteHeadSha:       mirror.RemoteHeadSHA,
+			LastError:           mirror.LastError,
+			NextSyncAt:          timestamppb.New(mirror.NextSyncAt),
+		},
+	}
+
+	if mirror.CreatedAt != nil {
+		result.Metadata.CreatedAt = timestamppb.New(*mirror.CreatedAt)
+	}
+	if mirror.UpdatedAt != nil {
+		result.Metadata.UpdatedAt = timestamppb.New(*mirror.UpdatedAt)
+	}
+	if mirror.LastSyncedAt != nil {
+		result.Status.LastSyncedAt = timestamppb.New(*mirror.LastSyncedAt)
+	}
+
+	return result
+}
+
+func serializeCanvasGitMirrorEvents(events []models.CanvasGitMirrorEvent) []*pb.CanvasGitMirrorEvent {
+	result := make([]*pb.CanvasGitMirrorEvent, 0, len(events))
+	for _, event := range events {
+		versionID := ""
+		if event.VersionID != nil {
+			versionID = event.VersionID.String()
+		}
+
+		serialized := &pb.CanvasGitMirrorEvent{
+			Id:              event.ID.String(),
+			Direction:       gitMirrorDirectionToProto(event.Direction),
+			Status:          gitMirrorEventStatusToProto(event.Status),
+			VersionId:       versionID,
+			RemoteCommitSha: event.RemoteCommitSHA,
+			Message:         event.Message,
+		}
+
+		if event.CreatedAt != nil {
+			serialized.CreatedAt = timestamppb.New(*event.CreatedAt)
+		}
+
+		result = append(result, serialized)
+	}
+
+	return result
+}
+
+func gitMirrorImportModeToProto(mode string) pb.CanvasGitMirror_ImportMode {
+	switch mode {
+	case models.CanvasGitMirrorImportModeDisabled:
+		return pb.CanvasGitMirror_IMPORT_MODE_DISABLED
+	case models.CanvasGitMirrorImportModeStaging:
+		return pb.CanvasGitMirror_IMPORT_MODE_STAGING
+	case models.CanvasGitMirrorImportModePublish:
+		return pb.CanvasGitMirror_IMPORT_MODE_PUBLISH
+	}
+
+	return pb.CanvasGitMirror_IMPORT_MODE_UNSPECIFIED
+}
+
+func gitMirrorImportModeFromProto(mode pb.CanvasGitMirror_ImportMode) string {
+	switch mode {
+	case pb.CanvasGitMirror_IMPORT_MODE_DISABLED:
+		return models.CanvasGitMirrorImportModeDisabled
+	case pb.CanvasGitMirror_IMPORT_MODE_PUBLISH:
+		return models.CanvasGitMirrorImportModePublish
+	}
+
+	return models.CanvasGitMirrorImportModeStaging
+}
+
+func gitMirrorDirectionToProto(direction string) pb.CanvasGitMirrorEvent_Direction {
+	switch direction {
+	case models.CanvasGitMirrorDirectionPush:
+		return pb.CanvasGitMirrorEvent_DIRECTION_PUSH
+	case models.CanvasGitMirrorDirectionImport:
+		return pb.CanvasGitMirrorEvent_DIRECTION_IMPORT
+	}
+
+	return pb.CanvasGitMirrorEvent_DIRECTION_UNSPECIFIED
+}
+
+func gitMirrorEventStatusToProto(status string) pb.CanvasGitMirrorEvent_Status {
+	switch status {
+	case models.CanvasGitMirrorEventStatusSucceeded:
+		return pb.CanvasGitMirrorEvent_STATUS_SUCCEEDED
+	case models.CanvasGitMirrorEventStatusConflict:
+		return pb.CanvasGitMirrorEvent_STATUS_CONFLICT
+	case models.CanvasGitMirrorEventStatusFailed:
+		return pb.CanvasGitMirrorEvent_STATUS_FAILED
+	}
+
+	return pb.CanvasGitMirrorEvent_STATUS_UNSPECIFIED
+}
@@ -0,0 +1,67 @@
+package canvases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"gorm.io/gorm"
+)
+
+const MaxCanvasChangeRequestLimit = 100
+
+func ListCanvasChangeRequests(
+	ctx context.Context,
+	db *gorm.DB,
+	canvas *models.Canvas,
+	state pb.CanvasChangeRequest_State,
+	limit uint32,
+) (*pb.ListCanvasChangeRequestsResponse, error) {
+	if _, ok := authentication.GetUserIdFromMetadata(ctx); !ok {
+		return nil, grpcerrors.Unauthenticated(nil, "user not authenticated")
+	}
+
+	if limit == 0 || limit > MaxCanvasChangeRequestLimit {
+		limit = MaxCanvasChangeRequestLimit
+	}
+
+	requests, err := models.ListCanvasChangeRequests(db, canvas.ID, changeRequestStateFromProto(state), int(limit))
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to list change requests")
+	}
+
+	policy, err := models.FindEffectiveCanvasReviewPolicy(db, canvas)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to load review policy")
+	}
+
+	ids := make([]uuid.UUID, 0, len(requests))
+	for _, request := range requests {
+		ids = append(ids, request.ID)
+	}
+
+	eventsByRequest, err := models.ListCanvasChangeRequestEventsForRequests(db, ids)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to list change request events")
+	}
+
+	//
+	// Only reviewers matter for the list,
+	// so the users of comments are not loaded.
+	//
+	reviews := []models.CanvasChangeRequestEvent{}
+	for _, events := range eventsByRequest {
+		reviews = append(reviews, models.CurrentCanvasChangeRequestReviews(events)...)
+	}
+
+	usersByID := changeRequestUsersByID(db, canvas.OrganizationID.String(), requests, reviews)
+	result := make([]*pb.CanvasChangeRequest, 0, len(requests))
+	for i := range requests {
+		result = append(result, serializeCanvasChangeRequest(canvas, &requests[i], policy, eventsByRequest[requests[i].ID], usersByID))
+	}
+
+	return &pb.ListCanvasChangeRequestsResponse{ChangeRequests: result}, nil
+}
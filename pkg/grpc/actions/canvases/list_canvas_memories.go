@@ -63,14 +63,20 @@ func canvasMemoryToProto(record models.CanvasMemory) (*pb.CanvasMemory, error) {
 		return nil, err
 	}
 
-	return &pb.CanvasMemory{
+	serialized := &pb.CanvasMemory{
 		Id:        record.ID.String(),
 		Namespace: record.Namespace,
 		Values:    values,
 		Source:    canvasMemorySourceToProto(record.Source),
 		CreatedAt: timestamppb.New(record.CreatedAt),
 		UpdatedAt: timestamppb.New(record.UpdatedAt),
-	}, nil
+	}
+
+	if record.ExpiresAt != nil {
+		serialized.ExpiresAt = timestamppb.New(*record.ExpiresAt)
+	}
+
+	return serialized, nil
 }
 
 func canvasMemorySourceToProto(source string) pb.CanvasMemory_Source {
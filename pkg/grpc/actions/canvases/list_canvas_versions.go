@@ -38,15 +38,40 @@ func ListCanvasVersionsPaginated(
 	}
 
 	protoVersions := serializeCanvasVersionMetadataList(ctx, versions, canvas.OrganizationID.String())
+	nextPage := hasNextPage(len(versions), int(limit), count)
+
+	mirrorEvents, err := listCanvasVersionMirrorEvents(db, canvas.ID, versions, nextPage, beforeTime)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to list git mirror events")
+	}
 
 	return &pb.ListCanvasVersionsResponse{
 		Versions:      protoVersions,
 		TotalCount:    uint32(count),
-		HasNextPage:   hasNextPage(len(versions), int(limit), count),
+		HasNextPage:   nextPage,
 		LastTimestamp: getLastCanvasVersionTimestamp(versions),
+		MirrorEvents:  serializeCanvasGitMirrorEvents(mirrorEvents),
 	}, nil
 }
 
+// listCanvasVersionMirrorEvents returns the git mirror events that happened
+// in the time range covered by a page of versions, so pushes, imports and
+// conflicts can be shown in place in the version history.
+func listCanvasVersionMirrorEvents(
+	db *gorm.DB,
+	canvasID uuid.UUID,
+	versions []models.CanvasVersionMetadata,
+	nextPage bool,
+	beforeTime *time.Time,
+) ([]models.CanvasGitMirrorEvent, error) {
+	var after *time.Time
+	if nextPage && len(versions) > 0 {
+		after = versions[len(versions)-1].CreatedAt
+	}
+
+	return models.ListCanvasGitMirrorEventsBetween(db, canvasID, after, beforeTime, MaxCanvasVersionLimit)
+}
+
 func getCanvasVersionLimit(limit uint32) uint32 {
 	if limit <= 0 {
 		return DefaultLimit
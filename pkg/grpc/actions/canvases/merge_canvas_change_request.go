@@ -0,0 +1,232 @@
+package canvases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	"github.com/superplanehq/superplane/pkg/authorization"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	gitprovider "github.com/superplanehq/superplane/pkg/git/provider"
+	"github.com/superplanehq/superplane/pkg/grpc/actions/messages"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"github.com/superplanehq/superplane/pkg/usage"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// MergeCanvasChangeRequest publishes the files of an approved change
+// request as the new live version, authored by the change request author.
+// The reviews and the merge are recorded on the new version.
+func MergeCanvasChangeRequest(
+	ctx context.Context,
+	db *gorm.DB,
+	gitProvider gitprovider.Provider,
+	usageService usage.Service,
+	encryptor crypto.Encryptor,
+	registry *registry.Registry,
+	canvas *models.Canvas,
+	changeRequestID string,
+	webhookBaseURL string,
+	authService authorization.Authorization,
+) (*pb.MergeCanvasChangeRequestResponse, error) {
+	user, ok := authentication.GetUserIdFromMetadata(ctx)
+	if !ok {
+		return nil, grpcerrors.Unauthenticated(nil, "user not authenticated")
+	}
+
+	id, err := parseChangeRequestID(changeRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := models.FindCanvasChangeRequest(db, canvas.ID, id)
+	if err != nil {
+		return nil, changeRequestErrorToStatus(err, "failed to find change request")
+	}
+
+	policy, err := models.FindEffectiveCanvasReviewPolicy(db, canvas)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to load review policy")
+	}
+
+	events, err := models.ListCanvasChangeRequestEvents(db, request.ID)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to list change request events")
+	}
+
+	if review := evaluateChangeRequest(canvas, request, policy, events); !review.mergeable() {
+		return nil, grpcerrors.FailedPrecondition(nil, review.blockedReason)
+	}
+
+	mergedBy := uuid.MustParse(user)
+	stagedFiles := make([]models.WorkflowStagedFile, 0, len(request.Files))
+	for _, file := range request.Files {
+		stagedFiles = append(stagedFiles, models.WorkflowStagedFile{
+			OrganizationID: canvas.OrganizationID,
+			WorkflowID:     canvas.ID,
+			UserID:         request.AuthorID,
+			BaseVersionID:  request.BaseVersionID,
+			Path:           file.Path,
+			Content:        file.Content,
+			Deleted:        file.Deleted,
+		})
+	}
+
+	version, err := commitStagedFiles(
+		ctx,
+		db,
+		gitProvider,
+		usageService,
+		encryptor,
+		registry,
+		canvas,
+		request.AuthorID,
+		stagedFiles,
+		request.Title,
+		webhookBaseURL,
+		authService,
+		func(tx *gorm.DB, version *models.CanvasVersion) error {
+			return finalizeChangeRequestMerge(tx, canvas, request, mergedBy, version)
+		},
+	)
+
+	if err != nil {
+		return nil, changeRequestErrorToStatus(err, "failed to merge change request")
+	}
+
+	if err := messages.NewCanvasStagingMessage(canvas.ID.String(), request.AuthorID.String()).Publish(); err != nil {
+		log.Errorf("failed to publish canvas staging updated RabbitMQ message: %v", err)
+	}
+
+	merged, err := models.FindCanvasChangeRequest(db, canvas.ID, request.ID)
+	if err != nil {
+		return nil, changeRequestErrorToStatus(err, "failed to find change request")
+	}
+
+	serialized, err := serializeLoadedCanvasChangeRequest(db, canvas, merged)
+	if err != nil {
+		return nil, err
+	}
+
+	ownersByID, _ := ownersByIDForCanvasVersions(ctx, canvas.OrganizationID.String(), []models.CanvasVersion{*version})
+	return &pb.MergeCanvasChangeRequestResponse{
+		ChangeRequest: serialized,
+		Version:       SerializeCanvasVersion(version, canvas.OrganizationID.String(), ownersByID),
+	}, nil
+}
+
+// finalizeChangeRequestMerge runs in the publishing transaction. The change
+// request is locked and checked again, since it may have been resubmitted,
+// rejected or closed while its files were being published.
+func finalizeChangeRequestMerge(
+	tx *gorm.DB,
+	canvas *models.Canvas,
+	request *models.CanvasChangeRequest,
+	mergedBy uuid.UUID,
+	version *models.CanvasVersion,
+) error {
+	locked, err := models.LockCanvasChangeRequest(tx, canvas.ID, request.ID)
+	if err != nil {
+		return err
+	}
+
+	if locked.BaseVersionID != request.BaseVersionID || !sameJSON(locked.Files, request.Files) {
+		return grpcerrors.FailedPrecondition(nil, "change request was resubmitted while merging")
+	}
+
+	policy, err := models.FindEffectiveCanvasReviewPolicy(tx, canvas)
+	if err != nil {
+		return err
+	}
+
+	events, err := models.ListCanvasChangeRequestEvents(tx, locked.ID)
+	if err != nil {
+		return err
+	}
+
+	//
+	// The new version is already live at this point,
+	// so the check runs against the version the request was based on.
+	//
+	base := *canvas
+	base.LiveVersionID = &locked.BaseVersionID
+	if review := evaluateChangeRequest(&base, locked, policy, events); !review.mergeable() {
+		return grpcerrors.FailedPrecondition(nil, review.blockedReason)
+	}
+
+	record := models.CanvasVersionChangeRequest{
+		ID:       locked.ID.String(),
+		Title:    locked.Title,
+		AuthorID: locked.AuthorID.String(),
+		Reviews:  []models.CanvasVersionChangeRequestReview{},
+		MergedBy: mergedBy.String(),
+		MergedAt: time.Now(),
+	}
+
+	for _, event := range events {
+		if !event.IsReview() || event.CreatedAt == nil {
+			continue
+		}
+
+		record.Reviews = append(record.Reviews, models.CanvasVersionChangeRequestReview{
+			UserID:    event.UserID.String(),
+			Approved:  event.Type == models.CanvasChangeRequestEventApproved,
+			CreatedAt: *event.CreatedAt,
+		})
+	}
+
+	changeRequest := datatypes.NewJSONType(record)
+	version.ChangeRequest = &changeRequest
+	if err := tx.Model(version).Update("change_request", changeRequest).Error; err != nil {
+		return err
+	}
+
+	if err := locked.MarkMerged(tx, mergedBy, version.ID); err != nil {
+		return err
+	}
+
+	if _, err := models.CreateCanvasChangeRequestEvent(tx, locked.ID, mergedBy, models.CanvasChangeRequestEventMerged, ""); err != nil {
+		return err
+	}
+
+	return discardMergedStagedFiles(tx, canvas.ID, locked)
+}
+
+// discardMergedStagedFiles removes the author's staged files that were
+// published as they are. Files the author changed after resubmitting
+// are kept, so no unpublished work is lost.
+func discardMergedStagedFiles(tx *gorm.DB, canvasID uuid.UUID, request *models.CanvasChangeRequest) error {
+	stagedFiles, err := models.ListStagedFilesForUser(tx, canvasID, request.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	merged := make(map[string]models.CanvasChangeRequestFile, len(request.Files))
+	for _, file := range request.Files {
+		merged[file.Path] = file
+	}
+
+	paths := []string{}
+	for _, staged := range stagedFiles {
+		file, ok := merged[staged.Path]
+		if !ok || staged.BaseVersionID != request.BaseVersionID {
+			continue
+		}
+
+		if file.Content == staged.Content && file.Deleted == staged.Deleted {
+			paths = append(paths, staged.Path)
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	return models.DiscardStagedFilesForUser(tx, canvasID, request.AuthorID, paths)
+}
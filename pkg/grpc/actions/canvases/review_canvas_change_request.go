@@ -0,0 +1,103 @@
+package canvases
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"gorm.io/gorm"
+)
+
+// ReviewCanvasChangeRequest records an approval or a rejection.
+// Authors cannot review their own changes, and API keys cannot review
+// at all, so every approval comes from a second person.
+func ReviewCanvasChangeRequest(
+	ctx context.Context,
+	db *gorm.DB,
+	canvas *models.Canvas,
+	changeRequestID string,
+	decision pb.ReviewCanvasChangeRequestRequest_Decision,
+	comment string,
+) (*pb.ReviewCanvasChangeRequestResponse, error) {
+	user, ok := authentication.GetUserIdFromMetadata(ctx)
+	if !ok {
+		return nil, grpcerrors.Unauthenticated(nil, "user not authenticated")
+	}
+
+	id, err := parseChangeRequestID(changeRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventType := ""
+	switch decision {
+	case pb.ReviewCanvasChangeRequestRequest_DECISION_APPROVE:
+		eventType = models.CanvasChangeRequestEventApproved
+	case pb.ReviewCanvasChangeRequestRequest_DECISION_REJECT:
+		eventType = models.CanvasChangeRequestEventRejected
+	default:
+		return nil, grpcerrors.InvalidArgument(nil, "decision is required")
+	}
+
+	reviewer, err := models.FindActiveUserByIDInTransaction(db, canvas.OrganizationID.String(), user)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, grpcerrors.PermissionDenied(err, "user is not a member of the organization")
+		}
+
+		return nil, grpcerrors.Internal(err, "failed to find user")
+	}
+
+	if reviewer.IsAPIKey() {
+		return nil, grpcerrors.PermissionDenied(nil, "API keys cannot review change requests")
+	}
+
+	policy, err := models.FindEffectiveCanvasReviewPolicy(db, canvas)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to load review policy")
+	}
+
+	userID := uuid.MustParse(user)
+	if !policy.CanReview(userID) {
+		return nil, grpcerrors.PermissionDenied(nil, "you are not a reviewer for this canvas")
+	}
+
+	var request *models.CanvasChangeRequest
+	err = db.Transaction(func(tx *gorm.DB) error {
+		locked, err := models.LockCanvasChangeRequest(tx, canvas.ID, id)
+		if err != nil {
+			return err
+		}
+
+		if locked.AuthorID == userID {
+			return grpcerrors.PermissionDenied(nil, "authors cannot review their own change request")
+		}
+
+		if !locked.IsOpen() {
+			return grpcerrors.FailedPrecondition(nil, "change request is not open")
+		}
+
+		if _, err := models.CreateCanvasChangeRequestEvent(tx, locked.ID, userID, eventType, strings.TrimSpace(comment)); err != nil {
+			return err
+		}
+
+		request = locked
+		return nil
+	})
+
+	if err != nil {
+		return nil, changeRequestErrorToStatus(err, "failed to review change request")
+	}
+
+	serialized, err := serializeLoadedCanvasChangeRequest(db, canvas, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ReviewCanvasChangeRequestResponse{ChangeRequest: serialized}, nil
+}
@@ -0,0 +1,60 @@
+package canvases
+
+import (
+	"context"
+	"errors"
+
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+// SyncCanvasGitMirror only schedules the sync. The git mirror worker
+// picks it up on its next tick and records the outcome as a mirror event.
+func SyncCanvasGitMirror(ctx context.Context, db *gorm.DB, canvas *models.Canvas, resolution pb.SyncCanvasGitMirrorRequest_Resolution) (*pb.SyncCanvasGitMirrorResponse, error) {
+	var mirror *models.CanvasGitMirror
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		mirror, err = models.FindCanvasGitMirrorInTransaction(tx, canvas.ID)
+		if err != nil {
+			if errors.Is(err, models.ErrCanvasGitMirrorNotFound) {
+				return grpcerrors.NotFound(err, "git mirror not configured")
+			}
+
+			return err
+		}
+
+		liveVersion, err := models.FindLiveCanvasVersionInTransaction(tx, canvas.ID)
+		if err != nil {
+			return err
+		}
+
+		switch resolution {
+		case pb.SyncCanvasGitMirrorRequest_RESOLUTION_KEEP_CANVAS:
+			return mirror.RequestSync(tx, models.CanvasGitMirrorResolutionKeepCanvas, liveVersion.ID)
+
+		case pb.SyncCanvasGitMirrorRequest_RESOLUTION_KEEP_REMOTE:
+			if mirror.ImportMode == models.CanvasGitMirrorImportModeDisabled {
+				return grpcerrors.FailedPrecondition(nil, "imports are disabled for this git mirror")
+			}
+
+			return mirror.RequestSync(tx, models.CanvasGitMirrorResolutionKeepRemote, liveVersion.ID)
+		}
+
+		return mirror.RequestSync(tx, "", liveVersion.ID)
+	})
+
+	if err != nil {
+		if grpcerrors.Code(err) != codes.Unknown {
+			return nil, err
+		}
+
+		return nil, grpcerrors.Internal(err, "failed to schedule git mirror sync")
+	}
+
+	return &pb.SyncCanvasGitMirrorResponse{
+		Mirror: serializeCanvasGitMirror(mirror),
+	}, nil
+}
@@ -0,0 +1,99 @@
+package canvases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"gorm.io/gorm"
+)
+
+// UpdateCanvasChangeRequest lets the author edit the title and description,
+// and resubmit their current staging. Resubmitting records an update event
+// in the thread, and reviews given before it no longer count.
+func UpdateCanvasChangeRequest(
+	ctx context.Context,
+	db *gorm.DB,
+	canvas *models.Canvas,
+	changeRequestID string,
+	spec *pb.CanvasChangeRequest_Spec,
+	resubmitStaging bool,
+) (*pb.UpdateCanvasChangeRequestResponse, error) {
+	user, ok := authentication.GetUserIdFromMetadata(ctx)
+	if !ok {
+		return nil, grpcerrors.Unauthenticated(nil, "user not authenticated")
+	}
+
+	id, err := parseChangeRequestID(changeRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec == nil && !resubmitStaging {
+		return nil, grpcerrors.InvalidArgument(nil, "nothing to update")
+	}
+
+	title := ""
+	if spec != nil {
+		title, err = validateChangeRequestTitle(spec.Title)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	userID := uuid.MustParse(user)
+	var request *models.CanvasChangeRequest
+	err = db.Transaction(func(tx *gorm.DB) error {
+		locked, err := models.LockCanvasChangeRequest(tx, canvas.ID, id)
+		if err != nil {
+			return err
+		}
+
+		if locked.AuthorID != userID {
+			return grpcerrors.PermissionDenied(nil, "only the author can update a change request")
+		}
+
+		if !locked.IsOpen() {
+			return grpcerrors.FailedPrecondition(nil, "change request is not open")
+		}
+
+		if spec != nil {
+			if err := locked.UpdateSpec(tx, title, strings.TrimSpace(spec.Description)); err != nil {
+				return err
+			}
+		}
+
+		if resubmitStaging {
+			baseVersionID, files, err := snapshotStagingForChangeRequest(tx, canvas, userID)
+			if err != nil {
+				return err
+			}
+
+			if err := locked.UpdateFiles(tx, baseVersionID, files); err != nil {
+				return err
+			}
+
+			if _, err := models.CreateCanvasChangeRequestEvent(tx, locked.ID, userID, models.CanvasChangeRequestEventUpdated, ""); err != nil {
+				return err
+			}
+		}
+
+		request = locked
+		return nil
+	})
+
+	if err != nil {
+		return nil, changeRequestErrorToStatus(err, "failed to update change request")
+	}
+
+	serialized, err := serializeLoadedCanvasChangeRequest(db, canvas, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateCanvasChangeRequestResponse{ChangeRequest: serialized}, nil
+}
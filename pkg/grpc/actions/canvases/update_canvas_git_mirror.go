@@ -0,0 +1,128 @@
+package canvases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	"github.com/superplanehq/superplane/pkg/core"
+	gitprovider "github.com/superplanehq/superplane/pkg/git/provider"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"gorm.io/gorm"
+)
+
+// UpdateCanvasGitMirror configures the mirror of a canvas. The current user
+// becomes the mirror owner: imported changes are staged for them, and
+// published in their name when the mirror publishes directly.
+func UpdateCanvasGitMirror(
+	ctx context.Context,
+	db *gorm.DB,
+	registry *registry.Registry,
+	canvas *models.Canvas,
+	spec *pb.CanvasGitMirror_Spec,
+) (*pb.UpdateCanvasGitMirrorResponse, error) {
+	user, ok := authentication.GetUserIdFromMetadata(ctx)
+	if !ok {
+		return nil, grpcerrors.Unauthenticated(nil, "user not authenticated")
+	}
+
+	if spec == nil {
+		return nil, grpcerrors.InvalidArgument(nil, "spec is required")
+	}
+
+	integrationID, err := uuid.Parse(strings.TrimSpace(spec.GetIntegrationId()))
+	if err != nil {
+		return nil, grpcerrors.InvalidArgument(nil, "invalid integration_id")
+	}
+
+	repository := strings.Trim(strings.TrimSpace(spec.GetRepository()), "/")
+	if repository == "" {
+		return nil, grpcerrors.InvalidArgument(nil, "repository is required")
+	}
+
+	branch := gitprovider.DefaultBranch(spec.GetBranch())
+	if !isValidGitMirrorBranch(branch) {
+		return nil, grpcerrors.InvalidArgument(nil, fmt.Sprintf("invalid branch %q", branch))
+	}
+
+	path := ""
+	if strings.Trim(strings.TrimSpace(spec.GetPath()), "/") != "" {
+		path, err = gitprovider.ValidateUserPath(spec.GetPath())
+		if err != nil {
+			return nil, grpcerrors.InvalidArgument(err, fmt.Sprintf("invalid path %q", spec.GetPath()))
+		}
+	}
+
+	if err := validateGitMirrorIntegration(db, registry, canvas.OrganizationID, integrationID); err != nil {
+		return nil, err
+	}
+
+	mirror, err := models.SaveCanvasGitMirror(db, &models.CanvasGitMirror{
+		OrganizationID: canvas.OrganizationID,
+		CanvasID:       canvas.ID,
+		IntegrationID:  integrationID,
+		Repository:     repository,
+		Branch:         branch,
+		Path:           path,
+		ImportMode:     gitMirrorImportModeFromProto(spec.GetImportMode()),
+		CreatedBy:      uuid.MustParse(user),
+	})
+
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to save git mirror")
+	}
+
+	return &pb.UpdateCanvasGitMirrorResponse{
+		Mirror: serializeCanvasGitMirror(mirror),
+	}, nil
+}
+
+func validateGitMirrorIntegration(db *gorm.DB, integrationRegistry *registry.Registry, organizationID, integrationID uuid.UUID) error {
+	integration, err := models.FindIntegrationInTransaction(db, organizationID, integrationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return grpcerrors.NotFound(err, "integration not found")
+		}
+
+		return grpcerrors.Internal(err, "failed to find integration")
+	}
+
+	if integration.State != models.IntegrationStateReady {
+		return grpcerrors.FailedPrecondition(nil, fmt.Sprintf("integration %q is not ready", integration.InstallationName))
+	}
+
+	integrationImpl, err := integrationRegistry.GetIntegration(integration.AppName)
+	if err != nil {
+		return grpcerrors.FailedPrecondition(err, fmt.Sprintf("integration %q is not available", integration.InstallationName))
+	}
+
+	if _, ok := registry.UnwrapIntegration(integrationImpl).(core.IntegrationGitRemoteProvider); !ok {
+		return grpcerrors.InvalidArgument(nil, fmt.Sprintf("integration %q does not support git mirroring", integration.InstallationName))
+	}
+
+	return nil
+}
+
+// isValidGitMirrorBranch follows the git check-ref-format rules
+// that matter for branch names typed in by users.
+func isValidGitMirrorBranch(branch string) bool {
+	if branch == "" || strings.HasPrefix(branch, "-") || strings.HasPrefix(branch, "/") ||
+		strings.HasSuffix(branch, "/") || strings.HasSuffix(branch, ".") || strings.HasSuffix(branch, ".lock") ||
+		strings.Contains(branch, "..") || strings.Contains(branch, "//") || strings.Contains(branch, "@{") {
+		return false
+	}
+
+	for _, r := range branch {
+		if r <= ' ' || r == 0x7f || strings.ContainsRune("~^:?*[\\", r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/datatypes"
 )
 
 func canvasMetadataFromCanvas(canvas *models.Canvas) (name, description string) {
@@ -34,6 +35,11 @@ func SerializeCanvasVersionMetadata(version *models.CanvasVersionMetadata, organ
 		CommitMessage: version.CommitMessage,
 	}
 
+	if version.ChangeRequest != nil {
+		record := version.ChangeRequest.Data()
+		metadata.ChangeRequest = serializeVersionChangeRequest(organizationID, &record, ownersByID)
+	}
+
 	if version.CreatedAt != nil {
 		metadata.CreatedAt = timestamppb.New(*version.CreatedAt)
 	}
@@ -57,6 +63,11 @@ func SerializeCanvasVersion(version *models.CanvasVersion, organizationID string
 		CommitMessage: version.CommitMessage,
 	}
 
+	if version.ChangeRequest != nil {
+		record := version.ChangeRequest.Data()
+		metadata.ChangeRequest = serializeVersionChangeRequest(organizationID, &record, ownersByID)
+	}
+
 	if version.CreatedAt != nil {
 		metadata.CreatedAt = timestamppb.New(*version.CreatedAt)
 	}
@@ -93,6 +104,8 @@ func ownersByIDForCanvasVersionMetadata(ctx context.Context, orgID string, versi
 		if versions[i].OwnerID != nil {
 			idSet[versions[i].OwnerID.String()] = struct{}{}
 		}
+
+		addChangeRequestUserIDs(idSet, versions[i].ChangeRequest)
 	}
 	if len(idSet) == 0 {
 		return map[string]*models.User{}, nil
@@ -123,6 +136,8 @@ func ownersByIDForCanvasVersions(ctx context.Context, orgID string, versions []m
 		if versions[i].OwnerID != nil {
 			idSet[versions[i].OwnerID.String()] = struct{}{}
 		}
+
+		addChangeRequestUserIDs(idSet, versions[i].ChangeRequest)
 	}
 	if len(idSet) == 0 {
 		return map[string]*models.User{}, nil
@@ -146,6 +161,19 @@ func ownersByIDForCanvasVersions(ctx context.Context, orgID string, versions []m
 	return ownersByID, nil
 }
 
+func addChangeRequestUserIDs(idSet map[string]struct{}, changeRequest *datatypes.JSONType[models.CanvasVersionChangeRequest]) {
+	if changeRequest == nil {
+		return
+	}
+
+	record := changeRequest.Data()
+	idSet[record.AuthorID] = struct{}{}
+	idSet[record.MergedBy] = struct{}{}
+	for _, review := range record.Reviews {
+		idSet[review.UserID] = struct{}{}
+	}
+}
+
 func serializeCanvasVersionMetadataList(ctx context.Context, versions []models.CanvasVersionMetadata, organizationID string) []*pb.CanvasVersion_Metadata {
 	var err error
 	ctx, done := telemetry.Span(ctx, "canvases.serialize_versions")
@@ -22,6 +22,12 @@ func canvasFolderErrorToStatus(err error, internalMessage string) error {
 		return grpcerrors.AlreadyExists(err, "canvas folder with the same title already exists")
 	case errors.Is(err, models.ErrCanvasFolderCanvasNotFound):
 		return grpcerrors.NotFound(err, "canvas not found")
+	case errors.Is(err, models.ErrCanvasReviewPolicyTooManyApprovals):
+		return grpcerrors.InvalidArgument(err, "at most 10 approvals can be required")
+	case errors.Is(err, models.ErrCanvasReviewPolicyInvalidReviewer):
+		return grpcerrors.InvalidArgument(err, "reviewers must be members of the organization")
+	case errors.Is(err, models.ErrCanvasReviewPolicyNotEnoughReviewers):
+		return grpcerrors.InvalidArgument(err, "required approvals exceed the number of reviewers")
 	case errors.Is(err, gorm.ErrRecordNotFound):
 		return grpcerrors.NotFound(err, "canvas folder not found")
 	default:
@@ -0,0 +1,110 @@
+package canvasfolders
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/canvas_folders"
+	"gorm.io/gorm"
+)
+
+func GetCanvasFolderReviewPolicy(_ context.Context, organizationID, id string) (*pb.GetCanvasFolderReviewPolicyResponse, error) {
+	folder, err := findReviewPolicyFolder(organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := findCanvasFolderReviewPolicy(folder.ID)
+	if err != nil {
+		return nil, canvasFolderErrorToStatus(err, "failed to load canvas folder review policy")
+	}
+
+	return &pb.GetCanvasFolderReviewPolicyResponse{Policy: policy}, nil
+}
+
+// UpdateCanvasFolderReviewPolicy sets the policy applied to every canvas
+// in the folder that has no policy of its own.
+// Zero required approvals removes it.
+func UpdateCanvasFolderReviewPolicy(
+	_ context.Context,
+	organizationID,
+	id string,
+	requiredApprovals uint32,
+	reviewerIDs []string,
+) (*pb.UpdateCanvasFolderReviewPolicyResponse, error) {
+	folder, err := findReviewPolicyFolder(organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	db := database.Conn()
+	if requiredApprovals == 0 {
+		if err := models.DeleteCanvasFolderReviewPolicy(db, folder.ID); err != nil {
+			return nil, canvasFolderErrorToStatus(err, "failed to delete canvas folder review policy")
+		}
+
+		return &pb.UpdateCanvasFolderReviewPolicyResponse{Policy: &pb.CanvasFolderReviewPolicy{}}, nil
+	}
+
+	reviewers, err := models.NormalizeCanvasReviewers(db, folder.OrganizationID, int(requiredApprovals), reviewerIDs)
+	if err != nil {
+		return nil, canvasFolderErrorToStatus(err, "failed to validate reviewers")
+	}
+
+	err = models.SaveCanvasReviewPolicy(db, &models.CanvasReviewPolicy{
+		OrganizationID:    folder.OrganizationID,
+		FolderID:          &folder.ID,
+		RequiredApprovals: int(requiredApprovals),
+		ReviewerIDs:       reviewers,
+	})
+
+	if err != nil {
+		return nil, canvasFolderErrorToStatus(err, "failed to save canvas folder review policy")
+	}
+
+	policy, err := findCanvasFolderReviewPolicy(folder.ID)
+	if err != nil {
+		return nil, canvasFolderErrorToStatus(err, "failed to load canvas folder review policy")
+	}
+
+	return &pb.UpdateCanvasFolderReviewPolicyResponse{Policy: policy}, nil
+}
+
+func findReviewPolicyFolder(organizationID, id string) (*models.CanvasFolder, error) {
+	organizationUUID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, grpcerrors.InvalidArgument(err, "invalid organization id")
+	}
+
+	folderID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, grpcerrors.InvalidArgument(err, "invalid canvas folder id")
+	}
+
+	folder, err := models.FindCanvasFolder(organizationUUID, folderID)
+	if err != nil {
+		return nil, canvasFolderErrorToStatus(err, "failed to find canvas folder")
+	}
+
+	return folder, nil
+}
+
+func findCanvasFolderReviewPolicy(folderID uuid.UUID) (*pb.CanvasFolderReviewPolicy, error) {
+	policy, err := models.FindCanvasFolderReviewPolicy(database.Conn(), folderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &pb.CanvasFolderReviewPolicy{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CanvasFolderReviewPolicy{
+		RequiredApprovals: uint32(policy.RequiredApprovals),
+		ReviewerIds:       policy.ReviewerIDs,
+	}, nil
+}
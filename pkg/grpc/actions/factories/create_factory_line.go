@@ -25,6 +25,10 @@ func CreateFactoryLine(ctx context.Context, organizationID string, req *pb.Creat
 		return nil, factoryErrorToStatus(invalidArgument("name is required"), "failed to create factory line")
 	}
 
+	if req.MonthlyBudgetCents != nil && req.GetMonthlyBudgetCents() < 0 {
+		return nil, factoryErrorToStatus(invalidArgument("monthly budget cannot be negative"), "failed to create factory line")
+	}
+
 	db := database.DB(ctx)
 	factory, err := models.FindFactory(db, orgID, factoryID)
 	if err != nil {
@@ -41,6 +45,12 @@ func CreateFactoryLine(ctx context.Context, organizationID string, req *pb.Creat
 		return nil, factoryErrorToStatus(err, "failed to create factory line")
 	}
 
+	if req.MonthlyBudgetCents != nil {
+		if err := line.SetMonthlyBudget(db, req.MonthlyBudgetCents); err != nil {
+			return nil, factoryErrorToStatus(err, "failed to create factory line")
+		}
+	}
+
 	return &pb.CreateFactoryLineResponse{
 		Line: serializeFactoryLine(line),
 	}, nil
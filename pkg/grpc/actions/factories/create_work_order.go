@@ -13,6 +13,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/models"
 	factoryevents "github.com/superplanehq/superplane/pkg/models/factory"
 	pb "github.com/superplanehq/superplane/pkg/protos/factories"
+	"gorm.io/gorm"
 )
 
 func CreateWorkOrder(ctx context.Context, organizationID string, req *pb.CreateWorkOrderRequest) (*pb.CreateWorkOrderResponse, error) {
@@ -31,6 +32,10 @@ func CreateWorkOrder(ctx context.Context, organizationID string, req *pb.CreateW
 		return nil, factoryErrorToStatus(invalidArgument("title is required"), "failed to create work order")
 	}
 
+	if req.BudgetCents != nil && req.GetBudgetCents() < 0 {
+		return nil, factoryErrorToStatus(invalidArgument("budget cannot be negative"), "failed to create work order")
+	}
+
 	userID, ok := authentication.GetUserIdFromMetadata(ctx)
 	if !ok {
 		return nil, grpcerrors.Unauthenticated(nil, "user not authenticated")
@@ -52,7 +57,41 @@ func CreateWorkOrder(ctx context.Context, organizationID string, req *pb.CreateW
 		return nil, factoryErrorToStatus(err, "failed to create work order")
 	}
 
-	order, err := factory.CreateWorkOrder(db, title, req.GetDescription(), &createdByID, assigneeIDs, nil)
+	var template *models.FactoryWorkOrderTemplate
+	if req.TemplateId != nil {
+		templateID, err := parseTemplateID(req.GetTemplateId())
+		if err != nil {
+			return nil, factoryErrorToStatus(err, "failed to create work order")
+		}
+
+		template, err = factory.FindWorkOrderTemplate(db, templateID)
+		if err != nil {
+			return nil, factoryErrorToStatus(err, "failed to create work order")
+		}
+	} else if len(req.GetFields().GetFields()) > 0 {
+		return nil, factoryErrorToStatus(invalidArgument("fields require a template"), "failed to create work order")
+	}
+
+	var order *models.FactoryWorkOrder
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		order, err = factory.CreateWorkOrder(tx, title, req.GetDescription(), &createdByID, assigneeIDs, nil)
+		if err != nil {
+			return err
+		}
+
+		if template != nil {
+			if err := order.ApplyTemplate(tx, template, req.GetFields().AsMap()); err != nil {
+				return err
+			}
+		}
+
+		if req.BudgetCents != nil {
+			return order.SetBudget(tx, req.BudgetCents)
+		}
+
+		return nil
+	})
 	if err != nil {
 		return nil, factoryErrorToStatus(err, "failed to create work order")
 	}
@@ -0,0 +1,42 @@
+package factories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/factories"
+)
+
+func CreateWorkOrderTemplate(ctx context.Context, organizationID string, req *pb.CreateWorkOrderTemplateRequest) (*pb.CreateWorkOrderTemplateResponse, error) {
+	orgID, err := parseOrganizationID(organizationID)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to create work order template")
+	}
+
+	factoryID, err := parseFactoryID(req.GetFactoryId())
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to create work order template")
+	}
+
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return nil, factoryErrorToStatus(invalidArgument("name is required"), "failed to create work order template")
+	}
+
+	db := database.DB(ctx)
+	factory, err := models.FindFactory(db, orgID, factoryID)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to create work order template")
+	}
+
+	template, err := factory.CreateWorkOrderTemplate(db, name, req.GetDescription(), parseTemplateFields(req.GetFields()))
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to create work order template")
+	}
+
+	return &pb.CreateWorkOrderTemplateResponse{
+		Template: serializeWorkOrderTemplate(template),
+	}, nil
+}
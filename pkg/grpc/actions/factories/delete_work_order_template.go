@@ -0,0 +1,43 @@
+package factories
+
+import (
+	"context"
+
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/factories"
+)
+
+func DeleteWorkOrderTemplate(ctx context.Context, organizationID string, req *pb.DeleteWorkOrderTemplateRequest) (*pb.DeleteWorkOrderTemplateResponse, error) {
+	orgID, err := parseOrganizationID(organizationID)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to delete work order template")
+	}
+
+	factoryID, err := parseFactoryID(req.GetFactoryId())
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to delete work order template")
+	}
+
+	templateID, err := parseTemplateID(req.GetTemplateId())
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to delete work order template")
+	}
+
+	db := database.DB(ctx)
+	factory, err := models.FindFactory(db, orgID, factoryID)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to delete work order template")
+	}
+
+	template, err := factory.FindWorkOrderTemplate(db, templateID)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to delete work order template")
+	}
+
+	if err := template.Delete(db); err != nil {
+		return nil, factoryErrorToStatus(err, "failed to delete work order template")
+	}
+
+	return &pb.DeleteWorkOrderTemplateResponse{}, nil
+}
@@ -66,6 +66,18 @@ func factoryErrorToStatus(err error, internalMessage string) error {
 		return grpcerrors.FailedPrecondition(err, "factory line has no steps")
 	case errors.Is(err, models.ErrFactoryLineStepNotOnRun):
 		return grpcerrors.FailedPrecondition(err, "factory line step entrypoint must use the onRun trigger")
+	case errors.Is(err, models.ErrFactoryBudgetExceeded):
+		return grpcerrors.FailedPrecondition(err, err.Error())
+	case errors.Is(err, models.ErrFactoryBudgetInvalid):
+		return grpcerrors.InvalidArgument(err, err.Error())
+	case errors.Is(err, models.ErrFactoryWorkOrderTemplateNotFound):
+		return grpcerrors.NotFound(err, "work order template not found")
+	case errors.Is(err, models.ErrFactoryWorkOrderTemplateNameAlreadyExists):
+		return grpcerrors.AlreadyExists(err, "work order template with the same name already exists")
+	case errors.Is(err, models.ErrFactoryWorkOrderTemplateInvalid):
+		return grpcerrors.InvalidArgument(err, err.Error())
+	case errors.Is(err, models.ErrFactoryWorkOrderFieldsInvalid):
+		return grpcerrors.InvalidArgument(err, err.Error())
 	case errors.Is(err, models.ErrFactoryWorkOrderArtifactInvalid):
 		return grpcerrors.InvalidArgument(err, err.Error())
 	case errors.Is(err, errInvalidArgument):
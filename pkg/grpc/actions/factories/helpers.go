@@ -2,9 +2,13 @@ package factories
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/grpc/actions"
 	"github.com/superplanehq/superplane/pkg/models"
+	configpb "github.com/superplanehq/superplane/pkg/protos/configuration"
 	pb "github.com/superplanehq/superplane/pkg/protos/factories"
 	"gorm.io/gorm"
 )
@@ -36,6 +40,23 @@ func parseOrderID(orderID string) (uuid.UUID, error) {
 	return id, nil
 }
 
+func parseTemplateID(templateID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(templateID)
+	if err != nil {
+		return uuid.Nil, invalidArgument("invalid work order template id")
+	}
+
+	return id, nil
+}
+
+func parseTemplateFields(pbFields []*configpb.Field) []configuration.Field {
+	fields := make([]configuration.Field, len(pbFields))
+	for i, pbField := range pbFields {
+		fields[i] = actions.ProtoToConfigurationField(pbField)
+	}
+	return fields
+}
+
 func parseAssigneeIDs(tx *gorm.DB, organizationID uuid.UUID, assigneeIDs []string) ([]uuid.UUID, error) {
 	if len(assigneeIDs) == 0 {
 		return nil, nil
@@ -61,7 +82,7 @@ func parseAssigneeIDs(tx *gorm.DB, organizationID uuid.UUID, assigneeIDs []strin
 	return parsed, nil
 }
 
-func listWorkOrderFilters(req *pb.ListWorkOrdersRequest) models.ListFactoryWorkOrdersFilters {
+func listWorkOrderFilters(req *pb.ListWorkOrdersRequest) (models.ListFactoryWorkOrdersFilters, error) {
 	filters := models.ListFactoryWorkOrdersFilters{
 		Unassigned: req.Unassigned,
 	}
@@ -86,7 +107,28 @@ func listWorkOrderFilters(req *pb.ListWorkOrdersRequest) models.ListFactoryWorkO
 		filters.AssigneeIDs = append(filters.AssigneeIDs, userID)
 	}
 
-	return filters
+	if req.TemplateId != nil {
+		templateID, err := parseTemplateID(req.GetTemplateId())
+		if err != nil {
+			return filters, err
+		}
+		filters.TemplateID = &templateID
+	}
+
+	for _, filter := range req.Fields {
+		name, value, ok := strings.Cut(filter, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return filters, invalidArgument(fmt.Sprintf("invalid field filter %q: expected name=value", filter))
+		}
+
+		if filters.Fields == nil {
+			filters.Fields = map[string]string{}
+		}
+		filters.Fields[name] = value
+	}
+
+	return filters, nil
 }
 
 func closeWorkOrderResult(result pb.WorkOrder_Result) (string, error) {
@@ -0,0 +1,36 @@
+package factories
+
+import (
+	"context"
+
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/factories"
+)
+
+func ListWorkOrderTemplates(ctx context.Context, organizationID string, req *pb.ListWorkOrderTemplatesRequest) (*pb.ListWorkOrderTemplatesResponse, error) {
+	orgID, err := parseOrganizationID(organizationID)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to list work order templates")
+	}
+
+	factoryID, err := parseFactoryID(req.GetFactoryId())
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to list work order templates")
+	}
+
+	db := database.DB(ctx)
+	factory, err := models.FindFactory(db, orgID, factoryID)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to list work order templates")
+	}
+
+	templates, err := factory.ListWorkOrderTemplates(db)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to list work order templates")
+	}
+
+	return &pb.ListWorkOrderTemplatesResponse{
+		Templates: serializeWorkOrderTemplates(templates),
+	}, nil
+}
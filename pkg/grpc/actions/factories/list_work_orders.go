@@ -19,13 +19,18 @@ func ListWorkOrders(ctx context.Context, organizationID string, req *pb.ListWork
 		return nil, factoryErrorToStatus(err, "failed to list work orders")
 	}
 
+	filters, err := listWorkOrderFilters(req)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to list work orders")
+	}
+
 	db := database.DB(ctx)
 	factory, err := models.FindFactory(db, orgID, factoryID)
 	if err != nil {
 		return nil, factoryErrorToStatus(err, "failed to list work orders")
 	}
 
-	orders, err := factory.ListWorkOrders(db, listWorkOrderFilters(req))
+	orders, err := factory.ListWorkOrders(db, filters)
 	if err != nil {
 		return nil, factoryErrorToStatus(err, "failed to list work orders")
 	}
@@ -2,9 +2,12 @@ package factories
 
 import (
 	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/grpc/actions"
 	"github.com/superplanehq/superplane/pkg/models"
 	"github.com/superplanehq/superplane/pkg/models/factory"
+	configpb "github.com/superplanehq/superplane/pkg/protos/configuration"
 	pb "github.com/superplanehq/superplane/pkg/protos/factories"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
 )
@@ -143,11 +146,12 @@ func serializeFactoryLine(line *models.FactoryLine) *pb.FactoryLine {
 	}
 
 	return &pb.FactoryLine{
-		Id:        line.ID.String(),
-		Name:      line.Name,
-		Steps:     steps,
-		CreatedAt: timestamppb.New(line.CreatedAt),
-		UpdatedAt: timestamppb.New(line.UpdatedAt),
+		Id:                 line.ID.String(),
+		Name:               line.Name,
+		Steps:              steps,
+		CreatedAt:          timestamppb.New(line.CreatedAt),
+		UpdatedAt:          timestamppb.New(line.UpdatedAt),
+		MonthlyBudgetCents: line.MonthlyBudgetCents,
 	}
 }
 
@@ -185,6 +189,20 @@ func serializeWorkOrder(
 		return nil, err
 	}
 
+	var fields *structpb.Struct
+	if values := order.Fields.Data(); len(values) > 0 {
+		fields, err = structpb.NewStruct(values)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var templateID *string
+	if order.TemplateID != nil {
+		id := order.TemplateID.String()
+		templateID = &id
+	}
+
 	return &pb.WorkOrder{
 		Id:             order.ID.String(),
 		Title:          order.Title,
@@ -201,9 +219,36 @@ func serializeWorkOrder(
 		TotalTokens:    totalTokens,
 		TotalCostCents: totalCostCents,
 		StatusNotes:    statusNotes,
+		BudgetCents:    order.BudgetCents,
+		TemplateId:     templateID,
+		Fields:         fields,
 	}, nil
 }
 
+func serializeWorkOrderTemplates(templates []models.FactoryWorkOrderTemplate) []*pb.WorkOrderTemplate {
+	result := make([]*pb.WorkOrderTemplate, len(templates))
+	for i := range templates {
+		result[i] = serializeWorkOrderTemplate(&templates[i])
+	}
+	return result
+}
+
+func serializeWorkOrderTemplate(template *models.FactoryWorkOrderTemplate) *pb.WorkOrderTemplate {
+	fields := make([]*configpb.Field, len(template.Fields))
+	for i, field := range template.Fields {
+		fields[i] = actions.ConfigurationFieldToProto(field)
+	}
+
+	return &pb.WorkOrderTemplate{
+		Id:          template.ID.String(),
+		Name:        template.Name,
+		Description: template.Description,
+		Fields:      fields,
+		CreatedAt:   timestamppb.New(template.CreatedAt),
+		UpdatedAt:   timestamppb.New(template.UpdatedAt),
+	}
+}
+
 func serializeWorkOrderStatusNotes(order *models.FactoryWorkOrder) ([]*pb.WorkOrderStatusNote, error) {
 	notes, err := order.StatusNotes()
 	if err != nil {
@@ -53,12 +53,25 @@ func UpdateFactoryLine(ctx context.Context, organizationID string, req *pb.Updat
 		}
 	}
 
-	if name == nil && steps == nil {
-		return nil, factoryErrorToStatus(invalidArgument("name or steps must be provided"), "failed to update factory line")
+	updateBudget := req.MonthlyBudgetCents != nil || req.GetClearMonthlyBudget()
+	if req.MonthlyBudgetCents != nil && req.GetClearMonthlyBudget() {
+		return nil, factoryErrorToStatus(invalidArgument("monthly budget cannot be set and cleared at once"), "failed to update factory line")
 	}
 
-	if err := line.Update(db, name, steps); err != nil {
-		return nil, factoryErrorToStatus(err, "failed to update factory line")
+	if name == nil && steps == nil && !updateBudget {
+		return nil, factoryErrorToStatus(invalidArgument("name, steps or monthly budget must be provided"), "failed to update factory line")
+	}
+
+	if name != nil || steps != nil {
+		if err := line.Update(db, name, steps); err != nil {
+			return nil, factoryErrorToStatus(err, "failed to update factory line")
+		}
+	}
+
+	if updateBudget {
+		if err := line.SetMonthlyBudget(db, req.MonthlyBudgetCents); err != nil {
+			return nil, factoryErrorToStatus(err, "failed to update factory line")
+		}
 	}
 
 	return &pb.UpdateFactoryLineResponse{
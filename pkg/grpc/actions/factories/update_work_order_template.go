@@ -0,0 +1,65 @@
+package factories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/factories"
+)
+
+func UpdateWorkOrderTemplate(ctx context.Context, organizationID string, req *pb.UpdateWorkOrderTemplateRequest) (*pb.UpdateWorkOrderTemplateResponse, error) {
+	orgID, err := parseOrganizationID(organizationID)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to update work order template")
+	}
+
+	factoryID, err := parseFactoryID(req.GetFactoryId())
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to update work order template")
+	}
+
+	templateID, err := parseTemplateID(req.GetTemplateId())
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to update work order template")
+	}
+
+	db := database.DB(ctx)
+	factory, err := models.FindFactory(db, orgID, factoryID)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to update work order template")
+	}
+
+	template, err := factory.FindWorkOrderTemplate(db, templateID)
+	if err != nil {
+		return nil, factoryErrorToStatus(err, "failed to update work order template")
+	}
+
+	var name *string
+	if req.Name != nil {
+		trimmed := strings.TrimSpace(req.GetName())
+		if trimmed == "" {
+			return nil, factoryErrorToStatus(invalidArgument("name cannot be empty"), "failed to update work order template")
+		}
+		name = &trimmed
+	}
+
+	var fields []configuration.Field
+	if len(req.GetFields()) > 0 {
+		fields = parseTemplateFields(req.GetFields())
+	}
+
+	if name == nil && req.Description == nil && fields == nil {
+		return nil, factoryErrorToStatus(invalidArgument("name, description or fields must be provided"), "failed to update work order template")
+	}
+
+	if err := template.Update(db, name, req.Description, fields); err != nil {
+		return nil, factoryErrorToStatus(err, "failed to update work order template")
+	}
+
+	return &pb.UpdateWorkOrderTemplateResponse{
+		Template: serializeWorkOrderTemplate(template),
+	}, nil
+}
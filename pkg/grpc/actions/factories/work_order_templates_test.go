@@ -0,0 +1,164 @@
+package factories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	"github.com/superplanehq/superplane/pkg/database"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	configpb "github.com/superplanehq/superplane/pkg/protos/configuration"
+	pb "github.com/superplanehq/superplane/pkg/protos/factories"
+	"github.com/superplanehq/superplane/test/support"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func Test__WorkOrderTemplates(t *testing.T) {
+	r := support.Setup(t)
+	ctx := authentication.SetUserIdInMetadata(context.Background(), r.User.String())
+
+	factoryModel, err := models.CreateFactory(database.DB(t.Context()), r.Organization.ID, support.RandomName("factory"), "", "")
+	require.NoError(t, err)
+
+	low := "low"
+	created, err := CreateWorkOrderTemplate(ctx, r.Organization.ID.String(), &pb.CreateWorkOrderTemplateRequest{
+		FactoryId: factoryModel.ID.String(),
+		Name:      "Bug report",
+		Fields: []*configpb.Field{
+			{Name: "customer", Type: "string", Required: true},
+			{
+				Name:         "severity",
+				Type:         "select",
+				DefaultValue: &low,
+				TypeOptions: &configpb.TypeOptions{
+					Select: &configpb.SelectTypeOptions{
+						Options: []*configpb.SelectOption{
+							{Label: "Low", Value: "low"},
+							{Label: "High", Value: "high"},
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created.Template)
+	assert.Equal(t, "Bug report", created.Template.Name)
+	require.Len(t, created.Template.Fields, 2)
+
+	t.Run("duplicate name -> error", func(t *testing.T) {
+		_, err := CreateWorkOrderTemplate(ctx, r.Organization.ID.String(), &pb.CreateWorkOrderTemplateRequest{
+			FactoryId: factoryModel.ID.String(),
+			Name:      "Bug report",
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.AlreadyExists, grpcerrors.Code(err))
+	})
+
+	t.Run("unsupported field type -> error", func(t *testing.T) {
+		_, err := CreateWorkOrderTemplate(ctx, r.Organization.ID.String(), &pb.CreateWorkOrderTemplateRequest{
+			FactoryId: factoryModel.ID.String(),
+			Name:      "Secrets",
+			Fields:    []*configpb.Field{{Name: "token", Type: "secret-key"}},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcerrors.Code(err))
+	})
+
+	t.Run("creates order with template fields and defaults", func(t *testing.T) {
+		fields, err := structpb.NewStruct(map[string]any{"customer": "acme"})
+		require.NoError(t, err)
+
+		resp, err := CreateWorkOrder(ctx, r.Organization.ID.String(), &pb.CreateWorkOrderRequest{
+			FactoryId:  factoryModel.ID.String(),
+			Title:      "Login broken",
+			TemplateId: &created.Template.Id,
+			Fields:     fields,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp.Order.TemplateId)
+		assert.Equal(t, created.Template.Id, resp.Order.GetTemplateId())
+		assert.Equal(t, "acme", resp.Order.Fields.AsMap()["customer"])
+		assert.Equal(t, "low", resp.Order.Fields.AsMap()["severity"])
+
+		listed, err := ListWorkOrders(ctx, r.Organization.ID.String(), &pb.ListWorkOrdersRequest{
+			FactoryId: factoryModel.ID.String(),
+			Fields:    []string{"customer=acme"},
+		})
+		require.NoError(t, err)
+		require.Len(t, listed.Orders, 1)
+		assert.Equal(t, resp.Order.Id, listed.Orders[0].Id)
+
+		listed, err = ListWorkOrders(ctx, r.Organization.ID.String(), &pb.ListWorkOrdersRequest{
+			FactoryId: factoryModel.ID.String(),
+			Fields:    []string{"customer=globex"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, listed.Orders)
+	})
+
+	t.Run("missing required field -> error", func(t *testing.T) {
+		_, err := CreateWorkOrder(ctx, r.Organization.ID.String(), &pb.CreateWorkOrderRequest{
+			FactoryId:  factoryModel.ID.String(),
+			Title:      "No customer",
+			TemplateId: &created.Template.Id,
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcerrors.Code(err))
+	})
+
+	t.Run("unknown field -> error", func(t *testing.T) {
+		fields, err := structpb.NewStruct(map[string]any{"customer": "acme", "region": "eu"})
+		require.NoError(t, err)
+
+		_, err = CreateWorkOrder(ctx, r.Organization.ID.String(), &pb.CreateWorkOrderRequest{
+			FactoryId:  factoryModel.ID.String(),
+			Title:      "Unknown field",
+			TemplateId: &created.Template.Id,
+			Fields:     fields,
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcerrors.Code(err))
+	})
+
+	t.Run("fields without template -> error", func(t *testing.T) {
+		fields, err := structpb.NewStruct(map[string]any{"customer": "acme"})
+		require.NoError(t, err)
+
+		_, err = CreateWorkOrder(ctx, r.Organization.ID.String(), &pb.CreateWorkOrderRequest{
+			FactoryId: factoryModel.ID.String(),
+			Title:     "No template",
+			Fields:    fields,
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, grpcerrors.Code(err))
+	})
+
+	t.Run("update and delete template", func(t *testing.T) {
+		name := "Incident"
+		updated, err := UpdateWorkOrderTemplate(ctx, r.Organization.ID.String(), &pb.UpdateWorkOrderTemplateRequest{
+			FactoryId:  factoryModel.ID.String(),
+			TemplateId: created.Template.Id,
+			Name:       &name,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Incident", updated.Template.Name)
+		assert.Len(t, updated.Template.Fields, 2)
+
+		_, err = DeleteWorkOrderTemplate(ctx, r.Organization.ID.String(), &pb.DeleteWorkOrderTemplateRequest{
+			FactoryId:  factoryModel.ID.String(),
+			TemplateId: created.Template.Id,
+		})
+		require.NoError(t, err)
+
+		listed, err := ListWorkOrderTemplates(ctx, r.Organization.ID.String(), &pb.ListWorkOrderTemplatesRequest{
+			FactoryId: factoryModel.ID.String(),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, listed.Templates)
+	})
+}
@@ -0,0 +1,62 @@
+package organizations
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/database"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/organizations"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func CreateOrganizationMemory(ctx context.Context, orgID, userID, namespace string, values *structpb.Value) (*pb.CreateOrganizationMemoryResponse, error) {
+	organizationID, err := uuid.Parse(orgID)
+	if err != nil {
+		return nil, grpcerrors.InvalidArgument(err, "invalid organization ID")
+	}
+
+	writerID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, grpcerrors.InvalidArgument(err, "invalid user ID")
+	}
+
+	//
+	// The org: prefix is how canvases refer to organization memory,
+	// so accept it here too, to allow copying namespaces from components.
+	//
+	namespace, _ = core.ParseMemoryNamespace(namespace)
+	if namespace == "" {
+		return nil, grpcerrors.InvalidArgument(nil, "namespace is required")
+	}
+
+	if values == nil {
+		return nil, grpcerrors.InvalidArgument(nil, "values are required")
+	}
+
+	db := database.DB(ctx)
+	record, err := models.AddOrganizationMemoryInTransaction(
+		db,
+		organizationID,
+		namespace,
+		values.AsInterface(),
+		models.OrganizationMemoryWriter{UserID: &writerID},
+	)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to create organization memory")
+	}
+
+	writers, err := findOrganizationMemoryWriters(db, []models.OrganizationMemory{record})
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to find organization memory writers")
+	}
+
+	serialized, err := serializeOrganizationMemory(record, writers)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to serialize organization memory")
+	}
+
+	return &pb.CreateOrganizationMemoryResponse{Memory: serialized}, nil
+}
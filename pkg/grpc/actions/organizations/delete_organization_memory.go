@@ -0,0 +1,40 @@
+package organizations
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/organizations"
+	"gorm.io/gorm"
+)
+
+func DeleteOrganizationMemory(ctx context.Context, orgID, memoryID string) (*pb.DeleteOrganizationMemoryResponse, error) {
+	organizationID, err := uuid.Parse(orgID)
+	if err != nil {
+		return nil, grpcerrors.InvalidArgument(err, "invalid organization ID")
+	}
+
+	id, err := uuid.Parse(memoryID)
+	if err != nil {
+		return nil, grpcerrors.InvalidArgument(err, "invalid memory ID")
+	}
+
+	db := database.DB(ctx)
+	_, err = models.FindOrganizationMemoryInTransaction(db, organizationID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, grpcerrors.NotFound(err, "organization memory not found")
+		}
+		return nil, grpcerrors.Internal(err, "failed to find organization memory")
+	}
+
+	if err := models.DeleteOrganizationMemoryInTransaction(db, organizationID, id); err != nil {
+		return nil, grpcerrors.Internal(err, "failed to delete organization memory")
+	}
+
+	return &pb.DeleteOrganizationMemoryResponse{}, nil
+}
@@ -0,0 +1,137 @@
+package organizations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/organizations"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func ListOrganizationMemories(ctx context.Context, orgID string, namespace string) (*pb.ListOrganizationMemoriesResponse, error) {
+	organizationID, err := uuid.Parse(orgID)
+	if err != nil {
+		return nil, grpcerrors.InvalidArgument(err, "invalid organization ID")
+	}
+
+	db := database.DB(ctx)
+	records, err := models.ListOrganizationMemoriesInTransaction(db, organizationID, strings.TrimSpace(namespace))
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to list organization memory")
+	}
+
+	writers, err := findOrganizationMemoryWriters(db, records)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to find organization memory writers")
+	}
+
+	items := make([]*pb.OrganizationMemory, 0, len(records))
+	for _, record := range records {
+		item, err := serializeOrganizationMemory(record, writers)
+		if err != nil {
+			return nil, grpcerrors.Internal(err, "failed to serialize organization memory")
+		}
+		items = append(items, item)
+	}
+
+	return &pb.ListOrganizationMemoriesResponse{Items: items}, nil
+}
+
+// organizationMemoryWriters holds the names of the canvases and users
+// that wrote organization memory, including deleted ones.
+type organizationMemoryWriters struct {
+	canvases map[uuid.UUID]string
+	users    map[uuid.UUID]string
+}
+
+func (w organizationMemoryWriters) serialize(canvasID, userID *uuid.UUID) *pb.OrganizationMemoryWriter {
+	writer := &pb.OrganizationMemoryWriter{}
+	if canvasID != nil {
+		writer.CanvasId = canvasID.String()
+		writer.CanvasName = w.canvases[*canvasID]
+	}
+
+	if userID != nil {
+		writer.UserId = userID.String()
+		writer.UserName = w.users[*userID]
+	}
+
+	return writer
+}
+
+func findOrganizationMemoryWriters(db *gorm.DB, records []models.OrganizationMemory) (organizationMemoryWriters, error) {
+	canvasIDs := []uuid.UUID{}
+	userIDs := []uuid.UUID{}
+	for _, record := range records {
+		for _, id := range []*uuid.UUID{record.CreatedByCanvasID, record.UpdatedByCanvasID} {
+			if id != nil {
+				canvasIDs = append(canvasIDs, *id)
+			}
+		}
+
+		for _, id := range []*uuid.UUID{record.CreatedByUserID, record.UpdatedByUserID} {
+			if id != nil {
+				userIDs = append(userIDs, *id)
+			}
+		}
+	}
+
+	return findOrganizationMemoryWritersByIDs(db, canvasIDs, userIDs)
+}
+
+func findOrganizationMemoryWritersByIDs(db *gorm.DB, canvasIDs, userIDs []uuid.UUID) (organizationMemoryWriters, error) {
+	writers := organizationMemoryWriters{
+		canvases: map[uuid.UUID]string{},
+		users:    map[uuid.UUID]string{},
+	}
+
+	if len(canvasIDs) > 0 {
+		var canvases []models.Canvas
+		err := db.Unscoped().
+			Select("id, name").
+			Where("id IN ?", canvasIDs).
+			Find(&canvases).
+			Error
+		if err != nil {
+			return writers, err
+		}
+
+		for _, canvas := range canvases {
+			writers.canvases[canvas.ID] = canvas.Name
+		}
+	}
+
+	users, err := models.FindMaybeDeletedUsersByIDs(db, userIDs)
+	if err != nil {
+		return writers, err
+	}
+
+	for _, user := range users {
+		writers.users[user.ID] = user.Name
+	}
+
+	return writers, nil
+}
+
+func serializeOrganizationMemory(record models.OrganizationMemory, writers organizationMemoryWriters) (*pb.OrganizationMemory, error) {
+	values, err := structpb.NewValue(record.Values.Data())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.OrganizationMemory{
+		Id:        record.ID.String(),
+		Namespace: record.Namespace,
+		Values:    values,
+		CreatedBy: writers.serialize(record.CreatedByCanvasID, record.CreatedByUserID),
+		UpdatedBy: writers.serialize(record.UpdatedByCanvasID, record.UpdatedByUserID),
+		CreatedAt: timestamppb.New(record.CreatedAt),
+		UpdatedAt: timestamppb.New(record.UpdatedAt),
+	}, nil
+}
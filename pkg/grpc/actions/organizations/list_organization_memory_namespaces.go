@@ -0,0 +1,52 @@
+package organizations
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	grpcerrors "github.com/superplanehq/superplane/pkg/grpc/errors"
+	"github.com/superplanehq/superplane/pkg/models"
+	pb "github.com/superplanehq/superplane/pkg/protos/organizations"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ListOrganizationMemoryNamespaces(ctx context.Context, orgID string) (*pb.ListOrganizationMemoryNamespacesResponse, error) {
+	organizationID, err := uuid.Parse(orgID)
+	if err != nil {
+		return nil, grpcerrors.InvalidArgument(err, "invalid organization ID")
+	}
+
+	db := database.DB(ctx)
+	namespaces, err := models.ListOrganizationMemoryNamespacesInTransaction(db, organizationID)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to list organization memory namespaces")
+	}
+
+	canvasIDs := []uuid.UUID{}
+	for _, namespace := range namespaces {
+		canvasIDs = append(canvasIDs, namespace.CanvasIDs...)
+	}
+
+	writers, err := findOrganizationMemoryWritersByIDs(db, canvasIDs, nil)
+	if err != nil {
+		return nil, grpcerrors.Internal(err, "failed to find organization memory writers")
+	}
+
+	serialized := make([]*pb.OrganizationMemoryNamespace, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		canvasWriters := make([]*pb.OrganizationMemoryWriter, 0, len(namespace.CanvasIDs))
+		for _, canvasID := range namespace.CanvasIDs {
+			canvasWriters = append(canvasWriters, writers.serialize(&canvasID, nil))
+		}
+
+		serialized = append(serialized, &pb.OrganizationMemoryNamespace{
+			Namespace:     namespace.Namespace,
+			RecordCount:   namespace.Records,
+			LastWrittenAt: timestamppb.New(namespace.LastWrittenAt),
+			Writers:       canvasWriters,
+		})
+	}
+
+	return &pb.ListOrganizationMemoryNamespacesResponse{Namespaces: serialized}, nil
+}
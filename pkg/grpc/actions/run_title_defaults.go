@@ -12,6 +12,7 @@ var defaultRunTitleExpressions = map[string]string{
 	"onBroadcast": "{{ root().data.app.name }}",
 	"onRun":       "App run {{ date(root().timestamp).Format(\"2006-01-02 15:04:05\") }}",
 
+	"argocd.onApplicationStatusChanged": "{{ root().data.name }} {{ root().data.sync.status }} {{ root().data.health.status }}",
 	"aws.cloudwatch.onAlarm":            "{{ root().data.detail.alarmName }} - {{ root().data.detail.previousState.value }} -> {{ root().data.detail.state.value }}",
 	"aws.codeArtifact.onPackageVersion": "{{ root().data.detail.packageName }} {{ root().data.detail.packageVersion }}",
 	"aws.codepipeline.onPipeline":       "{{ root().data.detail.pipeline }}",
@@ -30,6 +31,12 @@ var defaultRunTitleExpressions = map[string]string{
 	"azure.onVirtualMachineRestarted":   "{{ root().data.subject }}",
 	"azure.onVirtualMachineStarted":     "{{ root().data.subject }}",
 	"azure.onVirtualMachineStopped":     "{{ root().data.subject }}",
+	"azuredevops.onBuildCompleted":      "{{ root().data.resource.definition.name }} {{ root().data.resource.buildNumber }} {{ root().data.resource.result }}",
+	"azuredevops.onPullRequest":         "{{ root().data.resource.title }}",
+	"azuredevops.onPush":                "{{ root().data.resource.refUpdates[0].name }}",
+	"bitbucket.onPipelineCompleted":     "{{ root().data.commit_status.name }} {{ root().data.commit_status.state }}",
+	"bitbucket.onPullRequest":           "#{{ root().data.pullrequest.id }} - {{ root().data.pullrequest.title }}",
+	"bitbucket.onPullRequestComment":    "#{{ root().data.pullrequest.id }} - {{ root().data.pullrequest.title }}",
 	"bitbucket.onPush":                  "{{ root().data.push.changes[0].new.name }}",
 	"circleci.onWorkflowCompleted":      "{{ root().data.workflow.name }}",
 
@@ -39,6 +46,7 @@ var defaultRunTitleExpressions = map[string]string{
 	"cloudsmith.onSecurityScanCompleted":    "{{ root().data.name }} {{ root().data.version }} - {{ root().data.security_scan_status }}",
 	"dash0.onAlertNotification":             "{{ root().data.issue.summary }}",
 	"dash0.onSyntheticCheckNotification":    "{{ root().data.issue.summary }}",
+	"datadog.onMonitorAlert":                "{{ root().data.monitor_name }} {{ root().data.alert_transition }}",
 	"dockerhub.onImagePush":                 "{{ root().data.repository.repo_name }}:{{ root().data.push_data.tag }}",
 	"elastic.onAlertFires":                  "{{ root().data.ruleName }}",
 	"elastic.onCaseStatusChange":            "{{ root().data.title }}",
@@ -52,6 +60,12 @@ var defaultRunTitleExpressions = map[string]string{
 	"gcp.monitoring.onAlert":                  "{{ root().data.conditionName }} {{ root().data.state }} - {{ root().data.resourceName }}",
 	"gcp.pubsub.onMessage":                    "{{ root().data.messageId }}",
 
+	"gitea.onIssueComment": "#{{ root().data.issue.number }} - {{ root().data.issue.title }}",
+	"gitea.onPullRequest":  "#{{ root().data.number }} - {{ root().data.pull_request.title }}",
+	"gitea.onPush":         "{{ root().data.head_commit != nil ? root().data.head_commit.message + \" - \" + root().data.head_commit.id[:7] : root().data.ref }}",
+	"gitea.onRelease":      "{{ root().data.release.name }}",
+	"gitea.onTag":          "{{ root().data.ref }}",
+
 	"github.onBranchCreated":   "{{ root().data.ref }}",
 	"github.onCheckRun":        "{{ root().data.check_run.name }} {{ root().data.check_run.conclusion }} - {{ root().data.check_run.head_sha[:7] }}",
 	"github.onCommitStatus":    "{{ root().data.context }} {{ root().data.state }} - {{ root().data.sha[:7] }}",
@@ -83,11 +97,14 @@ var defaultRunTitleExpressions = map[string]string{
 	"harness.onPipelineCompleted":         "{{ root().data.pipelineIdentifier }} {{ root().data.status }}",
 	"honeycomb.onAlertFired":              "{{ root().data.name }}",
 	"incident.onIncident":                 "{{ root().data.incident.name }}",
+	"jenkins.onBuildCompleted":            "{{ root().data.job }} #{{ root().data.number }} {{ root().data.result }}",
 	"jfrogArtifactory.onArtifactUploaded": "{{ root().data.name }} in {{ root().data.repo }}",
 	"jira.onAlert":                        "{{ root().data.alert.tinyId }} - {{ root().data.alert.message }}",
 	"jira.onIncident":                     "{{ root().data.issue.key }} - {{ root().data.issue.fields.summary }}",
 	"jira.onIssue":                        "{{ root().data.issue.key }} - {{ root().data.issue.fields.summary }}",
 	"jira.onIssueComment":                 "{{ root().data.issue.key }} - {{ root().data.issue.fields.summary }}",
+	"kubernetes.onPodCrashLoop":           "{{ root().data.pod }} {{ root().data.container }} crash looping",
+	"kubernetes.onRolloutFailed":          "{{ root().data.name }} rollout failed",
 	"launchdarkly.onFeatureFlagChange":    "{{ root().data.name }}",
 	"linear.onIssue":                      "{{ root().data.data.identifier }} - {{ root().data.data.title }}",
 	"linear.onIssueComment":               "{{ root().data.data.issue.identifier }} - {{ root().data.data.issue.title }}",
@@ -98,6 +115,8 @@ var defaultRunTitleExpressions = map[string]string{
 	"oci.onComputeInstanceCreated":        "{{ root().data.data.resourceName }}",
 	"oci.onInstanceStateChange":           "{{ root().data.data.resourceName }}",
 	"octopus.onDeploymentEvent":           "{{ root().data.message }}",
+	"opsgenie.onAlert":                    "{{ root().data.alert.tinyId }} - {{ root().data.alert.message }}",
+	"opsgenie.onAlertStatusChanged":       "{{ root().data.alert.tinyId }} {{ root().data.action }} - {{ root().data.alert.message }}",
 	"pagerduty.onIncident":                "{{ root().data.incident.title }}",
 	"pagerduty.onIncidentAnnotated":       "{{ root().data.incident.title }}",
 	"pagerduty.onIncidentStatusUpdate":    "{{ root().data.status_update.message }}",
@@ -113,4 +132,6 @@ var defaultRunTitleExpressions = map[string]string{
 	"teams.onMention":                     "{{ root().data.text }}",
 	"teams.onMessage":                     "{{ root().data.text }}",
 	"telegram.onMention":                  "{{ root().data.text }}",
+	"terraform.onRunCompleted":            "{{ root().data.workspace.name }} {{ root().data.run.status }}",
+	"terraform.onRunNeedsAttention":       "{{ root().data.workspace.name }} {{ root().data.run.message }}",
 }
@@ -41,3 +41,19 @@ func (s *CanvasFolderService) DeleteCanvasFolder(ctx context.Context, req *pb.De
 	organizationID := ctx.Value(authorization.OrganizationContextKey).(string)
 	return canvasfolders.DeleteCanvasFolder(ctx, organizationID, req.Id)
 }
+
+func (s *CanvasFolderService) GetCanvasFolderReviewPolicy(
+	ctx context.Context,
+	req *pb.GetCanvasFolderReviewPolicyRequest,
+) (*pb.GetCanvasFolderReviewPolicyResponse, error) {
+	organizationID := ctx.Value(authorization.OrganizationContextKey).(string)
+	return canvasfolders.GetCanvasFolderReviewPolicy(ctx, organizationID, req.Id)
+}
+
+func (s *CanvasFolderService) UpdateCanvasFolderReviewPolicy(
+	ctx context.Context,
+	req *pb.UpdateCanvasFolderReviewPolicyRequest,
+) (*pb.UpdateCanvasFolderReviewPolicyResponse, error) {
+	organizationID := ctx.Value(authorization.OrganizationContextKey).(string)
+	return canvasfolders.UpdateCanvasFolderReviewPolicy(ctx, organizationID, req.Id, req.RequiredApprovals, req.ReviewerIds)
+}
@@ -153,6 +153,143 @@ func (s *CanvasService) UpdateCanvasPreference(
 	return canvases.UpdateCanvasPreference(ctx, db, canvas, userID, req)
 }
 
+func (s *CanvasService) GetCanvasReviewPolicy(ctx context.Context, req *pb.GetCanvasReviewPolicyRequest) (*pb.GetCanvasReviewPolicyResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.GetCanvasReviewPolicy(ctx, db, canvas)
+}
+
+func (s *CanvasService) UpdateCanvasReviewPolicy(ctx context.Context, req *pb.UpdateCanvasReviewPolicyRequest) (*pb.UpdateCanvasReviewPolicyResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.UpdateCanvasReviewPolicy(ctx, db, canvas, req.RequiredApprovals, req.ReviewerIds)
+}
+
+func (s *CanvasService) ListCanvasChangeRequests(ctx context.Context, req *pb.ListCanvasChangeRequestsRequest) (*pb.ListCanvasChangeRequestsResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.ListCanvasChangeRequests(ctx, db, canvas, req.State, req.Limit)
+}
+
+func (s *CanvasService) CreateCanvasChangeRequest(ctx context.Context, req *pb.CreateCanvasChangeRequestRequest) (*pb.CreateCanvasChangeRequestResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.CreateCanvasChangeRequest(ctx, db, canvas, req.Title, req.Description)
+}
+
+func (s *CanvasService) DescribeCanvasChangeRequest(ctx context.Context, req *pb.DescribeCanvasChangeRequestRequest) (*pb.DescribeCanvasChangeRequestResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.DescribeCanvasChangeRequest(ctx, db, s.registry, canvas, req.ChangeRequestId)
+}
+
+func (s *CanvasService) UpdateCanvasChangeRequest(ctx context.Context, req *pb.UpdateCanvasChangeRequestRequest) (*pb.UpdateCanvasChangeRequestResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.UpdateCanvasChangeRequest(ctx, db, canvas, req.ChangeRequestId, req.Spec, req.ResubmitStaging)
+}
+
+func (s *CanvasService) ReviewCanvasChangeRequest(ctx context.Context, req *pb.ReviewCanvasChangeRequestRequest) (*pb.ReviewCanvasChangeRequestResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.ReviewCanvasChangeRequest(ctx, db, canvas, req.ChangeRequestId, req.Decision, req.Comment)
+}
+
+func (s *CanvasService) AddCanvasChangeRequestComment(ctx context.Context, req *pb.AddCanvasChangeRequestCommentRequest) (*pb.AddCanvasChangeRequestCommentResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.AddCanvasChangeRequestComment(ctx, db, canvas, req.ChangeRequestId, req.Body)
+}
+
+func (s *CanvasService) MergeCanvasChangeRequest(ctx context.Context, req *pb.MergeCanvasChangeRequestRequest) (*pb.MergeCanvasChangeRequestResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.MergeCanvasChangeRequest(
+		ctx,
+		db,
+		s.gitProvider,
+		s.usageService,
+		s.encryptor,
+		s.registry,
+		canvas,
+		req.ChangeRequestId,
+		s.webhookBaseURL,
+		s.authService,
+	)
+}
+
+func (s *CanvasService) CloseCanvasChangeRequest(ctx context.Context, req *pb.CloseCanvasChangeRequestRequest) (*pb.CloseCanvasChangeRequestResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.CloseCanvasChangeRequest(ctx, db, canvas, req.ChangeRequestId, req.Comment)
+}
+
+func (s *CanvasService) GetCanvasGitMirror(ctx context.Context, req *pb.GetCanvasGitMirrorRequest) (*pb.GetCanvasGitMirrorResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.GetCanvasGitMirror(ctx, db, canvas)
+}
+
+func (s *CanvasService) UpdateCanvasGitMirror(ctx context.Context, req *pb.UpdateCanvasGitMirrorRequest) (*pb.UpdateCanvasGitMirrorResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.UpdateCanvasGitMirror(ctx, db, s.registry, canvas, req.Spec)
+}
+
+func (s *CanvasService) DeleteCanvasGitMirror(ctx context.Context, req *pb.DeleteCanvasGitMirrorRequest) (*pb.DeleteCanvasGitMirrorResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.DeleteCanvasGitMirror(ctx, db, canvas)
+}
+
+func (s *CanvasService) SyncCanvasGitMirror(ctx context.Context, req *pb.SyncCanvasGitMirrorRequest) (*pb.SyncCanvasGitMirrorResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.SyncCanvasGitMirror(ctx, db, canvas, req.Resolution)
+}
+
 func (s *CanvasService) ListCanvasVersions(ctx context.Context, req *pb.ListCanvasVersionsRequest) (*pb.ListCanvasVersionsResponse, error) {
 	db := database.DB(ctx)
 	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
@@ -365,6 +502,24 @@ func (s *CanvasService) UpdateCanvasMemoryNamespace(ctx context.Context, req *pb
 	return canvases.UpdateCanvasMemoryNamespace(ctx, db, canvas, req.Namespace, req.NewNamespace, req.Entries)
 }
 
+func (s *CanvasService) GetCanvasMemoryNamespaceSettings(ctx context.Context, req *pb.GetCanvasMemoryNamespaceSettingsRequest) (*pb.GetCanvasMemoryNamespaceSettingsResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.GetCanvasMemoryNamespaceSettings(ctx, db, canvas, req.Namespace)
+}
+
+func (s *CanvasService) UpdateCanvasMemoryNamespaceSettings(ctx context.Context, req *pb.UpdateCanvasMemoryNamespaceSettingsRequest) (*pb.UpdateCanvasMemoryNamespaceSettingsResponse, error) {
+	db := database.DB(ctx)
+	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
+	if err != nil {
+		return nil, err
+	}
+	return canvases.UpdateCanvasMemoryNamespaceSettings(ctx, db, canvas, req.Namespace, req.Schema, req.DefaultTtlSeconds, req.IndexedKeys)
+}
+
 func (s *CanvasService) ListEventExecutions(ctx context.Context, req *pb.ListEventExecutionsRequest) (*pb.ListEventExecutionsResponse, error) {
 	db := database.DB(ctx)
 	canvas, err := s.findCanvas(ctx, db, req.CanvasId)
@@ -59,6 +59,26 @@ func (s *FactoryService) UpdateFactoryLine(ctx context.Context, req *pb.UpdateFa
 	return actions.UpdateFactoryLine(ctx, organizationID, req)
 }
 
+func (s *FactoryService) ListWorkOrderTemplates(ctx context.Context, req *pb.ListWorkOrderTemplatesRequest) (*pb.ListWorkOrderTemplatesResponse, error) {
+	organizationID := ctx.Value(authorization.OrganizationContextKey).(string)
+	return actions.ListWorkOrderTemplates(ctx, organizationID, req)
+}
+
+func (s *FactoryService) CreateWorkOrderTemplate(ctx context.Context, req *pb.CreateWorkOrderTemplateRequest) (*pb.CreateWorkOrderTemplateResponse, error) {
+	organizationID := ctx.Value(authorization.OrganizationContextKey).(string)
+	return actions.CreateWorkOrderTemplate(ctx, organizationID, req)
+}
+
+func (s *FactoryService) UpdateWorkOrderTemplate(ctx context.Context, req *pb.UpdateWorkOrderTemplateRequest) (*pb.UpdateWorkOrderTemplateResponse, error) {
+	organizationID := ctx.Value(authorization.OrganizationContextKey).(string)
+	return actions.UpdateWorkOrderTemplate(ctx, organizationID, req)
+}
+
+func (s *FactoryService) DeleteWorkOrderTemplate(ctx context.Context, req *pb.DeleteWorkOrderTemplateRequest) (*pb.DeleteWorkOrderTemplateResponse, error) {
+	organizationID := ctx.Value(authorization.OrganizationContextKey).(string)
+	return actions.DeleteWorkOrderTemplate(ctx, organizationID, req)
+}
+
 func (s *FactoryService) ListFactoryApps(ctx context.Context, req *pb.ListFactoryAppsRequest) (*pb.ListFactoryAppsResponse, error) {
 	organizationID := ctx.Value(authorization.OrganizationContextKey).(string)
 	return actions.ListFactoryApps(ctx, organizationID, req)
@@ -197,6 +197,31 @@ func accountIDFromContext(ctx context.Context) (string, error) {
 	return accountMeta[0], nil
 }
 
+func (s *OrganizationService) ListOrganizationMemories(ctx context.Context, req *pb.ListOrganizationMemoriesRequest) (*pb.ListOrganizationMemoriesResponse, error) {
+	orgID := ctx.Value(authorization.DomainIdContextKey).(string)
+	return organizations.ListOrganizationMemories(ctx, orgID, req.Namespace)
+}
+
+func (s *OrganizationService) ListOrganizationMemoryNamespaces(ctx context.Context, req *pb.ListOrganizationMemoryNamespacesRequest) (*pb.ListOrganizationMemoryNamespacesResponse, error) {
+	orgID := ctx.Value(authorization.DomainIdContextKey).(string)
+	return organizations.ListOrganizationMemoryNamespaces(ctx, orgID)
+}
+
+func (s *OrganizationService) CreateOrganizationMemory(ctx context.Context, req *pb.CreateOrganizationMemoryRequest) (*pb.CreateOrganizationMemoryResponse, error) {
+	orgID := ctx.Value(authorization.DomainIdContextKey).(string)
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return organizations.CreateOrganizationMemory(ctx, orgID, userID, req.Namespace, req.Values)
+}
+
+func (s *OrganizationService) DeleteOrganizationMemory(ctx context.Context, req *pb.DeleteOrganizationMemoryRequest) (*pb.DeleteOrganizationMemoryResponse, error) {
+	orgID := ctx.Value(authorization.DomainIdContextKey).(string)
+	return organizations.DeleteOrganizationMemory(ctx, orgID, req.MemoryId)
+}
+
 func userIDFromContext(ctx context.Context) (string, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -0,0 +1,191 @@
+package argocd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const (
+	ResourceTypeApplication = "application"
+)
+
+func init() {
+	registry.RegisterIntegrationWithWebhookHandler("argocd", &ArgoCD{}, &ArgoCDWebhookHandler{})
+}
+
+type ArgoCD struct{}
+
+type Configuration struct {
+	ServerURL     string `json:"serverUrl" mapstructure:"serverUrl"`
+	AuthToken     string `json:"authToken" mapstructure:"authToken"`
+	WebhookSecret string `json:"webhookSecret,omitempty" mapstructure:"webhookSecret"`
+}
+
+type Metadata struct {
+	Username string `json:"username,omitempty" mapstructure:"username"`
+}
+
+func (a *ArgoCD) Name() string {
+	return "argocd"
+}
+
+func (a *ArgoCD) Label() string {
+	return "Argo CD"
+}
+
+func (a *ArgoCD) Icon() string {
+	return "argocd"
+}
+
+func (a *ArgoCD) Description() string {
+	return "Sync, roll back and watch Argo CD applications"
+}
+
+func (a *ArgoCD) Instructions() string {
+	return `
+1. **Server URL:** URL of the Argo CD API server (e.g. ` + "`https://argocd.example.com`" + `).
+2. **Auth Token:** Create an account with the ` + "`apiKey`" + ` capability in ` + "`argocd-cm`" + `, give it access in ` + "`argocd-rbac-cm`" + `, and generate a token for it:
+   - ` + "`argocd account generate-token --account superplane`" + `
+   - The account needs ` + "`get`" + `, ` + "`sync`" + ` and ` + "`update`" + ` on the applications SuperPlane manages.
+3. **Webhook Secret** (recommended): If set, Argo CD notifications must send ` + "`Authorization: Bearer <secret>`" + ` to SuperPlane.
+4. **Notifications:** The On Application Status Changed trigger receives Argo CD notifications. Argo CD notifications are configured in the ` + "`argocd-notifications-cm`" + ` ConfigMap, so SuperPlane can't create them by API. The trigger setup panel shows the exact snippet to add.`
+}
+
+func (a *ArgoCD) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "serverUrl",
+			Label:       "Server URL",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "https://argocd.example.com",
+			Description: "Argo CD API server URL",
+		},
+		{
+			Name:        "authToken",
+			Label:       "Auth Token",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "Argo CD account token",
+		},
+		{
+			Name:        "webhookSecret",
+			Label:       "Webhook Secret",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Sensitive:   true,
+			Description: "Secret required by incoming Argo CD notifications. Recommended for production environments.",
+		},
+	}
+}
+
+func (a *ArgoCD) Actions() []core.Action {
+	return []core.Action{
+		&SyncApplication{},
+		&WaitForApplication{},
+		&RollbackApplication{},
+		&GetApplication{},
+		&SetParameter{},
+	}
+}
+
+func (a *ArgoCD) Triggers() []core.Trigger {
+	return []core.Trigger{
+		&OnApplicationStatusChanged{},
+	}
+}
+
+func (a *ArgoCD) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (a *ArgoCD) Sync(ctx core.SyncContext) error {
+	config := Configuration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(config.ServerURL) == "" {
+		return fmt.Errorf("serverUrl is required")
+	}
+
+	if strings.TrimSpace(config.AuthToken) == "" {
+		return fmt.Errorf("authToken is required")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	userInfo, err := client.GetUserInfo()
+	if err != nil {
+		return fmt.Errorf("failed to verify Argo CD credentials: %w", err)
+	}
+
+	if !userInfo.LoggedIn {
+		return fmt.Errorf("failed to verify Argo CD credentials: token is not valid")
+	}
+
+	if _, err := client.ListApplications(); err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	ctx.Integration.SetMetadata(Metadata{Username: userInfo.Username})
+	ctx.Integration.Ready()
+	return nil
+}
+
+func (a *ArgoCD) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (a *ArgoCD) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	if resourceType != ResourceTypeApplication {
+		return []core.IntegrationResource{}, nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	applications, err := client.ListApplications()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(applications))
+	for _, application := range applications {
+		if application.Metadata.Name == "" {
+			continue
+		}
+
+		//
+		// The namespace is part of the ID, so applications
+		// outside of the control plane namespace work too.
+		//
+		ref := ApplicationRef{Namespace: application.Metadata.Namespace, Name: application.Metadata.Name}
+		resources = append(resources, core.IntegrationResource{
+			Type: ResourceTypeApplication,
+			Name: application.Metadata.Name,
+			ID:   ref.String(),
+		})
+	}
+
+	return resources, nil
+}
+
+func (a *ArgoCD) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (a *ArgoCD) HandleHook(ctx core.IntegrationHookContext) error {
+	return nil
+}
@@ -0,0 +1,115 @@
+package argocd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ArgoCD__Sync(t *testing.T) {
+	integration := &ArgoCD{}
+
+	t.Run("valid token -> ready", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"loggedIn":true,"username":"superplane","iss":"argocd"}`),
+				jsonResponse(http.StatusOK, `{"items":[{"metadata":{"name":"guestbook","namespace":"argocd"}}]}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://argocd.example.com/api/v1/session/userinfo", httpContext.Requests[0].URL.String())
+		assert.Equal(t, "Bearer token-123", httpContext.Requests[0].Header.Get("Authorization"))
+		assert.Equal(t, "/api/v1/applications", httpContext.Requests[1].URL.Path)
+		assert.Equal(t, Metadata{Username: "superplane"}, integrationCtx.Metadata)
+	})
+
+	t.Run("token not logged in -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"loggedIn":false}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "token is not valid")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+	})
+
+	t.Run("unauthorized -> error with API message", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusUnauthorized, `{"error":"invalid session","code":16,"message":"invalid session: token is expired"}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "request failed with 401: invalid session: token is expired")
+	})
+}
+
+func Test__ArgoCD__ListResources(t *testing.T) {
+	httpContext := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"items":[
+				{"metadata":{"name":"guestbook","namespace":"argocd"}},
+				{"metadata":{"name":"payments","namespace":"team-a"}}
+			]}`),
+		},
+	}
+
+	resources, err := (&ArgoCD{}).ListResources(ResourceTypeApplication, core.ListResourcesContext{
+		HTTP:        httpContext,
+		Integration: testIntegration(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []core.IntegrationResource{
+		{Type: ResourceTypeApplication, Name: "guestbook", ID: "argocd/guestbook"},
+		{Type: ResourceTypeApplication, Name: "payments", ID: "team-a/payments"},
+	}, resources)
+}
+
+func Test__ParseApplicationRef(t *testing.T) {
+	ref, err := ParseApplicationRef(" guestbook ")
+	require.NoError(t, err)
+	assert.Equal(t, ApplicationRef{Name: "guestbook"}, ref)
+	assert.Nil(t, ref.query())
+
+	ref, err = ParseApplicationRef("team-a/payments")
+	require.NoError(t, err)
+	assert.Equal(t, ApplicationRef{Namespace: "team-a", Name: "payments"}, ref)
+	assert.Equal(t, "team-a", ref.query().Get("appNamespace"))
+	assert.Equal(t, "/api/v1/applications/payments/sync", ref.path("/sync"))
+
+	_, err = ParseApplicationRef("")
+	require.ErrorContains(t, err, "application is required")
+
+	_, err = ParseApplicationRef("a/b/c")
+	require.ErrorContains(t, err, "expected name or namespace/name")
+}
@@ -0,0 +1,381 @@
+package argocd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type Client struct {
+	ServerURL string
+	Token     string
+	http      core.HTTPContext
+}
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with %d: %s", e.StatusCode, e.Body)
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+type UserInfo struct {
+	LoggedIn bool   `json:"loggedIn"`
+	Username string `json:"username"`
+	Issuer   string `json:"iss"`
+}
+
+type Application struct {
+	Metadata ApplicationMetadata `json:"metadata"`
+	Spec     ApplicationSpec     `json:"spec"`
+	Status   ApplicationStatus   `json:"status"`
+
+	//
+	// Operation is set while an operation waits
+	// for the application controller to pick it up.
+	//
+	Operation map[string]any `json:"operation,omitempty"`
+}
+
+type ApplicationMetadata struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+type ApplicationSpec struct {
+	Project     string                 `json:"project"`
+	Source      *ApplicationSource     `json:"source,omitempty"`
+	Sources     []ApplicationSource    `json:"sources,omitempty"`
+	Destination ApplicationDestination `json:"destination"`
+	SyncPolicy  *struct {
+		Automated *struct {
+			Prune    bool `json:"prune"`
+			SelfHeal bool `json:"selfHeal"`
+		} `json:"automated,omitempty"`
+	} `json:"syncPolicy,omitempty"`
+}
+
+type ApplicationSource struct {
+	RepoURL        string `json:"repoURL"`
+	Path           string `json:"path,omitempty"`
+	Chart          string `json:"chart,omitempty"`
+	TargetRevision string `json:"targetRevision,omitempty"`
+}
+
+type ApplicationDestination struct {
+	Server    string `json:"server,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type ApplicationStatus struct {
+	Sync struct {
+		Status    string   `json:"status"`
+		Revision  string   `json:"revision,omitempty"`
+		Revisions []string `json:"revisions,omitempty"`
+	} `json:"sync"`
+	Health struct {
+		Status  string `json:"status"`
+		Message string `json:"message,omitempty"`
+	} `json:"health"`
+	OperationState *OperationState        `json:"operationState,omitempty"`
+	History        []RevisionHistory      `json:"history,omitempty"`
+	Conditions     []ApplicationCondition `json:"conditions,omitempty"`
+	ReconciledAt   string                 `json:"reconciledAt,omitempty"`
+}
+
+type OperationState struct {
+	Phase      string `json:"phase"`
+	Message    string `json:"message,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+	SyncResult *struct {
+		Revision string `json:"revision,omitempty"`
+	} `json:"syncResult,omitempty"`
+}
+
+type RevisionHistory struct {
+	ID         int64  `json:"id"`
+	Revision   string `json:"revision,omitempty"`
+	DeployedAt string `json:"deployedAt,omitempty"`
+}
+
+type ApplicationCondition struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// SyncRequest is the body of the sync endpoint.
+type SyncRequest struct {
+	AppNamespace string `json:"appNamespace,omitempty"`
+	Revision     string `json:"revision,omitempty"`
+	Prune        bool   `json:"prune"`
+	DryRun       bool   `json:"dryRun"`
+}
+
+// RollbackRequest is the body of the rollback endpoint.
+type RollbackRequest struct {
+	AppNamespace string `json:"appNamespace,omitempty"`
+	ID           int64  `json:"id"`
+	Prune        bool   `json:"prune"`
+	DryRun       bool   `json:"dryRun"`
+}
+
+// ApplicationRef points to an application. Namespace is only
+// set for applications outside of the Argo CD control plane namespace.
+type ApplicationRef struct {
+	Namespace string
+	Name      string
+}
+
+// ParseApplicationRef accepts an application as "name",
+// or as "namespace/name", like the argocd CLI does.
+func ParseApplicationRef(value string) (ApplicationRef, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ApplicationRef{}, fmt.Errorf("application is required")
+	}
+
+	namespace, name, found := strings.Cut(value, "/")
+	if !found {
+		return ApplicationRef{Name: value}, nil
+	}
+
+	namespace = strings.TrimSpace(namespace)
+	name = strings.TrimSpace(name)
+	if namespace == "" || name == "" || strings.Contains(name, "/") {
+		return ApplicationRef{}, fmt.Errorf("invalid application %q, expected name or namespace/name", value)
+	}
+
+	return ApplicationRef{Namespace: namespace, Name: name}, nil
+}
+
+func (r ApplicationRef) String() string {
+	if r.Namespace == "" {
+		return r.Name
+	}
+
+	return r.Namespace + "/" + r.Name
+}
+
+func (r ApplicationRef) query() url.Values {
+	if r.Namespace == "" {
+		return nil
+	}
+
+	return url.Values{"appNamespace": []string{r.Namespace}}
+}
+
+func (r ApplicationRef) path(suffix string) string {
+	return "/api/v1/applications/" + url.PathEscape(r.Name) + suffix
+}
+
+func NewClient(httpClient core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("no integration context")
+	}
+
+	serverURL, err := ctx.GetConfig("serverUrl")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedURL := strings.TrimRight(strings.TrimSpace(string(serverURL)), "/")
+	if trimmedURL == "" {
+		return nil, fmt.Errorf("serverUrl is required")
+	}
+
+	token, err := ctx.GetConfig("authToken")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedToken := strings.TrimSpace(string(token))
+	if trimmedToken == "" {
+		return nil, fmt.Errorf("authToken is required")
+	}
+
+	return &Client{
+		ServerURL: trimmedURL,
+		Token:     trimmedToken,
+		http:      httpClient,
+	}, nil
+}
+
+func (c *Client) GetUserInfo() (UserInfo, error) {
+	_, body, err := c.execRequest(http.MethodGet, "/api/v1/session/userinfo", nil, nil)
+	if err != nil {
+		return UserInfo{}, err
+	}
+
+	var userInfo UserInfo
+	if err := json.Unmarshal(body, &userInfo); err != nil {
+		return UserInfo{}, fmt.Errorf("failed to unmarshal user info response: %w", err)
+	}
+
+	return userInfo, nil
+}
+
+func (c *Client) ListApplications() ([]Application, error) {
+	query := url.Values{}
+	query.Set("fields", "items.metadata.name,items.metadata.namespace,items.spec.project")
+
+	_, body, err := c.execRequest(http.MethodGet, "/api/v1/applications", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Items []Application `json:"items"`
+	}
+
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal applications response: %w", err)
+	}
+
+	return list.Items, nil
+}
+
+func (c *Client) GetApplication(ref ApplicationRef) (*Application, error) {
+	_, body, err := c.execRequest(http.MethodGet, ref.path(""), ref.query(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeApplication(body)
+}
+
+// GetApplicationObject returns the application as it comes from the API,
+// so it can be changed and sent back without losing fields.
+func (c *Client) GetApplicationObject(ref ApplicationRef) (map[string]any, error) {
+	_, body, err := c.execRequest(http.MethodGet, ref.path(""), ref.query(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal application response: %w", err)
+	}
+
+	return object, nil
+}
+
+func (c *Client) UpdateApplicationSpec(ref ApplicationRef, spec map[string]any) error {
+	_, _, err := c.execRequest(http.MethodPut, ref.path("/spec"), ref.query(), spec)
+	return err
+}
+
+func (c *Client) SyncApplication(ref ApplicationRef, request SyncRequest) (*Application, error) {
+	request.AppNamespace = ref.Namespace
+	_, body, err := c.execRequest(http.MethodPost, ref.path("/sync"), nil, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeApplication(body)
+}
+
+func (c *Client) RollbackApplication(ref ApplicationRef, request RollbackRequest) (*Application, error) {
+	request.AppNamespace = ref.Namespace
+	_, body, err := c.execRequest(http.MethodPost, ref.path("/rollback"), nil, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeApplication(body)
+}
+
+func decodeApplication(body []byte) (*Application, error) {
+	var application Application
+	if err := json.Unmarshal(body, &application); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal application response: %w", err)
+	}
+
+	return &application, nil
+}
+
+func (c *Client) execRequest(
+	method string,
+	path string,
+	query url.Values,
+	payload any,
+) (*http.Response, []byte, error) {
+	endpoint := c.ServerURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		encodedBody, err := json.Marshal(payload)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(encodedBody)
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return nil, nil, &APIError{StatusCode: res.StatusCode, Body: apiErrorMessage(responseBody)}
+	}
+
+	return res, responseBody, nil
+}
+
+// apiErrorMessage pulls the message out of the gRPC gateway
+// error body, which is what the API returns on errors.
+func apiErrorMessage(body []byte) string {
+	var apiError struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &apiError); err == nil {
+		if apiError.Message != "" {
+			return apiError.Message
+		}
+
+		if apiError.Error != "" {
+			return apiError.Error
+		}
+	}
+
+	return strings.TrimSpace(string(body))
+}
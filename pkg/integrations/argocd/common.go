@@ -0,0 +1,150 @@
+package argocd
+
+import (
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+const (
+	SuccessOutputChannel = "success"
+	FailedOutputChannel  = "failed"
+
+	HealthStatusHealthy     = "Healthy"
+	HealthStatusProgressing = "Progressing"
+	HealthStatusDegraded    = "Degraded"
+	HealthStatusSuspended   = "Suspended"
+	HealthStatusMissing     = "Missing"
+	HealthStatusUnknown     = "Unknown"
+
+	SyncStatusSynced    = "Synced"
+	SyncStatusOutOfSync = "OutOfSync"
+	SyncStatusUnknown   = "Unknown"
+
+	OperationPhaseRunning     = "Running"
+	OperationPhaseTerminating = "Terminating"
+	OperationPhaseSucceeded   = "Succeeded"
+	OperationPhaseFailed      = "Failed"
+	OperationPhaseError       = "Error"
+
+	maxHistoryEntries = 10
+)
+
+func applicationField() configuration.Field {
+	return configuration.Field{
+		Name:        "application",
+		Label:       "Application",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    true,
+		Description: "Argo CD application, as name or namespace/name",
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type: ResourceTypeApplication,
+			},
+		},
+	}
+}
+
+// source returns the only source of an application,
+// or nil for applications with several sources.
+func (s *ApplicationSpec) source() *ApplicationSource {
+	if s.Source != nil {
+		return s.Source
+	}
+
+	if len(s.Sources) == 1 {
+		return &s.Sources[0]
+	}
+
+	return nil
+}
+
+func operationFailed(phase string) bool {
+	return phase == OperationPhaseFailed || phase == OperationPhaseError
+}
+
+func operationRunning(phase string) bool {
+	return phase == OperationPhaseRunning || phase == OperationPhaseTerminating
+}
+
+func applicationPayload(application *Application) map[string]any {
+	payload := map[string]any{
+		"name":      application.Metadata.Name,
+		"namespace": application.Metadata.Namespace,
+		"project":   application.Spec.Project,
+		"destination": map[string]any{
+			"server":    application.Spec.Destination.Server,
+			"name":      application.Spec.Destination.Name,
+			"namespace": application.Spec.Destination.Namespace,
+		},
+		"sync": map[string]any{
+			"status":   application.Status.Sync.Status,
+			"revision": application.Status.Sync.Revision,
+		},
+		"health": map[string]any{
+			"status":  application.Status.Health.Status,
+			"message": application.Status.Health.Message,
+		},
+		"autoSync": application.Spec.SyncPolicy != nil && application.Spec.SyncPolicy.Automated != nil,
+	}
+
+	if source := application.Spec.source(); source != nil {
+		payload["source"] = map[string]any{
+			"repoURL":        source.RepoURL,
+			"path":           source.Path,
+			"chart":          source.Chart,
+			"targetRevision": source.TargetRevision,
+		}
+	}
+
+	if operation := application.Status.OperationState; operation != nil {
+		payload["operation"] = operationPayload(operation)
+	}
+
+	//
+	// History is oldest first, and the API keeps up to
+	// spec.revisionHistoryLimit entries, so only the latest ones are kept.
+	//
+	history := application.Status.History
+	if len(history) > maxHistoryEntries {
+		history = history[len(history)-maxHistoryEntries:]
+	}
+
+	entries := make([]any, 0, len(history))
+	for _, entry := range history {
+		entries = append(entries, map[string]any{
+			"id":         entry.ID,
+			"revision":   entry.Revision,
+			"deployedAt": entry.DeployedAt,
+		})
+	}
+	payload["history"] = entries
+
+	conditions := make([]any, 0, len(application.Status.Conditions))
+	for _, condition := range application.Status.Conditions {
+		conditions = append(conditions, map[string]any{
+			"type":    condition.Type,
+			"message": condition.Message,
+		})
+	}
+	payload["conditions"] = conditions
+
+	return payload
+}
+
+func operationPayload(operation *OperationState) map[string]any {
+	payload := map[string]any{
+		"phase":      operation.Phase,
+		"message":    operation.Message,
+		"startedAt":  operation.StartedAt,
+		"finishedAt": operation.FinishedAt,
+	}
+
+	if operation.SyncResult != nil {
+		payload["revision"] = operation.SyncResult.Revision
+	}
+
+	return payload
+}
+
+func intPtr(value int) *int {
+	return &value
+}
@@ -0,0 +1,92 @@
+package argocd
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_data_on_application_status_changed.json
+var exampleDataOnApplicationStatusChangedBytes []byte
+
+//go:embed example_output_sync_application.json
+var exampleOutputSyncApplicationBytes []byte
+
+//go:embed example_output_wait_for_application.json
+var exampleOutputWaitForApplicationBytes []byte
+
+//go:embed example_output_rollback_application.json
+var exampleOutputRollbackApplicationBytes []byte
+
+//go:embed example_output_get_application.json
+var exampleOutputGetApplicationBytes []byte
+
+//go:embed example_output_set_parameter.json
+var exampleOutputSetParameterBytes []byte
+
+var exampleDataOnApplicationStatusChangedOnce sync.Once
+var exampleDataOnApplicationStatusChanged map[string]any
+
+var exampleOutputSyncApplicationOnce sync.Once
+var exampleOutputSyncApplication map[string]any
+
+var exampleOutputWaitForApplicationOnce sync.Once
+var exampleOutputWaitForApplication map[string]any
+
+var exampleOutputRollbackApplicationOnce sync.Once
+var exampleOutputRollbackApplication map[string]any
+
+var exampleOutputGetApplicationOnce sync.Once
+var exampleOutputGetApplication map[string]any
+
+var exampleOutputSetParameterOnce sync.Once
+var exampleOutputSetParameter map[string]any
+
+func (t *OnApplicationStatusChanged) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnApplicationStatusChangedOnce,
+		exampleDataOnApplicationStatusChangedBytes,
+		&exampleDataOnApplicationStatusChanged,
+	)
+}
+
+func (s *SyncApplication) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputSyncApplicationOnce,
+		exampleOutputSyncApplicationBytes,
+		&exampleOutputSyncApplication,
+	)
+}
+
+func (w *WaitForApplication) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputWaitForApplicationOnce,
+		exampleOutputWaitForApplicationBytes,
+		&exampleOutputWaitForApplication,
+	)
+}
+
+func (r *RollbackApplication) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputRollbackApplicationOnce,
+		exampleOutputRollbackApplicationBytes,
+		&exampleOutputRollbackApplication,
+	)
+}
+
+func (g *GetApplication) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputGetApplicationOnce,
+		exampleOutputGetApplicationBytes,
+		&exampleOutputGetApplication,
+	)
+}
+
+func (s *SetParameter) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputSetParameterOnce,
+		exampleOutputSetParameterBytes,
+		&exampleOutputSetParameter,
+	)
+}
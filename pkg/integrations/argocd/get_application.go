@@ -0,0 +1,116 @@
+package argocd
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const GetApplicationPayloadType = "argocd.application"
+
+type GetApplication struct{}
+
+type GetApplicationSpec struct {
+	Application string `json:"application" mapstructure:"application"`
+}
+
+func (g *GetApplication) Name() string {
+	return "argocd.getApplication"
+}
+
+func (g *GetApplication) Label() string {
+	return "Get Application"
+}
+
+func (g *GetApplication) Description() string {
+	return "Get the status and history of an Argo CD application"
+}
+
+func (g *GetApplication) Documentation() string {
+	return `The Get Application component reads an Argo CD application.
+
+## Use Cases
+
+- **Rollbacks**: Find the history ID of the previous deployment
+- **Conditional steps**: Branch on the health or sync status of an application
+- **Reporting**: Post the deployed revision of an application
+
+## Configuration
+
+- **Application**: The Argo CD application to read
+
+## Output
+
+The project, source, destination, sync and health statuses, latest operation, conditions, and the latest 10 deployments of the history of the application, oldest first.`
+}
+
+func (g *GetApplication) Icon() string {
+	return "argocd"
+}
+
+func (g *GetApplication) Color() string {
+	return "orange"
+}
+
+func (g *GetApplication) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (g *GetApplication) Configuration() []configuration.Field {
+	return []configuration.Field{applicationField()}
+}
+
+func (g *GetApplication) Setup(ctx core.SetupContext) error {
+	_, err := decodeGetApplicationSpec(ctx.Configuration)
+	return err
+}
+
+func (g *GetApplication) Execute(ctx core.ExecutionContext) error {
+	ref, err := decodeGetApplicationSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	application, err := client.GetApplication(ref)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get application %s: %v", ref, err))
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, GetApplicationPayloadType, []any{applicationPayload(application)})
+}
+
+func (g *GetApplication) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (g *GetApplication) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (g *GetApplication) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (g *GetApplication) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (g *GetApplication) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeGetApplicationSpec(value any) (ApplicationRef, error) {
+	spec := GetApplicationSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return ApplicationRef{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	return ParseApplicationRef(spec.Application)
+}
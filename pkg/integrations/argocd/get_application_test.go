@@ -0,0 +1,75 @@
+package argocd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GetApplication__Setup(t *testing.T) {
+	component := &GetApplication{}
+
+	t.Run("invalid application -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"application": "team-a/"}})
+		require.ErrorContains(t, err, "invalid application")
+	})
+}
+
+func Test__GetApplication__Execute(t *testing.T) {
+	component := &GetApplication{}
+
+	t.Run("application in another namespace -> emits application", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{
+					"metadata":{"name":"payments","namespace":"team-a"},
+					"spec":{"project":"default","destination":{"server":"https://kubernetes.default.svc","namespace":"payments"},"syncPolicy":{"automated":{"prune":true}}},
+					"status":{"sync":{"status":"Synced","revision":"8f2c1d0"},"health":{"status":"Healthy"}}
+				}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"application": "team-a/payments"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		request := httpContext.Requests[0]
+		assert.Equal(t, http.MethodGet, request.Method)
+		assert.Equal(t, "/api/v1/applications/payments", request.URL.Path)
+		assert.Equal(t, "team-a", request.URL.Query().Get("appNamespace"))
+
+		assert.Equal(t, GetApplicationPayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "Synced", payload["sync"].(map[string]any)["status"])
+		assert.Equal(t, "Healthy", payload["health"].(map[string]any)["status"])
+		assert.Equal(t, true, payload["autoSync"])
+	})
+
+	t.Run("missing application -> fails", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"application": "guestbook"},
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					jsonResponse(http.StatusNotFound, `{"error":"applications.argoproj.io \"guestbook\" not found","code":5,"message":"applications.argoproj.io \"guestbook\" not found"}`),
+				},
+			},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to get application guestbook")
+	})
+}
@@ -0,0 +1,346 @@
+package argocd
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const OnApplicationStatusChangedPayloadType = "argocd.application.statusChanged"
+
+type OnApplicationStatusChanged struct{}
+
+type OnApplicationStatusChangedConfiguration struct {
+	Applications    []string `json:"applications" mapstructure:"applications"`
+	HealthStatuses  []string `json:"healthStatuses" mapstructure:"healthStatuses"`
+	SyncStatuses    []string `json:"syncStatuses" mapstructure:"syncStatuses"`
+	OperationPhases []string `json:"operationPhases" mapstructure:"operationPhases"`
+}
+
+type OnApplicationStatusChangedMetadata struct {
+	WebhookURL         string `json:"webhookUrl" mapstructure:"webhookUrl"`
+	WebhookAuthEnabled bool   `json:"webhookAuthEnabled,omitempty" mapstructure:"webhookAuthEnabled"`
+}
+
+// NotificationPayload is the body sent by the superplane
+// template of the Argo CD notifications ConfigMap.
+type NotificationPayload struct {
+	Application      string `json:"application"`
+	Namespace        string `json:"namespace"`
+	Project          string `json:"project"`
+	SyncStatus       string `json:"syncStatus"`
+	Revision         string `json:"revision"`
+	HealthStatus     string `json:"healthStatus"`
+	OperationPhase   string `json:"operationPhase"`
+	OperationMessage string `json:"operationMessage"`
+	FinishedAt       string `json:"finishedAt"`
+	URL              string `json:"url"`
+}
+
+func (t *OnApplicationStatusChanged) Name() string {
+	return "argocd.onApplicationStatusChanged"
+}
+
+func (t *OnApplicationStatusChanged) Label() string {
+	return "On Application Status Changed"
+}
+
+func (t *OnApplicationStatusChanged) Description() string {
+	return "Listen to sync, health and operation changes of Argo CD applications"
+}
+
+func (t *OnApplicationStatusChanged) Documentation() string {
+	return `The On Application Status Changed trigger starts a workflow when Argo CD notifications report a change of an application.
+
+## Use Cases
+
+- **Post-deploy automation**: Run smoke tests once a sync succeeds and the application is healthy
+- **Automatic rollbacks**: Roll back when an application becomes Degraded
+- **Alerting**: Notify the owning team when a sync fails
+
+## Configuration
+
+- **Applications**: Only fire for these applications. Leave empty for every application.
+- **Health statuses**: Only fire for these health statuses
+- **Sync statuses**: Only fire for these sync statuses
+- **Operation phases**: Only fire for these phases of the latest sync operation
+
+Empty filters match everything.
+
+## Argo CD setup (manual)
+
+Argo CD notifications are configured in the ` + "`argocd-notifications-cm`" + ` ConfigMap, so SuperPlane can't create them by API. When the node is saved, SuperPlane generates a webhook URL, and the trigger setup panel shows the snippet to add to the ConfigMap. The snippet adds:
+
+- A ` + "`superplane`" + ` webhook service, pointing to the webhook URL
+- A ` + "`superplane`" + ` template, with the body SuperPlane expects
+- An ` + "`on-superplane`" + ` trigger, which fires when a sync starts, succeeds or fails, and when an application becomes Healthy, Degraded or OutOfSync
+- A default subscription of every application to the trigger
+
+If the integration has a **Webhook Secret**, store it as ` + "`superplane-webhook-secret`" + ` in the ` + "`argocd-notifications-secret`" + ` Secret.`
+}
+
+func (t *OnApplicationStatusChanged) Icon() string {
+	return "argocd"
+}
+
+func (t *OnApplicationStatusChanged) Color() string {
+	return "orange"
+}
+
+func (t *OnApplicationStatusChanged) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "applications",
+			Label:    "Applications",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Application",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+			Description: "Only fire for these applications",
+		},
+		{
+			Name:        "healthStatuses",
+			Label:       "Health statuses",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    false,
+			Description: "Only fire for these health statuses",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Healthy", Value: HealthStatusHealthy},
+						{Label: "Progressing", Value: HealthStatusProgressing},
+						{Label: "Degraded", Value: HealthStatusDegraded},
+						{Label: "Suspended", Value: HealthStatusSuspended},
+						{Label: "Missing", Value: HealthStatusMissing},
+						{Label: "Unknown", Value: HealthStatusUnknown},
+					},
+				},
+			},
+		},
+		{
+			Name:        "syncStatuses",
+			Label:       "Sync statuses",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    false,
+			Description: "Only fire for these sync statuses",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Synced", Value: SyncStatusSynced},
+						{Label: "OutOfSync", Value: SyncStatusOutOfSync},
+						{Label: "Unknown", Value: SyncStatusUnknown},
+					},
+				},
+			},
+		},
+		{
+			Name:        "operationPhases",
+			Label:       "Operation phases",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    false,
+			Description: "Only fire for these phases of the latest sync operation",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Running", Value: OperationPhaseRunning},
+						{Label: "Succeeded", Value: OperationPhaseSucceeded},
+						{Label: "Failed", Value: OperationPhaseFailed},
+						{Label: "Error", Value: OperationPhaseError},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *OnApplicationStatusChanged) Setup(ctx core.TriggerContext) error {
+	if _, err := decodeOnApplicationStatusChangedConfiguration(ctx.Configuration); err != nil {
+		return err
+	}
+
+	if ctx.Integration == nil {
+		return fmt.Errorf("missing integration context")
+	}
+
+	if err := ctx.Integration.RequestWebhook(struct{}{}); err != nil {
+		return err
+	}
+
+	if ctx.Webhook == nil {
+		return fmt.Errorf("missing webhook context")
+	}
+
+	webhookURL, err := ctx.Webhook.Setup()
+	if err != nil {
+		return fmt.Errorf("failed to setup webhook URL: %w", err)
+	}
+
+	webhookSecret, err := optionalIntegrationConfig(ctx.Integration, "webhookSecret")
+	if err != nil {
+		return err
+	}
+
+	if ctx.Metadata == nil {
+		return nil
+	}
+
+	return ctx.Metadata.Set(OnApplicationStatusChangedMetadata{
+		WebhookURL:         webhookURL,
+		WebhookAuthEnabled: webhookSecret != "",
+	})
+}
+
+func (t *OnApplicationStatusChanged) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (t *OnApplicationStatusChanged) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnApplicationStatusChanged) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	config, err := decodeOnApplicationStatusChangedConfiguration(ctx.Configuration)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	webhookSecret, err := optionalIntegrationConfig(ctx.Integration, "webhookSecret")
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to read webhook secret: %w", err)
+	}
+
+	if webhookSecret != "" {
+		token, found := strings.CutPrefix(ctx.Headers.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(webhookSecret)) != 1 {
+			return http.StatusForbidden, nil, fmt.Errorf("invalid authorization")
+		}
+	}
+
+	notification := NotificationPayload{}
+	if err := json.Unmarshal(ctx.Body, &notification); err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("failed to parse request body: %w", err)
+	}
+
+	if notification.Application == "" {
+		return http.StatusBadRequest, nil, fmt.Errorf("missing application")
+	}
+
+	if !config.matches(notification) {
+		return http.StatusOK, nil, nil
+	}
+
+	if err := ctx.Events.Emit(OnApplicationStatusChangedPayloadType, notificationEventPayload(notification)); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to emit event: %w", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnApplicationStatusChanged) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+func decodeOnApplicationStatusChangedConfiguration(value any) (OnApplicationStatusChangedConfiguration, error) {
+	config := OnApplicationStatusChangedConfiguration{}
+	if err := mapstructure.Decode(value, &config); err != nil {
+		return OnApplicationStatusChangedConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Applications = nonEmpty(config.Applications)
+	config.HealthStatuses = nonEmpty(config.HealthStatuses)
+	config.SyncStatuses = nonEmpty(config.SyncStatuses)
+	config.OperationPhases = nonEmpty(config.OperationPhases)
+	return config, nil
+}
+
+func (c OnApplicationStatusChangedConfiguration) matches(notification NotificationPayload) bool {
+	if len(c.Applications) > 0 {
+		ref := ApplicationRef{Namespace: notification.Namespace, Name: notification.Application}
+		if !slices.Contains(c.Applications, notification.Application) && !slices.Contains(c.Applications, ref.String()) {
+			return false
+		}
+	}
+
+	if len(c.HealthStatuses) > 0 && !slices.Contains(c.HealthStatuses, notification.HealthStatus) {
+		return false
+	}
+
+	if len(c.SyncStatuses) > 0 && !slices.Contains(c.SyncStatuses, notification.SyncStatus) {
+		return false
+	}
+
+	if len(c.OperationPhases) > 0 && !slices.Contains(c.OperationPhases, notification.OperationPhase) {
+		return false
+	}
+
+	return true
+}
+
+func notificationEventPayload(notification NotificationPayload) map[string]any {
+	payload := map[string]any{
+		"name":      notification.Application,
+		"namespace": notification.Namespace,
+		"project":   notification.Project,
+		"sync": map[string]any{
+			"status":   notification.SyncStatus,
+			"revision": notification.Revision,
+		},
+		"health": map[string]any{
+			"status": notification.HealthStatus,
+		},
+		"url": notification.URL,
+	}
+
+	if notification.OperationPhase != "" {
+		payload["operation"] = map[string]any{
+			"phase":      notification.OperationPhase,
+			"message":    notification.OperationMessage,
+			"finishedAt": notification.FinishedAt,
+		}
+	}
+
+	return payload
+}
+
+func nonEmpty(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+func optionalIntegrationConfig(integration core.IntegrationContext, name string) (string, error) {
+	if integration == nil {
+		return "", nil
+	}
+
+	value, err := integration.GetConfig(name)
+	if err != nil {
+		message := strings.ToLower(err.Error())
+		if strings.Contains(message, strings.ToLower(name)) && strings.Contains(message, "not found") {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(string(value)), nil
+}
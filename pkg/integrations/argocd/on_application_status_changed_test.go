@@ -0,0 +1,119 @@
+package argocd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const degradedNotification = `{
+	"application": "guestbook",
+	"namespace": "argocd",
+	"project": "default",
+	"syncStatus": "Synced",
+	"revision": "4f1c3a2",
+	"healthStatus": "Degraded",
+	"operationPhase": "Succeeded",
+	"operationMessage": "successfully synced",
+	"finishedAt": "2026-10-19T10:02:40Z",
+	"url": "https://argocd.example.com/applications/guestbook"
+}`
+
+func Test__OnApplicationStatusChanged__Setup(t *testing.T) {
+	integrationCtx := testIntegration()
+	integrationCtx.Configuration["webhookSecret"] = "secret-123"
+	metadata := &contexts.MetadataContext{}
+
+	err := (&OnApplicationStatusChanged{}).Setup(core.TriggerContext{
+		Configuration: map[string]any{"healthStatuses": []string{HealthStatusDegraded}},
+		Integration:   integrationCtx,
+		Metadata:      metadata,
+		Webhook:       &contexts.NodeWebhookContext{},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, integrationCtx.WebhookRequests, 1)
+	stored := metadata.Metadata.(OnApplicationStatusChangedMetadata)
+	assert.NotEmpty(t, stored.WebhookURL)
+	assert.True(t, stored.WebhookAuthEnabled)
+}
+
+func Test__OnApplicationStatusChanged__HandleWebhook(t *testing.T) {
+	trigger := &OnApplicationStatusChanged{}
+
+	handle := func(configuration map[string]any, integration *contexts.IntegrationContext, authorization string, body string) (int, *contexts.EventContext, error) {
+		events := &contexts.EventContext{}
+		headers := http.Header{}
+		if authorization != "" {
+			headers.Set("Authorization", authorization)
+		}
+
+		code, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          []byte(body),
+			Headers:       headers,
+			Configuration: configuration,
+			Integration:   integration,
+			Events:        events,
+		})
+
+		return code, events, err
+	}
+
+	t.Run("matching notification -> emits event", func(t *testing.T) {
+		code, events, err := handle(map[string]any{
+			"applications":   []string{"argocd/guestbook"},
+			"healthStatuses": []string{HealthStatusDegraded},
+		}, testIntegration(), "", degradedNotification)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, OnApplicationStatusChangedPayloadType, events.Payloads[0].Type)
+
+		data := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "guestbook", data["name"])
+		assert.Equal(t, "Degraded", data["health"].(map[string]any)["status"])
+		assert.Equal(t, "Succeeded", data["operation"].(map[string]any)["phase"])
+	})
+
+	t.Run("filtered out -> no event", func(t *testing.T) {
+		code, events, err := handle(map[string]any{
+			"operationPhases": []string{OperationPhaseFailed, OperationPhaseError},
+		}, testIntegration(), "", degradedNotification)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("other application -> no event", func(t *testing.T) {
+		_, events, err := handle(map[string]any{"applications": []string{"payments"}}, testIntegration(), "", degradedNotification)
+		require.NoError(t, err)
+		assert.Equal(t, 0, events.Count())
+	})
+
+	t.Run("webhook secret", func(t *testing.T) {
+		integrationCtx := testIntegration()
+		integrationCtx.Configuration["webhookSecret"] = "secret-123"
+
+		code, events, err := handle(map[string]any{}, integrationCtx, "Bearer wrong", degradedNotification)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Equal(t, 0, events.Count())
+
+		code, events, err = handle(map[string]any{}, integrationCtx, "Bearer secret-123", degradedNotification)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("invalid body -> bad request", func(t *testing.T) {
+		code, _, err := handle(map[string]any{}, testIntegration(), "", `{"syncStatus":"Synced"}`)
+		require.ErrorContains(t, err, "missing application")
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
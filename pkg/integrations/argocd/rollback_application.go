@@ -0,0 +1,172 @@
+package argocd
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const RollbackApplicationPayloadType = "argocd.application.rollback"
+
+type RollbackApplication struct{}
+
+type RollbackApplicationSpec struct {
+	Application string `json:"application" mapstructure:"application"`
+	HistoryID   int64  `json:"historyId" mapstructure:"historyId"`
+	Prune       bool   `json:"prune" mapstructure:"prune"`
+	DryRun      bool   `json:"dryRun" mapstructure:"dryRun"`
+}
+
+func (r *RollbackApplication) Name() string {
+	return "argocd.rollbackApplication"
+}
+
+func (r *RollbackApplication) Label() string {
+	return "Rollback Application"
+}
+
+func (r *RollbackApplication) Description() string {
+	return "Roll back an Argo CD application to a previous deployment"
+}
+
+func (r *RollbackApplication) Documentation() string {
+	return `The Rollback Application component rolls an Argo CD application back to a deployment of its history, like ` + "`argocd app rollback`" + ` does.
+
+## Use Cases
+
+- **Automatic rollbacks**: Roll back when a sync fails or the application becomes Degraded
+- **Incident response**: Return to the last known good revision from an incident workflow
+
+## Configuration
+
+- **Application**: The Argo CD application to roll back
+- **History ID**: ID of the deployment to roll back to. The **Get Application** component outputs the history of an application, with the ID of each deployment.
+- **Prune**: Delete resources that are not in the deployment rolled back to
+- **Dry run**: Preview the rollback without changing the cluster
+
+Argo CD only rolls back applications without automated sync, since automated sync would immediately sync the application again.
+
+## Output
+
+The application, with the rollback operation that was started.`
+}
+
+func (r *RollbackApplication) Icon() string {
+	return "argocd"
+}
+
+func (r *RollbackApplication) Color() string {
+	return "orange"
+}
+
+func (r *RollbackApplication) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (r *RollbackApplication) Configuration() []configuration.Field {
+	return []configuration.Field{
+		applicationField(),
+		{
+			Name:        "historyId",
+			Label:       "History ID",
+			Type:        configuration.FieldTypeNumber,
+			Required:    true,
+			Description: "ID of the deployment in the application history",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(0)},
+			},
+		},
+		{
+			Name:        "prune",
+			Label:       "Prune",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Delete resources that are not in the deployment rolled back to",
+		},
+		{
+			Name:        "dryRun",
+			Label:       "Dry run",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Preview the rollback without changing the cluster",
+		},
+	}
+}
+
+func (r *RollbackApplication) Setup(ctx core.SetupContext) error {
+	_, _, err := decodeRollbackApplicationSpec(ctx.Configuration)
+	return err
+}
+
+func (r *RollbackApplication) Execute(ctx core.ExecutionContext) error {
+	spec, ref, err := decodeRollbackApplicationSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	application, err := client.RollbackApplication(ref, RollbackRequest{
+		ID:     spec.HistoryID,
+		Prune:  spec.Prune,
+		DryRun: spec.DryRun,
+	})
+
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to roll back application %s to %d: %v", ref, spec.HistoryID, err))
+	}
+
+	payload := applicationPayload(application)
+	payload["request"] = map[string]any{
+		"historyId": spec.HistoryID,
+		"prune":     spec.Prune,
+		"dryRun":    spec.DryRun,
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, RollbackApplicationPayloadType, []any{payload})
+}
+
+func (r *RollbackApplication) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (r *RollbackApplication) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (r *RollbackApplication) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (r *RollbackApplication) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (r *RollbackApplication) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeRollbackApplicationSpec(value any) (RollbackApplicationSpec, ApplicationRef, error) {
+	spec := RollbackApplicationSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return RollbackApplicationSpec{}, ApplicationRef{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := ParseApplicationRef(spec.Application)
+	if err != nil {
+		return RollbackApplicationSpec{}, ApplicationRef{}, err
+	}
+
+	if spec.HistoryID < 0 {
+		return RollbackApplicationSpec{}, ApplicationRef{}, fmt.Errorf("history ID must not be negative")
+	}
+
+	return spec, ref, nil
+}
@@ -0,0 +1,86 @@
+package argocd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RollbackApplication__Setup(t *testing.T) {
+	component := &RollbackApplication{}
+
+	t.Run("missing application -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"historyId": 3}})
+		require.ErrorContains(t, err, "application is required")
+	})
+
+	t.Run("negative history ID -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"application": "guestbook", "historyId": "-1"}})
+		require.ErrorContains(t, err, "history ID must not be negative")
+	})
+}
+
+func Test__RollbackApplication__Execute(t *testing.T) {
+	component := &RollbackApplication{}
+
+	t.Run("rollback started -> emits application and request", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{
+					"metadata":{"name":"payments","namespace":"team-a"},
+					"spec":{"project":"default"},
+					"status":{"sync":{"status":"OutOfSync"},"health":{"status":"Progressing"},"operationState":{"phase":"Running"}}
+				}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"application": "team-a/payments", "historyId": "3", "prune": true},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		request := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/api/v1/applications/payments/rollback", request.URL.Path)
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		sent := RollbackRequest{}
+		require.NoError(t, json.Unmarshal(body, &sent))
+		assert.Equal(t, RollbackRequest{AppNamespace: "team-a", ID: 3, Prune: true}, sent)
+
+		assert.Equal(t, RollbackApplicationPayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "Running", payload["operation"].(map[string]any)["phase"])
+		assert.Equal(t, map[string]any{"historyId": int64(3), "prune": true, "dryRun": false}, payload["request"])
+	})
+
+	t.Run("auto-sync enabled -> fails", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"application": "guestbook", "historyId": 3},
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					jsonResponse(http.StatusBadRequest, `{"error":"rollback cannot be initiated when auto-sync is enabled","code":9,"message":"rollback cannot be initiated when auto-sync is enabled"}`),
+				},
+			},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Equal(t, "failed to roll back application guestbook to 3: request failed with 400: rollback cannot be initiated when auto-sync is enabled", executionState.FailureMessage)
+	})
+}
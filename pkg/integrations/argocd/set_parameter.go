@@ -0,0 +1,261 @@
+package argocd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const SetParameterPayloadType = "argocd.application.parameters"
+
+type SetParameter struct{}
+
+type SetParameterSpec struct {
+	Application string              `json:"application" mapstructure:"application"`
+	Parameters  []ParameterOverride `json:"parameters" mapstructure:"parameters"`
+}
+
+type ParameterOverride struct {
+	Name  string `json:"name" mapstructure:"name"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+func (s *SetParameter) Name() string {
+	return "argocd.setParameter"
+}
+
+func (s *SetParameter) Label() string {
+	return "Set Parameter Override"
+}
+
+func (s *SetParameter) Description() string {
+	return "Override Helm parameters of an Argo CD application"
+}
+
+func (s *SetParameter) Documentation() string {
+	return `The Set Parameter Override component overrides Helm parameters of an Argo CD application, like ` + "`argocd app set --helm-set`" + ` does.
+
+## Use Cases
+
+- **Image promotions**: Set the image tag of an application to the version CI just built
+- **Feature toggles**: Flip chart values from a workflow
+- **Preview environments**: Point an application at the branch under review
+
+## Configuration
+
+- **Application**: The Argo CD application to change
+- **Parameters**: Helm parameters to set, like ` + "`image.tag`" + `. Parameters that are already overridden get the new value; other overrides are kept.
+
+Only applications with a single source are supported. The parameters are set on the application spec, so a sync is needed to deploy them, unless the application syncs automatically.
+
+## Output
+
+The parameters that were set, every Helm parameter of the application after the change, and whether the spec changed.`
+}
+
+func (s *SetParameter) Icon() string {
+	return "argocd"
+}
+
+func (s *SetParameter) Color() string {
+	return "orange"
+}
+
+func (s *SetParameter) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (s *SetParameter) Configuration() []configuration.Field {
+	return []configuration.Field{
+		applicationField(),
+		{
+			Name:     "parameters",
+			Label:    "Parameters",
+			Type:     configuration.FieldTypeList,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Parameter",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:        "name",
+								Label:       "Name",
+								Type:        configuration.FieldTypeString,
+								Required:    true,
+								Placeholder: "image.tag",
+							},
+							{
+								Name:     "value",
+								Label:    "Value",
+								Type:     configuration.FieldTypeString,
+								Required: false,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *SetParameter) Setup(ctx core.SetupContext) error {
+	_, _, err := decodeSetParameterSpec(ctx.Configuration)
+	return err
+}
+
+func (s *SetParameter) Execute(ctx core.ExecutionContext) error {
+	spec, ref, err := decodeSetParameterSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	application, err := client.GetApplicationObject(ref)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get application %s: %v", ref, err))
+	}
+
+	applicationSpec, _ := application["spec"].(map[string]any)
+	if applicationSpec == nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("application %s has no spec", ref))
+	}
+
+	parameters, changed, err := SetHelmParameters(applicationSpec, spec.Parameters)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to set parameters of application %s: %v", ref, err))
+	}
+
+	if changed {
+		if err := client.UpdateApplicationSpec(ref, applicationSpec); err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to update application %s: %v", ref, err))
+		}
+	}
+
+	set := make([]any, 0, len(spec.Parameters))
+	for _, parameter := range spec.Parameters {
+		set = append(set, map[string]any{"name": parameter.Name, "value": parameter.Value})
+	}
+
+	payload := map[string]any{
+		"application":    ref.String(),
+		"parameters":     set,
+		"helmParameters": parameters,
+		"changed":        changed,
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, SetParameterPayloadType, []any{payload})
+}
+
+func (s *SetParameter) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (s *SetParameter) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (s *SetParameter) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (s *SetParameter) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (s *SetParameter) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeSetParameterSpec(value any) (SetParameterSpec, ApplicationRef, error) {
+	spec := SetParameterSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return SetParameterSpec{}, ApplicationRef{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := ParseApplicationRef(spec.Application)
+	if err != nil {
+		return SetParameterSpec{}, ApplicationRef{}, err
+	}
+
+	if len(spec.Parameters) == 0 {
+		return SetParameterSpec{}, ApplicationRef{}, fmt.Errorf("at least one parameter is required")
+	}
+
+	for i := range spec.Parameters {
+		spec.Parameters[i].Name = strings.TrimSpace(spec.Parameters[i].Name)
+		if spec.Parameters[i].Name == "" {
+			return SetParameterSpec{}, ApplicationRef{}, fmt.Errorf("parameter %d: name is required", i+1)
+		}
+	}
+
+	return spec, ref, nil
+}
+
+// SetHelmParameters upserts Helm parameters in the source of an application spec,
+// keeping the other fields of the spec as they are. It returns every
+// Helm parameter of the source, and whether any of them changed.
+func SetHelmParameters(spec map[string]any, overrides []ParameterOverride) ([]any, bool, error) {
+	source, err := singleSource(spec)
+	if err != nil {
+		return nil, false, err
+	}
+
+	helm, _ := source["helm"].(map[string]any)
+	if helm == nil {
+		helm = map[string]any{}
+		source["helm"] = helm
+	}
+
+	parameters, _ := helm["parameters"].([]any)
+	changed := false
+	for _, override := range overrides {
+		found := false
+		for _, item := range parameters {
+			parameter, ok := item.(map[string]any)
+			if !ok || parameter["name"] != override.Name {
+				continue
+			}
+
+			found = true
+			if parameter["value"] != override.Value {
+				parameter["value"] = override.Value
+				changed = true
+			}
+		}
+
+		if !found {
+			parameters = append(parameters, map[string]any{"name": override.Name, "value": override.Value})
+			changed = true
+		}
+	}
+
+	helm["parameters"] = parameters
+	return parameters, changed, nil
+}
+
+func singleSource(spec map[string]any) (map[string]any, error) {
+	if source, ok := spec["source"].(map[string]any); ok {
+		return source, nil
+	}
+
+	sources, _ := spec["sources"].([]any)
+	if len(sources) != 1 {
+		return nil, fmt.Errorf("only applications with a single source are supported")
+	}
+
+	source, ok := sources[0].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid application source")
+	}
+
+	return source, nil
+}
@@ -0,0 +1,108 @@
+package argocd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__SetHelmParameters(t *testing.T) {
+	t.Run("updates and appends parameters", func(t *testing.T) {
+		spec := map[string]any{
+			"project": "default",
+			"source": map[string]any{
+				"repoURL": "https://charts.example.com",
+				"chart":   "payments",
+				"helm": map[string]any{
+					"releaseName": "payments",
+					"parameters": []any{
+						map[string]any{"name": "replicaCount", "value": "3"},
+						map[string]any{"name": "image.tag", "value": "v1.4.1", "forceString": true},
+					},
+				},
+			},
+		}
+
+		parameters, changed, err := SetHelmParameters(spec, []ParameterOverride{
+			{Name: "image.tag", Value: "v1.4.2"},
+			{Name: "ingress.enabled", Value: "true"},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, []any{
+			map[string]any{"name": "replicaCount", "value": "3"},
+			map[string]any{"name": "image.tag", "value": "v1.4.2", "forceString": true},
+			map[string]any{"name": "ingress.enabled", "value": "true"},
+		}, parameters)
+
+		helm := spec["source"].(map[string]any)["helm"].(map[string]any)
+		assert.Equal(t, "payments", helm["releaseName"])
+		assert.Equal(t, parameters, helm["parameters"])
+	})
+
+	t.Run("same values -> not changed", func(t *testing.T) {
+		spec := map[string]any{
+			"sources": []any{
+				map[string]any{"helm": map[string]any{"parameters": []any{map[string]any{"name": "image.tag", "value": "v1"}}}},
+			},
+		}
+
+		_, changed, err := SetHelmParameters(spec, []ParameterOverride{{Name: "image.tag", Value: "v1"}})
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("several sources -> error", func(t *testing.T) {
+		spec := map[string]any{"sources": []any{map[string]any{}, map[string]any{}}}
+		_, _, err := SetHelmParameters(spec, []ParameterOverride{{Name: "image.tag", Value: "v1"}})
+		require.ErrorContains(t, err, "only applications with a single source are supported")
+	})
+}
+
+func Test__SetParameter__Execute(t *testing.T) {
+	httpContext := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{
+				"metadata":{"name":"payments","namespace":"argocd"},
+				"spec":{"project":"default","source":{"repoURL":"https://github.com/example/gitops.git","path":"charts/payments"},"syncPolicy":{"syncOptions":["CreateNamespace=true"]}}
+			}`),
+			jsonResponse(http.StatusOK, `{}`),
+		},
+	}
+
+	executionState := &contexts.ExecutionStateContext{}
+	err := (&SetParameter{}).Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"application": "payments",
+			"parameters": []any{
+				map[string]any{"name": " image.tag ", "value": "v1.4.2"},
+			},
+		},
+		HTTP:           httpContext,
+		Integration:    testIntegration(),
+		ExecutionState: executionState,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, httpContext.Requests, 2)
+	update := httpContext.Requests[1]
+	assert.Equal(t, http.MethodPut, update.Method)
+	assert.Equal(t, "/api/v1/applications/payments/spec", update.URL.Path)
+
+	body, err := io.ReadAll(update.Body)
+	require.NoError(t, err)
+	sent := map[string]any{}
+	require.NoError(t, json.Unmarshal(body, &sent))
+	assert.Equal(t, []any{"CreateNamespace=true"}, sent["syncPolicy"].(map[string]any)["syncOptions"])
+	assert.Equal(t, []any{map[string]any{"name": "image.tag", "value": "v1.4.2"}}, sent["source"].(map[string]any)["helm"].(map[string]any)["parameters"])
+
+	payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, true, payload["changed"])
+}
@@ -0,0 +1,167 @@
+package argocd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const SyncApplicationPayloadType = "argocd.application.sync"
+
+type SyncApplication struct{}
+
+type SyncApplicationSpec struct {
+	Application string `json:"application" mapstructure:"application"`
+	Revision    string `json:"revision" mapstructure:"revision"`
+	Prune       bool   `json:"prune" mapstructure:"prune"`
+	DryRun      bool   `json:"dryRun" mapstructure:"dryRun"`
+}
+
+func (s *SyncApplication) Name() string {
+	return "argocd.syncApplication"
+}
+
+func (s *SyncApplication) Label() string {
+	return "Sync Application"
+}
+
+func (s *SyncApplication) Description() string {
+	return "Start a sync of an Argo CD application"
+}
+
+func (s *SyncApplication) Documentation() string {
+	return `The Sync Application component starts a sync of an Argo CD application, like ` + "`argocd app sync`" + ` does.
+
+## Use Cases
+
+- **Deployments**: Deploy a new revision once CI publishes it
+- **Manual sync policies**: Sync applications that don't sync automatically after an approval
+- **Previews**: Dry run a sync to check what would change
+
+## Configuration
+
+- **Application**: The Argo CD application to sync
+- **Revision**: Git revision, tag or Helm chart version to sync to. Defaults to the target revision of the application.
+- **Prune**: Delete resources that are no longer in the source
+- **Dry run**: Preview the sync without changing the cluster
+
+## Output
+
+The application, with the sync operation that was started. The component does not wait for the sync to finish; use **Wait for Application** after it.`
+}
+
+func (s *SyncApplication) Icon() string {
+	return "argocd"
+}
+
+func (s *SyncApplication) Color() string {
+	return "orange"
+}
+
+func (s *SyncApplication) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (s *SyncApplication) Configuration() []configuration.Field {
+	return []configuration.Field{
+		applicationField(),
+		{
+			Name:        "revision",
+			Label:       "Revision",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "main",
+			Description: "Revision to sync to. Defaults to the target revision of the application.",
+		},
+		{
+			Name:        "prune",
+			Label:       "Prune",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Delete resources that are no longer in the source",
+		},
+		{
+			Name:        "dryRun",
+			Label:       "Dry run",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Preview the sync without changing the cluster",
+		},
+	}
+}
+
+func (s *SyncApplication) Setup(ctx core.SetupContext) error {
+	_, _, err := decodeSyncApplicationSpec(ctx.Configuration)
+	return err
+}
+
+func (s *SyncApplication) Execute(ctx core.ExecutionContext) error {
+	spec, ref, err := decodeSyncApplicationSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	application, err := client.SyncApplication(ref, SyncRequest{
+		Revision: spec.Revision,
+		Prune:    spec.Prune,
+		DryRun:   spec.DryRun,
+	})
+
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to sync application %s: %v", ref, err))
+	}
+
+	payload := applicationPayload(application)
+	payload["request"] = map[string]any{
+		"revision": spec.Revision,
+		"prune":    spec.Prune,
+		"dryRun":   spec.DryRun,
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, SyncApplicationPayloadType, []any{payload})
+}
+
+func (s *SyncApplication) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (s *SyncApplication) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (s *SyncApplication) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (s *SyncApplication) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (s *SyncApplication) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeSyncApplicationSpec(value any) (SyncApplicationSpec, ApplicationRef, error) {
+	spec := SyncApplicationSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return SyncApplicationSpec{}, ApplicationRef{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := ParseApplicationRef(spec.Application)
+	if err != nil {
+		return SyncApplicationSpec{}, ApplicationRef{}, err
+	}
+
+	spec.Revision = strings.TrimSpace(spec.Revision)
+	return spec, ref, nil
+}
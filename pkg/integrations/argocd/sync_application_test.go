@@ -0,0 +1,79 @@
+package argocd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__SyncApplication__Execute(t *testing.T) {
+	component := &SyncApplication{}
+
+	t.Run("sync started -> emits application", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{
+					"metadata":{"name":"payments","namespace":"team-a"},
+					"spec":{"project":"default","source":{"repoURL":"https://github.com/example/gitops.git","path":"apps/payments","targetRevision":"main"}},
+					"status":{"sync":{"status":"OutOfSync"},"health":{"status":"Healthy"},"operationState":{"phase":"Running"}}
+				}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"application": "team-a/payments",
+				"revision":    " v1.4.2 ",
+				"prune":       true,
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		request := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/api/v1/applications/payments/sync", request.URL.Path)
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		sent := SyncRequest{}
+		require.NoError(t, json.Unmarshal(body, &sent))
+		assert.Equal(t, SyncRequest{AppNamespace: "team-a", Revision: "v1.4.2", Prune: true}, sent)
+
+		assert.Equal(t, core.DefaultOutputChannel.Name, executionState.Channel)
+		assert.Equal(t, SyncApplicationPayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "payments", payload["name"])
+		assert.Equal(t, "Running", payload["operation"].(map[string]any)["phase"])
+	})
+
+	t.Run("operation in progress -> fails", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusBadRequest, `{"error":"another operation is already in progress","code":9,"message":"another operation is already in progress"}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"application": "guestbook"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Equal(t, "failed to sync application guestbook: request failed with 400: another operation is already in progress", executionState.FailureMessage)
+	})
+}
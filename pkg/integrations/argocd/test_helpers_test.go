@@ -0,0 +1,25 @@
+package argocd
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func testIntegration() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"serverUrl": "https://argocd.example.com/",
+			"authToken": "token-123",
+		},
+	}
+}
@@ -0,0 +1,363 @@
+package argocd
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	WaitForApplicationPayloadType   = "argocd.application.ready"
+	WaitForApplicationPollAction    = "poll"
+	WaitForApplicationPollInterval  = 10 * time.Second
+	WaitForApplicationMaxPollErrors = 5
+
+	DefaultWaitTimeoutMinutes = 10
+
+	WaitConditionHealthy = "healthy"
+	WaitConditionSynced  = "synced"
+
+	WaitStatusReady    = "ready"
+	WaitStatusWaiting  = "waiting"
+	WaitStatusFailed   = "failed"
+	WaitStatusTimedOut = "timedOut"
+)
+
+type WaitForApplication struct{}
+
+type WaitForApplicationSpec struct {
+	Application    string   `json:"application" mapstructure:"application"`
+	Conditions     []string `json:"conditions" mapstructure:"conditions"`
+	TimeoutMinutes int      `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+	FailOnDegraded *bool    `json:"failOnDegraded,omitempty" mapstructure:"failOnDegraded"`
+}
+
+type WaitForApplicationMetadata struct {
+	Application    string `json:"application" mapstructure:"application"`
+	StartedAt      string `json:"startedAt" mapstructure:"startedAt"`
+	Status         string `json:"status" mapstructure:"status"`
+	Message        string `json:"message,omitempty" mapstructure:"message"`
+	PollErrorCount int    `json:"pollErrorCount,omitempty" mapstructure:"pollErrorCount"`
+}
+
+// WaitStatus is where an application stands
+// compared to the conditions being waited for.
+type WaitStatus struct {
+	Status  string
+	Message string
+}
+
+func (w *WaitForApplication) Name() string {
+	return "argocd.waitForApplication"
+}
+
+func (w *WaitForApplication) Label() string {
+	return "Wait for Application"
+}
+
+func (w *WaitForApplication) Description() string {
+	return "Wait for an Argo CD application to be healthy and synced"
+}
+
+func (w *WaitForApplication) Documentation() string {
+	return `The Wait for Application component waits until an Argo CD application is healthy and synced, like ` + "`argocd app wait`" + ` does.
+
+## Use Cases
+
+- **Deploy pipelines**: Only move on to smoke tests once the new revision is live
+- **Rollback automation**: Route failed syncs to a rollback or an alert
+- **Release gates**: Hold promotions until every resource is healthy
+
+## Configuration
+
+- **Application**: The Argo CD application to wait for
+- **Wait for**: Healthy, Synced, or both
+- **Timeout (minutes)**: How long to wait before giving up. Defaults to 10 minutes.
+- **Fail when degraded**: Stop waiting as soon as the application is Degraded
+
+## Output Channels
+
+- **Success**: The application is healthy and synced, and no operation is running
+- **Failed**: The latest sync operation failed, the application is Degraded, or it did not get ready in time
+
+Sync operations that are pending or running are always waited for.`
+}
+
+func (w *WaitForApplication) Icon() string {
+	return "argocd"
+}
+
+func (w *WaitForApplication) Color() string {
+	return "orange"
+}
+
+func (w *WaitForApplication) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: SuccessOutputChannel, Label: "Success"},
+		{Name: FailedOutputChannel, Label: "Failed"},
+	}
+}
+
+func (w *WaitForApplication) Configuration() []configuration.Field {
+	return []configuration.Field{
+		applicationField(),
+		{
+			Name:        "conditions",
+			Label:       "Wait for",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    true,
+			Default:     []string{WaitConditionHealthy, WaitConditionSynced},
+			Description: "Conditions the application must meet",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Healthy", Value: WaitConditionHealthy},
+						{Label: "Synced", Value: WaitConditionSynced},
+					},
+				},
+			},
+		},
+		{
+			Name:        "timeoutMinutes",
+			Label:       "Timeout (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultWaitTimeoutMinutes,
+			Description: "How long to wait for the application",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: intPtr(1),
+					Max: intPtr(720),
+				},
+			},
+		},
+		{
+			Name:        "failOnDegraded",
+			Label:       "Fail when degraded",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "Stop waiting as soon as the application is Degraded",
+		},
+	}
+}
+
+func (w *WaitForApplication) Setup(ctx core.SetupContext) error {
+	_, _, err := decodeWaitForApplicationSpec(ctx.Configuration)
+	return err
+}
+
+func (w *WaitForApplication) Execute(ctx core.ExecutionContext) error {
+	spec, ref, err := decodeWaitForApplicationSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	metadata := WaitForApplicationMetadata{
+		Application: ref.String(),
+		StartedAt:   time.Now().UTC().Format(time.RFC3339),
+		Status:      WaitStatusWaiting,
+	}
+
+	return w.check(client, ctx.Metadata, ctx.ExecutionState, ctx.Requests, metadata, spec)
+}
+
+func (w *WaitForApplication) Hooks() []core.Hook {
+	return []core.Hook{{Name: WaitForApplicationPollAction, Type: core.HookTypeInternal}}
+}
+
+func (w *WaitForApplication) HandleHook(ctx core.ActionHookContext) error {
+	switch ctx.Name {
+	case WaitForApplicationPollAction:
+		return w.poll(ctx)
+	default:
+		return fmt.Errorf("unknown hook: %s", ctx.Name)
+	}
+}
+
+func (w *WaitForApplication) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	spec, _, err := decodeWaitForApplicationSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	metadata := WaitForApplicationMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	return w.check(client, ctx.Metadata, ctx.ExecutionState, ctx.Requests, metadata, spec)
+}
+
+func (w *WaitForApplication) check(
+	client *Client,
+	metadataCtx core.MetadataWriter,
+	executionState core.ExecutionStateContext,
+	requests core.RequestContext,
+	metadata WaitForApplicationMetadata,
+	spec WaitForApplicationSpec,
+) error {
+	ref, err := ParseApplicationRef(metadata.Application)
+	if err != nil {
+		return err
+	}
+
+	application, err := client.GetApplication(ref)
+	if err != nil {
+		metadata.PollErrorCount++
+		metadata.Message = err.Error()
+		if setErr := metadataCtx.Set(metadata); setErr != nil {
+			return setErr
+		}
+
+		if IsNotFound(err) || metadata.PollErrorCount >= WaitForApplicationMaxPollErrors {
+			return executionState.Fail("error", fmt.Sprintf("failed to get application %s: %v", ref, err))
+		}
+
+		return requests.ScheduleActionCall(WaitForApplicationPollAction, map[string]any{}, WaitForApplicationPollInterval)
+	}
+
+	status := ApplicationWaitStatus(application, spec.Conditions, *spec.FailOnDegraded)
+	metadata.PollErrorCount = 0
+	metadata.Status = status.Status
+	metadata.Message = status.Message
+
+	startedAt, err := time.Parse(time.RFC3339, metadata.StartedAt)
+	if err == nil && status.Status == WaitStatusWaiting && time.Since(startedAt) >= time.Duration(spec.TimeoutMinutes)*time.Minute {
+		metadata.Status = WaitStatusTimedOut
+		metadata.Message = fmt.Sprintf("application was not ready in %d minutes: %s", spec.TimeoutMinutes, status.Message)
+	}
+
+	if err := metadataCtx.Set(metadata); err != nil {
+		return err
+	}
+
+	if metadata.Status == WaitStatusWaiting {
+		return requests.ScheduleActionCall(WaitForApplicationPollAction, map[string]any{}, WaitForApplicationPollInterval)
+	}
+
+	payload := applicationPayload(application)
+	payload["wait"] = map[string]any{
+		"status":    metadata.Status,
+		"message":   metadata.Message,
+		"startedAt": metadata.StartedAt,
+		"endedAt":   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	channel := FailedOutputChannel
+	if metadata.Status == WaitStatusReady {
+		channel = SuccessOutputChannel
+	}
+
+	return executionState.Emit(channel, WaitForApplicationPayloadType, []any{payload})
+}
+
+func (w *WaitForApplication) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (w *WaitForApplication) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (w *WaitForApplication) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func decodeWaitForApplicationSpec(value any) (WaitForApplicationSpec, ApplicationRef, error) {
+	spec := WaitForApplicationSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return WaitForApplicationSpec{}, ApplicationRef{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := ParseApplicationRef(spec.Application)
+	if err != nil {
+		return WaitForApplicationSpec{}, ApplicationRef{}, err
+	}
+
+	if len(spec.Conditions) == 0 {
+		return WaitForApplicationSpec{}, ApplicationRef{}, fmt.Errorf("at least one condition is required")
+	}
+
+	for _, condition := range spec.Conditions {
+		if condition != WaitConditionHealthy && condition != WaitConditionSynced {
+			return WaitForApplicationSpec{}, ApplicationRef{}, fmt.Errorf("invalid condition %q, expected healthy or synced", condition)
+		}
+	}
+
+	if spec.TimeoutMinutes <= 0 {
+		spec.TimeoutMinutes = DefaultWaitTimeoutMinutes
+	}
+
+	if spec.FailOnDegraded == nil {
+		failOnDegraded := true
+		spec.FailOnDegraded = &failOnDegraded
+	}
+
+	return spec, ref, nil
+}
+
+// ApplicationWaitStatus follows the checks of argocd app wait:
+// no operation is pending or running, and the application
+// has the health and sync statuses being waited for.
+func ApplicationWaitStatus(application *Application, conditions []string, failOnDegraded bool) WaitStatus {
+	status := application.Status
+	if application.Operation != nil {
+		return WaitStatus{Status: WaitStatusWaiting, Message: "sync operation is pending"}
+	}
+
+	if operation := status.OperationState; operation != nil {
+		if operationRunning(operation.Phase) {
+			return WaitStatus{Status: WaitStatusWaiting, Message: fmt.Sprintf("sync operation is %s", operation.Phase)}
+		}
+
+		//
+		// A failed operation only matters while the application is out of sync,
+		// since a later automated sync might have brought it back in sync.
+		//
+		if operationFailed(operation.Phase) && status.Sync.Status != SyncStatusSynced {
+			return WaitStatus{Status: WaitStatusFailed, Message: fmt.Sprintf("sync operation %s: %s", operation.Phase, operation.Message)}
+		}
+	}
+
+	waitForHealthy := slices.Contains(conditions, WaitConditionHealthy)
+	if waitForHealthy && failOnDegraded && status.Health.Status == HealthStatusDegraded {
+		return WaitStatus{Status: WaitStatusFailed, Message: fmt.Sprintf("application is Degraded: %s", status.Health.Message)}
+	}
+
+	if waitForHealthy && status.Health.Status != HealthStatusHealthy {
+		return WaitStatus{Status: WaitStatusWaiting, Message: fmt.Sprintf("health status is %s", valueOrUnknown(status.Health.Status))}
+	}
+
+	if slices.Contains(conditions, WaitConditionSynced) && status.Sync.Status != SyncStatusSynced {
+		return WaitStatus{Status: WaitStatusWaiting, Message: fmt.Sprintf("sync status is %s", valueOrUnknown(status.Sync.Status))}
+	}
+
+	return WaitStatus{Status: WaitStatusReady, Message: fmt.Sprintf("application %q is ready", application.Metadata.Name)}
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "Unknown"
+	}
+
+	return value
+}
@@ -0,0 +1,186 @@
+package argocd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ApplicationWaitStatus(t *testing.T) {
+	application := func(sync, health string, operation *OperationState) *Application {
+		a := &Application{}
+		a.Metadata.Name = "guestbook"
+		a.Status.Sync.Status = sync
+		a.Status.Health.Status = health
+		a.Status.Health.Message = "pods are crash looping"
+		a.Status.OperationState = operation
+		return a
+	}
+
+	both := []string{WaitConditionHealthy, WaitConditionSynced}
+	testCases := []struct {
+		name           string
+		application    *Application
+		conditions     []string
+		failOnDegraded bool
+		status         string
+		message        string
+	}{
+		{"ready", application(SyncStatusSynced, HealthStatusHealthy, nil), both, true, WaitStatusReady, `application "guestbook" is ready`},
+		{"operation running", application(SyncStatusSynced, HealthStatusHealthy, &OperationState{Phase: OperationPhaseRunning}), both, true, WaitStatusWaiting, "sync operation is Running"},
+		{"progressing", application(SyncStatusSynced, HealthStatusProgressing, nil), both, true, WaitStatusWaiting, "health status is Progressing"},
+		{"out of sync", application(SyncStatusOutOfSync, HealthStatusHealthy, nil), both, true, WaitStatusWaiting, "sync status is OutOfSync"},
+		{"out of sync, only health", application(SyncStatusOutOfSync, HealthStatusHealthy, nil), []string{WaitConditionHealthy}, true, WaitStatusReady, `application "guestbook" is ready`},
+		{"degraded", application(SyncStatusSynced, HealthStatusDegraded, nil), both, true, WaitStatusFailed, "application is Degraded: pods are crash looping"},
+		{"degraded, keep waiting", application(SyncStatusSynced, HealthStatusDegraded, nil), both, false, WaitStatusWaiting, "health status is Degraded"},
+		{
+			"operation failed",
+			application(SyncStatusOutOfSync, HealthStatusHealthy, &OperationState{Phase: OperationPhaseFailed, Message: "one or more objects failed to apply"}),
+			both, true, WaitStatusFailed, "sync operation Failed: one or more objects failed to apply",
+		},
+		{
+			"operation failed, synced since",
+			application(SyncStatusSynced, HealthStatusHealthy, &OperationState{Phase: OperationPhaseFailed}),
+			both, true, WaitStatusReady, `application "guestbook" is ready`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			status := ApplicationWaitStatus(testCase.application, testCase.conditions, testCase.failOnDegraded)
+			assert.Equal(t, testCase.status, status.Status)
+			assert.Equal(t, testCase.message, status.Message)
+		})
+	}
+
+	t.Run("pending operation -> waiting", func(t *testing.T) {
+		pending := application(SyncStatusSynced, HealthStatusHealthy, nil)
+		pending.Operation = map[string]any{"sync": map[string]any{}}
+		assert.Equal(t, WaitStatusWaiting, ApplicationWaitStatus(pending, both, true).Status)
+	})
+}
+
+func Test__WaitForApplication(t *testing.T) {
+	component := &WaitForApplication{}
+
+	t.Run("not ready -> schedules poll", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"metadata":{"name":"guestbook"},"status":{"sync":{"status":"Synced"},"health":{"status":"Progressing"}}}`),
+			},
+		}
+
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"application": "guestbook", "conditions": []string{WaitConditionHealthy, WaitConditionSynced}},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, WaitForApplicationPollAction, requests.Action)
+		assert.Equal(t, WaitForApplicationPollInterval, requests.Duration)
+
+		stored := metadata.Metadata.(WaitForApplicationMetadata)
+		assert.Equal(t, WaitStatusWaiting, stored.Status)
+		assert.Equal(t, "health status is Progressing", stored.Message)
+	})
+
+	t.Run("poll ready -> success", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"metadata":{"name":"guestbook"},"status":{"sync":{"status":"Synced"},"health":{"status":"Healthy"}}}`),
+			},
+		}
+
+		metadata := &contexts.MetadataContext{
+			Metadata: WaitForApplicationMetadata{
+				Application: "guestbook",
+				StartedAt:   time.Now().UTC().Format(time.RFC3339),
+				Status:      WaitStatusWaiting,
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           WaitForApplicationPollAction,
+			Configuration:  map[string]any{"application": "guestbook", "conditions": []string{WaitConditionHealthy}},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       metadata,
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, SuccessOutputChannel, executionState.Channel)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, WaitStatusReady, payload["wait"].(map[string]any)["status"])
+	})
+
+	t.Run("poll after timeout -> failed", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"metadata":{"name":"guestbook"},"status":{"sync":{"status":"OutOfSync"},"health":{"status":"Healthy"}}}`),
+			},
+		}
+
+		metadata := &contexts.MetadataContext{
+			Metadata: WaitForApplicationMetadata{
+				Application: "guestbook",
+				StartedAt:   time.Now().Add(-11 * time.Minute).UTC().Format(time.RFC3339),
+				Status:      WaitStatusWaiting,
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           WaitForApplicationPollAction,
+			Configuration:  map[string]any{"application": "guestbook", "conditions": []string{WaitConditionSynced}},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       metadata,
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, FailedOutputChannel, executionState.Channel)
+		stored := metadata.Metadata.(WaitForApplicationMetadata)
+		assert.Equal(t, WaitStatusTimedOut, stored.Status)
+		assert.Equal(t, "application was not ready in 10 minutes: sync status is OutOfSync", stored.Message)
+	})
+
+	t.Run("application not found -> fails", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusNotFound, `{"message":"applications.argoproj.io \"guestbook\" not found"}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"application": "guestbook", "conditions": []string{WaitConditionHealthy}},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to get application guestbook")
+	})
+}
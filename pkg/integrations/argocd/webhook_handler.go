@@ -0,0 +1,24 @@
+package argocd
+
+import "github.com/superplanehq/superplane/pkg/core"
+
+// ArgoCDWebhookHandler doesn't provision anything, since Argo CD
+// notifications only live in the argocd-notifications-cm ConfigMap.
+// Every trigger of an integration shares the same webhook URL.
+type ArgoCDWebhookHandler struct{}
+
+func (h *ArgoCDWebhookHandler) CompareConfig(a any, b any) (bool, error) {
+	return true, nil
+}
+
+func (h *ArgoCDWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
+	return nil, nil
+}
+
+func (h *ArgoCDWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
+	return nil
+}
+
+func (h *ArgoCDWebhookHandler) Merge(current, requested any) (any, bool, error) {
+	return current, false, nil
+}
@@ -0,0 +1,230 @@
+package azuredevops
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	ApprovalDecisionApproved = "approved"
+	ApprovalDecisionRejected = "rejected"
+)
+
+type ApproveCheck struct{}
+
+type ApproveCheckSpec struct {
+	Project  string `json:"project" mapstructure:"project"`
+	Run      string `json:"run" mapstructure:"run"`
+	Decision string `json:"decision" mapstructure:"decision"`
+	Comment  string `json:"comment" mapstructure:"comment"`
+}
+
+func (c *ApproveCheck) Name() string {
+	return "azuredevops.approveCheck"
+}
+
+func (c *ApproveCheck) Label() string {
+	return "Approve Environment Check"
+}
+
+func (c *ApproveCheck) Description() string {
+	return "Approve or reject the pending environment approvals of a pipeline run"
+}
+
+func (c *ApproveCheck) Documentation() string {
+	return `The Approve Environment Check component approves or rejects the pending approvals of an Azure Pipelines run, which stages deploying to protected environments wait for.
+
+## Use Cases
+
+- **Gated deployments**: Approve production deployments once checks in other systems pass
+- **Change management**: Approve after a change request is approved, or reject when it is declined
+- **Automated rollbacks**: Reject the deployment of a run when monitoring detects a regression
+
+## Configuration
+
+- **Project**: The Azure DevOps project of the pipeline
+- **Run ID**: The ID of the run waiting for approval, for example ` + "`{{ root().data.resource.id }}`" + `
+- **Decision**: Approve or reject
+- **Comment**: Optional comment recorded with the decision
+
+## Output
+
+The updated approvals, with their ID, status and pipeline.
+
+## Notes
+
+The identity of the integration must be an approver of the environment. The execution fails when the run has no pending approvals.`
+}
+
+func (c *ApproveCheck) Icon() string {
+	return "check"
+}
+
+func (c *ApproveCheck) Color() string {
+	return "blue"
+}
+
+func (c *ApproveCheck) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ApproveCheck) Configuration() []configuration.Field {
+	return []configuration.Field{
+		projectField(),
+		{
+			Name:        "run",
+			Label:       "Run ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "The ID of the pipeline run waiting for approval",
+		},
+		{
+			Name:     "decision",
+			Label:    "Decision",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  ApprovalDecisionApproved,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Approve", Value: ApprovalDecisionApproved},
+						{Label: "Reject", Value: ApprovalDecisionRejected},
+					},
+				},
+			},
+		},
+		{
+			Name:     "comment",
+			Label:    "Comment",
+			Type:     configuration.FieldTypeText,
+			Required: false,
+		},
+	}
+}
+
+func (c *ApproveCheck) Setup(ctx core.SetupContext) error {
+	spec, err := decodeApproveCheckSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if !isExpression(spec.Run) {
+		if _, err := parseID("run", spec.Run); err != nil {
+			return err
+		}
+	}
+
+	_, err = ensureProjectInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Project, "")
+	return err
+}
+
+func (c *ApproveCheck) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeApproveCheckSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	runID, err := parseID("run", spec.Run)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	approvals, err := client.ListPendingApprovals(spec.Project)
+	if err != nil {
+		return fmt.Errorf("failed to list pending approvals: %w", err)
+	}
+
+	updates := []ApprovalUpdate{}
+	for _, approval := range approvals {
+		if approval.Pipeline.Owner.ID == runID {
+			updates = append(updates, ApprovalUpdate{
+				ApprovalID: approval.ID,
+				Status:     spec.Decision,
+				Comment:    spec.Comment,
+			})
+		}
+	}
+
+	if len(updates) == 0 {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("run %d has no pending approvals", runID))
+	}
+
+	updated, err := client.UpdateApprovals(spec.Project, updates)
+	if err != nil {
+		return fmt.Errorf("failed to update approvals of run %d: %w", runID, err)
+	}
+
+	result := make([]map[string]any, 0, len(updated))
+	for _, approval := range updated {
+		result = append(result, map[string]any{
+			"id":       approval.ID,
+			"status":   approval.Status,
+			"pipeline": approval.Pipeline.Name,
+		})
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"azuredevops.approval",
+		[]any{map[string]any{
+			"run":       runID,
+			"project":   spec.Project,
+			"decision":  spec.Decision,
+			"comment":   spec.Comment,
+			"approvals": result,
+		}},
+	)
+}
+
+func decodeApproveCheckSpec(value any) (ApproveCheckSpec, error) {
+	spec := ApproveCheckSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	project, err := validateProject(spec.Project)
+	if err != nil {
+		return spec, err
+	}
+
+	spec.Project = project
+	if strings.TrimSpace(spec.Run) == "" {
+		return spec, fmt.Errorf("run is required")
+	}
+
+	if spec.Decision != ApprovalDecisionApproved && spec.Decision != ApprovalDecisionRejected {
+		return spec, fmt.Errorf("invalid decision %q", spec.Decision)
+	}
+
+	return spec, nil
+}
+
+func (c *ApproveCheck) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *ApproveCheck) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *ApproveCheck) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *ApproveCheck) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ApproveCheck) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,91 @@
+package azuredevops
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const pendingApprovalsResponse = `{"count":2,"value":[
+	{"id":"a1","status":"pending","pipeline":{"id":"12","name":"web-cd","owner":{"id":1234,"name":"20260115.3"}}},
+	{"id":"a2","status":"pending","pipeline":{"id":"12","name":"web-cd","owner":{"id":1235,"name":"20260115.4"}}}
+]}`
+
+func Test__ApproveCheck__Setup(t *testing.T) {
+	t.Run("invalid decision -> error", func(t *testing.T) {
+		err := (&ApproveCheck{}).Setup(core.SetupContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"project": "web", "run": "1234", "decision": "maybe"},
+		})
+
+		require.ErrorContains(t, err, `invalid decision "maybe"`)
+	})
+
+	t.Run("invalid run -> error", func(t *testing.T) {
+		err := (&ApproveCheck{}).Setup(core.SetupContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"project": "web", "run": "latest", "decision": ApprovalDecisionApproved},
+		})
+
+		require.ErrorContains(t, err, `invalid run "latest"`)
+	})
+}
+
+func Test__ApproveCheck__Execute(t *testing.T) {
+	t.Run("pending approvals of the run are updated", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, pendingApprovalsResponse),
+			jsonResponse(http.StatusOK, `{"count":1,"value":[{"id":"a1","status":"approved","pipeline":{"id":"12","name":"web-cd"}}]}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := (&ApproveCheck{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"project":  "web",
+				"run":      "1234",
+				"decision": ApprovalDecisionApproved,
+				"comment":  "LGTM",
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, core.DefaultOutputChannel.Name, executionState.Channel)
+		assert.Equal(t, "azuredevops.approval", executionState.Type)
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/contoso/web/_apis/pipelines/approvals", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "pending", httpContext.Requests[0].URL.Query().Get("state"))
+		assert.Equal(t, http.MethodPatch, httpContext.Requests[1].Method)
+		body, err := io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"approvalId":"a1","status":"approved","comment":"LGTM"}]`, string(body))
+	})
+
+	t.Run("no pending approvals -> fails execution", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := (&ApproveCheck{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{"project": "web", "run": "999", "decision": ApprovalDecisionRejected},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, pendingApprovalsResponse),
+			}},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "run 999 has no pending approvals")
+	})
+}
@@ -0,0 +1,336 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const (
+	AuthTypePersonalAccessToken = "personalAccessToken"
+	AuthTypeEntra               = "entra"
+
+	secretAccessToken = "accessToken"
+
+	// Entra access tokens are valid for at least an hour.
+	entraTokenRefreshInterval = 30 * time.Minute
+)
+
+func init() {
+	registry.RegisterIntegrationWithWebhookHandler("azuredevops", &AzureDevOps{}, &AzureDevOpsWebhookHandler{})
+}
+
+type AzureDevOps struct{}
+
+type Configuration struct {
+	Organization        string `json:"organization" mapstructure:"organization"`
+	AuthType            string `json:"authType" mapstructure:"authType"`
+	PersonalAccessToken string `json:"personalAccessToken" mapstructure:"personalAccessToken"`
+	TenantID            string `json:"tenantId" mapstructure:"tenantId"`
+	ClientID            string `json:"clientId" mapstructure:"clientId"`
+	ClientSecret        string `json:"clientSecret" mapstructure:"clientSecret"`
+}
+
+type Metadata struct {
+	Organization string `json:"organization" mapstructure:"organization"`
+	AuthType     string `json:"authType" mapstructure:"authType"`
+}
+
+func (a *AzureDevOps) Name() string {
+	return "azuredevops"
+}
+
+func (a *AzureDevOps) Label() string {
+	return "Azure DevOps"
+}
+
+func (a *AzureDevOps) Icon() string {
+	return "azuredevops"
+}
+
+func (a *AzureDevOps) Description() string {
+	return "Run Azure Pipelines, manage Boards work items and react to Repos events"
+}
+
+func (a *AzureDevOps) Instructions() string {
+	return `
+1. **Organization:** The name of your Azure DevOps organization, or its URL, like ` + "`https://dev.azure.com/contoso`" + `
+2. **Authentication:**
+   - **Personal Access Token:** In Azure DevOps, go to **User settings → Personal access tokens → New Token**.
+   - **Microsoft Entra ID:** Create an app registration with a client secret in Entra ID, and add its service principal to the organization in **Organization settings → Users**.
+3. **Permissions:** SuperPlane needs these scopes, or the matching permissions for service principals:
+   - **Build:** Read & execute, to run pipelines
+   - **Code:** Read & write, to comment on pull requests
+   - **Work Items:** Read & write
+   - **Service Hooks:** Read, write & manage, for triggers
+   - Approving environment checks needs the user or service principal to be an approver of the check.`
+}
+
+func (a *AzureDevOps) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "organization",
+			Label:       "Organization",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "contoso",
+			Description: "Name or URL of the Azure DevOps organization",
+		},
+		{
+			Name:     "authType",
+			Label:    "Authentication Type",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  AuthTypePersonalAccessToken,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Personal Access Token", Value: AuthTypePersonalAccessToken},
+						{Label: "Microsoft Entra ID", Value: AuthTypeEntra},
+					},
+				},
+			},
+		},
+		{
+			Name:        "personalAccessToken",
+			Label:       "Personal Access Token",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "Azure DevOps personal access token",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authType", Values: []string{AuthTypePersonalAccessToken}},
+			},
+		},
+		{
+			Name:        "tenantId",
+			Label:       "Tenant ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "00000000-0000-0000-0000-000000000000",
+			Description: "Directory (tenant) ID of the app registration",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authType", Values: []string{AuthTypeEntra}},
+			},
+		},
+		{
+			Name:        "clientId",
+			Label:       "Client ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "00000000-0000-0000-0000-000000000000",
+			Description: "Application (client) ID of the app registration",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authType", Values: []string{AuthTypeEntra}},
+			},
+		},
+		{
+			Name:        "clientSecret",
+			Label:       "Client Secret",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "Client secret of the app registration",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authType", Values: []string{AuthTypeEntra}},
+			},
+		},
+	}
+}
+
+func (a *AzureDevOps) Actions() []core.Action {
+	return []core.Action{
+		&RunPipeline{},
+		&ApproveCheck{},
+		&CreateWorkItem{},
+		&UpdateWorkItem{},
+		&CreatePullRequestComment{},
+	}
+}
+
+func (a *AzureDevOps) Triggers() []core.Trigger {
+	return []core.Trigger{
+		&OnPush{},
+		&OnPullRequest{},
+		&OnBuildCompleted{},
+	}
+}
+
+func (a *AzureDevOps) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (a *AzureDevOps) Sync(ctx core.SyncContext) error {
+	config := Configuration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	organization := parseOrganization(config.Organization)
+	if organization == "" {
+		return fmt.Errorf("organization is required")
+	}
+
+	switch config.AuthType {
+	case AuthTypePersonalAccessToken:
+		if strings.TrimSpace(config.PersonalAccessToken) == "" {
+			return fmt.Errorf("personalAccessToken is required")
+		}
+
+	case AuthTypeEntra:
+		if err := a.refreshEntraToken(ctx, config); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("authType %q is not supported", config.AuthType)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.ListProjects(); err != nil {
+		return fmt.Errorf("failed to verify Azure DevOps credentials: %w", err)
+	}
+
+	ctx.Integration.SetMetadata(Metadata{Organization: organization, AuthType: config.AuthType})
+	ctx.Integration.Ready()
+	return nil
+}
+
+// refreshEntraToken gets an access token for the service principal,
+// and schedules a resync to refresh it before it expires.
+func (a *AzureDevOps) refreshEntraToken(ctx core.SyncContext, config Configuration) error {
+	if strings.TrimSpace(config.TenantID) == "" {
+		return fmt.Errorf("tenantId is required")
+	}
+
+	if strings.TrimSpace(config.ClientID) == "" {
+		return fmt.Errorf("clientId is required")
+	}
+
+	if strings.TrimSpace(config.ClientSecret) == "" {
+		return fmt.Errorf("clientSecret is required")
+	}
+
+	token, err := RequestEntraToken(
+		ctx.HTTP,
+		strings.TrimSpace(config.TenantID),
+		strings.TrimSpace(config.ClientID),
+		strings.TrimSpace(config.ClientSecret),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to get Entra access token: %w", err)
+	}
+
+	if err := ctx.Integration.SetSecret(secretAccessToken, []byte(token.AccessToken)); err != nil {
+		return fmt.Errorf("failed to store access token: %w", err)
+	}
+
+	return ctx.Integration.ScheduleResync(entraTokenRefreshInterval)
+}
+
+func (a *AzureDevOps) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (a *AzureDevOps) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	switch resourceType {
+	case ResourceTypeProject, ResourceTypeRepository, ResourceTypePipeline, ResourceTypeWorkItemType:
+	default:
+		return []core.IntegrationResource{}, nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	if resourceType == ResourceTypeProject {
+		projects, err := client.ListProjects()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(projects))
+		for _, project := range projects {
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: project.Name, ID: project.ID})
+		}
+
+		return resources, nil
+	}
+
+	//
+	// Everything else belongs to a project.
+	//
+	project := strings.TrimSpace(ctx.Parameters["project"])
+	if project == "" {
+		return []core.IntegrationResource{}, nil
+	}
+
+	switch resourceType {
+	case ResourceTypeRepository:
+		repositories, err := client.ListRepositories(project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(repositories))
+		for _, repository := range repositories {
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: repository.Name, ID: repository.ID})
+		}
+
+		return resources, nil
+
+	case ResourceTypePipeline:
+		pipelines, err := client.ListPipelines(project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pipelines: %w", err)
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(pipelines))
+		for _, pipeline := range pipelines {
+			resources = append(resources, core.IntegrationResource{
+				Type: resourceType,
+				Name: pipelineDisplayName(pipeline),
+				ID:   strconv.FormatInt(pipeline.ID, 10),
+			})
+		}
+
+		return resources, nil
+
+	default:
+		types, err := client.ListWorkItemTypes(project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list work item types: %w", err)
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(types))
+		for _, workItemType := range types {
+			if workItemType.IsDisabled {
+				continue
+			}
+
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: workItemType.Name, ID: workItemType.Name})
+		}
+
+		return resources, nil
+	}
+}
+
+func (a *AzureDevOps) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (a *AzureDevOps) HandleHook(ctx core.IntegrationHookContext) error {
+	return nil
+}
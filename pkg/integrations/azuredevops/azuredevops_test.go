@@ -0,0 +1,175 @@
+package azuredevops
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__AzureDevOps__Sync(t *testing.T) {
+	a := &AzureDevOps{}
+
+	t.Run("organization is required", func(t *testing.T) {
+		err := a.Sync(core.SyncContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   &contexts.IntegrationContext{},
+			Configuration: map[string]any{"authType": AuthTypePersonalAccessToken, "personalAccessToken": "pat"},
+		})
+
+		require.ErrorContains(t, err, "organization is required")
+	})
+
+	t.Run("unsupported authType returns error", func(t *testing.T) {
+		err := a.Sync(core.SyncContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   &contexts.IntegrationContext{},
+			Configuration: map[string]any{"organization": "contoso", "authType": "basic"},
+		})
+
+		require.ErrorContains(t, err, `authType "basic" is not supported`)
+	})
+
+	t.Run("personal access token -> verifies credentials", func(t *testing.T) {
+		integration := testIntegration()
+		integration.Metadata = nil
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"count":1,"value":[`+`{"id":"6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c","name":"web"}`+`]}`),
+		}}
+
+		err := a.Sync(core.SyncContext{
+			HTTP:        httpContext,
+			Integration: integration,
+			Configuration: map[string]any{
+				"organization":        "https://dev.azure.com/contoso/",
+				"authType":            AuthTypePersonalAccessToken,
+				"personalAccessToken": "pat",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integration.State)
+		assert.Equal(t, Metadata{Organization: "contoso", AuthType: AuthTypePersonalAccessToken}, integration.Metadata)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "/contoso/_apis/projects", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "7.1", httpContext.Requests[0].URL.Query().Get("api-version"))
+		assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte(":pat")), httpContext.Requests[0].Header.Get("Authorization"))
+	})
+
+	t.Run("credentials not accepted -> error", func(t *testing.T) {
+		err := a.Sync(core.SyncContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				{StatusCode: http.StatusNonAuthoritativeInfo, Body: io.NopCloser(strings.NewReader(`<html>Sign in</html>`))},
+			}},
+			Integration:   testIntegration(),
+			Configuration: testIntegration().Configuration,
+		})
+
+		require.ErrorContains(t, err, "failed to verify Azure DevOps credentials")
+	})
+
+	t.Run("entra -> stores access token and schedules refresh", func(t *testing.T) {
+		integration := &contexts.IntegrationContext{
+			Configuration: map[string]any{"organization": "contoso", "authType": AuthTypeEntra},
+		}
+
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"access_token":"entra-token","expires_in":3599}`),
+			jsonResponse(http.StatusOK, `{"count":0,"value":[]}`),
+		}}
+
+		err := a.Sync(core.SyncContext{
+			HTTP:        httpContext,
+			Integration: integration,
+			Configuration: map[string]any{
+				"organization": "contoso",
+				"authType":     AuthTypeEntra,
+				"tenantId":     "tenant",
+				"clientId":     "client",
+				"clientSecret": "secret",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "entra-token", string(integration.CurrentSecrets[secretAccessToken].Value))
+		assert.Equal(t, entraTokenRefreshInterval, integration.ResyncRequests[0])
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://login.microsoftonline.com/tenant/oauth2/v2.0/token", httpContext.Requests[0].URL.String())
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "grant_type=client_credentials")
+		assert.Equal(t, "Bearer entra-token", httpContext.Requests[1].Header.Get("Authorization"))
+	})
+
+	t.Run("entra without client secret -> error", func(t *testing.T) {
+		err := a.Sync(core.SyncContext{
+			HTTP:        &contexts.HTTPContext{},
+			Integration: &contexts.IntegrationContext{},
+			Configuration: map[string]any{
+				"organization": "contoso",
+				"authType":     AuthTypeEntra,
+				"tenantId":     "tenant",
+				"clientId":     "client",
+			},
+		})
+
+		require.ErrorContains(t, err, "clientSecret is required")
+	})
+}
+
+func Test__AzureDevOps__ListResources(t *testing.T) {
+	a := &AzureDevOps{}
+
+	t.Run("pipelines include their folder", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"count":2,"value":[{"id":12,"name":"web-ci","folder":"\\"},{"id":13,"name":"deploy","folder":"\\release"}]}`),
+		}}
+
+		resources, err := a.ListResources(ResourceTypePipeline, core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Parameters:  map[string]string{"project": "web"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []core.IntegrationResource{
+			{Type: ResourceTypePipeline, Name: "web-ci", ID: "12"},
+			{Type: ResourceTypePipeline, Name: "release/deploy", ID: "13"},
+		}, resources)
+		assert.Equal(t, "/contoso/web/_apis/pipelines", httpContext.Requests[0].URL.Path)
+	})
+
+	t.Run("disabled work item types are skipped", func(t *testing.T) {
+		resources, err := a.ListResources(ResourceTypeWorkItemType, core.ListResourcesContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"count":2,"value":[{"name":"Bug"},{"name":"Issue","isDisabled":true}]}`),
+			}},
+			Integration: testIntegration(),
+			Parameters:  map[string]string{"project": "web"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []core.IntegrationResource{{Type: ResourceTypeWorkItemType, Name: "Bug", ID: "Bug"}}, resources)
+	})
+
+	t.Run("no project -> no repositories", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		resources, err := a.ListResources(ResourceTypeRepository, core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Parameters:  map[string]string{},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+		assert.Empty(t, httpContext.Requests)
+	})
+}
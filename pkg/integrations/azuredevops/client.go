@@ -0,0 +1,568 @@
+package azuredevops
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	baseURL    = "https://dev.azure.com"
+	apiVersion = "7.1"
+
+	// entraScope is the scope of Azure DevOps access tokens issued by Microsoft Entra ID.
+	entraScope = "499b84ac-1321-427f-aa17-267ca6975798/.default"
+)
+
+type Client struct {
+	Organization  string
+	authorization string
+	http          core.HTTPContext
+}
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with %d: %s", e.StatusCode, e.Body)
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func NewClient(httpClient core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("no integration context")
+	}
+
+	organization, err := ctx.GetConfig("organization")
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseOrganization(string(organization))
+	if name == "" {
+		return nil, fmt.Errorf("organization is required")
+	}
+
+	authType, err := ctx.GetConfig("authType")
+	if err != nil {
+		return nil, err
+	}
+
+	authorization, err := authorizationHeader(string(authType), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Organization:  name,
+		authorization: authorization,
+		http:          httpClient,
+	}, nil
+}
+
+// parseOrganization accepts the name of an organization,
+// or its URL, like https://dev.azure.com/contoso.
+func parseOrganization(value string) string {
+	organization := strings.TrimSpace(value)
+	organization = strings.TrimPrefix(organization, "https://")
+	organization = strings.TrimPrefix(organization, "dev.azure.com/")
+	organization = strings.Trim(organization, "/")
+
+	//
+	// Legacy organization URLs look like https://contoso.visualstudio.com.
+	//
+	if name, found := strings.CutSuffix(organization, ".visualstudio.com"); found {
+		return name
+	}
+
+	return organization
+}
+
+func authorizationHeader(authType string, ctx core.IntegrationContext) (string, error) {
+	switch authType {
+	case AuthTypePersonalAccessToken:
+		token, err := ctx.GetConfig("personalAccessToken")
+		if err != nil {
+			return "", err
+		}
+
+		trimmed := strings.TrimSpace(string(token))
+		if trimmed == "" {
+			return "", fmt.Errorf("personalAccessToken is required")
+		}
+
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(":"+trimmed)), nil
+
+	case AuthTypeEntra:
+		token, err := accessTokenFromSecrets(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		return "Bearer " + token, nil
+
+	default:
+		return "", fmt.Errorf("authType %q is not supported", authType)
+	}
+}
+
+func accessTokenFromSecrets(ctx core.IntegrationContext) (string, error) {
+	secrets, err := ctx.GetSecrets()
+	if err != nil {
+		return "", fmt.Errorf("failed to get integration secrets: %w", err)
+	}
+
+	for _, secret := range secrets {
+		if secret.Name == secretAccessToken {
+			return string(secret.Value), nil
+		}
+	}
+
+	return "", fmt.Errorf("Entra access token not found: integration needs to sync")
+}
+
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// RequestEntraToken exchanges the credentials of a service principal
+// for an Azure DevOps access token.
+func RequestEntraToken(httpClient core.HTTPContext, tenantID, clientID, clientSecret string) (*TokenResponse, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"scope":         {entraScope},
+	}
+
+	tokenURL := fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", url.PathEscape(tenantID))
+	req, err := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: res.StatusCode, Body: string(body)}
+	}
+
+	token := TokenResponse{}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("error decoding token response: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("no access token in response")
+	}
+
+	return &token, nil
+}
+
+type listResponse[T any] struct {
+	Count int `json:"count"`
+	Value []T `json:"value"`
+}
+
+type Link struct {
+	Href string `json:"href"`
+}
+
+type Identity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+type Project struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+func (c *Client) ListProjects() ([]Project, error) {
+	response := listResponse[Project]{}
+	if err := c.doJSON(http.MethodGet, "/_apis/projects", url.Values{"$top": {"500"}}, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Value, nil
+}
+
+func (c *Client) GetProject(project string) (*Project, error) {
+	result := Project{}
+	if err := c.doJSON(http.MethodGet, "/_apis/projects/"+url.PathEscape(project), nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type Repository struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	DefaultBranch string `json:"defaultBranch"`
+	WebURL        string `json:"webUrl"`
+}
+
+func (c *Client) ListRepositories(project string) ([]Repository, error) {
+	response := listResponse[Repository]{}
+	if err := c.doJSON(http.MethodGet, projectPath(project, "/_apis/git/repositories"), nil, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Value, nil
+}
+
+func (c *Client) GetRepository(project, repository string) (*Repository, error) {
+	result := Repository{}
+	path := projectPath(project, "/_apis/git/repositories/"+url.PathEscape(repository))
+	if err := c.doJSON(http.MethodGet, path, nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type Pipeline struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Folder string `json:"folder"`
+}
+
+func (c *Client) ListPipelines(project string) ([]Pipeline, error) {
+	response := listResponse[Pipeline]{}
+	if err := c.doJSON(http.MethodGet, projectPath(project, "/_apis/pipelines"), url.Values{"$top": {"1000"}}, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Value, nil
+}
+
+func (c *Client) GetPipeline(project string, pipelineID int64) (*Pipeline, error) {
+	result := Pipeline{}
+	path := projectPath(project, fmt.Sprintf("/_apis/pipelines/%d", pipelineID))
+	if err := c.doJSON(http.MethodGet, path, nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+type RunVariable struct {
+	Value    string `json:"value"`
+	IsSecret bool   `json:"isSecret"`
+}
+
+type RunPipelineRequest struct {
+	Resources          *RunResources          `json:"resources,omitempty"`
+	Variables          map[string]RunVariable `json:"variables,omitempty"`
+	TemplateParameters map[string]string      `json:"templateParameters,omitempty"`
+}
+
+type RunResources struct {
+	Repositories map[string]RunRepository `json:"repositories"`
+}
+
+type RunRepository struct {
+	RefName string `json:"refName"`
+}
+
+type Run struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	State        string `json:"state"`
+	Result       string `json:"result"`
+	CreatedDate  string `json:"createdDate"`
+	FinishedDate string `json:"finishedDate"`
+	Pipeline     struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"pipeline"`
+	Links struct {
+		Web Link `json:"web"`
+	} `json:"_links"`
+}
+
+func (c *Client) RunPipeline(project string, pipelineID int64, request RunPipelineRequest) (*Run, error) {
+	run := Run{}
+	path := projectPath(project, fmt.Sprintf("/_apis/pipelines/%d/runs", pipelineID))
+	if err := c.doJSON(http.MethodPost, path, nil, request, &run); err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+func (c *Client) GetRun(project string, pipelineID, runID int64) (*Run, error) {
+	run := Run{}
+	path := projectPath(project, fmt.Sprintf("/_apis/pipelines/%d/runs/%d", pipelineID, runID))
+	if err := c.doJSON(http.MethodGet, path, nil, nil, &run); err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+// CancelRun cancels a pipeline run. Runs are builds,
+// and only the builds API can cancel them.
+func (c *Client) CancelRun(project string, runID int64) error {
+	path := projectPath(project, fmt.Sprintf("/_apis/build/builds/%d", runID))
+	return c.doJSON(http.MethodPatch, path, nil, map[string]string{"status": "cancelling"}, nil)
+}
+
+type Approval struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	Instructions string `json:"instructions"`
+	CreatedOn    string `json:"createdOn"`
+	LastModified string `json:"lastModifiedOn"`
+	Pipeline     struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Owner struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"owner"`
+	} `json:"pipeline"`
+}
+
+type ApprovalUpdate struct {
+	ApprovalID string `json:"approvalId"`
+	Status     string `json:"status"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+func (c *Client) ListPendingApprovals(project string) ([]Approval, error) {
+	response := listResponse[Approval]{}
+	query := url.Values{"state": {"pending"}, "$expand": {"steps"}}
+	if err := c.doJSON(http.MethodGet, projectPath(project, "/_apis/pipelines/approvals"), query, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Value, nil
+}
+
+func (c *Client) UpdateApprovals(project string, updates []ApprovalUpdate) ([]Approval, error) {
+	response := listResponse[Approval]{}
+	if err := c.doJSON(http.MethodPatch, projectPath(project, "/_apis/pipelines/approvals"), nil, updates, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Value, nil
+}
+
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+type WorkItem struct {
+	ID     int64          `json:"id"`
+	Rev    int64          `json:"rev"`
+	Fields map[string]any `json:"fields"`
+	URL    string         `json:"url"`
+	Links  struct {
+		HTML Link `json:"html"`
+	} `json:"_links"`
+}
+
+type WorkItemType struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsDisabled  bool   `json:"isDisabled"`
+}
+
+func (c *Client) ListWorkItemTypes(project string) ([]WorkItemType, error) {
+	response := listResponse[WorkItemType]{}
+	if err := c.doJSON(http.MethodGet, projectPath(project, "/_apis/wit/workitemtypes"), nil, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Value, nil
+}
+
+func (c *Client) CreateWorkItem(project, workItemType string, operations []PatchOperation) (*WorkItem, error) {
+	workItem := WorkItem{}
+	path := projectPath(project, "/_apis/wit/workitems/$"+url.PathEscape(workItemType))
+	if err := c.doJSON(http.MethodPost, path, nil, operations, &workItem); err != nil {
+		return nil, err
+	}
+
+	return &workItem, nil
+}
+
+func (c *Client) UpdateWorkItem(project string, id int64, operations []PatchOperation) (*WorkItem, error) {
+	workItem := WorkItem{}
+	path := projectPath(project, fmt.Sprintf("/_apis/wit/workitems/%d", id))
+	if err := c.doJSON(http.MethodPatch, path, nil, operations, &workItem); err != nil {
+		return nil, err
+	}
+
+	return &workItem, nil
+}
+
+type Comment struct {
+	ID            int64    `json:"id"`
+	Content       string   `json:"content"`
+	Author        Identity `json:"author"`
+	PublishedDate string   `json:"publishedDate"`
+}
+
+type Thread struct {
+	ID            int64     `json:"id"`
+	Status        string    `json:"status"`
+	PublishedDate string    `json:"publishedDate"`
+	Comments      []Comment `json:"comments"`
+}
+
+func (c *Client) CreatePullRequestThread(project, repository string, pullRequestID int64, content string) (*Thread, error) {
+	request := map[string]any{
+		"comments": []map[string]any{
+			{"parentCommentId": 0, "content": content, "commentType": "text"},
+		},
+		"status": "active",
+	}
+
+	thread := Thread{}
+	path := projectPath(project, fmt.Sprintf("/_apis/git/repositories/%s/pullRequests/%d/threads", url.PathEscape(repository), pullRequestID))
+	if err := c.doJSON(http.MethodPost, path, nil, request, &thread); err != nil {
+		return nil, err
+	}
+
+	return &thread, nil
+}
+
+type Subscription struct {
+	ID               string            `json:"id,omitempty"`
+	PublisherID      string            `json:"publisherId"`
+	EventType        string            `json:"eventType"`
+	ResourceVersion  string            `json:"resourceVersion"`
+	ConsumerID       string            `json:"consumerId"`
+	ConsumerActionID string            `json:"consumerActionId"`
+	PublisherInputs  map[string]string `json:"publisherInputs"`
+	ConsumerInputs   map[string]string `json:"consumerInputs"`
+}
+
+func (c *Client) CreateSubscription(subscription Subscription) (*Subscription, error) {
+	created := Subscription{}
+	if err := c.doJSON(http.MethodPost, "/_apis/hooks/subscriptions", nil, subscription, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (c *Client) DeleteSubscription(id string) error {
+	return c.doJSON(http.MethodDelete, "/_apis/hooks/subscriptions/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func projectPath(project, path string) string {
+	return "/" + url.PathEscape(project) + path
+}
+
+// doJSON sends a request to the API of the organization, and decodes
+// the response into out. Work item operations are sent as JSON patches.
+func (c *Client) doJSON(method, path string, query url.Values, payload any, out any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	query.Set("api-version", apiVersion)
+	requestURL := fmt.Sprintf("%s/%s%s?%s", baseURL, url.PathEscape(c.Organization), path, query.Encode())
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("error marshaling request: %w", err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", c.authorization)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		contentType := "application/json"
+		if _, ok := payload.([]PatchOperation); ok {
+			contentType = "application/json-patch+json"
+		}
+
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+
+	defer res.Body.Close()
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return &APIError{StatusCode: res.StatusCode, Body: apiErrorMessage(responseBody)}
+	}
+
+	//
+	// Azure DevOps answers requests with invalid credentials
+	// with its sign-in page, instead of an error status.
+	//
+	if strings.HasPrefix(strings.TrimSpace(string(responseBody)), "<") {
+		return &APIError{StatusCode: http.StatusUnauthorized, Body: "credentials were not accepted"}
+	}
+
+	if out != nil && len(responseBody) > 0 {
+		if err := json.Unmarshal(responseBody, out); err != nil {
+			return fmt.Errorf("error decoding response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func apiErrorMessage(body []byte) string {
+	response := struct {
+		Message string `json:"message"`
+	}{}
+
+	if err := json.Unmarshal(body, &response); err == nil && response.Message != "" {
+		return response.Message
+	}
+
+	return string(body)
+}
@@ -0,0 +1,270 @@
+package azuredevops
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	ResourceTypeProject      = "project"
+	ResourceTypeRepository   = "repository"
+	ResourceTypePipeline     = "pipeline"
+	ResourceTypeWorkItemType = "workItemType"
+
+	PassedOutputChannel = "passed"
+	FailedOutputChannel = "failed"
+
+	WebhookSecretHeader = "X-SuperPlane-Webhook-Secret"
+)
+
+type NodeMetadata struct {
+	Project    *ProjectMetadata    `json:"project,omitempty" mapstructure:"project"`
+	Repository *RepositoryMetadata `json:"repository,omitempty" mapstructure:"repository"`
+	Pipeline   *PipelineMetadata   `json:"pipeline,omitempty" mapstructure:"pipeline"`
+}
+
+type ProjectMetadata struct {
+	ID   string `json:"id" mapstructure:"id"`
+	Name string `json:"name" mapstructure:"name"`
+}
+
+type RepositoryMetadata struct {
+	ID   string `json:"id" mapstructure:"id"`
+	Name string `json:"name" mapstructure:"name"`
+}
+
+type PipelineMetadata struct {
+	ID   int64  `json:"id" mapstructure:"id"`
+	Name string `json:"name" mapstructure:"name"`
+}
+
+func projectField() configuration.Field {
+	return configuration.Field{
+		Name:     "project",
+		Label:    "Project",
+		Type:     configuration.FieldTypeIntegrationResource,
+		Required: true,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type:           ResourceTypeProject,
+				UseNameAsValue: true,
+			},
+		},
+	}
+}
+
+func repositoryField() configuration.Field {
+	return configuration.Field{
+		Name:     "repository",
+		Label:    "Repository",
+		Type:     configuration.FieldTypeIntegrationResource,
+		Required: true,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type:           ResourceTypeRepository,
+				UseNameAsValue: true,
+				Parameters:     projectParameter(),
+			},
+		},
+	}
+}
+
+func pipelineField(required bool, description string) configuration.Field {
+	return configuration.Field{
+		Name:        "pipeline",
+		Label:       "Pipeline",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    required,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type:       ResourceTypePipeline,
+				Parameters: projectParameter(),
+			},
+		},
+	}
+}
+
+func projectParameter() []configuration.ParameterRef {
+	return []configuration.ParameterRef{
+		{
+			Name:      "project",
+			ValueFrom: &configuration.ParameterValueFrom{Field: "project"},
+		},
+	}
+}
+
+// fieldsField lets work items set any field, by its reference name.
+func fieldsField() configuration.Field {
+	return configuration.Field{
+		Name:        "fields",
+		Label:       "Fields",
+		Type:        configuration.FieldTypeList,
+		Required:    false,
+		Description: "Other fields, by reference name, like Microsoft.VSTS.Common.Priority",
+		TypeOptions: &configuration.TypeOptions{
+			List: &configuration.ListTypeOptions{
+				ItemLabel: "Field",
+				ItemDefinition: &configuration.ListItemDefinition{
+					Type: configuration.FieldTypeObject,
+					Schema: []configuration.Field{
+						{
+							Name:     "name",
+							Label:    "Name",
+							Type:     configuration.FieldTypeString,
+							Required: true,
+						},
+						{
+							Name:     "value",
+							Label:    "Value",
+							Type:     configuration.FieldTypeString,
+							Required: false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type FieldValue struct {
+	Name  string `json:"name" mapstructure:"name"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+func validateFields(fields []FieldValue) error {
+	for i, field := range fields {
+		if strings.TrimSpace(field.Name) == "" {
+			return fmt.Errorf("field %d: name is required", i+1)
+		}
+	}
+
+	return nil
+}
+
+func fieldOperations(fields []FieldValue) []PatchOperation {
+	operations := make([]PatchOperation, 0, len(fields))
+	for _, field := range fields {
+		operations = append(operations, PatchOperation{
+			Op:    "add",
+			Path:  "/fields/" + strings.TrimSpace(field.Name),
+			Value: field.Value,
+		})
+	}
+
+	return operations
+}
+
+// parseID parses the numeric IDs of runs, work items and pull requests.
+// Expressions are only resolved on execution, so they're not validated in setup.
+func parseID(name, value string) (int64, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if trimmed == "" {
+		return 0, fmt.Errorf("%s is required", name)
+	}
+
+	id, err := strconv.ParseInt(trimmed, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+
+	return id, nil
+}
+
+func isExpression(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+func validateProject(project string) (string, error) {
+	trimmed := strings.TrimSpace(project)
+	if trimmed == "" {
+		return "", fmt.Errorf("project is required")
+	}
+
+	return trimmed, nil
+}
+
+// ensureProjectInMetadata resolves the project, and optionally the repository
+// of a node, and stores their IDs, which service hook subscriptions need.
+func ensureProjectInMetadata(http core.HTTPContext, ctx core.MetadataWriter, integration core.IntegrationContext, project, repository string) (*NodeMetadata, error) {
+	project, err := validateProject(project)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeMetadata := NodeMetadata{}
+	if err := mapstructure.Decode(ctx.Get(), &nodeMetadata); err != nil {
+		return nil, fmt.Errorf("failed to decode node metadata: %w", err)
+	}
+
+	if nodeMetadata.Project != nil && nodeMetadata.Project.Name == project {
+		if repository == "" || (nodeMetadata.Repository != nil && nodeMetadata.Repository.Name == repository) {
+			return &nodeMetadata, nil
+		}
+	}
+
+	client, err := NewClient(http, integration)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := client.GetProject(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", project, err)
+	}
+
+	nodeMetadata = NodeMetadata{Project: &ProjectMetadata{ID: p.ID, Name: p.Name}}
+	if repository != "" {
+		r, err := client.GetRepository(project, repository)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repository %s: %w", repository, err)
+		}
+
+		nodeMetadata.Repository = &RepositoryMetadata{ID: r.ID, Name: r.Name}
+	}
+
+	return &nodeMetadata, ctx.Set(nodeMetadata)
+}
+
+func pipelineDisplayName(pipeline Pipeline) string {
+	folder := strings.Trim(strings.ReplaceAll(pipeline.Folder, "\\", "/"), "/")
+	if folder == "" {
+		return pipeline.Name
+	}
+
+	return folder + "/" + pipeline.Name
+}
+
+// verifyWebhook checks the secret that service hooks send as a header.
+func verifyWebhook(ctx core.WebhookRequestContext) (int, error) {
+	received := ctx.Headers.Get(WebhookSecretHeader)
+	if received == "" {
+		return http.StatusForbidden, fmt.Errorf("missing %s header", WebhookSecretHeader)
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error getting webhook secret: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(received), secret) != 1 {
+		return http.StatusForbidden, fmt.Errorf("invalid webhook secret")
+	}
+
+	return http.StatusOK, nil
+}
+
+// ServiceHookEvent is the payload service hooks send for every event.
+type ServiceHookEvent struct {
+	EventType          string         `json:"eventType"`
+	Resource           map[string]any `json:"resource"`
+	ResourceContainers map[string]any `json:"resourceContainers"`
+	CreatedDate        string         `json:"createdDate"`
+}
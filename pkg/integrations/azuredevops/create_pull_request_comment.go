@@ -0,0 +1,195 @@
+package azuredevops
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CreatePullRequestComment struct{}
+
+type CreatePullRequestCommentSpec struct {
+	Project     string `json:"project" mapstructure:"project"`
+	Repository  string `json:"repository" mapstructure:"repository"`
+	PullRequest string `json:"pullRequest" mapstructure:"pullRequest"`
+	Comment     string `json:"comment" mapstructure:"comment"`
+}
+
+func (c *CreatePullRequestComment) Name() string {
+	return "azuredevops.createPullRequestComment"
+}
+
+func (c *CreatePullRequestComment) Label() string {
+	return "Create Pull Request Comment"
+}
+
+func (c *CreatePullRequestComment) Description() string {
+	return "Comment on an Azure Repos pull request"
+}
+
+func (c *CreatePullRequestComment) Documentation() string {
+	return `The Create Pull Request Comment component adds a comment to a pull request in an Azure Repos Git repository, as a new thread.
+
+## Use Cases
+
+- **Build feedback**: Post test results or preview environment links on the pull request
+- **Review automation**: Leave a checklist or a summary for reviewers
+- **Deployment notes**: Comment once the changes of a pull request are deployed
+
+## Configuration
+
+- **Project**: The Azure DevOps project of the repository
+- **Repository**: The repository of the pull request
+- **Pull Request**: The ID of the pull request, for example ` + "`{{ root().data.resource.pullRequestId }}`" + `
+- **Comment**: The comment, in Markdown
+
+## Output
+
+The thread of the comment, with its ID, status and comments.`
+}
+
+func (c *CreatePullRequestComment) Icon() string {
+	return "message-square"
+}
+
+func (c *CreatePullRequestComment) Color() string {
+	return "blue"
+}
+
+func (c *CreatePullRequestComment) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreatePullRequestComment) Configuration() []configuration.Field {
+	return []configuration.Field{
+		projectField(),
+		repositoryField(),
+		{
+			Name:        "pullRequest",
+			Label:       "Pull Request",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "The ID of the pull request",
+		},
+		{
+			Name:        "comment",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeText,
+			Required:    true,
+			Description: "The comment, in Markdown",
+		},
+	}
+}
+
+func (c *CreatePullRequestComment) Setup(ctx core.SetupContext) error {
+	spec, err := decodeCreatePullRequestCommentSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if !isExpression(spec.PullRequest) {
+		if _, err := parseID("pull request", spec.PullRequest); err != nil {
+			return err
+		}
+	}
+
+	_, err = ensureProjectInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Project, spec.Repository)
+	return err
+}
+
+func (c *CreatePullRequestComment) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCreatePullRequestCommentSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	pullRequestID, err := parseID("pull request", spec.PullRequest)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	thread, err := client.CreatePullRequestThread(spec.Project, spec.Repository, pullRequestID, spec.Comment)
+	if err != nil {
+		return fmt.Errorf("failed to comment on pull request %d: %w", pullRequestID, err)
+	}
+
+	comments := make([]map[string]any, 0, len(thread.Comments))
+	for _, comment := range thread.Comments {
+		comments = append(comments, map[string]any{
+			"id":             comment.ID,
+			"content":        comment.Content,
+			"author":         comment.Author.DisplayName,
+			"published_date": comment.PublishedDate,
+		})
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"azuredevops.pullRequest.comment",
+		[]any{map[string]any{
+			"id":             thread.ID,
+			"pull_request":   pullRequestID,
+			"repository":     spec.Repository,
+			"status":         thread.Status,
+			"published_date": thread.PublishedDate,
+			"comments":       comments,
+		}},
+	)
+}
+
+func decodeCreatePullRequestCommentSpec(value any) (CreatePullRequestCommentSpec, error) {
+	spec := CreatePullRequestCommentSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	project, err := validateProject(spec.Project)
+	if err != nil {
+		return spec, err
+	}
+
+	spec.Project = project
+	spec.Repository = strings.TrimSpace(spec.Repository)
+	if spec.Repository == "" {
+		return spec, fmt.Errorf("repository is required")
+	}
+
+	if strings.TrimSpace(spec.PullRequest) == "" {
+		return spec, fmt.Errorf("pull request is required")
+	}
+
+	if strings.TrimSpace(spec.Comment) == "" {
+		return spec, fmt.Errorf("comment is required")
+	}
+
+	return spec, nil
+}
+
+func (c *CreatePullRequestComment) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreatePullRequestComment) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreatePullRequestComment) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *CreatePullRequestComment) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreatePullRequestComment) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
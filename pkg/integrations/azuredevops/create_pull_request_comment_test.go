@@ -0,0 +1,79 @@
+package azuredevops
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreatePullRequestComment__Setup(t *testing.T) {
+	t.Run("invalid pull request -> error", func(t *testing.T) {
+		err := (&CreatePullRequestComment{}).Setup(core.SetupContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"project": "web", "repository": "web-app", "pullRequest": "abc", "comment": "Hi"},
+		})
+
+		require.ErrorContains(t, err, `invalid pull request "abc"`)
+	})
+
+	t.Run("project and repository are stored in metadata", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		err := (&CreatePullRequestComment{}).Setup(core.SetupContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":"6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c","name":"web"}`),
+				jsonResponse(http.StatusOK, `{"id":"278d5cd2-584d-4b63-824a-2ba458937249","name":"web-app"}`),
+			}},
+			Integration:   testIntegration(),
+			Metadata:      metadata,
+			Configuration: map[string]any{"project": "web", "repository": "web-app", "pullRequest": "42", "comment": "Hi"},
+		})
+
+		require.NoError(t, err)
+		stored := metadata.Metadata.(NodeMetadata)
+		assert.Equal(t, "278d5cd2-584d-4b63-824a-2ba458937249", stored.Repository.ID)
+	})
+}
+
+func Test__CreatePullRequestComment__Execute(t *testing.T) {
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+		jsonResponse(http.StatusOK, `{
+			"id": 87,
+			"status": "active",
+			"comments": [{"id": 1, "content": "Preview is ready", "author": {"displayName": "SuperPlane"}}]
+		}`),
+	}}
+
+	executionState := &contexts.ExecutionStateContext{}
+	err := (&CreatePullRequestComment{}).Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"project":     "web",
+			"repository":  "web-app",
+			"pullRequest": "42",
+			"comment":     "Preview is ready",
+		},
+		HTTP:           httpContext,
+		Integration:    testIntegration(),
+		ExecutionState: executionState,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "azuredevops.pullRequest.comment", executionState.Type)
+	payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, int64(87), payload["id"])
+
+	require.Len(t, httpContext.Requests, 1)
+	assert.Equal(t, "/contoso/web/_apis/git/repositories/web-app/pullRequests/42/threads", httpContext.Requests[0].URL.Path)
+	body, err := io.ReadAll(httpContext.Requests[0].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"comments": [{"parentCommentId": 0, "content": "Preview is ready", "commentType": "text"}],
+		"status": "active"
+	}`, string(body))
+}
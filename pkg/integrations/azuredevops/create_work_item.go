@@ -0,0 +1,250 @@
+package azuredevops
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	FieldTitle        = "System.Title"
+	FieldDescription  = "System.Description"
+	FieldAssignedTo   = "System.AssignedTo"
+	FieldTags         = "System.Tags"
+	FieldState        = "System.State"
+	FieldHistory      = "System.History"
+	FieldWorkItemType = "System.WorkItemType"
+)
+
+type CreateWorkItem struct{}
+
+type CreateWorkItemSpec struct {
+	Project      string       `json:"project" mapstructure:"project"`
+	WorkItemType string       `json:"workItemType" mapstructure:"workItemType"`
+	Title        string       `json:"title" mapstructure:"title"`
+	Description  string       `json:"description" mapstructure:"description"`
+	AssignedTo   string       `json:"assignedTo" mapstructure:"assignedTo"`
+	Tags         []string     `json:"tags" mapstructure:"tags"`
+	Fields       []FieldValue `json:"fields" mapstructure:"fields"`
+}
+
+func (c *CreateWorkItem) Name() string {
+	return "azuredevops.createWorkItem"
+}
+
+func (c *CreateWorkItem) Label() string {
+	return "Create Work Item"
+}
+
+func (c *CreateWorkItem) Description() string {
+	return "Create an Azure Boards work item"
+}
+
+func (c *CreateWorkItem) Documentation() string {
+	return `The Create Work Item component creates a work item in Azure Boards.
+
+## Use Cases
+
+- **Incident tracking**: Create a bug when a deployment or a build fails
+- **Release tasks**: Create tasks for the manual steps of a release
+- **Follow-ups**: Create work items from alerts or from review comments
+
+## Configuration
+
+- **Project**: The Azure DevOps project of the work item
+- **Type**: The work item type, like Bug, Task or User Story
+- **Title**: The title of the work item
+- **Description**: Optional description, in HTML
+- **Assigned To**: Optional user to assign, by email or display name
+- **Tags**: Optional tags
+- **Fields**: Other fields, by reference name, like ` + "`Microsoft.VSTS.Common.Priority`" + `
+
+## Output
+
+The created work item, with its ID, revision, fields and a link to it.`
+}
+
+func (c *CreateWorkItem) Icon() string {
+	return "square-pen"
+}
+
+func (c *CreateWorkItem) Color() string {
+	return "blue"
+}
+
+func (c *CreateWorkItem) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateWorkItem) Configuration() []configuration.Field {
+	return []configuration.Field{
+		projectField(),
+		{
+			Name:     "workItemType",
+			Label:    "Type",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:           ResourceTypeWorkItemType,
+					UseNameAsValue: true,
+					Parameters:     projectParameter(),
+				},
+			},
+		},
+		{
+			Name:     "title",
+			Label:    "Title",
+			Type:     configuration.FieldTypeString,
+			Required: true,
+		},
+		{
+			Name:        "description",
+			Label:       "Description",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "The description, in HTML",
+		},
+		{
+			Name:        "assignedTo",
+			Label:       "Assigned To",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "The email or display name of the user to assign",
+		},
+		{
+			Name:     "tags",
+			Label:    "Tags",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Tag",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+		fieldsField(),
+	}
+}
+
+func (c *CreateWorkItem) Setup(ctx core.SetupContext) error {
+	spec, err := decodeCreateWorkItemSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureProjectInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Project, "")
+	return err
+}
+
+func (c *CreateWorkItem) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCreateWorkItemSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	operations := []PatchOperation{{Op: "add", Path: "/fields/" + FieldTitle, Value: spec.Title}}
+	if spec.Description != "" {
+		operations = append(operations, PatchOperation{Op: "add", Path: "/fields/" + FieldDescription, Value: spec.Description})
+	}
+
+	if spec.AssignedTo != "" {
+		operations = append(operations, PatchOperation{Op: "add", Path: "/fields/" + FieldAssignedTo, Value: spec.AssignedTo})
+	}
+
+	if len(spec.Tags) > 0 {
+		operations = append(operations, PatchOperation{Op: "add", Path: "/fields/" + FieldTags, Value: strings.Join(spec.Tags, "; ")})
+	}
+
+	operations = append(operations, fieldOperations(spec.Fields)...)
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	workItem, err := client.CreateWorkItem(spec.Project, spec.WorkItemType, operations)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", spec.WorkItemType, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"azuredevops.workItem",
+		[]any{workItemToMap(workItem)},
+	)
+}
+
+func decodeCreateWorkItemSpec(value any) (CreateWorkItemSpec, error) {
+	spec := CreateWorkItemSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	project, err := validateProject(spec.Project)
+	if err != nil {
+		return spec, err
+	}
+
+	spec.Project = project
+	spec.WorkItemType = strings.TrimSpace(spec.WorkItemType)
+	if spec.WorkItemType == "" {
+		return spec, fmt.Errorf("work item type is required")
+	}
+
+	spec.Title = strings.TrimSpace(spec.Title)
+	if spec.Title == "" {
+		return spec, fmt.Errorf("title is required")
+	}
+
+	spec.AssignedTo = strings.TrimSpace(spec.AssignedTo)
+	tags := []string{}
+	for _, tag := range spec.Tags {
+		if trimmed := strings.TrimSpace(tag); trimmed != "" {
+			tags = append(tags, trimmed)
+		}
+	}
+
+	spec.Tags = tags
+	return spec, validateFields(spec.Fields)
+}
+
+func workItemToMap(workItem *WorkItem) map[string]any {
+	return map[string]any{
+		"id":      workItem.ID,
+		"rev":     workItem.Rev,
+		"type":    workItem.Fields[FieldWorkItemType],
+		"title":   workItem.Fields[FieldTitle],
+		"state":   workItem.Fields[FieldState],
+		"fields":  workItem.Fields,
+		"url":     workItem.Links.HTML.Href,
+		"api_url": workItem.URL,
+	}
+}
+
+func (c *CreateWorkItem) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateWorkItem) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreateWorkItem) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *CreateWorkItem) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateWorkItem) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,67 @@
+package azuredevops
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreateWorkItem__Setup(t *testing.T) {
+	err := (&CreateWorkItem{}).Setup(core.SetupContext{
+		HTTP:          &contexts.HTTPContext{},
+		Integration:   testIntegration(),
+		Metadata:      &contexts.MetadataContext{},
+		Configuration: map[string]any{"project": "web", "workItemType": "Bug", "title": " "},
+	})
+
+	require.ErrorContains(t, err, "title is required")
+}
+
+func Test__CreateWorkItem__Execute(t *testing.T) {
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+		jsonResponse(http.StatusOK, `{
+			"id": 301,
+			"rev": 1,
+			"fields": {"System.WorkItemType": "Bug", "System.Title": "Deployment failed", "System.State": "New"},
+			"_links": {"html": {"href": "https://dev.azure.com/contoso/web/_workitems/edit/301"}}
+		}`),
+	}}
+
+	executionState := &contexts.ExecutionStateContext{}
+	err := (&CreateWorkItem{}).Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"project":      "web",
+			"workItemType": "Bug",
+			"title":        "Deployment failed",
+			"assignedTo":   "jamal@example.com",
+			"tags":         []any{"deployment", " ", "production"},
+			"fields":       []any{map[string]any{"name": "Microsoft.VSTS.Common.Priority", "value": "1"}},
+		},
+		HTTP:           httpContext,
+		Integration:    testIntegration(),
+		ExecutionState: executionState,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "azuredevops.workItem", executionState.Type)
+	payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, int64(301), payload["id"])
+	assert.Equal(t, "https://dev.azure.com/contoso/web/_workitems/edit/301", payload["url"])
+
+	require.Len(t, httpContext.Requests, 1)
+	assert.Equal(t, "/contoso/web/_apis/wit/workitems/$Bug", httpContext.Requests[0].URL.Path)
+	assert.Equal(t, "application/json-patch+json", httpContext.Requests[0].Header.Get("Content-Type"))
+	body, err := io.ReadAll(httpContext.Requests[0].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"op": "add", "path": "/fields/System.Title", "value": "Deployment failed"},
+		{"op": "add", "path": "/fields/System.AssignedTo", "value": "jamal@example.com"},
+		{"op": "add", "path": "/fields/System.Tags", "value": "deployment; production"},
+		{"op": "add", "path": "/fields/Microsoft.VSTS.Common.Priority", "value": "1"}
+	]`, string(body))
+}
@@ -0,0 +1,88 @@
+package azuredevops
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_data_on_push.json
+var exampleDataOnPushBytes []byte
+
+var exampleDataOnPushOnce sync.Once
+var exampleDataOnPush map[string]any
+
+func (t *OnPush) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnPushOnce, exampleDataOnPushBytes, &exampleDataOnPush)
+}
+
+//go:embed example_data_on_pull_request.json
+var exampleDataOnPullRequestBytes []byte
+
+var exampleDataOnPullRequestOnce sync.Once
+var exampleDataOnPullRequest map[string]any
+
+func (t *OnPullRequest) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnPullRequestOnce, exampleDataOnPullRequestBytes, &exampleDataOnPullRequest)
+}
+
+//go:embed example_data_on_build_completed.json
+var exampleDataOnBuildCompletedBytes []byte
+
+var exampleDataOnBuildCompletedOnce sync.Once
+var exampleDataOnBuildCompleted map[string]any
+
+func (t *OnBuildCompleted) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnBuildCompletedOnce, exampleDataOnBuildCompletedBytes, &exampleDataOnBuildCompleted)
+}
+
+//go:embed example_output_run_pipeline.json
+var exampleOutputRunPipelineBytes []byte
+
+var exampleOutputRunPipelineOnce sync.Once
+var exampleOutputRunPipeline map[string]any
+
+func (c *RunPipeline) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputRunPipelineOnce, exampleOutputRunPipelineBytes, &exampleOutputRunPipeline)
+}
+
+//go:embed example_output_approve_check.json
+var exampleOutputApproveCheckBytes []byte
+
+var exampleOutputApproveCheckOnce sync.Once
+var exampleOutputApproveCheck map[string]any
+
+func (c *ApproveCheck) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputApproveCheckOnce, exampleOutputApproveCheckBytes, &exampleOutputApproveCheck)
+}
+
+//go:embed example_output_create_work_item.json
+var exampleOutputCreateWorkItemBytes []byte
+
+var exampleOutputCreateWorkItemOnce sync.Once
+var exampleOutputCreateWorkItem map[string]any
+
+func (c *CreateWorkItem) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateWorkItemOnce, exampleOutputCreateWorkItemBytes, &exampleOutputCreateWorkItem)
+}
+
+//go:embed example_output_update_work_item.json
+var exampleOutputUpdateWorkItemBytes []byte
+
+var exampleOutputUpdateWorkItemOnce sync.Once
+var exampleOutputUpdateWorkItem map[string]any
+
+func (c *UpdateWorkItem) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputUpdateWorkItemOnce, exampleOutputUpdateWorkItemBytes, &exampleOutputUpdateWorkItem)
+}
+
+//go:embed example_output_create_pull_request_comment.json
+var exampleOutputCreatePullRequestCommentBytes []byte
+
+var exampleOutputCreatePullRequestCommentOnce sync.Once
+var exampleOutputCreatePullRequestComment map[string]any
+
+func (c *CreatePullRequestComment) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreatePullRequestCommentOnce, exampleOutputCreatePullRequestCommentBytes, &exampleOutputCreatePullRequestComment)
+}
@@ -0,0 +1,236 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	EventTypeBuildComplete = "build.complete"
+
+	RunResultSucceeded          = "succeeded"
+	RunResultPartiallySucceeded = "partiallySucceeded"
+	RunResultFailed             = "failed"
+	RunResultCanceled           = "canceled"
+)
+
+var runResultOptions = []configuration.FieldOption{
+	{Label: "Succeeded", Value: RunResultSucceeded},
+	{Label: "Partially succeeded", Value: RunResultPartiallySucceeded},
+	{Label: "Failed", Value: RunResultFailed},
+	{Label: "Canceled", Value: RunResultCanceled},
+}
+
+type OnBuildCompleted struct{}
+
+type OnBuildCompletedConfiguration struct {
+	Project  string                    `json:"project" mapstructure:"project"`
+	Pipeline string                    `json:"pipeline" mapstructure:"pipeline"`
+	Results  []string                  `json:"results" mapstructure:"results"`
+	Branches []configuration.Predicate `json:"branches" mapstructure:"branches"`
+}
+
+func (p *OnBuildCompleted) Name() string {
+	return "azuredevops.onBuildCompleted"
+}
+
+func (p *OnBuildCompleted) Label() string {
+	return "On Build Completed"
+}
+
+func (p *OnBuildCompleted) Description() string {
+	return "Listen to completed Azure Pipelines builds"
+}
+
+func (p *OnBuildCompleted) Documentation() string {
+	return `The On Build Completed trigger starts a workflow execution when an Azure Pipelines build completes.
+
+## Use Cases
+
+- **Deployments**: Deploy when the build of the main branch succeeds
+- **Failure notifications**: Notify the team when a build fails
+- **Cross-project workflows**: Continue in other systems once a build completes
+
+## Configuration
+
+- **Project**: The Azure DevOps project of the pipeline
+- **Pipeline**: Optional. Only builds of this pipeline start an execution. All pipelines of the project when empty.
+- **Results**: The build results to listen to
+- **Branches**: Optional. Only builds of matching branches, like ` + "`refs/heads/main`" + `, start an execution.
+
+## Event Data
+
+The event is the service hook payload of the build:
+- **resource.id** and **resource.buildNumber**: The build
+- **resource.result**: succeeded, partiallySucceeded, failed or canceled
+- **resource.definition**: The pipeline of the build
+- **resource.sourceBranch** and **resource.sourceVersion**: What was built
+
+## Webhook Setup
+
+This trigger automatically creates a service hook subscription in the project. The subscription is managed by SuperPlane and is deleted when the trigger is removed.`
+}
+
+func (p *OnBuildCompleted) Icon() string {
+	return "azuredevops"
+}
+
+func (p *OnBuildCompleted) Color() string {
+	return "blue"
+}
+
+func (p *OnBuildCompleted) Configuration() []configuration.Field {
+	return []configuration.Field{
+		projectField(),
+		pipelineField(false, "Only listen to builds of this pipeline"),
+		{
+			Name:     "results",
+			Label:    "Results",
+			Type:     configuration.FieldTypeMultiSelect,
+			Required: true,
+			Default:  []string{RunResultSucceeded, RunResultFailed},
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: runResultOptions,
+				},
+			},
+		},
+		{
+			Name:     "branches",
+			Label:    "Branches",
+			Type:     configuration.FieldTypeAnyPredicateList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+	}
+}
+
+func (p *OnBuildCompleted) Setup(ctx core.TriggerContext) error {
+	config := OnBuildCompletedConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if len(config.Results) == 0 {
+		return fmt.Errorf("at least one result is required")
+	}
+
+	metadata, err := ensureProjectInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, config.Project, "")
+	if err != nil {
+		return err
+	}
+
+	if config.Pipeline != "" {
+		if err := ensurePipelineInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, metadata, config.Pipeline); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		EventTypes: []string{EventTypeBuildComplete},
+		ProjectID:  metadata.Project.ID,
+	})
+}
+
+func (p *OnBuildCompleted) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (p *OnBuildCompleted) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (p *OnBuildCompleted) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	if status, err := verifyWebhook(ctx); err != nil {
+		return status, nil, err
+	}
+
+	data := map[string]any{}
+	if err := json.Unmarshal(ctx.Body, &data); err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	if data["eventType"] != EventTypeBuildComplete {
+		return http.StatusOK, nil, nil
+	}
+
+	config := OnBuildCompletedConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	resource, _ := data["resource"].(map[string]any)
+	result, _ := resource["result"].(string)
+	if !slices.Contains(config.Results, result) {
+		return http.StatusOK, nil, nil
+	}
+
+	if config.Pipeline != "" && !matchesPipeline(config.Pipeline, resource) {
+		return http.StatusOK, nil, nil
+	}
+
+	if len(config.Branches) > 0 {
+		branch, _ := resource["sourceBranch"].(string)
+		if !configuration.MatchesAnyPredicate(config.Branches, branch) {
+			return http.StatusOK, nil, nil
+		}
+	}
+
+	if err := ctx.Events.Emit("azuredevops.build.completed", data); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (p *OnBuildCompleted) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+func matchesPipeline(pipeline string, resource map[string]any) bool {
+	pipelineID, err := strconv.ParseInt(strings.TrimSpace(pipeline), 10, 64)
+	if err != nil {
+		return false
+	}
+
+	definition, _ := resource["definition"].(map[string]any)
+	definitionID, ok := definition["id"].(float64)
+	return ok && int64(definitionID) == pipelineID
+}
+
+// ensurePipelineInMetadata stores the name of the pipeline of a node, which is configured by its ID.
+func ensurePipelineInMetadata(http core.HTTPContext, ctx core.MetadataWriter, integration core.IntegrationContext, metadata *NodeMetadata, pipeline string) error {
+	pipelineID, err := strconv.ParseInt(strings.TrimSpace(pipeline), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid pipeline %q", pipeline)
+	}
+
+	if metadata.Pipeline != nil && metadata.Pipeline.ID == pipelineID {
+		return nil
+	}
+
+	client, err := NewClient(http, integration)
+	if err != nil {
+		return err
+	}
+
+	p, err := client.GetPipeline(metadata.Project.Name, pipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline %d: %w", pipelineID, err)
+	}
+
+	metadata.Pipeline = &PipelineMetadata{ID: p.ID, Name: pipelineDisplayName(*p)}
+	return ctx.Set(*metadata)
+}
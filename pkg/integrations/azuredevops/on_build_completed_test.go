@@ -0,0 +1,88 @@
+package azuredevops
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnBuildCompleted__Setup(t *testing.T) {
+	t.Run("no results -> error", func(t *testing.T) {
+		err := (&OnBuildCompleted{}).Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"project": "web", "results": []string{}},
+		})
+
+		require.ErrorContains(t, err, "at least one result is required")
+	})
+
+	t.Run("webhook is requested for the project", func(t *testing.T) {
+		integration := testIntegration()
+		metadata := &contexts.MetadataContext{}
+		err := (&OnBuildCompleted{}).Setup(core.TriggerContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":"6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c","name":"web"}`),
+				jsonResponse(http.StatusOK, `{"id":12,"name":"web-ci","folder":"\\"}`),
+			}},
+			Integration:   integration,
+			Metadata:      metadata,
+			Configuration: map[string]any{"project": "web", "pipeline": "12", "results": []string{RunResultFailed}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integration.WebhookRequests, 1)
+		assert.Equal(t, WebhookConfiguration{
+			EventTypes: []string{EventTypeBuildComplete},
+			ProjectID:  "6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c",
+		}, integration.WebhookRequests[0])
+		assert.Equal(t, "web-ci", metadata.Metadata.(NodeMetadata).Pipeline.Name)
+	})
+}
+
+func Test__OnBuildCompleted__HandleWebhook(t *testing.T) {
+	trigger := &OnBuildCompleted{}
+	config := map[string]any{
+		"project":  "web",
+		"pipeline": "12",
+		"results":  []string{RunResultFailed},
+	}
+
+	build := func(definitionID int, result string) string {
+		return fmt.Sprintf(`{"eventType":"build.complete","resource":{"id":1234,"result":%q,"definition":{"id":%d}}}`, result, definitionID)
+	}
+
+	t.Run("result not selected -> event is not emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		code, _, err := trigger.HandleWebhook(serviceHookRequest(build(12, RunResultSucceeded), config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("other pipeline -> event is not emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		code, _, err := trigger.HandleWebhook(serviceHookRequest(build(13, RunResultFailed), config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("failed build of the pipeline -> event is emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		code, _, err := trigger.HandleWebhook(serviceHookRequest(build(12, RunResultFailed), config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "azuredevops.build.completed", events.Payloads[0].Type)
+	})
+}
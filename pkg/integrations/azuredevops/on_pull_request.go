@@ -0,0 +1,217 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	PullRequestActionCreated = "created"
+	PullRequestActionUpdated = "updated"
+
+	EventTypePullRequestCreated = "git.pullrequest.created"
+	EventTypePullRequestUpdated = "git.pullrequest.updated"
+)
+
+var pullRequestEventTypes = map[string]string{
+	PullRequestActionCreated: EventTypePullRequestCreated,
+	PullRequestActionUpdated: EventTypePullRequestUpdated,
+}
+
+type OnPullRequest struct{}
+
+type OnPullRequestConfiguration struct {
+	Project        string                    `json:"project" mapstructure:"project"`
+	Repository     string                    `json:"repository" mapstructure:"repository"`
+	Actions        []string                  `json:"actions" mapstructure:"actions"`
+	TargetBranches []configuration.Predicate `json:"targetBranches" mapstructure:"targetBranches"`
+}
+
+func (p *OnPullRequest) Name() string {
+	return "azuredevops.onPullRequest"
+}
+
+func (p *OnPullRequest) Label() string {
+	return "On Pull Request"
+}
+
+func (p *OnPullRequest) Description() string {
+	return "Listen to Azure Repos pull requests being created or updated"
+}
+
+func (p *OnPullRequest) Documentation() string {
+	return `The On Pull Request trigger starts a workflow execution when a pull request in an Azure Repos Git repository is created or updated.
+
+## Use Cases
+
+- **Preview environments**: Deploy a preview environment for each pull request
+- **Review automation**: Run checks and comment on pull requests
+- **Release workflows**: Deploy when pull requests are completed
+
+## Configuration
+
+- **Project**: The Azure DevOps project of the repository
+- **Repository**: The repository to monitor
+- **Actions**: Created, updated, or both. Updates include new pushes, votes, status changes and completions.
+- **Target Branches**: Optional. Only pull requests into matching branches, like ` + "`refs/heads/main`" + `, start an execution.
+
+## Event Data
+
+The event is the service hook payload of the pull request, with:
+- **action**: created or updated
+- **resource**: The pull request, with its ID, title, status, source and target branches, and reviewers
+- **message.text**: What changed, for updates
+
+## Webhook Setup
+
+This trigger automatically creates service hook subscriptions in the project. The subscriptions are managed by SuperPlane and are deleted when the trigger is removed.`
+}
+
+func (p *OnPullRequest) Icon() string {
+	return "azuredevops"
+}
+
+func (p *OnPullRequest) Color() string {
+	return "blue"
+}
+
+func (p *OnPullRequest) Configuration() []configuration.Field {
+	return []configuration.Field{
+		projectField(),
+		repositoryField(),
+		{
+			Name:     "actions",
+			Label:    "Actions",
+			Type:     configuration.FieldTypeMultiSelect,
+			Required: true,
+			Default:  []string{PullRequestActionCreated, PullRequestActionUpdated},
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Created", Value: PullRequestActionCreated},
+						{Label: "Updated", Value: PullRequestActionUpdated},
+					},
+				},
+			},
+		},
+		{
+			Name:     "targetBranches",
+			Label:    "Target Branches",
+			Type:     configuration.FieldTypeAnyPredicateList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+	}
+}
+
+func (p *OnPullRequest) Setup(ctx core.TriggerContext) error {
+	config := OnPullRequestConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	eventTypes, err := pullRequestEvents(config.Actions)
+	if err != nil {
+		return err
+	}
+
+	if config.Repository == "" {
+		return fmt.Errorf("repository is required")
+	}
+
+	metadata, err := ensureProjectInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, config.Project, config.Repository)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		EventTypes:   eventTypes,
+		ProjectID:    metadata.Project.ID,
+		RepositoryID: metadata.Repository.ID,
+	})
+}
+
+func (p *OnPullRequest) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (p *OnPullRequest) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (p *OnPullRequest) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	if status, err := verifyWebhook(ctx); err != nil {
+		return status, nil, err
+	}
+
+	data := map[string]any{}
+	if err := json.Unmarshal(ctx.Body, &data); err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	config := OnPullRequestConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	eventType, _ := data["eventType"].(string)
+	action := ""
+	for _, a := range config.Actions {
+		if pullRequestEventTypes[a] == eventType {
+			action = a
+		}
+	}
+
+	if action == "" {
+		return http.StatusOK, nil, nil
+	}
+
+	if len(config.TargetBranches) > 0 {
+		resource, _ := data["resource"].(map[string]any)
+		targetRef, _ := resource["targetRefName"].(string)
+		if !configuration.MatchesAnyPredicate(config.TargetBranches, targetRef) {
+			return http.StatusOK, nil, nil
+		}
+	}
+
+	data["action"] = action
+	if err := ctx.Events.Emit("azuredevops.pullRequest", data); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (p *OnPullRequest) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+func pullRequestEvents(actions []string) ([]string, error) {
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("at least one action is required")
+	}
+
+	eventTypes := []string{}
+	for _, action := range actions {
+		eventType, ok := pullRequestEventTypes[action]
+		if !ok {
+			return nil, fmt.Errorf("invalid action %q", action)
+		}
+
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	return eventTypes, nil
+}
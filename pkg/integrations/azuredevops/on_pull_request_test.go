@@ -0,0 +1,90 @@
+package azuredevops
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnPullRequest__Setup(t *testing.T) {
+	t.Run("no actions -> error", func(t *testing.T) {
+		err := (&OnPullRequest{}).Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"project": "web", "repository": "web-app", "actions": []string{}},
+		})
+
+		require.ErrorContains(t, err, "at least one action is required")
+	})
+
+	t.Run("webhook is requested for the selected actions", func(t *testing.T) {
+		integration := testIntegration()
+		err := (&OnPullRequest{}).Setup(core.TriggerContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":"6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c","name":"web"}`),
+				jsonResponse(http.StatusOK, `{"id":"278d5cd2-584d-4b63-824a-2ba458937249","name":"web-app"}`),
+			}},
+			Integration: integration,
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"project":    "web",
+				"repository": "web-app",
+				"actions":    []string{PullRequestActionCreated, PullRequestActionUpdated},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integration.WebhookRequests, 1)
+		webhookRequest := integration.WebhookRequests[0].(WebhookConfiguration)
+		assert.Equal(t, []string{EventTypePullRequestCreated, EventTypePullRequestUpdated}, webhookRequest.EventTypes)
+		assert.Equal(t, "278d5cd2-584d-4b63-824a-2ba458937249", webhookRequest.RepositoryID)
+	})
+}
+
+func Test__OnPullRequest__HandleWebhook(t *testing.T) {
+	trigger := &OnPullRequest{}
+	config := map[string]any{
+		"project":        "web",
+		"repository":     "web-app",
+		"actions":        []string{PullRequestActionCreated},
+		"targetBranches": []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: "refs/heads/main"}},
+	}
+
+	t.Run("action not selected -> event is not emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"eventType":"git.pullrequest.updated","resource":{"targetRefName":"refs/heads/main"}}`
+		code, _, err := trigger.HandleWebhook(serviceHookRequest(body, config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("other target branch -> event is not emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"eventType":"git.pullrequest.created","resource":{"targetRefName":"refs/heads/release"}}`
+		code, _, err := trigger.HandleWebhook(serviceHookRequest(body, config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("pull request created -> event is emitted with action", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"eventType":"git.pullrequest.created","resource":{"pullRequestId":42,"targetRefName":"refs/heads/main"}}`
+		code, _, err := trigger.HandleWebhook(serviceHookRequest(body, config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "azuredevops.pullRequest", events.Payloads[0].Type)
+		assert.Equal(t, PullRequestActionCreated, events.Payloads[0].Data.(map[string]any)["action"])
+	})
+}
@@ -0,0 +1,184 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const EventTypeGitPush = "git.push"
+
+type OnPush struct{}
+
+type OnPushConfiguration struct {
+	Project    string                    `json:"project" mapstructure:"project"`
+	Repository string                    `json:"repository" mapstructure:"repository"`
+	Refs       []configuration.Predicate `json:"refs" mapstructure:"refs"`
+}
+
+func (p *OnPush) Name() string {
+	return "azuredevops.onPush"
+}
+
+func (p *OnPush) Label() string {
+	return "On Push"
+}
+
+func (p *OnPush) Description() string {
+	return "Listen to pushes to Azure Repos"
+}
+
+func (p *OnPush) Documentation() string {
+	return `The On Push trigger starts a workflow execution when code is pushed to an Azure Repos Git repository.
+
+## Use Cases
+
+- **CI/CD automation**: Build and deploy on pushes to the main branch
+- **Release tags**: Start release workflows when tags are pushed
+- **Notifications**: Notify teams of pushes to important branches
+
+## Configuration
+
+- **Project**: The Azure DevOps project of the repository
+- **Repository**: The repository to monitor
+- **Refs**: The refs to monitor, like ` + "`refs/heads/main`" + ` or ` + "`refs/tags/v*`" + `
+
+## Event Data
+
+The event is the service hook payload of the push:
+- **resource.refUpdates**: The updated refs, with their old and new commits
+- **resource.commits**: The pushed commits
+- **resource.pushedBy**: Who pushed
+- **resource.repository**: The repository
+
+## Webhook Setup
+
+This trigger automatically creates a service hook subscription in the project. The subscription is managed by SuperPlane and is deleted when the trigger is removed.`
+}
+
+func (p *OnPush) Icon() string {
+	return "azuredevops"
+}
+
+func (p *OnPush) Color() string {
+	return "blue"
+}
+
+func (p *OnPush) Configuration() []configuration.Field {
+	return []configuration.Field{
+		projectField(),
+		repositoryField(),
+		{
+			Name:     "refs",
+			Label:    "Refs",
+			Type:     configuration.FieldTypeAnyPredicateList,
+			Required: true,
+			Default: []map[string]any{
+				{
+					"type":  configuration.PredicateTypeEquals,
+					"value": "refs/heads/main",
+				},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+	}
+}
+
+func (p *OnPush) Setup(ctx core.TriggerContext) error {
+	config := OnPushConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.Repository == "" {
+		return fmt.Errorf("repository is required")
+	}
+
+	metadata, err := ensureProjectInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, config.Project, config.Repository)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		EventTypes:   []string{EventTypeGitPush},
+		ProjectID:    metadata.Project.ID,
+		RepositoryID: metadata.Repository.ID,
+	})
+}
+
+func (p *OnPush) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (p *OnPush) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (p *OnPush) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	if status, err := verifyWebhook(ctx); err != nil {
+		return status, nil, err
+	}
+
+	data := map[string]any{}
+	if err := json.Unmarshal(ctx.Body, &data); err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	if data["eventType"] != EventTypeGitPush {
+		return http.StatusOK, nil, nil
+	}
+
+	config := OnPushConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if !matchesAnyRef(config.Refs, data) {
+		return http.StatusOK, nil, nil
+	}
+
+	if err := ctx.Events.Emit("azuredevops.push", data); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (p *OnPush) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+// matchesAnyRef tells if any of the refs updated by a push matches.
+func matchesAnyRef(refs []configuration.Predicate, data map[string]any) bool {
+	resource, ok := data["resource"].(map[string]any)
+	if !ok {
+		return false
+	}
+
+	refUpdates, ok := resource["refUpdates"].([]any)
+	if !ok {
+		return false
+	}
+
+	for _, refUpdate := range refUpdates {
+		update, ok := refUpdate.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		name, _ := update["name"].(string)
+		if name != "" && configuration.MatchesAnyPredicate(refs, name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package azuredevops
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func serviceHookRequest(body string, configuration map[string]any, events *contexts.EventContext) core.WebhookRequestContext {
+	headers := http.Header{}
+	headers.Set(WebhookSecretHeader, "test-secret")
+
+	return core.WebhookRequestContext{
+		Body:          []byte(body),
+		Headers:       headers,
+		Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+		Configuration: configuration,
+		Events:        events,
+	}
+}
+
+func Test__OnPush__Setup(t *testing.T) {
+	t.Run("repository is required", func(t *testing.T) {
+		err := (&OnPush{}).Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"project": "web"},
+		})
+
+		require.ErrorContains(t, err, "repository is required")
+	})
+
+	t.Run("webhook is requested for the repository", func(t *testing.T) {
+		integration := testIntegration()
+		err := (&OnPush{}).Setup(core.TriggerContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":"6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c","name":"web"}`),
+				jsonResponse(http.StatusOK, `{"id":"278d5cd2-584d-4b63-824a-2ba458937249","name":"web-app"}`),
+			}},
+			Integration:   integration,
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"project": "web", "repository": "web-app"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integration.WebhookRequests, 1)
+		assert.Equal(t, WebhookConfiguration{
+			EventTypes:   []string{EventTypeGitPush},
+			ProjectID:    "6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c",
+			RepositoryID: "278d5cd2-584d-4b63-824a-2ba458937249",
+		}, integration.WebhookRequests[0])
+	})
+}
+
+func Test__OnPush__HandleWebhook(t *testing.T) {
+	trigger := &OnPush{}
+	config := map[string]any{
+		"project":    "web",
+		"repository": "web-app",
+		"refs":       []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: "refs/heads/main"}},
+	}
+
+	pushTo := func(ref string) string {
+		return `{"eventType":"git.push","resource":{"refUpdates":[{"name":"` + ref + `"}]}}`
+	}
+
+	t.Run("missing secret -> 403", func(t *testing.T) {
+		request := serviceHookRequest(pushTo("refs/heads/main"), config, &contexts.EventContext{})
+		request.Headers.Del(WebhookSecretHeader)
+
+		code, _, err := trigger.HandleWebhook(request)
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.ErrorContains(t, err, "missing X-SuperPlane-Webhook-Secret header")
+	})
+
+	t.Run("invalid secret -> 403", func(t *testing.T) {
+		request := serviceHookRequest(pushTo("refs/heads/main"), config, &contexts.EventContext{})
+		request.Headers.Set(WebhookSecretHeader, "wrong")
+
+		code, _, err := trigger.HandleWebhook(request)
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.ErrorContains(t, err, "invalid webhook secret")
+	})
+
+	t.Run("other ref -> event is not emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		code, _, err := trigger.HandleWebhook(serviceHookRequest(pushTo("refs/heads/feature"), config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("matching ref -> event is emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		code, _, err := trigger.HandleWebhook(serviceHookRequest(pushTo("refs/heads/main"), config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "azuredevops.push", events.Payloads[0].Type)
+	})
+}
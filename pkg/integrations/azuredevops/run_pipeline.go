@@ -0,0 +1,409 @@
+package azuredevops
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	RunPipelinePayloadType   = "azuredevops.run.finished"
+	RunPipelinePollAction    = "poll"
+	RunPipelinePollInterval  = 10 * time.Second
+	RunPipelineMaxPollErrors = 5
+
+	RunStateCompleted = "completed"
+)
+
+type RunPipeline struct{}
+
+type RunPipelineSpec struct {
+	Project    string                 `json:"project" mapstructure:"project"`
+	Pipeline   string                 `json:"pipeline" mapstructure:"pipeline"`
+	Branch     string                 `json:"branch" mapstructure:"branch"`
+	Variables  []RunPipelineVariable  `json:"variables" mapstructure:"variables"`
+	Parameters []RunPipelineParameter `json:"parameters" mapstructure:"parameters"`
+}
+
+type RunPipelineVariable struct {
+	Name   string `json:"name" mapstructure:"name"`
+	Value  string `json:"value" mapstructure:"value"`
+	Secret bool   `json:"secret" mapstructure:"secret"`
+}
+
+type RunPipelineParameter struct {
+	Name  string `json:"name" mapstructure:"name"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+type RunPipelineMetadata struct {
+	Project        string `json:"project" mapstructure:"project"`
+	PipelineID     int64  `json:"pipelineId" mapstructure:"pipelineId"`
+	RunID          int64  `json:"runId" mapstructure:"runId"`
+	URL            string `json:"url" mapstructure:"url"`
+	StartedAt      string `json:"startedAt" mapstructure:"startedAt"`
+	Status         string `json:"status" mapstructure:"status"`
+	PollErrorCount int    `json:"pollErrorCount,omitempty" mapstructure:"pollErrorCount"`
+}
+
+func (c *RunPipeline) Name() string {
+	return "azuredevops.runPipeline"
+}
+
+func (c *RunPipeline) Label() string {
+	return "Run Pipeline"
+}
+
+func (c *RunPipeline) Description() string {
+	return "Queue an Azure Pipelines run and wait for its result"
+}
+
+func (c *RunPipeline) Documentation() string {
+	return `The Run Pipeline component queues a run of an Azure Pipelines pipeline, and waits until the run completes.
+
+## Use Cases
+
+- **CI/CD orchestration**: Run Azure pipelines as steps of a workflow
+- **Deployments**: Run a deployment pipeline with the version to deploy as a parameter
+- **Gated releases**: Only continue once the pipeline succeeds
+
+## Configuration
+
+- **Project**: The Azure DevOps project of the pipeline
+- **Pipeline**: The pipeline to run
+- **Branch**: Optional. The branch to run the pipeline on, like ` + "`main`" + ` or ` + "`refs/heads/main`" + `. The default branch of the pipeline when empty.
+- **Variables**: Pipeline variables. The variables must be settable at queue time. Secret variables are hidden in the logs.
+- **Parameters**: Runtime parameters of YAML pipelines
+
+## Output Channels
+
+- **Passed**: The run succeeded
+- **Failed**: The run failed, partially succeeded or was canceled
+
+## Notes
+
+The run is followed until it completes by polling Azure DevOps every 10 seconds. Cancelling the execution cancels the run.`
+}
+
+func (c *RunPipeline) Icon() string {
+	return "play"
+}
+
+func (c *RunPipeline) Color() string {
+	return "blue"
+}
+
+func (c *RunPipeline) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: PassedOutputChannel, Label: "Passed"},
+		{Name: FailedOutputChannel, Label: "Failed"},
+	}
+}
+
+func (c *RunPipeline) Configuration() []configuration.Field {
+	return []configuration.Field{
+		projectField(),
+		pipelineField(true, "The pipeline to run"),
+		{
+			Name:        "branch",
+			Label:       "Branch",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "The branch to run the pipeline on. The default branch of the pipeline when empty.",
+		},
+		{
+			Name:     "variables",
+			Label:    "Variables",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Variable",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "name",
+								Label:    "Name",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+							{
+								Name:     "value",
+								Label:    "Value",
+								Type:     configuration.FieldTypeString,
+								Required: false,
+							},
+							{
+								Name:     "secret",
+								Label:    "Secret",
+								Type:     configuration.FieldTypeBool,
+								Required: false,
+								Default:  false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:     "parameters",
+			Label:    "Parameters",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Parameter",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "name",
+								Label:    "Name",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+							{
+								Name:     "value",
+								Label:    "Value",
+								Type:     configuration.FieldTypeString,
+								Required: false,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *RunPipeline) Setup(ctx core.SetupContext) error {
+	spec, err := decodeRunPipelineSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := ensureProjectInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Project, "")
+	if err != nil {
+		return err
+	}
+
+	return ensurePipelineInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, metadata, spec.Pipeline)
+}
+
+func (c *RunPipeline) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeRunPipelineSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	pipelineID, err := parseID("pipeline", spec.Pipeline)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	run, err := client.RunPipeline(spec.Project, pipelineID, runPipelineRequest(spec))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to run pipeline %d: %v", pipelineID, err))
+	}
+
+	metadata := RunPipelineMetadata{
+		Project:    spec.Project,
+		PipelineID: pipelineID,
+		RunID:      run.ID,
+		URL:        run.Links.Web.Href,
+		StartedAt:  time.Now().UTC().Format(time.RFC3339),
+		Status:     run.State,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(RunPipelinePollAction, map[string]any{}, RunPipelinePollInterval)
+}
+
+func (c *RunPipeline) Hooks() []core.Hook {
+	return []core.Hook{{Name: RunPipelinePollAction, Type: core.HookTypeInternal}}
+}
+
+func (c *RunPipeline) HandleHook(ctx core.ActionHookContext) error {
+	switch ctx.Name {
+	case RunPipelinePollAction:
+		return c.poll(ctx)
+	default:
+		return fmt.Errorf("unknown hook: %s", ctx.Name)
+	}
+}
+
+func (c *RunPipeline) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := RunPipelineMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	run, err := client.GetRun(metadata.Project, metadata.PipelineID, metadata.RunID)
+	if err != nil {
+		return c.handlePollError(ctx, metadata, err)
+	}
+
+	metadata.PollErrorCount = 0
+	metadata.Status = run.State
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	if run.State != RunStateCompleted {
+		return ctx.Requests.ScheduleActionCall(RunPipelinePollAction, map[string]any{}, RunPipelinePollInterval)
+	}
+
+	channel := PassedOutputChannel
+	if run.Result != RunResultSucceeded {
+		channel = FailedOutputChannel
+	}
+
+	return ctx.ExecutionState.Emit(channel, RunPipelinePayloadType, []any{runToMap(metadata.Project, run)})
+}
+
+func (c *RunPipeline) handlePollError(ctx core.ActionHookContext, metadata RunPipelineMetadata, err error) error {
+	metadata.PollErrorCount++
+	if setErr := ctx.Metadata.Set(metadata); setErr != nil {
+		return setErr
+	}
+
+	if IsNotFound(err) || metadata.PollErrorCount >= RunPipelineMaxPollErrors {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get run %d: %v", metadata.RunID, err))
+	}
+
+	return ctx.Requests.ScheduleActionCall(RunPipelinePollAction, map[string]any{}, RunPipelinePollInterval)
+}
+
+func (c *RunPipeline) Cancel(ctx core.ExecutionContext) error {
+	metadata := RunPipelineMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return nil
+	}
+
+	if metadata.RunID == 0 {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil
+	}
+
+	if err := client.CancelRun(metadata.Project, metadata.RunID); err != nil {
+		ctx.Logger.Warnf("Failed to cancel run %d of %s: %v", metadata.RunID, metadata.Project, err)
+	} else {
+		ctx.Logger.Infof("Cancelled run %d of %s", metadata.RunID, metadata.Project)
+	}
+
+	return nil
+}
+
+func (c *RunPipeline) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *RunPipeline) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func decodeRunPipelineSpec(value any) (RunPipelineSpec, error) {
+	spec := RunPipelineSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	project, err := validateProject(spec.Project)
+	if err != nil {
+		return spec, err
+	}
+
+	spec.Project = project
+	spec.Pipeline = strings.TrimSpace(spec.Pipeline)
+	if spec.Pipeline == "" {
+		return spec, fmt.Errorf("pipeline is required")
+	}
+
+	spec.Branch = strings.TrimSpace(spec.Branch)
+	for i := range spec.Variables {
+		spec.Variables[i].Name = strings.TrimSpace(spec.Variables[i].Name)
+		if spec.Variables[i].Name == "" {
+			return spec, fmt.Errorf("variable %d: name is required", i+1)
+		}
+	}
+
+	for i := range spec.Parameters {
+		spec.Parameters[i].Name = strings.TrimSpace(spec.Parameters[i].Name)
+		if spec.Parameters[i].Name == "" {
+			return spec, fmt.Errorf("parameter %d: name is required", i+1)
+		}
+	}
+
+	return spec, nil
+}
+
+func runPipelineRequest(spec RunPipelineSpec) RunPipelineRequest {
+	request := RunPipelineRequest{}
+	if spec.Branch != "" {
+		branch := spec.Branch
+		if !strings.HasPrefix(branch, "refs/") {
+			branch = "refs/heads/" + branch
+		}
+
+		request.Resources = &RunResources{
+			Repositories: map[string]RunRepository{"self": {RefName: branch}},
+		}
+	}
+
+	if len(spec.Variables) > 0 {
+		request.Variables = map[string]RunVariable{}
+		for _, variable := range spec.Variables {
+			request.Variables[variable.Name] = RunVariable{Value: variable.Value, IsSecret: variable.Secret}
+		}
+	}
+
+	if len(spec.Parameters) > 0 {
+		request.TemplateParameters = map[string]string{}
+		for _, parameter := range spec.Parameters {
+			request.TemplateParameters[parameter.Name] = parameter.Value
+		}
+	}
+
+	return request
+}
+
+func runToMap(project string, run *Run) map[string]any {
+	return map[string]any{
+		"id":            run.ID,
+		"name":          run.Name,
+		"project":       project,
+		"pipeline":      map[string]any{"id": run.Pipeline.ID, "name": run.Pipeline.Name},
+		"state":         run.State,
+		"result":        run.Result,
+		"created_date":  run.CreatedDate,
+		"finished_date": run.FinishedDate,
+		"url":           run.Links.Web.Href,
+	}
+}
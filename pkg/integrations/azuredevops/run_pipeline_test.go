@@ -0,0 +1,211 @@
+package azuredevops
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RunPipeline__Setup(t *testing.T) {
+	t.Run("parameter without name -> error", func(t *testing.T) {
+		err := (&RunPipeline{}).Setup(core.SetupContext{
+			HTTP:        &contexts.HTTPContext{},
+			Integration: testIntegration(),
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"project":    "web",
+				"pipeline":   "12",
+				"parameters": []any{map[string]any{"name": " ", "value": "production"}},
+			},
+		})
+
+		require.ErrorContains(t, err, "parameter 1: name is required")
+	})
+
+	t.Run("project and pipeline are stored in metadata", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		err := (&RunPipeline{}).Setup(core.SetupContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":"6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c","name":"web"}`),
+				jsonResponse(http.StatusOK, `{"id":12,"name":"deploy","folder":"\\release"}`),
+			}},
+			Integration:   testIntegration(),
+			Metadata:      metadata,
+			Configuration: map[string]any{"project": "web", "pipeline": "12"},
+		})
+
+		require.NoError(t, err)
+		stored := metadata.Metadata.(NodeMetadata)
+		assert.Equal(t, "web", stored.Project.Name)
+		assert.Equal(t, &PipelineMetadata{ID: 12, Name: "release/deploy"}, stored.Pipeline)
+	})
+}
+
+func Test__RunPipeline__Execute(t *testing.T) {
+	component := &RunPipeline{}
+
+	t.Run("branch, variables and parameters -> runs and polls", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{
+				"id": 1234,
+				"name": "20260115.3",
+				"state": "inProgress",
+				"_links": {"web": {"href": "https://dev.azure.com/contoso/web/_build/results?buildId=1234"}}
+			}`),
+		}}
+
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"project":  "web",
+				"pipeline": "12",
+				"branch":   "main",
+				"variables": []any{
+					map[string]any{"name": "VERSION", "value": "1.8.2"},
+					map[string]any{"name": "TOKEN", "value": "secret", "secret": true},
+				},
+				"parameters": []any{map[string]any{"name": "environment", "value": "production"}},
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, RunPipelinePollAction, requests.Action)
+		assert.Equal(t, RunPipelinePollInterval, requests.Duration)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "/contoso/web/_apis/pipelines/12/runs", httpContext.Requests[0].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"resources": {"repositories": {"self": {"refName": "refs/heads/main"}}},
+			"variables": {
+				"VERSION": {"value": "1.8.2", "isSecret": false},
+				"TOKEN": {"value": "secret", "isSecret": true}
+			},
+			"templateParameters": {"environment": "production"}
+		}`, string(body))
+
+		stored := metadata.Metadata.(RunPipelineMetadata)
+		assert.Equal(t, int64(12), stored.PipelineID)
+		assert.Equal(t, int64(1234), stored.RunID)
+		assert.Equal(t, "https://dev.azure.com/contoso/web/_build/results?buildId=1234", stored.URL)
+	})
+
+	t.Run("run can not be queued -> fails execution", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"project": "web", "pipeline": "12"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusBadRequest, `{"message":"Variable VERSION cannot be set at queue time"}`),
+			}},
+			Integration:    testIntegration(),
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "Variable VERSION cannot be set at queue time")
+	})
+}
+
+func Test__RunPipeline__Poll(t *testing.T) {
+	component := &RunPipeline{}
+
+	poll := func(responses ...*http.Response) (*contexts.MetadataContext, *contexts.RequestContext, *contexts.ExecutionStateContext, error) {
+		metadataContext := &contexts.MetadataContext{Metadata: RunPipelineMetadata{
+			Project:    "web",
+			PipelineID: 12,
+			RunID:      1234,
+			Status:     "inProgress",
+		}}
+
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           RunPipelinePollAction,
+			HTTP:           &contexts.HTTPContext{Responses: responses},
+			Integration:    testIntegration(),
+			Metadata:       metadataContext,
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		return metadataContext, requests, executionState, err
+	}
+
+	t.Run("run in progress -> polls again", func(t *testing.T) {
+		_, requests, executionState, err := poll(jsonResponse(http.StatusOK, `{"id":1234,"state":"inProgress"}`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, RunPipelinePollAction, requests.Action)
+	})
+
+	t.Run("run succeeded -> emits on passed", func(t *testing.T) {
+		_, _, executionState, err := poll(jsonResponse(http.StatusOK, `{
+			"id": 1234,
+			"state": "completed",
+			"result": "succeeded",
+			"pipeline": {"id": 12, "name": "web-ci"}
+		}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, PassedOutputChannel, executionState.Channel)
+		assert.Equal(t, RunPipelinePayloadType, executionState.Type)
+	})
+
+	t.Run("run canceled -> emits on failed", func(t *testing.T) {
+		_, _, executionState, err := poll(jsonResponse(http.StatusOK, `{"id":1234,"state":"completed","result":"canceled"}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, FailedOutputChannel, executionState.Channel)
+	})
+
+	t.Run("transient error -> polls again", func(t *testing.T) {
+		metadata, requests, executionState, err := poll(jsonResponse(http.StatusServiceUnavailable, `{"message":"unavailable"}`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, RunPipelinePollAction, requests.Action)
+		assert.Equal(t, 1, metadata.Metadata.(RunPipelineMetadata).PollErrorCount)
+	})
+
+	t.Run("run not found -> fails execution", func(t *testing.T) {
+		_, _, executionState, err := poll(jsonResponse(http.StatusNotFound, `{"message":"not found"}`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to get run 1234")
+	})
+}
+
+func Test__RunPipeline__Cancel(t *testing.T) {
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{}`)}}
+	err := (&RunPipeline{}).Cancel(core.ExecutionContext{
+		HTTP:        httpContext,
+		Integration: testIntegration(),
+		Metadata:    &contexts.MetadataContext{Metadata: RunPipelineMetadata{Project: "web", PipelineID: 12, RunID: 1234}},
+		Logger:      logrus.NewEntry(logrus.New()),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, httpContext.Requests, 1)
+	assert.Equal(t, http.MethodPatch, httpContext.Requests[0].Method)
+	assert.Equal(t, "/contoso/web/_apis/build/builds/1234", httpContext.Requests[0].URL.Path)
+}
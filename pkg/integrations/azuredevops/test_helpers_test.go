@@ -0,0 +1,28 @@
+package azuredevops
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func testIntegration() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"organization":        "contoso",
+			"authType":            AuthTypePersonalAccessToken,
+			"personalAccessToken": "pat",
+		},
+		Metadata: Metadata{Organization: "contoso", AuthType: AuthTypePersonalAccessToken},
+	}
+}
@@ -0,0 +1,228 @@
+package azuredevops
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type UpdateWorkItem struct{}
+
+type UpdateWorkItemSpec struct {
+	Project    string       `json:"project" mapstructure:"project"`
+	WorkItem   string       `json:"workItem" mapstructure:"workItem"`
+	Title      string       `json:"title" mapstructure:"title"`
+	State      string       `json:"state" mapstructure:"state"`
+	AssignedTo string       `json:"assignedTo" mapstructure:"assignedTo"`
+	Comment    string       `json:"comment" mapstructure:"comment"`
+	Fields     []FieldValue `json:"fields" mapstructure:"fields"`
+}
+
+func (c *UpdateWorkItem) Name() string {
+	return "azuredevops.updateWorkItem"
+}
+
+func (c *UpdateWorkItem) Label() string {
+	return "Update Work Item"
+}
+
+func (c *UpdateWorkItem) Description() string {
+	return "Update an Azure Boards work item"
+}
+
+func (c *UpdateWorkItem) Documentation() string {
+	return `The Update Work Item component updates the fields of a work item in Azure Boards, and can add a comment to its discussion.
+
+## Use Cases
+
+- **Release tracking**: Resolve work items once their changes are deployed
+- **Incident updates**: Add build or deployment results to a bug
+- **Assignment**: Assign work items to the on-call engineer
+
+## Configuration
+
+- **Project**: The Azure DevOps project of the work item
+- **Work Item**: The ID of the work item
+- **Title**: Optional new title
+- **State**: Optional new state, like Active, Resolved or Closed. The states depend on the work item type.
+- **Assigned To**: Optional user to assign, by email or display name
+- **Comment**: Optional comment to add to the discussion of the work item
+- **Fields**: Other fields, by reference name, like ` + "`Microsoft.VSTS.Common.Priority`" + `
+
+At least one change is required.
+
+## Output
+
+The updated work item, with its ID, revision, fields and a link to it.`
+}
+
+func (c *UpdateWorkItem) Icon() string {
+	return "square-pen"
+}
+
+func (c *UpdateWorkItem) Color() string {
+	return "blue"
+}
+
+func (c *UpdateWorkItem) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *UpdateWorkItem) Configuration() []configuration.Field {
+	return []configuration.Field{
+		projectField(),
+		{
+			Name:        "workItem",
+			Label:       "Work Item",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "The ID of the work item",
+		},
+		{
+			Name:     "title",
+			Label:    "Title",
+			Type:     configuration.FieldTypeString,
+			Required: false,
+		},
+		{
+			Name:     "state",
+			Label:    "State",
+			Type:     configuration.FieldTypeString,
+			Required: false,
+		},
+		{
+			Name:        "assignedTo",
+			Label:       "Assigned To",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "The email or display name of the user to assign",
+		},
+		{
+			Name:        "comment",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Comment to add to the discussion of the work item",
+		},
+		fieldsField(),
+	}
+}
+
+func (c *UpdateWorkItem) Setup(ctx core.SetupContext) error {
+	spec, err := decodeUpdateWorkItemSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if !isExpression(spec.WorkItem) {
+		if _, err := parseID("work item", spec.WorkItem); err != nil {
+			return err
+		}
+	}
+
+	_, err = ensureProjectInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Project, "")
+	return err
+}
+
+func (c *UpdateWorkItem) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeUpdateWorkItemSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	workItemID, err := parseID("work item", spec.WorkItem)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	workItem, err := client.UpdateWorkItem(spec.Project, workItemID, updateWorkItemOperations(spec))
+	if err != nil {
+		return fmt.Errorf("failed to update work item %d: %w", workItemID, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"azuredevops.workItem",
+		[]any{workItemToMap(workItem)},
+	)
+}
+
+func decodeUpdateWorkItemSpec(value any) (UpdateWorkItemSpec, error) {
+	spec := UpdateWorkItemSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	project, err := validateProject(spec.Project)
+	if err != nil {
+		return spec, err
+	}
+
+	spec.Project = project
+	if strings.TrimSpace(spec.WorkItem) == "" {
+		return spec, fmt.Errorf("work item is required")
+	}
+
+	spec.Title = strings.TrimSpace(spec.Title)
+	spec.State = strings.TrimSpace(spec.State)
+	spec.AssignedTo = strings.TrimSpace(spec.AssignedTo)
+	if err := validateFields(spec.Fields); err != nil {
+		return spec, err
+	}
+
+	if len(updateWorkItemOperations(spec)) == 0 {
+		return spec, fmt.Errorf("at least one change is required")
+	}
+
+	return spec, nil
+}
+
+func updateWorkItemOperations(spec UpdateWorkItemSpec) []PatchOperation {
+	operations := []PatchOperation{}
+	if spec.Title != "" {
+		operations = append(operations, PatchOperation{Op: "add", Path: "/fields/" + FieldTitle, Value: spec.Title})
+	}
+
+	if spec.State != "" {
+		operations = append(operations, PatchOperation{Op: "add", Path: "/fields/" + FieldState, Value: spec.State})
+	}
+
+	if spec.AssignedTo != "" {
+		operations = append(operations, PatchOperation{Op: "add", Path: "/fields/" + FieldAssignedTo, Value: spec.AssignedTo})
+	}
+
+	if strings.TrimSpace(spec.Comment) != "" {
+		operations = append(operations, PatchOperation{Op: "add", Path: "/fields/" + FieldHistory, Value: spec.Comment})
+	}
+
+	return append(operations, fieldOperations(spec.Fields)...)
+}
+
+func (c *UpdateWorkItem) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *UpdateWorkItem) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *UpdateWorkItem) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *UpdateWorkItem) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *UpdateWorkItem) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
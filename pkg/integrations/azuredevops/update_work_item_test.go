@@ -0,0 +1,72 @@
+package azuredevops
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__UpdateWorkItem__Setup(t *testing.T) {
+	t.Run("no changes -> error", func(t *testing.T) {
+		err := (&UpdateWorkItem{}).Setup(core.SetupContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"project": "web", "workItem": "301"},
+		})
+
+		require.ErrorContains(t, err, "at least one change is required")
+	})
+
+	t.Run("work item expression is not validated", func(t *testing.T) {
+		err := (&UpdateWorkItem{}).Setup(core.SetupContext{
+			HTTP:        &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"id":"6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c","name":"web"}`)}},
+			Integration: testIntegration(),
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"project":  "web",
+				"workItem": "{{ $['Create Work Item'].data.id }}",
+				"state":    "Resolved",
+			},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__UpdateWorkItem__Execute(t *testing.T) {
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+		jsonResponse(http.StatusOK, `{"id":301,"rev":3,"fields":{"System.State":"Resolved"}}`),
+	}}
+
+	executionState := &contexts.ExecutionStateContext{}
+	err := (&UpdateWorkItem{}).Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"project":  "web",
+			"workItem": "#301",
+			"state":    "Resolved",
+			"comment":  "Deployed to production",
+		},
+		HTTP:           httpContext,
+		Integration:    testIntegration(),
+		ExecutionState: executionState,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "azuredevops.workItem", executionState.Type)
+
+	require.Len(t, httpContext.Requests, 1)
+	assert.Equal(t, http.MethodPatch, httpContext.Requests[0].Method)
+	assert.Equal(t, "/contoso/web/_apis/wit/workitems/301", httpContext.Requests[0].URL.Path)
+	body, err := io.ReadAll(httpContext.Requests[0].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"op": "add", "path": "/fields/System.State", "value": "Resolved"},
+		{"op": "add", "path": "/fields/System.History", "value": "Deployed to production"}
+	]`, string(body))
+}
@@ -0,0 +1,136 @@
+package azuredevops
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type WebhookConfiguration struct {
+	EventTypes   []string `json:"eventTypes" mapstructure:"eventTypes"`
+	ProjectID    string   `json:"projectId" mapstructure:"projectId"`
+	RepositoryID string   `json:"repositoryId,omitempty" mapstructure:"repositoryId"`
+}
+
+type WebhookMetadata struct {
+	SubscriptionIDs []string `json:"subscriptionIds" mapstructure:"subscriptionIds"`
+}
+
+// AzureDevOpsWebhookHandler manages service hook subscriptions.
+// Service hooks have a single event type, so a webhook
+// has one subscription for each of its event types.
+type AzureDevOpsWebhookHandler struct{}
+
+func (h *AzureDevOpsWebhookHandler) CompareConfig(a, b any) (bool, error) {
+	configA := WebhookConfiguration{}
+	if err := mapstructure.Decode(a, &configA); err != nil {
+		return false, err
+	}
+
+	configB := WebhookConfiguration{}
+	if err := mapstructure.Decode(b, &configB); err != nil {
+		return false, err
+	}
+
+	if configA.ProjectID != configB.ProjectID || configA.RepositoryID != configB.RepositoryID {
+		return false, nil
+	}
+
+	for _, eventType := range configB.EventTypes {
+		if !slices.Contains(configA.EventTypes, eventType) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (h *AzureDevOpsWebhookHandler) Merge(current, requested any) (any, bool, error) {
+	return current, false, nil
+}
+
+func (h *AzureDevOpsWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
+	config := WebhookConfiguration{}
+	if err := mapstructure.Decode(ctx.Webhook.GetConfiguration(), &config); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook configuration: %w", err)
+	}
+
+	if config.ProjectID == "" {
+		return nil, fmt.Errorf("project is required")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhook secret: %w", err)
+	}
+
+	metadata := WebhookMetadata{SubscriptionIDs: []string{}}
+	for _, eventType := range config.EventTypes {
+		publisherInputs := map[string]string{"projectId": config.ProjectID}
+		if config.RepositoryID != "" {
+			publisherInputs["repository"] = config.RepositoryID
+		}
+
+		subscription, err := client.CreateSubscription(Subscription{
+			PublisherID:      "tfs",
+			EventType:        eventType,
+			ResourceVersion:  "1.0",
+			ConsumerID:       "webHooks",
+			ConsumerActionID: "httpRequest",
+			PublisherInputs:  publisherInputs,
+			ConsumerInputs: map[string]string{
+				"url":         ctx.Webhook.GetURL(),
+				"httpHeaders": fmt.Sprintf("%s:%s", WebhookSecretHeader, secret),
+			},
+		})
+
+		if err != nil {
+			h.deleteSubscriptions(ctx, client, metadata.SubscriptionIDs)
+			return nil, fmt.Errorf("error creating service hook for %s: %w", eventType, err)
+		}
+
+		metadata.SubscriptionIDs = append(metadata.SubscriptionIDs, subscription.ID)
+	}
+
+	return metadata, nil
+}
+
+func (h *AzureDevOpsWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
+	metadata := WebhookMetadata{}
+	if err := mapstructure.Decode(ctx.Webhook.GetMetadata(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode webhook metadata: %w", err)
+	}
+
+	if len(metadata.SubscriptionIDs) == 0 {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range metadata.SubscriptionIDs {
+		if err := client.DeleteSubscription(id); err != nil && !IsNotFound(err) {
+			return fmt.Errorf("error deleting service hook %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteSubscriptions removes the subscriptions of a webhook that couldn't be fully set up.
+func (h *AzureDevOpsWebhookHandler) deleteSubscriptions(ctx core.WebhookHandlerContext, client *Client, ids []string) {
+	for _, id := range ids {
+		if err := client.DeleteSubscription(id); err != nil && ctx.Logger != nil {
+			ctx.Logger.Warnf("Failed to delete service hook %s: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__AzureDevOpsWebhookHandler__CompareConfig(t *testing.T) {
+	h := &AzureDevOpsWebhookHandler{}
+
+	testCases := []struct {
+		name        string
+		configA     any
+		configB     any
+		expectEqual bool
+	}{
+		{
+			name:        "same events",
+			configA:     WebhookConfiguration{EventTypes: []string{EventTypeGitPush}, ProjectID: "p1", RepositoryID: "r1"},
+			configB:     WebhookConfiguration{EventTypes: []string{EventTypeGitPush}, ProjectID: "p1", RepositoryID: "r1"},
+			expectEqual: true,
+		},
+		{
+			name: "subset of events",
+			configA: WebhookConfiguration{
+				EventTypes: []string{EventTypePullRequestCreated, EventTypePullRequestUpdated},
+				ProjectID:  "p1",
+			},
+			configB:     WebhookConfiguration{EventTypes: []string{EventTypePullRequestCreated}, ProjectID: "p1"},
+			expectEqual: true,
+		},
+		{
+			name:        "missing event",
+			configA:     WebhookConfiguration{EventTypes: []string{EventTypePullRequestCreated}, ProjectID: "p1"},
+			configB:     WebhookConfiguration{EventTypes: []string{EventTypePullRequestUpdated}, ProjectID: "p1"},
+			expectEqual: false,
+		},
+		{
+			name:        "different repositories",
+			configA:     WebhookConfiguration{EventTypes: []string{EventTypeGitPush}, ProjectID: "p1", RepositoryID: "r1"},
+			configB:     WebhookConfiguration{EventTypes: []string{EventTypeGitPush}, ProjectID: "p1", RepositoryID: "r2"},
+			expectEqual: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			equal, err := h.CompareConfig(tc.configA, tc.configB)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectEqual, equal)
+		})
+	}
+}
+
+func Test__AzureDevOpsWebhookHandler__Setup(t *testing.T) {
+	h := &AzureDevOpsWebhookHandler{}
+
+	t.Run("one subscription per event type", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"id":"sub-1"}`),
+			jsonResponse(http.StatusOK, `{"id":"sub-2"}`),
+		}}
+
+		metadata, err := h.Setup(core.WebhookHandlerContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Webhook: &contexts.WebhookContext{
+				URL:    "https://superplane.example.com/api/v1/webhooks/123",
+				Secret: []byte("webhook-secret"),
+				Configuration: WebhookConfiguration{
+					EventTypes:   []string{EventTypePullRequestCreated, EventTypePullRequestUpdated},
+					ProjectID:    "p1",
+					RepositoryID: "r1",
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, WebhookMetadata{SubscriptionIDs: []string{"sub-1", "sub-2"}}, metadata)
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/contoso/_apis/hooks/subscriptions", httpContext.Requests[0].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+
+		subscription := Subscription{}
+		require.NoError(t, json.Unmarshal(body, &subscription))
+		assert.Equal(t, EventTypePullRequestCreated, subscription.EventType)
+		assert.Equal(t, map[string]string{"projectId": "p1", "repository": "r1"}, subscription.PublisherInputs)
+		assert.Equal(t, "https://superplane.example.com/api/v1/webhooks/123", subscription.ConsumerInputs["url"])
+		assert.Equal(t, "X-SuperPlane-Webhook-Secret:webhook-secret", subscription.ConsumerInputs["httpHeaders"])
+	})
+
+	t.Run("failure -> created subscriptions are deleted", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"id":"sub-1"}`),
+			jsonResponse(http.StatusBadRequest, `{"message":"invalid event type"}`),
+			jsonResponse(http.StatusNoContent, ``),
+		}}
+
+		_, err := h.Setup(core.WebhookHandlerContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Webhook: &contexts.WebhookContext{
+				Configuration: WebhookConfiguration{
+					EventTypes: []string{EventTypePullRequestCreated, EventTypePullRequestUpdated},
+					ProjectID:  "p1",
+				},
+			},
+		})
+
+		require.ErrorContains(t, err, "invalid event type")
+		require.Len(t, httpContext.Requests, 3)
+		assert.Equal(t, http.MethodDelete, httpContext.Requests[2].Method)
+		assert.Equal(t, "/contoso/_apis/hooks/subscriptions/sub-1", httpContext.Requests[2].URL.Path)
+	})
+}
+
+func Test__AzureDevOpsWebhookHandler__Cleanup(t *testing.T) {
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+		jsonResponse(http.StatusNoContent, ``),
+		jsonResponse(http.StatusNotFound, `{"message":"not found"}`),
+	}}
+
+	err := (&AzureDevOpsWebhookHandler{}).Cleanup(core.WebhookHandlerContext{
+		HTTP:        httpContext,
+		Integration: testIntegration(),
+		Webhook: &contexts.WebhookContext{
+			Metadata: WebhookMetadata{SubscriptionIDs: []string{"sub-1", "sub-2"}},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, httpContext.Requests, 2)
+}
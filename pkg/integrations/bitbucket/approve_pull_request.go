@@ -0,0 +1,154 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type ApprovePullRequest struct{}
+
+type ApprovePullRequestSpec struct {
+	Repository  string `json:"repository" mapstructure:"repository"`
+	PullRequest string `json:"pullRequest" mapstructure:"pullRequest"`
+}
+
+func (c *ApprovePullRequest) Name() string {
+	return "bitbucket.approvePullRequest"
+}
+
+func (c *ApprovePullRequest) Label() string {
+	return "Approve Pull Request"
+}
+
+func (c *ApprovePullRequest) Description() string {
+	return "Approve a Bitbucket pull request"
+}
+
+func (c *ApprovePullRequest) Documentation() string {
+	return `The Approve Pull Request component approves a pull request in a Bitbucket repository, as the user of the integration token.
+
+## Use Cases
+
+- **Automated approvals**: Approve dependency updates once their checks pass
+- **Approval gates**: Approve a pull request after a manual approval step in SuperPlane
+
+## Configuration
+
+- **Repository**: The Bitbucket repository of the pull request
+- **Pull Request**: The ID of the pull request
+
+## Output
+
+The approval, with the user that approved the pull request.
+
+## Notes
+
+Bitbucket does not allow authors to approve their own pull requests, and workspace access tokens can not approve pull requests.`
+}
+
+func (c *ApprovePullRequest) Icon() string {
+	return "check"
+}
+
+func (c *ApprovePullRequest) Color() string {
+	return "blue"
+}
+
+func (c *ApprovePullRequest) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ApprovePullRequest) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		pullRequestField(),
+	}
+}
+
+func (c *ApprovePullRequest) Setup(ctx core.SetupContext) error {
+	spec, err := decodeApprovePullRequestSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Repository)
+	return err
+}
+
+func (c *ApprovePullRequest) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeApprovePullRequestSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	workspace, slug, err := splitRepository(ctx.Integration, spec.Repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClientFromIntegration(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	participant, err := client.ApprovePullRequest(workspace, slug, spec.PullRequest)
+	if err != nil {
+		return fmt.Errorf("failed to approve pull request %s: %w", spec.PullRequest, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"bitbucket.pullRequest.approval",
+		[]any{map[string]any{
+			"pull_request":    spec.PullRequest,
+			"approved":        participant.Approved,
+			"user":            participant.User.DisplayName,
+			"role":            participant.Role,
+			"participated_on": participant.ParticipatedOn,
+		}},
+	)
+}
+
+func decodeApprovePullRequestSpec(value any) (ApprovePullRequestSpec, error) {
+	spec := ApprovePullRequestSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.Repository) == "" {
+		return spec, fmt.Errorf("repository is required")
+	}
+
+	pullRequest, err := parsePullRequestID(spec.PullRequest)
+	if err != nil {
+		return spec, err
+	}
+
+	spec.PullRequest = pullRequest
+	return spec, nil
+}
+
+func (c *ApprovePullRequest) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *ApprovePullRequest) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *ApprovePullRequest) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *ApprovePullRequest) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ApprovePullRequest) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,51 @@
+package bitbucket
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ApprovePullRequest__Execute(t *testing.T) {
+	component := &ApprovePullRequest{}
+
+	t.Run("approves pull request", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"user":{"display_name":"SuperPlane"},"role":"REVIEWER","approved":true,"participated_on":"2026-10-19T10:06:40+00:00"}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"repository": "hello", "pullRequest": "42"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "/2.0/repositories/superplane/hello/pullrequests/42/approve", httpContext.Requests[0].URL.Path)
+
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, payload["approved"])
+		assert.Equal(t, "SuperPlane", payload["user"])
+	})
+
+	t.Run("approval rejected -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"repository": "superplane/hello", "pullRequest": "42"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusBadRequest, `{"error":{"message":"You can't approve your own pull request."}}`),
+			}},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "failed to approve pull request 42")
+		require.ErrorContains(t, err, "approve your own pull request")
+	})
+}
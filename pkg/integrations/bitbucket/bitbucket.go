@@ -20,10 +20,12 @@ To configure Bitbucket with SuperPlane:
 	- Go to **Atlassian Settings → Security → Create API token**.
 	- Select **Bitbucket** App.
 	- Create a token with admin:workspace:bitbucket scope.
+	- Pull request and pipeline components also need the write:pullrequest:bitbucket, write:repository:bitbucket and write:pipeline:bitbucket scopes.
 
 - **Workspace Access Token mode**:
    - Go to **Bitbucket Workspace Settings → Security → Access tokens**.
    - Create a workspace access token.
+   - Pull request and pipeline components need the **Pull requests: Write**, **Repositories: Write** and **Pipelines: Write** scopes.
 
 - **Copy the token** and your workspace slug (for example: ` + "`my-workspace`" + `) below.
 `
@@ -66,7 +68,7 @@ func (b *Bitbucket) Icon() string {
 }
 
 func (b *Bitbucket) Description() string {
-	return "React to events in your Bitbucket repositories"
+	return "React to events in your Bitbucket repositories, and manage pull requests and pipelines"
 }
 
 func (b *Bitbucket) Instructions() string {
@@ -120,11 +122,20 @@ func (b *Bitbucket) Configuration() []configuration.Field {
 }
 
 func (b *Bitbucket) Actions() []core.Action {
-	return []core.Action{}
+	return []core.Action{
+		&ApprovePullRequest{},
+		&CreatePullRequestComment{},
+		&MergePullRequest{},
+		&RunPipeline{},
+		&SetCommitStatus{},
+	}
 }
 
 func (b *Bitbucket) Triggers() []core.Trigger {
 	return []core.Trigger{
+		&OnPipelineCompleted{},
+		&OnPullRequest{},
+		&OnPullRequestComment{},
 		&OnPush{},
 	}
 }
@@ -106,3 +106,83 @@ func Test__Bitbucket__Sync(t *testing.T) {
 		assert.Equal(t, "ready", integrationCtx.State)
 	})
 }
+
+func Test__Bitbucket__ListResources(t *testing.T) {
+	b := &Bitbucket{}
+
+	t.Run("workspace access token -> workspace of the integration", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		resources, err := b.ListResources(ResourceTypeWorkspace, core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+		assert.Equal(t, "superplane", resources[0].Name)
+		assert.Equal(t, "{superplane}", resources[0].ID)
+		assert.Empty(t, httpContext.Requests)
+	})
+
+	t.Run("api token -> workspaces of the user", func(t *testing.T) {
+		integration := testIntegration()
+		integration.Configuration["email"] = "john@example.com"
+		integration.Metadata = Metadata{AuthType: AuthTypeAPIToken, Workspace: &WorkspaceMetadata{Slug: "superplane"}}
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"values":[{"workspace":{"uuid":"{a}","slug":"superplane","name":"SuperPlane"}},{"workspace":{"uuid":"{b}","slug":"acme","name":"Acme"}}]}`),
+		}}
+
+		resources, err := b.ListResources(ResourceTypeWorkspace, core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: integration,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+		assert.Equal(t, "acme", resources[1].Name)
+		assert.Equal(t, "{b}", resources[1].ID)
+	})
+
+	t.Run("repositories", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"values":[{"uuid":"{hello}","name":"hello","full_name":"superplane/hello","slug":"hello"}]}`)}}
+		resources, err := b.ListResources(ResourceTypeRepository, core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+		assert.Equal(t, "superplane/hello", resources[0].Name)
+		assert.Equal(t, "{hello}", resources[0].ID)
+		assert.Equal(t, "/2.0/repositories/superplane", httpContext.Requests[0].URL.Path)
+	})
+
+	t.Run("branches of a repository", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"values":[{"name":"main","target":{"hash":"709d658"}},{"name":"develop","target":{"hash":"1e3a6c7"}}]}`),
+		}}
+
+		resources, err := b.ListResources(ResourceTypeBranch, core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Parameters:  map[string]string{"repository": "superplane/hello"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+		assert.Equal(t, "main", resources[0].Name)
+		assert.Equal(t, "develop", resources[1].ID)
+		assert.Equal(t, "/2.0/repositories/superplane/hello/refs/branches", httpContext.Requests[0].URL.Path)
+	})
+
+	t.Run("branches without repository -> no resources", func(t *testing.T) {
+		resources, err := b.ListResources(ResourceTypeBranch, core.ListResourcesContext{
+			HTTP:        &contexts.HTTPContext{},
+			Integration: testIntegration(),
+			Parameters:  map[string]string{},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+	})
+}
@@ -3,9 +3,12 @@ package bitbucket
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"strings"
 
 	"github.com/superplanehq/superplane/pkg/core"
 )
@@ -251,3 +254,333 @@ func (c *Client) DeleteWebhook(workspace, repoSlug, webhookUID string) error {
 
 	return nil
 }
+
+// APIError is returned for Bitbucket API responses with an unexpected status code.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// doJSON sends a request to the Bitbucket API, and decodes the response into out.
+// Paths are relative to the API base URL, and absolute URLs are used as they are.
+func (c *Client) doJSON(method, path string, payload any, out any) (*http.Response, error) {
+	url := path
+	if !strings.HasPrefix(path, "https://") {
+		url = baseURL + path
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling request: %w", err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	c.setAuthHeaders(req)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return nil, fmt.Errorf("error decoding response: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+type WorkspacePermissionsResponse struct {
+	Values []struct {
+		Workspace Workspace `json:"workspace"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+// ListWorkspaces lists the workspaces the user of an API token is a member of.
+func (c *Client) ListWorkspaces() ([]Workspace, error) {
+	url := "/user/permissions/workspaces?pagelen=100"
+	workspaces := []Workspace{}
+
+	for url != "" {
+		var response WorkspacePermissionsResponse
+		if _, err := c.doJSON(http.MethodGet, url, nil, &response); err != nil {
+			return nil, err
+		}
+
+		for _, value := range response.Values {
+			workspaces = append(workspaces, value.Workspace)
+		}
+
+		url = response.Next
+	}
+
+	return workspaces, nil
+}
+
+type Branch struct {
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+}
+
+type BranchResponse struct {
+	Values []Branch `json:"values"`
+	Next   string   `json:"next"`
+}
+
+func (c *Client) ListBranches(workspace, repoSlug string) ([]Branch, error) {
+	url := fmt.Sprintf("/repositories/%s/%s/refs/branches?pagelen=100", workspace, repoSlug)
+	branches := []Branch{}
+
+	for url != "" {
+		var response BranchResponse
+		if _, err := c.doJSON(http.MethodGet, url, nil, &response); err != nil {
+			return nil, err
+		}
+
+		branches = append(branches, response.Values...)
+		url = response.Next
+	}
+
+	return branches, nil
+}
+
+type Link struct {
+	Href string `json:"href" mapstructure:"href"`
+}
+
+type Account struct {
+	UUID        string `json:"uuid"`
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+}
+
+type PullRequestRef struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	Commit struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+}
+
+type PullRequest struct {
+	ID          int64          `json:"id"`
+	Title       string         `json:"title"`
+	State       string         `json:"state"`
+	Author      Account        `json:"author"`
+	Source      PullRequestRef `json:"source"`
+	Destination PullRequestRef `json:"destination"`
+	MergeCommit *struct {
+		Hash string `json:"hash"`
+	} `json:"merge_commit"`
+	ClosedBy  *Account `json:"closed_by"`
+	CreatedOn string   `json:"created_on"`
+	UpdatedOn string   `json:"updated_on"`
+	Links     struct {
+		HTML Link `json:"html"`
+	} `json:"links"`
+}
+
+func pullRequestPath(workspace, repoSlug, pullRequest string) string {
+	return fmt.Sprintf("/repositories/%s/%s/pullrequests/%s", workspace, repoSlug, pullRequest)
+}
+
+type Comment struct {
+	ID      int64 `json:"id"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	User      Account `json:"user"`
+	CreatedOn string  `json:"created_on"`
+	Links     struct {
+		HTML Link `json:"html"`
+	} `json:"links"`
+}
+
+func (c *Client) CreatePullRequestComment(workspace, repoSlug, pullRequest, content string) (*Comment, error) {
+	request := map[string]any{"content": map[string]string{"raw": content}}
+
+	var comment Comment
+	_, err := c.doJSON(http.MethodPost, pullRequestPath(workspace, repoSlug, pullRequest)+"/comments", request, &comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+type Participant struct {
+	User           Account `json:"user"`
+	Role           string  `json:"role"`
+	Approved       bool    `json:"approved"`
+	State          string  `json:"state"`
+	ParticipatedOn string  `json:"participated_on"`
+}
+
+func (c *Client) ApprovePullRequest(workspace, repoSlug, pullRequest string) (*Participant, error) {
+	var participant Participant
+	_, err := c.doJSON(http.MethodPost, pullRequestPath(workspace, repoSlug, pullRequest)+"/approve", nil, &participant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &participant, nil
+}
+
+type MergePullRequestRequest struct {
+	Type              string `json:"type"`
+	Message           string `json:"message,omitempty"`
+	CloseSourceBranch bool   `json:"close_source_branch"`
+	MergeStrategy     string `json:"merge_strategy"`
+}
+
+// MergePullRequest merges a pull request. Bitbucket merges large pull requests
+// asynchronously: the pull request is nil then, and the task status URL is returned.
+func (c *Client) MergePullRequest(workspace, repoSlug, pullRequest string, request MergePullRequestRequest) (*PullRequest, string, error) {
+	var merged PullRequest
+	resp, err := c.doJSON(http.MethodPost, pullRequestPath(workspace, repoSlug, pullRequest)+"/merge", request, &merged)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode == http.StatusAccepted {
+		return nil, resp.Header.Get("Location"), nil
+	}
+
+	return &merged, "", nil
+}
+
+type CommitStatus struct {
+	Key         string `json:"key"`
+	State       string `json:"state"`
+	Name        string `json:"name,omitempty"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+	CreatedOn   string `json:"created_on,omitempty"`
+	UpdatedOn   string `json:"updated_on,omitempty"`
+}
+
+func (c *Client) SetCommitStatus(workspace, repoSlug, commit string, status CommitStatus) (*CommitStatus, error) {
+	var created CommitStatus
+	path := fmt.Sprintf("/repositories/%s/%s/commit/%s/statuses/build", workspace, repoSlug, commit)
+	if _, err := c.doJSON(http.MethodPost, path, status, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+type PipelineVariable struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Secured bool   `json:"secured"`
+}
+
+type PipelineSelector struct {
+	Type    string `json:"type"`
+	Pattern string `json:"pattern"`
+}
+
+type PipelineTarget struct {
+	Type     string            `json:"type"`
+	RefType  string            `json:"ref_type"`
+	RefName  string            `json:"ref_name"`
+	Selector *PipelineSelector `json:"selector,omitempty"`
+}
+
+type Pipeline struct {
+	UUID        string `json:"uuid"`
+	BuildNumber int64  `json:"build_number"`
+	State       struct {
+		Name   string `json:"name"`
+		Result *struct {
+			Name string `json:"name"`
+		} `json:"result"`
+	} `json:"state"`
+	Target struct {
+		RefName string `json:"ref_name"`
+		Commit  *struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+	} `json:"target"`
+	CreatedOn         string `json:"created_on"`
+	CompletedOn       string `json:"completed_on"`
+	DurationInSeconds int64  `json:"duration_in_seconds"`
+}
+
+// Result is the result of a completed pipeline, or empty while it runs.
+func (p *Pipeline) Result() string {
+	if p.State.Name != PipelineStateCompleted || p.State.Result == nil {
+		return ""
+	}
+
+	return p.State.Result.Name
+}
+
+func (c *Client) RunPipeline(workspace, repoSlug string, target PipelineTarget, variables []PipelineVariable) (*Pipeline, error) {
+	request := map[string]any{
+		"target":    target,
+		"variables": variables,
+	}
+
+	var pipeline Pipeline
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines/", workspace, repoSlug)
+	if _, err := c.doJSON(http.MethodPost, path, request, &pipeline); err != nil {
+		return nil, err
+	}
+
+	return &pipeline, nil
+}
+
+func (c *Client) GetPipeline(workspace, repoSlug, pipelineUUID string) (*Pipeline, error) {
+	var pipeline Pipeline
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines/%s", workspace, repoSlug, neturl.PathEscape(pipelineUUID))
+	if _, err := c.doJSON(http.MethodGet, path, nil, &pipeline); err != nil {
+		return nil, err
+	}
+
+	return &pipeline, nil
+}
+
+func (c *Client) StopPipeline(workspace, repoSlug, pipelineUUID string) error {
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines/%s/stopPipeline", workspace, repoSlug, neturl.PathEscape(pipelineUUID))
+	_, err := c.doJSON(http.MethodPost, path, nil, nil)
+	return err
+}
@@ -2,10 +2,15 @@ package bitbucket
 
 import (
 	"fmt"
+	"net/http"
 	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
 )
 
 type NodeMetadata struct {
@@ -73,3 +78,177 @@ func repositoryMetadataMatches(repo RepositoryMetadata, repository string) bool
 func repositoryMatches(repo Repository, repository string) bool {
 	return repo.FullName == repository || repo.Name == repository || repo.Slug == repository || repo.UUID == repository
 }
+
+const (
+	ResourceTypeWorkspace  = "workspace"
+	ResourceTypeRepository = "repository"
+	ResourceTypeBranch     = "branch"
+
+	PassedOutputChannel = "passed"
+	FailedOutputChannel = "failed"
+
+	PipelineStateCompleted = "COMPLETED"
+	PipelineResultSuccess  = "SUCCESSFUL"
+)
+
+func repositoryField() configuration.Field {
+	return configuration.Field{
+		Name:     "repository",
+		Label:    "Repository",
+		Type:     configuration.FieldTypeIntegrationResource,
+		Required: true,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type:           ResourceTypeRepository,
+				UseNameAsValue: true,
+			},
+		},
+	}
+}
+
+func pullRequestField() configuration.Field {
+	return configuration.Field{
+		Name:        "pullRequest",
+		Label:       "Pull Request",
+		Type:        configuration.FieldTypeString,
+		Required:    true,
+		Description: "ID of the pull request",
+		Placeholder: "{{ root().data.pullrequest.id }}",
+	}
+}
+
+// splitRepository returns the workspace and slug of a repository,
+// given by its full name, or by its slug for repositories of the
+// integration's workspace.
+func splitRepository(integration core.IntegrationContext, repository string) (string, string, error) {
+	repository = strings.TrimSpace(repository)
+	if repository == "" {
+		return "", "", fmt.Errorf("repository is required")
+	}
+
+	if workspace, slug, found := strings.Cut(repository, "/"); found {
+		return workspace, slug, nil
+	}
+
+	var metadata Metadata
+	if err := mapstructure.Decode(integration.GetMetadata(), &metadata); err != nil {
+		return "", "", fmt.Errorf("failed to decode integration metadata: %w", err)
+	}
+
+	if metadata.Workspace == nil {
+		return "", "", fmt.Errorf("integration workspace is not set")
+	}
+
+	return metadata.Workspace.Slug, repository, nil
+}
+
+func branchField(description string) configuration.Field {
+	return configuration.Field{
+		Name:        "branch",
+		Label:       "Branch",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    true,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type:           ResourceTypeBranch,
+				UseNameAsValue: true,
+				Parameters: []configuration.ParameterRef{
+					{
+						Name:      "repository",
+						ValueFrom: &configuration.ParameterValueFrom{Field: "repository"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newClientFromIntegration(http core.HTTPContext, integration core.IntegrationContext) (*Client, error) {
+	var metadata Metadata
+	if err := mapstructure.Decode(integration.GetMetadata(), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode integration metadata: %w", err)
+	}
+
+	client, err := NewClient(metadata.AuthType, http, integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return client, nil
+}
+
+// parsePullRequestID validates a pull request ID, unless it is an expression
+// that is only resolved when the component runs.
+func parsePullRequestID(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("pull request is required")
+	}
+
+	if strings.Contains(value, "{{") {
+		return value, nil
+	}
+
+	if id, err := strconv.ParseInt(value, 10, 64); err != nil || id <= 0 {
+		return "", fmt.Errorf("invalid pull request ID %q", value)
+	}
+
+	return value, nil
+}
+
+// verifyWebhook returns the event key of a webhook request, after verifying
+// the signature Bitbucket sends with every event. Events with other keys
+// than the ones given are not verified, and an empty key is returned for them.
+func verifyWebhook(ctx core.WebhookRequestContext, eventKeys ...string) (string, int, error) {
+	eventKey := ctx.Headers.Get("X-Event-Key")
+	if eventKey == "" {
+		return "", http.StatusBadRequest, fmt.Errorf("missing X-Event-Key header")
+	}
+
+	if !slices.Contains(eventKeys, eventKey) {
+		return "", http.StatusOK, nil
+	}
+
+	signature := ctx.Headers.Get("X-Hub-Signature")
+	if signature == "" {
+		return "", http.StatusForbidden, fmt.Errorf("missing X-Hub-Signature header")
+	}
+
+	signature = strings.TrimPrefix(signature, "sha256=")
+	if signature == "" {
+		return "", http.StatusForbidden, fmt.Errorf("invalid signature format")
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return "", http.StatusInternalServerError, fmt.Errorf("error getting webhook secret")
+	}
+
+	if err := crypto.VerifySignature(secret, ctx.Body, signature); err != nil {
+		return "", http.StatusForbidden, fmt.Errorf("invalid signature")
+	}
+
+	return eventKey, http.StatusOK, nil
+}
+
+func pullRequestToMap(pullRequest *PullRequest) map[string]any {
+	result := map[string]any{
+		"id":                 pullRequest.ID,
+		"title":              pullRequest.Title,
+		"state":              pullRequest.State,
+		"author":             pullRequest.Author.DisplayName,
+		"source_branch":      pullRequest.Source.Branch.Name,
+		"source_commit":      pullRequest.Source.Commit.Hash,
+		"destination_branch": pullRequest.Destination.Branch.Name,
+		"created_on":         pullRequest.CreatedOn,
+		"updated_on":         pullRequest.UpdatedOn,
+		"url":                pullRequest.Links.HTML.Href,
+	}
+
+	if pullRequest.MergeCommit != nil {
+		result["merge_commit"] = pullRequest.MergeCommit.Hash
+	}
+
+	return result
+}
@@ -0,0 +1,165 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CreatePullRequestComment struct{}
+
+type CreatePullRequestCommentSpec struct {
+	Repository  string `json:"repository" mapstructure:"repository"`
+	PullRequest string `json:"pullRequest" mapstructure:"pullRequest"`
+	Comment     string `json:"comment" mapstructure:"comment"`
+}
+
+func (c *CreatePullRequestComment) Name() string {
+	return "bitbucket.createPullRequestComment"
+}
+
+func (c *CreatePullRequestComment) Label() string {
+	return "Create Pull Request Comment"
+}
+
+func (c *CreatePullRequestComment) Description() string {
+	return "Comment on a Bitbucket pull request"
+}
+
+func (c *CreatePullRequestComment) Documentation() string {
+	return `The Create Pull Request Comment component adds a comment to a pull request in a Bitbucket repository.
+
+## Use Cases
+
+- **Build feedback**: Post test results or preview environment links on the pull request
+- **Review automation**: Leave a checklist or a summary for reviewers
+- **Deployment notes**: Comment once the changes of a pull request are deployed
+
+## Configuration
+
+- **Repository**: The Bitbucket repository of the pull request
+- **Pull Request**: The ID of the pull request, for example ` + "`{{ root().data.pullrequest.id }}`" + `
+- **Comment**: The comment, in Markdown
+
+## Output
+
+The comment, with its ID, content, author and a link to it.`
+}
+
+func (c *CreatePullRequestComment) Icon() string {
+	return "message-square"
+}
+
+func (c *CreatePullRequestComment) Color() string {
+	return "blue"
+}
+
+func (c *CreatePullRequestComment) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreatePullRequestComment) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		pullRequestField(),
+		{
+			Name:        "comment",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeText,
+			Required:    true,
+			Description: "The comment, in Markdown",
+		},
+	}
+}
+
+func (c *CreatePullRequestComment) Setup(ctx core.SetupContext) error {
+	spec, err := decodeCreatePullRequestCommentSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Repository)
+	return err
+}
+
+func (c *CreatePullRequestComment) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCreatePullRequestCommentSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	workspace, slug, err := splitRepository(ctx.Integration, spec.Repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClientFromIntegration(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	comment, err := client.CreatePullRequestComment(workspace, slug, spec.PullRequest, spec.Comment)
+	if err != nil {
+		return fmt.Errorf("failed to comment on pull request %s: %w", spec.PullRequest, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"bitbucket.pullRequest.comment",
+		[]any{map[string]any{
+			"id":           comment.ID,
+			"pull_request": spec.PullRequest,
+			"content":      comment.Content.Raw,
+			"user":         comment.User.DisplayName,
+			"created_on":   comment.CreatedOn,
+			"url":          comment.Links.HTML.Href,
+		}},
+	)
+}
+
+func decodeCreatePullRequestCommentSpec(value any) (CreatePullRequestCommentSpec, error) {
+	spec := CreatePullRequestCommentSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.Repository) == "" {
+		return spec, fmt.Errorf("repository is required")
+	}
+
+	pullRequest, err := parsePullRequestID(spec.PullRequest)
+	if err != nil {
+		return spec, err
+	}
+
+	spec.PullRequest = pullRequest
+	if strings.TrimSpace(spec.Comment) == "" {
+		return spec, fmt.Errorf("comment is required")
+	}
+
+	return spec, nil
+}
+
+func (c *CreatePullRequestComment) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreatePullRequestComment) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreatePullRequestComment) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *CreatePullRequestComment) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreatePullRequestComment) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,80 @@
+package bitbucket
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreatePullRequestComment__Setup(t *testing.T) {
+	component := &CreatePullRequestComment{}
+
+	t.Run("invalid pull request -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "superplane/hello", "pullRequest": "abc", "comment": "LGTM"},
+		})
+
+		require.ErrorContains(t, err, `invalid pull request ID "abc"`)
+	})
+
+	t.Run("expression as pull request -> stores repository", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		err := component.Setup(core.SetupContext{
+			HTTP:        &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"values":[{"uuid":"{hello}","name":"hello","full_name":"superplane/hello","slug":"hello"}]}`)}},
+			Integration: testIntegration(),
+			Metadata:    metadata,
+			Configuration: map[string]any{
+				"repository":  "superplane/hello",
+				"pullRequest": "{{ root().data.pullrequest.id }}",
+				"comment":     "LGTM",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "hello", metadata.Metadata.(NodeMetadata).Repository.Slug)
+	})
+}
+
+func Test__CreatePullRequestComment__Execute(t *testing.T) {
+	component := &CreatePullRequestComment{}
+
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+		jsonResponse(http.StatusCreated, `{
+			"id": 512348761,
+			"content": {"raw": "Preview is ready"},
+			"user": {"display_name": "SuperPlane"},
+			"created_on": "2026-10-19T10:05:12.381942+00:00",
+			"links": {"html": {"href": "https://bitbucket.org/superplane/hello/pull-requests/42#comment-512348761"}}
+		}`),
+	}}
+
+	executionState := &contexts.ExecutionStateContext{}
+	err := component.Execute(core.ExecutionContext{
+		Configuration:  map[string]any{"repository": "superplane/hello", "pullRequest": "42", "comment": "Preview is ready"},
+		HTTP:           httpContext,
+		Integration:    testIntegration(),
+		ExecutionState: executionState,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, httpContext.Requests, 1)
+	assert.Equal(t, http.MethodPost, httpContext.Requests[0].Method)
+	assert.Equal(t, "/2.0/repositories/superplane/hello/pullrequests/42/comments", httpContext.Requests[0].URL.Path)
+	body, err := io.ReadAll(httpContext.Requests[0].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"content":{"raw":"Preview is ready"}}`, string(body))
+
+	assert.Equal(t, core.DefaultOutputChannel.Name, executionState.Channel)
+	assert.Equal(t, "bitbucket.pullRequest.comment", executionState.Type)
+	payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, int64(512348761), payload["id"])
+	assert.Equal(t, "42", payload["pull_request"])
+}
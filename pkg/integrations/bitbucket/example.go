@@ -16,3 +16,83 @@ var exampleDataOnPush map[string]any
 func (t *OnPush) ExampleData() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleDataOnPushOnce, exampleDataOnPushBytes, &exampleDataOnPush)
 }
+
+//go:embed example_data_on_pipeline_completed.json
+var exampleDataOnPipelineCompletedBytes []byte
+
+var exampleDataOnPipelineCompletedOnce sync.Once
+var exampleDataOnPipelineCompleted map[string]any
+
+func (t *OnPipelineCompleted) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnPipelineCompletedOnce, exampleDataOnPipelineCompletedBytes, &exampleDataOnPipelineCompleted)
+}
+
+//go:embed example_data_on_pull_request.json
+var exampleDataOnPullRequestBytes []byte
+
+var exampleDataOnPullRequestOnce sync.Once
+var exampleDataOnPullRequest map[string]any
+
+func (t *OnPullRequest) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnPullRequestOnce, exampleDataOnPullRequestBytes, &exampleDataOnPullRequest)
+}
+
+//go:embed example_data_on_pull_request_comment.json
+var exampleDataOnPullRequestCommentBytes []byte
+
+var exampleDataOnPullRequestCommentOnce sync.Once
+var exampleDataOnPullRequestComment map[string]any
+
+func (t *OnPullRequestComment) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnPullRequestCommentOnce, exampleDataOnPullRequestCommentBytes, &exampleDataOnPullRequestComment)
+}
+
+//go:embed example_output_approve_pull_request.json
+var exampleOutputApprovePullRequestBytes []byte
+
+var exampleOutputApprovePullRequestOnce sync.Once
+var exampleOutputApprovePullRequest map[string]any
+
+func (c *ApprovePullRequest) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputApprovePullRequestOnce, exampleOutputApprovePullRequestBytes, &exampleOutputApprovePullRequest)
+}
+
+//go:embed example_output_create_pull_request_comment.json
+var exampleOutputCreatePullRequestCommentBytes []byte
+
+var exampleOutputCreatePullRequestCommentOnce sync.Once
+var exampleOutputCreatePullRequestComment map[string]any
+
+func (c *CreatePullRequestComment) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreatePullRequestCommentOnce, exampleOutputCreatePullRequestCommentBytes, &exampleOutputCreatePullRequestComment)
+}
+
+//go:embed example_output_merge_pull_request.json
+var exampleOutputMergePullRequestBytes []byte
+
+var exampleOutputMergePullRequestOnce sync.Once
+var exampleOutputMergePullRequest map[string]any
+
+func (c *MergePullRequest) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputMergePullRequestOnce, exampleOutputMergePullRequestBytes, &exampleOutputMergePullRequest)
+}
+
+//go:embed example_output_run_pipeline.json
+var exampleOutputRunPipelineBytes []byte
+
+var exampleOutputRunPipelineOnce sync.Once
+var exampleOutputRunPipeline map[string]any
+
+func (c *RunPipeline) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputRunPipelineOnce, exampleOutputRunPipelineBytes, &exampleOutputRunPipeline)
+}
+
+//go:embed example_output_set_commit_status.json
+var exampleOutputSetCommitStatusBytes []byte
+
+var exampleOutputSetCommitStatusOnce sync.Once
+var exampleOutputSetCommitStatus map[string]any
+
+func (c *SetCommitStatus) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSetCommitStatusOnce, exampleOutputSetCommitStatusBytes, &exampleOutputSetCommitStatus)
+}
@@ -0,0 +1,53 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const gitBaseURL = "https://bitbucket.org"
+
+// ResolveGitRemote returns the HTTPS clone URL for a repository.
+// Repositories without a workspace use the integration's workspace.
+func (b *Bitbucket) ResolveGitRemote(ctx core.IntegrationGitRemoteContext, repository string) (*core.GitRemote, error) {
+	repository = strings.TrimSuffix(strings.Trim(strings.TrimSpace(repository), "/"), ".git")
+	if repository == "" {
+		return nil, fmt.Errorf("repository is required")
+	}
+
+	if !strings.Contains(repository, "/") {
+		workspace, err := ctx.Integration.GetConfig("workspace")
+		if err != nil {
+			return nil, fmt.Errorf("error getting workspace config: %w", err)
+		}
+		repository = strings.TrimSpace(string(workspace)) + "/" + repository
+	}
+
+	authType, err := ctx.Integration.GetConfig("authType")
+	if err != nil {
+		return nil, fmt.Errorf("error getting authType config: %w", err)
+	}
+
+	client, err := NewClient(string(authType), ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// Bitbucket uses fixed usernames to tell token types apart over git:
+	// https://support.atlassian.com/bitbucket-cloud/docs/using-api-tokens/
+	// https://support.atlassian.com/bitbucket-cloud/docs/using-access-tokens/
+	//
+	username := "x-token-auth"
+	if client.AuthType == AuthTypeAPIToken {
+		username = "x-bitbucket-api-token-auth"
+	}
+
+	return &core.GitRemote{
+		URL:      fmt.Sprintf("%s/%s.git", gitBaseURL, repository),
+		Username: username,
+		Password: client.Token,
+	}, nil
+}
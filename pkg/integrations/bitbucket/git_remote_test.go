@@ -0,0 +1,51 @@
+package bitbucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Bitbucket__ResolveGitRemote(t *testing.T) {
+	b := &Bitbucket{}
+
+	t.Run("workspace access token", func(t *testing.T) {
+		ctx := core.IntegrationGitRemoteContext{
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{
+					"authType":  AuthTypeWorkspaceAccessToken,
+					"token":     "workspace-token",
+					"workspace": "acme",
+				},
+			},
+		}
+
+		remote, err := b.ResolveGitRemote(ctx, "canvases")
+		require.NoError(t, err)
+		assert.Equal(t, "https://bitbucket.org/acme/canvases.git", remote.URL)
+		assert.Equal(t, "x-token-auth", remote.Username)
+		assert.Equal(t, "workspace-token", remote.Password)
+	})
+
+	t.Run("api token", func(t *testing.T) {
+		ctx := core.IntegrationGitRemoteContext{
+			Integration: &contexts.IntegrationContext{
+				Configuration: map[string]any{
+					"authType":  AuthTypeAPIToken,
+					"email":     "jane@example.com",
+					"token":     "api-token",
+					"workspace": "acme",
+				},
+			},
+		}
+
+		remote, err := b.ResolveGitRemote(ctx, "other/canvases.git")
+		require.NoError(t, err)
+		assert.Equal(t, "https://bitbucket.org/other/canvases.git", remote.URL)
+		assert.Equal(t, "x-bitbucket-api-token-auth", remote.Username)
+		assert.Equal(t, "api-token", remote.Password)
+	})
+}
@@ -2,13 +2,16 @@ package bitbucket
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/core"
 )
 
 func (b *Bitbucket) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
-	if resourceType != "repository" {
+	switch resourceType {
+	case ResourceTypeWorkspace, ResourceTypeRepository, ResourceTypeBranch:
+	default:
 		return []core.IntegrationResource{}, nil
 	}
 
@@ -22,7 +25,19 @@ func (b *Bitbucket) ListResources(resourceType string, ctx core.ListResourcesCon
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
 
-	repositories, err := client.ListRepositories(metadata.Workspace.Slug)
+	switch resourceType {
+	case ResourceTypeWorkspace:
+		return listWorkspaces(client, metadata)
+	case ResourceTypeBranch:
+		return listBranches(client, ctx.Integration, ctx.Parameters["repository"])
+	}
+
+	workspace := strings.TrimSpace(ctx.Parameters["workspace"])
+	if workspace == "" {
+		workspace = metadata.Workspace.Slug
+	}
+
+	repositories, err := client.ListRepositories(workspace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list repositories: %w", err)
 	}
@@ -38,3 +53,60 @@ func (b *Bitbucket) ListResources(resourceType string, ctx core.ListResourcesCon
 
 	return resources, nil
 }
+
+// listWorkspaces lists the workspaces of the user of an API token.
+// Workspace access tokens only have access to their own workspace.
+func listWorkspaces(client *Client, metadata Metadata) ([]core.IntegrationResource, error) {
+	if metadata.AuthType == AuthTypeWorkspaceAccessToken {
+		if metadata.Workspace == nil {
+			return []core.IntegrationResource{}, nil
+		}
+
+		return []core.IntegrationResource{
+			{Type: ResourceTypeWorkspace, Name: metadata.Workspace.Slug, ID: metadata.Workspace.UUID},
+		}, nil
+	}
+
+	workspaces, err := client.ListWorkspaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		resources = append(resources, core.IntegrationResource{
+			Type: ResourceTypeWorkspace,
+			Name: workspace.Slug,
+			ID:   workspace.UUID,
+		})
+	}
+
+	return resources, nil
+}
+
+func listBranches(client *Client, integration core.IntegrationContext, repository string) ([]core.IntegrationResource, error) {
+	if strings.TrimSpace(repository) == "" {
+		return []core.IntegrationResource{}, nil
+	}
+
+	workspace, slug, err := splitRepository(integration, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	branches, err := client.ListBranches(workspace, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(branches))
+	for _, branch := range branches {
+		resources = append(resources, core.IntegrationResource{
+			Type: ResourceTypeBranch,
+			Name: branch.Name,
+			ID:   branch.Name,
+		})
+	}
+
+	return resources, nil
+}
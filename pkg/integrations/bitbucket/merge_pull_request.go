@@ -0,0 +1,212 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type MergePullRequest struct{}
+
+const (
+	MergeStrategyMergeCommit = "merge_commit"
+	MergeStrategySquash      = "squash"
+	MergeStrategyFastForward = "fast_forward"
+)
+
+type MergePullRequestSpec struct {
+	Repository        string `json:"repository" mapstructure:"repository"`
+	PullRequest       string `json:"pullRequest" mapstructure:"pullRequest"`
+	MergeStrategy     string `json:"mergeStrategy" mapstructure:"mergeStrategy"`
+	Message           string `json:"message" mapstructure:"message"`
+	CloseSourceBranch bool   `json:"closeSourceBranch" mapstructure:"closeSourceBranch"`
+}
+
+func (c *MergePullRequest) Name() string {
+	return "bitbucket.mergePullRequest"
+}
+
+func (c *MergePullRequest) Label() string {
+	return "Merge Pull Request"
+}
+
+func (c *MergePullRequest) Description() string {
+	return "Merge a Bitbucket pull request"
+}
+
+func (c *MergePullRequest) Documentation() string {
+	return `The Merge Pull Request component merges a pull request in a Bitbucket repository.
+
+## Use Cases
+
+- **Automated merges**: Merge dependency updates once their pipelines pass
+- **Release workflows**: Merge a release branch after it is approved and deployed
+
+## Configuration
+
+- **Repository**: The Bitbucket repository of the pull request
+- **Pull Request**: The ID of the pull request
+- **Merge Strategy**: Merge commit, squash or fast forward. The strategy must be allowed for the destination branch.
+- **Commit Message**: Message of the merge commit. Defaults to the message Bitbucket generates.
+- **Close Source Branch**: Delete the source branch after the merge
+
+## Output
+
+The merged pull request, with its state and merge commit. Bitbucket merges large pull requests in the background: the output then has the ` + "`MERGING`" + ` state and the ` + "`task_url`" + ` of the merge task.`
+}
+
+func (c *MergePullRequest) Icon() string {
+	return "git-merge"
+}
+
+func (c *MergePullRequest) Color() string {
+	return "blue"
+}
+
+func (c *MergePullRequest) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *MergePullRequest) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		pullRequestField(),
+		{
+			Name:     "mergeStrategy",
+			Label:    "Merge Strategy",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  MergeStrategyMergeCommit,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Merge commit", Value: MergeStrategyMergeCommit},
+						{Label: "Squash", Value: MergeStrategySquash},
+						{Label: "Fast forward", Value: MergeStrategyFastForward},
+					},
+				},
+			},
+		},
+		{
+			Name:        "message",
+			Label:       "Commit Message",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Message of the merge commit. Defaults to the Bitbucket message.",
+		},
+		{
+			Name:        "closeSourceBranch",
+			Label:       "Close Source Branch",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Delete the source branch after the merge",
+		},
+	}
+}
+
+func (c *MergePullRequest) Setup(ctx core.SetupContext) error {
+	spec, err := decodeMergePullRequestSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Repository)
+	return err
+}
+
+func (c *MergePullRequest) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeMergePullRequestSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	workspace, slug, err := splitRepository(ctx.Integration, spec.Repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClientFromIntegration(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	merged, taskURL, err := client.MergePullRequest(workspace, slug, spec.PullRequest, MergePullRequestRequest{
+		Type:              "pullrequest",
+		Message:           spec.Message,
+		CloseSourceBranch: spec.CloseSourceBranch,
+		MergeStrategy:     spec.MergeStrategy,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to merge pull request %s: %w", spec.PullRequest, err)
+	}
+
+	//
+	// Large pull requests are merged in the background,
+	// and Bitbucket only returns the status of the merge task.
+	//
+	payload := map[string]any{
+		"id":       spec.PullRequest,
+		"state":    "MERGING",
+		"task_url": taskURL,
+	}
+
+	if merged != nil {
+		payload = pullRequestToMap(merged)
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, "bitbucket.pullRequest.merged", []any{payload})
+}
+
+func decodeMergePullRequestSpec(value any) (MergePullRequestSpec, error) {
+	spec := MergePullRequestSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.Repository) == "" {
+		return spec, fmt.Errorf("repository is required")
+	}
+
+	pullRequest, err := parsePullRequestID(spec.PullRequest)
+	if err != nil {
+		return spec, err
+	}
+
+	spec.PullRequest = pullRequest
+	if spec.MergeStrategy == "" {
+		spec.MergeStrategy = MergeStrategyMergeCommit
+	}
+
+	if !slices.Contains([]string{MergeStrategyMergeCommit, MergeStrategySquash, MergeStrategyFastForward}, spec.MergeStrategy) {
+		return spec, fmt.Errorf("invalid merge strategy %q", spec.MergeStrategy)
+	}
+
+	return spec, nil
+}
+
+func (c *MergePullRequest) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *MergePullRequest) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *MergePullRequest) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *MergePullRequest) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *MergePullRequest) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,83 @@
+package bitbucket
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__MergePullRequest__Setup(t *testing.T) {
+	err := (&MergePullRequest{}).Setup(core.SetupContext{
+		HTTP:          &contexts.HTTPContext{},
+		Integration:   testIntegration(),
+		Metadata:      &contexts.MetadataContext{},
+		Configuration: map[string]any{"repository": "superplane/hello", "pullRequest": "42", "mergeStrategy": "rebase"},
+	})
+
+	require.ErrorContains(t, err, `invalid merge strategy "rebase"`)
+}
+
+func Test__MergePullRequest__Execute(t *testing.T) {
+	component := &MergePullRequest{}
+
+	t.Run("merged -> emits pull request", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{
+				"id": 42,
+				"title": "Add health check endpoint",
+				"state": "MERGED",
+				"source": {"branch": {"name": "feature/health-check"}, "commit": {"hash": "1e3a6c7f9d2b"}},
+				"destination": {"branch": {"name": "main"}},
+				"merge_commit": {"hash": "8f2d41a0c6e3"}
+			}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"repository":        "superplane/hello",
+				"pullRequest":       "42",
+				"mergeStrategy":     MergeStrategySquash,
+				"closeSourceBranch": true,
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "/2.0/repositories/superplane/hello/pullrequests/42/merge", httpContext.Requests[0].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"type":"pullrequest","close_source_branch":true,"merge_strategy":"squash"}`, string(body))
+
+		assert.Equal(t, "bitbucket.pullRequest.merged", executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "MERGED", payload["state"])
+		assert.Equal(t, "8f2d41a0c6e3", payload["merge_commit"])
+	})
+
+	t.Run("merged in the background -> emits merge task", func(t *testing.T) {
+		response := jsonResponse(http.StatusAccepted, ``)
+		response.Header.Set("Location", "https://api.bitbucket.org/2.0/repositories/superplane/hello/pullrequests/42/merge/task-status/1")
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"repository": "superplane/hello", "pullRequest": "42"},
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{response}},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "MERGING", payload["state"])
+		assert.Contains(t, payload["task_url"], "/merge/task-status/1")
+	})
+}
@@ -0,0 +1,187 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnPipelineCompleted struct{}
+
+type OnPipelineCompletedConfiguration struct {
+	Repository string                    `json:"repository" mapstructure:"repository"`
+	Results    []string                  `json:"results" mapstructure:"results"`
+	Branches   []configuration.Predicate `json:"branches" mapstructure:"branches"`
+}
+
+func (p *OnPipelineCompleted) Name() string {
+	return "bitbucket.onPipelineCompleted"
+}
+
+func (p *OnPipelineCompleted) Label() string {
+	return "On Pipeline Completed"
+}
+
+func (p *OnPipelineCompleted) Description() string {
+	return "Listen to completed Bitbucket pipelines"
+}
+
+func (p *OnPipelineCompleted) Documentation() string {
+	return `The On Pipeline Completed trigger starts a workflow execution when a Bitbucket Pipelines pipeline completes.
+
+## Use Cases
+
+- **Deployments**: Deploy when the pipeline of the main branch succeeds
+- **Failure notifications**: Notify the team when a pipeline fails
+- **Pipeline chaining**: Run workflows across repositories once a pipeline completes
+
+## Configuration
+
+- **Repository**: Select the Bitbucket repository to monitor
+- **Results**: The pipeline results to listen to
+- **Branches**: Optional. Only pipelines of matching branches start an execution.
+
+## Event Data
+
+Each event includes:
+- **commit_status**: The build status of the pipeline, with its state, the branch and the commit, and a link to the pipeline
+- **repository**: Repository information
+- **actor**: Information about who ran the pipeline
+
+## Webhook Setup
+
+This trigger automatically sets up a Bitbucket webhook when configured. Bitbucket reports pipeline results as commit statuses: the trigger only listens to the statuses of Bitbucket Pipelines. The webhook is managed by SuperPlane and will be cleaned up when the trigger is removed.`
+}
+
+func (p *OnPipelineCompleted) Icon() string {
+	return "bitbucket"
+}
+
+func (p *OnPipelineCompleted) Color() string {
+	return "blue"
+}
+
+func (p *OnPipelineCompleted) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:     "results",
+			Label:    "Results",
+			Type:     configuration.FieldTypeMultiSelect,
+			Required: true,
+			Default:  []string{CommitStatusSuccessful, CommitStatusFailed},
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Successful", Value: CommitStatusSuccessful},
+						{Label: "Failed", Value: CommitStatusFailed},
+						{Label: "Stopped", Value: CommitStatusStopped},
+					},
+				},
+			},
+		},
+		{
+			Name:     "branches",
+			Label:    "Branches",
+			Type:     configuration.FieldTypeAnyPredicateList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+	}
+}
+
+func (p *OnPipelineCompleted) Setup(ctx core.TriggerContext) error {
+	config := OnPipelineCompletedConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if len(config.Results) == 0 {
+		return fmt.Errorf("at least one result is required")
+	}
+
+	repo, err := ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, config.Repository)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		EventTypes:     []string{"repo:commit_status_updated"},
+		RepositorySlug: repo.Slug,
+	})
+}
+
+func (p *OnPipelineCompleted) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (p *OnPipelineCompleted) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (p *OnPipelineCompleted) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	eventKey, status, err := verifyWebhook(ctx, "repo:commit_status_updated")
+	if eventKey == "" {
+		return status, nil, err
+	}
+
+	data := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &data)
+	if err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	config := OnPipelineCompletedConfiguration{}
+	err = mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	//
+	// Bitbucket reports the results of pipelines as commit statuses.
+	// Statuses of other tools, and of pipelines still running, are ignored.
+	//
+	commitStatus, ok := data["commit_status"].(map[string]any)
+	if !ok {
+		return http.StatusOK, nil, nil
+	}
+
+	url, _ := commitStatus["url"].(string)
+	if !strings.Contains(url, "/pipelines/") {
+		return http.StatusOK, nil, nil
+	}
+
+	state, _ := commitStatus["state"].(string)
+	if !slices.Contains(config.Results, state) {
+		return http.StatusOK, nil, nil
+	}
+
+	if len(config.Branches) > 0 {
+		branch, _ := commitStatus["refname"].(string)
+		if !configuration.MatchesAnyPredicate(config.Branches, branch) {
+			return http.StatusOK, nil, nil
+		}
+	}
+
+	err = ctx.Events.Emit("bitbucket.pipeline.completed", data)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (p *OnPipelineCompleted) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
@@ -0,0 +1,73 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func commitStatusPayload(state, refname, url string) []byte {
+	return fmt.Appendf(nil, `{"commit_status":{"type":"build","state":%q,"refname":%q,"url":%q}}`, state, refname, url)
+}
+
+func Test__OnPipelineCompleted__Setup(t *testing.T) {
+	integration := testIntegration()
+	err := (&OnPipelineCompleted{}).Setup(core.TriggerContext{
+		HTTP:          &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"values":[{"uuid":"{hello}","name":"hello","full_name":"superplane/hello","slug":"hello"}]}`)}},
+		Integration:   integration,
+		Metadata:      &contexts.MetadataContext{},
+		Configuration: map[string]any{"repository": "superplane/hello", "results": []string{CommitStatusFailed}},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, integration.WebhookRequests, 1)
+	webhookRequest := integration.WebhookRequests[0].(WebhookConfiguration)
+	assert.Equal(t, []string{"repo:commit_status_updated"}, webhookRequest.EventTypes)
+}
+
+func Test__OnPipelineCompleted__HandleWebhook(t *testing.T) {
+	trigger := &OnPipelineCompleted{}
+	pipelineURL := "https://bitbucket.org/superplane/hello/addon/pipelines/home#!/results/318"
+	config := map[string]any{
+		"repository": "superplane/hello",
+		"results":    []string{CommitStatusSuccessful, CommitStatusFailed},
+		"branches": []configuration.Predicate{
+			{Type: configuration.PredicateTypeEquals, Value: "main"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		body    []byte
+		emitted bool
+	}{
+		{"pipeline in progress -> ignored", commitStatusPayload("INPROGRESS", "main", pipelineURL), false},
+		{"status of another tool -> ignored", commitStatusPayload("SUCCESSFUL", "main", "https://ci.example.com/builds/1"), false},
+		{"result not selected -> ignored", commitStatusPayload("STOPPED", "main", pipelineURL), false},
+		{"branch does not match -> ignored", commitStatusPayload("FAILED", "develop", pipelineURL), false},
+		{"pipeline failed on main -> emitted", commitStatusPayload("FAILED", "main", pipelineURL), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			events := &contexts.EventContext{}
+			code, _, err := trigger.HandleWebhook(signedWebhookRequest("repo:commit_status_updated", test.body, config, events))
+
+			assert.Equal(t, http.StatusOK, code)
+			require.NoError(t, err)
+			if !test.emitted {
+				assert.Zero(t, events.Count())
+				return
+			}
+
+			require.Equal(t, 1, events.Count())
+			assert.Equal(t, "bitbucket.pipeline.completed", events.Payloads[0].Type)
+		})
+	}
+}
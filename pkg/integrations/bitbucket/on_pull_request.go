@@ -0,0 +1,197 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	PullRequestActionCreated  = "created"
+	PullRequestActionUpdated  = "updated"
+	PullRequestActionApproved = "approved"
+	PullRequestActionMerged   = "merged"
+)
+
+// pullRequestEvents maps the actions of the trigger to Bitbucket webhook events.
+var pullRequestEvents = map[string]string{
+	PullRequestActionCreated:  "pullrequest:created",
+	PullRequestActionUpdated:  "pullrequest:updated",
+	PullRequestActionApproved: "pullrequest:approved",
+	PullRequestActionMerged:   "pullrequest:fulfilled",
+}
+
+type OnPullRequest struct{}
+
+type OnPullRequestConfiguration struct {
+	Repository string   `json:"repository" mapstructure:"repository"`
+	Actions    []string `json:"actions" mapstructure:"actions"`
+}
+
+func (p *OnPullRequest) Name() string {
+	return "bitbucket.onPullRequest"
+}
+
+func (p *OnPullRequest) Label() string {
+	return "On Pull Request"
+}
+
+func (p *OnPullRequest) Description() string {
+	return "Listen to Bitbucket pull request events"
+}
+
+func (p *OnPullRequest) Documentation() string {
+	return `The On Pull Request trigger starts a workflow execution when a pull request in a Bitbucket repository is created, updated, approved or merged.
+
+## Use Cases
+
+- **Preview environments**: Deploy a preview environment when a pull request is created or updated
+- **Review automation**: Run checks and notify reviewers on pull request changes
+- **Release workflows**: Deploy when a pull request is merged
+
+## Configuration
+
+- **Repository**: Select the Bitbucket repository to monitor
+- **Actions**: The pull request actions to listen to
+
+## Event Data
+
+Each pull request event includes:
+- **action**: The action of the event: created, updated, approved or merged
+- **pullrequest**: The pull request, with its ID, title, state, source and destination branches
+- **approval**: The approval, for approved pull requests
+- **repository**: Repository information
+- **actor**: Information about who triggered the event
+
+## Webhook Setup
+
+This trigger automatically sets up a Bitbucket webhook when configured. The webhook is managed by SuperPlane and will be cleaned up when the trigger is removed.`
+}
+
+func (p *OnPullRequest) Icon() string {
+	return "bitbucket"
+}
+
+func (p *OnPullRequest) Color() string {
+	return "blue"
+}
+
+func (p *OnPullRequest) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:     "actions",
+			Label:    "Actions",
+			Type:     configuration.FieldTypeMultiSelect,
+			Required: true,
+			Default:  []string{PullRequestActionCreated, PullRequestActionUpdated},
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Created", Value: PullRequestActionCreated},
+						{Label: "Updated", Value: PullRequestActionUpdated},
+						{Label: "Approved", Value: PullRequestActionApproved},
+						{Label: "Merged", Value: PullRequestActionMerged},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *OnPullRequest) Setup(ctx core.TriggerContext) error {
+	config := OnPullRequestConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	events, err := pullRequestEventTypes(config.Actions)
+	if err != nil {
+		return err
+	}
+
+	repo, err := ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, config.Repository)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		EventTypes:     events,
+		RepositorySlug: repo.Slug,
+	})
+}
+
+func (p *OnPullRequest) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (p *OnPullRequest) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (p *OnPullRequest) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	config := OnPullRequestConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	events, err := pullRequestEventTypes(config.Actions)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	eventKey, status, err := verifyWebhook(ctx, events...)
+	if eventKey == "" {
+		return status, nil, err
+	}
+
+	data := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &data)
+	if err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	for action, event := range pullRequestEvents {
+		if event == eventKey {
+			data["action"] = action
+		}
+	}
+
+	err = ctx.Events.Emit("bitbucket.pullRequest", data)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (p *OnPullRequest) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+func pullRequestEventTypes(actions []string) ([]string, error) {
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("at least one action is required")
+	}
+
+	events := []string{}
+	for _, action := range actions {
+		event, ok := pullRequestEvents[action]
+		if !ok {
+			return nil, fmt.Errorf("invalid action %q", action)
+		}
+
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
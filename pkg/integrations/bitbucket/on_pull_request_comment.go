@@ -0,0 +1,168 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnPullRequestComment struct{}
+
+type OnPullRequestCommentConfiguration struct {
+	Repository    string `json:"repository" mapstructure:"repository"`
+	ContentFilter string `json:"contentFilter" mapstructure:"contentFilter"`
+}
+
+func (p *OnPullRequestComment) Name() string {
+	return "bitbucket.onPullRequestComment"
+}
+
+func (p *OnPullRequestComment) Label() string {
+	return "On Pull Request Comment"
+}
+
+func (p *OnPullRequestComment) Description() string {
+	return "Listen to comments on Bitbucket pull requests"
+}
+
+func (p *OnPullRequestComment) Documentation() string {
+	return `The On Pull Request Comment trigger starts a workflow execution when a comment is added to a pull request in a Bitbucket repository.
+
+## Use Cases
+
+- **ChatOps**: Run workflows from commands like ` + "`/deploy`" + ` in pull request comments
+- **Review notifications**: Forward review comments to other tools
+
+## Configuration
+
+- **Repository**: Select the Bitbucket repository to monitor
+- **Content Filter**: Optional regular expression. Only comments matching it start an execution.
+
+## Event Data
+
+Each comment event includes:
+- **comment**: The comment, with its content and author
+- **pullrequest**: The pull request that was commented on
+- **repository**: Repository information
+- **actor**: Information about who commented
+
+## Webhook Setup
+
+This trigger automatically sets up a Bitbucket webhook when configured. The webhook is managed by SuperPlane and will be cleaned up when the trigger is removed.`
+}
+
+func (p *OnPullRequestComment) Icon() string {
+	return "bitbucket"
+}
+
+func (p *OnPullRequestComment) Color() string {
+	return "blue"
+}
+
+func (p *OnPullRequestComment) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:        "contentFilter",
+			Label:       "Content Filter",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Regular expression the comment must match",
+			Placeholder: "e.g. ^/deploy",
+		},
+	}
+}
+
+func (p *OnPullRequestComment) Setup(ctx core.TriggerContext) error {
+	config := OnPullRequestCommentConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.ContentFilter != "" {
+		if _, err := regexp.Compile(config.ContentFilter); err != nil {
+			return fmt.Errorf("invalid content filter: %w", err)
+		}
+	}
+
+	repo, err := ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, config.Repository)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		EventTypes:     []string{"pullrequest:comment_created"},
+		RepositorySlug: repo.Slug,
+	})
+}
+
+func (p *OnPullRequestComment) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (p *OnPullRequestComment) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (p *OnPullRequestComment) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	eventKey, status, err := verifyWebhook(ctx, "pullrequest:comment_created")
+	if eventKey == "" {
+		return status, nil, err
+	}
+
+	data := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &data)
+	if err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	config := OnPullRequestCommentConfiguration{}
+	err = mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.ContentFilter != "" {
+		filter, err := regexp.Compile(config.ContentFilter)
+		if err != nil {
+			return http.StatusInternalServerError, nil, fmt.Errorf("invalid content filter: %w", err)
+		}
+
+		if !filter.MatchString(extractCommentContent(data)) {
+			return http.StatusOK, nil, nil
+		}
+	}
+
+	err = ctx.Events.Emit("bitbucket.pullRequest.comment", data)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (p *OnPullRequestComment) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+// extractCommentContent extracts the raw comment from a Bitbucket comment payload.
+func extractCommentContent(data map[string]any) string {
+	comment, ok := data["comment"].(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	content, ok := comment["content"].(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	raw, _ := content["raw"].(string)
+	return raw
+}
@@ -0,0 +1,67 @@
+package bitbucket
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnPullRequestComment__Setup(t *testing.T) {
+	trigger := &OnPullRequestComment{}
+
+	t.Run("invalid content filter -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "superplane/hello", "contentFilter": "(deploy"},
+		})
+
+		require.ErrorContains(t, err, "invalid content filter")
+	})
+
+	t.Run("webhook is requested", func(t *testing.T) {
+		integration := testIntegration()
+		err := trigger.Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"values":[{"uuid":"{hello}","name":"hello","full_name":"superplane/hello","slug":"hello"}]}`)}},
+			Integration:   integration,
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "superplane/hello"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integration.WebhookRequests, 1)
+		webhookRequest := integration.WebhookRequests[0].(WebhookConfiguration)
+		assert.Equal(t, []string{"pullrequest:comment_created"}, webhookRequest.EventTypes)
+	})
+}
+
+func Test__OnPullRequestComment__HandleWebhook(t *testing.T) {
+	trigger := &OnPullRequestComment{}
+	config := map[string]any{"repository": "superplane/hello", "contentFilter": "^/deploy"}
+
+	t.Run("comment does not match filter -> event is not emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := []byte(`{"comment":{"content":{"raw":"Looks good"}}}`)
+		code, _, err := trigger.HandleWebhook(signedWebhookRequest("pullrequest:comment_created", body, config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("comment matches filter -> event is emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := []byte(`{"comment":{"content":{"raw":"/deploy staging"}},"pullrequest":{"id":42}}`)
+		code, _, err := trigger.HandleWebhook(signedWebhookRequest("pullrequest:comment_created", body, config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "bitbucket.pullRequest.comment", events.Payloads[0].Type)
+	})
+}
@@ -0,0 +1,99 @@
+package bitbucket
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func signedWebhookRequest(eventKey string, body []byte, configuration map[string]any, events *contexts.EventContext) core.WebhookRequestContext {
+	headers := http.Header{}
+	headers.Set("X-Event-Key", eventKey)
+	headers.Set("X-Hub-Signature", "sha256="+signBitbucketPayload("test-secret", body))
+
+	return core.WebhookRequestContext{
+		Body:          body,
+		Headers:       headers,
+		Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+		Configuration: configuration,
+		Events:        events,
+	}
+}
+
+func Test__OnPullRequest__Setup(t *testing.T) {
+	trigger := &OnPullRequest{}
+
+	t.Run("no actions -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "superplane/hello", "actions": []string{}},
+		})
+
+		require.ErrorContains(t, err, "at least one action is required")
+	})
+
+	t.Run("webhook is requested for the selected actions", func(t *testing.T) {
+		integration := testIntegration()
+		err := trigger.Setup(core.TriggerContext{
+			HTTP:        &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"values":[{"uuid":"{hello}","name":"hello","full_name":"superplane/hello","slug":"hello"}]}`)}},
+			Integration: integration,
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"repository": "superplane/hello",
+				"actions":    []string{PullRequestActionCreated, PullRequestActionMerged},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integration.WebhookRequests, 1)
+		webhookRequest := integration.WebhookRequests[0].(WebhookConfiguration)
+		assert.Equal(t, []string{"pullrequest:created", "pullrequest:fulfilled"}, webhookRequest.EventTypes)
+		assert.Equal(t, "hello", webhookRequest.RepositorySlug)
+	})
+}
+
+func Test__OnPullRequest__HandleWebhook(t *testing.T) {
+	trigger := &OnPullRequest{}
+	config := map[string]any{
+		"repository": "superplane/hello",
+		"actions":    []string{PullRequestActionCreated, PullRequestActionMerged},
+	}
+
+	t.Run("action not selected -> event is not emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		code, _, err := trigger.HandleWebhook(signedWebhookRequest("pullrequest:updated", []byte(`{}`), config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("invalid signature -> 403", func(t *testing.T) {
+		request := signedWebhookRequest("pullrequest:created", []byte(`{}`), config, &contexts.EventContext{})
+		request.Headers.Set("X-Hub-Signature", "sha256=invalid")
+
+		code, _, err := trigger.HandleWebhook(request)
+
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Error(t, err)
+	})
+
+	t.Run("merged pull request -> event is emitted with action", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := []byte(`{"pullrequest":{"id":42,"title":"Add health check endpoint","state":"MERGED"}}`)
+		code, _, err := trigger.HandleWebhook(signedWebhookRequest("pullrequest:fulfilled", body, config, events))
+
+		assert.Equal(t, http.StatusOK, code)
+		require.NoError(t, err)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "bitbucket.pullRequest", events.Payloads[0].Type)
+		data := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, PullRequestActionMerged, data["action"])
+	})
+}
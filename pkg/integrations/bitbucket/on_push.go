@@ -4,12 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
 	"github.com/superplanehq/superplane/pkg/core"
-	"github.com/superplanehq/superplane/pkg/crypto"
 )
 
 type OnPush struct{}
@@ -74,7 +72,7 @@ func (p *OnPush) Configuration() []configuration.Field {
 			Required: true,
 			TypeOptions: &configuration.TypeOptions{
 				Resource: &configuration.ResourceTypeOptions{
-					Type:           "repository",
+					Type:           ResourceTypeRepository,
 					UseNameAsValue: true,
 				},
 			},
@@ -126,38 +124,9 @@ func (p *OnPush) HandleHook(ctx core.TriggerHookContext) (map[string]any, error)
 }
 
 func (p *OnPush) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
-	//
-	// Verify the event type.
-	//
-	eventKey := ctx.Headers.Get("X-Event-Key")
+	eventKey, status, err := verifyWebhook(ctx, "repo:push")
 	if eventKey == "" {
-		return http.StatusBadRequest, nil, fmt.Errorf("missing X-Event-Key header")
-	}
-
-	if eventKey != "repo:push" {
-		return http.StatusOK, nil, nil
-	}
-
-	//
-	// Verify the webhook signature.
-	//
-	signature := ctx.Headers.Get("X-Hub-Signature")
-	if signature == "" {
-		return http.StatusForbidden, nil, fmt.Errorf("missing X-Hub-Signature header")
-	}
-
-	signature = strings.TrimPrefix(signature, "sha256=")
-	if signature == "" {
-		return http.StatusForbidden, nil, fmt.Errorf("invalid signature format")
-	}
-
-	secret, err := ctx.Webhook.GetSecret()
-	if err != nil {
-		return http.StatusInternalServerError, nil, fmt.Errorf("error getting webhook secret")
-	}
-
-	if err := crypto.VerifySignature(secret, ctx.Body, signature); err != nil {
-		return http.StatusForbidden, nil, fmt.Errorf("invalid signature")
+		return status, nil, err
 	}
 
 	//
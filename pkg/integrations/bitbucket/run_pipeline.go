@@ -0,0 +1,370 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	RunPipelinePayloadType   = "bitbucket.pipeline.finished"
+	RunPipelinePollAction    = "poll"
+	RunPipelinePollInterval  = 10 * time.Second
+	RunPipelineMaxPollErrors = 5
+)
+
+type RunPipeline struct{}
+
+type RunPipelineSpec struct {
+	Repository string                `json:"repository" mapstructure:"repository"`
+	Branch     string                `json:"branch" mapstructure:"branch"`
+	Pipeline   string                `json:"pipeline" mapstructure:"pipeline"`
+	Variables  []RunPipelineVariable `json:"variables" mapstructure:"variables"`
+}
+
+type RunPipelineVariable struct {
+	Name    string `json:"name" mapstructure:"name"`
+	Value   string `json:"value" mapstructure:"value"`
+	Secured bool   `json:"secured" mapstructure:"secured"`
+}
+
+type RunPipelineMetadata struct {
+	Repository     string `json:"repository" mapstructure:"repository"`
+	PipelineUUID   string `json:"pipelineUuid" mapstructure:"pipelineUuid"`
+	BuildNumber    int64  `json:"buildNumber" mapstructure:"buildNumber"`
+	URL            string `json:"url" mapstructure:"url"`
+	StartedAt      string `json:"startedAt" mapstructure:"startedAt"`
+	Status         string `json:"status" mapstructure:"status"`
+	PollErrorCount int    `json:"pollErrorCount,omitempty" mapstructure:"pollErrorCount"`
+}
+
+func (c *RunPipeline) Name() string {
+	return "bitbucket.runPipeline"
+}
+
+func (c *RunPipeline) Label() string {
+	return "Run Pipeline"
+}
+
+func (c *RunPipeline) Description() string {
+	return "Run a Bitbucket pipeline and wait for its result"
+}
+
+func (c *RunPipeline) Documentation() string {
+	return `The Run Pipeline component runs a Bitbucket Pipelines pipeline on a branch, and waits until the pipeline completes.
+
+## Use Cases
+
+- **CI/CD orchestration**: Run Bitbucket pipelines as steps of a workflow
+- **Deployments**: Run a custom deployment pipeline with the version to deploy as a variable
+- **Gated releases**: Only continue once the pipeline succeeds
+
+## Configuration
+
+- **Repository**: The Bitbucket repository with the pipeline
+- **Branch**: The branch to run the pipeline on
+- **Pipeline**: Name of a custom pipeline, defined under ` + "`pipelines.custom`" + ` in ` + "`bitbucket-pipelines.yml`" + `. When empty, the pipeline of the branch runs.
+- **Variables**: Pipeline variables. Secured variables are hidden in the Bitbucket logs.
+
+## Output Channels
+
+- **Passed**: The pipeline result is SUCCESSFUL
+- **Failed**: The pipeline failed, errored or was stopped
+
+## Notes
+
+The pipeline is followed until it completes by polling Bitbucket every 10 seconds. Cancelling the execution stops the pipeline.`
+}
+
+func (c *RunPipeline) Icon() string {
+	return "play"
+}
+
+func (c *RunPipeline) Color() string {
+	return "blue"
+}
+
+func (c *RunPipeline) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: PassedOutputChannel, Label: "Passed"},
+		{Name: FailedOutputChannel, Label: "Failed"},
+	}
+}
+
+func (c *RunPipeline) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		branchField("The branch to run the pipeline on"),
+		{
+			Name:        "pipeline",
+			Label:       "Pipeline",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Name of a custom pipeline. Runs the pipeline of the branch when empty.",
+		},
+		{
+			Name:     "variables",
+			Label:    "Variables",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Variable",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "name",
+								Label:    "Name",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+							{
+								Name:     "value",
+								Label:    "Value",
+								Type:     configuration.FieldTypeString,
+								Required: false,
+							},
+							{
+								Name:     "secured",
+								Label:    "Secured",
+								Type:     configuration.FieldTypeBool,
+								Required: false,
+								Default:  false,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *RunPipeline) Setup(ctx core.SetupContext) error {
+	spec, err := decodeRunPipelineSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Repository)
+	return err
+}
+
+func (c *RunPipeline) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeRunPipelineSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	workspace, slug, err := splitRepository(ctx.Integration, spec.Repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClientFromIntegration(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	target := PipelineTarget{
+		Type:    "pipeline_ref_target",
+		RefType: "branch",
+		RefName: spec.Branch,
+	}
+
+	if spec.Pipeline != "" {
+		target.Selector = &PipelineSelector{Type: "custom", Pattern: spec.Pipeline}
+	}
+
+	variables := make([]PipelineVariable, 0, len(spec.Variables))
+	for _, variable := range spec.Variables {
+		variables = append(variables, PipelineVariable{
+			Key:     variable.Name,
+			Value:   variable.Value,
+			Secured: variable.Secured,
+		})
+	}
+
+	pipeline, err := client.RunPipeline(workspace, slug, target, variables)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to run pipeline on %s: %v", spec.Branch, err))
+	}
+
+	metadata := RunPipelineMetadata{
+		Repository:   workspace + "/" + slug,
+		PipelineUUID: pipeline.UUID,
+		BuildNumber:  pipeline.BuildNumber,
+		URL:          pipelineURL(workspace, slug, pipeline.BuildNumber),
+		StartedAt:    time.Now().UTC().Format(time.RFC3339),
+		Status:       pipeline.State.Name,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(RunPipelinePollAction, map[string]any{}, RunPipelinePollInterval)
+}
+
+func (c *RunPipeline) Hooks() []core.Hook {
+	return []core.Hook{{Name: RunPipelinePollAction, Type: core.HookTypeInternal}}
+}
+
+func (c *RunPipeline) HandleHook(ctx core.ActionHookContext) error {
+	switch ctx.Name {
+	case RunPipelinePollAction:
+		return c.poll(ctx)
+	default:
+		return fmt.Errorf("unknown hook: %s", ctx.Name)
+	}
+}
+
+func (c *RunPipeline) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := RunPipelineMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	workspace, slug, err := splitRepository(ctx.Integration, metadata.Repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClientFromIntegration(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	pipeline, err := client.GetPipeline(workspace, slug, metadata.PipelineUUID)
+	if err != nil {
+		return c.handlePollError(ctx, metadata, err)
+	}
+
+	metadata.PollErrorCount = 0
+	metadata.Status = pipeline.State.Name
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	result := pipeline.Result()
+	if result == "" {
+		return ctx.Requests.ScheduleActionCall(RunPipelinePollAction, map[string]any{}, RunPipelinePollInterval)
+	}
+
+	channel := PassedOutputChannel
+	if result != PipelineResultSuccess {
+		channel = FailedOutputChannel
+	}
+
+	return ctx.ExecutionState.Emit(channel, RunPipelinePayloadType, []any{pipelineToMap(metadata.Repository, metadata.URL, pipeline)})
+}
+
+func (c *RunPipeline) handlePollError(ctx core.ActionHookContext, metadata RunPipelineMetadata, err error) error {
+	metadata.PollErrorCount++
+	if setErr := ctx.Metadata.Set(metadata); setErr != nil {
+		return setErr
+	}
+
+	if IsNotFound(err) || metadata.PollErrorCount >= RunPipelineMaxPollErrors {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get pipeline #%d: %v", metadata.BuildNumber, err))
+	}
+
+	return ctx.Requests.ScheduleActionCall(RunPipelinePollAction, map[string]any{}, RunPipelinePollInterval)
+}
+
+func (c *RunPipeline) Cancel(ctx core.ExecutionContext) error {
+	metadata := RunPipelineMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return nil
+	}
+
+	if metadata.PipelineUUID == "" {
+		return nil
+	}
+
+	workspace, slug, err := splitRepository(ctx.Integration, metadata.Repository)
+	if err != nil {
+		return nil
+	}
+
+	client, err := newClientFromIntegration(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil
+	}
+
+	if err := client.StopPipeline(workspace, slug, metadata.PipelineUUID); err != nil {
+		ctx.Logger.Warnf("Failed to stop pipeline #%d of %s: %v", metadata.BuildNumber, metadata.Repository, err)
+	} else {
+		ctx.Logger.Infof("Stopped pipeline #%d of %s", metadata.BuildNumber, metadata.Repository)
+	}
+
+	return nil
+}
+
+func (c *RunPipeline) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *RunPipeline) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func decodeRunPipelineSpec(value any) (RunPipelineSpec, error) {
+	spec := RunPipelineSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.Repository) == "" {
+		return spec, fmt.Errorf("repository is required")
+	}
+
+	spec.Branch = strings.TrimSpace(spec.Branch)
+	if spec.Branch == "" {
+		return spec, fmt.Errorf("branch is required")
+	}
+
+	spec.Pipeline = strings.TrimSpace(spec.Pipeline)
+	for i := range spec.Variables {
+		spec.Variables[i].Name = strings.TrimSpace(spec.Variables[i].Name)
+		if spec.Variables[i].Name == "" {
+			return spec, fmt.Errorf("variable %d: name is required", i+1)
+		}
+	}
+
+	return spec, nil
+}
+
+func pipelineURL(workspace, slug string, buildNumber int64) string {
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/pipelines/results/%d", workspace, slug, buildNumber)
+}
+
+func pipelineToMap(repository, url string, pipeline *Pipeline) map[string]any {
+	result := map[string]any{
+		"uuid":                pipeline.UUID,
+		"build_number":        pipeline.BuildNumber,
+		"repository":          repository,
+		"branch":              pipeline.Target.RefName,
+		"state":               pipeline.State.Name,
+		"result":              pipeline.Result(),
+		"created_on":          pipeline.CreatedOn,
+		"completed_on":        pipeline.CompletedOn,
+		"duration_in_seconds": pipeline.DurationInSeconds,
+		"url":                 url,
+	}
+
+	if pipeline.Target.Commit != nil {
+		result["commit"] = pipeline.Target.Commit.Hash
+	}
+
+	return result
+}
@@ -0,0 +1,200 @@
+package bitbucket
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RunPipeline__Setup(t *testing.T) {
+	err := (&RunPipeline{}).Setup(core.SetupContext{
+		HTTP:        &contexts.HTTPContext{},
+		Integration: testIntegration(),
+		Metadata:    &contexts.MetadataContext{},
+		Configuration: map[string]any{
+			"repository": "superplane/hello",
+			"branch":     "main",
+			"variables":  []any{map[string]any{"name": " ", "value": "1.8.2"}},
+		},
+	})
+
+	require.ErrorContains(t, err, "variable 1: name is required")
+}
+
+func Test__RunPipeline__Execute(t *testing.T) {
+	component := &RunPipeline{}
+
+	t.Run("custom pipeline with variables -> runs and polls", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusCreated, `{"uuid":"{3c9a2b44}","build_number":318,"state":{"name":"PENDING"}}`),
+		}}
+
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"repository": "superplane/hello",
+				"branch":     "main",
+				"pipeline":   "deploy",
+				"variables": []any{
+					map[string]any{"name": "VERSION", "value": "1.8.2"},
+					map[string]any{"name": "TOKEN", "value": "secret", "secured": true},
+				},
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, RunPipelinePollAction, requests.Action)
+		assert.Equal(t, RunPipelinePollInterval, requests.Duration)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "/2.0/repositories/superplane/hello/pipelines/", httpContext.Requests[0].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"target": {
+				"type": "pipeline_ref_target",
+				"ref_type": "branch",
+				"ref_name": "main",
+				"selector": {"type": "custom", "pattern": "deploy"}
+			},
+			"variables": [
+				{"key": "VERSION", "value": "1.8.2", "secured": false},
+				{"key": "TOKEN", "value": "secret", "secured": true}
+			]
+		}`, string(body))
+
+		stored := metadata.Metadata.(RunPipelineMetadata)
+		assert.Equal(t, "superplane/hello", stored.Repository)
+		assert.Equal(t, "{3c9a2b44}", stored.PipelineUUID)
+		assert.Equal(t, int64(318), stored.BuildNumber)
+		assert.Equal(t, "https://bitbucket.org/superplane/hello/pipelines/results/318", stored.URL)
+	})
+
+	t.Run("pipeline can not run -> fails execution", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"repository": "superplane/hello", "branch": "main"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusBadRequest, `{"error":{"message":"Pipelines is not enabled"}}`),
+			}},
+			Integration:    testIntegration(),
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to run pipeline on main")
+	})
+}
+
+func Test__RunPipeline__Poll(t *testing.T) {
+	component := &RunPipeline{}
+
+	poll := func(responses ...*http.Response) (*contexts.MetadataContext, *contexts.RequestContext, *contexts.ExecutionStateContext, error) {
+		metadataContext := &contexts.MetadataContext{Metadata: RunPipelineMetadata{
+			Repository:   "superplane/hello",
+			PipelineUUID: "{3c9a2b44}",
+			BuildNumber:  318,
+			URL:          "https://bitbucket.org/superplane/hello/pipelines/results/318",
+			Status:       "PENDING",
+		}}
+
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           RunPipelinePollAction,
+			HTTP:           &contexts.HTTPContext{Responses: responses},
+			Integration:    testIntegration(),
+			Metadata:       metadataContext,
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		return metadataContext, requests, executionState, err
+	}
+
+	t.Run("pipeline running -> polls again", func(t *testing.T) {
+		metadata, requests, executionState, err := poll(jsonResponse(http.StatusOK, `{"uuid":"{3c9a2b44}","state":{"name":"IN_PROGRESS"}}`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, RunPipelinePollAction, requests.Action)
+		assert.Equal(t, "IN_PROGRESS", metadata.Metadata.(RunPipelineMetadata).Status)
+	})
+
+	t.Run("pipeline successful -> emits on passed", func(t *testing.T) {
+		_, _, executionState, err := poll(jsonResponse(http.StatusOK, `{
+			"uuid": "{3c9a2b44}",
+			"build_number": 318,
+			"state": {"name": "COMPLETED", "result": {"name": "SUCCESSFUL"}},
+			"target": {"ref_name": "main", "commit": {"hash": "709d658"}},
+			"duration_in_seconds": 209
+		}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, PassedOutputChannel, executionState.Channel)
+		assert.Equal(t, RunPipelinePayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "SUCCESSFUL", payload["result"])
+		assert.Equal(t, "709d658", payload["commit"])
+		assert.Equal(t, "https://bitbucket.org/superplane/hello/pipelines/results/318", payload["url"])
+	})
+
+	t.Run("pipeline stopped -> emits on failed", func(t *testing.T) {
+		_, _, executionState, err := poll(jsonResponse(http.StatusOK, `{"uuid":"{3c9a2b44}","state":{"name":"COMPLETED","result":{"name":"STOPPED"}}}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, FailedOutputChannel, executionState.Channel)
+	})
+
+	t.Run("transient error -> polls again", func(t *testing.T) {
+		metadata, requests, executionState, err := poll(jsonResponse(http.StatusBadGateway, `bad gateway`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, RunPipelinePollAction, requests.Action)
+		assert.Equal(t, 1, metadata.Metadata.(RunPipelineMetadata).PollErrorCount)
+	})
+
+	t.Run("pipeline not found -> fails execution", func(t *testing.T) {
+		_, _, executionState, err := poll(jsonResponse(http.StatusNotFound, `{"error":{"message":"Not found"}}`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to get pipeline #318")
+	})
+}
+
+func Test__RunPipeline__Cancel(t *testing.T) {
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusNoContent, ``)}}
+	err := (&RunPipeline{}).Cancel(core.ExecutionContext{
+		HTTP:        httpContext,
+		Integration: testIntegration(),
+		Metadata: &contexts.MetadataContext{Metadata: RunPipelineMetadata{
+			Repository:   "superplane/hello",
+			PipelineUUID: "{3c9a2b44}",
+			BuildNumber:  318,
+		}},
+		Logger: logrus.NewEntry(logrus.New()),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, httpContext.Requests, 1)
+	assert.Equal(t, "/2.0/repositories/superplane/hello/pipelines/{3c9a2b44}/stopPipeline", httpContext.Requests[0].URL.Path)
+}
@@ -0,0 +1,241 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type SetCommitStatus struct{}
+
+const (
+	CommitStatusInProgress = "INPROGRESS"
+	CommitStatusSuccessful = "SUCCESSFUL"
+	CommitStatusFailed     = "FAILED"
+	CommitStatusStopped    = "STOPPED"
+)
+
+type SetCommitStatusSpec struct {
+	Repository  string `json:"repository" mapstructure:"repository"`
+	Commit      string `json:"commit" mapstructure:"commit"`
+	State       string `json:"state" mapstructure:"state"`
+	Key         string `json:"key" mapstructure:"key"`
+	Name        string `json:"name" mapstructure:"name"`
+	URL         string `json:"url" mapstructure:"url"`
+	Description string `json:"description" mapstructure:"description"`
+}
+
+func (c *SetCommitStatus) Name() string {
+	return "bitbucket.setCommitStatus"
+}
+
+func (c *SetCommitStatus) Label() string {
+	return "Set Commit Status"
+}
+
+func (c *SetCommitStatus) Description() string {
+	return "Set the build status of a Bitbucket commit"
+}
+
+func (c *SetCommitStatus) Documentation() string {
+	return `The Set Commit Status component reports a build status on a commit in a Bitbucket repository. The status is shown on the commit and on its pull requests, and can be required by merge checks.
+
+## Use Cases
+
+- **External CI**: Report the result of builds and tests that run outside of Bitbucket Pipelines
+- **Deployment status**: Show on a commit whether it was deployed
+- **Merge checks**: Block merges until a SuperPlane workflow succeeds
+
+## Configuration
+
+- **Repository**: The Bitbucket repository of the commit
+- **Commit**: The SHA of the commit
+- **State**: In progress, successful, failed or stopped
+- **Key**: Identifies the status. Setting a status with the same key again updates it.
+- **Name**, **URL** and **Description**: What is shown for the status, and where it links to
+
+## Output
+
+The status that was set.`
+}
+
+func (c *SetCommitStatus) Icon() string {
+	return "circle-check"
+}
+
+func (c *SetCommitStatus) Color() string {
+	return "blue"
+}
+
+func (c *SetCommitStatus) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *SetCommitStatus) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:        "commit",
+			Label:       "Commit",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "SHA of the commit",
+			Placeholder: "{{ root().data.push.changes[0].new.target.hash }}",
+		},
+		{
+			Name:     "state",
+			Label:    "State",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  CommitStatusInProgress,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "In progress", Value: CommitStatusInProgress},
+						{Label: "Successful", Value: CommitStatusSuccessful},
+						{Label: "Failed", Value: CommitStatusFailed},
+						{Label: "Stopped", Value: CommitStatusStopped},
+					},
+				},
+			},
+		},
+		{
+			Name:        "key",
+			Label:       "Key",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Default:     "superplane",
+			Description: "Identifies the status. Setting a status with the same key again updates it.",
+		},
+		{
+			Name:        "name",
+			Label:       "Name",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Name shown for the status",
+		},
+		{
+			Name:        "url",
+			Label:       "URL",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Link to the details of the status",
+		},
+		{
+			Name:     "description",
+			Label:    "Description",
+			Type:     configuration.FieldTypeString,
+			Required: false,
+		},
+	}
+}
+
+func (c *SetCommitStatus) Setup(ctx core.SetupContext) error {
+	spec, err := decodeSetCommitStatusSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Repository)
+	return err
+}
+
+func (c *SetCommitStatus) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeSetCommitStatusSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(spec.Commit) == "" {
+		return fmt.Errorf("commit is required")
+	}
+
+	workspace, slug, err := splitRepository(ctx.Integration, spec.Repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClientFromIntegration(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	status, err := client.SetCommitStatus(workspace, slug, strings.TrimSpace(spec.Commit), CommitStatus{
+		Key:         spec.Key,
+		State:       spec.State,
+		Name:        spec.Name,
+		URL:         spec.URL,
+		Description: spec.Description,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to set status of commit %s: %w", spec.Commit, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"bitbucket.commitStatus",
+		[]any{map[string]any{
+			"commit":      strings.TrimSpace(spec.Commit),
+			"key":         status.Key,
+			"state":       status.State,
+			"name":        status.Name,
+			"url":         status.URL,
+			"description": status.Description,
+			"created_on":  status.CreatedOn,
+			"updated_on":  status.UpdatedOn,
+		}},
+	)
+}
+
+func decodeSetCommitStatusSpec(value any) (SetCommitStatusSpec, error) {
+	spec := SetCommitStatusSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.Repository) == "" {
+		return spec, fmt.Errorf("repository is required")
+	}
+
+	if !slices.Contains([]string{CommitStatusInProgress, CommitStatusSuccessful, CommitStatusFailed, CommitStatusStopped}, spec.State) {
+		return spec, fmt.Errorf("invalid state %q", spec.State)
+	}
+
+	spec.Key = strings.TrimSpace(spec.Key)
+	if spec.Key == "" {
+		return spec, fmt.Errorf("key is required")
+	}
+
+	spec.URL = strings.TrimSpace(spec.URL)
+	if spec.URL == "" {
+		return spec, fmt.Errorf("url is required")
+	}
+
+	return spec, nil
+}
+
+func (c *SetCommitStatus) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *SetCommitStatus) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *SetCommitStatus) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *SetCommitStatus) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *SetCommitStatus) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,87 @@
+package bitbucket
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__SetCommitStatus__Setup(t *testing.T) {
+	component := &SetCommitStatus{}
+
+	t.Run("invalid state -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			HTTP:        &contexts.HTTPContext{},
+			Integration: testIntegration(),
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"repository": "superplane/hello",
+				"state":      "PENDING",
+				"key":        "superplane",
+				"url":        "https://superplane.example.com",
+			},
+		})
+
+		require.ErrorContains(t, err, `invalid state "PENDING"`)
+	})
+
+	t.Run("url is required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			HTTP:        &contexts.HTTPContext{},
+			Integration: testIntegration(),
+			Metadata:    &contexts.MetadataContext{},
+			Configuration: map[string]any{
+				"repository": "superplane/hello",
+				"state":      CommitStatusSuccessful,
+				"key":        "superplane",
+			},
+		})
+
+		require.ErrorContains(t, err, "url is required")
+	})
+}
+
+func Test__SetCommitStatus__Execute(t *testing.T) {
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+		jsonResponse(http.StatusCreated, `{
+			"key": "superplane",
+			"state": "SUCCESSFUL",
+			"name": "Deploy",
+			"url": "https://superplane.example.com",
+			"created_on": "2026-10-19T10:02:31+00:00",
+			"updated_on": "2026-10-19T10:09:14+00:00"
+		}`),
+	}}
+
+	executionState := &contexts.ExecutionStateContext{}
+	err := (&SetCommitStatus{}).Execute(core.ExecutionContext{
+		Configuration: map[string]any{
+			"repository": "superplane/hello",
+			"commit":     " 709d658dc5b6 ",
+			"state":      CommitStatusSuccessful,
+			"key":        "superplane",
+			"name":       "Deploy",
+			"url":        "https://superplane.example.com",
+		},
+		HTTP:           httpContext,
+		Integration:    testIntegration(),
+		ExecutionState: executionState,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, httpContext.Requests, 1)
+	assert.Equal(t, "/2.0/repositories/superplane/hello/commit/709d658dc5b6/statuses/build", httpContext.Requests[0].URL.Path)
+	body, err := io.ReadAll(httpContext.Requests[0].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"superplane","state":"SUCCESSFUL","name":"Deploy","url":"https://superplane.example.com"}`, string(body))
+
+	assert.Equal(t, "bitbucket.commitStatus", executionState.Type)
+	payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, "709d658dc5b6", payload["commit"])
+	assert.Equal(t, "SUCCESSFUL", payload["state"])
+}
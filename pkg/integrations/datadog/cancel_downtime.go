@@ -0,0 +1,135 @@
+package datadog
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CancelDowntime struct{}
+
+type CancelDowntimeSpec struct {
+	DowntimeID string `json:"downtimeId" mapstructure:"downtimeId"`
+}
+
+func (c *CancelDowntime) Name() string {
+	return "datadog.cancelDowntime"
+}
+
+func (c *CancelDowntime) Label() string {
+	return "Cancel Downtime"
+}
+
+func (c *CancelDowntime) Description() string {
+	return "Cancel a downtime in Datadog"
+}
+
+func (c *CancelDowntime) Icon() string {
+	return "calendar-check"
+}
+
+func (c *CancelDowntime) Color() string {
+	return "gray"
+}
+
+func (c *CancelDowntime) Documentation() string {
+	return `The Cancel Downtime component cancels a Datadog downtime, so the monitors it muted notify again.
+
+## Use Cases
+
+- **Deployments**: End the downtime of a deployment as soon as it finished
+- **Cleanup**: Cancel downtimes created earlier in a workflow when it fails
+
+## Outputs
+
+The component emits the ID of the canceled downtime.
+`
+}
+
+func (c *CancelDowntime) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CancelDowntime) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "downtimeId",
+			Label:       "Downtime ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "ID of the downtime to cancel",
+			Placeholder: "{{ $['Create Downtime'].data.id }}",
+		},
+	}
+}
+
+func (c *CancelDowntime) Setup(ctx core.SetupContext) error {
+	_, err := decodeCancelDowntimeSpec(ctx.Configuration)
+	return err
+}
+
+func (c *CancelDowntime) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCancelDowntimeSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	err = client.CancelDowntime(spec.DowntimeID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel downtime %s: %v", spec.DowntimeID, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"datadog.downtime.canceled",
+		[]any{map[string]any{
+			"id":       spec.DowntimeID,
+			"canceled": true,
+		}},
+	)
+}
+
+func decodeCancelDowntimeSpec(value any) (CancelDowntimeSpec, error) {
+	spec := CancelDowntimeSpec{}
+	err := mapstructure.Decode(value, &spec)
+	if err != nil {
+		return spec, fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	spec.DowntimeID = strings.TrimSpace(spec.DowntimeID)
+	if spec.DowntimeID == "" {
+		return spec, errors.New("downtimeId is required")
+	}
+
+	return spec, nil
+}
+
+func (c *CancelDowntime) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CancelDowntime) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CancelDowntime) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CancelDowntime) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CancelDowntime) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
@@ -0,0 +1,61 @@
+package datadog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CancelDowntime__Setup(t *testing.T) {
+	component := &CancelDowntime{}
+
+	t.Run("missing downtime -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"downtimeId": " "}})
+		require.ErrorContains(t, err, "downtimeId is required")
+	})
+}
+
+func Test__CancelDowntime__Execute(t *testing.T) {
+	component := &CancelDowntime{}
+
+	t.Run("cancels the downtime", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{{StatusCode: http.StatusNoContent, Body: http.NoBody}},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"downtimeId": "dt-1"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodDelete, httpContext.Requests[0].Method)
+		assert.Equal(t, "/api/v2/downtime/dt-1", httpContext.Requests[0].URL.Path)
+
+		assert.Equal(t, "datadog.downtime.canceled", executionState.Type)
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "dt-1", data["id"])
+		assert.Equal(t, true, data["canceled"])
+	})
+
+	t.Run("downtime not found -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"downtimeId": "dt-404"},
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{jsonResponse(http.StatusNotFound, `{"errors": ["Downtime not found"]}`)},
+			},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.ErrorContains(t, err, "failed to cancel downtime dt-404")
+	})
+}
@@ -3,9 +3,14 @@ package datadog
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/superplanehq/superplane/pkg/core"
 )
@@ -13,10 +18,25 @@ import (
 type Client struct {
 	APIKey  string
 	AppKey  string
+	Site    string
 	BaseURL string
 	http    core.HTTPContext
 }
 
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request got %d code: %s", e.StatusCode, e.Body)
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
 func NewClient(http core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
 	apiKey, err := ctx.GetConfig("apiKey")
 	if err != nil {
@@ -36,6 +56,7 @@ func NewClient(http core.HTTPContext, ctx core.IntegrationContext) (*Client, err
 	return &Client{
 		APIKey:  string(apiKey),
 		AppKey:  string(appKey),
+		Site:    string(site),
 		BaseURL: fmt.Sprintf("https://api.%s", site),
 		http:    http,
 	}, nil
@@ -63,7 +84,7 @@ func (c *Client) execRequest(method, url string, body io.Reader) ([]byte, error)
 	}
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return nil, fmt.Errorf("request got %d code: %s", res.StatusCode, string(responseBody))
+		return nil, &APIError{StatusCode: res.StatusCode, Body: string(responseBody)}
 	}
 
 	return responseBody, nil
@@ -126,3 +147,283 @@ func (c *Client) CreateEvent(req CreateEventRequest) (*Event, error) {
 
 	return &response.Event, nil
 }
+
+// AppURL is the base URL of the Datadog web app for the site.
+// US1 and EU use an app subdomain, the other sites do not.
+func (c *Client) AppURL() string {
+	if strings.Count(c.Site, ".") > 1 {
+		return "https://" + c.Site
+	}
+
+	return "https://app." + c.Site
+}
+
+func (c *Client) doJSON(method, path string, payload any, out any) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("error marshaling request: %v", err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	responseBody, err := c.execRequest(method, c.BaseURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(responseBody) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(responseBody, out); err != nil {
+		return fmt.Errorf("error parsing response: %v", err)
+	}
+
+	return nil
+}
+
+// MetricSeries is one timeseries of a metrics query.
+type MetricSeries struct {
+	Metric      string       `json:"metric"`
+	DisplayName string       `json:"display_name"`
+	Scope       string       `json:"scope"`
+	Expression  string       `json:"expression"`
+	TagSet      []string     `json:"tag_set"`
+	Pointlist   [][]*float64 `json:"pointlist"`
+	Start       int64        `json:"start"`
+	End         int64        `json:"end"`
+	Interval    int64        `json:"interval"`
+	Length      int          `json:"length"`
+}
+
+type MetricsQueryResponse struct {
+	Status   string         `json:"status"`
+	Error    string         `json:"error"`
+	Query    string         `json:"query"`
+	FromDate int64          `json:"from_date"`
+	ToDate   int64          `json:"to_date"`
+	Series   []MetricSeries `json:"series"`
+}
+
+// QueryMetrics queries the timeseries of a metrics query between two points in time.
+func (c *Client) QueryMetrics(query string, from, to time.Time) (*MetricsQueryResponse, error) {
+	values := url.Values{}
+	values.Set("query", query)
+	values.Set("from", strconv.FormatInt(from.Unix(), 10))
+	values.Set("to", strconv.FormatInt(to.Unix(), 10))
+
+	var response MetricsQueryResponse
+	if err := c.doJSON(http.MethodGet, "/api/v1/query?"+values.Encode(), nil, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Status == "error" {
+		return nil, fmt.Errorf("query failed: %s", response.Error)
+	}
+
+	return &response, nil
+}
+
+// Monitor is a Datadog monitor.
+type Monitor struct {
+	ID           int64          `json:"id"`
+	Name         string         `json:"name"`
+	Type         string         `json:"type"`
+	Query        string         `json:"query"`
+	Message      string         `json:"message"`
+	Tags         []string       `json:"tags"`
+	OverallState string         `json:"overall_state"`
+	Priority     *int           `json:"priority"`
+	Created      string         `json:"created"`
+	Modified     string         `json:"modified"`
+	Options      map[string]any `json:"options"`
+}
+
+func (c *Client) GetMonitor(id int64) (*Monitor, error) {
+	var monitor Monitor
+	if err := c.doJSON(http.MethodGet, fmt.Sprintf("/api/v1/monitor/%d", id), nil, &monitor); err != nil {
+		return nil, err
+	}
+
+	return &monitor, nil
+}
+
+func (c *Client) ListMonitors() ([]Monitor, error) {
+	var monitors []Monitor
+	if err := c.doJSON(http.MethodGet, "/api/v1/monitor", nil, &monitors); err != nil {
+		return nil, err
+	}
+
+	return monitors, nil
+}
+
+// DowntimeAttributes are the attributes of a downtime, as sent to
+// and returned by the downtimes API.
+type DowntimeAttributes struct {
+	Scope             string                    `json:"scope"`
+	Message           string                    `json:"message,omitempty"`
+	Status            string                    `json:"status,omitempty"`
+	MonitorIdentifier DowntimeMonitorIdentifier `json:"monitor_identifier"`
+	Schedule          *DowntimeSchedule         `json:"schedule,omitempty"`
+	Created           string                    `json:"created,omitempty"`
+	Canceled          *string                   `json:"canceled,omitempty"`
+}
+
+type DowntimeMonitorIdentifier struct {
+	MonitorID   *int64   `json:"monitor_id,omitempty"`
+	MonitorTags []string `json:"monitor_tags,omitempty"`
+}
+
+type DowntimeSchedule struct {
+	Start *string `json:"start,omitempty"`
+	End   *string `json:"end,omitempty"`
+}
+
+type Downtime struct {
+	ID         string             `json:"id"`
+	Type       string             `json:"type"`
+	Attributes DowntimeAttributes `json:"attributes"`
+}
+
+func (c *Client) CreateDowntime(attributes DowntimeAttributes) (*Downtime, error) {
+	request := map[string]any{
+		"data": map[string]any{
+			"type":       "downtime",
+			"attributes": attributes,
+		},
+	}
+
+	var response struct {
+		Data Downtime `json:"data"`
+	}
+
+	if err := c.doJSON(http.MethodPost, "/api/v2/downtime", request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+func (c *Client) CancelDowntime(id string) error {
+	return c.doJSON(http.MethodDelete, "/api/v2/downtime/"+url.PathEscape(id), nil, nil)
+}
+
+// ListCurrentDowntimes lists the downtimes that are active or scheduled.
+func (c *Client) ListCurrentDowntimes() ([]Downtime, error) {
+	var response struct {
+		Data []Downtime `json:"data"`
+	}
+
+	if err := c.doJSON(http.MethodGet, "/api/v2/downtime?current_only=true", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// DeploymentRequest is a deployment sent to DORA Metrics.
+// Timestamps are in nanoseconds since the epoch.
+type DeploymentRequest struct {
+	Service    string         `json:"service"`
+	Env        string         `json:"env,omitempty"`
+	Version    string         `json:"version,omitempty"`
+	StartedAt  int64          `json:"started_at"`
+	FinishedAt int64          `json:"finished_at"`
+	Git        *DeploymentGit `json:"git,omitempty"`
+}
+
+type DeploymentGit struct {
+	RepositoryURL string `json:"repository_url"`
+	CommitSHA     string `json:"commit_sha"`
+}
+
+func (c *Client) SubmitDeployment(deployment DeploymentRequest) (string, error) {
+	request := map[string]any{
+		"data": map[string]any{
+			"attributes": deployment,
+		},
+	}
+
+	var response struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+
+	if err := c.doJSON(http.MethodPost, "/api/v2/dora/deployment", request, &response); err != nil {
+		return "", err
+	}
+
+	return response.Data.ID, nil
+}
+
+type Incident struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		PublicID         int64          `json:"public_id"`
+		Title            string         `json:"title"`
+		CustomerImpacted bool           `json:"customer_impacted"`
+		Severity         string         `json:"severity"`
+		State            string         `json:"state"`
+		Created          string         `json:"created"`
+		Fields           map[string]any `json:"fields"`
+	} `json:"attributes"`
+}
+
+func (c *Client) CreateIncident(title, severity string, customerImpacted bool) (*Incident, error) {
+	request := map[string]any{
+		"data": map[string]any{
+			"type": "incidents",
+			"attributes": map[string]any{
+				"title":             title,
+				"customer_impacted": customerImpacted,
+				"fields": map[string]any{
+					"severity": map[string]any{"type": "dropdown", "value": severity},
+				},
+			},
+		},
+	}
+
+	var response struct {
+		Data Incident `json:"data"`
+	}
+
+	if err := c.doJSON(http.MethodPost, "/api/v2/incidents", request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// WebhookRequest is a webhook of the Datadog webhooks integration.
+// Payload and custom headers are JSON documents encoded as strings.
+type WebhookRequest struct {
+	Name          string `json:"name"`
+	URL           string `json:"url"`
+	Payload       string `json:"payload"`
+	CustomHeaders string `json:"custom_headers"`
+	EncodeAs      string `json:"encode_as"`
+}
+
+const webhooksPath = "/api/v1/integration/webhooks/configuration/webhooks"
+
+// UpsertWebhook creates the webhook, or updates it if a webhook with its name exists.
+func (c *Client) UpsertWebhook(webhook WebhookRequest) error {
+	err := c.doJSON(http.MethodGet, webhooksPath+"/"+url.PathEscape(webhook.Name), nil, nil)
+	if err == nil {
+		return c.doJSON(http.MethodPut, webhooksPath+"/"+url.PathEscape(webhook.Name), webhook, nil)
+	}
+
+	if !IsNotFound(err) {
+		return err
+	}
+
+	return c.doJSON(http.MethodPost, webhooksPath, webhook, nil)
+}
+
+func (c *Client) DeleteWebhook(name string) error {
+	return c.doJSON(http.MethodDelete, webhooksPath+"/"+url.PathEscape(name), nil, nil)
+}
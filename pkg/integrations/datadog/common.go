@@ -0,0 +1,177 @@
+package datadog
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+const ResourceTypeMonitor = "monitor"
+
+var durationOptions = []configuration.FieldOption{
+	{Label: "30 minutes", Value: "30m"},
+	{Label: "1 hour", Value: "1h"},
+	{Label: "2 hours", Value: "2h"},
+	{Label: "4 hours", Value: "4h"},
+	{Label: "8 hours", Value: "8h"},
+	{Label: "24 hours", Value: "24h"},
+	{Label: "Until canceled", Value: "none"},
+}
+
+func monitorField(description string, required bool) configuration.Field {
+	return configuration.Field{
+		Name:        "monitor",
+		Label:       "Monitor",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    required,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type: ResourceTypeMonitor,
+			},
+		},
+	}
+}
+
+func durationField(description string) configuration.Field {
+	return configuration.Field{
+		Name:        "duration",
+		Label:       "Duration",
+		Type:        configuration.FieldTypeSelect,
+		Required:    true,
+		Default:     "1h",
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			Select: &configuration.SelectTypeOptions{Options: durationOptions},
+		},
+	}
+}
+
+func parseMonitorID(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("monitor is required")
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid monitor ID %q", value)
+	}
+
+	return id, nil
+}
+
+// parseDuration parses a duration option. "none" means no end, and is
+// returned as zero.
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, fmt.Errorf("duration is required")
+	}
+
+	if value == "none" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	return duration, nil
+}
+
+// downtimeSchedule starts a downtime now, and ends it after the duration.
+func downtimeSchedule(duration time.Duration) *DowntimeSchedule {
+	if duration == 0 {
+		return nil
+	}
+
+	end := time.Now().UTC().Add(duration).Format(time.RFC3339)
+	return &DowntimeSchedule{End: &end}
+}
+
+func monitorToMap(client *Client, monitor *Monitor) map[string]any {
+	return map[string]any{
+		"id":            monitor.ID,
+		"name":          monitor.Name,
+		"type":          monitor.Type,
+		"query":         monitor.Query,
+		"message":       monitor.Message,
+		"tags":          monitor.Tags,
+		"overall_state": monitor.OverallState,
+		"priority":      monitor.Priority,
+		"created":       monitor.Created,
+		"modified":      monitor.Modified,
+		"url":           fmt.Sprintf("%s/monitors/%d", client.AppURL(), monitor.ID),
+	}
+}
+
+func downtimeToMap(client *Client, downtime *Downtime) map[string]any {
+	result := map[string]any{
+		"id":      downtime.ID,
+		"scope":   downtime.Attributes.Scope,
+		"message": downtime.Attributes.Message,
+		"status":  downtime.Attributes.Status,
+		"created": downtime.Attributes.Created,
+		"url":     fmt.Sprintf("%s/monitors/downtimes?id=%s", client.AppURL(), downtime.ID),
+	}
+
+	if downtime.Attributes.MonitorIdentifier.MonitorID != nil {
+		result["monitor_id"] = *downtime.Attributes.MonitorIdentifier.MonitorID
+	}
+
+	if len(downtime.Attributes.MonitorIdentifier.MonitorTags) > 0 {
+		result["monitor_tags"] = downtime.Attributes.MonitorIdentifier.MonitorTags
+	}
+
+	if downtime.Attributes.Schedule != nil {
+		result["start"] = downtime.Attributes.Schedule.Start
+		result["end"] = downtime.Attributes.Schedule.End
+	}
+
+	return result
+}
+
+// seriesToMap adds the minimum, maximum, average and last value of
+// a timeseries, so that workflows can compare them to thresholds.
+func seriesToMap(series MetricSeries) map[string]any {
+	result := map[string]any{
+		"metric":       series.Metric,
+		"display_name": series.DisplayName,
+		"scope":        series.Scope,
+		"expression":   series.Expression,
+		"tag_set":      series.TagSet,
+		"pointlist":    series.Pointlist,
+		"interval":     series.Interval,
+	}
+
+	var values []float64
+	for _, point := range series.Pointlist {
+		if len(point) < 2 || point[1] == nil {
+			continue
+		}
+
+		values = append(values, *point[1])
+	}
+
+	if len(values) == 0 {
+		return result
+	}
+
+	minValue, maxValue, sum := math.Inf(1), math.Inf(-1), 0.0
+	for _, value := range values {
+		minValue = math.Min(minValue, value)
+		maxValue = math.Max(maxValue, value)
+		sum += value
+	}
+
+	result["min"] = minValue
+	result["max"] = maxValue
+	result["avg"] = sum / float64(len(values))
+	result["last"] = values[len(values)-1]
+	return result
+}
@@ -0,0 +1,195 @@
+package datadog
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CreateDowntime struct{}
+
+type CreateDowntimeSpec struct {
+	Scope       string `json:"scope"`
+	Monitor     string `json:"monitor"`
+	MonitorTags string `json:"monitorTags"`
+	Duration    string `json:"duration"`
+	Message     string `json:"message"`
+}
+
+func (c *CreateDowntime) Name() string {
+	return "datadog.createDowntime"
+}
+
+func (c *CreateDowntime) Label() string {
+	return "Create Downtime"
+}
+
+func (c *CreateDowntime) Description() string {
+	return "Schedule a downtime in Datadog"
+}
+
+func (c *CreateDowntime) Icon() string {
+	return "calendar-x"
+}
+
+func (c *CreateDowntime) Color() string {
+	return "gray"
+}
+
+func (c *CreateDowntime) Documentation() string {
+	return `The Create Downtime component schedules a downtime in Datadog that starts now, muting the notifications of the matching monitors.
+
+## Use Cases
+
+- **Deployments**: Mute the monitors of a service while it is deployed
+- **Maintenance windows**: Silence alerts for the hosts a workflow works on
+
+## Configuration
+
+- **Scope**: The scope to mute, like ` + "`env:prod`" + `. Use ` + "`*`" + ` for every scope.
+- **Monitor**: Only mute this monitor
+- **Monitor Tags**: Mute the monitors with all of these tags, when no monitor is selected. Defaults to every monitor.
+- **Duration**: How long the downtime lasts, or until it is canceled
+- **Message**: Why the downtime is scheduled
+
+## Outputs
+
+The component emits the downtime, with its ` + "`id`" + `, ` + "`scope`" + `, ` + "`status`" + `, ` + "`start`" + ` and ` + "`end`" + `. Use the ID to cancel the downtime with Cancel Downtime.
+`
+}
+
+func (c *CreateDowntime) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateDowntime) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "scope",
+			Label:       "Scope",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Default:     "*",
+			Description: "Scope to mute, like env:prod or host:web-1. Use * for every scope.",
+			Placeholder: "env:prod",
+		},
+		monitorField("Only mute this monitor. Leave empty to mute monitors by tags.", false),
+		{
+			Name:        "monitorTags",
+			Label:       "Monitor Tags",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Comma-separated tags of the monitors to mute, when no monitor is selected. Defaults to every monitor.",
+			Placeholder: "service:api,team:platform",
+		},
+		durationField("How long the downtime lasts"),
+		{
+			Name:        "message",
+			Label:       "Message",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Why the downtime is scheduled",
+		},
+	}
+}
+
+func (c *CreateDowntime) Setup(ctx core.SetupContext) error {
+	_, _, err := decodeCreateDowntimeSpec(ctx.Configuration)
+	return err
+}
+
+func (c *CreateDowntime) Execute(ctx core.ExecutionContext) error {
+	spec, duration, err := decodeCreateDowntimeSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	attributes := DowntimeAttributes{
+		Scope:    spec.Scope,
+		Message:  spec.Message,
+		Schedule: downtimeSchedule(duration),
+	}
+
+	if spec.Monitor != "" {
+		monitorID, err := parseMonitorID(spec.Monitor)
+		if err != nil {
+			return err
+		}
+
+		attributes.MonitorIdentifier.MonitorID = &monitorID
+	} else {
+		attributes.MonitorIdentifier.MonitorTags = parseTags(spec.MonitorTags)
+		if len(attributes.MonitorIdentifier.MonitorTags) == 0 {
+			attributes.MonitorIdentifier.MonitorTags = []string{"*"}
+		}
+	}
+
+	downtime, err := client.CreateDowntime(attributes)
+	if err != nil {
+		return fmt.Errorf("failed to create downtime: %v", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"datadog.downtime",
+		[]any{downtimeToMap(client, downtime)},
+	)
+}
+
+func decodeCreateDowntimeSpec(value any) (CreateDowntimeSpec, time.Duration, error) {
+	spec := CreateDowntimeSpec{}
+	err := mapstructure.Decode(value, &spec)
+	if err != nil {
+		return spec, 0, fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	spec.Scope = strings.TrimSpace(spec.Scope)
+	if spec.Scope == "" {
+		return spec, 0, errors.New("scope is required")
+	}
+
+	spec.Monitor = strings.TrimSpace(spec.Monitor)
+	if spec.Monitor != "" && !strings.Contains(spec.Monitor, "{{") {
+		if _, err := parseMonitorID(spec.Monitor); err != nil {
+			return spec, 0, err
+		}
+	}
+
+	duration, err := parseDuration(spec.Duration)
+	if err != nil {
+		return spec, 0, err
+	}
+
+	return spec, duration, nil
+}
+
+func (c *CreateDowntime) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateDowntime) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateDowntime) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CreateDowntime) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreateDowntime) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
@@ -0,0 +1,98 @@
+package datadog
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const downtimeResponse = `{
+	"data": {
+		"id": "3f2a9c1d-7e4b-11ef-8a60-da7ad0900002",
+		"type": "downtime",
+		"attributes": {
+			"scope": "env:prod",
+			"status": "active",
+			"monitor_identifier": {"monitor_tags": ["service:api"]}
+		}
+	}
+}`
+
+func Test__CreateDowntime__Setup(t *testing.T) {
+	component := &CreateDowntime{}
+
+	t.Run("scope is required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"scope": "", "duration": "1h"}})
+		require.ErrorContains(t, err, "scope is required")
+	})
+
+	t.Run("invalid monitor -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"scope": "*", "monitor": "abc", "duration": "1h"}})
+		require.ErrorContains(t, err, "invalid monitor ID")
+	})
+
+	t.Run("monitor expression -> accepted", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"scope": "*", "monitor": "{{ root().data.monitor_id }}", "duration": "1h"},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__CreateDowntime__Execute(t *testing.T) {
+	component := &CreateDowntime{}
+
+	t.Run("downtime by monitor tags", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusOK, downtimeResponse)},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"scope":       "env:prod",
+				"monitorTags": "service:api, team:platform",
+				"duration":    "2h",
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "datadog.downtime", executionState.Type)
+
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"monitor_tags":["service:api","team:platform"]`)
+		assert.NotContains(t, string(body), `"monitor_id"`)
+
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "3f2a9c1d-7e4b-11ef-8a60-da7ad0900002", data["id"])
+		assert.Equal(t, "https://app.datadoghq.com/monitors/downtimes?id=3f2a9c1d-7e4b-11ef-8a60-da7ad0900002", data["url"])
+	})
+
+	t.Run("no monitor or tags -> every monitor", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusOK, downtimeResponse)},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"scope": "host:web-1", "duration": "30m"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.NoError(t, err)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"monitor_tags":["*"]`)
+	})
+}
@@ -0,0 +1,179 @@
+package datadog
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CreateIncident struct{}
+
+type CreateIncidentSpec struct {
+	Title            string `json:"title"`
+	Severity         string `json:"severity"`
+	CustomerImpacted bool   `json:"customerImpacted" mapstructure:"customerImpacted"`
+}
+
+func (c *CreateIncident) Name() string {
+	return "datadog.createIncident"
+}
+
+func (c *CreateIncident) Label() string {
+	return "Create Incident"
+}
+
+func (c *CreateIncident) Description() string {
+	return "Declare an incident in Datadog"
+}
+
+func (c *CreateIncident) Icon() string {
+	return "siren"
+}
+
+func (c *CreateIncident) Color() string {
+	return "gray"
+}
+
+func (c *CreateIncident) Documentation() string {
+	return `The Create Incident component declares an incident in Datadog Incident Management.
+
+## Use Cases
+
+- **Failed deployments**: Declare an incident when a deployment degrades a service and is rolled back
+- **Alert escalation**: Declare an incident when a critical monitor stays in alert
+
+## Configuration
+
+- **Title**: Title of the incident
+- **Severity**: SEV-1 to SEV-5, or Unknown
+- **Customer Impacted**: Whether customers are affected
+
+## Outputs
+
+The component emits the incident, with its ` + "`id`" + `, ` + "`public_id`" + `, ` + "`title`" + `, ` + "`severity`" + `, ` + "`state`" + ` and a ` + "`url`" + ` to it in Datadog.
+`
+}
+
+func (c *CreateIncident) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateIncident) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "title",
+			Label:       "Title",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Title of the incident",
+		},
+		{
+			Name:     "severity",
+			Label:    "Severity",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "UNKNOWN",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "SEV-1", Value: "SEV-1"},
+						{Label: "SEV-2", Value: "SEV-2"},
+						{Label: "SEV-3", Value: "SEV-3"},
+						{Label: "SEV-4", Value: "SEV-4"},
+						{Label: "SEV-5", Value: "SEV-5"},
+						{Label: "Unknown", Value: "UNKNOWN"},
+					},
+				},
+			},
+		},
+		{
+			Name:        "customerImpacted",
+			Label:       "Customer Impacted",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Whether customers are affected by the incident",
+		},
+	}
+}
+
+func (c *CreateIncident) Setup(ctx core.SetupContext) error {
+	_, err := decodeCreateIncidentSpec(ctx.Configuration)
+	return err
+}
+
+func (c *CreateIncident) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCreateIncidentSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	incident, err := client.CreateIncident(spec.Title, spec.Severity, spec.CustomerImpacted)
+	if err != nil {
+		return fmt.Errorf("failed to create incident: %v", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"datadog.incident",
+		[]any{map[string]any{
+			"id":                incident.ID,
+			"public_id":         incident.Attributes.PublicID,
+			"title":             incident.Attributes.Title,
+			"severity":          incident.Attributes.Severity,
+			"state":             incident.Attributes.State,
+			"customer_impacted": incident.Attributes.CustomerImpacted,
+			"created":           incident.Attributes.Created,
+			"url":               fmt.Sprintf("%s/incidents/%d", client.AppURL(), incident.Attributes.PublicID),
+		}},
+	)
+}
+
+func decodeCreateIncidentSpec(value any) (CreateIncidentSpec, error) {
+	spec := CreateIncidentSpec{}
+	err := mapstructure.Decode(value, &spec)
+	if err != nil {
+		return spec, fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	spec.Title = strings.TrimSpace(spec.Title)
+	if spec.Title == "" {
+		return spec, errors.New("title is required")
+	}
+
+	if spec.Severity == "" {
+		spec.Severity = "UNKNOWN"
+	}
+
+	return spec, nil
+}
+
+func (c *CreateIncident) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateIncident) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateIncident) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CreateIncident) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreateIncident) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
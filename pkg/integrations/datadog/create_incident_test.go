@@ -0,0 +1,59 @@
+package datadog
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreateIncident__Execute(t *testing.T) {
+	component := &CreateIncident{}
+
+	t.Run("incident is declared", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusCreated, `{
+					"data": {
+						"id": "00000000-0000-0000-1234-000000000000",
+						"type": "incidents",
+						"attributes": {"public_id": 42, "title": "API errors", "severity": "SEV-2", "state": "active"}
+					}
+				}`),
+			},
+		}
+
+		integration := testIntegration()
+		integration.Configuration["site"] = "us5.datadoghq.com"
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"title": "API errors", "severity": "SEV-2", "customerImpacted": true},
+			HTTP:           httpContext,
+			Integration:    integration,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "datadog.incident", executionState.Type)
+		assert.Equal(t, "https://api.us5.datadoghq.com/api/v2/incidents", httpContext.Requests[0].URL.String())
+
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"customer_impacted":true`)
+		assert.Contains(t, string(body), `"severity":{"type":"dropdown","value":"SEV-2"}`)
+
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, int64(42), data["public_id"])
+		assert.Equal(t, "https://us5.datadoghq.com/incidents/42", data["url"])
+	})
+
+	t.Run("title is required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"title": " "}})
+		require.ErrorContains(t, err, "title is required")
+	})
+}
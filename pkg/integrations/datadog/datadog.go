@@ -2,6 +2,7 @@ package datadog
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/superplanehq/superplane/pkg/configuration"
@@ -16,10 +17,14 @@ To configure Datadog to work with SuperPlane:
 2. **Get Application Key**: Go to Organization Settings > Application Keys to create an Application Key
 3. **Select Site**: Choose the Datadog site that matches your account (US1, US3, US5, EU, or AP1)
 4. **Enter Credentials**: Provide your API Key, Application Key, and Site in the integration configuration
+
+The Application Key needs access to monitors, downtimes, metrics, incidents and the webhooks integration for every component to work.
+
+The **On Monitor Alert** trigger receives notifications through a webhook SuperPlane creates in the Datadog webhooks integration. Add the ` + "`@webhook-superplane-…`" + ` mention shown on the trigger to the message of the monitors it should listen to.
 `
 
 func init() {
-	registry.RegisterIntegration("datadog", &Datadog{})
+	registry.RegisterIntegrationWithWebhookHandler("datadog", &Datadog{}, &DatadogWebhookHandler{})
 }
 
 type Datadog struct{}
@@ -43,7 +48,7 @@ func (d *Datadog) Icon() string {
 }
 
 func (d *Datadog) Description() string {
-	return "Create events in Datadog"
+	return "Query metrics, manage monitors and downtimes, and react to monitor alerts in Datadog"
 }
 
 func (d *Datadog) Instructions() string {
@@ -92,11 +97,21 @@ func (d *Datadog) Configuration() []configuration.Field {
 func (d *Datadog) Actions() []core.Action {
 	return []core.Action{
 		&CreateEvent{},
+		&QueryMetrics{},
+		&GetMonitor{},
+		&MuteMonitor{},
+		&UnmuteMonitor{},
+		&CreateDowntime{},
+		&CancelDowntime{},
+		&SubmitDeployment{},
+		&CreateIncident{},
 	}
 }
 
 func (d *Datadog) Triggers() []core.Trigger {
-	return []core.Trigger{}
+	return []core.Trigger{
+		&OnMonitorAlert{},
+	}
 }
 
 func (d *Datadog) Cleanup(ctx core.IntegrationCleanupContext) error {
@@ -141,7 +156,30 @@ func (d *Datadog) HandleRequest(ctx core.HTTPRequestContext) {
 }
 
 func (d *Datadog) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
-	return []core.IntegrationResource{}, nil
+	if resourceType != ResourceTypeMonitor {
+		return []core.IntegrationResource{}, nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, fmt.Errorf("error creating client: %v", err)
+	}
+
+	monitors, err := client.ListMonitors()
+	if err != nil {
+		return nil, fmt.Errorf("error listing monitors: %v", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(monitors))
+	for _, monitor := range monitors {
+		resources = append(resources, core.IntegrationResource{
+			Type: ResourceTypeMonitor,
+			Name: monitor.Name,
+			ID:   strconv.FormatInt(monitor.ID, 10),
+		})
+	}
+
+	return resources, nil
 }
 
 func (d *Datadog) Hooks() []core.Hook {
@@ -156,3 +156,41 @@ func Test__Datadog__Sync(t *testing.T) {
 		assert.NotEqual(t, "ready", appCtx.State)
 	})
 }
+
+func Test__Datadog__ListResources(t *testing.T) {
+	d := &Datadog{}
+
+	t.Run("monitors", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `[{"id": 1, "name": "API errors"}, {"id": 2, "name": "API latency"}]`),
+			},
+		}
+
+		resources, err := d.ListResources(ResourceTypeMonitor, core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []core.IntegrationResource{
+			{Type: ResourceTypeMonitor, Name: "API errors", ID: "1"},
+			{Type: ResourceTypeMonitor, Name: "API latency", ID: "2"},
+		}, resources)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://api.datadoghq.com/api/v1/monitor", httpContext.Requests[0].URL.String())
+	})
+
+	t.Run("unknown resource type -> empty", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		resources, err := d.ListResources("dashboard", core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+		assert.Empty(t, httpContext.Requests)
+	})
+}
@@ -16,3 +16,93 @@ var exampleOutputCreateEvent map[string]any
 func (c *CreateEvent) ExampleOutput() map[string]any {
 	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateEventOnce, exampleOutputCreateEventBytes, &exampleOutputCreateEvent)
 }
+
+//go:embed example_output_query_metrics.json
+var exampleOutputQueryMetricsBytes []byte
+
+var exampleOutputQueryMetricsOnce sync.Once
+var exampleOutputQueryMetrics map[string]any
+
+func (c *QueryMetrics) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputQueryMetricsOnce, exampleOutputQueryMetricsBytes, &exampleOutputQueryMetrics)
+}
+
+//go:embed example_output_get_monitor.json
+var exampleOutputGetMonitorBytes []byte
+
+var exampleOutputGetMonitorOnce sync.Once
+var exampleOutputGetMonitor map[string]any
+
+func (c *GetMonitor) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputGetMonitorOnce, exampleOutputGetMonitorBytes, &exampleOutputGetMonitor)
+}
+
+//go:embed example_output_mute_monitor.json
+var exampleOutputMuteMonitorBytes []byte
+
+var exampleOutputMuteMonitorOnce sync.Once
+var exampleOutputMuteMonitor map[string]any
+
+func (c *MuteMonitor) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputMuteMonitorOnce, exampleOutputMuteMonitorBytes, &exampleOutputMuteMonitor)
+}
+
+//go:embed example_output_unmute_monitor.json
+var exampleOutputUnmuteMonitorBytes []byte
+
+var exampleOutputUnmuteMonitorOnce sync.Once
+var exampleOutputUnmuteMonitor map[string]any
+
+func (c *UnmuteMonitor) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputUnmuteMonitorOnce, exampleOutputUnmuteMonitorBytes, &exampleOutputUnmuteMonitor)
+}
+
+//go:embed example_output_create_downtime.json
+var exampleOutputCreateDowntimeBytes []byte
+
+var exampleOutputCreateDowntimeOnce sync.Once
+var exampleOutputCreateDowntime map[string]any
+
+func (c *CreateDowntime) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateDowntimeOnce, exampleOutputCreateDowntimeBytes, &exampleOutputCreateDowntime)
+}
+
+//go:embed example_output_cancel_downtime.json
+var exampleOutputCancelDowntimeBytes []byte
+
+var exampleOutputCancelDowntimeOnce sync.Once
+var exampleOutputCancelDowntime map[string]any
+
+func (c *CancelDowntime) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCancelDowntimeOnce, exampleOutputCancelDowntimeBytes, &exampleOutputCancelDowntime)
+}
+
+//go:embed example_output_submit_deployment.json
+var exampleOutputSubmitDeploymentBytes []byte
+
+var exampleOutputSubmitDeploymentOnce sync.Once
+var exampleOutputSubmitDeployment map[string]any
+
+func (c *SubmitDeployment) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSubmitDeploymentOnce, exampleOutputSubmitDeploymentBytes, &exampleOutputSubmitDeployment)
+}
+
+//go:embed example_output_create_incident.json
+var exampleOutputCreateIncidentBytes []byte
+
+var exampleOutputCreateIncidentOnce sync.Once
+var exampleOutputCreateIncident map[string]any
+
+func (c *CreateIncident) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateIncidentOnce, exampleOutputCreateIncidentBytes, &exampleOutputCreateIncident)
+}
+
+//go:embed example_data_on_monitor_alert.json
+var exampleDataOnMonitorAlertBytes []byte
+
+var exampleDataOnMonitorAlertOnce sync.Once
+var exampleDataOnMonitorAlert map[string]any
+
+func (t *OnMonitorAlert) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnMonitorAlertOnce, exampleDataOnMonitorAlertBytes, &exampleDataOnMonitorAlert)
+}
@@ -0,0 +1,124 @@
+package datadog
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type GetMonitor struct{}
+
+// MonitorSpec is the configuration of the actions
+// that only need a monitor.
+type MonitorSpec struct {
+	Monitor string `json:"monitor"`
+}
+
+func (c *GetMonitor) Name() string {
+	return "datadog.getMonitor"
+}
+
+func (c *GetMonitor) Label() string {
+	return "Get Monitor"
+}
+
+func (c *GetMonitor) Description() string {
+	return "Get a monitor and its state from Datadog"
+}
+
+func (c *GetMonitor) Icon() string {
+	return "eye"
+}
+
+func (c *GetMonitor) Color() string {
+	return "gray"
+}
+
+func (c *GetMonitor) Documentation() string {
+	return `The Get Monitor component gets a Datadog monitor, with its current state.
+
+## Use Cases
+
+- **Deployment gates**: Only continue a rollout while a monitor is OK
+- **Enrichment**: Add the query and message of a monitor to a ticket or a notification
+
+## Outputs
+
+The component emits the monitor, with:
+- ` + "`id`" + `, ` + "`name`" + ` and ` + "`type`" + `: The monitor
+- ` + "`overall_state`" + `: The state of the monitor, like OK, Alert, Warn or No Data
+- ` + "`query`" + `, ` + "`message`" + ` and ` + "`tags`" + `: How the monitor is defined
+- ` + "`url`" + `: Link to the monitor in Datadog
+`
+}
+
+func (c *GetMonitor) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *GetMonitor) Configuration() []configuration.Field {
+	return []configuration.Field{
+		monitorField("The monitor to get", true),
+	}
+}
+
+func (c *GetMonitor) Setup(ctx core.SetupContext) error {
+	_, err := decodeMonitorSpec(ctx.Configuration)
+	return err
+}
+
+func (c *GetMonitor) Execute(ctx core.ExecutionContext) error {
+	monitorID, err := decodeMonitorSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	monitor, err := client.GetMonitor(monitorID)
+	if err != nil {
+		return fmt.Errorf("failed to get monitor %d: %v", monitorID, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"datadog.monitor",
+		[]any{monitorToMap(client, monitor)},
+	)
+}
+
+func decodeMonitorSpec(value any) (int64, error) {
+	spec := MonitorSpec{}
+	err := mapstructure.Decode(value, &spec)
+	if err != nil {
+		return 0, fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	return parseMonitorID(spec.Monitor)
+}
+
+func (c *GetMonitor) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *GetMonitor) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *GetMonitor) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *GetMonitor) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *GetMonitor) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
@@ -0,0 +1,67 @@
+package datadog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GetMonitor__Setup(t *testing.T) {
+	component := &GetMonitor{}
+
+	t.Run("monitor is required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{}})
+		require.ErrorContains(t, err, "monitor is required")
+	})
+
+	t.Run("invalid monitor ID -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"monitor": "api-latency"}})
+		require.ErrorContains(t, err, "invalid monitor ID")
+	})
+}
+
+func Test__GetMonitor__Execute(t *testing.T) {
+	component := &GetMonitor{}
+
+	t.Run("monitor is emitted with its URL", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusOK, `{"id":12345678,"name":"API p95 latency is high","type":"query alert","query":"avg(last_5m):p95:trace.http.request.duration{service:api} > 0.5","message":"API latency is above 500ms.","tags":["service:api","env:prod"],"overall_state":"Alert","priority":2}`)},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"monitor": "12345678"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "datadog.monitor", executionState.Type)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "/api/v1/monitor/12345678", httpContext.Requests[0].URL.Path)
+
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "Alert", data["overall_state"])
+		assert.Equal(t, "https://app.datadoghq.com/monitors/12345678", data["url"])
+	})
+
+	t.Run("monitor not found -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusNotFound, `{"errors": ["Monitor not found"]}`)},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"monitor": "12345678"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.ErrorContains(t, err, "request got 404 code")
+	})
+}
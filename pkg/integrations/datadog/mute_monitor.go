@@ -0,0 +1,176 @@
+package datadog
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type MuteMonitor struct{}
+
+type MuteMonitorSpec struct {
+	Monitor  string `json:"monitor"`
+	Scope    string `json:"scope"`
+	Duration string `json:"duration"`
+	Message  string `json:"message"`
+}
+
+func (c *MuteMonitor) Name() string {
+	return "datadog.muteMonitor"
+}
+
+func (c *MuteMonitor) Label() string {
+	return "Mute Monitor"
+}
+
+func (c *MuteMonitor) Description() string {
+	return "Mute a monitor in Datadog"
+}
+
+func (c *MuteMonitor) Icon() string {
+	return "bell-off"
+}
+
+func (c *MuteMonitor) Color() string {
+	return "gray"
+}
+
+func (c *MuteMonitor) Documentation() string {
+	return `The Mute Monitor component mutes a Datadog monitor, by scheduling a downtime for it that starts now.
+
+## Use Cases
+
+- **Deployments**: Mute monitors that are expected to fire while a service restarts
+- **Maintenance**: Silence a monitor while a workflow runs a migration
+
+## Configuration
+
+- **Monitor**: The monitor to mute
+- **Scope**: Only mute the monitor for this scope, like ` + "`env:prod`" + `. Defaults to every scope.
+- **Duration**: How long the monitor stays muted, or until it is unmuted
+- **Message**: Why the monitor is muted
+
+## Outputs
+
+The component emits the monitor, and the downtime that mutes it under ` + "`downtime`" + `. Use the ID of the downtime to cancel it, or unmute the monitor with Unmute Monitor.
+`
+}
+
+func (c *MuteMonitor) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *MuteMonitor) Configuration() []configuration.Field {
+	return []configuration.Field{
+		monitorField("The monitor to mute", true),
+		{
+			Name:        "scope",
+			Label:       "Scope",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Default:     "*",
+			Description: "Only mute the monitor for this scope, like env:prod. Defaults to every scope.",
+			Placeholder: "env:prod",
+		},
+		durationField("How long the monitor stays muted"),
+		{
+			Name:        "message",
+			Label:       "Message",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Why the monitor is muted",
+		},
+	}
+}
+
+func (c *MuteMonitor) Setup(ctx core.SetupContext) error {
+	_, _, _, err := decodeMuteMonitorSpec(ctx.Configuration)
+	return err
+}
+
+func (c *MuteMonitor) Execute(ctx core.ExecutionContext) error {
+	spec, monitorID, duration, err := decodeMuteMonitorSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	monitor, err := client.GetMonitor(monitorID)
+	if err != nil {
+		return fmt.Errorf("failed to get monitor %d: %v", monitorID, err)
+	}
+
+	downtime, err := client.CreateDowntime(DowntimeAttributes{
+		Scope:             spec.Scope,
+		Message:           spec.Message,
+		MonitorIdentifier: DowntimeMonitorIdentifier{MonitorID: &monitorID},
+		Schedule:          downtimeSchedule(duration),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to mute monitor %d: %v", monitorID, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"datadog.monitor.muted",
+		[]any{map[string]any{
+			"monitor":  monitorToMap(client, monitor),
+			"downtime": downtimeToMap(client, downtime),
+		}},
+	)
+}
+
+func decodeMuteMonitorSpec(value any) (MuteMonitorSpec, int64, time.Duration, error) {
+	spec := MuteMonitorSpec{}
+	err := mapstructure.Decode(value, &spec)
+	if err != nil {
+		return spec, 0, 0, fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	monitorID, err := parseMonitorID(spec.Monitor)
+	if err != nil {
+		return spec, 0, 0, err
+	}
+
+	duration, err := parseDuration(spec.Duration)
+	if err != nil {
+		return spec, 0, 0, err
+	}
+
+	spec.Scope = strings.TrimSpace(spec.Scope)
+	if spec.Scope == "" {
+		spec.Scope = "*"
+	}
+
+	return spec, monitorID, duration, nil
+}
+
+func (c *MuteMonitor) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *MuteMonitor) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *MuteMonitor) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *MuteMonitor) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *MuteMonitor) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
@@ -0,0 +1,103 @@
+package datadog
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__MuteMonitor__Execute(t *testing.T) {
+	component := &MuteMonitor{}
+
+	t.Run("creates a downtime for the monitor", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":12345678,"name":"API p95 latency is high","type":"query alert","query":"avg(last_5m):p95:trace.http.request.duration{service:api} > 0.5","message":"API latency is above 500ms.","tags":["service:api","env:prod"],"overall_state":"Alert","priority":2}`),
+				jsonResponse(http.StatusOK, `{
+					"data": {
+						"id": "3f2a9c1d-7e4b-11ef-8a60-da7ad0900002",
+						"type": "downtime",
+						"attributes": {
+							"scope": "env:prod",
+							"status": "active",
+							"monitor_identifier": {"monitor_id": 12345678},
+							"schedule": {"start": "2026-01-19T12:00:00+00:00", "end": "2026-01-19T13:00:00+00:00"}
+						}
+					}
+				}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"monitor":  "12345678",
+				"scope":    "env:prod",
+				"duration": "1h",
+				"message":  "Deploying api",
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "datadog.monitor.muted", executionState.Type)
+
+		require.Len(t, httpContext.Requests, 2)
+		request := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/api/v2/downtime", request.URL.Path)
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+
+		payload := struct {
+			Data struct {
+				Attributes DowntimeAttributes `json:"attributes"`
+			} `json:"data"`
+		}{}
+
+		require.NoError(t, json.Unmarshal(body, &payload))
+		attributes := payload.Data.Attributes
+		assert.Equal(t, "env:prod", attributes.Scope)
+		assert.Equal(t, "Deploying api", attributes.Message)
+		require.NotNil(t, attributes.MonitorIdentifier.MonitorID)
+		assert.Equal(t, int64(12345678), *attributes.MonitorIdentifier.MonitorID)
+		require.NotNil(t, attributes.Schedule)
+		require.NotNil(t, attributes.Schedule.End)
+
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		downtime := data["downtime"].(map[string]any)
+		assert.Equal(t, "3f2a9c1d-7e4b-11ef-8a60-da7ad0900002", downtime["id"])
+		assert.Equal(t, int64(12345678), downtime["monitor_id"])
+	})
+
+	t.Run("until canceled -> no schedule", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":12345678,"name":"API p95 latency is high","type":"query alert","query":"avg(last_5m):p95:trace.http.request.duration{service:api} > 0.5","message":"API latency is above 500ms.","tags":["service:api","env:prod"],"overall_state":"Alert","priority":2}`),
+				jsonResponse(http.StatusOK, `{"data": {"id": "abc", "type": "downtime", "attributes": {"scope": "*"}}}`),
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"monitor": "12345678", "duration": "none"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.NoError(t, err)
+		body, err := io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		assert.NotContains(t, string(body), "schedule")
+		assert.Contains(t, string(body), `"scope":"*"`)
+	})
+}
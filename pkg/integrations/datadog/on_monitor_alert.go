@@ -0,0 +1,278 @@
+package datadog
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const OnMonitorAlertPayloadType = "datadog.monitor.alert"
+
+var transitionOptions = []configuration.FieldOption{
+	{Label: "Triggered", Value: "Triggered"},
+	{Label: "Re-Triggered", Value: "Re-Triggered"},
+	{Label: "Warn", Value: "Warn"},
+	{Label: "Re-Warn", Value: "Re-Warn"},
+	{Label: "Recovered", Value: "Recovered"},
+	{Label: "No Data", Value: "No Data"},
+	{Label: "Renotify", Value: "Renotify"},
+}
+
+type OnMonitorAlert struct{}
+
+type OnMonitorAlertConfiguration struct {
+	Transitions []string `json:"transitions" mapstructure:"transitions"`
+	Monitors    []string `json:"monitors" mapstructure:"monitors"`
+	Tags        []string `json:"tags" mapstructure:"tags"`
+}
+
+type OnMonitorAlertMetadata struct {
+	WebhookName string `json:"webhookName" mapstructure:"webhookName"`
+	Mention     string `json:"mention" mapstructure:"mention"`
+}
+
+func (t *OnMonitorAlert) Name() string {
+	return "datadog.onMonitorAlert"
+}
+
+func (t *OnMonitorAlert) Label() string {
+	return "On Monitor Alert"
+}
+
+func (t *OnMonitorAlert) Description() string {
+	return "Listen to Datadog monitor notifications"
+}
+
+func (t *OnMonitorAlert) Documentation() string {
+	return `The On Monitor Alert trigger starts a workflow when a Datadog monitor notifies, like when it triggers or recovers.
+
+## Use Cases
+
+- **Deployment verification**: Roll back a deployment when the monitors of the service trigger
+- **Automated diagnostics**: Collect logs and traces as soon as a monitor triggers
+- **Incident response**: Page the on-call engineer or declare an incident for critical monitors
+
+## Configuration
+
+- **Transitions**: Only fire for these monitor transitions. Defaults to Triggered.
+- **Monitors**: Only fire for these monitors. Leave empty for every monitor.
+- **Tags**: Only fire for monitors with at least one of these tags. Leave empty for every monitor.
+
+## Event Data
+
+The notification, with the monitor ID and name, the transition, the alert type, priority, scope, tags, hostname, title, body and a link to the event.
+
+## Webhook Setup
+
+SuperPlane creates a webhook in the Datadog webhooks integration when the trigger is saved, and deletes it when the last trigger of the integration is removed. Datadog only sends notifications of monitors that mention the webhook, so add the ` + "`@webhook-superplane-…`" + ` mention shown on the trigger to the message of every monitor the trigger should listen to.`
+}
+
+func (t *OnMonitorAlert) Icon() string {
+	return "alert-triangle"
+}
+
+func (t *OnMonitorAlert) Color() string {
+	return "gray"
+}
+
+func (t *OnMonitorAlert) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "transitions",
+			Label:       "Transitions",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    true,
+			Default:     []string{"Triggered"},
+			Description: "Only fire for these monitor transitions",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{Options: transitionOptions},
+			},
+		},
+		{
+			Name:        "monitors",
+			Label:       "Monitors",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Only fire for these monitors",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type:  ResourceTypeMonitor,
+					Multi: true,
+				},
+			},
+		},
+		{
+			Name:        "tags",
+			Label:       "Tags",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Only fire for monitors with at least one of these tags",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Tag",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *OnMonitorAlert) Setup(ctx core.TriggerContext) error {
+	config := OnMonitorAlertConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if len(config.Transitions) == 0 {
+		return fmt.Errorf("at least one transition is required")
+	}
+
+	for _, transition := range config.Transitions {
+		if !slices.ContainsFunc(transitionOptions, func(option configuration.FieldOption) bool {
+			return option.Value == transition
+		}) {
+			return fmt.Errorf("invalid transition %q", transition)
+		}
+	}
+
+	for _, monitor := range config.Monitors {
+		if _, err := parseMonitorID(monitor); err != nil {
+			return err
+		}
+	}
+
+	name := webhookName(ctx.Integration.ID())
+	err := ctx.Metadata.Set(OnMonitorAlertMetadata{
+		WebhookName: name,
+		Mention:     "@webhook-" + name,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{})
+}
+
+func (t *OnMonitorAlert) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (t *OnMonitorAlert) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnMonitorAlert) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	config := OnMonitorAlertConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	header := strings.TrimSpace(ctx.Headers.Get(webhookSecretHeader))
+	if header == "" {
+		return http.StatusForbidden, nil, fmt.Errorf("missing %s header", webhookSecretHeader)
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error authenticating request")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(header), secret) != 1 {
+		return http.StatusForbidden, nil, fmt.Errorf("invalid webhook secret")
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(ctx.Body, &payload); err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	normalizeMonitorAlert(payload)
+
+	transition, _ := payload["alert_transition"].(string)
+	if !slices.Contains(config.Transitions, transition) {
+		return http.StatusOK, nil, nil
+	}
+
+	monitors := nonEmpty(config.Monitors)
+	if len(monitors) > 0 {
+		monitorID, _ := payload["monitor_id"].(int64)
+		if !slices.Contains(monitors, strconv.FormatInt(monitorID, 10)) {
+			return http.StatusOK, nil, nil
+		}
+	}
+
+	tags := nonEmpty(config.Tags)
+	if len(tags) > 0 {
+		alertTags, _ := payload["tags"].([]string)
+		if !slices.ContainsFunc(tags, func(tag string) bool { return slices.Contains(alertTags, tag) }) {
+			return http.StatusOK, nil, nil
+		}
+	}
+
+	if err := ctx.Events.Emit(OnMonitorAlertPayloadType, payload); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to emit event: %w", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnMonitorAlert) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+// normalizeMonitorAlert converts the values Datadog templates
+// into strings back into numbers and lists.
+func normalizeMonitorAlert(payload map[string]any) {
+	for _, key := range []string{"monitor_id", "date"} {
+		payload[key] = parseInt(payload[key])
+	}
+
+	if org, ok := payload["org"].(map[string]any); ok {
+		org["id"] = parseInt(org["id"])
+	}
+
+	if value, ok := payload["tags"].(string); ok {
+		tags := parseTags(value)
+		if tags == nil {
+			tags = []string{}
+		}
+
+		payload["tags"] = tags
+	}
+}
+
+func parseInt(value any) any {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return value
+	}
+
+	return n
+}
+
+func nonEmpty(values []string) []string {
+	result := []string{}
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,158 @@
+package datadog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testWebhookSecret = "secret-1"
+
+func monitorAlertWebhook(transition, monitorID, tags string) string {
+	return `{
+		"id": "7890123456789012345",
+		"event_type": "query_alert_monitor",
+		"alert_type": "error",
+		"alert_transition": "` + transition + `",
+		"monitor_id": "` + monitorID + `",
+		"monitor_name": "API p95 latency is high",
+		"priority": "P2",
+		"tags": "` + tags + `",
+		"title": "[Triggered] API p95 latency is high",
+		"date": "1768824000000",
+		"body": "API latency is above 500ms.",
+		"org": {"id": "123456", "name": "Acme"}
+	}`
+}
+
+func handleMonitorAlert(configuration map[string]any, body, secret string) (int, *contexts.EventContext, error) {
+	headers := http.Header{}
+	if secret != "" {
+		headers.Set(webhookSecretHeader, secret)
+	}
+
+	events := &contexts.EventContext{}
+	code, _, err := (&OnMonitorAlert{}).HandleWebhook(core.WebhookRequestContext{
+		Body:          []byte(body),
+		Headers:       headers,
+		Configuration: configuration,
+		Integration:   testIntegration(),
+		Webhook:       &contexts.NodeWebhookContext{Secret: testWebhookSecret},
+		Events:        events,
+	})
+
+	return code, events, err
+}
+
+func Test__OnMonitorAlert__Setup(t *testing.T) {
+	trigger := &OnMonitorAlert{}
+
+	t.Run("requests the webhook and stores the mention", func(t *testing.T) {
+		integration := testIntegration()
+		integration.IntegrationID = "0c3e2c57-1a7b-4a35-9f0a-4b1f2e4c9d11"
+		metadata := &contexts.MetadataContext{}
+
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"transitions": []string{"Triggered"}},
+			Integration:   integration,
+			Metadata:      metadata,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integration.WebhookRequests, 1)
+
+		name := webhookName(integration.ID())
+		assert.Equal(t, OnMonitorAlertMetadata{WebhookName: name, Mention: "@webhook-" + name}, metadata.Get())
+	})
+
+	t.Run("invalid transition -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"transitions": []string{"Exploded"}},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, `invalid transition "Exploded"`)
+	})
+
+	t.Run("no transitions -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"transitions": []string{}},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, "at least one transition is required")
+	})
+}
+
+func Test__OnMonitorAlert__HandleWebhook(t *testing.T) {
+	configuration := map[string]any{"transitions": []string{"Triggered", "Recovered"}}
+
+	t.Run("missing secret -> forbidden", func(t *testing.T) {
+		code, events, err := handleMonitorAlert(configuration, monitorAlertWebhook("Triggered", "12345678", ""), "")
+
+		require.ErrorContains(t, err, "missing X-SuperPlane-Webhook-Secret header")
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("wrong secret -> forbidden", func(t *testing.T) {
+		code, _, err := handleMonitorAlert(configuration, monitorAlertWebhook("Triggered", "12345678", ""), "wrong")
+
+		require.ErrorContains(t, err, "invalid webhook secret")
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+
+	t.Run("triggered monitor -> emits normalized event", func(t *testing.T) {
+		body := monitorAlertWebhook("Triggered", "12345678", "env:prod,service:api")
+		code, events, err := handleMonitorAlert(configuration, body, testWebhookSecret)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, OnMonitorAlertPayloadType, events.Payloads[0].Type)
+
+		data := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, int64(12345678), data["monitor_id"])
+		assert.Equal(t, int64(1768824000000), data["date"])
+		assert.Equal(t, []string{"env:prod", "service:api"}, data["tags"])
+		assert.Equal(t, int64(123456), data["org"].(map[string]any)["id"])
+	})
+
+	t.Run("other transition -> ignored", func(t *testing.T) {
+		code, events, err := handleMonitorAlert(configuration, monitorAlertWebhook("Warn", "12345678", ""), testWebhookSecret)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("monitor filter", func(t *testing.T) {
+		filtered := map[string]any{"transitions": []string{"Triggered"}, "monitors": []string{"12345678"}}
+
+		_, events, err := handleMonitorAlert(filtered, monitorAlertWebhook("Triggered", "999", ""), testWebhookSecret)
+		require.NoError(t, err)
+		assert.Zero(t, events.Count())
+
+		_, events, err = handleMonitorAlert(filtered, monitorAlertWebhook("Triggered", "12345678", ""), testWebhookSecret)
+		require.NoError(t, err)
+		assert.Equal(t, 1, events.Count())
+	})
+
+	t.Run("tag filter", func(t *testing.T) {
+		filtered := map[string]any{"transitions": []string{"Triggered"}, "tags": []string{"service:api"}}
+
+		_, events, err := handleMonitorAlert(filtered, monitorAlertWebhook("Triggered", "1", "service:web"), testWebhookSecret)
+		require.NoError(t, err)
+		assert.Zero(t, events.Count())
+
+		_, events, err = handleMonitorAlert(filtered, monitorAlertWebhook("Triggered", "1", "env:prod,service:api"), testWebhookSecret)
+		require.NoError(t, err)
+		assert.Equal(t, 1, events.Count())
+	})
+}
@@ -0,0 +1,182 @@
+package datadog
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type QueryMetrics struct{}
+
+type QueryMetricsSpec struct {
+	Query     string `json:"query"`
+	TimeRange string `json:"timeRange"`
+}
+
+func (c *QueryMetrics) Name() string {
+	return "datadog.queryMetrics"
+}
+
+func (c *QueryMetrics) Label() string {
+	return "Query Metrics"
+}
+
+func (c *QueryMetrics) Description() string {
+	return "Query metric timeseries in Datadog"
+}
+
+func (c *QueryMetrics) Icon() string {
+	return "chart-bar"
+}
+
+func (c *QueryMetrics) Color() string {
+	return "gray"
+}
+
+func (c *QueryMetrics) Documentation() string {
+	return `The Query Metrics component queries metric timeseries in Datadog, over a window that ends now.
+
+## Use Cases
+
+- **Deployment verification**: Check error rates or latency after a deployment, and roll back when they are too high
+- **Capacity checks**: Look at resource usage before scaling or running a migration
+
+## Configuration
+
+- **Query**: A Datadog metrics query, like ` + "`avg:trace.http.request.errors{service:api,env:prod}.as_count()`" + `
+- **Time Range**: How far back the window starts
+
+## Outputs
+
+The component emits the query, the window, and one entry per timeseries under ` + "`series`" + `, with:
+- ` + "`metric`" + `, ` + "`scope`" + ` and ` + "`tag_set`" + `: What the timeseries is about
+- ` + "`pointlist`" + `: The points, as ` + "`[timestamp in ms, value]`" + ` pairs
+- ` + "`min`" + `, ` + "`max`" + `, ` + "`avg`" + ` and ` + "`last`" + `: Summary of the values, for comparing them to thresholds
+`
+}
+
+func (c *QueryMetrics) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *QueryMetrics) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "query",
+			Label:       "Query",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Datadog metrics query",
+			Placeholder: "avg:system.cpu.user{env:prod}",
+		},
+		{
+			Name:     "timeRange",
+			Label:    "Time Range",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  "15m",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Last 5 minutes", Value: "5m"},
+						{Label: "Last 15 minutes", Value: "15m"},
+						{Label: "Last 30 minutes", Value: "30m"},
+						{Label: "Last hour", Value: "1h"},
+						{Label: "Last 4 hours", Value: "4h"},
+						{Label: "Last 24 hours", Value: "24h"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *QueryMetrics) Setup(ctx core.SetupContext) error {
+	_, _, err := decodeQueryMetricsSpec(ctx.Configuration)
+	return err
+}
+
+func (c *QueryMetrics) Execute(ctx core.ExecutionContext) error {
+	spec, window, err := decodeQueryMetricsSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	to := time.Now()
+	from := to.Add(-window)
+	response, err := client.QueryMetrics(spec.Query, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to query metrics: %v", err)
+	}
+
+	series := make([]map[string]any, 0, len(response.Series))
+	for _, s := range response.Series {
+		series = append(series, seriesToMap(s))
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"datadog.metrics",
+		[]any{map[string]any{
+			"query":  spec.Query,
+			"from":   from.UTC().Format(time.RFC3339),
+			"to":     to.UTC().Format(time.RFC3339),
+			"series": series,
+		}},
+	)
+}
+
+func decodeQueryMetricsSpec(value any) (QueryMetricsSpec, time.Duration, error) {
+	spec := QueryMetricsSpec{}
+	err := mapstructure.Decode(value, &spec)
+	if err != nil {
+		return spec, 0, fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	spec.Query = strings.TrimSpace(spec.Query)
+	if spec.Query == "" {
+		return spec, 0, errors.New("query is required")
+	}
+
+	if spec.TimeRange == "" {
+		spec.TimeRange = "15m"
+	}
+
+	window, err := time.ParseDuration(spec.TimeRange)
+	if err != nil || window <= 0 {
+		return spec, 0, fmt.Errorf("invalid time range %q", spec.TimeRange)
+	}
+
+	return spec, window, nil
+}
+
+func (c *QueryMetrics) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *QueryMetrics) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *QueryMetrics) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *QueryMetrics) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *QueryMetrics) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
@@ -0,0 +1,101 @@
+package datadog
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__QueryMetrics__Setup(t *testing.T) {
+	component := &QueryMetrics{}
+
+	t.Run("query is required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"query": " ", "timeRange": "15m"},
+		})
+
+		require.ErrorContains(t, err, "query is required")
+	})
+
+	t.Run("valid configuration", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"query": "avg:system.cpu.user{*}", "timeRange": "1h"},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__QueryMetrics__Execute(t *testing.T) {
+	component := &QueryMetrics{}
+
+	t.Run("series with summary values", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{
+					"status": "ok",
+					"query": "avg:trace.http.request.errors{service:api}",
+					"series": [{
+						"metric": "trace.http.request.errors",
+						"scope": "service:api",
+						"pointlist": [[1768823100000, 0], [1768823400000, null], [1768823700000, 4], [1768824000000, 2]],
+						"interval": 300
+					}]
+				}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"query": "avg:trace.http.request.errors{service:api}", "timeRange": "15m"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "datadog.metrics", executionState.Type)
+
+		require.Len(t, httpContext.Requests, 1)
+		request := httpContext.Requests[0]
+		assert.Equal(t, "/api/v1/query", request.URL.Path)
+		assert.Equal(t, "avg:trace.http.request.errors{service:api}", request.URL.Query().Get("query"))
+
+		from, err := strconv.ParseInt(request.URL.Query().Get("from"), 10, 64)
+		require.NoError(t, err)
+		to, err := strconv.ParseInt(request.URL.Query().Get("to"), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, int64((15 * time.Minute).Seconds()), to-from)
+
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		series := data["series"].([]map[string]any)
+		require.Len(t, series, 1)
+		assert.Equal(t, 0.0, series[0]["min"])
+		assert.Equal(t, 4.0, series[0]["max"])
+		assert.Equal(t, 2.0, series[0]["avg"])
+		assert.Equal(t, 2.0, series[0]["last"])
+	})
+
+	t.Run("query error -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"status": "error", "error": "Error parsing query"}`),
+			},
+		}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"query": "avg:", "timeRange": "15m"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.ErrorContains(t, err, "Error parsing query")
+	})
+}
@@ -0,0 +1,225 @@
+package datadog
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type SubmitDeployment struct{}
+
+type SubmitDeploymentSpec struct {
+	Service       string `json:"service"`
+	Env           string `json:"env"`
+	Version       string `json:"version"`
+	RepositoryURL string `json:"repositoryUrl" mapstructure:"repositoryUrl"`
+	CommitSHA     string `json:"commitSha" mapstructure:"commitSha"`
+	StartedAt     string `json:"startedAt" mapstructure:"startedAt"`
+}
+
+func (c *SubmitDeployment) Name() string {
+	return "datadog.submitDeployment"
+}
+
+func (c *SubmitDeployment) Label() string {
+	return "Submit Deployment"
+}
+
+func (c *SubmitDeployment) Description() string {
+	return "Submit a deployment marker to Datadog"
+}
+
+func (c *SubmitDeployment) Icon() string {
+	return "rocket"
+}
+
+func (c *SubmitDeployment) Color() string {
+	return "gray"
+}
+
+func (c *SubmitDeployment) Documentation() string {
+	return `The Submit Deployment component records a deployment of a service in Datadog DORA Metrics, which marks it on the service pages and uses it for deployment frequency, lead time and change failure rate.
+
+## Use Cases
+
+- **Deployment tracking**: Record every deployment a workflow runs, with its version and commit
+- **Deployment verification**: Correlate a deployment with the metrics and monitors of the service
+
+## Configuration
+
+- **Service**: The service that was deployed
+- **Environment** and **Version**: Where and what was deployed
+- **Repository URL** and **Commit SHA**: The commit that was deployed. Set both or neither.
+- **Started At**: When the deployment started. Defaults to now. The deployment finishes when the component runs.
+
+## Outputs
+
+The component emits the ID of the deployment in Datadog, and what was submitted.
+`
+}
+
+func (c *SubmitDeployment) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *SubmitDeployment) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "service",
+			Label:       "Service",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "The service that was deployed",
+			Placeholder: "api",
+		},
+		{
+			Name:        "env",
+			Label:       "Environment",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "prod",
+		},
+		{
+			Name:        "version",
+			Label:       "Version",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "1.8.2",
+		},
+		{
+			Name:        "repositoryUrl",
+			Label:       "Repository URL",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Git repository of the service. Required with a commit SHA.",
+			Placeholder: "https://github.com/acme/api",
+		},
+		{
+			Name:        "commitSha",
+			Label:       "Commit SHA",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Commit that was deployed. Required with a repository URL.",
+		},
+		{
+			Name:        "startedAt",
+			Label:       "Started At",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "When the deployment started, in RFC 3339 format. Defaults to now.",
+			Placeholder: "2026-01-15T09:00:00Z",
+		},
+	}
+}
+
+func (c *SubmitDeployment) Setup(ctx core.SetupContext) error {
+	spec := SubmitDeploymentSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	return spec.validate()
+}
+
+func (c *SubmitDeployment) Execute(ctx core.ExecutionContext) error {
+	spec := SubmitDeploymentSpec{}
+	err := mapstructure.Decode(ctx.Configuration, &spec)
+	if err != nil {
+		return fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	if err := spec.validate(); err != nil {
+		return err
+	}
+
+	finishedAt := time.Now()
+	startedAt := finishedAt
+	if spec.StartedAt != "" {
+		startedAt, err = time.Parse(time.RFC3339, spec.StartedAt)
+		if err != nil {
+			return fmt.Errorf("invalid startedAt %q: expected RFC 3339 format", spec.StartedAt)
+		}
+	}
+
+	deployment := DeploymentRequest{
+		Service:    spec.Service,
+		Env:        spec.Env,
+		Version:    spec.Version,
+		StartedAt:  startedAt.UnixNano(),
+		FinishedAt: finishedAt.UnixNano(),
+	}
+
+	if spec.RepositoryURL != "" {
+		deployment.Git = &DeploymentGit{RepositoryURL: spec.RepositoryURL, CommitSHA: spec.CommitSHA}
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	id, err := client.SubmitDeployment(deployment)
+	if err != nil {
+		return fmt.Errorf("failed to submit deployment: %v", err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"datadog.deployment",
+		[]any{map[string]any{
+			"id":          id,
+			"service":     spec.Service,
+			"env":         spec.Env,
+			"version":     spec.Version,
+			"commit_sha":  spec.CommitSHA,
+			"started_at":  startedAt.UTC().Format(time.RFC3339),
+			"finished_at": finishedAt.UTC().Format(time.RFC3339),
+		}},
+	)
+}
+
+func (s *SubmitDeploymentSpec) validate() error {
+	s.Service = strings.TrimSpace(s.Service)
+	s.Env = strings.TrimSpace(s.Env)
+	s.Version = strings.TrimSpace(s.Version)
+	s.RepositoryURL = strings.TrimSpace(s.RepositoryURL)
+	s.CommitSHA = strings.TrimSpace(s.CommitSHA)
+	s.StartedAt = strings.TrimSpace(s.StartedAt)
+
+	if s.Service == "" {
+		return errors.New("service is required")
+	}
+
+	if (s.RepositoryURL == "") != (s.CommitSHA == "") {
+		return errors.New("repositoryUrl and commitSha must be set together")
+	}
+
+	return nil
+}
+
+func (c *SubmitDeployment) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *SubmitDeployment) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *SubmitDeployment) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *SubmitDeployment) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *SubmitDeployment) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
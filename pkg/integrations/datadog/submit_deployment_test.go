@@ -0,0 +1,93 @@
+package datadog
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__SubmitDeployment__Setup(t *testing.T) {
+	component := &SubmitDeployment{}
+
+	t.Run("service is required", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"service": ""}})
+		require.ErrorContains(t, err, "service is required")
+	})
+
+	t.Run("commit SHA without repository -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"service": "api", "commitSha": "66adc93"}})
+		require.ErrorContains(t, err, "repositoryUrl and commitSha must be set together")
+	})
+}
+
+func Test__SubmitDeployment__Execute(t *testing.T) {
+	component := &SubmitDeployment{}
+
+	t.Run("deployment is submitted", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusAccepted, `{"data": {"id": "4242fcdd-4ec5-4a4f-9f4c-a3f1a9e2c517", "type": "dora_deployment"}}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"service":       "api",
+				"env":           "prod",
+				"version":       "1.8.2",
+				"repositoryUrl": "https://github.com/acme/api",
+				"commitSha":     "66adc9350f2cc9b250b69abddab733dd55e1a588",
+				"startedAt":     "2026-01-19T11:52:00Z",
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "datadog.deployment", executionState.Type)
+		assert.Equal(t, "/api/v2/dora/deployment", httpContext.Requests[0].URL.Path)
+
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+
+		payload := struct {
+			Data struct {
+				Attributes DeploymentRequest `json:"attributes"`
+			} `json:"data"`
+		}{}
+
+		require.NoError(t, json.Unmarshal(body, &payload))
+		deployment := payload.Data.Attributes
+		assert.Equal(t, "api", deployment.Service)
+		assert.Equal(t, "prod", deployment.Env)
+		assert.Equal(t, time.Date(2026, 1, 19, 11, 52, 0, 0, time.UTC).UnixNano(), deployment.StartedAt)
+		assert.Greater(t, deployment.FinishedAt, deployment.StartedAt)
+		require.NotNil(t, deployment.Git)
+		assert.Equal(t, "https://github.com/acme/api", deployment.Git.RepositoryURL)
+
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "4242fcdd-4ec5-4a4f-9f4c-a3f1a9e2c517", data["id"])
+	})
+
+	t.Run("invalid startedAt -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"service": "api", "startedAt": "yesterday"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.ErrorContains(t, err, "invalid startedAt")
+		assert.Empty(t, httpContext.Requests)
+	})
+}
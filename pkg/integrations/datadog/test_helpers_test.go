@@ -0,0 +1,26 @@
+package datadog
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func testIntegration() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"site":   "datadoghq.com",
+			"apiKey": "test-api-key",
+			"appKey": "test-app-key",
+		},
+	}
+}
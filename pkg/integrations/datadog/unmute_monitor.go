@@ -0,0 +1,130 @@
+package datadog
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type UnmuteMonitor struct{}
+
+func (c *UnmuteMonitor) Name() string {
+	return "datadog.unmuteMonitor"
+}
+
+func (c *UnmuteMonitor) Label() string {
+	return "Unmute Monitor"
+}
+
+func (c *UnmuteMonitor) Description() string {
+	return "Unmute a monitor in Datadog"
+}
+
+func (c *UnmuteMonitor) Icon() string {
+	return "bell"
+}
+
+func (c *UnmuteMonitor) Color() string {
+	return "gray"
+}
+
+func (c *UnmuteMonitor) Documentation() string {
+	return `The Unmute Monitor component unmutes a Datadog monitor, by canceling the active and scheduled downtimes of that monitor.
+
+## Use Cases
+
+- **Deployments**: Unmute monitors once a deployment finished
+- **Maintenance**: Turn alerting back on when a migration is done, before its downtime ends
+
+## Notes
+
+Only downtimes for this specific monitor are canceled. Downtimes that match the monitor by its tags are left alone.
+
+## Outputs
+
+The component emits the monitor, and the IDs of the downtimes that were canceled under ` + "`canceled_downtimes`" + `.
+`
+}
+
+func (c *UnmuteMonitor) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *UnmuteMonitor) Configuration() []configuration.Field {
+	return []configuration.Field{
+		monitorField("The monitor to unmute", true),
+	}
+}
+
+func (c *UnmuteMonitor) Setup(ctx core.SetupContext) error {
+	_, err := decodeMonitorSpec(ctx.Configuration)
+	return err
+}
+
+func (c *UnmuteMonitor) Execute(ctx core.ExecutionContext) error {
+	monitorID, err := decodeMonitorSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	monitor, err := client.GetMonitor(monitorID)
+	if err != nil {
+		return fmt.Errorf("failed to get monitor %d: %v", monitorID, err)
+	}
+
+	downtimes, err := client.ListCurrentDowntimes()
+	if err != nil {
+		return fmt.Errorf("failed to list downtimes: %v", err)
+	}
+
+	canceled := []string{}
+	for _, downtime := range downtimes {
+		id := downtime.Attributes.MonitorIdentifier.MonitorID
+		if id == nil || *id != monitorID {
+			continue
+		}
+
+		err := client.CancelDowntime(downtime.ID)
+		if err != nil && !IsNotFound(err) {
+			return fmt.Errorf("failed to cancel downtime %s: %v", downtime.ID, err)
+		}
+
+		canceled = append(canceled, downtime.ID)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"datadog.monitor.unmuted",
+		[]any{map[string]any{
+			"monitor":            monitorToMap(client, monitor),
+			"canceled_downtimes": canceled,
+		}},
+	)
+}
+
+func (c *UnmuteMonitor) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *UnmuteMonitor) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *UnmuteMonitor) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *UnmuteMonitor) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *UnmuteMonitor) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
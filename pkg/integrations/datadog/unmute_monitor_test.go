@@ -0,0 +1,53 @@
+package datadog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__UnmuteMonitor__Execute(t *testing.T) {
+	component := &UnmuteMonitor{}
+
+	t.Run("cancels the downtimes of the monitor", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"id":12345678,"name":"API p95 latency is high","type":"query alert","query":"avg(last_5m):p95:trace.http.request.duration{service:api} > 0.5","message":"API latency is above 500ms.","tags":["service:api","env:prod"],"overall_state":"Alert","priority":2}`),
+				jsonResponse(http.StatusOK, `{
+					"data": [
+						{"id": "dt-1", "attributes": {"scope": "*", "monitor_identifier": {"monitor_id": 12345678}}},
+						{"id": "dt-2", "attributes": {"scope": "*", "monitor_identifier": {"monitor_id": 999}}},
+						{"id": "dt-3", "attributes": {"scope": "*", "monitor_identifier": {"monitor_tags": ["service:api"]}}},
+						{"id": "dt-4", "attributes": {"scope": "env:prod", "monitor_identifier": {"monitor_id": 12345678}}}
+					]
+				}`),
+				{StatusCode: http.StatusNoContent, Body: http.NoBody},
+				jsonResponse(http.StatusNotFound, `{"errors": ["Downtime not found"]}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"monitor": "12345678"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "datadog.monitor.unmuted", executionState.Type)
+
+		require.Len(t, httpContext.Requests, 4)
+		assert.Equal(t, "true", httpContext.Requests[1].URL.Query().Get("current_only"))
+		assert.Equal(t, http.MethodDelete, httpContext.Requests[2].Method)
+		assert.Equal(t, "/api/v2/downtime/dt-1", httpContext.Requests[2].URL.Path)
+		assert.Equal(t, "/api/v2/downtime/dt-4", httpContext.Requests[3].URL.Path)
+
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, []string{"dt-1", "dt-4"}, data["canceled_downtimes"])
+	})
+}
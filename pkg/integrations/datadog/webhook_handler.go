@@ -0,0 +1,118 @@
+package datadog
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const webhookSecretHeader = "X-SuperPlane-Webhook-Secret"
+
+// webhookPayload is the body Datadog sends for a monitor notification.
+// Every value is a template variable in a JSON string, and
+// normalizeMonitorAlert converts the numeric and list values back.
+const webhookPayload = `{
+	"id": "$ID",
+	"event_type": "$EVENT_TYPE",
+	"alert_type": "$ALERT_TYPE",
+	"alert_transition": "$ALERT_TRANSITION",
+	"alert_status": "$ALERT_STATUS",
+	"alert_scope": "$ALERT_SCOPE",
+	"alert_query": "$ALERT_QUERY",
+	"hostname": "$HOSTNAME",
+	"monitor_id": "$ALERT_ID",
+	"monitor_name": "$ALERT_TITLE",
+	"priority": "$ALERT_PRIORITY",
+	"tags": "$TAGS",
+	"title": "$EVENT_TITLE",
+	"date": "$DATE",
+	"body": "$EVENT_MSG",
+	"link": "$LINK",
+	"org": {"id": "$ORG_ID", "name": "$ORG_NAME"}
+}`
+
+// WebhookConfiguration is empty: every trigger of the integration
+// shares one webhook, and filters the notifications it receives.
+type WebhookConfiguration struct{}
+
+type WebhookMetadata struct {
+	Name string `json:"name" mapstructure:"name"`
+}
+
+// DatadogWebhookHandler manages a webhook of the Datadog webhooks
+// integration that sends monitor notifications to SuperPlane.
+type DatadogWebhookHandler struct{}
+
+func (h *DatadogWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhook secret: %v", err)
+	}
+
+	headers, err := json.Marshal(map[string]string{webhookSecretHeader: string(secret)})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding webhook headers: %v", err)
+	}
+
+	name := webhookName(ctx.Integration.ID())
+	err = client.UpsertWebhook(WebhookRequest{
+		Name:          name,
+		URL:           ctx.Webhook.GetURL(),
+		Payload:       webhookPayload,
+		CustomHeaders: string(headers),
+		EncodeAs:      "json",
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating webhook: %v", err)
+	}
+
+	return WebhookMetadata{Name: name}, nil
+}
+
+// webhookName is derived from the integration ID, so the triggers
+// know which @webhook mention to add to monitors before it exists.
+func webhookName(integrationID uuid.UUID) string {
+	hash := sha256.Sum256([]byte(integrationID.String()))
+	return fmt.Sprintf("superplane-%x", hash[:8])
+}
+
+func (h *DatadogWebhookHandler) CompareConfig(a, b any) (bool, error) {
+	return true, nil
+}
+
+func (h *DatadogWebhookHandler) Merge(current, requested any) (any, bool, error) {
+	return current, false, nil
+}
+
+func (h *DatadogWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
+	metadata := WebhookMetadata{}
+	if err := mapstructure.Decode(ctx.Webhook.GetMetadata(), &metadata); err != nil {
+		return fmt.Errorf("error decoding webhook metadata: %v", err)
+	}
+
+	if metadata.Name == "" {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteWebhook(metadata.Name)
+	if err != nil && !IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package datadog
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__DatadogWebhookHandler__Setup(t *testing.T) {
+	handler := &DatadogWebhookHandler{}
+	webhook := &contexts.WebhookContext{
+		ID:     "webhook-1",
+		URL:    "https://superplane.example.com/api/v1/webhooks/webhook-1",
+		Secret: []byte("secret-1"),
+	}
+
+	t.Run("no existing webhook -> creates one", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusNotFound, `{"errors": ["Webhook not found"]}`),
+				jsonResponse(http.StatusOK, `{}`),
+			},
+		}
+
+		integration := testIntegration()
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Integration: integration,
+			Webhook:     webhook,
+		})
+
+		require.NoError(t, err)
+		name := webhookName(integration.ID())
+		assert.Equal(t, WebhookMetadata{Name: name}, metadata)
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/api/v1/integration/webhooks/configuration/webhooks/"+name, httpContext.Requests[0].URL.Path)
+		assert.Equal(t, http.MethodPost, httpContext.Requests[1].Method)
+		assert.Equal(t, "/api/v1/integration/webhooks/configuration/webhooks", httpContext.Requests[1].URL.Path)
+
+		data, err := io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+
+		body := WebhookRequest{}
+		require.NoError(t, json.Unmarshal(data, &body))
+		assert.Equal(t, name, body.Name)
+		assert.Equal(t, webhook.URL, body.URL)
+		assert.Equal(t, "json", body.EncodeAs)
+		assert.JSONEq(t, `{"X-SuperPlane-Webhook-Secret": "secret-1"}`, body.CustomHeaders)
+		assert.True(t, json.Valid([]byte(body.Payload)))
+	})
+
+	t.Run("existing webhook -> updated", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"name": "superplane"}`),
+				jsonResponse(http.StatusOK, `{}`),
+			},
+		}
+
+		_, err := handler.Setup(core.WebhookHandlerContext{
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Webhook:     webhook,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodPut, httpContext.Requests[1].Method)
+	})
+}
+
+func Test__DatadogWebhookHandler__Cleanup(t *testing.T) {
+	handler := &DatadogWebhookHandler{}
+
+	t.Run("deletes the webhook", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{{StatusCode: http.StatusOK, Body: http.NoBody}},
+		}
+
+		err := handler.Cleanup(core.WebhookHandlerContext{
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Webhook:     &contexts.WebhookContext{Metadata: map[string]any{"name": "superplane-1"}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodDelete, httpContext.Requests[0].Method)
+		assert.Equal(t, "/api/v1/integration/webhooks/configuration/webhooks/superplane-1", httpContext.Requests[0].URL.Path)
+	})
+
+	t.Run("webhook already deleted -> no error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusNotFound, `{"errors": ["Webhook not found"]}`)},
+		}
+
+		err := handler.Cleanup(core.WebhookHandlerContext{
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Webhook:     &contexts.WebhookContext{Metadata: map[string]any{"name": "superplane-1"}},
+		})
+
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,388 @@
+package gitea
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const pageSize = 50
+
+type Client struct {
+	BaseURL string
+	Token   string
+	http    core.HTTPContext
+}
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with %d: %s", e.StatusCode, e.Body)
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func NewClient(httpClient core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("no integration context")
+	}
+
+	baseURL, err := ctx.GetConfig("baseUrl")
+	if err != nil {
+		return nil, fmt.Errorf("error getting baseUrl config: %w", err)
+	}
+
+	token, err := ctx.GetConfig("accessToken")
+	if err != nil {
+		return nil, fmt.Errorf("error getting accessToken config: %w", err)
+	}
+
+	normalized, err := normalizeBaseURL(string(baseURL))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		BaseURL: normalized,
+		Token:   strings.TrimSpace(string(token)),
+		http:    httpClient,
+	}, nil
+}
+
+// normalizeBaseURL accepts the URL of a Gitea or Forgejo instance,
+// with or without a trailing slash or the /api/v1 suffix.
+func normalizeBaseURL(value string) (string, error) {
+	trimmed := strings.TrimSuffix(strings.TrimRight(strings.TrimSpace(value), "/"), "/api/v1")
+	if trimmed == "" {
+		return "", fmt.Errorf("baseUrl is required")
+	}
+
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", fmt.Errorf("invalid baseUrl %q", value)
+	}
+
+	return trimmed, nil
+}
+
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+}
+
+func (c *Client) GetCurrentUser() (*User, error) {
+	user := User{}
+	if err := c.doJSON(http.MethodGet, "/user", nil, nil, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+type Version struct {
+	Version string `json:"version"`
+}
+
+func (c *Client) GetVersion() (*Version, error) {
+	version := Version{}
+	if err := c.doJSON(http.MethodGet, "/version", nil, nil, &version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+type Repository struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+	HTMLURL       string `json:"html_url"`
+	CloneURL      string `json:"clone_url"`
+	Owner         User   `json:"owner"`
+}
+
+func (c *Client) ListRepositories() ([]Repository, error) {
+	return listAll[Repository](c, "/user/repos", nil)
+}
+
+func (c *Client) GetRepository(owner, repo string) (*Repository, error) {
+	repository := Repository{}
+	if err := c.doJSON(http.MethodGet, repoPath(owner, repo, ""), nil, nil, &repository); err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
+
+type Branch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+func (c *Client) ListBranches(owner, repo string) ([]Branch, error) {
+	return listAll[Branch](c, repoPath(owner, repo, "/branches"), nil)
+}
+
+type Hook struct {
+	ID     int64    `json:"id"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+}
+
+func (c *Client) CreateHook(owner, repo, webhookURL, secret string, events []string) (*Hook, error) {
+	request := map[string]any{
+		"type": "gitea",
+		"config": map[string]string{
+			"url":          webhookURL,
+			"content_type": "json",
+			"secret":       secret,
+		},
+		"events":        events,
+		"active":        true,
+		"branch_filter": "*",
+	}
+
+	hook := Hook{}
+	if err := c.doJSON(http.MethodPost, repoPath(owner, repo, "/hooks"), nil, request, &hook); err != nil {
+		return nil, err
+	}
+
+	return &hook, nil
+}
+
+func (c *Client) DeleteHook(owner, repo string, id int64) error {
+	return c.doJSON(http.MethodDelete, repoPath(owner, repo, fmt.Sprintf("/hooks/%d", id)), nil, nil, nil)
+}
+
+type Comment struct {
+	ID             int64  `json:"id"`
+	Body           string `json:"body"`
+	HTMLURL        string `json:"html_url"`
+	IssueURL       string `json:"issue_url"`
+	PullRequestURL string `json:"pull_request_url"`
+	User           User   `json:"user"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// CreateIssueComment comments on an issue. Pull requests are issues
+// too, and share their numbers, so this comments on pull requests as well.
+func (c *Client) CreateIssueComment(owner, repo string, index int64, body string) (*Comment, error) {
+	comment := Comment{}
+	path := repoPath(owner, repo, fmt.Sprintf("/issues/%d/comments", index))
+	if err := c.doJSON(http.MethodPost, path, nil, map[string]string{"body": body}, &comment); err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+type CommitStatusRequest struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context,omitempty"`
+}
+
+type CommitStatus struct {
+	ID          int64  `json:"id"`
+	State       string `json:"status"`
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+	CreatedAt   string `json:"created_at"`
+	Creator     User   `json:"creator"`
+}
+
+func (c *Client) CreateCommitStatus(owner, repo, sha string, request CommitStatusRequest) (*CommitStatus, error) {
+	status := CommitStatus{}
+	path := repoPath(owner, repo, "/statuses/"+url.PathEscape(sha))
+	if err := c.doJSON(http.MethodPost, path, nil, request, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+type ReleaseRequest struct {
+	TagName         string `json:"tag_name"`
+	TargetCommitish string `json:"target_commitish,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Body            string `json:"body,omitempty"`
+	Draft           bool   `json:"draft"`
+	Prerelease      bool   `json:"prerelease"`
+}
+
+type Release struct {
+	ID              int64  `json:"id"`
+	TagName         string `json:"tag_name"`
+	TargetCommitish string `json:"target_commitish"`
+	Name            string `json:"name"`
+	Body            string `json:"body"`
+	Draft           bool   `json:"draft"`
+	Prerelease      bool   `json:"prerelease"`
+	HTMLURL         string `json:"html_url"`
+	CreatedAt       string `json:"created_at"`
+	PublishedAt     string `json:"published_at"`
+	Author          User   `json:"author"`
+}
+
+func (c *Client) CreateRelease(owner, repo string, request ReleaseRequest) (*Release, error) {
+	release := Release{}
+	if err := c.doJSON(http.MethodPost, repoPath(owner, repo, "/releases"), nil, request, &release); err != nil {
+		return nil, err
+	}
+
+	return &release, nil
+}
+
+type PullRequestBranch struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+type PullRequest struct {
+	ID             int64             `json:"id"`
+	Number         int64             `json:"number"`
+	Title          string            `json:"title"`
+	State          string            `json:"state"`
+	Merged         bool              `json:"merged"`
+	MergedAt       string            `json:"merged_at"`
+	MergeCommitSHA string            `json:"merge_commit_sha"`
+	HTMLURL        string            `json:"html_url"`
+	User           User              `json:"user"`
+	Head           PullRequestBranch `json:"head"`
+	Base           PullRequestBranch `json:"base"`
+}
+
+func (c *Client) GetPullRequest(owner, repo string, index int64) (*PullRequest, error) {
+	pullRequest := PullRequest{}
+	if err := c.doJSON(http.MethodGet, repoPath(owner, repo, fmt.Sprintf("/pulls/%d", index)), nil, nil, &pullRequest); err != nil {
+		return nil, err
+	}
+
+	return &pullRequest, nil
+}
+
+type MergePullRequestRequest struct {
+	Do                     string `json:"Do"`
+	MergeTitleField        string `json:"MergeTitleField,omitempty"`
+	MergeMessageField      string `json:"MergeMessageField,omitempty"`
+	DeleteBranchAfterMerge bool   `json:"delete_branch_after_merge"`
+}
+
+func (c *Client) MergePullRequest(owner, repo string, index int64, request MergePullRequestRequest) error {
+	return c.doJSON(http.MethodPost, repoPath(owner, repo, fmt.Sprintf("/pulls/%d/merge", index)), nil, request, nil)
+}
+
+func repoPath(owner, repo, path string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + path
+}
+
+// listAll follows the page based pagination of the API,
+// until a page with less items than requested is returned.
+func listAll[T any](c *Client, path string, query url.Values) ([]T, error) {
+	items := []T{}
+	for page := 1; ; page++ {
+		q := url.Values{}
+		for key, values := range query {
+			q[key] = values
+		}
+
+		q.Set("page", fmt.Sprintf("%d", page))
+		q.Set("limit", fmt.Sprintf("%d", pageSize))
+
+		pageItems := []T{}
+		if err := c.doJSON(http.MethodGet, path, q, nil, &pageItems); err != nil {
+			return nil, err
+		}
+
+		items = append(items, pageItems...)
+		if len(pageItems) < pageSize {
+			return items, nil
+		}
+	}
+}
+
+func (c *Client) doJSON(method, path string, query url.Values, payload any, out any) error {
+	requestURL := c.BaseURL + "/api/v1" + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("error marshaling request: %w", err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "token "+c.Token)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &APIError{StatusCode: resp.StatusCode, Body: apiErrorMessage(responseBody)}
+	}
+
+	if out == nil || len(bytes.TrimSpace(responseBody)) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(responseBody, out); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return nil
+}
+
+func apiErrorMessage(body []byte) string {
+	response := struct {
+		Message string `json:"message"`
+	}{}
+
+	if err := json.Unmarshal(body, &response); err == nil && response.Message != "" {
+		return response.Message
+	}
+
+	return strings.TrimSpace(string(body))
+}
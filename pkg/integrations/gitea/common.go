@@ -0,0 +1,232 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
+)
+
+const (
+	ResourceTypeRepository = "repository"
+	ResourceTypeBranch     = "branch"
+)
+
+type NodeMetadata struct {
+	Repository *RepositoryMetadata `json:"repository" mapstructure:"repository"`
+}
+
+type RepositoryMetadata struct {
+	ID       int64  `json:"id" mapstructure:"id"`
+	Name     string `json:"name" mapstructure:"name"`
+	FullName string `json:"full_name" mapstructure:"full_name"`
+	URL      string `json:"url" mapstructure:"url"`
+}
+
+func ensureRepoInMetadata(http core.HTTPContext, ctx core.MetadataWriter, integration core.IntegrationContext, repository string) (*RepositoryMetadata, error) {
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodeMetadata NodeMetadata
+	if err := mapstructure.Decode(ctx.Get(), &nodeMetadata); err != nil {
+		return nil, fmt.Errorf("failed to decode node metadata: %w", err)
+	}
+
+	if nodeMetadata.Repository != nil && strings.EqualFold(nodeMetadata.Repository.FullName, owner+"/"+repo) {
+		return nodeMetadata.Repository, nil
+	}
+
+	client, err := NewClient(http, integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	repository = owner + "/" + repo
+	r, err := client.GetRepository(owner, repo)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, fmt.Errorf("repository %s is not accessible to the access token", repository)
+		}
+
+		return nil, fmt.Errorf("failed to get repository %s: %w", repository, err)
+	}
+
+	repoMetadata := &RepositoryMetadata{
+		ID:       r.ID,
+		Name:     r.Name,
+		FullName: r.FullName,
+		URL:      r.HTMLURL,
+	}
+
+	return repoMetadata, ctx.Set(NodeMetadata{Repository: repoMetadata})
+}
+
+// splitRepository returns the owner and name of a repository given by its full name.
+// Gitea has no default owner, so the owner is always required.
+func splitRepository(repository string) (string, string, error) {
+	repository = strings.TrimSpace(repository)
+	if repository == "" {
+		return "", "", fmt.Errorf("repository is required")
+	}
+
+	owner, repo, found := strings.Cut(repository, "/")
+	owner = strings.TrimSpace(owner)
+	repo = strings.TrimSpace(repo)
+	if !found || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", fmt.Errorf("invalid repository %q, expected owner/name", repository)
+	}
+
+	return owner, repo, nil
+}
+
+func repositoryField() configuration.Field {
+	return configuration.Field{
+		Name:     "repository",
+		Label:    "Repository",
+		Type:     configuration.FieldTypeIntegrationResource,
+		Required: true,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type:           ResourceTypeRepository,
+				UseNameAsValue: true,
+			},
+		},
+	}
+}
+
+func branchField(name, label, description string, required bool) configuration.Field {
+	return configuration.Field{
+		Name:        name,
+		Label:       label,
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    required,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type:           ResourceTypeBranch,
+				UseNameAsValue: true,
+				Parameters: []configuration.ParameterRef{
+					{
+						Name:      "repository",
+						ValueFrom: &configuration.ParameterValueFrom{Field: "repository"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func refsField(label, defaultValue string) configuration.Field {
+	return configuration.Field{
+		Name:     "refs",
+		Label:    label,
+		Type:     configuration.FieldTypeAnyPredicateList,
+		Required: true,
+		Default: []map[string]any{
+			{
+				"type":  configuration.PredicateTypeEquals,
+				"value": defaultValue,
+			},
+		},
+		TypeOptions: &configuration.TypeOptions{
+			AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+				Operators: configuration.AllPredicateOperators,
+			},
+		},
+	}
+}
+
+// parseIndex validates the number of an issue or pull request,
+// unless it is an expression that is only resolved when the component runs.
+func parseIndex(value, name string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("%s is required", name)
+	}
+
+	if strings.Contains(value, "{{") {
+		return value, nil
+	}
+
+	if index, err := strconv.ParseInt(value, 10, 64); err != nil || index <= 0 {
+		return "", fmt.Errorf("invalid %s number %q", name, value)
+	}
+
+	return value, nil
+}
+
+func toIndex(value, name string) (int64, error) {
+	index, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || index <= 0 {
+		return 0, fmt.Errorf("invalid %s number %q", name, value)
+	}
+
+	return index, nil
+}
+
+// headerValue returns the first non-empty header of the ones given.
+// Forgejo sends its own headers, and the Gitea ones for compatibility,
+// while Gitea only sends the Gitea ones.
+func headerValue(headers http.Header, names ...string) string {
+	for _, name := range names {
+		if value := headers.Get(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// verifyWebhook returns the event of a webhook request, after verifying
+// the signature sent with every event. Other events than the ones given
+// are not verified, and an empty event is returned for them.
+func verifyWebhook(ctx core.WebhookRequestContext, events ...string) (string, int, error) {
+	event := headerValue(ctx.Headers, "X-Forgejo-Event", "X-Gitea-Event")
+	if event == "" {
+		return "", http.StatusBadRequest, fmt.Errorf("missing X-Gitea-Event header")
+	}
+
+	if !slices.Contains(events, event) {
+		return "", http.StatusOK, nil
+	}
+
+	signature := headerValue(ctx.Headers, "X-Forgejo-Signature", "X-Gitea-Signature")
+	if signature == "" {
+		return "", http.StatusForbidden, fmt.Errorf("missing X-Gitea-Signature header")
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return "", http.StatusInternalServerError, fmt.Errorf("error getting webhook secret")
+	}
+
+	if err := crypto.VerifySignature(secret, ctx.Body, signature); err != nil {
+		return "", http.StatusForbidden, fmt.Errorf("invalid signature")
+	}
+
+	return event, http.StatusOK, nil
+}
+
+func pullRequestToMap(pullRequest *PullRequest) map[string]any {
+	return map[string]any{
+		"number":           pullRequest.Number,
+		"title":            pullRequest.Title,
+		"state":            pullRequest.State,
+		"merged":           pullRequest.Merged,
+		"merged_at":        pullRequest.MergedAt,
+		"merge_commit_sha": pullRequest.MergeCommitSHA,
+		"user":             pullRequest.User.Login,
+		"head_branch":      pullRequest.Head.Ref,
+		"head_sha":         pullRequest.Head.SHA,
+		"base_branch":      pullRequest.Base.Ref,
+		"url":              pullRequest.HTMLURL,
+	}
+}
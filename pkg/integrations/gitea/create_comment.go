@@ -0,0 +1,177 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CreateComment struct{}
+
+type CreateCommentSpec struct {
+	Repository string `json:"repository" mapstructure:"repository"`
+	Number     string `json:"number" mapstructure:"number"`
+	Body       string `json:"body" mapstructure:"body"`
+}
+
+func (c *CreateComment) Name() string {
+	return "gitea.createComment"
+}
+
+func (c *CreateComment) Label() string {
+	return "Create Comment"
+}
+
+func (c *CreateComment) Description() string {
+	return "Comment on a Gitea issue or pull request"
+}
+
+func (c *CreateComment) Documentation() string {
+	return `The Create Comment component adds a comment to an issue or a pull request in a Gitea or Forgejo repository.
+
+## Use Cases
+
+- **Build feedback**: Post test results or preview environment links on the pull request
+- **ChatOps replies**: Answer commands sent in comments
+- **Deployment notes**: Comment on an issue once its fix is deployed
+
+## Configuration
+
+- **Repository**: The repository of the issue or pull request
+- **Number**: The number of the issue or pull request, for example ` + "`{{ root().data.issue.number }}`" + ` for comment events, or ` + "`{{ root().data.number }}`" + ` for pull request events. Issues and pull requests share their numbers.
+- **Comment**: The comment, in Markdown
+
+## Output
+
+The comment, with its ID, body, author and a link to it.`
+}
+
+func (c *CreateComment) Icon() string {
+	return "message-square"
+}
+
+func (c *CreateComment) Color() string {
+	return "green"
+}
+
+func (c *CreateComment) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateComment) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:        "number",
+			Label:       "Number",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Number of the issue or pull request",
+			Placeholder: "{{ root().data.issue.number }}",
+		},
+		{
+			Name:        "body",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeText,
+			Required:    true,
+			Description: "The comment, in Markdown",
+		},
+	}
+}
+
+func (c *CreateComment) Setup(ctx core.SetupContext) error {
+	spec, err := decodeCreateCommentSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Repository)
+	return err
+}
+
+func (c *CreateComment) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCreateCommentSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	index, err := toIndex(spec.Number, "issue or pull request")
+	if err != nil {
+		return err
+	}
+
+	owner, repo, err := splitRepository(spec.Repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	comment, err := client.CreateIssueComment(owner, repo, index, spec.Body)
+	if err != nil {
+		return fmt.Errorf("failed to comment on #%d: %w", index, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"gitea.comment",
+		[]any{map[string]any{
+			"id":         comment.ID,
+			"number":     index,
+			"body":       comment.Body,
+			"user":       comment.User.Login,
+			"created_at": comment.CreatedAt,
+			"url":        comment.HTMLURL,
+		}},
+	)
+}
+
+func decodeCreateCommentSpec(value any) (CreateCommentSpec, error) {
+	spec := CreateCommentSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.Repository) == "" {
+		return spec, fmt.Errorf("repository is required")
+	}
+
+	number, err := parseIndex(spec.Number, "issue or pull request")
+	if err != nil {
+		return spec, err
+	}
+
+	spec.Number = number
+	if strings.TrimSpace(spec.Body) == "" {
+		return spec, fmt.Errorf("comment is required")
+	}
+
+	return spec, nil
+}
+
+func (c *CreateComment) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateComment) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreateComment) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *CreateComment) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateComment) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,80 @@
+package gitea
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreateComment__Setup(t *testing.T) {
+	component := &CreateComment{}
+
+	t.Run("invalid number -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "platform/hello", "number": "abc", "body": "LGTM"},
+		})
+
+		require.ErrorContains(t, err, `invalid issue or pull request number "abc"`)
+	})
+
+	t.Run("expression as number -> stores repository", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		err := component.Setup(core.SetupContext{
+			HTTP:        &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"id":12,"name":"hello","full_name":"platform/hello","html_url":"https://git.example.com/platform/hello","default_branch":"main","owner":{"id":3,"login":"platform"}}`)}},
+			Integration: testIntegration(),
+			Metadata:    metadata,
+			Configuration: map[string]any{
+				"repository": "platform/hello",
+				"number":     "{{ root().data.issue.number }}",
+				"body":       "LGTM",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), metadata.Metadata.(NodeMetadata).Repository.ID)
+	})
+}
+
+func Test__CreateComment__Execute(t *testing.T) {
+	component := &CreateComment{}
+
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+		jsonResponse(http.StatusCreated, `{
+			"id": 1894,
+			"body": "Preview is ready",
+			"user": {"login": "superplane"},
+			"created_at": "2026-10-19T10:24:05Z",
+			"html_url": "https://git.example.com/platform/hello/pulls/42#issuecomment-1894"
+		}`),
+	}}
+
+	executionState := &contexts.ExecutionStateContext{}
+	err := component.Execute(core.ExecutionContext{
+		Configuration:  map[string]any{"repository": "platform/hello", "number": "42", "body": "Preview is ready"},
+		HTTP:           httpContext,
+		Integration:    testIntegration(),
+		ExecutionState: executionState,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, httpContext.Requests, 1)
+	assert.Equal(t, http.MethodPost, httpContext.Requests[0].Method)
+	assert.Equal(t, "/api/v1/repos/platform/hello/issues/42/comments", httpContext.Requests[0].URL.Path)
+	body, err := io.ReadAll(httpContext.Requests[0].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"body":"Preview is ready"}`, string(body))
+
+	assert.Equal(t, core.DefaultOutputChannel.Name, executionState.Channel)
+	assert.Equal(t, "gitea.comment", executionState.Type)
+	payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, int64(1894), payload["id"])
+	assert.Equal(t, int64(42), payload["number"])
+}
@@ -0,0 +1,208 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CreateRelease struct{}
+
+type CreateReleaseSpec struct {
+	Repository string `json:"repository" mapstructure:"repository"`
+	Tag        string `json:"tag" mapstructure:"tag"`
+	Target     string `json:"target" mapstructure:"target"`
+	Name       string `json:"name" mapstructure:"name"`
+	Body       string `json:"body" mapstructure:"body"`
+	Draft      bool   `json:"draft" mapstructure:"draft"`
+	Prerelease bool   `json:"prerelease" mapstructure:"prerelease"`
+}
+
+func (c *CreateRelease) Name() string {
+	return "gitea.createRelease"
+}
+
+func (c *CreateRelease) Label() string {
+	return "Create Release"
+}
+
+func (c *CreateRelease) Description() string {
+	return "Create a release in a Gitea repository"
+}
+
+func (c *CreateRelease) Documentation() string {
+	return `The Create Release component creates a release in a Gitea or Forgejo repository.
+
+## Use Cases
+
+- **Release automation**: Publish a release once a version is built and tested
+- **Release candidates**: Create pre-releases for versions that are being verified
+
+## Configuration
+
+- **Repository**: The repository to create the release in
+- **Tag**: The tag of the release. The tag is created when it doesn't exist yet.
+- **Target** *(optional)*: Branch or commit the tag is created from. Defaults to the default branch of the repository.
+- **Name** and **Notes** *(optional)*: The title of the release and its notes, in Markdown
+- **Draft** and **Pre-release**: Create the release as a draft or as a pre-release
+
+## Output
+
+The release, with its ID, tag, name and a link to it.`
+}
+
+func (c *CreateRelease) Icon() string {
+	return "tag"
+}
+
+func (c *CreateRelease) Color() string {
+	return "green"
+}
+
+func (c *CreateRelease) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateRelease) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:        "tag",
+			Label:       "Tag",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Tag of the release",
+			Placeholder: "e.g. v1.2.0",
+		},
+		branchField("target", "Target", "Branch the tag is created from, when it doesn't exist yet", false),
+		{
+			Name:     "name",
+			Label:    "Name",
+			Type:     configuration.FieldTypeString,
+			Required: false,
+		},
+		{
+			Name:        "body",
+			Label:       "Notes",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Release notes, in Markdown",
+		},
+		{
+			Name:     "draft",
+			Label:    "Draft",
+			Type:     configuration.FieldTypeBool,
+			Required: false,
+			Default:  false,
+		},
+		{
+			Name:     "prerelease",
+			Label:    "Pre-release",
+			Type:     configuration.FieldTypeBool,
+			Required: false,
+			Default:  false,
+		},
+	}
+}
+
+func (c *CreateRelease) Setup(ctx core.SetupContext) error {
+	spec, err := decodeCreateReleaseSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Repository)
+	return err
+}
+
+func (c *CreateRelease) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCreateReleaseSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	owner, repo, err := splitRepository(spec.Repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	release, err := client.CreateRelease(owner, repo, ReleaseRequest{
+		TagName:         spec.Tag,
+		TargetCommitish: spec.Target,
+		Name:            spec.Name,
+		Body:            spec.Body,
+		Draft:           spec.Draft,
+		Prerelease:      spec.Prerelease,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create release %s: %w", spec.Tag, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"gitea.release",
+		[]any{map[string]any{
+			"id":               release.ID,
+			"tag_name":         release.TagName,
+			"target_commitish": release.TargetCommitish,
+			"name":             release.Name,
+			"body":             release.Body,
+			"draft":            release.Draft,
+			"prerelease":       release.Prerelease,
+			"author":           release.Author.Login,
+			"created_at":       release.CreatedAt,
+			"published_at":     release.PublishedAt,
+			"url":              release.HTMLURL,
+		}},
+	)
+}
+
+func decodeCreateReleaseSpec(value any) (CreateReleaseSpec, error) {
+	spec := CreateReleaseSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.Repository) == "" {
+		return spec, fmt.Errorf("repository is required")
+	}
+
+	spec.Tag = strings.TrimSpace(spec.Tag)
+	if spec.Tag == "" {
+		return spec, fmt.Errorf("tag is required")
+	}
+
+	spec.Target = strings.TrimSpace(spec.Target)
+	spec.Name = strings.TrimSpace(spec.Name)
+	return spec, nil
+}
+
+func (c *CreateRelease) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *CreateRelease) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreateRelease) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *CreateRelease) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateRelease) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,85 @@
+package gitea
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreateRelease__Setup(t *testing.T) {
+	err := (&CreateRelease{}).Setup(core.SetupContext{
+		HTTP:          &contexts.HTTPContext{},
+		Integration:   testIntegration(),
+		Metadata:      &contexts.MetadataContext{},
+		Configuration: map[string]any{"repository": "platform/hello", "tag": " "},
+	})
+
+	require.ErrorContains(t, err, "tag is required")
+}
+
+func Test__CreateRelease__Execute(t *testing.T) {
+	t.Run("release is created", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusCreated, `{
+				"id": 58,
+				"tag_name": "v1.2.0",
+				"target_commitish": "main",
+				"name": "v1.2.0",
+				"prerelease": true,
+				"author": {"login": "superplane"},
+				"html_url": "https://git.example.com/platform/hello/releases/tag/v1.2.0"
+			}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := (&CreateRelease{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"repository": "platform/hello",
+				"tag":        "v1.2.0",
+				"target":     "main",
+				"name":       "v1.2.0",
+				"prerelease": true,
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodPost, httpContext.Requests[0].Method)
+		assert.Equal(t, "/api/v1/repos/platform/hello/releases", httpContext.Requests[0].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"tag_name": "v1.2.0",
+			"target_commitish": "main",
+			"name": "v1.2.0",
+			"draft": false,
+			"prerelease": true
+		}`, string(body))
+
+		assert.Equal(t, "gitea.release", executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, int64(58), payload["id"])
+		assert.Equal(t, "superplane", payload["author"])
+	})
+
+	t.Run("existing release -> error", func(t *testing.T) {
+		err := (&CreateRelease{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{"repository": "platform/hello", "tag": "v1.2.0"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusConflict, `{"message":"release already exists"}`),
+			}},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "failed to create release v1.2.0: request failed with 409")
+	})
+}
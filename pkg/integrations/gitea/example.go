@@ -0,0 +1,98 @@
+package gitea
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_data_on_push.json
+var exampleDataOnPushBytes []byte
+
+var exampleDataOnPushOnce sync.Once
+var exampleDataOnPush map[string]any
+
+func (t *OnPush) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnPushOnce, exampleDataOnPushBytes, &exampleDataOnPush)
+}
+
+//go:embed example_data_on_tag.json
+var exampleDataOnTagBytes []byte
+
+var exampleDataOnTagOnce sync.Once
+var exampleDataOnTag map[string]any
+
+func (t *OnTag) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnTagOnce, exampleDataOnTagBytes, &exampleDataOnTag)
+}
+
+//go:embed example_data_on_pull_request.json
+var exampleDataOnPullRequestBytes []byte
+
+var exampleDataOnPullRequestOnce sync.Once
+var exampleDataOnPullRequest map[string]any
+
+func (t *OnPullRequest) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnPullRequestOnce, exampleDataOnPullRequestBytes, &exampleDataOnPullRequest)
+}
+
+//go:embed example_data_on_issue_comment.json
+var exampleDataOnIssueCommentBytes []byte
+
+var exampleDataOnIssueCommentOnce sync.Once
+var exampleDataOnIssueComment map[string]any
+
+func (t *OnIssueComment) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnIssueCommentOnce, exampleDataOnIssueCommentBytes, &exampleDataOnIssueComment)
+}
+
+//go:embed example_data_on_release.json
+var exampleDataOnReleaseBytes []byte
+
+var exampleDataOnReleaseOnce sync.Once
+var exampleDataOnRelease map[string]any
+
+func (t *OnRelease) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleDataOnReleaseOnce, exampleDataOnReleaseBytes, &exampleDataOnRelease)
+}
+
+//go:embed example_output_create_comment.json
+var exampleOutputCreateCommentBytes []byte
+
+var exampleOutputCreateCommentOnce sync.Once
+var exampleOutputCreateComment map[string]any
+
+func (c *CreateComment) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateCommentOnce, exampleOutputCreateCommentBytes, &exampleOutputCreateComment)
+}
+
+//go:embed example_output_set_commit_status.json
+var exampleOutputSetCommitStatusBytes []byte
+
+var exampleOutputSetCommitStatusOnce sync.Once
+var exampleOutputSetCommitStatus map[string]any
+
+func (c *SetCommitStatus) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSetCommitStatusOnce, exampleOutputSetCommitStatusBytes, &exampleOutputSetCommitStatus)
+}
+
+//go:embed example_output_create_release.json
+var exampleOutputCreateReleaseBytes []byte
+
+var exampleOutputCreateReleaseOnce sync.Once
+var exampleOutputCreateRelease map[string]any
+
+func (c *CreateRelease) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputCreateReleaseOnce, exampleOutputCreateReleaseBytes, &exampleOutputCreateRelease)
+}
+
+//go:embed example_output_merge_pull_request.json
+var exampleOutputMergePullRequestBytes []byte
+
+var exampleOutputMergePullRequestOnce sync.Once
+var exampleOutputMergePullRequest map[string]any
+
+func (c *MergePullRequest) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputMergePullRequestOnce, exampleOutputMergePullRequestBytes, &exampleOutputMergePullRequest)
+}
@@ -0,0 +1,39 @@
+package gitea
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// ResolveGitRemote returns the HTTPS clone URL for a repository of the instance.
+// Gitea accepts access tokens as the password of the token's user over git.
+func (g *Gitea) ResolveGitRemote(ctx core.IntegrationGitRemoteContext, repository string) (*core.GitRemote, error) {
+	owner, repo, err := splitRepository(strings.TrimSuffix(strings.Trim(strings.TrimSpace(repository), "/"), ".git"))
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := Metadata{}
+	if err := mapstructure.Decode(ctx.Integration.GetMetadata(), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode integration metadata: %w", err)
+	}
+
+	username := "oauth2"
+	if metadata.User != nil && metadata.User.Login != "" {
+		username = metadata.User.Login
+	}
+
+	return &core.GitRemote{
+		URL:      fmt.Sprintf("%s/%s/%s.git", client.BaseURL, owner, repo),
+		Username: username,
+		Password: client.Token,
+	}, nil
+}
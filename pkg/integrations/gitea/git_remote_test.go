@@ -0,0 +1,31 @@
+package gitea
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func Test__Gitea__ResolveGitRemote(t *testing.T) {
+	g := &Gitea{}
+
+	t.Run("repository is required", func(t *testing.T) {
+		_, err := g.ResolveGitRemote(core.IntegrationGitRemoteContext{Integration: testIntegration()}, " ")
+		require.ErrorContains(t, err, "repository is required")
+	})
+
+	t.Run("repository without owner -> error", func(t *testing.T) {
+		_, err := g.ResolveGitRemote(core.IntegrationGitRemoteContext{Integration: testIntegration()}, "hello")
+		require.ErrorContains(t, err, "expected owner/name")
+	})
+
+	t.Run("clone URL of the instance", func(t *testing.T) {
+		remote, err := g.ResolveGitRemote(core.IntegrationGitRemoteContext{Integration: testIntegration()}, "platform/hello.git")
+		require.NoError(t, err)
+		assert.Equal(t, "https://git.example.com/platform/hello.git", remote.URL)
+		assert.Equal(t, "superplane", remote.Username)
+		assert.Equal(t, "token", remote.Password)
+	})
+}
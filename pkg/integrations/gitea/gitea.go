@@ -0,0 +1,163 @@
+package gitea
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const installationInstructions = `
+To configure Gitea or Forgejo with SuperPlane:
+
+- Go to **Settings → Applications → Manage Access Tokens** on your instance.
+- Generate a token with the **repository: Read and Write**, **issue: Read and Write** and **user: Read** scopes.
+- **Copy the token** and the URL of your instance (for example: ` + "`https://git.example.com`" + `) below.
+
+The token's user needs admin access to the repositories used by triggers, since triggers create repository webhooks.
+`
+
+func init() {
+	registry.RegisterIntegrationWithWebhookHandler("gitea", &Gitea{}, &GiteaWebhookHandler{})
+}
+
+// Gitea integrates with Gitea instances and with Forgejo,
+// which is a fork of Gitea that kept its API and webhooks.
+type Gitea struct{}
+
+type Configuration struct {
+	BaseURL     string `json:"baseUrl" mapstructure:"baseUrl"`
+	AccessToken string `json:"accessToken" mapstructure:"accessToken"`
+}
+
+type Metadata struct {
+	BaseURL string        `json:"baseUrl" mapstructure:"baseUrl"`
+	Version string        `json:"version" mapstructure:"version"`
+	User    *UserMetadata `json:"user,omitempty" mapstructure:"user,omitempty"`
+}
+
+type UserMetadata struct {
+	ID    int64  `json:"id" mapstructure:"id"`
+	Login string `json:"login" mapstructure:"login"`
+}
+
+func (g *Gitea) Name() string {
+	return "gitea"
+}
+
+func (g *Gitea) Label() string {
+	return "Gitea"
+}
+
+func (g *Gitea) Icon() string {
+	return "gitea"
+}
+
+func (g *Gitea) Description() string {
+	return "React to events in your Gitea or Forgejo repositories, and manage comments, commit statuses, releases and pull requests"
+}
+
+func (g *Gitea) Instructions() string {
+	return installationInstructions
+}
+
+func (g *Gitea) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "baseUrl",
+			Label:       "Base URL",
+			Type:        configuration.FieldTypeString,
+			Description: "URL of your Gitea or Forgejo instance",
+			Placeholder: "e.g. https://git.example.com",
+			Required:    true,
+		},
+		{
+			Name:        "accessToken",
+			Label:       "Access Token",
+			Type:        configuration.FieldTypeString,
+			Sensitive:   true,
+			Description: "Access token of the user SuperPlane acts as",
+			Required:    true,
+		},
+	}
+}
+
+func (g *Gitea) Actions() []core.Action {
+	return []core.Action{
+		&CreateComment{},
+		&CreateRelease{},
+		&MergePullRequest{},
+		&SetCommitStatus{},
+	}
+}
+
+func (g *Gitea) Triggers() []core.Trigger {
+	return []core.Trigger{
+		&OnIssueComment{},
+		&OnPullRequest{},
+		&OnPush{},
+		&OnRelease{},
+		&OnTag{},
+	}
+}
+
+func (g *Gitea) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (g *Gitea) Sync(ctx core.SyncContext) error {
+	config := Configuration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.BaseURL == "" {
+		return fmt.Errorf("baseUrl is required")
+	}
+
+	if config.AccessToken == "" {
+		return fmt.Errorf("accessToken is required")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		return fmt.Errorf("error verifying access token: %w", err)
+	}
+
+	version, err := client.GetVersion()
+	if err != nil {
+		return fmt.Errorf("error getting version: %w", err)
+	}
+
+	ctx.Integration.SetMetadata(Metadata{
+		BaseURL: client.BaseURL,
+		Version: version.Version,
+		User: &UserMetadata{
+			ID:    user.ID,
+			Login: user.Login,
+		},
+	})
+
+	ctx.Integration.Ready()
+
+	return nil
+}
+
+func (g *Gitea) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (g *Gitea) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (g *Gitea) HandleHook(ctx core.IntegrationHookContext) error {
+	return nil
+}
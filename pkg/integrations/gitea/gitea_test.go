@@ -0,0 +1,72 @@
+package gitea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Gitea__Sync(t *testing.T) {
+	g := &Gitea{}
+
+	t.Run("baseUrl is required", func(t *testing.T) {
+		err := g.Sync(core.SyncContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   &contexts.IntegrationContext{},
+			Configuration: map[string]any{"accessToken": "token"},
+		})
+
+		require.ErrorContains(t, err, "baseUrl is required")
+	})
+
+	t.Run("invalid token -> error", func(t *testing.T) {
+		integration := &contexts.IntegrationContext{
+			Configuration: map[string]any{"baseUrl": "https://git.example.com", "accessToken": "token"},
+		}
+
+		err := g.Sync(core.SyncContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusUnauthorized, `{"message":"token is required"}`),
+			}},
+			Integration:   integration,
+			Configuration: integration.Configuration,
+		})
+
+		require.ErrorContains(t, err, "error verifying access token: request failed with 401: token is required")
+		assert.NotEqual(t, "ready", integration.State)
+	})
+
+	t.Run("user and version are stored", func(t *testing.T) {
+		integration := &contexts.IntegrationContext{
+			Configuration: map[string]any{"baseUrl": "https://git.example.com/api/v1/", "accessToken": "token"},
+		}
+
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"id":1,"login":"superplane"}`),
+			jsonResponse(http.StatusOK, `{"version":"9.0.1+gitea-1.22.0"}`),
+		}}
+
+		err := g.Sync(core.SyncContext{
+			HTTP:          httpContext,
+			Integration:   integration,
+			Configuration: integration.Configuration,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integration.State)
+		assert.Equal(t, Metadata{
+			BaseURL: "https://git.example.com",
+			Version: "9.0.1+gitea-1.22.0",
+			User:    &UserMetadata{ID: 1, Login: "superplane"},
+		}, integration.Metadata)
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://git.example.com/api/v1/user", httpContext.Requests[0].URL.String())
+		assert.Equal(t, "token token", httpContext.Requests[0].Header.Get("Authorization"))
+		assert.Equal(t, "https://git.example.com/api/v1/version", httpContext.Requests[1].URL.String())
+	})
+}
@@ -0,0 +1,68 @@
+package gitea
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func (g *Gitea) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	switch resourceType {
+	case ResourceTypeRepository, ResourceTypeBranch:
+	default:
+		return []core.IntegrationResource{}, nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	if resourceType == ResourceTypeBranch {
+		return listBranches(client, ctx.Parameters["repository"])
+	}
+
+	repositories, err := client.ListRepositories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(repositories))
+	for _, repo := range repositories {
+		resources = append(resources, core.IntegrationResource{
+			Type: ResourceTypeRepository,
+			Name: repo.FullName,
+			ID:   fmt.Sprintf("%d", repo.ID),
+		})
+	}
+
+	return resources, nil
+}
+
+func listBranches(client *Client, repository string) ([]core.IntegrationResource, error) {
+	if strings.TrimSpace(repository) == "" {
+		return []core.IntegrationResource{}, nil
+	}
+
+	owner, repo, err := splitRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	branches, err := client.ListBranches(owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(branches))
+	for _, branch := range branches {
+		resources = append(resources, core.IntegrationResource{
+			Type: ResourceTypeBranch,
+			Name: branch.Name,
+			ID:   branch.Name,
+		})
+	}
+
+	return resources, nil
+}
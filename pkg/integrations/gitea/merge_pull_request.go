@@ -0,0 +1,239 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type MergePullRequest struct{}
+
+const (
+	MergeStyleMerge           = "merge"
+	MergeStyleRebase          = "rebase"
+	MergeStyleRebaseMerge     = "rebase-merge"
+	MergeStyleSquash          = "squash"
+	MergeStyleFastForwardOnly = "fast-forward-only"
+)
+
+var mergeStyles = []string{
+	MergeStyleMerge,
+	MergeStyleRebase,
+	MergeStyleRebaseMerge,
+	MergeStyleSquash,
+	MergeStyleFastForwardOnly,
+}
+
+type MergePullRequestSpec struct {
+	Repository   string `json:"repository" mapstructure:"repository"`
+	PullRequest  string `json:"pullRequest" mapstructure:"pullRequest"`
+	MergeStyle   string `json:"mergeStyle" mapstructure:"mergeStyle"`
+	Title        string `json:"title" mapstructure:"title"`
+	Message      string `json:"message" mapstructure:"message"`
+	DeleteBranch bool   `json:"deleteBranch" mapstructure:"deleteBranch"`
+}
+
+func (c *MergePullRequest) Name() string {
+	return "gitea.mergePullRequest"
+}
+
+func (c *MergePullRequest) Label() string {
+	return "Merge Pull Request"
+}
+
+func (c *MergePullRequest) Description() string {
+	return "Merge a Gitea pull request"
+}
+
+func (c *MergePullRequest) Documentation() string {
+	return `The Merge Pull Request component merges a pull request in a Gitea or Forgejo repository.
+
+## Use Cases
+
+- **Merge queues**: Merge pull requests once all of their checks passed
+- **Dependency updates**: Merge automated dependency updates after they are verified
+
+## Configuration
+
+- **Repository**: The repository of the pull request
+- **Pull Request**: The number of the pull request, for example ` + "`{{ root().data.number }}`" + `
+- **Merge Style**: Merge commit, rebase, rebase with merge commit, squash or fast-forward only. The style must be allowed in the repository settings.
+- **Title** and **Message** *(optional)*: Title and message of the merge commit. Default to the ones of the instance.
+- **Delete Branch**: Delete the head branch after the merge
+
+## Output
+
+The merged pull request, with its state and merge commit.`
+}
+
+func (c *MergePullRequest) Icon() string {
+	return "git-merge"
+}
+
+func (c *MergePullRequest) Color() string {
+	return "green"
+}
+
+func (c *MergePullRequest) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *MergePullRequest) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:        "pullRequest",
+			Label:       "Pull Request",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Number of the pull request",
+			Placeholder: "{{ root().data.number }}",
+		},
+		{
+			Name:     "mergeStyle",
+			Label:    "Merge Style",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  MergeStyleMerge,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Merge commit", Value: MergeStyleMerge},
+						{Label: "Rebase", Value: MergeStyleRebase},
+						{Label: "Rebase with merge commit", Value: MergeStyleRebaseMerge},
+						{Label: "Squash", Value: MergeStyleSquash},
+						{Label: "Fast-forward only", Value: MergeStyleFastForwardOnly},
+					},
+				},
+			},
+		},
+		{
+			Name:        "title",
+			Label:       "Commit Title",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Title of the merge commit",
+		},
+		{
+			Name:        "message",
+			Label:       "Commit Message",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Message of the merge commit",
+		},
+		{
+			Name:        "deleteBranch",
+			Label:       "Delete Branch",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Delete the head branch after the merge",
+		},
+	}
+}
+
+func (c *MergePullRequest) Setup(ctx core.SetupContext) error {
+	spec, err := decodeMergePullRequestSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Repository)
+	return err
+}
+
+func (c *MergePullRequest) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeMergePullRequestSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	index, err := toIndex(spec.PullRequest, "pull request")
+	if err != nil {
+		return err
+	}
+
+	owner, repo, err := splitRepository(spec.Repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	err = client.MergePullRequest(owner, repo, index, MergePullRequestRequest{
+		Do:                     spec.MergeStyle,
+		MergeTitleField:        spec.Title,
+		MergeMessageField:      spec.Message,
+		DeleteBranchAfterMerge: spec.DeleteBranch,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to merge pull request #%d: %w", index, err)
+	}
+
+	//
+	// The merge endpoint has no response body,
+	// so the merged pull request is fetched again.
+	//
+	pullRequest, err := client.GetPullRequest(owner, repo, index)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request #%d: %w", index, err)
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, "gitea.pullRequest.merged", []any{pullRequestToMap(pullRequest)})
+}
+
+func decodeMergePullRequestSpec(value any) (MergePullRequestSpec, error) {
+	spec := MergePullRequestSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.Repository) == "" {
+		return spec, fmt.Errorf("repository is required")
+	}
+
+	pullRequest, err := parseIndex(spec.PullRequest, "pull request")
+	if err != nil {
+		return spec, err
+	}
+
+	spec.PullRequest = pullRequest
+	if spec.MergeStyle == "" {
+		spec.MergeStyle = MergeStyleMerge
+	}
+
+	if !slices.Contains(mergeStyles, spec.MergeStyle) {
+		return spec, fmt.Errorf("invalid merge style %q", spec.MergeStyle)
+	}
+
+	return spec, nil
+}
+
+func (c *MergePullRequest) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *MergePullRequest) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *MergePullRequest) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *MergePullRequest) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *MergePullRequest) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,79 @@
+package gitea
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__MergePullRequest__Setup(t *testing.T) {
+	err := (&MergePullRequest{}).Setup(core.SetupContext{
+		HTTP:          &contexts.HTTPContext{},
+		Integration:   testIntegration(),
+		Metadata:      &contexts.MetadataContext{},
+		Configuration: map[string]any{"repository": "platform/hello", "pullRequest": "42", "mergeStyle": "octopus"},
+	})
+
+	require.ErrorContains(t, err, `invalid merge style "octopus"`)
+}
+
+func Test__MergePullRequest__Execute(t *testing.T) {
+	t.Run("pull request is merged", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, ``),
+			jsonResponse(http.StatusOK, `{
+				"number": 42,
+				"title": "Add health check endpoint",
+				"state": "closed",
+				"merged": true,
+				"merge_commit_sha": "c81e728d9d4c2f636f067f89cc14862c5e1f0a3b",
+				"head": {"ref": "feature/health-check"},
+				"base": {"ref": "main"}
+			}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := (&MergePullRequest{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"repository":   "platform/hello",
+				"pullRequest":  "42",
+				"mergeStyle":   MergeStyleSquash,
+				"deleteBranch": true,
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/api/v1/repos/platform/hello/pulls/42/merge", httpContext.Requests[0].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"Do":"squash","delete_branch_after_merge":true}`, string(body))
+		assert.Equal(t, "/api/v1/repos/platform/hello/pulls/42", httpContext.Requests[1].URL.Path)
+
+		assert.Equal(t, "gitea.pullRequest.merged", executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, payload["merged"])
+		assert.Equal(t, "c81e728d9d4c2f636f067f89cc14862c5e1f0a3b", payload["merge_commit_sha"])
+	})
+
+	t.Run("pull request is not mergeable -> error", func(t *testing.T) {
+		err := (&MergePullRequest{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{"repository": "platform/hello", "pullRequest": "42"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusMethodNotAllowed, `{"message":"Please try again later"}`),
+			}},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "failed to merge pull request #42: request failed with 405: Please try again later")
+	})
+}
@@ -0,0 +1,164 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnIssueComment struct{}
+
+type OnIssueCommentConfiguration struct {
+	Repository    string `json:"repository" mapstructure:"repository"`
+	ContentFilter string `json:"contentFilter" mapstructure:"contentFilter"`
+}
+
+func (c *OnIssueComment) Name() string {
+	return "gitea.onIssueComment"
+}
+
+func (c *OnIssueComment) Label() string {
+	return "On Issue Comment"
+}
+
+func (c *OnIssueComment) Description() string {
+	return "Listen to comments on Gitea issues and pull requests"
+}
+
+func (c *OnIssueComment) Documentation() string {
+	return `The On Issue Comment trigger starts a workflow execution when a comment is added to an issue or a pull request in a Gitea or Forgejo repository.
+
+## Use Cases
+
+- **ChatOps**: Run workflows from commands like ` + "`/deploy`" + ` in pull request comments
+- **Triage**: Forward comments on issues to other tools
+
+## Configuration
+
+- **Repository**: Select the repository to monitor
+- **Content Filter**: Optional regular expression. Only comments matching it start an execution.
+
+## Event Data
+
+Each comment event includes:
+- **comment**: The comment, with its body and author
+- **issue**: The issue or pull request that was commented on
+- **is_pull**: Whether the comment was added to a pull request
+- **repository**: Repository information
+- **sender**: Information about who commented
+
+Edited and deleted comments are ignored.
+
+## Webhook Setup
+
+This trigger automatically sets up a repository webhook when configured. The webhook is managed by SuperPlane and will be cleaned up when the trigger is removed.`
+}
+
+func (c *OnIssueComment) Icon() string {
+	return "gitea"
+}
+
+func (c *OnIssueComment) Color() string {
+	return "green"
+}
+
+func (c *OnIssueComment) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:        "contentFilter",
+			Label:       "Content Filter",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Regular expression the comment must match",
+			Placeholder: "e.g. ^/deploy",
+		},
+	}
+}
+
+func (c *OnIssueComment) Setup(ctx core.TriggerContext) error {
+	config := OnIssueCommentConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.ContentFilter != "" {
+		if _, err := regexp.Compile(config.ContentFilter); err != nil {
+			return fmt.Errorf("invalid content filter: %w", err)
+		}
+	}
+
+	repo, err := ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, config.Repository)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		EventTypes: []string{"issue_comment", "pull_request_comment"},
+		Repository: repo.FullName,
+	})
+}
+
+func (c *OnIssueComment) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *OnIssueComment) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (c *OnIssueComment) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	//
+	// Comments on pull requests are delivered as issue comments too.
+	//
+	event, status, err := verifyWebhook(ctx, "issue_comment")
+	if event == "" {
+		return status, nil, err
+	}
+
+	data := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &data)
+	if err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	if action, _ := data["action"].(string); action != "created" {
+		return http.StatusOK, nil, nil
+	}
+
+	config := OnIssueCommentConfiguration{}
+	err = mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if config.ContentFilter != "" {
+		filter, err := regexp.Compile(config.ContentFilter)
+		if err != nil {
+			return http.StatusInternalServerError, nil, fmt.Errorf("invalid content filter: %w", err)
+		}
+
+		comment, _ := data["comment"].(map[string]any)
+		body, _ := comment["body"].(string)
+		if !filter.MatchString(body) {
+			return http.StatusOK, nil, nil
+		}
+	}
+
+	err = ctx.Events.Emit("gitea.issueComment", data)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (c *OnIssueComment) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
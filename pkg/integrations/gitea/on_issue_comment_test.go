@@ -0,0 +1,74 @@
+package gitea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnIssueComment__Setup(t *testing.T) {
+	t.Run("invalid content filter -> error", func(t *testing.T) {
+		err := (&OnIssueComment{}).Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "platform/hello", "contentFilter": "(deploy"},
+		})
+
+		require.ErrorContains(t, err, "invalid content filter")
+	})
+
+	t.Run("webhook is requested for issue and pull request comments", func(t *testing.T) {
+		integration := testIntegration()
+		err := (&OnIssueComment{}).Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"id":12,"name":"hello","full_name":"platform/hello","html_url":"https://git.example.com/platform/hello","default_branch":"main","owner":{"id":3,"login":"platform"}}`)}},
+			Integration:   integration,
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "platform/hello"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integration.WebhookRequests, 1)
+		assert.Equal(t, WebhookConfiguration{
+			EventTypes: []string{"issue_comment", "pull_request_comment"},
+			Repository: "platform/hello",
+		}, integration.WebhookRequests[0])
+	})
+}
+
+func Test__OnIssueComment__HandleWebhook(t *testing.T) {
+	trigger := &OnIssueComment{}
+	config := map[string]any{"contentFilter": "^/deploy"}
+
+	t.Run("edited comment -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"action": "edited", "comment": {"body": "/deploy staging"}}`
+		code, _, err := trigger.HandleWebhook(webhookRequest("issue_comment", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("comment does not match filter -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"action": "created", "comment": {"body": "LGTM"}}`
+		code, _, err := trigger.HandleWebhook(webhookRequest("issue_comment", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("comment matches filter -> event is emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"action": "created", "is_pull": true, "comment": {"body": "/deploy staging"}}`
+		code, _, err := trigger.HandleWebhook(webhookRequest("issue_comment", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "gitea.issueComment", events.Payloads[0].Type)
+	})
+}
@@ -0,0 +1,214 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	PullRequestActionOpened       = "opened"
+	PullRequestActionSynchronized = "synchronized"
+	PullRequestActionEdited       = "edited"
+	PullRequestActionClosed       = "closed"
+	PullRequestActionMerged       = "merged"
+	PullRequestActionReopened     = "reopened"
+)
+
+var pullRequestActions = []string{
+	PullRequestActionOpened,
+	PullRequestActionSynchronized,
+	PullRequestActionEdited,
+	PullRequestActionClosed,
+	PullRequestActionMerged,
+	PullRequestActionReopened,
+}
+
+type OnPullRequest struct{}
+
+type OnPullRequestConfiguration struct {
+	Repository     string                    `json:"repository" mapstructure:"repository"`
+	Actions        []string                  `json:"actions" mapstructure:"actions"`
+	TargetBranches []configuration.Predicate `json:"targetBranches" mapstructure:"targetBranches"`
+}
+
+func (p *OnPullRequest) Name() string {
+	return "gitea.onPullRequest"
+}
+
+func (p *OnPullRequest) Label() string {
+	return "On Pull Request"
+}
+
+func (p *OnPullRequest) Description() string {
+	return "Listen to pull request events in a Gitea repository"
+}
+
+func (p *OnPullRequest) Documentation() string {
+	return `The On Pull Request trigger starts a workflow execution when a pull request is opened, updated, merged or closed in a Gitea or Forgejo repository.
+
+## Use Cases
+
+- **Preview environments**: Create an environment when a pull request is opened, and remove it when it is closed
+- **Pull request checks**: Run tests on every new commit of a pull request
+- **Post-merge automation**: Deploy or notify once a pull request is merged
+
+## Configuration
+
+- **Repository**: Select the repository to monitor
+- **Actions**: The pull request actions to listen to. Synchronized is sent when new commits are pushed to a pull request.
+- **Target Branches** *(optional)*: Only listen to pull requests into branches matching one of these predicates (e.g. ` + "`main`" + `). Leave empty for all branches.
+
+## Event Data
+
+Each pull request event includes:
+- **action**: The action of the event. Merged pull requests have the ` + "`merged`" + ` action, instead of ` + "`closed`" + `.
+- **number**: The number of the pull request
+- **pull_request**: The pull request, with its title, state, head and base branches
+- **repository**: Repository information
+- **sender**: Information about who triggered the event
+
+## Webhook Setup
+
+This trigger automatically sets up a repository webhook when configured. The webhook is managed by SuperPlane and will be cleaned up when the trigger is removed.`
+}
+
+func (p *OnPullRequest) Icon() string {
+	return "gitea"
+}
+
+func (p *OnPullRequest) Color() string {
+	return "green"
+}
+
+func (p *OnPullRequest) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:     "actions",
+			Label:    "Actions",
+			Type:     configuration.FieldTypeMultiSelect,
+			Required: true,
+			Default:  []string{PullRequestActionOpened, PullRequestActionSynchronized},
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Opened", Value: PullRequestActionOpened},
+						{Label: "Synchronized", Value: PullRequestActionSynchronized},
+						{Label: "Edited", Value: PullRequestActionEdited},
+						{Label: "Closed", Value: PullRequestActionClosed},
+						{Label: "Merged", Value: PullRequestActionMerged},
+						{Label: "Reopened", Value: PullRequestActionReopened},
+					},
+				},
+			},
+		},
+		{
+			Name:        "targetBranches",
+			Label:       "Target Branches",
+			Type:        configuration.FieldTypeAnyPredicateList,
+			Required:    false,
+			Togglable:   true,
+			Description: "Only listen to pull requests into matching branches",
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+	}
+}
+
+func (p *OnPullRequest) Setup(ctx core.TriggerContext) error {
+	config := OnPullRequestConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if len(config.Actions) == 0 {
+		return fmt.Errorf("at least one action is required")
+	}
+
+	for _, action := range config.Actions {
+		if !slices.Contains(pullRequestActions, action) {
+			return fmt.Errorf("invalid action %q", action)
+		}
+	}
+
+	repo, err := ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, config.Repository)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		EventTypes: []string{"pull_request"},
+		Repository: repo.FullName,
+	})
+}
+
+func (p *OnPullRequest) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (p *OnPullRequest) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (p *OnPullRequest) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	event, status, err := verifyWebhook(ctx, "pull_request")
+	if event == "" {
+		return status, nil, err
+	}
+
+	data := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &data)
+	if err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	config := OnPullRequestConfiguration{}
+	err = mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	pullRequest, _ := data["pull_request"].(map[string]any)
+
+	//
+	// Merged pull requests are sent as closed ones.
+	//
+	action, _ := data["action"].(string)
+	if merged, _ := pullRequest["merged"].(bool); action == PullRequestActionClosed && merged {
+		action = PullRequestActionMerged
+		data["action"] = action
+	}
+
+	if !slices.Contains(config.Actions, action) {
+		return http.StatusOK, nil, nil
+	}
+
+	if len(config.TargetBranches) > 0 {
+		base, _ := pullRequest["base"].(map[string]any)
+		branch, _ := base["ref"].(string)
+		if !configuration.MatchesAnyPredicate(config.TargetBranches, branch) {
+			return http.StatusOK, nil, nil
+		}
+	}
+
+	err = ctx.Events.Emit("gitea.pullRequest", data)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (p *OnPullRequest) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
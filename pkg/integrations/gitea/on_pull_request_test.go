@@ -0,0 +1,103 @@
+package gitea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnPullRequest__Setup(t *testing.T) {
+	t.Run("invalid action -> error", func(t *testing.T) {
+		err := (&OnPullRequest{}).Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "platform/hello", "actions": []string{"approved"}},
+		})
+
+		require.ErrorContains(t, err, `invalid action "approved"`)
+	})
+
+	t.Run("webhook is requested", func(t *testing.T) {
+		integration := testIntegration()
+		err := (&OnPullRequest{}).Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"id":12,"name":"hello","full_name":"platform/hello","html_url":"https://git.example.com/platform/hello","default_branch":"main","owner":{"id":3,"login":"platform"}}`)}},
+			Integration:   integration,
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "platform/hello", "actions": []string{PullRequestActionOpened}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integration.WebhookRequests, 1)
+		assert.Equal(t, WebhookConfiguration{EventTypes: []string{"pull_request"}, Repository: "platform/hello"}, integration.WebhookRequests[0])
+	})
+}
+
+func Test__OnPullRequest__HandleWebhook(t *testing.T) {
+	trigger := &OnPullRequest{}
+
+	t.Run("action not selected -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"action": "edited", "pull_request": {"base": {"ref": "main"}}}`
+		config := map[string]any{"actions": []string{PullRequestActionOpened}}
+		code, _, err := trigger.HandleWebhook(webhookRequest("pull_request", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("merged pull request -> merged action", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"action": "closed", "number": 42, "pull_request": {"merged": true, "base": {"ref": "main"}}}`
+		config := map[string]any{"actions": []string{PullRequestActionMerged}}
+		code, _, err := trigger.HandleWebhook(webhookRequest("pull_request", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "gitea.pullRequest", events.Payloads[0].Type)
+		assert.Equal(t, PullRequestActionMerged, events.Payloads[0].Data.(map[string]any)["action"])
+	})
+
+	t.Run("closed without merge -> not merged", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"action": "closed", "pull_request": {"merged": false, "base": {"ref": "main"}}}`
+		config := map[string]any{"actions": []string{PullRequestActionMerged}}
+		code, _, err := trigger.HandleWebhook(webhookRequest("pull_request", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("target branch does not match -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"action": "opened", "pull_request": {"base": {"ref": "develop"}}}`
+		config := map[string]any{
+			"actions":        []string{PullRequestActionOpened},
+			"targetBranches": []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: "main"}},
+		}
+
+		code, _, err := trigger.HandleWebhook(webhookRequest("pull_request", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("target branch matches -> event is emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"action": "synchronized", "pull_request": {"base": {"ref": "main"}}}`
+		config := map[string]any{
+			"actions":        []string{PullRequestActionSynchronized},
+			"targetBranches": []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: "main"}},
+		}
+
+		code, _, err := trigger.HandleWebhook(webhookRequest("pull_request", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 1, events.Count())
+	})
+}
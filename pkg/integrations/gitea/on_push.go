@@ -0,0 +1,225 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/pathfilter"
+)
+
+type OnPush struct{}
+
+type OnPushConfiguration struct {
+	Repository string                    `json:"repository" mapstructure:"repository"`
+	Refs       []configuration.Predicate `json:"refs" mapstructure:"refs"`
+	Paths      []string                  `json:"paths" mapstructure:"paths"`
+}
+
+func (p *OnPush) Name() string {
+	return "gitea.onPush"
+}
+
+func (p *OnPush) Label() string {
+	return "On Push"
+}
+
+func (p *OnPush) Description() string {
+	return "Listen to Gitea push events"
+}
+
+func (p *OnPush) Documentation() string {
+	return `The On Push trigger starts a workflow execution when code is pushed to a Gitea or Forgejo repository.
+
+## Use Cases
+
+- **CI/CD automation**: Trigger builds and deployments on code pushes
+- **Code quality checks**: Run linting and tests on every push
+- **Monorepos**: Only run the workflow of the parts of a repository that changed
+
+## Configuration
+
+- **Repository**: Select the repository to monitor
+- **Refs**: Configure which branches to monitor (e.g., ` + "`refs/heads/main`" + `)
+- **Paths** *(optional)*: Glob patterns for added, modified, and removed files. Use ` + "`!`" + ` prefix to exclude (for example ` + "`billing/**`" + ` with ` + "`!billing/**/*.md`" + `). Patterns starting only with ` + "`!`" + ` assume an include of ` + "`**`" + `. Leave empty to fire on all pushes.
+
+## Event Data
+
+Each push event includes:
+- **ref**: The pushed ref, for example ` + "`refs/heads/main`" + `
+- **before** and **after**: The commits before and after the push
+- **commits**: The pushed commits, with their added, modified and removed files
+- **head_commit**: The last pushed commit
+- **repository**: Repository information
+- **pusher**: Information about who pushed
+
+Pushes that delete a branch are ignored.
+
+## Webhook Setup
+
+This trigger automatically sets up a repository webhook when configured. The webhook is managed by SuperPlane and will be cleaned up when the trigger is removed.`
+}
+
+func (p *OnPush) Icon() string {
+	return "gitea"
+}
+
+func (p *OnPush) Color() string {
+	return "green"
+}
+
+func (p *OnPush) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		refsField("Refs", "refs/heads/main"),
+		{
+			Name:        "paths",
+			Label:       "Paths",
+			Description: "Optional. Globs for changed files. Prefix with ! to exclude. Exclude-only lists assume ** (all paths). Leave empty to fire on all pushes.",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Togglable:   true,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Pattern",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *OnPush) Setup(ctx core.TriggerContext) error {
+	config := OnPushConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	repo, err := ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, config.Repository)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		EventTypes: []string{"push"},
+		Repository: repo.FullName,
+	})
+}
+
+func (p *OnPush) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (p *OnPush) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (p *OnPush) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	event, status, err := verifyWebhook(ctx, "push")
+	if event == "" {
+		return status, nil, err
+	}
+
+	data := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &data)
+	if err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	config := OnPushConfiguration{}
+	err = mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	//
+	// Pushes deleting a branch have an all-zero "after" commit.
+	//
+	if isDeletion(data) {
+		return http.StatusOK, nil, nil
+	}
+
+	ref, _ := data["ref"].(string)
+	if ref == "" {
+		return http.StatusBadRequest, nil, fmt.Errorf("missing ref")
+	}
+
+	if !configuration.MatchesAnyPredicate(config.Refs, ref) {
+		return http.StatusOK, nil, nil
+	}
+
+	pathPatterns := pathfilter.TrimNonEmptyStrings(config.Paths)
+	if len(pathPatterns) > 0 {
+		passed := pathfilter.EvaluatePushPathGlobFilter(
+			pathPatterns,
+			extractChangedFiles(data),
+			func(pattern string) {
+				ctx.Logger.Warnf("Invalid path glob syntax (skipping pattern) %q", pattern)
+			},
+			func(pattern string, err error) {
+				ctx.Logger.Warnf("Path glob match error for pattern %q: %v", pattern, err)
+			},
+			func(reason string) {
+				ctx.Logger.Warnf("gitea.onPush paths: %s", reason)
+			},
+		)
+
+		if !passed {
+			return http.StatusOK, nil, nil
+		}
+	}
+
+	err = ctx.Events.Emit("gitea.push", data)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (p *OnPush) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+func isDeletion(data map[string]any) bool {
+	after, _ := data["after"].(string)
+	return after != "" && strings.Trim(after, "0") == ""
+}
+
+// extractChangedFiles collects all added, modified, and removed file paths
+// from every commit in the push payload.
+func extractChangedFiles(data map[string]any) []string {
+	commits, ok := data["commits"].([]any)
+	if !ok {
+		return nil
+	}
+
+	var files []string
+	for _, c := range commits {
+		commit, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, key := range []string{"added", "modified", "removed"} {
+			list, ok := commit[key].([]any)
+			if !ok {
+				continue
+			}
+
+			for _, f := range list {
+				if path, ok := f.(string); ok {
+					files = append(files, path)
+				}
+			}
+		}
+	}
+
+	return files
+}
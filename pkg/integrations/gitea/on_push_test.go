@@ -0,0 +1,154 @@
+package gitea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnPush__Setup(t *testing.T) {
+	t.Run("repository without owner -> error", func(t *testing.T) {
+		err := (&OnPush{}).Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "hello"},
+		})
+
+		require.ErrorContains(t, err, `invalid repository "hello", expected owner/name`)
+	})
+
+	t.Run("repository is not accessible", func(t *testing.T) {
+		err := (&OnPush{}).Setup(core.TriggerContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusNotFound, `{"message":"GetRepositoryByName"}`),
+			}},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "platform/world"},
+		})
+
+		require.ErrorContains(t, err, "repository platform/world is not accessible to the access token")
+	})
+
+	t.Run("metadata is set and webhook is requested", func(t *testing.T) {
+		integration := testIntegration()
+		metadata := &contexts.MetadataContext{}
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"id":12,"name":"hello","full_name":"platform/hello","html_url":"https://git.example.com/platform/hello","default_branch":"main","owner":{"id":3,"login":"platform"}}`)}}
+
+		err := (&OnPush{}).Setup(core.TriggerContext{
+			HTTP:          httpContext,
+			Integration:   integration,
+			Metadata:      metadata,
+			Configuration: map[string]any{"repository": "platform/hello"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "/api/v1/repos/platform/hello", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "platform/hello", metadata.Metadata.(NodeMetadata).Repository.FullName)
+		require.Len(t, integration.WebhookRequests, 1)
+		assert.Equal(t, WebhookConfiguration{EventTypes: []string{"push"}, Repository: "platform/hello"}, integration.WebhookRequests[0])
+	})
+}
+
+func Test__OnPush__HandleWebhook(t *testing.T) {
+	trigger := &OnPush{}
+	refs := []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: "refs/heads/main"}}
+	body := `{
+		"ref": "refs/heads/main",
+		"after": "4f2d9c1e7b3a8e5d6c0f1a2b3c4d5e6f7a8b9c0d",
+		"commits": [{"added": ["api/health.go"], "modified": ["docs/README.md"], "removed": []}]
+	}`
+
+	t.Run("missing event header -> 400", func(t *testing.T) {
+		ctx := webhookRequest("push", body, map[string]any{"refs": refs}, &contexts.EventContext{})
+		ctx.Headers.Del("X-Gitea-Event")
+
+		code, _, err := trigger.HandleWebhook(ctx)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.ErrorContains(t, err, "missing X-Gitea-Event header")
+	})
+
+	t.Run("other event -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		code, _, err := trigger.HandleWebhook(webhookRequest("create", body, map[string]any{"refs": refs}, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("invalid signature -> 403", func(t *testing.T) {
+		ctx := webhookRequest("push", body, map[string]any{"refs": refs}, &contexts.EventContext{})
+		ctx.Headers.Set("X-Gitea-Signature", "bad")
+
+		code, _, err := trigger.HandleWebhook(ctx)
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("forgejo headers are accepted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		ctx := webhookRequest("push", body, map[string]any{"refs": refs}, events)
+		ctx.Headers.Set("X-Forgejo-Event", ctx.Headers.Get("X-Gitea-Event"))
+		ctx.Headers.Set("X-Forgejo-Signature", ctx.Headers.Get("X-Gitea-Signature"))
+		ctx.Headers.Del("X-Gitea-Event")
+		ctx.Headers.Del("X-Gitea-Signature")
+
+		code, _, err := trigger.HandleWebhook(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "gitea.push", events.Payloads[0].Type)
+	})
+
+	t.Run("ref does not match -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		otherRefs := []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: "refs/heads/release"}}
+		code, _, err := trigger.HandleWebhook(webhookRequest("push", body, map[string]any{"refs": otherRefs}, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("branch deletion -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		deletion := `{"ref": "refs/heads/main", "after": "0000000000000000000000000000000000000000", "commits": []}`
+		code, _, err := trigger.HandleWebhook(webhookRequest("push", deletion, map[string]any{"refs": refs}, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("no changed file matches paths -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		config := map[string]any{"refs": refs, "paths": []string{"web/**"}}
+		code, _, err := trigger.HandleWebhook(webhookRequest("push", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("excluded paths -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		config := map[string]any{"refs": refs, "paths": []string{"**", "!api/**", "!docs/**"}}
+		code, _, err := trigger.HandleWebhook(webhookRequest("push", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("changed file matches paths -> event is emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		config := map[string]any{"refs": refs, "paths": []string{"api/**", "!**/*.md"}}
+		code, _, err := trigger.HandleWebhook(webhookRequest("push", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "refs/heads/main", events.Payloads[0].Data.(map[string]any)["ref"])
+	})
+}
@@ -0,0 +1,158 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	ReleaseActionPublished = "published"
+	ReleaseActionUpdated   = "updated"
+	ReleaseActionDeleted   = "deleted"
+)
+
+type OnRelease struct{}
+
+type OnReleaseConfiguration struct {
+	Repository string   `json:"repository" mapstructure:"repository"`
+	Actions    []string `json:"actions" mapstructure:"actions"`
+}
+
+func (r *OnRelease) Name() string {
+	return "gitea.onRelease"
+}
+
+func (r *OnRelease) Label() string {
+	return "On Release"
+}
+
+func (r *OnRelease) Description() string {
+	return "Listen to release events in a Gitea repository"
+}
+
+func (r *OnRelease) Documentation() string {
+	return `The On Release trigger starts a workflow execution when a release is published, updated or deleted in a Gitea or Forgejo repository.
+
+## Use Cases
+
+- **Deployments**: Deploy a version once its release is published
+- **Release notes**: Forward release notes to chat or to a changelog
+
+## Configuration
+
+- **Repository**: Select the repository to monitor
+- **Actions**: The release actions to listen to
+
+## Event Data
+
+Each release event includes:
+- **action**: The action of the event: published, updated or deleted
+- **release**: The release, with its tag, name, notes and assets
+- **repository**: Repository information
+- **sender**: Information about who triggered the event
+
+## Webhook Setup
+
+This trigger automatically sets up a repository webhook when configured. The webhook is managed by SuperPlane and will be cleaned up when the trigger is removed.`
+}
+
+func (r *OnRelease) Icon() string {
+	return "gitea"
+}
+
+func (r *OnRelease) Color() string {
+	return "green"
+}
+
+func (r *OnRelease) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:     "actions",
+			Label:    "Actions",
+			Type:     configuration.FieldTypeMultiSelect,
+			Required: true,
+			Default:  []string{ReleaseActionPublished},
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Published", Value: ReleaseActionPublished},
+						{Label: "Updated", Value: ReleaseActionUpdated},
+						{Label: "Deleted", Value: ReleaseActionDeleted},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *OnRelease) Setup(ctx core.TriggerContext) error {
+	config := OnReleaseConfiguration{}
+	err := mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if len(config.Actions) == 0 {
+		return fmt.Errorf("at least one action is required")
+	}
+
+	repo, err := ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, config.Repository)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		EventTypes: []string{"release"},
+		Repository: repo.FullName,
+	})
+}
+
+func (r *OnRelease) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (r *OnRelease) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (r *OnRelease) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	event, status, err := verifyWebhook(ctx, "release")
+	if event == "" {
+		return status, nil, err
+	}
+
+	data := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &data)
+	if err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	config := OnReleaseConfiguration{}
+	err = mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	action, _ := data["action"].(string)
+	if !slices.Contains(config.Actions, action) {
+		return http.StatusOK, nil, nil
+	}
+
+	err = ctx.Events.Emit("gitea.release", data)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (r *OnRelease) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
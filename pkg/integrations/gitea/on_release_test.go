@@ -0,0 +1,62 @@
+package gitea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnRelease__Setup(t *testing.T) {
+	t.Run("actions are required", func(t *testing.T) {
+		err := (&OnRelease{}).Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "platform/hello"},
+		})
+
+		require.ErrorContains(t, err, "at least one action is required")
+	})
+
+	t.Run("webhook is requested", func(t *testing.T) {
+		integration := testIntegration()
+		err := (&OnRelease{}).Setup(core.TriggerContext{
+			HTTP:          &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"id":12,"name":"hello","full_name":"platform/hello","html_url":"https://git.example.com/platform/hello","default_branch":"main","owner":{"id":3,"login":"platform"}}`)}},
+			Integration:   integration,
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: map[string]any{"repository": "platform/hello", "actions": []string{ReleaseActionPublished}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integration.WebhookRequests, 1)
+		assert.Equal(t, WebhookConfiguration{EventTypes: []string{"release"}, Repository: "platform/hello"}, integration.WebhookRequests[0])
+	})
+}
+
+func Test__OnRelease__HandleWebhook(t *testing.T) {
+	trigger := &OnRelease{}
+	config := map[string]any{"actions": []string{ReleaseActionPublished}}
+
+	t.Run("action not selected -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"action": "deleted", "release": {"tag_name": "v1.2.0"}}`
+		code, _, err := trigger.HandleWebhook(webhookRequest("release", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("published release -> event is emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"action": "published", "release": {"tag_name": "v1.2.0"}}`
+		code, _, err := trigger.HandleWebhook(webhookRequest("release", body, config, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "gitea.release", events.Payloads[0].Type)
+	})
+}
@@ -0,0 +1,158 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type OnTag struct{}
+
+type OnTagConfiguration struct {
+	Repository string                    `json:"repository" mapstructure:"repository"`
+	Tags       []configuration.Predicate `json:"tags" mapstructure:"tags"`
+}
+
+func (t *OnTag) Name() string {
+	return "gitea.onTag"
+}
+
+func (t *OnTag) Label() string {
+	return "On Tag"
+}
+
+func (t *OnTag) Description() string {
+	return "Listen to tags created in a Gitea repository"
+}
+
+func (t *OnTag) Documentation() string {
+	return `The On Tag trigger starts a workflow execution when a tag is created in a Gitea or Forgejo repository.
+
+## Use Cases
+
+- **Release automation**: Build and publish artifacts when a version is tagged
+- **Deployments**: Deploy tagged versions to production
+
+## Configuration
+
+- **Repository**: Select the repository to monitor
+- **Tags**: Configure which tags to monitor. Predicates are matched against both the tag name (e.g. ` + "`v1.2.0`" + `) and the full ref (e.g. ` + "`refs/tags/v1.2.0`" + `).
+
+## Event Data
+
+Each tag event includes:
+- **ref**: The name of the tag
+- **ref_type**: Always ` + "`tag`" + `
+- **sha**: The commit the tag points to
+- **repository**: Repository information
+- **sender**: Information about who created the tag
+
+## Webhook Setup
+
+This trigger automatically sets up a repository webhook when configured. The webhook is managed by SuperPlane and will be cleaned up when the trigger is removed.`
+}
+
+func (t *OnTag) Icon() string {
+	return "gitea"
+}
+
+func (t *OnTag) Color() string {
+	return "green"
+}
+
+func (t *OnTag) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:     "tags",
+			Label:    "Tags",
+			Type:     configuration.FieldTypeAnyPredicateList,
+			Required: true,
+			Default: []map[string]any{
+				{
+					"type":  configuration.PredicateTypeMatches,
+					"value": ".*",
+				},
+			},
+			TypeOptions: &configuration.TypeOptions{
+				AnyPredicateList: &configuration.AnyPredicateListTypeOptions{
+					Operators: configuration.AllPredicateOperators,
+				},
+			},
+		},
+	}
+}
+
+func (t *OnTag) Setup(ctx core.TriggerContext) error {
+	config := OnTagConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	repo, err := ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, config.Repository)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		EventTypes: []string{"create"},
+		Repository: repo.FullName,
+	})
+}
+
+func (t *OnTag) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (t *OnTag) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnTag) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	event, status, err := verifyWebhook(ctx, "create")
+	if event == "" {
+		return status, nil, err
+	}
+
+	data := map[string]any{}
+	err = json.Unmarshal(ctx.Body, &data)
+	if err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	//
+	// Create events are also sent for new branches.
+	//
+	refType, _ := data["ref_type"].(string)
+	if refType != "tag" {
+		return http.StatusOK, nil, nil
+	}
+
+	config := OnTagConfiguration{}
+	err = mapstructure.Decode(ctx.Configuration, &config)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, _ := data["ref"].(string)
+	tag := strings.TrimPrefix(ref, "refs/tags/")
+	if !configuration.MatchesAnyPredicate(config.Tags, tag) && !configuration.MatchesAnyPredicate(config.Tags, "refs/tags/"+tag) {
+		return http.StatusOK, nil, nil
+	}
+
+	err = ctx.Events.Emit("gitea.tag", data)
+	if err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("error emitting event: %v", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnTag) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
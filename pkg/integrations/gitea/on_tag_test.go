@@ -0,0 +1,69 @@
+package gitea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnTag__Setup(t *testing.T) {
+	integration := testIntegration()
+	err := (&OnTag{}).Setup(core.TriggerContext{
+		HTTP:          &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"id":12,"name":"hello","full_name":"platform/hello","html_url":"https://git.example.com/platform/hello","default_branch":"main","owner":{"id":3,"login":"platform"}}`)}},
+		Integration:   integration,
+		Metadata:      &contexts.MetadataContext{},
+		Configuration: map[string]any{"repository": "platform/hello"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, integration.WebhookRequests, 1)
+	assert.Equal(t, WebhookConfiguration{EventTypes: []string{"create"}, Repository: "platform/hello"}, integration.WebhookRequests[0])
+}
+
+func Test__OnTag__HandleWebhook(t *testing.T) {
+	trigger := &OnTag{}
+	tags := []configuration.Predicate{{Type: configuration.PredicateTypeMatches, Value: "^v1\\."}}
+
+	t.Run("branch created -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"ref": "v1.2.0-fix", "ref_type": "branch"}`
+		code, _, err := trigger.HandleWebhook(webhookRequest("create", body, map[string]any{"tags": tags}, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("tag does not match -> ignored", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"ref": "v2.0.0", "ref_type": "tag"}`
+		code, _, err := trigger.HandleWebhook(webhookRequest("create", body, map[string]any{"tags": tags}, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("tag matches -> event is emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		body := `{"ref": "v1.2.0", "ref_type": "tag", "sha": "4f2d9c1e"}`
+		code, _, err := trigger.HandleWebhook(webhookRequest("create", body, map[string]any{"tags": tags}, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, "gitea.tag", events.Payloads[0].Type)
+	})
+
+	t.Run("full ref matches -> event is emitted", func(t *testing.T) {
+		events := &contexts.EventContext{}
+		fullRefs := []configuration.Predicate{{Type: configuration.PredicateTypeEquals, Value: "refs/tags/v1.2.0"}}
+		body := `{"ref": "v1.2.0", "ref_type": "tag"}`
+		code, _, err := trigger.HandleWebhook(webhookRequest("create", body, map[string]any{"tags": fullRefs}, events))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, 1, events.Count())
+	})
+}
@@ -0,0 +1,242 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type SetCommitStatus struct{}
+
+const (
+	CommitStatusPending = "pending"
+	CommitStatusSuccess = "success"
+	CommitStatusError   = "error"
+	CommitStatusFailure = "failure"
+	CommitStatusWarning = "warning"
+)
+
+var commitStatuses = []string{
+	CommitStatusPending,
+	CommitStatusSuccess,
+	CommitStatusError,
+	CommitStatusFailure,
+	CommitStatusWarning,
+}
+
+type SetCommitStatusSpec struct {
+	Repository  string `json:"repository" mapstructure:"repository"`
+	Commit      string `json:"commit" mapstructure:"commit"`
+	State       string `json:"state" mapstructure:"state"`
+	Context     string `json:"context" mapstructure:"context"`
+	URL         string `json:"url" mapstructure:"url"`
+	Description string `json:"description" mapstructure:"description"`
+}
+
+func (c *SetCommitStatus) Name() string {
+	return "gitea.setCommitStatus"
+}
+
+func (c *SetCommitStatus) Label() string {
+	return "Set Commit Status"
+}
+
+func (c *SetCommitStatus) Description() string {
+	return "Set the status of a Gitea commit"
+}
+
+func (c *SetCommitStatus) Documentation() string {
+	return `The Set Commit Status component reports a status on a commit in a Gitea or Forgejo repository. The status is shown on the commit and on its pull requests, and can be required by branch protection rules.
+
+## Use Cases
+
+- **External CI**: Report the result of builds and tests that run outside of Gitea Actions
+- **Deployment status**: Show on a commit whether it was deployed
+- **Branch protection**: Block merges until a SuperPlane workflow succeeds
+
+## Configuration
+
+- **Repository**: The repository of the commit
+- **Commit**: The SHA of the commit
+- **State**: Pending, success, error, failure or warning
+- **Context**: Identifies the status. Setting a status with the same context again updates it.
+- **URL** and **Description**: Where the status links to, and what is shown for it
+
+## Output
+
+The status that was set.`
+}
+
+func (c *SetCommitStatus) Icon() string {
+	return "circle-check"
+}
+
+func (c *SetCommitStatus) Color() string {
+	return "green"
+}
+
+func (c *SetCommitStatus) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *SetCommitStatus) Configuration() []configuration.Field {
+	return []configuration.Field{
+		repositoryField(),
+		{
+			Name:        "commit",
+			Label:       "Commit",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "SHA of the commit",
+			Placeholder: "{{ root().data.after }}",
+		},
+		{
+			Name:     "state",
+			Label:    "State",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  CommitStatusPending,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Pending", Value: CommitStatusPending},
+						{Label: "Success", Value: CommitStatusSuccess},
+						{Label: "Error", Value: CommitStatusError},
+						{Label: "Failure", Value: CommitStatusFailure},
+						{Label: "Warning", Value: CommitStatusWarning},
+					},
+				},
+			},
+		},
+		{
+			Name:        "context",
+			Label:       "Context",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Default:     "superplane",
+			Description: "Identifies the status. Setting a status with the same context again updates it.",
+		},
+		{
+			Name:        "url",
+			Label:       "URL",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Link to the details of the status",
+		},
+		{
+			Name:     "description",
+			Label:    "Description",
+			Type:     configuration.FieldTypeString,
+			Required: false,
+		},
+	}
+}
+
+func (c *SetCommitStatus) Setup(ctx core.SetupContext) error {
+	spec, err := decodeSetCommitStatusSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	_, err = ensureRepoInMetadata(ctx.HTTP, ctx.Metadata, ctx.Integration, spec.Repository)
+	return err
+}
+
+func (c *SetCommitStatus) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeSetCommitStatusSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if spec.Commit == "" {
+		return fmt.Errorf("commit is required")
+	}
+
+	owner, repo, err := splitRepository(spec.Repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	status, err := client.CreateCommitStatus(owner, repo, spec.Commit, CommitStatusRequest{
+		State:       spec.State,
+		Context:     spec.Context,
+		TargetURL:   spec.URL,
+		Description: spec.Description,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to set status of commit %s: %w", spec.Commit, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"gitea.commitStatus",
+		[]any{map[string]any{
+			"id":          status.ID,
+			"commit":      spec.Commit,
+			"state":       status.State,
+			"context":     status.Context,
+			"url":         status.TargetURL,
+			"description": status.Description,
+			"creator":     status.Creator.Login,
+			"created_at":  status.CreatedAt,
+		}},
+	)
+}
+
+func decodeSetCommitStatusSpec(value any) (SetCommitStatusSpec, error) {
+	spec := SetCommitStatusSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(spec.Repository) == "" {
+		return spec, fmt.Errorf("repository is required")
+	}
+
+	spec.Commit = strings.TrimSpace(spec.Commit)
+	if spec.State == "" {
+		spec.State = CommitStatusPending
+	}
+
+	if !slices.Contains(commitStatuses, spec.State) {
+		return spec, fmt.Errorf("invalid state %q", spec.State)
+	}
+
+	spec.Context = strings.TrimSpace(spec.Context)
+	if spec.Context == "" {
+		return spec, fmt.Errorf("context is required")
+	}
+
+	return spec, nil
+}
+
+func (c *SetCommitStatus) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *SetCommitStatus) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *SetCommitStatus) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *SetCommitStatus) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *SetCommitStatus) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
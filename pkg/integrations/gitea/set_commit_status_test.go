@@ -0,0 +1,84 @@
+package gitea
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__SetCommitStatus__Setup(t *testing.T) {
+	err := (&SetCommitStatus{}).Setup(core.SetupContext{
+		HTTP:          &contexts.HTTPContext{},
+		Integration:   testIntegration(),
+		Metadata:      &contexts.MetadataContext{},
+		Configuration: map[string]any{"repository": "platform/hello", "state": "running", "context": "superplane"},
+	})
+
+	require.ErrorContains(t, err, `invalid state "running"`)
+}
+
+func Test__SetCommitStatus__Execute(t *testing.T) {
+	component := &SetCommitStatus{}
+
+	t.Run("commit is required", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"repository": "platform/hello", "commit": " ", "state": "success", "context": "superplane"},
+			HTTP:           &contexts.HTTPContext{},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "commit is required")
+	})
+
+	t.Run("status is set", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusCreated, `{
+				"id": 912,
+				"status": "success",
+				"context": "superplane",
+				"target_url": "https://app.superplane.com/canvases/deploy",
+				"description": "Deployed to staging",
+				"creator": {"login": "superplane"},
+				"created_at": "2026-10-19T10:24:07Z"
+			}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"repository":  "platform/hello",
+				"commit":      "4f2d9c1e7b3a8e5d6c0f1a2b3c4d5e6f7a8b9c0d",
+				"state":       "success",
+				"context":     "superplane",
+				"url":         "https://app.superplane.com/canvases/deploy",
+				"description": "Deployed to staging",
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "/api/v1/repos/platform/hello/statuses/4f2d9c1e7b3a8e5d6c0f1a2b3c4d5e6f7a8b9c0d", httpContext.Requests[0].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"state": "success",
+			"context": "superplane",
+			"target_url": "https://app.superplane.com/canvases/deploy",
+			"description": "Deployed to staging"
+		}`, string(body))
+
+		assert.Equal(t, "gitea.commitStatus", executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "success", payload["state"])
+		assert.Equal(t, "superplane", payload["creator"])
+	})
+}
@@ -0,0 +1,48 @@
+package gitea
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func testIntegration() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{"baseUrl": "https://git.example.com", "accessToken": "token"},
+		Metadata: Metadata{
+			BaseURL: "https://git.example.com",
+			Version: "1.22.3",
+			User:    &UserMetadata{ID: 1, Login: "superplane"},
+		},
+	}
+}
+
+// webhookRequest signs the body like Gitea does, and sends the headers
+// of the given event. Forgejo sends the same headers with its own prefix.
+func webhookRequest(event, body string, configuration map[string]any, events *contexts.EventContext) core.WebhookRequestContext {
+	headers := http.Header{}
+	headers.Set("X-Gitea-Event", event)
+	headers.Set("X-Gitea-Signature", crypto.Sign([]byte("test-secret"), []byte(body)))
+
+	return core.WebhookRequestContext{
+		Body:          []byte(body),
+		Headers:       headers,
+		Webhook:       &contexts.NodeWebhookContext{Secret: "test-secret"},
+		Configuration: configuration,
+		Events:        events,
+		Logger:        logrus.NewEntry(logrus.New()),
+	}
+}
@@ -0,0 +1,112 @@
+package gitea
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type WebhookConfiguration struct {
+	EventTypes []string `json:"eventTypes" mapstructure:"eventTypes"`
+	Repository string   `json:"repository" mapstructure:"repository"`
+}
+
+type WebhookMetadata struct {
+	ID int64 `json:"id" mapstructure:"id"`
+}
+
+type GiteaWebhookHandler struct{}
+
+func (h *GiteaWebhookHandler) CompareConfig(a, b any) (bool, error) {
+	configA := WebhookConfiguration{}
+	if err := mapstructure.Decode(a, &configA); err != nil {
+		return false, err
+	}
+
+	configB := WebhookConfiguration{}
+	if err := mapstructure.Decode(b, &configB); err != nil {
+		return false, err
+	}
+
+	if configA.Repository != configB.Repository {
+		return false, nil
+	}
+
+	// Check if A contains all events from B (A is superset of B)
+	// This allows webhook sharing when existing webhook has more events than needed
+	for _, eventType := range configB.EventTypes {
+		if !slices.Contains(configA.EventTypes, eventType) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (h *GiteaWebhookHandler) Merge(current, requested any) (any, bool, error) {
+	return current, false, nil
+}
+
+func (h *GiteaWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
+	config := WebhookConfiguration{}
+	if err := mapstructure.Decode(ctx.Webhook.GetConfiguration(), &config); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook configuration: %w", err)
+	}
+
+	owner, repo, err := splitRepository(config.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhook secret: %w", err)
+	}
+
+	hook, err := client.CreateHook(owner, repo, ctx.Webhook.GetURL(), string(secret), config.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	return WebhookMetadata{ID: hook.ID}, nil
+}
+
+func (h *GiteaWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
+	metadata := WebhookMetadata{}
+	if err := mapstructure.Decode(ctx.Webhook.GetMetadata(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode webhook metadata: %w", err)
+	}
+
+	// If the webhook was never created (Setup failed), there's nothing to clean up.
+	if metadata.ID == 0 {
+		return nil
+	}
+
+	config := WebhookConfiguration{}
+	if err := mapstructure.Decode(ctx.Webhook.GetConfiguration(), &config); err != nil {
+		return fmt.Errorf("failed to decode webhook configuration: %w", err)
+	}
+
+	owner, repo, err := splitRepository(config.Repository)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteHook(owner, repo, metadata.ID); err != nil && !IsNotFound(err) {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package gitea
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	contexts "github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GiteaWebhookHandler__CompareConfig(t *testing.T) {
+	h := &GiteaWebhookHandler{}
+
+	testCases := []struct {
+		name        string
+		configA     any
+		configB     any
+		expectEqual bool
+	}{
+		{
+			name:        "same events",
+			configA:     WebhookConfiguration{EventTypes: []string{"push"}, Repository: "platform/hello"},
+			configB:     WebhookConfiguration{EventTypes: []string{"push"}, Repository: "platform/hello"},
+			expectEqual: true,
+		},
+		{
+			name:        "subset of events",
+			configA:     WebhookConfiguration{EventTypes: []string{"issue_comment", "pull_request_comment"}, Repository: "platform/hello"},
+			configB:     WebhookConfiguration{EventTypes: []string{"issue_comment"}, Repository: "platform/hello"},
+			expectEqual: true,
+		},
+		{
+			name:        "missing event",
+			configA:     WebhookConfiguration{EventTypes: []string{"push"}, Repository: "platform/hello"},
+			configB:     WebhookConfiguration{EventTypes: []string{"release"}, Repository: "platform/hello"},
+			expectEqual: false,
+		},
+		{
+			name:        "different repositories",
+			configA:     WebhookConfiguration{EventTypes: []string{"push"}, Repository: "platform/hello"},
+			configB:     WebhookConfiguration{EventTypes: []string{"push"}, Repository: "platform/world"},
+			expectEqual: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			equal, err := h.CompareConfig(tc.configA, tc.configB)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectEqual, equal)
+		})
+	}
+}
+
+func Test__GiteaWebhookHandler__Setup(t *testing.T) {
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+		jsonResponse(http.StatusCreated, `{"id": 31, "events": ["push"], "active": true}`),
+	}}
+
+	metadata, err := (&GiteaWebhookHandler{}).Setup(core.WebhookHandlerContext{
+		HTTP:        httpContext,
+		Integration: testIntegration(),
+		Webhook: &contexts.WebhookContext{
+			URL:           "https://superplane.example.com/api/v1/webhooks/123",
+			Secret:        []byte("webhook-secret"),
+			Configuration: WebhookConfiguration{EventTypes: []string{"push"}, Repository: "platform/hello"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, WebhookMetadata{ID: 31}, metadata)
+
+	require.Len(t, httpContext.Requests, 1)
+	assert.Equal(t, http.MethodPost, httpContext.Requests[0].Method)
+	assert.Equal(t, "/api/v1/repos/platform/hello/hooks", httpContext.Requests[0].URL.Path)
+	body, err := io.ReadAll(httpContext.Requests[0].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "gitea",
+		"config": {
+			"url": "https://superplane.example.com/api/v1/webhooks/123",
+			"content_type": "json",
+			"secret": "webhook-secret"
+		},
+		"events": ["push"],
+		"active": true,
+		"branch_filter": "*"
+	}`, string(body))
+}
+
+func Test__GiteaWebhookHandler__Cleanup(t *testing.T) {
+	h := &GiteaWebhookHandler{}
+
+	t.Run("webhook was never created -> nothing to delete", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		err := h.Cleanup(core.WebhookHandlerContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Webhook:     &contexts.WebhookContext{Metadata: map[string]any{}},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, httpContext.Requests)
+	})
+
+	t.Run("webhook is deleted", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusNoContent, ``)}}
+		err := h.Cleanup(core.WebhookHandlerContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Webhook: &contexts.WebhookContext{
+				Metadata:      WebhookMetadata{ID: 31},
+				Configuration: WebhookConfiguration{EventTypes: []string{"push"}, Repository: "platform/hello"},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodDelete, httpContext.Requests[0].Method)
+		assert.Equal(t, "/api/v1/repos/platform/hello/hooks/31", httpContext.Requests[0].URL.Path)
+	})
+}
@@ -0,0 +1,69 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/integrations/github/common"
+)
+
+// ResolveGitRemote uses the same access token exposed as GITHUB_TOKEN.
+// GitHub accepts both app installation tokens and PATs as the password
+// for the x-access-token user.
+func (g *GitHub) ResolveGitRemote(ctx core.IntegrationGitRemoteContext, repository string) (*core.GitRemote, error) {
+	owner, name, err := gitRemoteOwnerAndName(ctx.Integration, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := resolveAccessToken(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.GitRemote{
+		URL:      fmt.Sprintf("https://github.com/%s/%s.git", owner, name),
+		Username: "x-access-token",
+		Password: token,
+	}, nil
+}
+
+func gitRemoteOwnerAndName(integration core.IntegrationContext, repository string) (string, string, error) {
+	repository = strings.TrimSuffix(strings.Trim(strings.TrimSpace(repository), "/"), ".git")
+	if repository == "" {
+		return "", "", fmt.Errorf("repository is required")
+	}
+
+	parts := strings.Split(repository, "/")
+	switch len(parts) {
+	case 2:
+		return parts[0], parts[1], nil
+	case 1:
+		owner, err := integrationOwner(integration)
+		if err != nil {
+			return "", "", err
+		}
+		return owner, parts[0], nil
+	default:
+		return "", "", fmt.Errorf("invalid repository %q: expected owner/repo", repository)
+	}
+}
+
+func integrationOwner(integration core.IntegrationContext) (string, error) {
+	if !integration.LegacySetup() {
+		return integration.Properties().GetString(common.PropertyOwner)
+	}
+
+	var metadata common.Metadata
+	if err := mapstructure.Decode(integration.GetMetadata(), &metadata); err != nil {
+		return "", fmt.Errorf("failed to decode metadata: %v", err)
+	}
+
+	if metadata.Owner == "" {
+		return "", fmt.Errorf("integration owner is not set")
+	}
+
+	return metadata.Owner, nil
+}
@@ -0,0 +1,35 @@
+package gitlab
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// ResolveGitRemote returns the HTTPS clone URL for a project path.
+// GitLab accepts personal and OAuth access tokens as the password
+// for the oauth2 user.
+func (g *GitLab) ResolveGitRemote(ctx core.IntegrationGitRemoteContext, repository string) (*core.GitRemote, error) {
+	project := strings.TrimSuffix(strings.Trim(strings.TrimSpace(repository), "/"), ".git")
+	if project == "" || !strings.Contains(project, "/") {
+		return nil, fmt.Errorf("invalid project %q: expected the full project path, e.g. group/project", repository)
+	}
+
+	authType, err := ctx.Integration.GetConfig("authType")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authType: %v", err)
+	}
+
+	token, err := getAuthToken(ctx.Integration, string(authType))
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, _ := ctx.Integration.GetConfig("baseUrl")
+	return &core.GitRemote{
+		URL:      fmt.Sprintf("%s/%s.git", normalizeBaseURL(string(baseURL)), project),
+		Username: "oauth2",
+		Password: token,
+	}, nil
+}
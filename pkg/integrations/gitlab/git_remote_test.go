@@ -0,0 +1,36 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GitLab__ResolveGitRemote(t *testing.T) {
+	g := &GitLab{}
+	ctx := core.IntegrationGitRemoteContext{
+		Integration: &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"authType":    AuthTypePersonalAccessToken,
+				"accessToken": "pat",
+				"baseUrl":     "https://gitlab.example.com/",
+			},
+		},
+	}
+
+	t.Run("project path", func(t *testing.T) {
+		remote, err := g.ResolveGitRemote(ctx, "/platform/canvases.git")
+		require.NoError(t, err)
+		assert.Equal(t, "https://gitlab.example.com/platform/canvases.git", remote.URL)
+		assert.Equal(t, "oauth2", remote.Username)
+		assert.Equal(t, "pat", remote.Password)
+	})
+
+	t.Run("project without group", func(t *testing.T) {
+		_, err := g.ResolveGitRemote(ctx, "canvases")
+		require.ErrorContains(t, err, "group/project")
+	})
+}
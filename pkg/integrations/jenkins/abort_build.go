@@ -0,0 +1,147 @@
+package jenkins
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const AbortBuildPayloadType = "jenkins.build.aborted"
+
+type AbortBuild struct{}
+
+type AbortBuildSpec struct {
+	Job         string `json:"job" mapstructure:"job"`
+	BuildNumber string `json:"buildNumber" mapstructure:"buildNumber"`
+}
+
+func (a *AbortBuild) Name() string {
+	return "jenkins.abortBuild"
+}
+
+func (a *AbortBuild) Label() string {
+	return "Abort Build"
+}
+
+func (a *AbortBuild) Description() string {
+	return "Abort a running Jenkins build"
+}
+
+func (a *AbortBuild) Documentation() string {
+	return `The Abort Build component aborts a running Jenkins build, like the stop button of the build page does.
+
+## Use Cases
+
+- **Superseded builds**: Abort a build when a newer commit arrives
+- **Incident response**: Stop deployment jobs during an incident
+
+## Configuration
+
+- **Job**: The job of the build
+- **Build Number**: The number of the build to abort
+
+## Output
+
+The build, as it was when the abort was requested. Builds that already finished are left as they are.`
+}
+
+func (a *AbortBuild) Icon() string {
+	return "jenkins"
+}
+
+func (a *AbortBuild) Color() string {
+	return "gray"
+}
+
+func (a *AbortBuild) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (a *AbortBuild) Configuration() []configuration.Field {
+	return []configuration.Field{
+		jobField(),
+		buildNumberField(),
+	}
+}
+
+func (a *AbortBuild) Setup(ctx core.SetupContext) error {
+	_, _, err := decodeAbortBuildSpec(ctx.Configuration, false)
+	return err
+}
+
+func (a *AbortBuild) Execute(ctx core.ExecutionContext) error {
+	spec, number, err := decodeAbortBuildSpec(ctx.Configuration, true)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	build, err := client.GetBuild(spec.Job, number)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get build %s #%d: %v", spec.Job, number, err))
+	}
+
+	aborted := false
+	if build.Building {
+		if err := client.StopBuild(spec.Job, number); err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to abort build %s #%d: %v", spec.Job, number, err))
+		}
+
+		aborted = true
+	}
+
+	payload := buildPayload(spec.Job, build)
+	payload["aborted"] = aborted
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, AbortBuildPayloadType, []any{payload})
+}
+
+func (a *AbortBuild) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (a *AbortBuild) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (a *AbortBuild) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (a *AbortBuild) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (a *AbortBuild) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeAbortBuildSpec(value any, validateBuild bool) (AbortBuildSpec, int64, error) {
+	spec := AbortBuildSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return AbortBuildSpec{}, 0, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	job, err := validateJob(spec.Job)
+	if err != nil {
+		return AbortBuildSpec{}, 0, err
+	}
+
+	spec.Job = job
+	if !validateBuild {
+		return spec, 0, nil
+	}
+
+	number, err := validateBuildNumber(spec.BuildNumber)
+	if err != nil {
+		return AbortBuildSpec{}, 0, err
+	}
+
+	return spec, number, nil
+}
@@ -0,0 +1,106 @@
+package jenkins
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__AbortBuild__Setup(t *testing.T) {
+	component := &AbortBuild{}
+
+	t.Run("missing job -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"buildNumber": "142"},
+		})
+
+		require.Error(t, err)
+	})
+
+	t.Run("build number expression -> ok", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"job": "platform/api", "buildNumber": "{{ $[\"Trigger Build\"].data.number }}"},
+		})
+
+		require.NoError(t, err)
+	})
+}
+
+func Test__AbortBuild__Execute(t *testing.T) {
+	component := &AbortBuild{}
+
+	t.Run("running build -> stops it", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"number": 142, "building": true, "url": "https://jenkins.example.com/job/platform/job/api/142/"}`),
+			jsonResponse(http.StatusNotFound, `Not Found`),
+			jsonResponse(http.StatusOK, ``),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"job": "platform/api", "buildNumber": "#142"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 3)
+		assert.Equal(t, http.MethodPost, httpContext.Requests[2].Method)
+		assert.Equal(t, "/job/platform/job/api/142/stop", httpContext.Requests[2].URL.Path)
+
+		assert.Equal(t, AbortBuildPayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, payload["aborted"])
+		assert.Equal(t, "platform/api", payload["job"])
+	})
+
+	t.Run("finished build -> not stopped", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"number": 142, "building": false, "result": "SUCCESS"}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"job": "platform/api", "buildNumber": "142"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, payload["aborted"])
+		assert.Equal(t, "SUCCESS", payload["result"])
+	})
+
+	t.Run("invalid build number -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"job": "platform/api", "buildNumber": "latest"},
+			HTTP:           &contexts.HTTPContext{},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, `invalid build number "latest"`)
+	})
+
+	t.Run("build not found -> fails", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"job": "platform/api", "buildNumber": "142"},
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusNotFound, `Not Found`)}},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to get build platform/api #142")
+	})
+}
@@ -0,0 +1,473 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+// maxConsoleBytes caps how much of a console log is read,
+// since only its tail is kept.
+const maxConsoleBytes = 5 * 1024 * 1024
+
+type Client struct {
+	BaseURL  string
+	Username string
+	Token    string
+	http     core.HTTPContext
+
+	crumb        *crumb
+	crumbFetched bool
+}
+
+type crumb struct {
+	Field   string
+	Value   string
+	Cookies []*http.Cookie
+}
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with %d: %s", e.StatusCode, e.Body)
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+type WhoAmI struct {
+	Name          string `json:"name"`
+	Anonymous     bool   `json:"anonymous"`
+	Authenticated bool   `json:"authenticated"`
+}
+
+type Job struct {
+	Class    string `json:"_class"`
+	Name     string `json:"name"`
+	FullName string `json:"fullName"`
+	URL      string `json:"url"`
+	Jobs     []Job  `json:"jobs,omitempty"`
+}
+
+// IsFolder tells folders and multibranch projects, which
+// can't be built, apart from the jobs inside of them.
+func (j Job) IsFolder() bool {
+	return j.Jobs != nil || strings.Contains(j.Class, "Folder")
+}
+
+type JobDetails struct {
+	FullName  string `json:"fullName"`
+	URL       string `json:"url"`
+	Buildable bool   `json:"buildable"`
+	Property  []struct {
+		ParameterDefinitions []struct {
+			Name string `json:"name"`
+		} `json:"parameterDefinitions,omitempty"`
+	} `json:"property"`
+}
+
+// IsParameterized tells if builds of the job take parameters,
+// which changes the endpoint builds are triggered with.
+func (j *JobDetails) IsParameterized() bool {
+	for _, property := range j.Property {
+		if len(property.ParameterDefinitions) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+type QueueItem struct {
+	ID         int64  `json:"id"`
+	Cancelled  bool   `json:"cancelled"`
+	Blocked    bool   `json:"blocked"`
+	Stuck      bool   `json:"stuck"`
+	Why        string `json:"why"`
+	Executable *struct {
+		Number int64  `json:"number"`
+		URL    string `json:"url"`
+	} `json:"executable"`
+}
+
+type Build struct {
+	Number            int64  `json:"number"`
+	URL               string `json:"url"`
+	DisplayName       string `json:"displayName"`
+	FullDisplayName   string `json:"fullDisplayName"`
+	Result            string `json:"result"`
+	Building          bool   `json:"building"`
+	Duration          int64  `json:"duration"`
+	EstimatedDuration int64  `json:"estimatedDuration"`
+	Timestamp         int64  `json:"timestamp"`
+}
+
+type TestReport struct {
+	FailCount int64       `json:"failCount"`
+	PassCount int64       `json:"passCount"`
+	SkipCount int64       `json:"skipCount"`
+	Duration  float64     `json:"duration"`
+	Suites    []TestSuite `json:"suites"`
+}
+
+type TestSuite struct {
+	Name  string     `json:"name"`
+	Cases []TestCase `json:"cases"`
+}
+
+type TestCase struct {
+	ClassName    string  `json:"className"`
+	Name         string  `json:"name"`
+	Status       string  `json:"status"`
+	Duration     float64 `json:"duration"`
+	ErrorDetails string  `json:"errorDetails"`
+}
+
+func NewClient(httpClient core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("no integration context")
+	}
+
+	baseURL, err := ctx.GetConfig("url")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedURL := strings.TrimRight(strings.TrimSpace(string(baseURL)), "/")
+	if trimmedURL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+
+	username, err := ctx.GetConfig("username")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedUsername := strings.TrimSpace(string(username))
+	if trimmedUsername == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+
+	token, err := ctx.GetConfig("apiToken")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedToken := strings.TrimSpace(string(token))
+	if trimmedToken == "" {
+		return nil, fmt.Errorf("apiToken is required")
+	}
+
+	return &Client{
+		BaseURL:  trimmedURL,
+		Username: trimmedUsername,
+		Token:    trimmedToken,
+		http:     httpClient,
+	}, nil
+}
+
+// JobPath turns the full name of a job, like "team/app/main",
+// into its path, like "/job/team/job/app/job/main".
+func JobPath(fullName string) string {
+	parts := strings.Split(strings.Trim(fullName, "/"), "/")
+	var builder strings.Builder
+	for _, part := range parts {
+		builder.WriteString("/job/")
+		builder.WriteString(url.PathEscape(part))
+	}
+
+	return builder.String()
+}
+
+func buildPath(job string, number int64) string {
+	return JobPath(job) + "/" + strconv.FormatInt(number, 10)
+}
+
+func (c *Client) WhoAmI() (*WhoAmI, error) {
+	_, body, err := c.execRequest(http.MethodGet, "/whoAmI/api/json", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	whoAmI := WhoAmI{}
+	if err := json.Unmarshal(body, &whoAmI); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user response: %w", err)
+	}
+
+	return &whoAmI, nil
+}
+
+// ListJobs lists jobs up to three folders deep.
+func (c *Client) ListJobs() ([]Job, error) {
+	fields := "_class,name,fullName,url"
+	query := url.Values{}
+	query.Set("tree", fmt.Sprintf("jobs[%s,jobs[%s,jobs[%s,jobs[%s]]]]", fields, fields, fields, fields))
+
+	_, body, err := c.execRequest(http.MethodGet, "/api/json", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var root struct {
+		Jobs []Job `json:"jobs"`
+	}
+
+	if err := json.Unmarshal(body, &root); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal jobs response: %w", err)
+	}
+
+	jobs := []Job{}
+	var collect func([]Job)
+	collect = func(list []Job) {
+		for _, job := range list {
+			if job.IsFolder() {
+				collect(job.Jobs)
+				continue
+			}
+
+			jobs = append(jobs, job)
+		}
+	}
+
+	collect(root.Jobs)
+	return jobs, nil
+}
+
+func (c *Client) GetJob(job string) (*JobDetails, error) {
+	query := url.Values{}
+	query.Set("tree", "fullName,url,buildable,property[parameterDefinitions[name]]")
+
+	_, body, err := c.execRequest(http.MethodGet, JobPath(job)+"/api/json", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	details := JobDetails{}
+	if err := json.Unmarshal(body, &details); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job response: %w", err)
+	}
+
+	return &details, nil
+}
+
+// TriggerBuild queues a build, and returns the ID of the queue item.
+func (c *Client) TriggerBuild(job string, parameterized bool, parameters map[string]string) (int64, error) {
+	path := JobPath(job) + "/build"
+	var body io.Reader
+	if parameterized {
+		path = JobPath(job) + "/buildWithParameters"
+		form := url.Values{}
+		for name, value := range parameters {
+			form.Set(name, value)
+		}
+
+		body = strings.NewReader(form.Encode())
+	}
+
+	res, _, err := c.execRequest(http.MethodPost, path, nil, body)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseQueueItemID(res.Header.Get("Location"))
+}
+
+func parseQueueItemID(location string) (int64, error) {
+	_, id, found := strings.Cut(strings.TrimRight(location, "/"), "/queue/item/")
+	if !found {
+		return 0, fmt.Errorf("no queue item in response, location is %q", location)
+	}
+
+	queueID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid queue item %q: %w", id, err)
+	}
+
+	return queueID, nil
+}
+
+func (c *Client) GetQueueItem(id int64) (*QueueItem, error) {
+	_, body, err := c.execRequest(http.MethodGet, fmt.Sprintf("/queue/item/%d/api/json", id), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	item := QueueItem{}
+	if err := json.Unmarshal(body, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal queue item response: %w", err)
+	}
+
+	return &item, nil
+}
+
+func (c *Client) CancelQueueItem(id int64) error {
+	query := url.Values{}
+	query.Set("id", strconv.FormatInt(id, 10))
+	_, _, err := c.execRequest(http.MethodPost, "/queue/cancelItem", query, nil)
+	return err
+}
+
+func (c *Client) GetBuild(job string, number int64) (*Build, error) {
+	query := url.Values{}
+	query.Set("tree", "number,url,displayName,fullDisplayName,result,building,duration,estimatedDuration,timestamp")
+
+	_, body, err := c.execRequest(http.MethodGet, buildPath(job, number)+"/api/json", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	build := Build{}
+	if err := json.Unmarshal(body, &build); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal build response: %w", err)
+	}
+
+	return &build, nil
+}
+
+func (c *Client) GetConsoleText(job string, number int64) (string, error) {
+	_, body, err := c.execRequest(http.MethodGet, buildPath(job, number)+"/consoleText", nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+func (c *Client) GetTestReport(job string, number int64) (*TestReport, error) {
+	query := url.Values{}
+	query.Set("tree", "failCount,passCount,skipCount,duration,suites[name,cases[className,name,status,duration,errorDetails]]")
+
+	_, body, err := c.execRequest(http.MethodGet, buildPath(job, number)+"/testReport/api/json", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := TestReport{}
+	if err := json.Unmarshal(body, &report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal test report response: %w", err)
+	}
+
+	return &report, nil
+}
+
+func (c *Client) StopBuild(job string, number int64) error {
+	_, _, err := c.execRequest(http.MethodPost, buildPath(job, number)+"/stop", nil, nil)
+	return err
+}
+
+// getCrumb fetches a CSRF crumb for POST requests once per client.
+// Jenkins instances with CSRF protection disabled return 404.
+func (c *Client) getCrumb() (*crumb, error) {
+	if c.crumbFetched {
+		return c.crumb, nil
+	}
+
+	res, body, err := c.execRequest(http.MethodGet, "/crumbIssuer/api/json", nil, nil)
+	if err != nil {
+		if IsNotFound(err) {
+			c.crumbFetched = true
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get crumb: %w", err)
+	}
+
+	var response struct {
+		Crumb             string `json:"crumb"`
+		CrumbRequestField string `json:"crumbRequestField"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal crumb response: %w", err)
+	}
+
+	//
+	// Crumbs are bound to the web session they were issued
+	// for, so the session cookie goes along with the crumb.
+	//
+	c.crumb = &crumb{
+		Field:   response.CrumbRequestField,
+		Value:   response.Crumb,
+		Cookies: res.Cookies(),
+	}
+
+	c.crumbFetched = true
+	return c.crumb, nil
+}
+
+func (c *Client) execRequest(method, path string, query url.Values, body io.Reader) (*http.Response, []byte, error) {
+	var requestCrumb *crumb
+	if method != http.MethodGet {
+		var err error
+		requestCrumb, err = c.getCrumb()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	endpoint := c.BaseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.SetBasicAuth(c.Username, c.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	if requestCrumb != nil && requestCrumb.Field != "" {
+		req.Header.Set(requestCrumb.Field, requestCrumb.Value)
+		for _, cookie := range requestCrumb.Cookies {
+			req.AddCookie(cookie)
+		}
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(res.Body, maxConsoleBytes))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return nil, nil, &APIError{StatusCode: res.StatusCode, Body: apiErrorMessage(res, responseBody)}
+	}
+
+	return res, responseBody, nil
+}
+
+// apiErrorMessage keeps error messages short, since
+// Jenkins answers errors with full HTML pages.
+func apiErrorMessage(res *http.Response, body []byte) string {
+	if strings.Contains(res.Header.Get("Content-Type"), "text/html") {
+		return http.StatusText(res.StatusCode)
+	}
+
+	message := strings.TrimSpace(string(body))
+	if len(message) > 500 {
+		message = message[:500]
+	}
+
+	return message
+}
@@ -0,0 +1,104 @@
+package jenkins
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+const (
+	SuccessOutputChannel = "success"
+	FailedOutputChannel  = "failed"
+
+	BuildResultSuccess  = "SUCCESS"
+	BuildResultUnstable = "UNSTABLE"
+	BuildResultFailure  = "FAILURE"
+	BuildResultNotBuilt = "NOT_BUILT"
+	BuildResultAborted  = "ABORTED"
+)
+
+var buildResults = []string{
+	BuildResultSuccess,
+	BuildResultUnstable,
+	BuildResultFailure,
+	BuildResultNotBuilt,
+	BuildResultAborted,
+}
+
+func jobField() configuration.Field {
+	return configuration.Field{
+		Name:        "job",
+		Label:       "Job",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    true,
+		Description: "Jenkins job. Jobs in folders use their full name, like team/app.",
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type: ResourceTypeJob,
+			},
+		},
+	}
+}
+
+func buildNumberField() configuration.Field {
+	return configuration.Field{
+		Name:        "buildNumber",
+		Label:       "Build Number",
+		Type:        configuration.FieldTypeString,
+		Required:    true,
+		Placeholder: "{{ $['Trigger Build'].data.number }}",
+		Description: "Number of the build, like the number output of Trigger Build",
+	}
+}
+
+func validateJob(value string) (string, error) {
+	job := strings.Trim(strings.TrimSpace(value), "/")
+	if job == "" {
+		return "", fmt.Errorf("job is required")
+	}
+
+	return job, nil
+}
+
+func validateBuildNumber(value string) (int64, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if trimmed == "" {
+		return 0, fmt.Errorf("buildNumber is required")
+	}
+
+	number, err := strconv.ParseInt(trimmed, 10, 64)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid build number %q", value)
+	}
+
+	return number, nil
+}
+
+func buildPayload(job string, build *Build) map[string]any {
+	return map[string]any{
+		"job":         job,
+		"number":      build.Number,
+		"displayName": build.DisplayName,
+		"url":         build.URL,
+		"result":      build.Result,
+		"building":    build.Building,
+		"duration":    build.Duration,
+		"timestamp":   build.Timestamp,
+	}
+}
+
+func buildFailed(result string) bool {
+	return slices.Contains([]string{
+		BuildResultUnstable,
+		BuildResultFailure,
+		BuildResultNotBuilt,
+		BuildResultAborted,
+	}, result)
+}
+
+func intPtr(value int) *int {
+	return &value
+}
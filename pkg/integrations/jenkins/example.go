@@ -0,0 +1,78 @@
+package jenkins
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_data_on_build_completed.json
+var exampleDataOnBuildCompletedBytes []byte
+
+//go:embed example_output_trigger_build.json
+var exampleOutputTriggerBuildBytes []byte
+
+//go:embed example_output_get_console_log.json
+var exampleOutputGetConsoleLogBytes []byte
+
+//go:embed example_output_get_test_report.json
+var exampleOutputGetTestReportBytes []byte
+
+//go:embed example_output_abort_build.json
+var exampleOutputAbortBuildBytes []byte
+
+var exampleDataOnBuildCompletedOnce sync.Once
+var exampleDataOnBuildCompleted map[string]any
+
+var exampleOutputTriggerBuildOnce sync.Once
+var exampleOutputTriggerBuild map[string]any
+
+var exampleOutputGetConsoleLogOnce sync.Once
+var exampleOutputGetConsoleLog map[string]any
+
+var exampleOutputGetTestReportOnce sync.Once
+var exampleOutputGetTestReport map[string]any
+
+var exampleOutputAbortBuildOnce sync.Once
+var exampleOutputAbortBuild map[string]any
+
+func (t *OnBuildCompleted) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnBuildCompletedOnce,
+		exampleDataOnBuildCompletedBytes,
+		&exampleDataOnBuildCompleted,
+	)
+}
+
+func (t *TriggerBuild) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputTriggerBuildOnce,
+		exampleOutputTriggerBuildBytes,
+		&exampleOutputTriggerBuild,
+	)
+}
+
+func (g *GetConsoleLog) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputGetConsoleLogOnce,
+		exampleOutputGetConsoleLogBytes,
+		&exampleOutputGetConsoleLog,
+	)
+}
+
+func (g *GetTestReport) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputGetTestReportOnce,
+		exampleOutputGetTestReportBytes,
+		&exampleOutputGetTestReport,
+	)
+}
+
+func (a *AbortBuild) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputAbortBuildOnce,
+		exampleOutputAbortBuildBytes,
+		&exampleOutputAbortBuild,
+	)
+}
@@ -0,0 +1,192 @@
+package jenkins
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	GetConsoleLogPayloadType = "jenkins.build.consoleLog"
+
+	DefaultConsoleLogLines = 100
+	MaxConsoleLogLines     = 2000
+)
+
+type GetConsoleLog struct{}
+
+type GetConsoleLogSpec struct {
+	Job         string `json:"job" mapstructure:"job"`
+	BuildNumber string `json:"buildNumber" mapstructure:"buildNumber"`
+	Lines       int    `json:"lines" mapstructure:"lines"`
+}
+
+func (g *GetConsoleLog) Name() string {
+	return "jenkins.getConsoleLog"
+}
+
+func (g *GetConsoleLog) Label() string {
+	return "Get Console Log"
+}
+
+func (g *GetConsoleLog) Description() string {
+	return "Get the last lines of the console log of a Jenkins build"
+}
+
+func (g *GetConsoleLog) Documentation() string {
+	return `The Get Console Log component reads the tail of the console log of a Jenkins build.
+
+## Use Cases
+
+- **Failure reports**: Post the end of the log of a failed build to Slack or an issue
+- **AI triage**: Pass the log to an AI component to summarize why a build failed
+
+## Configuration
+
+- **Job**: The job of the build
+- **Build Number**: The number of the build, like the number output of **Trigger Build**
+- **Lines**: How many lines to read from the end of the log. Defaults to 100, and at most 2000.
+
+## Output
+
+The last lines of the log, and whether earlier lines were left out.`
+}
+
+func (g *GetConsoleLog) Icon() string {
+	return "jenkins"
+}
+
+func (g *GetConsoleLog) Color() string {
+	return "gray"
+}
+
+func (g *GetConsoleLog) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (g *GetConsoleLog) Configuration() []configuration.Field {
+	return []configuration.Field{
+		jobField(),
+		buildNumberField(),
+		{
+			Name:        "lines",
+			Label:       "Lines",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultConsoleLogLines,
+			Description: "How many lines to read from the end of the log",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: intPtr(1),
+					Max: intPtr(MaxConsoleLogLines),
+				},
+			},
+		},
+	}
+}
+
+func (g *GetConsoleLog) Setup(ctx core.SetupContext) error {
+	_, _, err := decodeGetConsoleLogSpec(ctx.Configuration, false)
+	return err
+}
+
+func (g *GetConsoleLog) Execute(ctx core.ExecutionContext) error {
+	spec, number, err := decodeGetConsoleLogSpec(ctx.Configuration, true)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	text, err := client.GetConsoleText(spec.Job, number)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get console log of %s #%d: %v", spec.Job, number, err))
+	}
+
+	tail, truncated := TailLines(text, spec.Lines)
+	payload := map[string]any{
+		"job":       spec.Job,
+		"number":    number,
+		"log":       tail,
+		"lines":     spec.Lines,
+		"truncated": truncated,
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, GetConsoleLogPayloadType, []any{payload})
+}
+
+func (g *GetConsoleLog) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (g *GetConsoleLog) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (g *GetConsoleLog) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (g *GetConsoleLog) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (g *GetConsoleLog) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+// decodeGetConsoleLogSpec only validates the build number on execution,
+// since it usually comes from an expression.
+func decodeGetConsoleLogSpec(value any, validateBuild bool) (GetConsoleLogSpec, int64, error) {
+	spec := GetConsoleLogSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return GetConsoleLogSpec{}, 0, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	job, err := validateJob(spec.Job)
+	if err != nil {
+		return GetConsoleLogSpec{}, 0, err
+	}
+
+	spec.Job = job
+	if spec.Lines <= 0 {
+		spec.Lines = DefaultConsoleLogLines
+	}
+
+	if spec.Lines > MaxConsoleLogLines {
+		return GetConsoleLogSpec{}, 0, fmt.Errorf("lines must be at most %d", MaxConsoleLogLines)
+	}
+
+	if !validateBuild {
+		return spec, 0, nil
+	}
+
+	number, err := validateBuildNumber(spec.BuildNumber)
+	if err != nil {
+		return GetConsoleLogSpec{}, 0, err
+	}
+
+	return spec, number, nil
+}
+
+// TailLines returns the last lines of a text,
+// and whether earlier lines were left out.
+func TailLines(text string, lines int) (string, bool) {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return "", false
+	}
+
+	all := strings.Split(text, "\n")
+	if len(all) <= lines {
+		return text, false
+	}
+
+	return strings.Join(all[len(all)-lines:], "\n"), true
+}
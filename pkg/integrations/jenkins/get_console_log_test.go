@@ -0,0 +1,64 @@
+package jenkins
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GetConsoleLog__Execute(t *testing.T) {
+	component := &GetConsoleLog{}
+
+	t.Run("long log -> emits tail", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, "Started by user jane\nBuilding\nFAIL\nFinished: FAILURE\n"),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"job": "platform/api", "buildNumber": "#142", "lines": 2},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "/job/platform/job/api/142/consoleText", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, GetConsoleLogPayloadType, executionState.Type)
+
+		require.Len(t, executionState.Payloads, 1)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "FAIL\nFinished: FAILURE", payload["log"])
+		assert.Equal(t, true, payload["truncated"])
+		assert.Equal(t, int64(142), payload["number"])
+	})
+
+	t.Run("invalid build number -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"job": "platform/api", "buildNumber": "latest"},
+			HTTP:           &contexts.HTTPContext{},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, `invalid build number "latest"`)
+	})
+}
+
+func Test__TailLines(t *testing.T) {
+	tail, truncated := TailLines("one\ntwo\nthree\n", 5)
+	assert.Equal(t, "one\ntwo\nthree", tail)
+	assert.False(t, truncated)
+
+	tail, truncated = TailLines("one\ntwo\nthree", 1)
+	assert.Equal(t, "three", tail)
+	assert.True(t, truncated)
+
+	tail, truncated = TailLines("", 10)
+	assert.Empty(t, tail)
+	assert.False(t, truncated)
+}
@@ -0,0 +1,193 @@
+package jenkins
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	GetTestReportPayloadType = "jenkins.build.testReport"
+
+	MaxFailedTestCases = 50
+
+	TestCaseStatusFailed     = "FAILED"
+	TestCaseStatusRegression = "REGRESSION"
+)
+
+type GetTestReport struct{}
+
+type GetTestReportSpec struct {
+	Job         string `json:"job" mapstructure:"job"`
+	BuildNumber string `json:"buildNumber" mapstructure:"buildNumber"`
+}
+
+func (g *GetTestReport) Name() string {
+	return "jenkins.getTestReport"
+}
+
+func (g *GetTestReport) Label() string {
+	return "Get Test Report"
+}
+
+func (g *GetTestReport) Description() string {
+	return "Get the test results of a Jenkins build"
+}
+
+func (g *GetTestReport) Documentation() string {
+	return `The Get Test Report component reads the test results a Jenkins build published, like with the ` + "`junit`" + ` step.
+
+## Use Cases
+
+- **Failure reports**: List the failed tests of a build in a Slack message or an issue
+- **Quality gates**: Branch on the number of failed or skipped tests
+- **Flaky test tracking**: Collect failed tests across builds
+
+## Configuration
+
+- **Job**: The job of the build
+- **Build Number**: The number of the build, like the number output of **Trigger Build**
+
+## Output
+
+The number of passed, failed and skipped tests, and up to 50 failed tests with their error details. Builds that did not publish test results output ` + "`available: false`" + `.`
+}
+
+func (g *GetTestReport) Icon() string {
+	return "jenkins"
+}
+
+func (g *GetTestReport) Color() string {
+	return "gray"
+}
+
+func (g *GetTestReport) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (g *GetTestReport) Configuration() []configuration.Field {
+	return []configuration.Field{
+		jobField(),
+		buildNumberField(),
+	}
+}
+
+func (g *GetTestReport) Setup(ctx core.SetupContext) error {
+	_, _, err := decodeGetTestReportSpec(ctx.Configuration, false)
+	return err
+}
+
+func (g *GetTestReport) Execute(ctx core.ExecutionContext) error {
+	spec, number, err := decodeGetTestReportSpec(ctx.Configuration, true)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"job":    spec.Job,
+		"number": number,
+	}
+
+	report, err := client.GetTestReport(spec.Job, number)
+	if err != nil {
+		if !IsNotFound(err) {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get test report of %s #%d: %v", spec.Job, number, err))
+		}
+
+		//
+		// Jenkins has no test report for builds
+		// that did not publish test results.
+		//
+		payload["available"] = false
+		return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, GetTestReportPayloadType, []any{payload})
+	}
+
+	failed := FailedTestCases(report, MaxFailedTestCases)
+	payload["available"] = true
+	payload["total"] = report.PassCount + report.FailCount + report.SkipCount
+	payload["passCount"] = report.PassCount
+	payload["failCount"] = report.FailCount
+	payload["skipCount"] = report.SkipCount
+	payload["duration"] = report.Duration
+	payload["failedTests"] = failed
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, GetTestReportPayloadType, []any{payload})
+}
+
+func (g *GetTestReport) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (g *GetTestReport) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (g *GetTestReport) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (g *GetTestReport) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (g *GetTestReport) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeGetTestReportSpec(value any, validateBuild bool) (GetTestReportSpec, int64, error) {
+	spec := GetTestReportSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return GetTestReportSpec{}, 0, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	job, err := validateJob(spec.Job)
+	if err != nil {
+		return GetTestReportSpec{}, 0, err
+	}
+
+	spec.Job = job
+	if !validateBuild {
+		return spec, 0, nil
+	}
+
+	number, err := validateBuildNumber(spec.BuildNumber)
+	if err != nil {
+		return GetTestReportSpec{}, 0, err
+	}
+
+	return spec, number, nil
+}
+
+// FailedTestCases lists the failed and regressed tests of a report, up to limit.
+func FailedTestCases(report *TestReport, limit int) []map[string]any {
+	failed := []map[string]any{}
+	for _, suite := range report.Suites {
+		for _, testCase := range suite.Cases {
+			if testCase.Status != TestCaseStatusFailed && testCase.Status != TestCaseStatusRegression {
+				continue
+			}
+
+			if len(failed) >= limit {
+				return failed
+			}
+
+			failed = append(failed, map[string]any{
+				"suite":        suite.Name,
+				"className":    testCase.ClassName,
+				"name":         testCase.Name,
+				"status":       testCase.Status,
+				"duration":     testCase.Duration,
+				"errorDetails": testCase.ErrorDetails,
+			})
+		}
+	}
+
+	return failed
+}
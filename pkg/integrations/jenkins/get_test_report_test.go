@@ -0,0 +1,59 @@
+package jenkins
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GetTestReport__Execute(t *testing.T) {
+	component := &GetTestReport{}
+
+	t.Run("report -> counts and failed tests", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"job": "platform/api", "buildNumber": "142"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{
+					"failCount": 1, "passCount": 3, "skipCount": 1, "duration": 2.5,
+					"suites": [{"name": "billing", "cases": [
+						{"className": "billing.InvoiceTest", "name": "testTotals", "status": "PASSED"},
+						{"className": "billing.InvoiceTest", "name": "testRounding", "status": "REGRESSION", "errorDetails": "expected 10.07"},
+						{"className": "billing.InvoiceTest", "name": "testLegacy", "status": "SKIPPED"}
+					]}]
+				}`),
+			}},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, payload["available"])
+		assert.Equal(t, int64(5), payload["total"])
+
+		failed := payload["failedTests"].([]map[string]any)
+		require.Len(t, failed, 1)
+		assert.Equal(t, "testRounding", failed[0]["name"])
+		assert.Equal(t, "expected 10.07", failed[0]["errorDetails"])
+	})
+
+	t.Run("no report -> not available", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"job": "platform/api", "buildNumber": "142"},
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusNotFound, `Not Found`)}},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, executionState.Passed)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, payload["available"])
+	})
+}
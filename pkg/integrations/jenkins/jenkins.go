@@ -0,0 +1,162 @@
+package jenkins
+
+import (
+	"fmt"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const ResourceTypeJob = "job"
+
+func init() {
+	registry.RegisterIntegrationWithWebhookHandler("jenkins", &Jenkins{}, &JenkinsWebhookHandler{})
+}
+
+type Jenkins struct{}
+
+type Configuration struct {
+	URL      string `json:"url" mapstructure:"url"`
+	Username string `json:"username" mapstructure:"username"`
+	APIToken string `json:"apiToken" mapstructure:"apiToken"`
+}
+
+type Metadata struct {
+	User string `json:"user" mapstructure:"user"`
+}
+
+func (j *Jenkins) Name() string {
+	return "jenkins"
+}
+
+func (j *Jenkins) Label() string {
+	return "Jenkins"
+}
+
+func (j *Jenkins) Icon() string {
+	return "jenkins"
+}
+
+func (j *Jenkins) Description() string {
+	return "Trigger and follow Jenkins builds"
+}
+
+func (j *Jenkins) Instructions() string {
+	return `
+1. **URL:** The URL of your Jenkins controller, like ` + "`https://jenkins.example.com`" + `
+2. **Username:** The Jenkins user SuperPlane acts as. A dedicated user is recommended.
+3. **API Token:** Log in as that user, and create a token in **your user → Security → API Token → Add new Token**.
+   - The user needs **Job/Build**, **Job/Cancel** and **Job/Read** on the jobs SuperPlane manages.
+   - CSRF crumbs are handled automatically.`
+}
+
+func (j *Jenkins) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "url",
+			Label:       "URL",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "https://jenkins.example.com",
+			Description: "URL of the Jenkins controller",
+		},
+		{
+			Name:        "username",
+			Label:       "Username",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Jenkins user the API token belongs to",
+		},
+		{
+			Name:        "apiToken",
+			Label:       "API Token",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "Jenkins API token",
+		},
+	}
+}
+
+func (j *Jenkins) Actions() []core.Action {
+	return []core.Action{
+		&TriggerBuild{},
+		&GetConsoleLog{},
+		&GetTestReport{},
+		&AbortBuild{},
+	}
+}
+
+func (j *Jenkins) Triggers() []core.Trigger {
+	return []core.Trigger{
+		&OnBuildCompleted{},
+	}
+}
+
+func (j *Jenkins) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (j *Jenkins) Sync(ctx core.SyncContext) error {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	whoAmI, err := client.WhoAmI()
+	if err != nil {
+		return fmt.Errorf("failed to verify Jenkins credentials: %w", err)
+	}
+
+	//
+	// Jenkins answers requests with invalid credentials as
+	// the anonymous user when anonymous read access is allowed.
+	//
+	if whoAmI.Anonymous || !whoAmI.Authenticated {
+		return fmt.Errorf("credentials of %s were not accepted", client.Username)
+	}
+
+	ctx.Integration.SetMetadata(Metadata{User: whoAmI.Name})
+	ctx.Integration.Ready()
+	return nil
+}
+
+func (j *Jenkins) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (j *Jenkins) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	if resourceType != ResourceTypeJob {
+		return []core.IntegrationResource{}, nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := client.ListJobs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(jobs))
+	for _, job := range jobs {
+		resources = append(resources, core.IntegrationResource{
+			Type: ResourceTypeJob,
+			Name: job.FullName,
+			ID:   job.FullName,
+		})
+	}
+
+	return resources, nil
+}
+
+func (j *Jenkins) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (j *Jenkins) HandleHook(ctx core.IntegrationHookContext) error {
+	return nil
+}
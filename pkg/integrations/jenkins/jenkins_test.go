@@ -0,0 +1,171 @@
+package jenkins
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Jenkins__Sync(t *testing.T) {
+	integration := &Jenkins{}
+
+	t.Run("valid credentials -> ready", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"name":"superplane","anonymous":false,"authenticated":true}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		assert.Equal(t, Metadata{User: "superplane"}, integrationCtx.Metadata)
+
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://jenkins.example.com/whoAmI/api/json", httpContext.Requests[0].URL.String())
+		username, password, ok := httpContext.Requests[0].BasicAuth()
+		require.True(t, ok)
+		assert.Equal(t, "superplane", username)
+		assert.Equal(t, "token-123", password)
+	})
+
+	t.Run("anonymous -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"name":"anonymous","anonymous":true,"authenticated":true}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "credentials of superplane were not accepted")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+	})
+
+	t.Run("unauthorized HTML page -> short error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				{
+					StatusCode: http.StatusUnauthorized,
+					Header:     http.Header{"Content-Type": []string{"text/html;charset=utf-8"}},
+					Body:       io.NopCloser(strings.NewReader("<html><body>Invalid password/token</body></html>")),
+				},
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "request failed with 401: Unauthorized")
+	})
+}
+
+func Test__Jenkins__ListResources(t *testing.T) {
+	integration := &Jenkins{}
+
+	httpContext := &contexts.HTTPContext{
+		Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{
+				"jobs": [
+					{"_class": "hudson.model.FreeStyleProject", "name": "deploy", "fullName": "deploy"},
+					{"_class": "com.cloudbees.hudson.plugins.folder.Folder", "name": "platform", "fullName": "platform", "jobs": [
+						{"_class": "org.jenkinsci.plugins.workflow.job.WorkflowJob", "name": "api", "fullName": "platform/api"},
+						{"_class": "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject", "name": "web", "fullName": "platform/web", "jobs": [
+							{"_class": "org.jenkinsci.plugins.workflow.job.WorkflowJob", "name": "main", "fullName": "platform/web/main"}
+						]}
+					]},
+					{"_class": "com.cloudbees.hudson.plugins.folder.Folder", "name": "empty", "fullName": "empty", "jobs": []}
+				]
+			}`),
+		},
+	}
+
+	resources, err := integration.ListResources(ResourceTypeJob, core.ListResourcesContext{
+		HTTP:        httpContext,
+		Integration: testIntegration(),
+	})
+
+	require.NoError(t, err)
+	names := []string{}
+	for _, resource := range resources {
+		names = append(names, resource.ID)
+	}
+
+	assert.Equal(t, []string{"deploy", "platform/api", "platform/web/main"}, names)
+}
+
+func Test__JobPath(t *testing.T) {
+	assert.Equal(t, "/job/deploy", JobPath("deploy"))
+	// branches of multibranch projects are named like feature%2Flogin
+	assert.Equal(t, "/job/platform/job/web/job/feature%252Flogin", JobPath("platform/web/feature%2Flogin"))
+	assert.Equal(t, "/job/my%20job", JobPath("/my job/"))
+}
+
+func Test__Client__Crumb(t *testing.T) {
+	t.Run("crumb issuer enabled -> crumb and session cookie sent on POST", func(t *testing.T) {
+		crumbResponse := jsonResponse(http.StatusOK, `{"crumb":"abc123","crumbRequestField":"Jenkins-Crumb"}`)
+		crumbResponse.Header.Add("Set-Cookie", "JSESSIONID.1=session-1; Path=/")
+
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				crumbResponse,
+				jsonResponse(http.StatusOK, ``),
+				jsonResponse(http.StatusOK, ``),
+			},
+		}
+
+		client, err := NewClient(httpContext, testIntegration())
+		require.NoError(t, err)
+		require.NoError(t, client.StopBuild("platform/api", 142))
+		require.NoError(t, client.StopBuild("platform/api", 143))
+
+		require.Len(t, httpContext.Requests, 3)
+		assert.Equal(t, "/crumbIssuer/api/json", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "/job/platform/job/api/142/stop", httpContext.Requests[1].URL.Path)
+		assert.Equal(t, "abc123", httpContext.Requests[1].Header.Get("Jenkins-Crumb"))
+		cookie, err := httpContext.Requests[1].Cookie("JSESSIONID.1")
+		require.NoError(t, err)
+		assert.Equal(t, "session-1", cookie.Value)
+
+		// the crumb is only fetched once
+		assert.Equal(t, "/job/platform/job/api/143/stop", httpContext.Requests[2].URL.Path)
+		assert.Equal(t, "abc123", httpContext.Requests[2].Header.Get("Jenkins-Crumb"))
+	})
+
+	t.Run("crumb issuer disabled -> POST without crumb", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusNotFound, `Not Found`),
+				jsonResponse(http.StatusOK, ``),
+			},
+		}
+
+		client, err := NewClient(httpContext, testIntegration())
+		require.NoError(t, err)
+		require.NoError(t, client.StopBuild("deploy", 7))
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Empty(t, httpContext.Requests[1].Header.Get("Jenkins-Crumb"))
+	})
+}
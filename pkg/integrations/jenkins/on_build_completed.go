@@ -0,0 +1,298 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	OnBuildCompletedPayloadType = "jenkins.build.completed"
+
+	NotificationPhaseCompleted = "COMPLETED"
+)
+
+type OnBuildCompleted struct{}
+
+type OnBuildCompletedConfiguration struct {
+	Jobs    []string `json:"jobs" mapstructure:"jobs"`
+	Results []string `json:"results" mapstructure:"results"`
+}
+
+type OnBuildCompletedMetadata struct {
+	WebhookURL string `json:"webhookUrl" mapstructure:"webhookUrl"`
+}
+
+// NotificationPayload is the JSON body the Jenkins Notification plugin
+// sends for each phase of a build.
+type NotificationPayload struct {
+	Name        string            `json:"name"`
+	DisplayName string            `json:"display_name"`
+	URL         string            `json:"url"`
+	Build       NotificationBuild `json:"build"`
+}
+
+type NotificationBuild struct {
+	FullURL    string            `json:"full_url"`
+	Number     int64             `json:"number"`
+	QueueID    int64             `json:"queue_id"`
+	Phase      string            `json:"phase"`
+	Status     string            `json:"status"`
+	URL        string            `json:"url"`
+	Duration   int64             `json:"duration"`
+	Timestamp  int64             `json:"timestamp"`
+	Parameters map[string]any    `json:"parameters"`
+	SCM        map[string]string `json:"scm"`
+}
+
+func (t *OnBuildCompleted) Name() string {
+	return "jenkins.onBuildCompleted"
+}
+
+func (t *OnBuildCompleted) Label() string {
+	return "On Build Completed"
+}
+
+func (t *OnBuildCompleted) Description() string {
+	return "Listen to completed Jenkins builds"
+}
+
+func (t *OnBuildCompleted) Documentation() string {
+	return `The On Build Completed trigger starts a workflow when a Jenkins build completes, as reported by the Jenkins Notification plugin.
+
+## Use Cases
+
+- **Deployments**: Deploy the artifacts of a successful build
+- **Failure handling**: Open an issue or notify the team when a build fails
+- **Cross-system pipelines**: Continue in SuperPlane once a Jenkins pipeline finishes
+
+## Configuration
+
+- **Jobs**: Only fire for these jobs. Jobs in folders use their full name, like ` + "`team/app`" + `. Leave empty for every job.
+- **Results**: Only fire for builds with these results. Leave empty for every result.
+
+## Jenkins setup (manual)
+
+Endpoints of the [Notification plugin](https://plugins.jenkins.io/notification/) are configured on each job, so SuperPlane can't create them by API. When the node is saved, SuperPlane generates a webhook URL. On each job to listen to, open **Configure → Job Notifications → Add Endpoint**, and set:
+
+- **Format**: JSON
+- **Protocol**: HTTP
+- **Event**: Job Completed, or All Events
+- **URL**: The webhook URL
+
+Only the COMPLETED phase of a build fires the trigger. The webhook URL is the only credential, so keep it private.`
+}
+
+func (t *OnBuildCompleted) Icon() string {
+	return "jenkins"
+}
+
+func (t *OnBuildCompleted) Color() string {
+	return "gray"
+}
+
+func (t *OnBuildCompleted) Configuration() []configuration.Field {
+	options := make([]configuration.FieldOption, 0, len(buildResults))
+	for _, result := range buildResults {
+		options = append(options, configuration.FieldOption{Label: result, Value: result})
+	}
+
+	return []configuration.Field{
+		{
+			Name:     "jobs",
+			Label:    "Jobs",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Job",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+			Description: "Only fire for these jobs",
+		},
+		{
+			Name:        "results",
+			Label:       "Results",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    false,
+			Description: "Only fire for builds with these results",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: options,
+				},
+			},
+		},
+	}
+}
+
+func (t *OnBuildCompleted) Setup(ctx core.TriggerContext) error {
+	if _, err := decodeOnBuildCompletedConfiguration(ctx.Configuration); err != nil {
+		return err
+	}
+
+	if ctx.Integration == nil {
+		return fmt.Errorf("missing integration context")
+	}
+
+	if err := ctx.Integration.RequestWebhook(struct{}{}); err != nil {
+		return err
+	}
+
+	if ctx.Webhook == nil {
+		return fmt.Errorf("missing webhook context")
+	}
+
+	webhookURL, err := ctx.Webhook.Setup()
+	if err != nil {
+		return fmt.Errorf("failed to setup webhook URL: %w", err)
+	}
+
+	if ctx.Metadata == nil {
+		return nil
+	}
+
+	return ctx.Metadata.Set(OnBuildCompletedMetadata{WebhookURL: webhookURL})
+}
+
+func (t *OnBuildCompleted) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (t *OnBuildCompleted) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnBuildCompleted) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	config, err := decodeOnBuildCompletedConfiguration(ctx.Configuration)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	notification := NotificationPayload{}
+	if err := json.Unmarshal(ctx.Body, &notification); err != nil {
+		return http.StatusBadRequest, nil, fmt.Errorf("failed to parse request body: %w", err)
+	}
+
+	if notification.Name == "" || notification.Build.Number == 0 {
+		return http.StatusBadRequest, nil, fmt.Errorf("missing job or build number")
+	}
+
+	//
+	// The plugin notifies every phase of a build when
+	// configured for all events, but only completed builds fire.
+	//
+	if notification.Build.Phase != NotificationPhaseCompleted {
+		return http.StatusOK, nil, nil
+	}
+
+	job := JobFullName(notification)
+	if !config.matches(job, notification.Build.Status) {
+		return http.StatusOK, nil, nil
+	}
+
+	if err := ctx.Events.Emit(OnBuildCompletedPayloadType, notificationEventPayload(job, notification)); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to emit event: %w", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnBuildCompleted) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+func decodeOnBuildCompletedConfiguration(value any) (OnBuildCompletedConfiguration, error) {
+	config := OnBuildCompletedConfiguration{}
+	if err := mapstructure.Decode(value, &config); err != nil {
+		return OnBuildCompletedConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	jobs := make([]string, 0, len(config.Jobs))
+	for _, job := range config.Jobs {
+		job = strings.Trim(strings.TrimSpace(job), "/")
+		if job != "" {
+			jobs = append(jobs, job)
+		}
+	}
+
+	for _, result := range config.Results {
+		if !slices.Contains(buildResults, result) {
+			return OnBuildCompletedConfiguration{}, fmt.Errorf("invalid result %q", result)
+		}
+	}
+
+	config.Jobs = jobs
+	return config, nil
+}
+
+func (c OnBuildCompletedConfiguration) matches(job, result string) bool {
+	if len(c.Jobs) > 0 && !slices.Contains(c.Jobs, job) {
+		return false
+	}
+
+	if len(c.Results) > 0 && !slices.Contains(c.Results, result) {
+		return false
+	}
+
+	return true
+}
+
+// JobFullName finds the full name of the job of a notification.
+// The plugin only sends the short name of jobs, so jobs in folders
+// are named from their URL, like job/team/job/app/ for team/app.
+func JobFullName(notification NotificationPayload) string {
+	parts := strings.Split(strings.Trim(notification.URL, "/"), "/")
+	if len(parts) < 2 || len(parts)%2 != 0 {
+		return notification.Name
+	}
+
+	names := make([]string, 0, len(parts)/2)
+	for i := 0; i < len(parts); i += 2 {
+		if parts[i] != "job" {
+			return notification.Name
+		}
+
+		name, err := url.PathUnescape(parts[i+1])
+		if err != nil {
+			return notification.Name
+		}
+
+		names = append(names, name)
+	}
+
+	return strings.Join(names, "/")
+}
+
+func notificationEventPayload(job string, notification NotificationPayload) map[string]any {
+	build := notification.Build
+	payload := map[string]any{
+		"job":       job,
+		"name":      notification.Name,
+		"number":    build.Number,
+		"result":    build.Status,
+		"url":       build.FullURL,
+		"queueId":   build.QueueID,
+		"duration":  build.Duration,
+		"timestamp": build.Timestamp,
+	}
+
+	if len(build.Parameters) > 0 {
+		payload["parameters"] = build.Parameters
+	}
+
+	if len(build.SCM) > 0 {
+		payload["scm"] = build.SCM
+	}
+
+	return payload
+}
@@ -0,0 +1,115 @@
+package jenkins
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func notification(phase, status string) string {
+	return `{
+		"name": "api",
+		"url": "job/platform/job/api/",
+		"build": {
+			"full_url": "https://jenkins.example.com/job/platform/job/api/142/",
+			"number": 142,
+			"queue_id": 3871,
+			"phase": "` + phase + `",
+			"status": "` + status + `",
+			"url": "job/platform/job/api/142/",
+			"scm": {"url": "https://github.com/acme/api.git", "branch": "origin/main", "commit": "4f2a9c1"},
+			"parameters": {"VERSION": "1.8.2"}
+		}
+	}`
+}
+
+func Test__OnBuildCompleted__HandleWebhook(t *testing.T) {
+	trigger := &OnBuildCompleted{}
+
+	handle := func(configuration map[string]any, body string) (int, *contexts.EventContext, error) {
+		events := &contexts.EventContext{}
+		code, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          []byte(body),
+			Headers:       http.Header{},
+			Configuration: configuration,
+			Events:        events,
+		})
+
+		return code, events, err
+	}
+
+	t.Run("completed build -> emits event", func(t *testing.T) {
+		code, events, err := handle(map[string]any{}, notification("COMPLETED", "SUCCESS"))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, OnBuildCompletedPayloadType, events.Payloads[0].Type)
+
+		data := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "platform/api", data["job"])
+		assert.Equal(t, int64(142), data["number"])
+		assert.Equal(t, "SUCCESS", data["result"])
+		assert.Equal(t, map[string]any{"VERSION": "1.8.2"}, data["parameters"])
+	})
+
+	t.Run("other phases -> ignored", func(t *testing.T) {
+		for _, phase := range []string{"QUEUED", "STARTED", "FINALIZED"} {
+			code, events, err := handle(map[string]any{}, notification(phase, "SUCCESS"))
+
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, code)
+			assert.Zero(t, events.Count(), phase)
+		}
+	})
+
+	t.Run("filters -> only matching builds emit", func(t *testing.T) {
+		config := map[string]any{"jobs": []any{"platform/api"}, "results": []any{"FAILURE", "UNSTABLE"}}
+
+		_, events, err := handle(config, notification("COMPLETED", "SUCCESS"))
+		require.NoError(t, err)
+		assert.Zero(t, events.Count())
+
+		_, events, err = handle(config, notification("COMPLETED", "FAILURE"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, events.Count())
+
+		_, events, err = handle(map[string]any{"jobs": []any{"api"}}, notification("COMPLETED", "FAILURE"))
+		require.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("invalid body -> bad request", func(t *testing.T) {
+		code, _, err := handle(map[string]any{}, `{"name":"api"}`)
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func Test__OnBuildCompleted__Setup(t *testing.T) {
+	trigger := &OnBuildCompleted{}
+	integration := testIntegration()
+	metadata := &contexts.MetadataContext{}
+
+	err := trigger.Setup(core.TriggerContext{
+		Configuration: map[string]any{},
+		Integration:   integration,
+		Webhook:       &contexts.NodeWebhookContext{},
+		Metadata:      metadata,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, integration.WebhookRequests, 1)
+	assert.NotEmpty(t, metadata.Metadata.(OnBuildCompletedMetadata).WebhookURL)
+}
+
+func Test__JobFullName(t *testing.T) {
+	assert.Equal(t, "platform/api", JobFullName(NotificationPayload{Name: "api", URL: "job/platform/job/api/"}))
+	assert.Equal(t, "platform/web/feature%2Flogin", JobFullName(NotificationPayload{Name: "feature%2Flogin", URL: "job/platform/job/web/job/feature%252Flogin/"}))
+	assert.Equal(t, "deploy", JobFullName(NotificationPayload{Name: "deploy", URL: ""}))
+}
@@ -0,0 +1,27 @@
+package jenkins
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func testIntegration() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"url":      "https://jenkins.example.com/",
+			"username": "superplane",
+			"apiToken": "token-123",
+		},
+	}
+}
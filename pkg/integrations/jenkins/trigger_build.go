@@ -0,0 +1,346 @@
+package jenkins
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	TriggerBuildPayloadType   = "jenkins.build.finished"
+	TriggerBuildPollAction    = "poll"
+	TriggerBuildPollInterval  = 10 * time.Second
+	TriggerBuildMaxPollErrors = 5
+
+	TriggerBuildStatusQueued   = "queued"
+	TriggerBuildStatusBuilding = "building"
+)
+
+type TriggerBuild struct{}
+
+type TriggerBuildSpec struct {
+	Job        string           `json:"job" mapstructure:"job"`
+	Parameters []BuildParameter `json:"parameters" mapstructure:"parameters"`
+}
+
+type BuildParameter struct {
+	Name  string `json:"name" mapstructure:"name"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+type TriggerBuildMetadata struct {
+	Job            string `json:"job" mapstructure:"job"`
+	QueueID        int64  `json:"queueId" mapstructure:"queueId"`
+	BuildNumber    int64  `json:"buildNumber,omitempty" mapstructure:"buildNumber"`
+	BuildURL       string `json:"buildUrl,omitempty" mapstructure:"buildUrl"`
+	StartedAt      string `json:"startedAt" mapstructure:"startedAt"`
+	Status         string `json:"status" mapstructure:"status"`
+	PollErrorCount int    `json:"pollErrorCount,omitempty" mapstructure:"pollErrorCount"`
+}
+
+func (t *TriggerBuild) Name() string {
+	return "jenkins.triggerBuild"
+}
+
+func (t *TriggerBuild) Label() string {
+	return "Trigger Build"
+}
+
+func (t *TriggerBuild) Description() string {
+	return "Trigger a Jenkins build and wait for its result"
+}
+
+func (t *TriggerBuild) Documentation() string {
+	return `The Trigger Build component queues a build of a Jenkins job, and waits until the build finishes.
+
+## Use Cases
+
+- **CI/CD orchestration**: Run Jenkins pipelines as steps of a workflow
+- **Deployments**: Trigger deployment jobs with the version to deploy as a parameter
+- **Gated releases**: Only continue once the build succeeds
+
+## Configuration
+
+- **Job**: The job to build. Jobs in folders and multibranch projects use their full name, like ` + "`team/app/main`" + `.
+- **Parameters**: Build parameters. Parameters that are not set use the defaults of the job. Parameters are ignored for jobs that are not parameterized.
+
+## Output Channels
+
+- **Success**: The build result is SUCCESS
+- **Failed**: The build result is UNSTABLE, FAILURE, NOT_BUILT or ABORTED
+
+## Notes
+
+The build is followed from its queue item to its result by polling Jenkins every 10 seconds. Cancelling the execution aborts the build, or removes it from the queue if it did not start yet.`
+}
+
+func (t *TriggerBuild) Icon() string {
+	return "jenkins"
+}
+
+func (t *TriggerBuild) Color() string {
+	return "gray"
+}
+
+func (t *TriggerBuild) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: SuccessOutputChannel, Label: "Success"},
+		{Name: FailedOutputChannel, Label: "Failed"},
+	}
+}
+
+func (t *TriggerBuild) Configuration() []configuration.Field {
+	return []configuration.Field{
+		jobField(),
+		{
+			Name:     "parameters",
+			Label:    "Parameters",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Parameter",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "name",
+								Label:    "Name",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+							{
+								Name:     "value",
+								Label:    "Value",
+								Type:     configuration.FieldTypeString,
+								Required: false,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *TriggerBuild) Setup(ctx core.SetupContext) error {
+	_, err := decodeTriggerBuildSpec(ctx.Configuration)
+	return err
+}
+
+func (t *TriggerBuild) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeTriggerBuildSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	job, err := client.GetJob(spec.Job)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get job %s: %v", spec.Job, err))
+	}
+
+	if !job.Buildable {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("job %s is disabled or can't be built", spec.Job))
+	}
+
+	parameters := map[string]string{}
+	for _, parameter := range spec.Parameters {
+		parameters[parameter.Name] = parameter.Value
+	}
+
+	queueID, err := client.TriggerBuild(spec.Job, job.IsParameterized(), parameters)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to trigger build of %s: %v", spec.Job, err))
+	}
+
+	metadata := TriggerBuildMetadata{
+		Job:       spec.Job,
+		QueueID:   queueID,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+		Status:    TriggerBuildStatusQueued,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(TriggerBuildPollAction, map[string]any{}, TriggerBuildPollInterval)
+}
+
+func (t *TriggerBuild) Hooks() []core.Hook {
+	return []core.Hook{{Name: TriggerBuildPollAction, Type: core.HookTypeInternal}}
+}
+
+func (t *TriggerBuild) HandleHook(ctx core.ActionHookContext) error {
+	switch ctx.Name {
+	case TriggerBuildPollAction:
+		return t.poll(ctx)
+	default:
+		return fmt.Errorf("unknown hook: %s", ctx.Name)
+	}
+}
+
+func (t *TriggerBuild) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	metadata := TriggerBuildMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	if metadata.BuildNumber == 0 {
+		return t.pollQueueItem(ctx, client, metadata)
+	}
+
+	return t.pollBuild(ctx, client, metadata)
+}
+
+// pollQueueItem waits for the queue item to become a build.
+func (t *TriggerBuild) pollQueueItem(ctx core.ActionHookContext, client *Client, metadata TriggerBuildMetadata) error {
+	item, err := client.GetQueueItem(metadata.QueueID)
+	if err != nil {
+		return t.handlePollError(ctx, metadata, fmt.Sprintf("queue item %d", metadata.QueueID), err)
+	}
+
+	if item.Cancelled {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("build of %s was cancelled while queued", metadata.Job))
+	}
+
+	metadata.PollErrorCount = 0
+	if item.Executable != nil && item.Executable.Number > 0 {
+		metadata.BuildNumber = item.Executable.Number
+		metadata.BuildURL = item.Executable.URL
+		metadata.Status = TriggerBuildStatusBuilding
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(TriggerBuildPollAction, map[string]any{}, TriggerBuildPollInterval)
+}
+
+// pollBuild waits for the build to finish.
+func (t *TriggerBuild) pollBuild(ctx core.ActionHookContext, client *Client, metadata TriggerBuildMetadata) error {
+	build, err := client.GetBuild(metadata.Job, metadata.BuildNumber)
+	if err != nil {
+		return t.handlePollError(ctx, metadata, fmt.Sprintf("build %s #%d", metadata.Job, metadata.BuildNumber), err)
+	}
+
+	metadata.PollErrorCount = 0
+	if build.Building || build.Result == "" {
+		if err := ctx.Metadata.Set(metadata); err != nil {
+			return err
+		}
+
+		return ctx.Requests.ScheduleActionCall(TriggerBuildPollAction, map[string]any{}, TriggerBuildPollInterval)
+	}
+
+	metadata.Status = build.Result
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	payload := buildPayload(metadata.Job, build)
+	payload["queueId"] = metadata.QueueID
+
+	channel := SuccessOutputChannel
+	if buildFailed(build.Result) {
+		channel = FailedOutputChannel
+	}
+
+	return ctx.ExecutionState.Emit(channel, TriggerBuildPayloadType, []any{payload})
+}
+
+func (t *TriggerBuild) handlePollError(ctx core.ActionHookContext, metadata TriggerBuildMetadata, subject string, err error) error {
+	metadata.PollErrorCount++
+	if setErr := ctx.Metadata.Set(metadata); setErr != nil {
+		return setErr
+	}
+
+	if IsNotFound(err) || metadata.PollErrorCount >= TriggerBuildMaxPollErrors {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get %s: %v", subject, err))
+	}
+
+	return ctx.Requests.ScheduleActionCall(TriggerBuildPollAction, map[string]any{}, TriggerBuildPollInterval)
+}
+
+func (t *TriggerBuild) Cancel(ctx core.ExecutionContext) error {
+	metadata := TriggerBuildMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return nil
+	}
+
+	if metadata.Job == "" || metadata.QueueID == 0 {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil
+	}
+
+	if metadata.BuildNumber == 0 {
+		if err := client.CancelQueueItem(metadata.QueueID); err != nil {
+			ctx.Logger.Warnf("Failed to cancel queue item %d of %s: %v", metadata.QueueID, metadata.Job, err)
+		} else {
+			ctx.Logger.Infof("Cancelled queue item %d of %s", metadata.QueueID, metadata.Job)
+		}
+
+		return nil
+	}
+
+	if err := client.StopBuild(metadata.Job, metadata.BuildNumber); err != nil {
+		ctx.Logger.Warnf("Failed to abort build %s #%d: %v", metadata.Job, metadata.BuildNumber, err)
+	} else {
+		ctx.Logger.Infof("Aborted build %s #%d", metadata.Job, metadata.BuildNumber)
+	}
+
+	return nil
+}
+
+func (t *TriggerBuild) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (t *TriggerBuild) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func decodeTriggerBuildSpec(value any) (TriggerBuildSpec, error) {
+	spec := TriggerBuildSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return TriggerBuildSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	job, err := validateJob(spec.Job)
+	if err != nil {
+		return TriggerBuildSpec{}, err
+	}
+
+	spec.Job = job
+	for i := range spec.Parameters {
+		spec.Parameters[i].Name = strings.TrimSpace(spec.Parameters[i].Name)
+		if spec.Parameters[i].Name == "" {
+			return TriggerBuildSpec{}, fmt.Errorf("parameter %d: name is required", i+1)
+		}
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,247 @@
+package jenkins
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func queuedResponse(location string) *http.Response {
+	response := jsonResponse(http.StatusCreated, ``)
+	response.Header.Set("Location", location)
+	return response
+}
+
+func Test__TriggerBuild__Execute(t *testing.T) {
+	component := &TriggerBuild{}
+
+	t.Run("parameterized job -> builds with parameters and polls", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"fullName":"platform/api","buildable":true,"property":[{},{"parameterDefinitions":[{"name":"VERSION"}]}]}`),
+			jsonResponse(http.StatusNotFound, ``),
+			queuedResponse("https://jenkins.example.com/queue/item/3871/"),
+		}}
+
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"job":        "platform/api",
+				"parameters": []any{map[string]any{"name": "VERSION", "value": "1.8.2"}},
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, TriggerBuildPollAction, requests.Action)
+		assert.Equal(t, TriggerBuildPollInterval, requests.Duration)
+
+		require.Len(t, httpContext.Requests, 3)
+		assert.Equal(t, "/job/platform/job/api/buildWithParameters", httpContext.Requests[2].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[2].Body)
+		require.NoError(t, err)
+		assert.Equal(t, "VERSION=1.8.2", string(body))
+
+		stored, ok := metadata.Metadata.(TriggerBuildMetadata)
+		require.True(t, ok)
+		assert.Equal(t, int64(3871), stored.QueueID)
+		assert.Equal(t, TriggerBuildStatusQueued, stored.Status)
+	})
+
+	t.Run("job without parameters -> build endpoint", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"fullName":"deploy","buildable":true,"property":[]}`),
+			jsonResponse(http.StatusNotFound, ``),
+			queuedResponse("https://jenkins.example.com/queue/item/12/"),
+		}}
+
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"job": "deploy"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "/job/deploy/build", httpContext.Requests[2].URL.Path)
+	})
+
+	t.Run("disabled job -> fails execution", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"job": "deploy"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"fullName":"deploy","buildable":false}`),
+			}},
+			Integration:    testIntegration(),
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Equal(t, "job deploy is disabled or can't be built", executionState.FailureMessage)
+	})
+}
+
+func Test__TriggerBuild__Poll(t *testing.T) {
+	component := &TriggerBuild{}
+
+	poll := func(metadata TriggerBuildMetadata, responses ...*http.Response) (*contexts.MetadataContext, *contexts.RequestContext, *contexts.ExecutionStateContext, error) {
+		metadataContext := &contexts.MetadataContext{Metadata: metadata}
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           TriggerBuildPollAction,
+			Configuration:  map[string]any{"job": metadata.Job},
+			HTTP:           &contexts.HTTPContext{Responses: responses},
+			Integration:    testIntegration(),
+			Metadata:       metadataContext,
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		return metadataContext, requests, executionState, err
+	}
+
+	queued := TriggerBuildMetadata{
+		Job:       "platform/api",
+		QueueID:   3871,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+		Status:    TriggerBuildStatusQueued,
+	}
+
+	building := queued
+	building.BuildNumber = 142
+	building.Status = TriggerBuildStatusBuilding
+
+	t.Run("queue item still waiting -> polls again", func(t *testing.T) {
+		metadata, requests, executionState, err := poll(queued, jsonResponse(http.StatusOK, `{"id":3871,"why":"Waiting for next available executor"}`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, TriggerBuildPollAction, requests.Action)
+		assert.Equal(t, int64(0), metadata.Metadata.(TriggerBuildMetadata).BuildNumber)
+	})
+
+	t.Run("queue item left queue -> stores build number", func(t *testing.T) {
+		metadata, requests, executionState, err := poll(queued, jsonResponse(http.StatusOK, `{
+			"id": 3871,
+			"executable": {"number": 142, "url": "https://jenkins.example.com/job/platform/job/api/142/"}
+		}`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, TriggerBuildPollAction, requests.Action)
+
+		stored := metadata.Metadata.(TriggerBuildMetadata)
+		assert.Equal(t, int64(142), stored.BuildNumber)
+		assert.Equal(t, TriggerBuildStatusBuilding, stored.Status)
+	})
+
+	t.Run("queue item cancelled -> fails execution", func(t *testing.T) {
+		_, _, executionState, err := poll(queued, jsonResponse(http.StatusOK, `{"id":3871,"cancelled":true}`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Equal(t, "build of platform/api was cancelled while queued", executionState.FailureMessage)
+	})
+
+	t.Run("build running -> polls again", func(t *testing.T) {
+		_, requests, executionState, err := poll(building, jsonResponse(http.StatusOK, `{"number":142,"building":true,"result":null}`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, TriggerBuildPollAction, requests.Action)
+	})
+
+	t.Run("build succeeded -> emits on success", func(t *testing.T) {
+		_, _, executionState, err := poll(building, jsonResponse(http.StatusOK, `{
+			"number": 142,
+			"url": "https://jenkins.example.com/job/platform/job/api/142/",
+			"building": false,
+			"result": "SUCCESS",
+			"duration": 184230
+		}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, SuccessOutputChannel, executionState.Channel)
+		assert.Equal(t, TriggerBuildPayloadType, executionState.Type)
+	})
+
+	t.Run("build unstable -> emits on failed", func(t *testing.T) {
+		_, _, executionState, err := poll(building, jsonResponse(http.StatusOK, `{"number":142,"building":false,"result":"UNSTABLE"}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, FailedOutputChannel, executionState.Channel)
+	})
+
+	t.Run("transient error -> polls again", func(t *testing.T) {
+		metadata, requests, executionState, err := poll(building, jsonResponse(http.StatusBadGateway, `bad gateway`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, TriggerBuildPollAction, requests.Action)
+		assert.Equal(t, 1, metadata.Metadata.(TriggerBuildMetadata).PollErrorCount)
+	})
+
+	t.Run("build not found -> fails execution", func(t *testing.T) {
+		_, _, executionState, err := poll(building, jsonResponse(http.StatusNotFound, `Not Found`))
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to get build platform/api #142")
+	})
+}
+
+func Test__TriggerBuild__Cancel(t *testing.T) {
+	component := &TriggerBuild{}
+
+	cancel := func(metadata TriggerBuildMetadata) *contexts.HTTPContext {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusNotFound, ``),
+			jsonResponse(http.StatusOK, ``),
+		}}
+
+		err := component.Cancel(core.ExecutionContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Metadata:    &contexts.MetadataContext{Metadata: metadata},
+			Logger:      logrus.NewEntry(logrus.New()),
+		})
+
+		require.NoError(t, err)
+		return httpContext
+	}
+
+	t.Run("queued -> cancels queue item", func(t *testing.T) {
+		httpContext := cancel(TriggerBuildMetadata{Job: "platform/api", QueueID: 3871})
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/queue/cancelItem", httpContext.Requests[1].URL.Path)
+		assert.Equal(t, "3871", httpContext.Requests[1].URL.Query().Get("id"))
+	})
+
+	t.Run("building -> aborts build", func(t *testing.T) {
+		httpContext := cancel(TriggerBuildMetadata{Job: "platform/api", QueueID: 3871, BuildNumber: 142})
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/job/platform/job/api/142/stop", httpContext.Requests[1].URL.Path)
+	})
+}
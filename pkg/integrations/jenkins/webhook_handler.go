@@ -0,0 +1,24 @@
+package jenkins
+
+import "github.com/superplanehq/superplane/pkg/core"
+
+// JenkinsWebhookHandler doesn't provision anything, since endpoints
+// of the Notification plugin are configured on each job in Jenkins.
+// Every trigger of an integration shares the same webhook URL.
+type JenkinsWebhookHandler struct{}
+
+func (h *JenkinsWebhookHandler) CompareConfig(a any, b any) (bool, error) {
+	return true, nil
+}
+
+func (h *JenkinsWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
+	return nil, nil
+}
+
+func (h *JenkinsWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
+	return nil
+}
+
+func (h *JenkinsWebhookHandler) Merge(current, requested any) (any, bool, error) {
+	return current, false, nil
+}
@@ -0,0 +1,285 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	gitprovider "github.com/superplanehq/superplane/pkg/git/provider"
+)
+
+const (
+	ApplyManifestPayloadType = "kubernetes.manifest.applied"
+
+	ManifestSourceFile   = "file"
+	ManifestSourceInline = "inline"
+
+	maxManifestSize = 1024 * 1024
+)
+
+type ApplyManifest struct{}
+
+type ApplyManifestSpec struct {
+	Source    string `json:"source" mapstructure:"source"`
+	File      string `json:"file" mapstructure:"file"`
+	Manifest  string `json:"manifest" mapstructure:"manifest"`
+	Namespace string `json:"namespace" mapstructure:"namespace"`
+	Force     bool   `json:"force" mapstructure:"force"`
+}
+
+func (a *ApplyManifest) Name() string {
+	return "kubernetes.applyManifest"
+}
+
+func (a *ApplyManifest) Label() string {
+	return "Apply Manifest"
+}
+
+func (a *ApplyManifest) Description() string {
+	return "Server-side apply Kubernetes manifests"
+}
+
+func (a *ApplyManifest) Documentation() string {
+	return `The Apply Manifest component server-side applies Kubernetes manifests, like ` + "`kubectl apply --server-side`" + ` does.
+
+## Use Cases
+
+- **Deployments**: Apply the manifests of a release kept in the canvas repository
+- **Configuration changes**: Update ConfigMaps, Secrets or feature flags from a workflow
+- **Environment setup**: Create namespaces and their resources for preview environments
+
+## Configuration
+
+- **Source**: Read the manifest from a file of the canvas repository, or write it inline
+- **File**: Path of the manifest in the canvas repository
+- **Manifest**: Inline YAML or JSON manifest. Supports expressions.
+- **Namespace**: Namespace of the resources that don't set one. Defaults to the namespace of the integration, or ` + "`default`" + `.
+- **Force conflicts**: Take ownership of fields that other field managers own, instead of failing
+
+Manifests can hold several documents separated by ` + "`---`" + `, and ` + "`List`" + ` objects. Resources are applied in order, with the ` + "`superplane`" + ` field manager.
+
+## Output
+
+The list of applied resources, with their kind, name, namespace and resource version.`
+}
+
+func (a *ApplyManifest) Icon() string {
+	return "kubernetes"
+}
+
+func (a *ApplyManifest) Color() string {
+	return "blue"
+}
+
+func (a *ApplyManifest) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (a *ApplyManifest) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "source",
+			Label:    "Source",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  ManifestSourceFile,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Repository file", Value: ManifestSourceFile},
+						{Label: "Inline", Value: ManifestSourceInline},
+					},
+				},
+			},
+		},
+		{
+			Name:        "file",
+			Label:       "File",
+			Type:        configuration.FieldTypeRepositoryFile,
+			Required:    false,
+			Description: "Manifest file in the canvas repository",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "source", Values: []string{ManifestSourceFile}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "source", Values: []string{ManifestSourceFile}},
+			},
+		},
+		{
+			Name:        "manifest",
+			Label:       "Manifest",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "YAML or JSON manifest, with one or more documents",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "source", Values: []string{ManifestSourceInline}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "source", Values: []string{ManifestSourceInline}},
+			},
+		},
+		namespaceField(),
+		{
+			Name:        "force",
+			Label:       "Force conflicts",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Take ownership of fields other field managers own",
+		},
+	}
+}
+
+func (a *ApplyManifest) Setup(ctx core.SetupContext) error {
+	spec, err := decodeApplyManifestSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	//
+	// Inline manifests can hold expressions,
+	// so they are only parsed when the component runs.
+	//
+	if spec.Source == ManifestSourceFile {
+		if _, err := gitprovider.ValidateUserPath(spec.File); err != nil {
+			return fmt.Errorf("invalid file %q: %w", spec.File, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *ApplyManifest) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeApplyManifestSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	manifest := spec.Manifest
+	if spec.Source == ManifestSourceFile {
+		manifest, err = readManifestFile(ctx.Files, spec.File)
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", err.Error())
+		}
+	}
+
+	objects, err := ParseManifests([]byte(manifest))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if len(objects) == 0 {
+		return ctx.ExecutionState.Fail("error", "manifest has no resources")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = client.DefaultNamespace()
+	}
+
+	applied := make([]any, 0, len(objects))
+	for _, object := range objects {
+		result, err := client.Apply(object, namespace, spec.Force)
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", err.Error())
+		}
+
+		applied = append(applied, resourcePayload(result))
+	}
+
+	payload := map[string]any{
+		"namespace": namespace,
+		"resources": applied,
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, ApplyManifestPayloadType, []any{payload})
+}
+
+func (a *ApplyManifest) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (a *ApplyManifest) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (a *ApplyManifest) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (a *ApplyManifest) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (a *ApplyManifest) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeApplyManifestSpec(value any) (ApplyManifestSpec, error) {
+	spec := ApplyManifestSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return ApplyManifestSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Source = strings.TrimSpace(spec.Source)
+	if spec.Source == "" {
+		spec.Source = ManifestSourceFile
+	}
+
+	spec.File = strings.TrimSpace(spec.File)
+	spec.Namespace = strings.TrimSpace(spec.Namespace)
+
+	switch spec.Source {
+	case ManifestSourceFile:
+		if spec.File == "" {
+			return ApplyManifestSpec{}, fmt.Errorf("file is required")
+		}
+
+	case ManifestSourceInline:
+		if strings.TrimSpace(spec.Manifest) == "" {
+			return ApplyManifestSpec{}, fmt.Errorf("manifest is required")
+		}
+
+	default:
+		return ApplyManifestSpec{}, fmt.Errorf("unknown source: %s", spec.Source)
+	}
+
+	return spec, nil
+}
+
+func readManifestFile(files core.RepositoryFilesContext, rawPath string) (string, error) {
+	path, err := gitprovider.ValidateUserPath(rawPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid file %q: %w", rawPath, err)
+	}
+
+	if files == nil {
+		return "", errors.New("manifest file configured but file access is not available")
+	}
+
+	reader, err := files.Read(path)
+	if err != nil {
+		return "", fmt.Errorf("read manifest file %q: %w", rawPath, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxManifestSize+1))
+	if err != nil {
+		return "", fmt.Errorf("read manifest file %q: %w", rawPath, err)
+	}
+
+	if len(data) > maxManifestSize {
+		return "", fmt.Errorf("manifest file %q exceeds maximum size of %d bytes", rawPath, maxManifestSize)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+type fakeFilesContext struct {
+	files map[string]string
+}
+
+func (f *fakeFilesContext) List() ([]string, error) {
+	paths := make([]string, 0, len(f.files))
+	for path := range f.files {
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+func (f *fakeFilesContext) Read(path string) (io.ReadCloser, error) {
+	content, ok := f.files[path]
+	if !ok {
+		return nil, fmt.Errorf("file not found: %s", path)
+	}
+
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+func Test__ApplyManifest__Setup(t *testing.T) {
+	component := &ApplyManifest{}
+
+	t.Run("missing file -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"source": ManifestSourceFile}})
+		require.ErrorContains(t, err, "file is required")
+	})
+
+	t.Run("file outside the repository -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"source": ManifestSourceFile, "file": "../secrets.yaml"}})
+		require.ErrorContains(t, err, "invalid file")
+	})
+
+	t.Run("missing inline manifest -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"source": ManifestSourceInline}})
+		require.ErrorContains(t, err, "manifest is required")
+	})
+}
+
+func Test__ApplyManifest__Execute(t *testing.T) {
+	component := &ApplyManifest{}
+
+	t.Run("repository file -> applies every resource", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"api-config","namespace":"production","resourceVersion":"10","managedFields":[{}]}}`),
+				jsonResponse(http.StatusOK, `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"api","namespace":"production","resourceVersion":"11","generation":4}}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"source":    ManifestSourceFile,
+				"file":      "deploy/api.yaml",
+				"namespace": "production",
+				"force":     true,
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+			Files: &fakeFilesContext{files: map[string]string{
+				"deploy/api.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: api-config\n---\napiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: api\n",
+			}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+
+		request := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPatch, request.Method)
+		assert.Equal(t, "/api/v1/namespaces/production/configmaps/api-config", request.URL.Path)
+		assert.Equal(t, "superplane", request.URL.Query().Get("fieldManager"))
+		assert.Equal(t, "true", request.URL.Query().Get("force"))
+		assert.Equal(t, "application/apply-patch+yaml", request.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		object := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &object))
+		assert.Equal(t, "production", object["metadata"].(map[string]any)["namespace"])
+
+		assert.Equal(t, "/apis/apps/v1/namespaces/production/deployments/api", httpContext.Requests[1].URL.Path)
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, ApplyManifestPayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		resources := payload["resources"].([]any)
+		require.Len(t, resources, 2)
+		assert.Equal(t, "api-config", resources[0].(map[string]any)["name"])
+		assert.Equal(t, "11", resources[1].(map[string]any)["resourceVersion"])
+	})
+
+	t.Run("conflict -> execution fails", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusConflict, `{"kind":"Status","reason":"Conflict","message":"Apply failed with 1 conflict: conflict with \"kubectl\""}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"source":   ManifestSourceInline,
+				"manifest": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: api-config\n",
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "conflict with \"kubectl\"")
+		assert.Equal(t, "/api/v1/namespaces/default/configmaps/api-config", httpContext.Requests[0].URL.Path)
+		assert.Empty(t, httpContext.Requests[0].URL.Query().Get("force"))
+	})
+}
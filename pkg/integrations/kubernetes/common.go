@@ -0,0 +1,269 @@
+package kubernetes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	KindDeployment  = "Deployment"
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+	KindReplicaSet  = "ReplicaSet"
+
+	SuccessOutputChannel = "success"
+	FailedOutputChannel  = "failed"
+)
+
+// ResourceSpec is how components reference a single object.
+type ResourceSpec struct {
+	APIVersion string `json:"apiVersion" mapstructure:"apiVersion"`
+	Kind       string `json:"kind" mapstructure:"kind"`
+	Namespace  string `json:"namespace" mapstructure:"namespace"`
+	Name       string `json:"name" mapstructure:"name"`
+}
+
+func (s ResourceSpec) validate() error {
+	if strings.TrimSpace(s.APIVersion) == "" {
+		return fmt.Errorf("apiVersion is required")
+	}
+
+	if strings.TrimSpace(s.Kind) == "" {
+		return fmt.Errorf("kind is required")
+	}
+
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	return nil
+}
+
+func (s ResourceSpec) resource() Resource {
+	return Resource{
+		APIVersion: strings.TrimSpace(s.APIVersion),
+		Kind:       strings.TrimSpace(s.Kind),
+		Namespace:  strings.TrimSpace(s.Namespace),
+		Name:       strings.TrimSpace(s.Name),
+	}
+}
+
+// apiVersionOfKind is the API version of the
+// workload kinds components select from a list.
+func apiVersionOfKind(kind string) string {
+	switch kind {
+	case KindDeployment, KindStatefulSet, KindDaemonSet, KindReplicaSet:
+		return "apps/v1"
+	}
+
+	return ""
+}
+
+func resourceFields() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "apiVersion",
+			Label:       "API version",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Default:     "apps/v1",
+			Placeholder: "apps/v1",
+			Description: "API version of the resource, like v1, apps/v1 or batch/v1",
+		},
+		{
+			Name:        "kind",
+			Label:       "Kind",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Default:     KindDeployment,
+			Placeholder: KindDeployment,
+			Description: "Kind of the resource, like Deployment, Service or ConfigMap",
+		},
+		namespaceField(),
+		{
+			Name:        "name",
+			Label:       "Name",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name of the resource",
+		},
+	}
+}
+
+func namespaceField() configuration.Field {
+	return configuration.Field{
+		Name:        "namespace",
+		Label:       "Namespace",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    false,
+		Description: "Namespace of the resource. Leave empty to use the namespace of the integration, or default.",
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type: ResourceTypeNamespace,
+			},
+		},
+	}
+}
+
+// workloadFields are the fields of components that act
+// on a workload, with the kinds they support.
+func workloadFields(kinds ...string) []configuration.Field {
+	options := make([]configuration.FieldOption, 0, len(kinds))
+	for _, kind := range kinds {
+		options = append(options, configuration.FieldOption{Label: kind, Value: kind})
+	}
+
+	return []configuration.Field{
+		{
+			Name:     "kind",
+			Label:    "Kind",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  kinds[0],
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{Options: options},
+			},
+		},
+		namespaceField(),
+		{
+			Name:        "name",
+			Label:       "Name",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Name of the workload",
+		},
+	}
+}
+
+// WorkloadSpec is how components reference a workload.
+type WorkloadSpec struct {
+	Kind      string `json:"kind" mapstructure:"kind"`
+	Namespace string `json:"namespace" mapstructure:"namespace"`
+	Name      string `json:"name" mapstructure:"name"`
+}
+
+func (s WorkloadSpec) validate(kinds ...string) error {
+	kind := strings.TrimSpace(s.Kind)
+	if kind == "" {
+		return fmt.Errorf("kind is required")
+	}
+
+	supported := false
+	for _, candidate := range kinds {
+		if candidate == kind {
+			supported = true
+			break
+		}
+	}
+
+	if !supported {
+		return fmt.Errorf("kind %s is not supported, use one of: %s", kind, strings.Join(kinds, ", "))
+	}
+
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	return nil
+}
+
+func (s WorkloadSpec) resource() Resource {
+	kind := strings.TrimSpace(s.Kind)
+	return Resource{
+		APIVersion: apiVersionOfKind(kind),
+		Kind:       kind,
+		Namespace:  strings.TrimSpace(s.Namespace),
+		Name:       strings.TrimSpace(s.Name),
+	}
+}
+
+// ParseManifests reads the objects of a multi-document YAML or JSON manifest.
+// Empty documents are skipped, and List objects are expanded into their items.
+func ParseManifests(data []byte) ([]map[string]any, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	objects := []map[string]any{}
+
+	for index := 0; ; index++ {
+		var document map[string]any
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest document %d: %w", index+1, err)
+		}
+
+		if len(document) == 0 {
+			continue
+		}
+
+		kind, _ := document["kind"].(string)
+		if strings.HasSuffix(kind, "List") {
+			items, _ := document["items"].([]any)
+			for _, item := range items {
+				object, ok := item.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("invalid item in manifest document %d", index+1)
+				}
+
+				objects = append(objects, object)
+			}
+
+			continue
+		}
+
+		objects = append(objects, document)
+	}
+
+	for index, object := range objects {
+		resource := resourceOf(object)
+		if resource.APIVersion == "" || resource.Kind == "" {
+			return nil, fmt.Errorf("object %d has no apiVersion or kind", index+1)
+		}
+
+		if resource.Name == "" {
+			return nil, fmt.Errorf("%s %d has no metadata.name", resource.Kind, index+1)
+		}
+	}
+
+	return objects, nil
+}
+
+// resourcePayload describes an object in the events components emit.
+func resourcePayload(object map[string]any) map[string]any {
+	metadata, _ := object["metadata"].(map[string]any)
+	payload := map[string]any{
+		"apiVersion": object["apiVersion"],
+		"kind":       object["kind"],
+		"name":       metadata["name"],
+	}
+
+	for _, field := range []string{"namespace", "uid", "resourceVersion", "generation"} {
+		if value, ok := metadata[field]; ok {
+			payload[field] = value
+		}
+	}
+
+	return payload
+}
+
+// withoutManagedFields drops the field manager bookkeeping,
+// which is noise for anything reading an object downstream.
+func withoutManagedFields(object map[string]any) map[string]any {
+	if metadata, ok := object["metadata"].(map[string]any); ok {
+		delete(metadata, "managedFields")
+	}
+
+	return object
+}
+
+func intPtr(value int) *int {
+	return &value
+}
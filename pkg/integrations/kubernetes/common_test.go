@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test__ParseManifests(t *testing.T) {
+	t.Run("multiple documents", func(t *testing.T) {
+		objects, err := ParseManifests([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: api-config
+data:
+  LOG_LEVEL: info
+---
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: production
+spec:
+  replicas: 3
+`))
+
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+		assert.Equal(t, Resource{APIVersion: "v1", Kind: "ConfigMap", Name: "api-config"}, resourceOf(objects[0]))
+		assert.Equal(t, Resource{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "production", Name: "api"}, resourceOf(objects[1]))
+	})
+
+	t.Run("lists are expanded", func(t *testing.T) {
+		objects, err := ParseManifests([]byte(`{
+  "apiVersion": "v1",
+  "kind": "List",
+  "items": [
+    {"apiVersion": "v1", "kind": "Service", "metadata": {"name": "api"}},
+    {"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "api-token"}}
+  ]
+}`))
+
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+		assert.Equal(t, "Service", resourceOf(objects[0]).Kind)
+		assert.Equal(t, "Secret", resourceOf(objects[1]).Kind)
+	})
+
+	t.Run("object without kind -> error", func(t *testing.T) {
+		_, err := ParseManifests([]byte("apiVersion: v1\nmetadata:\n  name: api\n"))
+		require.ErrorContains(t, err, "object 1 has no apiVersion or kind")
+	})
+
+	t.Run("object without name -> error", func(t *testing.T) {
+		_, err := ParseManifests([]byte("apiVersion: v1\nkind: ConfigMap\n"))
+		require.ErrorContains(t, err, "has no metadata.name")
+	})
+
+	t.Run("invalid YAML -> error", func(t *testing.T) {
+		_, err := ParseManifests([]byte("kind: [ConfigMap"))
+		require.ErrorContains(t, err, "invalid manifest document 1")
+	})
+}
@@ -0,0 +1,169 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	DeleteResourcePayloadType = "kubernetes.resource.deleted"
+
+	PropagationPolicyBackground = "Background"
+	PropagationPolicyForeground = "Foreground"
+	PropagationPolicyOrphan     = "Orphan"
+)
+
+type DeleteResource struct{}
+
+type DeleteResourceSpec struct {
+	ResourceSpec      `mapstructure:",squash"`
+	PropagationPolicy string `json:"propagationPolicy" mapstructure:"propagationPolicy"`
+}
+
+func (d *DeleteResource) Name() string {
+	return "kubernetes.deleteResource"
+}
+
+func (d *DeleteResource) Label() string {
+	return "Delete Resource"
+}
+
+func (d *DeleteResource) Description() string {
+	return "Delete a Kubernetes resource"
+}
+
+func (d *DeleteResource) Documentation() string {
+	return `The Delete Resource component deletes a resource of any kind.
+
+## Use Cases
+
+- **Environment teardown**: Remove preview environments once their pull request closes
+- **Cleanup**: Delete finished Jobs, stale ConfigMaps or leftover resources
+- **Incident response**: Delete a stuck pod so its controller replaces it
+
+## Configuration
+
+- **API version**: API version of the resource, like ` + "`v1`" + `, ` + "`apps/v1`" + ` or ` + "`batch/v1`" + `
+- **Kind**: Kind of the resource, like ` + "`Deployment`" + ` or ` + "`ConfigMap`" + `
+- **Namespace**: Namespace of the resource. Ignored for cluster-scoped kinds.
+- **Name**: Name of the resource
+- **Propagation policy**: How the dependents of the resource are deleted: in the background, before the resource (foreground), or not at all (orphan)
+
+## Output
+
+The deleted resource. Deleting a resource that doesn't exist succeeds, with ` + "`found`" + ` set to false.`
+}
+
+func (d *DeleteResource) Icon() string {
+	return "kubernetes"
+}
+
+func (d *DeleteResource) Color() string {
+	return "blue"
+}
+
+func (d *DeleteResource) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (d *DeleteResource) Configuration() []configuration.Field {
+	return append(resourceFields(), configuration.Field{
+		Name:     "propagationPolicy",
+		Label:    "Propagation policy",
+		Type:     configuration.FieldTypeSelect,
+		Required: false,
+		Default:  PropagationPolicyBackground,
+		TypeOptions: &configuration.TypeOptions{
+			Select: &configuration.SelectTypeOptions{
+				Options: []configuration.FieldOption{
+					{Label: "Background", Value: PropagationPolicyBackground},
+					{Label: "Foreground", Value: PropagationPolicyForeground},
+					{Label: "Orphan", Value: PropagationPolicyOrphan},
+				},
+			},
+		},
+	})
+}
+
+func (d *DeleteResource) Setup(ctx core.SetupContext) error {
+	_, err := decodeDeleteResourceSpec(ctx.Configuration)
+	return err
+}
+
+func (d *DeleteResource) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeDeleteResourceSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	resource := spec.resource()
+	found := true
+	if err := client.Delete(resource, spec.PropagationPolicy); err != nil {
+		if !IsNotFound(err) {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to delete %s: %v", resource, err))
+		}
+
+		found = false
+	}
+
+	payload := map[string]any{
+		"apiVersion": resource.APIVersion,
+		"kind":       resource.Kind,
+		"namespace":  resource.Namespace,
+		"name":       resource.Name,
+		"found":      found,
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, DeleteResourcePayloadType, []any{payload})
+}
+
+func (d *DeleteResource) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (d *DeleteResource) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (d *DeleteResource) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (d *DeleteResource) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (d *DeleteResource) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeDeleteResourceSpec(value any) (DeleteResourceSpec, error) {
+	spec := DeleteResourceSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return DeleteResourceSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := spec.validate(); err != nil {
+		return DeleteResourceSpec{}, err
+	}
+
+	spec.PropagationPolicy = strings.TrimSpace(spec.PropagationPolicy)
+	switch spec.PropagationPolicy {
+	case "":
+		spec.PropagationPolicy = PropagationPolicyBackground
+	case PropagationPolicyBackground, PropagationPolicyForeground, PropagationPolicyOrphan:
+	default:
+		return DeleteResourceSpec{}, fmt.Errorf("unknown propagation policy: %s", spec.PropagationPolicy)
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,111 @@
+package kubernetes
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__DeleteResource__Setup(t *testing.T) {
+	component := &DeleteResource{}
+
+	t.Run("missing name -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"apiVersion": "v1", "kind": "ConfigMap"}})
+		require.ErrorContains(t, err, "name is required")
+	})
+
+	t.Run("unknown propagation policy -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{
+			"apiVersion":        "v1",
+			"kind":              "ConfigMap",
+			"name":              "api-config",
+			"propagationPolicy": "Cascade",
+		}})
+
+		require.ErrorContains(t, err, "unknown propagation policy")
+	})
+}
+
+func Test__DeleteResource__Execute(t *testing.T) {
+	component := &DeleteResource{}
+
+	t.Run("deletes with the propagation policy", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"kind":"Status","status":"Success"}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"apiVersion":        "apps/v1",
+				"kind":              "Deployment",
+				"namespace":         "preview-42",
+				"name":              "api",
+				"propagationPolicy": PropagationPolicyForeground,
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		request := httpContext.Requests[0]
+		assert.Equal(t, http.MethodDelete, request.Method)
+		assert.Equal(t, "/apis/apps/v1/namespaces/preview-42/deployments/api", request.URL.Path)
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"kind":"DeleteOptions","apiVersion":"v1","propagationPolicy":"Foreground"}`, string(body))
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, DeleteResourcePayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, true, payload["found"])
+		assert.Equal(t, "api", payload["name"])
+	})
+
+	t.Run("missing resource -> passes with found false", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"apiVersion": "v1", "kind": "ConfigMap", "name": "api-config"},
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					jsonResponse(http.StatusNotFound, `{"kind":"Status","reason":"NotFound","message":"configmaps \"api-config\" not found"}`),
+				},
+			},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, executionState.Passed)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, false, payload["found"])
+	})
+
+	t.Run("forbidden -> execution fails", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"apiVersion": "v1", "kind": "ConfigMap", "name": "api-config"},
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					jsonResponse(http.StatusForbidden, `{"kind":"Status","reason":"Forbidden","message":"configmaps \"api-config\" is forbidden"}`),
+				},
+			},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to delete ConfigMap/api-config")
+	})
+}
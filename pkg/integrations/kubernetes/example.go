@@ -0,0 +1,134 @@
+package kubernetes
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_output_apply_manifest.json
+var exampleOutputApplyManifestBytes []byte
+
+//go:embed example_output_wait_for_rollout.json
+var exampleOutputWaitForRolloutBytes []byte
+
+//go:embed example_output_scale.json
+var exampleOutputScaleBytes []byte
+
+//go:embed example_output_restart.json
+var exampleOutputRestartBytes []byte
+
+//go:embed example_output_delete_resource.json
+var exampleOutputDeleteResourceBytes []byte
+
+//go:embed example_output_get_resource.json
+var exampleOutputGetResourceBytes []byte
+
+//go:embed example_output_run_job.json
+var exampleOutputRunJobBytes []byte
+
+//go:embed example_data_on_rollout_failed.json
+var exampleDataOnRolloutFailedBytes []byte
+
+//go:embed example_data_on_pod_crash_loop.json
+var exampleDataOnPodCrashLoopBytes []byte
+
+var exampleOutputApplyManifestOnce sync.Once
+var exampleOutputApplyManifest map[string]any
+
+var exampleOutputWaitForRolloutOnce sync.Once
+var exampleOutputWaitForRollout map[string]any
+
+var exampleOutputScaleOnce sync.Once
+var exampleOutputScale map[string]any
+
+var exampleOutputRestartOnce sync.Once
+var exampleOutputRestart map[string]any
+
+var exampleOutputDeleteResourceOnce sync.Once
+var exampleOutputDeleteResource map[string]any
+
+var exampleOutputGetResourceOnce sync.Once
+var exampleOutputGetResource map[string]any
+
+var exampleOutputRunJobOnce sync.Once
+var exampleOutputRunJob map[string]any
+
+var exampleDataOnRolloutFailedOnce sync.Once
+var exampleDataOnRolloutFailed map[string]any
+
+var exampleDataOnPodCrashLoopOnce sync.Once
+var exampleDataOnPodCrashLoop map[string]any
+
+func (a *ApplyManifest) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputApplyManifestOnce,
+		exampleOutputApplyManifestBytes,
+		&exampleOutputApplyManifest,
+	)
+}
+
+func (w *WaitForRollout) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputWaitForRolloutOnce,
+		exampleOutputWaitForRolloutBytes,
+		&exampleOutputWaitForRollout,
+	)
+}
+
+func (s *Scale) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputScaleOnce,
+		exampleOutputScaleBytes,
+		&exampleOutputScale,
+	)
+}
+
+func (r *Restart) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputRestartOnce,
+		exampleOutputRestartBytes,
+		&exampleOutputRestart,
+	)
+}
+
+func (d *DeleteResource) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputDeleteResourceOnce,
+		exampleOutputDeleteResourceBytes,
+		&exampleOutputDeleteResource,
+	)
+}
+
+func (g *GetResource) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputGetResourceOnce,
+		exampleOutputGetResourceBytes,
+		&exampleOutputGetResource,
+	)
+}
+
+func (r *RunJob) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputRunJobOnce,
+		exampleOutputRunJobBytes,
+		&exampleOutputRunJob,
+	)
+}
+
+func (t *OnRolloutFailed) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnRolloutFailedOnce,
+		exampleDataOnRolloutFailedBytes,
+		&exampleDataOnRolloutFailed,
+	)
+}
+
+func (t *OnPodCrashLoop) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnPodCrashLoopOnce,
+		exampleDataOnPodCrashLoopBytes,
+		&exampleDataOnPodCrashLoop,
+	)
+}
@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const GetResourcePayloadType = "kubernetes.resource"
+
+type GetResource struct{}
+
+func (g *GetResource) Name() string {
+	return "kubernetes.getResource"
+}
+
+func (g *GetResource) Label() string {
+	return "Get Resource"
+}
+
+func (g *GetResource) Description() string {
+	return "Get a Kubernetes resource"
+}
+
+func (g *GetResource) Documentation() string {
+	return `The Get Resource component reads a resource of any kind, with its spec and status.
+
+## Use Cases
+
+- **Deploy checks**: Read the image or replicas a Deployment runs before changing it
+- **Routing**: Branch a workflow on the status of a resource
+- **Lookups**: Read values from ConfigMaps, or the address of a Service or Ingress
+
+## Configuration
+
+- **API version**: API version of the resource, like ` + "`v1`" + `, ` + "`apps/v1`" + ` or ` + "`batch/v1`" + `
+- **Kind**: Kind of the resource, like ` + "`Deployment`" + ` or ` + "`ConfigMap`" + `
+- **Namespace**: Namespace of the resource. Ignored for cluster-scoped kinds.
+- **Name**: Name of the resource
+
+## Output
+
+The resource, as the API server returns it, without ` + "`metadata.managedFields`" + `. The execution fails if the resource doesn't exist.`
+}
+
+func (g *GetResource) Icon() string {
+	return "kubernetes"
+}
+
+func (g *GetResource) Color() string {
+	return "blue"
+}
+
+func (g *GetResource) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (g *GetResource) Configuration() []configuration.Field {
+	return resourceFields()
+}
+
+func (g *GetResource) Setup(ctx core.SetupContext) error {
+	_, err := decodeResourceSpec(ctx.Configuration)
+	return err
+}
+
+func (g *GetResource) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeResourceSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	resource := spec.resource()
+	object, err := client.Get(resource)
+	if err != nil {
+		if IsNotFound(err) {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("%s not found", resource))
+		}
+
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get %s: %v", resource, err))
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, GetResourcePayloadType, []any{withoutManagedFields(object)})
+}
+
+func (g *GetResource) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (g *GetResource) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (g *GetResource) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (g *GetResource) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (g *GetResource) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeResourceSpec(value any) (ResourceSpec, error) {
+	spec := ResourceSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return ResourceSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := spec.validate(); err != nil {
+		return ResourceSpec{}, err
+	}
+
+	return spec, nil
+}
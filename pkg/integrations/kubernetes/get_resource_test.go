@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GetResource__Setup(t *testing.T) {
+	component := &GetResource{}
+
+	t.Run("missing kind -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"apiVersion": "v1", "name": "api"}})
+		require.ErrorContains(t, err, "kind is required")
+	})
+}
+
+func Test__GetResource__Execute(t *testing.T) {
+	component := &GetResource{}
+
+	t.Run("custom resource -> discovers its path and emits it", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"resources":[{"name":"certificates","kind":"Certificate","namespaced":true},{"name":"certificates/status","kind":"Certificate","namespaced":true}]}`),
+				jsonResponse(http.StatusOK, `{"apiVersion":"cert-manager.io/v1","kind":"Certificate","metadata":{"name":"api-tls","namespace":"production","managedFields":[{}]},"status":{"conditions":[{"type":"Ready","status":"True"}]}}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"apiVersion": "cert-manager.io/v1",
+				"kind":       "Certificate",
+				"namespace":  "production",
+				"name":       "api-tls",
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/apis/cert-manager.io/v1", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "/apis/cert-manager.io/v1/namespaces/production/certificates/api-tls", httpContext.Requests[1].URL.Path)
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, GetResourcePayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		metadata := payload["metadata"].(map[string]any)
+		assert.Equal(t, "api-tls", metadata["name"])
+		assert.NotContains(t, metadata, "managedFields")
+	})
+
+	t.Run("missing resource -> execution fails", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"apiVersion": "v1", "kind": "Secret", "name": "api-credentials"},
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					jsonResponse(http.StatusNotFound, `{"kind":"Status","reason":"NotFound","message":"secrets \"api-credentials\" not found"}`),
+				},
+			},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Equal(t, "Secret/api-credentials not found", executionState.FailureMessage)
+	})
+}
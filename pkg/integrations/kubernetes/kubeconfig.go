@@ -0,0 +1,166 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+type kubeconfig struct {
+	CurrentContext string              `json:"current-context"`
+	Clusters       []kubeconfigCluster `json:"clusters"`
+	Contexts       []kubeconfigContext `json:"contexts"`
+	Users          []kubeconfigUser    `json:"users"`
+}
+
+type kubeconfigCluster struct {
+	Name    string `json:"name"`
+	Cluster struct {
+		Server                   string `json:"server"`
+		CertificateAuthorityData string `json:"certificate-authority-data"`
+		CertificateAuthority     string `json:"certificate-authority"`
+		InsecureSkipTLSVerify    bool   `json:"insecure-skip-tls-verify"`
+		TLSServerName            string `json:"tls-server-name"`
+	} `json:"cluster"`
+}
+
+type kubeconfigContext struct {
+	Name    string `json:"name"`
+	Context struct {
+		Cluster   string `json:"cluster"`
+		User      string `json:"user"`
+		Namespace string `json:"namespace"`
+	} `json:"context"`
+}
+
+type kubeconfigUser struct {
+	Name string `json:"name"`
+	User struct {
+		Token                 string `json:"token"`
+		TokenFile             string `json:"tokenFile"`
+		ClientCertificateData string `json:"client-certificate-data"`
+		ClientCertificate     string `json:"client-certificate"`
+		ClientKeyData         string `json:"client-key-data"`
+		ClientKey             string `json:"client-key"`
+		Username              string `json:"username"`
+		Password              string `json:"password"`
+		Exec                  any    `json:"exec"`
+		AuthProvider          any    `json:"auth-provider"`
+	} `json:"user"`
+}
+
+// credentialsFromKubeconfig resolves the credentials of a kubeconfig context,
+// or of its current context when contextName is empty. Only self-contained
+// kubeconfigs work: file references, exec plugins and auth providers need the
+// machine kubectl runs on, which SuperPlane is not.
+func credentialsFromKubeconfig(data []byte, contextName string) (*Credentials, error) {
+	config := kubeconfig{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	contextName = strings.TrimSpace(contextName)
+	if contextName == "" {
+		contextName = config.CurrentContext
+	}
+
+	if contextName == "" {
+		if len(config.Contexts) != 1 {
+			return nil, fmt.Errorf("kubeconfig has no current context, choose one")
+		}
+
+		contextName = config.Contexts[0].Name
+	}
+
+	var context *kubeconfigContext
+	for i := range config.Contexts {
+		if config.Contexts[i].Name == contextName {
+			context = &config.Contexts[i]
+			break
+		}
+	}
+
+	if context == nil {
+		return nil, fmt.Errorf("context %q not found in kubeconfig", contextName)
+	}
+
+	var cluster *kubeconfigCluster
+	for i := range config.Clusters {
+		if config.Clusters[i].Name == context.Context.Cluster {
+			cluster = &config.Clusters[i]
+			break
+		}
+	}
+
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster %q not found in kubeconfig", context.Context.Cluster)
+	}
+
+	if cluster.Cluster.CertificateAuthority != "" {
+		return nil, fmt.Errorf("cluster %q references a certificate authority file, embed it with certificate-authority-data", cluster.Name)
+	}
+
+	credentials := &Credentials{
+		Server:                strings.TrimRight(cluster.Cluster.Server, "/"),
+		InsecureSkipTLSVerify: cluster.Cluster.InsecureSkipTLSVerify,
+		TLSServerName:         cluster.Cluster.TLSServerName,
+		Namespace:             context.Context.Namespace,
+	}
+
+	if cluster.Cluster.CertificateAuthorityData != "" {
+		ca, err := base64.StdEncoding.DecodeString(cluster.Cluster.CertificateAuthorityData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate-authority-data: %w", err)
+		}
+
+		credentials.CertificateAuthority = ca
+	}
+
+	if context.Context.User == "" {
+		return credentials, nil
+	}
+
+	var user *kubeconfigUser
+	for i := range config.Users {
+		if config.Users[i].Name == context.Context.User {
+			user = &config.Users[i]
+			break
+		}
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("user %q not found in kubeconfig", context.Context.User)
+	}
+
+	switch {
+	case user.User.Exec != nil:
+		return nil, fmt.Errorf("user %q uses an exec credential plugin, which is not supported: use a service account token or OIDC instead", user.Name)
+	case user.User.AuthProvider != nil:
+		return nil, fmt.Errorf("user %q uses an auth provider, which is not supported: use a service account token or OIDC instead", user.Name)
+	case user.User.TokenFile != "", user.User.ClientCertificate != "", user.User.ClientKey != "":
+		return nil, fmt.Errorf("user %q references files, embed them with token, client-certificate-data and client-key-data", user.Name)
+	}
+
+	credentials.Token = user.User.Token
+	credentials.Username = user.User.Username
+	credentials.Password = user.User.Password
+
+	if user.User.ClientCertificateData != "" || user.User.ClientKeyData != "" {
+		certificate, err := base64.StdEncoding.DecodeString(user.User.ClientCertificateData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client-certificate-data: %w", err)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(user.User.ClientKeyData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client-key-data: %w", err)
+		}
+
+		credentials.ClientCertificate = certificate
+		credentials.ClientKey = key
+	}
+
+	return credentials, nil
+}
@@ -0,0 +1,81 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKubeconfig(user string) string {
+	ca := base64.StdEncoding.EncodeToString([]byte("ca-pem"))
+	return `
+apiVersion: v1
+kind: Config
+current-context: production
+clusters:
+- name: production
+  cluster:
+    server: https://production.example.com:6443/
+    certificate-authority-data: ` + ca + `
+- name: staging
+  cluster:
+    server: https://staging.example.com
+    insecure-skip-tls-verify: true
+contexts:
+- name: production
+  context:
+    cluster: production
+    user: deployer
+    namespace: apps
+- name: staging
+  context:
+    cluster: staging
+    user: deployer
+users:
+- name: deployer
+  user:
+` + user
+}
+
+func Test__CredentialsFromKubeconfig(t *testing.T) {
+	t.Run("current context", func(t *testing.T) {
+		credentials, err := credentialsFromKubeconfig([]byte(testKubeconfig("    token: token-123\n")), "")
+		require.NoError(t, err)
+		assert.Equal(t, "https://production.example.com:6443", credentials.Server)
+		assert.Equal(t, []byte("ca-pem"), credentials.CertificateAuthority)
+		assert.Equal(t, "token-123", credentials.Token)
+		assert.Equal(t, "apps", credentials.Namespace)
+	})
+
+	t.Run("chosen context", func(t *testing.T) {
+		credentials, err := credentialsFromKubeconfig([]byte(testKubeconfig("    username: admin\n    password: secret\n")), "staging")
+		require.NoError(t, err)
+		assert.Equal(t, "https://staging.example.com", credentials.Server)
+		assert.True(t, credentials.InsecureSkipTLSVerify)
+		assert.Equal(t, "admin", credentials.Username)
+		assert.Equal(t, "secret", credentials.Password)
+		assert.Empty(t, credentials.Namespace)
+	})
+
+	t.Run("unknown context -> error", func(t *testing.T) {
+		_, err := credentialsFromKubeconfig([]byte(testKubeconfig("    token: token-123\n")), "development")
+		require.ErrorContains(t, err, `context "development" not found`)
+	})
+
+	t.Run("exec plugin -> error", func(t *testing.T) {
+		_, err := credentialsFromKubeconfig([]byte(testKubeconfig("    exec:\n      command: aws\n")), "")
+		require.ErrorContains(t, err, "exec credential plugin")
+	})
+
+	t.Run("file references -> error", func(t *testing.T) {
+		_, err := credentialsFromKubeconfig([]byte(testKubeconfig("    client-certificate: /home/me/cert.pem\n")), "")
+		require.ErrorContains(t, err, "references files")
+	})
+
+	t.Run("invalid YAML -> error", func(t *testing.T) {
+		_, err := credentialsFromKubeconfig([]byte("clusters: ["), "")
+		require.ErrorContains(t, err, "invalid kubeconfig")
+	})
+}
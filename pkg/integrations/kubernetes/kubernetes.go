@@ -0,0 +1,426 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const (
+	AuthMethodKubeconfig          = "kubeconfig"
+	AuthMethodServiceAccountToken = "serviceAccountToken"
+	AuthMethodOIDC                = "oidc"
+
+	SecretNameOIDCToken = "oidcToken"
+
+	// OIDC tokens are short-lived, and refreshed by resyncing
+	// the integration halfway through their lifetime.
+	oidcTokenDuration = time.Hour
+
+	ResourceTypeNamespace   = "namespace"
+	ResourceTypeDeployment  = "deployment"
+	ResourceTypeStatefulSet = "statefulset"
+)
+
+func init() {
+	registry.RegisterIntegration("kubernetes", &Kubernetes{})
+}
+
+type Kubernetes struct{}
+
+type Configuration struct {
+	AuthMethod           string `json:"authMethod" mapstructure:"authMethod"`
+	Kubeconfig           string `json:"kubeconfig" mapstructure:"kubeconfig"`
+	Context              string `json:"context" mapstructure:"context"`
+	Server               string `json:"server" mapstructure:"server"`
+	CertificateAuthority string `json:"certificateAuthority" mapstructure:"certificateAuthority"`
+	Token                string `json:"token" mapstructure:"token"`
+	Audience             string `json:"audience" mapstructure:"audience"`
+}
+
+type Metadata struct {
+	Server     string `json:"server" mapstructure:"server"`
+	Version    string `json:"version" mapstructure:"version"`
+	AuthMethod string `json:"authMethod" mapstructure:"authMethod"`
+	Subject    string `json:"subject,omitempty" mapstructure:"subject"`
+}
+
+func (k *Kubernetes) Name() string {
+	return "kubernetes"
+}
+
+func (k *Kubernetes) Label() string {
+	return "Kubernetes"
+}
+
+func (k *Kubernetes) Icon() string {
+	return "kubernetes"
+}
+
+func (k *Kubernetes) Description() string {
+	return "Apply manifests, watch rollouts and react to cluster events in Kubernetes"
+}
+
+func (k *Kubernetes) Instructions() string {
+	return `Choose how SuperPlane authenticates to the API server.
+
+## Kubeconfig
+
+Paste a kubeconfig, and optionally the context to use. It must be self-contained: certificates and tokens are embedded (` + "`certificate-authority-data`" + `, ` + "`client-certificate-data`" + `, ` + "`token`" + `), since exec plugins like ` + "`aws eks get-token`" + ` and file references only work on your machine.
+
+## Service account token
+
+1. Create a service account and bind it to a role with the permissions your canvases need:
+   ` + "`kubectl create serviceaccount superplane -n kube-system`" + `
+2. Create a long-lived token for it:
+   ` + "`kubectl create token superplane -n kube-system --duration=8760h`" + `
+3. Enter the **API server URL**, the token, and the cluster **CA certificate** (PEM), unless the API server has a publicly trusted certificate.
+
+## OIDC (keyless)
+
+SuperPlane signs short-lived tokens with its own OIDC provider, and refreshes them automatically.
+
+1. Configure the API server to trust SuperPlane as an OIDC issuer: set the issuer URL to this SuperPlane instance's URL, the client ID to the **Audience** below, and the username claim to ` + "`sub`" + `.
+2. Bind the user ` + "`app-installation:<integration ID>`" + ` (with your username prefix, if any) to a role with the permissions your canvases need. The exact subject is shown in the integration details once connected.
+3. Enter the **API server URL**, the **Audience** and the cluster **CA certificate** (PEM).
+
+## Permissions
+
+Components need permission for what they do: ` + "`patch`" + ` for apply, scale and restart, ` + "`get`" + ` and ` + "`delete`" + ` on the resources they manage, ` + "`create`" + ` on jobs and ` + "`get`" + ` on ` + "`pods/log`" + ` to run jobs, and ` + "`list`" + ` on deployments and pods for the triggers.`
+}
+
+func (k *Kubernetes) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "authMethod",
+			Label:       "Authentication",
+			Type:        configuration.FieldTypeSelect,
+			Required:    true,
+			Default:     AuthMethodKubeconfig,
+			Description: "How SuperPlane authenticates to the API server",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Kubeconfig", Value: AuthMethodKubeconfig},
+						{Label: "Service account token", Value: AuthMethodServiceAccountToken},
+						{Label: "OIDC", Value: AuthMethodOIDC},
+					},
+				},
+			},
+		},
+		{
+			Name:        "kubeconfig",
+			Label:       "Kubeconfig",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Sensitive:   true,
+			Description: "Self-contained kubeconfig, with embedded certificates and credentials",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodKubeconfig}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "authMethod", Values: []string{AuthMethodKubeconfig}},
+			},
+		},
+		{
+			Name:        "context",
+			Label:       "Context",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Kubeconfig context to use. Leave empty to use the current context.",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodKubeconfig}},
+			},
+		},
+		{
+			Name:        "server",
+			Label:       "API server URL",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "https://my-cluster.example.com:6443",
+			Description: "URL of the Kubernetes API server",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodServiceAccountToken, AuthMethodOIDC}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "authMethod", Values: []string{AuthMethodServiceAccountToken, AuthMethodOIDC}},
+			},
+		},
+		{
+			Name:        "certificateAuthority",
+			Label:       "CA certificate",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "PEM certificate of the cluster CA. Leave empty if the API server has a publicly trusted certificate.",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodServiceAccountToken, AuthMethodOIDC}},
+			},
+		},
+		{
+			Name:        "token",
+			Label:       "Service account token",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Sensitive:   true,
+			Description: "Bearer token of the service account",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodServiceAccountToken}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "authMethod", Values: []string{AuthMethodServiceAccountToken}},
+			},
+		},
+		{
+			Name:        "audience",
+			Label:       "Audience",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "superplane",
+			Description: "Audience of the signed tokens. Must match the client ID of the API server OIDC configuration.",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodOIDC}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "authMethod", Values: []string{AuthMethodOIDC}},
+			},
+		},
+	}
+}
+
+func (k *Kubernetes) Actions() []core.Action {
+	return []core.Action{
+		&ApplyManifest{},
+		&WaitForRollout{},
+		&Scale{},
+		&Restart{},
+		&DeleteResource{},
+		&GetResource{},
+		&RunJob{},
+	}
+}
+
+func (k *Kubernetes) Triggers() []core.Trigger {
+	return []core.Trigger{
+		&OnRolloutFailed{},
+		&OnPodCrashLoop{},
+	}
+}
+
+func (k *Kubernetes) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (k *Kubernetes) Sync(ctx core.SyncContext) error {
+	config := Configuration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	metadata := Metadata{AuthMethod: authMethodOrDefault(config.AuthMethod)}
+
+	if metadata.AuthMethod == AuthMethodOIDC {
+		subject, err := k.refreshOIDCToken(ctx, config)
+		if err != nil {
+			return err
+		}
+
+		metadata.Subject = subject
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	version, err := client.GetVersion()
+	if err != nil {
+		return fmt.Errorf("failed to reach the Kubernetes API server: %w", err)
+	}
+
+	//
+	// The version endpoint is often public,
+	// so check the credentials with a request that needs them.
+	//
+	if _, err := client.ListNamespaces(); err != nil && !isForbidden(err) {
+		return fmt.Errorf("failed to verify Kubernetes credentials: %w", err)
+	}
+
+	metadata.Server = client.credentials.Server
+	metadata.Version, _ = version["gitVersion"].(string)
+	ctx.Integration.SetMetadata(metadata)
+
+	if metadata.AuthMethod == AuthMethodOIDC {
+		if err := ctx.Integration.ScheduleResync(oidcTokenDuration / 2); err != nil {
+			ctx.Logger.Warnf("could not schedule Kubernetes OIDC token refresh: %v", err)
+		}
+	}
+
+	ctx.Integration.Ready()
+	return nil
+}
+
+func (k *Kubernetes) refreshOIDCToken(ctx core.SyncContext, config Configuration) (string, error) {
+	audience := strings.TrimSpace(config.Audience)
+	if audience == "" {
+		return "", fmt.Errorf("audience is required")
+	}
+
+	if ctx.OIDC == nil {
+		return "", fmt.Errorf("OIDC provider is not configured on this SuperPlane instance")
+	}
+
+	subject := fmt.Sprintf("app-installation:%s", ctx.Integration.ID())
+	token, err := ctx.OIDC.Sign(subject, oidcTokenDuration, audience, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate OIDC token: %w", err)
+	}
+
+	if err := ctx.Integration.SetSecret(SecretNameOIDCToken, []byte(token)); err != nil {
+		return "", fmt.Errorf("failed to store OIDC token: %w", err)
+	}
+
+	return subject, nil
+}
+
+func (k *Kubernetes) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (k *Kubernetes) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resourceType {
+	case ResourceTypeNamespace:
+		namespaces, err := client.ListNamespaces()
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(namespaces))
+		for _, namespace := range namespaces {
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: namespace.Name, ID: namespace.Name})
+		}
+
+		return resources, nil
+
+	case ResourceTypeDeployment:
+		deployments, err := client.ListDeployments(namespaceParameter(client, ctx.Parameters), "")
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(deployments))
+		for _, deployment := range deployments {
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: deployment.Metadata.Name, ID: deployment.Metadata.Name})
+		}
+
+		return resources, nil
+
+	case ResourceTypeStatefulSet:
+		statefulSets, err := client.ListStatefulSets(namespaceParameter(client, ctx.Parameters))
+		if err != nil {
+			return nil, err
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(statefulSets))
+		for _, statefulSet := range statefulSets {
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: statefulSet.Metadata.Name, ID: statefulSet.Metadata.Name})
+		}
+
+		return resources, nil
+
+	default:
+		return []core.IntegrationResource{}, nil
+	}
+}
+
+func (k *Kubernetes) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (k *Kubernetes) HandleHook(ctx core.IntegrationHookContext) error {
+	return nil
+}
+
+func namespaceParameter(client *Client, parameters map[string]string) string {
+	if namespace := strings.TrimSpace(parameters["namespace"]); namespace != "" {
+		return namespace
+	}
+
+	return client.DefaultNamespace()
+}
+
+func authMethodOrDefault(method string) string {
+	if strings.TrimSpace(method) == "" {
+		return AuthMethodKubeconfig
+	}
+
+	return strings.TrimSpace(method)
+}
+
+func credentialsFromIntegration(integration core.IntegrationContext) (*Credentials, error) {
+	switch method := authMethodOrDefault(optionalConfig(integration, "authMethod")); method {
+	case AuthMethodKubeconfig:
+		kubeconfig := optionalConfig(integration, "kubeconfig")
+		if kubeconfig == "" {
+			return nil, fmt.Errorf("kubeconfig is required")
+		}
+
+		return credentialsFromKubeconfig([]byte(kubeconfig), optionalConfig(integration, "context"))
+
+	case AuthMethodServiceAccountToken:
+		token := optionalConfig(integration, "token")
+		if token == "" {
+			return nil, fmt.Errorf("service account token is required")
+		}
+
+		return &Credentials{
+			Server:               strings.TrimRight(optionalConfig(integration, "server"), "/"),
+			CertificateAuthority: []byte(optionalConfig(integration, "certificateAuthority")),
+			Token:                token,
+		}, nil
+
+	case AuthMethodOIDC:
+		secrets, err := integration.GetSecrets()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OIDC token: %w", err)
+		}
+
+		token := ""
+		for _, secret := range secrets {
+			if secret.Name == SecretNameOIDCToken {
+				token = string(secret.Value)
+			}
+		}
+
+		if token == "" {
+			return nil, fmt.Errorf("OIDC token is missing, sync the integration")
+		}
+
+		return &Credentials{
+			Server:               strings.TrimRight(optionalConfig(integration, "server"), "/"),
+			CertificateAuthority: []byte(optionalConfig(integration, "certificateAuthority")),
+			Token:                token,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown authentication method: %s", method)
+	}
+}
+
+func optionalConfig(integration core.IntegrationContext, name string) string {
+	value, err := integration.GetConfig(name)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(value))
+}
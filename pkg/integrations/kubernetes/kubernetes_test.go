@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Kubernetes__Sync(t *testing.T) {
+	integration := &Kubernetes{}
+
+	t.Run("service account token -> ready", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"gitVersion":"v1.31.2"}`),
+				jsonResponse(http.StatusOK, `{"items":[{"metadata":{"name":"default"}}]}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "https://cluster.example.com/version", httpContext.Requests[0].URL.String())
+		assert.Equal(t, "Bearer token-123", httpContext.Requests[1].Header.Get("Authorization"))
+		assert.Equal(t, "/api/v1/namespaces", httpContext.Requests[1].URL.Path)
+
+		metadata, ok := integrationCtx.Metadata.(Metadata)
+		require.True(t, ok)
+		assert.Equal(t, "v1.31.2", metadata.Version)
+		assert.Equal(t, AuthMethodServiceAccountToken, metadata.AuthMethod)
+	})
+
+	t.Run("RBAC denies listing namespaces -> ready", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"gitVersion":"v1.31.2"}`),
+				jsonResponse(http.StatusForbidden, `{"kind":"Status","reason":"Forbidden","message":"namespaces is forbidden"}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+	})
+
+	t.Run("invalid token -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"gitVersion":"v1.31.2"}`),
+				jsonResponse(http.StatusUnauthorized, `{"kind":"Status","reason":"Unauthorized","message":"Unauthorized"}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "failed to verify Kubernetes credentials")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+	})
+
+	t.Run("OIDC -> signs a token and schedules its refresh", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"gitVersion":"v1.31.2"}`),
+				jsonResponse(http.StatusOK, `{"items":[]}`),
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			IntegrationID: "11111111-2222-3333-4444-555555555555",
+			Configuration: map[string]any{
+				"authMethod": AuthMethodOIDC,
+				"server":     "https://cluster.example.com",
+				"audience":   "superplane",
+			},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+			OIDC:          support.NewOIDCProvider(),
+		})
+
+		require.NoError(t, err)
+		token := integrationCtx.CurrentSecrets[SecretNameOIDCToken]
+		require.NotEmpty(t, token.Value)
+		assert.Equal(t, "Bearer "+string(token.Value), httpContext.Requests[1].Header.Get("Authorization"))
+		assert.Equal(t, []time.Duration{30 * time.Minute}, integrationCtx.ResyncRequests)
+
+		metadata := integrationCtx.Metadata.(Metadata)
+		assert.Equal(t, "app-installation:11111111-2222-3333-4444-555555555555", metadata.Subject)
+	})
+
+	t.Run("OIDC without audience -> error", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"authMethod": AuthMethodOIDC,
+				"server":     "https://cluster.example.com",
+			},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   integrationCtx,
+			OIDC:          support.NewOIDCProvider(),
+		})
+
+		require.ErrorContains(t, err, "audience is required")
+	})
+}
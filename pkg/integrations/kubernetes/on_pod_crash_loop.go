@@ -0,0 +1,257 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	OnPodCrashLoopPayloadType  = "kubernetes.pod.crashLoop"
+	OnPodCrashLoopPollAction   = "poll"
+	OnPodCrashLoopPollInterval = 1 * time.Minute
+
+	crashLoopBackOffReason = "CrashLoopBackOff"
+)
+
+type OnPodCrashLoop struct{}
+
+type OnPodCrashLoopConfiguration struct {
+	Namespace     string `json:"namespace" mapstructure:"namespace"`
+	LabelSelector string `json:"labelSelector" mapstructure:"labelSelector"`
+	MinRestarts   int    `json:"minRestarts" mapstructure:"minRestarts"`
+}
+
+// OnPodCrashLoopMetadata remembers the containers that are crash looping,
+// so each crash loop only fires once, until the container recovers.
+type OnPodCrashLoopMetadata struct {
+	CrashLooping []string `json:"crashLooping" mapstructure:"crashLooping"`
+}
+
+func (t *OnPodCrashLoop) Name() string {
+	return "kubernetes.onPodCrashLoop"
+}
+
+func (t *OnPodCrashLoop) Label() string {
+	return "On Pod Crash Loop"
+}
+
+func (t *OnPodCrashLoop) Description() string {
+	return "Listen to pods whose containers are crash looping"
+}
+
+func (t *OnPodCrashLoop) Documentation() string {
+	return `The On Pod Crash Loop trigger starts a workflow when a container of a pod keeps crashing, and Kubernetes backs off restarting it (` + "`CrashLoopBackOff`" + `).
+
+## Use Cases
+
+- **Alerting**: Notify the owning team with the exit code and reason of the last crash
+- **Automatic rollbacks**: Roll back a Deployment whose new pods crash on start
+- **Diagnostics**: Collect logs and events of the crashing pod into a ticket
+
+## Configuration
+
+- **Namespace**: Namespace to watch. Defaults to the namespace of the integration, or ` + "`default`" + `.
+- **Label selector**: Only watch pods matching the selector, like ` + "`app=api`" + `
+- **Minimum restarts**: Only fire once a container restarted at least this many times
+
+## How It Works
+
+SuperPlane checks the pods every minute, and fires once for each crash looping container. It fires again if the container recovers and starts crash looping again later.`
+}
+
+func (t *OnPodCrashLoop) Icon() string {
+	return "kubernetes"
+}
+
+func (t *OnPodCrashLoop) Color() string {
+	return "blue"
+}
+
+func (t *OnPodCrashLoop) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "namespace",
+			Label:       "Namespace",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Namespace to watch",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeNamespace,
+				},
+			},
+		},
+		{
+			Name:        "labelSelector",
+			Label:       "Label selector",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "app=api",
+			Description: "Only watch pods matching the selector",
+		},
+		{
+			Name:        "minRestarts",
+			Label:       "Minimum restarts",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     0,
+			Description: "Only fire once a container restarted at least this many times",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(0)},
+			},
+		},
+	}
+}
+
+func (t *OnPodCrashLoop) Setup(ctx core.TriggerContext) error {
+	if _, err := decodeOnPodCrashLoopConfiguration(ctx.Configuration); err != nil {
+		return err
+	}
+
+	if ctx.Integration == nil {
+		return fmt.Errorf("missing integration context")
+	}
+
+	return ctx.Requests.ScheduleActionCall(OnPodCrashLoopPollAction, map[string]any{}, OnPodCrashLoopPollInterval)
+}
+
+func (t *OnPodCrashLoop) Hooks() []core.Hook {
+	return []core.Hook{{Name: OnPodCrashLoopPollAction, Type: core.HookTypeInternal}}
+}
+
+func (t *OnPodCrashLoop) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	switch ctx.Name {
+	case OnPodCrashLoopPollAction:
+		return nil, t.poll(ctx)
+	default:
+		return nil, fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (t *OnPodCrashLoop) poll(ctx core.TriggerHookContext) error {
+	config, err := decodeOnPodCrashLoopConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	metadata := OnPodCrashLoopMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode trigger metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	namespace := config.Namespace
+	if namespace == "" {
+		namespace = client.DefaultNamespace()
+	}
+
+	pods, err := client.ListPods(namespace, config.LabelSelector)
+	if err != nil {
+		if ctx.Logger != nil {
+			ctx.Logger.Warnf("failed to list pods in %s: %v", namespace, err)
+		}
+
+		return ctx.Requests.ScheduleActionCall(OnPodCrashLoopPollAction, map[string]any{}, OnPodCrashLoopPollInterval)
+	}
+
+	alreadyFired := map[string]bool{}
+	for _, key := range metadata.CrashLooping {
+		alreadyFired[key] = true
+	}
+
+	//
+	// Only containers that still crash loop are kept,
+	// which lets a container that recovered fire again.
+	//
+	crashLooping := []string{}
+	for _, pod := range pods {
+		for _, container := range pod.Status.ContainerStatuses {
+			if !isCrashLooping(container, config.MinRestarts) {
+				continue
+			}
+
+			key := pod.Metadata.UID + "/" + container.Name
+			crashLooping = append(crashLooping, key)
+			if alreadyFired[key] {
+				continue
+			}
+
+			if err := ctx.Events.Emit(OnPodCrashLoopPayloadType, crashLoopPayload(&pod, container)); err != nil {
+				return fmt.Errorf("failed to emit event: %w", err)
+			}
+		}
+	}
+
+	if err := ctx.Metadata.Set(OnPodCrashLoopMetadata{CrashLooping: crashLooping}); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(OnPodCrashLoopPollAction, map[string]any{}, OnPodCrashLoopPollInterval)
+}
+
+func (t *OnPodCrashLoop) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnPodCrashLoop) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+func decodeOnPodCrashLoopConfiguration(value any) (OnPodCrashLoopConfiguration, error) {
+	config := OnPodCrashLoopConfiguration{}
+	if err := mapstructure.WeakDecode(value, &config); err != nil {
+		return OnPodCrashLoopConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Namespace = strings.TrimSpace(config.Namespace)
+	config.LabelSelector = strings.TrimSpace(config.LabelSelector)
+	if config.MinRestarts < 0 {
+		return OnPodCrashLoopConfiguration{}, fmt.Errorf("minimum restarts must not be negative")
+	}
+
+	return config, nil
+}
+
+func isCrashLooping(container ContainerStatus, minRestarts int) bool {
+	if container.State.Waiting == nil || container.State.Waiting.Reason != crashLoopBackOffReason {
+		return false
+	}
+
+	return int(container.RestartCount) >= minRestarts
+}
+
+func crashLoopPayload(pod *Pod, container ContainerStatus) map[string]any {
+	payload := map[string]any{
+		"namespace":    pod.Metadata.Namespace,
+		"pod":          pod.Metadata.Name,
+		"uid":          pod.Metadata.UID,
+		"labels":       pod.Metadata.Labels,
+		"node":         pod.Spec.NodeName,
+		"container":    container.Name,
+		"image":        container.Image,
+		"restartCount": container.RestartCount,
+		"reason":       container.State.Waiting.Reason,
+		"message":      container.State.Waiting.Message,
+	}
+
+	if terminated := container.LastState.Terminated; terminated != nil {
+		payload["lastTermination"] = map[string]any{
+			"exitCode":   terminated.ExitCode,
+			"reason":     terminated.Reason,
+			"message":    terminated.Message,
+			"finishedAt": terminated.FinishedAt,
+		}
+	}
+
+	return payload
+}
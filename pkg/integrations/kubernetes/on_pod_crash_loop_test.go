@@ -0,0 +1,110 @@
+package kubernetes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const crashLoopingPods = `{"items":[
+	{
+		"metadata":{"name":"api-1","namespace":"production","uid":"pod-1"},
+		"status":{"containerStatuses":[
+			{"name":"api","restartCount":4,"state":{"waiting":{"reason":"CrashLoopBackOff"}},"lastTerminationState":{"terminated":{"exitCode":1,"reason":"Error"}}},
+			{"name":"proxy","restartCount":0,"state":{"running":{}}}
+		]}
+	},
+	{
+		"metadata":{"name":"api-2","namespace":"production","uid":"pod-2"},
+		"status":{"containerStatuses":[{"name":"api","restartCount":1,"state":{"waiting":{"reason":"CrashLoopBackOff"}}}]}
+	}
+]}`
+
+func Test__OnPodCrashLoop(t *testing.T) {
+	trigger := &OnPodCrashLoop{}
+
+	t.Run("setup schedules a poll", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"namespace": "production"},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Requests:      requests,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, OnPodCrashLoopPollAction, requests.Action)
+		assert.Equal(t, OnPodCrashLoopPollInterval, requests.Duration)
+	})
+
+	t.Run("fires once for each crash looping container", func(t *testing.T) {
+		configuration := map[string]any{"namespace": "production", "labelSelector": "app=api", "minRestarts": 3}
+		metadata := &contexts.MetadataContext{}
+		events := &contexts.EventContext{}
+
+		poll := func() {
+			_, err := trigger.HandleHook(core.TriggerHookContext{
+				Name:          OnPodCrashLoopPollAction,
+				Configuration: configuration,
+				HTTP:          &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, crashLoopingPods)}},
+				Integration:   testIntegration(),
+				Metadata:      metadata,
+				Requests:      &contexts.RequestContext{},
+				Events:        events,
+			})
+			require.NoError(t, err)
+		}
+
+		poll()
+		require.Len(t, events.Payloads, 1)
+		assert.Equal(t, OnPodCrashLoopPayloadType, events.Payloads[0].Type)
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "api-1", payload["pod"])
+		assert.Equal(t, "api", payload["container"])
+		assert.Equal(t, int32(1), payload["lastTermination"].(map[string]any)["exitCode"])
+		assert.Equal(t, []string{"pod-1/api"}, metadata.Metadata.(OnPodCrashLoopMetadata).CrashLooping)
+
+		poll()
+		assert.Len(t, events.Payloads, 1)
+	})
+
+	t.Run("recovered containers fire again", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{Metadata: OnPodCrashLoopMetadata{CrashLooping: []string{"pod-1/api", "pod-3/api"}}}
+		events := &contexts.EventContext{}
+
+		_, err := trigger.HandleHook(core.TriggerHookContext{
+			Name:          OnPodCrashLoopPollAction,
+			Configuration: map[string]any{},
+			HTTP:          &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, crashLoopingPods)}},
+			Integration:   testIntegration(),
+			Metadata:      metadata,
+			Requests:      &contexts.RequestContext{},
+			Events:        events,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, events.Payloads, 1)
+		assert.Equal(t, "api-2", events.Payloads[0].Data.(map[string]any)["pod"])
+		assert.Equal(t, []string{"pod-1/api", "pod-2/api"}, metadata.Metadata.(OnPodCrashLoopMetadata).CrashLooping)
+	})
+
+	t.Run("cluster unreachable -> keeps polling", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		_, err := trigger.HandleHook(core.TriggerHookContext{
+			Name:          OnPodCrashLoopPollAction,
+			Configuration: map[string]any{},
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Requests:      requests,
+			Events:        &contexts.EventContext{},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, OnPodCrashLoopPollAction, requests.Action)
+	})
+}
@@ -0,0 +1,244 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	OnRolloutFailedPayloadType  = "kubernetes.rollout.failed"
+	OnRolloutFailedPollAction   = "poll"
+	OnRolloutFailedPollInterval = 1 * time.Minute
+)
+
+type OnRolloutFailed struct{}
+
+type OnRolloutFailedConfiguration struct {
+	Namespace     string `json:"namespace" mapstructure:"namespace"`
+	LabelSelector string `json:"labelSelector" mapstructure:"labelSelector"`
+}
+
+// OnRolloutFailedMetadata remembers the rollouts that already failed,
+// so each failed rollout only fires once, however long it stays failed.
+type OnRolloutFailedMetadata struct {
+	Initialized bool     `json:"initialized" mapstructure:"initialized"`
+	Failed      []string `json:"failed" mapstructure:"failed"`
+}
+
+func (t *OnRolloutFailed) Name() string {
+	return "kubernetes.onRolloutFailed"
+}
+
+func (t *OnRolloutFailed) Label() string {
+	return "On Rollout Failed"
+}
+
+func (t *OnRolloutFailed) Description() string {
+	return "Listen to Deployment rollouts that exceed their progress deadline"
+}
+
+func (t *OnRolloutFailed) Documentation() string {
+	return `The On Rollout Failed trigger starts a workflow when a Deployment rollout fails.
+
+## Use Cases
+
+- **Automatic rollbacks**: Roll back to the previous version when a rollout gets stuck
+- **Alerting**: Notify the owning team with the reason of the failure
+- **Incident management**: Open an incident for failed production rollouts
+
+## Configuration
+
+- **Namespace**: Namespace to watch. Defaults to the namespace of the integration, or ` + "`default`" + `.
+- **Label selector**: Only watch Deployments matching the selector, like ` + "`app=api,tier!=canary`" + `
+
+## How It Works
+
+Kubernetes marks a rollout as failed when it makes no progress for the ` + "`progressDeadlineSeconds`" + ` of the Deployment, 10 minutes by default. SuperPlane checks the Deployments every minute, and fires once for each failed rollout. Rollouts that had already failed when the trigger was set up don't fire.`
+}
+
+func (t *OnRolloutFailed) Icon() string {
+	return "kubernetes"
+}
+
+func (t *OnRolloutFailed) Color() string {
+	return "blue"
+}
+
+func (t *OnRolloutFailed) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "namespace",
+			Label:       "Namespace",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    false,
+			Description: "Namespace to watch",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeNamespace,
+				},
+			},
+		},
+		{
+			Name:        "labelSelector",
+			Label:       "Label selector",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "app=api",
+			Description: "Only watch Deployments matching the selector",
+		},
+	}
+}
+
+func (t *OnRolloutFailed) Setup(ctx core.TriggerContext) error {
+	if _, err := decodeOnRolloutFailedConfiguration(ctx.Configuration); err != nil {
+		return err
+	}
+
+	if ctx.Integration == nil {
+		return fmt.Errorf("missing integration context")
+	}
+
+	if err := ctx.Metadata.Set(OnRolloutFailedMetadata{}); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(OnRolloutFailedPollAction, map[string]any{}, OnRolloutFailedPollInterval)
+}
+
+func (t *OnRolloutFailed) Hooks() []core.Hook {
+	return []core.Hook{{Name: OnRolloutFailedPollAction, Type: core.HookTypeInternal}}
+}
+
+func (t *OnRolloutFailed) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	switch ctx.Name {
+	case OnRolloutFailedPollAction:
+		return nil, t.poll(ctx)
+	default:
+		return nil, fmt.Errorf("unknown action: %s", ctx.Name)
+	}
+}
+
+func (t *OnRolloutFailed) poll(ctx core.TriggerHookContext) error {
+	config, err := decodeOnRolloutFailedConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	metadata := OnRolloutFailedMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode trigger metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	namespace := config.Namespace
+	if namespace == "" {
+		namespace = client.DefaultNamespace()
+	}
+
+	deployments, err := client.ListDeployments(namespace, config.LabelSelector)
+	if err != nil {
+		//
+		// The cluster might be unreachable for a while,
+		// so keep polling instead of giving up.
+		//
+		if ctx.Logger != nil {
+			ctx.Logger.Warnf("failed to list deployments in %s: %v", namespace, err)
+		}
+
+		return ctx.Requests.ScheduleActionCall(OnRolloutFailedPollAction, map[string]any{}, OnRolloutFailedPollInterval)
+	}
+
+	previouslyFailed := map[string]bool{}
+	for _, key := range metadata.Failed {
+		previouslyFailed[key] = true
+	}
+
+	failed := []string{}
+	for _, deployment := range deployments {
+		condition := failedRolloutCondition(&deployment)
+		if condition == nil {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%d", deployment.Metadata.UID, deployment.Metadata.Generation)
+		failed = append(failed, key)
+		if previouslyFailed[key] || !metadata.Initialized {
+			continue
+		}
+
+		if err := ctx.Events.Emit(OnRolloutFailedPayloadType, rolloutFailedPayload(&deployment, condition)); err != nil {
+			return fmt.Errorf("failed to emit event: %w", err)
+		}
+	}
+
+	if err := ctx.Metadata.Set(OnRolloutFailedMetadata{Initialized: true, Failed: failed}); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(OnRolloutFailedPollAction, map[string]any{}, OnRolloutFailedPollInterval)
+}
+
+func (t *OnRolloutFailed) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnRolloutFailed) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
+
+func decodeOnRolloutFailedConfiguration(value any) (OnRolloutFailedConfiguration, error) {
+	config := OnRolloutFailedConfiguration{}
+	if err := mapstructure.Decode(value, &config); err != nil {
+		return OnRolloutFailedConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Namespace = strings.TrimSpace(config.Namespace)
+	config.LabelSelector = strings.TrimSpace(config.LabelSelector)
+	return config, nil
+}
+
+// failedRolloutCondition returns the Progressing condition of a Deployment
+// whose latest rollout exceeded its progress deadline, if it did.
+func failedRolloutCondition(deployment *Deployment) *Condition {
+	if deployment.Metadata.Generation > deployment.Status.ObservedGeneration {
+		return nil
+	}
+
+	for i, condition := range deployment.Status.Conditions {
+		if condition.Type == "Progressing" && condition.Reason == "ProgressDeadlineExceeded" {
+			return &deployment.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+func rolloutFailedPayload(deployment *Deployment, condition *Condition) map[string]any {
+	return map[string]any{
+		"namespace":  deployment.Metadata.Namespace,
+		"name":       deployment.Metadata.Name,
+		"uid":        deployment.Metadata.UID,
+		"generation": deployment.Metadata.Generation,
+		"labels":     deployment.Metadata.Labels,
+		"reason":     condition.Reason,
+		"message":    condition.Message,
+		"failedAt":   condition.LastTransitionTime,
+		"replicas": map[string]any{
+			"desired":     desiredReplicas(deployment.Spec.Replicas),
+			"updated":     deployment.Status.UpdatedReplicas,
+			"ready":       deployment.Status.ReadyReplicas,
+			"available":   deployment.Status.AvailableReplicas,
+			"unavailable": deployment.Status.UnavailableReplicas,
+		},
+	}
+}
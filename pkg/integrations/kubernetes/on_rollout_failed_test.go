@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const failedDeployments = `{"items":[
+	{
+		"metadata":{"name":"api","namespace":"production","uid":"deploy-1","generation":7},
+		"spec":{"replicas":3},
+		"status":{"observedGeneration":7,"updatedReplicas":1,"conditions":[{"type":"Progressing","status":"False","reason":"ProgressDeadlineExceeded","message":"ReplicaSet \"api-7c9d8b6f5\" has timed out progressing."}]}
+	},
+	{
+		"metadata":{"name":"worker","namespace":"production","uid":"deploy-2","generation":3},
+		"status":{"observedGeneration":3,"conditions":[{"type":"Progressing","status":"True","reason":"NewReplicaSetAvailable"}]}
+	}
+]}`
+
+func Test__OnRolloutFailed(t *testing.T) {
+	trigger := &OnRolloutFailed{}
+
+	poll := func(metadata *contexts.MetadataContext, events *contexts.EventContext) *contexts.HTTPContext {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, failedDeployments)}}
+		_, err := trigger.HandleHook(core.TriggerHookContext{
+			Name:          OnRolloutFailedPollAction,
+			Configuration: map[string]any{"namespace": "production", "labelSelector": "tier=backend"},
+			HTTP:          httpContext,
+			Integration:   testIntegration(),
+			Metadata:      metadata,
+			Requests:      &contexts.RequestContext{},
+			Events:        events,
+		})
+		require.NoError(t, err)
+		return httpContext
+	}
+
+	t.Run("rollouts failed before setup don't fire", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"namespace": "production"},
+			Integration:   testIntegration(),
+			Metadata:      metadata,
+			Requests:      &contexts.RequestContext{},
+		})
+		require.NoError(t, err)
+
+		events := &contexts.EventContext{}
+		httpContext := poll(metadata, events)
+
+		assert.Empty(t, events.Payloads)
+		assert.Equal(t, "/apis/apps/v1/namespaces/production/deployments", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "tier=backend", httpContext.Requests[0].URL.Query().Get("labelSelector"))
+		assert.Equal(t, OnRolloutFailedMetadata{Initialized: true, Failed: []string{"deploy-1/7"}}, metadata.Metadata)
+	})
+
+	t.Run("new failed rollouts fire once", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{Metadata: OnRolloutFailedMetadata{Initialized: true, Failed: []string{"deploy-1/6"}}}
+		events := &contexts.EventContext{}
+
+		poll(metadata, events)
+		require.Len(t, events.Payloads, 1)
+		assert.Equal(t, OnRolloutFailedPayloadType, events.Payloads[0].Type)
+		payload := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "api", payload["name"])
+		assert.Equal(t, "ProgressDeadlineExceeded", payload["reason"])
+
+		poll(metadata, events)
+		assert.Len(t, events.Payloads, 1)
+	})
+}
@@ -0,0 +1,128 @@
+package kubernetes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const RestartPayloadType = "kubernetes.workload.restarted"
+
+type Restart struct{}
+
+func (r *Restart) Name() string {
+	return "kubernetes.restart"
+}
+
+func (r *Restart) Label() string {
+	return "Restart"
+}
+
+func (r *Restart) Description() string {
+	return "Restart the pods of a Deployment, StatefulSet or DaemonSet"
+}
+
+func (r *Restart) Documentation() string {
+	return `The Restart component starts a new rollout of a workload, replacing all of its pods, like ` + "`kubectl rollout restart`" + ` does.
+
+## Use Cases
+
+- **Configuration reloads**: Pick up changed ConfigMaps and Secrets that pods only read on start
+- **Incident response**: Restart a workload that leaks memory or holds stale connections
+- **Image refreshes**: Pull a mutable tag again
+
+## Configuration
+
+- **Kind**: Deployment, StatefulSet or DaemonSet
+- **Namespace**: Namespace of the workload. Defaults to the namespace of the integration, or ` + "`default`" + `.
+- **Name**: Name of the workload
+
+## Output
+
+The workload and the time of the restart. The rollout respects the update strategy of the workload. Combine it with **Wait for Rollout** to wait for it to finish.`
+}
+
+func (r *Restart) Icon() string {
+	return "kubernetes"
+}
+
+func (r *Restart) Color() string {
+	return "blue"
+}
+
+func (r *Restart) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (r *Restart) Configuration() []configuration.Field {
+	return workloadFields(KindDeployment, KindStatefulSet, KindDaemonSet)
+}
+
+func (r *Restart) Setup(ctx core.SetupContext) error {
+	_, err := decodeRestartSpec(ctx.Configuration)
+	return err
+}
+
+func (r *Restart) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeRestartSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	resource := spec.resource()
+	if resource.Namespace == "" {
+		resource.Namespace = client.DefaultNamespace()
+	}
+
+	restartedAt := time.Now().UTC().Format(time.RFC3339)
+	object, err := client.Restart(resource, restartedAt)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to restart %s: %v", resource, err))
+	}
+
+	payload := resourcePayload(object)
+	payload["restartedAt"] = restartedAt
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, RestartPayloadType, []any{payload})
+}
+
+func (r *Restart) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (r *Restart) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (r *Restart) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (r *Restart) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (r *Restart) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeRestartSpec(value any) (WorkloadSpec, error) {
+	spec := WorkloadSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return WorkloadSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := spec.validate(KindDeployment, KindStatefulSet, KindDaemonSet); err != nil {
+		return WorkloadSpec{}, err
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,85 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Restart__Setup(t *testing.T) {
+	component := &Restart{}
+
+	t.Run("unsupported kind -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"kind": KindReplicaSet, "name": "api-6d4cf56db6"}})
+		require.ErrorContains(t, err, "kind ReplicaSet is not supported")
+	})
+
+	t.Run("missing name -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"kind": KindDeployment}})
+		require.ErrorContains(t, err, "name is required")
+	})
+}
+
+func Test__Restart__Execute(t *testing.T) {
+	component := &Restart{}
+
+	t.Run("stamps the pod template -> emits the workload", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"apiVersion":"apps/v1","kind":"DaemonSet","metadata":{"name":"agent","namespace":"monitoring","resourceVersion":"42","generation":7}}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"kind": KindDaemonSet, "namespace": "monitoring", "name": "agent"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		request := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPatch, request.Method)
+		assert.Equal(t, "/apis/apps/v1/namespaces/monitoring/daemonsets/agent", request.URL.Path)
+		assert.Equal(t, "application/strategic-merge-patch+json", request.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		patch := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &patch))
+		annotations := patch["spec"].(map[string]any)["template"].(map[string]any)["metadata"].(map[string]any)["annotations"].(map[string]any)
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, RestartPayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "agent", payload["name"])
+		assert.Equal(t, float64(7), payload["generation"])
+		assert.Equal(t, annotations["kubectl.kubernetes.io/restartedAt"], payload["restartedAt"])
+	})
+
+	t.Run("API error -> execution fails", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"kind": KindDeployment, "name": "api"},
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					jsonResponse(http.StatusForbidden, `{"kind":"Status","reason":"Forbidden","message":"deployments.apps \"api\" is forbidden"}`),
+				},
+			},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to restart Deployment/api in default")
+	})
+}
@@ -0,0 +1,534 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	RunJobPayloadType   = "kubernetes.job.finished"
+	RunJobPollAction    = "poll"
+	RunJobPollInterval  = 10 * time.Second
+	RunJobMaxPollErrors = 5
+	RunJobContainerName = "job"
+
+	DefaultJobTimeoutMinutes = 30
+	DefaultJobLogLines       = 100
+	maxJobLogLines           = 5000
+
+	// Finished jobs are kept for an hour, long enough to look
+	// into a failure, and then garbage collected by the cluster.
+	jobTTLSecondsAfterFinished = 3600
+
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+)
+
+type RunJob struct{}
+
+type RunJobSpec struct {
+	Namespace      string          `json:"namespace" mapstructure:"namespace"`
+	NamePrefix     string          `json:"namePrefix" mapstructure:"namePrefix"`
+	Image          string          `json:"image" mapstructure:"image"`
+	Command        []string        `json:"command" mapstructure:"command"`
+	Env            []RunJobEnvSpec `json:"env" mapstructure:"env"`
+	ServiceAccount string          `json:"serviceAccount" mapstructure:"serviceAccount"`
+	TimeoutMinutes int             `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+	LogLines       *int            `json:"logLines" mapstructure:"logLines"`
+}
+
+type RunJobEnvSpec struct {
+	Name  string `json:"name" mapstructure:"name"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+type RunJobMetadata struct {
+	Namespace      string `json:"namespace" mapstructure:"namespace"`
+	Job            string `json:"job" mapstructure:"job"`
+	Status         string `json:"status" mapstructure:"status"`
+	StartedAt      string `json:"startedAt,omitempty" mapstructure:"startedAt"`
+	PollErrorCount int    `json:"pollErrorCount,omitempty" mapstructure:"pollErrorCount"`
+}
+
+func (r *RunJob) Name() string {
+	return "kubernetes.runJob"
+}
+
+func (r *RunJob) Label() string {
+	return "Run Job"
+}
+
+func (r *RunJob) Description() string {
+	return "Run a Kubernetes Job to completion and collect its logs"
+}
+
+func (r *RunJob) Documentation() string {
+	return `The Run Job component creates a Kubernetes Job, waits for it to finish and collects the logs of its pod.
+
+## Use Cases
+
+- **Database migrations**: Run migrations inside the cluster before rolling out a new version
+- **Smoke tests**: Run tests against services that are only reachable from the cluster
+- **Maintenance**: Run one-off scripts, backups or cache warmups
+
+## Configuration
+
+- **Namespace**: Namespace to run the Job in. Defaults to the namespace of the integration, or ` + "`default`" + `.
+- **Name prefix**: Prefix of the Job name. A random suffix is added to it.
+- **Image**: Container image to run
+- **Command**: Command and arguments. Leave empty to use the entrypoint of the image.
+- **Environment variables**: Environment variables of the container
+- **Service account**: Service account of the pod. Leave empty to use the default service account of the namespace.
+- **Timeout (minutes)**: How long the Job can run before Kubernetes stops it. Defaults to 30 minutes.
+- **Log lines**: How many of the last log lines to collect. Defaults to 100.
+
+The Job doesn't retry failed pods, and is deleted an hour after it finishes. Cancelling the execution deletes the Job and its pod.
+
+## Output Channels
+
+- **Success**: The Job completed
+- **Failed**: The Job failed or ran out of time
+
+Both include the logs of the pod.`
+}
+
+func (r *RunJob) Icon() string {
+	return "kubernetes"
+}
+
+func (r *RunJob) Color() string {
+	return "blue"
+}
+
+func (r *RunJob) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: SuccessOutputChannel, Label: "Success"},
+		{Name: FailedOutputChannel, Label: "Failed"},
+	}
+}
+
+func (r *RunJob) Configuration() []configuration.Field {
+	return []configuration.Field{
+		namespaceField(),
+		{
+			Name:        "namePrefix",
+			Label:       "Name prefix",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Default:     "superplane-",
+			Description: "Prefix of the Job name",
+		},
+		{
+			Name:        "image",
+			Label:       "Image",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "ghcr.io/my-org/migrations:1.2.3",
+			Description: "Container image to run",
+		},
+		{
+			Name:        "command",
+			Label:       "Command",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Command and arguments. Leave empty to use the entrypoint of the image.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel:      "Argument",
+					ItemDefinition: &configuration.ListItemDefinition{Type: configuration.FieldTypeString},
+				},
+			},
+		},
+		{
+			Name:     "env",
+			Label:    "Environment variables",
+			Type:     configuration.FieldTypeList,
+			Required: false,
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Variable",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "name",
+								Label:    "Name",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+							{
+								Name:     "value",
+								Label:    "Value",
+								Type:     configuration.FieldTypeString,
+								Required: false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "serviceAccount",
+			Label:       "Service account",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Togglable:   true,
+			Description: "Service account of the pod",
+		},
+		{
+			Name:        "timeoutMinutes",
+			Label:       "Timeout (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultJobTimeoutMinutes,
+			Description: "How long the Job can run",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(1), Max: intPtr(1440)},
+			},
+		},
+		{
+			Name:        "logLines",
+			Label:       "Log lines",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultJobLogLines,
+			Description: "How many of the last log lines to collect",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{Min: intPtr(0), Max: intPtr(maxJobLogLines)},
+			},
+		},
+	}
+}
+
+func (r *RunJob) Setup(ctx core.SetupContext) error {
+	_, err := decodeRunJobSpec(ctx.Configuration)
+	return err
+}
+
+func (r *RunJob) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeRunJobSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = client.DefaultNamespace()
+	}
+
+	job, err := client.CreateJob(namespace, buildJob(spec, ctx.ID.String()))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create job: %v", err))
+	}
+
+	metadata := RunJobMetadata{
+		Namespace: namespace,
+		Job:       job.Metadata.Name,
+		Status:    JobStatusRunning,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(RunJobPollAction, map[string]any{}, RunJobPollInterval)
+}
+
+func (r *RunJob) Hooks() []core.Hook {
+	return []core.Hook{{Name: RunJobPollAction, Type: core.HookTypeInternal}}
+}
+
+func (r *RunJob) HandleHook(ctx core.ActionHookContext) error {
+	switch ctx.Name {
+	case RunJobPollAction:
+		return r.poll(ctx)
+	default:
+		return fmt.Errorf("unknown hook: %s", ctx.Name)
+	}
+}
+
+func (r *RunJob) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	spec, err := decodeRunJobSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	metadata := RunJobMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.Job == "" {
+		return fmt.Errorf("job name is missing from metadata")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	job, err := client.GetJob(metadata.Namespace, metadata.Job)
+	if err != nil {
+		metadata.PollErrorCount++
+		if setErr := ctx.Metadata.Set(metadata); setErr != nil {
+			return setErr
+		}
+
+		if IsNotFound(err) || metadata.PollErrorCount >= RunJobMaxPollErrors {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get job %s: %v", metadata.Job, err))
+		}
+
+		return ctx.Requests.ScheduleActionCall(RunJobPollAction, map[string]any{}, RunJobPollInterval)
+	}
+
+	metadata.PollErrorCount = 0
+	metadata.Status, _ = JobStatus(job)
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	if metadata.Status == JobStatusRunning {
+		return ctx.Requests.ScheduleActionCall(RunJobPollAction, map[string]any{}, RunJobPollInterval)
+	}
+
+	return r.emitResult(ctx, client, job, metadata, *spec.LogLines)
+}
+
+func (r *RunJob) emitResult(ctx core.ActionHookContext, client *Client, job *Job, metadata RunJobMetadata, logLines int) error {
+	status, message := JobStatus(job)
+	payload := map[string]any{
+		"namespace":      metadata.Namespace,
+		"job":            metadata.Job,
+		"status":         status,
+		"message":        message,
+		"succeeded":      job.Status.Succeeded,
+		"failed":         job.Status.Failed,
+		"startedAt":      job.Status.StartTime,
+		"completionTime": job.Status.CompletionTime,
+	}
+
+	//
+	// Logs are best-effort: the job result
+	// stands even when they can't be read.
+	//
+	pod, logs, err := r.latestPodLogs(client, metadata, logLines)
+	if err != nil {
+		if ctx.Logger != nil {
+			ctx.Logger.Warnf("failed to read logs of job %s: %v", metadata.Job, err)
+		}
+	} else if pod != nil {
+		payload["pod"] = pod.Metadata.Name
+		payload["logs"] = logs
+		if exitCode, ok := containerExitCode(pod); ok {
+			payload["exitCode"] = exitCode
+		}
+	}
+
+	channel := FailedOutputChannel
+	if status == JobStatusSucceeded {
+		channel = SuccessOutputChannel
+	}
+
+	return ctx.ExecutionState.Emit(channel, RunJobPayloadType, []any{payload})
+}
+
+// latestPodLogs reads the logs of the last pod of a job,
+// which is the one that decided how the job ended.
+func (r *RunJob) latestPodLogs(client *Client, metadata RunJobMetadata, logLines int) (*Pod, string, error) {
+	pods, err := client.ListPods(metadata.Namespace, "job-name="+metadata.Job)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(pods) == 0 {
+		return nil, "", nil
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Metadata.CreationTimestamp > pods[j].Metadata.CreationTimestamp
+	})
+
+	pod := pods[0]
+	if logLines == 0 {
+		return &pod, "", nil
+	}
+
+	logs, err := client.GetPodLogs(metadata.Namespace, pod.Metadata.Name, RunJobContainerName, logLines)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &pod, logs, nil
+}
+
+func (r *RunJob) Cancel(ctx core.ExecutionContext) error {
+	metadata := RunJobMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.Job == "" || metadata.Status != JobStatusRunning {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	resource := Resource{APIVersion: "batch/v1", Kind: "Job", Namespace: metadata.Namespace, Name: metadata.Job}
+	if err := client.Delete(resource, PropagationPolicyBackground); err != nil && !IsNotFound(err) {
+		return fmt.Errorf("failed to delete job %s: %w", metadata.Job, err)
+	}
+
+	return nil
+}
+
+func (r *RunJob) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (r *RunJob) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func decodeRunJobSpec(value any) (RunJobSpec, error) {
+	spec := RunJobSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return RunJobSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Image = strings.TrimSpace(spec.Image)
+	if spec.Image == "" {
+		return RunJobSpec{}, fmt.Errorf("image is required")
+	}
+
+	spec.Namespace = strings.TrimSpace(spec.Namespace)
+	spec.ServiceAccount = strings.TrimSpace(spec.ServiceAccount)
+	spec.NamePrefix = strings.TrimSpace(spec.NamePrefix)
+	if spec.NamePrefix == "" {
+		spec.NamePrefix = "superplane-"
+	}
+
+	for _, env := range spec.Env {
+		if strings.TrimSpace(env.Name) == "" {
+			return RunJobSpec{}, fmt.Errorf("environment variable name is required")
+		}
+	}
+
+	if spec.TimeoutMinutes <= 0 {
+		spec.TimeoutMinutes = DefaultJobTimeoutMinutes
+	}
+
+	switch {
+	case spec.LogLines == nil || *spec.LogLines < 0:
+		spec.LogLines = intPtr(DefaultJobLogLines)
+	case *spec.LogLines > maxJobLogLines:
+		spec.LogLines = intPtr(maxJobLogLines)
+	}
+
+	return spec, nil
+}
+
+func buildJob(spec RunJobSpec, executionID string) map[string]any {
+	container := map[string]any{
+		"name":  RunJobContainerName,
+		"image": spec.Image,
+	}
+
+	if len(spec.Command) > 0 {
+		container["command"] = spec.Command
+	}
+
+	if len(spec.Env) > 0 {
+		env := make([]map[string]any, 0, len(spec.Env))
+		for _, variable := range spec.Env {
+			env = append(env, map[string]any{"name": strings.TrimSpace(variable.Name), "value": variable.Value})
+		}
+
+		container["env"] = env
+	}
+
+	podSpec := map[string]any{
+		"restartPolicy": "Never",
+		"containers":    []any{container},
+	}
+
+	if spec.ServiceAccount != "" {
+		podSpec["serviceAccountName"] = spec.ServiceAccount
+	}
+
+	labels := map[string]any{
+		"app.kubernetes.io/managed-by": FieldManager,
+		"superplane.com/execution-id":  executionID,
+	}
+
+	return map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata": map[string]any{
+			"generateName": spec.NamePrefix,
+			"labels":       labels,
+		},
+		"spec": map[string]any{
+			"backoffLimit":            0,
+			"activeDeadlineSeconds":   spec.TimeoutMinutes * 60,
+			"ttlSecondsAfterFinished": jobTTLSecondsAfterFinished,
+			"template": map[string]any{
+				"metadata": map[string]any{"labels": labels},
+				"spec":     podSpec,
+			},
+		},
+	}
+}
+
+// JobStatus reads whether a job is still running from its
+// Complete and Failed conditions, with the reason it failed.
+func JobStatus(job *Job) (string, string) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != "True" {
+			continue
+		}
+
+		switch condition.Type {
+		case "Complete":
+			return JobStatusSucceeded, condition.Message
+		case "Failed":
+			message := condition.Reason
+			if condition.Message != "" {
+				message = fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+			}
+
+			return JobStatusFailed, message
+		}
+	}
+
+	return JobStatusRunning, ""
+}
+
+func containerExitCode(pod *Pod) (int32, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == RunJobContainerName && status.State.Terminated != nil {
+			return status.State.Terminated.ExitCode, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,169 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RunJob__Execute(t *testing.T) {
+	component := &RunJob{}
+
+	t.Run("missing image -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{}})
+		require.ErrorContains(t, err, "image is required")
+	})
+
+	t.Run("creates the job and schedules a poll", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusCreated, `{"metadata":{"name":"migrate-x7k2p","namespace":"production"}}`),
+			},
+		}
+
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		err := component.Execute(core.ExecutionContext{
+			ID: uuid.New(),
+			Configuration: map[string]any{
+				"namespace":      "production",
+				"namePrefix":     "migrate-",
+				"image":          "ghcr.io/acme/api:1.15.0",
+				"command":        []any{"bin/rails", "db:migrate"},
+				"env":            []any{map[string]any{"name": "RAILS_ENV", "value": "production"}},
+				"serviceAccount": "migrations",
+				"timeoutMinutes": 15,
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: &contexts.ExecutionStateContext{KVs: map[string]string{}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodPost, httpContext.Requests[0].Method)
+		assert.Equal(t, "/apis/batch/v1/namespaces/production/jobs", httpContext.Requests[0].URL.Path)
+
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		job := Job{}
+		raw := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &job))
+		require.NoError(t, json.Unmarshal(body, &raw))
+
+		spec := raw["spec"].(map[string]any)
+		assert.Equal(t, float64(0), spec["backoffLimit"])
+		assert.Equal(t, float64(900), spec["activeDeadlineSeconds"])
+		podSpec := spec["template"].(map[string]any)["spec"].(map[string]any)
+		assert.Equal(t, "Never", podSpec["restartPolicy"])
+		assert.Equal(t, "migrations", podSpec["serviceAccountName"])
+		container := podSpec["containers"].([]any)[0].(map[string]any)
+		assert.Equal(t, []any{"bin/rails", "db:migrate"}, container["command"])
+		assert.Equal(t, []any{map[string]any{"name": "RAILS_ENV", "value": "production"}}, container["env"])
+		assert.Equal(t, "migrate-", raw["metadata"].(map[string]any)["generateName"])
+
+		assert.Equal(t, RunJobMetadata{
+			Namespace: "production",
+			Job:       "migrate-x7k2p",
+			Status:    JobStatusRunning,
+			StartedAt: metadata.Metadata.(RunJobMetadata).StartedAt,
+		}, metadata.Metadata)
+		assert.Equal(t, RunJobPollAction, requests.Action)
+	})
+}
+
+func Test__RunJob__Poll(t *testing.T) {
+	component := &RunJob{}
+	configuration := map[string]any{"image": "ghcr.io/acme/api:1.15.0", "logLines": 50}
+	running := RunJobMetadata{Namespace: "production", Job: "migrate-x7k2p", Status: JobStatusRunning}
+
+	t.Run("job still running -> schedules another poll", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"metadata":{"name":"migrate-x7k2p"},"status":{"active":1}}`),
+			},
+		}
+
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           RunJobPollAction,
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       &contexts.MetadataContext{Metadata: running},
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, RunJobPollAction, requests.Action)
+	})
+
+	t.Run("job failed -> emits on failed with logs", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"metadata":{"name":"migrate-x7k2p"},"status":{"failed":1,"conditions":[{"type":"Failed","status":"True","reason":"BackoffLimitExceeded","message":"Job has reached the specified backoff limit"}]}}`),
+				jsonResponse(http.StatusOK, `{"items":[
+					{"metadata":{"name":"migrate-x7k2p-old","creationTimestamp":"2026-10-12T14:00:00Z"}},
+					{"metadata":{"name":"migrate-x7k2p-9fq4d","creationTimestamp":"2026-10-12T14:03:21Z"},"status":{"containerStatuses":[{"name":"job","state":{"terminated":{"exitCode":2}}}]}}
+				]}`),
+				jsonResponse(http.StatusOK, "migrating\nerror: relation \"orders\" does not exist\n"),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:           RunJobPollAction,
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       &contexts.MetadataContext{Metadata: running},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, FailedOutputChannel, executionState.Channel)
+		assert.Equal(t, "job-name=migrate-x7k2p", httpContext.Requests[1].URL.Query().Get("labelSelector"))
+		assert.Equal(t, "/api/v1/namespaces/production/pods/migrate-x7k2p-9fq4d/log", httpContext.Requests[2].URL.Path)
+		assert.Equal(t, "50", httpContext.Requests[2].URL.Query().Get("tailLines"))
+
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, JobStatusFailed, payload["status"])
+		assert.Equal(t, "BackoffLimitExceeded: Job has reached the specified backoff limit", payload["message"])
+		assert.Equal(t, int32(2), payload["exitCode"])
+		assert.Contains(t, payload["logs"], "does not exist")
+	})
+}
+
+func Test__RunJob__Cancel(t *testing.T) {
+	httpContext := &contexts.HTTPContext{
+		Responses: []*http.Response{jsonResponse(http.StatusOK, `{"kind":"Status","status":"Success"}`)},
+	}
+
+	err := (&RunJob{}).Cancel(core.ExecutionContext{
+		HTTP:        httpContext,
+		Integration: testIntegration(),
+		Metadata: &contexts.MetadataContext{Metadata: RunJobMetadata{
+			Namespace: "production",
+			Job:       "migrate-x7k2p",
+			Status:    JobStatusRunning,
+		}},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, httpContext.Requests, 1)
+	assert.Equal(t, http.MethodDelete, httpContext.Requests[0].Method)
+	assert.Equal(t, "/apis/batch/v1/namespaces/production/jobs/migrate-x7k2p", httpContext.Requests[0].URL.Path)
+}
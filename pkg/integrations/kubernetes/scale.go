@@ -0,0 +1,160 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const ScalePayloadType = "kubernetes.workload.scaled"
+
+type Scale struct{}
+
+type ScaleSpec struct {
+	WorkloadSpec `mapstructure:",squash"`
+	Replicas     *int `json:"replicas" mapstructure:"replicas"`
+}
+
+func (s *Scale) Name() string {
+	return "kubernetes.scale"
+}
+
+func (s *Scale) Label() string {
+	return "Scale"
+}
+
+func (s *Scale) Description() string {
+	return "Set the replicas of a Deployment, StatefulSet or ReplicaSet"
+}
+
+func (s *Scale) Documentation() string {
+	return `The Scale component sets the number of replicas of a workload, like ` + "`kubectl scale`" + ` does.
+
+## Use Cases
+
+- **Capacity changes**: Scale up ahead of a launch or a batch job, and back down after
+- **Incident response**: Scale a misbehaving workload to zero
+- **Cost control**: Scale preview environments down outside working hours
+
+## Configuration
+
+- **Kind**: Deployment, StatefulSet or ReplicaSet
+- **Namespace**: Namespace of the workload. Defaults to the namespace of the integration, or ` + "`default`" + `.
+- **Name**: Name of the workload
+- **Replicas**: Number of replicas to run
+
+## Output
+
+The workload, with its previous and new number of replicas. Combine it with **Wait for Rollout** to wait for the new replicas.`
+}
+
+func (s *Scale) Icon() string {
+	return "kubernetes"
+}
+
+func (s *Scale) Color() string {
+	return "blue"
+}
+
+func (s *Scale) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (s *Scale) Configuration() []configuration.Field {
+	return append(workloadFields(KindDeployment, KindStatefulSet, KindReplicaSet), configuration.Field{
+		Name:        "replicas",
+		Label:       "Replicas",
+		Type:        configuration.FieldTypeNumber,
+		Required:    true,
+		Default:     1,
+		Description: "Number of replicas to run",
+		TypeOptions: &configuration.TypeOptions{
+			Number: &configuration.NumberTypeOptions{Min: intPtr(0)},
+		},
+	})
+}
+
+func (s *Scale) Setup(ctx core.SetupContext) error {
+	_, err := decodeScaleSpec(ctx.Configuration)
+	return err
+}
+
+func (s *Scale) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeScaleSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	resource := spec.resource()
+	if resource.Namespace == "" {
+		resource.Namespace = client.DefaultNamespace()
+	}
+
+	scale, err := client.Scale(resource, int32(*spec.Replicas))
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to scale %s: %v", resource, err))
+	}
+
+	//
+	// The scale subresource answers with the new spec,
+	// and with the replicas that were running before the change.
+	//
+	status, _ := scale["status"].(map[string]any)
+	payload := map[string]any{
+		"kind":             resource.Kind,
+		"namespace":        resource.Namespace,
+		"name":             resource.Name,
+		"replicas":         *spec.Replicas,
+		"previousReplicas": status["replicas"],
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, ScalePayloadType, []any{payload})
+}
+
+func (s *Scale) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (s *Scale) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (s *Scale) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (s *Scale) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (s *Scale) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeScaleSpec(value any) (ScaleSpec, error) {
+	spec := ScaleSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return ScaleSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := spec.validate(KindDeployment, KindStatefulSet, KindReplicaSet); err != nil {
+		return ScaleSpec{}, err
+	}
+
+	if spec.Replicas == nil {
+		return ScaleSpec{}, fmt.Errorf("replicas is required")
+	}
+
+	if *spec.Replicas < 0 {
+		return ScaleSpec{}, fmt.Errorf("replicas must not be negative")
+	}
+
+	return spec, nil
+}
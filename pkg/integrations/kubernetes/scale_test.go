@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Scale__Setup(t *testing.T) {
+	component := &Scale{}
+
+	t.Run("unsupported kind -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"kind": KindDaemonSet, "name": "agent", "replicas": 2}})
+		require.ErrorContains(t, err, "kind DaemonSet is not supported")
+	})
+
+	t.Run("missing replicas -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"kind": KindDeployment, "name": "api"}})
+		require.ErrorContains(t, err, "replicas is required")
+	})
+
+	t.Run("negative replicas -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"kind": KindDeployment, "name": "api", "replicas": "-1"}})
+		require.ErrorContains(t, err, "replicas must not be negative")
+	})
+}
+
+func Test__Scale__Execute(t *testing.T) {
+	component := &Scale{}
+
+	t.Run("patches the scale subresource -> emits previous replicas", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"kind":"Scale","apiVersion":"autoscaling/v1","spec":{"replicas":5},"status":{"replicas":2}}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"kind": KindDeployment, "name": "api", "replicas": 5},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		request := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPatch, request.Method)
+		assert.Equal(t, "/apis/apps/v1/namespaces/default/deployments/api/scale", request.URL.Path)
+		assert.Equal(t, "application/merge-patch+json", request.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"spec":{"replicas":5}}`, string(body))
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, ScalePayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "default", payload["namespace"])
+		assert.Equal(t, 5, payload["replicas"])
+		assert.Equal(t, float64(2), payload["previousReplicas"])
+	})
+
+	t.Run("API error -> execution fails", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"kind": KindStatefulSet, "namespace": "data", "name": "postgres", "replicas": 0},
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					jsonResponse(http.StatusNotFound, `{"kind":"Status","reason":"NotFound","message":"statefulsets.apps \"postgres\" not found"}`),
+				},
+			},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to scale StatefulSet/postgres in data")
+	})
+}
@@ -0,0 +1,405 @@
+package kubernetes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	WaitForRolloutPayloadType   = "kubernetes.rollout.finished"
+	WaitForRolloutPollAction    = "poll"
+	WaitForRolloutPollInterval  = 10 * time.Second
+	WaitForRolloutMaxPollErrors = 5
+
+	DefaultRolloutTimeoutMinutes = 10
+
+	RolloutStatusComplete    = "complete"
+	RolloutStatusProgressing = "progressing"
+	RolloutStatusFailed      = "failed"
+	RolloutStatusTimedOut    = "timedOut"
+)
+
+type WaitForRollout struct{}
+
+type WaitForRolloutSpec struct {
+	WorkloadSpec   `mapstructure:",squash"`
+	TimeoutMinutes int `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+}
+
+type WaitForRolloutMetadata struct {
+	Kind           string `json:"kind" mapstructure:"kind"`
+	Namespace      string `json:"namespace" mapstructure:"namespace"`
+	Name           string `json:"name" mapstructure:"name"`
+	StartedAt      string `json:"startedAt" mapstructure:"startedAt"`
+	Status         string `json:"status" mapstructure:"status"`
+	Message        string `json:"message,omitempty" mapstructure:"message"`
+	PollErrorCount int    `json:"pollErrorCount,omitempty" mapstructure:"pollErrorCount"`
+}
+
+// RolloutStatus is where a rollout stands, worked out
+// the same way kubectl rollout status does.
+type RolloutStatus struct {
+	Status   string
+	Message  string
+	Replicas map[string]any
+}
+
+func (w *WaitForRollout) Name() string {
+	return "kubernetes.waitForRollout"
+}
+
+func (w *WaitForRollout) Label() string {
+	return "Wait for Rollout"
+}
+
+func (w *WaitForRollout) Description() string {
+	return "Wait for a Deployment or StatefulSet rollout to finish"
+}
+
+func (w *WaitForRollout) Documentation() string {
+	return `The Wait for Rollout component waits until a Deployment or StatefulSet finishes rolling out, like ` + "`kubectl rollout status`" + ` does.
+
+## Use Cases
+
+- **Deploy pipelines**: Only move on to smoke tests once the new version is running
+- **Rollback automation**: Route failed rollouts to a rollback or an alert
+- **Release gates**: Hold promotions until every replica is updated and available
+
+## Configuration
+
+- **Kind**: Deployment or StatefulSet
+- **Namespace**: Namespace of the workload. Defaults to the namespace of the integration, or ` + "`default`" + `.
+- **Name**: Name of the workload
+- **Timeout (minutes)**: How long to wait before giving up. Defaults to 10 minutes.
+
+## Output Channels
+
+- **Success**: Every replica runs the latest revision and is available
+- **Failed**: The Deployment exceeded its progress deadline, or the rollout did not finish in time`
+}
+
+func (w *WaitForRollout) Icon() string {
+	return "kubernetes"
+}
+
+func (w *WaitForRollout) Color() string {
+	return "blue"
+}
+
+func (w *WaitForRollout) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: SuccessOutputChannel, Label: "Success"},
+		{Name: FailedOutputChannel, Label: "Failed"},
+	}
+}
+
+func (w *WaitForRollout) Configuration() []configuration.Field {
+	return append(workloadFields(KindDeployment, KindStatefulSet), configuration.Field{
+		Name:        "timeoutMinutes",
+		Label:       "Timeout (minutes)",
+		Type:        configuration.FieldTypeNumber,
+		Required:    false,
+		Default:     DefaultRolloutTimeoutMinutes,
+		Description: "How long to wait for the rollout to finish",
+		TypeOptions: &configuration.TypeOptions{
+			Number: &configuration.NumberTypeOptions{
+				Min: intPtr(1),
+				Max: intPtr(720),
+			},
+		},
+	})
+}
+
+func (w *WaitForRollout) Setup(ctx core.SetupContext) error {
+	_, err := decodeWaitForRolloutSpec(ctx.Configuration)
+	return err
+}
+
+func (w *WaitForRollout) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeWaitForRolloutSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = client.DefaultNamespace()
+	}
+
+	metadata := WaitForRolloutMetadata{
+		Kind:      spec.Kind,
+		Namespace: namespace,
+		Name:      spec.Name,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+		Status:    RolloutStatusProgressing,
+	}
+
+	return w.check(client, ctx.Metadata, ctx.ExecutionState, ctx.Requests, metadata, spec.TimeoutMinutes)
+}
+
+func (w *WaitForRollout) Hooks() []core.Hook {
+	return []core.Hook{{Name: WaitForRolloutPollAction, Type: core.HookTypeInternal}}
+}
+
+func (w *WaitForRollout) HandleHook(ctx core.ActionHookContext) error {
+	switch ctx.Name {
+	case WaitForRolloutPollAction:
+		return w.poll(ctx)
+	default:
+		return fmt.Errorf("unknown hook: %s", ctx.Name)
+	}
+}
+
+func (w *WaitForRollout) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	spec, err := decodeWaitForRolloutSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	metadata := WaitForRolloutMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	return w.check(client, ctx.Metadata, ctx.ExecutionState, ctx.Requests, metadata, spec.TimeoutMinutes)
+}
+
+func (w *WaitForRollout) check(
+	client *Client,
+	metadataCtx core.MetadataWriter,
+	executionState core.ExecutionStateContext,
+	requests core.RequestContext,
+	metadata WaitForRolloutMetadata,
+	timeoutMinutes int,
+) error {
+	status, err := w.rolloutStatus(client, metadata)
+	if err != nil {
+		metadata.PollErrorCount++
+		metadata.Message = err.Error()
+		if setErr := metadataCtx.Set(metadata); setErr != nil {
+			return setErr
+		}
+
+		if IsNotFound(err) || metadata.PollErrorCount >= WaitForRolloutMaxPollErrors {
+			return executionState.Fail("error", fmt.Sprintf("failed to get rollout status of %s %s: %v", metadata.Kind, metadata.Name, err))
+		}
+
+		return requests.ScheduleActionCall(WaitForRolloutPollAction, map[string]any{}, WaitForRolloutPollInterval)
+	}
+
+	metadata.PollErrorCount = 0
+	metadata.Status = status.Status
+	metadata.Message = status.Message
+
+	startedAt, err := time.Parse(time.RFC3339, metadata.StartedAt)
+	if err == nil && status.Status == RolloutStatusProgressing && time.Since(startedAt) >= time.Duration(timeoutMinutes)*time.Minute {
+		metadata.Status = RolloutStatusTimedOut
+		metadata.Message = fmt.Sprintf("rollout did not finish in %d minutes: %s", timeoutMinutes, status.Message)
+	}
+
+	if err := metadataCtx.Set(metadata); err != nil {
+		return err
+	}
+
+	if metadata.Status == RolloutStatusProgressing {
+		return requests.ScheduleActionCall(WaitForRolloutPollAction, map[string]any{}, WaitForRolloutPollInterval)
+	}
+
+	payload := map[string]any{
+		"kind":      metadata.Kind,
+		"namespace": metadata.Namespace,
+		"name":      metadata.Name,
+		"status":    metadata.Status,
+		"message":   metadata.Message,
+		"replicas":  status.Replicas,
+		"startedAt": metadata.StartedAt,
+		"endedAt":   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	channel := FailedOutputChannel
+	if metadata.Status == RolloutStatusComplete {
+		channel = SuccessOutputChannel
+	}
+
+	return executionState.Emit(channel, WaitForRolloutPayloadType, []any{payload})
+}
+
+func (w *WaitForRollout) rolloutStatus(client *Client, metadata WaitForRolloutMetadata) (*RolloutStatus, error) {
+	switch metadata.Kind {
+	case KindDeployment:
+		deployment, err := client.GetDeployment(metadata.Namespace, metadata.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		return DeploymentRolloutStatus(deployment), nil
+
+	case KindStatefulSet:
+		statefulSet, err := client.GetStatefulSet(metadata.Namespace, metadata.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		return StatefulSetRolloutStatus(statefulSet), nil
+
+	default:
+		return nil, fmt.Errorf("kind %s is not supported", metadata.Kind)
+	}
+}
+
+func (w *WaitForRollout) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (w *WaitForRollout) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (w *WaitForRollout) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func decodeWaitForRolloutSpec(value any) (WaitForRolloutSpec, error) {
+	spec := WaitForRolloutSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return WaitForRolloutSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if err := spec.validate(KindDeployment, KindStatefulSet); err != nil {
+		return WaitForRolloutSpec{}, err
+	}
+
+	resource := spec.resource()
+	spec.Kind = resource.Kind
+	spec.Namespace = resource.Namespace
+	spec.Name = resource.Name
+
+	if spec.TimeoutMinutes <= 0 {
+		spec.TimeoutMinutes = DefaultRolloutTimeoutMinutes
+	}
+
+	return spec, nil
+}
+
+// DeploymentRolloutStatus follows the checks of kubectl rollout status:
+// the controller saw the latest spec, every replica was updated,
+// old replicas are gone, and updated replicas are available.
+func DeploymentRolloutStatus(deployment *Deployment) *RolloutStatus {
+	status := deployment.Status
+	result := &RolloutStatus{
+		Status: RolloutStatusProgressing,
+		Replicas: map[string]any{
+			"desired":   desiredReplicas(deployment.Spec.Replicas),
+			"updated":   status.UpdatedReplicas,
+			"ready":     status.ReadyReplicas,
+			"available": status.AvailableReplicas,
+		},
+	}
+
+	if deployment.Metadata.Generation > status.ObservedGeneration {
+		result.Message = "waiting for the deployment spec update to be observed"
+		return result
+	}
+
+	if condition := failedRolloutCondition(deployment); condition != nil {
+		result.Status = RolloutStatusFailed
+		result.Message = fmt.Sprintf("deployment %q exceeded its progress deadline: %s", deployment.Metadata.Name, condition.Message)
+		return result
+	}
+
+	desired := desiredReplicas(deployment.Spec.Replicas)
+	switch {
+	case status.UpdatedReplicas < desired:
+		result.Message = fmt.Sprintf("%d of %d new replicas have been updated", status.UpdatedReplicas, desired)
+	case status.Replicas > status.UpdatedReplicas:
+		result.Message = fmt.Sprintf("%d old replicas are pending termination", status.Replicas-status.UpdatedReplicas)
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		result.Message = fmt.Sprintf("%d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas)
+	default:
+		result.Status = RolloutStatusComplete
+		result.Message = fmt.Sprintf("deployment %q successfully rolled out", deployment.Metadata.Name)
+	}
+
+	return result
+}
+
+// StatefulSetRolloutStatus follows the checks of kubectl rollout status,
+// including partitioned rolling updates, which finish once the
+// replicas above the partition are updated.
+func StatefulSetRolloutStatus(statefulSet *StatefulSet) *RolloutStatus {
+	status := statefulSet.Status
+	desired := desiredReplicas(statefulSet.Spec.Replicas)
+	result := &RolloutStatus{
+		Status: RolloutStatusProgressing,
+		Replicas: map[string]any{
+			"desired": desired,
+			"updated": status.UpdatedReplicas,
+			"ready":   status.ReadyReplicas,
+		},
+	}
+
+	if statefulSet.Spec.UpdateStrategy.Type != "" && statefulSet.Spec.UpdateStrategy.Type != "RollingUpdate" {
+		result.Status = RolloutStatusFailed
+		result.Message = fmt.Sprintf("rollout status is only available for the RollingUpdate strategy, not %s", statefulSet.Spec.UpdateStrategy.Type)
+		return result
+	}
+
+	if status.ObservedGeneration == 0 || statefulSet.Metadata.Generation > status.ObservedGeneration {
+		result.Message = "waiting for the statefulset spec update to be observed"
+		return result
+	}
+
+	if status.ReadyReplicas < desired {
+		result.Message = fmt.Sprintf("%d of %d replicas are ready", status.ReadyReplicas, desired)
+		return result
+	}
+
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate != nil && rollingUpdate.Partition != nil {
+		expected := desired - *rollingUpdate.Partition
+		if status.UpdatedReplicas < expected {
+			result.Message = fmt.Sprintf("%d of %d replicas above the partition have been updated", status.UpdatedReplicas, expected)
+			return result
+		}
+
+		result.Status = RolloutStatusComplete
+		result.Message = fmt.Sprintf("partitioned rollout of %d new replicas complete", status.UpdatedReplicas)
+		return result
+	}
+
+	if status.UpdateRevision != status.CurrentRevision {
+		result.Message = fmt.Sprintf("%d of %d replicas have been updated", status.UpdatedReplicas, desired)
+		return result
+	}
+
+	result.Status = RolloutStatusComplete
+	result.Message = fmt.Sprintf("statefulset %q successfully rolled out", statefulSet.Metadata.Name)
+	return result
+}
+
+// desiredReplicas defaults to one replica, like the API server does.
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
@@ -0,0 +1,214 @@
+package kubernetes
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__DeploymentRolloutStatus(t *testing.T) {
+	deployment := func(generation, observed int64, replicas, total, updated, available int32, conditions ...Condition) *Deployment {
+		d := &Deployment{}
+		d.Metadata.Name = "api"
+		d.Metadata.Generation = generation
+		d.Spec.Replicas = &replicas
+		d.Status.ObservedGeneration = observed
+		d.Status.Replicas = total
+		d.Status.UpdatedReplicas = updated
+		d.Status.AvailableReplicas = available
+		d.Status.Conditions = conditions
+		return d
+	}
+
+	testCases := []struct {
+		name       string
+		deployment *Deployment
+		status     string
+		message    string
+	}{
+		{"spec not observed yet", deployment(3, 2, 3, 3, 3, 3), RolloutStatusProgressing, "waiting for the deployment spec update to be observed"},
+		{"replicas being updated", deployment(3, 3, 3, 3, 1, 3), RolloutStatusProgressing, "1 of 3 new replicas have been updated"},
+		{"old replicas terminating", deployment(3, 3, 3, 4, 3, 3), RolloutStatusProgressing, "1 old replicas are pending termination"},
+		{"updated replicas not available", deployment(3, 3, 3, 3, 3, 2), RolloutStatusProgressing, "2 of 3 updated replicas are available"},
+		{"complete", deployment(3, 3, 3, 3, 3, 3), RolloutStatusComplete, `deployment "api" successfully rolled out`},
+		{
+			"progress deadline exceeded",
+			deployment(3, 3, 3, 3, 1, 2, Condition{Type: "Progressing", Status: "False", Reason: "ProgressDeadlineExceeded", Message: "timed out"}),
+			RolloutStatusFailed,
+			`deployment "api" exceeded its progress deadline: timed out`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			status := DeploymentRolloutStatus(testCase.deployment)
+			assert.Equal(t, testCase.status, status.Status)
+			assert.Equal(t, testCase.message, status.Message)
+		})
+	}
+}
+
+func Test__StatefulSetRolloutStatus(t *testing.T) {
+	t.Run("partitioned rollout -> complete above the partition", func(t *testing.T) {
+		replicas, partition := int32(5), int32(3)
+		statefulSet := &StatefulSet{}
+		statefulSet.Metadata.Generation = 2
+		statefulSet.Spec.Replicas = &replicas
+		statefulSet.Spec.UpdateStrategy.Type = "RollingUpdate"
+		statefulSet.Spec.UpdateStrategy.RollingUpdate = &struct {
+			Partition *int32 `json:"partition,omitempty"`
+		}{Partition: &partition}
+		statefulSet.Status.ObservedGeneration = 2
+		statefulSet.Status.ReadyReplicas = 5
+		statefulSet.Status.UpdatedReplicas = 2
+
+		assert.Equal(t, RolloutStatusComplete, StatefulSetRolloutStatus(statefulSet).Status)
+	})
+
+	t.Run("revisions differ -> progressing", func(t *testing.T) {
+		replicas := int32(3)
+		statefulSet := &StatefulSet{}
+		statefulSet.Metadata.Generation = 2
+		statefulSet.Spec.Replicas = &replicas
+		statefulSet.Status.ObservedGeneration = 2
+		statefulSet.Status.ReadyReplicas = 3
+		statefulSet.Status.UpdatedReplicas = 1
+		statefulSet.Status.CurrentRevision = "db-1"
+		statefulSet.Status.UpdateRevision = "db-2"
+
+		status := StatefulSetRolloutStatus(statefulSet)
+		assert.Equal(t, RolloutStatusProgressing, status.Status)
+		assert.Equal(t, "1 of 3 replicas have been updated", status.Message)
+	})
+
+	t.Run("OnDelete strategy -> failed", func(t *testing.T) {
+		statefulSet := &StatefulSet{}
+		statefulSet.Spec.UpdateStrategy.Type = "OnDelete"
+		assert.Equal(t, RolloutStatusFailed, StatefulSetRolloutStatus(statefulSet).Status)
+	})
+}
+
+func Test__WaitForRollout(t *testing.T) {
+	component := &WaitForRollout{}
+	configuration := map[string]any{"kind": KindDeployment, "namespace": "production", "name": "api", "timeoutMinutes": 5}
+
+	t.Run("unsupported kind -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"kind": KindDaemonSet, "name": "agent"}})
+		require.ErrorContains(t, err, "kind DaemonSet is not supported")
+	})
+
+	t.Run("rollout in progress -> schedules a poll", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"metadata":{"name":"api","generation":2},"spec":{"replicas":3},"status":{"observedGeneration":2,"replicas":3,"updatedReplicas":1,"availableReplicas":3}}`),
+			},
+		}
+
+		metadata := &contexts.MetadataContext{}
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, WaitForRolloutPollAction, requests.Action)
+		assert.Equal(t, WaitForRolloutPollInterval, requests.Duration)
+		assert.Equal(t, "/apis/apps/v1/namespaces/production/deployments/api", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, RolloutStatusProgressing, metadata.Metadata.(WaitForRolloutMetadata).Status)
+	})
+
+	t.Run("rollout complete -> success", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"metadata":{"name":"api","generation":2},"spec":{"replicas":3},"status":{"observedGeneration":2,"replicas":3,"updatedReplicas":3,"availableReplicas":3}}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:          WaitForRolloutPollAction,
+			Configuration: configuration,
+			HTTP:          httpContext,
+			Integration:   testIntegration(),
+			Metadata: &contexts.MetadataContext{Metadata: WaitForRolloutMetadata{
+				Kind:      KindDeployment,
+				Namespace: "production",
+				Name:      "api",
+				StartedAt: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+				Status:    RolloutStatusProgressing,
+			}},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, SuccessOutputChannel, executionState.Channel)
+	})
+
+	t.Run("timeout -> failed", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"metadata":{"name":"api","generation":2},"spec":{"replicas":3},"status":{"observedGeneration":2,"replicas":3,"updatedReplicas":1,"availableReplicas":3}}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		requests := &contexts.RequestContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:          WaitForRolloutPollAction,
+			Configuration: configuration,
+			HTTP:          httpContext,
+			Integration:   testIntegration(),
+			Metadata: &contexts.MetadataContext{Metadata: WaitForRolloutMetadata{
+				Kind:      KindDeployment,
+				Namespace: "production",
+				Name:      "api",
+				StartedAt: time.Now().Add(-6 * time.Minute).UTC().Format(time.RFC3339),
+				Status:    RolloutStatusProgressing,
+			}},
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, FailedOutputChannel, executionState.Channel)
+		assert.Empty(t, requests.Action)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, RolloutStatusTimedOut, payload["status"])
+	})
+
+	t.Run("deployment not found -> execution fails", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusNotFound, `{"kind":"Status","reason":"NotFound","message":"deployments.apps \"api\" not found"}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{KVs: map[string]string{}}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  configuration,
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, executionState.Finished)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "not found")
+	})
+}
@@ -0,0 +1,123 @@
+package opsgenie
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type AcknowledgeAlert struct{}
+
+type AcknowledgeAlertSpec struct {
+	AlertRef `mapstructure:",squash"`
+	Note     string `json:"note" mapstructure:"note"`
+}
+
+func (c *AcknowledgeAlert) Name() string {
+	return "opsgenie.acknowledgeAlert"
+}
+
+func (c *AcknowledgeAlert) Label() string {
+	return "Acknowledge Alert"
+}
+
+func (c *AcknowledgeAlert) Description() string {
+	return "Acknowledge an alert in Opsgenie"
+}
+
+func (c *AcknowledgeAlert) Documentation() string {
+	return `The Acknowledge Alert component acknowledges an open Opsgenie alert.
+
+## Use Cases
+
+- **Incident response**: Acknowledge an alert to stop notifications and show that someone is working on it
+- **Automated triage**: Acknowledge alerts that a workflow handles on its own, like a known flaky check
+
+## Configuration
+
+- **Alert**: ID, tiny ID or alias of the alert
+- **Identifier Type**: How the alert is identified. Defaults to ID.
+- **Note**: Optional note added to the alert
+
+## Behavior
+
+Acknowledging an alert stops its escalations. The alert stays acknowledged until it is closed or unacknowledged.
+
+## Output
+
+The alert after it was acknowledged.`
+}
+
+func (c *AcknowledgeAlert) Icon() string {
+	return "check"
+}
+
+func (c *AcknowledgeAlert) Color() string {
+	return "gray"
+}
+
+func (c *AcknowledgeAlert) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *AcknowledgeAlert) Configuration() []configuration.Field {
+	return append(alertFields(), noteField("Note added to the alert with the acknowledgement"))
+}
+
+func (c *AcknowledgeAlert) Setup(ctx core.SetupContext) error {
+	_, err := decodeAcknowledgeAlertSpec(ctx.Configuration)
+	return err
+}
+
+func (c *AcknowledgeAlert) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeAcknowledgeAlertSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	request := AlertRequest{
+		Note:   spec.Note,
+		Source: DefaultSource,
+	}
+
+	return runAlertAction(ctx, spec.AlertRef, "acknowledge", "acknowledge", request, nil)
+}
+
+func (c *AcknowledgeAlert) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *AcknowledgeAlert) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *AcknowledgeAlert) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *AcknowledgeAlert) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *AcknowledgeAlert) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeAcknowledgeAlertSpec(value any) (AcknowledgeAlertSpec, error) {
+	spec := AcknowledgeAlertSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return AcknowledgeAlertSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := spec.AlertRef.validate()
+	if err != nil {
+		return AcknowledgeAlertSpec{}, err
+	}
+
+	spec.AlertRef = ref
+	spec.Note = strings.TrimSpace(spec.Note)
+	return spec, nil
+}
@@ -0,0 +1,48 @@
+package opsgenie
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func Test__AcknowledgeAlert__Execute(t *testing.T) {
+	t.Run("tiny ID -> acknowledges and emits the alert", func(t *testing.T) {
+		httpContext, executionState := runAction(t, &AcknowledgeAlert{}, map[string]any{
+			"alertId":        "1791",
+			"identifierType": IdentifierTypeTiny,
+			"note":           "Looking into it",
+		}, alertActionResponses())
+
+		assert.True(t, executionState.Passed)
+		require.Len(t, httpContext.Requests, 3)
+		assert.Equal(t, "/v2/alerts/1791/acknowledge", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "tiny", httpContext.Requests[0].URL.Query().Get("identifierType"))
+		assert.Equal(t, map[string]any{"note": "Looking into it", "source": "SuperPlane"}, requestBody(t, httpContext.Requests[0]))
+
+		//
+		// The alert is read with the ID from the request status.
+		//
+		assert.Equal(t, "/v2/alerts/alert-1", httpContext.Requests[2].URL.Path)
+		assert.Empty(t, httpContext.Requests[2].URL.Query().Get("identifierType"))
+	})
+
+	t.Run("failed request -> fails execution", func(t *testing.T) {
+		_, executionState := runAction(t, &AcknowledgeAlert{}, map[string]any{"alertId": "alert-1"}, []*http.Response{
+			jsonResponse(http.StatusAccepted, `{"requestId":"request-1"}`),
+			jsonResponse(http.StatusOK, requestStatusResponse(false)),
+		})
+
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to acknowledge alert alert-1")
+		assert.Contains(t, executionState.FailureMessage, "Alert does not exist")
+	})
+
+	t.Run("invalid identifier type -> error", func(t *testing.T) {
+		err := (&AcknowledgeAlert{}).Setup(core.SetupContext{Configuration: map[string]any{"alertId": "1", "identifierType": "name"}})
+		require.ErrorContains(t, err, "invalid identifier type")
+	})
+}
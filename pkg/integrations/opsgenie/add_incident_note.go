@@ -0,0 +1,121 @@
+package opsgenie
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type AddIncidentNote struct{}
+
+type AddIncidentNoteSpec struct {
+	IncidentRef `mapstructure:",squash"`
+	Note        string `json:"note" mapstructure:"note"`
+}
+
+func (c *AddIncidentNote) Name() string {
+	return "opsgenie.addIncidentNote"
+}
+
+func (c *AddIncidentNote) Label() string {
+	return "Add Incident Note"
+}
+
+func (c *AddIncidentNote) Description() string {
+	return "Add a note to an incident in Opsgenie"
+}
+
+func (c *AddIncidentNote) Documentation() string {
+	return `The Add Incident Note component adds a note to the timeline of an Opsgenie incident.
+
+## Use Cases
+
+- **Context for responders**: Add deployment details, logs or links to the incident
+- **Progress updates**: Record the steps an automated remediation took
+
+## Configuration
+
+- **Incident**: ID or tiny ID of the incident
+- **Identifier Type**: How the incident is identified. Defaults to ID.
+- **Note**: The note to add, up to 25000 characters
+
+## Output
+
+The incident the note was added to, with the note under ` + "`note`" + `.`
+}
+
+func (c *AddIncidentNote) Icon() string {
+	return "message-square"
+}
+
+func (c *AddIncidentNote) Color() string {
+	return "gray"
+}
+
+func (c *AddIncidentNote) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *AddIncidentNote) Configuration() []configuration.Field {
+	note := noteField("The note to add to the incident")
+	note.Required = true
+
+	return append(incidentFields(), note)
+}
+
+func (c *AddIncidentNote) Setup(ctx core.SetupContext) error {
+	_, err := decodeAddIncidentNoteSpec(ctx.Configuration)
+	return err
+}
+
+func (c *AddIncidentNote) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeAddIncidentNoteSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return runIncidentAction(ctx, spec.IncidentRef, "notes", "add note to", IncidentRequest{Note: spec.Note}, map[string]any{"note": spec.Note})
+}
+
+func (c *AddIncidentNote) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *AddIncidentNote) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *AddIncidentNote) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *AddIncidentNote) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *AddIncidentNote) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeAddIncidentNoteSpec(value any) (AddIncidentNoteSpec, error) {
+	spec := AddIncidentNoteSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return AddIncidentNoteSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := spec.IncidentRef.validate()
+	if err != nil {
+		return AddIncidentNoteSpec{}, err
+	}
+
+	spec.IncidentRef = ref
+	spec.Note = strings.TrimSpace(spec.Note)
+	if spec.Note == "" {
+		return AddIncidentNoteSpec{}, fmt.Errorf("note is required")
+	}
+
+	return spec, nil
+}
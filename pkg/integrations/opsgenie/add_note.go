@@ -0,0 +1,126 @@
+package opsgenie
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type AddNote struct{}
+
+type AddNoteSpec struct {
+	AlertRef `mapstructure:",squash"`
+	Note     string `json:"note" mapstructure:"note"`
+}
+
+func (c *AddNote) Name() string {
+	return "opsgenie.addNote"
+}
+
+func (c *AddNote) Label() string {
+	return "Add Note"
+}
+
+func (c *AddNote) Description() string {
+	return "Add a note to an alert in Opsgenie"
+}
+
+func (c *AddNote) Documentation() string {
+	return `The Add Note component adds a note to an Opsgenie alert.
+
+## Use Cases
+
+- **Context for responders**: Add deployment details, logs or links to the alert
+- **Progress updates**: Record the steps an automated remediation took
+
+## Configuration
+
+- **Alert**: ID, tiny ID or alias of the alert
+- **Identifier Type**: How the alert is identified. Defaults to ID.
+- **Note**: The note to add, up to 25000 characters
+
+## Output
+
+The alert the note was added to, with the note under ` + "`note`" + `.`
+}
+
+func (c *AddNote) Icon() string {
+	return "message-square"
+}
+
+func (c *AddNote) Color() string {
+	return "gray"
+}
+
+func (c *AddNote) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *AddNote) Configuration() []configuration.Field {
+	note := noteField("The note to add to the alert")
+	note.Required = true
+
+	return append(alertFields(), note)
+}
+
+func (c *AddNote) Setup(ctx core.SetupContext) error {
+	_, err := decodeAddNoteSpec(ctx.Configuration)
+	return err
+}
+
+func (c *AddNote) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeAddNoteSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	request := AlertRequest{
+		Note:   spec.Note,
+		Source: DefaultSource,
+	}
+
+	return runAlertAction(ctx, spec.AlertRef, "notes", "add note to", request, map[string]any{"note": spec.Note})
+}
+
+func (c *AddNote) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *AddNote) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *AddNote) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *AddNote) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *AddNote) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeAddNoteSpec(value any) (AddNoteSpec, error) {
+	spec := AddNoteSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return AddNoteSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := spec.AlertRef.validate()
+	if err != nil {
+		return AddNoteSpec{}, err
+	}
+
+	spec.AlertRef = ref
+	spec.Note = strings.TrimSpace(spec.Note)
+	if spec.Note == "" {
+		return AddNoteSpec{}, fmt.Errorf("note is required")
+	}
+
+	return spec, nil
+}
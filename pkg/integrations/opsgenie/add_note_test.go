@@ -0,0 +1,30 @@
+package opsgenie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func Test__AddNote__Execute(t *testing.T) {
+	t.Run("missing note -> error", func(t *testing.T) {
+		err := (&AddNote{}).Setup(core.SetupContext{Configuration: map[string]any{"alertId": "alert-1"}})
+		require.ErrorContains(t, err, "note is required")
+	})
+
+	t.Run("adds note", func(t *testing.T) {
+		httpContext, executionState := runAction(t, &AddNote{}, map[string]any{
+			"alertId": "alert-1",
+			"note":    "Rolled back api to 1.8.1.",
+		}, alertActionResponses())
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, "/v2/alerts/alert-1/notes", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "Rolled back api to 1.8.1.", requestBody(t, httpContext.Requests[0])["note"])
+
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "Rolled back api to 1.8.1.", payload["note"])
+	})
+}
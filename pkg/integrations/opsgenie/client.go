@@ -0,0 +1,544 @@
+package opsgenie
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	RegionUS = "us"
+	RegionEU = "eu"
+
+	IdentifierTypeID    = "id"
+	IdentifierTypeTiny  = "tiny"
+	IdentifierTypeAlias = "alias"
+
+	requestMaxAttempts = 10
+)
+
+// requestPollInterval is how long to wait between checks of an
+// asynchronous alert or incident request. Tests set it to zero.
+var requestPollInterval = 500 * time.Millisecond
+
+type Client struct {
+	BaseURL string
+	Region  string
+	APIKey  string
+	http    core.HTTPContext
+}
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with %d: %s", e.StatusCode, e.Body)
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+type Account struct {
+	Name      string `json:"name"`
+	UserCount int    `json:"userCount"`
+}
+
+type Team struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type Schedule struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Timezone string `json:"timezone"`
+	Enabled  bool   `json:"enabled"`
+}
+
+type Escalation struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Responder struct {
+	Type string `json:"type" mapstructure:"type"`
+	ID   string `json:"id,omitempty" mapstructure:"id"`
+	Name string `json:"name,omitempty" mapstructure:"name"`
+}
+
+type CreateAlertRequest struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Responders  []Responder       `json:"responders,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Entity      string            `json:"entity,omitempty"`
+	Source      string            `json:"source,omitempty"`
+	Priority    string            `json:"priority,omitempty"`
+	Note        string            `json:"note,omitempty"`
+}
+
+// AlertRequest is the body of the actions that change an alert.
+type AlertRequest struct {
+	Note       string         `json:"note,omitempty"`
+	Source     string         `json:"source,omitempty"`
+	EndTime    string         `json:"endTime,omitempty"`
+	Escalation *EscalationRef `json:"escalation,omitempty"`
+}
+
+type EscalationRef struct {
+	ID string `json:"id"`
+}
+
+type CreateIncidentRequest struct {
+	Message            string            `json:"message"`
+	Description        string            `json:"description,omitempty"`
+	Responders         []Responder       `json:"responders,omitempty"`
+	Tags               []string          `json:"tags,omitempty"`
+	Details            map[string]string `json:"details,omitempty"`
+	Priority           string            `json:"priority,omitempty"`
+	Note               string            `json:"note,omitempty"`
+	NotifyStakeholders bool              `json:"notifyStakeholders"`
+}
+
+// IncidentRequest is the body of the actions that change an incident.
+type IncidentRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+type RequestStatus struct {
+	Success     bool   `json:"success"`
+	IsSuccess   bool   `json:"isSuccess"`
+	Action      string `json:"action"`
+	ProcessedAt string `json:"processedAt"`
+	Status      string `json:"status"`
+	AlertID     string `json:"alertId"`
+	Alias       string `json:"alias"`
+	IncidentID  string `json:"incidentId"`
+}
+
+type OnCall struct {
+	Parent struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Enabled bool   `json:"enabled"`
+	} `json:"_parent"`
+	OnCallParticipants []OnCallParticipant `json:"onCallParticipants"`
+}
+
+type OnCallParticipant struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type Integration struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+type WebhookIntegrationRequest struct {
+	Name                string            `json:"name"`
+	Type                string            `json:"type"`
+	Enabled             bool              `json:"enabled"`
+	URL                 string            `json:"url"`
+	AddAlertDescription bool              `json:"addAlertDescription"`
+	AddAlertDetails     bool              `json:"addAlertDetails"`
+	SendAlertActions    bool              `json:"sendAlertActions"`
+	Headers             map[string]string `json:"headers,omitempty"`
+}
+
+func NewClient(httpClient core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("no integration context")
+	}
+
+	apiKey, err := ctx.GetConfig("apiKey")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedKey := strings.TrimSpace(string(apiKey))
+	if trimmedKey == "" {
+		return nil, fmt.Errorf("apiKey is required")
+	}
+
+	region := RegionUS
+	if value, err := ctx.GetConfig("region"); err == nil && strings.TrimSpace(string(value)) != "" {
+		region = strings.TrimSpace(string(value))
+	}
+
+	baseURL := "https://api.opsgenie.com"
+	switch region {
+	case RegionUS:
+	case RegionEU:
+		baseURL = "https://api.eu.opsgenie.com"
+	default:
+		return nil, fmt.Errorf("region %s is not supported", region)
+	}
+
+	return &Client{
+		BaseURL: baseURL,
+		Region:  region,
+		APIKey:  trimmedKey,
+		http:    httpClient,
+	}, nil
+}
+
+// AlertURL links to an alert in the Opsgenie web app of an account.
+func (c *Client) AlertURL(account, alertID string) string {
+	if account == "" || alertID == "" {
+		return ""
+	}
+
+	host := "app.opsgenie.com"
+	if c.Region == RegionEU {
+		host = "app.eu.opsgenie.com"
+	}
+
+	return fmt.Sprintf("https://%s.%s/alert/detail/%s/details", account, host, url.PathEscape(alertID))
+}
+
+func (c *Client) GetAccount() (*Account, error) {
+	var response struct {
+		Data Account `json:"data"`
+	}
+
+	if err := c.getJSON("/v2/account", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+func (c *Client) ListTeams() ([]Team, error) {
+	var response struct {
+		Data []Team `json:"data"`
+	}
+
+	if err := c.getJSON("/v2/teams", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+func (c *Client) ListSchedules() ([]Schedule, error) {
+	var response struct {
+		Data []Schedule `json:"data"`
+	}
+
+	if err := c.getJSON("/v2/schedules", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+func (c *Client) ListEscalations() ([]Escalation, error) {
+	var response struct {
+		Data []Escalation `json:"data"`
+	}
+
+	if err := c.getJSON("/v2/escalations", nil, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// CreateAlert queues the creation of an alert, and returns the request ID.
+func (c *Client) CreateAlert(request CreateAlertRequest) (string, error) {
+	return c.asyncRequest(http.MethodPost, "/v2/alerts", nil, request)
+}
+
+func (c *Client) GetAlert(identifier, identifierType string) (map[string]any, error) {
+	var response struct {
+		Data map[string]any `json:"data"`
+	}
+
+	err := c.getJSON("/v2/alerts/"+url.PathEscape(identifier), identifierQuery(identifierType), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// AlertAction queues an action on an alert, like acknowledge,
+// close or snooze, and returns the request ID.
+func (c *Client) AlertAction(identifier, identifierType, action string, request AlertRequest) (string, error) {
+	path := fmt.Sprintf("/v2/alerts/%s/%s", url.PathEscape(identifier), action)
+	return c.asyncRequest(http.MethodPost, path, identifierQuery(identifierType), request)
+}
+
+// WaitForRequest waits until Opsgenie processed an asynchronous
+// alert request, and returns its status.
+func (c *Client) WaitForRequest(requestID string) (*RequestStatus, error) {
+	return c.waitForRequest("/v2/alerts/requests/", requestID)
+}
+
+// CreateIncident queues the creation of an incident, and returns the request ID.
+func (c *Client) CreateIncident(request CreateIncidentRequest) (string, error) {
+	return c.asyncRequest(http.MethodPost, "/v1/incidents/create", nil, request)
+}
+
+func (c *Client) GetIncident(identifier, identifierType string) (map[string]any, error) {
+	var response struct {
+		Data map[string]any `json:"data"`
+	}
+
+	err := c.getJSON("/v1/incidents/"+url.PathEscape(identifier), identifierQuery(identifierType), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// IncidentAction queues an action on an incident, like resolve,
+// close or adding a note, and returns the request ID.
+func (c *Client) IncidentAction(identifier, identifierType, action string, request IncidentRequest) (string, error) {
+	path := fmt.Sprintf("/v1/incidents/%s/%s", url.PathEscape(identifier), action)
+	return c.asyncRequest(http.MethodPost, path, identifierQuery(identifierType), request)
+}
+
+// WaitForIncidentRequest waits until Opsgenie processed an
+// asynchronous incident request, and returns its status.
+func (c *Client) WaitForIncidentRequest(requestID string) (*RequestStatus, error) {
+	return c.waitForRequest("/v1/incidents/requests/", requestID)
+}
+
+func (c *Client) waitForRequest(basePath, requestID string) (*RequestStatus, error) {
+	for attempt := range requestMaxAttempts {
+		if attempt > 0 {
+			time.Sleep(requestPollInterval)
+		}
+
+		var response struct {
+			Data RequestStatus `json:"data"`
+		}
+
+		err := c.getJSON(basePath+url.PathEscape(requestID), nil, &response)
+		if err != nil {
+			//
+			// Requests that are not processed yet are not found.
+			//
+			if IsNotFound(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		if response.Data.ProcessedAt == "" && !response.Data.IsSuccess {
+			continue
+		}
+
+		if !response.Data.IsSuccess {
+			return nil, fmt.Errorf("request %s failed: %s", requestID, response.Data.Status)
+		}
+
+		return &response.Data, nil
+	}
+
+	return nil, fmt.Errorf("request %s was not processed in time", requestID)
+}
+
+func (c *Client) GetOnCalls(scheduleID string, date time.Time) (*OnCall, error) {
+	query := url.Values{}
+	query.Set("scheduleIdentifierType", "id")
+	query.Set("flat", "false")
+	if !date.IsZero() {
+		query.Set("date", date.UTC().Format(time.RFC3339))
+	}
+
+	var response struct {
+		Data OnCall `json:"data"`
+	}
+
+	if err := c.getJSON(fmt.Sprintf("/v2/schedules/%s/on-calls", url.PathEscape(scheduleID)), query, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+func (c *Client) ListIntegrations(integrationType string) ([]Integration, error) {
+	query := url.Values{}
+	query.Set("type", integrationType)
+
+	var response struct {
+		Data []Integration `json:"data"`
+	}
+
+	if err := c.getJSON("/v2/integrations", query, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+func (c *Client) CreateWebhookIntegration(request WebhookIntegrationRequest) (*Integration, error) {
+	_, body, err := c.execRequest(http.MethodPost, "/v2/integrations", nil, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIntegration(body)
+}
+
+func (c *Client) UpdateWebhookIntegration(id string, request WebhookIntegrationRequest) (*Integration, error) {
+	_, body, err := c.execRequest(http.MethodPut, "/v2/integrations/"+url.PathEscape(id), nil, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIntegration(body)
+}
+
+func (c *Client) DeleteIntegration(id string) error {
+	_, _, err := c.execRequest(http.MethodDelete, "/v2/integrations/"+url.PathEscape(id), nil, nil)
+	return err
+}
+
+func parseIntegration(body []byte) (*Integration, error) {
+	var response struct {
+		Data Integration `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal integration response: %w", err)
+	}
+
+	return &response.Data, nil
+}
+
+func identifierQuery(identifierType string) url.Values {
+	query := url.Values{}
+	if identifierType != "" && identifierType != IdentifierTypeID {
+		query.Set("identifierType", identifierType)
+	}
+
+	return query
+}
+
+func (c *Client) asyncRequest(method, path string, query url.Values, payload any) (string, error) {
+	_, body, err := c.execRequest(method, path, query, payload)
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		RequestID string `json:"requestId"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if response.RequestID == "" {
+		return "", fmt.Errorf("no request ID in response")
+	}
+
+	return response.RequestID, nil
+}
+
+func (c *Client) getJSON(path string, query url.Values, out any) error {
+	_, body, err := c.execRequest(http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) execRequest(method, path string, query url.Values, payload any) (*http.Response, []byte, error) {
+	endpoint := c.BaseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "GenieKey "+c.APIKey)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return nil, nil, &APIError{StatusCode: res.StatusCode, Body: apiErrorMessage(responseBody)}
+	}
+
+	return res, responseBody, nil
+}
+
+func apiErrorMessage(body []byte) string {
+	var apiError struct {
+		Message string            `json:"message"`
+		Errors  map[string]string `json:"errors"`
+	}
+
+	if err := json.Unmarshal(body, &apiError); err == nil && apiError.Message != "" {
+		if len(apiError.Errors) == 0 {
+			return apiError.Message
+		}
+
+		details := make([]string, 0, len(apiError.Errors))
+		for field, message := range apiError.Errors {
+			details = append(details, field+": "+message)
+		}
+
+		slices.Sort(details)
+
+		return apiError.Message + " (" + strings.Join(details, "; ") + ")"
+	}
+
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,119 @@
+package opsgenie
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CloseAlert struct{}
+
+type CloseAlertSpec struct {
+	AlertRef `mapstructure:",squash"`
+	Note     string `json:"note" mapstructure:"note"`
+}
+
+func (c *CloseAlert) Name() string {
+	return "opsgenie.closeAlert"
+}
+
+func (c *CloseAlert) Label() string {
+	return "Close Alert"
+}
+
+func (c *CloseAlert) Description() string {
+	return "Close an alert in Opsgenie"
+}
+
+func (c *CloseAlert) Documentation() string {
+	return `The Close Alert component closes an Opsgenie alert.
+
+## Use Cases
+
+- **Auto-resolution**: Close an alert when a workflow confirms that the problem is gone
+- **Rollback workflows**: Close the alert of a failed deployment once it was rolled back
+
+## Configuration
+
+- **Alert**: ID, tiny ID or alias of the alert
+- **Identifier Type**: How the alert is identified. Defaults to ID.
+- **Note**: Optional note added to the alert
+
+## Output
+
+The alert after it was closed.`
+}
+
+func (c *CloseAlert) Icon() string {
+	return "check-circle"
+}
+
+func (c *CloseAlert) Color() string {
+	return "gray"
+}
+
+func (c *CloseAlert) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CloseAlert) Configuration() []configuration.Field {
+	return append(alertFields(), noteField("Note added to the alert when it is closed"))
+}
+
+func (c *CloseAlert) Setup(ctx core.SetupContext) error {
+	_, err := decodeCloseAlertSpec(ctx.Configuration)
+	return err
+}
+
+func (c *CloseAlert) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCloseAlertSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	request := AlertRequest{
+		Note:   spec.Note,
+		Source: DefaultSource,
+	}
+
+	return runAlertAction(ctx, spec.AlertRef, "close", "close", request, nil)
+}
+
+func (c *CloseAlert) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CloseAlert) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *CloseAlert) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CloseAlert) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CloseAlert) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeCloseAlertSpec(value any) (CloseAlertSpec, error) {
+	spec := CloseAlertSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return CloseAlertSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := spec.AlertRef.validate()
+	if err != nil {
+		return CloseAlertSpec{}, err
+	}
+
+	spec.AlertRef = ref
+	spec.Note = strings.TrimSpace(spec.Note)
+	return spec, nil
+}
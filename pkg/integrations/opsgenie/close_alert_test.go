@@ -0,0 +1,18 @@
+package opsgenie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__CloseAlert__Execute(t *testing.T) {
+	httpContext, executionState := runAction(t, &CloseAlert{}, map[string]any{
+		"alertId":        "api-production-deploy-failed",
+		"identifierType": IdentifierTypeAlias,
+	}, alertActionResponses())
+
+	assert.True(t, executionState.Passed)
+	assert.Equal(t, "/v2/alerts/api-production-deploy-failed/close", httpContext.Requests[0].URL.Path)
+	assert.Equal(t, "alias", httpContext.Requests[0].URL.Query().Get("identifierType"))
+}
@@ -0,0 +1,114 @@
+package opsgenie
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CloseIncident struct{}
+
+type CloseIncidentSpec struct {
+	IncidentRef `mapstructure:",squash"`
+	Note        string `json:"note" mapstructure:"note"`
+}
+
+func (c *CloseIncident) Name() string {
+	return "opsgenie.closeIncident"
+}
+
+func (c *CloseIncident) Label() string {
+	return "Close Incident"
+}
+
+func (c *CloseIncident) Description() string {
+	return "Close an incident in Opsgenie"
+}
+
+func (c *CloseIncident) Documentation() string {
+	return `The Close Incident component closes an Opsgenie incident. Closed incidents can't be reopened, so close incidents after their postmortem, and resolve them when the problem is fixed.
+
+## Use Cases
+
+- **Postmortem workflows**: Close an incident once its postmortem is published
+- **Cleanup**: Close incidents opened by test or dry runs of a workflow
+
+## Configuration
+
+- **Incident**: ID or tiny ID of the incident
+- **Identifier Type**: How the incident is identified. Defaults to ID.
+- **Note**: Optional note added to the incident
+
+## Output
+
+The incident after it was closed.`
+}
+
+func (c *CloseIncident) Icon() string {
+	return "check-circle"
+}
+
+func (c *CloseIncident) Color() string {
+	return "gray"
+}
+
+func (c *CloseIncident) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CloseIncident) Configuration() []configuration.Field {
+	return append(incidentFields(), noteField("Note added to the incident when it is closed"))
+}
+
+func (c *CloseIncident) Setup(ctx core.SetupContext) error {
+	_, err := decodeCloseIncidentSpec(ctx.Configuration)
+	return err
+}
+
+func (c *CloseIncident) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCloseIncidentSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return runIncidentAction(ctx, spec.IncidentRef, "close", "close", IncidentRequest{Note: spec.Note}, nil)
+}
+
+func (c *CloseIncident) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CloseIncident) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *CloseIncident) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CloseIncident) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CloseIncident) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeCloseIncidentSpec(value any) (CloseIncidentSpec, error) {
+	spec := CloseIncidentSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return CloseIncidentSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := spec.IncidentRef.validate()
+	if err != nil {
+		return CloseIncidentSpec{}, err
+	}
+
+	spec.IncidentRef = ref
+	spec.Note = strings.TrimSpace(spec.Note)
+	return spec, nil
+}
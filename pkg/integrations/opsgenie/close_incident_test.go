@@ -0,0 +1,18 @@
+package opsgenie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test__CloseIncident__Execute(t *testing.T) {
+	httpContext, executionState := runAction(t, &CloseIncident{}, map[string]any{
+		"incidentId": "incident-1",
+	}, incidentActionResponses())
+
+	assert.True(t, executionState.Passed)
+	assert.Equal(t, "/v1/incidents/incident-1/close", httpContext.Requests[0].URL.Path)
+	assert.Empty(t, httpContext.Requests[0].URL.Query().Get("identifierType"))
+	assert.Empty(t, requestBody(t, httpContext.Requests[0]))
+}
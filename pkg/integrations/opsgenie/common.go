@@ -0,0 +1,364 @@
+package opsgenie
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	AlertPayloadType    = "opsgenie.alert"
+	IncidentPayloadType = "opsgenie.incident"
+
+	// DefaultSource is the source of alerts and alert
+	// actions, as shown in the alert activity log.
+	DefaultSource = "SuperPlane"
+
+	PriorityP1 = "P1"
+	PriorityP2 = "P2"
+	PriorityP3 = "P3"
+	PriorityP4 = "P4"
+	PriorityP5 = "P5"
+)
+
+var priorityOptions = []configuration.FieldOption{
+	{Label: "P1 - Critical", Value: PriorityP1},
+	{Label: "P2 - High", Value: PriorityP2},
+	{Label: "P3 - Moderate", Value: PriorityP3},
+	{Label: "P4 - Low", Value: PriorityP4},
+	{Label: "P5 - Informational", Value: PriorityP5},
+}
+
+func validPriority(priority string) bool {
+	return slices.ContainsFunc(priorityOptions, func(option configuration.FieldOption) bool {
+		return option.Value == priority
+	})
+}
+
+// AlertRef identifies the alert an action works on.
+type AlertRef struct {
+	AlertID        string `json:"alertId" mapstructure:"alertId"`
+	IdentifierType string `json:"identifierType" mapstructure:"identifierType"`
+}
+
+func (r AlertRef) validate() (AlertRef, error) {
+	r.AlertID = strings.TrimSpace(r.AlertID)
+	if r.AlertID == "" {
+		return AlertRef{}, fmt.Errorf("alertId is required")
+	}
+
+	switch r.IdentifierType {
+	case "":
+		r.IdentifierType = IdentifierTypeID
+	case IdentifierTypeID, IdentifierTypeTiny, IdentifierTypeAlias:
+	default:
+		return AlertRef{}, fmt.Errorf("invalid identifier type %q", r.IdentifierType)
+	}
+
+	return r, nil
+}
+
+func alertFields() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "alertId",
+			Label:       "Alert",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "{{ $['Create Alert'].data.id }}",
+			Description: "ID, tiny ID or alias of the alert",
+		},
+		{
+			Name:     "identifierType",
+			Label:    "Identifier Type",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  IdentifierTypeID,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "ID", Value: IdentifierTypeID},
+						{Label: "Tiny ID", Value: IdentifierTypeTiny},
+						{Label: "Alias", Value: IdentifierTypeAlias},
+					},
+				},
+			},
+		},
+	}
+}
+
+// IncidentRef identifies the incident an action works on.
+// Incidents have no alias, so they are identified by ID or tiny ID.
+type IncidentRef struct {
+	IncidentID     string `json:"incidentId" mapstructure:"incidentId"`
+	IdentifierType string `json:"identifierType" mapstructure:"identifierType"`
+}
+
+func (r IncidentRef) validate() (IncidentRef, error) {
+	r.IncidentID = strings.TrimSpace(r.IncidentID)
+	if r.IncidentID == "" {
+		return IncidentRef{}, fmt.Errorf("incidentId is required")
+	}
+
+	switch r.IdentifierType {
+	case "":
+		r.IdentifierType = IdentifierTypeID
+	case IdentifierTypeID, IdentifierTypeTiny:
+	default:
+		return IncidentRef{}, fmt.Errorf("invalid identifier type %q", r.IdentifierType)
+	}
+
+	return r, nil
+}
+
+func incidentFields() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "incidentId",
+			Label:       "Incident",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "{{ $['Create Incident'].data.id }}",
+			Description: "ID or tiny ID of the incident",
+		},
+		{
+			Name:     "identifierType",
+			Label:    "Identifier Type",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  IdentifierTypeID,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "ID", Value: IdentifierTypeID},
+						{Label: "Tiny ID", Value: IdentifierTypeTiny},
+					},
+				},
+			},
+		},
+	}
+}
+
+func noteField(description string) configuration.Field {
+	return configuration.Field{
+		Name:        "note",
+		Label:       "Note",
+		Type:        configuration.FieldTypeText,
+		Required:    false,
+		Description: description,
+	}
+}
+
+func tagsField() configuration.Field {
+	return configuration.Field{
+		Name:     "tags",
+		Label:    "Tags",
+		Type:     configuration.FieldTypeList,
+		Required: false,
+		TypeOptions: &configuration.TypeOptions{
+			List: &configuration.ListTypeOptions{
+				ItemLabel: "Tag",
+				ItemDefinition: &configuration.ListItemDefinition{
+					Type: configuration.FieldTypeString,
+				},
+			},
+		},
+	}
+}
+
+func detailsField() configuration.Field {
+	return configuration.Field{
+		Name:     "details",
+		Label:    "Details",
+		Type:     configuration.FieldTypeList,
+		Required: false,
+		TypeOptions: &configuration.TypeOptions{
+			List: &configuration.ListTypeOptions{
+				ItemLabel: "Detail",
+				ItemDefinition: &configuration.ListItemDefinition{
+					Type: configuration.FieldTypeObject,
+					Schema: []configuration.Field{
+						{
+							Name:     "key",
+							Label:    "Key",
+							Type:     configuration.FieldTypeString,
+							Required: true,
+						},
+						{
+							Name:     "value",
+							Label:    "Value",
+							Type:     configuration.FieldTypeString,
+							Required: false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func teamsField(description string) configuration.Field {
+	return configuration.Field{
+		Name:        "teams",
+		Label:       "Teams",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    false,
+		Description: description,
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type:  ResourceTypeTeam,
+				Multi: true,
+			},
+		},
+	}
+}
+
+func accountName(integration core.IntegrationContext) string {
+	if integration == nil {
+		return ""
+	}
+
+	metadata := Metadata{}
+	if err := mapstructure.Decode(integration.GetMetadata(), &metadata); err != nil {
+		return ""
+	}
+
+	return metadata.Account
+}
+
+// alertPayload is the alert as Opsgenie returns it, with a link to it.
+func alertPayload(client *Client, integration core.IntegrationContext, alert map[string]any) map[string]any {
+	payload := make(map[string]any, len(alert)+1)
+	for key, value := range alert {
+		payload[key] = value
+	}
+
+	alertID, _ := alert["id"].(string)
+	if link := client.AlertURL(accountName(integration), alertID); link != "" {
+		payload["url"] = link
+	}
+
+	return payload
+}
+
+// incidentPayload is the incident as Opsgenie returns it,
+// with the link to it in the web app under url.
+func incidentPayload(incident map[string]any) map[string]any {
+	payload := make(map[string]any, len(incident)+1)
+	for key, value := range incident {
+		payload[key] = value
+	}
+
+	if links, ok := incident["links"].(map[string]any); ok {
+		if link, _ := links["web"].(string); link != "" {
+			payload["url"] = link
+		}
+	}
+
+	return payload
+}
+
+// runIncidentAction runs an action on an incident, waits until Opsgenie
+// processed it, and emits the incident as it is after the action.
+func runIncidentAction(ctx core.ExecutionContext, ref IncidentRef, action, verb string, request IncidentRequest, extra map[string]any) error {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	requestID, err := client.IncidentAction(ref.IncidentID, ref.IdentifierType, action, request)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to %s incident %s: %v", verb, ref.IncidentID, err))
+	}
+
+	status, err := client.WaitForIncidentRequest(requestID)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to %s incident %s: %v", verb, ref.IncidentID, err))
+	}
+
+	identifier, identifierType := ref.IncidentID, ref.IdentifierType
+	if status.IncidentID != "" {
+		identifier, identifierType = status.IncidentID, IdentifierTypeID
+	}
+
+	incident, err := client.GetIncident(identifier, identifierType)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get incident %s: %v", identifier, err))
+	}
+
+	payload := incidentPayload(incident)
+	for key, value := range extra {
+		payload[key] = value
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, IncidentPayloadType, []any{payload})
+}
+
+// runAlertAction runs an action on an alert, waits until Opsgenie
+// processed it, and emits the alert as it is after the action.
+func runAlertAction(ctx core.ExecutionContext, ref AlertRef, action, verb string, request AlertRequest, extra map[string]any) error {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	requestID, err := client.AlertAction(ref.AlertID, ref.IdentifierType, action, request)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to %s alert %s: %v", verb, ref.AlertID, err))
+	}
+
+	status, err := client.WaitForRequest(requestID)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to %s alert %s: %v", verb, ref.AlertID, err))
+	}
+
+	identifier, identifierType := ref.AlertID, ref.IdentifierType
+	if status.AlertID != "" {
+		identifier, identifierType = status.AlertID, IdentifierTypeID
+	}
+
+	alert, err := client.GetAlert(identifier, identifierType)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get alert %s: %v", identifier, err))
+	}
+
+	payload := alertPayload(client, ctx.Integration, alert)
+	for key, value := range extra {
+		payload[key] = value
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, AlertPayloadType, []any{payload})
+}
+
+func detailsMap(details []AlertDetail) map[string]string {
+	if len(details) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(details))
+	for _, detail := range details {
+		result[detail.Key] = detail.Value
+	}
+
+	return result
+}
+
+func nonEmpty(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+func intPtr(value int) *int {
+	return &value
+}
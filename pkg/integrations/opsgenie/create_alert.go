@@ -0,0 +1,232 @@
+package opsgenie
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const MaxMessageLength = 130
+
+type CreateAlert struct{}
+
+type CreateAlertSpec struct {
+	Message     string        `json:"message" mapstructure:"message"`
+	Description string        `json:"description" mapstructure:"description"`
+	Alias       string        `json:"alias" mapstructure:"alias"`
+	Priority    string        `json:"priority" mapstructure:"priority"`
+	Teams       []string      `json:"teams" mapstructure:"teams"`
+	Tags        []string      `json:"tags" mapstructure:"tags"`
+	Details     []AlertDetail `json:"details" mapstructure:"details"`
+	Entity      string        `json:"entity" mapstructure:"entity"`
+}
+
+type AlertDetail struct {
+	Key   string `json:"key" mapstructure:"key"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+func (c *CreateAlert) Name() string {
+	return "opsgenie.createAlert"
+}
+
+func (c *CreateAlert) Label() string {
+	return "Create Alert"
+}
+
+func (c *CreateAlert) Description() string {
+	return "Create an alert in Opsgenie"
+}
+
+func (c *CreateAlert) Documentation() string {
+	return `The Create Alert component creates an alert in Opsgenie, and notifies its responders.
+
+## Use Cases
+
+- **Failed deployments**: Page the owning team when a deployment or its checks fail
+- **Workflow alerts**: Alert on conditions detected by a workflow, like a failed backup
+- **Escalation paths**: Create an alert as part of an incident response workflow
+
+## Configuration
+
+- **Message**: Short summary of the alert, up to 130 characters
+- **Description**: Longer details of the alert
+- **Alias**: Deduplication key. Creating an alert with the alias of an open alert increases the count of that alert instead of creating a new one.
+- **Priority**: P1 to P5. Defaults to P3.
+- **Teams**: Teams to notify
+- **Tags**: Tags of the alert
+- **Details**: Custom properties of the alert, as key/value pairs
+- **Entity**: The entity the alert is about, like a service or a host
+
+## Output
+
+The alert, with its ID, tiny ID, status, and a link to it.`
+}
+
+func (c *CreateAlert) Icon() string {
+	return "alert-triangle"
+}
+
+func (c *CreateAlert) Color() string {
+	return "gray"
+}
+
+func (c *CreateAlert) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateAlert) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "message",
+			Label:       "Message",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "Deployment of api to production failed",
+			Description: "Short summary of the alert, up to 130 characters",
+		},
+		{
+			Name:        "description",
+			Label:       "Description",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Longer details of the alert",
+		},
+		{
+			Name:        "alias",
+			Label:       "Alias",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Deduplication key of the alert",
+		},
+		{
+			Name:     "priority",
+			Label:    "Priority",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  PriorityP3,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{Options: priorityOptions},
+			},
+		},
+		teamsField("Teams to notify"),
+		tagsField(),
+		detailsField(),
+		{
+			Name:        "entity",
+			Label:       "Entity",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "The entity the alert is about, like a service or a host",
+		},
+	}
+}
+
+func (c *CreateAlert) Setup(ctx core.SetupContext) error {
+	_, err := decodeCreateAlertSpec(ctx.Configuration)
+	return err
+}
+
+func (c *CreateAlert) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCreateAlertSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	request := CreateAlertRequest{
+		Message:     spec.Message,
+		Alias:       spec.Alias,
+		Description: spec.Description,
+		Tags:        spec.Tags,
+		Details:     detailsMap(spec.Details),
+		Entity:      spec.Entity,
+		Source:      DefaultSource,
+		Priority:    spec.Priority,
+	}
+
+	for _, team := range spec.Teams {
+		request.Responders = append(request.Responders, Responder{Type: "team", ID: team})
+	}
+
+	requestID, err := client.CreateAlert(request)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create alert: %v", err))
+	}
+
+	status, err := client.WaitForRequest(requestID)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create alert: %v", err))
+	}
+
+	alert, err := client.GetAlert(status.AlertID, IdentifierTypeID)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get alert %s: %v", status.AlertID, err))
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, AlertPayloadType, []any{alertPayload(client, ctx.Integration, alert)})
+}
+
+func (c *CreateAlert) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateAlert) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *CreateAlert) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CreateAlert) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreateAlert) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeCreateAlertSpec(value any) (CreateAlertSpec, error) {
+	spec := CreateAlertSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return CreateAlertSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Message = strings.TrimSpace(spec.Message)
+	if spec.Message == "" {
+		return CreateAlertSpec{}, fmt.Errorf("message is required")
+	}
+
+	if len([]rune(spec.Message)) > MaxMessageLength {
+		return CreateAlertSpec{}, fmt.Errorf("message must be at most %d characters", MaxMessageLength)
+	}
+
+	if spec.Priority == "" {
+		spec.Priority = PriorityP3
+	}
+
+	if !validPriority(spec.Priority) {
+		return CreateAlertSpec{}, fmt.Errorf("invalid priority %q", spec.Priority)
+	}
+
+	spec.Alias = strings.TrimSpace(spec.Alias)
+	spec.Entity = strings.TrimSpace(spec.Entity)
+	spec.Teams = nonEmpty(spec.Teams)
+	spec.Tags = nonEmpty(spec.Tags)
+	for i := range spec.Details {
+		spec.Details[i].Key = strings.TrimSpace(spec.Details[i].Key)
+		if spec.Details[i].Key == "" {
+			return CreateAlertSpec{}, fmt.Errorf("detail %d: key is required", i+1)
+		}
+	}
+
+	return spec, nil
+}
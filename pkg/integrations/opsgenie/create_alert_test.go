@@ -0,0 +1,112 @@
+package opsgenie
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreateAlert__Setup(t *testing.T) {
+	component := &CreateAlert{}
+
+	t.Run("missing message -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"message": " "}})
+		require.ErrorContains(t, err, "message is required")
+	})
+
+	t.Run("message too long -> error", func(t *testing.T) {
+		message := make([]byte, MaxMessageLength+1)
+		for i := range message {
+			message[i] = 'a'
+		}
+
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"message": string(message)}})
+		require.ErrorContains(t, err, "at most 130 characters")
+	})
+
+	t.Run("invalid priority -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"message": "down", "priority": "P9"}})
+		require.ErrorContains(t, err, "invalid priority")
+	})
+}
+
+func Test__CreateAlert__Execute(t *testing.T) {
+	component := &CreateAlert{}
+
+	t.Run("creates alert, waits for the request and emits the alert", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusAccepted, `{"result":"Request will be processed","took":0.302,"requestId":"request-1"}`),
+				jsonResponse(http.StatusNotFound, `{"message":"Request not found. It might not be processed, yet."}`),
+				jsonResponse(http.StatusOK, requestStatusResponse(true)),
+				jsonResponse(http.StatusOK, `{"data":{"id":"alert-1","tinyId":"1791","alias":"api-production-deploy-failed","message":"Deployment of api to production failed","status":"open","acknowledged":false,"priority":"P2"}}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"message":  "Deployment of api to production failed",
+				"alias":    "api-production-deploy-failed",
+				"priority": PriorityP2,
+				"teams":    []any{"team-1"},
+				"tags":     []any{"deploy", " "},
+				"details":  []any{map[string]any{"key": "version", "value": "1.8.2"}},
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, AlertPayloadType, executionState.Type)
+
+		require.Len(t, httpContext.Requests, 4)
+		assert.Equal(t, http.MethodPost, httpContext.Requests[0].Method)
+		assert.Equal(t, "/v2/alerts", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "/v2/alerts/requests/request-1", httpContext.Requests[1].URL.Path)
+		assert.Equal(t, "/v2/alerts/alert-1", httpContext.Requests[3].URL.Path)
+
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		request := map[string]any{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		assert.Equal(t, "P2", request["priority"])
+		assert.Equal(t, "SuperPlane", request["source"])
+		assert.Equal(t, []any{"deploy"}, request["tags"])
+		assert.Equal(t, []any{map[string]any{"type": "team", "id": "team-1"}}, request["responders"])
+		assert.Equal(t, map[string]any{"version": "1.8.2"}, request["details"])
+
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "alert-1", payload["id"])
+		assert.Equal(t, "1791", payload["tinyId"])
+		assert.Equal(t, "https://acme.app.opsgenie.com/alert/detail/alert-1/details", payload["url"])
+	})
+
+	t.Run("API error -> fails execution", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusUnprocessableEntity, `{"message":"Request body is not processable. Please check the errors.","errors":{"message":"Message can not be empty."}}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"message": "down"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "Message can not be empty.")
+	})
+}
@@ -0,0 +1,219 @@
+package opsgenie
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type CreateIncident struct{}
+
+type CreateIncidentSpec struct {
+	Message            string        `json:"message" mapstructure:"message"`
+	Description        string        `json:"description" mapstructure:"description"`
+	Priority           string        `json:"priority" mapstructure:"priority"`
+	Teams              []string      `json:"teams" mapstructure:"teams"`
+	Tags               []string      `json:"tags" mapstructure:"tags"`
+	Details            []AlertDetail `json:"details" mapstructure:"details"`
+	Note               string        `json:"note" mapstructure:"note"`
+	NotifyStakeholders bool          `json:"notifyStakeholders" mapstructure:"notifyStakeholders"`
+}
+
+func (c *CreateIncident) Name() string {
+	return "opsgenie.createIncident"
+}
+
+func (c *CreateIncident) Label() string {
+	return "Create Incident"
+}
+
+func (c *CreateIncident) Description() string {
+	return "Create an incident in Opsgenie"
+}
+
+func (c *CreateIncident) Documentation() string {
+	return `The Create Incident component creates an incident in Opsgenie, and notifies its responder teams.
+
+## Use Cases
+
+- **Major outages**: Open an incident when a workflow detects that a service is down
+- **Failed releases**: Declare an incident when a production rollout can't be rolled back automatically
+
+## Configuration
+
+- **Message**: Short summary of the incident, up to 130 characters
+- **Description**: Longer details of the incident
+- **Priority**: P1 to P5. Defaults to P3.
+- **Teams**: Responder teams of the incident
+- **Tags**: Tags of the incident
+- **Details**: Custom properties of the incident, as key/value pairs
+- **Note**: Note added to the incident when it is created
+- **Notify Stakeholders**: Notify the stakeholders of the impacted services
+
+Incidents need Opsgenie Incident Management, which is part of the Standard and Enterprise plans.
+
+## Output
+
+The incident, with its ID, tiny ID, status, and a link to it.`
+}
+
+func (c *CreateIncident) Icon() string {
+	return "siren"
+}
+
+func (c *CreateIncident) Color() string {
+	return "gray"
+}
+
+func (c *CreateIncident) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateIncident) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "message",
+			Label:       "Message",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "api is down in production",
+			Description: "Short summary of the incident, up to 130 characters",
+		},
+		{
+			Name:        "description",
+			Label:       "Description",
+			Type:        configuration.FieldTypeText,
+			Required:    false,
+			Description: "Longer details of the incident",
+		},
+		{
+			Name:     "priority",
+			Label:    "Priority",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  PriorityP3,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{Options: priorityOptions},
+			},
+		},
+		teamsField("Responder teams of the incident"),
+		tagsField(),
+		detailsField(),
+		noteField("Note added to the incident when it is created"),
+		{
+			Name:        "notifyStakeholders",
+			Label:       "Notify Stakeholders",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Notify the stakeholders of the impacted services",
+		},
+	}
+}
+
+func (c *CreateIncident) Setup(ctx core.SetupContext) error {
+	_, err := decodeCreateIncidentSpec(ctx.Configuration)
+	return err
+}
+
+func (c *CreateIncident) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCreateIncidentSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	request := CreateIncidentRequest{
+		Message:            spec.Message,
+		Description:        spec.Description,
+		Tags:               spec.Tags,
+		Details:            detailsMap(spec.Details),
+		Priority:           spec.Priority,
+		Note:               spec.Note,
+		NotifyStakeholders: spec.NotifyStakeholders,
+	}
+
+	for _, team := range spec.Teams {
+		request.Responders = append(request.Responders, Responder{Type: "team", ID: team})
+	}
+
+	requestID, err := client.CreateIncident(request)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create incident: %v", err))
+	}
+
+	status, err := client.WaitForIncidentRequest(requestID)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create incident: %v", err))
+	}
+
+	incident, err := client.GetIncident(status.IncidentID, IdentifierTypeID)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get incident %s: %v", status.IncidentID, err))
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, IncidentPayloadType, []any{incidentPayload(incident)})
+}
+
+func (c *CreateIncident) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateIncident) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *CreateIncident) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CreateIncident) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreateIncident) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeCreateIncidentSpec(value any) (CreateIncidentSpec, error) {
+	spec := CreateIncidentSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return CreateIncidentSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Message = strings.TrimSpace(spec.Message)
+	if spec.Message == "" {
+		return CreateIncidentSpec{}, fmt.Errorf("message is required")
+	}
+
+	if len([]rune(spec.Message)) > MaxMessageLength {
+		return CreateIncidentSpec{}, fmt.Errorf("message must be at most %d characters", MaxMessageLength)
+	}
+
+	if spec.Priority == "" {
+		spec.Priority = PriorityP3
+	}
+
+	if !validPriority(spec.Priority) {
+		return CreateIncidentSpec{}, fmt.Errorf("invalid priority %q", spec.Priority)
+	}
+
+	spec.Note = strings.TrimSpace(spec.Note)
+	spec.Teams = nonEmpty(spec.Teams)
+	spec.Tags = nonEmpty(spec.Tags)
+	for i := range spec.Details {
+		spec.Details[i].Key = strings.TrimSpace(spec.Details[i].Key)
+		if spec.Details[i].Key == "" {
+			return CreateIncidentSpec{}, fmt.Errorf("detail %d: key is required", i+1)
+		}
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,83 @@
+package opsgenie
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func Test__CreateIncident__Setup(t *testing.T) {
+	component := &CreateIncident{}
+
+	t.Run("missing message -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"message": " "}})
+		require.ErrorContains(t, err, "message is required")
+	})
+
+	t.Run("invalid priority -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"message": "down", "priority": "P9"}})
+		require.ErrorContains(t, err, "invalid priority")
+	})
+
+	t.Run("detail without key -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{
+			"message": "down",
+			"details": []any{map[string]any{"key": " ", "value": "1.8.2"}},
+		}})
+
+		require.ErrorContains(t, err, "detail 1: key is required")
+	})
+}
+
+func Test__CreateIncident__Execute(t *testing.T) {
+	t.Run("creates incident, waits for the request and emits the incident", func(t *testing.T) {
+		httpContext, executionState := runAction(t, &CreateIncident{}, map[string]any{
+			"message":            "api is down in production",
+			"priority":           PriorityP1,
+			"teams":              []any{"team-1"},
+			"tags":               []any{"api", " "},
+			"details":            []any{map[string]any{"key": "version", "value": "1.8.2"}},
+			"notifyStakeholders": true,
+		}, []*http.Response{
+			jsonResponse(http.StatusAccepted, `{"result":"Request will be processed","took":0.113,"requestId":"request-1"}`),
+			jsonResponse(http.StatusNotFound, `{"message":"Request not found. It might not be processed, yet."}`),
+			jsonResponse(http.StatusOK, `{"data":{"success":true,"isSuccess":true,"action":"Create","processedAt":"2026-10-19T10:00:00.512Z","status":"Created incident","incidentId":"incident-1"}}`),
+			jsonResponse(http.StatusOK, `{"data":{"id":"incident-1","tinyId":"42","message":"api is down in production","status":"open","priority":"P1","links":{"web":"https://acme.app.opsgenie.com/incident/detail/incident-1"}}}`),
+		})
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, IncidentPayloadType, executionState.Type)
+
+		require.Len(t, httpContext.Requests, 4)
+		assert.Equal(t, http.MethodPost, httpContext.Requests[0].Method)
+		assert.Equal(t, "/v1/incidents/create", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "/v1/incidents/requests/request-1", httpContext.Requests[1].URL.Path)
+		assert.Equal(t, "/v1/incidents/incident-1", httpContext.Requests[3].URL.Path)
+
+		request := requestBody(t, httpContext.Requests[0])
+		assert.Equal(t, "P1", request["priority"])
+		assert.Equal(t, []any{"api"}, request["tags"])
+		assert.Equal(t, []any{map[string]any{"type": "team", "id": "team-1"}}, request["responders"])
+		assert.Equal(t, map[string]any{"version": "1.8.2"}, request["details"])
+		assert.Equal(t, true, request["notifyStakeholders"])
+
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "incident-1", payload["id"])
+		assert.Equal(t, "42", payload["tinyId"])
+		assert.Equal(t, "https://acme.app.opsgenie.com/incident/detail/incident-1", payload["url"])
+	})
+
+	t.Run("request failed -> fails", func(t *testing.T) {
+		_, executionState := runAction(t, &CreateIncident{}, map[string]any{"message": "api is down in production"}, []*http.Response{
+			jsonResponse(http.StatusAccepted, `{"result":"Request will be processed","took":0.1,"requestId":"request-1"}`),
+			jsonResponse(http.StatusOK, `{"data":{"success":false,"isSuccess":false,"action":"Create","processedAt":"2026-10-19T10:00:00.512Z","status":"Incident Management is not available for your plan"}}`),
+		})
+
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to create incident")
+		assert.Contains(t, executionState.FailureMessage, "not available for your plan")
+	})
+}
@@ -0,0 +1,144 @@
+package opsgenie
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type EscalateAlert struct{}
+
+type EscalateAlertSpec struct {
+	AlertRef   `mapstructure:",squash"`
+	Escalation string `json:"escalation" mapstructure:"escalation"`
+	Note       string `json:"note" mapstructure:"note"`
+}
+
+func (c *EscalateAlert) Name() string {
+	return "opsgenie.escalateAlert"
+}
+
+func (c *EscalateAlert) Label() string {
+	return "Escalate Alert"
+}
+
+func (c *EscalateAlert) Description() string {
+	return "Escalate an alert to an escalation policy in Opsgenie"
+}
+
+func (c *EscalateAlert) Documentation() string {
+	return `The Escalate Alert component hands an Opsgenie alert over to an escalation policy.
+
+## Use Cases
+
+- **Unacknowledged alerts**: Escalate an alert nobody picked up after a wait
+- **Severity changes**: Bring in a wider group when a workflow finds the impact is larger than expected
+
+## Configuration
+
+- **Alert**: ID, tiny ID or alias of the alert
+- **Identifier Type**: How the alert is identified. Defaults to ID.
+- **Escalation Policy**: The escalation policy that notifies the next responders
+- **Note**: Optional note added to the alert
+
+## Output
+
+The alert after it was escalated, with the escalation policy under ` + "`escalation`" + `.`
+}
+
+func (c *EscalateAlert) Icon() string {
+	return "arrow-up"
+}
+
+func (c *EscalateAlert) Color() string {
+	return "gray"
+}
+
+func (c *EscalateAlert) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *EscalateAlert) Configuration() []configuration.Field {
+	return append(
+		alertFields(),
+		configuration.Field{
+			Name:        "escalation",
+			Label:       "Escalation Policy",
+			Type:        configuration.FieldTypeIntegrationResource,
+			Required:    true,
+			Description: "Escalation policy that notifies the next responders",
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeEscalation,
+				},
+			},
+		},
+		noteField("Note added to the alert with the escalation"),
+	)
+}
+
+func (c *EscalateAlert) Setup(ctx core.SetupContext) error {
+	_, err := decodeEscalateAlertSpec(ctx.Configuration)
+	return err
+}
+
+func (c *EscalateAlert) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeEscalateAlertSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	request := AlertRequest{
+		Note:       spec.Note,
+		Source:     DefaultSource,
+		Escalation: &EscalationRef{ID: spec.Escalation},
+	}
+
+	return runAlertAction(ctx, spec.AlertRef, "escalate", "escalate", request, map[string]any{
+		"escalation": map[string]any{"id": spec.Escalation},
+	})
+}
+
+func (c *EscalateAlert) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *EscalateAlert) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *EscalateAlert) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *EscalateAlert) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *EscalateAlert) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeEscalateAlertSpec(value any) (EscalateAlertSpec, error) {
+	spec := EscalateAlertSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return EscalateAlertSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := spec.AlertRef.validate()
+	if err != nil {
+		return EscalateAlertSpec{}, err
+	}
+
+	spec.AlertRef = ref
+	spec.Escalation = strings.TrimSpace(spec.Escalation)
+	if spec.Escalation == "" {
+		return EscalateAlertSpec{}, fmt.Errorf("escalation is required")
+	}
+
+	spec.Note = strings.TrimSpace(spec.Note)
+	return spec, nil
+}
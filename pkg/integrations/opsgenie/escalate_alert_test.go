@@ -0,0 +1,27 @@
+package opsgenie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func Test__EscalateAlert__Execute(t *testing.T) {
+	t.Run("missing escalation -> error", func(t *testing.T) {
+		err := (&EscalateAlert{}).Setup(core.SetupContext{Configuration: map[string]any{"alertId": "alert-1"}})
+		require.ErrorContains(t, err, "escalation is required")
+	})
+
+	t.Run("escalates to the policy", func(t *testing.T) {
+		httpContext, executionState := runAction(t, &EscalateAlert{}, map[string]any{
+			"alertId":    "alert-1",
+			"escalation": "escalation-1",
+		}, alertActionResponses())
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, "/v2/alerts/alert-1/escalate", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, map[string]any{"id": "escalation-1"}, requestBody(t, httpContext.Requests[0])["escalation"])
+	})
+}
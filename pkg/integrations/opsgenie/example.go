@@ -0,0 +1,190 @@
+package opsgenie
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_data_on_alert.json
+var exampleDataOnAlertBytes []byte
+
+var exampleDataOnAlertOnce sync.Once
+var exampleDataOnAlert map[string]any
+
+//go:embed example_data_on_alert_status_changed.json
+var exampleDataOnAlertStatusChangedBytes []byte
+
+var exampleDataOnAlertStatusChangedOnce sync.Once
+var exampleDataOnAlertStatusChanged map[string]any
+
+//go:embed example_output_create_alert.json
+var exampleOutputCreateAlertBytes []byte
+
+var exampleOutputCreateAlertOnce sync.Once
+var exampleOutputCreateAlert map[string]any
+
+//go:embed example_output_acknowledge_alert.json
+var exampleOutputAcknowledgeAlertBytes []byte
+
+var exampleOutputAcknowledgeAlertOnce sync.Once
+var exampleOutputAcknowledgeAlert map[string]any
+
+//go:embed example_output_close_alert.json
+var exampleOutputCloseAlertBytes []byte
+
+var exampleOutputCloseAlertOnce sync.Once
+var exampleOutputCloseAlert map[string]any
+
+//go:embed example_output_add_note.json
+var exampleOutputAddNoteBytes []byte
+
+var exampleOutputAddNoteOnce sync.Once
+var exampleOutputAddNote map[string]any
+
+//go:embed example_output_escalate_alert.json
+var exampleOutputEscalateAlertBytes []byte
+
+var exampleOutputEscalateAlertOnce sync.Once
+var exampleOutputEscalateAlert map[string]any
+
+//go:embed example_output_snooze_alert.json
+var exampleOutputSnoozeAlertBytes []byte
+
+var exampleOutputSnoozeAlertOnce sync.Once
+var exampleOutputSnoozeAlert map[string]any
+
+//go:embed example_output_list_on_call.json
+var exampleOutputListOnCallBytes []byte
+
+var exampleOutputListOnCallOnce sync.Once
+var exampleOutputListOnCall map[string]any
+
+//go:embed example_output_create_incident.json
+var exampleOutputCreateIncidentBytes []byte
+
+var exampleOutputCreateIncidentOnce sync.Once
+var exampleOutputCreateIncident map[string]any
+
+//go:embed example_output_resolve_incident.json
+var exampleOutputResolveIncidentBytes []byte
+
+var exampleOutputResolveIncidentOnce sync.Once
+var exampleOutputResolveIncident map[string]any
+
+//go:embed example_output_close_incident.json
+var exampleOutputCloseIncidentBytes []byte
+
+var exampleOutputCloseIncidentOnce sync.Once
+var exampleOutputCloseIncident map[string]any
+
+//go:embed example_output_add_incident_note.json
+var exampleOutputAddIncidentNoteBytes []byte
+
+var exampleOutputAddIncidentNoteOnce sync.Once
+var exampleOutputAddIncidentNote map[string]any
+
+func (t *OnAlert) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnAlertOnce,
+		exampleDataOnAlertBytes,
+		&exampleDataOnAlert,
+	)
+}
+
+func (t *OnAlertStatusChanged) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnAlertStatusChangedOnce,
+		exampleDataOnAlertStatusChangedBytes,
+		&exampleDataOnAlertStatusChanged,
+	)
+}
+
+func (c *CreateAlert) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputCreateAlertOnce,
+		exampleOutputCreateAlertBytes,
+		&exampleOutputCreateAlert,
+	)
+}
+
+func (c *AcknowledgeAlert) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputAcknowledgeAlertOnce,
+		exampleOutputAcknowledgeAlertBytes,
+		&exampleOutputAcknowledgeAlert,
+	)
+}
+
+func (c *CloseAlert) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputCloseAlertOnce,
+		exampleOutputCloseAlertBytes,
+		&exampleOutputCloseAlert,
+	)
+}
+
+func (c *AddNote) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputAddNoteOnce,
+		exampleOutputAddNoteBytes,
+		&exampleOutputAddNote,
+	)
+}
+
+func (c *EscalateAlert) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputEscalateAlertOnce,
+		exampleOutputEscalateAlertBytes,
+		&exampleOutputEscalateAlert,
+	)
+}
+
+func (c *SnoozeAlert) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputSnoozeAlertOnce,
+		exampleOutputSnoozeAlertBytes,
+		&exampleOutputSnoozeAlert,
+	)
+}
+
+func (c *ListOnCall) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputListOnCallOnce,
+		exampleOutputListOnCallBytes,
+		&exampleOutputListOnCall,
+	)
+}
+
+func (c *CreateIncident) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputCreateIncidentOnce,
+		exampleOutputCreateIncidentBytes,
+		&exampleOutputCreateIncident,
+	)
+}
+
+func (c *ResolveIncident) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputResolveIncidentOnce,
+		exampleOutputResolveIncidentBytes,
+		&exampleOutputResolveIncident,
+	)
+}
+
+func (c *CloseIncident) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputCloseIncidentOnce,
+		exampleOutputCloseIncidentBytes,
+		&exampleOutputCloseIncident,
+	)
+}
+
+func (c *AddIncidentNote) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputAddIncidentNoteOnce,
+		exampleOutputAddIncidentNoteBytes,
+		&exampleOutputAddIncidentNote,
+	)
+}
@@ -0,0 +1,196 @@
+package opsgenie
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const OnCallPayloadType = "opsgenie.onCall"
+
+type ListOnCall struct{}
+
+type ListOnCallSpec struct {
+	Schedule string `json:"schedule" mapstructure:"schedule"`
+	Date     string `json:"date" mapstructure:"date"`
+}
+
+func (c *ListOnCall) Name() string {
+	return "opsgenie.listOnCall"
+}
+
+func (c *ListOnCall) Label() string {
+	return "List On-Call"
+}
+
+func (c *ListOnCall) Description() string {
+	return "List who is on call for an Opsgenie schedule"
+}
+
+func (c *ListOnCall) Documentation() string {
+	return `The List On-Call component returns the participants that are on call for an Opsgenie schedule.
+
+## Use Cases
+
+- **Notifications**: Mention the on-call engineer in a Slack message or a ticket
+- **Approvals**: Route an approval to whoever is on call
+- **Handoffs**: Check who will be on call at a given time
+
+## Configuration
+
+- **Schedule**: The schedule to check
+- **Date**: Optional point in time, in RFC 3339 format. Defaults to now.
+
+## Output
+
+The schedule, the date that was checked, and the on-call participants, each with its ID, name and type (user, team or escalation).`
+}
+
+func (c *ListOnCall) Icon() string {
+	return "users"
+}
+
+func (c *ListOnCall) Color() string {
+	return "gray"
+}
+
+func (c *ListOnCall) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ListOnCall) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "schedule",
+			Label:    "Schedule",
+			Type:     configuration.FieldTypeIntegrationResource,
+			Required: true,
+			TypeOptions: &configuration.TypeOptions{
+				Resource: &configuration.ResourceTypeOptions{
+					Type: ResourceTypeSchedule,
+				},
+			},
+		},
+		{
+			Name:        "date",
+			Label:       "Date",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "2026-01-15T09:00:00Z",
+			Description: "Point in time to check, in RFC 3339 format. Defaults to now.",
+		},
+	}
+}
+
+func (c *ListOnCall) Setup(ctx core.SetupContext) error {
+	spec, err := decodeListOnCallSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	// The date is often an expression, so it is only
+	// validated when it is a literal value.
+	if !strings.Contains(spec.Date, "{{") {
+		_, err = parseOnCallDate(spec.Date)
+	}
+
+	return err
+}
+
+func (c *ListOnCall) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeListOnCallSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	date, err := parseOnCallDate(spec.Date)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", err.Error())
+	}
+
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	onCall, err := client.GetOnCalls(spec.Schedule, date)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to list on-call participants of schedule %s: %v", spec.Schedule, err))
+	}
+
+	participants := make([]map[string]any, 0, len(onCall.OnCallParticipants))
+	for _, participant := range onCall.OnCallParticipants {
+		participants = append(participants, map[string]any{
+			"id":   participant.ID,
+			"name": participant.Name,
+			"type": participant.Type,
+		})
+	}
+
+	payload := map[string]any{
+		"schedule": map[string]any{
+			"id":   onCall.Parent.ID,
+			"name": onCall.Parent.Name,
+		},
+		"date":         date.UTC().Format(time.RFC3339),
+		"participants": participants,
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, OnCallPayloadType, []any{payload})
+}
+
+func (c *ListOnCall) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ListOnCall) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *ListOnCall) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *ListOnCall) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *ListOnCall) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeListOnCallSpec(value any) (ListOnCallSpec, error) {
+	spec := ListOnCallSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return ListOnCallSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Schedule = strings.TrimSpace(spec.Schedule)
+	if spec.Schedule == "" {
+		return ListOnCallSpec{}, fmt.Errorf("schedule is required")
+	}
+
+	spec.Date = strings.TrimSpace(spec.Date)
+	return spec, nil
+}
+
+func parseOnCallDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: expected RFC 3339 format, like 2026-01-15T09:00:00Z", value)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,46 @@
+package opsgenie
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func Test__ListOnCall__Execute(t *testing.T) {
+	t.Run("invalid date -> error", func(t *testing.T) {
+		err := (&ListOnCall{}).Setup(core.SetupContext{Configuration: map[string]any{"schedule": "schedule-1", "date": "tomorrow"}})
+		require.ErrorContains(t, err, "invalid date")
+	})
+
+	t.Run("expression date -> not validated on setup", func(t *testing.T) {
+		err := (&ListOnCall{}).Setup(core.SetupContext{Configuration: map[string]any{"schedule": "schedule-1", "date": "{{ root().data.date }}"}})
+		require.NoError(t, err)
+	})
+
+	t.Run("lists participants", func(t *testing.T) {
+		httpContext, executionState := runAction(t, &ListOnCall{}, map[string]any{
+			"schedule": "schedule-1",
+			"date":     "2026-10-19T10:00:00Z",
+		}, []*http.Response{
+			jsonResponse(http.StatusOK, `{
+				"data": {
+					"_parent": {"id": "schedule-1", "name": "Platform Primary", "enabled": true},
+					"onCallParticipants": [{"id": "user-1", "name": "jane@acme.com", "type": "user"}]
+				}
+			}`),
+		})
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, OnCallPayloadType, executionState.Type)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "/v2/schedules/schedule-1/on-calls", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "2026-10-19T10:00:00Z", httpContext.Requests[0].URL.Query().Get("date"))
+
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, map[string]any{"id": "schedule-1", "name": "Platform Primary"}, payload["schedule"])
+		assert.Equal(t, []map[string]any{{"id": "user-1", "name": "jane@acme.com", "type": "user"}}, payload["participants"])
+	})
+}
@@ -0,0 +1,159 @@
+package opsgenie
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const OnAlertPayloadType = "opsgenie.alert.created"
+
+type OnAlert struct{}
+
+type OnAlertConfiguration struct {
+	Priorities []string `json:"priorities" mapstructure:"priorities"`
+	Teams      []string `json:"teams" mapstructure:"teams"`
+	Tags       []string `json:"tags" mapstructure:"tags"`
+}
+
+func (t *OnAlert) Name() string {
+	return "opsgenie.onAlert"
+}
+
+func (t *OnAlert) Label() string {
+	return "On Alert"
+}
+
+func (t *OnAlert) Description() string {
+	return "Listen to alerts created in Opsgenie"
+}
+
+func (t *OnAlert) Documentation() string {
+	return `The On Alert trigger starts a workflow when an alert is created in Opsgenie.
+
+## Use Cases
+
+- **Automated diagnostics**: Collect logs and metrics as soon as an alert fires
+- **Incident channels**: Open a Slack channel or a ticket for high priority alerts
+- **Auto-remediation**: Restart a service or roll back a deployment for known alerts
+
+## Configuration
+
+- **Priorities**: Only fire for alerts with these priorities. Leave empty for every priority.
+- **Teams**: Only fire for alerts assigned to one of these teams. Leave empty for every team.
+- **Tags**: Only fire for alerts with at least one of these tags. Leave empty for every alert.
+
+## Event Data
+
+The action and the alert, with its ID, tiny ID, message, priority, tags, teams, responders and a link to it.
+
+## Webhook Setup
+
+SuperPlane creates a Webhook integration in Opsgenie when the trigger is saved, and deletes it when the last trigger of the integration is removed. The API key needs **Allow Configuration Access** for this.`
+}
+
+func (t *OnAlert) Icon() string {
+	return "alert-triangle"
+}
+
+func (t *OnAlert) Color() string {
+	return "gray"
+}
+
+func (t *OnAlert) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "priorities",
+			Label:       "Priorities",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    false,
+			Description: "Only fire for alerts with these priorities",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{Options: priorityOptions},
+			},
+		},
+		teamsField("Only fire for alerts assigned to one of these teams"),
+		{
+			Name:        "tags",
+			Label:       "Tags",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Only fire for alerts with at least one of these tags",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Tag",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *OnAlert) Setup(ctx core.TriggerContext) error {
+	config := OnAlertConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	for _, priority := range config.Priorities {
+		if !validPriority(priority) {
+			return fmt.Errorf("invalid priority %q", priority)
+		}
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{})
+}
+
+func (t *OnAlert) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (t *OnAlert) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnAlert) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	config := OnAlertConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	webhook, payload, status, err := readAlertWebhook(ctx)
+	if err != nil {
+		return status, nil, err
+	}
+
+	if webhook.Action != WebhookActionCreate {
+		return http.StatusOK, nil, nil
+	}
+
+	if len(config.Priorities) > 0 && !slices.Contains(config.Priorities, webhook.Alert.Priority) {
+		return http.StatusOK, nil, nil
+	}
+
+	teams := nonEmpty(config.Teams)
+	if len(teams) > 0 && !webhook.hasTeam(teams) {
+		return http.StatusOK, nil, nil
+	}
+
+	tags := nonEmpty(config.Tags)
+	if len(tags) > 0 && !webhook.hasTag(tags) {
+		return http.StatusOK, nil, nil
+	}
+
+	if err := ctx.Events.Emit(OnAlertPayloadType, alertEventPayload(ctx.Integration, webhook, payload)); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to emit event: %w", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnAlert) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
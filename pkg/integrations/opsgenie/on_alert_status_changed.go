@@ -0,0 +1,157 @@
+package opsgenie
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const OnAlertStatusChangedPayloadType = "opsgenie.alert.statusChanged"
+
+type OnAlertStatusChanged struct{}
+
+type OnAlertStatusChangedConfiguration struct {
+	Actions []string `json:"actions" mapstructure:"actions"`
+	Teams   []string `json:"teams" mapstructure:"teams"`
+}
+
+var statusChangeActionOptions = []configuration.FieldOption{
+	{Label: "Acknowledged", Value: WebhookActionAcknowledge},
+	{Label: "Unacknowledged", Value: WebhookActionUnAcknowledge},
+	{Label: "Closed", Value: WebhookActionClose},
+	{Label: "Deleted", Value: WebhookActionDelete},
+	{Label: "Snoozed", Value: WebhookActionSnooze},
+	{Label: "Snooze ended", Value: WebhookActionSnoozeEnded},
+	{Label: "Escalated", Value: WebhookActionEscalate},
+	{Label: "Escalated to next", Value: WebhookActionEscalateNext},
+	{Label: "Ownership assigned", Value: WebhookActionAssignOwnership},
+	{Label: "Ownership taken", Value: WebhookActionTakeOwnership},
+	{Label: "Priority updated", Value: WebhookActionUpdatePriority},
+	{Label: "Note added", Value: WebhookActionAddNote},
+}
+
+func (t *OnAlertStatusChanged) Name() string {
+	return "opsgenie.onAlertStatusChanged"
+}
+
+func (t *OnAlertStatusChanged) Label() string {
+	return "On Alert Status Changed"
+}
+
+func (t *OnAlertStatusChanged) Description() string {
+	return "Listen to alerts that are acknowledged, closed, snoozed or escalated in Opsgenie"
+}
+
+func (t *OnAlertStatusChanged) Documentation() string {
+	return `The On Alert Status Changed trigger starts a workflow when an Opsgenie alert is acknowledged, closed, snoozed, escalated, or otherwise changes.
+
+## Use Cases
+
+- **Status sync**: Update a ticket or a status page when an alert is acknowledged or closed
+- **Follow-ups**: Start a postmortem workflow when a high priority alert closes
+- **Escalation tracking**: Notify a wider channel when an alert is escalated
+
+## Configuration
+
+- **Actions**: The alert actions to fire for. Defaults to acknowledged and closed.
+- **Teams**: Only fire for alerts assigned to one of these teams. Leave empty for every team.
+
+## Event Data
+
+The action, the user or integration that ran it, and the alert, with its ID, tiny ID, message, priority, tags, teams and a link to it.
+
+## Webhook Setup
+
+SuperPlane creates a Webhook integration in Opsgenie when the trigger is saved, and deletes it when the last trigger of the integration is removed. The API key needs **Allow Configuration Access** for this.`
+}
+
+func (t *OnAlertStatusChanged) Icon() string {
+	return "alert-triangle"
+}
+
+func (t *OnAlertStatusChanged) Color() string {
+	return "gray"
+}
+
+func (t *OnAlertStatusChanged) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "actions",
+			Label:       "Actions",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    true,
+			Default:     []string{WebhookActionAcknowledge, WebhookActionClose},
+			Description: "The alert actions to fire for",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{Options: statusChangeActionOptions},
+			},
+		},
+		teamsField("Only fire for alerts assigned to one of these teams"),
+	}
+}
+
+func (t *OnAlertStatusChanged) Setup(ctx core.TriggerContext) error {
+	config := OnAlertStatusChangedConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if len(config.Actions) == 0 {
+		return fmt.Errorf("at least one action must be selected")
+	}
+
+	for _, action := range config.Actions {
+		validAction := slices.ContainsFunc(statusChangeActionOptions, func(option configuration.FieldOption) bool {
+			return option.Value == action
+		})
+
+		if !validAction {
+			return fmt.Errorf("invalid action %q", action)
+		}
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{})
+}
+
+func (t *OnAlertStatusChanged) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (t *OnAlertStatusChanged) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnAlertStatusChanged) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	config := OnAlertStatusChangedConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	webhook, payload, status, err := readAlertWebhook(ctx)
+	if err != nil {
+		return status, nil, err
+	}
+
+	if !slices.Contains(config.Actions, webhook.Action) {
+		return http.StatusOK, nil, nil
+	}
+
+	teams := nonEmpty(config.Teams)
+	if len(teams) > 0 && !webhook.hasTeam(teams) {
+		return http.StatusOK, nil, nil
+	}
+
+	if err := ctx.Events.Emit(OnAlertStatusChangedPayloadType, alertEventPayload(ctx.Integration, webhook, payload)); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to emit event: %w", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnAlertStatusChanged) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
@@ -0,0 +1,171 @@
+package opsgenie
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testWebhookSecret = "secret-1"
+
+func alertWebhook(action, priority string) string {
+	return `{
+		"action": "` + action + `",
+		"alert": {
+			"alertId": "alert-1",
+			"tinyId": "1791",
+			"message": "Deployment of api to production failed",
+			"priority": "` + priority + `",
+			"tags": ["deploy", "production"],
+			"teams": ["team-1"],
+			"responders": [{"id": "team-1", "type": "team", "name": "Platform"}],
+			"username": "System"
+		},
+		"source": {"name": "SuperPlane", "type": "API"},
+		"integrationId": "integration-1"
+	}`
+}
+
+func handleAlertWebhook(trigger core.Trigger, configuration map[string]any, body, secret string) (int, *contexts.EventContext, error) {
+	headers := http.Header{}
+	if secret != "" {
+		headers.Set(webhookSecretHeader, secret)
+	}
+
+	events := &contexts.EventContext{}
+	code, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+		Body:          []byte(body),
+		Headers:       headers,
+		Configuration: configuration,
+		Integration:   testIntegration(),
+		Webhook:       &contexts.NodeWebhookContext{Secret: testWebhookSecret},
+		Events:        events,
+	})
+
+	return code, events, err
+}
+
+func Test__OnAlert__HandleWebhook(t *testing.T) {
+	trigger := &OnAlert{}
+
+	t.Run("missing secret -> forbidden", func(t *testing.T) {
+		code, events, err := handleAlertWebhook(trigger, map[string]any{}, alertWebhook("Create", "P1"), "")
+
+		require.ErrorContains(t, err, "missing X-SuperPlane-Webhook-Secret header")
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("wrong secret -> forbidden", func(t *testing.T) {
+		code, _, err := handleAlertWebhook(trigger, map[string]any{}, alertWebhook("Create", "P1"), "wrong")
+
+		require.ErrorContains(t, err, "invalid webhook secret")
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+
+	t.Run("created alert -> emits event with link", func(t *testing.T) {
+		code, events, err := handleAlertWebhook(trigger, map[string]any{}, alertWebhook("Create", "P1"), testWebhookSecret)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, OnAlertPayloadType, events.Payloads[0].Type)
+
+		data := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "Create", data["action"])
+		alert := data["alert"].(map[string]any)
+		assert.Equal(t, "1791", alert["tinyId"])
+		assert.Equal(t, "https://acme.app.opsgenie.com/alert/detail/alert-1/details", alert["url"])
+	})
+
+	t.Run("other actions -> ignored", func(t *testing.T) {
+		_, events, err := handleAlertWebhook(trigger, map[string]any{}, alertWebhook("Acknowledge", "P1"), testWebhookSecret)
+
+		require.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("filters -> only matching alerts emit", func(t *testing.T) {
+		config := map[string]any{"priorities": []any{"P1", "P2"}, "teams": []any{"team-1"}, "tags": []any{"production"}}
+
+		_, events, err := handleAlertWebhook(trigger, config, alertWebhook("Create", "P3"), testWebhookSecret)
+		require.NoError(t, err)
+		assert.Zero(t, events.Count())
+
+		_, events, err = handleAlertWebhook(trigger, config, alertWebhook("Create", "P1"), testWebhookSecret)
+		require.NoError(t, err)
+		assert.Equal(t, 1, events.Count())
+
+		_, events, err = handleAlertWebhook(trigger, map[string]any{"teams": []any{"team-2"}}, alertWebhook("Create", "P1"), testWebhookSecret)
+		require.NoError(t, err)
+		assert.Zero(t, events.Count())
+
+		_, events, err = handleAlertWebhook(trigger, map[string]any{"tags": []any{"staging"}}, alertWebhook("Create", "P1"), testWebhookSecret)
+		require.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+}
+
+func Test__OnAlert__Setup(t *testing.T) {
+	trigger := &OnAlert{}
+
+	t.Run("requests the shared webhook", func(t *testing.T) {
+		integration := testIntegration()
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"priorities": []any{"P1"}},
+			Integration:   integration,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []any{WebhookConfiguration{}}, integration.WebhookRequests)
+	})
+
+	t.Run("invalid priority -> error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{
+			Configuration: map[string]any{"priorities": []any{"P0"}},
+			Integration:   testIntegration(),
+		})
+
+		require.ErrorContains(t, err, "invalid priority")
+	})
+}
+
+func Test__OnAlertStatusChanged__HandleWebhook(t *testing.T) {
+	trigger := &OnAlertStatusChanged{}
+	config := map[string]any{"actions": []any{"Acknowledge", "Close"}}
+
+	t.Run("selected action -> emits event", func(t *testing.T) {
+		code, events, err := handleAlertWebhook(trigger, config, alertWebhook("Close", "P1"), testWebhookSecret)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, OnAlertStatusChangedPayloadType, events.Payloads[0].Type)
+	})
+
+	t.Run("other actions -> ignored", func(t *testing.T) {
+		for _, action := range []string{"Create", "Snooze", "AddNote"} {
+			_, events, err := handleAlertWebhook(trigger, config, alertWebhook(action, "P1"), testWebhookSecret)
+
+			require.NoError(t, err)
+			assert.Zero(t, events.Count(), action)
+		}
+	})
+
+	t.Run("team filter", func(t *testing.T) {
+		config := map[string]any{"actions": []any{"Acknowledge"}, "teams": []any{"team-2"}}
+		_, events, err := handleAlertWebhook(trigger, config, alertWebhook("Acknowledge", "P1"), testWebhookSecret)
+
+		require.NoError(t, err)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("no actions -> setup error", func(t *testing.T) {
+		err := trigger.Setup(core.TriggerContext{Configuration: map[string]any{}, Integration: testIntegration()})
+		require.ErrorContains(t, err, "at least one action must be selected")
+	})
+}
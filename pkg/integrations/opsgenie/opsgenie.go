@@ -0,0 +1,186 @@
+package opsgenie
+
+import (
+	"fmt"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const (
+	ResourceTypeTeam       = "team"
+	ResourceTypeSchedule   = "schedule"
+	ResourceTypeEscalation = "escalation"
+)
+
+func init() {
+	registry.RegisterIntegrationWithWebhookHandler("opsgenie", &Opsgenie{}, &OpsgenieWebhookHandler{})
+}
+
+type Opsgenie struct{}
+
+type Configuration struct {
+	Region string `json:"region" mapstructure:"region"`
+	APIKey string `json:"apiKey" mapstructure:"apiKey"`
+}
+
+type Metadata struct {
+	Account string `json:"account" mapstructure:"account"`
+}
+
+func (o *Opsgenie) Name() string {
+	return "opsgenie"
+}
+
+func (o *Opsgenie) Label() string {
+	return "Opsgenie"
+}
+
+func (o *Opsgenie) Icon() string {
+	return "opsgenie"
+}
+
+func (o *Opsgenie) Description() string {
+	return "Manage and react to alerts and incidents in Opsgenie"
+}
+
+func (o *Opsgenie) Instructions() string {
+	return `
+1. **Region:** EU for accounts on ` + "`app.eu.opsgenie.com`" + `, US otherwise.
+2. **API Key:** In Opsgenie, go to **Settings → Integrations → Add integration → API**, and copy its API key.
+   - Enable **Read Access**, **Create and Update Access** and **Delete Access**.
+   - Triggers create a Webhook integration in Opsgenie, which needs **Allow Configuration Access** on the API integration.
+   - Leave the integration without a team, so it can act on alerts and incidents of every team.`
+}
+
+func (o *Opsgenie) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:     "region",
+			Label:    "Region",
+			Type:     configuration.FieldTypeSelect,
+			Required: true,
+			Default:  RegionUS,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "US", Value: RegionUS},
+						{Label: "EU", Value: RegionEU},
+					},
+				},
+			},
+		},
+		{
+			Name:        "apiKey",
+			Label:       "API Key",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "API key of an Opsgenie API integration",
+		},
+	}
+}
+
+func (o *Opsgenie) Actions() []core.Action {
+	return []core.Action{
+		&CreateAlert{},
+		&AcknowledgeAlert{},
+		&CloseAlert{},
+		&AddNote{},
+		&EscalateAlert{},
+		&SnoozeAlert{},
+		&ListOnCall{},
+		&CreateIncident{},
+		&ResolveIncident{},
+		&CloseIncident{},
+		&AddIncidentNote{},
+	}
+}
+
+func (o *Opsgenie) Triggers() []core.Trigger {
+	return []core.Trigger{
+		&OnAlert{},
+		&OnAlertStatusChanged{},
+	}
+}
+
+func (o *Opsgenie) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (o *Opsgenie) Sync(ctx core.SyncContext) error {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	account, err := client.GetAccount()
+	if err != nil {
+		return fmt.Errorf("failed to verify Opsgenie API key: %w", err)
+	}
+
+	ctx.Integration.SetMetadata(Metadata{Account: account.Name})
+	ctx.Integration.Ready()
+	return nil
+}
+
+func (o *Opsgenie) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (o *Opsgenie) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	switch resourceType {
+	case ResourceTypeTeam, ResourceTypeSchedule, ResourceTypeEscalation:
+	default:
+		return []core.IntegrationResource{}, nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []core.IntegrationResource{}
+	switch resourceType {
+	case ResourceTypeTeam:
+		teams, err := client.ListTeams()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list teams: %w", err)
+		}
+
+		for _, team := range teams {
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: team.Name, ID: team.ID})
+		}
+
+	case ResourceTypeSchedule:
+		schedules, err := client.ListSchedules()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list schedules: %w", err)
+		}
+
+		for _, schedule := range schedules {
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: schedule.Name, ID: schedule.ID})
+		}
+
+	case ResourceTypeEscalation:
+		escalations, err := client.ListEscalations()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list escalation policies: %w", err)
+		}
+
+		for _, escalation := range escalations {
+			resources = append(resources, core.IntegrationResource{Type: resourceType, Name: escalation.Name, ID: escalation.ID})
+		}
+	}
+
+	return resources, nil
+}
+
+func (o *Opsgenie) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (o *Opsgenie) HandleHook(ctx core.IntegrationHookContext) error {
+	return nil
+}
@@ -0,0 +1,134 @@
+package opsgenie
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Opsgenie__Sync(t *testing.T) {
+	integration := &Opsgenie{}
+
+	t.Run("valid API key -> ready", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":{"name":"acme","userCount":42,"plan":{"name":"Standard"}}}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		assert.Equal(t, Metadata{Account: "acme"}, integrationCtx.Metadata)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://api.opsgenie.com/v2/account", httpContext.Requests[0].URL.String())
+		assert.Equal(t, "GenieKey key-123", httpContext.Requests[0].Header.Get("Authorization"))
+	})
+
+	t.Run("EU region -> EU API", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":{"name":"acme"}}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		integrationCtx.Configuration["region"] = RegionEU
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "api.eu.opsgenie.com", httpContext.Requests[0].URL.Host)
+	})
+
+	t.Run("invalid API key -> error with API message", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusUnauthorized, `{"message":"Key format is not valid!","took":0.001,"requestId":"r-1"}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "request failed with 401: Key format is not valid!")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+	})
+}
+
+func Test__Opsgenie__ListResources(t *testing.T) {
+	integration := &Opsgenie{}
+
+	cases := []struct {
+		resourceType string
+		path         string
+		body         string
+		resource     core.IntegrationResource
+	}{
+		{
+			resourceType: ResourceTypeTeam,
+			path:         "/v2/teams",
+			body:         `{"data":[{"id":"team-1","name":"Platform","description":""}]}`,
+			resource:     core.IntegrationResource{Type: ResourceTypeTeam, Name: "Platform", ID: "team-1"},
+		},
+		{
+			resourceType: ResourceTypeSchedule,
+			path:         "/v2/schedules",
+			body:         `{"data":[{"id":"schedule-1","name":"Platform Primary","timezone":"Europe/Berlin","enabled":true}]}`,
+			resource:     core.IntegrationResource{Type: ResourceTypeSchedule, Name: "Platform Primary", ID: "schedule-1"},
+		},
+		{
+			resourceType: ResourceTypeEscalation,
+			path:         "/v2/escalations",
+			body:         `{"data":[{"id":"escalation-1","name":"Platform Escalation"}]}`,
+			resource:     core.IntegrationResource{Type: ResourceTypeEscalation, Name: "Platform Escalation", ID: "escalation-1"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.resourceType, func(t *testing.T) {
+			httpContext := &contexts.HTTPContext{
+				Responses: []*http.Response{jsonResponse(http.StatusOK, c.body)},
+			}
+
+			resources, err := integration.ListResources(c.resourceType, core.ListResourcesContext{
+				HTTP:        httpContext,
+				Integration: testIntegration(),
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, []core.IntegrationResource{c.resource}, resources)
+			require.Len(t, httpContext.Requests, 1)
+			assert.Equal(t, c.path, httpContext.Requests[0].URL.Path)
+		})
+	}
+
+	t.Run("unknown type -> empty", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		resources, err := integration.ListResources("user", core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+		assert.Empty(t, httpContext.Requests)
+	})
+}
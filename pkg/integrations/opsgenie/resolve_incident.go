@@ -0,0 +1,114 @@
+package opsgenie
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type ResolveIncident struct{}
+
+type ResolveIncidentSpec struct {
+	IncidentRef `mapstructure:",squash"`
+	Note        string `json:"note" mapstructure:"note"`
+}
+
+func (c *ResolveIncident) Name() string {
+	return "opsgenie.resolveIncident"
+}
+
+func (c *ResolveIncident) Label() string {
+	return "Resolve Incident"
+}
+
+func (c *ResolveIncident) Description() string {
+	return "Resolve an incident in Opsgenie"
+}
+
+func (c *ResolveIncident) Documentation() string {
+	return `The Resolve Incident component resolves an Opsgenie incident, once the problem behind it is fixed.
+
+## Use Cases
+
+- **Auto-resolution**: Resolve an incident when a workflow confirms that the service recovered
+- **Rollback workflows**: Resolve the incident of a failed release once it was rolled back
+
+## Configuration
+
+- **Incident**: ID or tiny ID of the incident
+- **Identifier Type**: How the incident is identified. Defaults to ID.
+- **Note**: Optional note added to the incident
+
+## Output
+
+The incident after it was resolved.`
+}
+
+func (c *ResolveIncident) Icon() string {
+	return "check"
+}
+
+func (c *ResolveIncident) Color() string {
+	return "gray"
+}
+
+func (c *ResolveIncident) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ResolveIncident) Configuration() []configuration.Field {
+	return append(incidentFields(), noteField("Note added to the incident when it is resolved"))
+}
+
+func (c *ResolveIncident) Setup(ctx core.SetupContext) error {
+	_, err := decodeResolveIncidentSpec(ctx.Configuration)
+	return err
+}
+
+func (c *ResolveIncident) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeResolveIncidentSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return runIncidentAction(ctx, spec.IncidentRef, "resolve", "resolve", IncidentRequest{Note: spec.Note}, nil)
+}
+
+func (c *ResolveIncident) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ResolveIncident) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *ResolveIncident) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *ResolveIncident) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *ResolveIncident) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeResolveIncidentSpec(value any) (ResolveIncidentSpec, error) {
+	spec := ResolveIncidentSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return ResolveIncidentSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := spec.IncidentRef.validate()
+	if err != nil {
+		return ResolveIncidentSpec{}, err
+	}
+
+	spec.IncidentRef = ref
+	spec.Note = strings.TrimSpace(spec.Note)
+	return spec, nil
+}
@@ -0,0 +1,62 @@
+package opsgenie
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func Test__ResolveIncident__Setup(t *testing.T) {
+	t.Run("missing incident -> error", func(t *testing.T) {
+		err := (&ResolveIncident{}).Setup(core.SetupContext{Configuration: map[string]any{"incidentId": " "}})
+		require.ErrorContains(t, err, "incidentId is required")
+	})
+
+	t.Run("alias -> error", func(t *testing.T) {
+		err := (&ResolveIncident{}).Setup(core.SetupContext{Configuration: map[string]any{
+			"incidentId":     "api-down",
+			"identifierType": IdentifierTypeAlias,
+		}})
+
+		require.ErrorContains(t, err, `invalid identifier type "alias"`)
+	})
+}
+
+func Test__ResolveIncident__Execute(t *testing.T) {
+	t.Run("resolves incident", func(t *testing.T) {
+		httpContext, executionState := runAction(t, &ResolveIncident{}, map[string]any{
+			"incidentId":     "42",
+			"identifierType": IdentifierTypeTiny,
+			"note":           "Rolled back api to 1.8.1.",
+		}, incidentActionResponses())
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, IncidentPayloadType, executionState.Type)
+
+		require.Len(t, httpContext.Requests, 3)
+		assert.Equal(t, http.MethodPost, httpContext.Requests[0].Method)
+		assert.Equal(t, "/v1/incidents/42/resolve", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "tiny", httpContext.Requests[0].URL.Query().Get("identifierType"))
+		assert.Equal(t, "Rolled back api to 1.8.1.", requestBody(t, httpContext.Requests[0])["note"])
+		assert.Equal(t, "/v1/incidents/requests/request-1", httpContext.Requests[1].URL.Path)
+		assert.Equal(t, "/v1/incidents/incident-1", httpContext.Requests[2].URL.Path)
+		assert.Empty(t, httpContext.Requests[2].URL.Query().Get("identifierType"))
+
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "resolved", payload["status"])
+		assert.Equal(t, "https://acme.app.opsgenie.com/incident/detail/incident-1", payload["url"])
+	})
+
+	t.Run("incident not found -> fails", func(t *testing.T) {
+		_, executionState := runAction(t, &ResolveIncident{}, map[string]any{"incidentId": "missing"}, []*http.Response{
+			jsonResponse(http.StatusNotFound, `{"message":"Incident with id [missing] does not exist"}`),
+		})
+
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to resolve incident missing")
+		assert.Contains(t, executionState.FailureMessage, "does not exist")
+	})
+}
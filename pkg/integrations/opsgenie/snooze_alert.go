@@ -0,0 +1,164 @@
+package opsgenie
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type SnoozeAlert struct{}
+
+type SnoozeAlertSpec struct {
+	AlertRef `mapstructure:",squash"`
+	Duration string `json:"duration" mapstructure:"duration"`
+	Note     string `json:"note" mapstructure:"note"`
+}
+
+var snoozeDurationOptions = []configuration.FieldOption{
+	{Label: "1 hour", Value: "3600"},
+	{Label: "4 hours", Value: "14400"},
+	{Label: "8 hours", Value: "28800"},
+	{Label: "24 hours", Value: "86400"},
+}
+
+func (c *SnoozeAlert) Name() string {
+	return "opsgenie.snoozeAlert"
+}
+
+func (c *SnoozeAlert) Label() string {
+	return "Snooze Alert"
+}
+
+func (c *SnoozeAlert) Description() string {
+	return "Snooze an alert in Opsgenie"
+}
+
+func (c *SnoozeAlert) Documentation() string {
+	return `The Snooze Alert component snoozes an Opsgenie alert for a period of time.
+
+## Use Cases
+
+- **Maintenance windows**: Snooze alerts that are expected while a workflow runs a migration
+- **Known issues**: Silence an alert while a fix is being deployed
+
+## Configuration
+
+- **Alert**: ID, tiny ID or alias of the alert
+- **Identifier Type**: How the alert is identified. Defaults to ID.
+- **Duration**: How long the alert stays snoozed
+- **Note**: Optional note added to the alert
+
+## Behavior
+
+A snoozed alert does not notify anyone until the snooze ends. When it ends, Opsgenie notifies the responders of the alert again.
+
+## Output
+
+The alert after it was snoozed, with the end of the snooze under ` + "`snoozedUntil`" + `.`
+}
+
+func (c *SnoozeAlert) Icon() string {
+	return "clock"
+}
+
+func (c *SnoozeAlert) Color() string {
+	return "gray"
+}
+
+func (c *SnoozeAlert) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *SnoozeAlert) Configuration() []configuration.Field {
+	return append(
+		alertFields(),
+		configuration.Field{
+			Name:        "duration",
+			Label:       "Duration",
+			Type:        configuration.FieldTypeSelect,
+			Required:    true,
+			Default:     "3600",
+			Description: "How long the alert stays snoozed",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{Options: snoozeDurationOptions},
+			},
+		},
+		noteField("Note added to the alert when it is snoozed"),
+	)
+}
+
+func (c *SnoozeAlert) Setup(ctx core.SetupContext) error {
+	_, err := decodeSnoozeAlertSpec(ctx.Configuration)
+	return err
+}
+
+func (c *SnoozeAlert) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeSnoozeAlertSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	seconds, _ := strconv.Atoi(spec.Duration)
+	endTime := time.Now().UTC().Add(time.Duration(seconds) * time.Second).Format(time.RFC3339)
+	request := AlertRequest{
+		Note:    spec.Note,
+		Source:  DefaultSource,
+		EndTime: endTime,
+	}
+
+	return runAlertAction(ctx, spec.AlertRef, "snooze", "snooze", request, map[string]any{"snoozedUntil": endTime})
+}
+
+func (c *SnoozeAlert) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *SnoozeAlert) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *SnoozeAlert) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *SnoozeAlert) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *SnoozeAlert) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeSnoozeAlertSpec(value any) (SnoozeAlertSpec, error) {
+	spec := SnoozeAlertSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return SnoozeAlertSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	ref, err := spec.AlertRef.validate()
+	if err != nil {
+		return SnoozeAlertSpec{}, err
+	}
+
+	spec.AlertRef = ref
+	if spec.Duration == "" {
+		return SnoozeAlertSpec{}, fmt.Errorf("duration is required")
+	}
+
+	validDuration := slices.ContainsFunc(snoozeDurationOptions, func(option configuration.FieldOption) bool {
+		return option.Value == spec.Duration
+	})
+
+	if !validDuration {
+		return SnoozeAlertSpec{}, fmt.Errorf("invalid duration %q", spec.Duration)
+	}
+
+	spec.Note = strings.TrimSpace(spec.Note)
+	return spec, nil
+}
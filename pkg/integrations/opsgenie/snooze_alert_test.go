@@ -0,0 +1,35 @@
+package opsgenie
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+func Test__SnoozeAlert__Execute(t *testing.T) {
+	t.Run("invalid duration -> error", func(t *testing.T) {
+		err := (&SnoozeAlert{}).Setup(core.SetupContext{Configuration: map[string]any{"alertId": "alert-1", "duration": "60"}})
+		require.ErrorContains(t, err, "invalid duration")
+	})
+
+	t.Run("snoozes until the end of the duration", func(t *testing.T) {
+		before := time.Now().UTC().Truncate(time.Second)
+		httpContext, executionState := runAction(t, &SnoozeAlert{}, map[string]any{
+			"alertId":  "alert-1",
+			"duration": "14400",
+		}, alertActionResponses())
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, "/v2/alerts/alert-1/snooze", httpContext.Requests[0].URL.Path)
+
+		endTime, err := time.Parse(time.RFC3339, requestBody(t, httpContext.Requests[0])["endTime"].(string))
+		require.NoError(t, err)
+		assert.WithinDuration(t, before.Add(4*time.Hour), endTime, time.Minute)
+
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, endTime.Format(time.RFC3339), payload["snoozedUntil"])
+	})
+}
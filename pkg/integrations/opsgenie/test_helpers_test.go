@@ -0,0 +1,90 @@
+package opsgenie
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func init() {
+	requestPollInterval = 0
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func testIntegration() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"apiKey": "key-123",
+			"region": RegionUS,
+		},
+		Metadata: Metadata{Account: "acme"},
+	}
+}
+
+func requestStatusResponse(success bool) string {
+	status := `"Created alert"`
+	if !success {
+		status = `"Alert does not exist"`
+	}
+
+	return `{"data":{"success":` + boolString(success) + `,"isSuccess":` + boolString(success) + `,"action":"Create","processedAt":"2026-10-19T10:00:00.512Z","status":` + status + `,"alertId":"alert-1"}}`
+}
+
+func boolString(value bool) string {
+	if value {
+		return "true"
+	}
+
+	return "false"
+}
+
+func alertActionResponses() []*http.Response {
+	return []*http.Response{
+		jsonResponse(http.StatusAccepted, `{"result":"Request will be processed","took":0.1,"requestId":"request-1"}`),
+		jsonResponse(http.StatusOK, requestStatusResponse(true)),
+		jsonResponse(http.StatusOK, `{"data":{"id":"alert-1","tinyId":"1791","alias":"api-production-deploy-failed","message":"Deployment of api to production failed","status":"open","acknowledged":false,"priority":"P2"}}`),
+	}
+}
+
+func incidentActionResponses() []*http.Response {
+	return []*http.Response{
+		jsonResponse(http.StatusAccepted, `{"result":"Request will be processed","took":0.1,"requestId":"request-1"}`),
+		jsonResponse(http.StatusOK, `{"data":{"success":true,"isSuccess":true,"action":"Resolve","processedAt":"2026-10-19T10:00:00.512Z","status":"Resolved incident","incidentId":"incident-1"}}`),
+		jsonResponse(http.StatusOK, `{"data":{"id":"incident-1","tinyId":"42","message":"api is down in production","status":"resolved","priority":"P1","links":{"web":"https://acme.app.opsgenie.com/incident/detail/incident-1","api":"https://api.opsgenie.com/v1/incidents/incident-1"}}}`),
+	}
+}
+
+func runAction(t *testing.T, component core.Action, configuration map[string]any, responses []*http.Response) (*contexts.HTTPContext, *contexts.ExecutionStateContext) {
+	httpContext := &contexts.HTTPContext{Responses: responses}
+	executionState := &contexts.ExecutionStateContext{}
+	err := component.Execute(core.ExecutionContext{
+		Configuration:  configuration,
+		HTTP:           httpContext,
+		Integration:    testIntegration(),
+		ExecutionState: executionState,
+	})
+
+	require.NoError(t, err)
+	return httpContext, executionState
+}
+
+func requestBody(t *testing.T, request *http.Request) map[string]any {
+	body, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+
+	result := map[string]any{}
+	require.NoError(t, json.Unmarshal(body, &result))
+	return result
+}
@@ -0,0 +1,111 @@
+package opsgenie
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const IntegrationTypeWebhook = "Webhook"
+
+// WebhookConfiguration is empty: every trigger of the integration
+// shares one webhook, and filters the alert events it receives.
+type WebhookConfiguration struct{}
+
+type WebhookMetadata struct {
+	IntegrationID string `json:"integrationId" mapstructure:"integrationId"`
+	Name          string `json:"name" mapstructure:"name"`
+}
+
+// OpsgenieWebhookHandler manages a Webhook integration in Opsgenie
+// that sends alert events of the account to SuperPlane.
+type OpsgenieWebhookHandler struct{}
+
+func (h *OpsgenieWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhook secret: %v", err)
+	}
+
+	name := webhookIntegrationName(ctx.Webhook.GetID())
+	request := WebhookIntegrationRequest{
+		Name:                name,
+		Type:                IntegrationTypeWebhook,
+		Enabled:             true,
+		URL:                 ctx.Webhook.GetURL(),
+		AddAlertDescription: true,
+		AddAlertDetails:     true,
+		SendAlertActions:    true,
+		Headers:             map[string]string{webhookSecretHeader: string(secret)},
+	}
+
+	existing, err := client.ListIntegrations(IntegrationTypeWebhook)
+	if err != nil {
+		return nil, fmt.Errorf("error listing integrations: %v", err)
+	}
+
+	for _, integration := range existing {
+		if integration.Name != name {
+			continue
+		}
+
+		updated, err := client.UpdateWebhookIntegration(integration.ID, request)
+		if err != nil {
+			return nil, fmt.Errorf("error updating webhook integration: %v", err)
+		}
+
+		return WebhookMetadata{IntegrationID: updated.ID, Name: name}, nil
+	}
+
+	created, err := client.CreateWebhookIntegration(request)
+	if err != nil {
+		return nil, fmt.Errorf("error creating webhook integration: %v", err)
+	}
+
+	return WebhookMetadata{IntegrationID: created.ID, Name: name}, nil
+}
+
+// webhookIntegrationName is derived from the webhook ID, so setting
+// up the webhook again updates the same Opsgenie integration.
+func webhookIntegrationName(webhookID string) string {
+	hash := sha256.Sum256([]byte(webhookID))
+	return fmt.Sprintf("SuperPlane %x", hash[:8])
+}
+
+func (h *OpsgenieWebhookHandler) CompareConfig(a, b any) (bool, error) {
+	return true, nil
+}
+
+func (h *OpsgenieWebhookHandler) Merge(current, requested any) (any, bool, error) {
+	return current, false, nil
+}
+
+func (h *OpsgenieWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
+	metadata := WebhookMetadata{}
+	if err := mapstructure.Decode(ctx.Webhook.GetMetadata(), &metadata); err != nil {
+		return fmt.Errorf("error decoding webhook metadata: %v", err)
+	}
+
+	if metadata.IntegrationID == "" {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteIntegration(metadata.IntegrationID)
+	if err != nil && !IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
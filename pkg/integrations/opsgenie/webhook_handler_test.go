@@ -0,0 +1,109 @@
+package opsgenie
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OpsgenieWebhookHandler__Setup(t *testing.T) {
+	handler := &OpsgenieWebhookHandler{}
+	webhook := &contexts.WebhookContext{
+		ID:     "webhook-1",
+		URL:    "https://superplane.example.com/api/v1/webhooks/webhook-1",
+		Secret: []byte("secret-1"),
+	}
+
+	t.Run("no existing integration -> creates one", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":[{"id":"other","name":"Monitoring","type":"Webhook"}]}`),
+				jsonResponse(http.StatusCreated, `{"data":{"id":"integration-1","name":"SuperPlane","type":"Webhook"}}`),
+			},
+		}
+
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Webhook:     webhook,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, WebhookMetadata{IntegrationID: "integration-1", Name: webhookIntegrationName("webhook-1")}, metadata)
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "Webhook", httpContext.Requests[0].URL.Query().Get("type"))
+		assert.Equal(t, http.MethodPost, httpContext.Requests[1].Method)
+
+		body := requestBody(t, httpContext.Requests[1])
+		assert.Equal(t, webhook.URL, body["url"])
+		assert.Equal(t, "Webhook", body["type"])
+		assert.Equal(t, true, body["sendAlertActions"])
+		assert.Equal(t, map[string]any{"X-SuperPlane-Webhook-Secret": "secret-1"}, body["headers"])
+	})
+
+	t.Run("existing integration -> updated", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":[{"id":"integration-1","name":"`+webhookIntegrationName("webhook-1")+`","type":"Webhook"}]}`),
+				jsonResponse(http.StatusOK, `{"data":{"id":"integration-1","name":"SuperPlane","type":"Webhook"}}`),
+			},
+		}
+
+		metadata, err := handler.Setup(core.WebhookHandlerContext{
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Webhook:     webhook,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "integration-1", metadata.(WebhookMetadata).IntegrationID)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodPut, httpContext.Requests[1].Method)
+		assert.Equal(t, "/v2/integrations/integration-1", httpContext.Requests[1].URL.Path)
+	})
+}
+
+func Test__OpsgenieWebhookHandler__Cleanup(t *testing.T) {
+	handler := &OpsgenieWebhookHandler{}
+
+	t.Run("deletes the integration", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusOK, `{"result":"Deleted"}`)},
+		}
+
+		err := handler.Cleanup(core.WebhookHandlerContext{
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Webhook:     &contexts.WebhookContext{Metadata: map[string]any{"integrationId": "integration-1"}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodDelete, httpContext.Requests[0].Method)
+		assert.Equal(t, "/v2/integrations/integration-1", httpContext.Requests[0].URL.Path)
+	})
+
+	t.Run("already deleted -> no error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusNotFound, `{"message":"Integration not found"}`)},
+		}
+
+		err := handler.Cleanup(core.WebhookHandlerContext{
+			Logger:      logrus.NewEntry(logrus.New()),
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Webhook:     &contexts.WebhookContext{Metadata: map[string]any{"integrationId": "integration-1"}},
+		})
+
+		require.NoError(t, err)
+	})
+}
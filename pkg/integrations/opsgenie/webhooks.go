@@ -0,0 +1,119 @@
+package opsgenie
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const webhookSecretHeader = "X-SuperPlane-Webhook-Secret"
+
+const (
+	WebhookActionCreate          = "Create"
+	WebhookActionAcknowledge     = "Acknowledge"
+	WebhookActionUnAcknowledge   = "UnAcknowledge"
+	WebhookActionClose           = "Close"
+	WebhookActionDelete          = "Delete"
+	WebhookActionSnooze          = "Snooze"
+	WebhookActionSnoozeEnded     = "SnoozeEnded"
+	WebhookActionEscalate        = "Escalate"
+	WebhookActionEscalateNext    = "EscalateNext"
+	WebhookActionAssignOwnership = "AssignOwnership"
+	WebhookActionTakeOwnership   = "TakeOwnership"
+	WebhookActionUpdatePriority  = "UpdatePriority"
+	WebhookActionAddNote         = "AddNote"
+)
+
+// AlertWebhook is the part of the alert events
+// of a Webhook integration the triggers filter on.
+type AlertWebhook struct {
+	Action string `json:"action"`
+	Alert  struct {
+		AlertID    string      `json:"alertId"`
+		Priority   string      `json:"priority"`
+		Tags       []string    `json:"tags"`
+		Teams      []string    `json:"teams"`
+		Responders []Responder `json:"responders"`
+	} `json:"alert"`
+}
+
+func (w *AlertWebhook) hasTeam(teams []string) bool {
+	for _, team := range teams {
+		if slices.Contains(w.Alert.Teams, team) {
+			return true
+		}
+
+		for _, responder := range w.Alert.Responders {
+			if responder.Type == "team" && responder.ID == team {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (w *AlertWebhook) hasTag(tags []string) bool {
+	for _, tag := range tags {
+		if slices.Contains(w.Alert.Tags, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readAlertWebhook verifies the secret header Opsgenie sends with
+// every event, and parses the event both as an AlertWebhook and as
+// the payload that is emitted.
+func readAlertWebhook(ctx core.WebhookRequestContext) (*AlertWebhook, map[string]any, int, error) {
+	header := strings.TrimSpace(ctx.Headers.Get(webhookSecretHeader))
+	if header == "" {
+		return nil, nil, http.StatusForbidden, fmt.Errorf("missing %s header", webhookSecretHeader)
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return nil, nil, http.StatusInternalServerError, fmt.Errorf("error authenticating request")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(header), secret) != 1 {
+		return nil, nil, http.StatusForbidden, fmt.Errorf("invalid webhook secret")
+	}
+
+	webhook := AlertWebhook{}
+	if err := json.Unmarshal(ctx.Body, &webhook); err != nil {
+		return nil, nil, http.StatusBadRequest, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	payload := map[string]any{}
+	if err := json.Unmarshal(ctx.Body, &payload); err != nil {
+		return nil, nil, http.StatusBadRequest, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	return &webhook, payload, http.StatusOK, nil
+}
+
+// alertEventPayload adds a link to the alert of an event.
+func alertEventPayload(integration core.IntegrationContext, webhook *AlertWebhook, payload map[string]any) map[string]any {
+	alert, ok := payload["alert"].(map[string]any)
+	if !ok {
+		return payload
+	}
+
+	client, err := NewClient(nil, integration)
+	if err != nil {
+		return payload
+	}
+
+	if link := client.AlertURL(accountName(integration), webhook.Alert.AlertID); link != "" {
+		alert["url"] = link
+	}
+
+	return payload
+}
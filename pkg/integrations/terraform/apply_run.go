@@ -0,0 +1,271 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	ApplyRunPayloadType   = "terraform.run.applied"
+	ApplyRunPollAction    = "poll"
+	ApplyRunPollInterval  = 15 * time.Second
+	ApplyRunMaxPollErrors = 5
+
+	DefaultApplyTimeoutMinutes = 60
+)
+
+type ApplyRun struct{}
+
+type ApplyRunSpec struct {
+	RunID          string `json:"runId" mapstructure:"runId"`
+	Comment        string `json:"comment" mapstructure:"comment"`
+	TimeoutMinutes int    `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+}
+
+type ApplyRunMetadata struct {
+	RunID          string `json:"runId" mapstructure:"runId"`
+	StartedAt      string `json:"startedAt" mapstructure:"startedAt"`
+	Status         string `json:"status" mapstructure:"status"`
+	PollErrorCount int    `json:"pollErrorCount,omitempty" mapstructure:"pollErrorCount"`
+}
+
+func (a *ApplyRun) Name() string {
+	return "terraform.applyRun"
+}
+
+func (a *ApplyRun) Label() string {
+	return "Apply Run"
+}
+
+func (a *ApplyRun) Description() string {
+	return "Confirm the plan of a Terraform Cloud run and wait for the apply"
+}
+
+func (a *ApplyRun) Documentation() string {
+	return `The Apply Run component confirms the plan of a Terraform Cloud run, and waits until the apply finishes.
+
+## Use Cases
+
+- **Gated applies**: Apply the run after an **Approval**
+- **Promotions**: Apply infrastructure changes once tests pass in a lower environment
+
+## Configuration
+
+- **Run ID**: The run to apply, like the ID output of **Create Run**. The run must be waiting for confirmation, which **Wait for Plan** checks.
+- **Comment**: Comment shown in the run timeline of Terraform Cloud
+- **Timeout (minutes)**: How long to wait for the apply. Defaults to 60 minutes.
+
+## Output Channels
+
+- **Success**: The run was applied
+- **Failed**: The apply errored or was canceled, or did not finish in time`
+}
+
+func (a *ApplyRun) Icon() string {
+	return "terraform"
+}
+
+func (a *ApplyRun) Color() string {
+	return "purple"
+}
+
+func (a *ApplyRun) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: SuccessOutputChannel, Label: "Success"},
+		{Name: FailedOutputChannel, Label: "Failed"},
+	}
+}
+
+func (a *ApplyRun) Configuration() []configuration.Field {
+	return []configuration.Field{
+		runIDField(),
+		{
+			Name:        "comment",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "Approved in SuperPlane",
+			Description: "Comment shown in the run timeline",
+		},
+		{
+			Name:        "timeoutMinutes",
+			Label:       "Timeout (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultApplyTimeoutMinutes,
+			Description: "How long to wait for the apply",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: intPtr(1),
+					Max: intPtr(1440),
+				},
+			},
+		},
+	}
+}
+
+func (a *ApplyRun) Setup(ctx core.SetupContext) error {
+	_, err := decodeApplyRunSpec(ctx.Configuration, false)
+	return err
+}
+
+func (a *ApplyRun) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeApplyRunSpec(ctx.Configuration, true)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	run, err := client.GetRun(spec.RunID)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get run %s: %v", spec.RunID, err))
+	}
+
+	if !run.Actions.IsConfirmable {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("run %s is %s, and can't be applied", run.ID, run.Status))
+	}
+
+	if err := client.ApplyRun(run.ID, spec.Comment); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to apply run %s: %v", run.ID, err))
+	}
+
+	metadata := ApplyRunMetadata{
+		RunID:     run.ID,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+		Status:    RunStatusConfirmed,
+	}
+
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	return ctx.Requests.ScheduleActionCall(ApplyRunPollAction, map[string]any{}, ApplyRunPollInterval)
+}
+
+func (a *ApplyRun) Hooks() []core.Hook {
+	return []core.Hook{{Name: ApplyRunPollAction, Type: core.HookTypeInternal}}
+}
+
+func (a *ApplyRun) HandleHook(ctx core.ActionHookContext) error {
+	switch ctx.Name {
+	case ApplyRunPollAction:
+		return a.poll(ctx)
+	default:
+		return fmt.Errorf("unknown hook: %s", ctx.Name)
+	}
+}
+
+func (a *ApplyRun) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	spec, err := decodeApplyRunSpec(ctx.Configuration, false)
+	if err != nil {
+		return err
+	}
+
+	metadata := ApplyRunMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	run, err := client.GetRun(metadata.RunID)
+	if err != nil {
+		metadata.PollErrorCount++
+		if setErr := ctx.Metadata.Set(metadata); setErr != nil {
+			return setErr
+		}
+
+		if IsNotFound(err) || metadata.PollErrorCount >= ApplyRunMaxPollErrors {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get run %s: %v", metadata.RunID, err))
+		}
+
+		return ctx.Requests.ScheduleActionCall(ApplyRunPollAction, map[string]any{}, ApplyRunPollInterval)
+	}
+
+	metadata.PollErrorCount = 0
+	metadata.Status = run.Status
+	if err := ctx.Metadata.Set(metadata); err != nil {
+		return err
+	}
+
+	message := ""
+	switch {
+	case run.Status == RunStatusApplied:
+		message = "run was applied"
+	case runFailed(run.Status):
+		message = fmt.Sprintf("run is %s", run.Status)
+	default:
+		startedAt, err := time.Parse(time.RFC3339, metadata.StartedAt)
+		if err != nil || time.Since(startedAt) < time.Duration(spec.TimeoutMinutes)*time.Minute {
+			return ctx.Requests.ScheduleActionCall(ApplyRunPollAction, map[string]any{}, ApplyRunPollInterval)
+		}
+
+		message = fmt.Sprintf("apply did not finish in %d minutes, run is %s", spec.TimeoutMinutes, run.Status)
+	}
+
+	workspace, _ := client.GetWorkspace(run.WorkspaceID)
+	payload := runPayload(client.Address, run, workspace)
+	payload["apply"] = map[string]any{
+		"message":   message,
+		"startedAt": metadata.StartedAt,
+		"endedAt":   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	channel := FailedOutputChannel
+	if run.Status == RunStatusApplied {
+		channel = SuccessOutputChannel
+	}
+
+	return ctx.ExecutionState.Emit(channel, ApplyRunPayloadType, []any{payload})
+}
+
+func (a *ApplyRun) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (a *ApplyRun) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (a *ApplyRun) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func decodeApplyRunSpec(value any, validateRun bool) (ApplyRunSpec, error) {
+	spec := ApplyRunSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return ApplyRunSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if validateRun {
+		runID, err := validateRunID(spec.RunID)
+		if err != nil {
+			return ApplyRunSpec{}, err
+		}
+
+		spec.RunID = runID
+	}
+
+	spec.Comment = strings.TrimSpace(spec.Comment)
+	if spec.TimeoutMinutes <= 0 {
+		spec.TimeoutMinutes = DefaultApplyTimeoutMinutes
+	}
+
+	return spec, nil
+}
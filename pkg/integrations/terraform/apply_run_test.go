@@ -0,0 +1,109 @@
+package terraform
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__ApplyRun(t *testing.T) {
+	component := &ApplyRun{}
+
+	t.Run("not confirmable -> fails execution", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, runResponse(RunStatusPlanning, false, false))}}
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"runId": "run-CZcmD7eagjhyX0vN"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Equal(t, "run run-CZcmD7eagjhyX0vN is planning, and can't be applied", executionState.FailureMessage)
+		assert.Len(t, httpContext.Requests, 1)
+	})
+
+	t.Run("confirmable -> applies and polls", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, runResponse(RunStatusPlanned, true, true)),
+			jsonResponse(http.StatusAccepted, ``),
+		}}
+
+		requests := &contexts.RequestContext{}
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"runId": "run-CZcmD7eagjhyX0vN", "comment": "Approved"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, ApplyRunPollAction, requests.Action)
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/api/v2/runs/run-CZcmD7eagjhyX0vN/actions/apply", httpContext.Requests[1].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"comment":"Approved"}`, string(body))
+	})
+
+	t.Run("applied -> emits on success", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:          ApplyRunPollAction,
+			Configuration: map[string]any{"runId": "run-CZcmD7eagjhyX0vN"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, runResponse(RunStatusApplied, false, false)),
+				jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production","auto-apply":false,"terraform-version":"1.9.5"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`),
+			}},
+			Integration: testIntegration(),
+			Metadata: &contexts.MetadataContext{Metadata: ApplyRunMetadata{
+				RunID:     "run-CZcmD7eagjhyX0vN",
+				StartedAt: time.Now().UTC().Format(time.RFC3339),
+				Status:    RunStatusConfirmed,
+			}},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, SuccessOutputChannel, executionState.Channel)
+		assert.Equal(t, ApplyRunPayloadType, executionState.Type)
+	})
+
+	t.Run("errored -> emits on failed", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.HandleHook(core.ActionHookContext{
+			Name:          ApplyRunPollAction,
+			Configuration: map[string]any{"runId": "run-CZcmD7eagjhyX0vN"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, runResponse(RunStatusErrored, false, false)),
+				jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production","auto-apply":false,"terraform-version":"1.9.5"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`),
+			}},
+			Integration: testIntegration(),
+			Metadata: &contexts.MetadataContext{Metadata: ApplyRunMetadata{
+				RunID:     "run-CZcmD7eagjhyX0vN",
+				StartedAt: time.Now().UTC().Format(time.RFC3339),
+			}},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, FailedOutputChannel, executionState.Channel)
+	})
+}
@@ -0,0 +1,863 @@
+package terraform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	DefaultAddress = "https://app.terraform.io"
+	jsonAPIType    = "application/vnd.api+json"
+	pageSize       = 100
+	maxPages       = 20
+)
+
+type Client struct {
+	Address string
+	Token   string
+	http    core.HTTPContext
+}
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with %d: %s", e.StatusCode, e.Body)
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+type Organization struct {
+	Name  string
+	Email string
+}
+
+type Workspace struct {
+	ID               string
+	Name             string
+	Organization     string
+	AutoApply        bool
+	TerraformVersion string
+	WorkingDirectory string
+	CurrentRunID     string
+}
+
+type Run struct {
+	ID               string
+	Status           string
+	Message          string
+	Source           string
+	IsDestroy        bool
+	PlanOnly         bool
+	AutoApply        bool
+	HasChanges       bool
+	CreatedAt        string
+	StatusTimestamps map[string]string
+	Actions          RunActions
+	WorkspaceID      string
+	PlanID           string
+	ApplyID          string
+}
+
+type RunActions struct {
+	IsCancelable  bool `json:"is-cancelable"`
+	IsConfirmable bool `json:"is-confirmable"`
+	IsDiscardable bool `json:"is-discardable"`
+}
+
+type Plan struct {
+	ID                   string `json:"-"`
+	Status               string `json:"status"`
+	HasChanges           bool   `json:"has-changes"`
+	ResourceAdditions    int    `json:"resource-additions"`
+	ResourceChanges      int    `json:"resource-changes"`
+	ResourceDestructions int    `json:"resource-destructions"`
+	ResourceImports      int    `json:"resource-imports"`
+}
+
+// ResourceChange is a change of the JSON plan, as
+// terraform show -json outputs it.
+type ResourceChange struct {
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Change  struct {
+		Actions []string `json:"actions"`
+	} `json:"change"`
+}
+
+// CreateRunRequest holds the attributes of a new run.
+type CreateRunRequest struct {
+	WorkspaceID string
+	Message     string
+	IsDestroy   bool
+	PlanOnly    bool
+	AutoApply   bool
+	Targets     []string
+	Variables   []RunVariable
+}
+
+type RunVariable struct {
+	Key   string `json:"key" mapstructure:"key"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+type NotificationConfiguration struct {
+	ID       string
+	Name     string
+	URL      string
+	Triggers []string
+}
+
+// RunTask is an organization run task,
+// the endpoint Terraform Cloud calls during runs.
+type RunTask struct {
+	ID   string
+	Name string
+	URL  string
+}
+
+// WorkspaceRunTask attaches a run task
+// to a stage of the runs of a workspace.
+type WorkspaceRunTask struct {
+	ID               string
+	TaskID           string
+	Stage            string
+	EnforcementLevel string
+}
+
+// TaskResult is the result of a run task request,
+// sent back to Terraform Cloud through its callback URL.
+type TaskResult struct {
+	Status  string
+	Message string
+	URL     string
+}
+
+//
+// JSON:API documents, as the Terraform Cloud API sends them.
+//
+
+type document struct {
+	Data json.RawMessage `json:"data"`
+}
+
+type resource struct {
+	ID            string                  `json:"id"`
+	Type          string                  `json:"type"`
+	Attributes    json.RawMessage         `json:"attributes"`
+	Relationships map[string]relationship `json:"relationships,omitempty"`
+}
+
+type relationship struct {
+	Data *struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	} `json:"data,omitempty"`
+}
+
+func (r resource) relationshipID(name string) string {
+	rel, ok := r.Relationships[name]
+	if !ok || rel.Data == nil {
+		return ""
+	}
+
+	return rel.Data.ID
+}
+
+func NewClient(httpClient core.HTTPContext, ctx core.IntegrationContext) (*Client, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("no integration context")
+	}
+
+	address := DefaultAddress
+	configured, err := ctx.GetConfig("address")
+	if err == nil && strings.TrimSpace(string(configured)) != "" {
+		address = strings.TrimSpace(string(configured))
+	}
+
+	token, err := ctx.GetConfig("apiToken")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedToken := strings.TrimSpace(string(token))
+	if trimmedToken == "" {
+		return nil, fmt.Errorf("apiToken is required")
+	}
+
+	return &Client{
+		Address: strings.TrimRight(address, "/"),
+		Token:   trimmedToken,
+		http:    httpClient,
+	}, nil
+}
+
+func (c *Client) ListOrganizations() ([]Organization, error) {
+	resources, err := c.listAll("/api/v2/organizations", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations := make([]Organization, 0, len(resources))
+	for _, r := range resources {
+		var attributes struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		}
+
+		if err := json.Unmarshal(r.Attributes, &attributes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal organization: %w", err)
+		}
+
+		if attributes.Name == "" {
+			attributes.Name = r.ID
+		}
+
+		organizations = append(organizations, Organization{Name: attributes.Name, Email: attributes.Email})
+	}
+
+	return organizations, nil
+}
+
+func (c *Client) ListWorkspaces(organization string) ([]Workspace, error) {
+	resources, err := c.listAll("/api/v2/organizations/"+url.PathEscape(organization)+"/workspaces", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := make([]Workspace, 0, len(resources))
+	for _, r := range resources {
+		workspace, err := decodeWorkspace(r)
+		if err != nil {
+			return nil, err
+		}
+
+		workspaces = append(workspaces, *workspace)
+	}
+
+	return workspaces, nil
+}
+
+func (c *Client) GetWorkspace(id string) (*Workspace, error) {
+	r, err := c.getResource("/api/v2/workspaces/" + url.PathEscape(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeWorkspace(*r)
+}
+
+func (c *Client) CreateRun(request CreateRunRequest) (*Run, error) {
+	attributes := map[string]any{
+		"message":    request.Message,
+		"is-destroy": request.IsDestroy,
+		"plan-only":  request.PlanOnly,
+		"auto-apply": request.AutoApply,
+	}
+
+	if len(request.Targets) > 0 {
+		attributes["target-addrs"] = request.Targets
+	}
+
+	if len(request.Variables) > 0 {
+		attributes["variables"] = request.Variables
+	}
+
+	payload := map[string]any{
+		"data": map[string]any{
+			"type":       "runs",
+			"attributes": attributes,
+			"relationships": map[string]any{
+				"workspace": map[string]any{
+					"data": map[string]any{"type": "workspaces", "id": request.WorkspaceID},
+				},
+			},
+		},
+	}
+
+	_, body, err := c.execRequest(http.MethodPost, "/api/v2/runs", nil, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decodeResource(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRun(*r)
+}
+
+func (c *Client) GetRun(id string) (*Run, error) {
+	r, err := c.getResource("/api/v2/runs/" + url.PathEscape(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRun(*r)
+}
+
+func (c *Client) GetPlan(id string) (*Plan, error) {
+	r, err := c.getResource("/api/v2/plans/" + url.PathEscape(id))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := Plan{}
+	if err := json.Unmarshal(r.Attributes, &plan); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plan: %w", err)
+	}
+
+	plan.ID = r.ID
+	return &plan, nil
+}
+
+// GetPlanResourceChanges reads the JSON plan and returns
+// the resource changes that do something.
+func (c *Client) GetPlanResourceChanges(planID string) ([]ResourceChange, error) {
+	_, body, err := c.execRequest(http.MethodGet, "/api/v2/plans/"+url.PathEscape(planID)+"/json-output", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan struct {
+		ResourceChanges []ResourceChange `json:"resource_changes"`
+	}
+
+	if err := json.Unmarshal(body, &plan); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON plan: %w", err)
+	}
+
+	changes := make([]ResourceChange, 0, len(plan.ResourceChanges))
+	for _, change := range plan.ResourceChanges {
+		if len(change.Change.Actions) == 1 && (change.Change.Actions[0] == "no-op" || change.Change.Actions[0] == "read") {
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+func (c *Client) ApplyRun(id, comment string) error {
+	return c.runAction(id, "apply", comment)
+}
+
+func (c *Client) DiscardRun(id, comment string) error {
+	return c.runAction(id, "discard", comment)
+}
+
+func (c *Client) runAction(id, action, comment string) error {
+	var payload any
+	if comment != "" {
+		payload = map[string]any{"comment": comment}
+	}
+
+	_, _, err := c.execRequest(http.MethodPost, "/api/v2/runs/"+url.PathEscape(id)+"/actions/"+action, nil, payload)
+	return err
+}
+
+func (c *Client) ListNotificationConfigurations(workspaceID string) ([]NotificationConfiguration, error) {
+	resources, err := c.listAll("/api/v2/workspaces/"+url.PathEscape(workspaceID)+"/notification-configurations", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	configurations := make([]NotificationConfiguration, 0, len(resources))
+	for _, r := range resources {
+		configuration, err := decodeNotificationConfiguration(r)
+		if err != nil {
+			return nil, err
+		}
+
+		configurations = append(configurations, *configuration)
+	}
+
+	return configurations, nil
+}
+
+func (c *Client) CreateNotificationConfiguration(workspaceID, name, webhookURL, token string, triggers []string) (*NotificationConfiguration, error) {
+	payload := notificationConfigurationPayload(name, webhookURL, token, triggers)
+	_, body, err := c.execRequest(http.MethodPost, "/api/v2/workspaces/"+url.PathEscape(workspaceID)+"/notification-configurations", nil, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decodeResource(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeNotificationConfiguration(*r)
+}
+
+func (c *Client) UpdateNotificationConfiguration(id, name, webhookURL, token string, triggers []string) (*NotificationConfiguration, error) {
+	payload := notificationConfigurationPayload(name, webhookURL, token, triggers)
+	_, body, err := c.execRequest(http.MethodPatch, "/api/v2/notification-configurations/"+url.PathEscape(id), nil, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decodeResource(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeNotificationConfiguration(*r)
+}
+
+func (c *Client) DeleteNotificationConfiguration(id string) error {
+	_, _, err := c.execRequest(http.MethodDelete, "/api/v2/notification-configurations/"+url.PathEscape(id), nil, nil)
+	return err
+}
+
+func notificationConfigurationPayload(name, webhookURL, token string, triggers []string) map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"type": "notification-configurations",
+			"attributes": map[string]any{
+				"destination-type": "generic",
+				"enabled":          true,
+				"name":             name,
+				"url":              webhookURL,
+				"token":            token,
+				"triggers":         triggers,
+			},
+		},
+	}
+}
+
+func (c *Client) ListRunTasks(organization string) ([]RunTask, error) {
+	resources, err := c.listAll("/api/v2/organizations/"+url.PathEscape(organization)+"/tasks", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]RunTask, 0, len(resources))
+	for _, r := range resources {
+		task, err := decodeRunTask(r)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, *task)
+	}
+
+	return tasks, nil
+}
+
+func (c *Client) CreateRunTask(organization, name, webhookURL, hmacKey string) (*RunTask, error) {
+	payload := runTaskPayload(name, webhookURL, hmacKey)
+	_, body, err := c.execRequest(http.MethodPost, "/api/v2/organizations/"+url.PathEscape(organization)+"/tasks", nil, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decodeResource(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRunTask(*r)
+}
+
+func (c *Client) UpdateRunTask(id, name, webhookURL, hmacKey string) (*RunTask, error) {
+	payload := runTaskPayload(name, webhookURL, hmacKey)
+	_, body, err := c.execRequest(http.MethodPatch, "/api/v2/tasks/"+url.PathEscape(id), nil, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decodeResource(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRunTask(*r)
+}
+
+func (c *Client) DeleteRunTask(id string) error {
+	_, _, err := c.execRequest(http.MethodDelete, "/api/v2/tasks/"+url.PathEscape(id), nil, nil)
+	return err
+}
+
+func runTaskPayload(name, webhookURL, hmacKey string) map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"type": "tasks",
+			"attributes": map[string]any{
+				"name":        name,
+				"url":         webhookURL,
+				"hmac-key":    hmacKey,
+				"category":    "task",
+				"enabled":     true,
+				"description": "Created by SuperPlane",
+			},
+		},
+	}
+}
+
+func (c *Client) ListWorkspaceRunTasks(workspaceID string) ([]WorkspaceRunTask, error) {
+	resources, err := c.listAll("/api/v2/workspaces/"+url.PathEscape(workspaceID)+"/tasks", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]WorkspaceRunTask, 0, len(resources))
+	for _, r := range resources {
+		task, err := decodeWorkspaceRunTask(r)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, *task)
+	}
+
+	return tasks, nil
+}
+
+func (c *Client) CreateWorkspaceRunTask(workspaceID, taskID, stage, enforcementLevel string) (*WorkspaceRunTask, error) {
+	payload := workspaceRunTaskPayload(taskID, stage, enforcementLevel)
+	_, body, err := c.execRequest(http.MethodPost, "/api/v2/workspaces/"+url.PathEscape(workspaceID)+"/tasks", nil, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decodeResource(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeWorkspaceRunTask(*r)
+}
+
+func (c *Client) UpdateWorkspaceRunTask(workspaceID, id, taskID, stage, enforcementLevel string) (*WorkspaceRunTask, error) {
+	payload := workspaceRunTaskPayload(taskID, stage, enforcementLevel)
+	_, body, err := c.execRequest(http.MethodPatch, "/api/v2/workspaces/"+url.PathEscape(workspaceID)+"/tasks/"+url.PathEscape(id), nil, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decodeResource(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeWorkspaceRunTask(*r)
+}
+
+func (c *Client) DeleteWorkspaceRunTask(workspaceID, id string) error {
+	_, _, err := c.execRequest(http.MethodDelete, "/api/v2/workspaces/"+url.PathEscape(workspaceID)+"/tasks/"+url.PathEscape(id), nil, nil)
+	return err
+}
+
+func workspaceRunTaskPayload(taskID, stage, enforcementLevel string) map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"type": "workspace-tasks",
+			"attributes": map[string]any{
+				"enforcement-level": enforcementLevel,
+				"stages":            []string{stage},
+			},
+			"relationships": map[string]any{
+				"task": map[string]any{
+					"data": map[string]any{"id": taskID, "type": "tasks"},
+				},
+			},
+		},
+	}
+}
+
+// SendTaskResult sends the result of a run task request to its callback URL.
+// The callback only accepts the access token that came with the request,
+// not the API token of the integration.
+func SendTaskResult(httpClient core.HTTPContext, callbackURL, accessToken string, result TaskResult) error {
+	attributes := map[string]any{
+		"status":  result.Status,
+		"message": result.Message,
+	}
+
+	if result.URL != "" {
+		attributes["url"] = result.URL
+	}
+
+	c := &Client{Token: accessToken, http: httpClient}
+	_, _, err := c.execRequest(http.MethodPatch, callbackURL, nil, map[string]any{
+		"data": map[string]any{
+			"type":       "task-results",
+			"attributes": attributes,
+		},
+	})
+
+	return err
+}
+
+func decodeWorkspace(r resource) (*Workspace, error) {
+	var attributes struct {
+		Name             string `json:"name"`
+		AutoApply        bool   `json:"auto-apply"`
+		TerraformVersion string `json:"terraform-version"`
+		WorkingDirectory string `json:"working-directory"`
+	}
+
+	if err := json.Unmarshal(r.Attributes, &attributes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workspace: %w", err)
+	}
+
+	return &Workspace{
+		ID:               r.ID,
+		Name:             attributes.Name,
+		Organization:     r.relationshipID("organization"),
+		AutoApply:        attributes.AutoApply,
+		TerraformVersion: attributes.TerraformVersion,
+		WorkingDirectory: attributes.WorkingDirectory,
+		CurrentRunID:     r.relationshipID("current-run"),
+	}, nil
+}
+
+func decodeRun(r resource) (*Run, error) {
+	var attributes struct {
+		Status           string            `json:"status"`
+		Message          string            `json:"message"`
+		Source           string            `json:"source"`
+		IsDestroy        bool              `json:"is-destroy"`
+		PlanOnly         bool              `json:"plan-only"`
+		AutoApply        bool              `json:"auto-apply"`
+		HasChanges       bool              `json:"has-changes"`
+		CreatedAt        string            `json:"created-at"`
+		StatusTimestamps map[string]string `json:"status-timestamps"`
+		Actions          RunActions        `json:"actions"`
+	}
+
+	if err := json.Unmarshal(r.Attributes, &attributes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal run: %w", err)
+	}
+
+	return &Run{
+		ID:               r.ID,
+		Status:           attributes.Status,
+		Message:          attributes.Message,
+		Source:           attributes.Source,
+		IsDestroy:        attributes.IsDestroy,
+		PlanOnly:         attributes.PlanOnly,
+		AutoApply:        attributes.AutoApply,
+		HasChanges:       attributes.HasChanges,
+		CreatedAt:        attributes.CreatedAt,
+		StatusTimestamps: attributes.StatusTimestamps,
+		Actions:          attributes.Actions,
+		WorkspaceID:      r.relationshipID("workspace"),
+		PlanID:           r.relationshipID("plan"),
+		ApplyID:          r.relationshipID("apply"),
+	}, nil
+}
+
+func decodeNotificationConfiguration(r resource) (*NotificationConfiguration, error) {
+	var attributes struct {
+		Name     string   `json:"name"`
+		URL      string   `json:"url"`
+		Triggers []string `json:"triggers"`
+	}
+
+	if err := json.Unmarshal(r.Attributes, &attributes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification configuration: %w", err)
+	}
+
+	return &NotificationConfiguration{
+		ID:       r.ID,
+		Name:     attributes.Name,
+		URL:      attributes.URL,
+		Triggers: attributes.Triggers,
+	}, nil
+}
+
+func decodeRunTask(r resource) (*RunTask, error) {
+	var attributes struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+
+	if err := json.Unmarshal(r.Attributes, &attributes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal run task: %w", err)
+	}
+
+	return &RunTask{ID: r.ID, Name: attributes.Name, URL: attributes.URL}, nil
+}
+
+func decodeWorkspaceRunTask(r resource) (*WorkspaceRunTask, error) {
+	var attributes struct {
+		EnforcementLevel string   `json:"enforcement-level"`
+		Stage            string   `json:"stage"`
+		Stages           []string `json:"stages"`
+	}
+
+	if err := json.Unmarshal(r.Attributes, &attributes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workspace run task: %w", err)
+	}
+
+	stage := attributes.Stage
+	if len(attributes.Stages) > 0 {
+		stage = attributes.Stages[0]
+	}
+
+	return &WorkspaceRunTask{
+		ID:               r.ID,
+		TaskID:           r.relationshipID("task"),
+		Stage:            stage,
+		EnforcementLevel: attributes.EnforcementLevel,
+	}, nil
+}
+
+func (c *Client) getResource(path string) (*resource, error) {
+	_, body, err := c.execRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResource(body)
+}
+
+func decodeResource(body []byte) (*resource, error) {
+	doc := document{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	r := resource{}
+	if err := json.Unmarshal(doc.Data, &r); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response data: %w", err)
+	}
+
+	return &r, nil
+}
+
+// listAll follows the pagination of list endpoints,
+// up to maxPages pages.
+func (c *Client) listAll(path string, query url.Values) ([]resource, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	query.Set("page[size]", fmt.Sprintf("%d", pageSize))
+
+	resources := []resource{}
+	for page := 1; page <= maxPages; page++ {
+		query.Set("page[number]", fmt.Sprintf("%d", page))
+		_, body, err := c.execRequest(http.MethodGet, path, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list struct {
+			Data []resource `json:"data"`
+			Meta struct {
+				Pagination struct {
+					NextPage *int `json:"next-page"`
+				} `json:"pagination"`
+			} `json:"meta"`
+		}
+
+		if err := json.Unmarshal(body, &list); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal list response: %w", err)
+		}
+
+		resources = append(resources, list.Data...)
+		if list.Meta.Pagination.NextPage == nil {
+			break
+		}
+	}
+
+	return resources, nil
+}
+
+func (c *Client) execRequest(
+	method string,
+	path string,
+	query url.Values,
+	payload any,
+) (*http.Response, []byte, error) {
+	endpoint := c.Address + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		encodedBody, err := json.Marshal(payload)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(encodedBody)
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Accept", jsonAPIType)
+	if payload != nil {
+		req.Header.Set("Content-Type", jsonAPIType)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	responseBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return nil, nil, &APIError{StatusCode: res.StatusCode, Body: apiErrorMessage(responseBody)}
+	}
+
+	return res, responseBody, nil
+}
+
+// apiErrorMessage joins the titles and details
+// of the JSON:API errors of a response.
+func apiErrorMessage(body []byte) string {
+	var apiError struct {
+		Errors []struct {
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(body, &apiError); err == nil && len(apiError.Errors) > 0 {
+		messages := make([]string, 0, len(apiError.Errors))
+		for _, e := range apiError.Errors {
+			switch {
+			case e.Detail != "" && e.Title != "":
+				messages = append(messages, e.Title+": "+e.Detail)
+			case e.Detail != "":
+				messages = append(messages, e.Detail)
+			default:
+				messages = append(messages, e.Title)
+			}
+		}
+
+		return strings.Join(messages, "; ")
+	}
+
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,252 @@
+package terraform
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	SuccessOutputChannel = "success"
+	FailedOutputChannel  = "failed"
+
+	RunStatusPending            = "pending"
+	RunStatusPlanning           = "planning"
+	RunStatusPlanned            = "planned"
+	RunStatusCostEstimated      = "cost_estimated"
+	RunStatusPolicyChecked      = "policy_checked"
+	RunStatusPolicyOverride     = "policy_override"
+	RunStatusPolicySoftFailed   = "policy_soft_failed"
+	RunStatusPostPlanCompleted  = "post_plan_completed"
+	RunStatusPlannedAndFinished = "planned_and_finished"
+	RunStatusPlannedAndSaved    = "planned_and_saved"
+	RunStatusConfirmed          = "confirmed"
+	RunStatusApplyQueued        = "apply_queued"
+	RunStatusQueuingApply       = "queuing_apply"
+	RunStatusPreApplyRunning    = "pre_apply_running"
+	RunStatusPreApplyCompleted  = "pre_apply_completed"
+	RunStatusApplying           = "applying"
+	RunStatusApplied            = "applied"
+	RunStatusDiscarded          = "discarded"
+	RunStatusErrored            = "errored"
+	RunStatusCanceled           = "canceled"
+	RunStatusForceCanceled      = "force_canceled"
+)
+
+// runFailed tells if a run ended without applying,
+// because it errored, a policy failed, or someone stopped it.
+func runFailed(status string) bool {
+	return slices.Contains([]string{
+		RunStatusErrored,
+		RunStatusCanceled,
+		RunStatusForceCanceled,
+		RunStatusDiscarded,
+		RunStatusPolicySoftFailed,
+	}, status)
+}
+
+// runFinished tells if a run can't change anymore.
+func runFinished(status string) bool {
+	return runFailed(status) || slices.Contains([]string{RunStatusApplied, RunStatusPlannedAndFinished, RunStatusPlannedAndSaved}, status)
+}
+
+// runApplying tells if the apply of a run was confirmed,
+// by someone or by auto-apply.
+func runApplying(status string) bool {
+	return slices.Contains([]string{
+		RunStatusConfirmed,
+		RunStatusApplyQueued,
+		RunStatusQueuingApply,
+		RunStatusPreApplyRunning,
+		RunStatusPreApplyCompleted,
+		RunStatusApplying,
+	}, status)
+}
+
+func organizationField() configuration.Field {
+	return configuration.Field{
+		Name:        "organization",
+		Label:       "Organization",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    true,
+		Description: "Terraform Cloud organization",
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type: ResourceTypeOrganization,
+			},
+		},
+	}
+}
+
+func workspaceField() configuration.Field {
+	return configuration.Field{
+		Name:        "workspace",
+		Label:       "Workspace",
+		Type:        configuration.FieldTypeIntegrationResource,
+		Required:    true,
+		Description: "Terraform Cloud workspace",
+		TypeOptions: &configuration.TypeOptions{
+			Resource: &configuration.ResourceTypeOptions{
+				Type: ResourceTypeWorkspace,
+				Parameters: []configuration.ParameterRef{
+					{
+						Name:      "organization",
+						ValueFrom: &configuration.ParameterValueFrom{Field: "organization"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func runIDField() configuration.Field {
+	return configuration.Field{
+		Name:        "runId",
+		Label:       "Run ID",
+		Type:        configuration.FieldTypeString,
+		Required:    true,
+		Placeholder: "run-CZcmD7eagjhyX0vN",
+		Description: "ID of the run, like the id output of Create Run",
+	}
+}
+
+func validateRunID(value string) (string, error) {
+	runID := strings.TrimSpace(value)
+	if runID == "" {
+		return "", fmt.Errorf("runId is required")
+	}
+
+	if !strings.HasPrefix(runID, "run-") {
+		return "", fmt.Errorf("invalid run ID %q, expected run-<id>", runID)
+	}
+
+	return runID, nil
+}
+
+func runURL(address string, workspace *Workspace, runID string) string {
+	if workspace == nil || workspace.Organization == "" || workspace.Name == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"%s/app/%s/workspaces/%s/runs/%s",
+		address,
+		url.PathEscape(workspace.Organization),
+		url.PathEscape(workspace.Name),
+		url.PathEscape(runID),
+	)
+}
+
+func runPayload(address string, run *Run, workspace *Workspace) map[string]any {
+	payload := map[string]any{
+		"id":         run.ID,
+		"status":     run.Status,
+		"message":    run.Message,
+		"source":     run.Source,
+		"isDestroy":  run.IsDestroy,
+		"planOnly":   run.PlanOnly,
+		"autoApply":  run.AutoApply,
+		"hasChanges": run.HasChanges,
+		"createdAt":  run.CreatedAt,
+		"actions": map[string]any{
+			"isConfirmable": run.Actions.IsConfirmable,
+			"isDiscardable": run.Actions.IsDiscardable,
+			"isCancelable":  run.Actions.IsCancelable,
+		},
+	}
+
+	if workspace != nil {
+		payload["workspace"] = map[string]any{
+			"id":           workspace.ID,
+			"name":         workspace.Name,
+			"organization": workspace.Organization,
+		}
+	} else if run.WorkspaceID != "" {
+		payload["workspace"] = map[string]any{"id": run.WorkspaceID}
+	}
+
+	if link := runURL(address, workspace, run.ID); link != "" {
+		payload["url"] = link
+	}
+
+	return payload
+}
+
+func planPayload(plan *Plan) map[string]any {
+	return map[string]any{
+		"id":         plan.ID,
+		"status":     plan.Status,
+		"hasChanges": plan.HasChanges,
+		"text":       planSummaryText(plan),
+		"summary": map[string]any{
+			"add":     plan.ResourceAdditions,
+			"change":  plan.ResourceChanges,
+			"destroy": plan.ResourceDestructions,
+			"import":  plan.ResourceImports,
+		},
+	}
+}
+
+// planSummaryText reads like the last line of terraform plan.
+func planSummaryText(plan *Plan) string {
+	if !plan.HasChanges && plan.ResourceAdditions == 0 && plan.ResourceChanges == 0 && plan.ResourceDestructions == 0 && plan.ResourceImports == 0 {
+		return "No changes."
+	}
+
+	text := fmt.Sprintf("Plan: %d to add, %d to change, %d to destroy.", plan.ResourceAdditions, plan.ResourceChanges, plan.ResourceDestructions)
+	if plan.ResourceImports > 0 {
+		text = fmt.Sprintf("Plan: %d to import, %d to add, %d to change, %d to destroy.", plan.ResourceImports, plan.ResourceAdditions, plan.ResourceChanges, plan.ResourceDestructions)
+	}
+
+	return text
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+type NodeMetadata struct {
+	Workspace *WorkspaceMetadata `json:"workspace,omitempty" mapstructure:"workspace"`
+}
+
+type WorkspaceMetadata struct {
+	ID           string `json:"id" mapstructure:"id"`
+	Name         string `json:"name" mapstructure:"name"`
+	Organization string `json:"organization" mapstructure:"organization"`
+}
+
+// setupWorkspaceMetadata checks that the workspace exists, and keeps
+// its name in the node metadata, so the UI can show it.
+func setupWorkspaceMetadata(httpCtx core.HTTPContext, integration core.IntegrationContext, metadataCtx core.MetadataWriter, workspaceID string) error {
+	metadata := NodeMetadata{}
+	if err := mapstructure.Decode(metadataCtx.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if metadata.Workspace != nil && metadata.Workspace.ID == workspaceID {
+		return nil
+	}
+
+	client, err := NewClient(httpCtx, integration)
+	if err != nil {
+		return err
+	}
+
+	workspace, err := client.GetWorkspace(workspaceID)
+	if err != nil {
+		return fmt.Errorf("workspace %s not found or inaccessible: %w", workspaceID, err)
+	}
+
+	return metadataCtx.Set(NodeMetadata{
+		Workspace: &WorkspaceMetadata{
+			ID:           workspace.ID,
+			Name:         workspace.Name,
+			Organization: workspace.Organization,
+		},
+	})
+}
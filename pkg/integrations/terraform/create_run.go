@@ -0,0 +1,258 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const CreateRunPayloadType = "terraform.run"
+
+type CreateRun struct{}
+
+type CreateRunSpec struct {
+	Organization string        `json:"organization" mapstructure:"organization"`
+	Workspace    string        `json:"workspace" mapstructure:"workspace"`
+	Message      string        `json:"message" mapstructure:"message"`
+	IsDestroy    bool          `json:"isDestroy" mapstructure:"isDestroy"`
+	PlanOnly     bool          `json:"planOnly" mapstructure:"planOnly"`
+	AutoApply    bool          `json:"autoApply" mapstructure:"autoApply"`
+	Targets      []string      `json:"targets" mapstructure:"targets"`
+	Variables    []RunVariable `json:"variables" mapstructure:"variables"`
+}
+
+func (c *CreateRun) Name() string {
+	return "terraform.createRun"
+}
+
+func (c *CreateRun) Label() string {
+	return "Create Run"
+}
+
+func (c *CreateRun) Description() string {
+	return "Queue a plan in a Terraform Cloud workspace"
+}
+
+func (c *CreateRun) Documentation() string {
+	return `The Create Run component queues a run in a Terraform Cloud workspace, with the latest configuration version of the workspace.
+
+## Use Cases
+
+- **Gated infrastructure changes**: Create a run, wait for the plan, get an approval, then apply it
+- **Drift checks**: Queue plan-only runs on a schedule
+- **Teardown**: Queue destroy runs for preview environments
+
+## Configuration
+
+- **Organization** and **Workspace**: Where the run is queued
+- **Message**: Message of the run. Defaults to "Queued by SuperPlane".
+- **Destroy**: Plan the destruction of every resource of the workspace
+- **Plan only**: Create a speculative plan, which can't be applied
+- **Auto apply**: Apply the plan without confirmation. Leave it off to confirm the plan with **Apply Run**.
+- **Targets**: Only plan these resource addresses, like ` + "`module.app`" + `
+- **Variables**: Values of Terraform variables for this run only. Values are HCL, so strings need quotes, like ` + "`\"v1.2.3\"`" + `.
+
+## Output
+
+The run, with its ID and status, and a link to it. Pass the ID to **Wait for Plan**.`
+}
+
+func (c *CreateRun) Icon() string {
+	return "terraform"
+}
+
+func (c *CreateRun) Color() string {
+	return "purple"
+}
+
+func (c *CreateRun) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *CreateRun) Configuration() []configuration.Field {
+	return []configuration.Field{
+		organizationField(),
+		workspaceField(),
+		{
+			Name:        "message",
+			Label:       "Message",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "Queued by SuperPlane",
+			Description: "Message of the run",
+		},
+		{
+			Name:        "isDestroy",
+			Label:       "Destroy",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Plan the destruction of every resource of the workspace",
+		},
+		{
+			Name:        "planOnly",
+			Label:       "Plan only",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Create a speculative plan, which can't be applied",
+		},
+		{
+			Name:        "autoApply",
+			Label:       "Auto apply",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     false,
+			Description: "Apply the plan without confirmation",
+		},
+		{
+			Name:        "targets",
+			Label:       "Targets",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Only plan these resource addresses",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Target",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeString,
+					},
+				},
+			},
+		},
+		{
+			Name:        "variables",
+			Label:       "Variables",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Terraform variables for this run. Values are HCL.",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Variable",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "key",
+								Label:    "Name",
+								Type:     configuration.FieldTypeString,
+								Required: true,
+							},
+							{
+								Name:        "value",
+								Label:       "Value",
+								Type:        configuration.FieldTypeString,
+								Required:    true,
+								Placeholder: `"v1.2.3"`,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *CreateRun) Setup(ctx core.SetupContext) error {
+	spec, err := decodeCreateRunSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	return setupWorkspaceMetadata(ctx.HTTP, ctx.Integration, ctx.Metadata, spec.Workspace)
+}
+
+func (c *CreateRun) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeCreateRunSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	workspace, err := client.GetWorkspace(spec.Workspace)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get workspace %s: %v", spec.Workspace, err))
+	}
+
+	run, err := client.CreateRun(CreateRunRequest{
+		WorkspaceID: workspace.ID,
+		Message:     spec.Message,
+		IsDestroy:   spec.IsDestroy,
+		PlanOnly:    spec.PlanOnly,
+		AutoApply:   spec.AutoApply,
+		Targets:     spec.Targets,
+		Variables:   spec.Variables,
+	})
+
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to create run in workspace %s: %v", workspace.Name, err))
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, CreateRunPayloadType, []any{runPayload(client.Address, run, workspace)})
+}
+
+func (c *CreateRun) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *CreateRun) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (c *CreateRun) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (c *CreateRun) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *CreateRun) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeCreateRunSpec(value any) (CreateRunSpec, error) {
+	spec := CreateRunSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return CreateRunSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Workspace = strings.TrimSpace(spec.Workspace)
+	if spec.Workspace == "" {
+		return CreateRunSpec{}, fmt.Errorf("workspace is required")
+	}
+
+	if spec.PlanOnly && spec.AutoApply {
+		return CreateRunSpec{}, fmt.Errorf("plan only runs can't be applied")
+	}
+
+	spec.Message = strings.TrimSpace(spec.Message)
+	if spec.Message == "" {
+		spec.Message = "Queued by SuperPlane"
+	}
+
+	targets := make([]string, 0, len(spec.Targets))
+	for _, target := range spec.Targets {
+		if target = strings.TrimSpace(target); target != "" {
+			targets = append(targets, target)
+		}
+	}
+
+	spec.Targets = targets
+
+	for i := range spec.Variables {
+		spec.Variables[i].Key = strings.TrimSpace(spec.Variables[i].Key)
+		if spec.Variables[i].Key == "" {
+			return CreateRunSpec{}, fmt.Errorf("variable %d: name is required", i+1)
+		}
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,120 @@
+package terraform
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__CreateRun__Execute(t *testing.T) {
+	component := &CreateRun{}
+
+	t.Run("creates run -> emits run with link", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production","auto-apply":false,"terraform-version":"1.9.5"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`),
+				jsonResponse(http.StatusCreated, runResponse(RunStatusPending, false, false)),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"organization": "acme",
+				"workspace":    "ws-6jrRyVDv1J8zQMB5",
+				"targets":      []any{"module.vpc", " "},
+				"variables":    []any{map[string]any{"key": "image_tag", "value": `"v1.2.3"`}},
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+
+		request := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/api/v2/runs", request.URL.Path)
+		assert.Equal(t, "application/vnd.api+json", request.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+
+		var document struct {
+			Data struct {
+				Attributes    map[string]any `json:"attributes"`
+				Relationships map[string]any `json:"relationships"`
+			} `json:"data"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &document))
+		assert.Equal(t, "Queued by SuperPlane", document.Data.Attributes["message"])
+		assert.Equal(t, false, document.Data.Attributes["auto-apply"])
+		assert.Equal(t, []any{"module.vpc"}, document.Data.Attributes["target-addrs"])
+		assert.Equal(t, []any{map[string]any{"key": "image_tag", "value": `"v1.2.3"`}}, document.Data.Attributes["variables"])
+		assert.Equal(t, "ws-6jrRyVDv1J8zQMB5", document.Data.Relationships["workspace"].(map[string]any)["data"].(map[string]any)["id"])
+
+		assert.Equal(t, core.DefaultOutputChannel.Name, executionState.Channel)
+		assert.Equal(t, CreateRunPayloadType, executionState.Type)
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "run-CZcmD7eagjhyX0vN", data["id"])
+		assert.Equal(t, "https://app.terraform.io/app/acme/workspaces/networking-production/runs/run-CZcmD7eagjhyX0vN", data["url"])
+	})
+
+	t.Run("API error -> fails execution", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production","auto-apply":false,"terraform-version":"1.9.5"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`),
+				jsonResponse(http.StatusConflict, `{"errors":[{"title":"conflict","detail":"Workspace is locked"}]}`),
+			},
+		}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"workspace": "ws-6jrRyVDv1J8zQMB5"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "conflict: Workspace is locked")
+	})
+}
+
+func Test__CreateRun__Setup(t *testing.T) {
+	component := &CreateRun{}
+
+	t.Run("plan only and auto apply -> error", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"workspace": "ws-1", "planOnly": true, "autoApply": true},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, "plan only runs can't be applied")
+	})
+
+	t.Run("stores workspace name", func(t *testing.T) {
+		metadata := &contexts.MetadataContext{}
+		err := component.Setup(core.SetupContext{
+			Configuration: map[string]any{"workspace": "ws-6jrRyVDv1J8zQMB5"},
+			HTTP:          &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production","auto-apply":false,"terraform-version":"1.9.5"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`)}},
+			Integration:   testIntegration(),
+			Metadata:      metadata,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, NodeMetadata{
+			Workspace: &WorkspaceMetadata{ID: "ws-6jrRyVDv1J8zQMB5", Name: "networking-production", Organization: "acme"},
+		}, metadata.Metadata)
+	})
+}
@@ -0,0 +1,158 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const DiscardRunPayloadType = "terraform.run.discarded"
+
+type DiscardRun struct{}
+
+type DiscardRunSpec struct {
+	RunID   string `json:"runId" mapstructure:"runId"`
+	Comment string `json:"comment" mapstructure:"comment"`
+}
+
+func (d *DiscardRun) Name() string {
+	return "terraform.discardRun"
+}
+
+func (d *DiscardRun) Label() string {
+	return "Discard Run"
+}
+
+func (d *DiscardRun) Description() string {
+	return "Discard the plan of a Terraform Cloud run"
+}
+
+func (d *DiscardRun) Documentation() string {
+	return `The Discard Run component discards a Terraform Cloud run that waits for confirmation, so the workspace can run the next one.
+
+## Use Cases
+
+- **Rejected approvals**: Discard the run when an **Approval** is rejected
+- **Expired changes**: Discard plans nobody confirmed in time
+
+## Configuration
+
+- **Run ID**: The run to discard, like the ID output of **Create Run**
+- **Comment**: Comment shown in the run timeline of Terraform Cloud
+
+## Output
+
+The discarded run.`
+}
+
+func (d *DiscardRun) Icon() string {
+	return "terraform"
+}
+
+func (d *DiscardRun) Color() string {
+	return "purple"
+}
+
+func (d *DiscardRun) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (d *DiscardRun) Configuration() []configuration.Field {
+	return []configuration.Field{
+		runIDField(),
+		{
+			Name:        "comment",
+			Label:       "Comment",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "Rejected in SuperPlane",
+			Description: "Comment shown in the run timeline",
+		},
+	}
+}
+
+func (d *DiscardRun) Setup(ctx core.SetupContext) error {
+	_, err := decodeDiscardRunSpec(ctx.Configuration, false)
+	return err
+}
+
+func (d *DiscardRun) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeDiscardRunSpec(ctx.Configuration, true)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	run, err := client.GetRun(spec.RunID)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get run %s: %v", spec.RunID, err))
+	}
+
+	if !run.Actions.IsDiscardable {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("run %s is %s, and can't be discarded", run.ID, run.Status))
+	}
+
+	if err := client.DiscardRun(run.ID, spec.Comment); err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to discard run %s: %v", run.ID, err))
+	}
+
+	//
+	// Discarding is asynchronous, so the run
+	// might not be discarded yet when read again.
+	//
+	if discarded, err := client.GetRun(run.ID); err == nil {
+		run = discarded
+	}
+
+	workspace, _ := client.GetWorkspace(run.WorkspaceID)
+	payload := runPayload(client.Address, run, workspace)
+	payload["comment"] = spec.Comment
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, DiscardRunPayloadType, []any{payload})
+}
+
+func (d *DiscardRun) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (d *DiscardRun) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (d *DiscardRun) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (d *DiscardRun) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (d *DiscardRun) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeDiscardRunSpec(value any, validateRun bool) (DiscardRunSpec, error) {
+	spec := DiscardRunSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return DiscardRunSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if validateRun {
+		runID, err := validateRunID(spec.RunID)
+		if err != nil {
+			return DiscardRunSpec{}, err
+		}
+
+		spec.RunID = runID
+	}
+
+	spec.Comment = strings.TrimSpace(spec.Comment)
+	return spec, nil
+}
@@ -0,0 +1,90 @@
+package terraform
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__DiscardRun(t *testing.T) {
+	component := &DiscardRun{}
+
+	t.Run("invalid run ID -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"runId": "ws-6jrRyVDv1J8zQMB5"},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "invalid run ID")
+	})
+
+	t.Run("not discardable -> fails execution", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, runResponse(RunStatusApplying, false, false))}}
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"runId": "run-CZcmD7eagjhyX0vN"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Equal(t, "run run-CZcmD7eagjhyX0vN is applying, and can't be discarded", executionState.FailureMessage)
+		assert.Len(t, httpContext.Requests, 1)
+	})
+
+	t.Run("discardable -> discards and emits the run", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, runResponse(RunStatusPlanned, true, true)),
+			jsonResponse(http.StatusAccepted, ``),
+			jsonResponse(http.StatusOK, runResponse(RunStatusDiscarded, false, false)),
+			jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production","auto-apply":false,"terraform-version":"1.9.5"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"runId": "run-CZcmD7eagjhyX0vN", "comment": " Superseded by a newer commit "},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 4)
+		assert.Equal(t, http.MethodPost, httpContext.Requests[1].Method)
+		assert.Equal(t, "/api/v2/runs/run-CZcmD7eagjhyX0vN/actions/discard", httpContext.Requests[1].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"comment":"Superseded by a newer commit"}`, string(body))
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, DiscardRunPayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, RunStatusDiscarded, payload["status"])
+		assert.Equal(t, "Superseded by a newer commit", payload["comment"])
+		assert.Equal(t, "networking-production", payload["workspace"].(map[string]any)["name"])
+	})
+
+	t.Run("API error -> fails execution", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"runId": "run-CZcmD7eagjhyX0vN"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, runResponse(RunStatusPlanned, true, true)),
+				jsonResponse(http.StatusConflict, `{"errors":[{"title":"conflict","detail":"Transition not allowed"}]}`),
+			}},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "failed to discard run run-CZcmD7eagjhyX0vN")
+	})
+}
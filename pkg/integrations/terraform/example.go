@@ -0,0 +1,134 @@
+package terraform
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_data_on_run_needs_attention.json
+var exampleDataOnRunNeedsAttentionBytes []byte
+
+//go:embed example_data_on_run_completed.json
+var exampleDataOnRunCompletedBytes []byte
+
+//go:embed example_data_on_run_task.json
+var exampleDataOnRunTaskBytes []byte
+
+//go:embed example_output_create_run.json
+var exampleOutputCreateRunBytes []byte
+
+//go:embed example_output_wait_for_plan.json
+var exampleOutputWaitForPlanBytes []byte
+
+//go:embed example_output_get_plan.json
+var exampleOutputGetPlanBytes []byte
+
+//go:embed example_output_apply_run.json
+var exampleOutputApplyRunBytes []byte
+
+//go:embed example_output_discard_run.json
+var exampleOutputDiscardRunBytes []byte
+
+//go:embed example_output_send_run_task_result.json
+var exampleOutputSendRunTaskResultBytes []byte
+
+var exampleDataOnRunNeedsAttentionOnce sync.Once
+var exampleDataOnRunNeedsAttention map[string]any
+
+var exampleDataOnRunCompletedOnce sync.Once
+var exampleDataOnRunCompleted map[string]any
+
+var exampleDataOnRunTaskOnce sync.Once
+var exampleDataOnRunTask map[string]any
+
+var exampleOutputCreateRunOnce sync.Once
+var exampleOutputCreateRun map[string]any
+
+var exampleOutputWaitForPlanOnce sync.Once
+var exampleOutputWaitForPlan map[string]any
+
+var exampleOutputGetPlanOnce sync.Once
+var exampleOutputGetPlan map[string]any
+
+var exampleOutputApplyRunOnce sync.Once
+var exampleOutputApplyRun map[string]any
+
+var exampleOutputDiscardRunOnce sync.Once
+var exampleOutputDiscardRun map[string]any
+
+var exampleOutputSendRunTaskResultOnce sync.Once
+var exampleOutputSendRunTaskResult map[string]any
+
+func (t *OnRunNeedsAttention) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnRunNeedsAttentionOnce,
+		exampleDataOnRunNeedsAttentionBytes,
+		&exampleDataOnRunNeedsAttention,
+	)
+}
+
+func (t *OnRunCompleted) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnRunCompletedOnce,
+		exampleDataOnRunCompletedBytes,
+		&exampleDataOnRunCompleted,
+	)
+}
+
+func (t *OnRunTask) ExampleData() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleDataOnRunTaskOnce,
+		exampleDataOnRunTaskBytes,
+		&exampleDataOnRunTask,
+	)
+}
+
+func (c *CreateRun) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputCreateRunOnce,
+		exampleOutputCreateRunBytes,
+		&exampleOutputCreateRun,
+	)
+}
+
+func (w *WaitForPlan) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputWaitForPlanOnce,
+		exampleOutputWaitForPlanBytes,
+		&exampleOutputWaitForPlan,
+	)
+}
+
+func (g *GetPlan) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputGetPlanOnce,
+		exampleOutputGetPlanBytes,
+		&exampleOutputGetPlan,
+	)
+}
+
+func (a *ApplyRun) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputApplyRunOnce,
+		exampleOutputApplyRunBytes,
+		&exampleOutputApplyRun,
+	)
+}
+
+func (d *DiscardRun) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputDiscardRunOnce,
+		exampleOutputDiscardRunBytes,
+		&exampleOutputDiscardRun,
+	)
+}
+
+func (s *SendRunTaskResult) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(
+		&exampleOutputSendRunTaskResultOnce,
+		exampleOutputSendRunTaskResultBytes,
+		&exampleOutputSendRunTaskResult,
+	)
+}
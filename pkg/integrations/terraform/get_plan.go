@@ -0,0 +1,183 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const GetPlanPayloadType = "terraform.plan"
+
+type GetPlan struct{}
+
+type GetPlanSpec struct {
+	RunID                  string `json:"runId" mapstructure:"runId"`
+	IncludeResourceChanges *bool  `json:"includeResourceChanges,omitempty" mapstructure:"includeResourceChanges"`
+}
+
+func (g *GetPlan) Name() string {
+	return "terraform.getPlan"
+}
+
+func (g *GetPlan) Label() string {
+	return "Get Plan Summary"
+}
+
+func (g *GetPlan) Description() string {
+	return "Get the resource changes planned by a Terraform Cloud run"
+}
+
+func (g *GetPlan) Documentation() string {
+	return `The Get Plan Summary component reads the plan of a Terraform Cloud run, with the number of resources to add, change, destroy and import.
+
+## Use Cases
+
+- **Approvals**: Show reviewers what a run is about to change before they approve it
+- **Guardrails**: Route plans that destroy resources to a stricter approval
+- **Change logs**: Post the planned changes to a chat channel
+
+## Configuration
+
+- **Run ID**: The run to read, like the ID output of **Create Run**
+- **Include resource changes**: Also list every resource that changes, with its address and actions. It reads the JSON plan, which needs permission to read the workspace state versions.
+
+## Output
+
+The run, the plan summary, like ` + "`Plan: 1 to add, 2 to change, 0 to destroy.`" + `, and the resource changes.`
+}
+
+func (g *GetPlan) Icon() string {
+	return "terraform"
+}
+
+func (g *GetPlan) Color() string {
+	return "purple"
+}
+
+func (g *GetPlan) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (g *GetPlan) Configuration() []configuration.Field {
+	return []configuration.Field{
+		runIDField(),
+		{
+			Name:        "includeResourceChanges",
+			Label:       "Include resource changes",
+			Type:        configuration.FieldTypeBool,
+			Required:    false,
+			Default:     true,
+			Description: "List every resource that changes",
+		},
+	}
+}
+
+func (g *GetPlan) Setup(ctx core.SetupContext) error {
+	_, err := decodeGetPlanSpec(ctx.Configuration, false)
+	return err
+}
+
+func (g *GetPlan) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeGetPlanSpec(ctx.Configuration, true)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	run, err := client.GetRun(spec.RunID)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get run %s: %v", spec.RunID, err))
+	}
+
+	if run.PlanID == "" {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("run %s has no plan", spec.RunID))
+	}
+
+	plan, err := client.GetPlan(run.PlanID)
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get plan %s: %v", run.PlanID, err))
+	}
+
+	workspace, _ := client.GetWorkspace(run.WorkspaceID)
+	payload := runPayload(client.Address, run, workspace)
+	payload["plan"] = planPayload(plan)
+
+	if *spec.IncludeResourceChanges && plan.Status == "finished" {
+		changes, err := client.GetPlanResourceChanges(plan.ID)
+		if err != nil {
+			return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to get JSON plan %s: %v", plan.ID, err))
+		}
+
+		payload["resourceChanges"] = resourceChangesPayload(changes)
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, GetPlanPayloadType, []any{payload})
+}
+
+func (g *GetPlan) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (g *GetPlan) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (g *GetPlan) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (g *GetPlan) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (g *GetPlan) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeGetPlanSpec(value any, validateRun bool) (GetPlanSpec, error) {
+	spec := GetPlanSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return GetPlanSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if validateRun {
+		runID, err := validateRunID(spec.RunID)
+		if err != nil {
+			return GetPlanSpec{}, err
+		}
+
+		spec.RunID = runID
+	}
+
+	if spec.IncludeResourceChanges == nil {
+		include := true
+		spec.IncludeResourceChanges = &include
+	}
+
+	return spec, nil
+}
+
+func resourceChangesPayload(changes []ResourceChange) []any {
+	result := make([]any, 0, len(changes))
+	for _, change := range changes {
+		actions := make([]any, 0, len(change.Change.Actions))
+		for _, action := range change.Change.Actions {
+			actions = append(actions, action)
+		}
+
+		result = append(result, map[string]any{
+			"address": change.Address,
+			"type":    change.Type,
+			"name":    change.Name,
+			"actions": actions,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+package terraform
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__GetPlan(t *testing.T) {
+	component := &GetPlan{}
+
+	t.Run("expression run ID -> not validated on setup", func(t *testing.T) {
+		err := component.Setup(core.SetupContext{Configuration: map[string]any{"runId": `{{ $["Create Run"].data.id }}`}})
+		require.NoError(t, err)
+	})
+
+	t.Run("finished plan -> emits summary and resource changes", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, runResponse(RunStatusPlanned, true, true)),
+			jsonResponse(http.StatusOK, `{"data":{"id":"plan-V6fJy1rgHbwWDe7H","type":"plans","attributes":{"status":"finished","has-changes":true,"resource-additions":1,"resource-changes":1,"resource-destructions":0,"resource-imports":0}}}`),
+			jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production","auto-apply":false,"terraform-version":"1.9.5"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`),
+			jsonResponse(http.StatusOK, `{"resource_changes":[
+				{"address":"aws_vpc.main","type":"aws_vpc","name":"main","change":{"actions":["update"]}},
+				{"address":"aws_subnet.private","type":"aws_subnet","name":"private","change":{"actions":["create"]}},
+				{"address":"data.aws_region.current","type":"aws_region","name":"current","change":{"actions":["read"]}},
+				{"address":"aws_route_table.main","type":"aws_route_table","name":"main","change":{"actions":["no-op"]}}
+			]}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"runId": "run-CZcmD7eagjhyX0vN"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 4)
+		assert.Equal(t, "/api/v2/plans/plan-V6fJy1rgHbwWDe7H", httpContext.Requests[1].URL.Path)
+		assert.Equal(t, "/api/v2/plans/plan-V6fJy1rgHbwWDe7H/json-output", httpContext.Requests[3].URL.Path)
+
+		assert.True(t, executionState.Passed)
+		assert.Equal(t, GetPlanPayloadType, executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		plan := payload["plan"].(map[string]any)
+		assert.Equal(t, "Plan: 1 to add, 1 to change, 0 to destroy.", plan["text"])
+
+		changes := payload["resourceChanges"].([]any)
+		require.Len(t, changes, 2)
+		assert.Equal(t, "aws_vpc.main", changes[0].(map[string]any)["address"])
+		assert.Equal(t, []any{"create"}, changes[1].(map[string]any)["actions"])
+	})
+
+	t.Run("resource changes disabled -> skips the JSON plan", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, runResponse(RunStatusPlanned, true, true)),
+			jsonResponse(http.StatusOK, `{"data":{"id":"plan-V6fJy1rgHbwWDe7H","type":"plans","attributes":{"status":"finished","has-changes":false}}}`),
+			jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production","auto-apply":false,"terraform-version":"1.9.5"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"runId": "run-CZcmD7eagjhyX0vN", "includeResourceChanges": false},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, httpContext.Requests, 3)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "No changes.", payload["plan"].(map[string]any)["text"])
+		assert.NotContains(t, payload, "resourceChanges")
+	})
+
+	t.Run("run without plan -> fails execution", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"runId": "run-CZcmD7eagjhyX0vN"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":{"id":"run-CZcmD7eagjhyX0vN","type":"runs","attributes":{"status":"pending"}}}`),
+			}},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Passed)
+		assert.Equal(t, "run run-CZcmD7eagjhyX0vN has no plan", executionState.FailureMessage)
+	})
+}
@@ -0,0 +1,93 @@
+package terraform
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const notificationSignatureHeader = "X-TFE-Notification-Signature"
+
+// NotificationPayload is the body of the generic
+// notifications Terraform Cloud sends for runs.
+type NotificationPayload struct {
+	PayloadVersion              int            `json:"payload_version"`
+	NotificationConfigurationID string         `json:"notification_configuration_id"`
+	RunURL                      string         `json:"run_url"`
+	RunID                       string         `json:"run_id"`
+	RunMessage                  string         `json:"run_message"`
+	RunCreatedAt                string         `json:"run_created_at"`
+	RunCreatedBy                string         `json:"run_created_by"`
+	WorkspaceID                 string         `json:"workspace_id"`
+	WorkspaceName               string         `json:"workspace_name"`
+	OrganizationName            string         `json:"organization_name"`
+	Notifications               []Notification `json:"notifications"`
+}
+
+type Notification struct {
+	Message      string `json:"message"`
+	Trigger      string `json:"trigger"`
+	RunStatus    string `json:"run_status"`
+	RunUpdatedAt string `json:"run_updated_at"`
+	RunUpdatedBy string `json:"run_updated_by"`
+}
+
+// readNotification checks the HMAC-SHA512 signature of a notification
+// with the webhook secret, and parses it.
+func readNotification(ctx core.WebhookRequestContext) (*NotificationPayload, int, error) {
+	signature := strings.TrimSpace(ctx.Headers.Get(notificationSignatureHeader))
+	if signature == "" {
+		return nil, http.StatusForbidden, fmt.Errorf("missing signature")
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("error authenticating request")
+	}
+
+	if !validSignature(secret, ctx.Body, signature) {
+		return nil, http.StatusForbidden, fmt.Errorf("invalid signature")
+	}
+
+	payload := NotificationPayload{}
+	if err := json.Unmarshal(ctx.Body, &payload); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	return &payload, http.StatusOK, nil
+}
+
+func validSignature(secret, body []byte, signature string) bool {
+	h := hmac.New(sha512.New, secret)
+	h.Write(body)
+	expected := hex.EncodeToString(h.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
+
+func notificationEventPayload(payload *NotificationPayload, notification Notification) map[string]any {
+	return map[string]any{
+		"trigger": notification.Trigger,
+		"message": notification.Message,
+		"run": map[string]any{
+			"id":        payload.RunID,
+			"url":       payload.RunURL,
+			"message":   payload.RunMessage,
+			"status":    notification.RunStatus,
+			"createdAt": payload.RunCreatedAt,
+			"createdBy": payload.RunCreatedBy,
+			"updatedAt": notification.RunUpdatedAt,
+			"updatedBy": notification.RunUpdatedBy,
+		},
+		"workspace": map[string]any{
+			"id":   payload.WorkspaceID,
+			"name": payload.WorkspaceName,
+		},
+		"organization": payload.OrganizationName,
+	}
+}
@@ -0,0 +1,155 @@
+package terraform
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const OnRunCompletedPayloadType = "terraform.run.completed"
+
+type OnRunCompleted struct{}
+
+type OnRunCompletedConfiguration struct {
+	Organization string   `json:"organization" mapstructure:"organization"`
+	Workspace    string   `json:"workspace" mapstructure:"workspace"`
+	Statuses     []string `json:"statuses" mapstructure:"statuses"`
+}
+
+func (t *OnRunCompleted) Name() string {
+	return "terraform.onRunCompleted"
+}
+
+func (t *OnRunCompleted) Label() string {
+	return "On Run Completed"
+}
+
+func (t *OnRunCompleted) Description() string {
+	return "Listen to Terraform Cloud runs that finish"
+}
+
+func (t *OnRunCompleted) Documentation() string {
+	return `The On Run Completed trigger starts a workflow when a run of a Terraform Cloud workspace finishes, applied or not.
+
+## Use Cases
+
+- **Post-apply automation**: Deploy applications once their infrastructure is applied
+- **Failure alerts**: Notify the owning team when a run errors
+- **Audit**: Record every infrastructure change
+
+## Configuration
+
+- **Organization** and **Workspace**: The workspace to listen to
+- **Statuses**: Only fire for runs that end with these statuses. Leave empty for every status.
+
+## Event Data
+
+The run, with its ID, final status and link, the workspace, and the notification message.
+
+## Webhook Setup
+
+SuperPlane creates a run notification in the workspace when the trigger is saved, and deletes it when the trigger is removed. The API token needs permission to manage the notifications of the workspace.`
+}
+
+func (t *OnRunCompleted) Icon() string {
+	return "terraform"
+}
+
+func (t *OnRunCompleted) Color() string {
+	return "purple"
+}
+
+func (t *OnRunCompleted) Configuration() []configuration.Field {
+	return []configuration.Field{
+		organizationField(),
+		workspaceField(),
+		{
+			Name:        "statuses",
+			Label:       "Statuses",
+			Type:        configuration.FieldTypeMultiSelect,
+			Required:    false,
+			Description: "Only fire for runs that end with these statuses",
+			TypeOptions: &configuration.TypeOptions{
+				MultiSelect: &configuration.MultiSelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Applied", Value: RunStatusApplied},
+						{Label: "Planned and finished", Value: RunStatusPlannedAndFinished},
+						{Label: "Errored", Value: RunStatusErrored},
+						{Label: "Canceled", Value: RunStatusCanceled},
+						{Label: "Discarded", Value: RunStatusDiscarded},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *OnRunCompleted) Setup(ctx core.TriggerContext) error {
+	config := OnRunCompletedConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	workspaceID := strings.TrimSpace(config.Workspace)
+	if workspaceID == "" {
+		return fmt.Errorf("workspace is required")
+	}
+
+	if err := setupWorkspaceMetadata(ctx.HTTP, ctx.Integration, ctx.Metadata, workspaceID); err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		WorkspaceID: workspaceID,
+		Triggers:    []string{NotificationTriggerCompleted, NotificationTriggerErrored},
+	})
+}
+
+func (t *OnRunCompleted) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (t *OnRunCompleted) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnRunCompleted) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	config := OnRunCompletedConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	payload, status, err := readNotification(ctx)
+	if err != nil {
+		return status, nil, err
+	}
+
+	if payload.WorkspaceID != "" && payload.WorkspaceID != strings.TrimSpace(config.Workspace) {
+		return http.StatusOK, nil, nil
+	}
+
+	for _, notification := range payload.Notifications {
+		if notification.Trigger != NotificationTriggerCompleted && notification.Trigger != NotificationTriggerErrored {
+			continue
+		}
+
+		if len(config.Statuses) > 0 && !slices.Contains(config.Statuses, notification.RunStatus) {
+			continue
+		}
+
+		if err := ctx.Events.Emit(OnRunCompletedPayloadType, notificationEventPayload(payload, notification)); err != nil {
+			return http.StatusInternalServerError, nil, fmt.Errorf("failed to emit event: %w", err)
+		}
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnRunCompleted) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
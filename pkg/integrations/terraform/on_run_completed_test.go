@@ -0,0 +1,125 @@
+package terraform
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const completedNotification = `{
+	"payload_version": 1,
+	"notification_configuration_id": "nc-1",
+	"run_url": "https://app.terraform.io/app/acme/workspaces/networking-production/runs/run-CZcmD7eagjhyX0vN",
+	"run_id": "run-CZcmD7eagjhyX0vN",
+	"run_message": "Update security groups",
+	"run_created_at": "2026-10-19T10:00:00.000Z",
+	"run_created_by": "jane",
+	"workspace_id": "ws-6jrRyVDv1J8zQMB5",
+	"workspace_name": "networking-production",
+	"organization_name": "acme",
+	"notifications": [
+		{"message": "Run Errored", "trigger": "run:errored", "run_status": "errored", "run_updated_at": "2026-10-19T10:02:00.000Z", "run_updated_by": null}
+	]
+}`
+
+func sign(secret, body string) string {
+	h := hmac.New(sha512.New, []byte(secret))
+	h.Write([]byte(body))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func Test__OnRunCompleted__HandleWebhook(t *testing.T) {
+	trigger := &OnRunCompleted{}
+
+	handle := func(configuration map[string]any, signature string, body string) (int, *contexts.EventContext, error) {
+		events := &contexts.EventContext{}
+		headers := http.Header{}
+		if signature != "" {
+			headers.Set("X-TFE-Notification-Signature", signature)
+		}
+
+		code, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          []byte(body),
+			Headers:       headers,
+			Configuration: configuration,
+			Webhook:       &contexts.NodeWebhookContext{Secret: "secret-123"},
+			Events:        events,
+		})
+
+		return code, events, err
+	}
+
+	t.Run("signed notification -> emits event", func(t *testing.T) {
+		code, events, err := handle(map[string]any{"workspace": "ws-6jrRyVDv1J8zQMB5"}, sign("secret-123", completedNotification), completedNotification)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, OnRunCompletedPayloadType, events.Payloads[0].Type)
+
+		data := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "run:errored", data["trigger"])
+		assert.Equal(t, "errored", data["run"].(map[string]any)["status"])
+		assert.Equal(t, "networking-production", data["workspace"].(map[string]any)["name"])
+	})
+
+	t.Run("status filtered out -> no event", func(t *testing.T) {
+		code, events, err := handle(
+			map[string]any{"workspace": "ws-6jrRyVDv1J8zQMB5", "statuses": []string{RunStatusApplied}},
+			sign("secret-123", completedNotification),
+			completedNotification,
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("verification request -> ok without event", func(t *testing.T) {
+		body := `{"payload_version":1,"notifications":[{"message":"Verification of SuperPlane","trigger":"verification"}]}`
+		code, events, err := handle(map[string]any{"workspace": "ws-6jrRyVDv1J8zQMB5"}, sign("secret-123", body), body)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("invalid signature -> forbidden", func(t *testing.T) {
+		code, events, err := handle(map[string]any{"workspace": "ws-6jrRyVDv1J8zQMB5"}, sign("other", completedNotification), completedNotification)
+
+		require.ErrorContains(t, err, "invalid signature")
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("missing signature -> forbidden", func(t *testing.T) {
+		code, _, err := handle(map[string]any{"workspace": "ws-6jrRyVDv1J8zQMB5"}, "", completedNotification)
+
+		require.ErrorContains(t, err, "missing signature")
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
+
+func Test__OnRunCompleted__Setup(t *testing.T) {
+	integrationCtx := testIntegration()
+	err := (&OnRunCompleted{}).Setup(core.TriggerContext{
+		Configuration: map[string]any{"organization": "acme", "workspace": "ws-6jrRyVDv1J8zQMB5"},
+		HTTP:          &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production","auto-apply":false,"terraform-version":"1.9.5"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`)}},
+		Integration:   integrationCtx,
+		Metadata:      &contexts.MetadataContext{},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, integrationCtx.WebhookRequests, 1)
+	assert.Equal(t, WebhookConfiguration{
+		WorkspaceID: "ws-6jrRyVDv1J8zQMB5",
+		Triggers:    []string{NotificationTriggerCompleted, NotificationTriggerErrored},
+	}, integrationCtx.WebhookRequests[0])
+}
@@ -0,0 +1,129 @@
+package terraform
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const OnRunNeedsAttentionPayloadType = "terraform.run.needsAttention"
+
+type OnRunNeedsAttention struct{}
+
+type OnRunNeedsAttentionConfiguration struct {
+	Organization string `json:"organization" mapstructure:"organization"`
+	Workspace    string `json:"workspace" mapstructure:"workspace"`
+}
+
+func (t *OnRunNeedsAttention) Name() string {
+	return "terraform.onRunNeedsAttention"
+}
+
+func (t *OnRunNeedsAttention) Label() string {
+	return "On Run Needs Attention"
+}
+
+func (t *OnRunNeedsAttention) Description() string {
+	return "Listen to Terraform Cloud runs waiting for confirmation"
+}
+
+func (t *OnRunNeedsAttention) Documentation() string {
+	return `The On Run Needs Attention trigger starts a workflow when a run of a Terraform Cloud workspace needs someone to act on it, like a plan waiting for confirmation, or a policy check waiting for an override.
+
+## Use Cases
+
+- **Approvals for any run**: Send runs started from VCS or the Terraform CLI to an **Approval**, then to **Apply Run** or **Discard Run**
+- **Reminders**: Notify the owning team when a plan waits for confirmation
+
+## Configuration
+
+- **Organization** and **Workspace**: The workspace to listen to
+
+## Event Data
+
+The run, with its ID, status and link, the workspace, and the notification message.
+
+## Webhook Setup
+
+SuperPlane creates a run notification in the workspace when the trigger is saved, and deletes it when the trigger is removed. The API token needs permission to manage the notifications of the workspace.`
+}
+
+func (t *OnRunNeedsAttention) Icon() string {
+	return "terraform"
+}
+
+func (t *OnRunNeedsAttention) Color() string {
+	return "purple"
+}
+
+func (t *OnRunNeedsAttention) Configuration() []configuration.Field {
+	return []configuration.Field{
+		organizationField(),
+		workspaceField(),
+	}
+}
+
+func (t *OnRunNeedsAttention) Setup(ctx core.TriggerContext) error {
+	config := OnRunNeedsAttentionConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	workspaceID := strings.TrimSpace(config.Workspace)
+	if workspaceID == "" {
+		return fmt.Errorf("workspace is required")
+	}
+
+	if err := setupWorkspaceMetadata(ctx.HTTP, ctx.Integration, ctx.Metadata, workspaceID); err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		WorkspaceID: workspaceID,
+		Triggers:    []string{NotificationTriggerNeedsAttention},
+	})
+}
+
+func (t *OnRunNeedsAttention) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (t *OnRunNeedsAttention) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnRunNeedsAttention) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	config := OnRunNeedsAttentionConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	payload, status, err := readNotification(ctx)
+	if err != nil {
+		return status, nil, err
+	}
+
+	if payload.WorkspaceID != "" && payload.WorkspaceID != strings.TrimSpace(config.Workspace) {
+		return http.StatusOK, nil, nil
+	}
+
+	for _, notification := range payload.Notifications {
+		if notification.Trigger != NotificationTriggerNeedsAttention {
+			continue
+		}
+
+		if err := ctx.Events.Emit(OnRunNeedsAttentionPayloadType, notificationEventPayload(payload, notification)); err != nil {
+			return http.StatusInternalServerError, nil, fmt.Errorf("failed to emit event: %w", err)
+		}
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnRunNeedsAttention) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
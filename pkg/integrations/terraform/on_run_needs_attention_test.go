@@ -0,0 +1,102 @@
+package terraform
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__OnRunNeedsAttention__HandleWebhook(t *testing.T) {
+	trigger := &OnRunNeedsAttention{}
+
+	handle := func(workspace string, body string) (int, *contexts.EventContext, error) {
+		events := &contexts.EventContext{}
+		headers := http.Header{}
+		headers.Set("X-TFE-Notification-Signature", sign("secret-123", body))
+
+		code, _, err := trigger.HandleWebhook(core.WebhookRequestContext{
+			Body:          []byte(body),
+			Headers:       headers,
+			Configuration: map[string]any{"workspace": workspace},
+			Webhook:       &contexts.NodeWebhookContext{Secret: "secret-123"},
+			Events:        events,
+		})
+
+		return code, events, err
+	}
+
+	needsAttention := `{
+		"payload_version": 1,
+		"run_id": "run-CZcmD7eagjhyX0vN",
+		"run_message": "Update security groups",
+		"workspace_id": "ws-6jrRyVDv1J8zQMB5",
+		"workspace_name": "networking-production",
+		"organization_name": "acme",
+		"notifications": [
+			{"message": "Run Needs Attention", "trigger": "run:needs_attention", "run_status": "planned"}
+		]
+	}`
+
+	t.Run("needs attention -> emits event", func(t *testing.T) {
+		code, events, err := handle("ws-6jrRyVDv1J8zQMB5", needsAttention)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		require.Equal(t, 1, events.Count())
+		assert.Equal(t, OnRunNeedsAttentionPayloadType, events.Payloads[0].Type)
+
+		data := events.Payloads[0].Data.(map[string]any)
+		assert.Equal(t, "run:needs_attention", data["trigger"])
+		assert.Equal(t, "planned", data["run"].(map[string]any)["status"])
+	})
+
+	t.Run("other workspace -> no event", func(t *testing.T) {
+		code, events, err := handle("ws-other", needsAttention)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+
+	t.Run("completed run -> no event", func(t *testing.T) {
+		code, events, err := handle("ws-6jrRyVDv1J8zQMB5", completedNotification)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Zero(t, events.Count())
+	})
+}
+
+func Test__OnRunNeedsAttention__Setup(t *testing.T) {
+	t.Run("missing workspace -> error", func(t *testing.T) {
+		err := (&OnRunNeedsAttention{}).Setup(core.TriggerContext{
+			Configuration: map[string]any{"organization": "acme"},
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+		})
+
+		require.ErrorContains(t, err, "workspace is required")
+	})
+
+	t.Run("workspace -> requests needs attention webhook", func(t *testing.T) {
+		integrationCtx := testIntegration()
+		err := (&OnRunNeedsAttention{}).Setup(core.TriggerContext{
+			Configuration: map[string]any{"organization": "acme", "workspace": "ws-6jrRyVDv1J8zQMB5"},
+			HTTP:          &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production"}}}`)}},
+			Integration:   integrationCtx,
+			Metadata:      &contexts.MetadataContext{},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, integrationCtx.WebhookRequests, 1)
+		assert.Equal(t, WebhookConfiguration{
+			WorkspaceID: "ws-6jrRyVDv1J8zQMB5",
+			Triggers:    []string{NotificationTriggerNeedsAttention},
+		}, integrationCtx.WebhookRequests[0])
+	})
+}
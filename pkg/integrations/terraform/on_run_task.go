@@ -0,0 +1,211 @@
+package terraform
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const OnRunTaskPayloadType = "terraform.runTask"
+
+type OnRunTask struct{}
+
+type OnRunTaskConfiguration struct {
+	Organization     string `json:"organization" mapstructure:"organization"`
+	Workspace        string `json:"workspace" mapstructure:"workspace"`
+	Stage            string `json:"stage" mapstructure:"stage"`
+	EnforcementLevel string `json:"enforcementLevel" mapstructure:"enforcementLevel"`
+}
+
+func (t *OnRunTask) Name() string {
+	return "terraform.onRunTask"
+}
+
+func (t *OnRunTask) Label() string {
+	return "On Run Task"
+}
+
+func (t *OnRunTask) Description() string {
+	return "Check Terraform Cloud runs with a run task"
+}
+
+func (t *OnRunTask) Documentation() string {
+	return `The On Run Task trigger makes the workflow a run task of a Terraform Cloud workspace. Terraform Cloud calls it at a stage of every run, and waits for the workflow to send the result back with **Send Run Task Result**.
+
+## Use Cases
+
+- **Custom checks**: Scan the plan, check a change window or a ticket, then pass or fail the run
+- **Approvals in runs**: Hold a run at the pre-apply stage until an **Approval** is given
+
+## Configuration
+
+- **Organization** and **Workspace**: The workspace whose runs call the task
+- **Stage**: When the task runs: before or after the plan, or before or after the apply
+- **Enforcement Level**: With **Mandatory**, a failed result stops the run. With **Advisory**, it only shows a warning.
+
+## Event Data
+
+The task result ID, the stage, the run, with its ID, message and link, the workspace, and the VCS details of the run. The access token Terraform Cloud sends with the request is kept by SuperPlane for **Send Run Task Result**, and is not part of the event.
+
+## Webhook Setup
+
+SuperPlane creates a run task in the organization and attaches it to the workspace when the trigger is saved, and deletes both when the trigger is removed. Terraform Cloud signs its requests with an HMAC key, checked on every request. The API token needs permission to manage the run tasks of the organization and the workspace.
+
+Terraform Cloud waits 10 minutes for the result. Without one, the task errors, which stops the run when the task is mandatory.`
+}
+
+func (t *OnRunTask) Icon() string {
+	return "terraform"
+}
+
+func (t *OnRunTask) Color() string {
+	return "purple"
+}
+
+func (t *OnRunTask) Configuration() []configuration.Field {
+	return []configuration.Field{
+		organizationField(),
+		workspaceField(),
+		{
+			Name:        "stage",
+			Label:       "Stage",
+			Type:        configuration.FieldTypeSelect,
+			Required:    true,
+			Default:     RunTaskStagePostPlan,
+			Description: "Stage of the run that calls the task",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Pre-plan", Value: RunTaskStagePrePlan},
+						{Label: "Post-plan", Value: RunTaskStagePostPlan},
+						{Label: "Pre-apply", Value: RunTaskStagePreApply},
+						{Label: "Post-apply", Value: RunTaskStagePostApply},
+					},
+				},
+			},
+		},
+		{
+			Name:        "enforcementLevel",
+			Label:       "Enforcement Level",
+			Type:        configuration.FieldTypeSelect,
+			Required:    true,
+			Default:     RunTaskEnforcementMandatory,
+			Description: "Whether a failed result stops the run",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Mandatory", Value: RunTaskEnforcementMandatory},
+						{Label: "Advisory", Value: RunTaskEnforcementAdvisory},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (t *OnRunTask) Setup(ctx core.TriggerContext) error {
+	config, err := decodeOnRunTaskConfiguration(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	if err := setupWorkspaceMetadata(ctx.HTTP, ctx.Integration, ctx.Metadata, config.Workspace); err != nil {
+		return err
+	}
+
+	return ctx.Integration.RequestWebhook(WebhookConfiguration{
+		WorkspaceID: config.Workspace,
+		RunTask: &RunTaskWebhookConfiguration{
+			Stage:            config.Stage,
+			EnforcementLevel: config.EnforcementLevel,
+		},
+	})
+}
+
+func decodeOnRunTaskConfiguration(value any) (OnRunTaskConfiguration, error) {
+	config := OnRunTaskConfiguration{}
+	if err := mapstructure.Decode(value, &config); err != nil {
+		return OnRunTaskConfiguration{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	config.Workspace = strings.TrimSpace(config.Workspace)
+	if config.Workspace == "" {
+		return OnRunTaskConfiguration{}, fmt.Errorf("workspace is required")
+	}
+
+	config.Stage = strings.TrimSpace(config.Stage)
+	if config.Stage == "" {
+		config.Stage = RunTaskStagePostPlan
+	}
+
+	if !slices.Contains([]string{RunTaskStagePrePlan, RunTaskStagePostPlan, RunTaskStagePreApply, RunTaskStagePostApply}, config.Stage) {
+		return OnRunTaskConfiguration{}, fmt.Errorf("invalid stage %s", config.Stage)
+	}
+
+	config.EnforcementLevel = strings.TrimSpace(config.EnforcementLevel)
+	if config.EnforcementLevel == "" {
+		config.EnforcementLevel = RunTaskEnforcementMandatory
+	}
+
+	if config.EnforcementLevel != RunTaskEnforcementMandatory && config.EnforcementLevel != RunTaskEnforcementAdvisory {
+		return OnRunTaskConfiguration{}, fmt.Errorf("invalid enforcement level %s", config.EnforcementLevel)
+	}
+
+	return config, nil
+}
+
+func (t *OnRunTask) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (t *OnRunTask) HandleHook(ctx core.TriggerHookContext) (map[string]any, error) {
+	return nil, nil
+}
+
+func (t *OnRunTask) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	config := OnRunTaskConfiguration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	request, status, err := readRunTaskRequest(ctx)
+	if err != nil {
+		return status, nil, err
+	}
+
+	if request.AccessToken == runTaskVerificationToken {
+		return http.StatusOK, nil, nil
+	}
+
+	if request.WorkspaceID != "" && request.WorkspaceID != strings.TrimSpace(config.Workspace) {
+		return http.StatusOK, nil, nil
+	}
+
+	if request.TaskResultID == "" || request.TaskResultCallbackURL == "" || request.AccessToken == "" {
+		return http.StatusBadRequest, nil, fmt.Errorf("run task request without a task result callback")
+	}
+
+	if ctx.Integration == nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("integration is required")
+	}
+
+	if err := savePendingTaskResult(ctx.Integration, request, time.Now()); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to save run task result callback: %w", err)
+	}
+
+	if err := ctx.Events.Emit(OnRunTaskPayloadType, runTaskEventPayload(request)); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to emit event: %w", err)
+	}
+
+	return http.StatusOK, nil, nil
+}
+
+func (t *OnRunTask) Cleanup(ctx core.TriggerContext) error {
+	return nil
+}
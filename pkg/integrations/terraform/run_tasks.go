@@ -0,0 +1,195 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	runTaskSignatureHeader = "X-TFC-Task-Signature"
+
+	//
+	// Terraform Cloud sends a request with this access token
+	// when a run task is created or updated, to verify the endpoint.
+	//
+	runTaskVerificationToken = "test-token"
+
+	//
+	// Access tokens of run task requests are kept as integration secrets
+	// until the result is sent. Terraform Cloud stops waiting for the
+	// result after 10 minutes, so older tokens are useless.
+	//
+	runTaskTokenSecretPrefix = "runTaskResult."
+	runTaskTokenRetention    = time.Hour
+
+	RunTaskStagePrePlan   = "pre_plan"
+	RunTaskStagePostPlan  = "post_plan"
+	RunTaskStagePreApply  = "pre_apply"
+	RunTaskStagePostApply = "post_apply"
+
+	RunTaskEnforcementAdvisory  = "advisory"
+	RunTaskEnforcementMandatory = "mandatory"
+
+	TaskResultStatusPassed = "passed"
+	TaskResultStatusFailed = "failed"
+)
+
+// RunTaskRequest is the body of the requests
+// Terraform Cloud sends to run tasks during runs.
+type RunTaskRequest struct {
+	PayloadVersion             int    `json:"payload_version"`
+	Stage                      string `json:"stage"`
+	AccessToken                string `json:"access_token"`
+	IsSpeculative              bool   `json:"is_speculative"`
+	TaskResultID               string `json:"task_result_id"`
+	TaskResultEnforcementLevel string `json:"task_result_enforcement_level"`
+	TaskResultCallbackURL      string `json:"task_result_callback_url"`
+	RunAppURL                  string `json:"run_app_url"`
+	RunID                      string `json:"run_id"`
+	RunMessage                 string `json:"run_message"`
+	RunCreatedAt               string `json:"run_created_at"`
+	RunCreatedBy               string `json:"run_created_by"`
+	WorkspaceID                string `json:"workspace_id"`
+	WorkspaceName              string `json:"workspace_name"`
+	WorkspaceAppURL            string `json:"workspace_app_url"`
+	OrganizationName           string `json:"organization_name"`
+	PlanJSONAPIURL             string `json:"plan_json_api_url"`
+	VCSRepoURL                 string `json:"vcs_repo_url"`
+	VCSBranch                  string `json:"vcs_branch"`
+	VCSCommitURL               string `json:"vcs_commit_url"`
+	VCSPullRequestURL          string `json:"vcs_pull_request_url"`
+}
+
+// pendingTaskResult is what sending the result of
+// a run task request needs, kept as an integration secret.
+type pendingTaskResult struct {
+	CallbackURL string    `json:"callbackUrl"`
+	AccessToken string    `json:"accessToken"`
+	ReceivedAt  time.Time `json:"receivedAt"`
+}
+
+// readRunTaskRequest checks the HMAC-SHA512 signature of a run task
+// request with the webhook secret, and parses it.
+func readRunTaskRequest(ctx core.WebhookRequestContext) (*RunTaskRequest, int, error) {
+	signature := strings.TrimSpace(ctx.Headers.Get(runTaskSignatureHeader))
+	if signature == "" {
+		return nil, http.StatusForbidden, fmt.Errorf("missing signature")
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("error authenticating request")
+	}
+
+	if !validSignature(secret, ctx.Body, signature) {
+		return nil, http.StatusForbidden, fmt.Errorf("invalid signature")
+	}
+
+	request := RunTaskRequest{}
+	if err := json.Unmarshal(ctx.Body, &request); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	return &request, http.StatusOK, nil
+}
+
+func runTaskTokenSecretName(taskResultID string) string {
+	return runTaskTokenSecretPrefix + taskResultID
+}
+
+// savePendingTaskResult keeps the callback of a run task request, so a
+// later Send Run Task Result can answer it, without the access token
+// ever being part of the event. Tokens past their use are dropped.
+func savePendingTaskResult(integration core.IntegrationContext, request *RunTaskRequest, now time.Time) error {
+	secrets, err := integration.GetSecrets()
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets {
+		if !strings.HasPrefix(secret.Name, runTaskTokenSecretPrefix) {
+			continue
+		}
+
+		pending := pendingTaskResult{}
+		if err := json.Unmarshal(secret.Value, &pending); err == nil && now.Sub(pending.ReceivedAt) < runTaskTokenRetention {
+			continue
+		}
+
+		if err := integration.Secrets().Delete(secret.Name); err != nil {
+			return err
+		}
+	}
+
+	value, err := json.Marshal(pendingTaskResult{
+		CallbackURL: request.TaskResultCallbackURL,
+		AccessToken: request.AccessToken,
+		ReceivedAt:  now,
+	})
+	if err != nil {
+		return err
+	}
+
+	return integration.SetSecret(runTaskTokenSecretName(request.TaskResultID), value)
+}
+
+// findPendingTaskResult returns the callback of a
+// run task request, or nil if it was already answered.
+func findPendingTaskResult(integration core.IntegrationContext, taskResultID string) (*pendingTaskResult, error) {
+	secrets, err := integration.GetSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	name := runTaskTokenSecretName(taskResultID)
+	for _, secret := range secrets {
+		if secret.Name != name {
+			continue
+		}
+
+		pending := pendingTaskResult{}
+		if err := json.Unmarshal(secret.Value, &pending); err != nil {
+			return nil, fmt.Errorf("failed to decode run task result %s: %w", taskResultID, err)
+		}
+
+		return &pending, nil
+	}
+
+	return nil, nil
+}
+
+func runTaskEventPayload(request *RunTaskRequest) map[string]any {
+	return map[string]any{
+		"stage":         request.Stage,
+		"isSpeculative": request.IsSpeculative,
+		"taskResult": map[string]any{
+			"id":               request.TaskResultID,
+			"enforcementLevel": request.TaskResultEnforcementLevel,
+			"callbackUrl":      request.TaskResultCallbackURL,
+		},
+		"run": map[string]any{
+			"id":        request.RunID,
+			"url":       request.RunAppURL,
+			"message":   request.RunMessage,
+			"createdAt": request.RunCreatedAt,
+			"createdBy": request.RunCreatedBy,
+		},
+		"workspace": map[string]any{
+			"id":   request.WorkspaceID,
+			"name": request.WorkspaceName,
+			"url":  request.WorkspaceAppURL,
+		},
+		"organization": request.OrganizationName,
+		"vcs": map[string]any{
+			"repoUrl":        request.VCSRepoURL,
+			"branch":         request.VCSBranch,
+			"commitUrl":      request.VCSCommitURL,
+			"pullRequestUrl": request.VCSPullRequestURL,
+		},
+	}
+}
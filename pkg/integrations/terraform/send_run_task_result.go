@@ -0,0 +1,201 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const SendRunTaskResultPayloadType = "terraform.runTask.result"
+
+type SendRunTaskResult struct{}
+
+type SendRunTaskResultSpec struct {
+	TaskResultID string `json:"taskResultId" mapstructure:"taskResultId"`
+	Status       string `json:"status" mapstructure:"status"`
+	Message      string `json:"message" mapstructure:"message"`
+	URL          string `json:"url" mapstructure:"url"`
+}
+
+func (s *SendRunTaskResult) Name() string {
+	return "terraform.sendRunTaskResult"
+}
+
+func (s *SendRunTaskResult) Label() string {
+	return "Send Run Task Result"
+}
+
+func (s *SendRunTaskResult) Description() string {
+	return "Pass or fail a Terraform Cloud run task"
+}
+
+func (s *SendRunTaskResult) Documentation() string {
+	return `The Send Run Task Result component answers a run task request received by **On Run Task**, so the Terraform Cloud run goes on, or stops.
+
+## Use Cases
+
+- **Checks**: Pass the run when the checks of the workflow succeed, fail it otherwise
+- **Approvals**: Pass the run when an **Approval** is given, fail it when it is rejected
+
+## Configuration
+
+- **Task Result ID**: The task result to answer, like the taskResult.id of the **On Run Task** event
+- **Status**: Passed or Failed. A failed result stops the run when the task is mandatory.
+- **Message**: Message shown with the task result in the run
+- **Details URL**: Link shown with the task result, like the URL of the workflow run
+
+## Output
+
+The task result that was sent.
+
+## Notes
+
+Each request can only be answered once, within the 10 minutes Terraform Cloud waits for the result.`
+}
+
+func (s *SendRunTaskResult) Icon() string {
+	return "terraform"
+}
+
+func (s *SendRunTaskResult) Color() string {
+	return "purple"
+}
+
+func (s *SendRunTaskResult) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (s *SendRunTaskResult) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "taskResultId",
+			Label:       "Task Result ID",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "taskrs-2nH5dncYoXaMVQmJ",
+			Description: "ID of the task result, like the taskResult.id of On Run Task",
+		},
+		{
+			Name:        "status",
+			Label:       "Status",
+			Type:        configuration.FieldTypeSelect,
+			Required:    true,
+			Default:     TaskResultStatusPassed,
+			Description: "Result of the run task",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Passed", Value: TaskResultStatusPassed},
+						{Label: "Failed", Value: TaskResultStatusFailed},
+					},
+				},
+			},
+		},
+		{
+			Name:        "message",
+			Label:       "Message",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "Checked by SuperPlane",
+			Description: "Message shown with the task result",
+		},
+		{
+			Name:        "url",
+			Label:       "Details URL",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Link shown with the task result",
+		},
+	}
+}
+
+func (s *SendRunTaskResult) Setup(ctx core.SetupContext) error {
+	_, err := decodeSendRunTaskResultSpec(ctx.Configuration, false)
+	return err
+}
+
+func (s *SendRunTaskResult) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeSendRunTaskResultSpec(ctx.Configuration, true)
+	if err != nil {
+		return err
+	}
+
+	pending, err := findPendingTaskResult(ctx.Integration, spec.TaskResultID)
+	if err != nil {
+		return err
+	}
+
+	if pending == nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("no pending run task request for %s, it was already answered or expired", spec.TaskResultID))
+	}
+
+	err = SendTaskResult(ctx.HTTP, pending.CallbackURL, pending.AccessToken, TaskResult{
+		Status:  spec.Status,
+		Message: spec.Message,
+		URL:     spec.URL,
+	})
+	if err != nil {
+		return ctx.ExecutionState.Fail("error", fmt.Sprintf("failed to send result of %s: %v", spec.TaskResultID, err))
+	}
+
+	if err := ctx.Integration.Secrets().Delete(runTaskTokenSecretName(spec.TaskResultID)); err != nil {
+		return err
+	}
+
+	return ctx.ExecutionState.Emit(core.DefaultOutputChannel.Name, SendRunTaskResultPayloadType, []any{
+		map[string]any{
+			"taskResult": map[string]any{
+				"id":      spec.TaskResultID,
+				"status":  spec.Status,
+				"message": spec.Message,
+				"url":     spec.URL,
+			},
+		},
+	})
+}
+
+func (s *SendRunTaskResult) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (s *SendRunTaskResult) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (s *SendRunTaskResult) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+func (s *SendRunTaskResult) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (s *SendRunTaskResult) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func decodeSendRunTaskResultSpec(value any, validateTaskResult bool) (SendRunTaskResultSpec, error) {
+	spec := SendRunTaskResultSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return SendRunTaskResultSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Status = strings.TrimSpace(spec.Status)
+	if spec.Status != TaskResultStatusPassed && spec.Status != TaskResultStatusFailed {
+		return SendRunTaskResultSpec{}, fmt.Errorf("status must be %s or %s", TaskResultStatusPassed, TaskResultStatusFailed)
+	}
+
+	if validateTaskResult {
+		spec.TaskResultID = strings.TrimSpace(spec.TaskResultID)
+		if !strings.HasPrefix(spec.TaskResultID, "taskrs-") {
+			return SendRunTaskResultSpec{}, fmt.Errorf("invalid task result ID %q, expected taskrs-<id>", spec.TaskResultID)
+		}
+	}
+
+	spec.Message = strings.TrimSpace(spec.Message)
+	spec.URL = strings.TrimSpace(spec.URL)
+	return spec, nil
+}
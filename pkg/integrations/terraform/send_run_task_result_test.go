@@ -0,0 +1,79 @@
+package terraform
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__SendRunTaskResult__Setup(t *testing.T) {
+	err := (&SendRunTaskResult{}).Setup(core.SetupContext{
+		Configuration: map[string]any{"taskResultId": "taskrs-2nH5dncYoXaMVQmJ", "status": "running"},
+	})
+
+	require.ErrorContains(t, err, "status must be passed or failed")
+}
+
+func Test__SendRunTaskResult__Execute(t *testing.T) {
+	withPendingResult := func(t *testing.T) *contexts.IntegrationContext {
+		integrationCtx := testIntegration()
+		value, err := json.Marshal(pendingTaskResult{
+			CallbackURL: "https://app.terraform.io/api/v2/task-results/taskrs-2nH5dncYoXaMVQmJ/callback",
+			AccessToken: "run-task-token",
+			ReceivedAt:  time.Now(),
+		})
+		require.NoError(t, err)
+		require.NoError(t, integrationCtx.SetSecret(runTaskTokenSecretName("taskrs-2nH5dncYoXaMVQmJ"), value))
+		return integrationCtx
+	}
+
+	t.Run("failed result -> sent to the callback with the run task token", func(t *testing.T) {
+		integrationCtx := withPendingResult(t)
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, ``)}}
+		executionState := &contexts.ExecutionStateContext{}
+
+		err := (&SendRunTaskResult{}).Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"taskResultId": "taskrs-2nH5dncYoXaMVQmJ", "status": "failed", "message": "Change window is closed"},
+			HTTP:           httpContext,
+			Integration:    integrationCtx,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		request := httpContext.Requests[0]
+		assert.Equal(t, http.MethodPatch, request.Method)
+		assert.Equal(t, "/api/v2/task-results/taskrs-2nH5dncYoXaMVQmJ/callback", request.URL.Path)
+		assert.Equal(t, "Bearer run-task-token", request.Header.Get("Authorization"))
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data":{"type":"task-results","attributes":{"status":"failed","message":"Change window is closed"}}}`, string(body))
+
+		assert.Equal(t, SendRunTaskResultPayloadType, executionState.Type)
+		assert.NotContains(t, integrationCtx.CurrentSecrets, runTaskTokenSecretName("taskrs-2nH5dncYoXaMVQmJ"))
+	})
+
+	t.Run("already answered -> fails", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+
+		err := (&SendRunTaskResult{}).Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"taskResultId": "taskrs-2nH5dncYoXaMVQmJ", "status": "passed"},
+			HTTP:           &contexts.HTTPContext{},
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, executionState.Finished)
+		assert.False(t, executionState.Passed)
+		assert.Contains(t, executionState.FailureMessage, "already answered or expired")
+	})
+}
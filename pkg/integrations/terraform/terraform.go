@@ -0,0 +1,201 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const (
+	ResourceTypeOrganization = "organization"
+	ResourceTypeWorkspace    = "workspace"
+)
+
+func init() {
+	registry.RegisterIntegrationWithWebhookHandler("terraform", &Terraform{}, &TerraformWebhookHandler{})
+}
+
+type Terraform struct{}
+
+type Configuration struct {
+	Address  string `json:"address" mapstructure:"address"`
+	APIToken string `json:"apiToken" mapstructure:"apiToken"`
+}
+
+type Metadata struct {
+	Organizations []string `json:"organizations" mapstructure:"organizations"`
+}
+
+func (t *Terraform) Name() string {
+	return "terraform"
+}
+
+func (t *Terraform) Label() string {
+	return "Terraform Cloud"
+}
+
+func (t *Terraform) Icon() string {
+	return "terraform"
+}
+
+func (t *Terraform) Description() string {
+	return "Plan, gate and apply Terraform Cloud runs"
+}
+
+func (t *Terraform) Instructions() string {
+	return `
+1. **Address:** Leave empty for HCP Terraform (` + "`https://app.terraform.io`" + `). For Terraform Enterprise, use the URL of your instance.
+2. **API Token:** Create a team token in **Organization Settings → Teams → Team API Token**, or a user token in **Account Settings → Tokens**.
+   - The team needs to **apply** runs on the workspaces SuperPlane manages.
+   - To create run notifications for triggers, it also needs to **manage workspace notifications** (Admin on the workspaces).
+   - For **On Run Task**, it also needs to **manage run tasks** of the organization, and to attach them to the workspaces.
+   - Organization tokens can't create runs, so they are not supported.`
+}
+
+func (t *Terraform) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "address",
+			Label:       "Address",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: DefaultAddress,
+			Description: "Terraform Enterprise URL. Leave empty for HCP Terraform.",
+		},
+		{
+			Name:        "apiToken",
+			Label:       "API Token",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "Terraform Cloud team or user API token",
+		},
+	}
+}
+
+func (t *Terraform) Actions() []core.Action {
+	return []core.Action{
+		&CreateRun{},
+		&WaitForPlan{},
+		&GetPlan{},
+		&ApplyRun{},
+		&DiscardRun{},
+		&SendRunTaskResult{},
+	}
+}
+
+func (t *Terraform) Triggers() []core.Trigger {
+	return []core.Trigger{
+		&OnRunNeedsAttention{},
+		&OnRunCompleted{},
+		&OnRunTask{},
+	}
+}
+
+func (t *Terraform) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (t *Terraform) Sync(ctx core.SyncContext) error {
+	config := Configuration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if strings.TrimSpace(config.APIToken) == "" {
+		return fmt.Errorf("apiToken is required")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	organizations, err := client.ListOrganizations()
+	if err != nil {
+		return fmt.Errorf("failed to verify Terraform Cloud credentials: %w", err)
+	}
+
+	if len(organizations) == 0 {
+		return fmt.Errorf("the API token has no access to any organization")
+	}
+
+	names := make([]string, 0, len(organizations))
+	for _, organization := range organizations {
+		names = append(names, organization.Name)
+	}
+
+	ctx.Integration.SetMetadata(Metadata{Organizations: names})
+	ctx.Integration.Ready()
+	return nil
+}
+
+func (t *Terraform) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (t *Terraform) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	if resourceType != ResourceTypeOrganization && resourceType != ResourceTypeWorkspace {
+		return []core.IntegrationResource{}, nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	if resourceType == ResourceTypeOrganization {
+		organizations, err := client.ListOrganizations()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organizations: %w", err)
+		}
+
+		resources := make([]core.IntegrationResource, 0, len(organizations))
+		for _, organization := range organizations {
+			resources = append(resources, core.IntegrationResource{
+				Type: ResourceTypeOrganization,
+				Name: organization.Name,
+				ID:   organization.Name,
+			})
+		}
+
+		return resources, nil
+	}
+
+	//
+	// Workspaces are listed per organization, so the list
+	// stays empty until an organization is selected.
+	//
+	organization := strings.TrimSpace(ctx.Parameters["organization"])
+	if organization == "" {
+		return []core.IntegrationResource{}, nil
+	}
+
+	workspaces, err := client.ListWorkspaces(organization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	resources := make([]core.IntegrationResource, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		resources = append(resources, core.IntegrationResource{
+			Type: ResourceTypeWorkspace,
+			Name: workspace.Name,
+			ID:   workspace.ID,
+		})
+	}
+
+	return resources, nil
+}
+
+func (t *Terraform) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (t *Terraform) HandleHook(ctx core.IntegrationHookContext) error {
+	return nil
+}
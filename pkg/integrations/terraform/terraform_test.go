@@ -0,0 +1,149 @@
+package terraform
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Terraform__Sync(t *testing.T) {
+	integration := &Terraform{}
+
+	t.Run("valid token -> ready", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":[{"id":"acme","type":"organizations","attributes":{"name":"acme"}}],"meta":{"pagination":{"next-page":null}}}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://app.terraform.io/api/v2/organizations", httpContext.Requests[0].URL.Scheme+"://"+httpContext.Requests[0].URL.Host+httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "Bearer token-123", httpContext.Requests[0].Header.Get("Authorization"))
+		assert.Equal(t, Metadata{Organizations: []string{"acme"}}, integrationCtx.Metadata)
+	})
+
+	t.Run("custom address -> used for requests", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":[{"id":"acme","type":"organizations","attributes":{"name":"acme"}}]}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		integrationCtx.Configuration["address"] = "https://tfe.example.com/"
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "tfe.example.com", httpContext.Requests[0].URL.Host)
+	})
+
+	t.Run("unauthorized -> error with API message", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusUnauthorized, `{"errors":[{"status":"401","title":"unauthorized"}]}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "request failed with 401: unauthorized")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+	})
+
+	t.Run("no organizations -> error", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":[]}`),
+			},
+		}
+
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "no access to any organization")
+	})
+}
+
+func Test__Terraform__ListResources(t *testing.T) {
+	integration := &Terraform{}
+
+	t.Run("workspaces of an organization", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":[{"id":"ws-1","type":"workspaces","attributes":{"name":"networking"}}],"meta":{"pagination":{"next-page":2}}}`),
+				jsonResponse(http.StatusOK, `{"data":[{"id":"ws-2","type":"workspaces","attributes":{"name":"compute"}}],"meta":{"pagination":{"next-page":null}}}`),
+			},
+		}
+
+		resources, err := integration.ListResources(ResourceTypeWorkspace, core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Parameters:  map[string]string{"organization": "acme"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []core.IntegrationResource{
+			{Type: ResourceTypeWorkspace, Name: "networking", ID: "ws-1"},
+			{Type: ResourceTypeWorkspace, Name: "compute", ID: "ws-2"},
+		}, resources)
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/api/v2/organizations/acme/workspaces", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "2", httpContext.Requests[1].URL.Query().Get("page[number]"))
+	})
+
+	t.Run("workspaces without organization -> empty", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{}
+		resources, err := integration.ListResources(ResourceTypeWorkspace, core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+			Parameters:  map[string]string{},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, resources)
+		assert.Empty(t, httpContext.Requests)
+	})
+
+	t.Run("organizations", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":[{"id":"acme","type":"organizations","attributes":{"name":"acme"}}]}`),
+			},
+		}
+
+		resources, err := integration.ListResources(ResourceTypeOrganization, core.ListResourcesContext{
+			HTTP:        httpContext,
+			Integration: testIntegration(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []core.IntegrationResource{{Type: ResourceTypeOrganization, Name: "acme", ID: "acme"}}, resources)
+	})
+}
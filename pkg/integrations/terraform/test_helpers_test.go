@@ -0,0 +1,51 @@
+package terraform
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func testIntegration() *contexts.IntegrationContext {
+	return &contexts.IntegrationContext{
+		Configuration: map[string]any{
+			"apiToken": "token-123",
+		},
+	}
+}
+
+func runResponse(status string, confirmable, discardable bool) string {
+	return `{
+		"data": {
+			"id": "run-CZcmD7eagjhyX0vN",
+			"type": "runs",
+			"attributes": {
+				"status": "` + status + `",
+				"message": "Queued by SuperPlane",
+				"has-changes": true,
+				"actions": {"is-confirmable": ` + boolString(confirmable) + `, "is-discardable": ` + boolString(discardable) + `}
+			},
+			"relationships": {
+				"workspace": {"data": {"id": "ws-6jrRyVDv1J8zQMB5", "type": "workspaces"}},
+				"plan": {"data": {"id": "plan-V6fJy1rgHbwWDe7H", "type": "plans"}}
+			}
+		}
+	}`
+}
+
+func boolString(value bool) string {
+	if value {
+		return "true"
+	}
+
+	return "false"
+}
@@ -0,0 +1,315 @@
+package terraform
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	WaitForPlanPayloadType   = "terraform.run.planned"
+	WaitForPlanPollAction    = "poll"
+	WaitForPlanPollInterval  = 15 * time.Second
+	WaitForPlanMaxPollErrors = 5
+
+	DefaultPlanTimeoutMinutes = 30
+
+	PlannedOutputChannel   = "planned"
+	NoChangesOutputChannel = "noChanges"
+
+	PlanWaitStatusWaiting   = "waiting"
+	PlanWaitStatusPlanned   = "planned"
+	PlanWaitStatusNoChanges = "noChanges"
+	PlanWaitStatusFailed    = "failed"
+	PlanWaitStatusTimedOut  = "timedOut"
+)
+
+type WaitForPlan struct{}
+
+type WaitForPlanSpec struct {
+	RunID          string `json:"runId" mapstructure:"runId"`
+	TimeoutMinutes int    `json:"timeoutMinutes" mapstructure:"timeoutMinutes"`
+}
+
+type WaitForPlanMetadata struct {
+	RunID          string `json:"runId" mapstructure:"runId"`
+	StartedAt      string `json:"startedAt" mapstructure:"startedAt"`
+	Status         string `json:"status" mapstructure:"status"`
+	Message        string `json:"message,omitempty" mapstructure:"message"`
+	PollErrorCount int    `json:"pollErrorCount,omitempty" mapstructure:"pollErrorCount"`
+}
+
+// PlanStatus is where a run stands, for someone
+// waiting for its plan to finish.
+type PlanStatus struct {
+	Status  string
+	Message string
+}
+
+func (w *WaitForPlan) Name() string {
+	return "terraform.waitForPlan"
+}
+
+func (w *WaitForPlan) Label() string {
+	return "Wait for Plan"
+}
+
+func (w *WaitForPlan) Description() string {
+	return "Wait for the plan of a Terraform Cloud run to finish"
+}
+
+func (w *WaitForPlan) Documentation() string {
+	return `The Wait for Plan component waits until the plan of a Terraform Cloud run is done, and outputs the resource change summary of the plan.
+
+## Use Cases
+
+- **Gated applies**: Route planned runs to an **Approval**, then to **Apply Run** or **Discard Run**
+- **No-op detection**: Skip the approval when the plan has no changes
+- **Failure alerts**: Notify the owning team when a plan errors
+
+## Configuration
+
+- **Run ID**: The run to wait for, like the ID output of **Create Run**
+- **Timeout (minutes)**: How long to wait before giving up. Defaults to 30 minutes.
+
+Runs wait in the queue of their workspace, so the timeout includes the time spent queued. Policy overrides and run tasks are waited for.
+
+## Output Channels
+
+- **Planned**: The plan has changes, and the run waits for confirmation, or applies automatically
+- **No Changes**: The plan has no changes, or the run is plan only
+- **Failed**: The run errored, was canceled or discarded, a policy check failed, or the plan did not finish in time`
+}
+
+func (w *WaitForPlan) Icon() string {
+	return "terraform"
+}
+
+func (w *WaitForPlan) Color() string {
+	return "purple"
+}
+
+func (w *WaitForPlan) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{
+		{Name: PlannedOutputChannel, Label: "Planned"},
+		{Name: NoChangesOutputChannel, Label: "No Changes"},
+		{Name: FailedOutputChannel, Label: "Failed"},
+	}
+}
+
+func (w *WaitForPlan) Configuration() []configuration.Field {
+	return []configuration.Field{
+		runIDField(),
+		{
+			Name:        "timeoutMinutes",
+			Label:       "Timeout (minutes)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Default:     DefaultPlanTimeoutMinutes,
+			Description: "How long to wait for the plan",
+			TypeOptions: &configuration.TypeOptions{
+				Number: &configuration.NumberTypeOptions{
+					Min: intPtr(1),
+					Max: intPtr(1440),
+				},
+			},
+		},
+	}
+}
+
+func (w *WaitForPlan) Setup(ctx core.SetupContext) error {
+	_, err := decodeWaitForPlanSpec(ctx.Configuration, false)
+	return err
+}
+
+func (w *WaitForPlan) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeWaitForPlanSpec(ctx.Configuration, true)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	metadata := WaitForPlanMetadata{
+		RunID:     spec.RunID,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+		Status:    PlanWaitStatusWaiting,
+	}
+
+	return w.check(client, ctx.Metadata, ctx.ExecutionState, ctx.Requests, metadata, spec)
+}
+
+func (w *WaitForPlan) Hooks() []core.Hook {
+	return []core.Hook{{Name: WaitForPlanPollAction, Type: core.HookTypeInternal}}
+}
+
+func (w *WaitForPlan) HandleHook(ctx core.ActionHookContext) error {
+	switch ctx.Name {
+	case WaitForPlanPollAction:
+		return w.poll(ctx)
+	default:
+		return fmt.Errorf("unknown hook: %s", ctx.Name)
+	}
+}
+
+func (w *WaitForPlan) poll(ctx core.ActionHookContext) error {
+	if ctx.ExecutionState.IsFinished() {
+		return nil
+	}
+
+	spec, err := decodeWaitForPlanSpec(ctx.Configuration, false)
+	if err != nil {
+		return err
+	}
+
+	metadata := WaitForPlanMetadata{}
+	if err := mapstructure.Decode(ctx.Metadata.Get(), &metadata); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	return w.check(client, ctx.Metadata, ctx.ExecutionState, ctx.Requests, metadata, spec)
+}
+
+func (w *WaitForPlan) check(
+	client *Client,
+	metadataCtx core.MetadataWriter,
+	executionState core.ExecutionStateContext,
+	requests core.RequestContext,
+	metadata WaitForPlanMetadata,
+	spec WaitForPlanSpec,
+) error {
+	run, err := client.GetRun(metadata.RunID)
+	if err != nil {
+		metadata.PollErrorCount++
+		metadata.Message = err.Error()
+		if setErr := metadataCtx.Set(metadata); setErr != nil {
+			return setErr
+		}
+
+		if IsNotFound(err) || metadata.PollErrorCount >= WaitForPlanMaxPollErrors {
+			return executionState.Fail("error", fmt.Sprintf("failed to get run %s: %v", metadata.RunID, err))
+		}
+
+		return requests.ScheduleActionCall(WaitForPlanPollAction, map[string]any{}, WaitForPlanPollInterval)
+	}
+
+	status := RunPlanStatus(run)
+	metadata.PollErrorCount = 0
+	metadata.Status = status.Status
+	metadata.Message = status.Message
+
+	startedAt, err := time.Parse(time.RFC3339, metadata.StartedAt)
+	if err == nil && status.Status == PlanWaitStatusWaiting && time.Since(startedAt) >= time.Duration(spec.TimeoutMinutes)*time.Minute {
+		metadata.Status = PlanWaitStatusTimedOut
+		metadata.Message = fmt.Sprintf("plan did not finish in %d minutes: %s", spec.TimeoutMinutes, status.Message)
+	}
+
+	if err := metadataCtx.Set(metadata); err != nil {
+		return err
+	}
+
+	if metadata.Status == PlanWaitStatusWaiting {
+		return requests.ScheduleActionCall(WaitForPlanPollAction, map[string]any{}, WaitForPlanPollInterval)
+	}
+
+	//
+	// The workspace is only needed for the link to the run,
+	// and the plan might not exist for runs that errored early,
+	// so failing to get them doesn't fail the execution.
+	//
+	workspace, _ := client.GetWorkspace(run.WorkspaceID)
+	payload := runPayload(client.Address, run, workspace)
+	if run.PlanID != "" {
+		if plan, err := client.GetPlan(run.PlanID); err == nil {
+			payload["plan"] = planPayload(plan)
+		}
+	}
+
+	payload["wait"] = map[string]any{
+		"status":    metadata.Status,
+		"message":   metadata.Message,
+		"startedAt": metadata.StartedAt,
+		"endedAt":   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	channel := FailedOutputChannel
+	switch metadata.Status {
+	case PlanWaitStatusPlanned:
+		channel = PlannedOutputChannel
+	case PlanWaitStatusNoChanges:
+		channel = NoChangesOutputChannel
+	}
+
+	return executionState.Emit(channel, WaitForPlanPayloadType, []any{payload})
+}
+
+func (w *WaitForPlan) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (w *WaitForPlan) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return 0, nil, nil
+}
+
+func (w *WaitForPlan) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
+
+// decodeWaitForPlanSpec only validates the run ID on execution,
+// since it usually comes from an expression.
+func decodeWaitForPlanSpec(value any, validateRun bool) (WaitForPlanSpec, error) {
+	spec := WaitForPlanSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return WaitForPlanSpec{}, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	if validateRun {
+		runID, err := validateRunID(spec.RunID)
+		if err != nil {
+			return WaitForPlanSpec{}, err
+		}
+
+		spec.RunID = runID
+	}
+
+	if spec.TimeoutMinutes <= 0 {
+		spec.TimeoutMinutes = DefaultPlanTimeoutMinutes
+	}
+
+	return spec, nil
+}
+
+// RunPlanStatus tells if the plan of a run is done, and how it ended.
+func RunPlanStatus(run *Run) PlanStatus {
+	switch {
+	case runFailed(run.Status):
+		return PlanStatus{Status: PlanWaitStatusFailed, Message: fmt.Sprintf("run is %s", run.Status)}
+
+	case run.Status == RunStatusPlannedAndFinished:
+		if run.PlanOnly && run.HasChanges {
+			return PlanStatus{Status: PlanWaitStatusNoChanges, Message: "plan only run finished"}
+		}
+
+		return PlanStatus{Status: PlanWaitStatusNoChanges, Message: "plan has no changes"}
+
+	case run.Actions.IsConfirmable:
+		return PlanStatus{Status: PlanWaitStatusPlanned, Message: "plan is waiting for confirmation"}
+
+	case runApplying(run.Status) || run.Status == RunStatusApplied:
+		return PlanStatus{Status: PlanWaitStatusPlanned, Message: fmt.Sprintf("run is %s", run.Status)}
+
+	default:
+		return PlanStatus{Status: PlanWaitStatusWaiting, Message: fmt.Sprintf("run is %s", run.Status)}
+	}
+}
@@ -0,0 +1,121 @@
+package terraform
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RunPlanStatus(t *testing.T) {
+	testCases := []struct {
+		name   string
+		run    Run
+		status string
+	}{
+		{"planning", Run{Status: RunStatusPlanning}, PlanWaitStatusWaiting},
+		{"policy override", Run{Status: RunStatusPolicyOverride}, PlanWaitStatusWaiting},
+		{"planned", Run{Status: RunStatusPlanned, Actions: RunActions{IsConfirmable: true}}, PlanWaitStatusPlanned},
+		{"cost estimated", Run{Status: RunStatusCostEstimated, Actions: RunActions{IsConfirmable: true}}, PlanWaitStatusPlanned},
+		{"auto applied", Run{Status: RunStatusApplying}, PlanWaitStatusPlanned},
+		{"no changes", Run{Status: RunStatusPlannedAndFinished}, PlanWaitStatusNoChanges},
+		{"plan only", Run{Status: RunStatusPlannedAndFinished, PlanOnly: true, HasChanges: true}, PlanWaitStatusNoChanges},
+		{"errored", Run{Status: RunStatusErrored}, PlanWaitStatusFailed},
+		{"discarded", Run{Status: RunStatusDiscarded}, PlanWaitStatusFailed},
+		{"policy soft failed", Run{Status: RunStatusPolicySoftFailed}, PlanWaitStatusFailed},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.status, RunPlanStatus(&testCase.run).Status)
+		})
+	}
+}
+
+func Test__WaitForPlan__Execute(t *testing.T) {
+	component := &WaitForPlan{}
+
+	t.Run("still planning -> schedules poll", func(t *testing.T) {
+		requests := &contexts.RequestContext{}
+		metadata := &contexts.MetadataContext{}
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"runId": "run-CZcmD7eagjhyX0vN"},
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, runResponse(RunStatusPlanning, false, false))}},
+			Integration:    testIntegration(),
+			Metadata:       metadata,
+			Requests:       requests,
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, executionState.Finished)
+		assert.Equal(t, WaitForPlanPollAction, requests.Action)
+		assert.Equal(t, WaitForPlanPollInterval, requests.Duration)
+		assert.Equal(t, PlanWaitStatusWaiting, metadata.Metadata.(WaitForPlanMetadata).Status)
+	})
+
+	t.Run("waiting for confirmation -> emits plan on planned", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		err := component.Execute(core.ExecutionContext{
+			Configuration: map[string]any{"runId": "run-CZcmD7eagjhyX0vN"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, runResponse(RunStatusPlanned, true, true)),
+				jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production","auto-apply":false,"terraform-version":"1.9.5"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`),
+				jsonResponse(http.StatusOK, `{"data":{"id":"plan-V6fJy1rgHbwWDe7H","type":"plans","attributes":{"status":"finished","has-changes":true,"resource-additions":1,"resource-changes":2,"resource-destructions":0}}}`),
+			}},
+			Integration:    testIntegration(),
+			Metadata:       &contexts.MetadataContext{},
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, PlannedOutputChannel, executionState.Channel)
+		data := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		plan := data["plan"].(map[string]any)
+		assert.Equal(t, "Plan: 1 to add, 2 to change, 0 to destroy.", plan["text"])
+		assert.Equal(t, PlanWaitStatusPlanned, data["wait"].(map[string]any)["status"])
+	})
+
+	t.Run("timed out -> emits on failed", func(t *testing.T) {
+		executionState := &contexts.ExecutionStateContext{}
+		metadata := &contexts.MetadataContext{Metadata: WaitForPlanMetadata{
+			RunID:     "run-CZcmD7eagjhyX0vN",
+			StartedAt: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+			Status:    PlanWaitStatusWaiting,
+		}}
+
+		err := component.HandleHook(core.ActionHookContext{
+			Name:          WaitForPlanPollAction,
+			Configuration: map[string]any{"runId": "run-CZcmD7eagjhyX0vN", "timeoutMinutes": 30},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, runResponse(RunStatusPlanning, false, false)),
+				jsonResponse(http.StatusOK, `{"data":{"id":"ws-6jrRyVDv1J8zQMB5","type":"workspaces","attributes":{"name":"networking-production","auto-apply":false,"terraform-version":"1.9.5"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`),
+				jsonResponse(http.StatusNotFound, `{"errors":[{"title":"not found"}]}`),
+			}},
+			Integration:    testIntegration(),
+			Metadata:       metadata,
+			Requests:       &contexts.RequestContext{},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, FailedOutputChannel, executionState.Channel)
+		assert.Equal(t, PlanWaitStatusTimedOut, metadata.Metadata.(WaitForPlanMetadata).Status)
+	})
+
+	t.Run("invalid run ID -> error", func(t *testing.T) {
+		err := component.Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"runId": "ws-123"},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "invalid run ID")
+	})
+}
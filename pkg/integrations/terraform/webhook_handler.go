@@ -0,0 +1,277 @@
+package terraform
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"slices"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const (
+	NotificationTriggerNeedsAttention = "run:needs_attention"
+	NotificationTriggerCompleted      = "run:completed"
+	NotificationTriggerErrored        = "run:errored"
+	NotificationTriggerVerification   = "verification"
+)
+
+type WebhookConfiguration struct {
+	WorkspaceID string   `json:"workspaceId" mapstructure:"workspaceId"`
+	Triggers    []string `json:"triggers" mapstructure:"triggers"`
+
+	//
+	// Set for run task triggers, which get a run task
+	// of their own, instead of the notification configuration.
+	//
+	RunTask *RunTaskWebhookConfiguration `json:"runTask,omitempty" mapstructure:"runTask"`
+}
+
+type RunTaskWebhookConfiguration struct {
+	Stage            string `json:"stage" mapstructure:"stage"`
+	EnforcementLevel string `json:"enforcementLevel" mapstructure:"enforcementLevel"`
+}
+
+type WebhookMetadata struct {
+	NotificationConfigurationID string `json:"notificationConfigurationId,omitempty" mapstructure:"notificationConfigurationId"`
+	RunTaskID                   string `json:"runTaskId,omitempty" mapstructure:"runTaskId"`
+	WorkspaceRunTaskID          string `json:"workspaceRunTaskId,omitempty" mapstructure:"workspaceRunTaskId"`
+	Name                        string `json:"name" mapstructure:"name"`
+}
+
+// TerraformWebhookHandler manages one notification configuration
+// per workspace, shared by every notification trigger of the workspace,
+// and one run task per stage of the workspace for run task triggers.
+type TerraformWebhookHandler struct{}
+
+func (h *TerraformWebhookHandler) Setup(ctx core.WebhookHandlerContext) (any, error) {
+	configuration := WebhookConfiguration{}
+	if err := mapstructure.Decode(ctx.Webhook.GetConfiguration(), &configuration); err != nil {
+		return nil, fmt.Errorf("error decoding configuration: %v", err)
+	}
+
+	if configuration.WorkspaceID == "" {
+		return nil, fmt.Errorf("workspaceId is required")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := ctx.Webhook.GetSecret()
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhook secret: %v", err)
+	}
+
+	if configuration.RunTask != nil {
+		return setupRunTask(client, ctx.Webhook, configuration, string(secret))
+	}
+
+	name := webhookResourceName(ctx.Webhook.GetID())
+	existing, err := client.ListNotificationConfigurations(configuration.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing notification configurations: %v", err)
+	}
+
+	for _, notificationConfiguration := range existing {
+		if notificationConfiguration.Name != name {
+			continue
+		}
+
+		updated, err := client.UpdateNotificationConfiguration(notificationConfiguration.ID, name, ctx.Webhook.GetURL(), string(secret), configuration.Triggers)
+		if err != nil {
+			return nil, fmt.Errorf("error updating notification configuration: %v", err)
+		}
+
+		return WebhookMetadata{NotificationConfigurationID: updated.ID, Name: updated.Name}, nil
+	}
+
+	created, err := client.CreateNotificationConfiguration(configuration.WorkspaceID, name, ctx.Webhook.GetURL(), string(secret), configuration.Triggers)
+	if err != nil {
+		return nil, fmt.Errorf("error creating notification configuration: %v", err)
+	}
+
+	return WebhookMetadata{NotificationConfigurationID: created.ID, Name: created.Name}, nil
+}
+
+// setupRunTask creates or updates the organization run task of the webhook,
+// and attaches it to the workspace. Terraform Cloud signs the requests
+// of the run task with the webhook secret.
+func setupRunTask(client *Client, webhook core.WebhookContext, configuration WebhookConfiguration, secret string) (any, error) {
+	workspace, err := client.GetWorkspace(configuration.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting workspace: %v", err)
+	}
+
+	name := webhookResourceName(webhook.GetID())
+	task, err := findRunTask(client, workspace.Organization, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if task == nil {
+		task, err = client.CreateRunTask(workspace.Organization, name, webhook.GetURL(), secret)
+		if err != nil {
+			return nil, fmt.Errorf("error creating run task: %v", err)
+		}
+	} else {
+		task, err = client.UpdateRunTask(task.ID, name, webhook.GetURL(), secret)
+		if err != nil {
+			return nil, fmt.Errorf("error updating run task: %v", err)
+		}
+	}
+
+	attached, err := client.ListWorkspaceRunTasks(workspace.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing workspace run tasks: %v", err)
+	}
+
+	stage := configuration.RunTask.Stage
+	enforcementLevel := configuration.RunTask.EnforcementLevel
+	for _, workspaceTask := range attached {
+		if workspaceTask.TaskID != task.ID {
+			continue
+		}
+
+		updated, err := client.UpdateWorkspaceRunTask(workspace.ID, workspaceTask.ID, task.ID, stage, enforcementLevel)
+		if err != nil {
+			return nil, fmt.Errorf("error updating workspace run task: %v", err)
+		}
+
+		return WebhookMetadata{RunTaskID: task.ID, WorkspaceRunTaskID: updated.ID, Name: task.Name}, nil
+	}
+
+	created, err := client.CreateWorkspaceRunTask(workspace.ID, task.ID, stage, enforcementLevel)
+	if err != nil {
+		return nil, fmt.Errorf("error attaching run task to workspace: %v", err)
+	}
+
+	return WebhookMetadata{RunTaskID: task.ID, WorkspaceRunTaskID: created.ID, Name: task.Name}, nil
+}
+
+func findRunTask(client *Client, organization, name string) (*RunTask, error) {
+	tasks, err := client.ListRunTasks(organization)
+	if err != nil {
+		return nil, fmt.Errorf("error listing run tasks: %v", err)
+	}
+
+	for _, task := range tasks {
+		if task.Name == name {
+			return &task, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// webhookResourceName is derived from the webhook ID, so setting up the
+// webhook again updates the same notification configuration or run task.
+func webhookResourceName(webhookID string) string {
+	hash := sha256.Sum256([]byte(webhookID))
+	return fmt.Sprintf("superplane-%x", hash[:8])
+}
+
+func (h *TerraformWebhookHandler) CompareConfig(a, b any) (bool, error) {
+	configA := WebhookConfiguration{}
+	if err := mapstructure.Decode(a, &configA); err != nil {
+		return false, err
+	}
+
+	configB := WebhookConfiguration{}
+	if err := mapstructure.Decode(b, &configB); err != nil {
+		return false, err
+	}
+
+	if configA.WorkspaceID != configB.WorkspaceID {
+		return false, nil
+	}
+
+	//
+	// Run task triggers share a run task only with
+	// triggers on the same stage and enforcement level.
+	//
+	if configA.RunTask == nil || configB.RunTask == nil {
+		return configA.RunTask == nil && configB.RunTask == nil, nil
+	}
+
+	return *configA.RunTask == *configB.RunTask, nil
+}
+
+func (h *TerraformWebhookHandler) Merge(current, requested any) (any, bool, error) {
+	currentConfig := WebhookConfiguration{}
+	if err := mapstructure.Decode(current, &currentConfig); err != nil {
+		return nil, false, err
+	}
+
+	requestedConfig := WebhookConfiguration{}
+	if err := mapstructure.Decode(requested, &requestedConfig); err != nil {
+		return nil, false, err
+	}
+
+	merged := WebhookConfiguration{
+		WorkspaceID: currentConfig.WorkspaceID,
+		Triggers:    slices.Clone(currentConfig.Triggers),
+		RunTask:     currentConfig.RunTask,
+	}
+
+	changed := false
+	for _, trigger := range requestedConfig.Triggers {
+		if !slices.Contains(merged.Triggers, trigger) {
+			merged.Triggers = append(merged.Triggers, trigger)
+			changed = true
+		}
+	}
+
+	return merged, changed, nil
+}
+
+func (h *TerraformWebhookHandler) Cleanup(ctx core.WebhookHandlerContext) error {
+	metadata := WebhookMetadata{}
+	if err := mapstructure.Decode(ctx.Webhook.GetMetadata(), &metadata); err != nil {
+		return fmt.Errorf("error decoding webhook metadata: %v", err)
+	}
+
+	if metadata.NotificationConfigurationID == "" && metadata.RunTaskID == "" {
+		return nil
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	if metadata.NotificationConfigurationID != "" {
+		err = client.DeleteNotificationConfiguration(metadata.NotificationConfigurationID)
+		if err != nil && !IsNotFound(err) {
+			return err
+		}
+	}
+
+	if metadata.RunTaskID == "" {
+		return nil
+	}
+
+	//
+	// The run task can only be deleted
+	// once no workspace uses it anymore.
+	//
+	if metadata.WorkspaceRunTaskID != "" {
+		configuration := WebhookConfiguration{}
+		if err := mapstructure.Decode(ctx.Webhook.GetConfiguration(), &configuration); err != nil {
+			return fmt.Errorf("error decoding configuration: %v", err)
+		}
+
+		err = client.DeleteWorkspaceRunTask(configuration.WorkspaceID, metadata.WorkspaceRunTaskID)
+		if err != nil && !IsNotFound(err) {
+			return err
+		}
+	}
+
+	err = client.DeleteRunTask(metadata.RunTaskID)
+	if err != nil && !IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package terraform
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__TerraformWebhookHandler__CompareConfig(t *testing.T) {
+	h := &TerraformWebhookHandler{}
+
+	same, err := h.CompareConfig(
+		WebhookConfiguration{WorkspaceID: "ws-1", Triggers: []string{NotificationTriggerNeedsAttention}},
+		WebhookConfiguration{WorkspaceID: "ws-1", Triggers: []string{NotificationTriggerCompleted}},
+	)
+
+	require.NoError(t, err)
+	assert.True(t, same)
+
+	same, err = h.CompareConfig(WebhookConfiguration{WorkspaceID: "ws-1"}, WebhookConfiguration{WorkspaceID: "ws-2"})
+	require.NoError(t, err)
+	assert.False(t, same)
+
+	postPlan := &RunTaskWebhookConfiguration{Stage: RunTaskStagePostPlan, EnforcementLevel: RunTaskEnforcementMandatory}
+	same, err = h.CompareConfig(WebhookConfiguration{WorkspaceID: "ws-1"}, WebhookConfiguration{WorkspaceID: "ws-1", RunTask: postPlan})
+	require.NoError(t, err)
+	assert.False(t, same)
+
+	same, err = h.CompareConfig(
+		WebhookConfiguration{WorkspaceID: "ws-1", RunTask: postPlan},
+		WebhookConfiguration{WorkspaceID: "ws-1", RunTask: &RunTaskWebhookConfiguration{Stage: RunTaskStagePostPlan, EnforcementLevel: RunTaskEnforcementMandatory}},
+	)
+	require.NoError(t, err)
+	assert.True(t, same)
+
+	same, err = h.CompareConfig(
+		WebhookConfiguration{WorkspaceID: "ws-1", RunTask: postPlan},
+		WebhookConfiguration{WorkspaceID: "ws-1", RunTask: &RunTaskWebhookConfiguration{Stage: RunTaskStagePreApply, EnforcementLevel: RunTaskEnforcementMandatory}},
+	)
+	require.NoError(t, err)
+	assert.False(t, same)
+}
+
+func Test__TerraformWebhookHandler__Merge(t *testing.T) {
+	h := &TerraformWebhookHandler{}
+
+	merged, changed, err := h.Merge(
+		WebhookConfiguration{WorkspaceID: "ws-1", Triggers: []string{NotificationTriggerNeedsAttention}},
+		WebhookConfiguration{WorkspaceID: "ws-1", Triggers: []string{NotificationTriggerCompleted, NotificationTriggerErrored}},
+	)
+
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, WebhookConfiguration{
+		WorkspaceID: "ws-1",
+		Triggers:    []string{NotificationTriggerNeedsAttention, NotificationTriggerCompleted, NotificationTriggerErrored},
+	}, merged)
+
+	_, changed, err = h.Merge(merged, WebhookConfiguration{WorkspaceID: "ws-1", Triggers: []string{NotificationTriggerCompleted}})
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func Test__TerraformWebhookHandler__Setup(t *testing.T) {
+	h := &TerraformWebhookHandler{}
+	webhook := &contexts.WebhookContext{
+		ID:            "0b7a0e6c-3c6c-4a55-9d0e-3c6f4c6e0a11",
+		URL:           "https://superplane.example.com/api/v1/webhooks/0b7a0e6c",
+		Secret:        []byte("secret-123"),
+		Configuration: WebhookConfiguration{WorkspaceID: "ws-1", Triggers: []string{NotificationTriggerNeedsAttention}},
+	}
+
+	t.Run("no notification yet -> creates it", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"data":[]}`),
+			jsonResponse(http.StatusCreated, `{"data":{"id":"nc-1","type":"notification-configurations","attributes":{"name":"superplane-x"}}}`),
+		}}
+
+		metadata, err := h.Setup(core.WebhookHandlerContext{HTTP: httpContext, Integration: testIntegration(), Webhook: webhook})
+		require.NoError(t, err)
+		assert.Equal(t, WebhookMetadata{NotificationConfigurationID: "nc-1", Name: "superplane-x"}, metadata)
+
+		require.Len(t, httpContext.Requests, 2)
+		request := httpContext.Requests[1]
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/api/v2/workspaces/ws-1/notification-configurations", request.URL.Path)
+
+		body, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+
+		var document struct {
+			Data struct {
+				Attributes map[string]any `json:"attributes"`
+			} `json:"data"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &document))
+		assert.Equal(t, "generic", document.Data.Attributes["destination-type"])
+		assert.Equal(t, "secret-123", document.Data.Attributes["token"])
+		assert.Equal(t, webhook.URL, document.Data.Attributes["url"])
+		assert.Equal(t, []any{NotificationTriggerNeedsAttention}, document.Data.Attributes["triggers"])
+	})
+
+	t.Run("notification exists -> updates it", func(t *testing.T) {
+		name := webhookResourceName(webhook.ID)
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"data":[{"id":"nc-1","type":"notification-configurations","attributes":{"name":"`+name+`"}}]}`),
+			jsonResponse(http.StatusOK, `{"data":{"id":"nc-1","type":"notification-configurations","attributes":{"name":"`+name+`"}}}`),
+		}}
+
+		metadata, err := h.Setup(core.WebhookHandlerContext{HTTP: httpContext, Integration: testIntegration(), Webhook: webhook})
+		require.NoError(t, err)
+		assert.Equal(t, WebhookMetadata{NotificationConfigurationID: "nc-1", Name: name}, metadata)
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, http.MethodPatch, httpContext.Requests[1].Method)
+		assert.Equal(t, "/api/v2/notification-configurations/nc-1", httpContext.Requests[1].URL.Path)
+	})
+}
+
+func Test__TerraformWebhookHandler__SetupRunTask(t *testing.T) {
+	h := &TerraformWebhookHandler{}
+	webhook := &contexts.WebhookContext{
+		ID:     "0b7a0e6c-3c6c-4a55-9d0e-3c6f4c6e0a11",
+		URL:    "https://superplane.example.com/api/v1/webhooks/0b7a0e6c",
+		Secret: []byte("secret-123"),
+		Configuration: WebhookConfiguration{
+			WorkspaceID: "ws-1",
+			RunTask:     &RunTaskWebhookConfiguration{Stage: RunTaskStagePostPlan, EnforcementLevel: RunTaskEnforcementMandatory},
+		},
+	}
+
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+		jsonResponse(http.StatusOK, `{"data":{"id":"ws-1","type":"workspaces","attributes":{"name":"networking-production"},"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`),
+		jsonResponse(http.StatusOK, `{"data":[]}`),
+		jsonResponse(http.StatusCreated, `{"data":{"id":"task-1","type":"tasks","attributes":{"name":"superplane-x"}}}`),
+		jsonResponse(http.StatusOK, `{"data":[]}`),
+		jsonResponse(http.StatusCreated, `{"data":{"id":"wstask-1","type":"workspace-tasks","attributes":{"enforcement-level":"mandatory","stages":["post_plan"]},"relationships":{"task":{"data":{"id":"task-1","type":"tasks"}}}}}`),
+	}}
+
+	metadata, err := h.Setup(core.WebhookHandlerContext{HTTP: httpContext, Integration: testIntegration(), Webhook: webhook})
+	require.NoError(t, err)
+	assert.Equal(t, WebhookMetadata{RunTaskID: "task-1", WorkspaceRunTaskID: "wstask-1", Name: "superplane-x"}, metadata)
+
+	require.Len(t, httpContext.Requests, 5)
+	createTask := httpContext.Requests[2]
+	assert.Equal(t, http.MethodPost, createTask.Method)
+	assert.Equal(t, "/api/v2/organizations/acme/tasks", createTask.URL.Path)
+
+	body, err := io.ReadAll(createTask.Body)
+	require.NoError(t, err)
+
+	var document struct {
+		Data struct {
+			Attributes map[string]any `json:"attributes"`
+		} `json:"data"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &document))
+	assert.Equal(t, "secret-123", document.Data.Attributes["hmac-key"])
+	assert.Equal(t, webhook.URL, document.Data.Attributes["url"])
+
+	attach := httpContext.Requests[4]
+	assert.Equal(t, http.MethodPost, attach.Method)
+	assert.Equal(t, "/api/v2/workspaces/ws-1/tasks", attach.URL.Path)
+	body, err = io.ReadAll(attach.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"type":"workspace-tasks","attributes":{"enforcement-level":"mandatory","stages":["post_plan"]},"relationships":{"task":{"data":{"id":"task-1","type":"tasks"}}}}}`, string(body))
+}
@@ -0,0 +1,332 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type Client struct {
+	Address   string
+	Namespace string
+	Token     string
+	http      core.HTTPContext
+}
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request failed with %d: %s", e.StatusCode, e.Body)
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func NewClient(httpClient core.HTTPContext, integration core.IntegrationContext) (*Client, error) {
+	if integration == nil {
+		return nil, fmt.Errorf("no integration context")
+	}
+
+	address, err := normalizeAddress(optionalConfig(integration, "address"))
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenFromIntegration(integration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Address:   address,
+		Namespace: strings.Trim(optionalConfig(integration, "namespace"), "/"),
+		Token:     token,
+		http:      httpClient,
+	}, nil
+}
+
+// newLoginClient creates a client without a token,
+// to exchange the configured credentials for one.
+func newLoginClient(httpClient core.HTTPContext, config Configuration) (*Client, error) {
+	address, err := normalizeAddress(config.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Address:   address,
+		Namespace: strings.Trim(strings.TrimSpace(config.Namespace), "/"),
+		http:      httpClient,
+	}, nil
+}
+
+func tokenFromIntegration(integration core.IntegrationContext) (string, error) {
+	switch method := authMethodOrDefault(optionalConfig(integration, "authMethod")); method {
+	case AuthMethodToken:
+		token := optionalConfig(integration, "token")
+		if token == "" {
+			return "", fmt.Errorf("token is required")
+		}
+
+		return token, nil
+
+	case AuthMethodAppRole, AuthMethodJWT:
+		secrets, err := integration.GetSecrets()
+		if err != nil {
+			return "", fmt.Errorf("failed to read Vault token: %w", err)
+		}
+
+		for _, secret := range secrets {
+			if secret.Name == SecretNameClientToken && len(secret.Value) > 0 {
+				return string(secret.Value), nil
+			}
+		}
+
+		return "", fmt.Errorf("Vault token is missing, sync the integration")
+
+	default:
+		return "", fmt.Errorf("unknown authentication method: %s", method)
+	}
+}
+
+func normalizeAddress(value string) (string, error) {
+	trimmed := strings.TrimSuffix(strings.TrimRight(strings.TrimSpace(value), "/"), "/v1")
+	if trimmed == "" {
+		return "", fmt.Errorf("address is required")
+	}
+
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", fmt.Errorf("invalid address %q", value)
+	}
+
+	return trimmed, nil
+}
+
+func optionalConfig(integration core.IntegrationContext, name string) string {
+	value, err := integration.GetConfig(name)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(value))
+}
+
+// Auth is the token issued by a login.
+type Auth struct {
+	ClientToken   string   `json:"client_token"`
+	Accessor      string   `json:"accessor"`
+	Policies      []string `json:"policies"`
+	LeaseDuration int      `json:"lease_duration"`
+	Renewable     bool     `json:"renewable"`
+}
+
+func (c *Client) Login(mount string, payload map[string]any) (*Auth, error) {
+	response := struct {
+		Auth *Auth `json:"auth"`
+	}{}
+
+	if err := c.doJSON(http.MethodPost, "/auth/"+escapePath(mount)+"/login", nil, payload, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Auth == nil || response.Auth.ClientToken == "" {
+		return nil, fmt.Errorf("login response has no token")
+	}
+
+	return response.Auth, nil
+}
+
+type TokenInfo struct {
+	DisplayName string   `json:"display_name"`
+	Policies    []string `json:"policies"`
+	TTL         int      `json:"ttl"`
+}
+
+func (c *Client) LookupSelf() (*TokenInfo, error) {
+	response := struct {
+		Data TokenInfo `json:"data"`
+	}{}
+
+	if err := c.doJSON(http.MethodGet, "/auth/token/lookup-self", nil, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// Secret is the generic response of Vault for reads and writes.
+// Dynamic secrets come with a lease, that can be renewed or revoked.
+type Secret struct {
+	RequestID     string         `json:"request_id"`
+	LeaseID       string         `json:"lease_id"`
+	LeaseDuration int            `json:"lease_duration"`
+	Renewable     bool           `json:"renewable"`
+	Data          map[string]any `json:"data"`
+	Warnings      []string       `json:"warnings"`
+}
+
+func (c *Client) Read(path string) (*Secret, error) {
+	secret := Secret{}
+	if err := c.doJSON(http.MethodGet, "/"+escapePath(path), nil, nil, &secret); err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
+func (c *Client) Write(path string, payload map[string]any) (*Secret, error) {
+	secret := Secret{}
+	if err := c.doJSON(http.MethodPost, "/"+escapePath(path), nil, payload, &secret); err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
+type KVSecret struct {
+	Data     map[string]any `json:"data"`
+	Metadata struct {
+		Version     int    `json:"version"`
+		CreatedTime string `json:"created_time"`
+	} `json:"metadata"`
+}
+
+func (c *Client) ReadKV(mount, path string, version int) (*KVSecret, error) {
+	query := url.Values{}
+	if version > 0 {
+		query.Set("version", fmt.Sprintf("%d", version))
+	}
+
+	response := struct {
+		Data *KVSecret `json:"data"`
+	}{}
+
+	if err := c.doJSON(http.MethodGet, "/"+escapePath(mount)+"/data/"+escapePath(path), query, nil, &response); err != nil {
+		return nil, err
+	}
+
+	//
+	// Deleted and destroyed versions are returned
+	// with their metadata only.
+	//
+	if response.Data == nil || response.Data.Data == nil {
+		return nil, &APIError{StatusCode: http.StatusNotFound, Body: "secret version is deleted or destroyed"}
+	}
+
+	return response.Data, nil
+}
+
+func (c *Client) RenewLease(leaseID string, increment int) (*Secret, error) {
+	payload := map[string]any{"lease_id": leaseID}
+	if increment > 0 {
+		payload["increment"] = increment
+	}
+
+	secret := Secret{}
+	if err := c.doJSON(http.MethodPut, "/sys/leases/renew", nil, payload, &secret); err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
+func (c *Client) RevokeLease(leaseID string) error {
+	return c.doJSON(http.MethodPut, "/sys/leases/revoke", nil, map[string]any{"lease_id": leaseID}, nil)
+}
+
+// escapePath escapes each segment of a Vault path,
+// keeping the slashes between them.
+func escapePath(path string) string {
+	segments := strings.Split(strings.Trim(strings.TrimSpace(path), "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func (c *Client) doJSON(method, path string, query url.Values, payload any, out any) error {
+	requestURL := c.Address + "/v1" + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("error marshaling request: %w", err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	if c.Token != "" {
+		req.Header.Set("X-Vault-Token", c.Token)
+	}
+
+	if c.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.Namespace)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &APIError{StatusCode: resp.StatusCode, Body: apiErrorMessage(responseBody)}
+	}
+
+	if out == nil || len(bytes.TrimSpace(responseBody)) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(responseBody, out); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return nil
+}
+
+func apiErrorMessage(body []byte) string {
+	response := struct {
+		Errors []string `json:"errors"`
+	}{}
+
+	if err := json.Unmarshal(body, &response); err == nil && len(response.Errors) > 0 {
+		return strings.Join(response.Errors, "; ")
+	}
+
+	return strings.TrimSpace(string(body))
+}
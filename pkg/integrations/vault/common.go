@@ -0,0 +1,57 @@
+package vault
+
+import (
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/configuration"
+)
+
+func mountField(defaultMount, description string) configuration.Field {
+	return configuration.Field{
+		Name:        "mount",
+		Label:       "Mount path",
+		Type:        configuration.FieldTypeString,
+		Required:    false,
+		Default:     defaultMount,
+		Description: description,
+	}
+}
+
+func leaseIDField() configuration.Field {
+	return configuration.Field{
+		Name:        "leaseId",
+		Label:       "Lease ID",
+		Type:        configuration.FieldTypeString,
+		Required:    true,
+		Description: "ID of the lease, starting with the path the credentials were issued from",
+		Placeholder: "e.g. database/creds/migrations/2f6a4b...",
+	}
+}
+
+func runLeaseIDField() configuration.Field {
+	field := leaseIDField()
+	field.Required = false
+	field.Description = "ID of the lease. Leave empty for every lease the integration issued to the current run."
+	return field
+}
+
+// leaseToMap returns the lease of a secret, with the time it expires at.
+// Secrets without a lease, like most static secrets, have an empty lease ID.
+func leaseToMap(secret *Secret) map[string]any {
+	lease := map[string]any{
+		"lease_id":       secret.LeaseID,
+		"lease_duration": secret.LeaseDuration,
+		"renewable":      secret.Renewable,
+	}
+
+	if secret.LeaseDuration > 0 {
+		lease["expires_at"] = time.Now().UTC().Add(time.Duration(secret.LeaseDuration) * time.Second).Format(time.RFC3339)
+	}
+
+	return lease
+}
+
+func isExpression(value string) bool {
+	return strings.Contains(value, "{{")
+}
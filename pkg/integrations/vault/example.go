@@ -0,0 +1,48 @@
+package vault
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/superplanehq/superplane/pkg/utils"
+)
+
+//go:embed example_output_read_secret.json
+var exampleOutputReadSecretBytes []byte
+
+var exampleOutputReadSecretOnce sync.Once
+var exampleOutputReadSecret map[string]any
+
+func (c *ReadSecret) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputReadSecretOnce, exampleOutputReadSecretBytes, &exampleOutputReadSecret)
+}
+
+//go:embed example_output_renew_lease.json
+var exampleOutputRenewLeaseBytes []byte
+
+var exampleOutputRenewLeaseOnce sync.Once
+var exampleOutputRenewLease map[string]any
+
+func (c *RenewLease) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputRenewLeaseOnce, exampleOutputRenewLeaseBytes, &exampleOutputRenewLease)
+}
+
+//go:embed example_output_revoke_lease.json
+var exampleOutputRevokeLeaseBytes []byte
+
+var exampleOutputRevokeLeaseOnce sync.Once
+var exampleOutputRevokeLease map[string]any
+
+func (c *RevokeLease) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputRevokeLeaseOnce, exampleOutputRevokeLeaseBytes, &exampleOutputRevokeLease)
+}
+
+//go:embed example_output_sign_ssh_key.json
+var exampleOutputSignSSHKeyBytes []byte
+
+var exampleOutputSignSSHKeyOnce sync.Once
+var exampleOutputSignSSHKey map[string]any
+
+func (c *SignSSHKey) ExampleOutput() map[string]any {
+	return utils.UnmarshalEmbeddedJSON(&exampleOutputSignSSHKeyOnce, exampleOutputSignSSHKeyBytes, &exampleOutputSignSSHKey)
+}
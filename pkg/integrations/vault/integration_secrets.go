@@ -0,0 +1,150 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+var prefixRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ResolveSecrets reads the exported secrets of the integration when a step
+// imports it, so their values are handed to the step without ever being
+// part of a run output. Leases of dynamic credentials are recorded for the
+// run, which revokes them when it finishes.
+func (v *Vault) ResolveSecrets(ctx core.IntegrationSecretContext) (map[string][]byte, error) {
+	metadata := Metadata{}
+	if err := mapstructure.Decode(ctx.Integration.GetMetadata(), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	if len(metadata.ExportedSecrets) == 0 {
+		return nil, fmt.Errorf("no secrets are exported, add them to the exported secrets of the integration")
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string][]byte{}
+	for _, exported := range metadata.ExportedSecrets {
+		data, err := readExportedSecret(ctx, client, exported)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range data {
+			name := exported.Prefix + key
+			if _, ok := values[name]; ok {
+				return nil, fmt.Errorf("%s is exported by more than one secret", name)
+			}
+
+			values[name], err = secretValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode %s: %w", name, err)
+			}
+		}
+	}
+
+	return values, nil
+}
+
+func readExportedSecret(ctx core.IntegrationSecretContext, client *Client, exported ExportedSecret) (map[string]any, error) {
+	if exported.Type == ExportedSecretTypeCredentials {
+		secret, err := client.Read(exported.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate credentials from %s: %w", exported.Path, err)
+		}
+
+		if err := recordLease(ctx, client, exported.Path, secret); err != nil {
+			return nil, err
+		}
+
+		return secret.Data, nil
+	}
+
+	secret, err := client.ReadKV(exported.Mount, exported.Path, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret %s/%s: %w", exported.Mount, exported.Path, err)
+	}
+
+	return secret.Data, nil
+}
+
+// recordLease records the lease of new credentials for the run. Credentials
+// whose lease can't be recorded are revoked right away, so nothing issued
+// to a run outlives it.
+func recordLease(ctx core.IntegrationSecretContext, client *Client, path string, secret *Secret) error {
+	if ctx.Leases == nil || secret.LeaseID == "" {
+		return nil
+	}
+
+	err := ctx.Leases.Record(core.IntegrationLease{ID: secret.LeaseID, Source: path})
+	if err == nil {
+		return nil
+	}
+
+	if revokeErr := client.RevokeLease(secret.LeaseID); revokeErr != nil {
+		return fmt.Errorf("failed to record lease of %s: %v, and failed to revoke it: %w", path, err, revokeErr)
+	}
+
+	return fmt.Errorf("failed to record lease of %s: %w", path, err)
+}
+
+// RevokeLease revokes a lease recorded for a run once the run finishes.
+func (v *Vault) RevokeLease(ctx core.IntegrationLeaseContext, leaseID string) error {
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	return client.RevokeLease(leaseID)
+}
+
+// secretValue returns strings as they are,
+// and any other value as JSON.
+func secretValue(value any) ([]byte, error) {
+	if s, ok := value.(string); ok {
+		return []byte(s), nil
+	}
+
+	return json.Marshal(value)
+}
+
+func normalizeExportedSecrets(exportedSecrets []ExportedSecret) ([]ExportedSecret, error) {
+	result := make([]ExportedSecret, 0, len(exportedSecrets))
+	for i, exported := range exportedSecrets {
+		exported.Type = strings.TrimSpace(exported.Type)
+		if exported.Type == "" {
+			exported.Type = ExportedSecretTypeKV
+		}
+
+		switch exported.Type {
+		case ExportedSecretTypeKV:
+			exported.Mount = mountOrDefault(exported.Mount, DefaultKVMount)
+		case ExportedSecretTypeCredentials:
+			exported.Mount = ""
+		default:
+			return nil, fmt.Errorf("exported secret %d: invalid type %s", i+1, exported.Type)
+		}
+
+		exported.Path = strings.Trim(strings.TrimSpace(exported.Path), "/")
+		if exported.Path == "" {
+			return nil, fmt.Errorf("exported secret %d: path is required", i+1)
+		}
+
+		exported.Prefix = strings.TrimSpace(exported.Prefix)
+		if exported.Prefix != "" && !prefixRegex.MatchString(exported.Prefix) {
+			return nil, fmt.Errorf("exported secret %d: invalid prefix %s", i+1, exported.Prefix)
+		}
+
+		result = append(result, exported)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package vault
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Vault__ResolveSecrets(t *testing.T) {
+	integration := &Vault{}
+
+	withExportedSecrets := func(exportedSecrets ...ExportedSecret) *contexts.IntegrationContext {
+		integrationCtx := testIntegration()
+		integrationCtx.Metadata = Metadata{AuthMethod: AuthMethodToken, ExportedSecrets: exportedSecrets}
+		return integrationCtx
+	}
+
+	t.Run("KV secrets and dynamic credentials -> environment variables", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"data":{"data":{"STRIPE_API_KEY":"sk_test","RETRIES":3},"metadata":{"version":7}}}`),
+			jsonResponse(http.StatusOK, `{"lease_id":"database/creds/migrations/2f6a4b","lease_duration":900,"renewable":true,"data":{"username":"v-migrations-x1","password":"p4ss"}}`),
+		}}
+
+		values, err := integration.ResolveSecrets(core.IntegrationSecretContext{
+			HTTP: httpContext,
+			Integration: withExportedSecrets(
+				ExportedSecret{Type: ExportedSecretTypeKV, Mount: "secret", Path: "apps/api/production"},
+				ExportedSecret{Type: ExportedSecretTypeCredentials, Path: "database/creds/migrations", Prefix: "DB_"},
+			),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{
+			"STRIPE_API_KEY": []byte("sk_test"),
+			"RETRIES":        []byte("3"),
+			"DB_username":    []byte("v-migrations-x1"),
+			"DB_password":    []byte("p4ss"),
+		}, values)
+
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/v1/secret/data/apps/api/production", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "/v1/database/creds/migrations", httpContext.Requests[1].URL.Path)
+		assert.Equal(t, "hvs.token", httpContext.Requests[1].Header.Get("X-Vault-Token"))
+	})
+
+	t.Run("lease of dynamic credentials is recorded for the run", func(t *testing.T) {
+		leases := &contexts.IntegrationLeaseRecorder{}
+		_, err := integration.ResolveSecrets(core.IntegrationSecretContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"lease_id":"database/creds/migrations/2f6a4b","lease_duration":900,"renewable":true,"data":{"password":"p4ss"}}`),
+			}},
+			Integration: withExportedSecrets(ExportedSecret{Type: ExportedSecretTypeCredentials, Path: "database/creds/migrations"}),
+			Leases:      leases,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []core.IntegrationLease{
+			{ID: "database/creds/migrations/2f6a4b", Source: "database/creds/migrations"},
+		}, leases.Leases)
+	})
+
+	t.Run("lease can't be recorded -> credentials are revoked", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"lease_id":"database/creds/migrations/2f6a4b","lease_duration":900,"renewable":true,"data":{"password":"p4ss"}}`),
+			jsonResponse(http.StatusNoContent, ``),
+		}}
+
+		_, err := integration.ResolveSecrets(core.IntegrationSecretContext{
+			HTTP:        httpContext,
+			Integration: withExportedSecrets(ExportedSecret{Type: ExportedSecretTypeCredentials, Path: "database/creds/migrations"}),
+			Leases:      &contexts.IntegrationLeaseRecorder{RecordErr: errors.New("database is down")},
+		})
+
+		require.ErrorContains(t, err, "failed to record lease of database/creds/migrations")
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/v1/sys/leases/revoke", httpContext.Requests[1].URL.Path)
+	})
+
+	t.Run("same name from two secrets -> error", func(t *testing.T) {
+		_, err := integration.ResolveSecrets(core.IntegrationSecretContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"data":{"data":{"password":"a"},"metadata":{"version":1}}}`),
+				jsonResponse(http.StatusOK, `{"data":{"password":"b"}}`),
+			}},
+			Integration: withExportedSecrets(
+				ExportedSecret{Type: ExportedSecretTypeKV, Mount: "secret", Path: "apps/api/production"},
+				ExportedSecret{Type: ExportedSecretTypeCredentials, Path: "database/creds/migrations"},
+			),
+		})
+
+		require.ErrorContains(t, err, "password is exported by more than one secret")
+	})
+
+	t.Run("no exported secrets -> error", func(t *testing.T) {
+		_, err := integration.ResolveSecrets(core.IntegrationSecretContext{
+			HTTP:        &contexts.HTTPContext{},
+			Integration: withExportedSecrets(),
+		})
+
+		require.ErrorContains(t, err, "no secrets are exported")
+	})
+
+	t.Run("Vault error -> error", func(t *testing.T) {
+		_, err := integration.ResolveSecrets(core.IntegrationSecretContext{
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusForbidden, `{"errors":["permission denied"]}`),
+			}},
+			Integration: withExportedSecrets(ExportedSecret{Type: ExportedSecretTypeCredentials, Path: "database/creds/migrations"}),
+		})
+
+		require.ErrorContains(t, err, "failed to generate credentials from database/creds/migrations")
+		require.ErrorContains(t, err, "permission denied")
+	})
+}
+
+func Test__Vault__RevokeLease(t *testing.T) {
+	httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+		jsonResponse(http.StatusNoContent, ``),
+	}}
+
+	err := (&Vault{}).RevokeLease(core.IntegrationLeaseContext{
+		HTTP:        httpContext,
+		Integration: testIntegration(),
+	}, "database/creds/migrations/2f6a4b")
+
+	require.NoError(t, err)
+	require.Len(t, httpContext.Requests, 1)
+	assert.Equal(t, http.MethodPut, httpContext.Requests[0].Method)
+	assert.Equal(t, "/v1/sys/leases/revoke", httpContext.Requests[0].URL.Path)
+}
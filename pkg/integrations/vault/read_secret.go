@@ -0,0 +1,186 @@
+package vault
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+const DefaultKVMount = "secret"
+
+type ReadSecret struct{}
+
+type ReadSecretSpec struct {
+	Mount   string `json:"mount" mapstructure:"mount"`
+	Path    string `json:"path" mapstructure:"path"`
+	Key     string `json:"key" mapstructure:"key"`
+	Version int    `json:"version" mapstructure:"version"`
+}
+
+func (c *ReadSecret) Name() string {
+	return "vault.readSecret"
+}
+
+func (c *ReadSecret) Label() string {
+	return "Read Secret"
+}
+
+func (c *ReadSecret) Description() string {
+	return "Read the version and keys of a secret in a Vault KV secrets engine"
+}
+
+func (c *ReadSecret) Documentation() string {
+	return `The Read Secret component reads a secret from a KV version 2 secrets engine in HashiCorp Vault, and outputs its version and key names.
+
+## Use Cases
+
+- **Rotation checks**: Only deploy once a secret was rotated to a new version
+- **Pre-flight checks**: Fail early when a secret or one of its keys is missing
+
+## Configuration
+
+- **Mount path**: Path the KV secrets engine is enabled at. Defaults to ` + "`secret`" + `.
+- **Path**: Path of the secret within the engine, e.g. ` + "`apps/api/production`" + `
+- **Key** *(optional)*: Fail unless the secret has this key
+- **Version** *(optional)*: Version of the secret to read. Defaults to the latest version.
+
+## Output
+
+The path, version, creation time and key names of the secret.
+
+The values of the secret are never part of the output, since anyone who can view the runs of the canvas could read them.
+To use them in a step, add the secret to the exported secrets of the integration, and import the integration with **Environment from** on a runner step.`
+}
+
+func (c *ReadSecret) Icon() string {
+	return "vault"
+}
+
+func (c *ReadSecret) Color() string {
+	return "yellow"
+}
+
+func (c *ReadSecret) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *ReadSecret) Configuration() []configuration.Field {
+	return []configuration.Field{
+		mountField(DefaultKVMount, "Path the KV version 2 secrets engine is enabled at"),
+		{
+			Name:        "path",
+			Label:       "Path",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Path of the secret within the secrets engine",
+			Placeholder: "e.g. apps/api/production",
+		},
+		{
+			Name:        "key",
+			Label:       "Key",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Fail unless the secret has this key",
+			Placeholder: "e.g. password",
+		},
+		{
+			Name:        "version",
+			Label:       "Version",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Version of the secret to read. Leave empty for the latest version.",
+		},
+	}
+}
+
+func (c *ReadSecret) Setup(ctx core.SetupContext) error {
+	_, err := decodeReadSecretSpec(ctx.Configuration)
+	return err
+}
+
+func (c *ReadSecret) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeReadSecretSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	secret, err := client.ReadKV(spec.Mount, spec.Path, spec.Version)
+	if err != nil {
+		return fmt.Errorf("failed to read secret %s: %w", spec.Path, err)
+	}
+
+	if spec.Key != "" {
+		if _, ok := secret.Data[spec.Key]; !ok {
+			return fmt.Errorf("secret %s has no key %s", spec.Path, spec.Key)
+		}
+	}
+
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"vault.secret",
+		[]any{map[string]any{
+			"mount":        spec.Mount,
+			"path":         spec.Path,
+			"version":      secret.Metadata.Version,
+			"created_time": secret.Metadata.CreatedTime,
+			"keys":         keys,
+		}},
+	)
+}
+
+func decodeReadSecretSpec(value any) (ReadSecretSpec, error) {
+	spec := ReadSecretSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Mount = mountOrDefault(spec.Mount, DefaultKVMount)
+	spec.Path = strings.Trim(strings.TrimSpace(spec.Path), "/")
+	if spec.Path == "" {
+		return spec, fmt.Errorf("path is required")
+	}
+
+	if spec.Version < 0 {
+		return spec, fmt.Errorf("version must be a positive number")
+	}
+
+	spec.Key = strings.TrimSpace(spec.Key)
+	return spec, nil
+}
+
+func (c *ReadSecret) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *ReadSecret) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *ReadSecret) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *ReadSecret) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *ReadSecret) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
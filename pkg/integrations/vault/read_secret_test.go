@@ -0,0 +1,95 @@
+package vault
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const kvResponse = `{
+	"data": {
+		"data": {"DATABASE_URL": "postgres://api@db/api", "STRIPE_API_KEY": "sk_test"},
+		"metadata": {"version": 7, "created_time": "2026-10-12T08:31:04.512Z"}
+	}
+}`
+
+func Test__ReadSecret__Setup(t *testing.T) {
+	err := (&ReadSecret{}).Setup(core.SetupContext{
+		Integration:   testIntegration(),
+		Metadata:      &contexts.MetadataContext{},
+		Configuration: map[string]any{"mount": "secret", "path": " / "},
+	})
+
+	require.ErrorContains(t, err, "path is required")
+}
+
+func Test__ReadSecret__Execute(t *testing.T) {
+	t.Run("latest version of the secret", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, kvResponse)}}
+		executionState := &contexts.ExecutionStateContext{}
+		err := (&ReadSecret{}).Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"path": "/apps/api/production"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://vault.example.com:8200/v1/secret/data/apps/api/production", httpContext.Requests[0].URL.String())
+
+		assert.Equal(t, "vault.secret", executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 7, payload["version"])
+		assert.Equal(t, []string{"DATABASE_URL", "STRIPE_API_KEY"}, payload["keys"])
+		assert.NotContains(t, payload, "data")
+	})
+
+	t.Run("required key of a pinned version", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, kvResponse)}}
+		executionState := &contexts.ExecutionStateContext{}
+		err := (&ReadSecret{}).Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"mount": "kv", "path": "apps/api/production", "key": "STRIPE_API_KEY", "version": "7"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "/v1/kv/data/apps/api/production", httpContext.Requests[0].URL.Path)
+		assert.Equal(t, "7", httpContext.Requests[0].URL.Query().Get("version"))
+
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, []string{"DATABASE_URL", "STRIPE_API_KEY"}, payload["keys"])
+		assert.NotContains(t, payload, "data")
+	})
+
+	t.Run("missing key -> error", func(t *testing.T) {
+		err := (&ReadSecret{}).Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"path": "apps/api/production", "key": "AWS_SECRET"},
+			HTTP:           &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusOK, kvResponse)}},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "has no key AWS_SECRET")
+	})
+
+	t.Run("deleted version -> error", func(t *testing.T) {
+		err := (&ReadSecret{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{"path": "apps/api/production"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusNotFound, `{"data":{"data":null,"metadata":{"version":8,"deletion_time":"2026-10-18T09:00:00Z"}}}`),
+			}},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.Error(t, err)
+		assert.True(t, IsNotFound(err))
+	})
+}
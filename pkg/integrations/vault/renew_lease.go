@@ -0,0 +1,172 @@
+package vault
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type RenewLease struct{}
+
+type RenewLeaseSpec struct {
+	LeaseID   string `json:"leaseId" mapstructure:"leaseId"`
+	Increment int    `json:"increment" mapstructure:"increment"`
+}
+
+func (c *RenewLease) Name() string {
+	return "vault.renewLease"
+}
+
+func (c *RenewLease) Label() string {
+	return "Renew Lease"
+}
+
+func (c *RenewLease) Description() string {
+	return "Extend the lease of Vault credentials"
+}
+
+func (c *RenewLease) Documentation() string {
+	return `The Renew Lease component extends the lease of dynamic credentials issued by HashiCorp Vault, so they stay valid for longer steps.
+
+## Use Cases
+
+- **Long deploys**: Keep the credentials exported to earlier steps of the run valid while a slow step is still running
+
+## Configuration
+
+- **Lease ID** *(optional)*: ID of the lease to renew, e.g. ` + "`database/creds/migrations/2f6a4b...`" + `. Leave empty to renew every lease the integration issued to the current run.
+- **Increment (seconds)** *(optional)*: Requested lease duration from now. Vault may grant less, up to the maximum TTL of the role.
+
+Leases issued to a run are revoked when the run finishes, renewed or not.
+
+## Output
+
+One payload per renewed lease, with its ID, the granted duration and the new expiry time.`
+}
+
+func (c *RenewLease) Icon() string {
+	return "vault"
+}
+
+func (c *RenewLease) Color() string {
+	return "yellow"
+}
+
+func (c *RenewLease) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *RenewLease) Configuration() []configuration.Field {
+	return []configuration.Field{
+		runLeaseIDField(),
+		{
+			Name:        "increment",
+			Label:       "Increment (seconds)",
+			Type:        configuration.FieldTypeNumber,
+			Required:    false,
+			Description: "Requested lease duration from now. Leave empty for the default TTL of the role.",
+			Placeholder: "e.g. 3600",
+		},
+	}
+}
+
+func (c *RenewLease) Setup(ctx core.SetupContext) error {
+	_, err := decodeRenewLeaseSpec(ctx.Configuration)
+	return err
+}
+
+func (c *RenewLease) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeRenewLeaseSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	leaseIDs := []string{spec.LeaseID}
+	if spec.LeaseID == "" {
+		leaseIDs, err = runLeaseIDs(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	payloads := make([]any, 0, len(leaseIDs))
+	for _, leaseID := range leaseIDs {
+		secret, err := client.RenewLease(leaseID, spec.Increment)
+		if err != nil {
+			return fmt.Errorf("failed to renew lease %s: %w", leaseID, err)
+		}
+
+		payloads = append(payloads, leaseToMap(secret))
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"vault.lease",
+		payloads,
+	)
+}
+
+func runLeaseIDs(ctx core.ExecutionContext) ([]string, error) {
+	if ctx.Runs == nil {
+		return nil, fmt.Errorf("lease ID is required outside of a run")
+	}
+
+	leases, err := ctx.Runs.Leases()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the leases of the run: %w", err)
+	}
+
+	if len(leases) == 0 {
+		return nil, fmt.Errorf("no leases were issued to this run, import exported credentials with Environment from on an earlier step")
+	}
+
+	leaseIDs := make([]string, 0, len(leases))
+	for _, lease := range leases {
+		leaseIDs = append(leaseIDs, lease.ID)
+	}
+
+	return leaseIDs, nil
+}
+
+func decodeRenewLeaseSpec(value any) (RenewLeaseSpec, error) {
+	spec := RenewLeaseSpec{}
+	if err := mapstructure.WeakDecode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.LeaseID = strings.TrimSpace(spec.LeaseID)
+	if spec.Increment < 0 {
+		return spec, fmt.Errorf("increment must be a positive number of seconds")
+	}
+
+	return spec, nil
+}
+
+func (c *RenewLease) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *RenewLease) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *RenewLease) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *RenewLease) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *RenewLease) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
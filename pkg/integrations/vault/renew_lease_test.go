@@ -0,0 +1,101 @@
+package vault
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RenewLease__Setup(t *testing.T) {
+	err := (&RenewLease{}).Setup(core.SetupContext{
+		Integration:   testIntegration(),
+		Metadata:      &contexts.MetadataContext{},
+		Configuration: map[string]any{"leaseId": "database/creds/migrations/Fj3Dx4", "increment": -1},
+	})
+
+	require.ErrorContains(t, err, "increment must be a positive number")
+}
+
+func Test__RenewLease__Execute(t *testing.T) {
+	t.Run("lease is renewed", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"lease_id":"database/creds/migrations/Fj3Dx4","lease_duration":1800,"renewable":true}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := (&RenewLease{}).Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"leaseId": " database/creds/migrations/Fj3Dx4 ", "increment": 1800},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodPut, httpContext.Requests[0].Method)
+		assert.Equal(t, "/v1/sys/leases/renew", httpContext.Requests[0].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"lease_id":"database/creds/migrations/Fj3Dx4","increment":1800}`, string(body))
+
+		assert.Equal(t, "vault.lease", executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, 1800, payload["lease_duration"])
+		assert.NotEmpty(t, payload["expires_at"])
+	})
+
+	t.Run("expired lease -> error", func(t *testing.T) {
+		err := (&RenewLease{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{"leaseId": "database/creds/migrations/Fj3Dx4"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusBadRequest, `{"errors":["lease not found"]}`),
+			}},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "lease not found")
+	})
+	t.Run("no lease ID -> leases of the run are renewed", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{"lease_id":"database/creds/migrations/a1","lease_duration":900,"renewable":true}`),
+			jsonResponse(http.StatusOK, `{"lease_id":"aws/creds/deploy/b2","lease_duration":900,"renewable":true}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := (&RenewLease{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{"increment": 900},
+			HTTP:          httpContext,
+			Integration:   testIntegration(),
+			Runs: &contexts.RunExecutionContext{RunLeases: []core.IntegrationLease{
+				{ID: "database/creds/migrations/a1", Source: "database/creds/migrations"},
+				{ID: "aws/creds/deploy/b2", Source: "aws/creds/deploy"},
+			}},
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		body, err := io.ReadAll(httpContext.Requests[1].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"lease_id":"aws/creds/deploy/b2","increment":900}`, string(body))
+		assert.Len(t, executionState.Payloads, 2)
+	})
+
+	t.Run("no lease ID and no leases issued to the run -> error", func(t *testing.T) {
+		err := (&RenewLease{}).Execute(core.ExecutionContext{
+			Configuration:  map[string]any{},
+			HTTP:           &contexts.HTTPContext{},
+			Integration:    testIntegration(),
+			Runs:           &contexts.RunExecutionContext{},
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "no leases were issued to this run")
+	})
+}
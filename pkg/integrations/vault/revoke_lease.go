@@ -0,0 +1,131 @@
+package vault
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+)
+
+type RevokeLease struct{}
+
+type RevokeLeaseSpec struct {
+	LeaseID string `json:"leaseId" mapstructure:"leaseId"`
+}
+
+func (c *RevokeLease) Name() string {
+	return "vault.revokeLease"
+}
+
+func (c *RevokeLease) Label() string {
+	return "Revoke Lease"
+}
+
+func (c *RevokeLease) Description() string {
+	return "Revoke Vault credentials before their lease expires"
+}
+
+func (c *RevokeLease) Documentation() string {
+	return `The Revoke Lease component revokes dynamic credentials issued by HashiCorp Vault, given the ID of their lease.
+
+## Use Cases
+
+- **Incident response**: Revoke credentials that might have leaked
+- **Early cleanup**: Revoke credentials as soon as the steps of a long run no longer need them
+
+Leases the integration issued to a run are revoked when the run finishes, so they don't need a Revoke Lease step.
+
+## Configuration
+
+- **Lease ID**: ID of the lease to revoke, e.g. ` + "`database/creds/migrations/2f6a4b...`" + `. ` + "`vault list sys/leases/lookup/<path>`" + ` lists the leases of a path.
+
+## Output
+
+The ID of the revoked lease, and the time it was revoked at. Revoking a lease that already expired or was revoked succeeds too.`
+}
+
+func (c *RevokeLease) Icon() string {
+	return "vault"
+}
+
+func (c *RevokeLease) Color() string {
+	return "yellow"
+}
+
+func (c *RevokeLease) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *RevokeLease) Configuration() []configuration.Field {
+	return []configuration.Field{
+		leaseIDField(),
+	}
+}
+
+func (c *RevokeLease) Setup(ctx core.SetupContext) error {
+	_, err := decodeRevokeLeaseSpec(ctx.Configuration)
+	return err
+}
+
+func (c *RevokeLease) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeRevokeLeaseSpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	if err := client.RevokeLease(spec.LeaseID); err != nil {
+		return fmt.Errorf("failed to revoke lease %s: %w", spec.LeaseID, err)
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"vault.lease.revoked",
+		[]any{map[string]any{
+			"lease_id":   spec.LeaseID,
+			"revoked_at": time.Now().UTC().Format(time.RFC3339),
+		}},
+	)
+}
+
+func decodeRevokeLeaseSpec(value any) (RevokeLeaseSpec, error) {
+	spec := RevokeLeaseSpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.LeaseID = strings.TrimSpace(spec.LeaseID)
+	if spec.LeaseID == "" {
+		return spec, fmt.Errorf("lease ID is required")
+	}
+
+	return spec, nil
+}
+
+func (c *RevokeLease) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *RevokeLease) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *RevokeLease) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *RevokeLease) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *RevokeLease) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
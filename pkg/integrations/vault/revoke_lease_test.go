@@ -0,0 +1,61 @@
+package vault
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__RevokeLease__Setup(t *testing.T) {
+	err := (&RevokeLease{}).Setup(core.SetupContext{
+		Integration:   testIntegration(),
+		Metadata:      &contexts.MetadataContext{},
+		Configuration: map[string]any{"leaseId": " "},
+	})
+
+	require.ErrorContains(t, err, "lease ID is required")
+}
+
+func Test__RevokeLease__Execute(t *testing.T) {
+	t.Run("lease is revoked", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{jsonResponse(http.StatusNoContent, "")}}
+		executionState := &contexts.ExecutionStateContext{}
+		err := (&RevokeLease{}).Execute(core.ExecutionContext{
+			Configuration:  map[string]any{"leaseId": "database/creds/migrations/Fj3Dx4"},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodPut, httpContext.Requests[0].Method)
+		assert.Equal(t, "/v1/sys/leases/revoke", httpContext.Requests[0].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"lease_id":"database/creds/migrations/Fj3Dx4"}`, string(body))
+
+		assert.Equal(t, "vault.lease.revoked", executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "database/creds/migrations/Fj3Dx4", payload["lease_id"])
+		assert.NotEmpty(t, payload["revoked_at"])
+	})
+
+	t.Run("permission denied -> error", func(t *testing.T) {
+		err := (&RevokeLease{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{"leaseId": "database/creds/migrations/Fj3Dx4"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusForbidden, `{"errors":["1 error occurred:\n\t* permission denied\n\n"]}`),
+			}},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "permission denied")
+	})
+}
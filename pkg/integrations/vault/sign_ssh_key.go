@@ -0,0 +1,242 @@
+package vault
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	DefaultSSHMount = "ssh"
+
+	CertTypeUser = "user"
+	CertTypeHost = "host"
+)
+
+type SignSSHKey struct{}
+
+type SignSSHKeySpec struct {
+	Mount      string `json:"mount" mapstructure:"mount"`
+	Role       string `json:"role" mapstructure:"role"`
+	PublicKey  string `json:"publicKey" mapstructure:"publicKey"`
+	Principals string `json:"principals" mapstructure:"principals"`
+	TTL        string `json:"ttl" mapstructure:"ttl"`
+	CertType   string `json:"certType" mapstructure:"certType"`
+}
+
+func (c *SignSSHKey) Name() string {
+	return "vault.signSSHKey"
+}
+
+func (c *SignSSHKey) Label() string {
+	return "Sign SSH Key"
+}
+
+func (c *SignSSHKey) Description() string {
+	return "Sign an SSH public key with the Vault SSH certificate authority"
+}
+
+func (c *SignSSHKey) Documentation() string {
+	return `The Sign SSH Key component signs an SSH public key with the certificate authority of a HashiCorp Vault SSH secrets engine, and outputs a short-lived certificate for it.
+
+## Use Cases
+
+- **Short-lived SSH access**: Hosts trust the Vault CA, so deploys connect with a certificate valid for minutes instead of a key authorized forever
+
+## Configuration
+
+- **Mount path**: Path the SSH secrets engine is enabled at. Defaults to ` + "`ssh`" + `.
+- **Role**: Vault role to sign the key with
+- **Public key**: The OpenSSH public key to sign
+- **Principals** *(optional)*: Comma-separated users (or hostnames, for host certificates) the certificate is valid for. Defaults to the default principals of the role.
+- **TTL** *(optional)*: How long the certificate is valid, e.g. ` + "`30m`" + `. Defaults to the TTL of the role.
+- **Certificate type**: User or host certificate
+
+## Using the certificate with SSH Command
+
+Store the private key of the signed public key in an organization Secret, and select it as the private key of the **SSH Command** component.
+Then set its **Certificate** to the signed key, e.g. ` + "`{{ $[\"Sign SSH Key\"].data.signed_key }}`" + `.
+
+## Output
+
+The serial number of the certificate, and the signed certificate under ` + "`signed_key`" + `.`
+}
+
+func (c *SignSSHKey) Icon() string {
+	return "vault"
+}
+
+func (c *SignSSHKey) Color() string {
+	return "yellow"
+}
+
+func (c *SignSSHKey) OutputChannels(configuration any) []core.OutputChannel {
+	return []core.OutputChannel{core.DefaultOutputChannel}
+}
+
+func (c *SignSSHKey) Configuration() []configuration.Field {
+	return []configuration.Field{
+		mountField(DefaultSSHMount, "Path the SSH secrets engine is enabled at"),
+		{
+			Name:        "role",
+			Label:       "Role",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Description: "Vault role to sign the key with",
+			Placeholder: "e.g. deploy",
+		},
+		{
+			Name:        "publicKey",
+			Label:       "Public key",
+			Type:        configuration.FieldTypeText,
+			Required:    true,
+			Description: "OpenSSH public key to sign",
+			Placeholder: "e.g. ssh-ed25519 AAAA... deploy@superplane",
+		},
+		{
+			Name:        "principals",
+			Label:       "Principals",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Comma-separated users or hostnames the certificate is valid for",
+			Placeholder: "e.g. ubuntu",
+		},
+		{
+			Name:        "ttl",
+			Label:       "TTL",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "How long the certificate is valid. Leave empty for the TTL of the role.",
+			Placeholder: "e.g. 30m",
+		},
+		{
+			Name:     "certType",
+			Label:    "Certificate type",
+			Type:     configuration.FieldTypeSelect,
+			Required: false,
+			Default:  CertTypeUser,
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "User", Value: CertTypeUser},
+						{Label: "Host", Value: CertTypeHost},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *SignSSHKey) Setup(ctx core.SetupContext) error {
+	_, err := decodeSignSSHKeySpec(ctx.Configuration)
+	return err
+}
+
+func (c *SignSSHKey) Execute(ctx core.ExecutionContext) error {
+	spec, err := decodeSignSSHKeySpec(ctx.Configuration)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"public_key": spec.PublicKey,
+		"cert_type":  spec.CertType,
+	}
+
+	if spec.Principals != "" {
+		payload["valid_principals"] = spec.Principals
+	}
+
+	if spec.TTL != "" {
+		payload["ttl"] = spec.TTL
+	}
+
+	secret, err := client.Write(spec.Mount+"/sign/"+spec.Role, payload)
+	if err != nil {
+		return fmt.Errorf("failed to sign SSH key with role %s: %w", spec.Role, err)
+	}
+
+	signedKey, _ := secret.Data["signed_key"].(string)
+	if signedKey == "" {
+		return fmt.Errorf("Vault returned no signed key")
+	}
+
+	return ctx.ExecutionState.Emit(
+		core.DefaultOutputChannel.Name,
+		"vault.sshCertificate",
+		[]any{map[string]any{
+			"mount":         spec.Mount,
+			"role":          spec.Role,
+			"cert_type":     spec.CertType,
+			"serial_number": secret.Data["serial_number"],
+			"signed_key":    strings.TrimSpace(signedKey),
+		}},
+	)
+}
+
+func decodeSignSSHKeySpec(value any) (SignSSHKeySpec, error) {
+	spec := SignSSHKeySpec{}
+	if err := mapstructure.Decode(value, &spec); err != nil {
+		return spec, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	spec.Mount = mountOrDefault(spec.Mount, DefaultSSHMount)
+	spec.Role = strings.Trim(strings.TrimSpace(spec.Role), "/")
+	if spec.Role == "" {
+		return spec, fmt.Errorf("role is required")
+	}
+
+	spec.PublicKey = strings.TrimSpace(spec.PublicKey)
+	if spec.PublicKey == "" {
+		return spec, fmt.Errorf("public key is required")
+	}
+
+	if !isExpression(spec.PublicKey) {
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(spec.PublicKey)); err != nil {
+			return spec, fmt.Errorf("invalid public key: %w", err)
+		}
+	}
+
+	spec.Principals = strings.TrimSpace(spec.Principals)
+	spec.TTL = strings.TrimSpace(spec.TTL)
+	spec.CertType = strings.TrimSpace(spec.CertType)
+	if spec.CertType == "" {
+		spec.CertType = CertTypeUser
+	}
+
+	if spec.CertType != CertTypeUser && spec.CertType != CertTypeHost {
+		return spec, fmt.Errorf("invalid certificate type: %s", spec.CertType)
+	}
+
+	return spec, nil
+}
+
+func (c *SignSSHKey) HandleWebhook(ctx core.WebhookRequestContext) (int, *core.WebhookResponseBody, error) {
+	return http.StatusOK, nil, nil
+}
+
+func (c *SignSSHKey) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (c *SignSSHKey) HandleHook(ctx core.ActionHookContext) error {
+	return nil
+}
+
+func (c *SignSSHKey) Cancel(ctx core.ExecutionContext) error {
+	return nil
+}
+
+func (c *SignSSHKey) Cleanup(ctx core.SetupContext) error {
+	return nil
+}
@@ -0,0 +1,98 @@
+package vault
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+const testPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGb9ECWmEzf6FQbrBZ9w7lshQhqowtrbLDFw4rXAxZuE deploy@superplane"
+
+func Test__SignSSHKey__Setup(t *testing.T) {
+	setup := func(configuration map[string]any) error {
+		return (&SignSSHKey{}).Setup(core.SetupContext{
+			Integration:   testIntegration(),
+			Metadata:      &contexts.MetadataContext{},
+			Configuration: configuration,
+		})
+	}
+
+	t.Run("role is required", func(t *testing.T) {
+		require.ErrorContains(t, setup(map[string]any{"publicKey": testPublicKey}), "role is required")
+	})
+
+	t.Run("invalid public key -> error", func(t *testing.T) {
+		require.ErrorContains(t, setup(map[string]any{"role": "deploy", "publicKey": "not a key"}), "invalid public key")
+	})
+
+	t.Run("public key from an expression is validated at run time", func(t *testing.T) {
+		require.NoError(t, setup(map[string]any{"role": "deploy", "publicKey": `{{ $["Keys"].data.public_key }}`}))
+	})
+
+	t.Run("invalid certificate type -> error", func(t *testing.T) {
+		require.ErrorContains(t, setup(map[string]any{"role": "deploy", "publicKey": testPublicKey, "certType": "ca"}), "invalid certificate type")
+	})
+}
+
+func Test__SignSSHKey__Execute(t *testing.T) {
+	t.Run("key is signed", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{Responses: []*http.Response{
+			jsonResponse(http.StatusOK, `{
+				"data": {
+					"serial_number": "5e:1c:0a:77:3b:92:d4:f1",
+					"signed_key": "ssh-ed25519-cert-v01@openssh.com AAAAIHNzaC1lZDI1NTE5LWNlcnQ=\n"
+				}
+			}`),
+		}}
+
+		executionState := &contexts.ExecutionStateContext{}
+		err := (&SignSSHKey{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{
+				"mount":      "ssh-client-signer",
+				"role":       "deploy",
+				"publicKey":  testPublicKey,
+				"principals": "ubuntu",
+				"ttl":        "30m",
+			},
+			HTTP:           httpContext,
+			Integration:    testIntegration(),
+			ExecutionState: executionState,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, http.MethodPost, httpContext.Requests[0].Method)
+		assert.Equal(t, "/v1/ssh-client-signer/sign/deploy", httpContext.Requests[0].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"public_key": "`+testPublicKey+`",
+			"cert_type": "user",
+			"valid_principals": "ubuntu",
+			"ttl": "30m"
+		}`, string(body))
+
+		assert.Equal(t, "vault.sshCertificate", executionState.Type)
+		payload := executionState.Payloads[0].(map[string]any)["data"].(map[string]any)
+		assert.Equal(t, "5e:1c:0a:77:3b:92:d4:f1", payload["serial_number"])
+		assert.Equal(t, "ssh-ed25519-cert-v01@openssh.com AAAAIHNzaC1lZDI1NTE5LWNlcnQ=", payload["signed_key"])
+	})
+
+	t.Run("role not allowed -> error", func(t *testing.T) {
+		err := (&SignSSHKey{}).Execute(core.ExecutionContext{
+			Configuration: map[string]any{"role": "deploy", "publicKey": testPublicKey, "principals": "root"},
+			HTTP: &contexts.HTTPContext{Responses: []*http.Response{
+				jsonResponse(http.StatusBadRequest, `{"errors":["root is not a valid value for valid_principals"]}`),
+			}},
+			Integration:    testIntegration(),
+			ExecutionState: &contexts.ExecutionStateContext{},
+		})
+
+		require.ErrorContains(t, err, "root is not a valid value")
+	})
+}
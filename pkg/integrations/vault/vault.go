@@ -0,0 +1,504 @@
+package vault
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/registry"
+)
+
+const (
+	AuthMethodToken   = "token"
+	AuthMethodAppRole = "approle"
+	AuthMethodJWT     = "jwt"
+
+	SecretNameClientToken = "clientToken"
+
+	DefaultAppRoleMount = "approle"
+	DefaultJWTMount     = "jwt"
+
+	ExportedSecretTypeKV          = "kv"
+	ExportedSecretTypeCredentials = "credentials"
+
+	// The JWT is only used to log in, so it can be very short-lived.
+	// The Vault token it is exchanged for is refreshed by resyncing
+	// the integration halfway through its lifetime.
+	jwtDuration = 5 * time.Minute
+)
+
+func init() {
+	registry.RegisterIntegration("vault", &Vault{})
+}
+
+type Vault struct{}
+
+type Configuration struct {
+	Address      string `json:"address" mapstructure:"address"`
+	Namespace    string `json:"namespace" mapstructure:"namespace"`
+	AuthMethod   string `json:"authMethod" mapstructure:"authMethod"`
+	Token        string `json:"token" mapstructure:"token"`
+	RoleID       string `json:"roleId" mapstructure:"roleId"`
+	SecretID     string `json:"secretId" mapstructure:"secretId"`
+	AppRoleMount string `json:"approleMount" mapstructure:"approleMount"`
+	JWTRole      string `json:"jwtRole" mapstructure:"jwtRole"`
+	JWTMount     string `json:"jwtMount" mapstructure:"jwtMount"`
+	Audience     string `json:"audience" mapstructure:"audience"`
+
+	ExportedSecrets []ExportedSecret `json:"exportedSecrets" mapstructure:"exportedSecrets"`
+}
+
+// ExportedSecret is a Vault secret that steps importing the integration,
+// like runners with Environment from, get as environment variables.
+type ExportedSecret struct {
+	Type   string `json:"type" mapstructure:"type"`
+	Mount  string `json:"mount,omitempty" mapstructure:"mount"`
+	Path   string `json:"path" mapstructure:"path"`
+	Prefix string `json:"prefix,omitempty" mapstructure:"prefix"`
+}
+
+type Metadata struct {
+	Address    string   `json:"address" mapstructure:"address"`
+	AuthMethod string   `json:"authMethod" mapstructure:"authMethod"`
+	Subject    string   `json:"subject,omitempty" mapstructure:"subject"`
+	Policies   []string `json:"policies" mapstructure:"policies"`
+
+	// ExportedSecrets are kept in the metadata, so they
+	// can be resolved without the configuration at hand.
+	ExportedSecrets []ExportedSecret `json:"exportedSecrets,omitempty" mapstructure:"exportedSecrets"`
+}
+
+func (v *Vault) Name() string {
+	return "vault"
+}
+
+func (v *Vault) Label() string {
+	return "HashiCorp Vault"
+}
+
+func (v *Vault) Icon() string {
+	return "vault"
+}
+
+func (v *Vault) Description() string {
+	return "Read secrets, and issue short-lived credentials and SSH certificates with HashiCorp Vault"
+}
+
+func (v *Vault) Instructions() string {
+	return `Enter the address of your Vault server, and choose how SuperPlane authenticates to it.
+Whichever method you choose, attach a policy that allows the paths your canvases use.
+
+## Token
+
+Create a token for SuperPlane, e.g. ` + "`vault token create -policy=superplane`" + `, and paste it below.
+SuperPlane doesn't renew this token, so it stops working when its TTL ends. Prefer AppRole or JWT for long-lived setups.
+
+## AppRole
+
+1. Enable the AppRole auth method: ` + "`vault auth enable approle`" + `
+2. Create a role: ` + "`vault write auth/approle/role/superplane token_policies=superplane token_ttl=1h`" + `
+3. Read its role ID with ` + "`vault read auth/approle/role/superplane/role-id`" + `, and generate a secret ID with ` + "`vault write -f auth/approle/role/superplane/secret-id`" + `.
+
+## JWT (keyless)
+
+SuperPlane signs short-lived tokens with its own OIDC provider, and logs in to Vault with them. No credentials are stored.
+
+1. Enable the JWT auth method: ` + "`vault auth enable jwt`" + `
+2. Trust SuperPlane as an issuer: ` + "`vault write auth/jwt/config oidc_discovery_url=<this SuperPlane instance's URL> bound_issuer=<the same URL>`" + `
+3. Create a role bound to the integration: ` + "`vault write auth/jwt/role/superplane role_type=jwt user_claim=sub bound_audiences=<Audience> bound_subject=app-installation:<integration ID> token_policies=superplane token_ttl=1h`" + `. The exact subject is shown in the integration details once connected.
+
+The Vault token is refreshed automatically, halfway through its lifetime.
+
+## Exported secrets
+
+Secret values never show up in run outputs. To give them to a step, export them here, and import the integration with **Environment from** on a runner step.
+Each key of an exported secret becomes an environment variable of the step, named after the key with an optional prefix.
+
+- **KV secret**: A secret of a KV version 2 secrets engine, read when the step starts
+- **Dynamic credentials**: A credentials path like ` + "`database/creds/migrations`" + `. Vault issues new credentials for every step that imports them.
+
+The leases of dynamic credentials are recorded for the run, and revoked when the run finishes, whatever its result. **Renew Lease** without a lease ID renews them while the run is still going.`
+}
+
+func (v *Vault) Configuration() []configuration.Field {
+	return []configuration.Field{
+		{
+			Name:        "address",
+			Label:       "Address",
+			Type:        configuration.FieldTypeString,
+			Required:    true,
+			Placeholder: "https://vault.example.com:8200",
+			Description: "URL of the Vault server",
+		},
+		{
+			Name:        "namespace",
+			Label:       "Namespace",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "e.g. admin",
+			Description: "Vault Enterprise or HCP Vault namespace. Leave empty for the root namespace.",
+		},
+		{
+			Name:        "authMethod",
+			Label:       "Authentication",
+			Type:        configuration.FieldTypeSelect,
+			Required:    true,
+			Default:     AuthMethodToken,
+			Description: "How SuperPlane authenticates to Vault",
+			TypeOptions: &configuration.TypeOptions{
+				Select: &configuration.SelectTypeOptions{
+					Options: []configuration.FieldOption{
+						{Label: "Token", Value: AuthMethodToken},
+						{Label: "AppRole", Value: AuthMethodAppRole},
+						{Label: "JWT", Value: AuthMethodJWT},
+					},
+				},
+			},
+		},
+		{
+			Name:        "token",
+			Label:       "Token",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Sensitive:   true,
+			Description: "Vault token used by SuperPlane",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodToken}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "authMethod", Values: []string{AuthMethodToken}},
+			},
+		},
+		{
+			Name:        "roleId",
+			Label:       "Role ID",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Description: "Role ID of the AppRole",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodAppRole}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "authMethod", Values: []string{AuthMethodAppRole}},
+			},
+		},
+		{
+			Name:        "secretId",
+			Label:       "Secret ID",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Sensitive:   true,
+			Description: "Secret ID generated for the AppRole",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodAppRole}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "authMethod", Values: []string{AuthMethodAppRole}},
+			},
+		},
+		{
+			Name:        "approleMount",
+			Label:       "AppRole mount path",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Default:     DefaultAppRoleMount,
+			Description: "Path the AppRole auth method is enabled at",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodAppRole}},
+			},
+		},
+		{
+			Name:        "jwtRole",
+			Label:       "Role",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "superplane",
+			Description: "Vault role to log in with",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodJWT}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "authMethod", Values: []string{AuthMethodJWT}},
+			},
+		},
+		{
+			Name:        "audience",
+			Label:       "Audience",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Placeholder: "vault",
+			Description: "Audience of the signed tokens. Must be one of the bound audiences of the Vault role.",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodJWT}},
+			},
+			RequiredConditions: []configuration.RequiredCondition{
+				{Field: "authMethod", Values: []string{AuthMethodJWT}},
+			},
+		},
+		{
+			Name:        "jwtMount",
+			Label:       "JWT mount path",
+			Type:        configuration.FieldTypeString,
+			Required:    false,
+			Default:     DefaultJWTMount,
+			Description: "Path the JWT auth method is enabled at",
+			VisibilityConditions: []configuration.VisibilityCondition{
+				{Field: "authMethod", Values: []string{AuthMethodJWT}},
+			},
+		},
+		{
+			Name:        "exportedSecrets",
+			Label:       "Exported secrets",
+			Type:        configuration.FieldTypeList,
+			Required:    false,
+			Description: "Secrets that steps importing this integration get as environment variables",
+			TypeOptions: &configuration.TypeOptions{
+				List: &configuration.ListTypeOptions{
+					ItemLabel: "Secret",
+					ItemDefinition: &configuration.ListItemDefinition{
+						Type: configuration.FieldTypeObject,
+						Schema: []configuration.Field{
+							{
+								Name:     "type",
+								Label:    "Type",
+								Type:     configuration.FieldTypeSelect,
+								Required: true,
+								Default:  ExportedSecretTypeKV,
+								TypeOptions: &configuration.TypeOptions{
+									Select: &configuration.SelectTypeOptions{
+										Options: []configuration.FieldOption{
+											{Label: "KV secret", Value: ExportedSecretTypeKV},
+											{Label: "Dynamic credentials", Value: ExportedSecretTypeCredentials},
+										},
+									},
+								},
+							},
+							{
+								Name:        "mount",
+								Label:       "Mount path",
+								Type:        configuration.FieldTypeString,
+								Required:    false,
+								Default:     DefaultKVMount,
+								Description: "Path the KV version 2 secrets engine is enabled at",
+								VisibilityConditions: []configuration.VisibilityCondition{
+									{Field: "type", Values: []string{ExportedSecretTypeKV}},
+								},
+							},
+							{
+								Name:        "path",
+								Label:       "Path",
+								Type:        configuration.FieldTypeString,
+								Required:    true,
+								Description: "Path of the KV secret, or of the role to issue credentials for",
+								Placeholder: "e.g. apps/api/production or database/creds/migrations",
+							},
+							{
+								Name:        "prefix",
+								Label:       "Prefix",
+								Type:        configuration.FieldTypeString,
+								Required:    false,
+								Description: "Prefix of the environment variable names, e.g. DB_",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (v *Vault) Actions() []core.Action {
+	return []core.Action{
+		&ReadSecret{},
+		&RenewLease{},
+		&RevokeLease{},
+		&SignSSHKey{},
+	}
+}
+
+func (v *Vault) Triggers() []core.Trigger {
+	return []core.Trigger{}
+}
+
+func (v *Vault) Cleanup(ctx core.IntegrationCleanupContext) error {
+	return nil
+}
+
+func (v *Vault) Sync(ctx core.SyncContext) error {
+	config := Configuration{}
+	if err := mapstructure.Decode(ctx.Configuration, &config); err != nil {
+		return fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	address, err := normalizeAddress(config.Address)
+	if err != nil {
+		return err
+	}
+
+	exportedSecrets, err := normalizeExportedSecrets(config.ExportedSecrets)
+	if err != nil {
+		return err
+	}
+
+	metadata := Metadata{
+		Address:         address,
+		AuthMethod:      authMethodOrDefault(config.AuthMethod),
+		ExportedSecrets: exportedSecrets,
+	}
+
+	var login *Auth
+	switch metadata.AuthMethod {
+	case AuthMethodToken:
+		if strings.TrimSpace(config.Token) == "" {
+			return fmt.Errorf("token is required")
+		}
+
+	case AuthMethodAppRole:
+		login, err = v.loginWithAppRole(ctx, config)
+		if err != nil {
+			return err
+		}
+
+	case AuthMethodJWT:
+		subject, auth, err := v.loginWithJWT(ctx, config)
+		if err != nil {
+			return err
+		}
+
+		metadata.Subject = subject
+		login = auth
+
+	default:
+		return fmt.Errorf("unknown authentication method: %s", metadata.AuthMethod)
+	}
+
+	if login != nil {
+		if err := ctx.Integration.SetSecret(SecretNameClientToken, []byte(login.ClientToken)); err != nil {
+			return fmt.Errorf("failed to store Vault token: %w", err)
+		}
+	}
+
+	client, err := NewClient(ctx.HTTP, ctx.Integration)
+	if err != nil {
+		return err
+	}
+
+	token, err := client.LookupSelf()
+	if err != nil {
+		return fmt.Errorf("failed to verify Vault token: %w", err)
+	}
+
+	metadata.Policies = token.Policies
+	ctx.Integration.SetMetadata(metadata)
+
+	if login != nil && login.LeaseDuration > 0 {
+		if err := ctx.Integration.ScheduleResync(time.Duration(login.LeaseDuration) * time.Second / 2); err != nil {
+			ctx.Logger.Warnf("could not schedule Vault token refresh: %v", err)
+		}
+	}
+
+	ctx.Integration.Ready()
+	return nil
+}
+
+func (v *Vault) loginWithAppRole(ctx core.SyncContext, config Configuration) (*Auth, error) {
+	roleID := strings.TrimSpace(config.RoleID)
+	if roleID == "" {
+		return nil, fmt.Errorf("role ID is required")
+	}
+
+	secretID := strings.TrimSpace(config.SecretID)
+	if secretID == "" {
+		return nil, fmt.Errorf("secret ID is required")
+	}
+
+	client, err := newLoginClient(ctx.HTTP, config)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := client.Login(mountOrDefault(config.AppRoleMount, DefaultAppRoleMount), map[string]any{
+		"role_id":   roleID,
+		"secret_id": secretID,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to log in with AppRole: %w", err)
+	}
+
+	return auth, nil
+}
+
+func (v *Vault) loginWithJWT(ctx core.SyncContext, config Configuration) (string, *Auth, error) {
+	role := strings.TrimSpace(config.JWTRole)
+	if role == "" {
+		return "", nil, fmt.Errorf("role is required")
+	}
+
+	audience := strings.TrimSpace(config.Audience)
+	if audience == "" {
+		return "", nil, fmt.Errorf("audience is required")
+	}
+
+	if ctx.OIDC == nil {
+		return "", nil, fmt.Errorf("OIDC provider is not configured on this SuperPlane instance")
+	}
+
+	subject := fmt.Sprintf("app-installation:%s", ctx.Integration.ID())
+	jwt, err := ctx.OIDC.Sign(subject, jwtDuration, audience, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate OIDC token: %w", err)
+	}
+
+	client, err := newLoginClient(ctx.HTTP, config)
+	if err != nil {
+		return "", nil, err
+	}
+
+	auth, err := client.Login(mountOrDefault(config.JWTMount, DefaultJWTMount), map[string]any{
+		"role": role,
+		"jwt":  jwt,
+	})
+
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to log in with JWT: %w", err)
+	}
+
+	return subject, auth, nil
+}
+
+func (v *Vault) HandleRequest(ctx core.HTTPRequestContext) {
+	// no-op
+}
+
+func (v *Vault) ListResources(resourceType string, ctx core.ListResourcesContext) ([]core.IntegrationResource, error) {
+	return []core.IntegrationResource{}, nil
+}
+
+func (v *Vault) Hooks() []core.Hook {
+	return []core.Hook{}
+}
+
+func (v *Vault) HandleHook(ctx core.IntegrationHookContext) error {
+	return nil
+}
+
+func authMethodOrDefault(method string) string {
+	if strings.TrimSpace(method) == "" {
+		return AuthMethodToken
+	}
+
+	return strings.TrimSpace(method)
+}
+
+func mountOrDefault(mount, defaultMount string) string {
+	mount = strings.Trim(strings.TrimSpace(mount), "/")
+	if mount == "" {
+		return defaultMount
+	}
+
+	return mount
+}
@@ -0,0 +1,244 @@
+package vault
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/test/support"
+	"github.com/superplanehq/superplane/test/support/contexts"
+)
+
+func Test__Vault__Sync(t *testing.T) {
+	integration := &Vault{}
+
+	t.Run("token -> ready", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{jsonResponse(http.StatusOK, `{"data":{"display_name":"token-superplane","policies":["default","superplane"],"ttl":0}}`)},
+		}
+
+		integrationCtx := testIntegration()
+		integrationCtx.Configuration["namespace"] = "admin/"
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ready", integrationCtx.State)
+		require.Len(t, httpContext.Requests, 1)
+		assert.Equal(t, "https://vault.example.com:8200/v1/auth/token/lookup-self", httpContext.Requests[0].URL.String())
+		assert.Equal(t, "hvs.token", httpContext.Requests[0].Header.Get("X-Vault-Token"))
+		assert.Equal(t, "admin", httpContext.Requests[0].Header.Get("X-Vault-Namespace"))
+		assert.Empty(t, integrationCtx.ResyncRequests)
+
+		metadata := integrationCtx.Metadata.(Metadata)
+		assert.Equal(t, AuthMethodToken, metadata.AuthMethod)
+		assert.Equal(t, []string{"default", "superplane"}, metadata.Policies)
+	})
+
+	t.Run("invalid token -> error", func(t *testing.T) {
+		integrationCtx := testIntegration()
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{jsonResponse(http.StatusForbidden, `{"errors":["permission denied"]}`)},
+			},
+			Integration: integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "permission denied")
+		assert.NotEqual(t, "ready", integrationCtx.State)
+	})
+
+	t.Run("exported secrets -> normalized into the metadata", func(t *testing.T) {
+		integrationCtx := testIntegration()
+		integrationCtx.Configuration["exportedSecrets"] = []any{
+			map[string]any{"path": "/apps/api/production/"},
+			map[string]any{"type": ExportedSecretTypeCredentials, "mount": "kv", "path": "database/creds/migrations", "prefix": " DB_ "},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{jsonResponse(http.StatusOK, `{"data":{"display_name":"token-superplane","policies":["default","superplane"],"ttl":0}}`)},
+			},
+			Integration: integrationCtx,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []ExportedSecret{
+			{Type: ExportedSecretTypeKV, Mount: DefaultKVMount, Path: "apps/api/production"},
+			{Type: ExportedSecretTypeCredentials, Path: "database/creds/migrations", Prefix: "DB_"},
+		}, integrationCtx.Metadata.(Metadata).ExportedSecrets)
+	})
+
+	t.Run("invalid exported secret prefix -> error", func(t *testing.T) {
+		integrationCtx := testIntegration()
+		integrationCtx.Configuration["exportedSecrets"] = []any{
+			map[string]any{"path": "apps/api/production", "prefix": "DB-"},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "exported secret 1: invalid prefix DB-")
+	})
+
+	t.Run("invalid address -> error", func(t *testing.T) {
+		integrationCtx := testIntegration()
+		integrationCtx.Configuration["address"] = "vault.example.com"
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "invalid address")
+	})
+
+	t.Run("AppRole -> logs in and schedules the token refresh", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"auth":{"client_token":"hvs.approle","policies":["superplane"],"lease_duration":3600,"renewable":true}}`),
+				jsonResponse(http.StatusOK, `{"data":{"display_name":"token-superplane","policies":["default","superplane"],"ttl":0}}`),
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"address":      "https://vault.example.com:8200/",
+				"authMethod":   AuthMethodAppRole,
+				"roleId":       "role-id",
+				"secretId":     "secret-id",
+				"approleMount": "/ci-approle/",
+			},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, httpContext.Requests, 2)
+		assert.Equal(t, "/v1/auth/ci-approle/login", httpContext.Requests[0].URL.Path)
+		assert.Empty(t, httpContext.Requests[0].Header.Get("X-Vault-Token"))
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"role_id":"role-id","secret_id":"secret-id"}`, string(body))
+
+		assert.Equal(t, "hvs.approle", string(integrationCtx.CurrentSecrets[SecretNameClientToken].Value))
+		assert.Equal(t, "hvs.approle", httpContext.Requests[1].Header.Get("X-Vault-Token"))
+		assert.Equal(t, []time.Duration{30 * time.Minute}, integrationCtx.ResyncRequests)
+		assert.Equal(t, "ready", integrationCtx.State)
+	})
+
+	t.Run("JWT -> logs in with a signed token", func(t *testing.T) {
+		httpContext := &contexts.HTTPContext{
+			Responses: []*http.Response{
+				jsonResponse(http.StatusOK, `{"auth":{"client_token":"hvs.jwt","policies":["superplane"],"lease_duration":1200}}`),
+				jsonResponse(http.StatusOK, `{"data":{"display_name":"token-superplane","policies":["default","superplane"],"ttl":0}}`),
+			},
+		}
+
+		integrationCtx := &contexts.IntegrationContext{
+			IntegrationID: "11111111-2222-3333-4444-555555555555",
+			Configuration: map[string]any{
+				"address":    "https://vault.example.com:8200",
+				"authMethod": AuthMethodJWT,
+				"jwtRole":    "superplane",
+				"audience":   "vault",
+			},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          httpContext,
+			Integration:   integrationCtx,
+			OIDC:          support.NewOIDCProvider(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "/v1/auth/jwt/login", httpContext.Requests[0].URL.Path)
+		body, err := io.ReadAll(httpContext.Requests[0].Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"role":"superplane","jwt":"test"}`, string(body))
+
+		assert.Equal(t, "hvs.jwt", httpContext.Requests[1].Header.Get("X-Vault-Token"))
+		assert.Equal(t, []time.Duration{10 * time.Minute}, integrationCtx.ResyncRequests)
+
+		metadata := integrationCtx.Metadata.(Metadata)
+		assert.Equal(t, "app-installation:11111111-2222-3333-4444-555555555555", metadata.Subject)
+	})
+
+	t.Run("JWT without OIDC provider -> error", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"address":    "https://vault.example.com:8200",
+				"authMethod": AuthMethodJWT,
+				"jwtRole":    "superplane",
+				"audience":   "vault",
+			},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP:          &contexts.HTTPContext{},
+			Integration:   integrationCtx,
+		})
+
+		require.ErrorContains(t, err, "OIDC provider is not configured")
+	})
+
+	t.Run("JWT login rejected -> error", func(t *testing.T) {
+		integrationCtx := &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"address":    "https://vault.example.com:8200",
+				"authMethod": AuthMethodJWT,
+				"jwtRole":    "superplane",
+				"audience":   "vault",
+			},
+		}
+
+		err := integration.Sync(core.SyncContext{
+			Configuration: integrationCtx.Configuration,
+			HTTP: &contexts.HTTPContext{
+				Responses: []*http.Response{
+					jsonResponse(http.StatusBadRequest, `{"errors":["error validating token: invalid audience (aud) claim"]}`),
+				},
+			},
+			Integration: integrationCtx,
+			OIDC:        support.NewOIDCProvider(),
+		})
+
+		require.ErrorContains(t, err, "invalid audience")
+		assert.Empty(t, integrationCtx.CurrentSecrets)
+	})
+}
+
+func Test__Vault__NewClient(t *testing.T) {
+	t.Run("AppRole without a stored token -> error", func(t *testing.T) {
+		_, err := NewClient(&contexts.HTTPContext{}, &contexts.IntegrationContext{
+			Configuration: map[string]any{
+				"address":    "https://vault.example.com:8200",
+				"authMethod": AuthMethodAppRole,
+			},
+		})
+
+		require.ErrorContains(t, err, "sync the integration")
+	})
+
+	t.Run("path segments are escaped", func(t *testing.T) {
+		assert.Equal(t, "secret/apps/my%20app", escapePath(" /secret/apps/my app/ "))
+	})
+}
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+const maxRequestBodyBytes = 4 << 20
+
+// CallerFunc resolves the authenticated caller of an HTTP request. It
+// returns false when the request is not authenticated.
+type CallerFunc func(r *http.Request) (Caller, bool)
+
+// HTTPHandler serves the MCP streamable HTTP transport. Every POST carries
+// one JSON-RPC message; responses are returned inline, as a single SSE
+// event when the client accepts text/event-stream. The server never
+// initiates messages, so there is no standalone GET stream.
+func HTTPHandler(server *Server, callerFor CallerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		caller, ok := callerFor(r)
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodyBytes+1))
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		if len(body) > maxRequestBodyBytes {
+			http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		response := server.Handle(r.Context(), caller, body)
+		if response == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		if acceptsEventStream(r) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("event: message\ndata: "))
+			_, _ = w.Write(response)
+			_, _ = w.Write([]byte("\n\n"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(response)
+	})
+}
+
+// acceptsEventStream prefers JSON when the client accepts both, since a
+// single response needs no stream.
+func acceptsEventStream(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "text/event-stream") && !strings.Contains(accept, "application/json")
+}
@@ -0,0 +1,204 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	"github.com/superplanehq/superplane/pkg/authorization"
+)
+
+const (
+	serverName    = "superplane"
+	serverVersion = "1.0.0"
+)
+
+const serverInstructions = "Tools operate on the SuperPlane organization that issued your API token. " +
+	"Call list_canvases to find canvas IDs, then pass canvas_id to the canvas tools. " +
+	"patch_staging stages edits for review in the SuperPlane UI; it never publishes them."
+
+// Caller is the authenticated principal behind an MCP request.
+type Caller struct {
+	UserID         string
+	OrganizationID string
+	// TokenScopes is the JSON-encoded scope list of a scoped token, or empty
+	// for unscoped credentials. It narrows the user's RBAC the same way it
+	// does for the REST API.
+	TokenScopes string
+}
+
+type routeAuthorizer interface {
+	AuthorizeRoute(
+		ctx context.Context,
+		route authorization.HTTPRoute,
+		userID string,
+		organizationID string,
+		tokenScopes string,
+		pathParams map[string]string,
+	) (context.Context, error)
+}
+
+// Server implements the tools subset of the Model Context Protocol over
+// JSON-RPC. It is transport agnostic: the HTTP handler and the stdio bridge
+// both feed it raw messages.
+type Server struct {
+	authorizer routeAuthorizer
+	tools      []Tool
+	byName     map[string]Tool
+}
+
+func NewServer(authorizer routeAuthorizer, tools []Tool) *Server {
+	byName := make(map[string]Tool, len(tools))
+	for _, tool := range tools {
+		if _, exists := byName[tool.Name]; exists {
+			panic(fmt.Sprintf("mcp tool %q already registered", tool.Name))
+		}
+		byName[tool.Name] = tool
+	}
+
+	return &Server{
+		authorizer: authorizer,
+		tools:      tools,
+		byName:     byName,
+	}
+}
+
+// Handle processes one JSON-RPC message and returns the encoded response.
+// It returns nil for notifications, which get no response.
+func (s *Server) Handle(ctx context.Context, caller Caller, message []byte) []byte {
+	message = bytes.TrimSpace(message)
+	if len(message) > 0 && message[0] == '[' {
+		return encodeResponse(errorResponse(nil, codeInvalidRequest, "batched requests are not supported"))
+	}
+
+	var req request
+	if err := json.Unmarshal(message, &req); err != nil {
+		return encodeResponse(errorResponse(nil, codeParseError, "invalid JSON"))
+	}
+	if req.JSONRPC != jsonRPCVersion || req.Method == "" {
+		if req.isNotification() {
+			return nil
+		}
+		return encodeResponse(errorResponse(req.ID, codeInvalidRequest, "invalid JSON-RPC request"))
+	}
+
+	result, rpcErr := s.dispatch(ctx, caller, &req)
+	if req.isNotification() {
+		return nil
+	}
+	if rpcErr != nil {
+		return encodeResponse(response{JSONRPC: jsonRPCVersion, ID: req.ID, Error: rpcErr})
+	}
+	return encodeResponse(response{JSONRPC: jsonRPCVersion, ID: req.ID, Result: result})
+}
+
+func (s *Server) dispatch(ctx context.Context, caller Caller, req *request) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var params initializeParams
+		if len(req.Params) > 0 {
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				return nil, &rpcError{Code: codeInvalidParams, Message: "invalid initialize params"}
+			}
+		}
+		return initializeResult{
+			ProtocolVersion: negotiateProtocolVersion(params.ProtocolVersion),
+			Capabilities:    map[string]any{"tools": map[string]any{"listChanged": false}},
+			ServerInfo:      serverInfo{Name: serverName, Version: serverVersion},
+			Instructions:    serverInstructions,
+		}, nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools(), nil
+	case "tools/call":
+		var params callToolParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "invalid tools/call params"}
+		}
+		tool, ok := s.byName[params.Name]
+		if !ok {
+			return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %q", params.Name)}
+		}
+		return s.callTool(ctx, caller, tool, params.Arguments), nil
+	default:
+		if strings.HasPrefix(req.Method, "notifications/") {
+			return nil, nil
+		}
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+	}
+}
+
+func (s *Server) listTools() listToolsResult {
+	tools := make([]toolDescriptor, 0, len(s.tools))
+	for _, tool := range s.tools {
+		descriptor := toolDescriptor{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: tool.InputSchema.Map(),
+		}
+		if tool.ReadOnly {
+			descriptor.Annotations = &toolHints{ReadOnlyHint: true}
+		}
+		tools = append(tools, descriptor)
+	}
+	return listToolsResult{Tools: tools}
+}
+
+// callTool reports tool failures in the result rather than as JSON-RPC
+// errors, so the model sees the message and can correct its arguments.
+func (s *Server) callTool(ctx context.Context, caller Caller, tool Tool, rawArgs json.RawMessage) callToolResult {
+	args := arguments{}
+	if len(bytes.TrimSpace(rawArgs)) > 0 && string(bytes.TrimSpace(rawArgs)) != "null" {
+		if err := json.Unmarshal(rawArgs, &args); err != nil {
+			return toolError("arguments must be a JSON object")
+		}
+	}
+
+	ctx = authentication.SetUserIdInMetadata(ctx, caller.UserID)
+	if tool.Route != nil {
+		pathParams, err := tool.pathParams(args)
+		if err != nil {
+			return toolError(err.Error())
+		}
+
+		ctx, err = s.authorizer.AuthorizeRoute(ctx, *tool.Route, caller.UserID, caller.OrganizationID, caller.TokenScopes, pathParams)
+		if err != nil {
+			return toolError("not found or not allowed for this token")
+		}
+	}
+
+	text, err := tool.Call(ctx, caller, args)
+	if err != nil {
+		log.WithError(err).WithField("tool", tool.Name).Debug("mcp tool call failed")
+		return toolError(err.Error())
+	}
+
+	return callToolResult{Content: []textContent{{Type: "text", Text: text}}}
+}
+
+func toolError(message string) callToolResult {
+	return callToolResult{
+		Content: []textContent{{Type: "text", Text: message}},
+		IsError: true,
+	}
+}
+
+func errorResponse(id json.RawMessage, code int, message string) response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return response{JSONRPC: jsonRPCVersion, ID: id, Error: &rpcError{Code: code, Message: message}}
+}
+
+func encodeResponse(resp response) []byte {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(errorResponse(resp.ID, codeInternalError, "failed to encode response"))
+	}
+	return data
+}
@@ -0,0 +1,270 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/agents"
+	agenttools "github.com/superplanehq/superplane/pkg/agents/agent_tools"
+	"github.com/superplanehq/superplane/pkg/authorization"
+)
+
+type fakeAuthorizer struct {
+	allow      bool
+	route      authorization.HTTPRoute
+	pathParams map[string]string
+	scopes     string
+}
+
+func (f *fakeAuthorizer) AuthorizeRoute(
+	ctx context.Context,
+	route authorization.HTTPRoute,
+	_ string,
+	_ string,
+	tokenScopes string,
+	pathParams map[string]string,
+) (context.Context, error) {
+	f.route = route
+	f.pathParams = pathParams
+	f.scopes = tokenScopes
+	if !f.allow {
+		return nil, errors.New("denied")
+	}
+	return ctx, nil
+}
+
+var testCaller = Caller{
+	UserID:         "3b1f6a47-8f0f-4a52-9b7e-4c3b0f1e2d11",
+	OrganizationID: "c2a8d1e4-5b6f-4d3a-8e9b-0a1b2c3d4e5f",
+	TokenScopes:    `["canvases:read"]`,
+}
+
+func echoTool() Tool {
+	return Tool{
+		Name:        "echo",
+		Description: "Echo the canvas ID.",
+		InputSchema: agents.CustomToolInputSchema{
+			Type:       "object",
+			Properties: map[string]agents.CustomToolInputSchema{"canvas_id": {Type: "string"}},
+			Required:   []string{"canvas_id"},
+		},
+		ReadOnly:   true,
+		Route:      route("GET", "/api/v1/canvases/{canvas_id}/runs"),
+		PathParams: map[string]string{authorization.CanvasIDPathParam: "canvas_id"},
+		Call: func(_ context.Context, _ Caller, args arguments) (string, error) {
+			return args["canvas_id"].(string), nil
+		},
+	}
+}
+
+func call(t *testing.T, server *Server, message string) map[string]any {
+	t.Helper()
+	raw := server.Handle(context.Background(), testCaller, []byte(message))
+	require.NotNil(t, raw)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+	return decoded
+}
+
+func TestServerInitialize(t *testing.T) {
+	server := NewServer(&fakeAuthorizer{allow: true}, []Tool{echoTool()})
+
+	response := call(t, server, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`)
+
+	result := response["result"].(map[string]any)
+	assert.Equal(t, "2025-03-26", result["protocolVersion"])
+	assert.Contains(t, result["capabilities"], "tools")
+	assert.Equal(t, serverName, result["serverInfo"].(map[string]any)["name"])
+
+	response = call(t, server, `{"jsonrpc":"2.0","id":2,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`)
+	assert.Equal(t, LatestProtocolVersion, response["result"].(map[string]any)["protocolVersion"])
+}
+
+func TestServerNotificationsGetNoResponse(t *testing.T) {
+	server := NewServer(&fakeAuthorizer{allow: true}, []Tool{echoTool()})
+
+	assert.Nil(t, server.Handle(context.Background(), testCaller, []byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+}
+
+func TestServerProtocolErrors(t *testing.T) {
+	server := NewServer(&fakeAuthorizer{allow: true}, []Tool{echoTool()})
+
+	response := call(t, server, `{not json`)
+	assert.Equal(t, float64(codeParseError), response["error"].(map[string]any)["code"])
+
+	response = call(t, server, `{"jsonrpc":"2.0","id":1,"method":"resources/list"}`)
+	assert.Equal(t, float64(codeMethodNotFound), response["error"].(map[string]any)["code"])
+
+	response = call(t, server, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"missing"}}`)
+	assert.Equal(t, float64(codeInvalidParams), response["error"].(map[string]any)["code"])
+}
+
+func TestServerListTools(t *testing.T) {
+	server := NewServer(&fakeAuthorizer{allow: true}, []Tool{echoTool()})
+
+	response := call(t, server, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+
+	tools := response["result"].(map[string]any)["tools"].([]any)
+	require.Len(t, tools, 1)
+	tool := tools[0].(map[string]any)
+	assert.Equal(t, "echo", tool["name"])
+	assert.Equal(t, "object", tool["inputSchema"].(map[string]any)["type"])
+	assert.Equal(t, true, tool["annotations"].(map[string]any)["readOnlyHint"])
+}
+
+func TestServerCallToolAuthorizesRoute(t *testing.T) {
+	authorizer := &fakeAuthorizer{allow: true}
+	server := NewServer(authorizer, []Tool{echoTool()})
+
+	response := call(t, server, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"canvas_id":"canvas-1"}}}`)
+
+	result := response["result"].(map[string]any)
+	assert.Nil(t, result["isError"])
+	assert.Equal(t, "canvas-1", result["content"].([]any)[0].(map[string]any)["text"])
+	assert.Equal(t, authorization.HTTPRoute{Method: "GET", Pattern: "/api/v1/canvases/{canvas_id}/runs"}, authorizer.route)
+	assert.Equal(t, map[string]string{"canvas_id": "canvas-1"}, authorizer.pathParams)
+	assert.Equal(t, testCaller.TokenScopes, authorizer.scopes)
+}
+
+func TestServerCallToolDenied(t *testing.T) {
+	called := false
+	tool := echoTool()
+	tool.Call = func(context.Context, Caller, arguments) (string, error) {
+		called = true
+		return "", nil
+	}
+	server := NewServer(&fakeAuthorizer{allow: false}, []Tool{tool})
+
+	response := call(t, server, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"canvas_id":"canvas-1"}}}`)
+
+	result := response["result"].(map[string]any)
+	assert.Equal(t, true, result["isError"])
+	assert.False(t, called)
+}
+
+func TestServerCallToolRequiresPathArguments(t *testing.T) {
+	server := NewServer(&fakeAuthorizer{allow: true}, []Tool{echoTool()})
+
+	response := call(t, server, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{}}}`)
+
+	result := response["result"].(map[string]any)
+	assert.Equal(t, true, result["isError"])
+	assert.Equal(t, "canvas_id is required", result["content"].([]any)[0].(map[string]any)["text"])
+}
+
+func TestNewToolsReusesAgentToolSchemas(t *testing.T) {
+	tools := NewTools(ToolDependencies{AgentTools: agenttools.NewRegistry(agenttools.Dependencies{})})
+
+	byName := map[string]Tool{}
+	for _, tool := range tools {
+		byName[tool.Name] = tool
+	}
+
+	for _, name := range []string{"list_canvases", "read_canvas", "read_runtime", "patch_staging", "list_work_orders", "create_work_order"} {
+		require.Contains(t, byName, name)
+	}
+
+	readRuntime := byName["read_runtime"]
+	assert.Contains(t, readRuntime.InputSchema.Properties, "resource")
+	assert.Contains(t, readRuntime.InputSchema.Properties, "run_id")
+	assert.NotContains(t, readRuntime.InputSchema.Properties, "action")
+	assert.Equal(t, []string{"canvas_id"}, readRuntime.InputSchema.Required)
+
+	// Every tool except the static component catalog is gated by a REST route
+	// with a registered authorization rule.
+	rules := authorization.DefaultAuthorizationRules()
+	for _, tool := range tools {
+		if tool.Name == "component_schema" {
+			assert.Nil(t, tool.Route)
+			continue
+		}
+		require.NotNil(t, tool.Route, tool.Name)
+		assert.Contains(t, rules, *tool.Route, tool.Name)
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	server := NewServer(&fakeAuthorizer{allow: true}, []Tool{echoTool()})
+	handler := HTTPHandler(server, func(r *http.Request) (Caller, bool) {
+		return testCaller, r.Header.Get("Authorization") != ""
+	})
+
+	post := func(body, accept string, authenticated bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/mcp", strings.NewReader(body))
+		req.Header.Set("Accept", accept)
+		if authenticated {
+			req.Header.Set("Authorization", "Bearer token")
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		recorder := post(`{"jsonrpc":"2.0","id":1,"method":"ping"}`, "application/json", false)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		recorder := post(`{"jsonrpc":"2.0","id":1,"method":"ping"}`, "application/json, text/event-stream", true)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":{}}`, recorder.Body.String())
+	})
+
+	t.Run("event stream", func(t *testing.T) {
+		recorder := post(`{"jsonrpc":"2.0","id":1,"method":"ping"}`, "text/event-stream", true)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(recorder.Body.String(), "event: message\ndata: {"))
+	})
+
+	t.Run("notification", func(t *testing.T) {
+		recorder := post(`{"jsonrpc":"2.0","method":"notifications/initialized"}`, "application/json", true)
+		assert.Equal(t, http.StatusAccepted, recorder.Code)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/mcp", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
+
+func TestStdioBridge(t *testing.T) {
+	server := NewServer(&fakeAuthorizer{allow: true}, []Tool{echoTool()})
+	httpServer := httptest.NewServer(HTTPHandler(server, func(r *http.Request) (Caller, bool) {
+		return testCaller, r.Header.Get("Authorization") == "Bearer secret"
+	}))
+	defer httpServer.Close()
+
+	in := strings.NewReader(strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"ping"}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"canvas_id":"c"}}}`,
+	}, "\n"))
+	var out bytes.Buffer
+
+	bridge := &StdioBridge{Endpoint: httpServer.URL, Token: "secret"}
+	require.NoError(t, bridge.Run(context.Background(), in, &out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":{}}`, lines[0])
+	assert.Contains(t, lines[1], `"text":"c"`)
+
+	out.Reset()
+	bridge.Token = "wrong"
+	require.NoError(t, bridge.Run(context.Background(), strings.NewReader(`{"jsonrpc":"2.0","id":7,"method":"ping"}`), &out))
+	assert.Contains(t, out.String(), `"id":7`)
+	assert.Contains(t, out.String(), `"error"`)
+}
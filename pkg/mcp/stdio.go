@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// StdioBridge relays newline-delimited JSON-RPC messages between a local
+// MCP client on stdio and the HTTP endpoint of a SuperPlane server, so
+// clients that only launch subprocesses can use the hosted tools.
+type StdioBridge struct {
+	Endpoint string
+	Token    string
+	Client   *http.Client
+}
+
+func (b *StdioBridge) Run(ctx context.Context, in io.Reader, out io.Writer) error {
+	client := b.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRequestBodyBytes)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		response, err := b.forward(ctx, client, line)
+		if err != nil {
+			response = bridgeError(line, err)
+		}
+		if len(response) == 0 {
+			continue
+		}
+
+		if _, err := out.Write(append(bytes.TrimSpace(response), '\n')); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (b *StdioBridge) forward(ctx context.Context, client *http.Client, message []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.Endpoint, bytes.NewReader(message))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+b.Token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRequestBodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusAccepted:
+		return nil, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("server responded with %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+// bridgeError turns a transport failure into a JSON-RPC error for the
+// request, since the stdio client would otherwise wait forever.
+func bridgeError(message []byte, err error) []byte {
+	var req request
+	if json.Unmarshal(message, &req) != nil || req.isNotification() {
+		return nil
+	}
+	return encodeResponse(errorResponse(req.ID, codeInternalError, err.Error()))
+}
@@ -0,0 +1,451 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/superplanehq/superplane/pkg/agents"
+	agenttools "github.com/superplanehq/superplane/pkg/agents/agent_tools"
+	"github.com/superplanehq/superplane/pkg/authorization"
+	canvasactions "github.com/superplanehq/superplane/pkg/grpc/actions/canvases"
+	factoryactions "github.com/superplanehq/superplane/pkg/grpc/actions/factories"
+	pb "github.com/superplanehq/superplane/pkg/protos/factories"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type arguments map[string]any
+
+// Tool is one MCP tool. Tools with a Route are authorized exactly like the
+// matching REST endpoint, so RBAC, scoped tokens, and experimental feature
+// gates apply the same way to both surfaces.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema agents.CustomToolInputSchema
+	ReadOnly    bool
+	Route       *authorization.HTTPRoute
+
+	// PathParams maps route path parameters to the tool arguments that
+	// carry their values.
+	PathParams map[string]string
+
+	Call func(ctx context.Context, caller Caller, args arguments) (string, error)
+}
+
+func (t Tool) pathParams(args arguments) (map[string]string, error) {
+	params := make(map[string]string, len(t.PathParams))
+	for param, argument := range t.PathParams {
+		value, _ := args[argument].(string)
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, fmt.Errorf("%s is required", argument)
+		}
+		params[param] = value
+	}
+	return params, nil
+}
+
+type ToolDependencies struct {
+	AgentTools        *agenttools.Registry
+	ComponentRegistry *registry.Registry
+}
+
+// NewTools builds the tool catalog. Canvas tools reuse the canvas agent's
+// actions and schemas; the agent session is replaced by the canvas named
+// in each call.
+func NewTools(deps ToolDependencies) []Tool {
+	tools := []Tool{listCanvasesTool(deps.ComponentRegistry)}
+	tools = append(tools, canvasActionTools(deps.AgentTools)...)
+	tools = append(tools, componentSchemaTool(deps.AgentTools))
+	tools = append(tools, workOrderTools()...)
+	return tools
+}
+
+func route(method, pattern string) *authorization.HTTPRoute {
+	return &authorization.HTTPRoute{Method: method, Pattern: pattern}
+}
+
+func listCanvasesTool(componentRegistry *registry.Registry) Tool {
+	return Tool{
+		Name:        "list_canvases",
+		Description: "List the canvases in the organization with their IDs, names, and descriptions.",
+		InputSchema: agents.CustomToolInputSchema{Type: "object", Properties: map[string]agents.CustomToolInputSchema{}},
+		ReadOnly:    true,
+		Route:       route("GET", "/api/v1/canvases"),
+		Call: func(ctx context.Context, caller Caller, _ arguments) (string, error) {
+			response, err := canvasactions.ListCanvases(ctx, componentRegistry, caller.OrganizationID, caller.UserID)
+			if err != nil {
+				return "", err
+			}
+
+			type canvasSummary struct {
+				ID          string `json:"id"`
+				Name        string `json:"name"`
+				Description string `json:"description,omitempty"`
+			}
+
+			canvases := make([]canvasSummary, 0, len(response.GetCanvases()))
+			for _, canvas := range response.GetCanvases() {
+				canvases = append(canvases, canvasSummary{
+					ID:          canvas.GetId(),
+					Name:        canvas.GetName(),
+					Description: canvas.GetDescription(),
+				})
+			}
+
+			return encodeText(map[string]any{"canvases": canvases})
+		},
+	}
+}
+
+type canvasActionTool struct {
+	name        string
+	action      string
+	description string
+	readOnly    bool
+	route       *authorization.HTTPRoute
+	pathParam   string
+	fields      []string
+}
+
+var canvasActionToolSpecs = []canvasActionTool{
+	{
+		name:        "read_canvas",
+		action:      "read",
+		description: "Read a canvas, including staged edits that are not published yet. Returns a compact summary unless include_canvas_yaml is set.",
+		readOnly:    true,
+		route:       route("GET", "/api/v1/canvases/{id}"),
+		pathParam:   authorization.IDPathParam,
+		fields:      []string{"include_console", "include_canvas_yaml", "include_integrations"},
+	},
+	{
+		name:        "read_runtime",
+		action:      "read_runtime",
+		description: "Read canvas runtime data: memory, runs, event and node executions, queue items, node events, and runner logs.",
+		readOnly:    true,
+		route:       route("GET", "/api/v1/canvases/{canvas_id}/runs"),
+		pathParam:   authorization.CanvasIDPathParam,
+		fields:      []string{"resource", "namespace", "node_id", "event_id", "execution_id", "run_id", "limit", "before", "states", "results"},
+	},
+	{
+		name:        "list_files",
+		action:      "list_files",
+		description: "List files in the canvas app repository.",
+		readOnly:    true,
+		route:       route("GET", "/api/v1/canvases/{canvas_id}/repository/files"),
+		pathParam:   authorization.CanvasIDPathParam,
+		fields:      []string{"query"},
+	},
+	{
+		name:        "read_file",
+		action:      "read_file",
+		description: "Read one or more files from the canvas app repository.",
+		readOnly:    true,
+		route:       route("GET", "/api/v1/canvases/{canvas_id}/repository/files"),
+		pathParam:   authorization.CanvasIDPathParam,
+		fields:      []string{"path", "paths"},
+	},
+	{
+		name:        "patch_staging",
+		action:      "patch_staging",
+		description: "Stage graph edits, console.yaml updates, or auto-layout on a canvas. Changes stay staged for review in SuperPlane and are never published by this tool.",
+		route:       route("PUT", "/api/v1/canvases/{canvas_id}/staging"),
+		pathParam:   authorization.CanvasIDPathParam,
+		fields:      []string{"version_id", "console_yaml", "patch_operations", "auto_layout"},
+	},
+}
+
+func canvasActionTools(agentTools *agenttools.Registry) []Tool {
+	definition, ok := agentTools.Definition(agenttools.AppAgentToolName)
+	if !ok {
+		panic(fmt.Sprintf("agent tool %q is not registered", agenttools.AppAgentToolName))
+	}
+	appSchema := definition.InputSchema()
+
+	tools := make([]Tool, 0, len(canvasActionToolSpecs))
+	for _, spec := range canvasActionToolSpecs {
+		properties := map[string]agents.CustomToolInputSchema{
+			"canvas_id": {
+				Type:        "string",
+				Description: "ID of the canvas, as returned by list_canvases.",
+			},
+		}
+		for _, field := range spec.fields {
+			property, ok := appSchema.Properties[field]
+			if !ok {
+				panic(fmt.Sprintf("agent tool %q has no %q input", agenttools.AppAgentToolName, field))
+			}
+			properties[field] = property
+		}
+
+		action := spec.action
+		tools = append(tools, Tool{
+			Name:        spec.name,
+			Description: spec.description,
+			InputSchema: agents.CustomToolInputSchema{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"canvas_id"},
+			},
+			ReadOnly:   spec.readOnly,
+			Route:      spec.route,
+			PathParams: map[string]string{spec.pathParam: "canvas_id"},
+			Call: func(ctx context.Context, caller Caller, args arguments) (string, error) {
+				input := make(arguments, len(args)+1)
+				for key, value := range args {
+					input[key] = value
+				}
+				input["action"] = action
+
+				canvasID, _ := args["canvas_id"].(string)
+				return executeAgentTool(ctx, agentTools, agents.AgentSessionContext{
+					OrganizationID: caller.OrganizationID,
+					UserID:         caller.UserID,
+					CanvasID:       strings.TrimSpace(canvasID),
+				}, agenttools.AppAgentToolName, input)
+			},
+		})
+	}
+
+	return tools
+}
+
+func componentSchemaTool(agentTools *agenttools.Registry) Tool {
+	definition, ok := agentTools.Definition(agenttools.ComponentSchemaAgentToolName)
+	if !ok {
+		panic(fmt.Sprintf("agent tool %q is not registered", agenttools.ComponentSchemaAgentToolName))
+	}
+
+	return Tool{
+		Name:        "component_schema",
+		Description: definition.Description(),
+		InputSchema: definition.InputSchema(),
+		ReadOnly:    true,
+		Call: func(ctx context.Context, caller Caller, args arguments) (string, error) {
+			return executeAgentTool(ctx, agentTools, agents.AgentSessionContext{
+				OrganizationID: caller.OrganizationID,
+				UserID:         caller.UserID,
+			}, agenttools.ComponentSchemaAgentToolName, args)
+		},
+	}
+}
+
+func executeAgentTool(ctx context.Context, agentTools *agenttools.Registry, session agents.AgentSessionContext, name string, input arguments) (string, error) {
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		return "", fmt.Errorf("encode arguments: %w", err)
+	}
+
+	result := agentTools.ExecuteCustomTool(ctx, session, agents.CustomToolUse{
+		Name:  name,
+		Input: string(encoded),
+	})
+	if result.IsError {
+		var payload struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal([]byte(result.Content), &payload) == nil && payload.Error != "" {
+			return "", fmt.Errorf("%s", payload.Error)
+		}
+		return "", fmt.Errorf("%s", result.Content)
+	}
+
+	return result.Content, nil
+}
+
+func workOrderTools() []Tool {
+	factoryID := agents.CustomToolInputSchema{Type: "string", Description: "Factory ID, as returned by list_factories."}
+	orderID := agents.CustomToolInputSchema{Type: "string", Description: "Work order ID."}
+	stringList := func(description string) agents.CustomToolInputSchema {
+		return agents.CustomToolInputSchema{Type: "array", Description: description, Items: &agents.CustomToolInputSchema{Type: "string"}}
+	}
+
+	return []Tool{
+		{
+			Name:        "list_factories",
+			Description: "List the factories in the organization.",
+			InputSchema: agents.CustomToolInputSchema{Type: "object", Properties: map[string]agents.CustomToolInputSchema{}},
+			ReadOnly:    true,
+			Route:       route("GET", "/api/v1/factories"),
+			Call: func(ctx context.Context, caller Caller, _ arguments) (string, error) {
+				response, err := factoryactions.ListFactories(ctx, caller.OrganizationID)
+				if err != nil {
+					return "", err
+				}
+				return encodeProto(response)
+			},
+		},
+		{
+			Name:        "list_work_orders",
+			Description: "List work orders in a factory, optionally filtered by assignee, state, result, or template.",
+			InputSchema: agents.CustomToolInputSchema{
+				Type: "object",
+				Properties: map[string]agents.CustomToolInputSchema{
+					"factory_id":   factoryID,
+					"assignee_ids": stringList("Only orders assigned to these user IDs."),
+					"states":       stringList("Only orders in these states: STATE_DRAFT, STATE_OPEN, STATE_CLOSED."),
+					"results":      stringList("Only closed orders with these results: RESULT_COMPLETED, RESULT_REJECTED, RESULT_FAILED."),
+					"unassigned":   {Type: "boolean", Description: "Only orders without assignees."},
+					"template_id":  {Type: "string", Description: "Only orders created from this template."},
+				},
+				Required: []string{"factory_id"},
+			},
+			ReadOnly:   true,
+			Route:      route("GET", "/api/v1/factories/{factory_id}/orders"),
+			PathParams: map[string]string{"factory_id": "factory_id"},
+			Call: func(ctx context.Context, caller Caller, args arguments) (string, error) {
+				req := &pb.ListWorkOrdersRequest{}
+				if err := decodeProto(args, req); err != nil {
+					return "", err
+				}
+				response, err := factoryactions.ListWorkOrders(ctx, caller.OrganizationID, req)
+				if err != nil {
+					return "", err
+				}
+				return encodeProto(response)
+			},
+		},
+		{
+			Name:        "describe_work_order",
+			Description: "Read a work order with its assignees, status, and fields.",
+			InputSchema: agents.CustomToolInputSchema{
+				Type: "object",
+				Properties: map[string]agents.CustomToolInputSchema{
+					"factory_id": factoryID,
+					"order_id":   orderID,
+				},
+				Required: []string{"factory_id", "order_id"},
+			},
+			ReadOnly:   true,
+			Route:      route("GET", "/api/v1/factories/{factory_id}/orders/{order_id}"),
+			PathParams: map[string]string{"factory_id": "factory_id", "order_id": "order_id"},
+			Call: func(ctx context.Context, caller Caller, args arguments) (string, error) {
+				req := &pb.DescribeWorkOrderRequest{}
+				if err := decodeProto(args, req); err != nil {
+					return "", err
+				}
+				response, err := factoryactions.DescribeWorkOrder(ctx, caller.OrganizationID, req)
+				if err != nil {
+					return "", err
+				}
+				return encodeProto(response)
+			},
+		},
+		{
+			Name:        "create_work_order",
+			Description: "Create a work order in a factory. Orders created from a template must set the template's required fields.",
+			InputSchema: agents.CustomToolInputSchema{
+				Type: "object",
+				Properties: map[string]agents.CustomToolInputSchema{
+					"factory_id":   factoryID,
+					"title":        {Type: "string", Description: "Work order title."},
+					"description":  {Type: "string", Description: "Markdown description."},
+					"assignee_ids": stringList("User IDs to assign."),
+					"budget_cents": {Type: "integer", Description: "Optional monthly spend budget in cents."},
+					"template_id":  {Type: "string", Description: "Optional work order template ID."},
+					"fields":       {Type: "object", Description: "Values for the template's custom fields, keyed by field name."},
+				},
+				Required: []string{"factory_id", "title"},
+			},
+			Route:      route("POST", "/api/v1/factories/{factory_id}/orders"),
+			PathParams: map[string]string{"factory_id": "factory_id"},
+			Call: func(ctx context.Context, caller Caller, args arguments) (string, error) {
+				req := &pb.CreateWorkOrderRequest{}
+				if err := decodeProto(args, req); err != nil {
+					return "", err
+				}
+				response, err := factoryactions.CreateWorkOrder(ctx, caller.OrganizationID, req)
+				if err != nil {
+					return "", err
+				}
+				return encodeProto(response)
+			},
+		},
+		{
+			Name:        "update_work_order_status",
+			Description: "Move a work order between states. Closing an order requires a result.",
+			InputSchema: agents.CustomToolInputSchema{
+				Type: "object",
+				Properties: map[string]agents.CustomToolInputSchema{
+					"factory_id": factoryID,
+					"order_id":   orderID,
+					"state":      {Type: "string", Enum: []string{"STATE_DRAFT", "STATE_OPEN", "STATE_CLOSED"}},
+					"result":     {Type: "string", Enum: []string{"RESULT_COMPLETED", "RESULT_REJECTED", "RESULT_FAILED"}},
+				},
+				Required: []string{"factory_id", "order_id", "state"},
+			},
+			Route:      route("PATCH", "/api/v1/factories/{factory_id}/orders/{order_id}/status"),
+			PathParams: map[string]string{"factory_id": "factory_id", "order_id": "order_id"},
+			Call: func(ctx context.Context, caller Caller, args arguments) (string, error) {
+				req := &pb.UpdateWorkOrderStatusRequest{}
+				if err := decodeProto(args, req); err != nil {
+					return "", err
+				}
+				response, err := factoryactions.UpdateWorkOrderStatus(ctx, caller.OrganizationID, req)
+				if err != nil {
+					return "", err
+				}
+				return encodeProto(response)
+			},
+		},
+		{
+			Name:        "add_work_order_comment",
+			Description: "Comment on a work order.",
+			InputSchema: agents.CustomToolInputSchema{
+				Type: "object",
+				Properties: map[string]agents.CustomToolInputSchema{
+					"factory_id":         factoryID,
+					"order_id":           orderID,
+					"body":               {Type: "string", Description: "Markdown comment body."},
+					"mentioned_user_ids": stringList("User IDs to notify."),
+				},
+				Required: []string{"factory_id", "order_id", "body"},
+			},
+			Route:      route("POST", "/api/v1/factories/{factory_id}/orders/{order_id}/comments"),
+			PathParams: map[string]string{"factory_id": "factory_id", "order_id": "order_id"},
+			Call: func(ctx context.Context, caller Caller, args arguments) (string, error) {
+				req := &pb.AddWorkOrderCommentRequest{}
+				if err := decodeProto(args, req); err != nil {
+					return "", err
+				}
+				response, err := factoryactions.AddWorkOrderComment(ctx, caller.OrganizationID, req)
+				if err != nil {
+					return "", err
+				}
+				return encodeProto(response)
+			},
+		},
+	}
+}
+
+func decodeProto(args arguments, message proto.Message) error {
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("encode arguments: %w", err)
+	}
+
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(encoded, message); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+func encodeProto(message proto.Message) (string, error) {
+	encoded, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return "", fmt.Errorf("encode result: %w", err)
+	}
+	return string(encoded), nil
+}
+
+func encodeText(value any) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("encode result: %w", err)
+	}
+	return string(encoded), nil
+}
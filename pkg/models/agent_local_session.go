@@ -0,0 +1,91 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrAgentLocalSessionNotFound = errors.New("agent local session not found")
+
+// AgentLocalSession holds the conversation state for agent providers that
+// manage sessions inside SuperPlane instead of on the provider side. State
+// is opaque to this package; the owning provider defines its shape.
+type AgentLocalSession struct {
+	ID        uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	Provider  string
+	State     datatypes.JSON
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+func (AgentLocalSession) TableName() string { return "agent_local_sessions" }
+
+func CreateAgentLocalSession(provider string, state []byte) (*AgentLocalSession, error) {
+	now := time.Now()
+	session := &AgentLocalSession{
+		ID:        uuid.New(),
+		Provider:  provider,
+		State:     datatypes.JSON(state),
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}
+
+	if err := database.Conn().Create(session).Error; err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func FindAgentLocalSession(id uuid.UUID) (*AgentLocalSession, error) {
+	var session AgentLocalSession
+	err := database.Conn().Where("id = ?", id).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAgentLocalSessionNotFound
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// UpdateAgentLocalSession locks the row, hands the current state to update
+// and stores whatever it returns. Concurrent writers (the API appending a
+// user message, the stream worker appending a model turn) are serialized.
+func UpdateAgentLocalSession(id uuid.UUID, update func(state []byte) ([]byte, error)) error {
+	return database.Conn().Transaction(func(tx *gorm.DB) error {
+		var session AgentLocalSession
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&session).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAgentLocalSessionNotFound
+			}
+			return err
+		}
+
+		state, err := update(session.State)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&session).Updates(map[string]any{
+			"state":      datatypes.JSON(state),
+			"updated_at": time.Now(),
+		}).Error
+	})
+}
+
+func DeleteAgentLocalSession(id uuid.UUID) error {
+	return database.Conn().Where("id = ?", id).Delete(&AgentLocalSession{}).Error
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	AgentPendingOperationDecisionConfirmed = "confirmed"
+	AgentPendingOperationDecisionDeclined  = "declined"
+)
+
+var (
+	ErrAgentPendingOperationNotFound     = errors.New("agent pending operation not found")
+	ErrAgentPendingOperationExpired      = errors.New("agent pending operation expired")
+	ErrAgentPendingOperationNotConfirmed = errors.New("agent pending operation not confirmed by the user")
+	ErrAgentPendingOperationDeclined     = errors.New("agent pending operation declined by the user")
+	ErrAgentPendingOperationDecided      = errors.New("agent pending operation already decided")
+)
+
+// AgentPendingOperation is a runtime operation the canvas agent asked to
+// run on behalf of the user. It only runs after the user confirms it through
+// its confirmation widget, so neither the agent nor a chat reply can
+// confirm it.
+type AgentPendingOperation struct {
+	ID        uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	SessionID uuid.UUID
+	Action    string
+	Input     datatypes.JSON
+	Summary   string
+	Decision  string
+	DecidedAt *time.Time
+	CreatedAt *time.Time
+	ExpiresAt *time.Time
+}
+
+func (AgentPendingOperation) TableName() string { return "agent_pending_operations" }
+
+func CreateAgentPendingOperation(sessionID uuid.UUID, action string, input []byte, summary string, ttl time.Duration) (*AgentPendingOperation, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	operation := &AgentPendingOperation{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		Action:    action,
+		Input:     datatypes.JSON(input),
+		Summary:   summary,
+		CreatedAt: &now,
+		ExpiresAt: &expiresAt,
+	}
+
+	if err := database.Conn().Create(operation).Error; err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+func FindAgentPendingOperation(id uuid.UUID) (*AgentPendingOperation, error) {
+	var operation AgentPendingOperation
+	err := database.Conn().Where("id = ?", id).First(&operation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAgentPendingOperationNotFound
+		}
+		return nil, err
+	}
+
+	return &operation, nil
+}
+
+// DecideAgentPendingOperation records the user's decision on a pending
+// operation. A decision is final: a declined operation cannot be confirmed
+// later, and the other way around.
+func DecideAgentPendingOperation(sessionID, id uuid.UUID, decision string) (*AgentPendingOperation, error) {
+	if decision != AgentPendingOperationDecisionConfirmed && decision != AgentPendingOperationDecisionDeclined {
+		return nil, fmt.Errorf("invalid decision %q", decision)
+	}
+
+	var operation AgentPendingOperation
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			Where("session_id = ?", sessionID).
+			First(&operation).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAgentPendingOperationNotFound
+			}
+			return err
+		}
+
+		if operation.ExpiresAt != nil && time.Now().After(*operation.ExpiresAt) {
+			return ErrAgentPendingOperationExpired
+		}
+
+		if operation.Decision != "" {
+			return ErrAgentPendingOperationDecided
+		}
+
+		now := time.Now()
+		operation.Decision = decision
+		operation.DecidedAt = &now
+		return tx.
+			Model(&operation).
+			Updates(map[string]any{"decision": decision, "decided_at": now}).
+			Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &operation, nil
+}
+
+// ConsumeAgentPendingOperation removes the pending operation and returns it
+// when the user has confirmed it. Expired and declined operations are
+// removed without being returned. An undecided operation stays pending so
+// it can be retried after the user answers.
+func ConsumeAgentPendingOperation(sessionID, id uuid.UUID, action string) (*AgentPendingOperation, error) {
+	var operation AgentPendingOperation
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			Where("session_id = ?", sessionID).
+			Where("action = ?", action).
+			First(&operation).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAgentPendingOperationNotFound
+			}
+			return err
+		}
+
+		if operation.ExpiresAt != nil && time.Now().After(*operation.ExpiresAt) {
+			return ErrAgentPendingOperationExpired
+		}
+
+		switch operation.Decision {
+		case AgentPendingOperationDecisionConfirmed:
+			return tx.Delete(&operation).Error
+		case AgentPendingOperationDecisionDeclined:
+			return ErrAgentPendingOperationDeclined
+		default:
+			return ErrAgentPendingOperationNotConfirmed
+		}
+	})
+
+	// Returning an error rolls the transaction back, so expired and
+	// declined rows are removed separately.
+	if errors.Is(err, ErrAgentPendingOperationExpired) || errors.Is(err, ErrAgentPendingOperationDeclined) {
+		_ = database.Conn().Where("id = ?", id).Delete(&AgentPendingOperation{}).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &operation, nil
+}
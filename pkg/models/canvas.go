@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -240,6 +241,38 @@ func FindCanvasWithoutOrgScopeInTransaction(tx *gorm.DB, id uuid.UUID) (*Canvas,
 	return &canvas, nil
 }
 
+// canvasOrganizations caches the organization of each canvas. A canvas
+// never moves to another organization, so entries never go stale.
+var canvasOrganizations sync.Map
+
+// FindCanvasOrganizationID returns the organization of a canvas, including
+// deleted ones. Only the first call for a canvas queries the database;
+// later calls are served from memory, so metric recorders can use it.
+func FindCanvasOrganizationID(canvasID uuid.UUID) (uuid.UUID, error) {
+	if organizationID, ok := canvasOrganizations.Load(canvasID); ok {
+		return organizationID.(uuid.UUID), nil
+	}
+
+	var organizationIDs []uuid.UUID
+	err := database.Conn().
+		Unscoped().
+		Model(&Canvas{}).
+		Where("id = ?", canvasID).
+		Limit(1).
+		Pluck("organization_id", &organizationIDs).
+		Error
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if len(organizationIDs) == 0 {
+		return uuid.Nil, gorm.ErrRecordNotFound
+	}
+
+	canvasOrganizations.Store(canvasID, organizationIDs[0])
+	return organizationIDs[0], nil
+}
+
 func FindUnscopedCanvas(id uuid.UUID) (*Canvas, error) {
 	return FindUnscopedCanvasInTransaction(database.Conn(), id)
 }
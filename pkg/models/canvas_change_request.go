@@ -0,0 +1,296 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	CanvasChangeRequestStatusOpen   = "open"
+	CanvasChangeRequestStatusMerged = "merged"
+	CanvasChangeRequestStatusClosed = "closed"
+
+	CanvasChangeRequestEventComment  = "comment"
+	CanvasChangeRequestEventApproved = "approved"
+	CanvasChangeRequestEventRejected = "rejected"
+	CanvasChangeRequestEventUpdated  = "updated"
+	CanvasChangeRequestEventMerged   = "merged"
+	CanvasChangeRequestEventClosed   = "closed"
+
+	canvasChangeRequestOpenAuthorIndex = "canvas_change_requests_open_author_idx"
+)
+
+var (
+	ErrCanvasChangeRequestNotFound    = errors.New("change request not found")
+	ErrCanvasChangeRequestAlreadyOpen = errors.New("an open change request already exists for this author")
+)
+
+// CanvasChangeRequestFile is a snapshot of one staged file.
+// Snapshots keep reviewed content immutable while the
+// author goes on editing their staging.
+type CanvasChangeRequestFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	Deleted bool   `json:"deleted"`
+}
+
+type CanvasChangeRequest struct {
+	ID              uuid.UUID
+	OrganizationID  uuid.UUID
+	CanvasID        uuid.UUID
+	AuthorID        uuid.UUID
+	BaseVersionID   uuid.UUID
+	Title           string
+	Description     string
+	Status          string
+	Files           datatypes.JSONSlice[CanvasChangeRequestFile]
+	MergedVersionID *uuid.UUID
+	ClosedBy        *uuid.UUID
+	ClosedAt        *time.Time
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
+}
+
+func (r *CanvasChangeRequest) TableName() string {
+	return "canvas_change_requests"
+}
+
+func (r *CanvasChangeRequest) IsOpen() bool {
+	return r.Status == CanvasChangeRequestStatusOpen
+}
+
+// CanvasChangeRequestEvent is one entry in the thread of a change request:
+// a comment, a review decision, a resubmission, the merge or the close.
+type CanvasChangeRequestEvent struct {
+	ID              uuid.UUID
+	ChangeRequestID uuid.UUID
+	UserID          uuid.UUID
+	Type            string
+	Body            string
+	CreatedAt       *time.Time
+}
+
+func (e *CanvasChangeRequestEvent) TableName() string {
+	return "canvas_change_request_events"
+}
+
+func (e *CanvasChangeRequestEvent) IsReview() bool {
+	return e.Type == CanvasChangeRequestEventApproved || e.Type == CanvasChangeRequestEventRejected
+}
+
+func CreateCanvasChangeRequest(tx *gorm.DB, request *CanvasChangeRequest) error {
+	now := time.Now()
+	if request.ID == uuid.Nil {
+		request.ID = uuid.New()
+	}
+
+	request.Status = CanvasChangeRequestStatusOpen
+	request.CreatedAt = &now
+	request.UpdatedAt = &now
+
+	err := tx.Create(request).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == canvasChangeRequestOpenAuthorIndex {
+		return ErrCanvasChangeRequestAlreadyOpen
+	}
+
+	return err
+}
+
+func FindCanvasChangeRequest(tx *gorm.DB, canvasID, id uuid.UUID) (*CanvasChangeRequest, error) {
+	return findCanvasChangeRequest(tx, canvasID, id)
+}
+
+// LockCanvasChangeRequest serializes reviews, updates and merges of
+// the same change request, so a merge never publishes content that
+// was resubmitted after the approvals were checked.
+func LockCanvasChangeRequest(tx *gorm.DB, canvasID, id uuid.UUID) (*CanvasChangeRequest, error) {
+	return findCanvasChangeRequest(tx.Clauses(clause.Locking{Strength: "UPDATE"}), canvasID, id)
+}
+
+func findCanvasChangeRequest(tx *gorm.DB, canvasID, id uuid.UUID) (*CanvasChangeRequest, error) {
+	var request CanvasChangeRequest
+	err := tx.
+		Where("canvas_id = ?", canvasID).
+		Where("id = ?", id).
+		First(&request).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCanvasChangeRequestNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// ListCanvasChangeRequests returns the newest change requests first.
+// An empty status lists change requests in any status.
+func ListCanvasChangeRequests(tx *gorm.DB, canvasID uuid.UUID, status string, limit int) ([]CanvasChangeRequest, error) {
+	query := tx.
+		Where("canvas_id = ?", canvasID).
+		Order("created_at DESC, id DESC")
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var requests []CanvasChangeRequest
+	if err := query.Find(&requests).Error; err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+func (r *CanvasChangeRequest) UpdateSpec(tx *gorm.DB, title, description string) error {
+	now := time.Now()
+	r.Title = title
+	r.Description = description
+	r.UpdatedAt = &now
+
+	return tx.Model(r).Updates(map[string]any{
+		"title":       title,
+		"description": description,
+		"updated_at":  now,
+	}).Error
+}
+
+func (r *CanvasChangeRequest) UpdateFiles(tx *gorm.DB, baseVersionID uuid.UUID, files []CanvasChangeRequestFile) error {
+	now := time.Now()
+	r.BaseVersionID = baseVersionID
+	r.Files = datatypes.NewJSONSlice(files)
+	r.UpdatedAt = &now
+
+	return tx.Model(r).Updates(map[string]any{
+		"base_version_id": baseVersionID,
+		"files":           r.Files,
+		"updated_at":      now,
+	}).Error
+}
+
+func (r *CanvasChangeRequest) MarkMerged(tx *gorm.DB, userID, versionID uuid.UUID) error {
+	return r.finish(tx, CanvasChangeRequestStatusMerged, userID, &versionID)
+}
+
+func (r *CanvasChangeRequest) MarkClosed(tx *gorm.DB, userID uuid.UUID) error {
+	return r.finish(tx, CanvasChangeRequestStatusClosed, userID, nil)
+}
+
+func (r *CanvasChangeRequest) finish(tx *gorm.DB, status string, userID uuid.UUID, versionID *uuid.UUID) error {
+	now := time.Now()
+	r.Status = status
+	r.ClosedBy = &userID
+	r.ClosedAt = &now
+	r.MergedVersionID = versionID
+	r.UpdatedAt = &now
+
+	return tx.Model(r).Updates(map[string]any{
+		"status":            status,
+		"closed_by":         userID,
+		"closed_at":         now,
+		"merged_version_id": versionID,
+		"updated_at":        now,
+	}).Error
+}
+
+func CreateCanvasChangeRequestEvent(tx *gorm.DB, changeRequestID, userID uuid.UUID, eventType, body string) (*CanvasChangeRequestEvent, error) {
+	now := time.Now()
+	event := CanvasChangeRequestEvent{
+		ID:              uuid.New(),
+		ChangeRequestID: changeRequestID,
+		UserID:          userID,
+		Type:            eventType,
+		Body:            body,
+		CreatedAt:       &now,
+	}
+
+	if err := tx.Create(&event).Error; err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// ListCanvasChangeRequestEvents returns the thread oldest first.
+func ListCanvasChangeRequestEvents(tx *gorm.DB, changeRequestID uuid.UUID) ([]CanvasChangeRequestEvent, error) {
+	var events []CanvasChangeRequestEvent
+	err := tx.
+		Where("change_request_id = ?", changeRequestID).
+		Order("created_at ASC, id ASC").
+		Find(&events).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func ListCanvasChangeRequestEventsForRequests(tx *gorm.DB, changeRequestIDs []uuid.UUID) (map[uuid.UUID][]CanvasChangeRequestEvent, error) {
+	result := make(map[uuid.UUID][]CanvasChangeRequestEvent, len(changeRequestIDs))
+	if len(changeRequestIDs) == 0 {
+		return result, nil
+	}
+
+	var events []CanvasChangeRequestEvent
+	err := tx.
+		Where("change_request_id IN ?", changeRequestIDs).
+		Order("created_at ASC, id ASC").
+		Find(&events).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		result[event.ChangeRequestID] = append(result[event.ChangeRequestID], event)
+	}
+
+	return result, nil
+}
+
+// CurrentCanvasChangeRequestReviews returns the latest review of each reviewer
+// given after the last resubmission, in the order they were given.
+// Reviews of earlier content no longer count.
+func CurrentCanvasChangeRequestReviews(events []CanvasChangeRequestEvent) []CanvasChangeRequestEvent {
+	latest := map[uuid.UUID]int{}
+	reviews := []CanvasChangeRequestEvent{}
+
+	for _, event := range events {
+		if event.Type == CanvasChangeRequestEventUpdated {
+			latest = map[uuid.UUID]int{}
+			reviews = []CanvasChangeRequestEvent{}
+			continue
+		}
+
+		if !event.IsReview() {
+			continue
+		}
+
+		if i, ok := latest[event.UserID]; ok {
+			reviews[i] = event
+			continue
+		}
+
+		latest[event.UserID] = len(reviews)
+		reviews = append(reviews, event)
+	}
+
+	return reviews
+}
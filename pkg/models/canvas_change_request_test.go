@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test__CurrentCanvasChangeRequestReviews(t *testing.T) {
+	alice := uuid.New()
+	bob := uuid.New()
+
+	event := func(userID uuid.UUID, eventType string) CanvasChangeRequestEvent {
+		return CanvasChangeRequestEvent{ID: uuid.New(), UserID: userID, Type: eventType}
+	}
+
+	t.Run("latest review of each user counts", func(t *testing.T) {
+		reviews := CurrentCanvasChangeRequestReviews([]CanvasChangeRequestEvent{
+			event(alice, CanvasChangeRequestEventRejected),
+			event(bob, CanvasChangeRequestEventComment),
+			event(bob, CanvasChangeRequestEventApproved),
+			event(alice, CanvasChangeRequestEventApproved),
+		})
+
+		require.Len(t, reviews, 2)
+		assert.Equal(t, alice, reviews[0].UserID)
+		assert.Equal(t, CanvasChangeRequestEventApproved, reviews[0].Type)
+		assert.Equal(t, bob, reviews[1].UserID)
+	})
+
+	t.Run("resubmission dismisses earlier reviews", func(t *testing.T) {
+		reviews := CurrentCanvasChangeRequestReviews([]CanvasChangeRequestEvent{
+			event(alice, CanvasChangeRequestEventApproved),
+			event(bob, CanvasChangeRequestEventUpdated),
+			event(bob, CanvasChangeRequestEventComment),
+		})
+
+		assert.Empty(t, reviews)
+	})
+}
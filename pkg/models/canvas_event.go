@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/telemetry/runtrace"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -25,6 +26,12 @@ type CanvasEvent struct {
 	RunID       uuid.UUID
 	State       string
 	CreatedAt   *time.Time
+
+	//
+	// W3C traceparent of the run trace. Root events start a new trace,
+	// and events emitted by executions continue the trace of the execution.
+	//
+	TraceParent string
 }
 
 func (e *CanvasEvent) TableName() string {
@@ -32,6 +39,10 @@ func (e *CanvasEvent) TableName() string {
 }
 
 func (e *CanvasEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ExecutionID == nil && e.TraceParent == "" {
+		e.TraceParent = runtrace.Start(tx.Statement.Context, e.WorkflowID.String(), e.NodeID, e.Channel)
+	}
+
 	if e.RunID != uuid.Nil {
 		return nil
 	}
@@ -39,7 +50,7 @@ func (e *CanvasEvent) BeforeCreate(tx *gorm.DB) error {
 	if e.ExecutionID != nil {
 		var execution CanvasNodeExecution
 		err := tx.
-			Select("run_id").
+			Select("run_id", "trace_parent").
 			Where("id = ?", *e.ExecutionID).
 			First(&execution).
 			Error
@@ -48,6 +59,10 @@ func (e *CanvasEvent) BeforeCreate(tx *gorm.DB) error {
 		}
 
 		e.RunID = execution.RunID
+		if e.TraceParent == "" {
+			e.TraceParent = execution.TraceParent
+		}
+
 		return nil
 	}
 
@@ -0,0 +1,332 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	//
+	// Remote pushes are ignored, and SuperPlane overwrites the mirrored path.
+	//
+	CanvasGitMirrorImportModeDisabled = "disabled"
+
+	//
+	// Remote pushes are staged for the user who configured the mirror.
+	//
+	CanvasGitMirrorImportModeStaging = "staging"
+
+	//
+	// Remote pushes are published as a new canvas version.
+	//
+	CanvasGitMirrorImportModePublish = "publish"
+
+	CanvasGitMirrorDirectionPush   = "push"
+	CanvasGitMirrorDirectionImport = "import"
+
+	CanvasGitMirrorEventStatusSucceeded = "succeeded"
+	CanvasGitMirrorEventStatusConflict  = "conflict"
+	CanvasGitMirrorEventStatusFailed    = "failed"
+
+	CanvasGitMirrorResolutionKeepCanvas = "keep_canvas"
+	CanvasGitMirrorResolutionKeepRemote = "keep_remote"
+)
+
+var ErrCanvasGitMirrorNotFound = errors.New("git mirror not found")
+
+// CanvasGitMirror keeps a canvas in sync with a directory on a branch of
+// an external repository. RemoteHeadSHA is the last remote commit known
+// to match LastPushedVersionID, so changes on either side are detected
+// by comparing against them.
+type CanvasGitMirror struct {
+	ID                  uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	OrganizationID      uuid.UUID
+	CanvasID            uuid.UUID
+	IntegrationID       uuid.UUID
+	Repository          string
+	Branch              string
+	Path                string
+	ImportMode          string
+	CreatedBy           uuid.UUID
+	LastPushedVersionID *uuid.UUID
+	RemoteHeadSHA       string
+	LastError           string
+	LastSyncedAt        *time.Time
+	NextSyncAt          time.Time
+	CreatedAt           *time.Time
+	UpdatedAt           *time.Time
+}
+
+func (CanvasGitMirror) TableName() string { return "canvas_git_mirrors" }
+
+type CanvasGitMirrorEvent struct {
+	ID              uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	MirrorID        uuid.UUID
+	CanvasID        uuid.UUID
+	Direction       string
+	Status          string
+	VersionID       *uuid.UUID
+	RemoteCommitSHA string
+	Message         string
+	CreatedAt       *time.Time
+}
+
+func (CanvasGitMirrorEvent) TableName() string { return "canvas_git_mirror_events" }
+
+func IsValidCanvasGitMirrorImportMode(mode string) bool {
+	switch mode {
+	case CanvasGitMirrorImportModeDisabled, CanvasGitMirrorImportModeStaging, CanvasGitMirrorImportModePublish:
+		return true
+	}
+
+	return false
+}
+
+func FindCanvasGitMirror(canvasID uuid.UUID) (*CanvasGitMirror, error) {
+	return FindCanvasGitMirrorInTransaction(database.Conn(), canvasID)
+}
+
+func FindCanvasGitMirrorInTransaction(tx *gorm.DB, canvasID uuid.UUID) (*CanvasGitMirror, error) {
+	var mirror CanvasGitMirror
+	err := tx.Where("canvas_id = ?", canvasID).First(&mirror).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCanvasGitMirrorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &mirror, nil
+}
+
+// SaveCanvasGitMirror creates or reconfigures the mirror of a canvas.
+// Pointing it somewhere else resets the sync state, so the next sync
+// pushes the live version on top of whatever the new branch contains.
+func SaveCanvasGitMirror(tx *gorm.DB, mirror *CanvasGitMirror) (*CanvasGitMirror, error) {
+	now := time.Now()
+	mirror.NextSyncAt = now
+	mirror.UpdatedAt = &now
+
+	existing, err := FindCanvasGitMirrorInTransaction(tx, mirror.CanvasID)
+	if err != nil && !errors.Is(err, ErrCanvasGitMirrorNotFound) {
+		return nil, err
+	}
+
+	if existing == nil {
+		mirror.CreatedAt = &now
+		if err := tx.Clauses(clause.Returning{}).Create(mirror).Error; err != nil {
+			return nil, err
+		}
+		return mirror, nil
+	}
+
+	updates := map[string]any{
+		"integration_id": mirror.IntegrationID,
+		"repository":     mirror.Repository,
+		"branch":         mirror.Branch,
+		"path":           mirror.Path,
+		"import_mode":    mirror.ImportMode,
+		"created_by":     mirror.CreatedBy,
+		"next_sync_at":   now,
+		"updated_at":     now,
+	}
+
+	if existing.IntegrationID != mirror.IntegrationID ||
+		existing.Repository != mirror.Repository ||
+		existing.Branch != mirror.Branch ||
+		existing.Path != mirror.Path {
+		updates["last_pushed_version_id"] = nil
+		updates["remote_head_sha"] = ""
+		updates["last_error"] = ""
+	}
+
+	if err := tx.Model(existing).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+
+	return FindCanvasGitMirrorInTransaction(tx, mirror.CanvasID)
+}
+
+func DeleteCanvasGitMirror(tx *gorm.DB, canvasID uuid.UUID) error {
+	result := tx.Where("canvas_id = ?", canvasID).Delete(&CanvasGitMirror{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCanvasGitMirrorNotFound
+	}
+
+	return nil
+}
+
+// RequestSync makes the mirror due on the next worker tick.
+//
+// A conflict is resolved by forgetting the sync state of the losing side.
+// Forgetting the remote head makes the next sync push the live version
+// over the remote branch, and marking the live version as already pushed
+// makes the next sync import the remote branch.
+func (m *CanvasGitMirror) RequestSync(tx *gorm.DB, resolution string, liveVersionID uuid.UUID) error {
+	now := time.Now()
+	updates := map[string]any{
+		"next_sync_at": now,
+		"updated_at":   now,
+	}
+
+	switch resolution {
+	case CanvasGitMirrorResolutionKeepCanvas:
+		m.RemoteHeadSHA = ""
+		updates["remote_head_sha"] = ""
+	case CanvasGitMirrorResolutionKeepRemote:
+		m.LastPushedVersionID = &liveVersionID
+		updates["last_pushed_version_id"] = liveVersionID
+	}
+
+	m.NextSyncAt = now
+	m.UpdatedAt = &now
+	return tx.Model(m).Updates(updates).Error
+}
+
+func ListDueCanvasGitMirrors(now time.Time, limit int) ([]CanvasGitMirror, error) {
+	var mirrors []CanvasGitMirror
+	err := database.Conn().
+		Where("next_sync_at <= ?", now).
+		Order("next_sync_at ASC").
+		Limit(limit).
+		Find(&mirrors).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mirrors, nil
+}
+
+// LeaseCanvasGitMirror claims a due mirror by pushing next_sync_at past
+// the sync window, so no other worker picks it up while it syncs.
+func LeaseCanvasGitMirror(tx *gorm.DB, id uuid.UUID, lease time.Duration) (*CanvasGitMirror, error) {
+	var mirror CanvasGitMirror
+
+	now := time.Now()
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("id = ?", id).
+		Where("next_sync_at <= ?", now).
+		First(&mirror).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	leasedUntil := now.Add(lease)
+	err = tx.Model(&mirror).
+		Update("next_sync_at", leasedUntil).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	mirror.NextSyncAt = leasedUntil
+	return &mirror, nil
+}
+
+// MarkSynced records that the remote head and the version are in sync.
+func (m *CanvasGitMirror) MarkSynced(tx *gorm.DB, versionID uuid.UUID, remoteHeadSHA string, nextSyncAt time.Time) error {
+	now := time.Now()
+	m.LastPushedVersionID = &versionID
+	m.RemoteHeadSHA = remoteHeadSHA
+	m.LastError = ""
+	m.LastSyncedAt = &now
+	m.NextSyncAt = nextSyncAt
+
+	return tx.Model(m).Updates(map[string]any{
+		"last_pushed_version_id": versionID,
+		"remote_head_sha":        remoteHeadSHA,
+		"last_error":             "",
+		"last_synced_at":         now,
+		"next_sync_at":           nextSyncAt,
+		"updated_at":             now,
+	}).Error
+}
+
+// MarkChecked records a sync attempt that left the sync state unchanged.
+// An empty lastError means both sides were found to be in sync.
+func (m *CanvasGitMirror) MarkChecked(tx *gorm.DB, lastError string, nextSyncAt time.Time) error {
+	now := time.Now()
+	m.LastError = lastError
+	m.NextSyncAt = nextSyncAt
+
+	updates := map[string]any{
+		"last_error":   lastError,
+		"next_sync_at": nextSyncAt,
+		"updated_at":   now,
+	}
+
+	if lastError == "" {
+		m.LastSyncedAt = &now
+		updates["last_synced_at"] = now
+	}
+
+	return tx.Model(m).Updates(updates).Error
+}
+
+// CreateCanvasGitMirrorEvent records a sync outcome. Conflicts and
+// failures repeat on every tick until someone acts, so an event equal
+// to the latest one for the mirror is not recorded again.
+func CreateCanvasGitMirrorEvent(tx *gorm.DB, event *CanvasGitMirrorEvent) error {
+	var latest CanvasGitMirrorEvent
+	err := tx.
+		Where("mirror_id = ?", event.MirrorID).
+		Order("created_at DESC").
+		First(&latest).
+		Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err == nil &&
+		latest.Direction == event.Direction &&
+		latest.Status == event.Status &&
+		latest.RemoteCommitSHA == event.RemoteCommitSHA &&
+		latest.Message == event.Message {
+		return nil
+	}
+
+	now := time.Now()
+	event.CreatedAt = &now
+	return tx.Clauses(clause.Returning{}).Create(event).Error
+}
+
+func ListCanvasGitMirrorEvents(tx *gorm.DB, canvasID uuid.UUID, limit int) ([]CanvasGitMirrorEvent, error) {
+	return ListCanvasGitMirrorEventsBetween(tx, canvasID, nil, nil, limit)
+}
+
+// ListCanvasGitMirrorEventsBetween lists events newest first, in the
+// (after, before) window, so they can be shown next to a page of
+// canvas versions.
+func ListCanvasGitMirrorEventsBetween(tx *gorm.DB, canvasID uuid.UUID, after, before *time.Time, limit int) ([]CanvasGitMirrorEvent, error) {
+	query := tx.
+		Where("canvas_id = ?", canvasID).
+		Order("created_at DESC")
+
+	if after != nil {
+		query = query.Where("created_at > ?", *after)
+	}
+	if before != nil {
+		query = query.Where("created_at < ?", *before)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var events []CanvasGitMirrorEvent
+	if err := query.Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -3,12 +3,14 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/superplanehq/superplane/pkg/database"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
@@ -16,6 +18,10 @@ const (
 	CanvasMemorySourceManual = "manual"
 )
 
+// canvasMemoryNotExpired hides records past their TTL
+// until the cleanup worker deletes them.
+const canvasMemoryNotExpired = "(expires_at IS NULL OR expires_at > NOW())"
+
 type CanvasMemory struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	CreatedAt time.Time
@@ -24,6 +30,13 @@ type CanvasMemory struct {
 	Namespace string
 	Values    datatypes.JSONType[any]
 	Source    string
+	ExpiresAt *time.Time
+
+	//
+	// Copy of the values under the indexed keys of the namespace,
+	// kept in its own GIN-indexed column for fast matches.
+	//
+	IndexedValues datatypes.JSONType[map[string]any]
 }
 
 func (CanvasMemory) TableName() string {
@@ -35,21 +48,80 @@ func AddCanvasMemoryRecordInTransaction(tx *gorm.DB, canvasID uuid.UUID, namespa
 }
 
 func AddCanvasMemoryRecordWithSourceInTransaction(tx *gorm.DB, canvasID uuid.UUID, namespace string, values any, source string) (CanvasMemory, error) {
+	return AddCanvasMemoryRecordWithTTLInTransaction(tx, canvasID, namespace, values, source, nil)
+}
+
+// AddCanvasMemoryRecordWithTTLInTransaction validates the values against the
+// namespace schema before adding them. Without a TTL, the default TTL of
+// the namespace applies, and records without either never expire.
+func AddCanvasMemoryRecordWithTTLInTransaction(
+	tx *gorm.DB,
+	canvasID uuid.UUID,
+	namespace string,
+	values any,
+	source string,
+	ttl *time.Duration,
+) (CanvasMemory, error) {
+	settings, err := FindCanvasMemoryNamespace(tx, canvasID, namespace)
+	if err != nil {
+		return CanvasMemory{}, err
+	}
+
+	return addCanvasMemoryRecord(tx, settings, canvasID, namespace, values, source, ttl)
+}
+
+func addCanvasMemoryRecord(
+	tx *gorm.DB,
+	settings *CanvasMemoryNamespace,
+	canvasID uuid.UUID,
+	namespace string,
+	values any,
+	source string,
+	ttl *time.Duration,
+) (CanvasMemory, error) {
 	if source == "" {
 		source = CanvasMemorySourceNode
 	}
 
+	if err := settings.Validate(values); err != nil {
+		return CanvasMemory{}, err
+	}
+
+	expiresAt, err := canvasMemoryExpiresAt(settings, ttl)
+	if err != nil {
+		return CanvasMemory{}, err
+	}
+
 	record := CanvasMemory{
-		CanvasID:  canvasID,
-		Namespace: namespace,
-		Values:    datatypes.NewJSONType(values),
-		Source:    source,
+		CanvasID:      canvasID,
+		Namespace:     namespace,
+		Values:        datatypes.NewJSONType(values),
+		Source:        source,
+		ExpiresAt:     expiresAt,
+		IndexedValues: datatypes.NewJSONType(settings.IndexedValues(values)),
 	}
 
-	err := tx.Create(&record).Error
+	err = tx.Create(&record).Error
 	return record, err
 }
 
+func canvasMemoryExpiresAt(settings *CanvasMemoryNamespace, ttl *time.Duration) (*time.Time, error) {
+	if ttl == nil {
+		ttl = settings.DefaultTTL()
+	}
+
+	if ttl == nil {
+		return nil, nil
+	}
+
+	if err := ValidateCanvasMemoryTTL(*ttl); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(*ttl)
+	return &expiresAt, nil
+}
+
 func AddCanvasMemoryInTransaction(tx *gorm.DB, canvasID uuid.UUID, namespace string, values any) error {
 	_, err := AddCanvasMemoryRecordInTransaction(tx, canvasID, namespace, values)
 	return err
@@ -113,6 +185,7 @@ func ListCanvasMemoriesInTransaction(tx *gorm.DB, canvasID uuid.UUID) ([]CanvasM
 	var records []CanvasMemory
 	err := tx.
 		Where("canvas_id = ?", canvasID).
+		Where(canvasMemoryNotExpired).
 		Order("created_at DESC").
 		Find(&records).Error
 	if err != nil {
@@ -130,6 +203,7 @@ func ListCanvasMemoriesByNamespaceInTransaction(tx *gorm.DB, canvasID uuid.UUID,
 	var records []CanvasMemory
 	err := tx.
 		Where("canvas_id = ? AND namespace = ?", canvasID, namespace).
+		Where(canvasMemoryNotExpired).
 		Order("created_at DESC").
 		Find(&records).Error
 	if err != nil {
@@ -148,16 +222,14 @@ func ListCanvasMemoriesByNamespaceAndMatchesInTransaction(tx *gorm.DB, canvasID
 		return []CanvasMemory{}, fmt.Errorf("at least one match expression is required")
 	}
 
-	matchesJSON, err := json.Marshal(matches)
+	query, err := canvasMemoryMatchQuery(tx, canvasID, namespace, matches)
 	if err != nil {
 		return nil, err
 	}
 
 	var records []CanvasMemory
 
-	err = tx.
-		Where("canvas_id = ? AND namespace = ?", canvasID, namespace).
-		Where("values @> ?::jsonb", matchesJSON).
+	err = query.
 		Order("created_at DESC").
 		Find(&records).
 		Error
@@ -178,16 +250,14 @@ func FindFirstCanvasMemoryByNamespaceAndMatchesInTransaction(tx *gorm.DB, canvas
 		return nil, fmt.Errorf("at least one match expression is required")
 	}
 
-	matchesJSON, err := json.Marshal(matches)
+	query, err := canvasMemoryMatchQuery(tx, canvasID, namespace, matches)
 	if err != nil {
 		return nil, err
 	}
 
 	var record CanvasMemory
 
-	err = tx.
-		Where("canvas_id = ? AND namespace = ?", canvasID, namespace).
-		Where("values @> ?::jsonb", matchesJSON).
+	err = query.
 		Order("created_at DESC").
 		Limit(1).
 		First(&record).
@@ -225,27 +295,21 @@ func DeleteCanvasMemoriesByNamespaceAndMatchesInTransaction(
 		return []CanvasMemory{}, fmt.Errorf("at least one match expression is required")
 	}
 
-	matchesJSON, err := json.Marshal(matches)
+	query, err := canvasMemoryMatchQuery(tx, canvasID, namespace, matches)
 	if err != nil {
 		return nil, err
 	}
 
 	var deletedRecords []CanvasMemory
-	err = tx.Raw(
-		`WITH deleted AS (
-			DELETE FROM canvas_memories
-			WHERE canvas_id = ? AND namespace = ? AND values @> ?::jsonb
-			RETURNING *
-		)
-		SELECT * FROM deleted ORDER BY created_at DESC`,
-		canvasID,
-		namespace,
-		matchesJSON,
-	).Scan(&deletedRecords).Error
+	err = query.
+		Clauses(clause.Returning{}).
+		Delete(&deletedRecords).
+		Error
 	if err != nil {
 		return nil, err
 	}
 
+	sortCanvasMemoriesNewestFirst(deletedRecords)
 	return deletedRecords, nil
 }
 
@@ -267,34 +331,17 @@ func UpdateCanvasMemoriesByNamespaceAndMatchesInTransaction(
 		return []CanvasMemory{}, fmt.Errorf("at least one value expression is required")
 	}
 
-	matchesJSON, err := json.Marshal(matches)
-	if err != nil {
-		return nil, err
-	}
-	valuesJSON, err := json.Marshal(values)
+	settings, err := FindCanvasMemoryNamespace(tx, canvasID, namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	var updatedRecords []CanvasMemory
-	err = tx.Raw(
-		`WITH updated AS (
-			UPDATE canvas_memories
-			SET values = values || ?::jsonb, updated_at = NOW()
-			WHERE canvas_id = ? AND namespace = ? AND values @> ?::jsonb
-			RETURNING *
-		)
-		SELECT * FROM updated ORDER BY created_at DESC`,
-		valuesJSON,
-		canvasID,
-		namespace,
-		matchesJSON,
-	).Scan(&updatedRecords).Error
+	query, err := canvasMemoryNamespaceQuery(tx, settings, canvasID, namespace, matches)
 	if err != nil {
 		return nil, err
 	}
 
-	return updatedRecords, nil
+	return updateCanvasMemories(query, settings, values)
 }
 
 func UpdateCanvasMemoriesByNamespaceAndMatches(canvasID uuid.UUID, namespace string, matches map[string]any, values map[string]any) ([]CanvasMemory, error) {
@@ -311,31 +358,151 @@ func UpdateCanvasMemoriesByNamespaceInTransaction(
 		return []CanvasMemory{}, fmt.Errorf("at least one value expression is required")
 	}
 
+	settings, err := FindCanvasMemoryNamespace(tx, canvasID, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := canvasMemoryNamespaceQuery(tx, settings, canvasID, namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return updateCanvasMemories(query, settings, values)
+}
+
+func UpdateCanvasMemoriesByNamespace(canvasID uuid.UUID, namespace string, values map[string]any) ([]CanvasMemory, error) {
+	return UpdateCanvasMemoriesByNamespaceInTransaction(database.Conn(), canvasID, namespace, values)
+}
+
+// DeleteExpiredCanvasMemories deletes up to limit records
+// that expired before the given time, and returns how many it deleted.
+func DeleteExpiredCanvasMemories(tx *gorm.DB, before time.Time, limit int) (int64, error) {
+	result := tx.Exec(
+		`DELETE FROM canvas_memories
+		WHERE id IN (
+			SELECT id FROM canvas_memories
+			WHERE expires_at IS NOT NULL AND expires_at <= ?
+			ORDER BY expires_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)`,
+		before,
+		limit,
+	)
+
+	return result.RowsAffected, result.Error
+}
+
+// updateCanvasMemories merges the values into every record the query
+// selects. With a schema, the merged values are computed and checked
+// first, while holding row locks, so no record ends up invalid.
+func updateCanvasMemories(query *gorm.DB, settings *CanvasMemoryNamespace, values map[string]any) ([]CanvasMemory, error) {
 	valuesJSON, err := json.Marshal(values)
 	if err != nil {
 		return nil, err
 	}
 
+	if settings.HasSchema() {
+		var merged []struct {
+			ID     uuid.UUID
+			Values datatypes.JSONType[any]
+		}
+
+		err := query.Session(&gorm.Session{}).
+			Model(&CanvasMemory{}).
+			Select("id, values || ?::jsonb AS values", valuesJSON).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scan(&merged).
+			Error
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range merged {
+			if err := settings.Validate(record.Values.Data()); err != nil {
+				return nil, fmt.Errorf("record %s: %w", record.ID, err)
+			}
+		}
+	}
+
+	indexedKeys, err := canvasMemoryIndexedKeysJSON(settings)
+	if err != nil {
+		return nil, err
+	}
+
 	var updatedRecords []CanvasMemory
-	err = tx.Raw(
-		`WITH updated AS (
-			UPDATE canvas_memories
-			SET values = values || ?::jsonb, updated_at = NOW()
-			WHERE canvas_id = ? AND namespace = ?
-			RETURNING *
-		)
-		SELECT * FROM updated ORDER BY created_at DESC`,
-		valuesJSON,
-		canvasID,
-		namespace,
-	).Scan(&updatedRecords).Error
+	err = query.
+		Model(&updatedRecords).
+		Clauses(clause.Returning{}).
+		Updates(map[string]any{
+			"values":         gorm.Expr("values || ?::jsonb", valuesJSON),
+			"indexed_values": gorm.Expr(canvasMemoryIndexedValuesSQL("values || ?::jsonb"), valuesJSON, indexedKeys),
+			"updated_at":     gorm.Expr("NOW()"),
+		}).
+		Error
 	if err != nil {
 		return nil, err
 	}
 
+	sortCanvasMemoriesNewestFirst(updatedRecords)
 	return updatedRecords, nil
 }
 
-func UpdateCanvasMemoriesByNamespace(canvasID uuid.UUID, namespace string, values map[string]any) ([]CanvasMemory, error) {
-	return UpdateCanvasMemoriesByNamespaceInTransaction(database.Conn(), canvasID, namespace, values)
+func canvasMemoryMatchQuery(tx *gorm.DB, canvasID uuid.UUID, namespace string, matches map[string]any) (*gorm.DB, error) {
+	settings, err := FindCanvasMemoryNamespace(tx, canvasID, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return canvasMemoryNamespaceQuery(tx, settings, canvasID, namespace, matches)
+}
+
+// canvasMemoryNamespaceQuery selects the live records of the namespace
+// that contain the matches. Matches on indexed keys are also checked
+// against indexed_values, which lets Postgres use the GIN index on it.
+func canvasMemoryNamespaceQuery(
+	tx *gorm.DB,
+	settings *CanvasMemoryNamespace,
+	canvasID uuid.UUID,
+	namespace string,
+	matches map[string]any,
+) (*gorm.DB, error) {
+	query := tx.
+		Where("canvas_id = ? AND namespace = ?", canvasID, namespace).
+		Where(canvasMemoryNotExpired)
+
+	if len(matches) == 0 {
+		return query, nil
+	}
+
+	if indexed := settings.IndexedMatches(matches); indexed != nil {
+		indexedJSON, err := json.Marshal(indexed)
+		if err != nil {
+			return nil, err
+		}
+
+		query = query.Where("indexed_values @> ?::jsonb", indexedJSON)
+	}
+
+	matchesJSON, err := json.Marshal(matches)
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Where("values @> ?::jsonb", matchesJSON), nil
+}
+
+func canvasMemoryIndexedKeysJSON(settings *CanvasMemoryNamespace) ([]byte, error) {
+	if settings == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(settings.IndexedKeys)
+}
+
+func sortCanvasMemoriesNewestFirst(records []CanvasMemory) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
 }
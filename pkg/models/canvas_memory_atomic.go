@@ -0,0 +1,232 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrCanvasMemoryNotANumber = errors.New("memory field is not a number")
+
+// CanvasMemorySwap describes a compare-and-swap on the newest record
+// containing Matches. A nil Expected means no such record may exist,
+// and the swap then adds one with Matches and Values. Otherwise the record
+// must contain Expected, and Values are merged into it.
+type CanvasMemorySwap struct {
+	Matches  map[string]any
+	Expected map[string]any
+	Values   map[string]any
+	TTL      *time.Duration
+}
+
+// IncrementCanvasMemoryInTransaction adds delta to a numeric field of the
+// newest record containing the matches. If there is no such record, one is
+// added with the matches and the field set to delta. The TTL only applies
+// to added records, so counters with a TTL count over fixed windows.
+func IncrementCanvasMemoryInTransaction(
+	tx *gorm.DB,
+	canvasID uuid.UUID,
+	namespace string,
+	matches map[string]any,
+	field string,
+	delta float64,
+	ttl *time.Duration,
+) (CanvasMemory, error) {
+	if len(matches) == 0 {
+		return CanvasMemory{}, fmt.Errorf("at least one match expression is required")
+	}
+	if field == "" {
+		return CanvasMemory{}, fmt.Errorf("field is required")
+	}
+
+	settings, current, err := lockCanvasMemory(tx, canvasID, namespace, matches)
+	if err != nil {
+		return CanvasMemory{}, err
+	}
+
+	if current == nil {
+		values := maps.Clone(matches)
+		values[field] = delta
+		return addCanvasMemoryRecord(tx, settings, canvasID, namespace, values, CanvasMemorySourceNode, ttl)
+	}
+
+	existing, ok := current.Values.Data().(map[string]any)
+	if !ok {
+		return CanvasMemory{}, fmt.Errorf("%w: record %s is not an object", ErrCanvasMemoryNotANumber, current.ID)
+	}
+
+	value := 0.0
+	if raw, ok := existing[field]; ok && raw != nil {
+		value, ok = raw.(float64)
+		if !ok {
+			return CanvasMemory{}, fmt.Errorf("%w: %q is %T", ErrCanvasMemoryNotANumber, field, raw)
+		}
+	}
+
+	values := maps.Clone(existing)
+	values[field] = value + delta
+	return saveCanvasMemoryValues(tx, settings, current, values, nil)
+}
+
+// CompareAndSwapCanvasMemoryInTransaction applies the swap if the current
+// state matches what it expects, and returns whether it did together with
+// the newest matching record after the operation, which is nil if none exists.
+func CompareAndSwapCanvasMemoryInTransaction(
+	tx *gorm.DB,
+	canvasID uuid.UUID,
+	namespace string,
+	swap CanvasMemorySwap,
+) (bool, *CanvasMemory, error) {
+	if len(swap.Matches) == 0 {
+		return false, nil, fmt.Errorf("at least one match expression is required")
+	}
+
+	settings, current, err := lockCanvasMemory(tx, canvasID, namespace, swap.Matches)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if swap.Expected == nil {
+		if current != nil {
+			return false, current, nil
+		}
+
+		values := maps.Clone(swap.Matches)
+		maps.Copy(values, swap.Values)
+		record, err := addCanvasMemoryRecord(tx, settings, canvasID, namespace, values, CanvasMemorySourceNode, swap.TTL)
+		if err != nil {
+			return false, nil, err
+		}
+
+		return true, &record, nil
+	}
+
+	if current == nil {
+		return false, nil, nil
+	}
+
+	existing, ok := current.Values.Data().(map[string]any)
+	if !ok || !canvasMemoryContains(existing, swap.Expected) {
+		return false, current, nil
+	}
+
+	values := maps.Clone(existing)
+	maps.Copy(values, swap.Values)
+	record, err := saveCanvasMemoryValues(tx, settings, current, values, swap.TTL)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return true, &record, nil
+}
+
+// lockCanvasMemory serializes atomic operations on the same namespace for
+// the rest of the transaction, and returns the newest live record that
+// contains the matches. Locking the namespace rather than the record
+// also covers the case where the record does not exist yet.
+func lockCanvasMemory(
+	tx *gorm.DB,
+	canvasID uuid.UUID,
+	namespace string,
+	matches map[string]any,
+) (*CanvasMemoryNamespace, *CanvasMemory, error) {
+	err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", canvasID.String()+"/"+namespace).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to lock memory namespace: %w", err)
+	}
+
+	settings, err := FindCanvasMemoryNamespace(tx, canvasID, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query, err := canvasMemoryNamespaceQuery(tx, settings, canvasID, namespace, matches)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []CanvasMemory
+	err = query.
+		Order("created_at DESC").
+		Limit(1).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Find(&records).
+		Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(records) == 0 {
+		return settings, nil, nil
+	}
+
+	return settings, &records[0], nil
+}
+
+func saveCanvasMemoryValues(
+	tx *gorm.DB,
+	settings *CanvasMemoryNamespace,
+	record *CanvasMemory,
+	values map[string]any,
+	ttl *time.Duration,
+) (CanvasMemory, error) {
+	if err := settings.Validate(values); err != nil {
+		return CanvasMemory{}, err
+	}
+
+	updates := map[string]any{
+		"values":         datatypes.NewJSONType[any](values),
+		"indexed_values": datatypes.NewJSONType(settings.IndexedValues(values)),
+		"updated_at":     time.Now(),
+	}
+
+	if ttl != nil {
+		if err := ValidateCanvasMemoryTTL(*ttl); err != nil {
+			return CanvasMemory{}, err
+		}
+
+		updates["expires_at"] = time.Now().Add(*ttl)
+	}
+
+	updated := *record
+	err := tx.Model(&updated).Clauses(clause.Returning{}).Updates(updates).Error
+	if err != nil {
+		return CanvasMemory{}, err
+	}
+
+	return updated, nil
+}
+
+// canvasMemoryContains reports whether every expected key
+// is present in the values with an equal JSON value.
+func canvasMemoryContains(values map[string]any, expected map[string]any) bool {
+	for key, want := range expected {
+		got, ok := values[key]
+		if !ok {
+			return false
+		}
+
+		gotJSON, err := json.Marshal(got)
+		if err != nil {
+			return false
+		}
+
+		wantJSON, err := json.Marshal(want)
+		if err != nil {
+			return false
+		}
+
+		if string(gotJSON) != string(wantJSON) {
+			return false
+		}
+	}
+
+	return true
+}
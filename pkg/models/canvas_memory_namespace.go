@@ -0,0 +1,311 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	CanvasMemoryMaxIndexedKeys = 8
+	CanvasMemoryMaxTTL         = 365 * 24 * time.Hour
+)
+
+var (
+	ErrCanvasMemoryInvalidSchema     = errors.New("invalid memory schema")
+	ErrCanvasMemorySchemaViolation   = errors.New("memory values do not match the namespace schema")
+	ErrCanvasMemoryInvalidTTL        = errors.New("memory TTL must be between 1 second and 365 days")
+	ErrCanvasMemoryInvalidIndexedKey = errors.New("invalid indexed key")
+
+	canvasMemorySchemaPrinter = message.NewPrinter(language.English)
+)
+
+// CanvasMemoryNamespace holds the optional settings of a memory namespace.
+// Namespaces without settings accept any values, never expire
+// and match records by scanning their values.
+type CanvasMemoryNamespace struct {
+	ID                uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CanvasID          uuid.UUID
+	Namespace         string
+	Schema            datatypes.JSON
+	DefaultTTLSeconds *int
+	IndexedKeys       datatypes.JSONSlice[string]
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+
+	compiledSchema *jsonschema.Schema
+}
+
+func (CanvasMemoryNamespace) TableName() string {
+	return "canvas_memory_namespaces"
+}
+
+func (n *CanvasMemoryNamespace) HasSchema() bool {
+	return n != nil && len(n.Schema) > 0 && string(n.Schema) != "null"
+}
+
+// DefaultTTL is safe to call on a nil namespace, and returns nil
+// when records added to the namespace do not expire by default.
+func (n *CanvasMemoryNamespace) DefaultTTL() *time.Duration {
+	if n == nil || n.DefaultTTLSeconds == nil {
+		return nil
+	}
+
+	ttl := time.Duration(*n.DefaultTTLSeconds) * time.Second
+	return &ttl
+}
+
+// Validate checks the values against the namespace schema.
+// It is safe to call on a nil namespace, which accepts anything.
+func (n *CanvasMemoryNamespace) Validate(values any) error {
+	if !n.HasSchema() {
+		return nil
+	}
+
+	if n.compiledSchema == nil {
+		schema, err := CompileCanvasMemorySchema(n.Schema)
+		if err != nil {
+			return err
+		}
+
+		n.compiledSchema = schema
+	}
+
+	instance, err := toJSONSchemaInstance(values)
+	if err != nil {
+		return err
+	}
+
+	if err := n.compiledSchema.Validate(instance); err != nil {
+		var validationErr *jsonschema.ValidationError
+		if errors.As(err, &validationErr) {
+			return fmt.Errorf("%w: %s", ErrCanvasMemorySchemaViolation, describeSchemaViolation(validationErr))
+		}
+
+		return fmt.Errorf("%w: %v", ErrCanvasMemorySchemaViolation, err)
+	}
+
+	return nil
+}
+
+// IndexedValues picks the indexed keys out of the values.
+// Only top-level keys of object values can be indexed.
+func (n *CanvasMemoryNamespace) IndexedValues(values any) map[string]any {
+	indexed := map[string]any{}
+	if n == nil || len(n.IndexedKeys) == 0 {
+		return indexed
+	}
+
+	object, ok := values.(map[string]any)
+	if !ok {
+		return indexed
+	}
+
+	for _, key := range n.IndexedKeys {
+		if value, ok := object[key]; ok {
+			indexed[key] = value
+		}
+	}
+
+	return indexed
+}
+
+// IndexedMatches returns the part of the matches that can be
+// served from the indexed_values column, or nil if there is none.
+func (n *CanvasMemoryNamespace) IndexedMatches(matches map[string]any) map[string]any {
+	indexed := n.IndexedValues(matches)
+	if len(indexed) == 0 {
+		return nil
+	}
+
+	return indexed
+}
+
+func CompileCanvasMemorySchema(schema []byte) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCanvasMemoryInvalidSchema, err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("memory.json", doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCanvasMemoryInvalidSchema, err)
+	}
+
+	compiled, err := compiler.Compile("memory.json")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCanvasMemoryInvalidSchema, err)
+	}
+
+	return compiled, nil
+}
+
+// NormalizeCanvasMemoryIndexedKeys trims and de-duplicates the keys.
+func NormalizeCanvasMemoryIndexedKeys(keys []string) ([]string, error) {
+	normalized := []string{}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("%w: keys cannot be empty", ErrCanvasMemoryInvalidIndexedKey)
+		}
+
+		if !slices.Contains(normalized, key) {
+			normalized = append(normalized, key)
+		}
+	}
+
+	if len(normalized) > CanvasMemoryMaxIndexedKeys {
+		return nil, fmt.Errorf("%w: at most %d keys can be indexed", ErrCanvasMemoryInvalidIndexedKey, CanvasMemoryMaxIndexedKeys)
+	}
+
+	return normalized, nil
+}
+
+func ValidateCanvasMemoryTTL(ttl time.Duration) error {
+	if ttl < time.Second || ttl > CanvasMemoryMaxTTL {
+		return ErrCanvasMemoryInvalidTTL
+	}
+
+	return nil
+}
+
+// FindCanvasMemoryNamespace returns nil when the namespace has no settings.
+func FindCanvasMemoryNamespace(tx *gorm.DB, canvasID uuid.UUID, namespace string) (*CanvasMemoryNamespace, error) {
+	var settings CanvasMemoryNamespace
+	err := tx.
+		Where("canvas_id = ? AND namespace = ?", canvasID, namespace).
+		First(&settings).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// SaveCanvasMemoryNamespace creates or replaces the settings of a namespace.
+// Existing records must satisfy a new schema, and the indexed values of
+// all records are rebuilt so matches keep finding records written before
+// a key was indexed.
+func SaveCanvasMemoryNamespace(tx *gorm.DB, settings *CanvasMemoryNamespace) error {
+	if settings.HasSchema() {
+		if _, err := CompileCanvasMemorySchema(settings.Schema); err != nil {
+			return err
+		}
+	} else {
+		settings.Schema = nil
+	}
+
+	if settings.DefaultTTLSeconds != nil {
+		if err := ValidateCanvasMemoryTTL(time.Duration(*settings.DefaultTTLSeconds) * time.Second); err != nil {
+			return err
+		}
+	}
+
+	keys, err := NormalizeCanvasMemoryIndexedKeys(settings.IndexedKeys)
+	if err != nil {
+		return err
+	}
+
+	settings.IndexedKeys = keys
+
+	if settings.HasSchema() {
+		records, err := ListCanvasMemoriesByNamespaceInTransaction(tx, settings.CanvasID, settings.Namespace)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			if err := settings.Validate(record.Values.Data()); err != nil {
+				return fmt.Errorf("record %s: %w", record.ID, err)
+			}
+		}
+	}
+
+	now := time.Now()
+	settings.UpdatedAt = now
+	if settings.CreatedAt.IsZero() {
+		settings.CreatedAt = now
+	}
+
+	if settings.ID == uuid.Nil {
+		settings.ID = uuid.New()
+	}
+
+	err = tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "canvas_id"}, {Name: "namespace"}},
+		DoUpdates: clause.AssignmentColumns([]string{"schema", "default_ttl_seconds", "indexed_keys", "updated_at"}),
+	}).Create(settings).Error
+
+	if err != nil {
+		return err
+	}
+
+	return reindexCanvasMemories(tx, settings)
+}
+
+func reindexCanvasMemories(tx *gorm.DB, settings *CanvasMemoryNamespace) error {
+	keys, err := canvasMemoryIndexedKeysJSON(settings)
+	if err != nil {
+		return err
+	}
+
+	return tx.Exec(
+		`UPDATE canvas_memories SET indexed_values = `+canvasMemoryIndexedValuesSQL("values")+`
+		WHERE canvas_id = ? AND namespace = ?`,
+		keys,
+		settings.CanvasID,
+		settings.Namespace,
+	).Error
+}
+
+// canvasMemoryIndexedValuesSQL builds the expression that picks the indexed
+// keys out of the values expression. The keys are the last parameter,
+// given as a JSON array. Values that are not objects have no indexed keys.
+func canvasMemoryIndexedValuesSQL(values string) string {
+	return `(
+		SELECT COALESCE(jsonb_object_agg(e.key, e.value), '{}'::jsonb)
+		FROM (SELECT ` + values + ` AS v) src,
+			jsonb_each(CASE WHEN jsonb_typeof(src.v) = 'object' THEN src.v ELSE '{}'::jsonb END) e
+		WHERE e.key IN (SELECT jsonb_array_elements_text(?::jsonb))
+	)`
+}
+
+// toJSONSchemaInstance round-trips the values through JSON, so the
+// validator sees json.Number and map[string]any like it would for a document.
+func toJSONSchemaInstance(values any) (any, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+}
+
+// describeSchemaViolation reports the first leaf error,
+// which is the most specific one, with the location it applies to.
+func describeSchemaViolation(err *jsonschema.ValidationError) string {
+	for len(err.Causes) > 0 {
+		err = err.Causes[0]
+	}
+
+	location := "/" + strings.Join(err.InstanceLocation, "/")
+	return fmt.Sprintf("%s: %s", location, err.ErrorKind.LocalizedString(canvasMemorySchemaPrinter))
+}
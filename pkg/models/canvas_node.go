@@ -573,6 +573,11 @@ type CanvasNodeQueueItem struct {
 	// name expressions are evaluated exactly once per item.
 	//
 	QueueName *string
+
+	//
+	// W3C traceparent of the run trace, set by the event router.
+	//
+	TraceParent string
 }
 
 func (i *CanvasNodeQueueItem) TableName() string {
@@ -91,6 +91,12 @@ type CanvasNodeExecution struct {
 	// Only new executions will use the new node configuration.
 	//
 	Configuration datatypes.JSONType[map[string]any]
+
+	//
+	// W3C traceparent of the run trace. The executor points it at its own
+	// span, so the events this execution emits are nested under it.
+	//
+	TraceParent string
 }
 
 func (e *CanvasNodeExecution) TableName() string {
@@ -480,6 +486,7 @@ func (e *CanvasNodeExecution) PassInTransaction(tx *gorm.DB, channelOutputs map[
 				ExecutionID: &e.ID,
 				RunID:       e.RunID,
 				State:       CanvasEventStatePending,
+				TraceParent: e.TraceParent,
 				CreatedAt:   &now,
 			})
 		}
@@ -539,6 +546,7 @@ func (e *CanvasNodeExecution) EmitOutputsInTransaction(tx *gorm.DB, channelOutpu
 				ExecutionID: &e.ID,
 				RunID:       e.RunID,
 				State:       CanvasEventStatePending,
+				TraceParent: e.TraceParent,
 				CreatedAt:   &now,
 			})
 		}
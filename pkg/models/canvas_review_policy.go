@@ -0,0 +1,220 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	CanvasReviewPolicySourceCanvas = "canvas"
+	CanvasReviewPolicySourceFolder = "folder"
+
+	CanvasReviewPolicyMaxApprovals = 10
+)
+
+var (
+	ErrCanvasReviewPolicyTooManyApprovals   = errors.New("too many required approvals")
+	ErrCanvasReviewPolicyInvalidReviewer    = errors.New("reviewer is not a member of the organization")
+	ErrCanvasReviewPolicyNotEnoughReviewers = errors.New("required approvals exceed the number of reviewers")
+)
+
+// CanvasReviewPolicy configures the approvals a change request needs
+// before it can be published. Policies are set on a canvas or on a folder,
+// and a canvas policy takes precedence over the policy of its folder.
+type CanvasReviewPolicy struct {
+	ID                uuid.UUID
+	OrganizationID    uuid.UUID
+	CanvasID          *uuid.UUID
+	FolderID          *uuid.UUID
+	RequiredApprovals int
+	ReviewerIDs       datatypes.JSONSlice[string]
+	CreatedAt         *time.Time
+	UpdatedAt         *time.Time
+}
+
+func (p *CanvasReviewPolicy) TableName() string {
+	return "canvas_review_policies"
+}
+
+func (p *CanvasReviewPolicy) Source() string {
+	if p.CanvasID != nil {
+		return CanvasReviewPolicySourceCanvas
+	}
+
+	return CanvasReviewPolicySourceFolder
+}
+
+// RequiresReview is safe to call on a nil policy,
+// which is what canvases without any policy get.
+func (p *CanvasReviewPolicy) RequiresReview() bool {
+	return p != nil && p.RequiredApprovals > 0
+}
+
+// CanReview reports whether a user can approve or reject changes.
+// Without an explicit reviewer list, any organization member can.
+func (p *CanvasReviewPolicy) CanReview(userID uuid.UUID) bool {
+	if p == nil || len(p.ReviewerIDs) == 0 {
+		return true
+	}
+
+	return slices.Contains(p.ReviewerIDs, userID.String())
+}
+
+// NormalizeCanvasReviewers de-duplicates the reviewer list and checks that
+// every reviewer is an active member, and not an API key, of the organization.
+func NormalizeCanvasReviewers(tx *gorm.DB, organizationID uuid.UUID, requiredApprovals int, reviewerIDs []string) ([]string, error) {
+	if requiredApprovals > CanvasReviewPolicyMaxApprovals {
+		return nil, ErrCanvasReviewPolicyTooManyApprovals
+	}
+
+	reviewers := []string{}
+	for _, id := range reviewerIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, ErrCanvasReviewPolicyInvalidReviewer
+		}
+
+		if !slices.Contains(reviewers, parsed.String()) {
+			reviewers = append(reviewers, parsed.String())
+		}
+	}
+
+	if len(reviewers) == 0 {
+		return reviewers, nil
+	}
+
+	//
+	// The required approvals must be reachable with the reviewers listed.
+	//
+	if len(reviewers) < requiredApprovals {
+		return nil, ErrCanvasReviewPolicyNotEnoughReviewers
+	}
+
+	var users []User
+	err := tx.
+		Where("organization_id = ?", organizationID).
+		Where("id IN ?", reviewers).
+		Find(&users).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) != len(reviewers) {
+		return nil, ErrCanvasReviewPolicyInvalidReviewer
+	}
+
+	for _, user := range users {
+		if user.IsAPIKey() {
+			return nil, ErrCanvasReviewPolicyInvalidReviewer
+		}
+	}
+
+	return reviewers, nil
+}
+
+// FindEffectiveCanvasReviewPolicy returns nil when
+// neither the canvas nor its folder has a review policy.
+func FindEffectiveCanvasReviewPolicy(tx *gorm.DB, canvas *Canvas) (*CanvasReviewPolicy, error) {
+	policy, err := FindCanvasReviewPolicy(tx, canvas.ID)
+	if err == nil {
+		return policy, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if canvas.CanvasFolderID == nil {
+		return nil, nil
+	}
+
+	policy, err = FindCanvasFolderReviewPolicy(tx, *canvas.CanvasFolderID)
+	if err == nil {
+		return policy, nil
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return nil, err
+}
+
+func FindCanvasReviewPolicy(tx *gorm.DB, canvasID uuid.UUID) (*CanvasReviewPolicy, error) {
+	var policy CanvasReviewPolicy
+	err := tx.
+		Where("canvas_id = ?", canvasID).
+		First(&policy).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func FindCanvasFolderReviewPolicy(tx *gorm.DB, folderID uuid.UUID) (*CanvasReviewPolicy, error) {
+	var policy CanvasReviewPolicy
+	err := tx.
+		Where("folder_id = ?", folderID).
+		First(&policy).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// SaveCanvasReviewPolicy creates or replaces the policy
+// for the canvas or folder the policy is scoped to.
+func SaveCanvasReviewPolicy(tx *gorm.DB, policy *CanvasReviewPolicy) error {
+	now := time.Now()
+	policy.UpdatedAt = &now
+	if policy.CreatedAt == nil {
+		policy.CreatedAt = &now
+	}
+
+	if policy.ID == uuid.Nil {
+		policy.ID = uuid.New()
+	}
+
+	target := clause.OnConflict{
+		Columns:     []clause.Column{{Name: "canvas_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "canvas_id IS NOT NULL"}}},
+	}
+
+	if policy.CanvasID == nil {
+		target = clause.OnConflict{
+			Columns:     []clause.Column{{Name: "folder_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "folder_id IS NOT NULL"}}},
+		}
+	}
+
+	target.DoUpdates = clause.AssignmentColumns([]string{"required_approvals", "reviewer_ids", "updated_at"})
+	return tx.Clauses(target).Create(policy).Error
+}
+
+func DeleteCanvasReviewPolicy(tx *gorm.DB, canvasID uuid.UUID) error {
+	return tx.
+		Where("canvas_id = ?", canvasID).
+		Delete(&CanvasReviewPolicy{}).
+		Error
+}
+
+func DeleteCanvasFolderReviewPolicy(tx *gorm.DB, folderID uuid.UUID) error {
+	return tx.
+		Where("folder_id = ?", folderID).
+		Delete(&CanvasReviewPolicy{}).
+		Error
+}
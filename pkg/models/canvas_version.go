@@ -23,6 +23,7 @@ type CanvasVersion struct {
 	ConsolePanels datatypes.JSONType[[]ConsolePanel]
 	ConsoleLayout datatypes.JSONType[[]ConsoleLayoutItem]
 	CommitSHA     string
+	ChangeRequest *datatypes.JSONType[CanvasVersionChangeRequest]
 	CreatedAt     *time.Time
 	UpdatedAt     *time.Time
 }
@@ -32,10 +33,29 @@ type CanvasVersionMetadata struct {
 	WorkflowID    uuid.UUID
 	OwnerID       *uuid.UUID
 	CommitMessage string
+	ChangeRequest *datatypes.JSONType[CanvasVersionChangeRequest]
 	CreatedAt     *time.Time
 	UpdatedAt     *time.Time
 }
 
+// CanvasVersionChangeRequest records the change request a version was
+// merged from, so who proposed, reviewed and published it stays on the
+// version even after the change request thread is gone.
+type CanvasVersionChangeRequest struct {
+	ID       string                             `json:"id"`
+	Title    string                             `json:"title"`
+	AuthorID string                             `json:"authorId"`
+	Reviews  []CanvasVersionChangeRequestReview `json:"reviews"`
+	MergedBy string                             `json:"mergedBy"`
+	MergedAt time.Time                          `json:"mergedAt"`
+}
+
+type CanvasVersionChangeRequestReview struct {
+	UserID    string    `json:"userId"`
+	Approved  bool      `json:"approved"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 func (c *CanvasVersion) TableName() string {
 	return "workflow_versions"
 }
@@ -163,7 +183,7 @@ func ListCanvasVersionHistoryInTransaction(
 ) ([]CanvasVersionMetadata, error) {
 	query := tx.
 		Model(&CanvasVersion{}).
-		Select("id", "workflow_id", "owner_id", "commit_message", "created_at", "updated_at").
+		Select("id", "workflow_id", "owner_id", "commit_message", "change_request", "created_at", "updated_at").
 		Where("workflow_id = ?", workflowID).
 		Order("created_at DESC, id DESC")
 
@@ -3,7 +3,9 @@ package models
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -506,6 +508,7 @@ func (f *Factory) CreateWorkOrder(tx *gorm.DB, title, description string, create
 		Result:         "",
 		CreatedByID:    createdBy,
 		SourceRunID:    sourceRunID,
+		Fields:         datatypes.NewJSONType(map[string]any{}),
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
@@ -592,6 +595,10 @@ type ListFactoryWorkOrdersFilters struct {
 	States      []string
 	Results     []string
 	Unassigned  *bool
+	TemplateID  *uuid.UUID
+	// Fields matches orders whose custom field equals the value. For
+	// multi-select fields, the value must be one of the selected options.
+	Fields map[string]string
 }
 
 func (f *Factory) ListWorkOrders(tx *gorm.DB, filters ListFactoryWorkOrdersFilters) ([]FactoryWorkOrder, error) {
@@ -628,6 +635,18 @@ func (f *Factory) ListWorkOrders(tx *gorm.DB, filters ListFactoryWorkOrdersFilte
 			)`, filters.AssigneeIDs)
 	}
 
+	if filters.TemplateID != nil {
+		query = query.Where("factory_work_orders.template_id = ?", *filters.TemplateID)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(filters.Fields)) {
+		value := filters.Fields[name]
+		query = query.Where(
+			"(factory_work_orders.fields ->> ? = ? OR (jsonb_typeof(factory_work_orders.fields -> ?) = 'array' AND factory_work_orders.fields -> ? @> to_jsonb(?::text)))",
+			name, value, name, name, value,
+		)
+	}
+
 	var orders []FactoryWorkOrder
 	err := query.
 		Order("factory_work_orders.created_at DESC").
@@ -35,6 +35,13 @@ const (
 	// same way status changes and comments do. Clearing rides on
 	// `order.status.updated`.
 	EventTypeOrderStatusNoteUpdated = "order.status_note.updated"
+	// EventTypeOrderBudgetWarning is recorded once per budget (and, for
+	// line budgets, once per month) when the order's spend crosses the
+	// warning threshold. EventTypeOrderBudgetExceeded is recorded right
+	// before the order's in-flight executions are cancelled and the order
+	// is closed as `failed`.
+	EventTypeOrderBudgetWarning  = "order.budget.warning"
+	EventTypeOrderBudgetExceeded = "order.budget.exceeded"
 
 	// Factory line events
 	EventTypeLineStepExecutionQueued   = "step.execution.queued"
@@ -85,6 +92,14 @@ const (
 	StatusNoteKindInfo = "info"
 )
 
+// Budget scopes. A work order budget caps the order's lifetime spend; a
+// line budget caps the spend of every order on the line per calendar
+// month (UTC).
+const (
+	BudgetScopeWorkOrder = "workOrder"
+	BudgetScopeLine      = "line"
+)
+
 // Events
 
 type WorkOrderAssigneesUpdated struct {
@@ -155,6 +170,18 @@ type WorkOrderCheckReported struct {
 	Run        *RunRef        `json:"run,omitempty"`
 }
 
+// WorkOrderBudgetReported backs both budget events. Period is the
+// calendar month (`2006-01`) a line budget applies to; it is empty for
+// work order budgets, which never reset.
+type WorkOrderBudgetReported struct {
+	Order      *WorkOrderRef `json:"order,omitempty"`
+	Line       *LineRef      `json:"line,omitempty"`
+	Scope      string        `json:"scope"`
+	Period     string        `json:"period,omitempty"`
+	LimitCents int64         `json:"limitCents"`
+	SpentCents int64         `json:"spentCents"`
+}
+
 // LineStepExecutionQueued is recorded when a work order becomes ready for
 // a step that is at its maxParallelism, so the work order waits in the
 // step's queue instead of starting a run.
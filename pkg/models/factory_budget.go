@@ -0,0 +1,445 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/models/factory"
+	"gorm.io/gorm"
+)
+
+const (
+	// FactoryBudgetWarningPercent is the share of a budget that, once spent,
+	// records an `order.budget.warning` event on the work order.
+	FactoryBudgetWarningPercent = 80
+
+	factoryBudgetPeriodLayout = "2006-01"
+)
+
+var (
+	ErrFactoryBudgetExceeded = errors.New("factory spend budget exceeded")
+	ErrFactoryBudgetInvalid  = errors.New("invalid factory budget")
+)
+
+// FactoryBudgetUsage is the spend measured against one budget. Period is
+// only set for line budgets, which reset every calendar month (UTC).
+type FactoryBudgetUsage struct {
+	Scope      string
+	Period     string
+	LimitCents int64
+	SpentCents int64
+}
+
+// IsExhausted reports whether nothing is left to spend. A zero budget is
+// exhausted from the start, which is how a line is paused on purpose.
+func (u *FactoryBudgetUsage) IsExhausted() bool {
+	return u.SpentCents >= u.LimitCents
+}
+
+// IsExceeded reports whether the spend went past the cap. Only this halts
+// in-flight work: spending exactly the cap still lets running steps finish.
+func (u *FactoryBudgetUsage) IsExceeded() bool {
+	return u.SpentCents > u.LimitCents
+}
+
+func (u *FactoryBudgetUsage) ReachedWarning() bool {
+	return u.SpentCents*100 >= u.LimitCents*FactoryBudgetWarningPercent
+}
+
+func (u *FactoryBudgetUsage) Error() error {
+	switch u.Scope {
+	case factory.BudgetScopeLine:
+		return fmt.Errorf(
+			"%w: line spent %d of its %d cents monthly budget for %s",
+			ErrFactoryBudgetExceeded, u.SpentCents, u.LimitCents, u.Period,
+		)
+	default:
+		return fmt.Errorf(
+			"%w: work order spent %d of its %d cents budget",
+			ErrFactoryBudgetExceeded, u.SpentCents, u.LimitCents,
+		)
+	}
+}
+
+// FactoryBudgetPeriodStart is the start of the calendar month (UTC) that
+// line budgets are measured over.
+func FactoryBudgetPeriodStart(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// BudgetUsage returns the order's lifetime spend against its budget, or
+// nil when the order has no budget.
+func (o *FactoryWorkOrder) BudgetUsage(tx *gorm.DB) (*FactoryBudgetUsage, error) {
+	if o.BudgetCents == nil {
+		return nil, nil
+	}
+
+	var totals UsageTotals
+	err := tx.Model(&LLMUsageEvent{}).
+		Select("COALESCE(SUM(total_tokens), 0) AS total_tokens, COALESCE(SUM(cost_micros), 0) AS cost_micros").
+		Where("work_order_id = ?", o.ID).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &FactoryBudgetUsage{
+		Scope:      factory.BudgetScopeWorkOrder,
+		LimitCents: *o.BudgetCents,
+		SpentCents: totals.CostCents(),
+	}, nil
+}
+
+// MonthlyBudgetUsage returns the spend of all work orders on the line in
+// the calendar month of now, or nil when the line has no monthly budget.
+func (l *FactoryLine) MonthlyBudgetUsage(tx *gorm.DB, now time.Time) (*FactoryBudgetUsage, error) {
+	if l.MonthlyBudgetCents == nil {
+		return nil, nil
+	}
+
+	since := FactoryBudgetPeriodStart(now)
+
+	var totals UsageTotals
+	err := tx.Model(&LLMUsageEvent{}).
+		Select("COALESCE(SUM(total_tokens), 0) AS total_tokens, COALESCE(SUM(cost_micros), 0) AS cost_micros").
+		Where("line_id = ?", l.ID).
+		Where("occurred_at >= ?", since).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &FactoryBudgetUsage{
+		Scope:      factory.BudgetScopeLine,
+		Period:     since.Format(factoryBudgetPeriodLayout),
+		LimitCents: *l.MonthlyBudgetCents,
+		SpentCents: totals.CostCents(),
+	}, nil
+}
+
+// SetBudget replaces the order's budget; nil removes it.
+func (o *FactoryWorkOrder) SetBudget(tx *gorm.DB, budgetCents *int64) error {
+	if budgetCents != nil && *budgetCents < 0 {
+		return fmt.Errorf("%w: budget cannot be negative", ErrFactoryBudgetInvalid)
+	}
+
+	now := time.Now()
+	o.BudgetCents = budgetCents
+	o.UpdatedAt = now
+
+	return tx.Model(o).Updates(map[string]any{
+		"budget_cents": budgetCents,
+		"updated_at":   now,
+	}).Error
+}
+
+// SetMonthlyBudget replaces the line's monthly budget; nil removes it.
+func (l *FactoryLine) SetMonthlyBudget(tx *gorm.DB, budgetCents *int64) error {
+	if budgetCents != nil && *budgetCents < 0 {
+		return fmt.Errorf("%w: budget cannot be negative", ErrFactoryBudgetInvalid)
+	}
+
+	now := time.Now()
+	l.MonthlyBudgetCents = budgetCents
+	l.UpdatedAt = now
+
+	return tx.Model(l).Updates(map[string]any{
+		"monthly_budget_cents": budgetCents,
+		"updated_at":           now,
+	}).Error
+}
+
+// EnsureBudgetAvailable rejects starting new work for order on l when
+// either the order's budget or the line's monthly budget is exhausted.
+func (l *FactoryLine) EnsureBudgetAvailable(tx *gorm.DB, order *FactoryWorkOrder) error {
+	return ensureFactoryBudgetAvailable(tx, order, l)
+}
+
+// EnsureFactoryBudgetForRun is the runner-task admission check: a run that
+// belongs to a factory step (directly or through an ancestor run) may not
+// start new billable work once its order or line budget is exhausted.
+// Runs outside factories are always allowed.
+func EnsureFactoryBudgetForRun(tx *gorm.DB, runID uuid.UUID) error {
+	execution, err := FindWorkOrderExecutionForRun(tx, runID)
+	if err != nil {
+		if errors.Is(err, ErrFactoryWorkOrderExecutionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	order, err := FindUnscopedWorkOrder(tx, execution.WorkOrderID)
+	if err != nil {
+		return err
+	}
+
+	line, err := FindUnscopedFactoryLine(tx, execution.LineID)
+	if err != nil && !errors.Is(err, ErrFactoryLineNotFound) {
+		return err
+	}
+
+	return ensureFactoryBudgetAvailable(tx, order, line)
+}
+
+// EnsureFactoryBudgetForNodeExecution applies EnsureFactoryBudgetForRun to
+// the run a node execution belongs to.
+func EnsureFactoryBudgetForNodeExecution(tx *gorm.DB, workflowID string, executionID uuid.UUID) error {
+	canvasID, err := uuid.Parse(workflowID)
+	if err != nil {
+		return err
+	}
+
+	execution, err := FindNodeExecutionInTransaction(tx, canvasID, executionID)
+	if err != nil {
+		return err
+	}
+
+	return EnsureFactoryBudgetForRun(tx, execution.RunID)
+}
+
+// ensureFactoryBudgetAvailable checks the order budget and, when line is
+// set, the line's monthly budget.
+func ensureFactoryBudgetAvailable(tx *gorm.DB, order *FactoryWorkOrder, line *FactoryLine) error {
+	orderUsage, err := order.BudgetUsage(tx)
+	if err != nil {
+		return err
+	}
+	if orderUsage != nil && orderUsage.IsExhausted() {
+		return orderUsage.Error()
+	}
+
+	if line == nil {
+		return nil
+	}
+
+	lineUsage, err := line.MonthlyBudgetUsage(tx, time.Now())
+	if err != nil {
+		return err
+	}
+	if lineUsage != nil && lineUsage.IsExhausted() {
+		return lineUsage.Error()
+	}
+
+	return nil
+}
+
+// FactoryBudgetEnforcement lists what EnforceBudgets halted, so callers
+// can publish the websocket / worker fan-out after the transaction commits.
+type FactoryBudgetEnforcement struct {
+	CancelledRuns []FactoryBudgetCancelledRun
+	ClosedOrders  []FactoryWorkOrder
+	WarnedOrders  []FactoryWorkOrder
+}
+
+type FactoryBudgetCancelledRun struct {
+	WorkflowID  uuid.UUID
+	RunID       uuid.UUID
+	DrainResult *RunCancellationDrainResult
+}
+
+// EnforceBudgets checks the budgets that cover this step execution after
+// new spend was recorded. When the work order or the line went over its
+// cap, every in-flight run of the affected orders is cancelled and the
+// orders are closed as `failed`; an order budget only affects its order,
+// a line budget affects every order with an active traversal of the
+// line. Below the cap, crossing FactoryBudgetWarningPercent records a
+// one-off warning event instead.
+func (e *FactoryWorkOrderExecution) EnforceBudgets(tx *gorm.DB) (*FactoryBudgetEnforcement, error) {
+	enforcement := &FactoryBudgetEnforcement{}
+
+	order, err := FindUnscopedWorkOrder(tx, e.WorkOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderUsage, err := order.BudgetUsage(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var line *FactoryLine
+	var lineUsage *FactoryBudgetUsage
+	line, err = FindUnscopedFactoryLine(tx, e.LineID)
+	if err != nil && !errors.Is(err, ErrFactoryLineNotFound) {
+		return nil, err
+	}
+	if line != nil {
+		lineUsage, err = line.MonthlyBudgetUsage(tx, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if orderUsage != nil && orderUsage.IsExceeded() {
+		if err := enforcement.halt(tx, order, line, orderUsage); err != nil {
+			return nil, err
+		}
+	}
+
+	if lineUsage != nil && lineUsage.IsExceeded() {
+		orders, err := line.listOrdersWithActiveDispatch(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range orders {
+			if err := enforcement.halt(tx, &orders[i], line, lineUsage); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, usage := range []*FactoryBudgetUsage{orderUsage, lineUsage} {
+		if usage == nil || usage.IsExceeded() || !usage.ReachedWarning() {
+			continue
+		}
+
+		if !order.IsOpen() || enforcement.closed(order.ID) {
+			continue
+		}
+
+		recorded, err := order.recordBudgetWarningOnce(tx, line, usage)
+		if err != nil {
+			return nil, err
+		}
+		if recorded {
+			enforcement.WarnedOrders = append(enforcement.WarnedOrders, *order)
+		}
+	}
+
+	return enforcement, nil
+}
+
+func (f *FactoryBudgetEnforcement) closed(orderID uuid.UUID) bool {
+	for _, order := range f.ClosedOrders {
+		if order.ID == orderID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// halt cancels the order's in-flight step runs and closes it as `failed`.
+// Orders that are no longer open were already halted (or closed by
+// someone else), so they are left alone.
+func (f *FactoryBudgetEnforcement) halt(tx *gorm.DB, order *FactoryWorkOrder, line *FactoryLine, usage *FactoryBudgetUsage) error {
+	if !order.IsOpen() {
+		return nil
+	}
+
+	var executions []FactoryWorkOrderExecution
+	err := tx.
+		Where("work_order_id = ?", order.ID).
+		Where("status IN ?", []string{FactoryWorkOrderExecutionStatusPending, FactoryWorkOrderExecutionStatusRunning}).
+		Where("run_id IS NOT NULL").
+		Find(&executions).
+		Error
+	if err != nil {
+		return err
+	}
+
+	for _, execution := range executions {
+		run, err := LockCanvasRunInTransaction(tx, *execution.RunID)
+		if err != nil {
+			return err
+		}
+
+		if run.State == CanvasRunStateFinished {
+			continue
+		}
+
+		drainResult, err := run.DrainForCancellation(tx, nil)
+		if err != nil {
+			return err
+		}
+
+		if run.State != CanvasRunStateCancelling {
+			if err := run.MarkAsCancelling(tx, nil); err != nil {
+				return err
+			}
+		}
+
+		f.CancelledRuns = append(f.CancelledRuns, FactoryBudgetCancelledRun{
+			WorkflowID:  run.WorkflowID,
+			RunID:       run.ID,
+			DrainResult: drainResult,
+		})
+	}
+
+	if err := order.recordBudgetEvent(tx, factory.EventTypeOrderBudgetExceeded, line, usage); err != nil {
+		return err
+	}
+
+	_, err = order.UpdateStatus(tx, FactoryWorkOrderStatusUpdate{
+		ToState:  FactoryWorkOrderStateClosed,
+		Result:   FactoryWorkOrderResultFailed,
+		SkipSame: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	f.ClosedOrders = append(f.ClosedOrders, *order)
+	return nil
+}
+
+func (l *FactoryLine) listOrdersWithActiveDispatch(tx *gorm.DB) ([]FactoryWorkOrder, error) {
+	var orders []FactoryWorkOrder
+	err := tx.
+		Where("id IN (?)", tx.
+			Model(&FactoryWorkOrderLineDispatch{}).
+			Select("work_order_id").
+			Where("line_id = ?", l.ID).
+			Where("state = ?", FactoryWorkOrderLineDispatchStateActive),
+		).
+		Order("created_at ASC").
+		Find(&orders).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// recordBudgetWarningOnce records the warning unless the order already has
+// one for the same budget scope and period.
+func (o *FactoryWorkOrder) recordBudgetWarningOnce(tx *gorm.DB, line *FactoryLine, usage *FactoryBudgetUsage) (bool, error) {
+	var count int64
+	err := tx.
+		Model(&FactoryWorkOrderEvent{}).
+		Where("work_order_id = ?", o.ID).
+		Where("type = ?", factory.EventTypeOrderBudgetWarning).
+		Where("data->>'scope' = ?", usage.Scope).
+		Where("COALESCE(data->>'period', '') = ?", usage.Period).
+		Count(&count).
+		Error
+	if err != nil {
+		return false, err
+	}
+
+	if count > 0 {
+		return false, nil
+	}
+
+	return true, o.recordBudgetEvent(tx, factory.EventTypeOrderBudgetWarning, line, usage)
+}
+
+func (o *FactoryWorkOrder) recordBudgetEvent(tx *gorm.DB, eventType string, line *FactoryLine, usage *FactoryBudgetUsage) error {
+	data := factory.WorkOrderBudgetReported{
+		Order:      o.Ref(),
+		Scope:      usage.Scope,
+		Period:     usage.Period,
+		LimitCents: usage.LimitCents,
+		SpentCents: usage.SpentCents,
+	}
+	if line != nil {
+		data.Line = &factory.LineRef{ID: line.ID, Name: line.Name}
+	}
+
+	return o.recordEvent(tx, eventType, data)
+}
@@ -0,0 +1,211 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/models/factory"
+	"github.com/superplanehq/superplane/test/support"
+	"gorm.io/gorm"
+)
+
+func Test__FactoryLine__EnsureBudgetAvailable(t *testing.T) {
+	r := support.Setup(t)
+	db := database.DB(t.Context())
+
+	factoryModel, err := models.CreateFactory(db, r.Organization.ID, support.RandomName("factory"), "", "")
+	require.NoError(t, err)
+	order, err := factoryModel.CreateWorkOrder(db, "Order", "", &r.User, nil, nil)
+	require.NoError(t, err)
+	line, err := factoryModel.CreateLine(db, "line", nil)
+	require.NoError(t, err)
+
+	t.Run("no budgets allow new work", func(t *testing.T) {
+		require.NoError(t, line.EnsureBudgetAvailable(db, order))
+	})
+
+	t.Run("zero line budget pauses the line", func(t *testing.T) {
+		zero := int64(0)
+		require.NoError(t, line.SetMonthlyBudget(db, &zero))
+		t.Cleanup(func() { require.NoError(t, line.SetMonthlyBudget(db, nil)) })
+
+		err := line.EnsureBudgetAvailable(db, order)
+		require.ErrorIs(t, err, models.ErrFactoryBudgetExceeded)
+	})
+
+	t.Run("order budget spent blocks new work", func(t *testing.T) {
+		budget := int64(100)
+		require.NoError(t, order.SetBudget(db, &budget))
+		createBudgetUsageEvent(t, db, order, line, 100)
+
+		err := line.EnsureBudgetAvailable(db, order)
+		require.ErrorIs(t, err, models.ErrFactoryBudgetExceeded)
+	})
+
+	t.Run("negative budgets are rejected", func(t *testing.T) {
+		negative := int64(-1)
+		require.ErrorIs(t, order.SetBudget(db, &negative), models.ErrFactoryBudgetInvalid)
+		require.ErrorIs(t, line.SetMonthlyBudget(db, &negative), models.ErrFactoryBudgetInvalid)
+	})
+}
+
+func Test__FactoryLine__MonthlyBudgetUsage__OnlyCountsCurrentMonth(t *testing.T) {
+	r := support.Setup(t)
+	db := database.DB(t.Context())
+
+	factoryModel, err := models.CreateFactory(db, r.Organization.ID, support.RandomName("factory"), "", "")
+	require.NoError(t, err)
+	order, err := factoryModel.CreateWorkOrder(db, "Order", "", &r.User, nil, nil)
+	require.NoError(t, err)
+	line, err := factoryModel.CreateLine(db, "line", nil)
+	require.NoError(t, err)
+
+	budget := int64(500)
+	require.NoError(t, line.SetMonthlyBudget(db, &budget))
+
+	now := time.Now()
+	previous := createBudgetUsageEvent(t, db, order, line, 300)
+	require.NoError(t, db.Model(previous).Update("occurred_at", models.FactoryBudgetPeriodStart(now).Add(-time.Hour)).Error)
+	createBudgetUsageEvent(t, db, order, line, 120)
+
+	usage, err := line.MonthlyBudgetUsage(db, now)
+	require.NoError(t, err)
+	require.NotNil(t, usage)
+	assert.Equal(t, factory.BudgetScopeLine, usage.Scope)
+	assert.Equal(t, now.UTC().Format("2006-01"), usage.Period)
+	assert.Equal(t, int64(500), usage.LimitCents)
+	assert.Equal(t, int64(120), usage.SpentCents)
+}
+
+func Test__FactoryWorkOrderExecution__EnforceBudgets(t *testing.T) {
+	r := support.Setup(t)
+	db := database.DB(t.Context())
+
+	factoryModel, err := models.CreateFactory(db, r.Organization.ID, support.RandomName("factory"), "", "")
+	require.NoError(t, err)
+	order, err := factoryModel.CreateWorkOrder(db, "Order", "", &r.User, nil, nil)
+	require.NoError(t, err)
+	_, err = order.UpdateStatus(db, models.FactoryWorkOrderStatusUpdate{ToState: models.FactoryWorkOrderStateOpen})
+	require.NoError(t, err)
+	line, err := factoryModel.CreateLine(db, "line", nil)
+	require.NoError(t, err)
+
+	budget := int64(100)
+	require.NoError(t, order.SetBudget(db, &budget))
+
+	canvas, _ := support.CreateCanvas(
+		t,
+		r.Organization.ID,
+		r.User,
+		[]models.CanvasNode{
+			{NodeID: "trigger", Type: models.NodeTypeTrigger},
+			{NodeID: "node-1", Type: models.NodeTypeComponent},
+		},
+		nil,
+	)
+	rootEvent := support.EmitCanvasEventForNode(t, canvas.ID, "trigger", "default", nil)
+	run := createRunForRootEvent(t, rootEvent)
+	dispatch := support.CreateFactoryLineDispatch(t, r.Organization.ID, factoryModel.ID, order.ID, line.ID, line.Name, nil)
+
+	now := time.Now()
+	execution := models.FactoryWorkOrderExecution{
+		ID:             uuid.New(),
+		OrganizationID: r.Organization.ID,
+		FactoryID:      factoryModel.ID,
+		WorkOrderID:    order.ID,
+		LineID:         line.ID,
+		LineDispatchID: dispatch.ID,
+		StepIndex:      0,
+		StepName:       "implement",
+		RunID:          &run.ID,
+		Status:         models.FactoryWorkOrderExecutionStatusRunning,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	require.NoError(t, db.Create(&execution).Error)
+
+	enforce := func() *models.FactoryBudgetEnforcement {
+		var enforcement *models.FactoryBudgetEnforcement
+		require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			enforcement, err = execution.EnforceBudgets(tx)
+			return err
+		}))
+		return enforcement
+	}
+
+	t.Run("below the warning threshold does nothing", func(t *testing.T) {
+		createBudgetUsageEvent(t, db, order, line, 50)
+
+		enforcement := enforce()
+		assert.Empty(t, enforcement.WarnedOrders)
+		assert.Empty(t, enforcement.ClosedOrders)
+	})
+
+	t.Run("crossing the warning threshold warns once", func(t *testing.T) {
+		createBudgetUsageEvent(t, db, order, line, 30)
+
+		enforcement := enforce()
+		require.Len(t, enforcement.WarnedOrders, 1)
+		assert.Empty(t, enforcement.ClosedOrders)
+
+		enforcement = enforce()
+		assert.Empty(t, enforcement.WarnedOrders)
+	})
+
+	t.Run("exceeding the budget cancels runs and closes the order as failed", func(t *testing.T) {
+		createBudgetUsageEvent(t, db, order, line, 25)
+
+		enforcement := enforce()
+		require.Len(t, enforcement.ClosedOrders, 1)
+		require.Len(t, enforcement.CancelledRuns, 1)
+		assert.Equal(t, run.ID, enforcement.CancelledRuns[0].RunID)
+
+		reloaded, err := models.FindUnscopedWorkOrder(db, order.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.FactoryWorkOrderStateClosed, reloaded.State)
+		assert.Equal(t, models.FactoryWorkOrderResultFailed, reloaded.Result)
+
+		events, err := reloaded.ListEvents(db, 50, nil)
+		require.NoError(t, err)
+		exceeded := 0
+		for _, event := range events {
+			if event.Type == factory.EventTypeOrderBudgetExceeded {
+				exceeded++
+			}
+		}
+		assert.Equal(t, 1, exceeded)
+
+		enforcement = enforce()
+		assert.Empty(t, enforcement.ClosedOrders)
+		assert.Empty(t, enforcement.CancelledRuns)
+	})
+}
+
+func createBudgetUsageEvent(t *testing.T, db *gorm.DB, order *models.FactoryWorkOrder, line *models.FactoryLine, costCents int64) *models.LLMUsageEvent {
+	t.Helper()
+
+	event := models.LLMUsageEvent{
+		ID:               uuid.New(),
+		OrganizationID:   order.OrganizationID,
+		FactoryID:        &order.FactoryID,
+		WorkOrderID:      &order.ID,
+		LineID:           &line.ID,
+		CanvasRunID:      uuid.New(),
+		NodeExecutionID:  uuid.New(),
+		NodeID:           "node-1",
+		Provider:         "anthropic",
+		Model:            "test-model",
+		CostMicros:       costCents * 10_000,
+		PriceBookVersion: "test",
+		IdempotencyKey:   uuid.NewString(),
+		OccurredAt:       time.Now(),
+	}
+	require.NoError(t, db.Create(&event).Error)
+	return &event
+}
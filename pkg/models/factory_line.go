@@ -51,8 +51,11 @@ type FactoryLine struct {
 	FactoryID      uuid.UUID
 	Name           string
 	Steps          datatypes.JSONSlice[FactoryLineStep]
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	// MonthlyBudgetCents caps the LLM spend of all work orders on the
+	// line per calendar month (UTC). Nil means no cap.
+	MonthlyBudgetCents *int64
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
 
 func (FactoryLine) TableName() string {
@@ -120,6 +123,21 @@ func (f *Factory) FindLine(tx *gorm.DB, lineID uuid.UUID) (*FactoryLine, error)
 	return &line, nil
 }
 
+// FindUnscopedFactoryLine is only OK to be used in workers and model
+// internals. For APIs, always use Factory.FindLine.
+func FindUnscopedFactoryLine(tx *gorm.DB, lineID uuid.UUID) (*FactoryLine, error) {
+	var line FactoryLine
+	err := tx.Where("id = ?", lineID).First(&line).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFactoryLineNotFound
+		}
+		return nil, err
+	}
+
+	return &line, nil
+}
+
 func (f *Factory) FindLineByName(tx *gorm.DB, name string) (*FactoryLine, error) {
 	var line FactoryLine
 	err := tx.
@@ -72,6 +72,14 @@ type FactoryWorkOrder struct {
 	// StatusNote is the jsonb array of current-wait announcements (see
 	// FactoryWorkOrderStatusNote). Cleared on every state transition.
 	StatusNote datatypes.JSON
+	// BudgetCents caps the order's lifetime LLM spend. Nil means no cap.
+	// See FactoryWorkOrderExecution.EnforceBudgets.
+	BudgetCents *int64
+	// TemplateID is the template the order was created from, if any.
+	// Fields holds the values of the template's custom fields, exposed to
+	// runs as order().fields.
+	TemplateID *uuid.UUID
+	Fields     datatypes.JSONType[map[string]any]
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 
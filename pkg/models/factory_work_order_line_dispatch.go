@@ -63,12 +63,17 @@ func (l *FactoryWorkOrderLineDispatch) Ref() *factory.LineRef {
 // Dispatch creates the line dispatch for order's traversal of l — snapshotting
 // l's current name/steps — and starts (or queues) step 0 inside it. Both
 // writes happen in the caller's transaction so a partial dispatch (parent
-// created, step 0 failed) can never be observed.
+// created, step 0 failed) can never be observed. An exhausted order or
+// line budget rejects the dispatch with ErrFactoryBudgetExceeded.
 func (l *FactoryLine) Dispatch(tx *gorm.DB, order *FactoryWorkOrder) (*FactoryWorkOrderLineDispatch, *FactoryLineStepResult, error) {
 	if len(l.Steps) == 0 {
 		return nil, nil, ErrFactoryLineHasNoSteps
 	}
 
+	if err := l.EnsureBudgetAvailable(tx, order); err != nil {
+		return nil, nil, err
+	}
+
 	now := time.Now()
 	dispatch := &FactoryWorkOrderLineDispatch{
 		ID:             uuid.New(),
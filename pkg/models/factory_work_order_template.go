@@ -0,0 +1,227 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/superplanehq/superplane/pkg/configuration"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const factoryWorkOrderTemplateNameUniqueConstraint = "factory_work_order_templates_factory_id_name_key"
+
+var (
+	ErrFactoryWorkOrderTemplateNotFound          = errors.New("work order template not found")
+	ErrFactoryWorkOrderTemplateNameAlreadyExists = errors.New("work order template name already exists")
+	ErrFactoryWorkOrderTemplateInvalid           = errors.New("invalid work order template")
+	ErrFactoryWorkOrderFieldsInvalid             = errors.New("invalid work order fields")
+)
+
+// FactoryWorkOrderTemplateFieldTypes are the configuration.Field types a
+// template may declare. Types that reference other resources (secrets,
+// integrations, apps, ...) are left out: order fields are plain values
+// that are exposed to runs through order().fields.
+var FactoryWorkOrderTemplateFieldTypes = []string{
+	configuration.FieldTypeString,
+	configuration.FieldTypeText,
+	configuration.FieldTypeNumber,
+	configuration.FieldTypeBool,
+	configuration.FieldTypeSelect,
+	configuration.FieldTypeMultiSelect,
+	configuration.FieldTypeDate,
+	configuration.FieldTypeDateTime,
+}
+
+type FactoryWorkOrderTemplate struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	FactoryID      uuid.UUID
+	Name           string
+	Description    string
+	Fields         datatypes.JSONSlice[configuration.Field]
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (FactoryWorkOrderTemplate) TableName() string {
+	return "factory_work_order_templates"
+}
+
+func mapFactoryWorkOrderTemplateNameUniqueConstraintError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == factoryWorkOrderTemplateNameUniqueConstraint {
+		return ErrFactoryWorkOrderTemplateNameAlreadyExists
+	}
+
+	return err
+}
+
+// ValidateFactoryWorkOrderTemplateFields checks the field definitions of a
+// template: names must be set and unique, and only the types listed in
+// FactoryWorkOrderTemplateFieldTypes are allowed.
+func ValidateFactoryWorkOrderTemplateFields(fields []configuration.Field) error {
+	seen := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		if field.Name == "" {
+			return fmt.Errorf("%w: field name is required", ErrFactoryWorkOrderTemplateInvalid)
+		}
+
+		if _, ok := seen[field.Name]; ok {
+			return fmt.Errorf("%w: duplicate field '%s'", ErrFactoryWorkOrderTemplateInvalid, field.Name)
+		}
+		seen[field.Name] = struct{}{}
+
+		if !slices.Contains(FactoryWorkOrderTemplateFieldTypes, field.Type) {
+			return fmt.Errorf("%w: field '%s' has unsupported type '%s'", ErrFactoryWorkOrderTemplateInvalid, field.Name, field.Type)
+		}
+	}
+
+	return nil
+}
+
+func (f *Factory) CreateWorkOrderTemplate(tx *gorm.DB, name, description string, fields []configuration.Field) (*FactoryWorkOrderTemplate, error) {
+	if err := ValidateFactoryWorkOrderTemplateFields(fields); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &FactoryWorkOrderTemplate{
+		ID:             uuid.New(),
+		OrganizationID: f.OrganizationID,
+		FactoryID:      f.ID,
+		Name:           name,
+		Description:    description,
+		Fields:         datatypes.JSONSlice[configuration.Field](fields),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := tx.Clauses(clause.Returning{}).Create(template).Error; err != nil {
+		return nil, mapFactoryWorkOrderTemplateNameUniqueConstraintError(err)
+	}
+
+	return template, nil
+}
+
+func (f *Factory) FindWorkOrderTemplate(tx *gorm.DB, templateID uuid.UUID) (*FactoryWorkOrderTemplate, error) {
+	var template FactoryWorkOrderTemplate
+	err := tx.
+		Where("organization_id = ? AND factory_id = ? AND id = ?", f.OrganizationID, f.ID, templateID).
+		First(&template).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFactoryWorkOrderTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (f *Factory) ListWorkOrderTemplates(tx *gorm.DB) ([]FactoryWorkOrderTemplate, error) {
+	var templates []FactoryWorkOrderTemplate
+	err := tx.
+		Where("organization_id = ? AND factory_id = ?", f.OrganizationID, f.ID).
+		Order("name ASC").
+		Order("id ASC").
+		Find(&templates).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// Update replaces the template's name, description and fields; nil leaves
+// the value as is. Orders created from the template keep the values they
+// were created with.
+func (t *FactoryWorkOrderTemplate) Update(tx *gorm.DB, name, description *string, fields []configuration.Field) error {
+	updates := map[string]any{
+		"updated_at": time.Now(),
+	}
+	if name != nil {
+		updates["name"] = *name
+	}
+	if description != nil {
+		updates["description"] = *description
+	}
+	if fields != nil {
+		if err := ValidateFactoryWorkOrderTemplateFields(fields); err != nil {
+			return err
+		}
+		updates["fields"] = datatypes.JSONSlice[configuration.Field](fields)
+	}
+
+	err := tx.Model(t).Updates(updates).Error
+	if err != nil {
+		return mapFactoryWorkOrderTemplateNameUniqueConstraintError(err)
+	}
+
+	return tx.
+		Where("id = ?", t.ID).
+		First(t).
+		Error
+}
+
+// Delete removes the template. Orders created from it keep their field
+// values; their template_id is cleared by the foreign key.
+func (t *FactoryWorkOrderTemplate) Delete(tx *gorm.DB) error {
+	return tx.Delete(t).Error
+}
+
+// ValidateValues checks order field values against the template: every
+// value must belong to a declared field and match its type, and required
+// fields must be present. Defaults are applied to missing optional fields.
+func (t *FactoryWorkOrderTemplate) ValidateValues(values map[string]any) (map[string]any, error) {
+	result := make(map[string]any, len(t.Fields))
+	for name, value := range values {
+		if !slices.ContainsFunc(t.Fields, func(field configuration.Field) bool { return field.Name == name }) {
+			return nil, fmt.Errorf("%w: unknown field '%s'", ErrFactoryWorkOrderFieldsInvalid, name)
+		}
+		result[name] = value
+	}
+
+	for _, field := range t.Fields {
+		if _, ok := result[field.Name]; !ok && field.Default != nil {
+			result[field.Name] = field.Default
+		}
+	}
+
+	if err := configuration.ValidateConfiguration(t.Fields, result); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFactoryWorkOrderFieldsInvalid, err)
+	}
+
+	return result, nil
+}
+
+// ApplyTemplate validates values against template and stores them as the
+// order's custom fields.
+func (o *FactoryWorkOrder) ApplyTemplate(tx *gorm.DB, template *FactoryWorkOrderTemplate, values map[string]any) error {
+	fields, err := template.ValidateValues(values)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	o.TemplateID = &template.ID
+	o.Fields = datatypes.NewJSONType(fields)
+	o.UpdatedAt = now
+
+	return tx.Model(o).Updates(map[string]any{
+		"template_id": template.ID,
+		"fields":      o.Fields,
+		"updated_at":  now,
+	}).Error
+}
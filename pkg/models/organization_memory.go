@@ -0,0 +1,356 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OrganizationMemory is a memory record shared by every canvas of an
+// organization. Besides the timestamps, each record keeps which canvas
+// or user created it and which one last updated it.
+type OrganizationMemory struct {
+	ID                uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID    uuid.UUID
+	Namespace         string
+	Values            datatypes.JSONType[any]
+	CreatedByCanvasID *uuid.UUID
+	CreatedByUserID   *uuid.UUID
+	UpdatedByCanvasID *uuid.UUID
+	UpdatedByUserID   *uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (OrganizationMemory) TableName() string {
+	return "organization_memories"
+}
+
+// OrganizationMemoryWriter identifies who writes organization memory.
+// Canvas executions set CanvasID, and API calls set UserID.
+type OrganizationMemoryWriter struct {
+	CanvasID *uuid.UUID
+	UserID   *uuid.UUID
+}
+
+// OrganizationMemoryNamespace summarizes a namespace of organization
+// memory, including every canvas that has written to it.
+type OrganizationMemoryNamespace struct {
+	Namespace     string
+	Records       int64
+	LastWrittenAt time.Time
+	CanvasIDs     []uuid.UUID
+}
+
+func AddOrganizationMemoryInTransaction(
+	tx *gorm.DB,
+	organizationID uuid.UUID,
+	namespace string,
+	values any,
+	writer OrganizationMemoryWriter,
+) (OrganizationMemory, error) {
+	now := time.Now()
+	record := OrganizationMemory{
+		OrganizationID:    organizationID,
+		Namespace:         namespace,
+		Values:            datatypes.NewJSONType(values),
+		CreatedByCanvasID: writer.CanvasID,
+		CreatedByUserID:   writer.UserID,
+		UpdatedByCanvasID: writer.CanvasID,
+		UpdatedByUserID:   writer.UserID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if err := tx.Create(&record).Error; err != nil {
+		return OrganizationMemory{}, err
+	}
+
+	return record, nil
+}
+
+// ListOrganizationMemoriesInTransaction lists the records of one
+// namespace, or of every namespace when the namespace is empty.
+func ListOrganizationMemoriesInTransaction(tx *gorm.DB, organizationID uuid.UUID, namespace string) ([]OrganizationMemory, error) {
+	query := tx.Where("organization_id = ?", organizationID)
+	if namespace != "" {
+		query = query.Where("namespace = ?", namespace)
+	}
+
+	var records []OrganizationMemory
+	err := query.
+		Order("namespace ASC").
+		Order("created_at DESC").
+		Find(&records).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func FindOrganizationMemoryInTransaction(tx *gorm.DB, organizationID, memoryID uuid.UUID) (*OrganizationMemory, error) {
+	var record OrganizationMemory
+	err := tx.
+		Where("organization_id = ? AND id = ?", organizationID, memoryID).
+		First(&record).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func ListOrganizationMemoriesByNamespaceAndMatchesInTransaction(
+	tx *gorm.DB,
+	organizationID uuid.UUID,
+	namespace string,
+	matches map[string]any,
+) ([]OrganizationMemory, error) {
+	if len(matches) == 0 {
+		return []OrganizationMemory{}, fmt.Errorf("at least one match expression is required")
+	}
+
+	query, err := organizationMemoryNamespaceQuery(tx, organizationID, namespace, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []OrganizationMemory
+	err = query.
+		Order("created_at DESC").
+		Find(&records).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func FindFirstOrganizationMemoryByNamespaceAndMatchesInTransaction(
+	tx *gorm.DB,
+	organizationID uuid.UUID,
+	namespace string,
+	matches map[string]any,
+) (*OrganizationMemory, error) {
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("at least one match expression is required")
+	}
+
+	query, err := organizationMemoryNamespaceQuery(tx, organizationID, namespace, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []OrganizationMemory
+	err = query.
+		Order("created_at DESC").
+		Limit(1).
+		Find(&records).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	return &records[0], nil
+}
+
+func DeleteOrganizationMemoryInTransaction(tx *gorm.DB, organizationID, memoryID uuid.UUID) error {
+	return tx.
+		Where("organization_id = ? AND id = ?", organizationID, memoryID).
+		Delete(&OrganizationMemory{}).
+		Error
+}
+
+func DeleteOrganizationMemoriesByNamespaceAndMatchesInTransaction(
+	tx *gorm.DB,
+	organizationID uuid.UUID,
+	namespace string,
+	matches map[string]any,
+) ([]OrganizationMemory, error) {
+	if len(matches) == 0 {
+		return []OrganizationMemory{}, fmt.Errorf("at least one match expression is required")
+	}
+
+	query, err := organizationMemoryNamespaceQuery(tx, organizationID, namespace, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	var deletedRecords []OrganizationMemory
+	err = query.
+		Clauses(clause.Returning{}).
+		Delete(&deletedRecords).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	sortOrganizationMemoriesNewestFirst(deletedRecords)
+	return deletedRecords, nil
+}
+
+func UpdateOrganizationMemoriesByNamespaceAndMatchesInTransaction(
+	tx *gorm.DB,
+	organizationID uuid.UUID,
+	namespace string,
+	matches map[string]any,
+	values map[string]any,
+	writer OrganizationMemoryWriter,
+) ([]OrganizationMemory, error) {
+	if len(matches) == 0 {
+		return []OrganizationMemory{}, fmt.Errorf("at least one match expression is required")
+	}
+
+	query, err := organizationMemoryNamespaceQuery(tx, organizationID, namespace, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	return updateOrganizationMemories(query, values, writer)
+}
+
+func UpdateOrganizationMemoriesByNamespaceInTransaction(
+	tx *gorm.DB,
+	organizationID uuid.UUID,
+	namespace string,
+	values map[string]any,
+	writer OrganizationMemoryWriter,
+) ([]OrganizationMemory, error) {
+	query, err := organizationMemoryNamespaceQuery(tx, organizationID, namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return updateOrganizationMemories(query, values, writer)
+}
+
+// ListOrganizationMemoryNamespacesInTransaction returns every namespace
+// of the organization with its record count and the canvases that created
+// or last updated one of its records, ordered by namespace.
+func ListOrganizationMemoryNamespacesInTransaction(tx *gorm.DB, organizationID uuid.UUID) ([]OrganizationMemoryNamespace, error) {
+	var rows []struct {
+		Namespace     string
+		Records       int64
+		LastWrittenAt time.Time
+	}
+
+	err := tx.
+		Model(&OrganizationMemory{}).
+		Select("namespace, COUNT(*) AS records, MAX(updated_at) AS last_written_at").
+		Where("organization_id = ?", organizationID).
+		Group("namespace").
+		Order("namespace ASC").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var writers []struct {
+		Namespace string
+		CanvasID  uuid.UUID
+	}
+
+	err = tx.Raw(
+		`SELECT DISTINCT namespace, canvas_id FROM (
+			SELECT namespace, created_by_canvas_id AS canvas_id FROM organization_memories WHERE organization_id = ?
+			UNION
+			SELECT namespace, updated_by_canvas_id AS canvas_id FROM organization_memories WHERE organization_id = ?
+		) writers
+		WHERE canvas_id IS NOT NULL
+		ORDER BY namespace, canvas_id`,
+		organizationID,
+		organizationID,
+	).Scan(&writers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]OrganizationMemoryNamespace, 0, len(rows))
+	byNamespace := make(map[string]int, len(rows))
+	for i, row := range rows {
+		namespaces = append(namespaces, OrganizationMemoryNamespace{
+			Namespace:     row.Namespace,
+			Records:       row.Records,
+			LastWrittenAt: row.LastWrittenAt,
+			CanvasIDs:     []uuid.UUID{},
+		})
+		byNamespace[row.Namespace] = i
+	}
+
+	for _, writer := range writers {
+		if i, ok := byNamespace[writer.Namespace]; ok {
+			namespaces[i].CanvasIDs = append(namespaces[i].CanvasIDs, writer.CanvasID)
+		}
+	}
+
+	return namespaces, nil
+}
+
+func updateOrganizationMemories(query *gorm.DB, values map[string]any, writer OrganizationMemoryWriter) ([]OrganizationMemory, error) {
+	if len(values) == 0 {
+		return []OrganizationMemory{}, fmt.Errorf("at least one value expression is required")
+	}
+
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedRecords []OrganizationMemory
+	err = query.
+		Model(&updatedRecords).
+		Clauses(clause.Returning{}).
+		Updates(map[string]any{
+			"values":               gorm.Expr("values || ?::jsonb", valuesJSON),
+			"updated_by_canvas_id": writer.CanvasID,
+			"updated_by_user_id":   writer.UserID,
+			"updated_at":           gorm.Expr("NOW()"),
+		}).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	sortOrganizationMemoriesNewestFirst(updatedRecords)
+	return updatedRecords, nil
+}
+
+func organizationMemoryNamespaceQuery(
+	tx *gorm.DB,
+	organizationID uuid.UUID,
+	namespace string,
+	matches map[string]any,
+) (*gorm.DB, error) {
+	query := tx.Where("organization_id = ? AND namespace = ?", organizationID, namespace)
+	if len(matches) == 0 {
+		return query, nil
+	}
+
+	matchesJSON, err := json.Marshal(matches)
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Where("values @> ?::jsonb", matchesJSON), nil
+}
+
+func sortOrganizationMemoriesNewestFirst(records []OrganizationMemory) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
+}
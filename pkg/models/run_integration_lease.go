@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RunIntegrationLease is a lease on credentials an integration issued to a
+// run while resolving secrets for one of its steps. It is revoked, and the
+// row removed, once the run finishes.
+type RunIntegrationLease struct {
+	ID            uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	CanvasID      uuid.UUID
+	RunID         uuid.UUID
+	IntegrationID uuid.UUID
+	LeaseID       string
+	Source        string
+	Attempts      int
+	CreatedAt     *time.Time
+}
+
+func (RunIntegrationLease) TableName() string { return "run_integration_leases" }
+
+// CreateRunIntegrationLease records a lease. It uses its own connection, so
+// the lease is kept even when the execution that resolved the secrets rolls
+// back: the credentials were issued either way.
+func CreateRunIntegrationLease(canvasID, runID, integrationID uuid.UUID, leaseID, source string) (*RunIntegrationLease, error) {
+	now := time.Now()
+	lease := &RunIntegrationLease{
+		ID:            uuid.New(),
+		CanvasID:      canvasID,
+		RunID:         runID,
+		IntegrationID: integrationID,
+		LeaseID:       leaseID,
+		Source:        source,
+		CreatedAt:     &now,
+	}
+
+	err := database.Conn().
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(lease).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return lease, nil
+}
+
+// ListRunIntegrationLeases returns the leases an integration issued to a run,
+// oldest first.
+func ListRunIntegrationLeases(tx *gorm.DB, runID, integrationID uuid.UUID) ([]RunIntegrationLease, error) {
+	var leases []RunIntegrationLease
+	err := tx.
+		Where("run_id = ? AND integration_id = ?", runID, integrationID).
+		Order("created_at, lease_id").
+		Find(&leases).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return leases, nil
+}
+
+// ListRunIntegrationLeasesToRevoke returns leases of runs that finished or
+// no longer exist, least attempted first.
+func ListRunIntegrationLeasesToRevoke(tx *gorm.DB, limit int) ([]RunIntegrationLease, error) {
+	var leases []RunIntegrationLease
+	err := tx.
+		Table("run_integration_leases AS l").
+		Select("l.*").
+		Joins("LEFT JOIN workflow_runs AS r ON r.id = l.run_id").
+		Where("r.id IS NULL OR r.state = ?", CanvasRunStateFinished).
+		Order("l.attempts, l.created_at").
+		Limit(limit).
+		Find(&leases).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return leases, nil
+}
+
+func DeleteRunIntegrationLease(tx *gorm.DB, id uuid.UUID) error {
+	return tx.Where("id = ?", id).Delete(&RunIntegrationLease{}).Error
+}
+
+func IncrementRunIntegrationLeaseAttempts(tx *gorm.DB, id uuid.UUID) (int, error) {
+	var lease RunIntegrationLease
+	err := tx.
+		Model(&lease).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "attempts"}}}).
+		Where("id = ?", id).
+		UpdateColumn("attempts", gorm.Expr("attempts + 1")).
+		Error
+	if err != nil {
+		return 0, err
+	}
+	return lease.Attempts, nil
+}
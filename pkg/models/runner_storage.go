@@ -0,0 +1,366 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrRunnerArtifactNotFound   = errors.New("artifact not found")
+	ErrRunnerCacheNotFound      = errors.New("cache not found")
+	ErrRunnerCacheQuotaExceeded = errors.New("cache is larger than the canvas cache quota")
+)
+
+// RunnerArtifact is a file set a runner task declared as output, stored as
+// a gzipped tarball for the run that produced it. The tarball itself is in
+// the runner blob store, under StorageKey.
+type RunnerArtifact struct {
+	ID             uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	OrganizationID uuid.UUID
+	CanvasID       uuid.UUID
+	RunID          uuid.UUID
+	ExecutionID    uuid.UUID
+	NodeID         string
+	Name           string
+	SizeBytes      int64
+	SHA256         string `gorm:"column:sha256"`
+	StorageKey     string
+	CreatedAt      *time.Time
+	ExpiresAt      *time.Time
+}
+
+func (RunnerArtifact) TableName() string { return "runner_artifacts" }
+
+// DownloadPath is the API path users with read access to the canvas
+// download the artifact from.
+func (a *RunnerArtifact) DownloadPath() string {
+	return fmt.Sprintf("/api/v1/canvases/%s/runs/%s/artifacts/%s/content", a.CanvasID, a.RunID, a.ID)
+}
+
+// RunnerCache is a gzipped tarball restored into runner tasks that ask for
+// the same key on the same canvas. The tarball itself is in the runner
+// blob store, under StorageKey.
+type RunnerCache struct {
+	ID             uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	OrganizationID uuid.UUID
+	CanvasID       uuid.UUID
+	Key            string
+	SizeBytes      int64
+	SHA256         string `gorm:"column:sha256"`
+	StorageKey     string
+	CreatedAt      *time.Time
+	LastUsedAt     *time.Time
+}
+
+func (RunnerCache) TableName() string { return "runner_caches" }
+
+// RunnerBlob describes content already written to the runner blob store.
+type RunnerBlob struct {
+	StorageKey string
+	SizeBytes  int64
+	SHA256     string
+}
+
+// SaveRunnerArtifact records an uploaded artifact. Uploading an artifact
+// with the same name from the same execution replaces it, so runner
+// retries don't fail on the unique name. The key of the replaced blob is
+// returned, for the caller to delete once nothing points to it.
+func SaveRunnerArtifact(execution *CanvasNodeExecution, organizationID uuid.UUID, name string, blob RunnerBlob, retention time.Duration) (*RunnerArtifact, string, error) {
+	now := time.Now()
+	expiresAt := now.Add(retention)
+	artifact := &RunnerArtifact{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		CanvasID:       execution.WorkflowID,
+		RunID:          execution.RunID,
+		ExecutionID:    execution.ID,
+		NodeID:         execution.NodeID,
+		Name:           name,
+		SizeBytes:      blob.SizeBytes,
+		SHA256:         blob.SHA256,
+		StorageKey:     blob.StorageKey,
+		CreatedAt:      &now,
+		ExpiresAt:      &expiresAt,
+	}
+
+	replacedKey := ""
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		var existing RunnerArtifact
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("execution_id = ? AND name = ?", execution.ID, name).
+			First(&existing).
+			Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(artifact).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		replacedKey = existing.StorageKey
+		artifact.ID = existing.ID
+		return tx.Model(&existing).Updates(map[string]any{
+			"size_bytes":  artifact.SizeBytes,
+			"sha256":      artifact.SHA256,
+			"storage_key": artifact.StorageKey,
+			"created_at":  now,
+			"expires_at":  expiresAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return artifact, replacedKey, nil
+}
+
+// ListRunnerArtifactsForRun returns the unexpired artifacts of a run,
+// oldest first.
+func ListRunnerArtifactsForRun(tx *gorm.DB, canvasID, runID uuid.UUID) ([]RunnerArtifact, error) {
+	var artifacts []RunnerArtifact
+	err := tx.
+		Where("canvas_id = ? AND run_id = ?", canvasID, runID).
+		Where("expires_at > ?", time.Now()).
+		Order("created_at, name").
+		Find(&artifacts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+// ListRunnerArtifactsForExecution returns the unexpired artifacts an
+// execution uploaded.
+func ListRunnerArtifactsForExecution(executionID uuid.UUID) ([]RunnerArtifact, error) {
+	var artifacts []RunnerArtifact
+	err := database.Conn().
+		Where("execution_id = ?", executionID).
+		Where("expires_at > ?", time.Now()).
+		Order("name").
+		Find(&artifacts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+// FindRunnerArtifact loads an unexpired artifact of a run.
+func FindRunnerArtifact(canvasID, runID, id uuid.UUID) (*RunnerArtifact, error) {
+	return findRunnerArtifact(database.Conn().Where("run_id = ?", runID), canvasID, id)
+}
+
+// FindRunnerArtifactInCanvas loads an unexpired artifact
+// uploaded by any run of the canvas.
+func FindRunnerArtifactInCanvas(tx *gorm.DB, canvasID, id uuid.UUID) (*RunnerArtifact, error) {
+	return findRunnerArtifact(tx, canvasID, id)
+}
+
+func findRunnerArtifact(tx *gorm.DB, canvasID, id uuid.UUID) (*RunnerArtifact, error) {
+	var artifact RunnerArtifact
+	err := tx.
+		Where("id = ? AND canvas_id = ?", id, canvasID).
+		Where("expires_at > ?", time.Now()).
+		First(&artifact).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRunnerArtifactNotFound
+		}
+		return nil, err
+	}
+	return &artifact, nil
+}
+
+// DeleteExpiredRunnerArtifacts deletes artifacts past their expiry, or
+// whose canvas was deleted, and returns the keys of their blobs.
+func DeleteExpiredRunnerArtifacts(db *gorm.DB, now time.Time, limit int) ([]string, error) {
+	return deleteRunnerStorageRows(db, &RunnerArtifact{}, limit,
+		"expires_at <= ? OR "+runnerStorageCanvasDeleted("runner_artifacts"), now)
+}
+
+// SaveRunnerCache records an uploaded cache, replacing the previous cache
+// with the same key. The caches of a canvas share a quota: the caches
+// restored least recently are evicted to make room for the new one, and
+// a cache larger than the whole quota is rejected. A quota of zero means
+// no quota. The keys of replaced and evicted blobs are returned, for the
+// caller to delete once nothing points to them.
+//
+// Rows are locked while the quota is checked, but a cache saved under a
+// new key concurrently can still push a canvas slightly over its quota
+// until the next save evicts again.
+func SaveRunnerCache(organizationID, canvasID uuid.UUID, key string, blob RunnerBlob, quotaBytes int64) (*RunnerCache, []string, error) {
+	if quotaBytes > 0 && blob.SizeBytes > quotaBytes {
+		return nil, nil, ErrRunnerCacheQuotaExceeded
+	}
+
+	now := time.Now()
+	cache := &RunnerCache{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		CanvasID:       canvasID,
+		Key:            key,
+		SizeBytes:      blob.SizeBytes,
+		SHA256:         blob.SHA256,
+		StorageKey:     blob.StorageKey,
+		CreatedAt:      &now,
+		LastUsedAt:     &now,
+	}
+
+	staleKeys := []string{}
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		var caches []RunnerCache
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("canvas_id = ?", canvasID).
+			Order("last_used_at, created_at").
+			Find(&caches).
+			Error
+		if err != nil {
+			return err
+		}
+
+		var existing *RunnerCache
+		usedBytes := int64(0)
+		for i := range caches {
+			if caches[i].Key == key {
+				existing = &caches[i]
+				continue
+			}
+			usedBytes += caches[i].SizeBytes
+		}
+
+		evicted := []uuid.UUID{}
+		for i := range caches {
+			if quotaBytes <= 0 || usedBytes+blob.SizeBytes <= quotaBytes {
+				break
+			}
+			if caches[i].Key == key {
+				continue
+			}
+
+			evicted = append(evicted, caches[i].ID)
+			staleKeys = append(staleKeys, caches[i].StorageKey)
+			usedBytes -= caches[i].SizeBytes
+		}
+
+		if len(evicted) > 0 {
+			if err := tx.Where("id IN ?", evicted).Delete(&RunnerCache{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if existing == nil {
+			return tx.Create(cache).Error
+		}
+
+		staleKeys = append(staleKeys, existing.StorageKey)
+		cache.ID = existing.ID
+		return tx.Model(existing).Updates(map[string]any{
+			"size_bytes":   cache.SizeBytes,
+			"sha256":       cache.SHA256,
+			"storage_key":  cache.StorageKey,
+			"created_at":   now,
+			"last_used_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cache, staleKeys, nil
+}
+
+// FindRunnerCache loads a cache entry and marks it as used, which keeps
+// it from expiring and from being evicted first.
+func FindRunnerCache(canvasID uuid.UUID, key string) (*RunnerCache, error) {
+	var cache RunnerCache
+	err := database.Conn().
+		Where("canvas_id = ? AND key = ?", canvasID, key).
+		First(&cache).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRunnerCacheNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	err = database.Conn().
+		Model(&RunnerCache{}).
+		Where("id = ?", cache.ID).
+		Update("last_used_at", now).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	cache.LastUsedAt = &now
+	return &cache, nil
+}
+
+// DeleteUnusedRunnerCaches deletes caches no task restored since
+// unusedSince, or whose canvas was deleted, and returns the keys
+// of their blobs.
+func DeleteUnusedRunnerCaches(db *gorm.DB, unusedSince time.Time, limit int) ([]string, error) {
+	return deleteRunnerStorageRows(db, &RunnerCache{}, limit,
+		"last_used_at <= ? OR "+runnerStorageCanvasDeleted("runner_caches"), unusedSince)
+}
+
+func runnerStorageCanvasDeleted(table string) string {
+	return fmt.Sprintf(
+		"NOT EXISTS (SELECT 1 FROM workflows WHERE workflows.id = %s.canvas_id AND workflows.deleted_at IS NULL)",
+		table,
+	)
+}
+
+// deleteRunnerStorageRows works like deleteRowsLimited, but returns the
+// blob keys of the deleted rows. Rows locked by another cleanup are
+// skipped, so the same blob is never handed out twice.
+func deleteRunnerStorageRows(db *gorm.DB, model any, limit int, query string, args ...any) ([]string, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	var rows []struct {
+		ID         uuid.UUID
+		StorageKey string
+	}
+
+	err := db.Model(model).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Select("id", "storage_key").
+		Where(query, args...).
+		Limit(limit).
+		Find(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	keys := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+		keys = append(keys, row.StorageKey)
+	}
+
+	if err := db.Where("id IN ?", ids).Delete(model).Error; err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
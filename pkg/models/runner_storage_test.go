@@ -0,0 +1,79 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/database"
+)
+
+func TestSaveRunnerCache(t *testing.T) {
+	organizationID := uuid.New()
+
+	t.Run("replacing a cache returns the replaced blob", func(t *testing.T) {
+		require.NoError(t, database.TruncateTables())
+		canvasID := uuid.New()
+
+		first, stale, err := SaveRunnerCache(organizationID, canvasID, "deps", runnerBlobForTest("first", 10), 0)
+		require.NoError(t, err)
+		assert.Empty(t, stale)
+
+		second, stale, err := SaveRunnerCache(organizationID, canvasID, "deps", runnerBlobForTest("second", 20), 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first"}, stale)
+		assert.Equal(t, first.ID, second.ID)
+
+		cache, err := FindRunnerCache(canvasID, "deps")
+		require.NoError(t, err)
+		assert.Equal(t, "second", cache.StorageKey)
+		assert.Equal(t, int64(20), cache.SizeBytes)
+	})
+
+	t.Run("evicts the least recently used caches of the canvas to stay within the quota", func(t *testing.T) {
+		require.NoError(t, database.TruncateTables())
+		canvasID := uuid.New()
+		otherCanvasID := uuid.New()
+
+		oldest, _, err := SaveRunnerCache(organizationID, canvasID, "oldest", runnerBlobForTest("oldest", 40), 100)
+		require.NoError(t, err)
+		recent, _, err := SaveRunnerCache(organizationID, canvasID, "recent", runnerBlobForTest("recent", 40), 100)
+		require.NoError(t, err)
+		_, _, err = SaveRunnerCache(organizationID, otherCanvasID, "other", runnerBlobForTest("other", 90), 100)
+		require.NoError(t, err)
+
+		require.NoError(t, database.Conn().Model(oldest).Update("last_used_at", time.Now().Add(-2*time.Hour)).Error)
+		require.NoError(t, database.Conn().Model(recent).Update("last_used_at", time.Now().Add(-time.Hour)).Error)
+
+		_, stale, err := SaveRunnerCache(organizationID, canvasID, "new", runnerBlobForTest("new", 50), 100)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"oldest"}, stale)
+
+		_, err = FindRunnerCache(canvasID, "oldest")
+		assert.ErrorIs(t, err, ErrRunnerCacheNotFound)
+		_, err = FindRunnerCache(canvasID, "recent")
+		assert.NoError(t, err)
+		_, err = FindRunnerCache(otherCanvasID, "other")
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects a cache larger than the quota", func(t *testing.T) {
+		require.NoError(t, database.TruncateTables())
+		canvasID := uuid.New()
+
+		_, _, err := SaveRunnerCache(organizationID, canvasID, "kept", runnerBlobForTest("kept", 10), 100)
+		require.NoError(t, err)
+
+		_, _, err = SaveRunnerCache(organizationID, canvasID, "huge", runnerBlobForTest("huge", 101), 100)
+		assert.ErrorIs(t, err, ErrRunnerCacheQuotaExceeded)
+
+		_, err = FindRunnerCache(canvasID, "kept")
+		assert.NoError(t, err)
+	})
+}
+
+func runnerBlobForTest(storageKey string, size int64) RunnerBlob {
+	return RunnerBlob{StorageKey: storageKey, SizeBytes: size, SHA256: "sha"}
+}
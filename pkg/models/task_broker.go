@@ -0,0 +1,410 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superplanehq/superplane/pkg/database"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	TaskBrokerTaskStatusQueued    = "queued"
+	TaskBrokerTaskStatusRunning   = "running"
+	TaskBrokerTaskStatusSucceeded = "succeeded"
+	TaskBrokerTaskStatusFailed    = "failed"
+	TaskBrokerTaskStatusCanceled  = "canceled"
+)
+
+var (
+	ErrTaskBrokerTaskNotFound = errors.New("task not found")
+	ErrTaskBrokerLeaseLost    = errors.New("task is not leased to this runner")
+)
+
+type TaskBrokerRunner struct {
+	ID         uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	FleetID    string
+	Name       string
+	TokenHash  string
+	CreatedAt  *time.Time
+	LastSeenAt *time.Time
+}
+
+func (TaskBrokerRunner) TableName() string { return "task_broker_runners" }
+
+type TaskBrokerTask struct {
+	ID                      uuid.UUID `gorm:"primaryKey;default:uuid_generate_v4()"`
+	FleetID                 string
+	Status                  string
+	Spec                    datatypes.JSON
+	ExecutionMode           string
+	DockerImage             string
+	ExecutionTimeoutSeconds int
+	WebhookURL              string
+	RunnerID                *uuid.UUID
+	CancelRequested         bool
+	ExitCode                *int
+	Output                  string
+	Error                   string
+	Result                  datatypes.JSON
+	CreatedAt               *time.Time
+	ClaimedAt               *time.Time
+	LeaseUntil              *time.Time
+	FinishedAt              *time.Time
+}
+
+func (TaskBrokerTask) TableName() string { return "task_broker_tasks" }
+
+func (t *TaskBrokerTask) IsFinished() bool {
+	switch t.Status {
+	case TaskBrokerTaskStatusSucceeded, TaskBrokerTaskStatusFailed, TaskBrokerTaskStatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+type TaskBrokerTaskLog struct {
+	ID        int64 `gorm:"primaryKey"`
+	TaskID    uuid.UUID
+	Record    datatypes.JSON
+	CreatedAt *time.Time
+}
+
+func (TaskBrokerTaskLog) TableName() string { return "task_broker_task_logs" }
+
+// TaskBrokerTaskOutcome is what a runner reports when a task stops.
+type TaskBrokerTaskOutcome struct {
+	Status   string
+	ExitCode *int
+	Output   string
+	Error    string
+	Result   []byte
+}
+
+// TaskBrokerFleet summarizes the runners registered under one fleet ID.
+type TaskBrokerFleet struct {
+	FleetID       string
+	Runners       int64
+	OnlineRunners int64
+}
+
+func CreateTaskBrokerRunner(fleetID, name, tokenHash string) (*TaskBrokerRunner, error) {
+	now := time.Now()
+	runner := &TaskBrokerRunner{
+		ID:         uuid.New(),
+		FleetID:    fleetID,
+		Name:       name,
+		TokenHash:  tokenHash,
+		CreatedAt:  &now,
+		LastSeenAt: &now,
+	}
+
+	if err := database.Conn().Create(runner).Error; err != nil {
+		return nil, err
+	}
+
+	return runner, nil
+}
+
+func FindTaskBrokerRunnerByTokenHash(tokenHash string) (*TaskBrokerRunner, error) {
+	var runner TaskBrokerRunner
+	err := database.Conn().Where("token_hash = ?", tokenHash).First(&runner).Error
+	if err != nil {
+		return nil, err
+	}
+	return &runner, nil
+}
+
+func TouchTaskBrokerRunner(id uuid.UUID) error {
+	return database.Conn().
+		Model(&TaskBrokerRunner{}).
+		Where("id = ?", id).
+		Update("last_seen_at", time.Now()).
+		Error
+}
+
+// ListTaskBrokerFleets returns every fleet with at least one registered
+// runner. Runners seen after onlineSince count as online.
+func ListTaskBrokerFleets(onlineSince time.Time) ([]TaskBrokerFleet, error) {
+	var fleets []TaskBrokerFleet
+	err := database.Conn().
+		Model(&TaskBrokerRunner{}).
+		Select("fleet_id, COUNT(*) AS runners, COUNT(*) FILTER (WHERE last_seen_at > ?) AS online_runners", onlineSince).
+		Group("fleet_id").
+		Order("fleet_id").
+		Scan(&fleets).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return fleets, nil
+}
+
+func CreateTaskBrokerTask(task *TaskBrokerTask) error {
+	now := time.Now()
+	task.ID = uuid.New()
+	task.Status = TaskBrokerTaskStatusQueued
+	task.CreatedAt = &now
+	return database.Conn().Create(task).Error
+}
+
+func FindTaskBrokerTask(id uuid.UUID) (*TaskBrokerTask, error) {
+	var task TaskBrokerTask
+	err := database.Conn().Where("id = ?", id).First(&task).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskBrokerTaskNotFound
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+func ListActiveTaskBrokerTasks() ([]TaskBrokerTask, error) {
+	var tasks []TaskBrokerTask
+	err := database.Conn().
+		Where("status IN ?", []string{TaskBrokerTaskStatusQueued, TaskBrokerTaskStatusRunning}).
+		Order("created_at").
+		Find(&tasks).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ClaimTaskBrokerTask leases the oldest queued task of the fleet to the
+// runner. It returns nil when the queue is empty.
+func ClaimTaskBrokerTask(runnerID uuid.UUID, fleetID string, lease time.Duration) (*TaskBrokerTask, error) {
+	var claimed *TaskBrokerTask
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		var task TaskBrokerTask
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("fleet_id = ?", fleetID).
+			Where("status = ?", TaskBrokerTaskStatusQueued).
+			Order("created_at").
+			First(&task).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		now := time.Now()
+		leaseUntil := now.Add(lease)
+		task.Status = TaskBrokerTaskStatusRunning
+		task.RunnerID = &runnerID
+		task.ClaimedAt = &now
+		task.LeaseUntil = &leaseUntil
+
+		err = tx.Model(&task).Updates(map[string]any{
+			"status":      task.Status,
+			"runner_id":   runnerID,
+			"claimed_at":  now,
+			"lease_until": leaseUntil,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		claimed = &task
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// RenewTaskBrokerTaskLease extends the lease of a running task held by the
+// runner and returns the task so the runner can observe cancel requests.
+func RenewTaskBrokerTaskLease(id, runnerID uuid.UUID, lease time.Duration) (*TaskBrokerTask, error) {
+	var task TaskBrokerTask
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&task).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTaskBrokerTaskNotFound
+			}
+			return err
+		}
+
+		if task.Status != TaskBrokerTaskStatusRunning || task.RunnerID == nil || *task.RunnerID != runnerID {
+			return ErrTaskBrokerLeaseLost
+		}
+
+		leaseUntil := time.Now().Add(lease)
+		task.LeaseUntil = &leaseUntil
+		return tx.Model(&task).Update("lease_until", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// RequestTaskBrokerTaskCancel cancels a queued task immediately and flags a
+// running one so its runner stops it. The returned bool reports whether the
+// task reached a terminal state in this call.
+func RequestTaskBrokerTaskCancel(id uuid.UUID) (*TaskBrokerTask, bool, error) {
+	var task TaskBrokerTask
+	finished := false
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&task).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTaskBrokerTaskNotFound
+			}
+			return err
+		}
+
+		switch task.Status {
+		case TaskBrokerTaskStatusQueued:
+			now := time.Now()
+			task.Status = TaskBrokerTaskStatusCanceled
+			task.CancelRequested = true
+			task.FinishedAt = &now
+			finished = true
+			return tx.Model(&task).Updates(map[string]any{
+				"status":           task.Status,
+				"cancel_requested": true,
+				"finished_at":      now,
+			}).Error
+		case TaskBrokerTaskStatusRunning:
+			task.CancelRequested = true
+			return tx.Model(&task).Update("cancel_requested", true).Error
+		default:
+			return nil
+		}
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &task, finished, nil
+}
+
+// FinishTaskBrokerTask records the outcome reported by the runner holding
+// the task. A task that already finished, for example because its lease
+// expired, is returned unchanged with ErrTaskBrokerLeaseLost.
+func FinishTaskBrokerTask(id, runnerID uuid.UUID, outcome TaskBrokerTaskOutcome) (*TaskBrokerTask, error) {
+	var task TaskBrokerTask
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&task).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTaskBrokerTaskNotFound
+			}
+			return err
+		}
+
+		if task.Status != TaskBrokerTaskStatusRunning || task.RunnerID == nil || *task.RunnerID != runnerID {
+			return ErrTaskBrokerLeaseLost
+		}
+
+		now := time.Now()
+		task.Status = outcome.Status
+		task.ExitCode = outcome.ExitCode
+		task.Output = outcome.Output
+		task.Error = outcome.Error
+		task.FinishedAt = &now
+		task.LeaseUntil = nil
+		if len(outcome.Result) > 0 {
+			task.Result = datatypes.JSON(outcome.Result)
+		}
+
+		return tx.Model(&task).Updates(map[string]any{
+			"status":      task.Status,
+			"exit_code":   task.ExitCode,
+			"output":      task.Output,
+			"error":       task.Error,
+			"result":      task.Result,
+			"finished_at": now,
+			"lease_until": nil,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// ExpireTaskBrokerTaskLeases fails running tasks whose runner stopped
+// renewing the lease. Tasks are not requeued because a script may have had
+// side effects before its runner disappeared.
+func ExpireTaskBrokerTaskLeases(now time.Time) ([]TaskBrokerTask, error) {
+	var expired []TaskBrokerTask
+	err := database.Conn().
+		Model(&expired).
+		Clauses(clause.Returning{}).
+		Where("status = ?", TaskBrokerTaskStatusRunning).
+		Where("lease_until < ?", now).
+		Updates(map[string]any{
+			"status":      TaskBrokerTaskStatusFailed,
+			"error":       "runner stopped responding before the task finished",
+			"finished_at": now,
+			"lease_until": nil,
+		}).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+func AppendTaskBrokerTaskLogs(taskID uuid.UUID, records [][]byte) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	logs := make([]TaskBrokerTaskLog, 0, len(records))
+	for _, record := range records {
+		logs = append(logs, TaskBrokerTaskLog{
+			TaskID:    taskID,
+			Record:    datatypes.JSON(record),
+			CreatedAt: &now,
+		})
+	}
+
+	return database.Conn().Create(&logs).Error
+}
+
+// ListTaskBrokerTaskLogs returns up to limit log records written after the
+// record with ID afterID, oldest first.
+func ListTaskBrokerTaskLogs(taskID uuid.UUID, afterID int64, limit int) ([]TaskBrokerTaskLog, error) {
+	var logs []TaskBrokerTaskLog
+	err := database.Conn().
+		Where("task_id = ?", taskID).
+		Where("id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Find(&logs).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
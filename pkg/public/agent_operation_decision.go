@@ -0,0 +1,91 @@
+package public
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/public/middleware"
+)
+
+type agentOperationDecisionRequest struct {
+	Decision string `json:"decision"`
+}
+
+type agentOperationDecisionResponse struct {
+	ConfirmationID string `json:"confirmation_id"`
+	Decision       string `json:"decision"`
+}
+
+// handleAgentOperationDecision records the decision the user took on a
+// runtime operation requested by the canvas agent. Only the browser session
+// of the session owner can decide: API and agent tokens are rejected, so the
+// agent cannot confirm its own request.
+func (s *Server) handleAgentOperationDecision(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "" {
+		http.Error(w, "operations can only be confirmed from the SuperPlane UI", http.StatusForbidden)
+		return
+	}
+
+	operationID, err := uuid.Parse(mux.Vars(r)["confirmationId"])
+	if err != nil {
+		http.Error(w, "invalid confirmation id", http.StatusBadRequest)
+		return
+	}
+
+	var req agentOperationDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Decision != models.AgentPendingOperationDecisionConfirmed && req.Decision != models.AgentPendingOperationDecisionDeclined {
+		http.Error(w, "decision must be confirmed or declined", http.StatusBadRequest)
+		return
+	}
+
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	operation, err := models.FindAgentPendingOperation(operationID)
+	if err != nil {
+		http.Error(w, "operation not found", http.StatusNotFound)
+		return
+	}
+
+	if _, err := models.FindAgentSessionForUser(user.OrganizationID, user.ID, operation.SessionID); err != nil {
+		http.Error(w, "operation not found", http.StatusNotFound)
+		return
+	}
+
+	operation, err = models.DecideAgentPendingOperation(operation.SessionID, operation.ID, req.Decision)
+	switch {
+	case errors.Is(err, models.ErrAgentPendingOperationNotFound):
+		http.Error(w, "operation not found", http.StatusNotFound)
+		return
+	case errors.Is(err, models.ErrAgentPendingOperationExpired):
+		http.Error(w, "operation expired, ask the agent to request it again", http.StatusGone)
+		return
+	case errors.Is(err, models.ErrAgentPendingOperationDecided):
+		http.Error(w, "operation was already decided", http.StatusConflict)
+		return
+	case err != nil:
+		log.Errorf("failed to record decision on agent operation %s: %v", operationID, err)
+		http.Error(w, "failed to record decision", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(agentOperationDecisionResponse{
+		ConfirmationID: operation.ID.String(),
+		Decision:       operation.Decision,
+	}); err != nil {
+		log.Errorf("failed to write decision on agent operation %s: %v", operationID, err)
+	}
+}
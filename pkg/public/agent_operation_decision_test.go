@@ -0,0 +1,124 @@
+package public
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/authentication"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/jwt"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/test/support"
+)
+
+func TestAgentOperationDecision(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	signer := jwt.NewSigner("test")
+	server, err := NewServer(
+		r.Encryptor, r.Registry, signer, support.NewOIDCProvider(), r.GitProvider,
+		"", "http://localhost", "http://localhost", "test", "/app/templates", r.AuthService, nil, false,
+	)
+	require.NoError(t, err)
+	registerTestGRPCGateway(t, server, r.AuthService, r.Registry, r.Encryptor, support.NewOIDCProvider(), r.GitProvider, nil)
+
+	token, err := authentication.GenerateAccountToken(signer, r.Account.ID.String(), time.Now(), time.Hour)
+	require.NoError(t, err)
+
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, nil, nil)
+	session := &models.AgentSession{
+		OrganizationID:    r.Organization.ID,
+		UserID:            r.User,
+		CanvasID:          canvas.ID,
+		Provider:          "anthropic",
+		ProviderSessionID: "provider-session",
+		Status:            models.AgentSessionStatusIdle,
+	}
+	require.NoError(t, models.CreateAgentSessionInTransaction(database.Conn(), session))
+
+	org := "?organization_id=" + r.Organization.ID.String()
+	decide := func(id uuid.UUID, body string, withCookie bool, header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/agents/operations/"+id.String()+"/decision"+org, strings.NewReader(body))
+		if withCookie {
+			req.AddCookie(&http.Cookie{Name: "account_token", Value: token})
+		}
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		server.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	newOperation := func() *models.AgentPendingOperation {
+		operation, err := models.CreateAgentPendingOperation(session.ID, "cancel_run", []byte(`{}`), "Cancel run.", time.Hour)
+		require.NoError(t, err)
+		return operation
+	}
+
+	t.Run("confirm records the decision", func(t *testing.T) {
+		operation := newOperation()
+
+		res := decide(operation.ID, `{"decision":"confirmed"}`, true, "")
+
+		require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+		stored, err := models.FindAgentPendingOperation(operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.AgentPendingOperationDecisionConfirmed, stored.Decision)
+		assert.NotNil(t, stored.DecidedAt)
+	})
+
+	t.Run("second decision is rejected", func(t *testing.T) {
+		operation := newOperation()
+		require.Equal(t, http.StatusOK, decide(operation.ID, `{"decision":"declined"}`, true, "").Code)
+
+		res := decide(operation.ID, `{"decision":"confirmed"}`, true, "")
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		stored, err := models.FindAgentPendingOperation(operation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.AgentPendingOperationDecisionDeclined, stored.Decision)
+	})
+
+	t.Run("unknown decision returns 400", func(t *testing.T) {
+		res := decide(newOperation().ID, `{"decision":"yes"}`, true, "")
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+
+	t.Run("token-authenticated request is rejected", func(t *testing.T) {
+		operation := newOperation()
+
+		res := decide(operation.ID, `{"decision":"confirmed"}`, false, "Bearer some-agent-token")
+
+		assert.Equal(t, http.StatusForbidden, res.Code)
+		stored, err := models.FindAgentPendingOperation(operation.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored.Decision)
+	})
+
+	t.Run("operation of another user's session returns 404", func(t *testing.T) {
+		otherSession := &models.AgentSession{
+			OrganizationID:    r.Organization.ID,
+			UserID:            uuid.New(),
+			CanvasID:          canvas.ID,
+			Provider:          "anthropic",
+			ProviderSessionID: "other-session",
+			Status:            models.AgentSessionStatusIdle,
+		}
+		require.NoError(t, models.CreateAgentSessionInTransaction(database.Conn(), otherSession))
+		operation, err := models.CreateAgentPendingOperation(otherSession.ID, "cancel_run", []byte(`{}`), "Cancel run.", time.Hour)
+		require.NoError(t, err)
+
+		res := decide(operation.ID, `{"decision":"confirmed"}`, true, "")
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
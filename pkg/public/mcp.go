@@ -0,0 +1,52 @@
+package public
+
+import (
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	agenttools "github.com/superplanehq/superplane/pkg/agents/agent_tools"
+	"github.com/superplanehq/superplane/pkg/authorization"
+	"github.com/superplanehq/superplane/pkg/mcp"
+	"github.com/superplanehq/superplane/pkg/public/middleware"
+)
+
+// mcpHandler serves the Model Context Protocol endpoint. Tool calls are
+// authorized against the same gateway rules as the REST API, so a scoped
+// API token reaches exactly what it could reach over HTTP.
+func (s *Server) mcpHandler() http.Handler {
+	agentTools := agenttools.NewRegistry(agenttools.Dependencies{
+		Encryptor:         s.encryptor,
+		ComponentRegistry: s.registry,
+		GitProvider:       s.gitProvider,
+		WebhookBaseURL:    s.WebhooksBaseURL,
+		AuthService:       s.authService,
+		UsageService:      s.usageService,
+	})
+
+	server := mcp.NewServer(
+		authorization.NewGatewayAuthorizer(s.authService),
+		mcp.NewTools(mcp.ToolDependencies{
+			AgentTools:        agentTools,
+			ComponentRegistry: s.registry,
+		}),
+	)
+
+	return mcp.HTTPHandler(server, func(r *http.Request) (mcp.Caller, bool) {
+		user, ok := middleware.GetUserFromContext(r.Context())
+		if !ok {
+			return mcp.Caller{}, false
+		}
+
+		scopes, err := requestTokenScopes(r, user)
+		if err != nil {
+			log.Errorf("failed to encode token scopes for MCP request: %v", err)
+			return mcp.Caller{}, false
+		}
+
+		return mcp.Caller{
+			UserID:         user.ID.String(),
+			OrganizationID: user.OrganizationID.String(),
+			TokenScopes:    scopes,
+		}, true
+	})
+}
@@ -0,0 +1,473 @@
+package public
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	runneraction "github.com/superplanehq/superplane/pkg/components/runner"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/public/middleware"
+	"github.com/superplanehq/superplane/pkg/runnerstorage"
+	"github.com/superplanehq/superplane/pkg/taskbroker/protocol"
+)
+
+const (
+	defaultRunnerStorageMaxUploadBytes = 50 * 1024 * 1024
+	defaultRunnerArtifactRetentionDays = 7
+	defaultRunnerCacheQuotaBytes       = 1024 * 1024 * 1024
+)
+
+// WithRunnerStore sets the blob store runner artifacts and caches are
+// kept in. Without one, the runner storage API answers 503.
+func (s *Server) WithRunnerStore(store runnerstorage.Store) *Server {
+	s.runnerStore = store
+	return s
+}
+
+// registerRunnerStorageRoutes mounts the API runners use for artifacts and
+// caches. Requests are authenticated with the per-execution storage token
+// minted when the runner task is created, not with a user session.
+func (s *Server) registerRunnerStorageRoutes() {
+	prefix := runneraction.RunnerStorageAPIPath
+	s.Router.HandleFunc(prefix+"/artifacts", s.handleRunnerArtifactUpload).Methods(http.MethodPost)
+	s.Router.HandleFunc(prefix+"/artifacts", s.handleRunnerArtifactList).Methods(http.MethodGet)
+	s.Router.HandleFunc(prefix+"/artifacts/{artifact_id}", s.handleRunnerArtifactDownload).Methods(http.MethodGet)
+	s.Router.HandleFunc(prefix+"/caches/{key:.+}", s.handleRunnerCacheRestore).Methods(http.MethodGet)
+	s.Router.HandleFunc(prefix+"/caches/{key:.+}", s.handleRunnerCacheSave).Methods(http.MethodPut)
+}
+
+// runnerStorageExecution resolves the execution a storage token was
+// minted for, writing the error response when it can't.
+func runnerStorageExecution(w http.ResponseWriter, r *http.Request) (*models.CanvasNodeExecution, *runneraction.StorageTokenClaims, bool) {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || strings.TrimSpace(token) == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, nil, false
+	}
+
+	claims, err := runneraction.ValidateStorageToken(strings.TrimSpace(token))
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, nil, false
+	}
+
+	canvasID, err := uuid.Parse(claims.CanvasID)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, nil, false
+	}
+	executionID, err := uuid.Parse(claims.ExecutionID)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, nil, false
+	}
+
+	execution, err := models.FindNodeExecution(canvasID, executionID)
+	if err != nil {
+		http.Error(w, "Execution not found", http.StatusNotFound)
+		return nil, nil, false
+	}
+
+	return execution, claims, true
+}
+
+func (s *Server) handleRunnerArtifactUpload(w http.ResponseWriter, r *http.Request) {
+	execution, claims, ok := runnerStorageExecution(w, r)
+	if !ok {
+		return
+	}
+
+	organizationID, err := uuid.Parse(claims.OrganizationID)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	if name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	if !runneraction.ValidArtifactName(name) {
+		http.Error(w, "Invalid artifact name", http.StatusBadRequest)
+		return
+	}
+
+	if !s.runnerStoreConfigured(w) {
+		return
+	}
+
+	blob, ok := s.writeRunnerStorageUpload(w, r, runnerstorage.NewArtifactKey(execution.WorkflowID))
+	if !ok {
+		return
+	}
+
+	artifact, replacedKey, err := models.SaveRunnerArtifact(execution, organizationID, name, *blob, runnerArtifactRetention())
+	if err != nil {
+		log.Errorf("Failed to save artifact %s for execution %s: %v", name, execution.ID, err)
+		s.deleteRunnerBlobs(r.Context(), blob.StorageKey)
+		http.Error(w, "Failed to save artifact", http.StatusInternalServerError)
+		return
+	}
+
+	if replacedKey != "" {
+		s.deleteRunnerBlobs(r.Context(), replacedKey)
+	}
+
+	writeRunnerStorageJSON(w, http.StatusCreated, storedArtifact(artifact))
+}
+
+func (s *Server) handleRunnerArtifactList(w http.ResponseWriter, r *http.Request) {
+	execution, _, ok := runnerStorageExecution(w, r)
+	if !ok {
+		return
+	}
+
+	artifacts, err := models.ListRunnerArtifactsForRun(database.Conn(), execution.WorkflowID, execution.RunID)
+	if err != nil {
+		http.Error(w, "Failed to list artifacts", http.StatusInternalServerError)
+		return
+	}
+
+	response := protocol.ListArtifactsResponse{Artifacts: []protocol.StoredArtifact{}}
+	for i := range artifacts {
+		response.Artifacts = append(response.Artifacts, storedArtifact(&artifacts[i]))
+	}
+	writeRunnerStorageJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleRunnerArtifactDownload(w http.ResponseWriter, r *http.Request) {
+	execution, _, ok := runnerStorageExecution(w, r)
+	if !ok {
+		return
+	}
+
+	artifactID, err := uuid.Parse(mux.Vars(r)["artifact_id"])
+	if err != nil {
+		http.Error(w, "Invalid artifact id", http.StatusBadRequest)
+		return
+	}
+
+	if !s.runnerStoreConfigured(w) {
+		return
+	}
+
+	artifact, err := models.FindRunnerArtifact(execution.WorkflowID, execution.RunID, artifactID)
+	if err != nil {
+		writeRunnerArtifactError(w, err)
+		return
+	}
+
+	s.writeRunnerArtifactContent(w, r, artifact)
+}
+
+func (s *Server) handleRunnerCacheRestore(w http.ResponseWriter, r *http.Request) {
+	execution, _, ok := runnerStorageExecution(w, r)
+	if !ok {
+		return
+	}
+
+	if !s.runnerStoreConfigured(w) {
+		return
+	}
+
+	cache, err := models.FindRunnerCache(execution.WorkflowID, mux.Vars(r)["key"])
+	if err != nil {
+		if errors.Is(err, models.ErrRunnerCacheNotFound) {
+			http.Error(w, "Cache not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to read cache", http.StatusInternalServerError)
+		return
+	}
+
+	content, err := s.runnerStore.Open(r.Context(), cache.StorageKey)
+	if err != nil {
+		if errors.Is(err, runnerstorage.ErrBlobNotFound) {
+			log.Warnf("Blob of cache %s for canvas %s is missing", cache.Key, cache.CanvasID)
+			http.Error(w, "Cache not found", http.StatusNotFound)
+			return
+		}
+		log.Errorf("Failed to open cache %s for canvas %s: %v", cache.Key, cache.CanvasID, err)
+		http.Error(w, "Failed to read cache", http.StatusInternalServerError)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Length", strconv.FormatInt(cache.SizeBytes, 10))
+	if _, err := io.Copy(w, content); err != nil {
+		log.Errorf("Failed to write cache %s: %v", cache.Key, err)
+	}
+}
+
+func (s *Server) handleRunnerCacheSave(w http.ResponseWriter, r *http.Request) {
+	execution, claims, ok := runnerStorageExecution(w, r)
+	if !ok {
+		return
+	}
+
+	organizationID, err := uuid.Parse(claims.OrganizationID)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	key := mux.Vars(r)["key"]
+	if strings.TrimSpace(key) == "" || len(key) > 256 {
+		http.Error(w, "Invalid cache key", http.StatusBadRequest)
+		return
+	}
+
+	if !s.runnerStoreConfigured(w) {
+		return
+	}
+
+	quota := runnerCacheQuotaBytes()
+	if r.ContentLength > quota {
+		http.Error(w, fmt.Sprintf("cache exceeds the canvas cache quota of %d bytes", quota), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	blob, ok := s.writeRunnerStorageUpload(w, r, runnerstorage.NewCacheKey(execution.WorkflowID))
+	if !ok {
+		return
+	}
+
+	_, staleKeys, err := models.SaveRunnerCache(organizationID, execution.WorkflowID, key, *blob, quota)
+	if err != nil {
+		s.deleteRunnerBlobs(r.Context(), blob.StorageKey)
+		if errors.Is(err, models.ErrRunnerCacheQuotaExceeded) {
+			http.Error(w, fmt.Sprintf("cache exceeds the canvas cache quota of %d bytes", quota), http.StatusRequestEntityTooLarge)
+			return
+		}
+		log.Errorf("Failed to save cache %s for canvas %s: %v", key, execution.WorkflowID, err)
+		http.Error(w, "Failed to save cache", http.StatusInternalServerError)
+		return
+	}
+
+	s.deleteRunnerBlobs(r.Context(), staleKeys...)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// handleRunArtifactDownload serves an artifact to users with read access
+// to the canvas, for `superplane runs download-artifact`.
+func (s *Server) handleRunArtifactDownload(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	allowed, err := s.authService.CheckOrganizationPermission(r.Context(),
+		user.ID.String(),
+		user.OrganizationID.String(),
+		"canvases",
+		"read",
+	)
+	if err != nil {
+		http.Error(w, "Authorization check failed", http.StatusInternalServerError)
+		return
+	}
+	if !allowed {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	vars := mux.Vars(r)
+	canvasID, err := uuid.Parse(vars["canvas_id"])
+	if err != nil {
+		http.Error(w, "Invalid canvas id", http.StatusBadRequest)
+		return
+	}
+	runID, err := uuid.Parse(vars["run_id"])
+	if err != nil {
+		http.Error(w, "Invalid run id", http.StatusBadRequest)
+		return
+	}
+	artifactID, err := uuid.Parse(vars["artifact_id"])
+	if err != nil {
+		http.Error(w, "Invalid artifact id", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := models.FindCanvas(user.OrganizationID, canvasID); err != nil {
+		http.Error(w, "Canvas not found", http.StatusNotFound)
+		return
+	}
+
+	if !s.runnerStoreConfigured(w) {
+		return
+	}
+
+	artifact, err := models.FindRunnerArtifact(canvasID, runID, artifactID)
+	if err != nil {
+		writeRunnerArtifactError(w, err)
+		return
+	}
+
+	s.writeRunnerArtifactContent(w, r, artifact)
+}
+
+func (s *Server) runnerStoreConfigured(w http.ResponseWriter) bool {
+	if s.runnerStore == nil {
+		http.Error(w, "Runner storage is not configured", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+// writeRunnerStorageUpload streams the request body into a new blob,
+// hashing it on the way, and writes the error response when it can't.
+func (s *Server) writeRunnerStorageUpload(w http.ResponseWriter, r *http.Request, storageKey string) (*models.RunnerBlob, bool) {
+	limit := runnerStorageMaxUploadBytes()
+	upload := &runnerStorageUpload{
+		reader: http.MaxBytesReader(w, r.Body, limit),
+		hash:   sha256.New(),
+	}
+
+	if err := s.runnerStore.Put(r.Context(), storageKey, upload); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(upload.err, &maxBytesErr):
+			http.Error(w, fmt.Sprintf("upload exceeds %d bytes", limit), http.StatusRequestEntityTooLarge)
+		case upload.err != nil:
+			http.Error(w, "Failed to read upload", http.StatusBadRequest)
+		default:
+			log.Errorf("Failed to store upload %s: %v", storageKey, err)
+			http.Error(w, "Failed to store upload", http.StatusInternalServerError)
+		}
+		return nil, false
+	}
+
+	if upload.size == 0 {
+		s.deleteRunnerBlobs(r.Context(), storageKey)
+		http.Error(w, "upload is empty", http.StatusBadRequest)
+		return nil, false
+	}
+
+	return &models.RunnerBlob{
+		StorageKey: storageKey,
+		SizeBytes:  upload.size,
+		SHA256:     hex.EncodeToString(upload.hash.Sum(nil)),
+	}, true
+}
+
+// runnerStorageUpload counts and hashes an upload while it is stored,
+// and keeps the error reading it, to tell a bad upload from a failing
+// store.
+type runnerStorageUpload struct {
+	reader io.Reader
+	hash   hash.Hash
+	size   int64
+	err    error
+}
+
+func (u *runnerStorageUpload) Read(p []byte) (int, error) {
+	n, err := u.reader.Read(p)
+	u.hash.Write(p[:n])
+	u.size += int64(n)
+	if err != nil && err != io.EOF {
+		u.err = err
+	}
+	return n, err
+}
+
+// deleteRunnerBlobs deletes blobs no row points to anymore. Failures
+// only leave an orphaned blob behind, so they are logged, not returned.
+func (s *Server) deleteRunnerBlobs(ctx context.Context, storageKeys ...string) {
+	ctx = context.WithoutCancel(ctx)
+	for _, storageKey := range storageKeys {
+		if err := s.runnerStore.Delete(ctx, storageKey); err != nil {
+			log.Warnf("Failed to delete runner storage blob %s: %v", storageKey, err)
+		}
+	}
+}
+
+func (s *Server) writeRunnerArtifactContent(w http.ResponseWriter, r *http.Request, artifact *models.RunnerArtifact) {
+	content, err := s.runnerStore.Open(r.Context(), artifact.StorageKey)
+	if err != nil {
+		if errors.Is(err, runnerstorage.ErrBlobNotFound) {
+			log.Warnf("Blob of artifact %s is missing", artifact.ID)
+			writeRunnerArtifactError(w, models.ErrRunnerArtifactNotFound)
+			return
+		}
+		log.Errorf("Failed to open artifact %s: %v", artifact.ID, err)
+		writeRunnerArtifactError(w, err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Length", strconv.FormatInt(artifact.SizeBytes, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": artifact.Name + ".tar.gz",
+	}))
+	if _, err := io.Copy(w, content); err != nil {
+		log.Errorf("Failed to write artifact %s: %v", artifact.ID, err)
+	}
+}
+
+func writeRunnerArtifactError(w http.ResponseWriter, err error) {
+	if errors.Is(err, models.ErrRunnerArtifactNotFound) {
+		http.Error(w, "Artifact not found", http.StatusNotFound)
+		return
+	}
+	http.Error(w, "Failed to read artifact", http.StatusInternalServerError)
+}
+
+func writeRunnerStorageJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func storedArtifact(artifact *models.RunnerArtifact) protocol.StoredArtifact {
+	return protocol.StoredArtifact{
+		ID:        artifact.ID.String(),
+		Name:      artifact.Name,
+		NodeID:    artifact.NodeID,
+		SizeBytes: artifact.SizeBytes,
+		SHA256:    artifact.SHA256,
+	}
+}
+
+func runnerStorageMaxUploadBytes() int64 {
+	if v, err := strconv.ParseInt(os.Getenv("RUNNER_STORAGE_MAX_UPLOAD_BYTES"), 10, 64); err == nil && v > 0 {
+		return v
+	}
+	return defaultRunnerStorageMaxUploadBytes
+}
+
+// runnerCacheQuotaBytes is how much cache content each canvas can keep.
+func runnerCacheQuotaBytes() int64 {
+	if v, err := strconv.ParseInt(os.Getenv("RUNNER_CACHE_QUOTA_BYTES_PER_CANVAS"), 10, 64); err == nil && v > 0 {
+		return v
+	}
+	return defaultRunnerCacheQuotaBytes
+}
+
+func runnerArtifactRetention() time.Duration {
+	days := defaultRunnerArtifactRetentionDays
+	if v, err := strconv.Atoi(os.Getenv("RUNNER_ARTIFACT_RETENTION_DAYS")); err == nil && v > 0 {
+		days = v
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
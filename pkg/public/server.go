@@ -31,6 +31,7 @@ import (
 	"github.com/superplanehq/superplane/pkg/jwt"
 	"github.com/superplanehq/superplane/pkg/logging"
 	"github.com/superplanehq/superplane/pkg/registry"
+	"github.com/superplanehq/superplane/pkg/runnerstorage"
 	"github.com/superplanehq/superplane/pkg/workers/contexts"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 	"go.opentelemetry.io/otel/attribute"
@@ -73,6 +74,10 @@ const (
 
 	// The size of the stage execution outputs can be up to 4k
 	MaxExecutionOutputsSize = 4 * 1024
+
+	// The embedded task broker is served under this prefix, so runners
+	// and TASK_BROKER_BASE_URL point at <base url>/broker.
+	TaskBrokerPathPrefix = "/broker"
 )
 
 var errUsageServiceUnavailable = errors.New("usage service unavailable")
@@ -95,6 +100,7 @@ type Server struct {
 	authHandler           *authentication.Handler
 	isDev                 bool
 	usageService          usage.Service
+	runnerStore           runnerstorage.Store
 }
 
 // WebsocketHub returns the websocket hub for this server
@@ -397,11 +403,23 @@ func (s *Server) RegisterGRPCGateway(services *grpc.Services) error {
 		orgAuthMiddleware(http.HandlerFunc(s.handleRepositoryFileDownload)),
 	).Methods(http.MethodGet)
 
+	s.Router.Handle(
+		"/api/v1/canvases/{canvas_id}/runs/{run_id}/artifacts/{artifact_id}/content",
+		orgAuthMiddleware(http.HandlerFunc(s.handleRunArtifactDownload)),
+	).Methods(http.MethodGet)
+
+	s.registerRunnerStorageRoutes()
+
 	s.Router.Handle(
 		"/api/v1/agents/chats/{chatId}/messages/{messageId}/images/{index}",
 		orgAuthMiddleware(http.HandlerFunc(s.handleAgentChatMessageImage)),
 	).Methods(http.MethodGet)
 
+	s.Router.Handle(
+		"/api/v1/agents/operations/{confirmationId}/decision",
+		orgAuthMiddleware(http.HandlerFunc(s.handleAgentOperationDecision)),
+	).Methods(http.MethodPost)
+
 	protectedGRPCHandler := orgAuthMiddleware(s.grpcGatewayHandler(grpcGatewayMux))
 
 	accountAuthMiddleware := middleware.AccountAuthMiddleware(s.jwt)
@@ -425,6 +443,8 @@ func (s *Server) RegisterGRPCGateway(services *grpc.Services) error {
 	s.Router.PathPrefix("/api/v1/workflows").Handler(protectedGRPCHandler)
 	s.Router.PathPrefix("/api/v1/factories").Handler(protectedGRPCHandler)
 
+	s.Router.Handle("/api/v1/mcp", orgAuthMiddleware(s.mcpHandler()))
+
 	return nil
 }
 
@@ -460,27 +480,39 @@ func (s *Server) grpcGatewayHandler(grpcGatewayMux *runtime.ServeMux) http.Handl
 		//
 		r2.Header.Del("x-Token-Scopes")
 
-		scopedClaims, ok := middleware.GetScopedTokenClaimsFromContext(r.Context())
-		if ok {
-			scopes, err := json.Marshal(scopedClaims.Scopes)
-			if err != nil {
-				http.Error(w, "Failed to encode scoped token scopes", http.StatusInternalServerError)
-				return
-			}
-			r2.Header.Set("x-Token-Scopes", string(scopes))
-		} else if user.HasAPIKeyCanvasScope() {
-			scopes, err := json.Marshal(apiKeyCanvasScopes(user.APIKeyCanvasIDs))
-			if err != nil {
-				http.Error(w, "Failed to encode API key scopes", http.StatusInternalServerError)
-				return
-			}
-			r2.Header.Set("x-Token-Scopes", string(scopes))
+		scopes, err := requestTokenScopes(r, user)
+		if err != nil {
+			http.Error(w, "Failed to encode token scopes", http.StatusInternalServerError)
+			return
+		}
+		if scopes != "" {
+			r2.Header.Set("x-Token-Scopes", scopes)
 		}
 
 		middleware.TraceGatewayServe(r.Context(), w, grpcGatewayMux, r2.WithContext(r.Context()))
 	})
 }
 
+// requestTokenScopes returns the JSON-encoded scopes that narrow the
+// authenticated user's permissions, or an empty string when the request
+// was made with unscoped credentials.
+func requestTokenScopes(r *http.Request, user *models.User) (string, error) {
+	var scopes []string
+	if scopedClaims, ok := middleware.GetScopedTokenClaimsFromContext(r.Context()); ok {
+		scopes = scopedClaims.Scopes
+	} else if user.HasAPIKeyCanvasScope() {
+		scopes = apiKeyCanvasScopes(user.APIKeyCanvasIDs)
+	} else {
+		return "", nil
+	}
+
+	encoded, err := json.Marshal(scopes)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
 func apiKeyCanvasScopes(canvasIDs []string) []string {
 	return jwt.ScopesFromPermissions([]jwt.Permission{
 		{ResourceType: "canvases", Action: "read", Resources: canvasIDs},
@@ -561,6 +593,13 @@ func (s *Server) RegisterWebSocketRoutes() {
 	)
 }
 
+// RegisterTaskBroker mounts the embedded task broker API under /broker.
+// It must be registered before the web routes, which catch every path.
+func (s *Server) RegisterTaskBroker(handler http.Handler) {
+	log.Info("Registering task broker routes")
+	s.Router.PathPrefix(TaskBrokerPathPrefix + "/").Handler(http.StripPrefix(TaskBrokerPathPrefix, handler))
+}
+
 func (s *Server) RegisterWebRoutes(webBasePath string) {
 	log.Infof("Registering web routes with base path: %s", webBasePath)
 
@@ -1253,10 +1292,13 @@ func (s *Server) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 	for _, node := range nodes {
 		code, response, err := s.executeWebhookNode(r.Context(), body, r.Header, node, onNewEvents, recordExecution)
 		if err != nil {
+			recordWebhookDelivery(node, telemetry.WebhookDeliveryResultRejected)
 			http.Error(w, fmt.Sprintf("error handling webhook: %v", err), code)
 			return
 		}
 
+		recordWebhookDelivery(node, telemetry.WebhookDeliveryResultAccepted)
+
 		if firstResponse == nil && response != nil && len(response.Body) > 0 {
 			firstResponse = response
 		}
@@ -1283,6 +1325,28 @@ func (s *Server) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func recordWebhookDelivery(node models.CanvasNode, result string) {
+	if !telemetry.PrometheusMetricsEnabled() {
+		return
+	}
+
+	organizationID, err := models.FindCanvasOrganizationID(node.WorkflowID)
+	if err != nil {
+		log.Warnf("failed to find organization for canvas %s: %v", node.WorkflowID, err)
+		return
+	}
+
+	trigger := ""
+	ref := node.Ref.Data()
+	if ref.Trigger != nil {
+		trigger = ref.Trigger.Name
+	} else if ref.Component != nil {
+		trigger = ref.Component.Name
+	}
+
+	telemetry.RecordWebhookDelivery(organizationID.String(), node.WorkflowID.String(), node.NodeID, trigger, result)
+}
+
 func (s *Server) executeWebhookNode(ctx context.Context, body []byte, headers http.Header, node models.CanvasNode, onNewEvents func([]models.CanvasEvent), recordExecution func(workflowID, executionID uuid.UUID)) (int, *core.WebhookResponseBody, error) {
 	if node.Type == models.NodeTypeTrigger {
 		return s.executeTriggerNode(ctx, body, headers, node, onNewEvents)
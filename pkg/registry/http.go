@@ -12,6 +12,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/superplanehq/superplane/pkg/core"
 	"gorm.io/gorm"
 )
 
@@ -240,6 +241,15 @@ func (c *HTTPContext) do(request *http.Request, tx *gorm.DB) (*http.Response, er
 		client = c.clientInTransaction(request, tx)
 	}
 
+	//
+	// Requests with their own TLS configuration can't share the pooled
+	// transports, so they get a one-off client, with the same policy checks.
+	//
+	if tlsConfig := core.TLSConfigFromRequest(request); tlsConfig != nil {
+		client = c.clientInTransaction(request, tx)
+		client.Transport.(*http.Transport).TLSClientConfig = tlsConfig
+	}
+
 	resp, err := client.Do(request)
 	if err != nil {
 		return nil, err
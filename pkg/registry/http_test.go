@@ -2,6 +2,8 @@ package registry
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"io"
 	"net"
 	"net/http"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/core"
 	"gorm.io/gorm"
 )
 
@@ -492,6 +495,40 @@ func Test__HTTPContextInTransaction__DoDoesNotUseSharedTransportPool(t *testing.
 	assert.Equal(t, int32(2), newConnections.Load())
 }
 
+func Test__HTTPContext__DoWithTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	ctx, err := NewHTTPContext(HTTPOptions{})
+	require.NoError(t, err)
+
+	t.Run("server with a private CA -> error", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		_, err = ctx.Do(request)
+		require.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("request trusting the CA -> ok", func(t *testing.T) {
+		pool := x509.NewCertPool()
+		pool.AddCert(server.Certificate())
+
+		request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		response, err := ctx.Do(core.WithTLSConfig(request, &tls.Config{RootCAs: pool}))
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", string(body))
+	})
+}
+
 func defaultHTTPOptions() HTTPOptions {
 	return HTTPOptions{
 		BlockedHosts: []string{
@@ -6,6 +6,7 @@ import (
 	// These blank imports intentionally trigger init-time registration.
 	_ "github.com/superplanehq/superplane/pkg/components/addmemory"
 	_ "github.com/superplanehq/superplane/pkg/components/approval"
+	_ "github.com/superplanehq/superplane/pkg/components/compareandswapmemory"
 	_ "github.com/superplanehq/superplane/pkg/components/deletememory"
 	_ "github.com/superplanehq/superplane/pkg/components/display"
 	_ "github.com/superplanehq/superplane/pkg/components/factory"
@@ -14,6 +15,7 @@ import (
 	_ "github.com/superplanehq/superplane/pkg/components/graphql"
 	_ "github.com/superplanehq/superplane/pkg/components/http"
 	_ "github.com/superplanehq/superplane/pkg/components/if"
+	_ "github.com/superplanehq/superplane/pkg/components/incrementmemory"
 	_ "github.com/superplanehq/superplane/pkg/components/loop"
 	_ "github.com/superplanehq/superplane/pkg/components/merge"
 	_ "github.com/superplanehq/superplane/pkg/components/messages"
@@ -27,8 +29,10 @@ import (
 	_ "github.com/superplanehq/superplane/pkg/components/updatememory"
 	_ "github.com/superplanehq/superplane/pkg/components/upsertmemory"
 	_ "github.com/superplanehq/superplane/pkg/components/wait"
+	_ "github.com/superplanehq/superplane/pkg/integrations/argocd"
 	_ "github.com/superplanehq/superplane/pkg/integrations/aws"
 	_ "github.com/superplanehq/superplane/pkg/integrations/azure"
+	_ "github.com/superplanehq/superplane/pkg/integrations/azuredevops"
 	_ "github.com/superplanehq/superplane/pkg/integrations/bitbucket"
 	_ "github.com/superplanehq/superplane/pkg/integrations/circleci"
 	_ "github.com/superplanehq/superplane/pkg/integrations/claude"
@@ -45,6 +49,7 @@ import (
 	_ "github.com/superplanehq/superplane/pkg/integrations/elastic"
 	_ "github.com/superplanehq/superplane/pkg/integrations/firehydrant"
 	_ "github.com/superplanehq/superplane/pkg/integrations/gcp"
+	_ "github.com/superplanehq/superplane/pkg/integrations/gitea"
 	_ "github.com/superplanehq/superplane/pkg/integrations/github"
 	_ "github.com/superplanehq/superplane/pkg/integrations/gitlab"
 	_ "github.com/superplanehq/superplane/pkg/integrations/grafana"
@@ -52,8 +57,10 @@ import (
 	_ "github.com/superplanehq/superplane/pkg/integrations/hetzner"
 	_ "github.com/superplanehq/superplane/pkg/integrations/honeycomb"
 	_ "github.com/superplanehq/superplane/pkg/integrations/incident"
+	_ "github.com/superplanehq/superplane/pkg/integrations/jenkins"
 	_ "github.com/superplanehq/superplane/pkg/integrations/jfrog_artifactory"
 	_ "github.com/superplanehq/superplane/pkg/integrations/jira"
+	_ "github.com/superplanehq/superplane/pkg/integrations/kubernetes"
 	_ "github.com/superplanehq/superplane/pkg/integrations/launchdarkly"
 	_ "github.com/superplanehq/superplane/pkg/integrations/linear"
 	_ "github.com/superplanehq/superplane/pkg/integrations/logfire"
@@ -62,6 +69,7 @@ import (
 	_ "github.com/superplanehq/superplane/pkg/integrations/octopus"
 	_ "github.com/superplanehq/superplane/pkg/integrations/openai"
 	_ "github.com/superplanehq/superplane/pkg/integrations/openrouter"
+	_ "github.com/superplanehq/superplane/pkg/integrations/opsgenie"
 	_ "github.com/superplanehq/superplane/pkg/integrations/pagerduty"
 	_ "github.com/superplanehq/superplane/pkg/integrations/perplexity"
 	_ "github.com/superplanehq/superplane/pkg/integrations/prometheus"
@@ -76,6 +84,8 @@ import (
 	_ "github.com/superplanehq/superplane/pkg/integrations/statuspage"
 	_ "github.com/superplanehq/superplane/pkg/integrations/teams"
 	_ "github.com/superplanehq/superplane/pkg/integrations/telegram"
+	_ "github.com/superplanehq/superplane/pkg/integrations/terraform"
+	_ "github.com/superplanehq/superplane/pkg/integrations/vault"
 	_ "github.com/superplanehq/superplane/pkg/triggers/messages"
 	_ "github.com/superplanehq/superplane/pkg/triggers/onerror"
 	_ "github.com/superplanehq/superplane/pkg/triggers/schedule"
@@ -0,0 +1,228 @@
+// Package runneragent implements `superplane runner`, a self-hosted runner
+// that registers with the embedded task broker, claims tasks for its fleet
+// and executes them on the host or with a local container runtime.
+package runneragent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/taskbroker/protocol"
+)
+
+const (
+	claimWait         = 30 * time.Second
+	claimRetryBackoff = 5 * time.Second
+	completeAttempts  = 5
+)
+
+type Config struct {
+	BrokerURL         string
+	RegistrationToken string
+	FleetID           string
+	Name              string
+	Concurrency       int
+	WorkDir           string
+	ContainerRuntime  string
+}
+
+type Agent struct {
+	config   Config
+	client   *Client
+	executor *executor
+}
+
+func New(config Config) (*Agent, error) {
+	if strings.TrimSpace(config.BrokerURL) == "" {
+		return nil, errors.New("broker URL is required")
+	}
+	if strings.TrimSpace(config.RegistrationToken) == "" {
+		return nil, errors.New("registration token is required")
+	}
+	if strings.TrimSpace(config.FleetID) == "" {
+		return nil, errors.New("fleet is required")
+	}
+
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
+	}
+	if config.Name == "" {
+		config.Name, _ = os.Hostname()
+	}
+	if config.WorkDir == "" {
+		config.WorkDir = os.TempDir()
+	}
+	if config.ContainerRuntime == "" {
+		config.ContainerRuntime = "docker"
+	}
+
+	return &Agent{
+		config: config,
+		client: NewClient(config.BrokerURL, ""),
+		executor: &executor{
+			workDir:          config.WorkDir,
+			containerRuntime: config.ContainerRuntime,
+		},
+	}, nil
+}
+
+// Run registers the runner and processes tasks until ctx is done. Tasks
+// already running when ctx is done are canceled and reported as such.
+func (a *Agent) Run(ctx context.Context) error {
+	if err := os.MkdirAll(a.config.WorkDir, 0o700); err != nil {
+		return fmt.Errorf("create work directory: %w", err)
+	}
+
+	registration, err := a.client.Register(ctx, a.config.RegistrationToken, a.config.FleetID, a.config.Name)
+	if err != nil {
+		return fmt.Errorf("register runner: %w", err)
+	}
+	log.Infof("runner: registered as %s in fleet %s", registration.RunnerID, a.config.FleetID)
+
+	var wg sync.WaitGroup
+	for range a.config.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.work(ctx)
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (a *Agent) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		task, err := a.client.Claim(ctx, claimWait)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Warnf("runner: failed to claim task: %v", err)
+			sleep(ctx, claimRetryBackoff)
+			continue
+		}
+
+		if task != nil {
+			a.process(ctx, task)
+		}
+	}
+}
+
+// process executes one claimed task while a heartbeat keeps its lease
+// alive, then reports the outcome.
+func (a *Agent) process(ctx context.Context, task *protocol.ClaimedTask) {
+	log.Infof("runner: running task %s", task.ID)
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	logs := newLogStreamer(a.client, task.ID)
+	logsCtx, stopLogs := context.WithCancel(context.Background())
+	go logs.run(logsCtx)
+
+	leaseLost := make(chan struct{})
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		a.heartbeat(runCtx, task, cancel, leaseLost)
+	}()
+
+	result := a.executor.run(runCtx, task, logs)
+	cancel(nil)
+	<-heartbeatDone
+
+	stopLogs()
+	logs.wait()
+
+	select {
+	case <-leaseLost:
+		log.Warnf("runner: lost the lease on task %s, dropping its result", task.ID)
+		return
+	default:
+	}
+
+	if ctx.Err() != nil && result.status != protocol.StatusSucceeded {
+		result = outcome{status: protocol.StatusCanceled, err: "runner shut down before the task finished"}
+	}
+
+	a.complete(task.ID, protocol.CompleteTaskRequest{
+		Status:   result.status,
+		ExitCode: result.exitCode,
+		Output:   logs.output(),
+		Error:    result.err,
+		Result:   result.result,
+	})
+}
+
+func (a *Agent) heartbeat(ctx context.Context, task *protocol.ClaimedTask, cancel context.CancelCauseFunc, leaseLost chan struct{}) {
+	interval := time.Duration(task.HeartbeatIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		response, err := a.client.Heartbeat(ctx, task.ID)
+		switch {
+		case errors.Is(err, ErrLeaseLost):
+			close(leaseLost)
+			cancel(ErrLeaseLost)
+			return
+		case err != nil:
+			if ctx.Err() == nil {
+				log.Warnf("runner: heartbeat for task %s failed: %v", task.ID, err)
+			}
+		case response.CancelRequested:
+			log.Infof("runner: task %s was canceled", task.ID)
+			cancel(errCanceled)
+			return
+		}
+	}
+}
+
+// complete reports the outcome, retrying transient failures. It does not
+// use the agent context so results are still reported during shutdown.
+func (a *Agent) complete(taskID string, req protocol.CompleteTaskRequest) {
+	for attempt := range completeAttempts {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * time.Second)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := a.client.Complete(ctx, taskID, req)
+		cancel()
+
+		if err == nil {
+			log.Infof("runner: task %s finished: %s", taskID, req.Status)
+			return
+		}
+		if errors.Is(err, ErrLeaseLost) {
+			log.Warnf("runner: task %s is no longer leased to this runner", taskID)
+			return
+		}
+		log.Warnf("runner: failed to report task %s: %v", taskID, err)
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
@@ -0,0 +1,249 @@
+package runneragent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/taskbroker/protocol"
+)
+
+// fakeBroker hands out one task and records what the runner reports.
+type fakeBroker struct {
+	mu              sync.Mutex
+	task            *protocol.ClaimedTask
+	cancelRequested bool
+	records         []protocol.LogRecord
+	completed       chan protocol.CompleteTaskRequest
+}
+
+func newFakeBroker(t *testing.T, task *protocol.ClaimedTask) (*fakeBroker, *httptest.Server) {
+	b := &fakeBroker{task: task, completed: make(chan protocol.CompleteTaskRequest, 1)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+protocol.RegisterRunnerPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer registration-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(protocol.RegisterRunnerResponse{RunnerID: "runner-1", Token: "runner-token"})
+	})
+	mux.HandleFunc("POST "+protocol.ClaimTaskPath, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer runner-token", r.Header.Get("Authorization"))
+
+		b.mu.Lock()
+		task := b.task
+		b.task = nil
+		b.mu.Unlock()
+
+		if task == nil {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(100 * time.Millisecond):
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(task)
+	})
+	mux.HandleFunc("POST /v1/tasks/{id}/heartbeat", func(w http.ResponseWriter, r *http.Request) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(protocol.HeartbeatResponse{CancelRequested: b.cancelRequested})
+	})
+	mux.HandleFunc("POST /v1/tasks/{id}/logs", func(w http.ResponseWriter, r *http.Request) {
+		var req protocol.AppendLogsRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		b.mu.Lock()
+		b.records = append(b.records, req.Records...)
+		b.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /v1/tasks/{id}/complete", func(w http.ResponseWriter, r *http.Request) {
+		var req protocol.CompleteTaskRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.WriteHeader(http.StatusNoContent)
+		b.completed <- req
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return b, server
+}
+
+func (b *fakeBroker) logRecords() []protocol.LogRecord {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]protocol.LogRecord(nil), b.records...)
+}
+
+func runAgent(t *testing.T, server *httptest.Server, broker *fakeBroker) protocol.CompleteTaskRequest {
+	t.Helper()
+
+	agent, err := New(Config{
+		BrokerURL:         server.URL,
+		RegistrationToken: "registration-token",
+		FleetID:           "build-fleet",
+		WorkDir:           t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- agent.Run(ctx) }()
+
+	var result protocol.CompleteTaskRequest
+	select {
+	case result = <-broker.completed:
+	case <-time.After(20 * time.Second):
+		t.Fatal("task was not completed")
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+	return result
+}
+
+func requireBash(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash is not available")
+	}
+}
+
+func Test__Agent__RunsScriptAndReportsResult(t *testing.T) {
+	requireBash(t)
+
+	broker, server := newFakeBroker(t, &protocol.ClaimedTask{
+		ID:                       "task-1",
+		LeaseSeconds:             60,
+		HeartbeatIntervalSeconds: 1,
+		Spec: protocol.TaskSpec{
+			RunMode:       protocol.RunModeBash,
+			MessageChain:  json.RawMessage(`{"root":{"value":42}}`),
+			SetupCommands: []string{"echo setting up"},
+			Environment:   []protocol.EnvironmentVariable{{Name: "GREETING", Value: "hello"}},
+			Files:         []protocol.TaskFile{{Path: "config/app.txt", Content: "from file"}},
+			Script: `echo "$GREETING"
+cat config/app.txt
+printf '{"payload":%s}' "$(cat "$SUPERPLANE_PAYLOAD_FILE")" > "$SUPERPLANE_RESULT_FILE"`,
+		},
+	})
+
+	result := runAgent(t, server, broker)
+
+	assert.Equal(t, protocol.StatusSucceeded, result.Status)
+	require.NotNil(t, result.ExitCode)
+	assert.Equal(t, 0, *result.ExitCode)
+	assert.JSONEq(t, `{"payload":{"root":{"value":42}}}`, string(result.Result))
+	assert.Equal(t, "setting up\nhello\nfrom file\n", result.Output)
+
+	records := broker.logRecords()
+	var types []string
+	for _, record := range records {
+		types = append(types, record.Type)
+	}
+	assert.Equal(t, []string{
+		protocol.LogRecordCmdStart, protocol.LogRecordLine, protocol.LogRecordCmdEnd,
+		protocol.LogRecordCmdStart, protocol.LogRecordLine, protocol.LogRecordLine, protocol.LogRecordCmdEnd,
+	}, types)
+	assert.Equal(t, "bash script.sh", records[3].Text)
+	assert.Equal(t, "passed", records[6].Status)
+}
+
+func Test__Agent__KeepsRunnerEnvironmentOutOfSteps(t *testing.T) {
+	requireBash(t)
+	t.Setenv("SUPERPLANE_RUNNER_REGISTRATION_TOKEN", "registration-token")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "runner-secret")
+
+	broker, server := newFakeBroker(t, &protocol.ClaimedTask{
+		ID:                       "task-5",
+		HeartbeatIntervalSeconds: 1,
+		Spec: protocol.TaskSpec{
+			Environment: []protocol.EnvironmentVariable{{Name: "GREETING", Value: "hello"}},
+			Script: `echo "token=${SUPERPLANE_RUNNER_REGISTRATION_TOKEN:-unset}"
+echo "aws=${AWS_SECRET_ACCESS_KEY:-unset}"
+echo "$GREETING"
+command -v bash > /dev/null && echo "path ok"`,
+		},
+	})
+
+	result := runAgent(t, server, broker)
+
+	assert.Equal(t, protocol.StatusSucceeded, result.Status)
+	assert.Equal(t, "token=unset\naws=unset\nhello\npath ok\n", result.Output)
+}
+
+func Test__Agent__StopsAtFailingCommand(t *testing.T) {
+	requireBash(t)
+
+	broker, server := newFakeBroker(t, &protocol.ClaimedTask{
+		ID:                       "task-2",
+		HeartbeatIntervalSeconds: 1,
+		Spec: protocol.TaskSpec{
+			Commands: []protocol.Command{
+				{Name: "Fail", Command: "echo boom && exit 3"},
+				{Name: "Never", Command: "echo unreachable"},
+			},
+		},
+	})
+
+	result := runAgent(t, server, broker)
+
+	assert.Equal(t, protocol.StatusFailed, result.Status)
+	require.NotNil(t, result.ExitCode)
+	assert.Equal(t, 3, *result.ExitCode)
+	assert.Equal(t, "boom\n", result.Output)
+	assert.Contains(t, result.Error, `"Fail" exited with code 3`)
+}
+
+func Test__Agent__CancelsTaskWhenBrokerRequestsIt(t *testing.T) {
+	requireBash(t)
+
+	broker, server := newFakeBroker(t, &protocol.ClaimedTask{
+		ID:                       "task-3",
+		HeartbeatIntervalSeconds: 1,
+		Spec:                     protocol.TaskSpec{Script: "sleep 30"},
+	})
+	broker.cancelRequested = true
+
+	started := time.Now()
+	result := runAgent(t, server, broker)
+
+	assert.Equal(t, protocol.StatusCanceled, result.Status)
+	assert.Less(t, time.Since(started), 15*time.Second)
+}
+
+func Test__Agent__FailsTaskOnTimeout(t *testing.T) {
+	requireBash(t)
+
+	broker, server := newFakeBroker(t, &protocol.ClaimedTask{
+		ID:                       "task-4",
+		HeartbeatIntervalSeconds: 30,
+		Spec:                     protocol.TaskSpec{Script: "sleep 30", ExecutionTimeoutSeconds: 1},
+	})
+
+	result := runAgent(t, server, broker)
+
+	assert.Equal(t, protocol.StatusFailed, result.Status)
+	assert.Contains(t, result.Error, "timed out")
+}
+
+func Test__WriteTaskFile__RejectsPathsOutsideTaskDir(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, path := range []string{"../escape.txt", "/etc/passwd", "a/../../escape.txt", ""} {
+		err := writeTaskFile(dir, protocol.TaskFile{Path: path, Content: "x"})
+		assert.Error(t, err, path)
+	}
+
+	require.NoError(t, writeTaskFile(dir, protocol.TaskFile{Path: "bin/run.sh", Content: "#!/bin/sh", Mode: "0755"}))
+}
@@ -0,0 +1,145 @@
+package runneragent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/taskbroker/protocol"
+)
+
+// ErrLeaseLost is returned when the broker no longer considers this runner
+// the owner of a task, for example after its lease expired.
+var ErrLeaseLost = errors.New("task lease lost")
+
+// Client talks to the runner side of the task broker API.
+type Client struct {
+	BaseURL string
+	Token   string
+	HTTP    *http.Client
+}
+
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Token:   token,
+		// Claims are long polls, so the timeout must exceed the claim wait.
+		HTTP: &http.Client{Timeout: 90 * time.Second},
+	}
+}
+
+// Register creates a runner in the given fleet and stores the issued
+// token on the client.
+func (c *Client) Register(ctx context.Context, registrationToken, fleetID, name string) (*protocol.RegisterRunnerResponse, error) {
+	var response protocol.RegisterRunnerResponse
+	req := protocol.RegisterRunnerRequest{FleetID: fleetID, Name: name}
+	status, err := c.do(ctx, registrationToken, protocol.RegisterRunnerPath, req, &response)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusCreated {
+		return nil, fmt.Errorf("unexpected status %d registering runner", status)
+	}
+
+	c.Token = response.Token
+	return &response, nil
+}
+
+// Claim waits up to wait for a queued task. It returns nil when no task
+// was queued in that time.
+func (c *Client) Claim(ctx context.Context, wait time.Duration) (*protocol.ClaimedTask, error) {
+	var task protocol.ClaimedTask
+	req := protocol.ClaimTaskRequest{WaitSeconds: int(wait / time.Second)}
+	status, err := c.do(ctx, c.Token, protocol.ClaimTaskPath, req, &task)
+	if err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case http.StatusOK:
+		return &task, nil
+	case http.StatusNoContent:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected status %d claiming task", status)
+	}
+}
+
+func (c *Client) Heartbeat(ctx context.Context, taskID string) (*protocol.HeartbeatResponse, error) {
+	var response protocol.HeartbeatResponse
+	status, err := c.do(ctx, c.Token, protocol.HeartbeatPath(taskID), struct{}{}, &response)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTaskStatus(status, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) AppendLogs(ctx context.Context, taskID string, records []protocol.LogRecord) error {
+	status, err := c.do(ctx, c.Token, protocol.LogsPath(taskID), protocol.AppendLogsRequest{Records: records}, nil)
+	if err != nil {
+		return err
+	}
+	return checkTaskStatus(status, http.StatusNoContent)
+}
+
+func (c *Client) Complete(ctx context.Context, taskID string, req protocol.CompleteTaskRequest) error {
+	status, err := c.do(ctx, c.Token, protocol.CompletePath(taskID), req, nil)
+	if err != nil {
+		return err
+	}
+	return checkTaskStatus(status, http.StatusNoContent)
+}
+
+func checkTaskStatus(status, want int) error {
+	switch status {
+	case want:
+		return nil
+	case http.StatusConflict, http.StatusNotFound:
+		return ErrLeaseLost
+	default:
+		return fmt.Errorf("unexpected status %d", status)
+	}
+}
+
+func (c *Client) do(ctx context.Context, token, path string, body any, out any) (int, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return resp.StatusCode, errors.New("broker rejected the runner credentials")
+	}
+
+	if out != nil && (resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated) {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("decode response: %w", err)
+		}
+		return resp.StatusCode, nil
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
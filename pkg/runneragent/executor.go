@@ -0,0 +1,379 @@
+package runneragent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/taskbroker/protocol"
+)
+
+const (
+	// containerTaskDir is where the task directory is mounted in
+	// container execution mode.
+	containerTaskDir = "/superplane/task"
+
+	payloadFileName = "payload.json"
+	resultFileName  = "result.json"
+
+	processWaitDelay = 5 * time.Second
+)
+
+// hostEnvironment lists the variables of the runner's own environment that
+// steps see in host execution mode. Everything else, registration token
+// included, stays with the runner.
+var hostEnvironment = []string{"PATH", "HOME", "LANG", "TMPDIR"}
+
+// errCanceled is the cancel cause used when the broker asks the runner to
+// stop a task.
+var errCanceled = errors.New("task canceled")
+
+type outcome struct {
+	status   string
+	exitCode *int
+	err      string
+	result   json.RawMessage
+}
+
+type step struct {
+	text    string
+	command string
+}
+
+// executor runs claimed tasks on the host or in a container started with
+// the local container runtime.
+type executor struct {
+	workDir          string
+	containerRuntime string
+}
+
+// taskRun holds the per-task state shared by every step.
+type taskRun struct {
+	executor *executor
+	id       string
+	spec     protocol.TaskSpec
+	dir      string
+	env      []string
+	logs     *logStreamer
+
+	// container is the name of the long-lived container steps run in,
+	// in container execution mode.
+	container string
+}
+
+func (e *executor) run(ctx context.Context, task *protocol.ClaimedTask, logs *logStreamer) outcome {
+	timeout := time.Duration(task.Spec.ExecutionTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = time.Hour
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dir := filepath.Join(e.workDir, task.ID)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return failed(fmt.Sprintf("create task directory: %v", err))
+	}
+	defer os.RemoveAll(dir)
+
+	run := &taskRun{executor: e, id: task.ID, spec: task.Spec, dir: dir, logs: logs}
+	steps, err := run.prepare()
+	if err != nil {
+		logs.error(err.Error())
+		return failed(err.Error())
+	}
+
+	if run.spec.ExecutionMode == protocol.ExecutionModeDocker {
+		if err := run.startContainer(ctx); err != nil {
+			logs.error(err.Error())
+			return failed(err.Error())
+		}
+		defer run.removeContainer()
+	}
+
+	if run.spec.Storage != nil {
+		if err := run.restoreStorage(ctx); err != nil {
+			if ctx.Err() != nil {
+				return run.interrupted(ctx, timeout)
+			}
+			logs.error(err.Error())
+			return failed(err.Error())
+		}
+	}
+
+	for i, s := range steps {
+		started := time.Now()
+		logs.commandStarted(i, s.text, started)
+		exitCode, err := run.runStep(ctx, s.command)
+		logs.commandFinished(i, err == nil && exitCode == 0, time.Since(started))
+
+		if ctx.Err() != nil {
+			return run.interrupted(ctx, timeout)
+		}
+
+		if err != nil {
+			logs.error(err.Error())
+			return failed(err.Error())
+		}
+
+		if exitCode != 0 {
+			o := failed(fmt.Sprintf("command %q exited with code %d", s.text, exitCode))
+			o.exitCode = &exitCode
+			return o
+		}
+	}
+
+	if run.spec.Storage != nil {
+		if err := run.saveStorage(ctx); err != nil {
+			if ctx.Err() != nil {
+				return run.interrupted(ctx, timeout)
+			}
+			logs.error(err.Error())
+			return failed(err.Error())
+		}
+	}
+
+	result, err := run.readResult()
+	if err != nil {
+		logs.error(err.Error())
+		return failed(err.Error())
+	}
+
+	zero := 0
+	return outcome{status: protocol.StatusSucceeded, exitCode: &zero, result: result}
+}
+
+func failed(message string) outcome {
+	return outcome{status: protocol.StatusFailed, err: message}
+}
+
+func (r *taskRun) interrupted(ctx context.Context, timeout time.Duration) outcome {
+	if errors.Is(context.Cause(ctx), errCanceled) {
+		r.logs.error("Task canceled")
+		return outcome{status: protocol.StatusCanceled, err: "task canceled"}
+	}
+
+	message := fmt.Sprintf("task timed out after %s", timeout)
+	r.logs.error(message)
+	return failed(message)
+}
+
+// prepare writes the task files, payload and script into the task
+// directory and returns the steps to run.
+func (r *taskRun) prepare() ([]step, error) {
+	for _, file := range r.spec.Files {
+		if err := writeTaskFile(r.dir, file); err != nil {
+			return nil, err
+		}
+	}
+
+	payload := []byte(r.spec.MessageChain)
+	if len(payload) == 0 {
+		payload = []byte("null")
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, payloadFileName), payload, 0o600); err != nil {
+		return nil, fmt.Errorf("write payload file: %w", err)
+	}
+
+	taskDir := r.dir
+	if r.spec.ExecutionMode == protocol.ExecutionModeDocker {
+		taskDir = containerTaskDir
+	}
+
+	for _, v := range r.spec.Environment {
+		r.env = append(r.env, v.Name+"="+v.Value)
+	}
+	r.env = append(r.env,
+		"SUPERPLANE_TASK_DIR="+taskDir,
+		"SUPERPLANE_PAYLOAD_FILE="+filepath.ToSlash(filepath.Join(taskDir, payloadFileName)),
+		"SUPERPLANE_RESULT_FILE="+filepath.ToSlash(filepath.Join(taskDir, resultFileName)),
+	)
+
+	steps := make([]step, 0, len(r.spec.SetupCommands)+len(r.spec.Commands)+1)
+	for _, command := range r.spec.SetupCommands {
+		steps = append(steps, step{text: command, command: command})
+	}
+
+	if strings.TrimSpace(r.spec.Script) != "" {
+		s, err := r.scriptStep()
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, s)
+	}
+
+	for _, command := range r.spec.Commands {
+		text := command.Name
+		if text == "" {
+			text = command.Command
+		}
+		steps = append(steps, step{text: text, command: command.Command})
+	}
+
+	return steps, nil
+}
+
+func (r *taskRun) scriptStep() (step, error) {
+	var name, interpreter string
+	switch r.spec.RunMode {
+	case protocol.RunModePython:
+		name, interpreter = "script.py", "python3"
+	case protocol.RunModeJavaScript:
+		name, interpreter = "script.js", "node"
+	case "", protocol.RunModeBash:
+		name, interpreter = "script.sh", "bash"
+	default:
+		return step{}, fmt.Errorf("unsupported run mode %q", r.spec.RunMode)
+	}
+
+	if err := os.WriteFile(filepath.Join(r.dir, name), []byte(r.spec.Script), 0o700); err != nil {
+		return step{}, fmt.Errorf("write script: %w", err)
+	}
+
+	command := interpreter + " " + name
+	return step{text: command, command: command}, nil
+}
+
+// writeTaskFile writes a task file relative to the task directory,
+// refusing paths that would escape it.
+func writeTaskFile(dir string, file protocol.TaskFile) error {
+	rel := filepath.Clean(filepath.FromSlash(file.Path))
+	if rel == "." || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("invalid task file path %q", file.Path)
+	}
+
+	mode := os.FileMode(0o644)
+	if file.Mode != "" {
+		parsed, err := strconv.ParseUint(file.Mode, 8, 32)
+		if err != nil {
+			return fmt.Errorf("invalid mode %q for task file %q", file.Mode, file.Path)
+		}
+		mode = os.FileMode(parsed).Perm()
+	}
+
+	path := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create directory for task file %q: %w", file.Path, err)
+	}
+	if err := os.WriteFile(path, []byte(file.Content), mode); err != nil {
+		return fmt.Errorf("write task file %q: %w", file.Path, err)
+	}
+	return os.Chmod(path, mode)
+}
+
+func (r *taskRun) startContainer(ctx context.Context) error {
+	r.container = "superplane-task-" + r.id
+	args := []string{"run", "--detach", "--rm", "--name", r.container,
+		"--volume", r.dir + ":" + containerTaskDir,
+		"--workdir", containerTaskDir,
+		"--entrypoint", "sleep",
+	}
+	for _, env := range r.env {
+		args = append(args, "--env", env)
+	}
+	args = append(args, r.spec.DockerImage, "infinity")
+
+	output, err := exec.CommandContext(ctx, r.executor.containerRuntime, args...).CombinedOutput()
+	if err != nil {
+		r.container = ""
+		return fmt.Errorf("start container from %s: %v: %s", r.spec.DockerImage, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (r *taskRun) removeContainer() {
+	if r.container == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_ = exec.CommandContext(ctx, r.executor.containerRuntime, "rm", "--force", r.container).Run()
+}
+
+// runStep runs one shell command, streaming its combined output as log
+// lines. A non-zero exit is reported through the exit code, not err.
+func (r *taskRun) runStep(ctx context.Context, command string) (int, error) {
+	var cmd *exec.Cmd
+	if r.container != "" {
+		cmd = exec.CommandContext(ctx, r.executor.containerRuntime, "exec", r.container, "sh", "-c", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "bash", "-c", command)
+		cmd.Dir = r.dir
+		cmd.Env = append(hostEnv(), r.env...)
+		killProcessGroup(cmd)
+	}
+	cmd.WaitDelay = processWaitDelay
+
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+
+	scanned := make(chan struct{})
+	go func() {
+		defer close(scanned)
+		r.scanLines(reader)
+	}()
+
+	err := cmd.Run()
+	writer.Close()
+	<-scanned
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, fmt.Errorf("run %q: %w", command, err)
+	}
+	return 0, nil
+}
+
+func hostEnv() []string {
+	env := make([]string, 0, len(hostEnvironment))
+	for _, name := range hostEnvironment {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	return env
+}
+
+func (r *taskRun) scanLines(reader io.Reader) {
+	buffered := bufio.NewReader(reader)
+	for {
+		line, err := buffered.ReadString('\n')
+		if line != "" {
+			r.logs.line(strings.TrimRight(line, "\r\n"))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (r *taskRun) readResult() (json.RawMessage, error) {
+	data, err := os.ReadFile(filepath.Join(r.dir, resultFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read result file: %w", err)
+	}
+
+	data = []byte(strings.TrimSpace(string(data)))
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(data) {
+		return nil, errors.New("SUPERPLANE_RESULT_FILE does not contain valid JSON")
+	}
+	return data, nil
+}
@@ -0,0 +1,137 @@
+package runneragent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/taskbroker/protocol"
+)
+
+const (
+	logFlushInterval = 500 * time.Millisecond
+	logBatchSize     = 200
+
+	// outputTailBytes is how much of the end of the output is reported on
+	// completion; the full output is only available through live logs.
+	outputTailBytes = 64 * 1024
+)
+
+// logStreamer batches log records and ships them to the broker in the
+// background, and keeps the tail of the plain output for the task result.
+type logStreamer struct {
+	client *Client
+	taskID string
+
+	mu      sync.Mutex
+	pending []protocol.LogRecord
+	tail    []byte
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+func newLogStreamer(client *Client, taskID string) *logStreamer {
+	return &logStreamer{
+		client: client,
+		taskID: taskID,
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+func (s *logStreamer) line(text string) {
+	s.mu.Lock()
+	s.tail = append(s.tail, text...)
+	s.tail = append(s.tail, '\n')
+	if len(s.tail) > outputTailBytes {
+		s.tail = s.tail[len(s.tail)-outputTailBytes:]
+	}
+	s.mu.Unlock()
+
+	s.add(protocol.LogRecord{Type: protocol.LogRecordLine, Text: text})
+}
+
+func (s *logStreamer) error(message string) {
+	s.add(protocol.LogRecord{Type: protocol.LogRecordError, Message: message})
+}
+
+func (s *logStreamer) commandStarted(index int, text string, startedAt time.Time) {
+	ms := startedAt.UnixMilli()
+	s.add(protocol.LogRecord{Type: protocol.LogRecordCmdStart, Index: &index, Text: text, StartedAt: &ms})
+}
+
+func (s *logStreamer) commandFinished(index int, passed bool, duration time.Duration) {
+	status := "passed"
+	if !passed {
+		status = "failed"
+	}
+	ms := duration.Milliseconds()
+	s.add(protocol.LogRecord{Type: protocol.LogRecordCmdEnd, Index: &index, Status: status, DurationMS: &ms})
+}
+
+func (s *logStreamer) add(record protocol.LogRecord) {
+	s.mu.Lock()
+	s.pending = append(s.pending, record)
+	full := len(s.pending) >= logBatchSize
+	s.mu.Unlock()
+
+	if full {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *logStreamer) output() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return string(s.tail)
+}
+
+// run ships batches until ctx is done, then flushes what is left.
+func (s *logStreamer) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(logFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.flush(context.Background())
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+		s.flush(ctx)
+	}
+}
+
+// wait blocks until run has flushed the remaining records.
+func (s *logStreamer) wait() {
+	<-s.done
+}
+
+func (s *logStreamer) flush(ctx context.Context) {
+	for {
+		s.mu.Lock()
+		n := min(len(s.pending), logBatchSize)
+		batch := s.pending[:n:n]
+		s.mu.Unlock()
+
+		if n == 0 {
+			return
+		}
+
+		if err := s.client.AppendLogs(ctx, s.taskID, batch); err != nil {
+			// Logs are best effort; the task result is what matters.
+			log.Warnf("runner: failed to send logs for task %s: %v", s.taskID, err)
+		}
+
+		s.mu.Lock()
+		s.pending = s.pending[n:]
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !unix
+
+package runneragent
+
+import "os/exec"
+
+func killProcessGroup(cmd *exec.Cmd) {}
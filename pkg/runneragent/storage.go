@@ -0,0 +1,370 @@
+package runneragent
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/superplanehq/superplane/pkg/taskbroker/protocol"
+)
+
+const storageHTTPTimeout = 10 * time.Minute
+
+// errNotFound is returned for missing caches and artifacts.
+var errNotFound = errors.New("not found")
+
+// storageClient uploads and fetches artifacts and caches through the
+// SuperPlane runner storage API with the token issued for the task.
+type storageClient struct {
+	apiURL string
+	token  string
+	http   *http.Client
+}
+
+func newStorageClient(storage *protocol.TaskStorage) *storageClient {
+	return &storageClient{
+		apiURL: strings.TrimRight(storage.APIURL, "/"),
+		token:  storage.Token,
+		http:   &http.Client{Timeout: storageHTTPTimeout},
+	}
+}
+
+func (c *storageClient) listArtifacts(ctx context.Context) ([]protocol.StoredArtifact, error) {
+	body, err := c.get(ctx, "/artifacts")
+	if err != nil {
+		return nil, err
+	}
+
+	var response protocol.ListArtifactsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("decode artifact list: %w", err)
+	}
+	return response.Artifacts, nil
+}
+
+func (c *storageClient) downloadArtifact(ctx context.Context, id string) ([]byte, error) {
+	return c.get(ctx, "/artifacts/"+url.PathEscape(id))
+}
+
+func (c *storageClient) uploadArtifact(ctx context.Context, name string, archive []byte) error {
+	return c.put(ctx, http.MethodPost, "/artifacts?name="+url.QueryEscape(name), archive)
+}
+
+func (c *storageClient) restoreCache(ctx context.Context, key string) ([]byte, error) {
+	return c.get(ctx, "/caches/"+url.PathEscape(key))
+}
+
+func (c *storageClient) saveCache(ctx context.Context, key string, archive []byte) error {
+	return c.put(ctx, http.MethodPut, "/caches/"+url.PathEscape(key), archive)
+}
+
+func (c *storageClient) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, storageError(resp)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (c *storageClient) put(ctx context.Context, method, path string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/gzip")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return storageError(resp)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func storageError(resp *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+}
+
+// restoreStorage extracts run artifacts and the cache into the task
+// directory. A missing or broken cache never fails the task.
+func (r *taskRun) restoreStorage(ctx context.Context) error {
+	storage := r.spec.Storage
+	client := newStorageClient(storage)
+
+	if storage.DownloadArtifacts {
+		artifacts, err := client.listArtifacts(ctx)
+		if err != nil {
+			return fmt.Errorf("list run artifacts: %w", err)
+		}
+		for _, artifact := range artifacts {
+			archive, err := client.downloadArtifact(ctx, artifact.ID)
+			if err != nil {
+				return fmt.Errorf("download artifact %s: %w", artifact.Name, err)
+			}
+			if err := extractArchive(r.dir, archive); err != nil {
+				return fmt.Errorf("extract artifact %s: %w", artifact.Name, err)
+			}
+			r.logs.line(fmt.Sprintf("Downloaded artifact %s (%s)", artifact.Name, formatBytes(int64(len(archive)))))
+		}
+	}
+
+	if storage.Cache != nil {
+		archive, err := client.restoreCache(ctx, storage.Cache.Key)
+		switch {
+		case errors.Is(err, errNotFound):
+			r.logs.line(fmt.Sprintf("No cache found for key %s", storage.Cache.Key))
+		case err != nil:
+			r.logs.line(fmt.Sprintf("Failed to restore cache %s: %v", storage.Cache.Key, err))
+		default:
+			if err := extractArchive(r.dir, archive); err != nil {
+				r.logs.line(fmt.Sprintf("Failed to extract cache %s: %v", storage.Cache.Key, err))
+			} else {
+				r.logs.line(fmt.Sprintf("Restored cache %s (%s)", storage.Cache.Key, formatBytes(int64(len(archive)))))
+			}
+		}
+	}
+
+	return nil
+}
+
+// saveStorage uploads the declared artifacts and the cache after the task
+// succeeded. Only artifact upload failures fail the task.
+func (r *taskRun) saveStorage(ctx context.Context) error {
+	storage := r.spec.Storage
+	client := newStorageClient(storage)
+
+	for _, artifact := range storage.UploadArtifacts {
+		archive, count, err := createArchive(r.dir, artifact.Paths)
+		if err != nil {
+			return fmt.Errorf("archive artifact %s: %w", artifact.Name, err)
+		}
+		if count == 0 {
+			r.logs.line(fmt.Sprintf("Artifact %s matched no files, skipping", artifact.Name))
+			continue
+		}
+		if err := client.uploadArtifact(ctx, artifact.Name, archive); err != nil {
+			return fmt.Errorf("upload artifact %s: %w", artifact.Name, err)
+		}
+		r.logs.line(fmt.Sprintf("Uploaded artifact %s: %d files (%s)", artifact.Name, count, formatBytes(int64(len(archive)))))
+	}
+
+	if storage.Cache != nil {
+		archive, count, err := createArchive(r.dir, storage.Cache.Paths)
+		switch {
+		case err != nil:
+			r.logs.line(fmt.Sprintf("Failed to archive cache %s: %v", storage.Cache.Key, err))
+		case count == 0:
+			r.logs.line(fmt.Sprintf("Cache paths matched no files, not saving %s", storage.Cache.Key))
+		default:
+			if err := client.saveCache(ctx, storage.Cache.Key, archive); err != nil {
+				r.logs.line(fmt.Sprintf("Failed to save cache %s: %v", storage.Cache.Key, err))
+			} else {
+				r.logs.line(fmt.Sprintf("Saved cache %s: %d files (%s)", storage.Cache.Key, count, formatBytes(int64(len(archive)))))
+			}
+		}
+	}
+
+	return nil
+}
+
+// createArchive writes the regular files under dir matching any of the
+// patterns into a gzipped tarball and returns it with the file count.
+// Patterns are slash-separated globs where ** matches any number of
+// directories; a pattern matching a directory includes all of it.
+func createArchive(dir string, patterns []string) ([]byte, int, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	count := 0
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !matchesAny(patterns, rel) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{
+			Name:    rel,
+			Mode:    int64(info.Mode().Perm()),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		file, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		if _, err := io.Copy(tw, file); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, 0, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), count, nil
+}
+
+// extractArchive extracts a gzipped tarball into dir, refusing entries
+// that would land outside it.
+func extractArchive(dir string, archive []byte) error {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		rel := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("archive entry %q is outside the task directory", header.Name)
+		}
+
+		target := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+			return err
+		}
+
+		mode := os.FileMode(header.Mode).Perm() | 0o600
+		file, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(file, tr)
+		closeErr := file.Close()
+		if err != nil {
+			return err
+		}
+		if closeErr != nil {
+			return closeErr
+		}
+	}
+}
+
+func matchesAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.Trim(strings.TrimPrefix(strings.TrimSpace(pattern), "./"), "/")
+		if pattern == "" {
+			continue
+		}
+		if matchGlob(strings.Split(pattern, "/"), strings.Split(rel, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches path segments against pattern segments. A pattern
+// that matches a prefix of the path matches the whole path, so directory
+// patterns include everything below them.
+func matchGlob(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+	if len(segments) == 0 {
+		return len(pattern) == 1 && pattern[0] == "**"
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchGlob(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	ok, err := path.Match(pattern[0], segments[0])
+	if err != nil || !ok {
+		return false
+	}
+	return matchGlob(pattern[1:], segments[1:])
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
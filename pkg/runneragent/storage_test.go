@@ -0,0 +1,135 @@
+package runneragent
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/taskbroker/protocol"
+)
+
+func Test__MatchesAny(t *testing.T) {
+	cases := []struct {
+		patterns []string
+		path     string
+		want     bool
+	}{
+		{[]string{"dist/**"}, "dist/app.js", true},
+		{[]string{"dist/**"}, "dist/assets/logo.png", true},
+		{[]string{"dist"}, "dist/assets/logo.png", true},
+		{[]string{"*.xml"}, "coverage.xml", true},
+		{[]string{"*.xml"}, "reports/coverage.xml", false},
+		{[]string{"**/*.xml"}, "reports/coverage.xml", true},
+		{[]string{"./build/*.tar"}, "build/app.tar", true},
+		{[]string{"build/*.tar"}, "build/nested/app.tar", false},
+		{[]string{"src/**/test"}, "src/a/b/test/file.go", true},
+		{[]string{"other"}, "dist/app.js", false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.want, matchesAny(c.patterns, c.path), "%v %s", c.patterns, c.path)
+	}
+}
+
+func Test__Archive__RoundTrip(t *testing.T) {
+	source := t.TempDir()
+	writeTestFile(t, source, "dist/app.js", "console.log(1)")
+	writeTestFile(t, source, "dist/assets/logo.svg", "<svg/>")
+	writeTestFile(t, source, "src/main.go", "package main")
+
+	archive, count, err := createArchive(source, []string{"dist/**"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	target := t.TempDir()
+	require.NoError(t, extractArchive(target, archive))
+
+	content, err := os.ReadFile(filepath.Join(target, "dist", "assets", "logo.svg"))
+	require.NoError(t, err)
+	assert.Equal(t, "<svg/>", string(content))
+	assert.NoFileExists(t, filepath.Join(target, "src", "main.go"))
+}
+
+func Test__Agent__UploadsArtifactsAndCaches(t *testing.T) {
+	requireBash(t)
+
+	var mu sync.Mutex
+	uploads := map[string][]byte{}
+	cacheArchive, _, err := createArchive(writeCacheDir(t), []string{"deps/**"})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /storage/caches/{key}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer storage-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "deps-v1", r.PathValue("key"))
+		_, _ = w.Write(cacheArchive)
+	})
+	mux.HandleFunc("PUT /storage/caches/{key}", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		uploads["cache:"+r.PathValue("key")] = body
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("POST /storage/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		uploads["artifact:"+r.URL.Query().Get("name")] = body
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	})
+	storage := httptest.NewServer(mux)
+	t.Cleanup(storage.Close)
+
+	broker, server := newFakeBroker(t, &protocol.ClaimedTask{
+		ID:                       "task-storage",
+		HeartbeatIntervalSeconds: 1,
+		Spec: protocol.TaskSpec{
+			Script: "cat deps/lib.txt && mkdir -p dist && echo built > dist/app.txt",
+			Storage: &protocol.TaskStorage{
+				APIURL:          storage.URL + "/storage",
+				Token:           "storage-token",
+				UploadArtifacts: []protocol.TaskArtifact{{Name: "build", Paths: []string{"dist/**"}}, {Name: "empty", Paths: []string{"missing/**"}}},
+				Cache:           &protocol.TaskCache{Key: "deps-v1", Paths: []string{"deps/**"}},
+			},
+		},
+	})
+
+	result := runAgent(t, server, broker)
+	require.Equal(t, protocol.StatusSucceeded, result.Status, result.Error)
+	assert.Contains(t, result.Output, "cached dependency\n")
+	assert.Contains(t, result.Output, "Restored cache deps-v1")
+	assert.Contains(t, result.Output, "Artifact empty matched no files, skipping")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Contains(t, uploads, "artifact:build")
+	require.Contains(t, uploads, "cache:deps-v1")
+	assert.NotContains(t, uploads, "artifact:empty")
+
+	extracted := t.TempDir()
+	require.NoError(t, extractArchive(extracted, uploads["artifact:build"]))
+	content, err := os.ReadFile(filepath.Join(extracted, "dist", "app.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "built\n", string(content))
+}
+
+func writeCacheDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFile(t, dir, "deps/lib.txt", "cached dependency\n")
+	return dir
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
@@ -0,0 +1,74 @@
+package runnerstorage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+/*
+ * In-memory runner storage.
+ *
+ * This store is used for testing purposes.
+ * It is not meant to be used in production.
+ */
+type MemoryStore struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{blobs: map[string][]byte{}}
+}
+
+func (s *MemoryStore) Name() string {
+	return MemoryProvider
+}
+
+func (s *MemoryStore) Put(_ context.Context, key string, content io.Reader) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *MemoryStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, ErrBlobNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blobs, key)
+	return nil
+}
+
+// Keys returns the keys with content, for tests to check what was deleted.
+func (s *MemoryStore) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.blobs))
+	for key := range s.blobs {
+		keys = append(keys, key)
+	}
+	return keys
+}
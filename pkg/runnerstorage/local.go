@@ -0,0 +1,140 @@
+package runnerstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+ * Local filesystem runner storage.
+ *
+ * Blobs are files under a root directory, which can be a local disk or
+ * a mounted volume. The API stores and serves blobs, and the cleanup
+ * worker deletes them, so every process running either one must see
+ * the same directory.
+ *
+ * Content is written to a temporary file next to its destination and
+ * renamed into place, so a blob is never visible half-written.
+ */
+type LocalStore struct {
+	root string
+}
+
+func NewLocalStore() (*LocalStore, error) {
+	root := strings.TrimSpace(os.Getenv("RUNNER_STORAGE_LOCAL_PATH"))
+	if root == "" {
+		return nil, fmt.Errorf("RUNNER_STORAGE_LOCAL_PATH is required")
+	}
+
+	return NewLocalStoreWithRoot(root)
+}
+
+func NewLocalStoreWithRoot(root string) (*LocalStore, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving runner storage root: %w", err)
+	}
+
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("error creating runner storage root: %w", err)
+	}
+
+	return &LocalStore{root: root}, nil
+}
+
+func (s *LocalStore) Name() string {
+	return LocalProvider
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, content io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("error creating blob directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("error creating blob: %w", err)
+	}
+
+	tempPath := file.Name()
+	if _, err := io.Copy(file, contextReader{ctx: ctx, reader: content}); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tempPath)
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("error writing blob: %w", err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("error writing blob: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("error opening blob: %w", err)
+	}
+
+	return file, nil
+}
+
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error deleting blob: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// contextReader stops a copy once the context is done,
+// so an abandoned upload doesn't keep writing to disk.
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.reader.Read(p)
+}
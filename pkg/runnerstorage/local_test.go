@@ -0,0 +1,117 @@
+package runnerstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test__LocalStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("put, open and delete a blob", func(t *testing.T) {
+		store, err := NewLocalStoreWithRoot(t.TempDir())
+		require.NoError(t, err)
+
+		key := NewArtifactKey(uuid.New())
+		require.NoError(t, store.Put(ctx, key, strings.NewReader("content")))
+
+		reader, err := store.Open(ctx, key)
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, reader.Close())
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(content))
+
+		require.NoError(t, store.Delete(ctx, key))
+		_, err = store.Open(ctx, key)
+		assert.ErrorIs(t, err, ErrBlobNotFound)
+	})
+
+	t.Run("put replaces the content of a key", func(t *testing.T) {
+		store, err := NewLocalStoreWithRoot(t.TempDir())
+		require.NoError(t, err)
+
+		key := NewCacheKey(uuid.New())
+		require.NoError(t, store.Put(ctx, key, strings.NewReader("first")))
+		require.NoError(t, store.Put(ctx, key, strings.NewReader("second")))
+
+		reader, err := store.Open(ctx, key)
+		require.NoError(t, err)
+		defer reader.Close()
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, "second", string(content))
+	})
+
+	t.Run("failed put leaves nothing behind", func(t *testing.T) {
+		root := t.TempDir()
+		store, err := NewLocalStoreWithRoot(root)
+		require.NoError(t, err)
+
+		key := NewArtifactKey(uuid.New())
+		err = store.Put(ctx, key, io.MultiReader(strings.NewReader("partial"), failingReader{}))
+		require.Error(t, err)
+
+		_, err = store.Open(ctx, key)
+		assert.ErrorIs(t, err, ErrBlobNotFound)
+
+		entries, err := os.ReadDir(filepath.Dir(filepath.Join(root, key)))
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("deleting a missing blob is not an error", func(t *testing.T) {
+		store, err := NewLocalStoreWithRoot(t.TempDir())
+		require.NoError(t, err)
+
+		assert.NoError(t, store.Delete(ctx, NewArtifactKey(uuid.New())))
+	})
+
+	t.Run("keys cannot escape the root", func(t *testing.T) {
+		store, err := NewLocalStoreWithRoot(t.TempDir())
+		require.NoError(t, err)
+
+		for _, key := range []string{"", "/etc/passwd", "../outside", "canvases/../../outside", "canvases//x", `canvases\x`} {
+			assert.ErrorIs(t, store.Put(ctx, key, strings.NewReader("x")), ErrInvalidKey, key)
+		}
+	})
+
+	t.Run("requires a root directory", func(t *testing.T) {
+		t.Setenv("RUNNER_STORAGE_LOCAL_PATH", "")
+		_, err := NewLocalStore()
+		assert.Error(t, err)
+	})
+}
+
+func Test__NewStore(t *testing.T) {
+	t.Run("defaults to the local store", func(t *testing.T) {
+		t.Setenv("RUNNER_STORAGE_PROVIDER", "")
+		t.Setenv("RUNNER_STORAGE_LOCAL_PATH", t.TempDir())
+
+		store, err := NewStore()
+		require.NoError(t, err)
+		assert.Equal(t, LocalProvider, store.Name())
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		t.Setenv("RUNNER_STORAGE_PROVIDER", "nope")
+
+		_, err := NewStore()
+		assert.Error(t, err)
+	})
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
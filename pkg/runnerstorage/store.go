@@ -0,0 +1,90 @@
+package runnerstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+/*
+ * Runner artifacts and caches are gzipped tarballs that can be large,
+ * so their contents live in a blob store, and Postgres only keeps their
+ * metadata and the key of the blob. Available providers:
+ * - local - files on a local disk or mounted volume
+ * - memory - in-memory, for tests
+ */
+const (
+	LocalProvider  = "local"
+	MemoryProvider = "memory"
+)
+
+var (
+	ErrBlobNotFound = errors.New("blob not found")
+	ErrInvalidKey   = errors.New("invalid blob key")
+)
+
+type Store interface {
+	Name() string
+
+	// Put writes the content under the key, replacing any previous
+	// content. Content is only visible to Open once Put returns.
+	Put(ctx context.Context, key string, content io.Reader) error
+
+	// Open returns the content stored under the key,
+	// or ErrBlobNotFound if there is none.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the content stored under the key.
+	// Deleting a key that has no content is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+func NewStore() (Store, error) {
+	provider := strings.TrimSpace(os.Getenv("RUNNER_STORAGE_PROVIDER"))
+	if provider == "" {
+		provider = LocalProvider
+	}
+
+	switch provider {
+	case LocalProvider:
+		log.Println("Creating Local Runner Storage")
+		return NewLocalStore()
+	case MemoryProvider:
+		log.Println("Creating In-Memory Runner Storage")
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported runner storage provider %q", provider)
+	}
+}
+
+// NewArtifactKey returns a key for a new artifact blob. Every upload gets
+// its own key, so replacing an artifact never overwrites content that is
+// still being downloaded.
+func NewArtifactKey(canvasID uuid.UUID) string {
+	return fmt.Sprintf("canvases/%s/artifacts/%s", canvasID, uuid.New())
+}
+
+// NewCacheKey returns a key for a new cache blob; see NewArtifactKey.
+func NewCacheKey(canvasID uuid.UUID) string {
+	return fmt.Sprintf("canvases/%s/caches/%s", canvasID, uuid.New())
+}
+
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
@@ -13,25 +13,32 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"github.com/superplanehq/superplane/pkg/agents"
 	agenttools "github.com/superplanehq/superplane/pkg/agents/agent_tools"
 	"github.com/superplanehq/superplane/pkg/agents/anthropic"
+	"github.com/superplanehq/superplane/pkg/agents/openai"
 	"github.com/superplanehq/superplane/pkg/authorization"
 	"github.com/superplanehq/superplane/pkg/components/runner"
 	"github.com/superplanehq/superplane/pkg/config"
 	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/database"
 	"github.com/superplanehq/superplane/pkg/git"
 	gitprovider "github.com/superplanehq/superplane/pkg/git/provider"
+	"github.com/superplanehq/superplane/pkg/gitmirror"
 	grpc "github.com/superplanehq/superplane/pkg/grpc"
 	agentsActions "github.com/superplanehq/superplane/pkg/grpc/actions/agents"
 	"github.com/superplanehq/superplane/pkg/jwt"
+	"github.com/superplanehq/superplane/pkg/models"
 	"github.com/superplanehq/superplane/pkg/networkpolicy"
 	"github.com/superplanehq/superplane/pkg/oidc"
 	"github.com/superplanehq/superplane/pkg/public"
 	registry "github.com/superplanehq/superplane/pkg/registry"
 	"github.com/superplanehq/superplane/pkg/registryimports"
+	"github.com/superplanehq/superplane/pkg/runnerstorage"
 	"github.com/superplanehq/superplane/pkg/services"
+	"github.com/superplanehq/superplane/pkg/taskbroker"
 	"github.com/superplanehq/superplane/pkg/telemetry"
 	"github.com/superplanehq/superplane/pkg/usage"
 	"github.com/superplanehq/superplane/pkg/workers"
@@ -72,7 +79,10 @@ func buildAgentService(authService authorization.Authorization) (agents.Provider
 
 	cfg := config.LoadAnthropicAgentConfig()
 	if !cfg.Enabled() {
-		log.Info("Anthropic managed agents disabled: missing ANTHROPIC_* env vars")
+		if openAICfg := config.LoadOpenAIAgentConfig(); openAICfg.Enabled() {
+			return buildOpenAIAgentService(openAICfg, authService)
+		}
+		log.Info("Managed agents disabled: missing ANTHROPIC_* or OPENAI_AGENT_* env vars")
 		return nil, nil
 	}
 
@@ -112,11 +122,28 @@ func buildAgentService(authService authorization.Authorization) (agents.Provider
 	return provider, service
 }
 
+func buildOpenAIAgentService(cfg config.OpenAIAgentConfig, authService authorization.Authorization) (agents.Provider, agentsActions.AgentsService) {
+	provider, err := openai.New(openai.Config{
+		BaseURL: cfg.BaseURL,
+		APIKey:  cfg.APIKey,
+		Model:   cfg.Model,
+	})
+	if err != nil {
+		log.WithError(err).Warn("failed to initialise OpenAI-compatible agent provider")
+		return nil, nil
+	}
+
+	service := agents.NewService(provider, authService)
+	log.WithField("model", cfg.Model).Info("OpenAI-compatible agent provider enabled")
+	return provider, service
+}
+
 func startWorkers(
 	encryptor crypto.Encryptor,
 	registry *registry.Registry,
 	oidcProvider oidc.Provider,
 	gitProvider gitprovider.Provider,
+	runnerStore runnerstorage.Store,
 	baseURL string,
 	authService authorization.Authorization,
 	agentProvider agents.Provider,
@@ -229,6 +256,13 @@ func startWorkers(
 		go w.Start(context.Background())
 	}
 
+	if os.Getenv("START_RUN_LEASE_REVOKER") == "yes" {
+		log.Println("Starting Run Lease Revoker")
+
+		w := workers.NewRunLeaseRevoker(registry, encryptor)
+		go w.Start(context.Background())
+	}
+
 	if os.Getenv("START_NODE_REQUEST_CLEANUP_WORKER") == "yes" {
 		log.Println("Starting Node Request Cleanup Worker")
 
@@ -236,6 +270,20 @@ func startWorkers(
 		go w.Start(context.Background())
 	}
 
+	if os.Getenv("START_RUNNER_STORAGE_CLEANUP_WORKER") == "yes" {
+		log.Println("Starting Runner Storage Cleanup Worker")
+
+		w := workers.NewRunnerStorageCleanupWorker(runnerStore)
+		go w.Start(context.Background())
+	}
+
+	if os.Getenv("START_CANVAS_MEMORY_CLEANUP_WORKER") == "yes" {
+		log.Println("Starting Canvas Memory Cleanup Worker")
+
+		w := workers.NewCanvasMemoryCleanupWorker()
+		go w.Start(context.Background())
+	}
+
 	if os.Getenv("START_REPOSITORY_PROVISIONER") == "yes" {
 		log.Println("Starting Repository Provisioner")
 		w := workers.NewRepositoryProvisionerWorker(rabbitMQURL, gitProvider)
@@ -304,6 +352,20 @@ func startWorkers(
 		go w.Start(context.Background())
 	}
 
+	if os.Getenv("START_GIT_MIRROR_WORKER") == "yes" {
+		log.Println("Starting Git Mirror Worker")
+
+		w := workers.NewGitMirrorWorker(&gitmirror.Service{
+			Registry:       registry,
+			Encryptor:      encryptor,
+			AuthService:    authService,
+			GitProvider:    gitProvider,
+			WebhookBaseURL: getWebhookBaseURL(baseURL),
+			UsageService:   getOptionalWorkerUsageService(),
+		})
+		go w.Start(context.Background())
+	}
+
 	if os.Getenv("START_EVENT_RETENTION_WORKER") == "yes" || os.Getenv("START_USAGE_SYNC_WORKER") == "yes" {
 		usageService := getRequiredWorkerUsageService()
 
@@ -383,6 +445,7 @@ func startPublicAPI(
 	oidcProvider oidc.Provider,
 	authService authorization.Authorization,
 	gitProvider gitprovider.Provider,
+	runnerStore runnerstorage.Store,
 	grpcServices *grpc.Services,
 ) {
 	log.Println("Starting Public API with integrated Web Server")
@@ -415,6 +478,8 @@ func startPublicAPI(
 		log.Panicf("Error creating public API server: %v", err)
 	}
 
+	server.WithRunnerStore(runnerStore)
+
 	// Start the EventDistributer worker if enabled
 	if os.Getenv("START_EVENT_DISTRIBUTER") == "yes" {
 		log.Println("Starting Event Distributer Worker")
@@ -442,6 +507,18 @@ func startPublicAPI(
 		log.Println("Websocket routes not registered")
 	}
 
+	if os.Getenv("START_TASK_BROKER") == "yes" {
+		log.Println("Registering embedded task broker on Public API")
+		broker := taskbroker.New(taskbroker.Options{
+			AuthToken:         os.Getenv("TASK_BROKER_AUTH_TOKEN"),
+			RegistrationToken: os.Getenv("TASK_BROKER_RUNNER_REGISTRATION_TOKEN"),
+		})
+		server.RegisterTaskBroker(broker.Handler())
+		go broker.Start(context.Background())
+	} else {
+		log.Println("Task broker not registered (START_TASK_BROKER != yes)")
+	}
+
 	// Register web routes only if START_WEB_SERVER is set to "yes"
 	if os.Getenv("START_WEB_SERVER") == "yes" {
 		webBasePath := os.Getenv("WEB_BASE_PATH")
@@ -550,10 +627,39 @@ func startPprofServer() {
 	}()
 }
 
+// startPrometheusServer exposes /metrics on its own port, so metrics
+// labeled with organization and canvas IDs stay off the public API.
+func startPrometheusServer() {
+	if os.Getenv("PROMETHEUS_METRICS_ENABLED") != "yes" {
+		return
+	}
+
+	port := os.Getenv("PROMETHEUS_METRICS_PORT")
+	if port == "" {
+		port = "9464"
+	}
+
+	if err := telemetry.InitPrometheusMetrics(); err != nil {
+		log.Warnf("Failed to initialize Prometheus metrics: %v", err)
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", telemetry.PrometheusHandler())
+
+	go func() {
+		log.Infof("Prometheus metrics server listening on :%s", port)
+		if err := http.ListenAndServe("0.0.0.0:"+port, mux); err != nil {
+			log.Warnf("Prometheus metrics server stopped: %v", err)
+		}
+	}()
+}
+
 func Start() {
 	configureLogging()
 	setupOtel()
 	startPprofServer()
+	startPrometheusServer()
 
 	telemetry.InitSentry()
 	telemetry.StartBeacon()
@@ -611,6 +717,11 @@ func Start() {
 		panic(fmt.Sprintf("failed to create git provider: %v", err))
 	}
 
+	runnerStore, err := runnerstorage.NewStore()
+	if err != nil {
+		panic(fmt.Sprintf("failed to create runner storage: %v", err))
+	}
+
 	registry, err := registry.NewRegistryWithOptions(registry.RegistryOptions{
 		Encryptor: encryptorInstance,
 		HTTP: registry.HTTPOptions{
@@ -654,6 +765,9 @@ func Start() {
 	runner.SetRunnerMinutesLimitChecker(func(organizationID string) error {
 		return usage.EnsureCanStartRunnerTask(context.Background(), runnerUsageService, organizationID)
 	})
+	runner.SetFactoryBudgetChecker(func(workflowID string, executionID uuid.UUID) error {
+		return models.EnsureFactoryBudgetForNodeExecution(database.Conn(), workflowID, executionID)
+	})
 
 	var grpcServices *grpc.Services
 	if os.Getenv("START_PUBLIC_API") == "yes" {
@@ -681,6 +795,7 @@ func Start() {
 			oidcProvider,
 			authService,
 			gitProvider,
+			runnerStore,
 			grpcServices,
 		)
 	}
@@ -690,6 +805,7 @@ func Start() {
 		registry,
 		oidcProvider,
 		gitProvider,
+		runnerStore,
 		baseURL,
 		authService,
 		agentProvider,
@@ -0,0 +1,161 @@
+// Package taskbroker is an embeddable implementation of the task broker API
+// used by the runner components. SuperPlane creates tasks through the
+// /v1/tasks client API, and self-hosted runners registered with
+// `superplane runner` claim and execute them.
+package taskbroker
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	DefaultLeaseDuration = time.Minute
+	runnerOnlineWindow   = 2 * time.Minute
+	leaseSweepInterval   = 15 * time.Second
+	maxClaimWait         = 30 * time.Second
+	claimPollInterval    = time.Second
+	maxRequestBodyBytes  = 8 << 20
+)
+
+type Options struct {
+	// AuthToken authenticates the client API and signs live log stream
+	// tokens. It is the TASK_BROKER_AUTH_TOKEN the runner components use.
+	AuthToken string
+
+	// RegistrationToken lets runners register. Registration is disabled
+	// when it is empty.
+	RegistrationToken string
+
+	LeaseDuration time.Duration
+	HTTPClient    *http.Client
+}
+
+type Broker struct {
+	authToken         string
+	registrationToken string
+	leaseDuration     time.Duration
+	webhooks          *webhookSender
+}
+
+func New(opts Options) *Broker {
+	lease := opts.LeaseDuration
+	if lease <= 0 {
+		lease = DefaultLeaseDuration
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &Broker{
+		authToken:         strings.TrimSpace(opts.AuthToken),
+		registrationToken: strings.TrimSpace(opts.RegistrationToken),
+		leaseDuration:     lease,
+		webhooks:          &webhookSender{client: client},
+	}
+}
+
+// Handler serves the broker API. Mount it so that request paths start at
+// /v1, for example behind http.StripPrefix.
+func (b *Broker) Handler() http.Handler {
+	r := mux.NewRouter()
+
+	client := r.NewRoute().Subrouter()
+	client.Use(b.requireClientToken)
+	client.HandleFunc("/v1/tasks", b.createTask).Methods(http.MethodPost)
+	client.HandleFunc("/v1/tasks", b.listActiveTasks).Methods(http.MethodGet)
+	client.HandleFunc("/v1/tasks/{id}", b.describeTask).Methods(http.MethodGet)
+	client.HandleFunc("/v1/tasks/{id}/cancel", b.cancelTask).Methods(http.MethodPost)
+	client.HandleFunc("/v1/fleets", b.listFleets).Methods(http.MethodGet)
+
+	// Live log streams authenticate with a short-lived token minted per task.
+	r.HandleFunc("/v1/tasks/{id}/live-logs", b.streamLiveLogs).Methods(http.MethodGet)
+
+	r.HandleFunc("/v1/runners", b.registerRunner).Methods(http.MethodPost)
+	runners := r.NewRoute().Subrouter()
+	runners.Use(b.requireRunnerToken)
+	runners.HandleFunc("/v1/runners/claim", b.claimTask).Methods(http.MethodPost)
+	runners.HandleFunc("/v1/tasks/{id}/heartbeat", b.heartbeat).Methods(http.MethodPost)
+	runners.HandleFunc("/v1/tasks/{id}/logs", b.appendLogs).Methods(http.MethodPost)
+	runners.HandleFunc("/v1/tasks/{id}/complete", b.completeTask).Methods(http.MethodPost)
+
+	return r
+}
+
+// Start fails tasks whose runner stopped sending heartbeats until ctx is
+// done. Every replica may run it; each expired task is claimed by one.
+func (b *Broker) Start(ctx context.Context) {
+	ticker := time.NewTicker(leaseSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.expireLeases()
+		}
+	}
+}
+
+func (b *Broker) expireLeases() {
+	expired, err := models.ExpireTaskBrokerTaskLeases(time.Now())
+	if err != nil {
+		log.Errorf("task broker: failed to expire task leases: %v", err)
+		return
+	}
+
+	for i := range expired {
+		log.Warnf("task broker: task %s failed after its runner lease expired", expired[i].ID)
+		b.webhooks.deliver(&expired[i])
+	}
+}
+
+func (b *Broker) requireClientToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if b.authToken == "" || !tokenMatches(bearerToken(r), b.authToken) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func bearerToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+func tokenMatches(got, want string) bool {
+	return got != "" && subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, out any) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)).Decode(out); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		log.Errorf("task broker: failed to encode response: %v", err)
+	}
+}
@@ -0,0 +1,248 @@
+package taskbroker
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/taskbroker/protocol"
+)
+
+const (
+	testAuthToken         = "client-token"
+	testRegistrationToken = "registration-token"
+)
+
+func newTestBroker(t *testing.T) http.Handler {
+	t.Helper()
+	require.NoError(t, database.TruncateTables())
+
+	return New(Options{
+		AuthToken:         testAuthToken,
+		RegistrationToken: testRegistrationToken,
+	}).Handler()
+}
+
+func doRequest(t *testing.T, handler http.Handler, method, path, token string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func registerTestRunner(t *testing.T, handler http.Handler, fleetID string) string {
+	t.Helper()
+
+	rec := doRequest(t, handler, http.MethodPost, protocol.RegisterRunnerPath, testRegistrationToken, protocol.RegisterRunnerRequest{
+		FleetID: fleetID,
+		Name:    "runner-1",
+	})
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var response protocol.RegisterRunnerResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.NotEmpty(t, response.Token)
+	return response.Token
+}
+
+func createTestTask(t *testing.T, handler http.Handler, body map[string]any) string {
+	t.Helper()
+
+	rec := doRequest(t, handler, http.MethodPost, "/v1/tasks", testAuthToken, body)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var response createTaskResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return response.ID
+}
+
+func Test__Broker__RequiresTokens(t *testing.T) {
+	handler := newTestBroker(t)
+
+	rec := doRequest(t, handler, http.MethodGet, "/v1/tasks", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = doRequest(t, handler, http.MethodGet, "/v1/tasks", "wrong", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = doRequest(t, handler, http.MethodPost, protocol.RegisterRunnerPath, testAuthToken, protocol.RegisterRunnerRequest{FleetID: "fleet"})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = doRequest(t, handler, http.MethodPost, protocol.ClaimTaskPath, "spr_unknown", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test__Broker__TaskLifecycle(t *testing.T) {
+	handler := newTestBroker(t)
+	runnerToken := registerTestRunner(t, handler, "build-fleet")
+
+	t.Run("runners only claim tasks for their fleet", func(t *testing.T) {
+		createTestTask(t, handler, map[string]any{"fleet_id": "other-fleet", "script": "echo other"})
+
+		rec := doRequest(t, handler, http.MethodPost, protocol.ClaimTaskPath, runnerToken, protocol.ClaimTaskRequest{})
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("claim, heartbeat, logs and complete", func(t *testing.T) {
+		taskID := createTestTask(t, handler, map[string]any{
+			"fleet_id":      "build-fleet",
+			"run_mode":      protocol.RunModeBash,
+			"script":        "echo hello",
+			"message_chain": map[string]any{"root": map[string]any{"value": 1}},
+		})
+
+		rec := doRequest(t, handler, http.MethodPost, protocol.ClaimTaskPath, runnerToken, protocol.ClaimTaskRequest{})
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var claimed protocol.ClaimedTask
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &claimed))
+		assert.Equal(t, taskID, claimed.ID)
+		assert.Equal(t, "echo hello", claimed.Spec.Script)
+		assert.Equal(t, protocol.ExecutionModeHost, claimed.Spec.ExecutionMode)
+		assert.Equal(t, defaultExecutionTimeoutSeconds, claimed.Spec.ExecutionTimeoutSeconds)
+		assert.JSONEq(t, `{"root":{"value":1}}`, string(claimed.Spec.MessageChain))
+
+		rec = doRequest(t, handler, http.MethodPost, protocol.HeartbeatPath(taskID), runnerToken, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		rec = doRequest(t, handler, http.MethodPost, protocol.LogsPath(taskID), runnerToken, protocol.AppendLogsRequest{
+			Records: []protocol.LogRecord{{Type: protocol.LogRecordLine, Text: "hello"}},
+		})
+		require.Equal(t, http.StatusNoContent, rec.Code)
+
+		exitCode := 0
+		rec = doRequest(t, handler, http.MethodPost, protocol.CompletePath(taskID), runnerToken, protocol.CompleteTaskRequest{
+			Status:   protocol.StatusSucceeded,
+			ExitCode: &exitCode,
+			Output:   "hello\n",
+			Result:   json.RawMessage(`{"ok":true}`),
+		})
+		require.Equal(t, http.StatusNoContent, rec.Code)
+
+		rec = doRequest(t, handler, http.MethodGet, "/v1/tasks/"+taskID, testAuthToken, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var task taskResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &task))
+		assert.Equal(t, models.TaskBrokerTaskStatusSucceeded, task.Status)
+		assert.Equal(t, "hello\n", task.Output)
+		assert.JSONEq(t, `{"ok":true}`, string(task.Result))
+
+		// A finished task no longer accepts runner updates.
+		rec = doRequest(t, handler, http.MethodPost, protocol.HeartbeatPath(taskID), runnerToken, nil)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("fleets report online runners", func(t *testing.T) {
+		rec := doRequest(t, handler, http.MethodGet, "/v1/fleets", testAuthToken, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"fleets":[{"id":"build-fleet","runners":1,"online_runners":1}]}`, rec.Body.String())
+	})
+}
+
+func Test__Broker__Cancel(t *testing.T) {
+	handler := newTestBroker(t)
+	runnerToken := registerTestRunner(t, handler, "build-fleet")
+
+	t.Run("queued tasks are canceled immediately", func(t *testing.T) {
+		taskID := createTestTask(t, handler, map[string]any{"fleet_id": "build-fleet", "script": "echo"})
+
+		rec := doRequest(t, handler, http.MethodPost, "/v1/tasks/"+taskID+"/cancel", testAuthToken, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		task, err := models.FindTaskBrokerTask(mustParseUUID(t, taskID))
+		require.NoError(t, err)
+		assert.Equal(t, models.TaskBrokerTaskStatusCanceled, task.Status)
+	})
+
+	t.Run("running tasks are canceled through the heartbeat", func(t *testing.T) {
+		taskID := createTestTask(t, handler, map[string]any{"fleet_id": "build-fleet", "script": "sleep 60"})
+
+		rec := doRequest(t, handler, http.MethodPost, protocol.ClaimTaskPath, runnerToken, protocol.ClaimTaskRequest{})
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		rec = doRequest(t, handler, http.MethodPost, "/v1/tasks/"+taskID+"/cancel", testAuthToken, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		rec = doRequest(t, handler, http.MethodPost, protocol.HeartbeatPath(taskID), runnerToken, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var heartbeat protocol.HeartbeatResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &heartbeat))
+		assert.True(t, heartbeat.CancelRequested)
+	})
+
+	t.Run("unknown task returns 404", func(t *testing.T) {
+		rec := doRequest(t, handler, http.MethodPost, "/v1/tasks/6c3f2a53-8f0c-4a8e-9a43-1a2b3c4d5e6f/cancel", testAuthToken, nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func Test__Broker__ExpiredLeasesFailTasks(t *testing.T) {
+	handler := newTestBroker(t)
+	runnerToken := registerTestRunner(t, handler, "build-fleet")
+	taskID := createTestTask(t, handler, map[string]any{"fleet_id": "build-fleet", "script": "echo"})
+
+	rec := doRequest(t, handler, http.MethodPost, protocol.ClaimTaskPath, runnerToken, protocol.ClaimTaskRequest{})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	expired, err := models.ExpireTaskBrokerTaskLeases(time.Now().Add(2 * DefaultLeaseDuration))
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+
+	task, err := models.FindTaskBrokerTask(mustParseUUID(t, taskID))
+	require.NoError(t, err)
+	assert.Equal(t, models.TaskBrokerTaskStatusFailed, task.Status)
+
+	exitCode := 0
+	rec = doRequest(t, handler, http.MethodPost, protocol.CompletePath(taskID), runnerToken, protocol.CompleteTaskRequest{
+		Status:   protocol.StatusSucceeded,
+		ExitCode: &exitCode,
+	})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func Test__Broker__CreateTaskValidation(t *testing.T) {
+	handler := newTestBroker(t)
+
+	for name, body := range map[string]map[string]any{
+		"missing fleet":          {"script": "echo"},
+		"missing script":         {"fleet_id": "fleet"},
+		"docker without image":   {"fleet_id": "fleet", "script": "echo", "execution_mode": "docker"},
+		"unknown execution mode": {"fleet_id": "fleet", "script": "echo", "execution_mode": "vm"},
+		"timeout too long":       {"fleet_id": "fleet", "script": "echo", "execution_timeout_seconds": maxExecutionTimeoutSeconds + 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := doRequest(t, handler, http.MethodPost, "/v1/tasks", testAuthToken, body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func mustParseUUID(t *testing.T, id string) uuid.UUID {
+	t.Helper()
+	parsed, err := uuid.Parse(id)
+	require.NoError(t, err)
+	return parsed
+}
@@ -0,0 +1,88 @@
+package taskbroker
+
+import (
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/components/runner"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	liveLogPollInterval = 500 * time.Millisecond
+	liveLogPageSize     = 500
+)
+
+// streamLiveLogs replays a task's log records as NDJSON and follows new
+// ones until the task finishes. Browsers authenticate with the stream
+// token minted by the live log session endpoint.
+func (b *Broker) streamLiveLogs(w http.ResponseWriter, r *http.Request) {
+	id, ok := taskIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if b.authToken == "" || runner.ValidateLiveLogStreamToken(bearerToken(r), id.String(), b.authToken) != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	task, err := models.FindTaskBrokerTask(id)
+	if err != nil {
+		respondRunnerTaskError(w, id, err)
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	var lastID int64
+	finished := task.IsFinished()
+	for {
+		logs, err := models.ListTaskBrokerTaskLogs(id, lastID, liveLogPageSize)
+		if err != nil {
+			log.Errorf("task broker: failed to read logs for task %s: %v", id, err)
+			return
+		}
+
+		for _, record := range logs {
+			lastID = record.ID
+			if _, err := w.Write(append([]byte(record.Record), '\n')); err != nil {
+				return
+			}
+		}
+		if len(logs) > 0 && flusher != nil {
+			flusher.Flush()
+		}
+
+		if len(logs) == liveLogPageSize {
+			continue
+		}
+
+		// Logs written before the task finished are all visible once the
+		// finished status is, so one more read after it drains the stream.
+		if finished {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(liveLogPollInterval):
+		}
+
+		task, err = models.FindTaskBrokerTask(id)
+		if err != nil {
+			log.Errorf("task broker: failed to read task %s: %v", id, err)
+			return
+		}
+		finished = task.IsFinished()
+	}
+}
@@ -0,0 +1,165 @@
+// Package protocol defines the JSON messages exchanged between the embedded
+// task broker and self-hosted runners. It has no server dependencies so the
+// runner agent can use it without pulling in the database layer.
+package protocol
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Runner endpoints, relative to the broker base URL.
+const (
+	RegisterRunnerPath = "/v1/runners"
+	ClaimTaskPath      = "/v1/runners/claim"
+)
+
+func HeartbeatPath(taskID string) string { return "/v1/tasks/" + taskID + "/heartbeat" }
+func LogsPath(taskID string) string      { return "/v1/tasks/" + taskID + "/logs" }
+func CompletePath(taskID string) string  { return "/v1/tasks/" + taskID + "/complete" }
+
+const (
+	RunModeJavaScript = "javascript_script"
+	RunModePython     = "python_script"
+	RunModeBash       = "bash_script"
+
+	ExecutionModeHost   = "host"
+	ExecutionModeDocker = "docker"
+
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusCanceled  = "canceled"
+)
+
+// Live log record types understood by the live log viewer.
+const (
+	LogRecordLine     = "line"
+	LogRecordError    = "error"
+	LogRecordCmdStart = "cmd_start"
+	LogRecordCmdEnd   = "cmd_end"
+)
+
+// RegisterRunnerRequest is authenticated with the broker's runner
+// registration token.
+type RegisterRunnerRequest struct {
+	FleetID string `json:"fleet_id"`
+	Name    string `json:"name"`
+}
+
+// RegisterRunnerResponse carries the token the runner uses for every other
+// call. It is only returned once.
+type RegisterRunnerResponse struct {
+	RunnerID string `json:"runner_id"`
+	Token    string `json:"token"`
+}
+
+type ClaimTaskRequest struct {
+	// WaitSeconds holds the request open until a task is queued, up to the
+	// broker's limit.
+	WaitSeconds int `json:"wait_seconds,omitempty"`
+}
+
+// ClaimedTask is returned by a successful claim. The runner must send a
+// heartbeat before the lease runs out or the broker fails the task.
+type ClaimedTask struct {
+	ID                       string   `json:"id"`
+	LeaseSeconds             int      `json:"lease_seconds"`
+	HeartbeatIntervalSeconds int      `json:"heartbeat_interval_seconds"`
+	Spec                     TaskSpec `json:"spec"`
+}
+
+// TaskSpec is the part of a POST /v1/tasks request a runner needs to
+// execute the task.
+type TaskSpec struct {
+	RunMode                 string                `json:"run_mode,omitempty"`
+	Script                  string                `json:"script,omitempty"`
+	MessageChain            json.RawMessage       `json:"message_chain,omitempty"`
+	Commands                []Command             `json:"commands,omitempty"`
+	SetupCommands           []string              `json:"setup_commands,omitempty"`
+	Environment             []EnvironmentVariable `json:"environment,omitempty"`
+	Files                   []TaskFile            `json:"files,omitempty"`
+	ExecutionMode           string                `json:"execution_mode,omitempty"`
+	DockerImage             string                `json:"docker_image,omitempty"`
+	ExecutionTimeoutSeconds int                   `json:"execution_timeout_seconds,omitempty"`
+	Labels                  map[string]string     `json:"labels,omitempty"`
+	Storage                 *TaskStorage          `json:"storage,omitempty"`
+}
+
+type Command struct {
+	Name    string `json:"name,omitempty"`
+	Command string `json:"command"`
+}
+
+type EnvironmentVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type TaskFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	Mode    string `json:"mode,omitempty"`
+}
+
+// TaskStorage points the runner at the SuperPlane runner storage API.
+// Artifacts and caches are uploaded as gzipped tarballs of the files
+// matched by the paths, relative to the task directory.
+type TaskStorage struct {
+	APIURL            string         `json:"api_url"`
+	Token             string         `json:"token"`
+	UploadArtifacts   []TaskArtifact `json:"upload_artifacts,omitempty"`
+	DownloadArtifacts bool           `json:"download_artifacts,omitempty"`
+	Cache             *TaskCache     `json:"cache,omitempty"`
+}
+
+type TaskArtifact struct {
+	Name  string   `json:"name"`
+	Paths []string `json:"paths"`
+}
+
+type TaskCache struct {
+	Key   string   `json:"key"`
+	Paths []string `json:"paths"`
+}
+
+// StoredArtifact is one entry of the runner storage artifact listing.
+type StoredArtifact struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	NodeID    string `json:"node_id"`
+	SizeBytes int64  `json:"size_bytes"`
+	SHA256    string `json:"sha256"`
+}
+
+type ListArtifactsResponse struct {
+	Artifacts []StoredArtifact `json:"artifacts"`
+}
+
+type HeartbeatResponse struct {
+	CancelRequested bool      `json:"cancel_requested"`
+	LeaseUntil      time.Time `json:"lease_until"`
+}
+
+// LogRecord is one live log entry. Runners send them in order; the broker
+// replays them to live log streams as NDJSON.
+type LogRecord struct {
+	Type       string `json:"type"`
+	Text       string `json:"text,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Index      *int   `json:"index,omitempty"`
+	Status     string `json:"status,omitempty"`
+	DurationMS *int64 `json:"duration_ms,omitempty"`
+	StartedAt  *int64 `json:"started_at,omitempty"`
+}
+
+type AppendLogsRequest struct {
+	Records []LogRecord `json:"records"`
+}
+
+type CompleteTaskRequest struct {
+	Status   string          `json:"status"`
+	ExitCode *int            `json:"exit_code,omitempty"`
+	Output   string          `json:"output,omitempty"`
+	Error    string          `json:"error,omitempty"`
+	Result   json.RawMessage `json:"result,omitempty"`
+}
@@ -0,0 +1,292 @@
+package taskbroker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/taskbroker/protocol"
+	"gorm.io/gorm"
+)
+
+const (
+	runnerTokenPrefix = "spr_"
+	maxLogRecords     = 1000
+)
+
+type runnerContextKey struct{}
+
+func (b *Broker) registerRunner(w http.ResponseWriter, r *http.Request) {
+	if b.registrationToken == "" || !tokenMatches(bearerToken(r), b.registrationToken) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req protocol.RegisterRunnerRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	fleetID := strings.TrimSpace(req.FleetID)
+	if fleetID == "" {
+		http.Error(w, "fleet_id is required", http.StatusBadRequest)
+		return
+	}
+
+	token, err := newRunnerToken()
+	if err != nil {
+		log.Errorf("task broker: failed to generate runner token: %v", err)
+		http.Error(w, "Failed to register runner", http.StatusInternalServerError)
+		return
+	}
+
+	runner, err := models.CreateTaskBrokerRunner(fleetID, strings.TrimSpace(req.Name), crypto.HashToken(token))
+	if err != nil {
+		log.Errorf("task broker: failed to register runner: %v", err)
+		http.Error(w, "Failed to register runner", http.StatusInternalServerError)
+		return
+	}
+
+	log.Infof("task broker: registered runner %s (%s) for fleet %s", runner.ID, runner.Name, runner.FleetID)
+	writeJSON(w, http.StatusCreated, protocol.RegisterRunnerResponse{
+		RunnerID: runner.ID.String(),
+		Token:    token,
+	})
+}
+
+func newRunnerToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return runnerTokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func (b *Broker) requireRunnerToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := bearerToken(r)
+		if token == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		runner, err := models.FindTaskBrokerRunnerByTokenHash(crypto.HashToken(token))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			log.Errorf("task broker: failed to find runner: %v", err)
+			http.Error(w, "Internal error", http.StatusInternalServerError)
+			return
+		}
+
+		if err := models.TouchTaskBrokerRunner(runner.ID); err != nil {
+			log.Warnf("task broker: failed to update last seen for runner %s: %v", runner.ID, err)
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), runnerContextKey{}, runner)))
+	})
+}
+
+func runnerFromContext(ctx context.Context) *models.TaskBrokerRunner {
+	runner, _ := ctx.Value(runnerContextKey{}).(*models.TaskBrokerRunner)
+	return runner
+}
+
+// claimTask hands the oldest queued task of the runner's fleet to the
+// runner. With wait_seconds set, the request is held open until a task is
+// queued, so idle runners don't have to poll aggressively.
+func (b *Broker) claimTask(w http.ResponseWriter, r *http.Request) {
+	runner := runnerFromContext(r.Context())
+
+	var req protocol.ClaimTaskRequest
+	if r.ContentLength != 0 && !decodeJSON(w, r, &req) {
+		return
+	}
+
+	wait := min(time.Duration(max(req.WaitSeconds, 0))*time.Second, maxClaimWait)
+	deadline := time.Now().Add(wait)
+
+	for {
+		task, err := models.ClaimTaskBrokerTask(runner.ID, runner.FleetID, b.leaseDuration)
+		if err != nil {
+			log.Errorf("task broker: runner %s failed to claim a task: %v", runner.ID, err)
+			http.Error(w, "Failed to claim task", http.StatusInternalServerError)
+			return
+		}
+
+		if task != nil {
+			b.respondClaimedTask(w, task)
+			return
+		}
+
+		if !time.Now().Before(deadline) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(claimPollInterval):
+		}
+	}
+}
+
+func (b *Broker) respondClaimedTask(w http.ResponseWriter, task *models.TaskBrokerTask) {
+	var spec protocol.TaskSpec
+	if err := json.Unmarshal(task.Spec, &spec); err != nil {
+		log.Errorf("task broker: task %s has an invalid spec: %v", task.ID, err)
+		http.Error(w, "Invalid task spec", http.StatusInternalServerError)
+		return
+	}
+
+	lease := int(b.leaseDuration / time.Second)
+	writeJSON(w, http.StatusOK, protocol.ClaimedTask{
+		ID:                       task.ID.String(),
+		LeaseSeconds:             lease,
+		HeartbeatIntervalSeconds: max(lease/3, 1),
+		Spec:                     spec,
+	})
+}
+
+func (b *Broker) heartbeat(w http.ResponseWriter, r *http.Request) {
+	runner := runnerFromContext(r.Context())
+	id, ok := taskIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	task, err := models.RenewTaskBrokerTaskLease(id, runner.ID, b.leaseDuration)
+	if err != nil {
+		respondRunnerTaskError(w, id, err)
+		return
+	}
+
+	response := protocol.HeartbeatResponse{CancelRequested: task.CancelRequested}
+	if task.LeaseUntil != nil {
+		response.LeaseUntil = *task.LeaseUntil
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (b *Broker) appendLogs(w http.ResponseWriter, r *http.Request) {
+	runner := runnerFromContext(r.Context())
+	id, ok := taskIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req protocol.AppendLogsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if len(req.Records) > maxLogRecords {
+		http.Error(w, "Too many log records", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	task, err := models.FindTaskBrokerTask(id)
+	if err != nil {
+		respondRunnerTaskError(w, id, err)
+		return
+	}
+
+	if task.Status != models.TaskBrokerTaskStatusRunning || task.RunnerID == nil || *task.RunnerID != runner.ID {
+		respondRunnerTaskError(w, id, models.ErrTaskBrokerLeaseLost)
+		return
+	}
+
+	records := make([][]byte, 0, len(req.Records))
+	for _, record := range req.Records {
+		encoded, err := json.Marshal(record)
+		if err != nil {
+			http.Error(w, "Invalid log record", http.StatusBadRequest)
+			return
+		}
+		records = append(records, encoded)
+	}
+
+	if err := models.AppendTaskBrokerTaskLogs(id, records); err != nil {
+		log.Errorf("task broker: failed to store logs for task %s: %v", id, err)
+		http.Error(w, "Failed to store logs", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Broker) completeTask(w http.ResponseWriter, r *http.Request) {
+	runner := runnerFromContext(r.Context())
+	id, ok := taskIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req protocol.CompleteTaskRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	switch req.Status {
+	case protocol.StatusSucceeded, protocol.StatusFailed, protocol.StatusCanceled:
+	default:
+		http.Error(w, "Invalid status", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Result) > 0 && !json.Valid(req.Result) {
+		http.Error(w, "Invalid result", http.StatusBadRequest)
+		return
+	}
+
+	task, err := models.FinishTaskBrokerTask(id, runner.ID, models.TaskBrokerTaskOutcome{
+		Status:   req.Status,
+		ExitCode: req.ExitCode,
+		Output:   req.Output,
+		Error:    req.Error,
+		Result:   req.Result,
+	})
+	if err != nil {
+		respondRunnerTaskError(w, id, err)
+		return
+	}
+
+	b.webhooks.deliver(task)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func taskIDFromPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Task not found", http.StatusNotFound)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondRunnerTaskError tells the runner to stop working on a task it no
+// longer holds with 409, so it can abort instead of retrying.
+func respondRunnerTaskError(w http.ResponseWriter, id uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, models.ErrTaskBrokerTaskNotFound):
+		http.Error(w, "Task not found", http.StatusNotFound)
+	case errors.Is(err, models.ErrTaskBrokerLeaseLost):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		log.Errorf("task broker: failed to update task %s: %v", id, err)
+		http.Error(w, "Internal error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,267 @@
+package taskbroker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/taskbroker/protocol"
+	"gorm.io/datatypes"
+)
+
+const (
+	defaultExecutionTimeoutSeconds = 3600
+	maxExecutionTimeoutSeconds     = 86400
+)
+
+type createTaskRequest struct {
+	FleetID    string `json:"fleet_id"`
+	WebhookURL string `json:"webhook_url"`
+	protocol.TaskSpec
+}
+
+type createTaskResponse struct {
+	ID string `json:"id"`
+}
+
+// taskResponse is returned by GET /v1/tasks/{id} and posted to the task
+// webhook when the task finishes.
+type taskResponse struct {
+	ID         string          `json:"id"`
+	TaskID     string          `json:"task_id"`
+	FleetID    string          `json:"fleet_id"`
+	Status     string          `json:"status"`
+	ExitCode   *int            `json:"exit_code,omitempty"`
+	Output     string          `json:"output,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	CreatedAt  *time.Time      `json:"created_at,omitempty"`
+	ClaimedAt  *time.Time      `json:"claimed_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+}
+
+type activeTaskResponse struct {
+	ID                      string     `json:"id"`
+	Status                  string     `json:"status"`
+	FleetID                 string     `json:"fleet_id"`
+	CreatedAt               *time.Time `json:"created_at"`
+	ClaimedAt               *time.Time `json:"claimed_at,omitempty"`
+	LeaseUntil              *time.Time `json:"lease_until,omitempty"`
+	RunnerID                string     `json:"runner_id,omitempty"`
+	ExecutionMode           string     `json:"execution_mode,omitempty"`
+	DockerImage             string     `json:"docker_image,omitempty"`
+	CancelRequested         bool       `json:"cancel_requested,omitempty"`
+	ExecutionTimeoutSeconds *int       `json:"execution_timeout_seconds,omitempty"`
+}
+
+type fleetResponse struct {
+	ID            string `json:"id"`
+	Runners       int64  `json:"runners"`
+	OnlineRunners int64  `json:"online_runners"`
+}
+
+func (b *Broker) createTask(w http.ResponseWriter, r *http.Request) {
+	var req createTaskRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := normalizeTaskRequest(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	spec, err := json.Marshal(req.TaskSpec)
+	if err != nil {
+		http.Error(w, "Invalid task spec", http.StatusBadRequest)
+		return
+	}
+
+	task := &models.TaskBrokerTask{
+		FleetID:                 req.FleetID,
+		Spec:                    datatypes.JSON(spec),
+		ExecutionMode:           req.ExecutionMode,
+		DockerImage:             req.DockerImage,
+		ExecutionTimeoutSeconds: req.ExecutionTimeoutSeconds,
+		WebhookURL:              req.WebhookURL,
+	}
+	if err := models.CreateTaskBrokerTask(task); err != nil {
+		log.Errorf("task broker: failed to create task: %v", err)
+		http.Error(w, "Failed to create task", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, createTaskResponse{ID: task.ID.String()})
+}
+
+func normalizeTaskRequest(req *createTaskRequest) error {
+	req.FleetID = strings.TrimSpace(req.FleetID)
+	if req.FleetID == "" {
+		return errors.New("fleet_id is required")
+	}
+
+	if strings.TrimSpace(req.Script) == "" && len(req.Commands) == 0 {
+		return errors.New("a script or at least one command is required")
+	}
+
+	switch req.RunMode {
+	case "", protocol.RunModeBash, protocol.RunModePython, protocol.RunModeJavaScript:
+	default:
+		return fmt.Errorf("unsupported run_mode %q", req.RunMode)
+	}
+
+	req.ExecutionMode = strings.ToLower(strings.TrimSpace(req.ExecutionMode))
+	switch req.ExecutionMode {
+	case "":
+		req.ExecutionMode = protocol.ExecutionModeHost
+	case protocol.ExecutionModeHost:
+	case protocol.ExecutionModeDocker:
+		if strings.TrimSpace(req.DockerImage) == "" {
+			return errors.New("docker_image is required in docker execution mode")
+		}
+	default:
+		return fmt.Errorf("unsupported execution_mode %q", req.ExecutionMode)
+	}
+
+	if req.ExecutionTimeoutSeconds <= 0 {
+		req.ExecutionTimeoutSeconds = defaultExecutionTimeoutSeconds
+	}
+	if req.ExecutionTimeoutSeconds > maxExecutionTimeoutSeconds {
+		return fmt.Errorf("execution_timeout_seconds must be at most %d", maxExecutionTimeoutSeconds)
+	}
+
+	return nil
+}
+
+func (b *Broker) listActiveTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := models.ListActiveTaskBrokerTasks()
+	if err != nil {
+		log.Errorf("task broker: failed to list tasks: %v", err)
+		http.Error(w, "Failed to list tasks", http.StatusInternalServerError)
+		return
+	}
+
+	out := make([]activeTaskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		timeout := task.ExecutionTimeoutSeconds
+		active := activeTaskResponse{
+			ID:                      task.ID.String(),
+			Status:                  task.Status,
+			FleetID:                 task.FleetID,
+			CreatedAt:               task.CreatedAt,
+			ClaimedAt:               task.ClaimedAt,
+			LeaseUntil:              task.LeaseUntil,
+			ExecutionMode:           task.ExecutionMode,
+			DockerImage:             task.DockerImage,
+			CancelRequested:         task.CancelRequested,
+			ExecutionTimeoutSeconds: &timeout,
+		}
+		if task.RunnerID != nil {
+			active.RunnerID = task.RunnerID.String()
+		}
+		out = append(out, active)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"tasks": out})
+}
+
+func (b *Broker) describeTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := findTask(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskResponse(task))
+}
+
+func (b *Broker) cancelTask(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Task not found", http.StatusNotFound)
+		return
+	}
+
+	task, finished, err := models.RequestTaskBrokerTaskCancel(id)
+	if err != nil {
+		if errors.Is(err, models.ErrTaskBrokerTaskNotFound) {
+			http.Error(w, "Task not found", http.StatusNotFound)
+			return
+		}
+		log.Errorf("task broker: failed to cancel task %s: %v", id, err)
+		http.Error(w, "Failed to cancel task", http.StatusInternalServerError)
+		return
+	}
+
+	if finished {
+		b.webhooks.deliver(task)
+	}
+
+	writeJSON(w, http.StatusOK, newTaskResponse(task))
+}
+
+func (b *Broker) listFleets(w http.ResponseWriter, r *http.Request) {
+	fleets, err := models.ListTaskBrokerFleets(time.Now().Add(-runnerOnlineWindow))
+	if err != nil {
+		log.Errorf("task broker: failed to list fleets: %v", err)
+		http.Error(w, "Failed to list fleets", http.StatusInternalServerError)
+		return
+	}
+
+	out := make([]fleetResponse, 0, len(fleets))
+	for _, fleet := range fleets {
+		out = append(out, fleetResponse{
+			ID:            fleet.FleetID,
+			Runners:       fleet.Runners,
+			OnlineRunners: fleet.OnlineRunners,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"fleets": out})
+}
+
+func findTask(w http.ResponseWriter, r *http.Request) (*models.TaskBrokerTask, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Task not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	task, err := models.FindTaskBrokerTask(id)
+	if err != nil {
+		if errors.Is(err, models.ErrTaskBrokerTaskNotFound) {
+			http.Error(w, "Task not found", http.StatusNotFound)
+			return nil, false
+		}
+		log.Errorf("task broker: failed to find task %s: %v", id, err)
+		http.Error(w, "Failed to find task", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return task, true
+}
+
+func newTaskResponse(task *models.TaskBrokerTask) taskResponse {
+	response := taskResponse{
+		ID:         task.ID.String(),
+		TaskID:     task.ID.String(),
+		FleetID:    task.FleetID,
+		Status:     task.Status,
+		ExitCode:   task.ExitCode,
+		Output:     task.Output,
+		Error:      task.Error,
+		CreatedAt:  task.CreatedAt,
+		ClaimedAt:  task.ClaimedAt,
+		FinishedAt: task.FinishedAt,
+	}
+	if len(task.Result) > 0 {
+		response.Result = json.RawMessage(task.Result)
+	}
+	return response
+}
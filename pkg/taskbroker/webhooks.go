@@ -0,0 +1,106 @@
+package taskbroker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	webhookMaxAttempts       = 3
+	webhookRetryBackoff      = 2 * time.Second
+	defaultWebhookSizeLimit  = 512 * 1024
+	webhookOutputTruncateMsg = "[output truncated]\n"
+)
+
+type webhookSender struct {
+	client *http.Client
+	// payloadSizeLimit caps the encoded payload; 0 uses the default.
+	payloadSizeLimit int
+}
+
+// deliver posts the finished task to its webhook in the background. The
+// runner components also poll task status, so a lost webhook only delays
+// the result.
+func (s *webhookSender) deliver(task *models.TaskBrokerTask) {
+	if task.WebhookURL == "" {
+		return
+	}
+
+	payload, err := s.payload(task)
+	if err != nil {
+		log.Errorf("task broker: failed to encode webhook for task %s: %v", task.ID, err)
+		return
+	}
+
+	go func(url string, taskID string) {
+		for attempt := range webhookMaxAttempts {
+			if attempt > 0 {
+				time.Sleep(webhookRetryBackoff * time.Duration(attempt))
+			}
+
+			err := s.post(url, payload)
+			if err == nil {
+				return
+			}
+			log.Warnf("task broker: webhook for task %s failed (attempt %d): %v", taskID, attempt+1, err)
+		}
+	}(task.WebhookURL, task.ID.String())
+}
+
+// payload trims the task output so the encoded body stays within the size
+// limit, keeping the end of the output where failures usually show up.
+func (s *webhookSender) payload(task *models.TaskBrokerTask) ([]byte, error) {
+	limit := s.payloadSizeLimit
+	if limit <= 0 {
+		limit = defaultWebhookSizeLimit
+	}
+
+	response := newTaskResponse(task)
+	body, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+
+	// Output is escaped when encoded, so trim until the payload fits.
+	output := response.Output
+	for len(body) > limit && output != "" {
+		cut := min(len(body)-limit+len(webhookOutputTruncateMsg), len(output))
+		output = output[cut:]
+		response.Output = webhookOutputTruncateMsg + output
+		if output == "" {
+			response.Output = ""
+		}
+
+		body, err = json.Marshal(response)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return body, nil
+}
+
+func (s *webhookSender) post(url string, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
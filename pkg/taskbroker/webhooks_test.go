@@ -0,0 +1,43 @@
+package taskbroker
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+func Test__WebhookPayload__TruncatesOutputFromTheStart(t *testing.T) {
+	sender := &webhookSender{payloadSizeLimit: 1024}
+	task := &models.TaskBrokerTask{
+		ID:     uuid.New(),
+		Status: models.TaskBrokerTaskStatusFailed,
+		Output: strings.Repeat("a", 2000) + "the last line",
+	}
+
+	payload, err := sender.payload(task)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(payload), 1024)
+
+	var response taskResponse
+	require.NoError(t, json.Unmarshal(payload, &response))
+	assert.True(t, strings.HasPrefix(response.Output, webhookOutputTruncateMsg))
+	assert.True(t, strings.HasSuffix(response.Output, "the last line"))
+	assert.Equal(t, task.ID.String(), response.TaskID)
+}
+
+func Test__WebhookPayload__KeepsSmallPayloads(t *testing.T) {
+	sender := &webhookSender{}
+	task := &models.TaskBrokerTask{ID: uuid.New(), Status: models.TaskBrokerTaskStatusSucceeded, Output: "ok\n"}
+
+	payload, err := sender.payload(task)
+	require.NoError(t, err)
+
+	var response taskResponse
+	require.NoError(t, json.Unmarshal(payload, &response))
+	assert.Equal(t, "ok\n", response.Output)
+}
@@ -535,6 +535,8 @@ func StartPeriodicMetricsReporter() {
 }
 
 func RecordQueueWorkerTickDuration(ctx context.Context, d time.Duration) {
+	recordPrometheusWorkerTick("queue_worker", d)
+
 	if !metricsReady.Load() {
 		return
 	}
@@ -571,6 +573,8 @@ func RecordQueueWorkerNodeProcessing(ctx context.Context, d time.Duration, outco
 }
 
 func RecordExecutorWorkerTickDuration(ctx context.Context, d time.Duration) {
+	recordPrometheusWorkerTick("executor_worker", d)
+
 	if !metricsReady.Load() {
 		return
 	}
@@ -609,6 +613,8 @@ func RecordExecutorWorkerExecution(ctx context.Context, d time.Duration, outcome
 }
 
 func RecordEventWorkerTickDuration(ctx context.Context, d time.Duration) {
+	recordPrometheusWorkerTick("event_worker", d)
+
 	if !metricsReady.Load() {
 		return
 	}
@@ -645,6 +651,8 @@ func RecordEventWorkerEventProcessing(ctx context.Context, d time.Duration, outc
 }
 
 func RecordNodeRequestWorkerTickDuration(ctx context.Context, d time.Duration) {
+	recordPrometheusWorkerTick("node_request_worker", d)
+
 	if !metricsReady.Load() {
 		return
 	}
@@ -661,6 +669,8 @@ func RecordNodeRequestWorkerRequestsCount(ctx context.Context, count int) {
 }
 
 func RecordWebhookProvisionerWorkerTickDuration(ctx context.Context, d time.Duration) {
+	recordPrometheusWorkerTick("webhook_provisioner_worker", d)
+
 	if !metricsReady.Load() {
 		return
 	}
@@ -699,6 +709,8 @@ func RecordWebhookProvisionerWorkerWebhookProcessing(ctx context.Context, d time
 }
 
 func RecordWebhookCleanupWorkerTickDuration(ctx context.Context, d time.Duration) {
+	recordPrometheusWorkerTick("webhook_cleanup_worker", d)
+
 	if !metricsReady.Load() {
 		return
 	}
@@ -735,6 +747,8 @@ func RecordWebhookCleanupWorkerWebhookProcessing(ctx context.Context, d time.Dur
 }
 
 func RecordWorkflowCleanupWorkerTickDuration(ctx context.Context, d time.Duration) {
+	recordPrometheusWorkerTick("workflow_cleanup_worker", d)
+
 	if !metricsReady.Load() {
 		return
 	}
@@ -751,6 +765,8 @@ func RecordWorkflowCleanupWorkerCanvasesCount(ctx context.Context, count int) {
 }
 
 func RecordRunFinalizerTickDuration(ctx context.Context, d time.Duration) {
+	recordPrometheusWorkerTick("run_finalizer", d)
+
 	if !metricsReady.Load() {
 		return
 	}
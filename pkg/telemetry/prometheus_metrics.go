@@ -0,0 +1,366 @@
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/database"
+	"go.opentelemetry.io/otel/attribute"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// Result values for webhook deliveries.
+const (
+	WebhookDeliveryResultAccepted = "accepted"
+	WebhookDeliveryResultRejected = "rejected"
+)
+
+// Buckets for workflow durations: executions take from milliseconds to
+// an hour, and approvals from minutes to days.
+var (
+	executionDurationSecondsBuckets = []float64{
+		0.1, 0.5, 1, 5, 15, 30, 60, 300, 900, 1800, 3600,
+	}
+
+	approvalWaitSecondsBuckets = []float64{
+		60, 300, 900, 1800, 3600, 4 * 3600, 12 * 3600, 24 * 3600, 3 * 24 * 3600, 7 * 24 * 3600,
+	}
+)
+
+//
+// The scrape endpoint has its own meter provider and registry, so it
+// works without OTEL_ENABLED and without pushing these high-cardinality
+// metrics to the OpenTelemetry collector.
+//
+
+var (
+	prometheusMetricsReady atomic.Bool
+	prometheusInitOnce     sync.Once
+	prometheusInitErr      error
+	prometheusRegistry     = prometheus.NewRegistry()
+
+	// Gauges are read from the database by a background loop,
+	// so scrapes only report the latest snapshot.
+	prometheusGauges atomic.Pointer[prometheusGaugeSnapshot]
+
+	prometheusWorkerTickDuration metric.Float64Histogram
+	prometheusRunsStarted        metric.Int64Counter
+	prometheusRunsFinished       metric.Int64Counter
+	prometheusExecutionDuration  metric.Float64Histogram
+	prometheusApprovalWait       metric.Float64Histogram
+	prometheusWebhookDeliveries  metric.Int64Counter
+	prometheusWorkOrderSteps     metric.Int64Counter
+)
+
+type prometheusGaugeSnapshot struct {
+	pool        *sql.DBStats
+	queueDepths []nodeQueueDepth
+}
+
+// InitPrometheusMetrics starts recording metrics for the /metrics
+// scrape endpoint. Until it is called, every recorder is a no-op.
+func InitPrometheusMetrics() error {
+	if err := initPrometheusInstruments(); err != nil {
+		return err
+	}
+
+	if prometheusMetricsReady.Swap(true) {
+		return nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			refreshPrometheusGauges()
+			<-ticker.C
+		}
+	}()
+
+	return nil
+}
+
+func initPrometheusInstruments() error {
+	prometheusInitOnce.Do(func() {
+		prometheusInitErr = newPrometheusInstruments()
+	})
+
+	return prometheusInitErr
+}
+
+func newPrometheusInstruments() error {
+	exporter, err := otelprometheus.New(
+		otelprometheus.WithRegisterer(prometheusRegistry),
+		otelprometheus.WithoutScopeInfo(),
+		otelprometheus.WithoutTargetInfo(),
+	)
+	if err != nil {
+		return err
+	}
+
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter)).Meter("superplane")
+
+	prometheusWorkerTickDuration, err = meter.Float64Histogram(
+		"superplane.worker.tick.duration",
+		metric.WithDescription("Duration of each background worker tick"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationSecondsHistogramBoundaries...),
+	)
+	if err != nil {
+		return err
+	}
+
+	prometheusRunsStarted, err = meter.Int64Counter(
+		"superplane.workflow.runs.started",
+		metric.WithDescription("Workflow runs started, by the trigger node that started them"),
+	)
+	if err != nil {
+		return err
+	}
+
+	prometheusRunsFinished, err = meter.Int64Counter(
+		"superplane.workflow.runs.finished",
+		metric.WithDescription("Workflow runs finished, by result"),
+	)
+	if err != nil {
+		return err
+	}
+
+	prometheusExecutionDuration, err = meter.Float64Histogram(
+		"superplane.workflow.node.execution.duration",
+		metric.WithDescription("Duration of finished node executions, from creation to finish"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(executionDurationSecondsBuckets...),
+	)
+	if err != nil {
+		return err
+	}
+
+	prometheusApprovalWait, err = meter.Float64Histogram(
+		"superplane.workflow.approval.wait",
+		metric.WithDescription("Time approval nodes waited before being resolved"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(approvalWaitSecondsBuckets...),
+	)
+	if err != nil {
+		return err
+	}
+
+	prometheusWebhookDeliveries, err = meter.Int64Counter(
+		"superplane.webhook.deliveries",
+		metric.WithDescription("Webhook deliveries received, by trigger and result"),
+	)
+	if err != nil {
+		return err
+	}
+
+	prometheusWorkOrderSteps, err = meter.Int64Counter(
+		"superplane.work_order.steps.finished",
+		metric.WithDescription("Factory work order steps finished, by result"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableGauge(
+		"superplane.db.pool.connections",
+		metric.WithDescription("Database connection pool connections by state"),
+		metric.WithInt64Callback(observeDBPoolConnections),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableGauge(
+		"superplane.workflow.node.queue.depth",
+		metric.WithDescription("Number of items waiting in the queue of a node"),
+		metric.WithInt64Callback(observeNodeQueueDepths),
+	)
+
+	return err
+}
+
+// PrometheusMetricsEnabled lets callers skip the lookups
+// they only need for labels when nothing is recorded.
+func PrometheusMetricsEnabled() bool {
+	return prometheusMetricsReady.Load()
+}
+
+func PrometheusHandler() http.Handler {
+	return promhttp.HandlerFor(prometheusRegistry, promhttp.HandlerOpts{})
+}
+
+func RecordWorkflowRunStarted(organizationID, canvasID, nodeID string) {
+	if !prometheusMetricsReady.Load() {
+		return
+	}
+
+	prometheusRunsStarted.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("organization_id", organizationID),
+		attribute.String("canvas_id", canvasID),
+		attribute.String("node_id", nodeID),
+	))
+}
+
+func RecordWorkflowRunFinished(organizationID, canvasID, nodeID, result string) {
+	if !prometheusMetricsReady.Load() {
+		return
+	}
+
+	prometheusRunsFinished.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("organization_id", organizationID),
+		attribute.String("canvas_id", canvasID),
+		attribute.String("node_id", nodeID),
+		attribute.String("result", result),
+	))
+}
+
+func RecordWorkflowNodeExecutionFinished(organizationID, canvasID, nodeID, component, result string, d time.Duration) {
+	if !prometheusMetricsReady.Load() {
+		return
+	}
+
+	prometheusExecutionDuration.Record(context.Background(), d.Seconds(), metric.WithAttributes(
+		attribute.String("organization_id", organizationID),
+		attribute.String("canvas_id", canvasID),
+		attribute.String("node_id", nodeID),
+		attribute.String("component", component),
+		attribute.String("result", result),
+	))
+}
+
+func RecordWorkflowApprovalWait(organizationID, canvasID, nodeID, result string, d time.Duration) {
+	if !prometheusMetricsReady.Load() {
+		return
+	}
+
+	prometheusApprovalWait.Record(context.Background(), d.Seconds(), metric.WithAttributes(
+		attribute.String("organization_id", organizationID),
+		attribute.String("canvas_id", canvasID),
+		attribute.String("node_id", nodeID),
+		attribute.String("result", result),
+	))
+}
+
+func RecordWebhookDelivery(organizationID, canvasID, nodeID, trigger, result string) {
+	if !prometheusMetricsReady.Load() {
+		return
+	}
+
+	prometheusWebhookDeliveries.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("organization_id", organizationID),
+		attribute.String("canvas_id", canvasID),
+		attribute.String("node_id", nodeID),
+		attribute.String("trigger", trigger),
+		attribute.String("result", result),
+	))
+}
+
+func RecordWorkOrderStepFinished(organizationID, canvasID, result string) {
+	if !prometheusMetricsReady.Load() {
+		return
+	}
+
+	prometheusWorkOrderSteps.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("organization_id", organizationID),
+		attribute.String("canvas_id", canvasID),
+		attribute.String("result", result),
+	))
+}
+
+func recordPrometheusWorkerTick(worker string, d time.Duration) {
+	if !prometheusMetricsReady.Load() {
+		return
+	}
+
+	prometheusWorkerTickDuration.Record(context.Background(), d.Seconds(), metric.WithAttributes(
+		attribute.String("worker", worker),
+	))
+}
+
+func observeDBPoolConnections(_ context.Context, observer metric.Int64Observer) error {
+	snapshot := prometheusGauges.Load()
+	if snapshot == nil || snapshot.pool == nil {
+		return nil
+	}
+
+	observer.Observe(int64(snapshot.pool.MaxOpenConnections), metric.WithAttributes(attribute.String("state", "max")))
+	observer.Observe(int64(snapshot.pool.OpenConnections), metric.WithAttributes(attribute.String("state", "open")))
+	observer.Observe(int64(snapshot.pool.InUse), metric.WithAttributes(attribute.String("state", "in_use")))
+	observer.Observe(int64(snapshot.pool.Idle), metric.WithAttributes(attribute.String("state", "idle")))
+	return nil
+}
+
+func observeNodeQueueDepths(_ context.Context, observer metric.Int64Observer) error {
+	snapshot := prometheusGauges.Load()
+	if snapshot == nil {
+		return nil
+	}
+
+	for _, depth := range snapshot.queueDepths {
+		observer.Observe(depth.Items, metric.WithAttributes(
+			attribute.String("organization_id", depth.OrganizationID),
+			attribute.String("canvas_id", depth.CanvasID),
+			attribute.String("node_id", depth.NodeID),
+		))
+	}
+
+	return nil
+}
+
+func refreshPrometheusGauges() {
+	snapshot := &prometheusGaugeSnapshot{}
+	if previous := prometheusGauges.Load(); previous != nil {
+		snapshot.queueDepths = previous.queueDepths
+	}
+
+	if stats, err := database.PoolStats(); err == nil {
+		snapshot.pool = &stats
+	}
+
+	depths, err := findNodeQueueDepths(context.Background())
+	if err != nil {
+		log.WithError(err).Warn("failed to report node queue depths")
+	} else {
+		snapshot.queueDepths = depths
+	}
+
+	prometheusGauges.Store(snapshot)
+}
+
+type nodeQueueDepth struct {
+	OrganizationID string
+	CanvasID       string
+	NodeID         string
+	Items          int64
+}
+
+func findNodeQueueDepths(ctx context.Context) ([]nodeQueueDepth, error) {
+	var depths []nodeQueueDepth
+
+	err := database.Conn().
+		WithContext(ctx).
+		Table("workflow_node_queue_items AS q").
+		Select("w.organization_id, q.workflow_id AS canvas_id, q.node_id, COUNT(*) AS items").
+		Joins("JOIN workflows AS w ON q.workflow_id = w.id").
+		Where("w.deleted_at IS NULL").
+		Group("w.organization_id, q.workflow_id, q.node_id").
+		Scan(&depths).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return depths, nil
+}
@@ -0,0 +1,94 @@
+package telemetry
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func scrapePrometheusMetrics(t *testing.T) string {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	PrometheusHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", recorder.Code)
+	}
+
+	return recorder.Body.String()
+}
+
+func TestRecordWorkflowMetrics_OnlyWhenEnabled(t *testing.T) {
+	if err := initPrometheusInstruments(); err != nil {
+		t.Fatalf("initPrometheusInstruments: %v", err)
+	}
+
+	t.Cleanup(func() {
+		prometheusMetricsReady.Store(false)
+	})
+
+	RecordWorkflowRunStarted("org-1", "canvas-1", "trigger")
+
+	prometheusMetricsReady.Store(true)
+	RecordWorkflowRunStarted("org-1", "canvas-1", "trigger")
+	RecordWorkflowApprovalWait("org-1", "canvas-1", "approve", "passed", 90*time.Minute)
+	recordPrometheusWorkerTick("queue_worker", 20*time.Millisecond)
+
+	body := scrapePrometheusMetrics(t)
+
+	expected := []string{
+		"# TYPE superplane_workflow_runs_started_total counter",
+		`superplane_workflow_runs_started_total{canvas_id="canvas-1",node_id="trigger",organization_id="org-1"} 1`,
+		"# TYPE superplane_workflow_approval_wait_seconds histogram",
+		`superplane_workflow_approval_wait_seconds_bucket{canvas_id="canvas-1",node_id="approve",organization_id="org-1",result="passed",le="3600"} 0`,
+		`superplane_workflow_approval_wait_seconds_bucket{canvas_id="canvas-1",node_id="approve",organization_id="org-1",result="passed",le="14400"} 1`,
+		`superplane_worker_tick_duration_seconds_bucket{worker="queue_worker",le="0.025"} 1`,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("expected output to contain %q, got:\n%s", line, body)
+		}
+	}
+}
+
+func TestPrometheusGauges_ReportLatestSnapshot(t *testing.T) {
+	if err := initPrometheusInstruments(); err != nil {
+		t.Fatalf("initPrometheusInstruments: %v", err)
+	}
+
+	t.Cleanup(func() {
+		prometheusGauges.Store(nil)
+	})
+
+	prometheusGauges.Store(&prometheusGaugeSnapshot{
+		pool:        &sql.DBStats{MaxOpenConnections: 10, OpenConnections: 3, InUse: 1, Idle: 2},
+		queueDepths: []nodeQueueDepth{{OrganizationID: "org-1", CanvasID: "canvas-1", NodeID: "node-1", Items: 4}},
+	})
+
+	body := scrapePrometheusMetrics(t)
+	for _, line := range []string{
+		`superplane_db_pool_connections{state="in_use"} 1`,
+		`superplane_workflow_node_queue_depth{canvas_id="canvas-1",node_id="node-1",organization_id="org-1"} 4`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("expected output to contain %q, got:\n%s", line, body)
+		}
+	}
+
+	prometheusGauges.Store(&prometheusGaugeSnapshot{
+		queueDepths: []nodeQueueDepth{{OrganizationID: "org-1", CanvasID: "canvas-1", NodeID: "node-2", Items: 2}},
+	})
+
+	body = scrapePrometheusMetrics(t)
+	if strings.Contains(body, `node_id="node-1"`) {
+		t.Errorf("expected drained queues to be dropped, got:\n%s", body)
+	}
+
+	if !strings.Contains(body, `superplane_workflow_node_queue_depth{canvas_id="canvas-1",node_id="node-2",organization_id="org-1"} 2`) {
+		t.Errorf("expected new queue depth, got:\n%s", body)
+	}
+}
@@ -0,0 +1,143 @@
+// Package runtrace makes a canvas run one trace. The root event starts it,
+// and every record the run creates afterwards - queue items, executions and
+// their output events - persists a W3C traceparent, so the worker that picks
+// the record up can continue the same trace in another process.
+//
+// It only depends on the global OpenTelemetry tracer provider, so models can
+// use it. When tracing is disabled, the provider is a no-op, and no
+// traceparent is ever created.
+package runtrace
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var propagator = propagation.TraceContext{}
+
+func tracer() trace.Tracer {
+	return otel.Tracer("superplane")
+}
+
+// Start starts the trace of the run created by a root event and returns
+// its traceparent. The trace is a new root, linked to the span in ctx, if
+// any, like the HTTP request of the webhook that emitted the event.
+func Start(ctx context.Context, canvasID, nodeID, channel string) string {
+	opts := []trace.SpanStartOption{
+		trace.WithNewRoot(),
+		trace.WithAttributes(
+			attribute.String("canvas.id", canvasID),
+			attribute.String("canvas.node.id", nodeID),
+			attribute.String("canvas.event.channel", channel),
+		),
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if link := trace.LinkFromContext(ctx); link.SpanContext.IsValid() {
+		opts = append(opts, trace.WithLinks(link))
+	}
+
+	spanCtx, span := tracer().Start(ctx, "canvas.run", opts...)
+	defer span.End()
+
+	return TraceParent(spanCtx)
+}
+
+// TraceParent returns the traceparent of the span in ctx,
+// or an empty string when there is no valid span.
+func TraceParent(ctx context.Context) string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ""
+	}
+
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// ContextWithTraceParent continues the trace of a persisted traceparent.
+// An empty or invalid traceparent leaves ctx unchanged.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+
+	return propagator.Extract(ctx, propagation.MapCarrier{"traceparent": traceParent})
+}
+
+// StartSpan starts a span in the run trace of a persisted traceparent.
+func StartSpan(traceParent, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx := ContextWithTraceParent(context.Background(), traceParent)
+	return tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// HTTPDoer is what components use to send HTTP requests.
+type HTTPDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+type tracedHTTPDoer struct {
+	ctx   context.Context
+	inner HTTPDoer
+}
+
+// WrapHTTP records outbound requests as client spans of the span in ctx,
+// and sends its W3C traceparent along, so the receiving service can continue
+// the trace. Components build their requests without a context, which is
+// why the trace context is taken from ctx and not from the request.
+func WrapHTTP(ctx context.Context, inner HTTPDoer) HTTPDoer {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return inner
+	}
+
+	return &tracedHTTPDoer{ctx: ctx, inner: inner}
+}
+
+func (d *tracedHTTPDoer) Do(request *http.Request) (*http.Response, error) {
+	parent := request.Context()
+	if !trace.SpanContextFromContext(parent).IsValid() {
+		parent = trace.ContextWithSpanContext(parent, trace.SpanContextFromContext(d.ctx))
+	}
+
+	ctx, span := tracer().Start(
+		parent,
+		"HTTP "+request.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", request.Method),
+			attribute.String("server.address", request.URL.Hostname()),
+			attribute.String("url.path", request.URL.Path),
+		),
+	)
+	defer span.End()
+
+	request = request.Clone(ctx)
+	if request.Header == nil {
+		request.Header = http.Header{}
+	}
+
+	propagator.Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+	response, err := d.inner.Do(request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", response.StatusCode))
+	if response.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, response.Status)
+	}
+
+	return response, nil
+}
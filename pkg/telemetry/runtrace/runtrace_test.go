@@ -0,0 +1,109 @@
+package runtrace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func configureTracerProvider(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+		otel.SetTracerProvider(previous)
+	})
+
+	return exporter
+}
+
+func TestStartWithoutTracerProvider(t *testing.T) {
+	assert.Empty(t, Start(context.Background(), "canvas-1", "trigger", "default"))
+}
+
+func TestRunIsOneTrace(t *testing.T) {
+	exporter := configureTracerProvider(t)
+
+	requestCtx, request := otel.Tracer("test").Start(context.Background(), "webhook")
+	request.End()
+
+	traceParent := Start(requestCtx, "canvas-1", "trigger", "default")
+	require.NotEmpty(t, traceParent)
+
+	routeCtx, route := StartSpan(traceParent, "canvas.event.route")
+	route.End()
+
+	_, execute := StartSpan(TraceParent(routeCtx), "canvas.execution")
+	execute.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+
+	run, routed, executed := spans[1], spans[2], spans[3]
+	assert.Equal(t, "canvas.run", run.Name)
+	assert.False(t, run.Parent.IsValid())
+	assert.NotEqual(t, request.SpanContext().TraceID(), run.SpanContext.TraceID())
+	require.Len(t, run.Links, 1)
+	assert.Equal(t, request.SpanContext().SpanID(), run.Links[0].SpanContext.SpanID())
+
+	assert.Equal(t, run.SpanContext.TraceID(), routed.SpanContext.TraceID())
+	assert.Equal(t, run.SpanContext.SpanID(), routed.Parent.SpanID())
+	assert.Equal(t, run.SpanContext.TraceID(), executed.SpanContext.TraceID())
+	assert.Equal(t, routed.SpanContext.SpanID(), executed.Parent.SpanID())
+}
+
+func TestContextWithInvalidTraceParent(t *testing.T) {
+	ctx := ContextWithTraceParent(context.Background(), "not-a-traceparent")
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+	assert.Empty(t, TraceParent(ctx))
+}
+
+func TestWrapHTTP(t *testing.T) {
+	exporter := configureTracerProvider(t)
+
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	t.Run("without a span, the client is not wrapped", func(t *testing.T) {
+		assert.Same(t, http.DefaultClient, WrapHTTP(context.Background(), http.DefaultClient))
+	})
+
+	t.Run("requests carry the traceparent of a client span", func(t *testing.T) {
+		ctx, execution := StartSpan(Start(context.Background(), "canvas-1", "trigger", "default"), "canvas.execution")
+		defer execution.End()
+
+		request, err := http.NewRequest(http.MethodPost, server.URL+"/deploy", nil)
+		require.NoError(t, err)
+
+		response, err := WrapHTTP(ctx, http.DefaultClient).Do(request)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		assert.Empty(t, request.Header.Get("traceparent"))
+
+		spans := exporter.GetSpans()
+		client := spans[len(spans)-1]
+		assert.Equal(t, "HTTP POST", client.Name)
+		assert.Equal(t, trace.SpanKindClient, client.SpanKind)
+		assert.Equal(t, execution.SpanContext().SpanID(), client.Parent.SpanID())
+		assert.Equal(t, "Error", client.Status.Code.String())
+
+		tracedCtx := ContextWithTraceParent(context.Background(), received)
+		assert.Equal(t, client.SpanContext.SpanID(), trace.SpanContextFromContext(tracedCtx).SpanID())
+	})
+}
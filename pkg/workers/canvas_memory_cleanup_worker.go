@@ -0,0 +1,107 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	canvasMemoryCleanupTickEvery           = 1 * time.Minute
+	canvasMemoryCleanupDeleteBatchSize     = 500
+	canvasMemoryCleanupMaxDeletesPerTick   = 5000
+	canvasMemoryCleanupPauseBetweenBatches = 20 * time.Millisecond
+)
+
+// CanvasMemoryCleanupWorker deletes canvas memory records past their expiry.
+// Expired records are already hidden from reads, so the worker only
+// reclaims space and does not need to run exactly on time.
+type CanvasMemoryCleanupWorker struct {
+	logger              *log.Entry
+	deleteBatchSize     int
+	maxDeletesPerTick   int
+	pauseBetweenBatches time.Duration
+}
+
+func NewCanvasMemoryCleanupWorker() *CanvasMemoryCleanupWorker {
+	return &CanvasMemoryCleanupWorker{
+		logger:              log.WithFields(log.Fields{"worker": "CanvasMemoryCleanupWorker"}),
+		deleteBatchSize:     canvasMemoryCleanupDeleteBatchSize,
+		maxDeletesPerTick:   canvasMemoryCleanupMaxDeletesPerTick,
+		pauseBetweenBatches: canvasMemoryCleanupPauseBetweenBatches,
+	}
+}
+
+func (w *CanvasMemoryCleanupWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(canvasMemoryCleanupTickEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *CanvasMemoryCleanupWorker) tick(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	startedAt := time.Now()
+	deleted, err := w.deleteInBatches(startedAt)
+	if err != nil {
+		w.logger.Errorf("Error deleting expired canvas memories: %v", err)
+		return
+	}
+
+	if deleted == 0 {
+		return
+	}
+
+	w.logger.WithFields(log.Fields{
+		"deleted":     deleted,
+		"duration_ms": time.Since(startedAt).Milliseconds(),
+	}).Info("Deleted expired canvas memories")
+}
+
+func (w *CanvasMemoryCleanupWorker) deleteInBatches(before time.Time) (int64, error) {
+	totalDeleted := int64(0)
+
+	for totalDeleted < int64(w.maxDeletesPerTick) {
+		budget := min(w.deleteBatchSize, w.maxDeletesPerTick-int(totalDeleted))
+
+		var deleted int64
+		err := database.Conn().Transaction(func(tx *gorm.DB) error {
+			count, err := models.DeleteExpiredCanvasMemories(tx, before, budget)
+			if err != nil {
+				return err
+			}
+			deleted = count
+			return nil
+		})
+		if err != nil {
+			return totalDeleted, fmt.Errorf("delete batch: %w", err)
+		}
+
+		totalDeleted += deleted
+		if deleted == 0 {
+			return totalDeleted, nil
+		}
+
+		if w.pauseBetweenBatches > 0 {
+			time.Sleep(w.pauseBetweenBatches)
+		}
+	}
+
+	return totalDeleted, nil
+}
@@ -0,0 +1,42 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/test/support"
+)
+
+func Test__CanvasMemoryCleanupWorker_DeletesExpiredMemories(t *testing.T) {
+	r := support.Setup(t)
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{}, []models.Edge{})
+
+	ttl := time.Hour
+	live, err := models.AddCanvasMemoryRecordWithTTLInTransaction(
+		database.Conn(), canvas.ID, "locks", map[string]any{"lock": "live"}, models.CanvasMemorySourceNode, &ttl,
+	)
+	require.NoError(t, err)
+
+	expired, err := models.AddCanvasMemoryRecordWithTTLInTransaction(
+		database.Conn(), canvas.ID, "locks", map[string]any{"lock": "expired"}, models.CanvasMemorySourceNode, &ttl,
+	)
+	require.NoError(t, err)
+	require.NoError(t, database.Conn().Model(&expired).Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	permanent, err := models.AddCanvasMemoryRecordWithTTLInTransaction(
+		database.Conn(), canvas.ID, "locks", map[string]any{"lock": "permanent"}, models.CanvasMemorySourceNode, nil,
+	)
+	require.NoError(t, err)
+
+	worker := NewCanvasMemoryCleanupWorker()
+	worker.pauseBetweenBatches = 0
+	worker.tick(t.Context())
+
+	assert.Equal(t, int64(0), countRowsByID(t, &models.CanvasMemory{}, expired.ID))
+	assert.Equal(t, int64(1), countRowsByID(t, &models.CanvasMemory{}, live.ID))
+	assert.Equal(t, int64(1), countRowsByID(t, &models.CanvasMemory{}, permanent.ID))
+}
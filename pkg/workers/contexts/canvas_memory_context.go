@@ -3,6 +3,7 @@ package contexts
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/superplanehq/superplane/pkg/core"
@@ -14,6 +15,12 @@ type CanvasMemoryContext struct {
 	tx        *gorm.DB
 	canvasID  uuid.UUID
 	onChanged func()
+
+	//
+	// Namespaces prefixed with org: refer to organization memory.
+	// The organization is looked up on first use.
+	//
+	organizationID *uuid.UUID
 }
 
 func NewCanvasMemoryContext(tx *gorm.DB, canvasID uuid.UUID) *CanvasMemoryContext {
@@ -40,16 +47,30 @@ func (c *CanvasMemoryContext) Add(namespace string, values any) error {
 }
 
 func (c *CanvasMemoryContext) AddRecord(namespace string, values any) (core.CanvasMemoryRecord, error) {
+	return c.AddRecordWithTTL(namespace, values, nil)
+}
+
+// AddRecordWithTTL adds a record that expires after the TTL.
+// A nil TTL falls back to the default TTL of the namespace.
+func (c *CanvasMemoryContext) AddRecordWithTTL(namespace string, values any, ttl *time.Duration) (core.CanvasMemoryRecord, error) {
 	namespace = strings.TrimSpace(namespace)
 	if namespace == "" {
 		return core.CanvasMemoryRecord{}, fmt.Errorf("namespace is required")
 	}
 
+	if name, organization := core.ParseMemoryNamespace(namespace); organization {
+		if ttl != nil {
+			return core.CanvasMemoryRecord{}, fmt.Errorf("organization memory does not support expiry")
+		}
+
+		return c.addOrganizationRecord(name, values)
+	}
+
 	if err := c.ensureNodeWritable(namespace); err != nil {
 		return core.CanvasMemoryRecord{}, err
 	}
 
-	record, err := models.AddCanvasMemoryRecordInTransaction(c.tx, c.canvasID, namespace, values)
+	record, err := models.AddCanvasMemoryRecordWithTTLInTransaction(c.tx, c.canvasID, namespace, values, models.CanvasMemorySourceNode, ttl)
 	if err != nil {
 		return core.CanvasMemoryRecord{}, err
 	}
@@ -64,6 +85,10 @@ func (c *CanvasMemoryContext) Find(namespace string, matches map[string]any) ([]
 		return nil, fmt.Errorf("namespace is required")
 	}
 
+	if name, organization := core.ParseMemoryNamespace(namespace); organization {
+		return c.findOrganizationRecords(name, matches)
+	}
+
 	records, err := models.ListCanvasMemoriesByNamespaceAndMatchesInTransaction(c.tx, c.canvasID, namespace, matches)
 	if err != nil {
 		return nil, err
@@ -83,6 +108,10 @@ func (c *CanvasMemoryContext) FindFirst(namespace string, matches map[string]any
 		return nil, fmt.Errorf("namespace is required")
 	}
 
+	if name, organization := core.ParseMemoryNamespace(namespace); organization {
+		return c.findFirstOrganizationRecord(name, matches)
+	}
+
 	record, err := models.FindFirstCanvasMemoryByNamespaceAndMatchesInTransaction(c.tx, c.canvasID, namespace, matches)
 	if err != nil {
 		return nil, err
@@ -100,6 +129,10 @@ func (c *CanvasMemoryContext) Delete(namespace string, matches map[string]any) (
 		return nil, fmt.Errorf("namespace is required")
 	}
 
+	if name, organization := core.ParseMemoryNamespace(namespace); organization {
+		return c.deleteOrganizationRecords(name, matches)
+	}
+
 	if err := c.ensureNodeWritable(namespace); err != nil {
 		return nil, err
 	}
@@ -136,6 +169,10 @@ func (c *CanvasMemoryContext) UpdateRecords(namespace string, matches map[string
 		return nil, fmt.Errorf("namespace is required")
 	}
 
+	if name, organization := core.ParseMemoryNamespace(namespace); organization {
+		return c.updateOrganizationRecords(name, matches, values)
+	}
+
 	if err := c.ensureNodeWritable(namespace); err != nil {
 		return nil, err
 	}
@@ -167,6 +204,10 @@ func (c *CanvasMemoryContext) UpdateNamespaceRecords(namespace string, values ma
 		return nil, fmt.Errorf("namespace is required")
 	}
 
+	if name, organization := core.ParseMemoryNamespace(namespace); organization {
+		return c.updateOrganizationNamespace(name, values)
+	}
+
 	if err := c.ensureNodeWritable(namespace); err != nil {
 		return nil, err
 	}
@@ -183,6 +224,76 @@ func (c *CanvasMemoryContext) UpdateNamespaceRecords(namespace string, values ma
 	return canvasMemoryRecords(records), nil
 }
 
+// Increment atomically adds delta to a numeric field of the newest record
+// matching the matches, adding the record if there is none.
+func (c *CanvasMemoryContext) Increment(
+	namespace string,
+	matches map[string]any,
+	field string,
+	delta float64,
+	ttl *time.Duration,
+) (core.CanvasMemoryRecord, error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" {
+		return core.CanvasMemoryRecord{}, fmt.Errorf("namespace is required")
+	}
+
+	if _, organization := core.ParseMemoryNamespace(namespace); organization {
+		return core.CanvasMemoryRecord{}, fmt.Errorf("organization memory does not support increments")
+	}
+
+	if err := c.ensureNodeWritable(namespace); err != nil {
+		return core.CanvasMemoryRecord{}, err
+	}
+
+	record, err := models.IncrementCanvasMemoryInTransaction(c.tx, c.canvasID, namespace, matches, field, delta, ttl)
+	if err != nil {
+		return core.CanvasMemoryRecord{}, err
+	}
+
+	c.notifyChanged()
+	return canvasMemoryRecord(record), nil
+}
+
+// CompareAndSwap atomically applies the swap when the newest matching
+// record is in the expected state. The returned record is the current
+// one, whether the swap happened or not, and nil if there is none.
+func (c *CanvasMemoryContext) CompareAndSwap(namespace string, swap core.CanvasMemorySwap) (bool, *core.CanvasMemoryRecord, error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" {
+		return false, nil, fmt.Errorf("namespace is required")
+	}
+
+	if _, organization := core.ParseMemoryNamespace(namespace); organization {
+		return false, nil, fmt.Errorf("organization memory does not support compare-and-swap")
+	}
+
+	if err := c.ensureNodeWritable(namespace); err != nil {
+		return false, nil, err
+	}
+
+	swapped, record, err := models.CompareAndSwapCanvasMemoryInTransaction(c.tx, c.canvasID, namespace, models.CanvasMemorySwap{
+		Matches:  swap.Matches,
+		Expected: swap.Expected,
+		Values:   swap.Values,
+		TTL:      swap.TTL,
+	})
+	if err != nil {
+		return false, nil, err
+	}
+
+	if swapped {
+		c.notifyChanged()
+	}
+
+	if record == nil {
+		return swapped, nil, nil
+	}
+
+	current := canvasMemoryRecord(*record)
+	return swapped, &current, nil
+}
+
 // ensureNodeWritable returns an error if the namespace is currently owned by
 // manually-created memory, preventing node executions from mutating it.
 func (c *CanvasMemoryContext) ensureNodeWritable(namespace string) error {
@@ -198,6 +309,147 @@ func (c *CanvasMemoryContext) ensureNodeWritable(namespace string) error {
 	return nil
 }
 
+func (c *CanvasMemoryContext) findOrganizationID() (uuid.UUID, error) {
+	if c.organizationID != nil {
+		return *c.organizationID, nil
+	}
+
+	var organizationID uuid.UUID
+	err := c.tx.
+		Model(&models.Canvas{}).
+		Select("organization_id").
+		Where("id = ?", c.canvasID).
+		Scan(&organizationID).
+		Error
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to find canvas organization: %w", err)
+	}
+
+	if organizationID == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("canvas %s not found", c.canvasID)
+	}
+
+	c.organizationID = &organizationID
+	return organizationID, nil
+}
+
+// organizationScope returns the organization whose memory
+// holds the namespace named after the org: prefix.
+func (c *CanvasMemoryContext) organizationScope(namespace string) (uuid.UUID, error) {
+	if namespace == "" {
+		return uuid.Nil, fmt.Errorf("namespace is required after %q", core.OrganizationMemoryPrefix)
+	}
+
+	return c.findOrganizationID()
+}
+
+func (c *CanvasMemoryContext) organizationWriter() models.OrganizationMemoryWriter {
+	canvasID := c.canvasID
+	return models.OrganizationMemoryWriter{CanvasID: &canvasID}
+}
+
+func (c *CanvasMemoryContext) addOrganizationRecord(namespace string, values any) (core.CanvasMemoryRecord, error) {
+	organizationID, err := c.organizationScope(namespace)
+	if err != nil {
+		return core.CanvasMemoryRecord{}, err
+	}
+
+	record, err := models.AddOrganizationMemoryInTransaction(c.tx, organizationID, namespace, values, c.organizationWriter())
+	if err != nil {
+		return core.CanvasMemoryRecord{}, err
+	}
+
+	return organizationMemoryRecord(record), nil
+}
+
+func (c *CanvasMemoryContext) findOrganizationRecords(namespace string, matches map[string]any) ([]any, error) {
+	organizationID, err := c.organizationScope(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := models.ListOrganizationMemoriesByNamespaceAndMatchesInTransaction(c.tx, organizationID, namespace, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	return canvasMemoryRecordValues(organizationMemoryRecords(records)), nil
+}
+
+func (c *CanvasMemoryContext) findFirstOrganizationRecord(namespace string, matches map[string]any) (any, error) {
+	organizationID, err := c.organizationScope(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := models.FindFirstOrganizationMemoryByNamespaceAndMatchesInTransaction(c.tx, organizationID, namespace, matches)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, nil
+	}
+
+	return record.Values.Data(), nil
+}
+
+func (c *CanvasMemoryContext) deleteOrganizationRecords(namespace string, matches map[string]any) ([]any, error) {
+	organizationID, err := c.organizationScope(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := models.DeleteOrganizationMemoriesByNamespaceAndMatchesInTransaction(c.tx, organizationID, namespace, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	return canvasMemoryRecordValues(organizationMemoryRecords(records)), nil
+}
+
+func (c *CanvasMemoryContext) updateOrganizationRecords(namespace string, matches map[string]any, values map[string]any) ([]core.CanvasMemoryRecord, error) {
+	organizationID, err := c.organizationScope(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := models.UpdateOrganizationMemoriesByNamespaceAndMatchesInTransaction(c.tx, organizationID, namespace, matches, values, c.organizationWriter())
+	if err != nil {
+		return nil, err
+	}
+
+	return organizationMemoryRecords(records), nil
+}
+
+func (c *CanvasMemoryContext) updateOrganizationNamespace(namespace string, values map[string]any) ([]core.CanvasMemoryRecord, error) {
+	organizationID, err := c.organizationScope(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := models.UpdateOrganizationMemoriesByNamespaceInTransaction(c.tx, organizationID, namespace, values, c.organizationWriter())
+	if err != nil {
+		return nil, err
+	}
+
+	return organizationMemoryRecords(records), nil
+}
+
+func organizationMemoryRecord(record models.OrganizationMemory) core.CanvasMemoryRecord {
+	return core.CanvasMemoryRecord{
+		ID:     record.ID,
+		Values: record.Values.Data(),
+	}
+}
+
+func organizationMemoryRecords(records []models.OrganizationMemory) []core.CanvasMemoryRecord {
+	out := make([]core.CanvasMemoryRecord, 0, len(records))
+	for _, record := range records {
+		out = append(out, organizationMemoryRecord(record))
+	}
+	return out
+}
+
 func canvasMemoryRecord(record models.CanvasMemory) core.CanvasMemoryRecord {
 	return core.CanvasMemoryRecord{
 		ID:     record.ID,
@@ -132,3 +132,47 @@ func Test__CanvasMemoryContext__RecordConversionHelpers(t *testing.T) {
 	records := canvasMemoryRecords([]models.CanvasMemory{record})
 	assert.Equal(t, []any{map[string]any{"service": "api"}}, canvasMemoryRecordValues(records))
 }
+
+func Test__CanvasMemoryContext__OrganizationMemory(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	writer, _ := support.CreateCanvas(t, r.Organization.ID, r.User, nil, nil)
+	reader, _ := support.CreateCanvas(t, r.Organization.ID, r.User, nil, nil)
+	writerCtx := NewCanvasMemoryContext(database.Conn(), writer.ID)
+	readerCtx := NewCanvasMemoryContext(database.Conn(), reader.ID)
+
+	preview := map[string]any{"pr": "42", "url": "https://pr-42.example.com"}
+	record, err := writerCtx.AddRecord("org:previews", preview)
+	require.NoError(t, err)
+
+	found, err := readerCtx.Find("org:previews", map[string]any{"pr": "42"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{preview}, found)
+
+	canvasFound, err := readerCtx.Find("previews", map[string]any{"pr": "42"})
+	require.NoError(t, err)
+	assert.Empty(t, canvasFound)
+
+	updated, err := readerCtx.UpdateRecords("org:previews", map[string]any{"pr": "42"}, map[string]any{"status": "stale"})
+	require.NoError(t, err)
+	require.Len(t, updated, 1)
+	assert.Equal(t, record.ID, updated[0].ID)
+
+	stored, err := models.FindOrganizationMemoryInTransaction(database.Conn(), r.Organization.ID, record.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.CreatedByCanvasID)
+	require.NotNil(t, stored.UpdatedByCanvasID)
+	assert.Equal(t, writer.ID, *stored.CreatedByCanvasID)
+	assert.Equal(t, reader.ID, *stored.UpdatedByCanvasID)
+
+	deleted, err := readerCtx.Delete("org:previews", map[string]any{"pr": "42"})
+	require.NoError(t, err)
+	assert.Len(t, deleted, 1)
+
+	_, err = writerCtx.AddRecord("org: ", preview)
+	require.ErrorContains(t, err, "namespace is required")
+
+	_, err = writerCtx.Increment("org:previews", map[string]any{"pr": "42"}, "count", 1, nil)
+	require.ErrorContains(t, err, "does not support increments")
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/superplanehq/superplane/pkg/core"
@@ -30,6 +31,10 @@ type FactoryContext struct {
 	// them after the surrounding transaction commits.
 	onWorkOrderNotification func(messages.FactoryWorkOrderNotificationMessage)
 
+	// Base URL of the API, for links to SuperPlane resources,
+	// like runner artifact downloads. Wired by the node executor.
+	baseURL string
+
 	lineStepOnce   bool
 	lineStepLoaded bool
 	lineStepCache  lineStepInfo
@@ -55,6 +60,11 @@ func (c *FactoryContext) WithWorkOrderUpdated(callback func(factoryID, orderID,
 	return c
 }
 
+func (c *FactoryContext) WithBaseURL(baseURL string) *FactoryContext {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+	return c
+}
+
 func (c *FactoryContext) WithWorkOrderNotification(
 	callback func(messages.FactoryWorkOrderNotificationMessage),
 ) *FactoryContext {
@@ -198,6 +208,46 @@ func (c *FactoryContext) AddWorkOrderArtifact(params core.AddWorkOrderArtifactPa
 	return artifactToCore(artifact)
 }
 
+func (c *FactoryContext) AddRunnerArtifactToWorkOrder(params core.AddRunnerArtifactToWorkOrderParams) (*core.WorkOrderArtifact, error) {
+	if c.baseURL == "" {
+		return nil, errors.New("base URL is not configured")
+	}
+
+	artifactID, err := uuid.Parse(params.RunnerArtifactID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid runnerArtifactId %q: %w", params.RunnerArtifactID, err)
+	}
+
+	runnerArtifact, err := models.FindRunnerArtifactInCanvas(c.tx, c.canvas.ID, artifactID)
+	if err != nil {
+		return nil, fmt.Errorf("runner artifact %s: %w", params.RunnerArtifactID, err)
+	}
+
+	title := params.Title
+	if title == "" {
+		title = runnerArtifact.Name
+	}
+
+	data := map[string]any{
+		"url":              c.baseURL + runnerArtifact.DownloadPath(),
+		"title":            title,
+		"runnerArtifactId": runnerArtifact.ID.String(),
+		"name":             runnerArtifact.Name,
+		"sizeBytes":        runnerArtifact.SizeBytes,
+		"sha256":           runnerArtifact.SHA256,
+	}
+	if runnerArtifact.ExpiresAt != nil {
+		data["expiresAt"] = runnerArtifact.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	return c.AddWorkOrderArtifact(core.AddWorkOrderArtifactParams{
+		OrderID: params.OrderID,
+		Type:    models.FactoryWorkOrderArtifactTypeLink,
+		Data:    data,
+		Key:     params.Key,
+	})
+}
+
 func (c *FactoryContext) UpdateWorkOrderArtifact(params core.UpdateWorkOrderArtifactParams) (*core.WorkOrderArtifact, error) {
 	order, err := c.resolveWorkOrder(params.OrderID)
 	if err != nil {
@@ -698,6 +698,61 @@ func TestFactoryContext_AddWorkOrderArtifact(t *testing.T) {
 	assert.Equal(t, "component-under-test", artifactAutomation.StepName)
 }
 
+func TestFactoryContext_AddRunnerArtifactToWorkOrder(t *testing.T) {
+	r := support.Setup(t)
+	defer r.Close()
+
+	factory, err := models.CreateFactory(database.Conn(), r.Organization.ID, support.RandomName("factory"), "", "")
+	require.NoError(t, err)
+
+	canvas, nodeExecution, run := setupFactoryAppExecution(t, r, factory.ID)
+	order, err := factory.CreateWorkOrder(database.Conn(), "Artifact target", "", &r.User, nil, nil)
+	require.NoError(t, err)
+	linkRunToWorkOrder(t, r, factory, order.ID, run.ID)
+
+	runnerArtifact, _, err := models.SaveRunnerArtifact(nodeExecution, r.Organization.ID, "coverage", models.RunnerBlob{
+		StorageKey: "coverage",
+		SizeBytes:  42,
+		SHA256:     "abc123",
+	}, time.Hour)
+	require.NoError(t, err)
+
+	ctx := NewFactoryContext(database.Conn(), canvas, nodeExecution).WithBaseURL("https://superplane.example.com/")
+
+	t.Run("attaches the runner artifact as a link to its download", func(t *testing.T) {
+		artifact, err := ctx.AddRunnerArtifactToWorkOrder(core.AddRunnerArtifactToWorkOrderParams{
+			OrderID:          order.ID.String(),
+			RunnerArtifactID: runnerArtifact.ID.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.FactoryWorkOrderArtifactTypeLink, artifact.Type)
+		assert.Equal(t, "https://superplane.example.com"+runnerArtifact.DownloadPath(), artifact.Data["url"])
+		assert.Equal(t, "coverage", artifact.Data["title"])
+		assert.Equal(t, runnerArtifact.ID.String(), artifact.Data["runnerArtifactId"])
+		assert.Equal(t, "abc123", artifact.Data["sha256"])
+		assert.EqualValues(t, 42, artifact.Data["sizeBytes"])
+	})
+
+	t.Run("rejects artifacts of other canvases", func(t *testing.T) {
+		otherCanvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{}, []models.Edge{})
+		otherExecution := *nodeExecution
+		otherExecution.ID = uuid.New()
+		otherExecution.WorkflowID = otherCanvas.ID
+		otherArtifact, _, err := models.SaveRunnerArtifact(&otherExecution, r.Organization.ID, "coverage", models.RunnerBlob{
+			StorageKey: "other",
+			SizeBytes:  1,
+			SHA256:     "sha",
+		}, time.Hour)
+		require.NoError(t, err)
+
+		_, err = ctx.AddRunnerArtifactToWorkOrder(core.AddRunnerArtifactToWorkOrderParams{
+			OrderID:          order.ID.String(),
+			RunnerArtifactID: otherArtifact.ID.String(),
+		})
+		assert.ErrorIs(t, err, models.ErrRunnerArtifactNotFound)
+	})
+}
+
 type automationPayload struct {
 	NodeID   string `json:"nodeId"`
 	NodeName string `json:"nodeName"`
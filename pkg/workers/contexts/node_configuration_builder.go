@@ -517,6 +517,8 @@ func (b *NodeConfigurationBuilder) ResolveExpressionWithExtraVariables(expressio
 		}),
 	}
 
+	exprOptions = append(exprOptions, exprruntime.FunctionOptions()...)
+
 	vm, err := expr.Compile(expression, exprOptions...)
 	if err != nil {
 		return "", err
@@ -1048,6 +1050,7 @@ func (b *NodeConfigurationBuilder) resolveOrderPayload(expression string) (any,
 		"factory_id":  order.FactoryID.String(),
 		"state":       order.State,
 		"result":      order.Result,
+		"fields":      orderFieldsPayload(order),
 	}
 
 	if err := attachOrderSource(b.tx, order, payload); err != nil {
@@ -1111,6 +1114,17 @@ func (b *NodeConfigurationBuilder) resolveOrderPayload(expression string) (any,
 	return payload, nil
 }
 
+// orderFieldsPayload exposes the template field values as order().fields.
+// Orders without a template get an empty map, so order().fields.<name>
+// evaluates to nil instead of failing.
+func orderFieldsPayload(order *models.FactoryWorkOrder) map[string]any {
+	fields := order.Fields.Data()
+	if fields == nil {
+		return map[string]any{}
+	}
+	return fields
+}
+
 func attachOrderSource(tx *gorm.DB, order *models.FactoryWorkOrder, payload map[string]any) error {
 	if order.SourceRunID == nil {
 		return nil
@@ -1371,7 +1385,11 @@ var reservedExpressionIdentifiers = map[string]struct{}{
 }
 
 func isReservedExpressionIdentifier(name string) bool {
-	_, ok := reservedExpressionIdentifiers[name]
+	if _, ok := reservedExpressionIdentifiers[name]; ok {
+		return true
+	}
+
+	_, ok := exprruntime.LookupFunction(name)
 	return ok
 }
 
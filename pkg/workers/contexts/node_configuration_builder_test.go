@@ -560,6 +560,33 @@ func Test_NodeConfigurationBuilder_JSONNumberExpressionsUseNumericTypes(t *testi
 	assert.Equal(t, true, result)
 }
 
+func Test_NodeConfigurationBuilder_StandardLibraryFunctions(t *testing.T) {
+	builder := NewNodeConfigurationBuilder(nil, uuid.New()).
+		WithInput(map[string]any{
+			"trigger": map[string]any{
+				"ref":     "refs/tags/v1.4.2",
+				"title":   "Fix: Login Redirect",
+				"seconds": json.Number("5400"),
+			},
+		})
+
+	result, err := builder.Build(map[string]any{
+		"next":  `{{ semverBump(regexReplace(previous().ref, "^refs/tags/", ""), "minor") }}`,
+		"slug":  "{{ slugify(previous().title) }}",
+		"took":  "{{ humanizeDuration(previous().seconds) }}",
+		"first": `{{ jmesPath(parseYAML("items: [a, b]"), "items[0]") }}`,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "v1.5.0", result["next"])
+	assert.Equal(t, "fix-login-redirect", result["slug"])
+	assert.Equal(t, "1h 30m", result["took"])
+	assert.Equal(t, "a", result["first"])
+
+	_, err = builder.ResolveExpressionWithExtraVariables(`slugify`, map[string]any{"slugify": "x"})
+	require.ErrorContains(t, err, "reserved")
+}
+
 func Test_NodeConfigurationBuilder_ObjectFieldPreservesWholeTemplateTypes(t *testing.T) {
 	builder := NewNodeConfigurationBuilder(nil, uuid.New()).
 		WithInput(map[string]any{
@@ -98,6 +98,7 @@ func BuildProcessQueueContext(
 			PreviousExecutionID: event.ExecutionID,
 			State:               models.CanvasNodeExecutionStatePending,
 			QueueName:           queueItem.QueueName,
+			TraceParent:         queueItem.TraceParent,
 			Configuration:       datatypes.NewJSONType(config),
 			CreatedAt:           &now,
 			UpdatedAt:           &now,
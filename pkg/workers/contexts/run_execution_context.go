@@ -146,3 +146,21 @@ func (c *RunExecutionContext) AddError(message string) error {
 
 	return run.AddError(c.tx, message, config.MaxPayloadSize())
 }
+
+func (c *RunExecutionContext) Leases() ([]core.IntegrationLease, error) {
+	if c.node.AppInstallationID == nil || c.execution.RunID == uuid.Nil {
+		return []core.IntegrationLease{}, nil
+	}
+
+	leases, err := models.ListRunIntegrationLeases(c.tx, c.execution.RunID, *c.node.AppInstallationID)
+	if err != nil {
+		return nil, fmt.Errorf("list run leases: %w", err)
+	}
+
+	result := make([]core.IntegrationLease, 0, len(leases))
+	for _, lease := range leases {
+		result = append(result, core.IntegrationLease{ID: lease.LeaseID, Source: lease.Source})
+	}
+
+	return result, nil
+}
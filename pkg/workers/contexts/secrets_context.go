@@ -24,6 +24,11 @@ type SecretsContext struct {
 	registry       *registry.Registry
 	encryptor      crypto.Encryptor
 	organizationID uuid.UUID
+
+	// canvasID and runID are set when secrets are resolved for a run, so
+	// leases issued while resolving integration secrets are recorded for it.
+	canvasID uuid.UUID
+	runID    uuid.UUID
 }
 
 func NewSecretsContext(tx *gorm.DB, reg *registry.Registry, organizationID uuid.UUID, encryptor crypto.Encryptor) *SecretsContext {
@@ -35,6 +40,12 @@ func NewSecretsContext(tx *gorm.DB, reg *registry.Registry, organizationID uuid.
 	}
 }
 
+func (c *SecretsContext) WithRun(canvasID, runID uuid.UUID) *SecretsContext {
+	c.canvasID = canvasID
+	c.runID = runID
+	return c
+}
+
 func (c *SecretsContext) GetKey(secretName, keyName string) ([]byte, error) {
 	if secretName == "" || keyName == "" {
 		return nil, core.ErrSecretKeyNotFound
@@ -121,6 +132,10 @@ func (c *SecretsContext) GetIntegrationKeys(integrationName string) (map[string]
 		Integration: NewIntegrationContext(c.tx, nil, integration, c.encryptor, c.registry, nil),
 	}
 
+	if c.runID != uuid.Nil {
+		secretCtx.Leases = &runLeaseRecorder{canvasID: c.canvasID, runID: c.runID, integrationID: integration.ID}
+	}
+
 	return provider.ResolveSecrets(secretCtx)
 }
 
@@ -139,3 +154,18 @@ func (c *SecretsContext) decryptSecretData(secret *models.Secret) (map[string]st
 	}
 	return data, nil
 }
+
+type runLeaseRecorder struct {
+	canvasID      uuid.UUID
+	runID         uuid.UUID
+	integrationID uuid.UUID
+}
+
+func (r *runLeaseRecorder) Record(lease core.IntegrationLease) error {
+	if strings.TrimSpace(lease.ID) == "" {
+		return fmt.Errorf("lease ID is required")
+	}
+
+	_, err := models.CreateRunIntegrationLease(r.canvasID, r.runID, r.integrationID, lease.ID, lease.Source)
+	return err
+}
@@ -15,6 +15,7 @@ import (
 type UsageContext struct {
 	organizationID uuid.UUID
 	execution      *models.CanvasNodeExecution
+	onRecorded     func()
 }
 
 func NewUsageContext(organizationID uuid.UUID, execution *models.CanvasNodeExecution) *UsageContext {
@@ -24,8 +25,16 @@ func NewUsageContext(organizationID uuid.UUID, execution *models.CanvasNodeExecu
 	}
 }
 
+// WithRecordedCallback registers a callback invoked after a ledger row is
+// written. Used to signal callers that spend changed so they can enforce
+// factory budgets once the node-executor transaction is over.
+func (c *UsageContext) WithRecordedCallback(onRecorded func()) *UsageContext {
+	c.onRecorded = onRecorded
+	return c
+}
+
 func (c *UsageContext) Record(record core.UsageRecord) error {
-	return models.RecordUsage(database.Conn(), models.LLMUsageEventInput{
+	err := models.RecordUsage(database.Conn(), models.LLMUsageEventInput{
 		OrganizationID:   c.organizationID,
 		CanvasRunID:      c.execution.RunID,
 		NodeExecutionID:  c.execution.ID,
@@ -40,4 +49,13 @@ func (c *UsageContext) Record(record core.UsageRecord) error {
 		TotalTokens:      record.TotalTokens,
 		CostMicros:       record.CostMicros,
 	})
+	if err != nil {
+		return err
+	}
+
+	if c.onRecorded != nil {
+		c.onRecorded()
+	}
+
+	return nil
 }
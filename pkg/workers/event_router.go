@@ -17,6 +17,8 @@ import (
 	"github.com/superplanehq/superplane/pkg/models"
 	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
 	"github.com/superplanehq/superplane/pkg/telemetry"
+	"github.com/superplanehq/superplane/pkg/telemetry/runtrace"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type EventRouter struct {
@@ -168,8 +170,18 @@ func (w *EventRouter) LockAndProcessEvent(logger *log.Entry, event models.Canvas
 		)
 	}()
 
+	ctx, span := runtrace.StartSpan(
+		event.TraceParent,
+		"canvas.event.route",
+		attribute.String("canvas.id", event.WorkflowID.String()),
+		attribute.String("canvas.node.id", event.NodeID),
+		attribute.String("canvas.event.id", event.ID.String()),
+	)
+	defer span.End()
+
 	var createdQueueItems []models.CanvasNodeQueueItem
 	var runID uuid.UUID
+	var organizationID uuid.UUID
 	err := database.Conn().Transaction(func(tx *gorm.DB) error {
 		lockedEvent, err := models.LockCanvasEvent(tx, event.ID)
 		if err != nil {
@@ -179,7 +191,7 @@ func (w *EventRouter) LockAndProcessEvent(logger *log.Entry, event models.Canvas
 			return nil
 		}
 
-		createdQueueItems, runID, err = w.processEvent(tx, logger, lockedEvent)
+		createdQueueItems, runID, organizationID, err = w.processEvent(ctx, tx, logger, lockedEvent)
 		if err != nil {
 			outcome = executorOutcomeFailed
 			reason = classifyProcessError(err)
@@ -207,6 +219,8 @@ func (w *EventRouter) LockAndProcessEvent(logger *log.Entry, event models.Canvas
 	// already in flight. Only announce run_started once when processing the root event,
 	// not on every downstream event in the same run.
 	if event.ExecutionID == nil && runID != uuid.Nil {
+		recordRunStartedMetrics(organizationID, event.WorkflowID, event.NodeID)
+
 		if err := messages.NewCanvasRunMessage(event.WorkflowID.String(), runID.String()).Publish(); err != nil {
 			logger.WithError(err).Warnf(
 				"Failed to publish run state message for run %s in workflow %s",
@@ -233,28 +247,29 @@ func (w *EventRouter) LockAndProcessEvent(logger *log.Entry, event models.Canvas
 	return nil
 }
 
-func (w *EventRouter) processEvent(tx *gorm.DB, logger *log.Entry, event *models.CanvasEvent) ([]models.CanvasNodeQueueItem, uuid.UUID, error) {
+func (w *EventRouter) processEvent(ctx context.Context, tx *gorm.DB, logger *log.Entry, event *models.CanvasEvent) ([]models.CanvasNodeQueueItem, uuid.UUID, uuid.UUID, error) {
 	canvas, err := models.FindCanvasWithoutOrgScopeInTransaction(tx, event.WorkflowID)
 	if err != nil {
-		return nil, uuid.Nil, err
+		return nil, uuid.Nil, uuid.Nil, err
 	}
 
 	_, liveEdges, err := models.FindLiveCanvasSpecInTransaction(tx, canvas.ID)
 	if err != nil {
-		return nil, uuid.Nil, err
+		return nil, uuid.Nil, uuid.Nil, err
 	}
 
 	if event.ExecutionID == nil {
-		return w.processRootEvent(tx, canvas, liveEdges, event)
+		queueItems, runID, err := w.processRootEvent(ctx, tx, canvas, liveEdges, event)
+		return queueItems, runID, canvas.OrganizationID, err
 	}
 
 	execution, err := models.FindNodeExecutionInTransaction(tx, event.WorkflowID, *event.ExecutionID)
 	if err != nil {
-		return nil, uuid.Nil, err
+		return nil, uuid.Nil, uuid.Nil, err
 	}
 
-	queueItems, err := w.processExecutionEvent(tx, logger, canvas, liveEdges, execution, event)
-	return queueItems, execution.RunID, err
+	queueItems, err := w.processExecutionEvent(ctx, tx, logger, canvas, liveEdges, execution, event)
+	return queueItems, execution.RunID, canvas.OrganizationID, err
 }
 
 func findOutgoingEdges(edges []models.Edge, sourceID string, channel string) []models.Edge {
@@ -268,7 +283,7 @@ func findOutgoingEdges(edges []models.Edge, sourceID string, channel string) []m
 	return matches
 }
 
-func (w *EventRouter) processRootEvent(tx *gorm.DB, canvas *models.Canvas, edges []models.Edge, event *models.CanvasEvent) ([]models.CanvasNodeQueueItem, uuid.UUID, error) {
+func (w *EventRouter) processRootEvent(ctx context.Context, tx *gorm.DB, canvas *models.Canvas, edges []models.Edge, event *models.CanvasEvent) ([]models.CanvasNodeQueueItem, uuid.UUID, error) {
 	now := time.Now()
 
 	w.logger.Infof("Processing root event %s", event.ID)
@@ -312,6 +327,7 @@ func (w *EventRouter) processRootEvent(tx *gorm.DB, canvas *models.Canvas, edges
 			RootEventID: event.ID,
 			RunID:       run.ID,
 			EventID:     event.ID,
+			TraceParent: runtrace.TraceParent(ctx),
 			CreatedAt:   &now,
 		}
 
@@ -331,6 +347,7 @@ func (w *EventRouter) processRootEvent(tx *gorm.DB, canvas *models.Canvas, edges
 }
 
 func (w *EventRouter) processExecutionEvent(
+	ctx context.Context,
 	tx *gorm.DB,
 	logger *log.Entry,
 	canvas *models.Canvas,
@@ -373,6 +390,7 @@ func (w *EventRouter) processExecutionEvent(
 			RootEventID: execution.RootEventID,
 			RunID:       execution.RunID,
 			EventID:     event.ID,
+			TraceParent: runtrace.TraceParent(ctx),
 			CreatedAt:   &now,
 		}
 
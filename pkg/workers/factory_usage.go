@@ -5,7 +5,10 @@ import (
 
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/grpc/actions/messages"
 	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/models/factory"
 	"gorm.io/gorm"
 )
 
@@ -29,3 +32,76 @@ func rollUpFactoryUsageBestEffort(logger *log.Entry, tx *gorm.DB, runID uuid.UUI
 		logger.WithError(err).WithField("run_id", runID).Error("failed to roll up factory usage")
 	}
 }
+
+// enforceFactoryBudgets halts the factory work covered by the budgets of
+// the step this run belongs to, then publishes the run and work order
+// fan-out for what was halted. Must run outside the transaction that
+// holds the run's executions: cancelling a run updates all of them.
+func enforceFactoryBudgets(runID uuid.UUID) error {
+	var enforcement *models.FactoryBudgetEnforcement
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		execution, err := models.FindWorkOrderExecutionForRun(tx, runID)
+		if err != nil {
+			if errors.Is(err, models.ErrFactoryWorkOrderExecutionNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		enforcement, err = execution.EnforceBudgets(tx)
+		return err
+	})
+	if err != nil || enforcement == nil {
+		return err
+	}
+
+	for _, cancelled := range enforcement.CancelledRuns {
+		if err := messages.NewCanvasRunMessage(cancelled.WorkflowID.String(), cancelled.RunID.String()).Publish(); err != nil {
+			log.WithError(err).Errorf("failed to publish run state message for run %s", cancelled.RunID)
+		}
+
+		messages.PublishRunCancellationDrain(cancelled.WorkflowID, cancelled.DrainResult)
+	}
+
+	for _, order := range enforcement.WarnedOrders {
+		if err := messages.PublishFactoryWorkOrderUpdated(
+			order.FactoryID.String(),
+			order.ID.String(),
+			factory.EventTypeOrderBudgetWarning,
+		); err != nil {
+			log.WithError(err).Errorf("failed to publish factory work order updated for order %s", order.ID)
+		}
+	}
+
+	for _, order := range enforcement.ClosedOrders {
+		if err := messages.PublishFactoryWorkOrderUpdated(
+			order.FactoryID.String(),
+			order.ID.String(),
+			factory.EventTypeOrderStatusUpdated,
+		); err != nil {
+			log.WithError(err).Errorf("failed to publish factory work order updated for order %s", order.ID)
+		}
+
+		notification := messages.FactoryWorkOrderNotificationMessage{
+			OrganizationID: order.OrganizationID.String(),
+			FactoryID:      order.FactoryID.String(),
+			OrderID:        order.ID.String(),
+			EventType:      factory.EventTypeOrderStatusUpdated,
+			ActorName:      "The spend budget",
+			FromState:      models.FactoryWorkOrderStateOpen,
+			ToState:        models.FactoryWorkOrderStateClosed,
+			Result:         models.FactoryWorkOrderResultFailed,
+		}
+		if err := notification.Publish(); err != nil {
+			log.WithError(err).Errorf("failed to publish work order notification for order %s", order.ID)
+		}
+	}
+
+	return nil
+}
+
+func enforceFactoryBudgetsBestEffort(logger *log.Entry, runID uuid.UUID) {
+	if err := enforceFactoryBudgets(runID); err != nil {
+		logger.WithError(err).WithField("run_id", runID).Error("failed to enforce factory budgets")
+	}
+}
@@ -0,0 +1,106 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/semaphore"
+	"gorm.io/gorm"
+
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/gitmirror"
+	"github.com/superplanehq/superplane/pkg/models"
+)
+
+const (
+	gitMirrorTickEvery = 10 * time.Second
+	gitMirrorBatchSize = 100
+
+	// gitMirrorLeaseDuration must exceed the longest expected sync,
+	// so a mirror still syncing is never picked up by another worker.
+	gitMirrorLeaseDuration = 5 * time.Minute
+)
+
+// GitMirrorWorker syncs canvases with their external git mirrors.
+// Each mirror is due every gitmirror.SyncInterval, or right away
+// after it is configured or a sync is requested.
+type GitMirrorWorker struct {
+	logger    *log.Entry
+	service   *gitmirror.Service
+	semaphore *semaphore.Weighted
+}
+
+func NewGitMirrorWorker(service *gitmirror.Service) *GitMirrorWorker {
+	return &GitMirrorWorker{
+		logger:    log.WithFields(log.Fields{"worker": "GitMirrorWorker"}),
+		service:   service,
+		semaphore: semaphore.NewWeighted(10),
+	}
+}
+
+func (w *GitMirrorWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(gitMirrorTickEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *GitMirrorWorker) tick(ctx context.Context) {
+	mirrors, err := models.ListDueCanvasGitMirrors(time.Now(), gitMirrorBatchSize)
+	if err != nil {
+		w.logger.Errorf("Error listing due git mirrors: %v", err)
+		return
+	}
+
+	for _, mirror := range mirrors {
+		if err := w.semaphore.Acquire(ctx, 1); err != nil {
+			return
+		}
+
+		go func(mirror models.CanvasGitMirror) {
+			defer w.semaphore.Release(1)
+
+			if err := w.LockAndSync(ctx, mirror); err != nil {
+				w.logger.Errorf("Error syncing git mirror for canvas %s: %v", mirror.CanvasID, err)
+			}
+		}(mirror)
+	}
+}
+
+// LockAndSync leases the mirror in a short transaction, so the sync,
+// which talks to the remote, never holds a DB transaction open.
+func (w *GitMirrorWorker) LockAndSync(ctx context.Context, mirror models.CanvasGitMirror) error {
+	var leased *models.CanvasGitMirror
+	err := database.Conn().Transaction(func(tx *gorm.DB) error {
+		m, err := models.LeaseCanvasGitMirror(tx, mirror.ID, gitMirrorLeaseDuration)
+		if err != nil {
+			return err
+		}
+
+		leased = m
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to lease git mirror %s: %w", mirror.ID, err)
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, gitMirrorLeaseDuration)
+	defer cancel()
+
+	return w.service.Sync(syncCtx, leased)
+}
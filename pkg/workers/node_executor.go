@@ -28,7 +28,10 @@ import (
 	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
 	"github.com/superplanehq/superplane/pkg/registry"
 	"github.com/superplanehq/superplane/pkg/telemetry"
+	"github.com/superplanehq/superplane/pkg/telemetry/runtrace"
 	"github.com/superplanehq/superplane/pkg/workers/contexts"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 var ErrRecordLocked = errors.New("record locked")
@@ -256,6 +259,16 @@ func (w *NodeExecutor) LockAndProcessNodeExecution(id uuid.UUID) error {
 		pendingWorkOrderNotifications = append(pendingWorkOrderNotifications, notification)
 	}
 
+	//
+	// LLM spend is written on a committed connection while the component
+	// runs. Budgets are enforced only after the transaction is over: halting
+	// a run cancels its executions, including the one this worker holds.
+	//
+	var usageRecordedRunID uuid.UUID
+	onUsageRecorded := func(runID uuid.UUID) {
+		usageRecordedRunID = runID
+	}
+
 	err := database.Conn().Transaction(func(tx *gorm.DB) error {
 		//
 		// Try to lock the execution record for update.
@@ -292,7 +305,7 @@ func (w *NodeExecutor) LockAndProcessNodeExecution(id uuid.UUID) error {
 		}
 
 		metricComponent = node.ComponentName()
-		processErr := w.executeActionNode(tx, execution, node, onNewEvents, onMemoryChanged, onPendingRunCreated, onFactoryWorkOrderUpdated, onFactoryWorkOrderNotification)
+		processErr := w.executeActionNode(tx, execution, node, onNewEvents, onMemoryChanged, onPendingRunCreated, onFactoryWorkOrderUpdated, onFactoryWorkOrderNotification, onUsageRecorded)
 		if processErr != nil {
 			metricOutcome = executorOutcomeFailed
 			metricReason = classifyAttemptFailure(processErr, execution)
@@ -307,6 +320,11 @@ func (w *NodeExecutor) LockAndProcessNodeExecution(id uuid.UUID) error {
 		return nil
 	})
 
+	// The ledger rows are committed even when the transaction rolled back.
+	if usageRecordedRunID != uuid.Nil {
+		enforceFactoryBudgetsBestEffort(w.logger, usageRecordedRunID)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -351,6 +369,7 @@ func (w *NodeExecutor) executeActionNode(
 	onPendingRunCreated func(workflowID, runID uuid.UUID),
 	onFactoryWorkOrderUpdated func(factoryID, orderID, reason string),
 	onFactoryWorkOrderNotification func(messages.FactoryWorkOrderNotificationMessage),
+	onUsageRecorded func(runID uuid.UUID),
 ) error {
 	logger := logging.WithExecution(
 		logging.WithNode(w.logger, *node),
@@ -370,6 +389,32 @@ func (w *NodeExecutor) executeActionNode(
 		return fmt.Errorf("action %s not found: %w", ref.Component.Name, err)
 	}
 
+	spanCtx, span := runtrace.StartSpan(
+		execution.TraceParent,
+		"canvas.execution",
+		attribute.String("canvas.id", execution.WorkflowID.String()),
+		attribute.String("canvas.node.id", execution.NodeID),
+		attribute.String("canvas.execution.id", execution.ID.String()),
+		attribute.String("canvas.component", ref.Component.Name),
+	)
+	defer func() {
+		if execution.Result == models.CanvasNodeExecutionResultFailed {
+			span.SetStatus(codes.Error, execution.ResultMessage)
+		}
+		span.End()
+	}()
+
+	//
+	// Point the execution at its own span, so the events it emits,
+	// now or when it finishes later, are nested under it.
+	//
+	if traceParent := runtrace.TraceParent(spanCtx); traceParent != execution.TraceParent {
+		execution.TraceParent = traceParent
+		if err := tx.Model(execution).UpdateColumn("trace_parent", traceParent).Error; err != nil {
+			return fmt.Errorf("failed to update execution trace: %w", err)
+		}
+	}
+
 	inputEvent, err := models.FindCanvasEventInTransaction(tx, execution.EventID)
 	if err != nil {
 		logger.Errorf("failed to find input event: %v", err)
@@ -404,13 +449,13 @@ func (w *NodeExecutor) executeActionNode(
 		BaseURL:        w.baseURL,
 		Configuration:  execution.Configuration.Data(),
 		Data:           input,
-		HTTP:           w.registry.HTTPContextInTransaction(tx),
+		HTTP:           runtrace.WrapHTTP(spanCtx, w.registry.HTTPContextInTransaction(tx)),
 		Metadata:       contexts.NewExecutionMetadataContext(tx, execution),
 		NodeMetadata:   contexts.NewNodeMetadataContext(tx, node),
 		ExecutionState: contexts.NewExecutionStateContext(tx, execution, onNewEvents),
 		Requests:       contexts.NewExecutionRequestContext(tx, execution),
 		Auth:           contexts.NewAuthReader(tx, workflow.OrganizationID, w.authService, nil),
-		Secrets:        contexts.NewSecretsContext(tx, w.registry, workflow.OrganizationID, w.encryptor),
+		Secrets:        contexts.NewSecretsContext(tx, w.registry, workflow.OrganizationID, w.encryptor).WithRun(execution.WorkflowID, execution.RunID),
 		CanvasMemory: contexts.NewCanvasMemoryContext(tx, execution.WorkflowID).
 			WithChangeCallback(func() { onMemoryChanged(execution.WorkflowID) }),
 		Files:       contexts.NewRepositoryFilesContext(w.gitProvider, execution.WorkflowID),
@@ -420,9 +465,11 @@ func (w *NodeExecutor) executeActionNode(
 		Apps:        contexts.NewAppExecutionContext(tx, workflow, node, execution),
 		Runs:        contexts.NewRunExecutionContext(tx, workflow, node, execution).WithPendingRunCreated(onPendingRunCreated),
 		Factory: contexts.NewFactoryContext(tx, workflow, execution).
+			WithBaseURL(w.baseURL).
 			WithWorkOrderUpdated(onFactoryWorkOrderUpdated).
 			WithWorkOrderNotification(onFactoryWorkOrderNotification),
-		Usage: contexts.NewUsageContext(workflow.OrganizationID, execution),
+		Usage: contexts.NewUsageContext(workflow.OrganizationID, execution).
+			WithRecordedCallback(func() { onUsageRecorded(execution.RunID) }),
 	}
 
 	if node.AppInstallationID != nil {
@@ -23,7 +23,9 @@ import (
 	pb "github.com/superplanehq/superplane/pkg/protos/canvases"
 	"github.com/superplanehq/superplane/pkg/registry"
 	"github.com/superplanehq/superplane/pkg/telemetry"
+	"github.com/superplanehq/superplane/pkg/telemetry/runtrace"
 	"github.com/superplanehq/superplane/pkg/workers/contexts"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type NodeQueueWorker struct {
@@ -421,13 +423,22 @@ func (w *NodeQueueWorker) dispatchQueueItem(
 	logger = logging.WithQueueItem(logger, *item)
 	logger.Info("Processing queue item")
 
+	spanCtx, span := runtrace.StartSpan(
+		item.TraceParent,
+		"canvas.queue_item.dispatch",
+		attribute.String("canvas.id", item.WorkflowID.String()),
+		attribute.String("canvas.node.id", item.NodeID),
+		attribute.String("canvas.queue_item.id", item.ID.String()),
+	)
+	defer span.End()
+
 	configFields, err := w.configurationFieldsForNode(node)
 	if err != nil {
 		return err
 	}
 
 	ctx, err := contexts.BuildProcessQueueContext(
-		w.registry.HTTPContextInTransaction(tx),
+		runtrace.WrapHTTP(spanCtx, w.registry.HTTPContextInTransaction(tx)),
 		tx,
 		node,
 		item,
@@ -615,6 +626,7 @@ func (w *NodeQueueWorker) handleNodeConfigurationError(tx *gorm.DB, configErr *c
 		RunID:               configErr.QueueItem.RunID,
 		EventID:             configErr.Event.ID,
 		PreviousExecutionID: configErr.Event.ExecutionID,
+		TraceParent:         configErr.QueueItem.TraceParent,
 		State:               models.CanvasNodeExecutionStateFinished,
 		Configuration:       configErr.Node.Configuration,
 		Result:              models.CanvasNodeExecutionResultFailed,
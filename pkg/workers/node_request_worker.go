@@ -20,7 +20,9 @@ import (
 	"github.com/superplanehq/superplane/pkg/models"
 	"github.com/superplanehq/superplane/pkg/registry"
 	"github.com/superplanehq/superplane/pkg/telemetry"
+	"github.com/superplanehq/superplane/pkg/telemetry/runtrace"
 	"github.com/superplanehq/superplane/pkg/workers/contexts"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type NodeRequestWorker struct {
@@ -358,17 +360,25 @@ func (w *NodeRequestWorker) invokeExecutionComponentHook(
 		return fmt.Errorf("workflow not found: %w", err)
 	}
 
+	spanCtx, span := runtrace.StartSpan(
+		execution.TraceParent,
+		"canvas.execution.hook",
+		attribute.String("canvas.execution.id", execution.ID.String()),
+		attribute.String("canvas.hook", spec.InvokeAction.ActionName),
+	)
+	defer span.End()
+
 	logger = logging.WithExecution(logger, execution)
 	hookCtx := core.ActionHookContext{
 		Name:           spec.InvokeAction.ActionName,
 		Configuration:  execution.Configuration.Data(),
 		Parameters:     spec.InvokeAction.Parameters,
-		HTTP:           w.registry.HTTPContextInTransaction(tx),
+		HTTP:           runtrace.WrapHTTP(spanCtx, w.registry.HTTPContextInTransaction(tx)),
 		Metadata:       contexts.NewExecutionMetadataContext(tx, execution),
 		ExecutionState: contexts.NewExecutionStateContext(tx, execution, onNewEvents),
 		Requests:       contexts.NewExecutionRequestContext(tx, execution),
 		Auth:           contexts.NewAuthReader(tx, workflow.OrganizationID, w.authService, nil),
-		Secrets:        contexts.NewSecretsContext(tx, w.registry, workflow.OrganizationID, w.encryptor),
+		Secrets:        contexts.NewSecretsContext(tx, w.registry, workflow.OrganizationID, w.encryptor).WithRun(execution.WorkflowID, execution.RunID),
 		Files:          contexts.NewRepositoryFilesContextInTransaction(w.gitProvider, execution.WorkflowID, tx),
 		Runs:           runCancellations.Bind(contexts.NewRunExecutionContext(tx, workflow, node, execution)),
 	}
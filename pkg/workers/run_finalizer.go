@@ -271,6 +271,8 @@ func (w *RunFinalizer) consumeExecutionFinished(delivery tackle.Delivery) error
 		return err
 	}
 
+	recordExecutionFinishedMetrics(w.logger, execution)
+
 	return w.finalizeRun(workflowID, execution.RunID, runFinalizerTriggerExecutionFinished)
 }
 
@@ -355,13 +357,16 @@ func (w *RunFinalizer) finalizeRun(workflowID, runID uuid.UUID, trigger string)
 	}
 
 	var finalized bool
+	var finishedRun *models.CanvasRun
 	var skippedAsFinished bool
 	var nextFactoryLineRuns []factoryLinePendingRun
 	var factoryOrderUpdates []factoryWorkOrderUpdate
+	var finishedStep *models.FactoryWorkOrderExecution
 	err := database.Conn().Transaction(func(tx *gorm.DB) error {
 		var skipReason string
 		var err error
-		finalized, skipReason, err = w.maybeFinalizeRun(tx, runID, trigger, eventCollector, executionCollector)
+		finishedRun, skipReason, err = w.maybeFinalizeRun(tx, runID, trigger, eventCollector, executionCollector)
+		finalized = finishedRun != nil
 		if skipReason != "" {
 			outcome = executorOutcomeSkipped
 			reason = skipReason
@@ -374,7 +379,7 @@ func (w *RunFinalizer) finalizeRun(workflowID, runID uuid.UUID, trigger string)
 			return nil
 		}
 
-		nextFactoryLineRuns, factoryOrderUpdates, err = w.executeNextFactoryLineStep(tx, runID)
+		nextFactoryLineRuns, factoryOrderUpdates, finishedStep, err = w.executeNextFactoryLineStep(tx, runID)
 		return err
 	})
 
@@ -397,6 +402,7 @@ func (w *RunFinalizer) finalizeRun(workflowID, runID uuid.UUID, trigger string)
 	}
 
 	logger.Info("Run finalized")
+	recordRunFinishedMetrics(logger, finishedRun, finishedStep)
 
 	if err := messages.NewCanvasRunMessage(workflowID.String(), runID.String()).Publish(); err != nil {
 		w.logger.WithError(err).Warnf("Failed to publish run state message for run %s", runID)
@@ -437,19 +443,21 @@ func (w *RunFinalizer) finalizeRun(workflowID, runID uuid.UUID, trigger string)
 	return nil
 }
 
-func (w *RunFinalizer) maybeFinalizeRun(tx *gorm.DB, runID uuid.UUID, trigger string, eventCollector func([]models.CanvasEvent), executionCollector func([]models.CanvasNodeExecution)) (bool, string, error) {
+// maybeFinalizeRun returns the finished run, or nil
+// with the reason why the run was not finalized.
+func (w *RunFinalizer) maybeFinalizeRun(tx *gorm.DB, runID uuid.UUID, trigger string, eventCollector func([]models.CanvasEvent), executionCollector func([]models.CanvasNodeExecution)) (*models.CanvasRun, string, error) {
 	run, err := models.LockCanvasRunInTransaction(tx, runID)
 	if err != nil {
-		return false, "", err
+		return nil, "", err
 	}
 
 	if run.State == models.CanvasRunStateFinished {
-		return false, runFinalizerReasonAlreadyFinished, nil
+		return nil, runFinalizerReasonAlreadyFinished, nil
 	}
 
 	openWork, err := run.FindOpenWork(tx)
 	if err != nil {
-		return false, "", err
+		return nil, "", err
 	}
 
 	if openWork.HasActiveExecutions || openWork.HasQueueItems || openWork.HasPendingEvents {
@@ -458,15 +466,15 @@ func (w *RunFinalizer) maybeFinalizeRun(tx *gorm.DB, runID uuid.UUID, trigger st
 			// LIMIT N. Bump updated_at here so a run that is still open is pushed to
 			// the back of the queue instead of being retried on every tick.
 			now := time.Now()
-			return false, runFinalizerReasonOpenWork, tx.Model(run).Update("updated_at", &now).Error
+			return nil, runFinalizerReasonOpenWork, tx.Model(run).Update("updated_at", &now).Error
 		}
 
-		return false, runFinalizerReasonOpenWork, nil
+		return nil, runFinalizerReasonOpenWork, nil
 	}
 
 	result, err := run.CalculateResult(tx)
 	if err != nil {
-		return false, "", err
+		return nil, "", err
 	}
 
 	now := time.Now()
@@ -484,7 +492,7 @@ func (w *RunFinalizer) maybeFinalizeRun(tx *gorm.DB, runID uuid.UUID, trigger st
 		Error
 
 	if err != nil {
-		return false, "", err
+		return nil, "", err
 	}
 
 	err = NewRunCallbackDispatcher(tx, w.registry, run).
@@ -493,10 +501,10 @@ func (w *RunFinalizer) maybeFinalizeRun(tx *gorm.DB, runID uuid.UUID, trigger st
 		DispatchFinished()
 
 	if err != nil {
-		return false, "", err
+		return nil, "", err
 	}
 
-	return true, "", nil
+	return run, "", nil
 }
 
 type factoryLinePendingRun struct {
@@ -533,21 +541,21 @@ func factoryAdmissionOutcomes(admitted []*models.FactoryLineStepResult) ([]facto
 	return pendingRuns, orderUpdates
 }
 
-func (w *RunFinalizer) executeNextFactoryLineStep(tx *gorm.DB, runID uuid.UUID) ([]factoryLinePendingRun, []factoryWorkOrderUpdate, error) {
+func (w *RunFinalizer) executeNextFactoryLineStep(tx *gorm.DB, runID uuid.UUID) ([]factoryLinePendingRun, []factoryWorkOrderUpdate, *models.FactoryWorkOrderExecution, error) {
 	//
 	// Finish current factory work order execution.
 	//
 	execution, err := models.FindWorkOrderExecutionByRunID(tx, runID)
 	if err != nil {
 		if errors.Is(err, models.ErrFactoryWorkOrderExecutionNotFound) {
-			return nil, nil, nil
+			return nil, nil, nil, nil
 		}
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	run, err := models.LockCanvasRunInTransaction(tx, runID)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Fill cached spend even when this step is already finished. A previous
@@ -558,11 +566,11 @@ func (w *RunFinalizer) executeNextFactoryLineStep(tx *gorm.DB, runID uuid.UUID)
 	}
 
 	if execution.Status == models.FactoryWorkOrderExecutionStatusFinished {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 
 	if err := execution.MarkFinished(tx, run.Result); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	//
@@ -572,7 +580,7 @@ func (w *RunFinalizer) executeNextFactoryLineStep(tx *gorm.DB, runID uuid.UUID)
 	//
 	admitted, err := models.AdmitQueuedForStep(tx, execution.LineID, execution.StepIndex)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	pendingRuns, orderUpdates := factoryAdmissionOutcomes(admitted)
@@ -584,21 +592,21 @@ func (w *RunFinalizer) executeNextFactoryLineStep(tx *gorm.DB, runID uuid.UUID)
 	//
 	dispatch, err := models.FindWorkOrderLineDispatch(tx, execution.LineDispatchID)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	if run.Result != models.CanvasRunResultPassed {
-		return pendingRuns, orderUpdates, dispatch.Finish(tx, run.Result)
+		return pendingRuns, orderUpdates, execution, dispatch.Finish(tx, run.Result)
 	}
 
 	factory, err := models.FindFactory(tx, execution.OrganizationID, execution.FactoryID)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	workOrder, err := factory.FindWorkOrder(tx, execution.WorkOrderID)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// The order closed while this step was running. The traversal is
@@ -606,17 +614,17 @@ func (w *RunFinalizer) executeNextFactoryLineStep(tx *gorm.DB, runID uuid.UUID)
 	// doesn't keep a zombie active dispatch (which would block any
 	// re-dispatch after a reopen).
 	if !workOrder.IsOpen() {
-		return pendingRuns, orderUpdates, dispatch.Finish(tx, models.CanvasRunResultCancelled)
+		return pendingRuns, orderUpdates, execution, dispatch.Finish(tx, models.CanvasRunResultCancelled)
 	}
 
 	nextIndex := execution.StepIndex + 1
 	if nextIndex >= len(dispatch.Steps) {
-		return pendingRuns, orderUpdates, dispatch.Finish(tx, models.CanvasRunResultPassed)
+		return pendingRuns, orderUpdates, execution, dispatch.Finish(tx, models.CanvasRunResultPassed)
 	}
 
 	result, err := dispatch.EnqueueOrStartStep(tx, workOrder, nextIndex)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	if result.Run != nil {
@@ -626,5 +634,5 @@ func (w *RunFinalizer) executeNextFactoryLineStep(tx *gorm.DB, runID uuid.UUID)
 		})
 	}
 
-	return pendingRuns, orderUpdates, nil
+	return pendingRuns, orderUpdates, execution, nil
 }
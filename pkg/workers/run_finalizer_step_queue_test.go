@@ -111,7 +111,7 @@ func advanceFactoryLineWithUpdates(
 	var updates []factoryWorkOrderUpdate
 	require.NoError(t, database.Conn().Transaction(func(tx *gorm.DB) error {
 		var err error
-		pending, updates, err = finalizer.executeNextFactoryLineStep(tx, runID)
+		pending, updates, _, err = finalizer.executeNextFactoryLineStep(tx, runID)
 		return err
 	}))
 	return pending, updates
@@ -0,0 +1,139 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/superplanehq/superplane/pkg/core"
+	"github.com/superplanehq/superplane/pkg/crypto"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/logging"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/registry"
+	"github.com/superplanehq/superplane/pkg/workers/contexts"
+)
+
+const (
+	runLeaseRevokerTickEvery   = 30 * time.Second
+	runLeaseRevokerBatchSize   = 100
+	runLeaseRevokerMaxAttempts = 10
+)
+
+// RunLeaseRevoker revokes the leases integrations issued to runs while
+// resolving secrets, once those runs finish. A lease that can't be revoked
+// is retried on the next ticks, and dropped after a few attempts: it
+// expires with its TTL anyway.
+type RunLeaseRevoker struct {
+	logger      *log.Entry
+	registry    *registry.Registry
+	encryptor   crypto.Encryptor
+	batchSize   int
+	maxAttempts int
+}
+
+func NewRunLeaseRevoker(registry *registry.Registry, encryptor crypto.Encryptor) *RunLeaseRevoker {
+	return &RunLeaseRevoker{
+		logger:      log.WithFields(log.Fields{"worker": "RunLeaseRevoker"}),
+		registry:    registry,
+		encryptor:   encryptor,
+		batchSize:   runLeaseRevokerBatchSize,
+		maxAttempts: runLeaseRevokerMaxAttempts,
+	}
+}
+
+func (w *RunLeaseRevoker) Start(ctx context.Context) {
+	ticker := time.NewTicker(runLeaseRevokerTickEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *RunLeaseRevoker) tick(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	leases, err := models.ListRunIntegrationLeasesToRevoke(database.Conn(), w.batchSize)
+	if err != nil {
+		w.logger.Errorf("Error listing leases to revoke: %v", err)
+		return
+	}
+
+	for _, lease := range leases {
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger := w.logger.WithFields(log.Fields{
+			"run_id":         lease.RunID,
+			"integration_id": lease.IntegrationID,
+			"source":         lease.Source,
+		})
+
+		if err := w.revoke(lease); err != nil {
+			w.recordFailure(logger, lease, err)
+			continue
+		}
+
+		if err := models.DeleteRunIntegrationLease(database.Conn(), lease.ID); err != nil {
+			logger.Errorf("Error deleting revoked lease: %v", err)
+		}
+	}
+}
+
+func (w *RunLeaseRevoker) revoke(lease models.RunIntegrationLease) error {
+	integration, err := models.FindUnscopedIntegration(lease.IntegrationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("find integration: %w", err)
+	}
+
+	integrationImpl, err := w.registry.GetIntegration(integration.AppName)
+	if err != nil {
+		return err
+	}
+
+	revoker, ok := registry.UnwrapIntegration(integrationImpl).(core.IntegrationLeaseRevoker)
+	if !ok {
+		return fmt.Errorf("integration %s does not revoke leases", integration.AppName)
+	}
+
+	tx := database.Conn()
+	return revoker.RevokeLease(core.IntegrationLeaseContext{
+		Logger:      logging.ForIntegration(*integration),
+		HTTP:        w.registry.HTTPContextInTransaction(tx),
+		Integration: contexts.NewIntegrationContext(tx, nil, integration, w.encryptor, w.registry, nil),
+	}, lease.LeaseID)
+}
+
+func (w *RunLeaseRevoker) recordFailure(logger *log.Entry, lease models.RunIntegrationLease, revokeErr error) {
+	attempts, err := models.IncrementRunIntegrationLeaseAttempts(database.Conn(), lease.ID)
+	if err != nil {
+		logger.Errorf("Error recording failed lease revocation: %v", err)
+		return
+	}
+
+	if attempts < w.maxAttempts {
+		logger.Warnf("Error revoking lease, attempt %d: %v", attempts, revokeErr)
+		return
+	}
+
+	logger.Errorf("Giving up revoking lease after %d attempts: %v", attempts, revokeErr)
+	if err := models.DeleteRunIntegrationLease(database.Conn(), lease.ID); err != nil {
+		logger.Errorf("Error deleting lease: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/test/support"
+)
+
+func Test__RunLeaseRevoker_RevokesLeasesOfFinishedRuns(t *testing.T) {
+	r := support.Setup(t)
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{}, []models.Edge{})
+
+	finishedRun, err := models.CreateCanvasRunInTransaction(database.Conn(), canvas.ID, "node-1", models.CanvasRunStateFinished, models.CanvasRunResultPassed)
+	require.NoError(t, err)
+	runningRun, err := models.CreateCanvasRunInTransaction(database.Conn(), canvas.ID, "node-1", models.CanvasRunStateStarted, "")
+	require.NoError(t, err)
+
+	//
+	// The integration no longer exists, so there is nothing left to revoke
+	// the leases with: leases of finished runs are dropped.
+	//
+	integrationID := uuid.New()
+	finishedLease, err := models.CreateRunIntegrationLease(canvas.ID, finishedRun.ID, integrationID, "database/creds/app/a1", "database/creds/app")
+	require.NoError(t, err)
+	runningLease, err := models.CreateRunIntegrationLease(canvas.ID, runningRun.ID, integrationID, "database/creds/app/b2", "database/creds/app")
+	require.NoError(t, err)
+
+	NewRunLeaseRevoker(r.Registry, r.Encryptor).tick(t.Context())
+
+	assert.Equal(t, int64(0), countRowsByID(t, &models.RunIntegrationLease{}, finishedLease.ID))
+	assert.Equal(t, int64(1), countRowsByID(t, &models.RunIntegrationLease{}, runningLease.ID))
+}
@@ -0,0 +1,137 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/runnerstorage"
+)
+
+const (
+	runnerStorageCleanupTickEvery           = 1 * time.Minute
+	runnerCacheUnusedDays                   = 7
+	runnerStorageCleanupDeleteBatchSize     = 50
+	runnerStorageCleanupMaxDeletesPerTick   = 500
+	runnerStorageCleanupPauseBetweenBatches = 50 * time.Millisecond
+)
+
+// RunnerStorageCleanupWorker deletes runner artifacts past their expiry,
+// caches that no task restored for a week, and both for deleted canvases.
+// Rows are deleted first and their blobs after the batch commits, so a
+// failure can only leave an orphaned blob, never a row without one.
+type RunnerStorageCleanupWorker struct {
+	logger              *log.Entry
+	store               runnerstorage.Store
+	cacheUnusedDays     int
+	deleteBatchSize     int
+	maxDeletesPerTick   int
+	pauseBetweenBatches time.Duration
+}
+
+func NewRunnerStorageCleanupWorker(store runnerstorage.Store) *RunnerStorageCleanupWorker {
+	return &RunnerStorageCleanupWorker{
+		logger:              log.WithFields(log.Fields{"worker": "RunnerStorageCleanupWorker"}),
+		store:               store,
+		cacheUnusedDays:     runnerCacheUnusedDays,
+		deleteBatchSize:     runnerStorageCleanupDeleteBatchSize,
+		maxDeletesPerTick:   runnerStorageCleanupMaxDeletesPerTick,
+		pauseBetweenBatches: runnerStorageCleanupPauseBetweenBatches,
+	}
+}
+
+func (w *RunnerStorageCleanupWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(runnerStorageCleanupTickEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *RunnerStorageCleanupWorker) tick(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	startedAt := time.Now()
+	artifacts, err := w.deleteInBatches(ctx, func(tx *gorm.DB, limit int) ([]string, error) {
+		return models.DeleteExpiredRunnerArtifacts(tx, startedAt, limit)
+	})
+	if err != nil {
+		w.logger.Errorf("Error deleting expired runner artifacts: %v", err)
+		return
+	}
+
+	unusedSince := startedAt.AddDate(0, 0, -w.cacheUnusedDays)
+	caches, err := w.deleteInBatches(ctx, func(tx *gorm.DB, limit int) ([]string, error) {
+		return models.DeleteUnusedRunnerCaches(tx, unusedSince, limit)
+	})
+	if err != nil {
+		w.logger.Errorf("Error deleting unused runner caches: %v", err)
+		return
+	}
+
+	if artifacts == 0 && caches == 0 {
+		return
+	}
+
+	w.logger.WithFields(log.Fields{
+		"artifacts":   artifacts,
+		"caches":      caches,
+		"duration_ms": time.Since(startedAt).Milliseconds(),
+	}).Info("Deleted runner artifacts and caches")
+}
+
+func (w *RunnerStorageCleanupWorker) deleteInBatches(ctx context.Context, deleteBatch func(tx *gorm.DB, limit int) ([]string, error)) (int64, error) {
+	totalDeleted := int64(0)
+
+	for totalDeleted < int64(w.maxDeletesPerTick) {
+		budget := min(w.deleteBatchSize, w.maxDeletesPerTick-int(totalDeleted))
+
+		var storageKeys []string
+		err := database.Conn().Transaction(func(tx *gorm.DB) error {
+			keys, err := deleteBatch(tx, budget)
+			if err != nil {
+				return err
+			}
+			storageKeys = keys
+			return nil
+		})
+		if err != nil {
+			return totalDeleted, fmt.Errorf("delete batch: %w", err)
+		}
+
+		w.deleteBlobs(ctx, storageKeys)
+
+		totalDeleted += int64(len(storageKeys))
+		if len(storageKeys) == 0 {
+			return totalDeleted, nil
+		}
+
+		if w.pauseBetweenBatches > 0 {
+			time.Sleep(w.pauseBetweenBatches)
+		}
+	}
+
+	return totalDeleted, nil
+}
+
+func (w *RunnerStorageCleanupWorker) deleteBlobs(ctx context.Context, storageKeys []string) {
+	ctx = context.WithoutCancel(ctx)
+	for _, storageKey := range storageKeys {
+		if err := w.store.Delete(ctx, storageKey); err != nil {
+			w.logger.WithError(err).Warnf("Error deleting runner storage blob %s", storageKey)
+		}
+	}
+}
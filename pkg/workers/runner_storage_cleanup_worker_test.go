@@ -0,0 +1,103 @@
+package workers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/runnerstorage"
+	"github.com/superplanehq/superplane/test/support"
+)
+
+func Test__RunnerStorageCleanupWorker_DeletesExpiredArtifactsAndUnusedCaches(t *testing.T) {
+	r := support.Setup(t)
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{}, []models.Edge{})
+	store := runnerstorage.NewMemoryStore()
+
+	expiredArtifact := createRunnerArtifactForCleanup(t, store, r.Organization.ID, canvas.ID, "expired", time.Now().Add(-time.Hour))
+	liveArtifact := createRunnerArtifactForCleanup(t, store, r.Organization.ID, canvas.ID, "live", time.Now().Add(time.Hour))
+
+	staleCache := createRunnerCacheForCleanup(t, store, r.Organization.ID, canvas.ID, "stale")
+	require.NoError(t, database.Conn().Model(staleCache).Update("last_used_at", time.Now().AddDate(0, 0, -8)).Error)
+	freshCache := createRunnerCacheForCleanup(t, store, r.Organization.ID, canvas.ID, "fresh")
+
+	worker := NewRunnerStorageCleanupWorker(store)
+	worker.pauseBetweenBatches = 0
+	worker.tick(t.Context())
+
+	assert.Equal(t, int64(0), countRowsByID(t, &models.RunnerArtifact{}, expiredArtifact.ID))
+	assert.Equal(t, int64(1), countRowsByID(t, &models.RunnerArtifact{}, liveArtifact.ID))
+	assert.Equal(t, int64(0), countRowsByID(t, &models.RunnerCache{}, staleCache.ID))
+	assert.Equal(t, int64(1), countRowsByID(t, &models.RunnerCache{}, freshCache.ID))
+	assert.ElementsMatch(t, []string{liveArtifact.StorageKey, freshCache.StorageKey}, store.Keys())
+}
+
+func Test__RunnerStorageCleanupWorker_DeletesStorageOfDeletedCanvases(t *testing.T) {
+	r := support.Setup(t)
+	canvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{}, []models.Edge{})
+	deletedCanvas, _ := support.CreateCanvas(t, r.Organization.ID, r.User, []models.CanvasNode{}, []models.Edge{})
+	store := runnerstorage.NewMemoryStore()
+
+	artifact := createRunnerArtifactForCleanup(t, store, r.Organization.ID, canvas.ID, "build", time.Now().Add(time.Hour))
+	cache := createRunnerCacheForCleanup(t, store, r.Organization.ID, canvas.ID, "deps")
+	createRunnerArtifactForCleanup(t, store, r.Organization.ID, deletedCanvas.ID, "build", time.Now().Add(time.Hour))
+	createRunnerCacheForCleanup(t, store, r.Organization.ID, deletedCanvas.ID, "deps")
+
+	require.NoError(t, deletedCanvas.SoftDelete())
+
+	worker := NewRunnerStorageCleanupWorker(store)
+	worker.pauseBetweenBatches = 0
+	worker.tick(t.Context())
+
+	assert.ElementsMatch(t, []string{artifact.StorageKey, cache.StorageKey}, store.Keys())
+}
+
+func createRunnerArtifactForCleanup(t *testing.T, store runnerstorage.Store, organizationID, canvasID uuid.UUID, name string, expiresAt time.Time) *models.RunnerArtifact {
+	t.Helper()
+
+	storageKey := runnerstorage.NewArtifactKey(canvasID)
+	require.NoError(t, store.Put(t.Context(), storageKey, strings.NewReader("data")))
+
+	artifact := models.RunnerArtifact{
+		OrganizationID: organizationID,
+		CanvasID:       canvasID,
+		RunID:          uuid.New(),
+		ExecutionID:    uuid.New(),
+		NodeID:         "node-1",
+		Name:           name,
+		SizeBytes:      4,
+		SHA256:         "sha",
+		StorageKey:     storageKey,
+		ExpiresAt:      &expiresAt,
+	}
+	require.NoError(t, database.Conn().Create(&artifact).Error)
+	return &artifact
+}
+
+func createRunnerCacheForCleanup(t *testing.T, store runnerstorage.Store, organizationID, canvasID uuid.UUID, key string) *models.RunnerCache {
+	t.Helper()
+
+	storageKey := runnerstorage.NewCacheKey(canvasID)
+	require.NoError(t, store.Put(t.Context(), storageKey, strings.NewReader(key)))
+
+	cache, _, err := models.SaveRunnerCache(organizationID, canvasID, key, models.RunnerBlob{
+		StorageKey: storageKey,
+		SizeBytes:  int64(len(key)),
+		SHA256:     "sha",
+	}, 0)
+	require.NoError(t, err)
+	return cache
+}
+
+func countRowsByID(t *testing.T, model any, id uuid.UUID) int64 {
+	t.Helper()
+
+	var count int64
+	require.NoError(t, database.Conn().Model(model).Where("id = ?", id).Count(&count).Error)
+	return count
+}
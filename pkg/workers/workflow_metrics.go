@@ -0,0 +1,116 @@
+package workers
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/superplanehq/superplane/pkg/database"
+	"github.com/superplanehq/superplane/pkg/models"
+	"github.com/superplanehq/superplane/pkg/telemetry"
+)
+
+const approvalComponentName = "approval"
+
+//
+// Workflow metrics for the Prometheus scrape endpoint.
+// They are labeled by organization and component. Callers pass in
+// what they already loaded, and the rest comes from in-memory caches,
+// so recording a metric doesn't cost a query after the first one
+// for a canvas or node.
+//
+
+type canvasNodeKey struct {
+	canvasID uuid.UUID
+	nodeID   string
+}
+
+// nodeComponents caches the component of each canvas node.
+// Nodes keep their component for as long as they exist.
+var nodeComponents sync.Map
+
+func recordRunStartedMetrics(organizationID, canvasID uuid.UUID, nodeID string) {
+	if !telemetry.PrometheusMetricsEnabled() {
+		return
+	}
+
+	telemetry.RecordWorkflowRunStarted(organizationID.String(), canvasID.String(), nodeID)
+}
+
+func recordRunFinishedMetrics(logger *log.Entry, run *models.CanvasRun, finishedStep *models.FactoryWorkOrderExecution) {
+	if !telemetry.PrometheusMetricsEnabled() {
+		return
+	}
+
+	//
+	// Runs started by a factory line step finish that step,
+	// which is what work order throughput is measured by.
+	//
+	if finishedStep != nil {
+		organizationID := finishedStep.OrganizationID.String()
+		telemetry.RecordWorkflowRunFinished(organizationID, run.WorkflowID.String(), run.NodeID, run.Result)
+		telemetry.RecordWorkOrderStepFinished(organizationID, run.WorkflowID.String(), run.Result)
+		return
+	}
+
+	organizationID, err := models.FindCanvasOrganizationID(run.WorkflowID)
+	if err != nil {
+		logger.WithError(err).Warnf("Failed to find organization for canvas %s", run.WorkflowID)
+		return
+	}
+
+	telemetry.RecordWorkflowRunFinished(organizationID.String(), run.WorkflowID.String(), run.NodeID, run.Result)
+}
+
+func recordExecutionFinishedMetrics(logger *log.Entry, execution *models.CanvasNodeExecution) {
+	if !telemetry.PrometheusMetricsEnabled() || execution.CreatedAt == nil || execution.UpdatedAt == nil {
+		return
+	}
+
+	organizationID, err := models.FindCanvasOrganizationID(execution.WorkflowID)
+	if err != nil {
+		logger.WithError(err).Warnf("Failed to find organization for canvas %s", execution.WorkflowID)
+		return
+	}
+
+	component := findNodeComponent(execution.WorkflowID, execution.NodeID)
+	duration := execution.UpdatedAt.Sub(*execution.CreatedAt)
+	telemetry.RecordWorkflowNodeExecutionFinished(
+		organizationID.String(),
+		execution.WorkflowID.String(),
+		execution.NodeID,
+		component,
+		execution.Result,
+		duration,
+	)
+
+	if component == approvalComponentName {
+		telemetry.RecordWorkflowApprovalWait(
+			organizationID.String(),
+			execution.WorkflowID.String(),
+			execution.NodeID,
+			execution.Result,
+			duration,
+		)
+	}
+}
+
+func findNodeComponent(canvasID uuid.UUID, nodeID string) string {
+	key := canvasNodeKey{canvasID: canvasID, nodeID: nodeID}
+	if component, ok := nodeComponents.Load(key); ok {
+		return component.(string)
+	}
+
+	node, err := models.FindCanvasNode(database.Conn(), canvasID, nodeID)
+	if err != nil {
+		return ""
+	}
+
+	component := ""
+	if node.Ref.Data().Component != nil {
+		component = node.Ref.Data().Component.Name
+	}
+
+	nodeComponents.Store(key, component)
+	return component
+}
@@ -52,12 +52,18 @@ START_WEBHOOK_PROVISIONER="${START_WEBHOOK_PROVISIONER:-yes}"
 START_WEBHOOK_CLEANUP_WORKER="${START_WEBHOOK_CLEANUP_WORKER:-yes}"
 START_CANVAS_CLEANUP_WORKER="${START_CANVAS_CLEANUP_WORKER:-yes}"
 START_NODE_REQUEST_CLEANUP_WORKER="${START_NODE_REQUEST_CLEANUP_WORKER:-yes}"
+START_RUN_LEASE_REVOKER="${START_RUN_LEASE_REVOKER:-yes}"
+START_RUNNER_STORAGE_CLEANUP_WORKER="${START_RUNNER_STORAGE_CLEANUP_WORKER:-yes}"
+START_CANVAS_MEMORY_CLEANUP_WORKER="${START_CANVAS_MEMORY_CLEANUP_WORKER:-yes}"
+START_GIT_MIRROR_WORKER="${START_GIT_MIRROR_WORKER:-yes}"
 START_REPOSITORY_PROVISIONER="${START_REPOSITORY_PROVISIONER:-yes}"
 NO_ENCRYPTION="${NO_ENCRYPTION:-yes}"
 SUPERPLANE_BEACON_ENABLED="${SUPERPLANE_BEACON_ENABLED:-yes}"
 SUPERPLANE_INSTALLATION_TYPE="${SUPERPLANE_INSTALLATION_TYPE:-demo}"
 GIT_STORAGE_PROVIDER="${GIT_STORAGE_PROVIDER:-supergit}"
 GIT_STORAGE_SUPERGIT_BASE_URL="${GIT_STORAGE_SUPERGIT_BASE_URL:-http://127.0.0.1:8080/api}"
+RUNNER_STORAGE_PROVIDER="${RUNNER_STORAGE_PROVIDER:-local}"
+RUNNER_STORAGE_LOCAL_PATH="${RUNNER_STORAGE_LOCAL_PATH:-/app/data/runner-storage}"
 SUPERGIT_ROOT="${SUPERGIT_ROOT:-/app/data/supergit/repos}"
 SUPERGIT_PORT="${SUPERGIT_PORT:-8080}"
 SUPERGIT_DEFAULT_BRANCH="${SUPERGIT_DEFAULT_BRANCH:-main}"
@@ -152,6 +158,10 @@ export START_WEBHOOK_PROVISIONER="${START_WEBHOOK_PROVISIONER}"
 export START_WEBHOOK_CLEANUP_WORKER="${START_WEBHOOK_CLEANUP_WORKER}"
 export START_CANVAS_CLEANUP_WORKER="${START_CANVAS_CLEANUP_WORKER}"
 export START_NODE_REQUEST_CLEANUP_WORKER="${START_NODE_REQUEST_CLEANUP_WORKER}"
+export START_RUN_LEASE_REVOKER="${START_RUN_LEASE_REVOKER}"
+export START_RUNNER_STORAGE_CLEANUP_WORKER="${START_RUNNER_STORAGE_CLEANUP_WORKER}"
+export START_CANVAS_MEMORY_CLEANUP_WORKER="${START_CANVAS_MEMORY_CLEANUP_WORKER}"
+export START_GIT_MIRROR_WORKER="${START_GIT_MIRROR_WORKER}"
 export START_REPOSITORY_PROVISIONER="${START_REPOSITORY_PROVISIONER}"
 export ENCRYPTION_KEY="${ENCRYPTION_KEY}"
 export JWT_SECRET="${JWT_SECRET}"
@@ -162,6 +172,8 @@ export SUPERPLANE_BEACON_ENABLED="${SUPERPLANE_BEACON_ENABLED}"
 export SUPERPLANE_INSTALLATION_TYPE="${SUPERPLANE_INSTALLATION_TYPE}"
 export GIT_STORAGE_PROVIDER="${GIT_STORAGE_PROVIDER}"
 export GIT_STORAGE_SUPERGIT_BASE_URL="${GIT_STORAGE_SUPERGIT_BASE_URL}"
+export RUNNER_STORAGE_PROVIDER="${RUNNER_STORAGE_PROVIDER}"
+export RUNNER_STORAGE_LOCAL_PATH="${RUNNER_STORAGE_LOCAL_PATH}"
 export SUPERGIT_ROOT="${SUPERGIT_ROOT}"
 export SUPERGIT_PORT="${SUPERGIT_PORT}"
 export SUPERGIT_DEFAULT_BRANCH="${SUPERGIT_DEFAULT_BRANCH}"
@@ -724,6 +724,8 @@ type RunExecutionContext struct {
 	LastCreateParams *core.RunCreationParams
 	AddErrorCalls    []string
 	AddErrorErr      error
+	RunLeases        []core.IntegrationLease
+	LeasesErr        error
 }
 
 func (c *RunExecutionContext) Create(params core.RunCreationParams) (*core.Run, error) {
@@ -753,3 +755,21 @@ func (c *RunExecutionContext) AddError(message string) error {
 	c.AddErrorCalls = append(c.AddErrorCalls, message)
 	return c.AddErrorErr
 }
+
+func (c *RunExecutionContext) Leases() ([]core.IntegrationLease, error) {
+	return c.RunLeases, c.LeasesErr
+}
+
+type IntegrationLeaseRecorder struct {
+	Leases    []core.IntegrationLease
+	RecordErr error
+}
+
+func (r *IntegrationLeaseRecorder) Record(lease core.IntegrationLease) error {
+	if r.RecordErr != nil {
+		return r.RecordErr
+	}
+
+	r.Leases = append(r.Leases, lease)
+	return nil
+}
@@ -1,14 +1,57 @@
 import { AlertTriangle } from "lucide-react";
+import { useState } from "react";
 import { Button } from "@/components/ui/button";
 
+type Decision = "confirmed" | "declined";
+
 interface ConfirmWidgetProps {
   message: string;
   yes: string;
   no: string;
+  // Set when the widget confirms a runtime operation requested by the agent.
+  // The decision is recorded on the operation before the answer is sent, and
+  // the operation only runs once it is confirmed here.
+  confirmationId?: string;
+  organizationId?: string;
   onAction?: (text: string) => void;
 }
 
-export function ConfirmWidget({ message, yes, no, onAction }: ConfirmWidgetProps) {
+export function ConfirmWidget({ message, yes, no, confirmationId, organizationId, onAction }: ConfirmWidgetProps) {
+  const [decision, setDecision] = useState<Decision | null>(null);
+  const [busy, setBusy] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  const answer = async (next: Decision, text: string) => {
+    if (!confirmationId) {
+      onAction?.(text);
+      return;
+    }
+
+    setBusy(true);
+    setError(null);
+    try {
+      const response = await fetch(`/api/v1/agents/operations/${confirmationId}/decision`, {
+        method: "POST",
+        headers: { "Content-Type": "application/json", "x-organization-id": organizationId ?? "" },
+        credentials: "include",
+        body: JSON.stringify({ decision: next }),
+      });
+      if (!response.ok) {
+        setError((await response.text()).trim() || `Request failed: ${response.status}`);
+        return;
+      }
+
+      setDecision(next);
+      onAction?.(text);
+    } catch (err) {
+      setError(err instanceof Error ? err.message : "Request failed");
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  const disabled = busy || decision !== null;
+
   return (
     <div className="my-4 rounded-lg border border-amber-200 bg-amber-50 p-3 dark:border-amber-900/60 dark:bg-amber-950/40">
       <div className="mb-3 flex items-start gap-2">
@@ -16,13 +59,26 @@ export function ConfirmWidget({ message, yes, no, onAction }: ConfirmWidgetProps
         <p className="text-sm text-amber-900 dark:text-amber-100">{message}</p>
       </div>
       <div className="flex gap-2">
-        <Button size="sm" variant="destructive" className="text-xs" onClick={() => onAction?.(yes)}>
+        <Button
+          size="sm"
+          variant="destructive"
+          className="text-xs"
+          disabled={disabled}
+          onClick={() => void answer("confirmed", yes)}
+        >
           {yes}
         </Button>
-        <Button size="sm" variant="outline" className="text-xs" onClick={() => onAction?.(no)}>
+        <Button
+          size="sm"
+          variant="outline"
+          className="text-xs"
+          disabled={disabled}
+          onClick={() => void answer("declined", no)}
+        >
           {no}
         </Button>
       </div>
+      {error ? <p className="mt-2 text-xs text-red-600 dark:text-red-400">{error}</p> : null}
     </div>
   );
 }
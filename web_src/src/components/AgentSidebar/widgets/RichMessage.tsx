@@ -98,7 +98,16 @@ function SegmentRenderer({
     case "buttons":
       return <ButtonsWidget prompt={segment.prompt} items={segment.items} onAction={onAction} />;
     case "confirm":
-      return <ConfirmWidget message={segment.message} yes={segment.yes} no={segment.no} onAction={onAction} />;
+      return (
+        <ConfirmWidget
+          message={segment.message}
+          yes={segment.yes}
+          no={segment.no}
+          confirmationId={segment.confirmationId}
+          organizationId={organizationId}
+          onAction={onAction}
+        />
+      );
     case "chart":
       return <ChartWidget config={segment.config} />;
     case "collapse":
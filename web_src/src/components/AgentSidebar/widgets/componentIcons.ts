@@ -14,6 +14,8 @@ export const BUILTIN_COMPONENT_ICON_SLUGS: Record<string, string> = {
   readMemory: "database",
   updateMemory: "database",
   upsertMemory: "database",
+  incrementMemory: "database",
+  compareAndSwapMemory: "database",
   if: "split",
   http: "globe",
   graphql: "network",
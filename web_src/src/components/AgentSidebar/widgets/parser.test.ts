@@ -38,6 +38,23 @@ no: No
     });
   });
 
+  it("parses confirmation id of a confirm block", () => {
+    const content = `:::confirm
+message: Cancel run 3f2a.
+yes: Cancel run
+no: Keep it running
+confirmation_id: 6b1d2c7e-0a4f-4f7e-9d52-8f3c1a2b4e6d
+:::`;
+    const segments = parseAgentContent(content);
+    expect(segments[0]).toEqual({
+      type: "confirm",
+      message: "Cancel run 3f2a.",
+      yes: "Cancel run",
+      no: "Keep it running",
+      confirmationId: "6b1d2c7e-0a4f-4f7e-9d52-8f3c1a2b4e6d",
+    });
+  });
+
   it("parses steps block", () => {
     const content = `:::steps
 - [x] Done step
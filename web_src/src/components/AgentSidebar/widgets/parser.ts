@@ -4,7 +4,7 @@ import YAML from "js-yaml";
 
 export type MarkdownSegment = { type: "markdown"; content: string };
 export type ButtonsSegment = { type: "buttons"; prompt: string; items: string[] };
-export type ConfirmSegment = { type: "confirm"; message: string; yes: string; no: string };
+export type ConfirmSegment = { type: "confirm"; message: string; yes: string; no: string; confirmationId?: string };
 export type ChartSegment = { type: "chart"; config: ChartConfig };
 export type CollapseSegment = { type: "collapse"; title: string; content: string };
 export type MermaidSegment = { type: "mermaid"; content: string };
@@ -212,6 +212,7 @@ function parseConfirm(raw: string): ConfirmSegment {
       message: parsed.message || raw.trim(),
       yes: parsed.yes || "Yes",
       no: parsed.no || "No",
+      ...(parsed.confirmation_id ? { confirmationId: String(parsed.confirmation_id) } : {}),
     };
   } catch {
     return { type: "confirm", message: raw.trim(), yes: "Yes", no: "No" };
@@ -117,6 +117,175 @@ export const EXPR_FUNCTIONS: readonly ExprFunction[] = [
       "Returns the work order for this run when dispatched from a factory, exposing id, title, description, factory_id, state, result, source, url, artifacts, and comments (the last three loaded when accessed).",
     example: 'none(order().artifacts, {#.type == "pr"})',
   },
+  // SuperPlane standard library
+  {
+    name: "addDuration",
+    snippet: "addDuration(${1:date}, ${2:duration})",
+    description: "Adds a duration to a date. Dates and durations can be given as strings.",
+    example: 'addDuration("2026-03-17T10:00:00Z", "1d").Day() == 18',
+  },
+  {
+    name: "durationBetween",
+    snippet: "durationBetween(${1:start}, ${2:end})",
+    description: "Returns the duration from start to end. Dates can be given as strings.",
+    example: 'durationBetween("2026-03-17T10:00:00Z", "2026-03-17T10:30:00Z").Minutes() == 30',
+  },
+  {
+    name: "fromBase64URL",
+    snippet: "fromBase64URL(${1:str})",
+    description: "Decodes a URL-safe Base64 string, with or without padding.",
+    example: 'fromBase64URL("YT9i") == "a?b"',
+  },
+  {
+    name: "fromHex",
+    snippet: "fromHex(${1:str})",
+    description: "Decodes a hexadecimal string.",
+    example: 'fromHex("6869") == "hi"',
+  },
+  {
+    name: "hmacSHA256",
+    snippet: "hmacSHA256(${1:key}, ${2:message})",
+    description: "Returns the HMAC-SHA256 of the message with the given key, hex encoded.",
+    example: 'hmacSHA256("secret", root().body)',
+  },
+  {
+    name: "humanizeDuration",
+    snippet: "humanizeDuration(${1:duration})",
+    description: "Formats a duration for people, using its two largest units. Numbers are read as seconds.",
+    example: 'humanizeDuration(duration("93m")) == "1h 33m"',
+  },
+  {
+    name: "jmesPath",
+    snippet: "jmesPath(${1:value}, ${2:query})",
+    description: "Evaluates a JMESPath query, e.g. to filter and project lists.",
+    example: 'jmesPath({items: [{n: "a", ok: true}, {n: "b", ok: false}]}, "items[?ok].n") == ["a"]',
+  },
+  {
+    name: "jsonPath",
+    snippet: "jsonPath(${1:value}, ${2:path})",
+    description: "Looks up a JSONPath such as $.items[0].name. Paths with a * wildcard return a list.",
+    example: 'jsonPath({items: [{name: "a"}, {name: "b"}]}, "$.items[*].name") == ["a", "b"]',
+  },
+  {
+    name: "parseCSV",
+    snippet: "parseCSV(${1:str}, ${2:header})",
+    description:
+      "Parses CSV. With a header row, returns a list of maps keyed by column name, otherwise a list of rows.",
+    example: 'parseCSV("name,env\\napi,prod")[0].env == "prod"',
+  },
+  {
+    name: "parseDuration",
+    snippet: "parseDuration(${1:str})",
+    description: "Parses a duration like duration() does, also accepting days (d) and weeks (w).",
+    example: 'parseDuration("1d12h") == duration("36h")',
+  },
+  {
+    name: "parseQuery",
+    snippet: "parseQuery(${1:query})",
+    description: "Parses a URL query string. Values are strings, or lists of strings when repeated.",
+    example: 'parseQuery("a=1&b=2&b=3") == {a: "1", b: ["2", "3"]}',
+  },
+  {
+    name: "parseURL",
+    snippet: "parseURL(${1:url})",
+    description:
+      "Parses a URL into scheme, user, host, hostname, port, path, query and fragment. Query values are strings, or lists of strings when repeated.",
+    example: 'parseURL("https://example.com:8443/a?env=prod").query.env == "prod"',
+  },
+  {
+    name: "parseYAML",
+    snippet: "parseYAML(${1:str})",
+    description: "Parses a YAML document.",
+    example: 'parseYAML("image:\\n  tag: v2").image.tag == "v2"',
+  },
+  {
+    name: "regexFind",
+    snippet: "regexFind(${1:str}, ${2:pattern})",
+    description:
+      "Returns the first match of the regular expression, or nil. With capture groups, returns the groups instead.",
+    example: 'regexFind("release-1.4", "(\\\\d+)\\\\.(\\\\d+)") == ["1", "4"]',
+  },
+  {
+    name: "regexFindAll",
+    snippet: "regexFindAll(${1:str}, ${2:pattern})",
+    description: "Returns all matches of the regular expression.",
+    example: 'regexFindAll("a1 b2", "\\\\d") == ["1", "2"]',
+  },
+  {
+    name: "regexMatch",
+    snippet: "regexMatch(${1:str}, ${2:pattern})",
+    description: "Reports whether the string contains a match of the regular expression.",
+    example: 'regexMatch("v1.2.3", "^v\\\\d+")',
+  },
+  {
+    name: "regexReplace",
+    snippet: "regexReplace(${1:str}, ${2:pattern}, ${3:replacement})",
+    description:
+      "Replaces all matches of the regular expression. The replacement can reference groups with $1 or ${name}.",
+    example: 'regexReplace("feature/JIRA-12", "^feature/", "") == "JIRA-12"',
+  },
+  {
+    name: "semver",
+    snippet: "semver(${1:version})",
+    description: "Parses a semantic version into major, minor, patch, prerelease, build and version.",
+    example: 'semver("v1.4.2-rc.1").minor == 4',
+  },
+  {
+    name: "semverBump",
+    snippet: "semverBump(${1:version}, ${2:part})",
+    description: "Increments the major, minor or patch part of a semantic version. A leading v is kept.",
+    example: 'semverBump("v1.4.2", "minor") == "v1.5.0"',
+  },
+  {
+    name: "semverCompare",
+    snippet: "semverCompare(${1:a}, ${2:b})",
+    description: "Compares two semantic versions, returning -1, 0 or 1.",
+    example: 'semverCompare("1.10.0", "1.9.3") == 1',
+  },
+  {
+    name: "sha256",
+    snippet: "sha256(${1:str})",
+    description: "Returns the SHA-256 digest of the string, hex encoded.",
+    example: 'sha256("abc")[:8] == "ba7816bf"',
+  },
+  {
+    name: "slugify",
+    snippet: "slugify(${1:str})",
+    description:
+      "Lowercases the string, drops accents and joins words with dashes, so it can be used in names, branches and URLs.",
+    example: 'slugify("Fix: Crème Brûlée!") == "fix-creme-brulee"',
+  },
+  {
+    name: "toBase64URL",
+    snippet: "toBase64URL(${1:str})",
+    description: "Encodes the string with the URL-safe Base64 alphabet, without padding.",
+    example: 'toBase64URL("a?b") == "YT9i"',
+  },
+  {
+    name: "toHex",
+    snippet: "toHex(${1:str})",
+    description: "Encodes the string as lowercase hexadecimal.",
+    example: 'toHex("hi") == "6869"',
+  },
+  {
+    name: "toYAML",
+    snippet: "toYAML(${1:value})",
+    description: "Converts the value to a YAML document.",
+    example: 'toYAML({replicas: 3}) == "replicas: 3\\n"',
+  },
+  {
+    name: "urlDecode",
+    snippet: "urlDecode(${1:str})",
+    description: "Reverses urlEncode.",
+    example: 'urlDecode("a+b%26c") == "a b&c"',
+  },
+  {
+    name: "urlEncode",
+    snippet: "urlEncode(${1:str})",
+    description: "Escapes the string so it can be placed in a URL query.",
+    example: 'urlEncode("a b&c") == "a+b%26c"',
+  },
+
   // String
   {
     name: "trim",
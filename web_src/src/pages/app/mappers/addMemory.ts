@@ -23,6 +23,7 @@ type AddMemoryMetadata = {
 type AddMemoryConfiguration = {
   namespace?: string;
   valueList?: Array<{ name?: string; value?: unknown }>;
+  ttl?: string;
   iterateList?: boolean;
   listSource?: string;
   itemVariable?: string;
@@ -112,6 +113,9 @@ function getAddMemoryMetadataList(node: NodeInfo): Array<{ icon: string; label:
   if (config.iterateList || metadata.iterateList) {
     items.push({ icon: "repeat", label: "List mode" });
   }
+  if ((config.ttl || "").trim()) {
+    items.push({ icon: "clock", label: `expires after ${config.ttl?.trim()}` });
+  }
 
   return items;
 }
@@ -0,0 +1,155 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  EventStateRegistry,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+  StateFunction,
+} from "../types";
+import type { ComponentBaseProps, EventStateMap } from "@/ui/componentBase";
+import { DEFAULT_EVENT_STATE_MAP } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type { MetadataItem } from "@/ui/metadataList";
+import argocdIcon from "@/assets/icons/integrations/argocd.svg";
+import { noopMapper } from "../noop";
+import { defaultStateFunction } from "../stateRegistry";
+
+type ArgoCDConfiguration = {
+  application?: string;
+  revision?: string;
+  historyId?: number;
+  conditions?: string[];
+  parameters?: Array<{ name?: string; value?: string }>;
+};
+
+type ArgoCDOutputs = {
+  default?: OutputPayload[];
+  success?: OutputPayload[];
+  failed?: OutputPayload[];
+};
+
+type ArgoCDApplicationOutput = {
+  name?: string;
+  namespace?: string;
+  project?: string;
+  application?: string;
+  sync?: { status?: string; revision?: string };
+  health?: { status?: string; message?: string };
+  operation?: { phase?: string; message?: string };
+  wait?: { status?: string; message?: string };
+  parameters?: Array<{ name?: string; value?: string }>;
+  changed?: boolean;
+};
+
+const conditionLabels: Record<string, string> = {
+  healthy: "Healthy",
+  synced: "Synced",
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = (node.configuration as ArgoCDConfiguration | undefined) ?? {};
+
+  if (configuration.application) {
+    metadata.push({ icon: "box", label: configuration.application });
+  }
+  if (configuration.revision) {
+    metadata.push({ icon: "git-commit", label: `Revision: ${configuration.revision}` });
+  }
+  if (typeof configuration.historyId === "number") {
+    metadata.push({ icon: "history", label: `History ID: ${configuration.historyId}` });
+  }
+  if (configuration.conditions && configuration.conditions.length > 0) {
+    const conditions = configuration.conditions.map((condition) => conditionLabels[condition] || condition);
+    metadata.push({ icon: "circle-check", label: conditions.join(" and ") });
+  }
+  if (configuration.parameters && configuration.parameters.length > 0) {
+    const names = configuration.parameters.map((parameter) => parameter.name).filter(Boolean);
+    metadata.push({
+      icon: "sliders-horizontal",
+      label: names.length > 2 ? `${names.length} parameters` : names.join(", "),
+    });
+  }
+
+  return metadata;
+}
+
+function getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+  const details: Record<string, string> = {};
+  const outputs = context.execution.outputs as ArgoCDOutputs | undefined;
+  const data = (outputs?.default?.[0] ?? outputs?.success?.[0] ?? outputs?.failed?.[0])?.data as
+    | ArgoCDApplicationOutput
+    | undefined;
+
+  if (context.execution.createdAt) {
+    details["Started At"] = new Date(context.execution.createdAt).toLocaleString();
+  }
+
+  if (!data) {
+    return details;
+  }
+
+  const application = data.application || [data.namespace, data.name].filter(Boolean).join("/");
+  if (application) details["Application"] = application;
+  if (data.project) details["Project"] = data.project;
+  if (data.sync?.status) details["Sync Status"] = data.sync.status;
+  if (data.sync?.revision) details["Revision"] = data.sync.revision;
+  if (data.health?.status) details["Health"] = data.health.status;
+  if (data.operation?.phase) details["Operation"] = data.operation.phase;
+  if (data.wait?.message) details["Message"] = data.wait.message;
+  else if (data.operation?.message) details["Message"] = data.operation.message;
+
+  if (data.parameters && data.parameters.length > 0) {
+    details["Parameters"] = data.parameters.map((parameter) => `${parameter.name}=${parameter.value}`).join(", ");
+  }
+  if (typeof data.changed === "boolean") {
+    details["Changed"] = data.changed ? "Yes" : "No";
+  }
+
+  return details;
+}
+
+function props(context: ComponentBaseContext): ComponentBaseProps {
+  const base = noopMapper.props(context);
+  return {
+    ...base,
+    iconSlug: undefined,
+    iconSrc: argocdIcon,
+    iconColor: getColorClass(context.componentDefinition.color),
+    collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+    metadata: metadataList(context.node),
+  };
+}
+
+export const argocdBaseMapper: ComponentBaseMapper = {
+  ...noopMapper,
+  props,
+  getExecutionDetails,
+};
+
+export const WAIT_STATE_MAP: EventStateMap = {
+  ...DEFAULT_EVENT_STATE_MAP,
+  failed: {
+    icon: "circle-x",
+    textColor: "text-gray-800",
+    backgroundColor: "bg-red-100",
+    badgeColor: "bg-red-500",
+  },
+};
+
+export const waitStateFunction: StateFunction = (execution) => {
+  if (!execution) return "neutral";
+
+  const outputs = execution.outputs as ArgoCDOutputs | undefined;
+  if (outputs?.failed?.length) {
+    return "failed";
+  }
+
+  return defaultStateFunction(execution);
+};
+
+export const WAIT_STATE_REGISTRY: EventStateRegistry = {
+  stateMap: WAIT_STATE_MAP,
+  getState: waitStateFunction,
+};
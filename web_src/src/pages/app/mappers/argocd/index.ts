@@ -0,0 +1,31 @@
+import type { ComponentBaseMapper, CustomFieldRenderer, EventStateRegistry, TriggerRenderer } from "../types";
+import { buildActionStateRegistry } from "../utils";
+import { argocdBaseMapper, WAIT_STATE_REGISTRY } from "./base";
+import {
+  onApplicationStatusChangedCustomFieldRenderer,
+  onApplicationStatusChangedTriggerRenderer,
+} from "./on_application_status_changed";
+
+export const componentMappers: Record<string, ComponentBaseMapper> = {
+  syncApplication: argocdBaseMapper,
+  waitForApplication: argocdBaseMapper,
+  rollbackApplication: argocdBaseMapper,
+  getApplication: argocdBaseMapper,
+  setParameter: argocdBaseMapper,
+};
+
+export const triggerRenderers: Record<string, TriggerRenderer> = {
+  onApplicationStatusChanged: onApplicationStatusChangedTriggerRenderer,
+};
+
+export const customFieldRenderers: Record<string, CustomFieldRenderer> = {
+  onApplicationStatusChanged: onApplicationStatusChangedCustomFieldRenderer,
+};
+
+export const eventStateRegistry: Record<string, EventStateRegistry> = {
+  syncApplication: buildActionStateRegistry("synced"),
+  waitForApplication: WAIT_STATE_REGISTRY,
+  rollbackApplication: buildActionStateRegistry("rolled back"),
+  getApplication: buildActionStateRegistry("retrieved"),
+  setParameter: buildActionStateRegistry("updated"),
+};
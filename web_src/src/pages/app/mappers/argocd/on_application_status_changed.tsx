@@ -0,0 +1,226 @@
+import type {
+  CustomFieldRenderer,
+  NodeInfo,
+  TriggerEventContext,
+  TriggerRenderer,
+  TriggerRendererContext,
+} from "../types";
+import React from "react";
+import type { TriggerProps } from "@/ui/trigger";
+import type { MetadataItem } from "@/ui/metadataList";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import argocdIcon from "@/assets/icons/integrations/argocd.svg";
+
+interface OnApplicationStatusChangedConfiguration {
+  applications?: string[];
+  healthStatuses?: string[];
+  syncStatuses?: string[];
+  operationPhases?: string[];
+}
+
+interface OnApplicationStatusChangedMetadata {
+  webhookUrl?: string;
+  webhookAuthEnabled?: boolean;
+}
+
+interface ApplicationStatusEvent {
+  name?: string;
+  namespace?: string;
+  project?: string;
+  sync?: { status?: string; revision?: string };
+  health?: { status?: string };
+  operation?: { phase?: string; message?: string; finishedAt?: string };
+  url?: string;
+}
+
+export const onApplicationStatusChangedTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as ApplicationStatusEvent;
+    return {
+      title: buildEventTitle(eventData),
+      subtitle: buildEventSubtitle(eventData, context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as ApplicationStatusEvent;
+    const values: Record<string, string> = {};
+
+    if (eventData?.name) values["Application"] = eventData.name;
+    if (eventData?.namespace) values["Namespace"] = eventData.namespace;
+    if (eventData?.project) values["Project"] = eventData.project;
+    if (eventData?.sync?.status) values["Sync Status"] = eventData.sync.status;
+    if (eventData?.sync?.revision) values["Revision"] = eventData.sync.revision;
+    if (eventData?.health?.status) values["Health"] = eventData.health.status;
+    if (eventData?.operation?.phase) values["Operation"] = eventData.operation.phase;
+    if (eventData?.operation?.message) values["Message"] = eventData.operation.message;
+    if (eventData?.url) values["URL"] = eventData.url;
+
+    return values;
+  },
+
+  getTriggerProps: (context: TriggerRendererContext): TriggerProps => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as OnApplicationStatusChangedConfiguration | undefined;
+    const metadata = node.metadata as OnApplicationStatusChangedMetadata | undefined;
+    const metadataItems: MetadataItem[] = [];
+
+    const applications = (configuration?.applications || []).filter((value) => value.trim().length > 0);
+    if (applications.length > 0) {
+      metadataItems.push({
+        icon: "box",
+        label: applications.length > 2 ? `${applications.length} applications` : applications.join(", "),
+      });
+    }
+
+    const statuses = [
+      ...(configuration?.healthStatuses || []),
+      ...(configuration?.syncStatuses || []),
+      ...(configuration?.operationPhases || []),
+    ];
+    if (statuses.length > 0) {
+      metadataItems.push({ icon: "funnel", label: statuses.join(", ") });
+    }
+
+    if (metadata?.webhookAuthEnabled) {
+      metadataItems.push({ icon: "lock", label: "Webhook Auth: Bearer" });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: argocdIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems.slice(0, 3),
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as ApplicationStatusEvent;
+      props.lastEventData = {
+        title: buildEventTitle(eventData),
+        subtitle: buildEventSubtitle(eventData, lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt),
+        state: "triggered",
+        eventId: lastEvent.id,
+      };
+    }
+
+    return props;
+  },
+};
+
+export const onApplicationStatusChangedCustomFieldRenderer: CustomFieldRenderer = {
+  render: (node: NodeInfo) => {
+    const metadata = node.metadata as OnApplicationStatusChangedMetadata | undefined;
+    const webhookUrl = metadata?.webhookUrl || "[URL GENERATED ONCE THE CANVAS IS SAVED]";
+    const webhookAuthEnabled = metadata?.webhookAuthEnabled || false;
+
+    return (
+      <div className="border-t-1 border-gray-200 pt-4">
+        <div className="space-y-3">
+          <div>
+            <span className="text-sm font-medium text-gray-700 dark:text-gray-300">Argo CD Notifications Setup</span>
+            <div className="text-xs text-gray-800 dark:text-gray-100 mt-2 border-1 border-gray-300 dark:border-gray-600 px-2.5 py-2 bg-gray-50 dark:bg-gray-800 rounded-md">
+              <ol className="list-decimal ml-4 space-y-1">
+                <li>Save the canvas to generate the webhook URL.</li>
+                <li>Add the snippet below to the argocd-notifications-cm ConfigMap.</li>
+                {webhookAuthEnabled && (
+                  <li>
+                    Store the integration Webhook Secret as superplane-webhook-secret in the argocd-notifications-secret
+                    Secret.
+                  </li>
+                )}
+              </ol>
+              <p className="mt-3">
+                Argo CD notifications are configured in a ConfigMap, so SuperPlane does not create them by API. Every
+                trigger of this integration shares the same webhook URL.
+              </p>
+              <div className="mt-3">
+                <span className="text-xs font-medium text-gray-700 dark:text-gray-200">Webhook URL</span>
+                <pre className="mt-1 text-xs text-gray-800 dark:text-gray-100 border-1 border-gray-300 dark:border-gray-600 px-2.5 py-2 bg-white dark:bg-gray-900 rounded-md font-mono whitespace-pre-wrap break-all">
+                  {webhookUrl}
+                </pre>
+              </div>
+              <div className="mt-3">
+                <span className="text-xs font-medium text-gray-700 dark:text-gray-200">argocd-notifications-cm Snippet</span>
+                <pre className="mt-1 text-xs text-gray-800 dark:text-gray-100 border-1 border-gray-300 dark:border-gray-600 px-2.5 py-2 bg-white dark:bg-gray-900 rounded-md font-mono whitespace-pre-wrap break-all">
+                  {buildNotificationsSnippet(webhookUrl, webhookAuthEnabled)}
+                </pre>
+              </div>
+            </div>
+          </div>
+        </div>
+      </div>
+    );
+  },
+};
+
+function buildEventTitle(eventData: ApplicationStatusEvent): string {
+  const name = eventData?.name || "Application";
+  const statuses = [eventData?.sync?.status, eventData?.health?.status].filter(Boolean);
+  return statuses.length > 0 ? `${name} · ${statuses.join(" · ")}` : name;
+}
+
+function buildEventSubtitle(eventData: ApplicationStatusEvent, createdAt?: string): string | React.ReactNode {
+  const phase = eventData?.operation?.phase;
+  const timeAgo = createdAt ? renderTimeAgo(new Date(createdAt)) : "";
+
+  if (phase && timeAgo) {
+    return (
+      <span>
+        Sync {phase.toLowerCase()} · {timeAgo}
+      </span>
+    );
+  }
+
+  return phase ? `Sync ${phase.toLowerCase()}` : timeAgo;
+}
+
+function buildNotificationsSnippet(webhookUrl: string, webhookAuthEnabled: boolean): string {
+  const authHeader = webhookAuthEnabled
+    ? `
+      - name: Authorization
+        value: Bearer $superplane-webhook-secret`
+    : "";
+
+  return `data:
+  service.webhook.superplane: |
+    url: ${webhookUrl}
+    headers:
+      - name: Content-Type
+        value: application/json${authHeader}
+  template.superplane: |
+    webhook:
+      superplane:
+        method: POST
+        body: |
+          {
+            "application": {{toJson .app.metadata.name}},
+            "namespace": {{toJson .app.metadata.namespace}},
+            "project": {{toJson .app.spec.project}},
+            "syncStatus": {{toJson .app.status.sync.status}},
+            "revision": {{toJson (dig "status" "sync" "revision" "" .app)}},
+            "healthStatus": {{toJson .app.status.health.status}},
+            "operationPhase": {{toJson (dig "status" "operationState" "phase" "" .app)}},
+            "operationMessage": {{toJson (dig "status" "operationState" "message" "" .app)}},
+            "finishedAt": {{toJson (dig "status" "operationState" "finishedAt" "" .app)}},
+            "url": {{toJson (printf "%s/applications/%s" .context.argocdUrl .app.metadata.name)}}
+          }
+  trigger.on-superplane: |
+    - when: app.status.operationState != nil and app.status.operationState.phase in ['Running']
+      send: [superplane]
+    - when: app.status.operationState != nil and app.status.operationState.phase in ['Succeeded']
+      send: [superplane]
+    - when: app.status.operationState != nil and app.status.operationState.phase in ['Error', 'Failed']
+      send: [superplane]
+    - when: app.status.health.status == 'Healthy'
+      send: [superplane]
+    - when: app.status.health.status == 'Degraded'
+      send: [superplane]
+    - when: app.status.sync.status == 'OutOfSync'
+      send: [superplane]
+  subscriptions: |
+    - recipients: [superplane]
+      triggers: [on-superplane]`;
+}
@@ -0,0 +1,168 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  EventStateRegistry,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+  StateFunction,
+} from "../types";
+import type { ComponentBaseProps, EventStateMap } from "@/ui/componentBase";
+import { DEFAULT_EVENT_STATE_MAP } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type { MetadataItem } from "@/ui/metadataList";
+import azureDevOpsIcon from "@/assets/icons/integrations/azuredevops.svg";
+import { noopMapper } from "../noop";
+import { defaultStateFunction } from "../stateRegistry";
+import type { NodeMetadata } from "./types";
+
+type AzureDevOpsConfiguration = {
+  project?: string;
+  repository?: string;
+  pipeline?: string;
+  branch?: string;
+  run?: string;
+  decision?: string;
+  workItemType?: string;
+  workItem?: string;
+  pullRequest?: string;
+  state?: string;
+};
+
+type AzureDevOpsOutputs = {
+  default?: OutputPayload[];
+  passed?: OutputPayload[];
+  failed?: OutputPayload[];
+};
+
+type AzureDevOpsOutput = {
+  id?: number;
+  name?: string;
+  type?: string;
+  title?: string;
+  state?: string;
+  result?: string;
+  run?: number;
+  decision?: string;
+  pull_request?: number;
+  pipeline?: { id?: number; name?: string };
+  finished_date?: string;
+  url?: string;
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = (node.configuration as AzureDevOpsConfiguration | undefined) ?? {};
+  const nodeMetadata = node.metadata as NodeMetadata | undefined;
+
+  const project = nodeMetadata?.project?.name || configuration.project;
+  if (project) {
+    metadata.push({ icon: "folder", label: project });
+  }
+  const repository = nodeMetadata?.repository?.name || configuration.repository;
+  if (repository) {
+    metadata.push({ icon: "book", label: repository });
+  }
+  const pipeline = nodeMetadata?.pipeline?.name || configuration.pipeline;
+  if (pipeline) {
+    metadata.push({ icon: "workflow", label: pipeline });
+  }
+  if (configuration.branch) {
+    metadata.push({ icon: "git-branch", label: configuration.branch });
+  }
+  if (configuration.run) {
+    metadata.push({ icon: "play", label: `Run: ${configuration.run}` });
+  }
+  if (configuration.decision) {
+    metadata.push({ icon: "circle-check", label: configuration.decision });
+  }
+  if (configuration.workItemType) {
+    metadata.push({ icon: "square-pen", label: configuration.workItemType });
+  }
+  if (configuration.workItem) {
+    metadata.push({ icon: "square-pen", label: `Work Item: ${configuration.workItem}` });
+  }
+  if (configuration.pullRequest) {
+    metadata.push({ icon: "git-pull-request", label: `PR: ${configuration.pullRequest}` });
+  }
+  if (configuration.state) {
+    metadata.push({ icon: "circle-dot", label: configuration.state });
+  }
+
+  return metadata;
+}
+
+function getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+  const details: Record<string, string> = {};
+  const outputs = context.execution.outputs as AzureDevOpsOutputs | undefined;
+  const data = (outputs?.default?.[0] ?? outputs?.passed?.[0] ?? outputs?.failed?.[0])?.data as
+    | AzureDevOpsOutput
+    | undefined;
+
+  if (context.execution.createdAt) {
+    details["Started At"] = new Date(context.execution.createdAt).toLocaleString();
+  }
+
+  if (!data) {
+    return details;
+  }
+
+  if (data.pipeline?.name) details["Pipeline"] = data.pipeline.name;
+  if (data.name) details["Run"] = data.name;
+  if (typeof data.run === "number") details["Run"] = `${data.run}`;
+  if (data.decision) details["Decision"] = data.decision;
+  if (typeof data.pull_request === "number") details["Pull Request"] = `#${data.pull_request}`;
+  if (data.type) details["Type"] = data.type;
+  if (data.title) details["Title"] = data.title;
+  if (typeof data.id === "number" && data.type) details["Work Item"] = `#${data.id}`;
+  if (data.state) details["State"] = data.state;
+  if (data.result) details["Result"] = data.result;
+  if (data.finished_date) details["Finished At"] = new Date(data.finished_date).toLocaleString();
+  if (data.url) details["URL"] = data.url;
+
+  return details;
+}
+
+function props(context: ComponentBaseContext): ComponentBaseProps {
+  const base = noopMapper.props(context);
+  return {
+    ...base,
+    iconSlug: undefined,
+    iconSrc: azureDevOpsIcon,
+    iconColor: getColorClass(context.componentDefinition.color),
+    collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+    metadata: metadataList(context.node),
+  };
+}
+
+export const azureDevOpsBaseMapper: ComponentBaseMapper = {
+  ...noopMapper,
+  props,
+  getExecutionDetails,
+};
+
+export const RUN_STATE_MAP: EventStateMap = {
+  ...DEFAULT_EVENT_STATE_MAP,
+  failed: {
+    icon: "circle-x",
+    textColor: "text-gray-800",
+    backgroundColor: "bg-red-100",
+    badgeColor: "bg-red-500",
+  },
+};
+
+export const runStateFunction: StateFunction = (execution) => {
+  if (!execution) return "neutral";
+
+  const outputs = execution.outputs as AzureDevOpsOutputs | undefined;
+  if (outputs?.failed?.length) {
+    return "failed";
+  }
+
+  return defaultStateFunction(execution);
+};
+
+export const RUN_STATE_REGISTRY: EventStateRegistry = {
+  stateMap: RUN_STATE_MAP,
+  getState: runStateFunction,
+};
@@ -0,0 +1,28 @@
+import type { ComponentBaseMapper, EventStateRegistry, TriggerRenderer } from "../types";
+import { buildActionStateRegistry } from "../utils";
+import { azureDevOpsBaseMapper, RUN_STATE_REGISTRY } from "./base";
+import { onBuildCompletedTriggerRenderer } from "./on_build_completed";
+import { onPullRequestTriggerRenderer } from "./on_pull_request";
+import { onPushTriggerRenderer } from "./on_push";
+
+export const componentMappers: Record<string, ComponentBaseMapper> = {
+  runPipeline: azureDevOpsBaseMapper,
+  approveCheck: azureDevOpsBaseMapper,
+  createWorkItem: azureDevOpsBaseMapper,
+  updateWorkItem: azureDevOpsBaseMapper,
+  createPullRequestComment: azureDevOpsBaseMapper,
+};
+
+export const triggerRenderers: Record<string, TriggerRenderer> = {
+  onPush: onPushTriggerRenderer,
+  onPullRequest: onPullRequestTriggerRenderer,
+  onBuildCompleted: onBuildCompletedTriggerRenderer,
+};
+
+export const eventStateRegistry: Record<string, EventStateRegistry> = {
+  runPipeline: RUN_STATE_REGISTRY,
+  approveCheck: buildActionStateRegistry("approved"),
+  createWorkItem: buildActionStateRegistry("created"),
+  updateWorkItem: buildActionStateRegistry("updated"),
+  createPullRequestComment: buildActionStateRegistry("commented"),
+};
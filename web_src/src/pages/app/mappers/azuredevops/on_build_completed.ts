@@ -0,0 +1,101 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import azureDevOpsIcon from "@/assets/icons/integrations/azuredevops.svg";
+import type { TriggerProps } from "@/ui/trigger";
+import type { Identity, NodeMetadata, ServiceHookEvent } from "./types";
+import type { Predicate } from "../utils";
+import { formatPredicate } from "../utils";
+import { buildSubtitle, projectMetadataItems } from "./on_push";
+
+export interface OnBuildCompletedConfiguration {
+  project?: string;
+  pipeline?: string;
+  results?: string[];
+  branches?: Predicate[];
+}
+
+export interface AzureDevOpsBuild {
+  id?: number;
+  buildNumber?: string;
+  result?: string;
+  sourceBranch?: string;
+  sourceVersion?: string;
+  definition?: {
+    id?: number;
+    name?: string;
+  };
+  requestedFor?: Identity;
+}
+
+function buildTitle(build?: AzureDevOpsBuild): string {
+  if (!build) return "";
+  return `${build.definition?.name ?? ""} ${build.buildNumber ?? ""}`.trim();
+}
+
+/**
+ * Renderer for the "azuredevops.onBuildCompleted" trigger
+ */
+export const onBuildCompletedTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const build = (context.event?.data as ServiceHookEvent<AzureDevOpsBuild>)?.resource;
+
+    return {
+      title: buildTitle(build),
+      subtitle: buildSubtitle(build?.result || "", context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const build = (context.event?.data as ServiceHookEvent<AzureDevOpsBuild>)?.resource;
+
+    return {
+      Pipeline: build?.definition?.name || "",
+      Build: build?.buildNumber || "",
+      Result: build?.result || "",
+      Branch: build?.sourceBranch || "",
+      SHA: build?.sourceVersion || "",
+      "Requested For": build?.requestedFor?.displayName || "",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnBuildCompletedConfiguration;
+    const metadata = node.metadata as unknown as NodeMetadata;
+    const metadataItems = projectMetadataItems(metadata);
+
+    if (metadata?.pipeline?.name) {
+      metadataItems.push({ icon: "workflow", label: metadata.pipeline.name });
+    }
+
+    if (configuration?.results && configuration.results.length > 0) {
+      metadataItems.push({ icon: "circle-dot", label: configuration.results.join(", ") });
+    }
+
+    if (configuration?.branches && configuration.branches.length > 0) {
+      metadataItems.push({ icon: "funnel", label: configuration.branches.map(formatPredicate).join(", ") });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: azureDevOpsIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const build = (lastEvent.data as ServiceHookEvent<AzureDevOpsBuild>)?.resource;
+      props.lastEventData = {
+        title: buildTitle(build),
+        subtitle: buildSubtitle(build?.result || "", lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt!),
+        state: "triggered",
+        eventId: lastEvent.id!,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,97 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import azureDevOpsIcon from "@/assets/icons/integrations/azuredevops.svg";
+import type { TriggerProps } from "@/ui/trigger";
+import type { Identity, NodeMetadata, ServiceHookEvent } from "./types";
+import type { Predicate } from "../utils";
+import { formatPredicate } from "../utils";
+import { buildSubtitle, projectMetadataItems } from "./on_push";
+
+export interface OnPullRequestConfiguration {
+  project?: string;
+  repository?: string;
+  actions?: string[];
+  targetBranches?: Predicate[];
+}
+
+export interface AzureDevOpsPullRequest {
+  pullRequestId?: number;
+  title?: string;
+  status?: string;
+  sourceRefName?: string;
+  targetRefName?: string;
+  createdBy?: Identity;
+}
+
+export type AzureDevOpsPullRequestEvent = ServiceHookEvent<AzureDevOpsPullRequest> & {
+  action?: string;
+};
+
+function pullRequestTitle(pullRequest?: AzureDevOpsPullRequest): string {
+  if (!pullRequest) return "";
+  return `#${pullRequest.pullRequestId ?? ""} ${pullRequest.title ?? ""}`.trim();
+}
+
+/**
+ * Renderer for the "azuredevops.onPullRequest" trigger
+ */
+export const onPullRequestTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as AzureDevOpsPullRequestEvent;
+
+    return {
+      title: pullRequestTitle(eventData?.resource),
+      subtitle: buildSubtitle(eventData?.action || "", context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as AzureDevOpsPullRequestEvent;
+    const pullRequest = eventData?.resource;
+
+    return {
+      Action: eventData?.action || "",
+      "Pull Request": pullRequestTitle(pullRequest),
+      Status: pullRequest?.status || "",
+      "Source Branch": pullRequest?.sourceRefName || "",
+      "Target Branch": pullRequest?.targetRefName || "",
+      Author: pullRequest?.createdBy?.displayName || "",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnPullRequestConfiguration;
+    const metadataItems = projectMetadataItems(node.metadata as unknown as NodeMetadata);
+
+    if (configuration?.actions && configuration.actions.length > 0) {
+      metadataItems.push({ icon: "git-pull-request", label: configuration.actions.join(", ") });
+    }
+
+    if (configuration?.targetBranches && configuration.targetBranches.length > 0) {
+      metadataItems.push({ icon: "funnel", label: configuration.targetBranches.map(formatPredicate).join(", ") });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: azureDevOpsIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as AzureDevOpsPullRequestEvent;
+      props.lastEventData = {
+        title: pullRequestTitle(eventData?.resource),
+        subtitle: buildSubtitle(eventData?.action || "", lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt!),
+        state: "triggered",
+        eventId: lastEvent.id!,
+      };
+    }
+
+    return props;
+  },
+};
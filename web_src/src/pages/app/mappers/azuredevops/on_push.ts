@@ -0,0 +1,117 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import azureDevOpsIcon from "@/assets/icons/integrations/azuredevops.svg";
+import type { TriggerProps } from "@/ui/trigger";
+import type { MetadataItem } from "@/ui/metadataList";
+import type { Identity, NodeMetadata, ServiceHookEvent } from "./types";
+import type { Predicate } from "../utils";
+import { formatPredicate } from "../utils";
+import { renderTimeAgo, renderWithTimeAgo } from "@/components/TimeAgo";
+
+export interface OnPushConfiguration {
+  project?: string;
+  repository?: string;
+  refs?: Predicate[];
+}
+
+export interface AzureDevOpsPush {
+  pushId?: number;
+  pushedBy?: Identity;
+  commits?: Array<{
+    commitId?: string;
+    comment?: string;
+    url?: string;
+  }>;
+  refUpdates?: Array<{
+    name?: string;
+    oldObjectId?: string;
+    newObjectId?: string;
+  }>;
+  repository?: {
+    id?: string;
+    name?: string;
+  };
+}
+
+export function buildSubtitle(content: string, createdAt?: string): string | React.ReactNode {
+  if (content && createdAt) {
+    return renderWithTimeAgo(content, new Date(createdAt));
+  }
+  return content || (createdAt ? renderTimeAgo(new Date(createdAt)) : "");
+}
+
+export function projectMetadataItems(metadata?: NodeMetadata): MetadataItem[] {
+  const items: MetadataItem[] = [];
+  if (metadata?.project?.name) {
+    items.push({ icon: "folder", label: metadata.project.name });
+  }
+  if (metadata?.repository?.name) {
+    items.push({ icon: "book", label: metadata.repository.name });
+  }
+  return items;
+}
+
+function pushTitle(push?: AzureDevOpsPush): string {
+  return push?.commits?.[0]?.comment?.split("\n")[0]?.trim() || push?.refUpdates?.[0]?.name || "";
+}
+
+function shortSha(push?: AzureDevOpsPush): string {
+  return push?.refUpdates?.[0]?.newObjectId?.slice(0, 7) || "";
+}
+
+/**
+ * Renderer for the "azuredevops.onPush" trigger
+ */
+export const onPushTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as ServiceHookEvent<AzureDevOpsPush>;
+
+    return {
+      title: pushTitle(eventData?.resource),
+      subtitle: buildSubtitle(shortSha(eventData?.resource), context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const push = (context.event?.data as ServiceHookEvent<AzureDevOpsPush>)?.resource;
+
+    return {
+      Ref: push?.refUpdates?.[0]?.name || "",
+      Commit: push?.commits?.[0]?.comment?.trim() || "",
+      SHA: push?.refUpdates?.[0]?.newObjectId || "",
+      Author: push?.pushedBy?.displayName || "",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnPushConfiguration;
+    const metadataItems = projectMetadataItems(node.metadata as unknown as NodeMetadata);
+
+    if (configuration?.refs && configuration.refs.length > 0) {
+      metadataItems.push({ icon: "funnel", label: configuration.refs.map(formatPredicate).join(", ") });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: azureDevOpsIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const push = (lastEvent.data as ServiceHookEvent<AzureDevOpsPush>)?.resource;
+      props.lastEventData = {
+        title: pushTitle(push),
+        subtitle: buildSubtitle(shortSha(push), lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt!),
+        state: "triggered",
+        eventId: lastEvent.id!,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,29 @@
+export interface NodeMetadata {
+  project?: {
+    id?: string;
+    name?: string;
+  };
+  repository?: {
+    id?: string;
+    name?: string;
+  };
+  pipeline?: {
+    id?: number;
+    name?: string;
+  };
+}
+
+export interface Identity {
+  id?: string;
+  displayName?: string;
+  uniqueName?: string;
+}
+
+export interface ServiceHookEvent<T> {
+  eventType?: string;
+  message?: {
+    text?: string;
+  };
+  resource?: T;
+  createdDate?: string;
+}
@@ -0,0 +1,163 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  EventStateRegistry,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+  StateFunction,
+} from "../types";
+import type { ComponentBaseProps, EventStateMap } from "@/ui/componentBase";
+import { DEFAULT_EVENT_STATE_MAP } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type { MetadataItem } from "@/ui/metadataList";
+import bitbucketIcon from "@/assets/icons/integrations/bitbucket.svg";
+import { noopMapper } from "../noop";
+import { defaultStateFunction } from "../stateRegistry";
+import type { NodeMetadata } from "./types";
+
+type BitbucketConfiguration = {
+  repository?: string;
+  pullRequest?: string;
+  branch?: string;
+  pipeline?: string;
+  commit?: string;
+  state?: string;
+  mergeStrategy?: string;
+  variables?: Array<{ name?: string; value?: string }>;
+};
+
+type BitbucketOutputs = {
+  default?: OutputPayload[];
+  passed?: OutputPayload[];
+  failed?: OutputPayload[];
+};
+
+type BitbucketOutput = {
+  id?: number | string;
+  pull_request?: string;
+  title?: string;
+  state?: string;
+  result?: string;
+  build_number?: number;
+  branch?: string;
+  commit?: string;
+  merge_commit?: string;
+  key?: string;
+  user?: string;
+  duration_in_seconds?: number;
+  task_url?: string;
+  url?: string;
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = (node.configuration as BitbucketConfiguration | undefined) ?? {};
+  const nodeMetadata = node.metadata as NodeMetadata | undefined;
+
+  const repository = nodeMetadata?.repository?.full_name || configuration.repository;
+  if (repository) {
+    metadata.push({ icon: "book", label: repository });
+  }
+  if (configuration.pullRequest) {
+    metadata.push({ icon: "git-pull-request", label: `PR: ${configuration.pullRequest}` });
+  }
+  if (configuration.branch) {
+    metadata.push({ icon: "git-branch", label: configuration.branch });
+  }
+  if (configuration.pipeline) {
+    metadata.push({ icon: "workflow", label: configuration.pipeline });
+  }
+  if (configuration.state) {
+    metadata.push({ icon: "circle-dot", label: configuration.state });
+  }
+  if (configuration.mergeStrategy) {
+    metadata.push({ icon: "git-merge", label: configuration.mergeStrategy });
+  }
+  if (configuration.variables && configuration.variables.length > 0) {
+    const names = configuration.variables.map((variable) => variable.name).filter(Boolean);
+    metadata.push({
+      icon: "sliders-horizontal",
+      label: names.length > 2 ? `${names.length} variables` : names.join(", "),
+    });
+  }
+
+  return metadata;
+}
+
+function getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+  const details: Record<string, string> = {};
+  const outputs = context.execution.outputs as BitbucketOutputs | undefined;
+  const data = (outputs?.default?.[0] ?? outputs?.passed?.[0] ?? outputs?.failed?.[0])?.data as
+    | BitbucketOutput
+    | undefined;
+
+  if (context.execution.createdAt) {
+    details["Started At"] = new Date(context.execution.createdAt).toLocaleString();
+  }
+
+  if (!data) {
+    return details;
+  }
+
+  if (data.pull_request) details["Pull Request"] = `#${data.pull_request}`;
+  if (data.title) details["Title"] = data.title;
+  if (typeof data.build_number === "number") details["Pipeline"] = `#${data.build_number}`;
+  if (data.branch) details["Branch"] = data.branch;
+  if (data.key) details["Key"] = data.key;
+  if (data.state) details["State"] = data.state;
+  if (data.result) details["Result"] = data.result;
+  if (data.merge_commit) details["Merge Commit"] = data.merge_commit.slice(0, 12);
+  if (data.commit) details["Commit"] = data.commit.slice(0, 12);
+  if (data.user) details["User"] = data.user;
+  if (typeof data.duration_in_seconds === "number" && data.duration_in_seconds > 0) {
+    details["Duration"] = `${data.duration_in_seconds}s`;
+  }
+  if (data.url) details["URL"] = data.url;
+
+  return details;
+}
+
+function props(context: ComponentBaseContext): ComponentBaseProps {
+  const base = noopMapper.props(context);
+  return {
+    ...base,
+    iconSlug: undefined,
+    iconSrc: bitbucketIcon,
+    iconColor: getColorClass(context.componentDefinition.color),
+    collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+    metadata: metadataList(context.node),
+  };
+}
+
+export const bitbucketBaseMapper: ComponentBaseMapper = {
+  ...noopMapper,
+  props,
+  getExecutionDetails,
+};
+
+export const PIPELINE_STATE_MAP: EventStateMap = {
+  ...DEFAULT_EVENT_STATE_MAP,
+  failed: {
+    icon: "circle-x",
+    textColor: "text-gray-800",
+    backgroundColor: "bg-red-100",
+    badgeColor: "bg-red-500",
+  },
+};
+
+export const pipelineStateFunction: StateFunction = (execution) => {
+  if (!execution) return "neutral";
+
+  const outputs = execution.outputs as BitbucketOutputs | undefined;
+  if (outputs?.failed?.length) {
+    return "failed";
+  }
+
+  return defaultStateFunction(execution);
+};
+
+export const PIPELINE_STATE_REGISTRY: EventStateRegistry = {
+  stateMap: PIPELINE_STATE_MAP,
+  getState: pipelineStateFunction,
+};
@@ -1,6 +1,30 @@
-import type { TriggerRenderer } from "../types";
+import type { ComponentBaseMapper, EventStateRegistry, TriggerRenderer } from "../types";
+import { buildActionStateRegistry } from "../utils";
+import { bitbucketBaseMapper, PIPELINE_STATE_REGISTRY } from "./base";
+import { onPipelineCompletedTriggerRenderer } from "./on_pipeline_completed";
+import { onPullRequestTriggerRenderer } from "./on_pull_request";
+import { onPullRequestCommentTriggerRenderer } from "./on_pull_request_comment";
 import { onPushTriggerRenderer } from "./on_push";
 
+export const componentMappers: Record<string, ComponentBaseMapper> = {
+  createPullRequestComment: bitbucketBaseMapper,
+  approvePullRequest: bitbucketBaseMapper,
+  mergePullRequest: bitbucketBaseMapper,
+  setCommitStatus: bitbucketBaseMapper,
+  runPipeline: bitbucketBaseMapper,
+};
+
 export const triggerRenderers: Record<string, TriggerRenderer> = {
   onPush: onPushTriggerRenderer,
+  onPullRequest: onPullRequestTriggerRenderer,
+  onPullRequestComment: onPullRequestCommentTriggerRenderer,
+  onPipelineCompleted: onPipelineCompletedTriggerRenderer,
+};
+
+export const eventStateRegistry: Record<string, EventStateRegistry> = {
+  createPullRequestComment: buildActionStateRegistry("commented"),
+  approvePullRequest: buildActionStateRegistry("approved"),
+  mergePullRequest: buildActionStateRegistry("merged"),
+  setCommitStatus: buildActionStateRegistry("updated"),
+  runPipeline: PIPELINE_STATE_REGISTRY,
 };
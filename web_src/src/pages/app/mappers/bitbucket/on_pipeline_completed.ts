@@ -0,0 +1,91 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import bitbucketIcon from "@/assets/icons/integrations/bitbucket.svg";
+import type { TriggerProps } from "@/ui/trigger";
+import type { NodeMetadata } from "./types";
+import type { Predicate } from "../utils";
+import { formatPredicate } from "../utils";
+import { buildSubtitle, repositoryMetadataItems } from "./on_pull_request";
+
+export interface OnPipelineCompletedConfiguration {
+  repository?: string;
+  results?: string[];
+  branches?: Predicate[];
+}
+
+export interface BitbucketCommitStatusEvent {
+  actor?: {
+    display_name?: string;
+  };
+  commit_status?: {
+    name?: string;
+    state?: string;
+    refname?: string;
+    url?: string;
+    commit?: {
+      hash?: string;
+    };
+  };
+}
+
+/**
+ * Renderer for the "bitbucket.onPipelineCompleted" trigger
+ */
+export const onPipelineCompletedTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as BitbucketCommitStatusEvent;
+
+    return {
+      title: eventData?.commit_status?.name || "",
+      subtitle: buildSubtitle(eventData?.commit_status?.state || "", context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as BitbucketCommitStatusEvent;
+
+    return {
+      Pipeline: eventData?.commit_status?.name || "",
+      Result: eventData?.commit_status?.state || "",
+      Branch: eventData?.commit_status?.refname || "",
+      SHA: eventData?.commit_status?.commit?.hash || "",
+      URL: eventData?.commit_status?.url || "",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnPipelineCompletedConfiguration;
+    const metadataItems = repositoryMetadataItems(node.metadata as unknown as NodeMetadata);
+
+    if (configuration?.results && configuration.results.length > 0) {
+      metadataItems.push({ icon: "circle-dot", label: configuration.results.join(", ") });
+    }
+
+    if (configuration?.branches && configuration.branches.length > 0) {
+      metadataItems.push({ icon: "funnel", label: configuration.branches.map(formatPredicate).join(", ") });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: bitbucketIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as BitbucketCommitStatusEvent;
+      props.lastEventData = {
+        title: eventData?.commit_status?.name || "",
+        subtitle: buildSubtitle(eventData?.commit_status?.state || "", lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt!),
+        state: "triggered",
+        eventId: lastEvent.id!,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,127 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import bitbucketIcon from "@/assets/icons/integrations/bitbucket.svg";
+import type { TriggerProps } from "@/ui/trigger";
+import type { MetadataItem } from "@/ui/metadataList";
+import type { NodeMetadata } from "./types";
+import { renderTimeAgo, renderWithTimeAgo } from "@/components/TimeAgo";
+
+export interface OnPullRequestConfiguration {
+  repository?: string;
+  actions?: string[];
+}
+
+export interface BitbucketPullRequest {
+  id?: number;
+  title?: string;
+  state?: string;
+  author?: {
+    display_name?: string;
+  };
+  source?: {
+    branch?: {
+      name?: string;
+    };
+    commit?: {
+      hash?: string;
+    };
+  };
+  destination?: {
+    branch?: {
+      name?: string;
+    };
+  };
+  links?: {
+    html?: {
+      href?: string;
+    };
+  };
+}
+
+export interface BitbucketPullRequestEvent {
+  action?: string;
+  actor?: {
+    display_name?: string;
+  };
+  pullrequest?: BitbucketPullRequest;
+}
+
+export function pullRequestTitle(pullRequest?: BitbucketPullRequest): string {
+  if (!pullRequest) return "";
+  return `#${pullRequest.id ?? ""} ${pullRequest.title ?? ""}`.trim();
+}
+
+export function buildSubtitle(content: string, createdAt?: string): string | React.ReactNode {
+  if (content && createdAt) {
+    return renderWithTimeAgo(content, new Date(createdAt));
+  }
+  return content || (createdAt ? renderTimeAgo(new Date(createdAt)) : "");
+}
+
+export function repositoryMetadataItems(metadata?: NodeMetadata): MetadataItem[] {
+  if (!metadata?.repository) {
+    return [];
+  }
+
+  return [{ icon: "book", label: metadata.repository.full_name || metadata.repository.name || "" }];
+}
+
+/**
+ * Renderer for the "bitbucket.onPullRequest" trigger
+ */
+export const onPullRequestTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as BitbucketPullRequestEvent;
+
+    return {
+      title: pullRequestTitle(eventData?.pullrequest),
+      subtitle: buildSubtitle(eventData?.action || "", context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as BitbucketPullRequestEvent;
+    const pullRequest = eventData?.pullrequest;
+
+    return {
+      Action: eventData?.action || "",
+      "Pull Request": pullRequestTitle(pullRequest),
+      Source: pullRequest?.source?.branch?.name || "",
+      Destination: pullRequest?.destination?.branch?.name || "",
+      Author: pullRequest?.author?.display_name || "",
+      URL: pullRequest?.links?.html?.href || "",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnPullRequestConfiguration;
+    const metadataItems = repositoryMetadataItems(node.metadata as unknown as NodeMetadata);
+
+    if (configuration?.actions && configuration.actions.length > 0) {
+      metadataItems.push({ icon: "funnel", label: configuration.actions.join(", ") });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: bitbucketIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as BitbucketPullRequestEvent;
+      props.lastEventData = {
+        title: pullRequestTitle(eventData?.pullrequest),
+        subtitle: buildSubtitle(eventData?.action || "", lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt!),
+        state: "triggered",
+        eventId: lastEvent.id!,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,91 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import bitbucketIcon from "@/assets/icons/integrations/bitbucket.svg";
+import type { TriggerProps } from "@/ui/trigger";
+import type { NodeMetadata } from "./types";
+import type { BitbucketPullRequest } from "./on_pull_request";
+import { buildSubtitle, pullRequestTitle, repositoryMetadataItems } from "./on_pull_request";
+
+export interface OnPullRequestCommentConfiguration {
+  repository?: string;
+  contentFilter?: string;
+}
+
+export interface BitbucketPullRequestCommentEvent {
+  actor?: {
+    display_name?: string;
+  };
+  pullrequest?: BitbucketPullRequest;
+  comment?: {
+    id?: number;
+    content?: {
+      raw?: string;
+    };
+    links?: {
+      html?: {
+        href?: string;
+      };
+    };
+  };
+}
+
+function commentTitle(eventData?: BitbucketPullRequestCommentEvent): string {
+  return eventData?.comment?.content?.raw?.split("\n")[0]?.trim() || "";
+}
+
+/**
+ * Renderer for the "bitbucket.onPullRequestComment" trigger
+ */
+export const onPullRequestCommentTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as BitbucketPullRequestCommentEvent;
+
+    return {
+      title: commentTitle(eventData),
+      subtitle: buildSubtitle(pullRequestTitle(eventData?.pullrequest), context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as BitbucketPullRequestCommentEvent;
+
+    return {
+      Comment: eventData?.comment?.content?.raw || "",
+      "Pull Request": pullRequestTitle(eventData?.pullrequest),
+      Author: eventData?.actor?.display_name || "",
+      URL: eventData?.comment?.links?.html?.href || "",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnPullRequestCommentConfiguration;
+    const metadataItems = repositoryMetadataItems(node.metadata as unknown as NodeMetadata);
+
+    if (configuration?.contentFilter) {
+      metadataItems.push({ icon: "funnel", label: configuration.contentFilter });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: bitbucketIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as BitbucketPullRequestCommentEvent;
+      props.lastEventData = {
+        title: commentTitle(eventData),
+        subtitle: buildSubtitle(pullRequestTitle(eventData?.pullrequest), lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt!),
+        state: "triggered",
+        eventId: lastEvent.id!,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,164 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  NodeInfo,
+  OutputPayload,
+  SubtitleContext,
+} from "./types";
+import type { ComponentBaseProps, EventSection, EventState, EventStateMap } from "@/ui/componentBase";
+import { DEFAULT_EVENT_STATE_MAP } from "@/ui/componentBase";
+import { getTriggerRenderer } from ".";
+import type React from "react";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import { defaultStateFunction } from "./stateRegistry";
+
+type CompareAndSwapMemoryMetadata = {
+  namespace?: string;
+  matchFields?: string[];
+  expect?: string;
+  swapped?: boolean;
+};
+
+type CompareAndSwapMemoryConfiguration = {
+  namespace?: string;
+  matchList?: Array<{ name?: string; value?: unknown }>;
+  expect?: string;
+  ttl?: string;
+};
+
+type CompareAndSwapMemoryOutputs = {
+  swapped?: OutputPayload[];
+  conflict?: OutputPayload[];
+};
+
+const COMPARE_AND_SWAP_MEMORY_STATE_MAP: EventStateMap = {
+  ...DEFAULT_EVENT_STATE_MAP,
+  conflict: {
+    icon: "circle-x",
+    textColor: "text-gray-800",
+    backgroundColor: "bg-orange-100",
+    badgeColor: "bg-orange-500",
+    label: "Conflict",
+  },
+};
+
+function getCompareAndSwapMemoryState(execution: ExecutionInfo): EventState {
+  const defaultState = defaultStateFunction(execution);
+  if (defaultState !== "success") {
+    return defaultState;
+  }
+
+  const outputs = execution.outputs as CompareAndSwapMemoryOutputs | undefined;
+  if (outputs?.conflict && outputs.conflict.length > 0) {
+    return "conflict";
+  }
+
+  return "success";
+}
+
+export const compareAndSwapMemoryMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+
+    return {
+      iconSlug: context.componentDefinition.icon ?? "database",
+      collapsed: context.node.isCollapsed,
+      collapsedBackground: "bg-white",
+      title:
+        context.node.name ||
+        context.componentDefinition.label ||
+        context.componentDefinition.name ||
+        "Unnamed component",
+      eventSections: lastExecution ? getEventSections(context.nodes, lastExecution) : undefined,
+      includeEmptyState: !lastExecution,
+      metadata: getCompareAndSwapMemoryMetadataList(context.node),
+      eventStateMap: COMPARE_AND_SWAP_MEMORY_STATE_MAP,
+    };
+  },
+  subtitle(context: SubtitleContext): string | React.ReactNode {
+    const timestamp = context.execution.updatedAt || context.execution.createdAt;
+    return timestamp ? renderTimeAgo(new Date(timestamp)) : "";
+  },
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const details: Record<string, string> = {};
+    const config = (context.node.configuration || {}) as CompareAndSwapMemoryConfiguration;
+    const metadata = (context.node.metadata || {}) as CompareAndSwapMemoryMetadata;
+    const namespace = (metadata.namespace || "").trim();
+    const matchFields = extractMatchFields(config, metadata);
+
+    if (namespace) {
+      details["Namespace"] = namespace;
+    }
+    if (matchFields.length > 0) {
+      details["Match Fields"] = matchFields.join(", ");
+    }
+    if (metadata.expect) {
+      details["Expect"] = expectLabel(metadata.expect);
+    }
+    if (typeof metadata.swapped === "boolean") {
+      details["Swapped"] = metadata.swapped ? "Yes" : "No";
+    }
+
+    return details;
+  },
+};
+
+function getEventSections(nodes: NodeInfo[], execution: ExecutionInfo): EventSection[] {
+  const rootTriggerNode = nodes.find((n) => n.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName || "");
+  const { title: fallbackTitle } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+  const subtitleTimestamp = execution.updatedAt || execution.createdAt;
+  const eventSubtitle = subtitleTimestamp ? renderTimeAgo(new Date(subtitleTimestamp)) : "";
+
+  return [
+    {
+      receivedAt: new Date(execution.createdAt),
+      eventTitle: fallbackTitle,
+      eventSubtitle,
+      eventState: getCompareAndSwapMemoryState(execution),
+      eventId: execution.rootEvent?.id || "",
+    },
+  ];
+}
+
+function getCompareAndSwapMemoryMetadataList(node: NodeInfo): Array<{ icon: string; label: string }> {
+  const config = (node.configuration || {}) as CompareAndSwapMemoryConfiguration;
+  const metadata = (node.metadata || {}) as CompareAndSwapMemoryMetadata;
+  const namespace = ((config.namespace as string) || metadata.namespace || "").trim();
+  const matchFields = extractMatchFields(config, metadata);
+  const items: Array<{ icon: string; label: string }> = [];
+
+  if (namespace) {
+    items.push({ icon: "database", label: namespace });
+  }
+  if (matchFields.length > 0) {
+    items.push({ icon: "search", label: `match: ${matchFields.join(", ")}` });
+  }
+  items.push({ icon: "lock", label: `expect: ${expectLabel(config.expect || metadata.expect)}` });
+  if ((config.ttl || "").trim()) {
+    items.push({ icon: "clock", label: `expires after ${config.ttl?.trim()}` });
+  }
+
+  return items;
+}
+
+function expectLabel(expect: string | undefined): string {
+  return expect === "match" ? "row with fields" : "no matching row";
+}
+
+function extractMatchFields(
+  config: CompareAndSwapMemoryConfiguration,
+  metadata: CompareAndSwapMemoryMetadata,
+): string[] {
+  const configFields = Array.isArray(config.matchList)
+    ? config.matchList.map((item) => (item?.name || "").trim()).filter((name): name is string => name.length > 0)
+    : [];
+
+  if (configFields.length > 0) {
+    return Array.from(new Set(configFields));
+  }
+
+  return Array.isArray(metadata.matchFields) ? metadata.matchFields.filter(Boolean) : [];
+}
@@ -0,0 +1,146 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+} from "../types";
+import type { ComponentBaseProps } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type { MetadataItem } from "@/ui/metadataList";
+import datadogIcon from "@/assets/icons/integrations/datadog.svg";
+import { noopMapper } from "../noop";
+import type { DatadogDowntime, DatadogMetricSeries, DatadogMonitor } from "./types";
+
+type DatadogConfiguration = {
+  query?: string;
+  timeRange?: string;
+  monitor?: string;
+  scope?: string;
+  duration?: string;
+  downtimeId?: string;
+  service?: string;
+  env?: string;
+  title?: string;
+  severity?: string;
+};
+
+type DatadogOutputs = {
+  default?: OutputPayload[];
+};
+
+type DatadogOutput = DatadogDowntime & {
+  monitor?: DatadogMonitor;
+  downtime?: DatadogDowntime;
+  canceled_downtimes?: string[];
+  series?: DatadogMetricSeries[];
+  name?: string;
+  overall_state?: string;
+  service?: string;
+  version?: string;
+  public_id?: number;
+  title?: string;
+  severity?: string;
+  state?: string;
+};
+
+const DURATION_LABELS: Record<string, string> = {
+  "30m": "30 minutes",
+  "1h": "1 hour",
+  "2h": "2 hours",
+  "4h": "4 hours",
+  "8h": "8 hours",
+  "24h": "24 hours",
+  none: "Until canceled",
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = (node.configuration as DatadogConfiguration | undefined) ?? {};
+
+  if (configuration.query) metadata.push({ icon: "chart-line", label: configuration.query });
+  if (configuration.timeRange) metadata.push({ icon: "clock", label: `Last ${configuration.timeRange}` });
+  if (configuration.monitor) metadata.push({ icon: "eye", label: `Monitor ${configuration.monitor}` });
+  if (configuration.scope && configuration.scope !== "*") metadata.push({ icon: "funnel", label: configuration.scope });
+  if (configuration.duration) {
+    metadata.push({ icon: "clock", label: DURATION_LABELS[configuration.duration] || configuration.duration });
+  }
+  if (configuration.downtimeId) metadata.push({ icon: "calendar-x", label: configuration.downtimeId });
+  if (configuration.service) {
+    metadata.push({
+      icon: "rocket",
+      label: configuration.env ? `${configuration.service} · ${configuration.env}` : configuration.service,
+    });
+  }
+  if (configuration.title) metadata.push({ icon: "siren", label: configuration.title });
+  if (configuration.severity) metadata.push({ icon: "flag", label: configuration.severity });
+
+  return metadata.slice(0, 3);
+}
+
+function formatNumber(value?: number): string {
+  return value === undefined ? "-" : String(Math.round(value * 1000) / 1000);
+}
+
+function getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+  const details: Record<string, string> = {};
+  const outputs = context.execution.outputs as DatadogOutputs | undefined;
+  const data = outputs?.default?.[0]?.data as DatadogOutput | undefined;
+
+  if (context.execution.createdAt) {
+    details["Started At"] = new Date(context.execution.createdAt).toLocaleString();
+  }
+
+  if (!data) {
+    return details;
+  }
+
+  data.series?.forEach((series) => {
+    const label = series.scope ? `${series.metric} (${series.scope})` : series.metric || "Series";
+    details[label] = [
+      `last ${formatNumber(series.last)}`,
+      `avg ${formatNumber(series.avg)}`,
+      `max ${formatNumber(series.max)}`,
+    ].join(" · ");
+  });
+  if (data.series && data.series.length === 0) details["Series"] = "No data";
+
+  const monitor = data.monitor || (data.overall_state ? (data as DatadogMonitor) : undefined);
+  if (monitor?.name) details["Monitor"] = monitor.name;
+  if (monitor?.overall_state) details["State"] = monitor.overall_state;
+  if (monitor?.url) details["Monitor URL"] = monitor.url;
+
+  const downtime = data.downtime || (data.scope ? data : undefined);
+  if (downtime?.id) details["Downtime"] = downtime.id;
+  if (downtime?.scope) details["Scope"] = downtime.scope;
+  if (downtime?.end) details["Ends At"] = new Date(downtime.end).toLocaleString();
+  if (data.canceled_downtimes) {
+    details["Canceled Downtimes"] = data.canceled_downtimes.length > 0 ? data.canceled_downtimes.join(", ") : "None";
+  }
+
+  if (data.service) details["Service"] = data.version ? `${data.service} ${data.version}` : data.service;
+  if (data.public_id) details["Incident"] = `#${data.public_id}`;
+  if (data.title) details["Title"] = data.title;
+  if (data.severity) details["Severity"] = data.severity;
+  if (data.url && !data.overall_state) details["URL"] = data.url;
+
+  return details;
+}
+
+function props(context: ComponentBaseContext): ComponentBaseProps {
+  const base = noopMapper.props(context);
+  return {
+    ...base,
+    iconSlug: undefined,
+    iconSrc: datadogIcon,
+    iconColor: getColorClass(context.componentDefinition.color),
+    collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+    metadata: metadataList(context.node),
+  };
+}
+
+export const datadogBaseMapper: ComponentBaseMapper = {
+  ...noopMapper,
+  props,
+  getExecutionDetails,
+};
@@ -1,13 +1,37 @@
-import type { ComponentBaseMapper, EventStateRegistry, TriggerRenderer } from "../types";
+import type { ComponentBaseMapper, CustomFieldRenderer, EventStateRegistry, TriggerRenderer } from "../types";
 import { createEventMapper } from "./create_event";
+import { datadogBaseMapper } from "./base";
+import { onMonitorAlertCustomFieldRenderer, onMonitorAlertTriggerRenderer } from "./on_monitor_alert";
 import { buildActionStateRegistry } from "../utils";
 
 export const componentMappers: Record<string, ComponentBaseMapper> = {
   createEvent: createEventMapper,
+  queryMetrics: datadogBaseMapper,
+  getMonitor: datadogBaseMapper,
+  muteMonitor: datadogBaseMapper,
+  unmuteMonitor: datadogBaseMapper,
+  createDowntime: datadogBaseMapper,
+  cancelDowntime: datadogBaseMapper,
+  submitDeployment: datadogBaseMapper,
+  createIncident: datadogBaseMapper,
 };
 
-export const triggerRenderers: Record<string, TriggerRenderer> = {};
+export const triggerRenderers: Record<string, TriggerRenderer> = {
+  onMonitorAlert: onMonitorAlertTriggerRenderer,
+};
+
+export const customFieldRenderers: Record<string, CustomFieldRenderer> = {
+  onMonitorAlert: onMonitorAlertCustomFieldRenderer,
+};
 
 export const eventStateRegistry: Record<string, EventStateRegistry> = {
   createEvent: buildActionStateRegistry("Event created"),
+  queryMetrics: buildActionStateRegistry("queried"),
+  getMonitor: buildActionStateRegistry("retrieved"),
+  muteMonitor: buildActionStateRegistry("muted"),
+  unmuteMonitor: buildActionStateRegistry("unmuted"),
+  createDowntime: buildActionStateRegistry("scheduled"),
+  cancelDowntime: buildActionStateRegistry("canceled"),
+  submitDeployment: buildActionStateRegistry("submitted"),
+  createIncident: buildActionStateRegistry("declared"),
 };
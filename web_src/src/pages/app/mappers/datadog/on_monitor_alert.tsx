@@ -0,0 +1,136 @@
+import type {
+  CustomFieldRenderer,
+  NodeInfo,
+  TriggerEventContext,
+  TriggerRenderer,
+  TriggerRendererContext,
+} from "../types";
+import React from "react";
+import type { TriggerProps } from "@/ui/trigger";
+import type { MetadataItem } from "@/ui/metadataList";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import datadogIcon from "@/assets/icons/integrations/datadog.svg";
+import type { MonitorAlert, OnMonitorAlertConfiguration, OnMonitorAlertMetadata } from "./types";
+
+export const onMonitorAlertTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as MonitorAlert;
+    return {
+      title: buildEventTitle(eventData),
+      subtitle: buildEventSubtitle(eventData, context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as MonitorAlert;
+    const values: Record<string, string> = {};
+
+    if (eventData?.monitor_name) values["Monitor"] = eventData.monitor_name;
+    if (eventData?.monitor_id) values["Monitor ID"] = String(eventData.monitor_id);
+    if (eventData?.alert_transition) values["Transition"] = eventData.alert_transition;
+    if (eventData?.alert_type) values["Alert Type"] = eventData.alert_type;
+    if (eventData?.priority) values["Priority"] = eventData.priority;
+    if (eventData?.alert_scope) values["Scope"] = eventData.alert_scope;
+    if (eventData?.hostname) values["Host"] = eventData.hostname;
+    if (eventData?.tags && eventData.tags.length > 0) values["Tags"] = eventData.tags.join(", ");
+    if (eventData?.date) values["Date"] = new Date(eventData.date).toLocaleString();
+    if (eventData?.link) values["URL"] = eventData.link;
+
+    return values;
+  },
+
+  getTriggerProps: (context: TriggerRendererContext): TriggerProps => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as OnMonitorAlertConfiguration | undefined;
+    const metadataItems: MetadataItem[] = [];
+
+    if (configuration?.transitions && configuration.transitions.length > 0) {
+      metadataItems.push({ icon: "funnel", label: configuration.transitions.join(", ") });
+    }
+
+    const monitors = (configuration?.monitors || []).filter((value) => value.trim().length > 0);
+    if (monitors.length > 0) {
+      metadataItems.push({ icon: "eye", label: monitors.length > 1 ? `${monitors.length} monitors` : "1 monitor" });
+    }
+
+    if (configuration?.tags && configuration.tags.length > 0) {
+      metadataItems.push({ icon: "tag", label: configuration.tags.join(", ") });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: datadogIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems.slice(0, 3),
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as MonitorAlert;
+      props.lastEventData = {
+        title: buildEventTitle(eventData),
+        subtitle: buildEventSubtitle(eventData, lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt),
+        state: "triggered",
+        eventId: lastEvent.id,
+      };
+    }
+
+    return props;
+  },
+};
+
+export const onMonitorAlertCustomFieldRenderer: CustomFieldRenderer = {
+  render: (node: NodeInfo) => {
+    const metadata = node.metadata as OnMonitorAlertMetadata | undefined;
+    const mention = metadata?.mention || "[MENTION GENERATED ONCE THE CANVAS IS SAVED]";
+
+    return (
+      <div className="border-t-1 border-gray-200 pt-4">
+        <div className="space-y-3">
+          <div>
+            <span className="text-sm font-medium text-gray-700 dark:text-gray-300">Datadog Webhook Setup</span>
+            <div className="text-xs text-gray-800 dark:text-gray-100 mt-2 border-1 border-gray-300 dark:border-gray-600 px-2.5 py-2 bg-gray-50 dark:bg-gray-800 rounded-md">
+              <p>
+                SuperPlane creates a webhook in the Datadog webhooks integration. Datadog only notifies it for monitors
+                that mention it, so add the mention below to the message of every monitor this trigger listens to.
+              </p>
+              <pre className="mt-2 text-xs text-gray-800 dark:text-gray-100 border-1 border-gray-300 dark:border-gray-600 px-2.5 py-2 bg-white dark:bg-gray-900 rounded-md font-mono whitespace-pre-wrap break-all">
+                {mention}
+              </pre>
+            </div>
+          </div>
+        </div>
+      </div>
+    );
+  },
+};
+
+function buildEventTitle(eventData: MonitorAlert): string {
+  const name = eventData?.monitor_name || eventData?.title || "Monitor";
+  return eventData?.alert_transition ? `${name} · ${eventData.alert_transition}` : name;
+}
+
+function buildEventSubtitle(eventData: MonitorAlert, createdAt?: string): string | React.ReactNode {
+  const parts: string[] = [];
+
+  if (eventData?.priority) {
+    parts.push(eventData.priority);
+  }
+
+  if (eventData?.alert_scope) {
+    parts.push(eventData.alert_scope);
+  }
+
+  const timeAgo = createdAt ? renderTimeAgo(new Date(createdAt)) : "";
+  if (parts.length > 0 && timeAgo) {
+    return (
+      <span>
+        {parts.join(" · ")} · {timeAgo}
+      </span>
+    );
+  }
+
+  return parts.length > 0 ? parts.join(" · ") : timeAgo;
+}
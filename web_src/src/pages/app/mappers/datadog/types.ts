@@ -3,6 +3,9 @@ export interface MonitorAlert {
   event_type?: string;
   alert_type?: string;
   alert_transition?: string;
+  alert_status?: string;
+  alert_scope?: string;
+  alert_query?: string;
   hostname?: string;
   monitor_id?: number;
   monitor_name?: string;
@@ -11,9 +14,49 @@ export interface MonitorAlert {
   title?: string;
   date?: number;
   body?: string;
+  link?: string;
   org?: OrgInfo;
 }
 
+export interface OnMonitorAlertConfiguration {
+  transitions?: string[];
+  monitors?: string[];
+  tags?: string[];
+}
+
+export interface OnMonitorAlertMetadata {
+  webhookName?: string;
+  mention?: string;
+}
+
+export interface DatadogMonitor {
+  id?: number;
+  name?: string;
+  type?: string;
+  overall_state?: string;
+  priority?: number;
+  tags?: string[];
+  url?: string;
+}
+
+export interface DatadogDowntime {
+  id?: string;
+  scope?: string;
+  status?: string;
+  start?: string;
+  end?: string;
+  url?: string;
+}
+
+export interface DatadogMetricSeries {
+  metric?: string;
+  scope?: string;
+  min?: number;
+  max?: number;
+  avg?: number;
+  last?: number;
+}
+
 export interface OrgInfo {
   id?: number;
   name?: string;
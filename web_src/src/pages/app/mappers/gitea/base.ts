@@ -0,0 +1,120 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+} from "../types";
+import type { ComponentBaseProps } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type { MetadataItem } from "@/ui/metadataList";
+import giteaIcon from "@/assets/icons/integrations/gitea.svg";
+import { noopMapper } from "../noop";
+import type { NodeMetadata } from "./types";
+
+type GiteaConfiguration = {
+  repository?: string;
+  number?: string;
+  pullRequest?: string;
+  commit?: string;
+  state?: string;
+  context?: string;
+  tag?: string;
+  mergeStyle?: string;
+};
+
+type GiteaOutputs = {
+  default?: OutputPayload[];
+};
+
+type GiteaOutput = {
+  id?: number;
+  number?: number;
+  title?: string;
+  state?: string;
+  context?: string;
+  commit?: string;
+  tag_name?: string;
+  name?: string;
+  merge_commit_sha?: string;
+  user?: string;
+  author?: string;
+  creator?: string;
+  url?: string;
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = (node.configuration as GiteaConfiguration | undefined) ?? {};
+  const nodeMetadata = node.metadata as NodeMetadata | undefined;
+
+  const repository = nodeMetadata?.repository?.full_name || configuration.repository;
+  if (repository) {
+    metadata.push({ icon: "book", label: repository });
+  }
+  if (configuration.number) {
+    metadata.push({ icon: "hash", label: configuration.number });
+  }
+  if (configuration.pullRequest) {
+    metadata.push({ icon: "git-pull-request", label: `PR: ${configuration.pullRequest}` });
+  }
+  if (configuration.tag) {
+    metadata.push({ icon: "tag", label: configuration.tag });
+  }
+  if (configuration.state) {
+    const label = configuration.context ? `${configuration.context}: ${configuration.state}` : configuration.state;
+    metadata.push({ icon: "circle-dot", label });
+  }
+  if (configuration.mergeStyle) {
+    metadata.push({ icon: "git-merge", label: configuration.mergeStyle });
+  }
+
+  return metadata;
+}
+
+function getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+  const details: Record<string, string> = {};
+  const outputs = context.execution.outputs as GiteaOutputs | undefined;
+  const data = outputs?.default?.[0]?.data as GiteaOutput | undefined;
+
+  if (context.execution.createdAt) {
+    details["Started At"] = new Date(context.execution.createdAt).toLocaleString();
+  }
+
+  if (!data) {
+    return details;
+  }
+
+  if (typeof data.number === "number") details["Number"] = `#${data.number}`;
+  if (data.title) details["Title"] = data.title;
+  if (data.tag_name) details["Tag"] = data.tag_name;
+  if (data.name) details["Name"] = data.name;
+  if (data.context) details["Context"] = data.context;
+  if (data.state) details["State"] = data.state;
+  if (data.commit) details["Commit"] = data.commit.slice(0, 12);
+  if (data.merge_commit_sha) details["Merge Commit"] = data.merge_commit_sha.slice(0, 12);
+
+  const user = data.user || data.author || data.creator;
+  if (user) details["User"] = user;
+  if (data.url) details["URL"] = data.url;
+
+  return details;
+}
+
+function props(context: ComponentBaseContext): ComponentBaseProps {
+  const base = noopMapper.props(context);
+  return {
+    ...base,
+    iconSlug: undefined,
+    iconSrc: giteaIcon,
+    iconColor: getColorClass(context.componentDefinition.color),
+    collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+    metadata: metadataList(context.node),
+  };
+}
+
+export const giteaBaseMapper: ComponentBaseMapper = {
+  ...noopMapper,
+  props,
+  getExecutionDetails,
+};
@@ -0,0 +1,30 @@
+import type { ComponentBaseMapper, EventStateRegistry, TriggerRenderer } from "../types";
+import { buildActionStateRegistry } from "../utils";
+import { giteaBaseMapper } from "./base";
+import { onIssueCommentTriggerRenderer } from "./on_issue_comment";
+import { onPullRequestTriggerRenderer } from "./on_pull_request";
+import { onPushTriggerRenderer } from "./on_push";
+import { onReleaseTriggerRenderer } from "./on_release";
+import { onTagTriggerRenderer } from "./on_tag";
+
+export const componentMappers: Record<string, ComponentBaseMapper> = {
+  createComment: giteaBaseMapper,
+  setCommitStatus: giteaBaseMapper,
+  createRelease: giteaBaseMapper,
+  mergePullRequest: giteaBaseMapper,
+};
+
+export const triggerRenderers: Record<string, TriggerRenderer> = {
+  onPush: onPushTriggerRenderer,
+  onTag: onTagTriggerRenderer,
+  onPullRequest: onPullRequestTriggerRenderer,
+  onIssueComment: onIssueCommentTriggerRenderer,
+  onRelease: onReleaseTriggerRenderer,
+};
+
+export const eventStateRegistry: Record<string, EventStateRegistry> = {
+  createComment: buildActionStateRegistry("commented"),
+  setCommitStatus: buildActionStateRegistry("updated"),
+  createRelease: buildActionStateRegistry("created"),
+  mergePullRequest: buildActionStateRegistry("merged"),
+};
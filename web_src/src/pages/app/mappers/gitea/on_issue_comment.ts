@@ -0,0 +1,95 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import giteaIcon from "@/assets/icons/integrations/gitea.svg";
+import type { TriggerProps } from "@/ui/trigger";
+import type { GiteaRepository, GiteaUser, NodeMetadata } from "./types";
+import { buildSubtitle, repositoryMetadataItems } from "./on_push";
+
+export interface OnIssueCommentConfiguration {
+  repository?: string;
+  contentFilter?: string;
+}
+
+export interface GiteaIssueCommentEvent {
+  action?: string;
+  is_pull?: boolean;
+  issue?: {
+    number?: number;
+    title?: string;
+    html_url?: string;
+  };
+  comment?: {
+    id?: number;
+    body?: string;
+    html_url?: string;
+    user?: GiteaUser;
+  };
+  repository?: GiteaRepository;
+  sender?: GiteaUser;
+}
+
+function issueTitle(event?: GiteaIssueCommentEvent): string {
+  if (!event?.issue) return "";
+  return `#${event.issue.number ?? ""} ${event.issue.title ?? ""}`.trim();
+}
+
+function commentPreview(event?: GiteaIssueCommentEvent): string {
+  return event?.comment?.body?.split("\n")[0]?.trim() || "";
+}
+
+/**
+ * Renderer for the "gitea.onIssueComment" trigger
+ */
+export const onIssueCommentTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as GiteaIssueCommentEvent;
+
+    return {
+      title: issueTitle(eventData),
+      subtitle: buildSubtitle(commentPreview(eventData), context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as GiteaIssueCommentEvent;
+
+    return {
+      [eventData?.is_pull ? "Pull Request" : "Issue"]: issueTitle(eventData),
+      Comment: eventData?.comment?.body || "",
+      Author: eventData?.comment?.user?.login || "",
+      URL: eventData?.comment?.html_url || "",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnIssueCommentConfiguration;
+    const metadataItems = repositoryMetadataItems(node.metadata as unknown as NodeMetadata);
+
+    if (configuration?.contentFilter) {
+      metadataItems.push({ icon: "funnel", label: configuration.contentFilter });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: giteaIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as GiteaIssueCommentEvent;
+      props.lastEventData = {
+        title: issueTitle(eventData),
+        subtitle: buildSubtitle(commentPreview(eventData), lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt!),
+        state: "triggered",
+        eventId: lastEvent.id!,
+      };
+    }
+
+    return props;
+  },
+};
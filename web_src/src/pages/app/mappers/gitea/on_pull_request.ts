@@ -0,0 +1,107 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import giteaIcon from "@/assets/icons/integrations/gitea.svg";
+import type { TriggerProps } from "@/ui/trigger";
+import type { GiteaRepository, GiteaUser, NodeMetadata } from "./types";
+import type { Predicate } from "../utils";
+import { formatPredicate } from "../utils";
+import { buildSubtitle, repositoryMetadataItems } from "./on_push";
+
+export interface OnPullRequestConfiguration {
+  repository?: string;
+  actions?: string[];
+  targetBranches?: Predicate[];
+}
+
+export interface GiteaPullRequest {
+  number?: number;
+  title?: string;
+  state?: string;
+  merged?: boolean;
+  html_url?: string;
+  user?: GiteaUser;
+  head?: {
+    ref?: string;
+    sha?: string;
+  };
+  base?: {
+    ref?: string;
+  };
+}
+
+export interface GiteaPullRequestEvent {
+  action?: string;
+  number?: number;
+  pull_request?: GiteaPullRequest;
+  repository?: GiteaRepository;
+  sender?: GiteaUser;
+}
+
+function pullRequestTitle(pullRequest?: GiteaPullRequest): string {
+  if (!pullRequest) return "";
+  return `#${pullRequest.number ?? ""} ${pullRequest.title ?? ""}`.trim();
+}
+
+/**
+ * Renderer for the "gitea.onPullRequest" trigger
+ */
+export const onPullRequestTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as GiteaPullRequestEvent;
+
+    return {
+      title: pullRequestTitle(eventData?.pull_request),
+      subtitle: buildSubtitle(eventData?.action || "", context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as GiteaPullRequestEvent;
+    const pullRequest = eventData?.pull_request;
+
+    return {
+      Action: eventData?.action || "",
+      "Pull Request": pullRequestTitle(pullRequest),
+      Head: pullRequest?.head?.ref || "",
+      Base: pullRequest?.base?.ref || "",
+      Author: pullRequest?.user?.login || "",
+      URL: pullRequest?.html_url || "",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnPullRequestConfiguration;
+    const metadataItems = repositoryMetadataItems(node.metadata as unknown as NodeMetadata);
+
+    if (configuration?.actions && configuration.actions.length > 0) {
+      metadataItems.push({ icon: "funnel", label: configuration.actions.join(", ") });
+    }
+
+    if (configuration?.targetBranches && configuration.targetBranches.length > 0) {
+      metadataItems.push({ icon: "git-branch", label: configuration.targetBranches.map(formatPredicate).join(", ") });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: giteaIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as GiteaPullRequestEvent;
+      props.lastEventData = {
+        title: pullRequestTitle(eventData?.pull_request),
+        subtitle: buildSubtitle(eventData?.action || "", lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt!),
+        state: "triggered",
+        eventId: lastEvent.id!,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,119 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import giteaIcon from "@/assets/icons/integrations/gitea.svg";
+import type { TriggerProps } from "@/ui/trigger";
+import type { MetadataItem } from "@/ui/metadataList";
+import type { GiteaRepository, GiteaUser, NodeMetadata } from "./types";
+import type { Predicate } from "../utils";
+import { formatPredicate } from "../utils";
+import { renderTimeAgo, renderWithTimeAgo } from "@/components/TimeAgo";
+
+export interface OnPushConfiguration {
+  repository?: string;
+  refs?: Predicate[];
+  paths?: string[];
+}
+
+export interface GiteaCommit {
+  id?: string;
+  message?: string;
+  url?: string;
+  author?: {
+    name?: string;
+    username?: string;
+  };
+}
+
+export interface GiteaPush {
+  ref?: string;
+  before?: string;
+  after?: string;
+  commits?: GiteaCommit[];
+  head_commit?: GiteaCommit;
+  repository?: GiteaRepository;
+  pusher?: GiteaUser;
+}
+
+export function buildSubtitle(content: string, createdAt?: string): string | React.ReactNode {
+  if (content && createdAt) {
+    return renderWithTimeAgo(content, new Date(createdAt));
+  }
+  return content || (createdAt ? renderTimeAgo(new Date(createdAt)) : "");
+}
+
+export function repositoryMetadataItems(metadata?: NodeMetadata): MetadataItem[] {
+  if (!metadata?.repository) {
+    return [];
+  }
+
+  return [{ icon: "book", label: metadata.repository.full_name || metadata.repository.name || "" }];
+}
+
+function pushTitle(push?: GiteaPush): string {
+  return push?.head_commit?.message?.split("\n")[0]?.trim() || push?.ref || "";
+}
+
+function shortSha(push?: GiteaPush): string {
+  return push?.after?.slice(0, 7) || "";
+}
+
+/**
+ * Renderer for the "gitea.onPush" trigger
+ */
+export const onPushTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as GiteaPush;
+
+    return {
+      title: pushTitle(eventData),
+      subtitle: buildSubtitle(shortSha(eventData), context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as GiteaPush;
+
+    return {
+      Ref: eventData?.ref || "",
+      Commit: eventData?.head_commit?.message?.trim() || "",
+      SHA: eventData?.after || "",
+      Author: eventData?.pusher?.login || "",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnPushConfiguration;
+    const metadataItems = repositoryMetadataItems(node.metadata as unknown as NodeMetadata);
+
+    if (configuration?.refs && configuration.refs.length > 0) {
+      metadataItems.push({ icon: "funnel", label: configuration.refs.map(formatPredicate).join(", ") });
+    }
+
+    if (configuration?.paths && configuration.paths.length > 0) {
+      metadataItems.push({ icon: "folder", label: configuration.paths.join(", ") });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: giteaIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as GiteaPush;
+      props.lastEventData = {
+        title: pushTitle(eventData),
+        subtitle: buildSubtitle(shortSha(eventData), lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt!),
+        state: "triggered",
+        eventId: lastEvent.id!,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,88 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import giteaIcon from "@/assets/icons/integrations/gitea.svg";
+import type { TriggerProps } from "@/ui/trigger";
+import type { GiteaRepository, GiteaUser, NodeMetadata } from "./types";
+import { buildSubtitle, repositoryMetadataItems } from "./on_push";
+
+export interface OnReleaseConfiguration {
+  repository?: string;
+  actions?: string[];
+}
+
+export interface GiteaReleaseEvent {
+  action?: string;
+  release?: {
+    id?: number;
+    tag_name?: string;
+    name?: string;
+    draft?: boolean;
+    prerelease?: boolean;
+    html_url?: string;
+    author?: GiteaUser;
+  };
+  repository?: GiteaRepository;
+  sender?: GiteaUser;
+}
+
+function releaseTitle(event?: GiteaReleaseEvent): string {
+  return event?.release?.name || event?.release?.tag_name || "";
+}
+
+/**
+ * Renderer for the "gitea.onRelease" trigger
+ */
+export const onReleaseTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as GiteaReleaseEvent;
+
+    return {
+      title: releaseTitle(eventData),
+      subtitle: buildSubtitle(eventData?.action || "", context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as GiteaReleaseEvent;
+
+    return {
+      Action: eventData?.action || "",
+      Release: releaseTitle(eventData),
+      Tag: eventData?.release?.tag_name || "",
+      Author: eventData?.release?.author?.login || "",
+      URL: eventData?.release?.html_url || "",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnReleaseConfiguration;
+    const metadataItems = repositoryMetadataItems(node.metadata as unknown as NodeMetadata);
+
+    if (configuration?.actions && configuration.actions.length > 0) {
+      metadataItems.push({ icon: "funnel", label: configuration.actions.join(", ") });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: giteaIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as GiteaReleaseEvent;
+      props.lastEventData = {
+        title: releaseTitle(eventData),
+        subtitle: buildSubtitle(eventData?.action || "", lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt!),
+        state: "triggered",
+        eventId: lastEvent.id!,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,77 @@
+import { getColorClass, getBackgroundColorClass } from "@/lib/colors";
+import type React from "react";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import giteaIcon from "@/assets/icons/integrations/gitea.svg";
+import type { TriggerProps } from "@/ui/trigger";
+import type { GiteaRepository, GiteaUser, NodeMetadata } from "./types";
+import type { Predicate } from "../utils";
+import { formatPredicate } from "../utils";
+import { buildSubtitle, repositoryMetadataItems } from "./on_push";
+
+export interface OnTagConfiguration {
+  repository?: string;
+  tags?: Predicate[];
+}
+
+export interface GiteaTagEvent {
+  ref?: string;
+  ref_type?: string;
+  sha?: string;
+  repository?: GiteaRepository;
+  sender?: GiteaUser;
+}
+
+/**
+ * Renderer for the "gitea.onTag" trigger
+ */
+export const onTagTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as GiteaTagEvent;
+
+    return {
+      title: eventData?.ref || "",
+      subtitle: buildSubtitle(eventData?.sha?.slice(0, 7) || "", context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as GiteaTagEvent;
+
+    return {
+      Tag: eventData?.ref || "",
+      SHA: eventData?.sha || "",
+      Author: eventData?.sender?.login || "",
+    };
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as unknown as OnTagConfiguration;
+    const metadataItems = repositoryMetadataItems(node.metadata as unknown as NodeMetadata);
+
+    if (configuration?.tags && configuration.tags.length > 0) {
+      metadataItems.push({ icon: "funnel", label: configuration.tags.map(formatPredicate).join(", ") });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: giteaIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as GiteaTagEvent;
+      props.lastEventData = {
+        title: eventData?.ref || "",
+        subtitle: buildSubtitle(eventData?.sha?.slice(0, 7) || "", lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt!),
+        state: "triggered",
+        eventId: lastEvent.id!,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,21 @@
+export interface NodeMetadata {
+  repository?: {
+    id?: number;
+    name?: string;
+    full_name?: string;
+    url?: string;
+  };
+}
+
+export interface GiteaUser {
+  id?: number;
+  login?: string;
+  full_name?: string;
+}
+
+export interface GiteaRepository {
+  id?: number;
+  name?: string;
+  full_name?: string;
+  html_url?: string;
+}
@@ -0,0 +1,127 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  ExecutionInfo,
+  NodeInfo,
+  SubtitleContext,
+} from "./types";
+import type { ComponentBaseProps, EventSection } from "@/ui/componentBase";
+import type React from "react";
+import { getStateMap, getTriggerRenderer } from ".";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import { defaultStateFunction } from "./stateRegistry";
+
+type IncrementMemoryMetadata = {
+  namespace?: string;
+  matchFields?: string[];
+  field?: string;
+  value?: number;
+};
+
+type IncrementMemoryConfiguration = {
+  namespace?: string;
+  matchList?: Array<{ name?: string; value?: unknown }>;
+  field?: string;
+  amount?: number;
+  ttl?: string;
+};
+
+export const incrementMemoryMapper: ComponentBaseMapper = {
+  props(context: ComponentBaseContext): ComponentBaseProps {
+    const lastExecution = context.lastExecutions.length > 0 ? context.lastExecutions[0] : null;
+    const componentName = context.componentDefinition.name ?? "incrementMemory";
+
+    return {
+      iconSlug: context.componentDefinition.icon ?? "database",
+      collapsed: context.node.isCollapsed,
+      collapsedBackground: "bg-white",
+      title:
+        context.node.name ||
+        context.componentDefinition.label ||
+        context.componentDefinition.name ||
+        "Unnamed component",
+      eventSections: lastExecution ? getEventSections(context.nodes, lastExecution) : undefined,
+      includeEmptyState: !lastExecution,
+      metadata: getIncrementMemoryMetadataList(context.node),
+      eventStateMap: getStateMap(componentName),
+    };
+  },
+  subtitle(context: SubtitleContext): string | React.ReactNode {
+    const timestamp = context.execution.updatedAt || context.execution.createdAt;
+    return timestamp ? renderTimeAgo(new Date(timestamp)) : "";
+  },
+  getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+    const details: Record<string, string> = {};
+    const config = (context.node.configuration || {}) as IncrementMemoryConfiguration;
+    const metadata = (context.node.metadata || {}) as IncrementMemoryMetadata;
+    const namespace = (metadata.namespace || "").trim();
+    const matchFields = extractMatchFields(config, metadata);
+
+    if (namespace) {
+      details["Namespace"] = namespace;
+    }
+    if (matchFields.length > 0) {
+      details["Match Fields"] = matchFields.join(", ");
+    }
+    if (metadata.field) {
+      details["Field"] = metadata.field;
+    }
+    if (typeof metadata.value === "number") {
+      details["Value"] = String(metadata.value);
+    }
+
+    return details;
+  },
+};
+
+function getEventSections(nodes: NodeInfo[], execution: ExecutionInfo): EventSection[] {
+  const rootTriggerNode = nodes.find((n) => n.id === execution.rootEvent?.nodeId);
+  const rootTriggerRenderer = getTriggerRenderer(rootTriggerNode?.componentName || "");
+  const { title: fallbackTitle } = rootTriggerRenderer.getTitleAndSubtitle({ event: execution.rootEvent });
+  const subtitleTimestamp = execution.updatedAt || execution.createdAt;
+  const eventSubtitle = subtitleTimestamp ? renderTimeAgo(new Date(subtitleTimestamp)) : "";
+
+  return [
+    {
+      receivedAt: new Date(execution.createdAt),
+      eventTitle: fallbackTitle,
+      eventSubtitle,
+      eventState: defaultStateFunction(execution),
+      eventId: execution.rootEvent?.id || "",
+    },
+  ];
+}
+
+function getIncrementMemoryMetadataList(node: NodeInfo): Array<{ icon: string; label: string }> {
+  const config = (node.configuration || {}) as IncrementMemoryConfiguration;
+  const metadata = (node.metadata || {}) as IncrementMemoryMetadata;
+  const namespace = ((config.namespace as string) || metadata.namespace || "").trim();
+  const field = ((config.field as string) || metadata.field || "").trim();
+  const amount = typeof config.amount === "number" ? config.amount : 1;
+  const items: Array<{ icon: string; label: string }> = [];
+
+  if (namespace) {
+    items.push({ icon: "database", label: namespace });
+  }
+  if (field) {
+    items.push({ icon: "plus", label: `${field} ${amount < 0 ? "-" : "+"} ${Math.abs(amount)}` });
+  }
+  if ((config.ttl || "").trim()) {
+    items.push({ icon: "clock", label: `expires after ${config.ttl?.trim()}` });
+  }
+
+  return items;
+}
+
+function extractMatchFields(config: IncrementMemoryConfiguration, metadata: IncrementMemoryMetadata): string[] {
+  const configFields = Array.isArray(config.matchList)
+    ? config.matchList.map((item) => (item?.name || "").trim()).filter((name): name is string => name.length > 0)
+    : [];
+
+  if (configFields.length > 0) {
+    return Array.from(new Set(configFields));
+  }
+
+  return Array.isArray(metadata.matchFields) ? metadata.matchFields.filter(Boolean) : [];
+}
@@ -17,10 +17,12 @@ import { webhookTriggerRenderer, webhookCustomFieldRenderer } from "./webhook";
 import { noopMapper } from "./noop";
 import { displayMapper } from "./display";
 import { addMemoryMapper } from "./addMemory";
+import { compareAndSwapMemoryMapper } from "./compareAndSwapMemory";
 import { deleteMemoryMapper } from "./deleteMemory";
 import { readMemoryMapper } from "./readMemory";
 import { updateMemoryMapper } from "./updateMemory";
 import { upsertMemoryMapper } from "./upsertMemory";
+import { incrementMemoryMapper } from "./incrementMemory";
 import { ifMapper, IF_STATE_REGISTRY } from "./if";
 import { httpMapper, HTTP_STATE_REGISTRY } from "./http";
 import { graphqlMapper, GRAPHQL_STATE_REGISTRY } from "./graphql";
@@ -78,6 +80,7 @@ import {
   componentMappers as datadogComponentMappers,
   triggerRenderers as datadogTriggerRenderers,
   eventStateRegistry as datadogEventStateRegistry,
+  customFieldRenderers as datadogCustomFieldRenderers,
 } from "./datadog/index";
 import {
   componentMappers as slackComponentMappers,
@@ -136,7 +139,11 @@ import {
   triggerRenderers as awsTriggerRenderers,
   eventStateRegistry as awsEventStateRegistry,
 } from "./aws";
-import { triggerRenderers as bitbucketTriggerRenderers } from "./bitbucket/index";
+import {
+  componentMappers as bitbucketComponentMappers,
+  triggerRenderers as bitbucketTriggerRenderers,
+  eventStateRegistry as bitbucketEventStateRegistry,
+} from "./bitbucket/index";
 import { componentMappers as coolifyComponentMappers } from "./coolify/index";
 import { componentMappers as hetznerComponentMappers } from "./hetzner/index";
 import {
@@ -201,6 +208,48 @@ import {
   triggerRenderers as claudeTriggerRenderers,
   eventStateRegistry as claudeEventStateRegistry,
 } from "./claude/index";
+import {
+  componentMappers as kubernetesComponentMappers,
+  triggerRenderers as kubernetesTriggerRenderers,
+  eventStateRegistry as kubernetesEventStateRegistry,
+} from "./kubernetes/index";
+import {
+  componentMappers as argocdComponentMappers,
+  customFieldRenderers as argocdCustomFieldRenderers,
+  triggerRenderers as argocdTriggerRenderers,
+  eventStateRegistry as argocdEventStateRegistry,
+} from "./argocd/index";
+import {
+  componentMappers as terraformComponentMappers,
+  triggerRenderers as terraformTriggerRenderers,
+  eventStateRegistry as terraformEventStateRegistry,
+} from "./terraform/index";
+import {
+  componentMappers as jenkinsComponentMappers,
+  customFieldRenderers as jenkinsCustomFieldRenderers,
+  triggerRenderers as jenkinsTriggerRenderers,
+  eventStateRegistry as jenkinsEventStateRegistry,
+} from "./jenkins/index";
+import {
+  componentMappers as opsgenieComponentMappers,
+  triggerRenderers as opsgenieTriggerRenderers,
+  eventStateRegistry as opsgenieEventStateRegistry,
+} from "./opsgenie/index";
+import {
+  componentMappers as azureDevOpsComponentMappers,
+  triggerRenderers as azureDevOpsTriggerRenderers,
+  eventStateRegistry as azureDevOpsEventStateRegistry,
+} from "./azuredevops/index";
+import {
+  componentMappers as giteaComponentMappers,
+  triggerRenderers as giteaTriggerRenderers,
+  eventStateRegistry as giteaEventStateRegistry,
+} from "./gitea/index";
+import {
+  componentMappers as vaultComponentMappers,
+  triggerRenderers as vaultTriggerRenderers,
+  eventStateRegistry as vaultEventStateRegistry,
+} from "./vault/index";
 import {
   componentMappers as logfireComponentMappers,
   triggerRenderers as logfireTriggerRenderers,
@@ -304,6 +353,8 @@ const componentBaseMappers: Record<string, ComponentBaseMapper> = {
   readMemory: readMemoryMapper,
   updateMemory: updateMemoryMapper,
   upsertMemory: upsertMemoryMapper,
+  incrementMemory: incrementMemoryMapper,
+  compareAndSwapMemory: compareAndSwapMemoryMapper,
   if: ifMapper,
   loop: loopMapper,
   http: httpMapper,
@@ -373,6 +424,15 @@ const appMappers: Record<string, Record<string, ComponentBaseMapper>> = {
   servicenow: servicenowComponentMappers,
   elastic: elasticComponentMappers,
   oci: ociComponentMappers,
+  kubernetes: kubernetesComponentMappers,
+  argocd: argocdComponentMappers,
+  terraform: terraformComponentMappers,
+  jenkins: jenkinsComponentMappers,
+  opsgenie: opsgenieComponentMappers,
+  bitbucket: bitbucketComponentMappers,
+  azuredevops: azureDevOpsComponentMappers,
+  gitea: giteaComponentMappers,
+  vault: vaultComponentMappers,
 };
 
 const appTriggerRenderers: Record<string, Record<string, TriggerRenderer>> = {
@@ -423,6 +483,14 @@ const appTriggerRenderers: Record<string, Record<string, TriggerRenderer>> = {
   servicenow: servicenowTriggerRenderers,
   elastic: elasticTriggerRenderers,
   oci: ociTriggerRenderers,
+  kubernetes: kubernetesTriggerRenderers,
+  argocd: argocdTriggerRenderers,
+  terraform: terraformTriggerRenderers,
+  jenkins: jenkinsTriggerRenderers,
+  opsgenie: opsgenieTriggerRenderers,
+  azuredevops: azureDevOpsTriggerRenderers,
+  gitea: giteaTriggerRenderers,
+  vault: vaultTriggerRenderers,
 };
 
 const appEventStateRegistries: Record<string, Record<string, EventStateRegistry>> = {
@@ -472,6 +540,15 @@ const appEventStateRegistries: Record<string, Record<string, EventStateRegistry>
   servicenow: servicenowEventStateRegistry,
   elastic: elasticEventStateRegistry,
   oci: ociEventStateRegistry,
+  kubernetes: kubernetesEventStateRegistry,
+  argocd: argocdEventStateRegistry,
+  terraform: terraformEventStateRegistry,
+  jenkins: jenkinsEventStateRegistry,
+  opsgenie: opsgenieEventStateRegistry,
+  bitbucket: bitbucketEventStateRegistry,
+  azuredevops: azureDevOpsEventStateRegistry,
+  gitea: giteaEventStateRegistry,
+  vault: vaultEventStateRegistry,
 };
 
 const eventStateRegistries: Record<string, EventStateRegistry> = {
@@ -508,6 +585,9 @@ const appCustomFieldRenderers: Record<string, Record<string, CustomFieldRenderer
   incident: incidentCustomFieldRenderers,
   gcp: gcpCustomFieldRenderers,
   servicenow: servicenowCustomFieldRenderers,
+  argocd: argocdCustomFieldRenderers,
+  jenkins: jenkinsCustomFieldRenderers,
+  datadog: datadogCustomFieldRenderers,
 };
 
 /**
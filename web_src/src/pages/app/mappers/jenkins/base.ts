@@ -0,0 +1,148 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  EventStateRegistry,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+  StateFunction,
+} from "../types";
+import type { ComponentBaseProps, EventStateMap } from "@/ui/componentBase";
+import { DEFAULT_EVENT_STATE_MAP } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type { MetadataItem } from "@/ui/metadataList";
+import jenkinsIcon from "@/assets/icons/integrations/jenkins.svg";
+import { noopMapper } from "../noop";
+import { defaultStateFunction } from "../stateRegistry";
+
+type JenkinsConfiguration = {
+  job?: string;
+  buildNumber?: string;
+  lines?: number;
+  parameters?: Array<{ name?: string; value?: string }>;
+};
+
+type JenkinsOutputs = {
+  default?: OutputPayload[];
+  success?: OutputPayload[];
+  failed?: OutputPayload[];
+};
+
+type JenkinsBuildOutput = {
+  job?: string;
+  number?: number;
+  url?: string;
+  result?: string;
+  duration?: number;
+  aborted?: boolean;
+  lines?: number;
+  truncated?: boolean;
+  available?: boolean;
+  total?: number;
+  passCount?: number;
+  failCount?: number;
+  skipCount?: number;
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = (node.configuration as JenkinsConfiguration | undefined) ?? {};
+
+  if (configuration.job) {
+    metadata.push({ icon: "briefcase", label: configuration.job });
+  }
+  if (configuration.buildNumber) {
+    metadata.push({ icon: "hash", label: `Build: ${configuration.buildNumber}` });
+  }
+  if (typeof configuration.lines === "number") {
+    metadata.push({ icon: "file-text", label: `Last ${configuration.lines} lines` });
+  }
+  if (configuration.parameters && configuration.parameters.length > 0) {
+    const names = configuration.parameters.map((parameter) => parameter.name).filter(Boolean);
+    metadata.push({
+      icon: "sliders-horizontal",
+      label: names.length > 2 ? `${names.length} parameters` : names.join(", "),
+    });
+  }
+
+  return metadata;
+}
+
+function getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+  const details: Record<string, string> = {};
+  const outputs = context.execution.outputs as JenkinsOutputs | undefined;
+  const data = (outputs?.default?.[0] ?? outputs?.success?.[0] ?? outputs?.failed?.[0])?.data as
+    | JenkinsBuildOutput
+    | undefined;
+
+  if (context.execution.createdAt) {
+    details["Started At"] = new Date(context.execution.createdAt).toLocaleString();
+  }
+
+  if (!data) {
+    return details;
+  }
+
+  if (data.job) details["Job"] = data.job;
+  if (typeof data.number === "number") details["Build"] = `#${data.number}`;
+  if (data.result) details["Result"] = data.result;
+  if (typeof data.duration === "number" && data.duration > 0) {
+    details["Duration"] = `${Math.round(data.duration / 1000)}s`;
+  }
+  if (typeof data.aborted === "boolean") details["Aborted"] = data.aborted ? "Yes" : "No";
+  if (typeof data.truncated === "boolean") details["Truncated"] = data.truncated ? "Yes" : "No";
+
+  if (data.available === false) {
+    details["Test Report"] = "Not published";
+  } else if (typeof data.total === "number") {
+    details["Tests"] = `${data.passCount ?? 0} passed, ${data.failCount ?? 0} failed, ${data.skipCount ?? 0} skipped`;
+  }
+
+  if (data.url) details["URL"] = data.url;
+
+  return details;
+}
+
+function props(context: ComponentBaseContext): ComponentBaseProps {
+  const base = noopMapper.props(context);
+  return {
+    ...base,
+    iconSlug: undefined,
+    iconSrc: jenkinsIcon,
+    iconColor: getColorClass(context.componentDefinition.color),
+    collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+    metadata: metadataList(context.node),
+  };
+}
+
+export const jenkinsBaseMapper: ComponentBaseMapper = {
+  ...noopMapper,
+  props,
+  getExecutionDetails,
+};
+
+export const BUILD_STATE_MAP: EventStateMap = {
+  ...DEFAULT_EVENT_STATE_MAP,
+  failed: {
+    icon: "circle-x",
+    textColor: "text-gray-800",
+    backgroundColor: "bg-red-100",
+    badgeColor: "bg-red-500",
+  },
+};
+
+export const buildStateFunction: StateFunction = (execution) => {
+  if (!execution) return "neutral";
+
+  const outputs = execution.outputs as JenkinsOutputs | undefined;
+  if (outputs?.failed?.length) {
+    return "failed";
+  }
+
+  return defaultStateFunction(execution);
+};
+
+export const BUILD_STATE_REGISTRY: EventStateRegistry = {
+  stateMap: BUILD_STATE_MAP,
+  getState: buildStateFunction,
+};
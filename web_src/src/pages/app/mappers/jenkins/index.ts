@@ -0,0 +1,26 @@
+import type { ComponentBaseMapper, CustomFieldRenderer, EventStateRegistry, TriggerRenderer } from "../types";
+import { buildActionStateRegistry } from "../utils";
+import { BUILD_STATE_REGISTRY, jenkinsBaseMapper } from "./base";
+import { onBuildCompletedCustomFieldRenderer, onBuildCompletedTriggerRenderer } from "./on_build_completed";
+
+export const componentMappers: Record<string, ComponentBaseMapper> = {
+  triggerBuild: jenkinsBaseMapper,
+  getConsoleLog: jenkinsBaseMapper,
+  getTestReport: jenkinsBaseMapper,
+  abortBuild: jenkinsBaseMapper,
+};
+
+export const triggerRenderers: Record<string, TriggerRenderer> = {
+  onBuildCompleted: onBuildCompletedTriggerRenderer,
+};
+
+export const customFieldRenderers: Record<string, CustomFieldRenderer> = {
+  onBuildCompleted: onBuildCompletedCustomFieldRenderer,
+};
+
+export const eventStateRegistry: Record<string, EventStateRegistry> = {
+  triggerBuild: BUILD_STATE_REGISTRY,
+  getConsoleLog: buildActionStateRegistry("retrieved"),
+  getTestReport: buildActionStateRegistry("retrieved"),
+  abortBuild: buildActionStateRegistry("aborted"),
+};
@@ -0,0 +1,150 @@
+import type {
+  CustomFieldRenderer,
+  NodeInfo,
+  TriggerEventContext,
+  TriggerRenderer,
+  TriggerRendererContext,
+} from "../types";
+import React from "react";
+import type { TriggerProps } from "@/ui/trigger";
+import type { MetadataItem } from "@/ui/metadataList";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import jenkinsIcon from "@/assets/icons/integrations/jenkins.svg";
+
+interface OnBuildCompletedConfiguration {
+  jobs?: string[];
+  results?: string[];
+}
+
+interface OnBuildCompletedMetadata {
+  webhookUrl?: string;
+}
+
+interface BuildCompletedEvent {
+  job?: string;
+  number?: number;
+  result?: string;
+  url?: string;
+  duration?: number;
+  scm?: { url?: string; branch?: string; commit?: string };
+}
+
+export const onBuildCompletedTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const eventData = context.event?.data as BuildCompletedEvent;
+    return {
+      title: buildEventTitle(eventData),
+      subtitle: buildEventSubtitle(eventData, context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const eventData = context.event?.data as BuildCompletedEvent;
+    const values: Record<string, string> = {};
+
+    if (eventData?.job) values["Job"] = eventData.job;
+    if (typeof eventData?.number === "number") values["Build"] = `#${eventData.number}`;
+    if (eventData?.result) values["Result"] = eventData.result;
+    if (eventData?.scm?.branch) values["Branch"] = eventData.scm.branch;
+    if (eventData?.scm?.commit) values["Commit"] = eventData.scm.commit;
+    if (eventData?.url) values["URL"] = eventData.url;
+
+    return values;
+  },
+
+  getTriggerProps: (context: TriggerRendererContext): TriggerProps => {
+    const { node, definition, lastEvent } = context;
+    const configuration = node.configuration as OnBuildCompletedConfiguration | undefined;
+    const metadataItems: MetadataItem[] = [];
+
+    const jobs = (configuration?.jobs || []).filter((value) => value.trim().length > 0);
+    if (jobs.length > 0) {
+      metadataItems.push({
+        icon: "briefcase",
+        label: jobs.length > 2 ? `${jobs.length} jobs` : jobs.join(", "),
+      });
+    }
+
+    if (configuration?.results && configuration.results.length > 0) {
+      metadataItems.push({ icon: "funnel", label: configuration.results.join(", ") });
+    }
+
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: jenkinsIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataItems,
+    };
+
+    if (lastEvent) {
+      const eventData = lastEvent.data as BuildCompletedEvent;
+      props.lastEventData = {
+        title: buildEventTitle(eventData),
+        subtitle: buildEventSubtitle(eventData, lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt),
+        state: "triggered",
+        eventId: lastEvent.id,
+      };
+    }
+
+    return props;
+  },
+};
+
+export const onBuildCompletedCustomFieldRenderer: CustomFieldRenderer = {
+  render: (node: NodeInfo) => {
+    const metadata = node.metadata as OnBuildCompletedMetadata | undefined;
+    const webhookUrl = metadata?.webhookUrl || "[URL GENERATED ONCE THE CANVAS IS SAVED]";
+
+    return (
+      <div className="border-t-1 border-gray-200 pt-4">
+        <div className="space-y-3">
+          <div>
+            <span className="text-sm font-medium text-gray-700 dark:text-gray-300">Jenkins Notification Setup</span>
+            <div className="text-xs text-gray-800 dark:text-gray-100 mt-2 border-1 border-gray-300 dark:border-gray-600 px-2.5 py-2 bg-gray-50 dark:bg-gray-800 rounded-md">
+              <ol className="list-decimal ml-4 space-y-1">
+                <li>Save the canvas to generate the webhook URL.</li>
+                <li>Install the Notification plugin in Jenkins.</li>
+                <li>On each job, open Configure → Job Notifications → Add Endpoint.</li>
+                <li>Set Format to JSON, Protocol to HTTP, Event to Job Completed, and URL to the webhook URL.</li>
+              </ol>
+              <p className="mt-3">
+                Notification endpoints are configured on each job, so SuperPlane does not create them by API. Every
+                trigger of this integration shares the same webhook URL, so keep it private.
+              </p>
+              <div className="mt-3">
+                <span className="text-xs font-medium text-gray-700 dark:text-gray-200">Webhook URL</span>
+                <pre className="mt-1 text-xs text-gray-800 dark:text-gray-100 border-1 border-gray-300 dark:border-gray-600 px-2.5 py-2 bg-white dark:bg-gray-900 rounded-md font-mono whitespace-pre-wrap break-all">
+                  {webhookUrl}
+                </pre>
+              </div>
+            </div>
+          </div>
+        </div>
+      </div>
+    );
+  },
+};
+
+function buildEventTitle(eventData: BuildCompletedEvent): string {
+  const job = eventData?.job || "Build";
+  const build = typeof eventData?.number === "number" ? `${job} #${eventData.number}` : job;
+  return eventData?.result ? `${build} · ${eventData.result}` : build;
+}
+
+function buildEventSubtitle(eventData: BuildCompletedEvent, createdAt?: string): string | React.ReactNode {
+  const branch = eventData?.scm?.branch;
+  const timeAgo = createdAt ? renderTimeAgo(new Date(createdAt)) : "";
+
+  if (branch && timeAgo) {
+    return (
+      <span>
+        {branch} · {timeAgo}
+      </span>
+    );
+  }
+
+  return branch || timeAgo;
+}
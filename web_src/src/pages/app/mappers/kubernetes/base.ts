@@ -0,0 +1,151 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  EventStateRegistry,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+  StateFunction,
+} from "../types";
+import type { ComponentBaseProps, EventStateMap } from "@/ui/componentBase";
+import { DEFAULT_EVENT_STATE_MAP } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type { MetadataItem } from "@/ui/metadataList";
+import kubernetesIcon from "@/assets/icons/integrations/kubernetes.svg";
+import { noopMapper } from "../noop";
+import { defaultStateFunction } from "../stateRegistry";
+
+type KubernetesConfiguration = {
+  source?: string;
+  file?: string;
+  kind?: string;
+  namespace?: string;
+  name?: string;
+  replicas?: number;
+  image?: string;
+};
+
+type KubernetesOutputs = {
+  default?: OutputPayload[];
+  success?: OutputPayload[];
+  failed?: OutputPayload[];
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = (node.configuration as KubernetesConfiguration | undefined) ?? {};
+
+  if (configuration.source === "file" && configuration.file) {
+    metadata.push({ icon: "file-text", label: configuration.file });
+  }
+  if (configuration.kind && configuration.name) {
+    metadata.push({ icon: "box", label: `${configuration.kind}: ${configuration.name}` });
+  }
+  if (configuration.image) {
+    metadata.push({ icon: "package", label: configuration.image });
+  }
+  if (configuration.namespace) {
+    metadata.push({ icon: "layers", label: `Namespace: ${configuration.namespace}` });
+  }
+  if (typeof configuration.replicas === "number") {
+    metadata.push({ icon: "copy", label: `Replicas: ${configuration.replicas}` });
+  }
+
+  return metadata;
+}
+
+function getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+  const details: Record<string, string> = {};
+  const outputs = context.execution.outputs as KubernetesOutputs | undefined;
+  const data = (outputs?.default?.[0] ?? outputs?.success?.[0] ?? outputs?.failed?.[0])?.data as
+    | Record<string, unknown>
+    | undefined;
+
+  if (context.execution.createdAt) {
+    details["Started At"] = new Date(context.execution.createdAt).toLocaleString();
+  }
+
+  if (!data) {
+    return details;
+  }
+
+  for (const [field, label] of [
+    ["kind", "Kind"],
+    ["namespace", "Namespace"],
+    ["name", "Name"],
+    ["job", "Job"],
+    ["pod", "Pod"],
+    ["status", "Status"],
+    ["message", "Message"],
+    ["exitCode", "Exit Code"],
+    ["replicas", "Replicas"],
+    ["restartedAt", "Restarted At"],
+  ] as const) {
+    const value = data[field];
+    if (typeof value === "string" && value) {
+      details[label] = value;
+    } else if (typeof value === "number") {
+      details[label] = String(value);
+    }
+  }
+
+  const resources = data.resources;
+  if (Array.isArray(resources)) {
+    details["Resources"] = resources
+      .map((resource) => {
+        const { kind, name } = resource as { kind?: string; name?: string };
+        return `${kind}/${name}`;
+      })
+      .join(", ");
+  }
+
+  return details;
+}
+
+function props(context: ComponentBaseContext): ComponentBaseProps {
+  const base = noopMapper.props(context);
+  return {
+    ...base,
+    iconSlug: undefined,
+    iconSrc: kubernetesIcon,
+    iconColor: getColorClass(context.componentDefinition.color),
+    collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+    metadata: metadataList(context.node),
+  };
+}
+
+export const kubernetesBaseMapper: ComponentBaseMapper = {
+  ...noopMapper,
+  props,
+  getExecutionDetails,
+};
+
+export const FINISHED_STATE_MAP: EventStateMap = {
+  ...DEFAULT_EVENT_STATE_MAP,
+  failed: {
+    icon: "circle-x",
+    textColor: "text-gray-800",
+    backgroundColor: "bg-red-100",
+    badgeColor: "bg-red-500",
+  },
+};
+
+/**
+ * Components with success and failed channels pass either way,
+ * so the channel they emitted on decides the state.
+ */
+export const finishedStateFunction: StateFunction = (execution) => {
+  if (!execution) return "neutral";
+
+  const outputs = execution.outputs as KubernetesOutputs | undefined;
+  if (outputs?.failed?.length) {
+    return "failed";
+  }
+
+  return defaultStateFunction(execution);
+};
+
+export const FINISHED_STATE_REGISTRY: EventStateRegistry = {
+  stateMap: FINISHED_STATE_MAP,
+  getState: finishedStateFunction,
+};
@@ -0,0 +1,29 @@
+import type { ComponentBaseMapper, EventStateRegistry, TriggerRenderer } from "../types";
+import { buildActionStateRegistry } from "../utils";
+import { FINISHED_STATE_REGISTRY, kubernetesBaseMapper } from "./base";
+import { kubernetesTriggerRenderer } from "./triggers";
+
+export const componentMappers: Record<string, ComponentBaseMapper> = {
+  applyManifest: kubernetesBaseMapper,
+  waitForRollout: kubernetesBaseMapper,
+  scale: kubernetesBaseMapper,
+  restart: kubernetesBaseMapper,
+  deleteResource: kubernetesBaseMapper,
+  getResource: kubernetesBaseMapper,
+  runJob: kubernetesBaseMapper,
+};
+
+export const triggerRenderers: Record<string, TriggerRenderer> = {
+  onRolloutFailed: kubernetesTriggerRenderer,
+  onPodCrashLoop: kubernetesTriggerRenderer,
+};
+
+export const eventStateRegistry: Record<string, EventStateRegistry> = {
+  applyManifest: buildActionStateRegistry("applied"),
+  waitForRollout: FINISHED_STATE_REGISTRY,
+  scale: buildActionStateRegistry("scaled"),
+  restart: buildActionStateRegistry("restarted"),
+  deleteResource: buildActionStateRegistry("deleted"),
+  getResource: buildActionStateRegistry("retrieved"),
+  runJob: FINISHED_STATE_REGISTRY,
+};
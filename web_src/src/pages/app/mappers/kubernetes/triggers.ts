@@ -0,0 +1,110 @@
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type React from "react";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import type { TriggerProps } from "@/ui/trigger";
+import type { MetadataItem } from "@/ui/metadataList";
+import kubernetesIcon from "@/assets/icons/integrations/kubernetes.svg";
+
+interface KubernetesTriggerConfiguration {
+  namespace?: string;
+  labelSelector?: string;
+  minRestarts?: number;
+}
+
+interface KubernetesEventData {
+  namespace?: string;
+  name?: string;
+  pod?: string;
+  container?: string;
+  image?: string;
+  reason?: string;
+  message?: string;
+  restartCount?: number;
+  generation?: number;
+  lastTermination?: { exitCode?: number; reason?: string };
+}
+
+function eventTitle(data: KubernetesEventData | undefined, type?: string): string {
+  if (type === "kubernetes.pod.crashLoop") {
+    return data?.pod ? `${data.pod} is crash looping` : "Pod crash loop";
+  }
+
+  return data?.name ? `${data.name} rollout failed` : "Rollout failed";
+}
+
+function buildSubtitle(createdAt?: string): string | React.ReactNode {
+  return createdAt ? renderTimeAgo(new Date(createdAt)) : "";
+}
+
+function metadataList(configuration: KubernetesTriggerConfiguration | undefined): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+
+  if (configuration?.namespace) {
+    metadata.push({ icon: "layers", label: `Namespace: ${configuration.namespace}` });
+  }
+  if (configuration?.labelSelector) {
+    metadata.push({ icon: "funnel", label: configuration.labelSelector });
+  }
+  if (configuration?.minRestarts) {
+    metadata.push({ icon: "rotate-ccw", label: `At least ${configuration.minRestarts} restarts` });
+  }
+
+  return metadata;
+}
+
+export const kubernetesTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const data = context.event?.data as KubernetesEventData | undefined;
+    return {
+      title: eventTitle(data, context.event?.type as string),
+      subtitle: buildSubtitle(context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const data = context.event?.data as KubernetesEventData | undefined;
+    const values: Record<string, string> = {};
+
+    if (context.event?.createdAt) {
+      values["Received At"] = new Date(context.event.createdAt).toLocaleString();
+    }
+    if (data?.namespace) values["Namespace"] = data.namespace;
+    if (data?.name) values["Deployment"] = data.name;
+    if (data?.generation !== undefined) values["Generation"] = String(data.generation);
+    if (data?.pod) values["Pod"] = data.pod;
+    if (data?.container) values["Container"] = data.container;
+    if (data?.image) values["Image"] = data.image;
+    if (data?.restartCount !== undefined) values["Restarts"] = String(data.restartCount);
+    if (data?.lastTermination?.exitCode !== undefined) {
+      values["Exit Code"] = String(data.lastTermination.exitCode);
+    }
+    if (data?.reason) values["Reason"] = data.reason;
+    if (data?.message) values["Message"] = data.message;
+
+    return values;
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: kubernetesIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataList(node.configuration as KubernetesTriggerConfiguration | undefined),
+    };
+
+    if (lastEvent) {
+      props.lastEventData = {
+        title: eventTitle(lastEvent.data as KubernetesEventData, lastEvent.type as string),
+        subtitle: buildSubtitle(lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt),
+        state: "triggered",
+        eventId: lastEvent.id,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,124 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+} from "../types";
+import type { ComponentBaseProps } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type { MetadataItem } from "@/ui/metadataList";
+import opsgenieIcon from "@/assets/icons/integrations/opsgenie.svg";
+import { noopMapper } from "../noop";
+
+type OpsgenieConfiguration = {
+  message?: string;
+  priority?: string;
+  alertId?: string;
+  incidentId?: string;
+  identifierType?: string;
+  duration?: string;
+  teams?: string[];
+};
+
+type OpsgenieOutputs = {
+  default?: OutputPayload[];
+};
+
+type OpsgenieOutput = {
+  id?: string;
+  tinyId?: string;
+  message?: string;
+  status?: string;
+  priority?: string;
+  acknowledged?: boolean;
+  snoozedUntil?: string;
+  url?: string;
+  schedule?: { id?: string; name?: string };
+  participants?: Array<{ name?: string; type?: string }>;
+};
+
+const SNOOZE_DURATION_LABELS: Record<string, string> = {
+  "3600": "1 hour",
+  "14400": "4 hours",
+  "28800": "8 hours",
+  "86400": "24 hours",
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = (node.configuration as OpsgenieConfiguration | undefined) ?? {};
+
+  const target = configuration.alertId || configuration.incidentId;
+  if (target) {
+    const identifierType = configuration.identifierType !== "id" ? configuration.identifierType : undefined;
+    metadata.push({
+      icon: configuration.incidentId ? "siren" : "bell",
+      label: identifierType ? `${identifierType}: ${target}` : target,
+    });
+  }
+  if (configuration.priority) {
+    metadata.push({ icon: "funnel", label: configuration.priority });
+  }
+  if (configuration.duration) {
+    metadata.push({
+      icon: "clock",
+      label: SNOOZE_DURATION_LABELS[configuration.duration] || `${configuration.duration}s`,
+    });
+  }
+  if (configuration.teams && configuration.teams.length > 0) {
+    metadata.push({
+      icon: "users",
+      label: configuration.teams.length > 1 ? `${configuration.teams.length} teams` : "1 team",
+    });
+  }
+
+  return metadata.slice(0, 3);
+}
+
+function getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+  const details: Record<string, string> = {};
+  const outputs = context.execution.outputs as OpsgenieOutputs | undefined;
+  const output = outputs?.default?.[0];
+  const data = output?.data as OpsgenieOutput | undefined;
+
+  if (context.execution.createdAt) {
+    details["Started At"] = new Date(context.execution.createdAt).toLocaleString();
+  }
+
+  if (!data) {
+    return details;
+  }
+
+  if (data.schedule?.name) details["Schedule"] = data.schedule.name;
+  if (data.participants) {
+    details["On Call"] = data.participants.length > 0 ? data.participants.map((p) => p.name).join(", ") : "Nobody";
+  }
+
+  if (data.tinyId) details[output?.type === "opsgenie.incident" ? "Incident" : "Alert"] = `#${data.tinyId}`;
+  if (data.message) details["Message"] = data.message;
+  if (data.status) details["Status"] = data.acknowledged ? `${data.status} (acknowledged)` : data.status;
+  if (data.priority) details["Priority"] = data.priority;
+  if (data.snoozedUntil) details["Snoozed Until"] = new Date(data.snoozedUntil).toLocaleString();
+  if (data.url) details["URL"] = data.url;
+
+  return details;
+}
+
+function props(context: ComponentBaseContext): ComponentBaseProps {
+  const base = noopMapper.props(context);
+  return {
+    ...base,
+    iconSlug: undefined,
+    iconSrc: opsgenieIcon,
+    iconColor: getColorClass(context.componentDefinition.color),
+    collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+    metadata: metadataList(context.node),
+  };
+}
+
+export const opsgenieBaseMapper: ComponentBaseMapper = {
+  ...noopMapper,
+  props,
+  getExecutionDetails,
+};
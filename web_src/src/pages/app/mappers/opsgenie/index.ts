@@ -0,0 +1,37 @@
+import type { ComponentBaseMapper, EventStateRegistry, TriggerRenderer } from "../types";
+import { buildActionStateRegistry } from "../utils";
+import { opsgenieBaseMapper } from "./base";
+import { opsgenieTriggerRenderer } from "./triggers";
+
+export const componentMappers: Record<string, ComponentBaseMapper> = {
+  createAlert: opsgenieBaseMapper,
+  acknowledgeAlert: opsgenieBaseMapper,
+  closeAlert: opsgenieBaseMapper,
+  addNote: opsgenieBaseMapper,
+  escalateAlert: opsgenieBaseMapper,
+  snoozeAlert: opsgenieBaseMapper,
+  listOnCall: opsgenieBaseMapper,
+  createIncident: opsgenieBaseMapper,
+  resolveIncident: opsgenieBaseMapper,
+  closeIncident: opsgenieBaseMapper,
+  addIncidentNote: opsgenieBaseMapper,
+};
+
+export const triggerRenderers: Record<string, TriggerRenderer> = {
+  onAlert: opsgenieTriggerRenderer,
+  onAlertStatusChanged: opsgenieTriggerRenderer,
+};
+
+export const eventStateRegistry: Record<string, EventStateRegistry> = {
+  createAlert: buildActionStateRegistry("created"),
+  acknowledgeAlert: buildActionStateRegistry("acknowledged"),
+  closeAlert: buildActionStateRegistry("closed"),
+  addNote: buildActionStateRegistry("added"),
+  escalateAlert: buildActionStateRegistry("escalated"),
+  snoozeAlert: buildActionStateRegistry("snoozed"),
+  listOnCall: buildActionStateRegistry("retrieved"),
+  createIncident: buildActionStateRegistry("created"),
+  resolveIncident: buildActionStateRegistry("resolved"),
+  closeIncident: buildActionStateRegistry("closed"),
+  addIncidentNote: buildActionStateRegistry("added"),
+};
@@ -0,0 +1,117 @@
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type React from "react";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import type { TriggerProps } from "@/ui/trigger";
+import type { MetadataItem } from "@/ui/metadataList";
+import opsgenieIcon from "@/assets/icons/integrations/opsgenie.svg";
+
+interface OpsgenieTriggerConfiguration {
+  priorities?: string[];
+  teams?: string[];
+  tags?: string[];
+  actions?: string[];
+}
+
+interface OpsgenieAlertEventData {
+  action?: string;
+  alert?: {
+    alertId?: string;
+    tinyId?: string;
+    alias?: string;
+    message?: string;
+    priority?: string;
+    tags?: string[];
+    entity?: string;
+    username?: string;
+    url?: string;
+  };
+}
+
+function eventTitle(data: OpsgenieAlertEventData | undefined): string {
+  const message = data?.alert?.message || "Alert";
+  return data?.alert?.tinyId ? `#${data.alert.tinyId} · ${message}` : message;
+}
+
+function buildSubtitle(data: OpsgenieAlertEventData | undefined, createdAt?: string): string | React.ReactNode {
+  const timeAgo = createdAt ? renderTimeAgo(new Date(createdAt)) : "";
+  const parts = [data?.action, data?.alert?.priority].filter(Boolean).join(" · ");
+  return parts || timeAgo;
+}
+
+function metadataList(configuration: OpsgenieTriggerConfiguration | undefined): MetadataItem[] {
+  const items: MetadataItem[] = [];
+
+  if (configuration?.actions && configuration.actions.length > 0) {
+    items.push({ icon: "funnel", label: configuration.actions.join(", ") });
+  }
+  if (configuration?.priorities && configuration.priorities.length > 0) {
+    items.push({ icon: "funnel", label: configuration.priorities.join(", ") });
+  }
+  if (configuration?.teams && configuration.teams.length > 0) {
+    items.push({
+      icon: "users",
+      label: configuration.teams.length > 1 ? `${configuration.teams.length} teams` : "1 team",
+    });
+  }
+  if (configuration?.tags && configuration.tags.length > 0) {
+    items.push({ icon: "tag", label: configuration.tags.join(", ") });
+  }
+
+  return items.slice(0, 3);
+}
+
+export const opsgenieTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const data = context.event?.data as OpsgenieAlertEventData | undefined;
+    return {
+      title: eventTitle(data),
+      subtitle: buildSubtitle(data, context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const data = context.event?.data as OpsgenieAlertEventData | undefined;
+    const values: Record<string, string> = {};
+
+    if (context.event?.createdAt) {
+      values["Received At"] = new Date(context.event.createdAt).toLocaleString();
+    }
+    if (data?.action) values["Action"] = data.action;
+    if (data?.alert?.alertId) values["Alert ID"] = data.alert.alertId;
+    if (data?.alert?.tinyId) values["Tiny ID"] = data.alert.tinyId;
+    if (data?.alert?.message) values["Message"] = data.alert.message;
+    if (data?.alert?.priority) values["Priority"] = data.alert.priority;
+    if (data?.alert?.alias) values["Alias"] = data.alert.alias;
+    if (data?.alert?.entity) values["Entity"] = data.alert.entity;
+    if (data?.alert?.tags && data.alert.tags.length > 0) values["Tags"] = data.alert.tags.join(", ");
+    if (data?.alert?.username) values["User"] = data.alert.username;
+    if (data?.alert?.url) values["URL"] = data.alert.url;
+
+    return values;
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: opsgenieIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataList(node.configuration as OpsgenieTriggerConfiguration | undefined),
+    };
+
+    if (lastEvent) {
+      const data = lastEvent.data as OpsgenieAlertEventData;
+      props.lastEventData = {
+        title: eventTitle(data),
+        subtitle: buildSubtitle(data, lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt),
+        state: "triggered",
+        eventId: lastEvent.id,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,163 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  EventStateRegistry,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+  StateFunction,
+} from "../types";
+import type { ComponentBaseProps, EventStateMap } from "@/ui/componentBase";
+import { DEFAULT_EVENT_STATE_MAP } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type { MetadataItem } from "@/ui/metadataList";
+import terraformIcon from "@/assets/icons/integrations/terraform.svg";
+import { noopMapper } from "../noop";
+import { defaultStateFunction } from "../stateRegistry";
+
+type TerraformConfiguration = {
+  runId?: string;
+  isDestroy?: boolean;
+  planOnly?: boolean;
+  autoApply?: boolean;
+  status?: string;
+};
+
+type TerraformNodeMetadata = {
+  workspace?: { id?: string; name?: string; organization?: string };
+};
+
+type TerraformOutputs = {
+  default?: OutputPayload[];
+  success?: OutputPayload[];
+  planned?: OutputPayload[];
+  noChanges?: OutputPayload[];
+  failed?: OutputPayload[];
+};
+
+type TerraformRunOutput = {
+  id?: string;
+  status?: string;
+  message?: string;
+  url?: string;
+  workspace?: { name?: string; organization?: string };
+  plan?: { text?: string };
+  resourceChanges?: Array<{ address?: string; actions?: string[] }>;
+  wait?: { message?: string };
+  apply?: { message?: string };
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = (node.configuration as TerraformConfiguration | undefined) ?? {};
+  const nodeMetadata = node.metadata as TerraformNodeMetadata | undefined;
+
+  if (nodeMetadata?.workspace?.name) {
+    const organization = nodeMetadata.workspace.organization;
+    metadata.push({
+      icon: "folder",
+      label: organization ? `${organization}/${nodeMetadata.workspace.name}` : nodeMetadata.workspace.name,
+    });
+  }
+  if (configuration.isDestroy) {
+    metadata.push({ icon: "trash-2", label: "Destroy" });
+  }
+  if (configuration.planOnly) {
+    metadata.push({ icon: "eye", label: "Plan only" });
+  }
+  if (configuration.autoApply) {
+    metadata.push({ icon: "zap", label: "Auto apply" });
+  }
+  if (configuration.runId && !configuration.runId.includes("{{")) {
+    metadata.push({ icon: "hash", label: configuration.runId });
+  }
+  if (configuration.status) {
+    metadata.push({ icon: "check", label: configuration.status });
+  }
+
+  return metadata;
+}
+
+function firstOutput(outputs: TerraformOutputs | undefined): TerraformRunOutput | undefined {
+  const payload =
+    outputs?.default?.[0] ??
+    outputs?.success?.[0] ??
+    outputs?.planned?.[0] ??
+    outputs?.noChanges?.[0] ??
+    outputs?.failed?.[0];
+
+  return payload?.data as TerraformRunOutput | undefined;
+}
+
+function getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+  const details: Record<string, string> = {};
+  const data = firstOutput(context.execution.outputs as TerraformOutputs | undefined);
+
+  if (context.execution.createdAt) {
+    details["Started At"] = new Date(context.execution.createdAt).toLocaleString();
+  }
+
+  if (!data) {
+    return details;
+  }
+
+  if (data.workspace?.name) details["Workspace"] = data.workspace.name;
+  if (data.id) details["Run"] = data.id;
+  if (data.status) details["Status"] = data.status;
+  if (data.message) details["Message"] = data.message;
+  if (data.plan?.text) details["Plan"] = data.plan.text;
+  if (data.resourceChanges && data.resourceChanges.length > 0) {
+    details["Resource Changes"] = data.resourceChanges
+      .map((change) => `${(change.actions || []).join("/")} ${change.address}`)
+      .join(", ");
+  }
+  if (data.wait?.message) details["Result"] = data.wait.message;
+  if (data.apply?.message) details["Result"] = data.apply.message;
+  if (data.url) details["URL"] = data.url;
+
+  return details;
+}
+
+function props(context: ComponentBaseContext): ComponentBaseProps {
+  const base = noopMapper.props(context);
+  return {
+    ...base,
+    iconSlug: undefined,
+    iconSrc: terraformIcon,
+    iconColor: getColorClass(context.componentDefinition.color),
+    collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+    metadata: metadataList(context.node),
+  };
+}
+
+export const terraformBaseMapper: ComponentBaseMapper = {
+  ...noopMapper,
+  props,
+  getExecutionDetails,
+};
+
+export const FINISHED_STATE_MAP: EventStateMap = {
+  ...DEFAULT_EVENT_STATE_MAP,
+  failed: {
+    icon: "circle-x",
+    textColor: "text-gray-800",
+    backgroundColor: "bg-red-100",
+    badgeColor: "bg-red-500",
+  },
+};
+
+export const finishedStateFunction: StateFunction = (execution) => {
+  if (!execution) return "neutral";
+
+  const outputs = execution.outputs as TerraformOutputs | undefined;
+  if (outputs?.failed?.length) {
+    return "failed";
+  }
+
+  return defaultStateFunction(execution);
+};
+
+export const FINISHED_STATE_REGISTRY: EventStateRegistry = {
+  stateMap: FINISHED_STATE_MAP,
+  getState: finishedStateFunction,
+};
@@ -0,0 +1,28 @@
+import type { ComponentBaseMapper, EventStateRegistry, TriggerRenderer } from "../types";
+import { buildActionStateRegistry } from "../utils";
+import { FINISHED_STATE_REGISTRY, terraformBaseMapper } from "./base";
+import { terraformTriggerRenderer } from "./triggers";
+
+export const componentMappers: Record<string, ComponentBaseMapper> = {
+  createRun: terraformBaseMapper,
+  waitForPlan: terraformBaseMapper,
+  getPlan: terraformBaseMapper,
+  applyRun: terraformBaseMapper,
+  discardRun: terraformBaseMapper,
+  sendRunTaskResult: terraformBaseMapper,
+};
+
+export const triggerRenderers: Record<string, TriggerRenderer> = {
+  onRunNeedsAttention: terraformTriggerRenderer,
+  onRunCompleted: terraformTriggerRenderer,
+  onRunTask: terraformTriggerRenderer,
+};
+
+export const eventStateRegistry: Record<string, EventStateRegistry> = {
+  createRun: buildActionStateRegistry("queued"),
+  waitForPlan: FINISHED_STATE_REGISTRY,
+  getPlan: buildActionStateRegistry("retrieved"),
+  applyRun: FINISHED_STATE_REGISTRY,
+  discardRun: buildActionStateRegistry("discarded"),
+  sendRunTaskResult: buildActionStateRegistry("sent"),
+};
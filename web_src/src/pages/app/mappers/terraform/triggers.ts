@@ -0,0 +1,126 @@
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type React from "react";
+import { renderTimeAgo } from "@/components/TimeAgo";
+import type { TriggerEventContext, TriggerRenderer, TriggerRendererContext } from "../types";
+import type { TriggerProps } from "@/ui/trigger";
+import type { MetadataItem } from "@/ui/metadataList";
+import terraformIcon from "@/assets/icons/integrations/terraform.svg";
+
+interface TerraformTriggerConfiguration {
+  statuses?: string[];
+  stage?: string;
+}
+
+interface TerraformTriggerMetadata {
+  workspace?: { name?: string; organization?: string };
+}
+
+interface TerraformRunEventData {
+  trigger?: string;
+  message?: string;
+  stage?: string;
+  taskResult?: { id?: string; enforcementLevel?: string };
+  organization?: string;
+  workspace?: { id?: string; name?: string };
+  run?: {
+    id?: string;
+    url?: string;
+    message?: string;
+    status?: string;
+    createdBy?: string;
+    updatedBy?: string;
+  };
+}
+
+function eventTitle(data: TerraformRunEventData | undefined): string {
+  const workspace = data?.workspace?.name || "Workspace";
+  if (data?.stage) return `${workspace} · Run task (${data.stage})`;
+  return data?.message ? `${workspace} · ${data.message}` : workspace;
+}
+
+function buildSubtitle(data: TerraformRunEventData | undefined, createdAt?: string): string | React.ReactNode {
+  const timeAgo = createdAt ? renderTimeAgo(new Date(createdAt)) : "";
+  return data?.run?.message || timeAgo;
+}
+
+function metadataList(
+  configuration: TerraformTriggerConfiguration | undefined,
+  metadata: TerraformTriggerMetadata | undefined,
+): MetadataItem[] {
+  const items: MetadataItem[] = [];
+
+  if (metadata?.workspace?.name) {
+    const organization = metadata.workspace.organization;
+    items.push({
+      icon: "folder",
+      label: organization ? `${organization}/${metadata.workspace.name}` : metadata.workspace.name,
+    });
+  }
+  if (configuration?.statuses && configuration.statuses.length > 0) {
+    items.push({ icon: "funnel", label: configuration.statuses.join(", ") });
+  }
+  if (configuration?.stage) {
+    items.push({ icon: "funnel", label: configuration.stage });
+  }
+
+  return items;
+}
+
+export const terraformTriggerRenderer: TriggerRenderer = {
+  getTitleAndSubtitle: (context: TriggerEventContext): { title: string; subtitle: string | React.ReactNode } => {
+    const data = context.event?.data as TerraformRunEventData | undefined;
+    return {
+      title: eventTitle(data),
+      subtitle: buildSubtitle(data, context.event?.createdAt),
+    };
+  },
+
+  getRootEventValues: (context: TriggerEventContext): Record<string, string> => {
+    const data = context.event?.data as TerraformRunEventData | undefined;
+    const values: Record<string, string> = {};
+
+    if (context.event?.createdAt) {
+      values["Received At"] = new Date(context.event.createdAt).toLocaleString();
+    }
+    if (data?.organization) values["Organization"] = data.organization;
+    if (data?.workspace?.name) values["Workspace"] = data.workspace.name;
+    if (data?.run?.id) values["Run"] = data.run.id;
+    if (data?.run?.status) values["Status"] = data.run.status;
+    if (data?.run?.message) values["Run Message"] = data.run.message;
+    if (data?.run?.createdBy) values["Created By"] = data.run.createdBy;
+    if (data?.message) values["Notification"] = data.message;
+    if (data?.stage) values["Stage"] = data.stage;
+    if (data?.taskResult?.id) values["Task Result"] = data.taskResult.id;
+    if (data?.taskResult?.enforcementLevel) values["Enforcement"] = data.taskResult.enforcementLevel;
+    if (data?.run?.url) values["URL"] = data.run.url;
+
+    return values;
+  },
+
+  getTriggerProps: (context: TriggerRendererContext) => {
+    const { node, definition, lastEvent } = context;
+    const props: TriggerProps = {
+      title: node.name || definition.label || "Unnamed trigger",
+      iconSrc: terraformIcon,
+      iconColor: getColorClass(definition.color),
+      collapsedBackground: getBackgroundColorClass(definition.color),
+      metadata: metadataList(
+        node.configuration as TerraformTriggerConfiguration | undefined,
+        node.metadata as TerraformTriggerMetadata | undefined,
+      ),
+    };
+
+    if (lastEvent) {
+      const data = lastEvent.data as TerraformRunEventData;
+      props.lastEventData = {
+        title: eventTitle(data),
+        subtitle: buildSubtitle(data, lastEvent.createdAt),
+        receivedAt: new Date(lastEvent.createdAt),
+        state: "triggered",
+        eventId: lastEvent.id,
+      };
+    }
+
+    return props;
+  },
+};
@@ -0,0 +1,107 @@
+import type {
+  ComponentBaseContext,
+  ComponentBaseMapper,
+  ExecutionDetailsContext,
+  NodeInfo,
+  OutputPayload,
+} from "../types";
+import type { ComponentBaseProps } from "@/ui/componentBase";
+import { getBackgroundColorClass, getColorClass } from "@/lib/colors";
+import type { MetadataItem } from "@/ui/metadataList";
+import vaultIcon from "@/assets/icons/integrations/vault.svg";
+import { noopMapper } from "../noop";
+
+type VaultConfiguration = {
+  mount?: string;
+  path?: string;
+  key?: string;
+  role?: string;
+  leaseId?: string;
+  principals?: string;
+};
+
+type VaultOutputs = {
+  default?: OutputPayload[];
+};
+
+// Secret values and credentials are left out on purpose:
+// only the identifiers of what was read or issued are shown.
+type VaultOutput = {
+  path?: string;
+  version?: number;
+  keys?: string[];
+  lease_id?: string;
+  lease_duration?: number;
+  expires_at?: string;
+  revoked_at?: string;
+  role?: string;
+  serial_number?: string;
+};
+
+function metadataList(node: NodeInfo): MetadataItem[] {
+  const metadata: MetadataItem[] = [];
+  const configuration = (node.configuration as VaultConfiguration | undefined) ?? {};
+
+  if (configuration.path) {
+    const path = configuration.mount ? `${configuration.mount}/${configuration.path}` : configuration.path;
+    metadata.push({ icon: "folder", label: path });
+  }
+  if (configuration.key) {
+    metadata.push({ icon: "key", label: configuration.key });
+  }
+  if (configuration.role) {
+    metadata.push({ icon: "shield-check", label: configuration.role });
+  }
+  if (configuration.principals) {
+    metadata.push({ icon: "users", label: configuration.principals });
+  }
+  if (configuration.leaseId) {
+    metadata.push({ icon: "clock", label: configuration.leaseId });
+  }
+
+  return metadata;
+}
+
+function getExecutionDetails(context: ExecutionDetailsContext): Record<string, string> {
+  const details: Record<string, string> = {};
+  const outputs = context.execution.outputs as VaultOutputs | undefined;
+  const data = outputs?.default?.[0]?.data as VaultOutput | undefined;
+
+  if (context.execution.createdAt) {
+    details["Started At"] = new Date(context.execution.createdAt).toLocaleString();
+  }
+
+  if (!data) {
+    return details;
+  }
+
+  if (data.path) details["Path"] = data.path;
+  if (typeof data.version === "number") details["Version"] = `${data.version}`;
+  if (data.keys && data.keys.length > 0) details["Keys"] = data.keys.join(", ");
+  if (data.role) details["Role"] = data.role;
+  if (data.serial_number) details["Serial Number"] = data.serial_number;
+  if (data.lease_id) details["Lease ID"] = data.lease_id;
+  if (data.lease_duration) details["Lease Duration"] = `${data.lease_duration}s`;
+  if (data.expires_at) details["Expires At"] = new Date(data.expires_at).toLocaleString();
+  if (data.revoked_at) details["Revoked At"] = new Date(data.revoked_at).toLocaleString();
+
+  return details;
+}
+
+function props(context: ComponentBaseContext): ComponentBaseProps {
+  const base = noopMapper.props(context);
+  return {
+    ...base,
+    iconSlug: undefined,
+    iconSrc: vaultIcon,
+    iconColor: getColorClass(context.componentDefinition.color),
+    collapsedBackground: getBackgroundColorClass(context.componentDefinition.color),
+    metadata: metadataList(context.node),
+  };
+}
+
+export const vaultBaseMapper: ComponentBaseMapper = {
+  ...noopMapper,
+  props,
+  getExecutionDetails,
+};
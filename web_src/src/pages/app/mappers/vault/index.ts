@@ -0,0 +1,19 @@
+import type { ComponentBaseMapper, EventStateRegistry, TriggerRenderer } from "../types";
+import { buildActionStateRegistry } from "../utils";
+import { vaultBaseMapper } from "./base";
+
+export const componentMappers: Record<string, ComponentBaseMapper> = {
+  readSecret: vaultBaseMapper,
+  renewLease: vaultBaseMapper,
+  revokeLease: vaultBaseMapper,
+  signSSHKey: vaultBaseMapper,
+};
+
+export const triggerRenderers: Record<string, TriggerRenderer> = {};
+
+export const eventStateRegistry: Record<string, EventStateRegistry> = {
+  readSecret: buildActionStateRegistry("read"),
+  renewLease: buildActionStateRegistry("renewed"),
+  revokeLease: buildActionStateRegistry("revoked"),
+  signSSHKey: buildActionStateRegistry("signed"),
+};
@@ -304,6 +304,36 @@ const ORGANIZATION_PERMISSIONS: PermissionCategory[] = [
       },
     ],
   },
+  {
+    category: "Memory",
+    icon: "database",
+    permissions: [
+      {
+        id: "memory.read",
+        name: "View Organization Memory",
+        description: "View memory shared by every canvas of the organization",
+        category: "Memory",
+        resource: "memory",
+        action: "read",
+      },
+      {
+        id: "memory.create",
+        name: "Add Organization Memory",
+        description: "Add records to organization memory",
+        category: "Memory",
+        resource: "memory",
+        action: "create",
+      },
+      {
+        id: "memory.delete",
+        name: "Delete Organization Memory",
+        description: "Delete records from organization memory",
+        category: "Memory",
+        resource: "memory",
+        action: "delete",
+      },
+    ],
+  },
   {
     category: "Integrations",
     icon: "integration_instructions",
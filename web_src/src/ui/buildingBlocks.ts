@@ -168,7 +168,15 @@ function toComponentBlock(component: ActionsAction, integrationName?: string): B
   };
 }
 
-const MEMORY_COMPONENT_NAMES = new Set(["addmemory", "readmemory", "updatememory", "deletememory", "upsertmemory"]);
+const MEMORY_COMPONENT_NAMES = new Set([
+  "addmemory",
+  "readmemory",
+  "updatememory",
+  "deletememory",
+  "upsertmemory",
+  "incrementmemory",
+  "compareandswapmemory",
+]);
 const DEBUGGING_COMPONENT_NAMES = new Set(["noop", "display"]);
 
 function isMemoryBlock(component: { name?: string }): boolean {
@@ -67,6 +67,14 @@ import statuspageIcon from "@/assets/icons/integrations/statuspage.svg";
 import launchdarklyIcon from "@/assets/icons/integrations/launchdarkly.svg";
 import teamsIcon from "@/assets/icons/integrations/teams.svg";
 import ociIcon from "@/assets/icons/integrations/oci.svg";
+import kubernetesIcon from "@/assets/icons/integrations/kubernetes.svg";
+import argocdIcon from "@/assets/icons/integrations/argocd.svg";
+import terraformIcon from "@/assets/icons/integrations/terraform.svg";
+import jenkinsIcon from "@/assets/icons/integrations/jenkins.svg";
+import opsgenieIcon from "@/assets/icons/integrations/opsgenie.svg";
+import azureDevOpsIcon from "@/assets/icons/integrations/azuredevops.svg";
+import giteaIcon from "@/assets/icons/integrations/gitea.svg";
+import vaultIcon from "@/assets/icons/integrations/vault.svg";
 import graphqlIcon from "@/assets/icons/graphql.svg";
 
 /** Integration type name (e.g. "github") → logo src. Used for Settings tab and header. */
@@ -121,6 +129,14 @@ export const INTEGRATION_APP_LOGO_MAP: Record<string, string> = {
   teams: teamsIcon,
   elastic: elasticIcon,
   oci: ociIcon,
+  kubernetes: kubernetesIcon,
+  argocd: argocdIcon,
+  terraform: terraformIcon,
+  jenkins: jenkinsIcon,
+  opsgenie: opsgenieIcon,
+  azuredevops: azureDevOpsIcon,
+  gitea: giteaIcon,
+  vault: vaultIcon,
   graphql: graphqlIcon,
 };
 
@@ -209,6 +225,14 @@ export const APP_LOGO_MAP: Record<string, string | Record<string, string>> = {
   },
   elastic: elasticIcon,
   oci: ociIcon,
+  kubernetes: kubernetesIcon,
+  argocd: argocdIcon,
+  terraform: terraformIcon,
+  jenkins: jenkinsIcon,
+  opsgenie: opsgenieIcon,
+  azuredevops: azureDevOpsIcon,
+  gitea: giteaIcon,
+  vault: vaultIcon,
 };
 
 /**